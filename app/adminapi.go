@@ -0,0 +1,412 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/lifecycle"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/policy"
+)
+
+// AdminValidators is the request and response of the admin API validator endpoints.
+type AdminValidators struct {
+	// All is true if all validators are selected, in which case Validators is empty.
+	All bool `json:"all"`
+	// Validators are the selected validator public keys.
+	Validators []string `json:"validators"`
+}
+
+// AdminDebugState is the response of the admin API debug state endpoint.
+type AdminDebugState struct {
+	Version       string          `json:"version"`
+	RuntimeConfig RuntimeConfig   `json:"runtime_config"`
+	Paused        AdminValidators `json:"paused"`
+	Duties        DutiesStatus    `json:"duties"`
+}
+
+// wireAdminAPI constructs the admin API and registers it with the life cycle manager.
+// It is served on its own listener with its own authentication, since it controls the node,
+// unlike the validator API used by the validator client and the public monitoring API.
+func wireAdminAPI(life *lifecycle.Manager, conf Config, pauser *validatorPauser, signingPolicy *policy.Engine,
+	reloader *configReloader, duties *dutiesStatus,
+) error {
+	if err := validateAdminAuth(conf); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /validators/paused", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSONResponse(w, http.StatusOK, pauser.Paused())
+	})
+	mux.HandleFunc("POST /validators/pause", newAdminValidatorsHandler(pauser.Pause))
+	mux.HandleFunc("POST /validators/resume", newAdminValidatorsHandler(pauser.Resume))
+
+	mux.HandleFunc("POST /exits", newAdminValidatorsHandler(func(ctx context.Context, req AdminValidators) (AdminValidators, error) {
+		return exitValidators(ctx, conf, pauser, signingPolicy, req)
+	}))
+
+	mux.HandleFunc("POST /config/reload", func(w http.ResponseWriter, _ *http.Request) {
+		reloader.Trigger()
+		writeResponse(w, http.StatusAccepted, "reload triggered")
+	})
+
+	mux.HandleFunc("GET /debug/state", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSONResponse(w, http.StatusOK, debugState(pauser, reloader, duties))
+	})
+
+	mux.HandleFunc("GET /debug/goroutines", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_ = writeGoroutines(w)
+	})
+
+	grpcServer := newAdminGRPCServer(conf, pauser, signingPolicy, reloader, duties)
+
+	// Serve gRPC requests on the same listener, so they are subject to the same authentication.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+
+	server, serve, err := newHTTPServer(conf.AdminAddr, handler, conf.AdminAuth)
+	if err != nil {
+		return err
+	}
+
+	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartAdminAPI, serve)
+	life.RegisterStop(lifecycle.StopAdminAPI, lifecycle.HookFunc(func(ctx context.Context) error {
+		grpcServer.Stop()
+		return server.Shutdown(ctx)
+	}))
+
+	return nil
+}
+
+// validateAdminAuth returns an error if the admin API is enabled without basic auth or client certificate authentication,
+// or with basic auth without TLS, since the credentials would be sent in plaintext.
+func validateAdminAuth(conf Config) error {
+	if conf.AdminAddr == "" {
+		return nil
+	}
+
+	if conf.AdminAuth.BasicAuth == "" && conf.AdminAuth.TLSClientCAFile == "" {
+		return errors.New("admin API requires authentication, set --admin-basic-auth or --admin-tls-client-ca-file")
+	} else if conf.AdminAuth.BasicAuth != "" && !conf.AdminAuth.TLSEnabled() {
+		return errors.New("admin API basic auth requires TLS, set --admin-tls-cert-file and --admin-tls-key-file")
+	}
+
+	return nil
+}
+
+// exitValidators signs and publishes partial exits of the requested validators, or all validators.
+// Exits are rejected while denied by the signing policy, if any, or while any of the validators are paused,
+// since they are signed outside the core workflow which enforces both.
+func exitValidators(ctx context.Context, conf Config, pauser *validatorPauser, signingPolicy *policy.Engine,
+	req AdminValidators,
+) (AdminValidators, error) {
+	if conf.AdminExitFunc == nil {
+		return AdminValidators{}, errors.New("exits not supported by this node")
+	}
+
+	// Validate and normalise the public keys before exiting.
+	pubkeys, err := pauser.parse(req)
+	if err != nil {
+		return AdminValidators{}, err
+	}
+
+	if err := pauser.CheckExit(req.All, pubkeys); err != nil {
+		return AdminValidators{}, err
+	}
+
+	if signingPolicy != nil {
+		if err := signingPolicy.CheckExit(ctx); err != nil {
+			return AdminValidators{}, err
+		}
+	}
+
+	req.Validators = []string{}
+	for _, pubkey := range pubkeys {
+		req.Validators = append(req.Validators, string(pubkey))
+	}
+
+	if err := conf.AdminExitFunc(ctx, conf, req.Validators); err != nil {
+		return AdminValidators{}, err
+	}
+
+	log.Info(ctx, "Signed and published partial exits", z.Bool("all", req.All), z.Int("count", len(req.Validators)))
+
+	return req, nil
+}
+
+// debugState returns the admin debug state, redacting the beacon node URLs.
+func debugState(pauser *validatorPauser, reloader *configReloader, duties *dutiesStatus) AdminDebugState {
+	runtimeConfig := reloader.Current()
+	runtimeConfig.BeaconNodeAddrs = redactURLs(runtimeConfig.BeaconNodeAddrs)
+	runtimeConfig.FallbackBeaconNodeAddrs = redactURLs(runtimeConfig.FallbackBeaconNodeAddrs)
+
+	return AdminDebugState{
+		Version:       version.Version.String(),
+		RuntimeConfig: runtimeConfig,
+		Paused:        pauser.Paused(),
+		Duties:        duties.Status(),
+	}
+}
+
+// writeGoroutines writes the stack traces of all goroutines.
+func writeGoroutines(w io.Writer) error {
+	return pprof.Lookup("goroutine").WriteTo(w, 2) // Debug level 2 prints full stack traces like a panic.
+}
+
+// newAdminValidatorsHandler returns a handler decoding the validators request, calling the function and writing its
+// response. An empty request body selects all validators.
+func newAdminValidatorsHandler(fn func(context.Context, AdminValidators) (AdminValidators, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req AdminValidators
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			writeResponse(w, http.StatusBadRequest, errors.Wrap(err, "decode request").Error())
+			return
+		}
+		req.All = len(req.Validators) == 0
+
+		ctx := log.WithTopic(r.Context(), "admin")
+		resp, err := fn(ctx, req)
+		if err != nil {
+			log.Warn(ctx, "Admin API request failed", err, z.Str("path", r.URL.Path))
+			writeResponse(w, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		writeJSONResponse(w, http.StatusOK, resp)
+	}
+}
+
+// newValidatorPauser returns a new validator pauser with no paused validators.
+func newValidatorPauser() *validatorPauser {
+	return &validatorPauser{
+		validatorsFunc: func() []core.PubKey { return nil },
+		paused:         make(map[core.PubKey]bool),
+	}
+}
+
+// validatorPauser pauses validators by dropping this node's partial signatures of paused validators,
+// so the node stops participating in their duties without restarting.
+// It is created before the core workflow, which populates it via SetValidatorsFunc.
+type validatorPauser struct {
+	mu             sync.Mutex
+	validatorsFunc func() []core.PubKey
+	all            bool
+	safeMode       bool
+	standby        bool
+	paused         map[core.PubKey]bool
+}
+
+// SetValidatorsFunc sets the function returning the current cluster validators.
+func (p *validatorPauser) SetValidatorsFunc(fn func() []core.PubKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.validatorsFunc = fn
+}
+
+// Pause pauses the requested validators, or all validators, and returns the paused validators.
+func (p *validatorPauser) Pause(ctx context.Context, req AdminValidators) (AdminValidators, error) {
+	pubkeys, err := p.parse(req)
+	if err != nil {
+		return AdminValidators{}, err
+	}
+
+	p.mu.Lock()
+	if req.All {
+		p.all = true
+	}
+	for _, pubkey := range pubkeys {
+		p.paused[pubkey] = true
+	}
+	p.mu.Unlock()
+
+	log.Info(ctx, "Paused validators", z.Bool("all", req.All), z.Int("count", len(pubkeys)))
+
+	return p.Paused(), nil
+}
+
+// EnterSafeMode pauses all validators until the node is restarted, since another instance
+// of this node was detected running on a different host. Validators can't be resumed in safe mode.
+func (p *validatorPauser) EnterSafeMode(ctx context.Context) {
+	p.mu.Lock()
+	p.all = true
+	p.safeMode = true
+	p.mu.Unlock()
+
+	log.Error(ctx, "Entered safe non-signing mode, an older instance of this node is running on a different host. "+
+		"Stop one of the instances and restart this node to resume signing", nil)
+}
+
+// EnterStandby pauses all validators while this node runs in warm standby mode until Promote is called.
+// Validators can't be resumed in standby mode.
+func (p *validatorPauser) EnterStandby(ctx context.Context) {
+	p.mu.Lock()
+	p.all = true
+	p.standby = true
+	p.mu.Unlock()
+
+	log.Info(ctx, "Running in warm standby mode, not signing until the leadership lease is acquired")
+}
+
+// Promote resumes all validators of a standby node that acquired the leadership lease, unless in safe mode.
+func (p *validatorPauser) Promote(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.standby || p.safeMode {
+		return
+	}
+
+	p.all = false
+	p.standby = false
+	clear(p.paused)
+
+	log.Info(ctx, "Promoted from warm standby mode, signing resumed")
+}
+
+// Resume resumes the requested validators, or all validators, and returns the paused validators.
+// Resuming individual validators while all validators are paused is not supported.
+func (p *validatorPauser) Resume(ctx context.Context, req AdminValidators) (AdminValidators, error) {
+	pubkeys, err := p.parse(req)
+	if err != nil {
+		return AdminValidators{}, err
+	}
+
+	p.mu.Lock()
+	if p.safeMode {
+		p.mu.Unlock()
+		return AdminValidators{}, errors.New("validators paused in safe mode due to duplicate instance, restart node instead")
+	} else if p.standby {
+		p.mu.Unlock()
+		return AdminValidators{}, errors.New("validators paused in standby mode until the leadership lease is acquired")
+	} else if req.All {
+		p.all = false
+		clear(p.paused)
+	} else if p.all {
+		p.mu.Unlock()
+		return AdminValidators{}, errors.New("all validators paused, resume all validators instead")
+	}
+	for _, pubkey := range pubkeys {
+		delete(p.paused, pubkey)
+	}
+	p.mu.Unlock()
+
+	log.Info(ctx, "Resumed validators", z.Bool("all", req.All), z.Int("count", len(pubkeys)))
+
+	return p.Paused(), nil
+}
+
+// CheckExit returns an error if any of the validators, or any validator if all is true, is paused.
+func (p *validatorPauser) CheckExit(all bool, pubkeys []core.PubKey) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.all {
+		return errors.New("exits rejected while all validators are paused")
+	} else if all && len(p.paused) > 0 {
+		return errors.New("exits rejected while validators are paused", z.Int("paused", len(p.paused)))
+	}
+
+	for _, pubkey := range pubkeys {
+		if p.paused[pubkey] {
+			return errors.New("exit rejected for paused validator", z.Redact(z.Str("pubkey", string(pubkey))))
+		}
+	}
+
+	return nil
+}
+
+// Paused returns the paused validators ordered by public key.
+func (p *validatorPauser) Paused() AdminValidators {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	resp := AdminValidators{All: p.all, Validators: []string{}}
+	for pubkey := range p.paused {
+		resp.Validators = append(resp.Validators, string(pubkey))
+	}
+	sort.Strings(resp.Validators)
+
+	return resp
+}
+
+// Filter implements core.SigningPolicyFilter, dropping the partial signatures of paused validators.
+func (p *validatorPauser) Filter(_ context.Context, duty core.Duty, set core.ParSignedDataSet) (core.ParSignedDataSet, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.all && len(p.paused) == 0 {
+		return set, nil
+	}
+
+	resp := make(core.ParSignedDataSet)
+	for pubkey, data := range set {
+		if p.all || p.paused[pubkey] {
+			continue
+		}
+
+		resp[pubkey] = data
+	}
+
+	if len(resp) == 0 && len(set) > 0 {
+		return nil, errors.New("partial signatures of paused validators dropped", z.Any("duty", duty))
+	}
+
+	return resp, nil
+}
+
+// parse returns the requested validator public keys, or an error if any isn't a cluster validator.
+func (p *validatorPauser) parse(req AdminValidators) ([]core.PubKey, error) {
+	p.mu.Lock()
+	validators := p.validatorsFunc()
+	p.mu.Unlock()
+
+	known := make(map[core.PubKey]bool)
+	for _, pubkey := range validators {
+		known[pubkey] = true
+	}
+
+	var resp []core.PubKey
+	for _, val := range req.Validators {
+		b, err := hex.DecodeString(strings.TrimPrefix(val, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "decode validator public key", z.Redact(z.Str("pubkey", val)))
+		}
+
+		pubkey, err := core.PubKeyFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+
+		if !known[pubkey] {
+			return nil, errors.New("unknown validator", z.Redact(z.Str("pubkey", val)))
+		}
+
+		resp = append(resp, pubkey)
+	}
+
+	return resp, nil
+}
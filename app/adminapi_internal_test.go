@@ -0,0 +1,328 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	adminpb "github.com/obolnetwork/charon/app/adminpb/v1"
+	"github.com/obolnetwork/charon/app/httpauth"
+	"github.com/obolnetwork/charon/app/lifecycle"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/policy"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestValidatorPauser(t *testing.T) {
+	ctx := context.Background()
+	val1 := testutil.RandomCorePubKey(t)
+	val2 := testutil.RandomCorePubKey(t)
+
+	pauser := newValidatorPauser()
+	pauser.SetValidatorsFunc(func() []core.PubKey { return []core.PubKey{val1, val2} })
+
+	duty := core.NewAttesterDuty(1)
+	set := core.ParSignedDataSet{
+		val1: core.ParSignedData{ShareIdx: 1},
+		val2: core.ParSignedData{ShareIdx: 1},
+	}
+
+	// Nothing paused.
+	filtered, err := pauser.Filter(ctx, duty, set)
+	require.NoError(t, err)
+	require.Equal(t, set, filtered)
+
+	// Pause a single validator without 0x prefix.
+	paused, err := pauser.Pause(ctx, AdminValidators{Validators: []string{strings.TrimPrefix(string(val1), "0x")}})
+	require.NoError(t, err)
+	require.Equal(t, AdminValidators{Validators: []string{string(val1)}}, paused)
+
+	filtered, err = pauser.Filter(ctx, duty, set)
+	require.NoError(t, err)
+	require.Equal(t, core.ParSignedDataSet{val2: set[val2]}, filtered)
+
+	_, err = pauser.Filter(ctx, duty, core.ParSignedDataSet{val1: set[val1]})
+	require.ErrorContains(t, err, "partial signatures of paused validators dropped")
+
+	// Unknown validators are rejected.
+	_, err = pauser.Pause(ctx, AdminValidators{Validators: []string{string(testutil.RandomCorePubKey(t))}})
+	require.ErrorContains(t, err, "unknown validator")
+
+	// Pause all validators.
+	paused, err = pauser.Pause(ctx, AdminValidators{All: true})
+	require.NoError(t, err)
+	require.True(t, paused.All)
+
+	_, err = pauser.Filter(ctx, duty, set)
+	require.ErrorContains(t, err, "partial signatures of paused validators dropped")
+
+	_, err = pauser.Resume(ctx, AdminValidators{Validators: []string{string(val1)}})
+	require.ErrorContains(t, err, "all validators paused")
+
+	// Resume all validators.
+	paused, err = pauser.Resume(ctx, AdminValidators{All: true})
+	require.NoError(t, err)
+	require.Equal(t, AdminValidators{Validators: []string{}}, paused)
+
+	filtered, err = pauser.Filter(ctx, duty, set)
+	require.NoError(t, err)
+	require.Equal(t, set, filtered)
+
+	// Standby mode pauses all validators until promoted.
+	pauser.EnterStandby(ctx)
+	_, err = pauser.Resume(ctx, AdminValidators{All: true})
+	require.ErrorContains(t, err, "standby mode")
+
+	pauser.Promote(ctx)
+	require.Equal(t, AdminValidators{Validators: []string{}}, pauser.Paused())
+
+	// Safe mode pauses all validators until restart.
+	pauser.EnterSafeMode(ctx)
+	require.True(t, pauser.Paused().All)
+
+	_, err = pauser.Resume(ctx, AdminValidators{All: true})
+	require.ErrorContains(t, err, "safe mode")
+
+	_, err = pauser.Filter(ctx, duty, set)
+	require.ErrorContains(t, err, "partial signatures of paused validators dropped")
+}
+
+func TestExitValidators(t *testing.T) {
+	ctx := context.Background()
+	val1 := testutil.RandomCorePubKey(t)
+	val2 := testutil.RandomCorePubKey(t)
+
+	pauser := newValidatorPauser()
+	pauser.SetValidatorsFunc(func() []core.PubKey { return []core.PubKey{val1, val2} })
+
+	var exited []string
+	conf := Config{AdminExitFunc: func(_ context.Context, _ Config, pubkeys []string) error {
+		exited = append(exited, pubkeys...)
+		return nil
+	}}
+
+	policyFile := filepath.Join(t.TempDir(), "policy.json")
+	writePolicy := func(t *testing.T, content string) *policy.Engine {
+		t.Helper()
+
+		require.NoError(t, os.WriteFile(policyFile, []byte(content), 0o644))
+		engine, err := policy.New(policyFile, time.Hour)
+		require.NoError(t, err)
+
+		return engine
+	}
+
+	// Allowed by the signing policy and not paused.
+	resp, err := exitValidators(ctx, conf, pauser, writePolicy(t, `{}`), AdminValidators{Validators: []string{string(val1)}})
+	require.NoError(t, err)
+	require.Equal(t, []string{string(val1)}, resp.Validators)
+	require.Equal(t, []string{string(val1)}, exited)
+
+	// Denied by the signing policy.
+	for _, content := range []string{`{"disabled_duties": ["exit"]}`, `{"deny_exit_windows": [{"from": "2000-01-01T00:00:00Z"}]}`} {
+		_, err = exitValidators(ctx, conf, pauser, writePolicy(t, content), AdminValidators{All: true})
+		require.ErrorContains(t, err, "voluntary exits denied by signing policy")
+	}
+
+	// Paused validators.
+	_, err = pauser.Pause(ctx, AdminValidators{Validators: []string{string(val2)}})
+	require.NoError(t, err)
+
+	_, err = exitValidators(ctx, conf, pauser, nil, AdminValidators{Validators: []string{string(val2)}})
+	require.ErrorContains(t, err, "exit rejected for paused validator")
+
+	_, err = exitValidators(ctx, conf, pauser, nil, AdminValidators{All: true})
+	require.ErrorContains(t, err, "exits rejected while validators are paused")
+
+	pauser.EnterStandby(ctx)
+	_, err = exitValidators(ctx, conf, pauser, nil, AdminValidators{Validators: []string{string(val1)}})
+	require.ErrorContains(t, err, "exits rejected while all validators are paused")
+
+	require.Equal(t, []string{string(val1)}, exited)
+}
+
+func TestAdminValidatorsHandler(t *testing.T) {
+	val := testutil.RandomCorePubKey(t)
+	pauser := newValidatorPauser()
+	pauser.SetValidatorsFunc(func() []core.PubKey { return []core.PubKey{val} })
+
+	handler := newAdminValidatorsHandler(pauser.Pause)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantResp   AdminValidators
+	}{
+		{
+			name:       "single",
+			body:       `{"validators":["` + string(val) + `"]}`,
+			wantStatus: http.StatusOK,
+			wantResp:   AdminValidators{Validators: []string{string(val)}},
+		},
+		{
+			name:       "empty body pauses all",
+			body:       "",
+			wantStatus: http.StatusOK,
+			wantResp:   AdminValidators{All: true, Validators: []string{string(val)}},
+		},
+		{
+			name:       "invalid json",
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid pubkey",
+			body:       `{"validators":["0xinvalid"]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest(http.MethodPost, "/validators/pause", strings.NewReader(test.body)))
+			require.Equal(t, test.wantStatus, rec.Code)
+
+			if test.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp AdminValidators
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			require.Equal(t, test.wantResp, resp)
+		})
+	}
+}
+
+func TestAdminGRPC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	val := testutil.RandomCorePubKey(t)
+	pauser := newValidatorPauser()
+	pauser.SetValidatorsFunc(func() []core.PubKey { return []core.PubKey{val} })
+
+	certFile, keyFile, roots := newTLSFiles(t)
+	conf := Config{
+		AdminAddr: testutil.AvailableAddr(t).String(),
+		AdminAuth: httpauth.Config{BasicAuth: "admin:secret", TLSCertFile: certFile, TLSKeyFile: keyFile},
+	}
+	reloader, err := newConfigReloader(conf)
+	require.NoError(t, err)
+
+	life := new(lifecycle.Manager)
+	require.NoError(t, wireAdminAPI(life, conf, pauser, nil, reloader, newDutiesStatus()))
+
+	done := make(chan error)
+	go func() {
+		done <- life.Run(ctx)
+	}()
+
+	newClient := func(t *testing.T, basicAuth string) adminpb.AdminServiceClient {
+		t.Helper()
+
+		auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(basicAuth))
+		conn, err := grpc.NewClient(conf.AdminAddr,
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12})),
+			grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				return invoker(metadata.AppendToOutgoingContext(ctx, "authorization", auth), method, req, reply, cc, opts...)
+			}),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+
+		return adminpb.NewAdminServiceClient(conn)
+	}
+
+	client := newClient(t, "admin:secret")
+
+	require.Eventually(t, func() bool {
+		_, err := client.GetPausedValidators(ctx, &adminpb.GetPausedValidatorsRequest{})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	paused, err := client.PauseValidators(ctx, &adminpb.PauseValidatorsRequest{Validators: []string{string(val)}})
+	require.NoError(t, err)
+	require.Equal(t, []string{string(val)}, paused.GetPaused().GetValidators())
+
+	_, err = client.PauseValidators(ctx, &adminpb.PauseValidatorsRequest{Validators: []string{"0xinvalid"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	state, err := client.GetDebugState(ctx, &adminpb.GetDebugStateRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{string(val)}, state.GetPaused().GetValidators())
+
+	_, err = client.ExitValidators(ctx, &adminpb.ExitValidatorsRequest{})
+	require.ErrorContains(t, err, "exits not supported by this node")
+
+	// Invalid credentials are rejected.
+	_, err = newClient(t, "admin:wrong").GetPausedValidators(ctx, &adminpb.GetPausedValidatorsRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// newTLSFiles writes a new self-signed localhost certificate and its private key to PEM files,
+// returning the file paths and a certificate pool trusting the certificate.
+func newTLSFiles(t *testing.T) (string, string, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "admin.crt"), filepath.Join(dir, "admin.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	return certFile, keyFile, roots
+}
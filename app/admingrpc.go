@@ -0,0 +1,149 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	adminpb "github.com/obolnetwork/charon/app/adminpb/v1"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core/policy"
+)
+
+// newAdminGRPCServer returns a new gRPC server of the admin API, logging failed requests.
+func newAdminGRPCServer(conf Config, pauser *validatorPauser, signingPolicy *policy.Engine, reloader *configReloader,
+	duties *dutiesStatus,
+) *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = log.WithTopic(ctx, "admin")
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			log.Warn(ctx, "Admin API request failed", err, z.Str("method", info.FullMethod))
+		}
+
+		return resp, err
+	}))
+
+	adminpb.RegisterAdminServiceServer(server, adminGRPCServer{
+		conf:          conf,
+		pauser:        pauser,
+		signingPolicy: signingPolicy,
+		reloader:      reloader,
+		duties:        duties,
+	})
+
+	return server
+}
+
+// adminGRPCServer implements the gRPC admin API, equivalent to the HTTP admin API endpoints.
+type adminGRPCServer struct {
+	adminpb.UnimplementedAdminServiceServer
+
+	conf          Config
+	pauser        *validatorPauser
+	signingPolicy *policy.Engine
+	reloader      *configReloader
+	duties        *dutiesStatus
+}
+
+func (s adminGRPCServer) GetPausedValidators(context.Context, *adminpb.GetPausedValidatorsRequest) (*adminpb.GetPausedValidatorsResponse, error) {
+	return &adminpb.GetPausedValidatorsResponse{Paused: validatorsToProto(s.pauser.Paused())}, nil
+}
+
+func (s adminGRPCServer) PauseValidators(ctx context.Context, req *adminpb.PauseValidatorsRequest) (*adminpb.PauseValidatorsResponse, error) {
+	paused, err := s.pauser.Pause(ctx, newAdminValidators(req.GetValidators()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &adminpb.PauseValidatorsResponse{Paused: validatorsToProto(paused)}, nil
+}
+
+func (s adminGRPCServer) ResumeValidators(ctx context.Context, req *adminpb.ResumeValidatorsRequest) (*adminpb.ResumeValidatorsResponse, error) {
+	paused, err := s.pauser.Resume(ctx, newAdminValidators(req.GetValidators()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &adminpb.ResumeValidatorsResponse{Paused: validatorsToProto(paused)}, nil
+}
+
+func (s adminGRPCServer) ExitValidators(ctx context.Context, req *adminpb.ExitValidatorsRequest) (*adminpb.ExitValidatorsResponse, error) {
+	exited, err := exitValidators(ctx, s.conf, s.pauser, s.signingPolicy, newAdminValidators(req.GetValidators()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminpb.ExitValidatorsResponse{Exited: validatorsToProto(exited)}, nil
+}
+
+func (s adminGRPCServer) ReloadConfig(context.Context, *adminpb.ReloadConfigRequest) (*adminpb.ReloadConfigResponse, error) {
+	s.reloader.Trigger()
+
+	return &adminpb.ReloadConfigResponse{}, nil
+}
+
+func (s adminGRPCServer) GetDebugState(context.Context, *adminpb.GetDebugStateRequest) (*adminpb.GetDebugStateResponse, error) {
+	state := debugState(s.pauser, s.reloader, s.duties)
+
+	resp := &adminpb.GetDebugStateResponse{
+		Version: state.Version,
+		RuntimeConfig: &adminpb.RuntimeConfig{
+			LogLevel:                state.RuntimeConfig.LogLevel,
+			BeaconNodeAddrs:         state.RuntimeConfig.BeaconNodeAddrs,
+			FallbackBeaconNodeAddrs: state.RuntimeConfig.FallbackBeaconNodeAddrs,
+			BuilderApi:              state.RuntimeConfig.BuilderAPI,
+			ProposerConfigFile:      state.RuntimeConfig.ProposerConfigFile,
+		},
+		Paused: validatorsToProto(state.Paused),
+		Duties: &adminpb.DutiesStatus{Slot: state.Duties.Slot},
+	}
+
+	for _, duty := range state.Duties.Upcoming {
+		resp.Duties.Upcoming = append(resp.Duties.Upcoming, &adminpb.UpcomingDuty{
+			Slot:       duty.Slot,
+			Duty:       duty.Duty,
+			Validators: int32(duty.Validators),
+		})
+	}
+
+	for _, duty := range state.Duties.Failures {
+		resp.Duties.Failures = append(resp.Duties.Failures, &adminpb.FailedDuty{
+			Time:      timestamppb.New(duty.Time),
+			Slot:      duty.Slot,
+			Duty:      duty.Duty,
+			Step:      duty.Step,
+			Reason:    duty.Reason,
+			Error:     duty.Error,
+			RootCause: duty.RootCause,
+		})
+	}
+
+	return resp, nil
+}
+
+func (adminGRPCServer) GetGoroutines(context.Context, *adminpb.GetGoroutinesRequest) (*adminpb.GetGoroutinesResponse, error) {
+	var b strings.Builder
+	if err := writeGoroutines(&b); err != nil {
+		return nil, err
+	}
+
+	return &adminpb.GetGoroutinesResponse{Stacks: b.String()}, nil
+}
+
+// newAdminValidators returns the validators request, selecting all validators if none are provided.
+func newAdminValidators(validators []string) AdminValidators {
+	return AdminValidators{All: len(validators) == 0, Validators: validators}
+}
+
+func validatorsToProto(validators AdminValidators) *adminpb.Validators {
+	return &adminpb.Validators{All: validators.All, Validators: validators.Validators}
+}
@@ -0,0 +1,1216 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: app/adminpb/v1/admin.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Validators is a selection of validators.
+type Validators struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	All           bool                   `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"`              // All is true if all validators are selected, in which case validators is empty.
+	Validators    []string               `protobuf:"bytes,2,rep,name=validators,proto3" json:"validators,omitempty"` // Validators are the hex encoded validator public keys.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Validators) Reset() {
+	*x = Validators{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Validators) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Validators) ProtoMessage() {}
+
+func (x *Validators) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Validators.ProtoReflect.Descriptor instead.
+func (*Validators) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Validators) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+func (x *Validators) GetValidators() []string {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+type GetPausedValidatorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPausedValidatorsRequest) Reset() {
+	*x = GetPausedValidatorsRequest{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPausedValidatorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPausedValidatorsRequest) ProtoMessage() {}
+
+func (x *GetPausedValidatorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPausedValidatorsRequest.ProtoReflect.Descriptor instead.
+func (*GetPausedValidatorsRequest) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type GetPausedValidatorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paused        *Validators            `protobuf:"bytes,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPausedValidatorsResponse) Reset() {
+	*x = GetPausedValidatorsResponse{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPausedValidatorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPausedValidatorsResponse) ProtoMessage() {}
+
+func (x *GetPausedValidatorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPausedValidatorsResponse.ProtoReflect.Descriptor instead.
+func (*GetPausedValidatorsResponse) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetPausedValidatorsResponse) GetPaused() *Validators {
+	if x != nil {
+		return x.Paused
+	}
+	return nil
+}
+
+type PauseValidatorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Validators    []string               `protobuf:"bytes,1,rep,name=validators,proto3" json:"validators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseValidatorsRequest) Reset() {
+	*x = PauseValidatorsRequest{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseValidatorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseValidatorsRequest) ProtoMessage() {}
+
+func (x *PauseValidatorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseValidatorsRequest.ProtoReflect.Descriptor instead.
+func (*PauseValidatorsRequest) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PauseValidatorsRequest) GetValidators() []string {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+type PauseValidatorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paused        *Validators            `protobuf:"bytes,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseValidatorsResponse) Reset() {
+	*x = PauseValidatorsResponse{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseValidatorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseValidatorsResponse) ProtoMessage() {}
+
+func (x *PauseValidatorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseValidatorsResponse.ProtoReflect.Descriptor instead.
+func (*PauseValidatorsResponse) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PauseValidatorsResponse) GetPaused() *Validators {
+	if x != nil {
+		return x.Paused
+	}
+	return nil
+}
+
+type ResumeValidatorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Validators    []string               `protobuf:"bytes,1,rep,name=validators,proto3" json:"validators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeValidatorsRequest) Reset() {
+	*x = ResumeValidatorsRequest{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeValidatorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeValidatorsRequest) ProtoMessage() {}
+
+func (x *ResumeValidatorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeValidatorsRequest.ProtoReflect.Descriptor instead.
+func (*ResumeValidatorsRequest) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResumeValidatorsRequest) GetValidators() []string {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+type ResumeValidatorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paused        *Validators            `protobuf:"bytes,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeValidatorsResponse) Reset() {
+	*x = ResumeValidatorsResponse{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeValidatorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeValidatorsResponse) ProtoMessage() {}
+
+func (x *ResumeValidatorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeValidatorsResponse.ProtoReflect.Descriptor instead.
+func (*ResumeValidatorsResponse) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ResumeValidatorsResponse) GetPaused() *Validators {
+	if x != nil {
+		return x.Paused
+	}
+	return nil
+}
+
+type ExitValidatorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Validators    []string               `protobuf:"bytes,1,rep,name=validators,proto3" json:"validators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExitValidatorsRequest) Reset() {
+	*x = ExitValidatorsRequest{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExitValidatorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExitValidatorsRequest) ProtoMessage() {}
+
+func (x *ExitValidatorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExitValidatorsRequest.ProtoReflect.Descriptor instead.
+func (*ExitValidatorsRequest) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExitValidatorsRequest) GetValidators() []string {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+type ExitValidatorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exited        *Validators            `protobuf:"bytes,1,opt,name=exited,proto3" json:"exited,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExitValidatorsResponse) Reset() {
+	*x = ExitValidatorsResponse{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExitValidatorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExitValidatorsResponse) ProtoMessage() {}
+
+func (x *ExitValidatorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExitValidatorsResponse.ProtoReflect.Descriptor instead.
+func (*ExitValidatorsResponse) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExitValidatorsResponse) GetExited() *Validators {
+	if x != nil {
+		return x.Exited
+	}
+	return nil
+}
+
+type ReloadConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadConfigRequest) Reset() {
+	*x = ReloadConfigRequest{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigRequest) ProtoMessage() {}
+
+func (x *ReloadConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigRequest.ProtoReflect.Descriptor instead.
+func (*ReloadConfigRequest) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{9}
+}
+
+type ReloadConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadConfigResponse) Reset() {
+	*x = ReloadConfigResponse{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigResponse) ProtoMessage() {}
+
+func (x *ReloadConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigResponse.ProtoReflect.Descriptor instead.
+func (*ReloadConfigResponse) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{10}
+}
+
+type GetDebugStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDebugStateRequest) Reset() {
+	*x = GetDebugStateRequest{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDebugStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDebugStateRequest) ProtoMessage() {}
+
+func (x *GetDebugStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDebugStateRequest.ProtoReflect.Descriptor instead.
+func (*GetDebugStateRequest) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{11}
+}
+
+type GetDebugStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	RuntimeConfig *RuntimeConfig         `protobuf:"bytes,2,opt,name=runtime_config,json=runtimeConfig,proto3" json:"runtime_config,omitempty"`
+	Paused        *Validators            `protobuf:"bytes,3,opt,name=paused,proto3" json:"paused,omitempty"`
+	Duties        *DutiesStatus          `protobuf:"bytes,4,opt,name=duties,proto3" json:"duties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDebugStateResponse) Reset() {
+	*x = GetDebugStateResponse{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDebugStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDebugStateResponse) ProtoMessage() {}
+
+func (x *GetDebugStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDebugStateResponse.ProtoReflect.Descriptor instead.
+func (*GetDebugStateResponse) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetDebugStateResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetDebugStateResponse) GetRuntimeConfig() *RuntimeConfig {
+	if x != nil {
+		return x.RuntimeConfig
+	}
+	return nil
+}
+
+func (x *GetDebugStateResponse) GetPaused() *Validators {
+	if x != nil {
+		return x.Paused
+	}
+	return nil
+}
+
+func (x *GetDebugStateResponse) GetDuties() *DutiesStatus {
+	if x != nil {
+		return x.Duties
+	}
+	return nil
+}
+
+// RuntimeConfig is the hot reloadable subset of the config, with redacted beacon node URLs.
+type RuntimeConfig struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	LogLevel                string                 `protobuf:"bytes,1,opt,name=log_level,json=logLevel,proto3" json:"log_level,omitempty"`
+	BeaconNodeAddrs         []string               `protobuf:"bytes,2,rep,name=beacon_node_addrs,json=beaconNodeAddrs,proto3" json:"beacon_node_addrs,omitempty"`
+	FallbackBeaconNodeAddrs []string               `protobuf:"bytes,3,rep,name=fallback_beacon_node_addrs,json=fallbackBeaconNodeAddrs,proto3" json:"fallback_beacon_node_addrs,omitempty"`
+	BuilderApi              bool                   `protobuf:"varint,4,opt,name=builder_api,json=builderApi,proto3" json:"builder_api,omitempty"`
+	ProposerConfigFile      string                 `protobuf:"bytes,5,opt,name=proposer_config_file,json=proposerConfigFile,proto3" json:"proposer_config_file,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *RuntimeConfig) Reset() {
+	*x = RuntimeConfig{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuntimeConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuntimeConfig) ProtoMessage() {}
+
+func (x *RuntimeConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuntimeConfig.ProtoReflect.Descriptor instead.
+func (*RuntimeConfig) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RuntimeConfig) GetLogLevel() string {
+	if x != nil {
+		return x.LogLevel
+	}
+	return ""
+}
+
+func (x *RuntimeConfig) GetBeaconNodeAddrs() []string {
+	if x != nil {
+		return x.BeaconNodeAddrs
+	}
+	return nil
+}
+
+func (x *RuntimeConfig) GetFallbackBeaconNodeAddrs() []string {
+	if x != nil {
+		return x.FallbackBeaconNodeAddrs
+	}
+	return nil
+}
+
+func (x *RuntimeConfig) GetBuilderApi() bool {
+	if x != nil {
+		return x.BuilderApi
+	}
+	return false
+}
+
+func (x *RuntimeConfig) GetProposerConfigFile() string {
+	if x != nil {
+		return x.ProposerConfigFile
+	}
+	return ""
+}
+
+type DutiesStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slot          uint64                 `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"` // Slot is the current slot, zero if the scheduler didn't start yet.
+	Upcoming      []*UpcomingDuty        `protobuf:"bytes,2,rep,name=upcoming,proto3" json:"upcoming,omitempty"`
+	Failures      []*FailedDuty          `protobuf:"bytes,3,rep,name=failures,proto3" json:"failures,omitempty"` // Failures are the most recent duty failures, latest first.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DutiesStatus) Reset() {
+	*x = DutiesStatus{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DutiesStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DutiesStatus) ProtoMessage() {}
+
+func (x *DutiesStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DutiesStatus.ProtoReflect.Descriptor instead.
+func (*DutiesStatus) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DutiesStatus) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *DutiesStatus) GetUpcoming() []*UpcomingDuty {
+	if x != nil {
+		return x.Upcoming
+	}
+	return nil
+}
+
+func (x *DutiesStatus) GetFailures() []*FailedDuty {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type UpcomingDuty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slot          uint64                 `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	Duty          string                 `protobuf:"bytes,2,opt,name=duty,proto3" json:"duty,omitempty"`
+	Validators    int32                  `protobuf:"varint,3,opt,name=validators,proto3" json:"validators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpcomingDuty) Reset() {
+	*x = UpcomingDuty{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpcomingDuty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpcomingDuty) ProtoMessage() {}
+
+func (x *UpcomingDuty) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpcomingDuty.ProtoReflect.Descriptor instead.
+func (*UpcomingDuty) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpcomingDuty) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *UpcomingDuty) GetDuty() string {
+	if x != nil {
+		return x.Duty
+	}
+	return ""
+}
+
+func (x *UpcomingDuty) GetValidators() int32 {
+	if x != nil {
+		return x.Validators
+	}
+	return 0
+}
+
+type FailedDuty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Slot          uint64                 `protobuf:"varint,2,opt,name=slot,proto3" json:"slot,omitempty"`
+	Duty          string                 `protobuf:"bytes,3,opt,name=duty,proto3" json:"duty,omitempty"`
+	Step          string                 `protobuf:"bytes,4,opt,name=step,proto3" json:"step,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	RootCause     string                 `protobuf:"bytes,7,opt,name=root_cause,json=rootCause,proto3" json:"root_cause,omitempty"` // Cluster-consistent root cause correlated from peer observations.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailedDuty) Reset() {
+	*x = FailedDuty{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailedDuty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedDuty) ProtoMessage() {}
+
+func (x *FailedDuty) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedDuty.ProtoReflect.Descriptor instead.
+func (*FailedDuty) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FailedDuty) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *FailedDuty) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *FailedDuty) GetDuty() string {
+	if x != nil {
+		return x.Duty
+	}
+	return ""
+}
+
+func (x *FailedDuty) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *FailedDuty) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *FailedDuty) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *FailedDuty) GetRootCause() string {
+	if x != nil {
+		return x.RootCause
+	}
+	return ""
+}
+
+type GetGoroutinesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGoroutinesRequest) Reset() {
+	*x = GetGoroutinesRequest{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGoroutinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGoroutinesRequest) ProtoMessage() {}
+
+func (x *GetGoroutinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGoroutinesRequest.ProtoReflect.Descriptor instead.
+func (*GetGoroutinesRequest) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{17}
+}
+
+type GetGoroutinesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stacks        string                 `protobuf:"bytes,1,opt,name=stacks,proto3" json:"stacks,omitempty"` // Stacks are the goroutine stack traces formatted like a panic.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGoroutinesResponse) Reset() {
+	*x = GetGoroutinesResponse{}
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGoroutinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGoroutinesResponse) ProtoMessage() {}
+
+func (x *GetGoroutinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_app_adminpb_v1_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGoroutinesResponse.ProtoReflect.Descriptor instead.
+func (*GetGoroutinesResponse) Descriptor() ([]byte, []int) {
+	return file_app_adminpb_v1_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetGoroutinesResponse) GetStacks() string {
+	if x != nil {
+		return x.Stacks
+	}
+	return ""
+}
+
+var File_app_adminpb_v1_admin_proto protoreflect.FileDescriptor
+
+var file_app_adminpb_v1_admin_proto_rawDesc = string([]byte{
+	0x0a, 0x1a, 0x61, 0x70, 0x70, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2f, 0x76, 0x31,
+	0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x61, 0x70,
+	0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3e, 0x0a,
+	0x0a, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61,
+	0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x1e, 0x0a,
+	0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x1c, 0x0a,
+	0x1a, 0x47, 0x65, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x51, 0x0a, 0x1b, 0x47,
+	0x65, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x70, 0x61,
+	0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x70, 0x70,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x22, 0x38,
+	0x0a, 0x16, 0x50, 0x61, 0x75, 0x73, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x4d, 0x0a, 0x17, 0x50, 0x61, 0x75, 0x73,
+	0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52,
+	0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x22, 0x39, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x73, 0x22, 0x4e, 0x0a, 0x18, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32,
+	0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73,
+	0x65, 0x64, 0x22, 0x37, 0x0a, 0x15, 0x45, 0x78, 0x69, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x4c, 0x0a, 0x16, 0x45,
+	0x78, 0x69, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x74, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x52, 0x06, 0x65, 0x78, 0x69, 0x74, 0x65, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x52, 0x65, 0x6c,
+	0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x44,
+	0x65, 0x62, 0x75, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0xe1, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x44, 0x65, 0x62, 0x75, 0x67, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x0e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61,
+	0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75,
+	0x6e, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0d, 0x72, 0x75, 0x6e,
+	0x74, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x32, 0x0a, 0x06, 0x70, 0x61,
+	0x75, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x70, 0x70,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x34,
+	0x0a, 0x06, 0x64, 0x75, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x75, 0x74, 0x69, 0x65, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x64, 0x75,
+	0x74, 0x69, 0x65, 0x73, 0x22, 0xe8, 0x01, 0x0a, 0x0d, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x5f, 0x6e, 0x6f,
+	0x64, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f,
+	0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x64, 0x64, 0x72, 0x73, 0x12,
+	0x3b, 0x0a, 0x1a, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x62, 0x65, 0x61, 0x63,
+	0x6f, 0x6e, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x17, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x42, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x64, 0x64, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x41, 0x70, 0x69, 0x12, 0x30, 0x0a,
+	0x14, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x22,
+	0x94, 0x01, 0x0a, 0x0c, 0x44, 0x75, 0x74, 0x69, 0x65, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x73, 0x6c, 0x6f, 0x74, 0x12, 0x38, 0x0a, 0x08, 0x75, 0x70, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67,
+	0x44, 0x75, 0x74, 0x79, 0x52, 0x08, 0x75, 0x70, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x36,
+	0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x44, 0x75, 0x74, 0x79, 0x52, 0x08, 0x66, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22, 0x56, 0x0a, 0x0c, 0x55, 0x70, 0x63, 0x6f, 0x6d, 0x69,
+	0x6e, 0x67, 0x44, 0x75, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x75,
+	0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x75, 0x74, 0x79, 0x12, 0x1e,
+	0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0xc5,
+	0x01, 0x0a, 0x0a, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x44, 0x75, 0x74, 0x79, 0x12, 0x2e, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x6c, 0x6f,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x75, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x64, 0x75, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x6f, 0x6f, 0x74, 0x5f,
+	0x63, 0x61, 0x75, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x6f, 0x6f,
+	0x74, 0x43, 0x61, 0x75, 0x73, 0x65, 0x22, 0x16, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x72,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2f,
+	0x0a, 0x15, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x63, 0x6b,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x73, 0x32,
+	0xc1, 0x05, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x6e, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2a, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x61, 0x75, 0x73,
+	0x65, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x62, 0x0a, 0x0f, 0x50, 0x61, 0x75, 0x73, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x73, 0x12, 0x26, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x61, 0x70,
+	0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x75,
+	0x73, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x65, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x27, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x28, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x0e, 0x45,
+	0x78, 0x69, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x25, 0x2e,
+	0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x78, 0x69, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x69, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x0c,
+	0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x23, 0x2e, 0x61,
+	0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x24, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x44, 0x65,
+	0x62, 0x75, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x24, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x62,
+	0x75, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x44, 0x65, 0x62, 0x75, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x72, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x24, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61,
+	0x70, 0x70, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x68,
+	0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x61, 0x70, 0x70, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62,
+	0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_app_adminpb_v1_admin_proto_rawDescOnce sync.Once
+	file_app_adminpb_v1_admin_proto_rawDescData []byte
+)
+
+func file_app_adminpb_v1_admin_proto_rawDescGZIP() []byte {
+	file_app_adminpb_v1_admin_proto_rawDescOnce.Do(func() {
+		file_app_adminpb_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_app_adminpb_v1_admin_proto_rawDesc), len(file_app_adminpb_v1_admin_proto_rawDesc)))
+	})
+	return file_app_adminpb_v1_admin_proto_rawDescData
+}
+
+var file_app_adminpb_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_app_adminpb_v1_admin_proto_goTypes = []any{
+	(*Validators)(nil),                  // 0: app.adminpb.v1.Validators
+	(*GetPausedValidatorsRequest)(nil),  // 1: app.adminpb.v1.GetPausedValidatorsRequest
+	(*GetPausedValidatorsResponse)(nil), // 2: app.adminpb.v1.GetPausedValidatorsResponse
+	(*PauseValidatorsRequest)(nil),      // 3: app.adminpb.v1.PauseValidatorsRequest
+	(*PauseValidatorsResponse)(nil),     // 4: app.adminpb.v1.PauseValidatorsResponse
+	(*ResumeValidatorsRequest)(nil),     // 5: app.adminpb.v1.ResumeValidatorsRequest
+	(*ResumeValidatorsResponse)(nil),    // 6: app.adminpb.v1.ResumeValidatorsResponse
+	(*ExitValidatorsRequest)(nil),       // 7: app.adminpb.v1.ExitValidatorsRequest
+	(*ExitValidatorsResponse)(nil),      // 8: app.adminpb.v1.ExitValidatorsResponse
+	(*ReloadConfigRequest)(nil),         // 9: app.adminpb.v1.ReloadConfigRequest
+	(*ReloadConfigResponse)(nil),        // 10: app.adminpb.v1.ReloadConfigResponse
+	(*GetDebugStateRequest)(nil),        // 11: app.adminpb.v1.GetDebugStateRequest
+	(*GetDebugStateResponse)(nil),       // 12: app.adminpb.v1.GetDebugStateResponse
+	(*RuntimeConfig)(nil),               // 13: app.adminpb.v1.RuntimeConfig
+	(*DutiesStatus)(nil),                // 14: app.adminpb.v1.DutiesStatus
+	(*UpcomingDuty)(nil),                // 15: app.adminpb.v1.UpcomingDuty
+	(*FailedDuty)(nil),                  // 16: app.adminpb.v1.FailedDuty
+	(*GetGoroutinesRequest)(nil),        // 17: app.adminpb.v1.GetGoroutinesRequest
+	(*GetGoroutinesResponse)(nil),       // 18: app.adminpb.v1.GetGoroutinesResponse
+	(*timestamppb.Timestamp)(nil),       // 19: google.protobuf.Timestamp
+}
+var file_app_adminpb_v1_admin_proto_depIdxs = []int32{
+	0,  // 0: app.adminpb.v1.GetPausedValidatorsResponse.paused:type_name -> app.adminpb.v1.Validators
+	0,  // 1: app.adminpb.v1.PauseValidatorsResponse.paused:type_name -> app.adminpb.v1.Validators
+	0,  // 2: app.adminpb.v1.ResumeValidatorsResponse.paused:type_name -> app.adminpb.v1.Validators
+	0,  // 3: app.adminpb.v1.ExitValidatorsResponse.exited:type_name -> app.adminpb.v1.Validators
+	13, // 4: app.adminpb.v1.GetDebugStateResponse.runtime_config:type_name -> app.adminpb.v1.RuntimeConfig
+	0,  // 5: app.adminpb.v1.GetDebugStateResponse.paused:type_name -> app.adminpb.v1.Validators
+	14, // 6: app.adminpb.v1.GetDebugStateResponse.duties:type_name -> app.adminpb.v1.DutiesStatus
+	15, // 7: app.adminpb.v1.DutiesStatus.upcoming:type_name -> app.adminpb.v1.UpcomingDuty
+	16, // 8: app.adminpb.v1.DutiesStatus.failures:type_name -> app.adminpb.v1.FailedDuty
+	19, // 9: app.adminpb.v1.FailedDuty.time:type_name -> google.protobuf.Timestamp
+	1,  // 10: app.adminpb.v1.AdminService.GetPausedValidators:input_type -> app.adminpb.v1.GetPausedValidatorsRequest
+	3,  // 11: app.adminpb.v1.AdminService.PauseValidators:input_type -> app.adminpb.v1.PauseValidatorsRequest
+	5,  // 12: app.adminpb.v1.AdminService.ResumeValidators:input_type -> app.adminpb.v1.ResumeValidatorsRequest
+	7,  // 13: app.adminpb.v1.AdminService.ExitValidators:input_type -> app.adminpb.v1.ExitValidatorsRequest
+	9,  // 14: app.adminpb.v1.AdminService.ReloadConfig:input_type -> app.adminpb.v1.ReloadConfigRequest
+	11, // 15: app.adminpb.v1.AdminService.GetDebugState:input_type -> app.adminpb.v1.GetDebugStateRequest
+	17, // 16: app.adminpb.v1.AdminService.GetGoroutines:input_type -> app.adminpb.v1.GetGoroutinesRequest
+	2,  // 17: app.adminpb.v1.AdminService.GetPausedValidators:output_type -> app.adminpb.v1.GetPausedValidatorsResponse
+	4,  // 18: app.adminpb.v1.AdminService.PauseValidators:output_type -> app.adminpb.v1.PauseValidatorsResponse
+	6,  // 19: app.adminpb.v1.AdminService.ResumeValidators:output_type -> app.adminpb.v1.ResumeValidatorsResponse
+	8,  // 20: app.adminpb.v1.AdminService.ExitValidators:output_type -> app.adminpb.v1.ExitValidatorsResponse
+	10, // 21: app.adminpb.v1.AdminService.ReloadConfig:output_type -> app.adminpb.v1.ReloadConfigResponse
+	12, // 22: app.adminpb.v1.AdminService.GetDebugState:output_type -> app.adminpb.v1.GetDebugStateResponse
+	18, // 23: app.adminpb.v1.AdminService.GetGoroutines:output_type -> app.adminpb.v1.GetGoroutinesResponse
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_app_adminpb_v1_admin_proto_init() }
+func file_app_adminpb_v1_admin_proto_init() {
+	if File_app_adminpb_v1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_app_adminpb_v1_admin_proto_rawDesc), len(file_app_adminpb_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_app_adminpb_v1_admin_proto_goTypes,
+		DependencyIndexes: file_app_adminpb_v1_admin_proto_depIdxs,
+		MessageInfos:      file_app_adminpb_v1_admin_proto_msgTypes,
+	}.Build()
+	File_app_adminpb_v1_admin_proto = out.File
+	file_app_adminpb_v1_admin_proto_goTypes = nil
+	file_app_adminpb_v1_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,367 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: app/adminpb/v1/admin.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AdminService_GetPausedValidators_FullMethodName = "/app.adminpb.v1.AdminService/GetPausedValidators"
+	AdminService_PauseValidators_FullMethodName     = "/app.adminpb.v1.AdminService/PauseValidators"
+	AdminService_ResumeValidators_FullMethodName    = "/app.adminpb.v1.AdminService/ResumeValidators"
+	AdminService_ExitValidators_FullMethodName      = "/app.adminpb.v1.AdminService/ExitValidators"
+	AdminService_ReloadConfig_FullMethodName        = "/app.adminpb.v1.AdminService/ReloadConfig"
+	AdminService_GetDebugState_FullMethodName       = "/app.adminpb.v1.AdminService/GetDebugState"
+	AdminService_GetGoroutines_FullMethodName       = "/app.adminpb.v1.AdminService/GetGoroutines"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService is the gRPC admin API of a charon node, served on the admin API listener alongside the HTTP endpoints.
+type AdminServiceClient interface {
+	// GetPausedValidators returns the paused validators.
+	GetPausedValidators(ctx context.Context, in *GetPausedValidatorsRequest, opts ...grpc.CallOption) (*GetPausedValidatorsResponse, error)
+	// PauseValidators pauses the validators, or all validators if none are provided.
+	PauseValidators(ctx context.Context, in *PauseValidatorsRequest, opts ...grpc.CallOption) (*PauseValidatorsResponse, error)
+	// ResumeValidators resumes the validators, or all validators if none are provided.
+	ResumeValidators(ctx context.Context, in *ResumeValidatorsRequest, opts ...grpc.CallOption) (*ResumeValidatorsResponse, error)
+	// ExitValidators signs and publishes partial exits of the validators, or all validators if none are provided.
+	ExitValidators(ctx context.Context, in *ExitValidatorsRequest, opts ...grpc.CallOption) (*ExitValidatorsResponse, error)
+	// ReloadConfig triggers a reload of the config file.
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+	// GetDebugState returns the version, runtime config, paused validators and duty status.
+	GetDebugState(ctx context.Context, in *GetDebugStateRequest, opts ...grpc.CallOption) (*GetDebugStateResponse, error)
+	// GetGoroutines returns the stack traces of all goroutines.
+	GetGoroutines(ctx context.Context, in *GetGoroutinesRequest, opts ...grpc.CallOption) (*GetGoroutinesResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) GetPausedValidators(ctx context.Context, in *GetPausedValidatorsRequest, opts ...grpc.CallOption) (*GetPausedValidatorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPausedValidatorsResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetPausedValidators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PauseValidators(ctx context.Context, in *PauseValidatorsRequest, opts ...grpc.CallOption) (*PauseValidatorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseValidatorsResponse)
+	err := c.cc.Invoke(ctx, AdminService_PauseValidators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ResumeValidators(ctx context.Context, in *ResumeValidatorsRequest, opts ...grpc.CallOption) (*ResumeValidatorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeValidatorsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ResumeValidators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ExitValidators(ctx context.Context, in *ExitValidatorsRequest, opts ...grpc.CallOption) (*ExitValidatorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExitValidatorsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ExitValidators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, AdminService_ReloadConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetDebugState(ctx context.Context, in *GetDebugStateRequest, opts ...grpc.CallOption) (*GetDebugStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDebugStateResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetDebugState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetGoroutines(ctx context.Context, in *GetGoroutinesRequest, opts ...grpc.CallOption) (*GetGoroutinesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGoroutinesResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetGoroutines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService is the gRPC admin API of a charon node, served on the admin API listener alongside the HTTP endpoints.
+type AdminServiceServer interface {
+	// GetPausedValidators returns the paused validators.
+	GetPausedValidators(context.Context, *GetPausedValidatorsRequest) (*GetPausedValidatorsResponse, error)
+	// PauseValidators pauses the validators, or all validators if none are provided.
+	PauseValidators(context.Context, *PauseValidatorsRequest) (*PauseValidatorsResponse, error)
+	// ResumeValidators resumes the validators, or all validators if none are provided.
+	ResumeValidators(context.Context, *ResumeValidatorsRequest) (*ResumeValidatorsResponse, error)
+	// ExitValidators signs and publishes partial exits of the validators, or all validators if none are provided.
+	ExitValidators(context.Context, *ExitValidatorsRequest) (*ExitValidatorsResponse, error)
+	// ReloadConfig triggers a reload of the config file.
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
+	// GetDebugState returns the version, runtime config, paused validators and duty status.
+	GetDebugState(context.Context, *GetDebugStateRequest) (*GetDebugStateResponse, error)
+	// GetGoroutines returns the stack traces of all goroutines.
+	GetGoroutines(context.Context, *GetGoroutinesRequest) (*GetGoroutinesResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) GetPausedValidators(context.Context, *GetPausedValidatorsRequest) (*GetPausedValidatorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPausedValidators not implemented")
+}
+func (UnimplementedAdminServiceServer) PauseValidators(context.Context, *PauseValidatorsRequest) (*PauseValidatorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseValidators not implemented")
+}
+func (UnimplementedAdminServiceServer) ResumeValidators(context.Context, *ResumeValidatorsRequest) (*ResumeValidatorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeValidators not implemented")
+}
+func (UnimplementedAdminServiceServer) ExitValidators(context.Context, *ExitValidatorsRequest) (*ExitValidatorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExitValidators not implemented")
+}
+func (UnimplementedAdminServiceServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (UnimplementedAdminServiceServer) GetDebugState(context.Context, *GetDebugStateRequest) (*GetDebugStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDebugState not implemented")
+}
+func (UnimplementedAdminServiceServer) GetGoroutines(context.Context, *GetGoroutinesRequest) (*GetGoroutinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGoroutines not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_GetPausedValidators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPausedValidatorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetPausedValidators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetPausedValidators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetPausedValidators(ctx, req.(*GetPausedValidatorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PauseValidators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseValidatorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PauseValidators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_PauseValidators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PauseValidators(ctx, req.(*PauseValidatorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ResumeValidators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeValidatorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ResumeValidators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ResumeValidators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ResumeValidators(ctx, req.(*ResumeValidatorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ExitValidators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExitValidatorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ExitValidators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ExitValidators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ExitValidators(ctx, req.(*ExitValidatorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ReloadConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetDebugState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDebugStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetDebugState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetDebugState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetDebugState(ctx, req.(*GetDebugStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetGoroutines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGoroutinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetGoroutines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetGoroutines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetGoroutines(ctx, req.(*GetGoroutinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "app.adminpb.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPausedValidators",
+			Handler:    _AdminService_GetPausedValidators_Handler,
+		},
+		{
+			MethodName: "PauseValidators",
+			Handler:    _AdminService_PauseValidators_Handler,
+		},
+		{
+			MethodName: "ResumeValidators",
+			Handler:    _AdminService_ResumeValidators_Handler,
+		},
+		{
+			MethodName: "ExitValidators",
+			Handler:    _AdminService_ExitValidators_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _AdminService_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "GetDebugState",
+			Handler:    _AdminService_GetDebugState_Handler,
+		},
+		{
+			MethodName: "GetGoroutines",
+			Handler:    _AdminService_GetGoroutines_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "app/adminpb/v1/admin.proto",
+}
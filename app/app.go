@@ -9,9 +9,12 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
@@ -26,18 +29,31 @@ import (
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"go.uber.org/automaxprocs/maxprocs"
 
+	"github.com/obolnetwork/charon/app/datadir"
+	"github.com/obolnetwork/charon/app/depositwatch"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/eventbus"
 	"github.com/obolnetwork/charon/app/featureset"
+	"github.com/obolnetwork/charon/app/httpauth"
 	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/app/lifecycle"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/manifestwatch"
+	"github.com/obolnetwork/charon/app/metricspush"
 	"github.com/obolnetwork/charon/app/peerinfo"
 	"github.com/obolnetwork/charon/app/privkeylock"
+	"github.com/obolnetwork/charon/app/profiling"
 	"github.com/obolnetwork/charon/app/promauto"
 	"github.com/obolnetwork/charon/app/retry"
+	"github.com/obolnetwork/charon/app/rewards"
+	"github.com/obolnetwork/charon/app/selfmonitor"
+	"github.com/obolnetwork/charon/app/slareport"
 	"github.com/obolnetwork/charon/app/stacksnipe"
+	"github.com/obolnetwork/charon/app/sysclock"
+	"github.com/obolnetwork/charon/app/telemetry"
 	"github.com/obolnetwork/charon/app/tracer"
+	"github.com/obolnetwork/charon/app/vallifecycle"
 	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
@@ -54,7 +70,9 @@ import (
 	"github.com/obolnetwork/charon/core/infosync"
 	"github.com/obolnetwork/charon/core/parsigdb"
 	"github.com/obolnetwork/charon/core/parsigex"
+	"github.com/obolnetwork/charon/core/policy"
 	"github.com/obolnetwork/charon/core/priority"
+	"github.com/obolnetwork/charon/core/rootcause"
 	"github.com/obolnetwork/charon/core/scheduler"
 	"github.com/obolnetwork/charon/core/sigagg"
 	"github.com/obolnetwork/charon/core/tracker"
@@ -63,19 +81,26 @@ import (
 	"github.com/obolnetwork/charon/eth2util/enr"
 	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/tbls"
-	"github.com/obolnetwork/charon/tbls/tblsconv"
-	"github.com/obolnetwork/charon/testutil/beaconmock" // Allow testutil
+	"github.com/obolnetwork/charon/testutil/beaconmock"    // Allow testutil
+	"github.com/obolnetwork/charon/testutil/validatormock" // Allow testutil
 )
 
+// signingPolicyReloadPeriod is the period the signing policy file is polled for changes.
+const signingPolicyReloadPeriod = 10 * time.Second
+
 type Config struct {
-	P2P                     p2p.Config
-	Log                     log.Config
-	Feature                 featureset.Config
-	LockFile                string
-	ManifestFile            string
-	NoVerify                bool
-	PrivKeyFile             string
-	PrivKeyLocking          bool
+	P2P          p2p.Config
+	Log          log.Config
+	Feature      featureset.Config
+	LockFile     string
+	ManifestFile string
+	// KeyAttestationsDir is the directory containing operator key attestations accepted during their grace period.
+	KeyAttestationsDir string
+	NoVerify           bool
+	PrivKeyFile        string
+	PrivKeyLocking     bool
+	// P2PPKCS11 enables the p2p identity (ENR) key being held by a PKCS#11 HSM instead of the private key file.
+	P2PPKCS11               k1util.PKCS11Config
 	MonitoringAddr          string
 	DebugAddr               string
 	ValidatorAPIAddr        string
@@ -84,13 +109,20 @@ type Config struct {
 	BeaconNodeSubmitTimeout time.Duration
 	JaegerAddr              string
 	JaegerService           string
+	OTLPAddr                string
+	OTLPServiceName         string
 	SimnetBMock             bool
 	SimnetVMock             bool
+	SimnetVMockReportFile   string
 	SimnetValidatorKeysDir  string
+	// RemoteSignerAddr enables the internal validator client requesting partial signatures from this
+	// Web3Signer-compatible remote signer, instead of an external validator client.
+	RemoteSignerAddr        string
 	SimnetSlotDuration      time.Duration
 	SyntheticBlockProposals bool
 	BuilderAPI              bool
 	SimnetBMockFuzz         bool
+	SimnetBMockScenarioFile string
 	TestnetConfig           eth2util.Network
 	ProcDirectory           string
 	ConsensusProtocol       string
@@ -98,6 +130,64 @@ type Config struct {
 	BeaconNodeHeaders       []string
 	TargetGasLimit          uint
 	FallbackBeaconNodeAddrs []string
+	ManifestReloadInterval  time.Duration
+	UpgradeTarget           string
+	UpgradeGateConsensus    bool
+	ClustersFile            string
+	SigningPolicyFile       string
+	// Standby runs the node in warm standby mode for an operator's primary node with the same private key,
+	// staying connected and synced but not signing until it acquires the leadership lease after the primary fails.
+	Standby bool
+	// StandbyFailoverTimeout is the duration after which this node grants the leadership lease of a peer
+	// to its standby instance if its primary instance isn't seen.
+	StandbyFailoverTimeout time.Duration
+	// ExecutionRPCEndpoint enables watching the deposit contract for deposits of the cluster validators
+	// via this execution layer JSON-RPC endpoint, scanning blocks from DepositStartBlock.
+	ExecutionRPCEndpoint string
+	DepositStartBlock    uint64
+	// RewardsTracking enables tracking the rewards and missed rewards of the cluster validators, served via the
+	// monitoring API and written to the RewardsReportDir every RewardsReportPeriod if not empty.
+	RewardsTracking     bool
+	RewardsReportDir    string
+	RewardsReportPeriod time.Duration
+	// ParticipationFile is the path of the file persisting peer participation counters across restarts.
+	ParticipationFile string
+	// SLAFile is the path of the file persisting the daily duty participation of each operator for SLA reports.
+	SLAFile string
+	// SLAReportDir enables writing the signed SLA report of each completed UTC day to this directory.
+	SLAReportDir string
+	// MonitoringPprof enables serving pprof endpoints on the monitoring API.
+	MonitoringPprof bool
+	// MonitoringAuth protects the monitoring API with basic auth, TLS and an IP allowlist.
+	MonitoringAuth httpauth.Config
+	// ValidatorAPIAuth protects the validator API with basic auth, TLS and an IP allowlist.
+	ValidatorAPIAuth httpauth.Config
+	// AdminAddr enables the admin API on this listening address, protected by AdminAuth.
+	AdminAddr string
+	AdminAuth httpauth.Config
+	// AdminExitFunc signs and publishes partial exits of the node's validators, or of all validators if none are
+	// provided, via the admin API. Nil disables exits via the admin API.
+	AdminExitFunc func(ctx context.Context, conf Config, pubkeys []string) error
+	// ProfilingPushAddr enables pushing continuous profiles to this Pyroscope server every ProfilingPushInterval.
+	ProfilingPushAddr     string
+	ProfilingPushInterval time.Duration
+	// TelemetryEndpoint enables reporting anonymized telemetry to this endpoint every TelemetryInterval.
+	TelemetryEndpoint string
+	TelemetryInterval time.Duration
+	// MetricsRemoteWriteURL and MetricsPushgatewayURL enable pushing metrics every MetricsPushInterval
+	// for nodes that cannot be scraped.
+	MetricsRemoteWriteURL string
+	MetricsPushgatewayURL string
+	MetricsPushInterval   time.Duration
+	// EventWebhookURLs and EventNATSURL enable delivering cluster events to webhooks and a NATS server.
+	EventWebhookURLs []string
+	EventNATSURL     string
+	EventNATSSubject string
+	// ProposerConfigFile overrides the cluster lock fee recipients, it is hot reloaded on changes.
+	ProposerConfigFile string
+	// ReloadConfig returns the latest runtime config, e.g. from the config file. It is called on SIGHUP and
+	// periodically to hot reload the runtime config without restarting. Nil disables runtime config reloading.
+	ReloadConfig func() (RuntimeConfig, error)
 
 	TestConfig TestConfig
 }
@@ -116,6 +206,8 @@ type TestConfig struct {
 	SimnetKeys []tbls.PrivateKey
 	// SimnetBMockOpts defines additional simnet beacon mock options.
 	SimnetBMockOpts []beaconmock.Option
+	// SimnetVMockOpts defines additional simnet validator mock options, e.g. fault injection.
+	SimnetVMockOpts []validatormock.Option
 	// BroadcastCallback is called when a duty is completed and sent to the broadcast component.
 	BroadcastCallback func(context.Context, core.Duty, core.SignedDataSet) error
 	// PrioritiseCallback is called with priority protocol results.
@@ -135,6 +227,10 @@ type TestConfig struct {
 func Run(ctx context.Context, conf Config) (err error) {
 	ctx = log.WithTopic(ctx, "app-start")
 
+	if conf.ClustersFile != "" {
+		return runMulti(ctx, conf)
+	}
+
 	_, _ = maxprocs.Set()
 
 	if err := featureset.Init(ctx, conf.Feature); err != nil {
@@ -143,9 +239,15 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	version.LogInfo(ctx, "Charon starting")
 
+	if err := datadir.Check(ctx, filepath.Dir(conf.PrivKeyFile)); err != nil {
+		return err
+	}
+
 	// Wire processes and their dependencies
 	life := new(lifecycle.Manager)
 
+	// The lock file is also created next to the private key file when the key is held by a PKCS#11 HSM,
+	// so the data dir is still protected against multiple instances.
 	if conf.PrivKeyLocking {
 		lockSvc, err := privkeylock.New(conf.PrivKeyFile+".lock", "charon run")
 		if err != nil {
@@ -167,7 +269,12 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return err
 	}
 
-	cluster, err := loadClusterManifest(ctx, conf)
+	dag, cluster, err := loadClusterManifest(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	cluster, peerExpiries, err := applyKeyAttestations(ctx, conf, cluster)
 	if err != nil {
 		return err
 	}
@@ -185,13 +292,9 @@ func Run(ctx context.Context, conf Config) (err error) {
 		featureset.EnableGnosisBlockHotfixIfNotDisabled(ctx, conf.Feature)
 	}
 
-	p2pKey := conf.TestConfig.P2PKey
-	if p2pKey == nil {
-		var err error
-		p2pKey, err = k1util.Load(conf.PrivKeyFile)
-		if err != nil {
-			return errors.Wrap(err, "load priv key")
-		}
+	p2pKey, err := loadP2PSigner(ctx, conf)
+	if err != nil {
+		return err
 	}
 
 	peers, err := manifest.ClusterPeers(cluster)
@@ -199,12 +302,12 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return err
 	}
 
-	if err := p2p.VerifyP2PKey(peers, p2pKey); err != nil {
+	if err := p2p.VerifyP2PPubKey(peers, p2pKey.PubKey()); err != nil {
 		return err
 	}
 
 	lockHashHex := hex7(cluster.GetInitialMutationHash())
-	tcpNode, err := wireP2P(ctx, life, conf, cluster, p2pKey, lockHashHex)
+	tcpNode, err := wireP2P(ctx, life, conf, cluster, p2pKey, lockHashHex, peerExpiries)
 	if err != nil {
 		return err
 	}
@@ -214,7 +317,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return errors.Wrap(err, "private key not matching cluster manifest file")
 	}
 
-	enrRec, err := enr.New(p2pKey)
+	enrRec, err := enr.NewWithSigner(p2pKey)
 	if err != nil {
 		return errors.Wrap(err, "creating enr record from privkey")
 	}
@@ -225,7 +328,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 		z.Str("cluster_name", cluster.GetName()),
 		z.Str("cluster_hash", lockHashHex),
 		z.Str("cluster_hash_full", hex.EncodeToString(cluster.GetInitialMutationHash())),
-		z.Str("enr", enrRec.String()),
+		z.Redact(z.Str("enr", enrRec.String())),
 		z.Int("peers", len(cluster.GetOperators())))
 
 	// Metric and logging labels.
@@ -249,6 +352,16 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return err
 	}
 
+	reloader, err := newConfigReloader(conf)
+	if err != nil {
+		return err
+	}
+	reloader.Subscribe(reloadLogLevel)
+	if !conf.SimnetBMock && !conf.SimnetBMockFuzz {
+		reloader.Subscribe(reloadBeaconNodes(conf, cluster.GetForkVersion(), eth2Cl, subEth2Cl))
+	}
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartConfigReload, lifecycle.HookFuncCtx(reloader.Run))
+
 	peerIDs, err := manifest.ClusterPeerIDs(cluster)
 	if err != nil {
 		return err
@@ -264,7 +377,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 	if len(conf.Nickname) > 32 {
 		return errors.New("nickname can not exceed 32 characters")
 	}
-	wirePeerInfo(life, tcpNode, peerIDs, cluster.GetInitialMutationHash(), sender, conf.BuilderAPI, conf.Nickname)
+	peerInfo := wirePeerInfo(life, tcpNode, peerIDs, cluster.GetInitialMutationHash(), sender, conf.BuilderAPI, conf.Nickname, conf.UpgradeTarget)
 
 	// seenPubkeys channel to send seen public keys from validatorapi to monitoringapi.
 	seenPubkeys := make(chan core.PubKey)
@@ -289,12 +402,116 @@ func Run(ctx context.Context, conf Config) (err error) {
 	}
 
 	consensusDebugger := consensus.NewDebugger()
+	duties := newDutiesStatus()
+
+	pauser := newValidatorPauser()
+	peerInfo.Instances().Subscribe(pauser.EnterSafeMode)
+	peerInfo.Instances().SubscribeLease(pauser.Promote)
+	peerInfo.Instances().EnableLeases(conf.Standby, privkeylock.LeaseQuorum(len(cluster.GetOperators())), conf.StandbyFailoverTimeout)
+	if conf.Standby {
+		pauser.EnterStandby(ctx)
+	}
+
+	var signingPolicy *policy.Engine
+	if conf.SigningPolicyFile != "" {
+		signingPolicy, err = policy.New(conf.SigningPolicyFile, signingPolicyReloadPeriod)
+		if err != nil {
+			return err
+		}
+
+		life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartSigningPolicy, lifecycle.HookFuncCtx(signingPolicy.Run))
+	}
+
+	if conf.AdminAddr != "" {
+		if err := wireAdminAPI(life, conf, pauser, signingPolicy, reloader, duties); err != nil {
+			return err
+		}
+	}
+
+	systemd, err := newSystemdNotifier()
+	if err != nil {
+		return err
+	}
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartSystemd, lifecycle.HookFuncCtx(systemd.Run))
+
+	eth2Pubkeys, err := toETH2Pubkeys(pubkeys)
+	if err != nil {
+		return err
+	}
+
+	deposits, err := wireDepositWatch(life, conf, cluster.GetForkVersion(), eth2Pubkeys, eth2Cl)
+	if err != nil {
+		return err
+	}
+
+	valLifecycle := vallifecycle.New(eth2Pubkeys, eth2Cl)
+	if deposits != nil {
+		valLifecycle.SetDepositedFunc(deposits.Deposited)
+	}
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartValidatorLifecycle, lifecycle.HookFuncCtx(valLifecycle.Run))
+
+	var rewardsTracker *rewards.Tracker
+	if conf.RewardsTracking {
+		rewardsTracker = rewards.New(eth2Cl, conf.RewardsReportDir, conf.RewardsReportPeriod)
+		life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartRewards, lifecycle.HookFuncCtx(rewardsTracker.Run))
+	}
+
+	err = wireMonitoringAPI(ctx, life, conf.MonitoringAddr, conf.DebugAddr, conf.MonitoringPprof, conf.MonitoringAuth,
+		tcpNode, eth2Cl, peerIDs, promRegistry, consensusDebugger, pubkeys, seenPubkeys, vapiCalls,
+		len(cluster.GetValidators()), peerInfo, duties, deposits, valLifecycle, rewardsTracker)
+	if err != nil {
+		return err
+	}
 
-	wireMonitoringAPI(ctx, life, conf.MonitoringAddr, conf.DebugAddr, tcpNode, eth2Cl, peerIDs,
-		promRegistry, consensusDebugger, pubkeys, seenPubkeys, vapiCalls, len(cluster.GetValidators()))
+	selfMonitor := selfmonitor.New(filepath.Dir(conf.PrivKeyFile))
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartSelfMonitor, lifecycle.HookFuncCtx(selfMonitor.Run))
+
+	clockMonitor := sysclock.NewMonitor(sysclock.New())
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartClockMonitor, lifecycle.HookFuncCtx(clockMonitor.Run))
+
+	if conf.ProfilingPushAddr != "" {
+		pusher := profiling.NewPusher(conf.ProfilingPushAddr, "charon", map[string]string{
+			"cluster_name":   labels["cluster_name"],
+			"cluster_peer":   labels["cluster_peer"],
+			"charon_version": labels["charon_version"],
+		}, conf.ProfilingPushInterval)
+		life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartProfiling, lifecycle.HookFuncCtx(pusher.Run))
+	}
+
+	if conf.TelemetryEndpoint != "" {
+		reporter := telemetry.New(conf.TelemetryEndpoint, conf.TelemetryInterval, promRegistry, len(peerIDs), len(cluster.GetValidators()))
+		life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartTelemetry, lifecycle.HookFuncCtx(reporter.Run))
+	}
+
+	if pushConf := (metricspush.Config{
+		RemoteWriteURL: conf.MetricsRemoteWriteURL,
+		PushgatewayURL: conf.MetricsPushgatewayURL,
+		Interval:       conf.MetricsPushInterval,
+	}); pushConf.Enabled() {
+		pusher := metricspush.New(pushConf, promRegistry, labels["cluster_peer"])
+		life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartMetricsPush, lifecycle.HookFuncCtx(pusher.Run))
+	}
+
+	// Hot reload the cluster manifest, unless it is provided explicitly for testing.
+	var watcher *manifestwatch.Watcher
+	if conf.ManifestReloadInterval > 0 && conf.TestConfig.Lock == nil {
+		watcher, err = manifestwatch.New(conf.ManifestFile, conf.LockFile, dag, conf.ManifestReloadInterval)
+		if err != nil {
+			return err
+		}
+
+		life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartManifestWatch, lifecycle.HookFuncCtx(watcher.Run))
+	}
+
+	bus, err := wireEventBus(life, conf, tcpNode, peerIDs)
+	if err != nil {
+		return err
+	} else if bus != nil {
+		valLifecycle.Subscribe(validatorLifecycleEvents(bus))
+	}
 
 	err = wireCoreWorkflow(ctx, life, conf, cluster, nodeIdx, tcpNode, p2pKey, eth2Cl, subEth2Cl,
-		peerIDs, sender, consensusDebugger, seenPubkeysFunc, vapiCallsFunc)
+		peerIDs, sender, consensusDebugger, seenPubkeysFunc, vapiCallsFunc, watcher, peerInfo, bus, reloader, duties, systemd, pauser, signingPolicy)
 	if err != nil {
 		return err
 	}
@@ -303,16 +520,115 @@ func Run(ctx context.Context, conf Config) (err error) {
 	return life.Run(ctx)
 }
 
+// wireDepositWatch wires the deposit watcher if an execution layer JSON-RPC endpoint is configured, else it returns nil.
+func wireDepositWatch(life *lifecycle.Manager, conf Config, forkVersion []byte, pubkeys []eth2p0.BLSPubKey,
+	eth2Cl eth2wrap.Client,
+) (*depositwatch.Watcher, error) {
+	if conf.ExecutionRPCEndpoint == "" {
+		return nil, nil //nolint:nilnil // Nil watcher disables deposit watching.
+	}
+
+	contract, err := eth2util.ForkVersionToDepositContract(forkVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher, err := depositwatch.New(conf.ExecutionRPCEndpoint, contract, conf.DepositStartBlock, pubkeys, eth2Cl)
+	if err != nil {
+		return nil, err
+	}
+
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartDepositWatch, lifecycle.HookFuncCtx(watcher.Run))
+
+	return watcher, nil
+}
+
+// wireSLAReport returns the store of the operators' duty participation and registers the daily SLA reporter.
+func wireSLAReport(life *lifecycle.Manager, conf Config, cluster *manifestpb.Cluster, p2pKey k1util.Signer,
+) (*slareport.Store, error) {
+	store, err := slareport.NewStore(conf.SLAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	slaCluster, err := slareport.NewCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	reporter := slareport.NewReporter(store, slaCluster, p2pKey, conf.SLAReportDir)
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartSLAReport, lifecycle.HookFuncCtx(reporter.Run))
+
+	return store, nil
+}
+
+// toETH2Pubkeys returns the core public keys as eth2 public keys.
+func toETH2Pubkeys(pubkeys []core.PubKey) ([]eth2p0.BLSPubKey, error) {
+	var resp []eth2p0.BLSPubKey
+	for _, pubkey := range pubkeys {
+		eth2Pubkey, err := pubkey.ToETH2()
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, eth2Pubkey)
+	}
+
+	return resp, nil
+}
+
 // wirePeerInfo wires the peerinfo protocol.
-func wirePeerInfo(life *lifecycle.Manager, tcpNode host.Host, peers []peer.ID, lockHash []byte, sender *p2p.Sender, builderEnabled bool, nickname string) {
+// It also shares the operator's upgrade target and enabled features with peers and reports the cluster's readiness to upgrade.
+func wirePeerInfo(life *lifecycle.Manager, tcpNode host.Host, peers []peer.ID, lockHash []byte, sender *p2p.Sender,
+	builderEnabled bool, nickname string, upgradeTarget string,
+) *peerinfo.PeerInfo {
 	gitHash, _ := version.GitCommit()
 	peerInfo := peerinfo.New(tcpNode, peers, version.Version, lockHash, gitHash, sender.SendReceive, builderEnabled, nickname)
+	peerInfo.SetUpgradeTarget(upgradeTarget)
+
+	var features []string
+	for _, feature := range featureset.Advertised() {
+		features = append(features, string(feature))
+	}
+	peerInfo.SetFeatures(features)
+
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartPeerInfo, lifecycle.HookFuncCtx(peerInfo.Run))
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartPeerInfo, lifecycle.HookFuncCtx(func(ctx context.Context) {
+		reportUpgradeReadiness(ctx, tcpNode, peers, peerInfo)
+	}))
+
+	return peerInfo
+}
+
+// loadP2PSigner returns the p2p identity key signer, either held by the configured PKCS#11 HSM
+// or loaded from the private key file.
+func loadP2PSigner(ctx context.Context, conf Config) (k1util.Signer, error) {
+	if conf.TestConfig.P2PKey != nil {
+		return k1util.NewSigner(conf.TestConfig.P2PKey), nil
+	}
+
+	if conf.P2PPKCS11.Enabled() {
+		signer, err := k1util.NewPKCS11Signer(ctx, conf.P2PPKCS11)
+		if err != nil {
+			return nil, errors.Wrap(err, "load pkcs11 p2p key")
+		}
+
+		log.Info(ctx, "Using PKCS#11 HSM held p2p key", z.Str("module", conf.P2PPKCS11.Module))
+
+		return signer, nil
+	}
+
+	key, err := k1util.Load(conf.PrivKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "load priv key")
+	}
+
+	return k1util.NewSigner(key), nil
 }
 
 // wireP2P constructs the p2p tcp (libp2p) and udp (discv5) nodes and registers it with the life cycle manager.
 func wireP2P(ctx context.Context, life *lifecycle.Manager, conf Config,
-	cluster *manifestpb.Cluster, p2pKey *k1.PrivateKey, lockHashHex string,
+	cluster *manifestpb.Cluster, p2pKey k1util.Signer, lockHashHex string, peerExpiries map[peer.ID]time.Time,
 ) (host.Host, error) {
 	peerIDs, err := manifest.ClusterPeerIDs(cluster)
 	if err != nil {
@@ -328,6 +644,7 @@ func wireP2P(ctx context.Context, life *lifecycle.Manager, conf Config,
 	if err != nil {
 		return nil, err
 	}
+	connGater = connGater.WithExpiringPeers(peerExpiries)
 
 	// Start libp2p TCP node.
 	opts := []libp2p.Option{
@@ -336,7 +653,7 @@ func wireP2P(ctx context.Context, life *lifecycle.Manager, conf Config,
 	}
 	opts = append(opts, conf.TestConfig.LibP2POpts...)
 
-	tcpNode, err := p2p.NewTCPNode(ctx, conf.P2P, p2pKey, connGater,
+	tcpNode, err := p2p.NewTCPNodeWithSigner(ctx, conf.P2P, p2pKey, connGater,
 		false, opts...)
 	if err != nil {
 		return nil, err
@@ -358,61 +675,38 @@ func wireP2P(ctx context.Context, life *lifecycle.Manager, conf Config,
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartP2PEventCollector, p2p.NewEventCollector(tcpNode))
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartP2PRouters, p2p.NewRelayRouter(tcpNode, peerIDs, relays))
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartForceDirectConns, p2p.ForceDirectConnections(tcpNode, peerIDs))
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartP2PPeerExpiry, p2p.NewPeerExpirer(tcpNode, peerExpiries))
 
 	return tcpNode, nil
 }
 
 // wireCoreWorkflow wires the core workflow components.
 func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
-	cluster *manifestpb.Cluster, nodeIdx cluster.NodeIdx, tcpNode host.Host, p2pKey *k1.PrivateKey,
+	cluster *manifestpb.Cluster, nodeIdx cluster.NodeIdx, tcpNode host.Host, p2pKey k1util.Signer,
 	eth2Cl, submissionEth2Cl eth2wrap.Client, peerIDs []peer.ID, sender *p2p.Sender,
 	consensusDebugger consensus.Debugger, seenPubkeys func(core.PubKey),
-	vapiCalls func(),
+	vapiCalls func(), watcher *manifestwatch.Watcher, peerInfo *peerinfo.PeerInfo, bus *eventbus.Bus,
+	reloader *configReloader, duties *dutiesStatus, systemd *systemdNotifier, pauser *validatorPauser,
+	signingPolicy *policy.Engine,
 ) error {
 	// Convert and prep public keys and public shares
-	var (
-		corePubkeys                  []core.PubKey
-		eth2Pubkeys                  []eth2p0.BLSPubKey
-		pubshares                    []eth2p0.BLSPubKey
-		allPubSharesByKey            = make(map[core.PubKey]map[int]tbls.PublicKey) // map[pubkey]map[shareIdx]pubshare
-		feeRecipientAddrByCorePubkey = make(map[core.PubKey]string)
-	)
-	for _, val := range cluster.GetValidators() {
-		pubkey, err := manifest.ValidatorPublicKey(val)
-		if err != nil {
-			return err
-		}
-
-		corePubkey, err := core.PubKeyFromBytes(pubkey[:])
-		if err != nil {
-			return err
-		}
-
-		allPubShares := make(map[int]tbls.PublicKey)
-		for i, b := range val.GetPubShares() {
-			pubshare, err := tblsconv.PubkeyFromBytes(b)
-			if err != nil {
-				return err
-			}
+	initialValSet, err := newValidatorSet(cluster.GetValidators())
+	if err != nil {
+		return err
+	}
 
-			// share index is 1-indexed
-			allPubShares[i+1] = pubshare
-		}
+	// valSet contains the current validator set which changes when the cluster manifest is reloaded.
+	valSet := new(atomic.Pointer[validatorSet])
+	valSet.Store(initialValSet)
 
+	var pubshares []eth2p0.BLSPubKey
+	for _, val := range cluster.GetValidators() {
 		pubShare, err := manifest.ValidatorPublicShare(val, nodeIdx.PeerIdx)
 		if err != nil {
 			return err
 		}
 
-		eth2Share := eth2p0.BLSPubKey(pubShare)
-
-		eth2Pubkey := eth2p0.BLSPubKey(pubkey)
-
-		eth2Pubkeys = append(eth2Pubkeys, eth2Pubkey)
-		corePubkeys = append(corePubkeys, corePubkey)
-		pubshares = append(pubshares, eth2Share)
-		allPubSharesByKey[corePubkey] = allPubShares
-		feeRecipientAddrByCorePubkey[corePubkey] = val.GetFeeRecipientAddress()
+		pubshares = append(pubshares, eth2p0.BLSPubKey(pubShare))
 	}
 
 	peers, err := manifest.ClusterPeers(cluster)
@@ -429,18 +723,21 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return core.NewDeadliner(ctx, label, deadlineFunc)
 	}
 
-	sched, err := scheduler.New(corePubkeys, eth2Cl, conf.BuilderAPI)
+	sched, err := scheduler.New(initialValSet.corePubkeys, eth2Cl, conf.BuilderAPI)
 	if err != nil {
 		return err
 	}
+	duties.SetUpcomingFunc(sched.UpcomingDuties)
+	sched.SubscribeSlots(duties.SlotTicked)
+	sched.SubscribeSlots(systemd.SlotTicked)
 
 	feeRecipientFunc := func(pubkey core.PubKey) string {
-		return feeRecipientAddrByCorePubkey[pubkey]
+		return reloader.FeeRecipient(pubkey, valSet.Load().feeRecipients[pubkey])
 	}
 	sched.SubscribeSlots(setFeeRecipient(eth2Cl, feeRecipientFunc))
 
 	// Setup validator cache, refreshing it every epoch.
-	valCache := eth2wrap.NewValidatorCache(eth2Cl, eth2Pubkeys)
+	valCache := eth2wrap.NewValidatorCache(eth2Cl, initialValSet.eth2Pubkeys)
 	eth2Cl.SetValidatorCache(valCache.Get)
 
 	firstValCacheRefresh := true
@@ -493,12 +790,12 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 
 	dutyDB := dutydb.NewMemDB(deadlinerFunc("dutydb"))
 
-	vapi, err := validatorapi.NewComponent(eth2Cl, allPubSharesByKey, nodeIdx.ShareIdx, feeRecipientFunc, conf.BuilderAPI, uint(cluster.GetTargetGasLimit()), seenPubkeys)
+	vapi, err := validatorapi.NewComponent(eth2Cl, initialValSet.allPubSharesByKey, nodeIdx.ShareIdx, feeRecipientFunc, conf.BuilderAPI, uint(cluster.GetTargetGasLimit()), seenPubkeys)
 	if err != nil {
 		return err
 	}
 
-	if err := wireVAPIRouter(ctx, life, conf.ValidatorAPIAddr, eth2Cl, vapi, vapiCalls, conf.BuilderAPI); err != nil {
+	if err := wireVAPIRouter(ctx, life, conf.ValidatorAPIAddr, conf.ValidatorAPIAuth, eth2Cl, vapi, vapiCalls, vapi.BuilderEnabled); err != nil {
 		return err
 	}
 
@@ -508,7 +805,10 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	if conf.TestConfig.ParSigExFunc != nil {
 		parSigEx = conf.TestConfig.ParSigExFunc()
 	} else {
-		verifyFunc, err := parsigex.NewEth2Verifier(eth2Cl, allPubSharesByKey)
+		verifyFunc, err := parsigex.NewEth2VerifierFunc(eth2Cl, func(pubkey core.PubKey) (map[int]tbls.PublicKey, bool) {
+			pubshares, ok := valSet.Load().allPubSharesByKey[pubkey]
+			return pubshares, ok
+		})
 		if err != nil {
 			return err
 		}
@@ -551,17 +851,44 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	// Priority protocol always uses QBFTv2.
 	err = wirePrioritise(ctx, conf, life, tcpNode, peerIDs, int(cluster.GetThreshold()),
 		sender.SendReceive, defaultConsensus, sched, p2pKey, deadlineFunc,
-		consensusController, cluster.GetConsensusProtocol())
+		consensusController, cluster.GetConsensusProtocol(), peerInfo)
 	if err != nil {
 		return err
 	}
 
-	if err = wireRecaster(ctx, eth2Cl, sched, sigAgg, broadcaster, cluster.GetValidators(),
-		conf.BuilderAPI, conf.TestConfig.BroadcastCallback); err != nil {
+	recaster, err := wireRecaster(ctx, eth2Cl, sched, sigAgg, broadcaster, cluster.GetValidators(),
+		conf.BuilderAPI, conf.TestConfig.BroadcastCallback)
+	if err != nil {
 		return errors.Wrap(err, "wire recaster")
 	}
 
-	track, err := newTracker(ctx, life, deadlineFunc, peers, eth2Cl)
+	reloader.Subscribe(reloadBuilderAPI(eth2Cl, fetch, vapi, recaster, valSet))
+
+	if watcher != nil {
+		wireManifestReload(watcher, eth2Cl, valSet, valCache, vapi, recaster, vapi.BuilderEnabled)
+		sched.SubscribeSlots(watcher.SlotTicked)
+	}
+
+	var (
+		coreBroadcaster core.Broadcaster = broadcaster
+		trackerOpts                      = []tracker.Option{duties.TrackerOption()}
+	)
+
+	rootCause := rootcause.New(tcpNode, peers, sender.SendReceive, p2p.RegisterHandler)
+	rootCause.Subscribe(duties.SetRootCause)
+	trackerOpts = append(trackerOpts, tracker.WithAnalysedDutyCallback(rootCause.Observe))
+	if bus != nil {
+		coreBroadcaster = wireDutyEvents(bus, sched, coreConsensus, broadcaster)
+		trackerOpts = append(trackerOpts, failedDutyEvents(bus))
+	}
+
+	slaStore, err := wireSLAReport(life, conf, cluster, p2pKey)
+	if err != nil {
+		return err
+	}
+	trackerOpts = append(trackerOpts, tracker.WithAnalysedDutyCallback(slaStore.Observe))
+
+	track, err := newTracker(ctx, life, deadlineFunc, peers, eth2Cl, conf.ParticipationFile, trackerOpts...)
 	if err != nil {
 		return err
 	}
@@ -572,12 +899,22 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	}
 
 	// Core always uses the "current" consensus that is changed dynamically.
-	opts := []core.WireOption{
+	var opts []core.WireOption
+	if signingPolicy != nil {
+		// Wrapped first, so denied partial signatures are tracked as failures.
+		opts = append(opts, core.WithSigningPolicy(signingPolicy.Filter))
+	}
+
+	// Partial signatures of validators paused via the admin API are also tracked as failures.
+	pauser.SetValidatorsFunc(func() []core.PubKey { return valSet.Load().corePubkeys })
+	opts = append(opts, core.WithSigningPolicy(pauser.Filter))
+
+	opts = append(opts,
 		core.WithTracing(),
 		core.WithTracking(track, inclusion),
 		core.WithAsyncRetry(retryer),
-	}
-	core.Wire(sched, fetch, coreConsensus, dutyDB, vapi, parSigDB, parSigEx, sigAgg, aggSigDB, broadcaster, opts...)
+	)
+	core.Wire(sched, fetch, coreConsensus, dutyDB, vapi, parSigDB, parSigEx, sigAgg, aggSigDB, coreBroadcaster, opts...)
 
 	err = wireValidatorMock(ctx, conf, eth2Cl, pubshares, sched)
 	if err != nil {
@@ -603,8 +940,8 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 // wirePrioritise wires the priority protocol which determines cluster wide priorities for the next epoch.
 func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, tcpNode host.Host,
 	peers []peer.ID, threshold int, sendFunc p2p.SendReceiveFunc, coreCons core.Consensus,
-	sched core.Scheduler, p2pKey *k1.PrivateKey, deadlineFunc func(duty core.Duty) (time.Time, bool),
-	consensusController core.ConsensusController, clusterPreferredProtocol string,
+	sched core.Scheduler, p2pKey k1util.Signer, deadlineFunc func(duty core.Duty) (time.Time, bool),
+	consensusController core.ConsensusController, clusterPreferredProtocol string, peerInfo *peerinfo.PeerInfo,
 ) error {
 	cons, ok := coreCons.(*qbft.Consensus)
 	if !ok {
@@ -627,11 +964,15 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 	// 2. Prioritizing the protocol specified by CLI flag (cluster run) to the top.
 	// In all cases this prioritizes all versions of the protocol identified by name.
 	// The order of all these operations are important.
+	// If the CLI flag protocol is gated by the upgrade target, it is only prioritized once all peers run the target version.
 	allProtocols := Protocols()
 	if clusterPreferredProtocol != "" {
 		allProtocols = protocols.PrioritizeProtocolsByName(clusterPreferredProtocol, allProtocols)
 	}
-	if conf.ConsensusProtocol != "" {
+	var gatedProtocols []protocol.ID
+	if conf.ConsensusProtocol != "" && conf.UpgradeGateConsensus {
+		gatedProtocols = protocols.PrioritizeProtocolsByName(conf.ConsensusProtocol, allProtocols)
+	} else if conf.ConsensusProtocol != "" {
 		allProtocols = protocols.PrioritizeProtocolsByName(conf.ConsensusProtocol, allProtocols)
 	}
 
@@ -639,6 +980,7 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 		version.Supported(),
 		allProtocols,
 		ProposalTypes(conf.BuilderAPI, conf.SyntheticBlockProposals),
+		featureset.Advertised(),
 	)
 
 	// Trigger info syncs in last slot of the epoch (for the next epoch).
@@ -647,6 +989,13 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 			return nil
 		}
 
+		if gatedProtocols != nil && allPeersUpgraded(tcpNode.ID(), peers, peerInfo, conf.UpgradeTarget) {
+			log.Info(ctx, "All peers upgraded, preferring gated consensus protocol",
+				z.Str("protocol", conf.ConsensusProtocol), z.Str("target", conf.UpgradeTarget))
+			isync.SetProtocols(gatedProtocols)
+			gatedProtocols = nil
+		}
+
 		return isync.Trigger(ctx, slot.Slot)
 	})
 
@@ -656,7 +1005,8 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 
 	prio.Subscribe(func(ctx context.Context, _ core.Duty, tr []priority.TopicResult) error {
 		for _, t := range tr {
-			if t.Topic == infosync.TopicProtocol {
+			switch t.Topic {
+			case infosync.TopicProtocol:
 				allProtocols := t.PrioritiesOnly()
 				preferredConsensusProtocol := protocols.MostPreferredConsensusProtocol(allProtocols)
 				preferredConsensusProtocolID := protocol.ID(preferredConsensusProtocol)
@@ -666,8 +1016,17 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 				} else {
 					log.Info(ctx, "Current consensus protocol changed", z.Str("protocol", preferredConsensusProtocol))
 				}
+			case infosync.TopicFeature:
+				var features []featureset.Feature
+				for _, prio := range t.PrioritiesOnly() {
+					features = append(features, featureset.Feature(prio))
+				}
 
-				break
+				activated, deactivated := featureset.SetClusterEnabled(features)
+				if len(activated) > 0 || len(deactivated) > 0 {
+					log.Info(ctx, "Cluster-wide negotiated features changed",
+						z.Any("activated", activated), z.Any("deactivated", deactivated))
+				}
 			}
 		}
 
@@ -684,7 +1043,7 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Scheduler, sigAgg core.SigAgg,
 	broadcaster core.Broadcaster, validators []*manifestpb.Validator, builderAPI bool,
 	callback func(context.Context, core.Duty, core.SignedDataSet) error,
-) error {
+) (*bcast.Recaster, error) {
 	recaster, err := bcast.NewRecaster(func(ctx context.Context) (map[eth2p0.BLSPubKey]struct{}, error) {
 		valList, err := eth2Cl.ActiveValidators(ctx)
 		if err != nil {
@@ -700,7 +1059,7 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 		return ret, nil
 	})
 	if err != nil {
-		return errors.Wrap(err, "recaster init")
+		return nil, errors.Wrap(err, "recaster init")
 	}
 
 	sched.SubscribeSlots(recaster.SlotTicked)
@@ -712,9 +1071,18 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 	}
 
 	if !builderAPI {
-		return nil
+		return recaster, nil
+	}
+
+	if err := storeBuilderRegistrations(ctx, eth2Cl, recaster, validators); err != nil {
+		return nil, err
 	}
 
+	return recaster, nil
+}
+
+// storeBuilderRegistrations stores the pre-generated builder registrations of the validators in the recaster.
+func storeBuilderRegistrations(ctx context.Context, eth2Cl eth2wrap.Client, recaster *bcast.Recaster, validators []*manifestpb.Validator) error {
 	for _, val := range validators {
 		// Check if the current cluster manifest supports pre-generate validator registrations.
 		if len(val.GetBuilderRegistrationJson()) == 0 {
@@ -751,7 +1119,7 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 
 // newTracker creates and starts a new tracker instance.
 func newTracker(ctx context.Context, life *lifecycle.Manager, deadlineFunc func(duty core.Duty) (time.Time, bool),
-	peers []p2p.Peer, eth2Cl eth2wrap.Client,
+	peers []p2p.Peer, eth2Cl eth2wrap.Client, participationFile string, opts ...tracker.Option,
 ) (core.Tracker, error) {
 	eth2Resp, err := eth2Cl.Spec(ctx, &eth2api.SpecOpts{})
 	if err != nil {
@@ -780,7 +1148,13 @@ func newTracker(ctx context.Context, life *lifecycle.Manager, deadlineFunc func(
 		return nil, err
 	}
 
-	track := tracker.New(analyser, deleter, peers, trackFrom)
+	participation, err := tracker.NewParticipationStore(participationFile)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, tracker.WithParticipationStore(participation))
+	track := tracker.New(analyser, deleter, peers, trackFrom, opts...)
 	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartTracker, lifecycle.HookFunc(track.Run))
 
 	return track, nil
@@ -889,6 +1263,14 @@ func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager, cl
 			opts = append(opts, beaconmock.WithDeterministicProposerDuties(dutyFactor))
 		}
 		opts = append(opts, conf.TestConfig.SimnetBMockOpts...)
+		if conf.SimnetBMockScenarioFile != "" {
+			scenario, err := beaconmock.LoadScenario(conf.SimnetBMockScenarioFile)
+			if err != nil {
+				return nil, nil, err
+			}
+			log.Info(ctx, "Beaconmock scenario configured", z.Int("events", len(scenario.Events)))
+			opts = append(opts, beaconmock.WithScenario(scenario)) // Scenario wraps the options above.
+		}
 		bmock, err := beaconmock.New(opts...)
 		if err != nil {
 			return nil, nil, err
@@ -966,26 +1348,55 @@ func configureEth2Client(ctx context.Context, forkVersion []byte, fallbackAddrs
 		}
 	}
 	if !ok {
-		lockNetwork, err := eth2util.ForkVersionToNetwork(forkVersion)
-		if err != nil {
-			return nil, errors.New("cannot parse lock file fork version")
-		}
-
-		bnNetwork, err := eth2util.ForkVersionToNetwork(schedule[0].CurrentVersion[:])
-		if err != nil {
-			return nil, errors.New("cannot parse network current fork version")
-		}
-
 		return nil, errors.New(
 			"mismatch between lock file fork version and beacon node fork schedule. Ensure the beacon node is on the correct network",
-			z.Str("beacon_node", bnNetwork),
-			z.Str("lock_file", lockNetwork),
+			z.Str("beacon_node", networkOrForkVersion(schedule[0].CurrentVersion[:])),
+			z.Str("lock_file", networkOrForkVersion(forkVersion)),
 		)
 	}
 
+	if err := checkDepositContract(ctx, eth2Cl, forkVersion); err != nil {
+		return nil, err
+	}
+
 	return eth2Cl, nil
 }
 
+// networkOrForkVersion returns the name of the network with the fork version, or the fork version in hex
+// if it isn't a known network, e.g. a custom devnet not configured via testnet flags.
+func networkOrForkVersion(forkVersion []byte) string {
+	network, err := eth2util.ForkVersionToNetwork(forkVersion)
+	if err != nil {
+		return fmt.Sprintf("%#x", forkVersion)
+	}
+
+	return network
+}
+
+// checkDepositContract returns an error if the network's deposit contract address is known and doesn't match
+// the beacon node's deposit contract, which identifies custom devnets sharing genesis fork versions.
+func checkDepositContract(ctx context.Context, eth2Cl eth2wrap.Client, forkVersion []byte) error {
+	expected, err := eth2util.ForkVersionToDepositContract(forkVersion)
+	if err != nil || expected == "" {
+		return nil //nolint:nilerr // Deposit contract unknown, nothing to check.
+	}
+
+	eth2Resp, err := eth2Cl.DepositContract(ctx, &eth2api.DepositContractOpts{})
+	if err != nil {
+		return errors.Wrap(err, "fetch deposit contract")
+	}
+
+	actual := fmt.Sprintf("%#x", eth2Resp.Data.Address)
+	if !strings.EqualFold(actual, expected) {
+		return errors.New("mismatch between testnet deposit contract and beacon node deposit contract. Ensure the beacon node is on the correct network",
+			z.Str("beacon_node", actual),
+			z.Str("testnet", expected),
+		)
+	}
+
+	return nil
+}
+
 // createMockValidators creates mock validators identified by their public shares.
 func createMockValidators(pubkeys []eth2p0.BLSPubKey) beaconmock.ValidatorSet {
 	resp := make(beaconmock.ValidatorSet)
@@ -1010,24 +1421,23 @@ func createMockValidators(pubkeys []eth2p0.BLSPubKey) beaconmock.ValidatorSet {
 }
 
 // wireVAPIRouter constructs the validator API router and registers it with the life cycle manager.
-func wireVAPIRouter(ctx context.Context, life *lifecycle.Manager, vapiAddr string, eth2Cl eth2wrap.Client,
-	handler validatorapi.Handler, vapiCalls func(), builderEnabled bool,
+func wireVAPIRouter(ctx context.Context, life *lifecycle.Manager, vapiAddr string, auth httpauth.Config, eth2Cl eth2wrap.Client,
+	handler validatorapi.Handler, vapiCalls func(), builderEnabled func() bool,
 ) error {
 	vrouter, err := validatorapi.NewRouter(ctx, handler, eth2Cl, builderEnabled)
 	if err != nil {
 		return errors.Wrap(err, "new monitoring server")
 	}
 
-	server := &http.Server{
-		Addr: vapiAddr,
-		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			vapiCalls()
-			vrouter.ServeHTTP(w, r)
-		}),
-		ReadHeaderTimeout: time.Second,
+	server, serve, err := newHTTPServer(vapiAddr, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vapiCalls()
+		vrouter.ServeHTTP(w, r)
+	}), auth)
+	if err != nil {
+		return err
 	}
 
-	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartValidatorAPI, httpServeHook(server.ListenAndServe))
+	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartValidatorAPI, serve)
 	life.RegisterStop(lifecycle.StopValidatorAPI, lifecycle.HookFunc(server.Shutdown))
 
 	return nil
@@ -1035,15 +1445,21 @@ func wireVAPIRouter(ctx context.Context, life *lifecycle.Manager, vapiAddr strin
 
 // wireTracing constructs the global tracer and registers it with the life cycle manager.
 func wireTracing(life *lifecycle.Manager, conf Config) error {
-	stopjaeger, err := tracer.Init(
+	service := conf.JaegerService
+	if conf.OTLPAddr != "" {
+		service = conf.OTLPServiceName
+	}
+
+	stopTracing, err := tracer.Init(
 		tracer.WithJaegerOrNoop(conf.JaegerAddr),
-		tracer.WithJaegerService(conf.JaegerService),
+		tracer.WithOTLPOrNoop(conf.OTLPAddr),
+		tracer.WithServiceName(service),
 	)
 	if err != nil {
-		return errors.Wrap(err, "init jaeger tracing")
+		return errors.Wrap(err, "init tracing")
 	}
 
-	life.RegisterStop(lifecycle.StopTracing, lifecycle.HookFunc(stopjaeger))
+	life.RegisterStop(lifecycle.StopTracing, lifecycle.HookFunc(stopTracing))
 
 	return nil
 }
@@ -1133,6 +1549,7 @@ func Protocols() []protocol.ID {
 	resp = append(resp, parsigex.Protocols()...)
 	resp = append(resp, peerinfo.Protocols()...)
 	resp = append(resp, priority.Protocols()...)
+	resp = append(resp, rootcause.Protocols()...)
 
 	return resp
 }
@@ -0,0 +1,399 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package backup creates and restores passphrase-encrypted backups of a node's cluster artifacts:
+// the key shares, cluster lock and manifest, ENR private key and an optional slashing protection history.
+//
+// A backup is a gzipped tar archive containing the files and an integrity manifest of their SHA256 hashes,
+// encrypted with an EIP-2335 keystore encryptor and wrapped in a JSON envelope.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/memlock"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+)
+
+const (
+	// formatVersion is the backup envelope and manifest format version.
+	formatVersion = "v1"
+	// manifestFile is the name of the integrity manifest in the archive.
+	manifestFile = "backup-manifest.json"
+	// SlashingProtectionFile is the name of the slashing protection history in the archive and restored data dir.
+	SlashingProtectionFile = "slashing-protection.json"
+	// MinPassphraseLen is the minimum length of backup passphrases.
+	MinPassphraseLen = 12
+
+	enrPrivateKeyFile = "charon-enr-private-key"
+	lockFile          = "cluster-lock.json"
+	clusterManifest   = "cluster-manifest.pb"
+	validatorKeysDir  = "validator_keys"
+)
+
+// envelope is the JSON format of the encrypted backup file.
+type envelope struct {
+	Version   string         `json:"version"`
+	CreatedAt time.Time      `json:"created_at"`
+	Crypto    map[string]any `json:"crypto"`
+}
+
+// Manifest is the integrity manifest of a backup.
+type Manifest struct {
+	Version       string    `json:"version"`
+	CharonVersion string    `json:"charon_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	Files         []File    `json:"files"`
+}
+
+// File is a file in the backup.
+type File struct {
+	Path   string `json:"path"`
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+	// Secret is true if the file contains private key material.
+	Secret bool `json:"secret"`
+}
+
+// Create returns a passphrase-encrypted backup of the cluster artifacts in the data dir and
+// the optional EIP-3076 slashing protection interchange file.
+func Create(dataDir string, slashingFile string, passphrase string, opts keystore.Options) ([]byte, Manifest, error) {
+	if err := validatePassphrase(passphrase); err != nil {
+		return nil, Manifest{}, err
+	} else if err := opts.Validate(); err != nil {
+		return nil, Manifest{}, err
+	}
+
+	files, err := collect(dataDir, slashingFile)
+	if err != nil {
+		return nil, Manifest{}, err
+	}
+	defer func() {
+		for _, content := range files {
+			memlock.Zero(content)
+		}
+	}()
+
+	manifest := Manifest{
+		Version:       formatVersion,
+		CharonVersion: version.Version.String(),
+		CreatedAt:     time.Now().UTC().Truncate(time.Second),
+	}
+	for _, name := range sortedKeys(files) {
+		manifest.Files = append(manifest.Files, File{
+			Path:   name,
+			Size:   len(files[name]),
+			SHA256: hashHex(files[name]),
+			Secret: isSecret(name),
+		})
+	}
+
+	archive, err := writeArchive(manifest, files)
+	if err != nil {
+		return nil, Manifest{}, err
+	}
+	defer memlock.Zero(archive)
+
+	crypto, err := keystorev4.New(opts.EncryptorOptions()...).Encrypt(archive, passphrase)
+	if err != nil {
+		return nil, Manifest{}, errors.Wrap(err, "encrypt backup")
+	}
+
+	b, err := json.MarshalIndent(envelope{
+		Version:   formatVersion,
+		CreatedAt: manifest.CreatedAt,
+		Crypto:    crypto,
+	}, "", " ")
+	if err != nil {
+		return nil, Manifest{}, errors.Wrap(err, "marshal backup")
+	}
+
+	return b, manifest, nil
+}
+
+// Restore decrypts the backup, verifies its integrity manifest and writes its files to the data dir.
+// It refuses to overwrite any existing file.
+func Restore(backup []byte, passphrase string, dataDir string) (Manifest, error) {
+	var env envelope
+	if err := json.Unmarshal(backup, &env); err != nil {
+		return Manifest{}, errors.Wrap(err, "unmarshal backup")
+	} else if env.Version != formatVersion {
+		return Manifest{}, errors.New("unsupported backup version", z.Str("version", env.Version))
+	}
+
+	archive, err := keystorev4.New().Decrypt(env.Crypto, passphrase)
+	if err != nil {
+		return Manifest{}, errors.Wrap(err, "decrypt backup, invalid passphrase or corrupted backup")
+	}
+	defer memlock.Zero(archive)
+
+	manifest, files, err := readArchive(archive)
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer func() {
+		for _, content := range files {
+			memlock.Zero(content)
+		}
+	}()
+
+	if err := verify(manifest, files); err != nil {
+		return Manifest{}, err
+	}
+
+	for _, file := range manifest.Files {
+		target := filepath.Join(dataDir, filepath.FromSlash(file.Path))
+		if _, err := os.Stat(target); err == nil {
+			return Manifest{}, errors.New("refusing to overwrite existing file", z.Str("file", target))
+		} else if !os.IsNotExist(err) {
+			return Manifest{}, errors.Wrap(err, "stat file", z.Str("file", target))
+		}
+	}
+
+	for _, file := range manifest.Files {
+		target := filepath.Join(dataDir, filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return Manifest{}, errors.Wrap(err, "create directory", z.Str("dir", filepath.Dir(target)))
+		}
+
+		perm := os.FileMode(0o644)
+		if file.Secret {
+			perm = 0o400
+		}
+
+		if err := os.WriteFile(target, files[file.Path], perm); err != nil {
+			return Manifest{}, errors.Wrap(err, "write file", z.Str("file", target))
+		}
+	}
+
+	return manifest, nil
+}
+
+// collect returns the contents of the backed up files by archive path.
+func collect(dataDir string, slashingFile string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	add := func(name string, file string, required bool) error {
+		b, err := os.ReadFile(file)
+		if os.IsNotExist(err) && !required {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "read file", z.Str("file", file))
+		}
+
+		files[name] = b
+
+		return nil
+	}
+
+	if err := add(enrPrivateKeyFile, filepath.Join(dataDir, enrPrivateKeyFile), true); err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{lockFile, clusterManifest} {
+		if err := add(name, filepath.Join(dataDir, name), false); err != nil {
+			return nil, err
+		}
+	}
+
+	if files[lockFile] == nil && files[clusterManifest] == nil {
+		return nil, errors.New("neither cluster lock nor cluster manifest found", z.Str("data_dir", dataDir))
+	}
+
+	keyFiles, err := filepath.Glob(filepath.Join(dataDir, validatorKeysDir, "keystore-*"))
+	if err != nil {
+		return nil, errors.Wrap(err, "read validator keys directory")
+	} else if len(keyFiles) == 0 {
+		return nil, errors.New("no key shares found", z.Str("dir", filepath.Join(dataDir, validatorKeysDir)))
+	}
+
+	for _, file := range keyFiles {
+		if err := add(path.Join(validatorKeysDir, filepath.Base(file)), file, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if slashingFile != "" {
+		if err := add(SlashingProtectionFile, slashingFile, true); err != nil {
+			return nil, err
+		} else if !json.Valid(files[SlashingProtectionFile]) {
+			return nil, errors.New("invalid slashing protection interchange file, not valid json", z.Str("file", slashingFile))
+		}
+	}
+
+	return files, nil
+}
+
+// writeArchive returns a gzipped tar archive of the manifest and files.
+func writeArchive(manifest Manifest, files map[string][]byte) ([]byte, error) {
+	manifestJSON, err := json.MarshalIndent(manifest, "", " ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal manifest")
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	write := func(name string, content []byte) error {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0o600,
+			Size:    int64(len(content)),
+			ModTime: manifest.CreatedAt,
+		})
+		if err != nil {
+			return errors.Wrap(err, "write archive header")
+		}
+
+		if _, err := tw.Write(content); err != nil {
+			return errors.Wrap(err, "write archive file")
+		}
+
+		return nil
+	}
+
+	if err := write(manifestFile, manifestJSON); err != nil {
+		return nil, err
+	}
+
+	for _, file := range manifest.Files {
+		if err := write(file.Path, files[file.Path]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "close archive")
+	} else if err := gw.Close(); err != nil {
+		return nil, errors.Wrap(err, "close gzip")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// readArchive returns the manifest and files of the gzipped tar archive.
+func readArchive(archive []byte) (Manifest, map[string][]byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return Manifest{}, nil, errors.Wrap(err, "read gzip")
+	}
+
+	var (
+		manifest     Manifest
+		manifestRead bool
+		files        = make(map[string][]byte)
+		tr           = tar.NewReader(gr)
+	)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return Manifest{}, nil, errors.Wrap(err, "read archive")
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return Manifest{}, nil, errors.Wrap(err, "read archive file", z.Str("file", header.Name))
+		}
+
+		if header.Name == manifestFile {
+			if err := json.Unmarshal(content, &manifest); err != nil {
+				return Manifest{}, nil, errors.Wrap(err, "unmarshal manifest")
+			}
+			manifestRead = true
+
+			continue
+		}
+
+		if _, ok := files[header.Name]; ok {
+			return Manifest{}, nil, errors.New("duplicate file in archive", z.Str("file", header.Name))
+		}
+
+		files[header.Name] = content
+	}
+
+	if !manifestRead {
+		return Manifest{}, nil, errors.New("backup manifest not found in archive")
+	}
+
+	return manifest, files, nil
+}
+
+// verify returns an error if the files don't exactly match the manifest.
+func verify(manifest Manifest, files map[string][]byte) error {
+	if manifest.Version != formatVersion {
+		return errors.New("unsupported backup manifest version", z.Str("version", manifest.Version))
+	}
+
+	for _, file := range manifest.Files {
+		if !validPath(file.Path) {
+			return errors.New("invalid file path in backup manifest", z.Str("file", file.Path))
+		}
+
+		content, ok := files[file.Path]
+		if !ok {
+			return errors.New("file in backup manifest missing from archive", z.Str("file", file.Path))
+		} else if len(content) != file.Size || hashHex(content) != file.SHA256 {
+			return errors.New("file integrity check failed", z.Str("file", file.Path))
+		}
+	}
+
+	if len(files) != len(manifest.Files) {
+		return errors.New("archive contains files not in backup manifest",
+			z.Int("files", len(files)), z.Int("manifest_files", len(manifest.Files)))
+	}
+
+	return nil
+}
+
+// validPath returns true if the archive path is a clean relative path within the data dir.
+func validPath(name string) bool {
+	return name != "" && name == path.Clean(name) && !path.IsAbs(name) &&
+		name != ".." && !strings.HasPrefix(name, "../")
+}
+
+// isSecret returns true if the archive file contains private key material.
+func isSecret(name string) bool {
+	return name == enrPrivateKeyFile || strings.HasPrefix(name, validatorKeysDir+"/")
+}
+
+func validatePassphrase(passphrase string) error {
+	if len(passphrase) < MinPassphraseLen {
+		return errors.New("backup passphrase too short", z.Int("min_length", MinPassphraseLen))
+	}
+
+	return nil
+}
+
+func hashHex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func sortedKeys(files map[string][]byte) []string {
+	var resp []string
+	for name := range files {
+		resp = append(resp, name)
+	}
+	sort.Strings(resp)
+
+	return resp
+}
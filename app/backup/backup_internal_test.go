@@ -0,0 +1,126 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package backup
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/keystore"
+)
+
+const testPassphrase = "correct horse battery staple"
+
+var testOpts = keystore.Options{KDF: keystore.KDFPbkdf2, CostPower: 10}
+
+func TestRoundTrip(t *testing.T) {
+	dataDir, slashingFile := newDataDir(t)
+
+	b, manifest, err := Create(dataDir, slashingFile, testPassphrase, testOpts)
+	require.NoError(t, err)
+	require.Len(t, manifest.Files, 6)
+
+	restoreDir := t.TempDir()
+	restored, err := Restore(b, testPassphrase, restoreDir)
+	require.NoError(t, err)
+	require.Equal(t, manifest, restored)
+
+	for _, file := range manifest.Files {
+		want := filepath.Join(dataDir, filepath.FromSlash(file.Path))
+		if file.Path == SlashingProtectionFile {
+			want = slashingFile
+		}
+
+		expect, err := os.ReadFile(want)
+		require.NoError(t, err)
+		actual, err := os.ReadFile(filepath.Join(restoreDir, filepath.FromSlash(file.Path)))
+		require.NoError(t, err)
+		require.Equal(t, expect, actual, file.Path)
+
+		info, err := os.Stat(filepath.Join(restoreDir, filepath.FromSlash(file.Path)))
+		require.NoError(t, err)
+		require.Equal(t, file.Secret, info.Mode().Perm() == 0o400, file.Path)
+	}
+
+	// Restoring again doesn't overwrite existing files.
+	_, err = Restore(b, testPassphrase, restoreDir)
+	require.ErrorContains(t, err, "refusing to overwrite existing file")
+}
+
+func TestInvalid(t *testing.T) {
+	dataDir, _ := newDataDir(t)
+
+	_, _, err := Create(dataDir, "", "short", testOpts)
+	require.ErrorContains(t, err, "backup passphrase too short")
+
+	_, _, err = Create(t.TempDir(), "", testPassphrase, testOpts)
+	require.ErrorContains(t, err, "read file")
+
+	b, _, err := Create(dataDir, "", testPassphrase, testOpts)
+	require.NoError(t, err)
+
+	_, err = Restore(b, "wrong passphrase!", t.TempDir())
+	require.ErrorContains(t, err, "decrypt backup")
+
+	// Tamper with the ciphertext.
+	var env envelope
+	require.NoError(t, json.Unmarshal(b, &env))
+	cipher := env.Crypto["cipher"].(map[string]any)
+	message := []byte(cipher["message"].(string))
+	message[0] ^= 0x01
+	cipher["message"] = string(message)
+	tampered, err := json.Marshal(env)
+	require.NoError(t, err)
+
+	_, err = Restore(tampered, testPassphrase, t.TempDir())
+	require.ErrorContains(t, err, "decrypt backup")
+}
+
+func TestVerify(t *testing.T) {
+	files := map[string][]byte{"cluster-lock.json": []byte("lock")}
+	manifest := Manifest{
+		Version: formatVersion,
+		Files:   []File{{Path: "cluster-lock.json", Size: 4, SHA256: hashHex([]byte("lock"))}},
+	}
+	require.NoError(t, verify(manifest, files))
+
+	files["cluster-lock.json"] = []byte("lick")
+	require.ErrorContains(t, verify(manifest, files), "file integrity check failed")
+
+	files["cluster-lock.json"] = []byte("lock")
+	files["extra"] = []byte("extra")
+	require.ErrorContains(t, verify(manifest, files), "archive contains files not in backup manifest")
+
+	for _, name := range []string{"../charon-enr-private-key", "/etc/passwd", "a/../../b", ""} {
+		manifest.Files[0].Path = name
+		require.ErrorContains(t, verify(manifest, files), "invalid file path", name)
+	}
+}
+
+// newDataDir returns a data dir with the backed up files and a slashing protection file.
+func newDataDir(t *testing.T) (string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, validatorKeysDir), 0o755))
+
+	for name, content := range map[string]string{
+		enrPrivateKeyFile:                  "enr key",
+		lockFile:                           `{"lock": true}`,
+		"validator_keys/keystore-0.json":   `{"keystore": 0}`,
+		"validator_keys/keystore-0.txt":    "password 0",
+		"validator_keys/keystore-1.json":   `{"keystore": 1}`,
+		"validator_keys/not-a-keystore.md": "ignored",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), []byte(content), 0o600))
+	}
+
+	slashingFile := filepath.Join(t.TempDir(), "interchange.json")
+	require.NoError(t, os.WriteFile(slashingFile, []byte(`{"metadata": {}, "data": []}`), 0o600))
+
+	return dir, slashingFile
+}
@@ -0,0 +1,163 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/depositwatch"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/peerinfo"
+	pbv1 "github.com/obolnetwork/charon/app/peerinfo/peerinfopb/v1"
+	"github.com/obolnetwork/charon/app/vallifecycle"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// ClusterStatus is the response of the monitoring API cluster status endpoint.
+type ClusterStatus struct {
+	Nodes []NodeStatus `json:"nodes"`
+	// Deposits is the activation progress of the cluster validators, nil if deposit watching is disabled.
+	Deposits *depositwatch.Report `json:"deposits,omitempty"`
+	// Validators are the lifecycle states of the cluster validators.
+	Validators []vallifecycle.Validator `json:"validators,omitempty"`
+}
+
+// NodeStatus is the status of a single node in the cluster as known by the queried node.
+type NodeStatus struct {
+	Peer      string `json:"peer"`
+	Nickname  string `json:"nickname"`
+	Self      bool   `json:"self"`
+	Connected bool   `json:"connected"`
+	Version   string `json:"version"`
+	// Reported is false if the node didn't share its status (yet), in which case the fields below are empty.
+	Reported        bool      `json:"reported"`
+	ReadyError      string    `json:"ready_error"`
+	BeaconSynced    bool      `json:"beacon_synced"`
+	Validators      int       `json:"validators"`
+	DutiesSucceeded uint64    `json:"duties_succeeded"`
+	DutiesFailed    uint64    `json:"duties_failed"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// newClusterStatusHandler returns a handler serving the status of all nodes in the cluster, including this node's
+// status and the latest statuses shared by its peers via the peerinfo protocol, as well as the activation progress
+// of the cluster validators if deposits is not nil and their lifecycle states if valLifecycle is not nil.
+func newClusterStatusHandler(tcpNode host.Host, peerIDs []peer.ID, peerInfo *peerinfo.PeerInfo,
+	deposits *depositwatch.Watcher, valLifecycle *vallifecycle.Tracker,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		received := peerInfo.Received()
+
+		var resp ClusterStatus
+		for _, pID := range peerIDs {
+			info, updatedAt := received[pID].Info, received[pID].ReceivedAt
+			self := pID == tcpNode.ID()
+			if self {
+				info, updatedAt = peerInfo.Local(), time.Now()
+			}
+
+			resp.Nodes = append(resp.Nodes, nodeStatus(pID, info, updatedAt, self,
+				self || tcpNode.Network().Connectedness(pID) == network.Connected))
+		}
+
+		if deposits != nil {
+			report := deposits.Report()
+			resp.Deposits = &report
+		}
+
+		if valLifecycle != nil {
+			resp.Validators = valLifecycle.Validators()
+		}
+
+		b, err := json.Marshal(resp)
+		if err != nil {
+			writeResponse(w, http.StatusInternalServerError, errors.Wrap(err, "marshal cluster status").Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	}
+}
+
+// nodeStatus returns the status of the node from its latest peer info, which may be nil.
+func nodeStatus(pID peer.ID, info *pbv1.PeerInfo, updatedAt time.Time, self, connected bool) NodeStatus {
+	resp := NodeStatus{
+		Peer:      p2p.PeerName(pID),
+		Self:      self,
+		Connected: connected,
+	}
+
+	if info == nil {
+		return resp
+	}
+
+	resp.Nickname = info.GetNickname()
+	resp.Version = info.GetCharonVersion()
+	resp.UpdatedAt = updatedAt
+
+	if status := info.GetStatus(); status != nil {
+		resp.Reported = true
+		resp.ReadyError = status.GetReadyError()
+		resp.BeaconSynced = status.GetBeaconSynced()
+		resp.Validators = int(status.GetValidators())
+		resp.DutiesSucceeded = status.GetDutiesSucceeded()
+		resp.DutiesFailed = status.GetDutiesFailed()
+	}
+
+	return resp
+}
+
+// newLocalStatusFunc returns a function returning this node's status shared with its peers.
+func newLocalStatusFunc(readyErrFunc func() error, gatherer prometheus.Gatherer, numValidators int) func() *pbv1.NodeStatus {
+	return func() *pbv1.NodeStatus {
+		readyErr := readyErrFunc()
+
+		var readyErrStr string
+		if readyErr != nil {
+			readyErrStr = readyErr.Error()
+		}
+
+		// Beacon node checks precede other ready checks, so any other error implies a synced beacon node.
+		beaconSynced := !errors.Is(readyErr, errReadyUninitialised) &&
+			!errors.Is(readyErr, errReadyBeaconNodeDown) &&
+			!errors.Is(readyErr, errReadyBeaconNodeSyncing) &&
+			!errors.Is(readyErr, errReadyBeaconNodeFarBehind)
+
+		return &pbv1.NodeStatus{
+			ReadyError:      readyErrStr,
+			BeaconSynced:    beaconSynced,
+			Validators:      int32(numValidators),
+			DutiesSucceeded: sumCounters(gatherer, "core_tracker_success_duties_total"),
+			DutiesFailed:    sumCounters(gatherer, "core_tracker_failed_duties_total"),
+		}
+	}
+}
+
+// sumCounters returns the sum of all series of the named counter, or zero if it can't be gathered.
+func sumCounters(gatherer prometheus.Gatherer, name string) uint64 {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return 0
+	}
+
+	var sum float64
+	for _, fam := range families {
+		if fam.GetName() != name {
+			continue
+		}
+
+		for _, metric := range fam.GetMetric() {
+			sum += metric.GetCounter().GetValue()
+		}
+	}
+
+	return uint64(sum)
+}
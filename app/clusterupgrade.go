@@ -0,0 +1,237 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/peerinfo"
+	pbv1 "github.com/obolnetwork/charon/app/peerinfo/peerinfopb/v1"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// upgradeReportPeriod is the period the cluster upgrade readiness is checked and reported.
+const upgradeReportPeriod = time.Minute
+
+// UpgradeStatus is the response of the monitoring API cluster upgrade endpoint.
+type UpgradeStatus struct {
+	// Target is the charon version the cluster upgrades to, empty if no operator signalled an upgrade.
+	Target string `json:"target"`
+	// Quorum is the number of nodes that must be ready for the cluster to upgrade.
+	Quorum int `json:"quorum"`
+	// Ready is the number of nodes that signalled the target version or already run it.
+	Ready int `json:"ready"`
+	// Upgraded is the number of nodes that already run the target version.
+	Upgraded    int           `json:"upgraded"`
+	QuorumReady bool          `json:"quorum_ready"`
+	AllUpgraded bool          `json:"all_upgraded"`
+	Nodes       []NodeUpgrade `json:"nodes"`
+}
+
+// NodeUpgrade is the upgrade status of a single node in the cluster as known by the queried node.
+type NodeUpgrade struct {
+	Peer     string `json:"peer"`
+	Nickname string `json:"nickname"`
+	Self     bool   `json:"self"`
+	// Reported is false if the node didn't share its peer info (yet), in which case the fields below are empty.
+	Reported bool   `json:"reported"`
+	Version  string `json:"version"`
+	Target   string `json:"target"`
+	Ready    bool   `json:"ready"`
+	Upgraded bool   `json:"upgraded"`
+}
+
+// newClusterUpgradeHandler returns a handler serving the cluster's readiness to upgrade to the target version.
+// The target defaults to this node's upgrade target, or the highest target signalled by any node, and can be
+// overridden by the "target" query parameter.
+func newClusterUpgradeHandler(tcpNode host.Host, peerIDs []peer.ID, peerInfo *peerinfo.PeerInfo) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target := r.URL.Query().Get("target")
+		if target == "" {
+			target = peerInfo.Local().GetUpgradeTarget()
+		}
+
+		resp, err := clusterUpgradeStatus(target, peerIDs, tcpNode.ID(), latestPeerInfos(tcpNode.ID(), peerInfo))
+		if err != nil {
+			writeResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		b, err := json.Marshal(resp)
+		if err != nil {
+			writeResponse(w, http.StatusInternalServerError, errors.Wrap(err, "marshal upgrade status").Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	}
+}
+
+// latestPeerInfos returns this node's own peer info and the latest peer info received from each peer.
+func latestPeerInfos(self peer.ID, peerInfo *peerinfo.PeerInfo) map[peer.ID]*pbv1.PeerInfo {
+	resp := map[peer.ID]*pbv1.PeerInfo{self: peerInfo.Local()}
+	for pID, received := range peerInfo.Received() {
+		if pID != self {
+			resp[pID] = received.Info
+		}
+	}
+
+	return resp
+}
+
+// clusterUpgradeStatus returns the cluster's readiness to upgrade to the target version given the latest peer info
+// of each node. If the target is empty, the highest version signalled by any node is used.
+// A node is ready if it signalled the target (or a later) version, or if it already runs it.
+func clusterUpgradeStatus(target string, peerIDs []peer.ID, self peer.ID, infos map[peer.ID]*pbv1.PeerInfo) (UpgradeStatus, error) {
+	if target == "" {
+		target = highestUpgradeTarget(infos)
+	}
+
+	resp := UpgradeStatus{
+		Target: target,
+		Quorum: cluster.Threshold(len(peerIDs)),
+	}
+
+	var targetVersion version.SemVer
+	if target != "" {
+		var err error
+		targetVersion, err = version.Parse(target)
+		if err != nil {
+			return UpgradeStatus{}, errors.Wrap(err, "invalid upgrade target")
+		}
+	}
+
+	for _, pID := range peerIDs {
+		node := NodeUpgrade{
+			Peer: p2p.PeerName(pID),
+			Self: pID == self,
+		}
+
+		info, ok := infos[pID]
+		if ok && info != nil {
+			node.Reported = true
+			node.Nickname = info.GetNickname()
+			node.Version = info.GetCharonVersion()
+			node.Target = info.GetUpgradeTarget()
+
+			if target != "" {
+				node.Upgraded = atLeastVersion(node.Version, targetVersion)
+				node.Ready = node.Upgraded || atLeastVersion(node.Target, targetVersion)
+			}
+		}
+
+		if node.Ready {
+			resp.Ready++
+		}
+		if node.Upgraded {
+			resp.Upgraded++
+		}
+
+		resp.Nodes = append(resp.Nodes, node)
+	}
+
+	if target != "" {
+		resp.QuorumReady = resp.Ready >= resp.Quorum
+		resp.AllUpgraded = resp.Upgraded == len(peerIDs)
+	}
+
+	return resp, nil
+}
+
+// allPeersUpgraded returns true if all nodes in the cluster run at least the target version.
+func allPeersUpgraded(self peer.ID, peerIDs []peer.ID, peerInfo *peerinfo.PeerInfo, target string) bool {
+	status, err := clusterUpgradeStatus(target, peerIDs, self, latestPeerInfos(self, peerInfo))
+	if err != nil {
+		return false
+	}
+
+	return status.AllUpgraded
+}
+
+// highestUpgradeTarget returns the highest valid upgrade target signalled by any node, or empty if none.
+func highestUpgradeTarget(infos map[peer.ID]*pbv1.PeerInfo) string {
+	var (
+		resp    string
+		highest version.SemVer
+	)
+	for _, info := range infos {
+		target, err := version.Parse(info.GetUpgradeTarget())
+		if err != nil {
+			continue
+		}
+
+		if resp == "" || version.Compare(target, highest) > 0 {
+			resp, highest = info.GetUpgradeTarget(), target
+		}
+	}
+
+	return resp
+}
+
+// atLeastVersion returns true if the version string is a valid version not lower than the target.
+func atLeastVersion(v string, target version.SemVer) bool {
+	semver, err := version.Parse(v)
+	if err != nil {
+		return false
+	}
+
+	return version.Compare(semver, target) >= 0
+}
+
+// reportUpgradeReadiness periodically logs when a quorum of the cluster becomes ready to upgrade to the signalled
+// target version and when all nodes have upgraded, until the context is cancelled.
+func reportUpgradeReadiness(ctx context.Context, tcpNode host.Host, peerIDs []peer.ID, peerInfo *peerinfo.PeerInfo) {
+	ctx = log.WithTopic(ctx, "upgrade")
+
+	ticker := time.NewTicker(upgradeReportPeriod)
+	defer ticker.Stop()
+
+	var prev UpgradeStatus
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		status, err := clusterUpgradeStatus(peerInfo.Local().GetUpgradeTarget(), peerIDs, tcpNode.ID(),
+			latestPeerInfos(tcpNode.ID(), peerInfo))
+		if err != nil {
+			log.Warn(ctx, "Invalid upgrade target", err)
+			continue
+		} else if status.Target == "" {
+			continue
+		}
+
+		targetChanged := status.Target != prev.Target
+		fields := []z.Field{
+			z.Str("target", status.Target),
+			z.Int("ready", status.Ready),
+			z.Int("upgraded", status.Upgraded),
+			z.Int("quorum", status.Quorum),
+		}
+
+		switch {
+		case status.AllUpgraded && (targetChanged || !prev.AllUpgraded):
+			log.Info(ctx, "All nodes upgraded to target version", fields...)
+		case status.QuorumReady && (targetChanged || !prev.QuorumReady):
+			log.Info(ctx, "Cluster quorum ready to upgrade", fields...)
+		case !status.QuorumReady && (targetChanged || prev.QuorumReady):
+			log.Info(ctx, "Waiting for cluster quorum to signal upgrade", fields...)
+		}
+
+		prev = status
+	}
+}
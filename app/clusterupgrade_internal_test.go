@@ -0,0 +1,101 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	pbv1 "github.com/obolnetwork/charon/app/peerinfo/peerinfopb/v1"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestClusterUpgradeStatus(t *testing.T) {
+	var peerIDs []peer.ID
+	for i := range 4 {
+		pID, err := p2p.PeerIDFromKey(testutil.GenerateInsecureK1Key(t, i).PubKey())
+		require.NoError(t, err)
+		peerIDs = append(peerIDs, pID)
+	}
+
+	info := func(version, target string) *pbv1.PeerInfo {
+		return &pbv1.PeerInfo{CharonVersion: version, UpgradeTarget: target}
+	}
+
+	tests := []struct {
+		name        string
+		target      string
+		infos       []*pbv1.PeerInfo
+		expTarget   string
+		ready       int
+		upgraded    int
+		quorumReady bool
+		allUpgraded bool
+		err         string
+	}{
+		{
+			name:  "no target",
+			infos: []*pbv1.PeerInfo{info("v1.2.0", ""), info("v1.2.0", ""), nil, nil},
+		},
+		{
+			name:      "signalled target",
+			infos:     []*pbv1.PeerInfo{info("v1.2.0", "v1.3"), info("v1.2.0", "v1.3"), info("v1.2.0", "v1.4"), nil},
+			expTarget: "v1.4",
+			ready:     1,
+		},
+		{
+			name:        "quorum ready",
+			target:      "v1.3",
+			infos:       []*pbv1.PeerInfo{info("v1.2.0", "v1.3"), info("v1.3.0", ""), info("v1.2.0", "v1.3.1"), nil},
+			expTarget:   "v1.3",
+			ready:       3,
+			upgraded:    1,
+			quorumReady: true,
+		},
+		{
+			name:        "all upgraded",
+			target:      "v1.3",
+			infos:       []*pbv1.PeerInfo{info("v1.3.0", ""), info("v1.3.1", ""), info("v1.4.0", ""), info("v1.3-rc1", "")},
+			expTarget:   "v1.3",
+			ready:       4,
+			upgraded:    4,
+			quorumReady: true,
+			allUpgraded: true,
+		},
+		{
+			name:   "invalid target",
+			target: "latest",
+			err:    "invalid upgrade target",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			infos := make(map[peer.ID]*pbv1.PeerInfo)
+			for i, info := range test.infos {
+				if info != nil {
+					infos[peerIDs[i]] = info
+				}
+			}
+
+			status, err := clusterUpgradeStatus(test.target, peerIDs, peerIDs[0], infos)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, test.expTarget, status.Target)
+			require.Equal(t, 3, status.Quorum)
+			require.Equal(t, test.ready, status.Ready)
+			require.Equal(t, test.upgraded, status.Upgraded)
+			require.Equal(t, test.quorumReady, status.QuorumReady)
+			require.Equal(t, test.allUpgraded, status.AllUpgraded)
+			require.Len(t, status.Nodes, len(peerIDs))
+			require.True(t, status.Nodes[0].Self)
+		})
+	}
+}
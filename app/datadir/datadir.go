@@ -0,0 +1,167 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package datadir versions the layout and file formats of the charon data directory and performs
+// forward migrations between schema versions.
+//
+// The schema version is stored in the data directory's version file. Data directories without a version file
+// have the initial legacy schema version 1. Charon refuses to run on data directories with a newer schema
+// version than it supports, since older versions may silently corrupt files in formats they don't know,
+// e.g. after a downgrade.
+package datadir
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// VersionFile is the name of the schema version file in the data directory.
+const VersionFile = "data-dir-version.json"
+
+// legacyVersion is the schema version of data directories without a version file.
+const legacyVersion = 1
+
+// Migration migrates a data directory from schema version Version-1 to Version.
+type Migration struct {
+	Version     int
+	Description string
+	Migrate     func(ctx context.Context, dir string) error
+}
+
+// versionJSON is the JSON format of the version file.
+type versionJSON struct {
+	Version       int       `json:"version"`
+	CharonVersion string    `json:"charon_version"`
+	MigratedAt    time.Time `json:"migrated_at"`
+}
+
+// migrations are the forward migrations ordered by version. Once released, migrations are never removed or
+// reordered, since data directories may already be stamped with their versions.
+var migrations = []Migration{
+	{
+		Version:     2,
+		Description: "Create the cluster manifest from the legacy cluster lock",
+		Migrate:     migrateManifest,
+	},
+}
+
+// CurrentVersion returns the latest schema version supported by this charon version.
+func CurrentVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// Version returns the schema version of the data directory.
+func Version(dir string) (int, error) {
+	b, err := os.ReadFile(filepath.Join(dir, VersionFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return legacyVersion, nil
+	} else if err != nil {
+		return 0, errors.Wrap(err, "read data dir version file", z.Str("dir", dir))
+	}
+
+	var v versionJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return 0, errors.Wrap(err, "unmarshal data dir version file", z.Str("dir", dir))
+	} else if v.Version < legacyVersion {
+		return 0, errors.New("invalid data dir version", z.Int("version", v.Version), z.Str("dir", dir))
+	}
+
+	return v.Version, nil
+}
+
+// Init stamps a newly created data directory with the current schema version, since it doesn't require migration.
+func Init(dir string) error {
+	return writeVersion(dir, CurrentVersion())
+}
+
+// Check returns an error if the data directory's schema version is newer than supported.
+// It logs a warning if the data directory requires migration.
+func Check(ctx context.Context, dir string) error {
+	v, err := Version(dir)
+	if err != nil {
+		return err
+	}
+
+	if v > CurrentVersion() {
+		return errors.New("data dir schema version newer than supported, refusing to run to prevent corrupting it; "+
+			"upgrade charon or restore a backup of the data dir",
+			z.Int("version", v), z.Int("supported", CurrentVersion()), z.Str("dir", dir))
+	} else if v < CurrentVersion() {
+		log.Warn(ctx, "Data dir schema version outdated, migrate it with 'charon migrate'", nil,
+			z.Int("version", v), z.Int("latest", CurrentVersion()), z.Str("dir", dir))
+	}
+
+	return nil
+}
+
+// Pending returns the migrations pending for the data directory.
+// It returns an error if the data directory's schema version is newer than supported.
+func Pending(dir string) ([]Migration, error) {
+	v, err := Version(dir)
+	if err != nil {
+		return nil, err
+	} else if v > CurrentVersion() {
+		return nil, errors.New("data dir schema version newer than supported, upgrade charon",
+			z.Int("version", v), z.Int("supported", CurrentVersion()), z.Str("dir", dir))
+	}
+
+	var resp []Migration
+	for _, m := range migrations {
+		if m.Version > v {
+			resp = append(resp, m)
+		}
+	}
+
+	return resp, nil
+}
+
+// Migrate applies the pending migrations to the data directory in order, updating the version file after each
+// migration so an interrupted migration resumes at the failed migration. It returns the applied migrations.
+func Migrate(ctx context.Context, dir string) ([]Migration, error) {
+	pending, err := Pending(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, m := range pending {
+		if err := m.Migrate(ctx, dir); err != nil {
+			return applied, errors.Wrap(err, "migrate data dir", z.Int("version", m.Version))
+		}
+
+		if err := writeVersion(dir, m.Version); err != nil {
+			return applied, err
+		}
+
+		log.Info(ctx, "Migrated data dir", z.Int("version", m.Version), z.Str("migration", m.Description))
+
+		applied = append(applied, m)
+	}
+
+	return applied, nil
+}
+
+// writeVersion writes the schema version to the data directory's version file.
+func writeVersion(dir string, v int) error {
+	b, err := json.MarshalIndent(versionJSON{
+		Version:       v,
+		CharonVersion: version.Version.String(),
+		MigratedAt:    time.Now().UTC().Truncate(time.Second),
+	}, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal data dir version")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, VersionFile), b, 0o644); err != nil { //nolint:gosec // Not a secret.
+		return errors.Wrap(err, "write data dir version file", z.Str("dir", dir))
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package datadir
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+)
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	lock, _, _ := cluster.NewForT(t, 1, 3, 4, 0, rand.New(rand.NewSource(0)))
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, lockFile), b, 0o444))
+
+	// Legacy data dir without version file.
+	v, err := Version(dir)
+	require.NoError(t, err)
+	require.Equal(t, legacyVersion, v)
+	require.NoError(t, Check(ctx, dir))
+
+	pending, err := Pending(dir)
+	require.NoError(t, err)
+	require.Len(t, pending, CurrentVersion()-legacyVersion)
+
+	applied, err := Migrate(ctx, dir)
+	require.NoError(t, err)
+	require.Len(t, applied, len(pending))
+
+	v, err = Version(dir)
+	require.NoError(t, err)
+	require.Equal(t, CurrentVersion(), v)
+
+	// Manifest created from the lock.
+	dag, err := manifest.LoadDAG(filepath.Join(dir, manifestFile), filepath.Join(dir, lockFile), nil)
+	require.NoError(t, err)
+	require.Len(t, dag.GetMutations(), 1)
+
+	// Migrating again is a noop.
+	applied, err = Migrate(ctx, dir)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+
+	// Newer versions are refused.
+	require.NoError(t, writeVersion(dir, CurrentVersion()+1))
+	require.ErrorContains(t, Check(ctx, dir), "data dir schema version newer than supported")
+	_, err = Migrate(ctx, dir)
+	require.ErrorContains(t, err, "data dir schema version newer than supported")
+}
+
+func TestInit(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, Init(dir))
+
+	v, err := Version(dir)
+	require.NoError(t, err)
+	require.Equal(t, CurrentVersion(), v)
+
+	pending, err := Pending(dir)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
@@ -0,0 +1,55 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package datadir
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+)
+
+const (
+	lockFile     = "cluster-lock.json"
+	manifestFile = "cluster-manifest.pb"
+)
+
+// migrateManifest creates the cluster manifest from the legacy cluster lock if the data directory doesn't contain
+// a cluster manifest yet. The lock is retained, since both are loaded and their cluster hashes verified to match.
+func migrateManifest(ctx context.Context, dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, manifestFile)); err == nil {
+		return nil
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, lockFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil // No cluster yet.
+	} else if err != nil {
+		return errors.Wrap(err, "read cluster lock")
+	}
+
+	legacy, err := manifest.NewRawLegacyLock(b)
+	if err != nil {
+		return err
+	}
+
+	mb, err := proto.Marshal(&manifestpb.SignedMutationList{Mutations: []*manifestpb.SignedMutation{legacy}})
+	if err != nil {
+		return errors.Wrap(err, "marshal cluster manifest")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, manifestFile), mb, 0o444); err != nil {
+		return errors.Wrap(err, "write cluster manifest")
+	}
+
+	log.Info(ctx, "Created cluster manifest from legacy cluster lock", z.Str("file", manifestFile))
+
+	return nil
+}
@@ -0,0 +1,248 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package depositwatch watches the execution layer deposit contract for deposits of the cluster's validators
+// and combines them with the validator states of the beacon node, reporting the activation progress of
+// each validator; not deposited, deposited, pending and active. This closes the visibility gap between
+// the DKG and the validators' activation.
+package depositwatch
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// pollPeriod is the period at which new deposits and validator states are queried.
+	pollPeriod = time.Minute
+	// maxBlockRange is the maximum number of blocks queried per eth_getLogs request, since providers limit it.
+	maxBlockRange = 10_000
+)
+
+// Status is the activation progress of a validator.
+type Status string
+
+const (
+	StatusNotDeposited Status = "not_deposited"
+	StatusDeposited    Status = "deposited"
+	StatusPending      Status = "pending"
+	StatusActive       Status = "active"
+	StatusExited       Status = "exited"
+)
+
+// statuses are all statuses in activation order.
+var statuses = []Status{StatusNotDeposited, StatusDeposited, StatusPending, StatusActive, StatusExited}
+
+// Validator is the activation progress of a cluster validator.
+type Validator struct {
+	PubKey        string `json:"pubkey"`
+	Status        Status `json:"status"`
+	DepositedGwei uint64 `json:"deposited_gwei"`
+}
+
+// Report is the activation progress of all cluster validators.
+type Report struct {
+	Validators []Validator `json:"validators"`
+	// LastBlock is the last execution layer block scanned for deposits.
+	LastBlock uint64    `json:"last_block"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Counts returns the number of validators by status.
+func (r Report) Counts() map[Status]int {
+	resp := make(map[Status]int)
+	for _, val := range r.Validators {
+		resp[val.Status]++
+	}
+
+	return resp
+}
+
+// New returns a new deposit watcher of the validators, querying deposits of the deposit contract
+// from the start block via the execution layer JSON-RPC endpoint and validator states via the beacon node.
+func New(rpcURL string, contract string, startBlock uint64, pubkeys []eth2p0.BLSPubKey,
+	eth2Cl eth2client.ValidatorsProvider,
+) (*Watcher, error) {
+	if contract == "" {
+		return nil, errors.New("deposit contract address unknown for network")
+	}
+
+	return &Watcher{
+		rpc:       rpcClient{url: rpcURL},
+		contract:  contract,
+		eth2Cl:    eth2Cl,
+		pubkeys:   pubkeys,
+		nextBlock: startBlock,
+		deposited: make(map[eth2p0.BLSPubKey]eth2p0.Gwei),
+		statuses:  make(map[eth2p0.BLSPubKey]Status),
+	}, nil
+}
+
+// Watcher watches the deposits and activation progress of the cluster validators.
+type Watcher struct {
+	rpc      rpcClient
+	contract string
+	eth2Cl   eth2client.ValidatorsProvider
+	pubkeys  []eth2p0.BLSPubKey
+
+	mu        sync.Mutex
+	nextBlock uint64
+	deposited map[eth2p0.BLSPubKey]eth2p0.Gwei
+	statuses  map[eth2p0.BLSPubKey]Status
+	report    Report
+}
+
+// Run updates the activation progress periodically until the context is cancelled.
+func (w *Watcher) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "depositwatch")
+
+	ticker := time.NewTicker(pollPeriod)
+	defer ticker.Stop()
+
+	for {
+		if err := w.update(ctx); err != nil && ctx.Err() == nil {
+			log.Warn(ctx, "Failed updating validator activation progress", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Report returns the latest activation progress of the cluster validators.
+func (w *Watcher) Report() Report {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.report
+}
+
+// Deposited returns true if a deposit of the validator was observed.
+func (w *Watcher) Deposited(pubkey eth2p0.BLSPubKey) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.deposited[pubkey] > 0
+}
+
+// update scans the new blocks for deposits of the cluster validators and updates the activation progress.
+func (w *Watcher) update(ctx context.Context) error {
+	latest, err := w.rpc.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[eth2p0.BLSPubKey]bool)
+	for _, pubkey := range w.pubkeys {
+		wanted[pubkey] = true
+	}
+
+	w.mu.Lock()
+	from := w.nextBlock
+	w.mu.Unlock()
+
+	for ; from <= latest; from += maxBlockRange {
+		to := min(from+maxBlockRange-1, latest)
+
+		deposits, err := w.rpc.Deposits(ctx, w.contract, from, to)
+		if err != nil {
+			return err
+		}
+
+		w.mu.Lock()
+		for _, d := range deposits {
+			if !wanted[d.PubKey] {
+				continue
+			}
+
+			w.deposited[d.PubKey] += d.Amount
+			log.Info(ctx, "Validator deposit detected", z.Redact(z.Str("pubkey", d.PubKey.String())),
+				z.U64("amount_gwei", uint64(d.Amount)), z.U64("block", d.Block))
+		}
+		w.nextBlock = to + 1
+		w.mu.Unlock()
+	}
+
+	states, err := w.beaconStatuses(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	report := Report{UpdatedAt: time.Now()}
+	if w.nextBlock > 0 {
+		report.LastBlock = w.nextBlock - 1
+	}
+	for _, pubkey := range w.pubkeys {
+		status, ok := states[pubkey]
+		if !ok && w.deposited[pubkey] > 0 {
+			status = StatusDeposited
+		} else if !ok {
+			status = StatusNotDeposited
+		}
+
+		if prev, ok := w.statuses[pubkey]; ok && prev != status {
+			log.Info(ctx, "Validator activation progress", z.Redact(z.Str("pubkey", pubkey.String())),
+				z.Str("previous", string(prev)), z.Str("status", string(status)))
+		}
+		w.statuses[pubkey] = status
+
+		report.Validators = append(report.Validators, Validator{
+			PubKey:        pubkey.String(),
+			Status:        status,
+			DepositedGwei: uint64(w.deposited[pubkey]),
+		})
+	}
+
+	sort.Slice(report.Validators, func(i, j int) bool {
+		return report.Validators[i].PubKey < report.Validators[j].PubKey
+	})
+
+	w.report = report
+	instrumentReport(report)
+
+	return nil
+}
+
+// beaconStatuses returns the statuses of the validators known by the beacon node, i.e. with processed deposits.
+func (w *Watcher) beaconStatuses(ctx context.Context) (map[eth2p0.BLSPubKey]Status, error) {
+	eth2Resp, err := w.eth2Cl.Validators(ctx, &eth2api.ValidatorsOpts{
+		State:   "head",
+		PubKeys: w.pubkeys,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch validators")
+	}
+
+	resp := make(map[eth2p0.BLSPubKey]Status)
+	for _, val := range eth2Resp.Data {
+		if val == nil || val.Validator == nil {
+			continue
+		}
+
+		switch {
+		case val.Status.IsPending():
+			resp[val.Validator.PublicKey] = StatusPending
+		case val.Status.IsActive():
+			resp[val.Validator.PublicKey] = StatusActive
+		case val.Status.HasExited():
+			resp[val.Validator.PublicKey] = StatusExited
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package depositwatch
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestWatcher(t *testing.T) {
+	ctx := context.Background()
+
+	var pending, active, deposited, missing, other eth2p0.BLSPubKey
+	for i, pk := range []*eth2p0.BLSPubKey{&pending, &active, &deposited, &missing, &other} {
+		pk[0] = byte(i + 1)
+	}
+
+	logs := []rpcLog{
+		depositLog(t, pending, 32e9, 10),
+		depositLog(t, deposited, 1e9, 15_000),
+		depositLog(t, deposited, 31e9, 15_001),
+		depositLog(t, other, 32e9, 15_002),
+	}
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string           `json:"method"`
+			Params []map[string]any `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var result any
+		switch req.Method {
+		case "eth_blockNumber":
+			result = "0x4e20" // 20000
+		case "eth_getLogs":
+			requests++
+			from, err := parseHexUint(req.Params[0]["fromBlock"].(string))
+			require.NoError(t, err)
+			to, err := parseHexUint(req.Params[0]["toBlock"].(string))
+			require.NoError(t, err)
+
+			filtered := []rpcLog{}
+			for _, l := range logs {
+				block, err := parseHexUint(l.BlockNumber)
+				require.NoError(t, err)
+				if block >= from && block <= to {
+					filtered = append(filtered, l)
+				}
+			}
+			result = filtered
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result}))
+	}))
+	defer srv.Close()
+
+	bmock, err := beaconmock.New(beaconmock.WithValidatorSet(beaconmock.ValidatorSet{
+		1: {Index: 1, Status: eth2v1.ValidatorStatePendingQueued, Validator: &eth2p0.Validator{PublicKey: pending}},
+		2: {Index: 2, Status: eth2v1.ValidatorStateActiveOngoing, Validator: &eth2p0.Validator{PublicKey: active}},
+	}))
+	require.NoError(t, err)
+
+	_, err = New(srv.URL, "", 0, nil, bmock)
+	require.ErrorContains(t, err, "deposit contract address unknown")
+
+	w, err := New(srv.URL, "0x00000000219ab540356cBB839Cbe05303d7705Fa", 0,
+		[]eth2p0.BLSPubKey{pending, active, deposited, missing}, bmock)
+	require.NoError(t, err)
+
+	require.NoError(t, w.update(ctx))
+	require.Equal(t, 3, requests) // Block range split into chunks.
+
+	report := w.Report()
+	require.EqualValues(t, 20_000, report.LastBlock)
+	require.Equal(t, map[Status]int{StatusPending: 1, StatusActive: 1, StatusDeposited: 1, StatusNotDeposited: 1}, report.Counts())
+
+	for _, val := range report.Validators {
+		if val.PubKey == deposited.String() {
+			require.EqualValues(t, 32e9, val.DepositedGwei)
+		}
+	}
+
+	// Only new blocks are scanned.
+	require.NoError(t, w.update(ctx))
+	require.Equal(t, 3, requests)
+}
+
+func TestParseDepositLogInvalid(t *testing.T) {
+	_, err := parseDepositLog(rpcLog{Data: "0x1234", BlockNumber: "0x1"})
+	require.ErrorContains(t, err, "deposit log data too short")
+}
+
+// depositLog returns the ABI encoded DepositEvent log of the deposit.
+func depositLog(t *testing.T, pubkey eth2p0.BLSPubKey, amount eth2p0.Gwei, block uint64) rpcLog {
+	t.Helper()
+
+	amountBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(amountBytes, uint64(amount))
+
+	fields := [][]byte{pubkey[:], make([]byte, 32), amountBytes, make([]byte, 96), make([]byte, 8)}
+
+	word := func(v int) []byte {
+		b := make([]byte, 32)
+		binary.BigEndian.PutUint64(b[24:], uint64(v))
+
+		return b
+	}
+
+	var head, tail []byte
+	for _, field := range fields {
+		head = append(head, word(32*len(fields)+len(tail))...)
+		tail = append(tail, word(len(field))...)
+		padded := make([]byte, (len(field)+31)/32*32)
+		copy(padded, field)
+		tail = append(tail, padded...)
+	}
+
+	return rpcLog{
+		BlockNumber: "0x" + strconv.FormatUint(block, 16),
+		Topics:      []string{depositEventTopic},
+		Data:        "0x" + hex.EncodeToString(append(head, tail...)),
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package depositwatch
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	validatorsGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "deposit",
+		Name:      "validators",
+		Help:      "Number of cluster validators by activation progress status; not_deposited, deposited, pending, active or exited",
+	}, []string{"status"})
+
+	lastBlockGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "deposit",
+		Name:      "last_block",
+		Help:      "Last execution layer block scanned for deposit contract events",
+	})
+)
+
+// instrumentReport sets the metrics of the report.
+func instrumentReport(report Report) {
+	counts := report.Counts()
+	for _, status := range statuses {
+		validatorsGauge.WithLabelValues(string(status)).Set(float64(counts[status]))
+	}
+
+	lastBlockGauge.Set(float64(report.LastBlock))
+}
@@ -0,0 +1,203 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package depositwatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// depositEventTopic is the keccak256 hash of the deposit contract's
+// DepositEvent(bytes,bytes,bytes,bytes,bytes) event signature.
+const depositEventTopic = "0x649bbc62d0e31342afea4e5cd82d4049e7e1ee912fc0889aa790803be39038c5"
+
+// rpcTimeout is the timeout of execution layer JSON-RPC requests.
+const rpcTimeout = 30 * time.Second
+
+// rpcClient is a minimal execution layer JSON-RPC client supporting the deposit contract queries.
+type rpcClient struct {
+	url string
+}
+
+// rpcLog is a log returned by eth_getLogs.
+type rpcLog struct {
+	BlockNumber string   `json:"blockNumber"`
+	Topics      []string `json:"topics"`
+	Data        string   `json:"data"`
+}
+
+// deposit is a parsed deposit contract DepositEvent.
+type deposit struct {
+	PubKey eth2p0.BLSPubKey
+	Amount eth2p0.Gwei
+	Block  uint64
+}
+
+// call performs the JSON-RPC call and decodes the result into resp.
+func (c rpcClient) call(ctx context.Context, method string, params []any, resp any) error {
+	ctx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	b, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal rpc request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "new rpc request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := new(http.Client).Do(req)
+	if err != nil {
+		return errors.Wrap(err, "execution layer rpc request", z.Str("method", method))
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read rpc response")
+	} else if httpResp.StatusCode/100 != 2 {
+		return errors.New("execution layer rpc http error", z.Int("status_code", httpResp.StatusCode), z.Str("method", method))
+	}
+
+	var rpcResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return errors.Wrap(err, "unmarshal rpc response")
+	} else if rpcResp.Error != nil {
+		return errors.New("execution layer rpc error", z.Str("method", method),
+			z.Int("code", rpcResp.Error.Code), z.Str("message", rpcResp.Error.Message))
+	}
+
+	if err := json.Unmarshal(rpcResp.Result, resp); err != nil {
+		return errors.Wrap(err, "unmarshal rpc result", z.Str("method", method))
+	}
+
+	return nil
+}
+
+// BlockNumber returns the latest execution layer block number.
+func (c rpcClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var hexNum string
+	if err := c.call(ctx, "eth_blockNumber", nil, &hexNum); err != nil {
+		return 0, err
+	}
+
+	return parseHexUint(hexNum)
+}
+
+// Deposits returns the deposit contract DepositEvents in the inclusive block range.
+func (c rpcClient) Deposits(ctx context.Context, contract string, from, to uint64) ([]deposit, error) {
+	filter := map[string]any{
+		"address":   contract,
+		"topics":    []string{depositEventTopic},
+		"fromBlock": "0x" + strconv.FormatUint(from, 16),
+		"toBlock":   "0x" + strconv.FormatUint(to, 16),
+	}
+
+	var logs []rpcLog
+	if err := c.call(ctx, "eth_getLogs", []any{filter}, &logs); err != nil {
+		return nil, err
+	}
+
+	var resp []deposit
+	for _, l := range logs {
+		d, err := parseDepositLog(l)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, d)
+	}
+
+	return resp, nil
+}
+
+// parseDepositLog returns the deposit of the DepositEvent log. The event data is the ABI encoding of five dynamic
+// bytes fields: pubkey, withdrawal_credentials, amount (little-endian gwei), signature and index.
+func parseDepositLog(l rpcLog) (deposit, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(l.Data, "0x"))
+	if err != nil {
+		return deposit{}, errors.Wrap(err, "decode deposit log data")
+	}
+
+	field := func(i int) ([]byte, error) {
+		if len(data) < 32*(i+1) {
+			return nil, errors.New("deposit log data too short")
+		}
+
+		offset := new(big.Int).SetBytes(data[32*i : 32*(i+1)])
+		if !offset.IsUint64() || offset.Uint64()+32 > uint64(len(data)) {
+			return nil, errors.New("invalid deposit log field offset")
+		}
+		start := offset.Uint64() + 32
+
+		length := new(big.Int).SetBytes(data[start-32 : start])
+		if !length.IsUint64() || start+length.Uint64() > uint64(len(data)) {
+			return nil, errors.New("invalid deposit log field length")
+		}
+
+		return data[start : start+length.Uint64()], nil
+	}
+
+	pubkey, err := field(0)
+	if err != nil {
+		return deposit{}, err
+	} else if len(pubkey) != len(eth2p0.BLSPubKey{}) {
+		return deposit{}, errors.New("invalid deposit pubkey length", z.Int("length", len(pubkey)))
+	}
+
+	amount, err := field(2)
+	if err != nil {
+		return deposit{}, err
+	} else if len(amount) != 8 {
+		return deposit{}, errors.New("invalid deposit amount length", z.Int("length", len(amount)))
+	}
+
+	block, err := parseHexUint(l.BlockNumber)
+	if err != nil {
+		return deposit{}, err
+	}
+
+	return deposit{
+		PubKey: eth2p0.BLSPubKey(pubkey),
+		Amount: eth2p0.Gwei(binary.LittleEndian.Uint64(amount)),
+		Block:  block,
+	}, nil
+}
+
+// parseHexUint returns the 0x-prefixed hex encoded quantity.
+func parseHexUint(s string) (uint64, error) {
+	v, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "parse hex quantity", z.Str("value", s))
+	}
+
+	return v, nil
+}
@@ -4,18 +4,35 @@ package app
 
 import (
 	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/cluster/manifest"
 	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/p2p"
 )
 
-// loadClusterManifest returns the cluster manifest from the given file path.
-func loadClusterManifest(ctx context.Context, conf Config) (*manifestpb.Cluster, error) {
+// loadClusterManifest returns the cluster DAG and materialised cluster manifest from the given file path.
+func loadClusterManifest(ctx context.Context, conf Config) (*manifestpb.SignedMutationList, *manifestpb.Cluster, error) {
 	if conf.TestConfig.Lock != nil {
-		return manifest.NewClusterFromLockForT(nil, *conf.TestConfig.Lock)
+		legacy, err := manifest.NewLegacyLockForT(nil, *conf.TestConfig.Lock)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		dag := &manifestpb.SignedMutationList{Mutations: []*manifestpb.SignedMutation{legacy}}
+		cluster, err := manifest.Materialise(dag)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return dag, cluster, nil
 	}
 
 	verifyLock := func(lock cluster.Lock) error {
@@ -34,10 +51,68 @@ func loadClusterManifest(ctx context.Context, conf Config) (*manifestpb.Cluster,
 		return nil
 	}
 
-	cluster, err := manifest.LoadCluster(conf.ManifestFile, conf.LockFile, verifyLock)
+	dag, err := manifest.LoadDAG(conf.ManifestFile, conf.LockFile, verifyLock)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "load cluster manifest")
+	}
+
+	cluster, err := manifest.Materialise(dag)
 	if err != nil {
-		return nil, errors.Wrap(err, "load cluster manifest")
+		return nil, nil, errors.Wrap(err, "load cluster manifest")
+	}
+
+	return dag, cluster, nil
+}
+
+// applyKeyAttestations returns the cluster with the unexpired operator key attestations in the key attestations
+// directory applied, and the expiry times of the attested operators' new peer IDs.
+// Expired attestations are ignored, since the key rotation must be approved by the cluster during the grace period.
+func applyKeyAttestations(ctx context.Context, conf Config, c *manifestpb.Cluster) (*manifestpb.Cluster, map[peer.ID]time.Time, error) {
+	if conf.KeyAttestationsDir == "" {
+		return c, nil, nil
+	}
+
+	atts, err := manifest.LoadKeyAttestations(conf.KeyAttestationsDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+
+	var unexpired []manifest.KeyAttestation
+	for _, att := range atts {
+		if !now.Before(att.NotAfter) {
+			log.Warn(ctx, "Ignoring expired operator key attestation, rotate the operator key with `charon alpha rotate-operator-key`", nil,
+				z.Redact(z.Str("new_enr", att.NewENR)), z.Any("not_after", att.NotAfter))
+
+			continue
+		}
+
+		unexpired = append(unexpired, att)
+	}
+
+	c, applied, err := manifest.ApplyKeyAttestations(c, unexpired, now)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "apply operator key attestations")
+	}
+
+	expiries := make(map[peer.ID]time.Time)
+	for _, att := range applied {
+		record, err := enr.Parse(att.NewENR)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "parse new operator enr")
+		}
+
+		peerID, err := p2p.PeerIDFromKey(record.PubKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		expiries[peerID] = att.NotAfter
+
+		log.Warn(ctx, "Accepting attested operator key until its grace period ends", nil,
+			z.Str("peer", p2p.PeerName(peerID)), z.Any("not_after", att.NotAfter))
 	}
 
-	return cluster, nil
+	return c, expiries, nil
 }
@@ -0,0 +1,169 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/rootcause"
+	"github.com/obolnetwork/charon/core/tracker"
+)
+
+// maxRecentFailures is the number of recent duty failures served by the duties endpoint.
+const maxRecentFailures = 20
+
+// DutiesStatus is the response of the monitoring API duties endpoint.
+type DutiesStatus struct {
+	// Slot is the current slot, zero if the scheduler didn't start yet.
+	Slot     uint64         `json:"slot"`
+	Upcoming []UpcomingDuty `json:"upcoming"`
+	// Failures are the most recent duty failures, latest first.
+	Failures []FailedDuty `json:"failures"`
+}
+
+// UpcomingDuty is a resolved duty scheduled for the current or a future slot.
+type UpcomingDuty struct {
+	Slot       uint64 `json:"slot"`
+	Duty       string `json:"duty"`
+	Validators int    `json:"validators"`
+}
+
+// FailedDuty is a duty failure as analysed by the tracker.
+type FailedDuty struct {
+	Time   time.Time `json:"time"`
+	Slot   uint64    `json:"slot"`
+	Duty   string    `json:"duty"`
+	Step   string    `json:"step"`
+	Reason string    `json:"reason"`
+	Error  string    `json:"error,omitempty"`
+	// RootCause is the cluster-consistent root cause correlated from peer observations, empty until correlated.
+	RootCause string `json:"root_cause,omitempty"`
+}
+
+// newDutiesStatus returns a new empty duties status store.
+func newDutiesStatus() *dutiesStatus {
+	return &dutiesStatus{
+		upcomingFunc: func(uint64) map[core.Duty]int { return nil },
+	}
+}
+
+// dutiesStatus stores the upcoming duties and recent duty failures served by the monitoring API.
+// It is created before the core workflow, which populates it via SetUpcomingFunc, SlotTicked and TrackerOption.
+type dutiesStatus struct {
+	mu           sync.Mutex
+	slot         uint64
+	upcomingFunc func(slot uint64) map[core.Duty]int
+	failures     []FailedDuty
+}
+
+// SetUpcomingFunc sets the function returning the resolved duties from a slot, see scheduler.UpcomingDuties.
+func (s *dutiesStatus) SetUpcomingFunc(fn func(slot uint64) map[core.Duty]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.upcomingFunc = fn
+}
+
+// SlotTicked stores the current slot.
+func (s *dutiesStatus) SlotTicked(_ context.Context, slot core.Slot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.slot = slot.Slot
+
+	return nil
+}
+
+// TrackerOption returns a tracker option storing failed duties.
+func (s *dutiesStatus) TrackerOption() tracker.Option {
+	return tracker.WithFailedDutyCallback(func(_ context.Context, duty core.Duty, step string, reasonCode string, err error) {
+		s.recordFailure(duty, step, reasonCode, err)
+	})
+}
+
+// recordFailure stores the duty failure, dropping the oldest failure if more than maxRecentFailures are stored.
+func (s *dutiesStatus) recordFailure(duty core.Duty, step string, reasonCode string, err error) {
+	failure := FailedDuty{
+		Time:   time.Now(),
+		Slot:   duty.Slot,
+		Duty:   duty.Type.String(),
+		Step:   step,
+		Reason: reasonCode,
+	}
+	if err != nil {
+		failure.Error = err.Error()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append([]FailedDuty{failure}, s.failures...)
+	if len(s.failures) > maxRecentFailures {
+		s.failures = s.failures[:maxRecentFailures]
+	}
+}
+
+// SetRootCause stores the cluster-consistent root cause of the failed duty, see rootcause.Correlator.
+func (s *dutiesStatus) SetRootCause(_ context.Context, duty core.Duty, cause rootcause.RootCause) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, failure := range s.failures {
+		if failure.Slot == duty.Slot && failure.Duty == duty.Type.String() {
+			s.failures[i].RootCause = cause.Summary
+		}
+	}
+}
+
+// Status returns the current duties status with upcoming duties ordered by slot and duty type.
+func (s *dutiesStatus) Status() DutiesStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := DutiesStatus{
+		Slot:     s.slot,
+		Upcoming: []UpcomingDuty{},
+		Failures: append([]FailedDuty{}, s.failures...),
+	}
+
+	if s.slot == 0 {
+		return resp
+	}
+
+	upcoming := s.upcomingFunc(s.slot)
+
+	var duties []core.Duty
+	for duty := range upcoming {
+		duties = append(duties, duty)
+	}
+
+	sort.Slice(duties, func(i, j int) bool {
+		if duties[i].Slot != duties[j].Slot {
+			return duties[i].Slot < duties[j].Slot
+		}
+
+		return duties[i].Type < duties[j].Type
+	})
+
+	for _, duty := range duties {
+		resp.Upcoming = append(resp.Upcoming, UpcomingDuty{
+			Slot:       duty.Slot,
+			Duty:       duty.Type.String(),
+			Validators: upcoming[duty],
+		})
+	}
+
+	return resp
+}
+
+// newDutiesHandler returns a handler serving the upcoming duties and recent duty failures.
+func newDutiesHandler(status *dutiesStatus) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeJSONResponse(w, http.StatusOK, status.Status())
+	}
+}
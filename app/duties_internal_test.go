@@ -0,0 +1,67 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/rootcause"
+)
+
+func TestDutiesStatus(t *testing.T) {
+	ctx := context.Background()
+	status := newDutiesStatus()
+
+	// Nothing before the first slot.
+	require.Empty(t, status.Status().Upcoming)
+
+	status.SetUpcomingFunc(func(slot uint64) map[core.Duty]int {
+		require.EqualValues(t, 10, slot)
+
+		return map[core.Duty]int{
+			core.NewProposerDuty(11):   1,
+			core.NewAggregatorDuty(10): 2,
+			core.NewAttesterDuty(10):   3,
+		}
+	})
+	require.NoError(t, status.SlotTicked(ctx, core.Slot{Slot: 10}))
+
+	srv := httptest.NewServer(newDutiesHandler(status))
+	defer srv.Close()
+
+	for i := range maxRecentFailures + 1 {
+		status.recordFailure(core.NewAttesterDuty(uint64(i)), "fetcher", "bcast_failed", errors.New("boom"))
+	}
+	status.SetRootCause(ctx, core.NewAttesterDuty(maxRecentFailures), rootcause.RootCause{Summary: "all 4/4 peers failed at fetcher: fetch_bn_error"})
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var got DutiesStatus
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+
+	require.EqualValues(t, 10, got.Slot)
+	require.Equal(t, []UpcomingDuty{
+		{Slot: 10, Duty: "attester", Validators: 3},
+		{Slot: 10, Duty: "aggregator", Validators: 2},
+		{Slot: 11, Duty: "proposer", Validators: 1},
+	}, got.Upcoming)
+
+	require.Len(t, got.Failures, maxRecentFailures)
+	require.EqualValues(t, maxRecentFailures, got.Failures[0].Slot) // Latest first.
+	require.Equal(t, "attester", got.Failures[0].Duty)
+	require.Equal(t, "fetcher", got.Failures[0].Step)
+	require.Equal(t, "bcast_failed", got.Failures[0].Reason)
+	require.Equal(t, "boom", got.Failures[0].Error)
+	require.Equal(t, "all 4/4 peers failed at fetcher: fetch_bn_error", got.Failures[0].RootCause)
+	require.Empty(t, got.Failures[1].RootCause)
+}
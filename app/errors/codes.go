@@ -0,0 +1,88 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package errors
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Code is a stable machine-readable identifier of a class of errors, formatted as E followed by four digits, e.g. E2101.
+// Codes are surfaced in logs (error_code field), metrics (code label) and API responses, allowing operators
+// to alert on specific failure classes. Codes are never renumbered or reused; the first digit identifies the component.
+type Code int
+
+const (
+	// CodeBeaconUnreachable indicates a beacon node request that failed without a response, e.g. connection refused.
+	CodeBeaconUnreachable Code = 2001
+	// CodeBeaconTimeout indicates a beacon node request that timed out.
+	CodeBeaconTimeout Code = 2101
+	// CodeBeaconErrorResponse indicates a beacon node request that returned a non-2xx http response.
+	CodeBeaconErrorResponse Code = 2201
+
+	// CodeInvalidPartialSignature indicates a partial signature received from a peer that failed verification.
+	CodeInvalidPartialSignature Code = 3101
+
+	// CodeConsensusTimeout indicates a consensus instance that didn't decide before its duty deadline.
+	CodeConsensusTimeout Code = 4101
+)
+
+// codeDescriptions are the descriptions of all codes. Duplicate codes fail to compile.
+var codeDescriptions = map[Code]string{
+	CodeBeaconUnreachable:       "Beacon node unreachable",
+	CodeBeaconTimeout:           "Beacon node request timeout",
+	CodeBeaconErrorResponse:     "Beacon node error response",
+	CodeInvalidPartialSignature: "Invalid partial signature from peer",
+	CodeConsensusTimeout:        "Consensus timeout",
+}
+
+// String returns the code formatted as E followed by four digits, e.g. E2101.
+func (c Code) String() string {
+	return fmt.Sprintf("E%04d", int(c))
+}
+
+// Description returns the human-readable description of the code.
+func (c Code) Description() string {
+	return codeDescriptions[c]
+}
+
+// WithCode returns the error with the code, replacing any existing code.
+// The code is retained when the error is wrapped.
+func WithCode(err error, code Code) error {
+	if err == nil {
+		return nil
+	}
+
+	if s, ok := err.(structured); ok { //nolint:errorlint // Only replace the code of structured errors, not wrapped ones.
+		s.code = code
+		return s
+	}
+
+	// Retain the fields and stack trace of structured errors wrapped by other errors.
+	resp := structured{
+		err:  err,
+		code: code,
+	}
+	var inner structured
+	if As(err, &inner) {
+		resp.fields = inner.fields
+		resp.stack = inner.stack
+	}
+
+	if resp.stack.Key == "" {
+		resp.stack = zap.StackSkip("stacktrace", 1)
+	}
+
+	return resp
+}
+
+// CodeOf returns the code of the error and true or false if the error has no code.
+func CodeOf(err error) (Code, bool) {
+	var s structured
+	if !As(err, &s) || s.code == 0 {
+		return 0, false
+	}
+
+	return s.code, true
+}
@@ -60,11 +60,13 @@ func Wrap(err error, msg string, fields ...z.Field) error {
 func SkipWrap(err error, msg string, skip int, fields ...z.Field) error {
 	var (
 		stack zap.Field
+		code  Code
 		inner structured
 	)
 	if As(err, &inner) {
 		fields = append(fields, inner.fields...) // Append inner fields
 		stack = inner.stack                      // Use inner stack trace
+		code = inner.code                        // Retain inner code
 	}
 
 	if stack.Key == "" {
@@ -75,6 +77,7 @@ func SkipWrap(err error, msg string, skip int, fields ...z.Field) error {
 		err:    fmt.Errorf("%s: %w", msg, err), //nolint:forbidigo // Wrap error message using stdlib.
 		fields: fields,
 		stack:  stack,
+		code:   code,
 	}
 }
 
@@ -83,6 +86,7 @@ type structured struct {
 	err    error
 	fields []z.Field
 	stack  zap.Field
+	code   Code
 }
 
 // Error returns the error message and implements the error interface.
@@ -90,9 +94,13 @@ func (s structured) Error() string {
 	return s.err.Error()
 }
 
-// Fields returns the structured fields.
+// Fields returns the structured fields, including the error code if present.
 func (s structured) Fields() []z.Field {
-	return s.fields
+	if s.code == 0 {
+		return s.fields
+	}
+
+	return append([]z.Field{z.Str("error_code", s.code.String())}, s.fields...)
 }
 
 // Stack returns the zap stack trace.
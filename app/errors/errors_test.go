@@ -3,11 +3,13 @@
 package errors_test
 
 import (
+	"fmt"
 	"io"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/z"
@@ -50,3 +52,36 @@ func TestIs(t *testing.T) {
 	require.True(t, errors.Is(errIO11, errIO11))
 	require.False(t, errors.Is(err111, errX))
 }
+
+func TestCode(t *testing.T) {
+	err := errors.New("timeout", z.Str("1", "1"))
+	_, ok := errors.CodeOf(err)
+	require.False(t, ok)
+
+	err = errors.WithCode(err, errors.CodeBeaconTimeout)
+	code, ok := errors.CodeOf(err)
+	require.True(t, ok)
+	require.Equal(t, errors.CodeBeaconTimeout, code)
+	require.Equal(t, "E2101", code.String())
+	require.Equal(t, "Beacon node request timeout", code.Description())
+	require.Equal(t, "timeout", err.Error())
+
+	// Codes are retained when wrapping.
+	wrapped := errors.Wrap(fmt.Errorf("fmt: %w", errors.Wrap(err, "wrap")), "outer") //nolint:forbidigo // Test stdlib wrapping.
+	code, ok = errors.CodeOf(wrapped)
+	require.True(t, ok)
+	require.Equal(t, errors.CodeBeaconTimeout, code)
+
+	// Codes are added as a field.
+	var fields []string
+	z.Err(wrapped)(func(f zap.Field) { fields = append(fields, f.Key) })
+	require.Contains(t, fields, "error_code")
+
+	// Codes can be added to non-structured errors.
+	code, ok = errors.CodeOf(errors.WithCode(io.EOF, errors.CodeBeaconUnreachable))
+	require.True(t, ok)
+	require.Equal(t, errors.CodeBeaconUnreachable, code)
+	require.True(t, errors.Is(errors.WithCode(io.EOF, errors.CodeBeaconUnreachable), io.EOF))
+
+	require.NoError(t, errors.WithCode(nil, errors.CodeBeaconTimeout))
+}
@@ -0,0 +1,216 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/promauto"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// overloadWindow is the period of beacon node overload responses considered for the degradation level.
+	overloadWindow = time.Minute
+	// degradedThreshold is the number of overload responses within the window to shed low priority requests.
+	degradedThreshold = 3
+	// overloadedThreshold is the number of overload responses within the window to only admit critical requests.
+	overloadedThreshold = 10
+)
+
+// ErrShed is returned for requests shed while the beacon node is overloaded.
+var ErrShed = errors.NewSentinel("beacon node request shed due to overload")
+
+var (
+	degradationGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "eth2",
+		Name:      "degradation_level",
+		Help:      "Beacon node overload degradation level; 0: normal, 1: degraded shedding low priority requests, 2: overloaded only admitting critical requests",
+	})
+
+	shedCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "eth2",
+		Name:      "shed_requests_total",
+		Help:      "Total number of beacon node requests shed due to beacon node overload",
+	}, []string{"endpoint"})
+
+	overloadCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "eth2",
+		Name:      "overload_responses_total",
+		Help:      "Total number of beacon node overload responses, i.e. 429, 503 or timeouts",
+	}, []string{"endpoint"})
+)
+
+// priority is the priority of a beacon node request. Requests with a priority below the degradation level are shed.
+type priority int
+
+const (
+	// priorityLow requests are informational, e.g. monitoring queries and registration refreshes.
+	priorityLow priority = iota
+	// priorityNormal is the default priority.
+	priorityNormal
+	// priorityCritical requests are on the attestation, aggregation and proposal paths, including the chain
+	// metadata and validator queries signing and broadcasting depend on, and are never shed.
+	priorityCritical
+)
+
+// level is the beacon node overload degradation level.
+type level int
+
+const (
+	levelNormal     level = iota // Admit all requests.
+	levelDegraded                // Shed low priority requests.
+	levelOverloaded              // Only admit critical requests.
+)
+
+// priorities defines the priority of beacon node requests by label, defaulting to priorityNormal.
+var priorities = map[string]priority{
+	"node_peer_count":                       priorityLow,
+	"node_version":                          priorityLow,
+	"block_attestations":                    priorityLow,
+	"signed_beacon_block":                   priorityLow,
+	"submit_validator_registrations":        priorityLow,
+	"spec":                                  priorityCritical,
+	"genesis":                               priorityCritical,
+	"genesis_time":                          priorityCritical,
+	"genesis_domain":                        priorityCritical,
+	"slot_duration":                         priorityCritical,
+	"slots_per_epoch":                       priorityCritical,
+	"fork":                                  priorityCritical,
+	"fork_schedule":                         priorityCritical,
+	"domain":                                priorityCritical,
+	"deposit_contract":                      priorityCritical,
+	"node_syncing":                          priorityCritical,
+	"validators":                            priorityCritical,
+	"active_validators":                     priorityCritical,
+	"complete_validators":                   priorityCritical,
+	"submit_beacon_committee_subscriptions": priorityCritical,
+	"submit_sync_committee_subscriptions":   priorityCritical,
+	"attester_duties":                       priorityCritical,
+	"proposer_duties":                       priorityCritical,
+	"sync_committee_duties":                 priorityCritical,
+	"attestation_data":                      priorityCritical,
+	"submit_attestations":                   priorityCritical,
+	"aggregate_beacon_committee_selections": priorityCritical,
+	"aggregate_attestation":                 priorityCritical,
+	"submit_aggregate_attestations":         priorityCritical,
+	"proposal":                              priorityCritical,
+	"submit_proposal":                       priorityCritical,
+	"submit_blinded_proposal":               priorityCritical,
+	"beacon_block_root":                     priorityCritical,
+	"submit_sync_committee_messages":        priorityCritical,
+	"aggregate_sync_committee_selections":   priorityCritical,
+	"sync_committee_contribution":           priorityCritical,
+	"submit_sync_committee_contributions":   priorityCritical,
+}
+
+// newDegrader returns a new degrader at the normal level.
+func newDegrader(nowFunc func() time.Time) *degrader {
+	return &degrader{nowFunc: nowFunc}
+}
+
+// degrader gracefully degrades under beacon node overload by shedding requests by priority.
+// The degradation level is derived from the number of overload responses, i.e. 429, 503 or timeouts,
+// within the overload window, so it recovers once the beacon node stops returning them.
+type degrader struct {
+	nowFunc func() time.Time
+
+	mu        sync.Mutex
+	overloads []time.Time
+	level     level
+}
+
+// Admit returns an error wrapping ErrShed if the request with the label is shed at the current degradation level.
+// A nil degrader admits all requests.
+func (d *degrader) Admit(ctx context.Context, label string) error {
+	if d == nil {
+		return nil
+	}
+
+	prio, ok := priorities[label]
+	if !ok {
+		prio = priorityNormal
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.update(ctx)
+
+	if int(prio) < int(d.level) {
+		shedCount.WithLabelValues(label).Inc()
+		return errors.Wrap(ErrShed, "shed beacon node request", z.Str("endpoint", label))
+	}
+
+	return nil
+}
+
+// Observe records the beacon node response error of the request with the label, if it indicates overload.
+func (d *degrader) Observe(ctx context.Context, label string, err error) {
+	if d == nil || !isOverload(ctx, err) {
+		return
+	}
+
+	overloadCount.WithLabelValues(label).Inc()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.overloads = append(d.overloads, d.nowFunc())
+	d.update(ctx)
+}
+
+// update drops overload responses outside the window and updates the degradation level.
+// It must be called with the mutex held.
+func (d *degrader) update(ctx context.Context) {
+	cutoff := d.nowFunc().Add(-overloadWindow)
+	for len(d.overloads) > 0 && d.overloads[0].Before(cutoff) {
+		d.overloads = d.overloads[1:]
+	}
+
+	next := levelNormal
+	if len(d.overloads) >= overloadedThreshold {
+		next = levelOverloaded
+	} else if len(d.overloads) >= degradedThreshold {
+		next = levelDegraded
+	}
+
+	if next == d.level {
+		return
+	}
+
+	if next > d.level {
+		log.Warn(ctx, "Beacon node overloaded, shedding lower priority requests", nil,
+			z.Int("level", int(next)), z.Int("overload_responses", len(d.overloads)))
+	} else {
+		log.Info(ctx, "Beacon node overload reduced, admitting more requests", z.Int("level", int(next)))
+	}
+
+	d.level = next
+	degradationGauge.Set(float64(next))
+}
+
+// isOverload returns true if the error indicates the beacon node is overloaded, i.e. it responded with
+// 429 Too Many Requests or 503 Service Unavailable, or the request timed out.
+func isOverload(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil { // Ignore requests cancelled by the caller.
+		return false
+	}
+
+	if apiErr := new(eth2api.Error); errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode == http.StatusServiceUnavailable
+	}
+
+	return errors.Is(err, context.DeadlineExceeded)
+}
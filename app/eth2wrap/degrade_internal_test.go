@@ -0,0 +1,99 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+func TestDegrader(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	d := newDegrader(func() time.Time { return now })
+
+	tooManyRequests := &eth2api.Error{StatusCode: http.StatusTooManyRequests}
+
+	requireAdmitted := func(t *testing.T, lowAdmitted, normalAdmitted bool) {
+		t.Helper()
+
+		for label, admitted := range map[string]bool{
+			"node_peer_count":                lowAdmitted,
+			"submit_validator_registrations": lowAdmitted,
+			"proposer_config":                normalAdmitted,
+			"unknown":                        normalAdmitted,
+			"spec":                           true,
+			"validators":                     true,
+			"attestation_data":               true,
+			"submit_proposal":                true,
+		} {
+			err := d.Admit(ctx, label)
+			if admitted {
+				require.NoError(t, err, label)
+			} else {
+				require.ErrorIs(t, err, ErrShed, label)
+			}
+		}
+	}
+
+	requireAdmitted(t, true, true)
+
+	// Non-overload errors are ignored.
+	for range overloadedThreshold {
+		d.Observe(ctx, "validators", &eth2api.Error{StatusCode: http.StatusNotFound})
+		d.Observe(ctx, "validators", errors.New("other"))
+		d.Observe(ctx, "validators", nil)
+	}
+	requireAdmitted(t, true, true)
+
+	for range degradedThreshold {
+		d.Observe(ctx, "attestation_data", tooManyRequests)
+	}
+	requireAdmitted(t, false, true)
+
+	for range overloadedThreshold - degradedThreshold {
+		d.Observe(ctx, "attestation_data", context.DeadlineExceeded)
+	}
+	requireAdmitted(t, false, false)
+
+	// Recovers once the overload responses are outside the window.
+	now = now.Add(overloadWindow + time.Second)
+	requireAdmitted(t, true, true)
+
+	// A nil degrader admits all requests.
+	var nilDegrader *degrader
+	require.NoError(t, nilDegrader.Admit(ctx, "node_peer_count"))
+	nilDegrader.Observe(ctx, "node_peer_count", tooManyRequests)
+}
+
+func TestIsOverload(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{name: "nil", ctx: context.Background(), err: nil, want: false},
+		{name: "429", ctx: context.Background(), err: &eth2api.Error{StatusCode: http.StatusTooManyRequests}, want: true},
+		{name: "503", ctx: context.Background(), err: errors.Wrap(&eth2api.Error{StatusCode: http.StatusServiceUnavailable}, "wrapped"), want: true},
+		{name: "500", ctx: context.Background(), err: &eth2api.Error{StatusCode: http.StatusInternalServerError}, want: false},
+		{name: "timeout", ctx: context.Background(), err: errors.Wrap(context.DeadlineExceeded, "wrapped"), want: true},
+		{name: "caller cancelled", ctx: cancelled, err: context.DeadlineExceeded, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, isOverload(test.ctx, test.err))
+		})
+	}
+}
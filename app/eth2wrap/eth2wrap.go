@@ -86,6 +86,27 @@ func NewMultiHTTP(timeout time.Duration, forkVersion [4]byte, headers map[string
 	)
 }
 
+// SetBeaconNodes replaces the beacon node endpoints of a client returned by NewMultiHTTP, optionally wrapped with
+// synthetic duties, without restarting. Requests in flight complete with the previous endpoints.
+func SetBeaconNodes(cl Client, timeout time.Duration, forkVersion [4]byte, headers map[string]string, addrs []string, fallbackAddrs []string) error {
+	if len(addrs) == 0 {
+		return errors.New("beacon node endpoints empty")
+	}
+
+	switch c := cl.(type) {
+	case multi:
+		c.setBackends(newClients(timeout, forkVersion, headers, addrs), newClients(timeout, forkVersion, headers, fallbackAddrs))
+	case *multi:
+		c.setBackends(newClients(timeout, forkVersion, headers, addrs), newClients(timeout, forkVersion, headers, fallbackAddrs))
+	case *synthWrapper:
+		return SetBeaconNodes(c.Client, timeout, forkVersion, headers, addrs, fallbackAddrs)
+	default:
+		return errors.New("client doesn't support replacing beacon nodes", z.Str("client", cl.Name()))
+	}
+
+	return nil
+}
+
 // NewSimnetFallbacks returns a slice of Client initialized with the provided settings. Used in Simnet setting.
 func NewSimnetFallbacks(timeout time.Duration, forkVersion [4]byte, headers map[string]string, addresses []string) []Client {
 	var clients []Client
@@ -120,7 +141,7 @@ func newBeaconClient(timeout time.Duration, forkVersion [4]byte, headers map[str
 	cl := newLazy(func(ctx context.Context) (Client, error) {
 		eth2Svc, err := eth2http.New(ctx, parameters...)
 		if err != nil {
-			return nil, wrapError(ctx, err, "new eth2 client", z.Str("address", address))
+			return nil, wrapError(ctx, err, "new eth2 client", z.Redact(z.Str("address", address)))
 		}
 		eth2Http, ok := eth2Svc.(*eth2http.Service)
 		if !ok {
@@ -143,8 +164,10 @@ type provideArgs struct {
 // provide calls the work function with each client in parallel, returning the
 // first successful result or first error.
 // The bestIdxFunc is called with the index of the client returning a successful response.
+// The degrader sheds the request identified by label if the beacon nodes are overloaded.
 func provide[O any](ctx context.Context, clients []Client, fallbacks []Client,
 	work forkjoin.Work[provideArgs, O], isSuccessFunc func(O) bool, bestSelector *bestSelector,
+	degrader *degrader, label string,
 ) (O, error) {
 	if isSuccessFunc == nil {
 		isSuccessFunc = func(O) bool { return true }
@@ -152,6 +175,10 @@ func provide[O any](ctx context.Context, clients []Client, fallbacks []Client,
 
 	zero := func() O { var z O; return z }()
 
+	if err := degrader.Admit(ctx, label); err != nil {
+		return zero, err
+	}
+
 	runForkJoin := func(clients []Client, isFallback bool) (O, error) {
 		if isFallback {
 			usingFallbackGauge.Set(1)
@@ -174,6 +201,8 @@ func provide[O any](ctx context.Context, clients []Client, fallbacks []Client,
 			hasNokResp bool
 		)
 		for res := range join() {
+			degrader.Observe(ctx, label, res.Err)
+
 			if ctx.Err() != nil {
 				return zero, ctx.Err()
 			} else if res.Err == nil && isSuccessFunc(res.Output) {
@@ -208,12 +237,14 @@ func provide[O any](ctx context.Context, clients []Client, fallbacks []Client,
 type empty struct{}
 
 // submit proxies provide, but returns nil instead of a successful result.
-func submit(ctx context.Context, clients []Client, fallbacks []Client, work func(context.Context, provideArgs) error, selector *bestSelector) error {
+func submit(ctx context.Context, clients []Client, fallbacks []Client, work func(context.Context, provideArgs) error,
+	selector *bestSelector, degrader *degrader, label string,
+) error {
 	_, err := provide(ctx, clients, fallbacks,
 		func(ctx context.Context, args provideArgs) (empty, error) {
 			return empty{}, work(ctx, args)
 		},
-		nil, selector,
+		nil, selector, degrader, label,
 	)
 
 	return err
@@ -249,8 +280,11 @@ func incError(endpoint string) {
 
 // wrapError returns the error as a wrapped structured error.
 func wrapError(ctx context.Context, err error, label string, fields ...z.Field) error {
+	var code errors.Code
+
 	// Decompose go-eth2-client http errors
 	if apiErr := new(eth2api.Error); errors.As(err, &apiErr) {
+		code = errors.CodeBeaconErrorResponse
 		err = errors.New("nok http response",
 			z.Int("status_code", apiErr.StatusCode),
 			z.Str("endpoint", apiErr.Endpoint),
@@ -262,10 +296,13 @@ func wrapError(ctx context.Context, err error, label string, fields ...z.Field)
 	// Decompose url errors
 	if uerr := new(url.Error); errors.As(err, &uerr) {
 		msg := "http request aborted" // The request didn't complete, no http response
+		code = errors.CodeBeaconUnreachable
 		if ctx.Err() != nil {
 			msg = "caller cancelled http request"
+			code = 0
 		} else if errors.Is(uerr.Err, context.DeadlineExceeded) || errors.Is(uerr.Err, context.Canceled) {
 			msg = "http request timeout"
+			code = errors.CodeBeaconTimeout
 		}
 		err = errors.Wrap(uerr.Err, msg,
 			z.Str("url", uerr.URL),
@@ -276,15 +313,23 @@ func wrapError(ctx context.Context, err error, label string, fields ...z.Field)
 	// Decompose net errors
 	if nerr := new(net.OpError); errors.As(err, &nerr) {
 		msg := "network operation error: " + nerr.Op
+		code = errors.CodeBeaconUnreachable
 		if ctx.Err() != nil {
 			msg = "caller cancelled network operation: " + nerr.Op
+			code = 0
 		} else if errors.Is(nerr.Err, context.DeadlineExceeded) || errors.Is(nerr.Err, context.Canceled) {
 			msg = "network operation timeout: " + nerr.Op
+			code = errors.CodeBeaconTimeout
 		}
-		err = errors.Wrap(nerr.Err, msg, z.Any("address", nerr.Addr))
+		err = errors.Wrap(nerr.Err, msg, z.Redact(z.Any("address", nerr.Addr)))
+	}
+
+	err = errors.Wrap(err, "beacon api "+label, append(fields, z.Str("label", label))...)
+	if code != 0 {
+		err = errors.WithCode(err, code)
 	}
 
-	return errors.Wrap(err, "beacon api "+label, append(fields, z.Str("label", label))...)
+	return err
 }
 
 // newBestSelector returns a new bestSelector.
@@ -321,6 +366,15 @@ func (s *bestSelector) BestAddress() (address string, ok bool) {
 	return address, ok
 }
 
+// Reset resets the counters, e.g. when the clients are replaced.
+func (s *bestSelector) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts = make(map[string]int)
+	s.start = time.Now()
+}
+
 // Increment increments the counter for the given address.
 func (s *bestSelector) Increment(address string) {
 	s.mu.Lock()
@@ -25,6 +25,7 @@ type Client interface {
 	eth2exp.ProposerConfigProvider
 	BlockAttestationsProvider
 	NodePeerCountProvider
+	RewardsProvider
 
 	CachedValidatorsProvider
 	SetValidatorCache(func(context.Context) (ActiveValidators, CompleteValidators, error))
@@ -72,11 +73,11 @@ type Client interface {
 func (m multi) SlotDuration(ctx context.Context) (time.Duration, error) {
 	const label = "slot_duration"
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (time.Duration, error) {
 			return args.client.SlotDuration(ctx)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -94,11 +95,11 @@ func (m multi) SlotDuration(ctx context.Context) (time.Duration, error) {
 func (m multi) SlotsPerEpoch(ctx context.Context) (uint64, error) {
 	const label = "slots_per_epoch"
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (uint64, error) {
 			return args.client.SlotsPerEpoch(ctx)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -114,11 +115,11 @@ func (m multi) SignedBeaconBlock(ctx context.Context, opts *api.SignedBeaconBloc
 	const label = "signed_beacon_block"
 	defer latency(ctx, label, false)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[*spec.VersionedSignedBeaconBlock], error) {
 			return args.client.SignedBeaconBlock(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -134,11 +135,11 @@ func (m multi) AggregateAttestation(ctx context.Context, opts *api.AggregateAtte
 	const label = "aggregate_attestation"
 	defer latency(ctx, label, false)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[*phase0.Attestation], error) {
 			return args.client.AggregateAttestation(ctx, opts)
 		},
-		isAggregateAttestationOk, m.selector,
+		isAggregateAttestationOk, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -154,11 +155,11 @@ func (m multi) SubmitAggregateAttestations(ctx context.Context, aggregateAndProo
 	const label = "submit_aggregate_attestations"
 	defer latency(ctx, label, false)()
 
-	err := submit(ctx, m.clients, m.fallbacks,
+	err := submit(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) error {
 			return args.client.SubmitAggregateAttestations(ctx, aggregateAndProofs)
 		},
-		m.selector,
+		m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -174,11 +175,11 @@ func (m multi) AttestationData(ctx context.Context, opts *api.AttestationDataOpt
 	const label = "attestation_data"
 	defer latency(ctx, label, false)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[*phase0.AttestationData], error) {
 			return args.client.AttestationData(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -194,11 +195,11 @@ func (m multi) SubmitAttestations(ctx context.Context, attestations []*phase0.At
 	const label = "submit_attestations"
 	defer latency(ctx, label, false)()
 
-	err := submit(ctx, m.clients, m.fallbacks,
+	err := submit(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) error {
 			return args.client.SubmitAttestations(ctx, attestations)
 		},
-		m.selector,
+		m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -214,11 +215,11 @@ func (m multi) AttesterDuties(ctx context.Context, opts *api.AttesterDutiesOpts)
 	const label = "attester_duties"
 	defer latency(ctx, label, false)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[[]*apiv1.AttesterDuty], error) {
 			return args.client.AttesterDuties(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -234,11 +235,11 @@ func (m multi) AttesterDuties(ctx context.Context, opts *api.AttesterDutiesOpts)
 func (m multi) DepositContract(ctx context.Context, opts *api.DepositContractOpts) (*api.Response[*apiv1.DepositContract], error) {
 	const label = "deposit_contract"
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[*apiv1.DepositContract], error) {
 			return args.client.DepositContract(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -255,11 +256,11 @@ func (m multi) SyncCommitteeDuties(ctx context.Context, opts *api.SyncCommitteeD
 	const label = "sync_committee_duties"
 	defer latency(ctx, label, false)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[[]*apiv1.SyncCommitteeDuty], error) {
 			return args.client.SyncCommitteeDuties(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -275,11 +276,11 @@ func (m multi) SubmitSyncCommitteeMessages(ctx context.Context, messages []*alta
 	const label = "submit_sync_committee_messages"
 	defer latency(ctx, label, false)()
 
-	err := submit(ctx, m.clients, m.fallbacks,
+	err := submit(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) error {
 			return args.client.SubmitSyncCommitteeMessages(ctx, messages)
 		},
-		m.selector,
+		m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -295,11 +296,11 @@ func (m multi) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptio
 	const label = "submit_sync_committee_subscriptions"
 	defer latency(ctx, label, false)()
 
-	err := submit(ctx, m.clients, m.fallbacks,
+	err := submit(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) error {
 			return args.client.SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
 		},
-		m.selector,
+		m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -315,11 +316,11 @@ func (m multi) SyncCommitteeContribution(ctx context.Context, opts *api.SyncComm
 	const label = "sync_committee_contribution"
 	defer latency(ctx, label, false)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[*altair.SyncCommitteeContribution], error) {
 			return args.client.SyncCommitteeContribution(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -335,11 +336,11 @@ func (m multi) SubmitSyncCommitteeContributions(ctx context.Context, contributio
 	const label = "submit_sync_committee_contributions"
 	defer latency(ctx, label, false)()
 
-	err := submit(ctx, m.clients, m.fallbacks,
+	err := submit(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) error {
 			return args.client.SubmitSyncCommitteeContributions(ctx, contributionAndProofs)
 		},
-		m.selector,
+		m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -355,11 +356,11 @@ func (m multi) Proposal(ctx context.Context, opts *api.ProposalOpts) (*api.Respo
 	const label = "proposal"
 	defer latency(ctx, label, true)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[*api.VersionedProposal], error) {
 			return args.client.Proposal(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -375,11 +376,11 @@ func (m multi) Proposal(ctx context.Context, opts *api.ProposalOpts) (*api.Respo
 func (m multi) BeaconBlockRoot(ctx context.Context, opts *api.BeaconBlockRootOpts) (*api.Response[*phase0.Root], error) {
 	const label = "beacon_block_root"
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[*phase0.Root], error) {
 			return args.client.BeaconBlockRoot(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -395,11 +396,11 @@ func (m multi) SubmitProposal(ctx context.Context, opts *api.SubmitProposalOpts)
 	const label = "submit_proposal"
 	defer latency(ctx, label, false)()
 
-	err := submit(ctx, m.clients, m.fallbacks,
+	err := submit(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) error {
 			return args.client.SubmitProposal(ctx, opts)
 		},
-		m.selector,
+		m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -415,11 +416,11 @@ func (m multi) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscript
 	const label = "submit_beacon_committee_subscriptions"
 	defer latency(ctx, label, false)()
 
-	err := submit(ctx, m.clients, m.fallbacks,
+	err := submit(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) error {
 			return args.client.SubmitBeaconCommitteeSubscriptions(ctx, subscriptions)
 		},
-		m.selector,
+		m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -435,11 +436,11 @@ func (m multi) SubmitBlindedProposal(ctx context.Context, opts *api.SubmitBlinde
 	const label = "submit_blinded_proposal"
 	defer latency(ctx, label, false)()
 
-	err := submit(ctx, m.clients, m.fallbacks,
+	err := submit(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) error {
 			return args.client.SubmitBlindedProposal(ctx, opts)
 		},
-		m.selector,
+		m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -455,11 +456,11 @@ func (m multi) SubmitValidatorRegistrations(ctx context.Context, registrations [
 	const label = "submit_validator_registrations"
 	defer latency(ctx, label, false)()
 
-	err := submit(ctx, m.clients, m.fallbacks,
+	err := submit(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) error {
 			return args.client.SubmitValidatorRegistrations(ctx, registrations)
 		},
-		m.selector,
+		m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -475,11 +476,11 @@ func (m multi) Fork(ctx context.Context, opts *api.ForkOpts) (*api.Response[*pha
 	const label = "fork"
 	defer latency(ctx, label, false)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[*phase0.Fork], error) {
 			return args.client.Fork(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -495,11 +496,11 @@ func (m multi) ForkSchedule(ctx context.Context, opts *api.ForkScheduleOpts) (*a
 	const label = "fork_schedule"
 	defer latency(ctx, label, false)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[[]*phase0.Fork], error) {
 			return args.client.ForkSchedule(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -515,11 +516,11 @@ func (m multi) ForkSchedule(ctx context.Context, opts *api.ForkScheduleOpts) (*a
 func (m multi) Genesis(ctx context.Context, opts *api.GenesisOpts) (*api.Response[*apiv1.Genesis], error) {
 	const label = "genesis"
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[*apiv1.Genesis], error) {
 			return args.client.Genesis(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -535,11 +536,11 @@ func (m multi) NodeSyncing(ctx context.Context, opts *api.NodeSyncingOpts) (*api
 	const label = "node_syncing"
 	defer latency(ctx, label, false)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[*apiv1.SyncState], error) {
 			return args.client.NodeSyncing(ctx, opts)
 		},
-		isSyncStateOk, m.selector,
+		isSyncStateOk, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -555,11 +556,11 @@ func (m multi) NodeSyncing(ctx context.Context, opts *api.NodeSyncingOpts) (*api
 func (m multi) NodeVersion(ctx context.Context, opts *api.NodeVersionOpts) (*api.Response[string], error) {
 	const label = "node_version"
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[string], error) {
 			return args.client.NodeVersion(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -576,11 +577,11 @@ func (m multi) SubmitProposalPreparations(ctx context.Context, preparations []*a
 	const label = "submit_proposal_preparations"
 	defer latency(ctx, label, true)()
 
-	err := submit(ctx, m.clients, m.fallbacks,
+	err := submit(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) error {
 			return args.client.SubmitProposalPreparations(ctx, preparations)
 		},
-		m.selector,
+		m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -596,11 +597,11 @@ func (m multi) ProposerDuties(ctx context.Context, opts *api.ProposerDutiesOpts)
 	const label = "proposer_duties"
 	defer latency(ctx, label, false)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[[]*apiv1.ProposerDuty], error) {
 			return args.client.ProposerDuties(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -616,11 +617,11 @@ func (m multi) ProposerDuties(ctx context.Context, opts *api.ProposerDutiesOpts)
 func (m multi) Spec(ctx context.Context, opts *api.SpecOpts) (*api.Response[map[string]any], error) {
 	const label = "spec"
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[map[string]any], error) {
 			return args.client.Spec(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -636,11 +637,11 @@ func (m multi) Validators(ctx context.Context, opts *api.ValidatorsOpts) (*api.R
 	const label = "validators"
 	defer latency(ctx, label, true)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
 			return args.client.Validators(ctx, opts)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -656,11 +657,11 @@ func (m multi) SubmitVoluntaryExit(ctx context.Context, voluntaryExit *phase0.Si
 	const label = "submit_voluntary_exit"
 	defer latency(ctx, label, false)()
 
-	err := submit(ctx, m.clients, m.fallbacks,
+	err := submit(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) error {
 			return args.client.SubmitVoluntaryExit(ctx, voluntaryExit)
 		},
-		m.selector,
+		m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -676,11 +677,11 @@ func (m multi) SubmitVoluntaryExit(ctx context.Context, voluntaryExit *phase0.Si
 func (m multi) Domain(ctx context.Context, domainType phase0.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
 	const label = "domain"
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (phase0.Domain, error) {
 			return args.client.Domain(ctx, domainType, epoch)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -699,11 +700,11 @@ func (m multi) Domain(ctx context.Context, domainType phase0.DomainType, epoch p
 func (m multi) GenesisDomain(ctx context.Context, domainType phase0.DomainType) (phase0.Domain, error) {
 	const label = "genesis_domain"
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (phase0.Domain, error) {
 			return args.client.GenesisDomain(ctx, domainType)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
@@ -719,11 +720,11 @@ func (m multi) GenesisDomain(ctx context.Context, domainType phase0.DomainType)
 func (m multi) GenesisTime(ctx context.Context) (time.Time, error) {
 	const label = "genesis_time"
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (time.Time, error) {
 			return args.client.GenesisTime(ctx)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 
 	if err != nil {
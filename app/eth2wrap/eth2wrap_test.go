@@ -26,7 +26,12 @@ import (
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/bcast"
 	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/eth2exp"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/testutil"
 	"github.com/obolnetwork/charon/testutil/beaconmock"
 )
@@ -401,6 +406,112 @@ func TestBlockAttestations(t *testing.T) {
 	require.Empty(t, resp)
 }
 
+func TestRewards(t *testing.T) {
+	blockStatus := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/rewards/attestations/10":
+			require.Equal(t, http.MethodPost, r.Method)
+			var ids []string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&ids))
+			require.Equal(t, []string{"1", "2"}, ids)
+
+			_, _ = w.Write([]byte(`{"data":{
+				"ideal_rewards":[{"effective_balance":"32000000000","head":"2000","target":"4000","source":"2000","inactivity":"0"}],
+				"total_rewards":[{"validator_index":"1","head":"2000","target":"4000","source":"2000","inactivity":"0"},
+					{"validator_index":"2","head":"0","target":"-4000","source":"-2000","inactivity":"0"}]}}`))
+		case "/eth/v1/beacon/rewards/blocks/123":
+			require.Equal(t, http.MethodGet, r.Method)
+			w.WriteHeader(blockStatus)
+			_, _ = w.Write([]byte(`{"data":{"proposer_index":"1","total":"40000000","attestations":"35000000",
+				"sync_aggregate":"5000000","proposer_slashings":"0","attester_slashings":"0"}}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	cl := eth2wrap.NewHTTPAdapterForT(t, srv.URL, nil, time.Hour)
+
+	attRewards, err := cl.AttestationRewards(context.Background(), 10, []eth2p0.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, &eth2wrap.AttestationRewards{
+		IdealRewards: []eth2wrap.IdealAttestationReward{{EffectiveBalance: 32e9, Head: 2000, Target: 4000, Source: 2000}},
+		TotalRewards: []eth2wrap.AttestationReward{
+			{ValidatorIndex: 1, Head: 2000, Target: 4000, Source: 2000},
+			{ValidatorIndex: 2, Target: -4000, Source: -2000},
+		},
+	}, attRewards)
+
+	blockRewards, err := cl.BlockRewards(context.Background(), "123")
+	require.NoError(t, err)
+	require.Equal(t, &eth2wrap.BlockRewards{
+		ProposerIndex: 1,
+		Total:         40_000_000,
+		Attestations:  35_000_000,
+		SyncAggregate: 5_000_000,
+	}, blockRewards)
+
+	blockStatus = http.StatusNotFound
+	blockRewards, err = cl.BlockRewards(context.Background(), "123")
+	require.NoError(t, err)
+	require.Nil(t, blockRewards)
+}
+
+// TestOverloadedDutyPath tests that the attestation signing and broadcast path is not shed when the beacon node is overloaded.
+func TestOverloadedDutyPath(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	tooManyRequests := &eth2api.Error{StatusCode: http.StatusTooManyRequests}
+	bmock.ProposerConfigFunc = func(context.Context) (*eth2exp.ProposerConfigResponse, error) {
+		return nil, tooManyRequests
+	}
+
+	var submitted []*eth2p0.Attestation
+	bmock.SubmitAttestationsFunc = func(_ context.Context, atts []*eth2p0.Attestation) error {
+		submitted = append(submitted, atts...)
+		return nil
+	}
+
+	eth2Cl := eth2wrap.NewMultiForT([]eth2wrap.Client{bmock}, nil)
+
+	// Overload the beacon node until normal priority requests are shed.
+	for range 10 {
+		_, err := eth2Cl.ProposerConfig(ctx)
+		require.Error(t, err)
+	}
+	_, err = eth2Cl.ProposerConfig(ctx)
+	require.ErrorIs(t, err, eth2wrap.ErrShed)
+
+	// Signing and broadcasting an attestation still succeeds.
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+	pubkey, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	att := testutil.RandomAttestation()
+	root, err := att.Data.HashTreeRoot()
+	require.NoError(t, err)
+
+	sigData, err := signing.GetDataRoot(ctx, eth2Cl, signing.DomainBeaconAttester, att.Data.Target.Epoch, root)
+	require.NoError(t, err)
+	sig, err := tbls.Sign(secret, sigData[:])
+	require.NoError(t, err)
+	att.Signature = eth2p0.BLSSignature(sig)
+
+	require.NoError(t, signing.Verify(ctx, eth2Cl, signing.DomainBeaconAttester, att.Data.Target.Epoch, root, att.Signature, pubkey))
+
+	bcaster, err := bcast.New(ctx, eth2Cl)
+	require.NoError(t, err)
+	require.NoError(t, bcaster.Broadcast(ctx, core.NewAttesterDuty(uint64(att.Data.Slot)), core.SignedDataSet{
+		testutil.RandomCorePubKey(t): core.Attestation{Attestation: *att},
+	}))
+	require.Equal(t, []*eth2p0.Attestation{att}, submitted)
+}
+
 // TestOneError tests the case where one of the servers returns errors.
 func TestOneError(t *testing.T) {
 	// Start an erroring server.
@@ -430,6 +541,38 @@ func TestOneError(t *testing.T) {
 	require.Equal(t, bmock.Address(), eth2Cl.Address())
 }
 
+func TestSetBeaconNodes(t *testing.T) {
+	// Start an erroring server.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	eth2Cl, err := eth2wrap.NewMultiHTTP(time.Second, [4]byte{}, nil, []string{srv.URL}, nil)
+	require.NoError(t, err)
+
+	_, err = eth2Cl.Spec(ctx, &eth2api.SpecOpts{})
+	require.Error(t, err)
+
+	// Replace the erroring server with the beacon mock.
+	err = eth2wrap.SetBeaconNodes(eth2wrap.WithSyntheticDuties(eth2Cl), time.Second, [4]byte{}, nil, []string{bmock.Address()}, nil)
+	require.NoError(t, err)
+
+	_, err = eth2Cl.Spec(ctx, &eth2api.SpecOpts{})
+	require.NoError(t, err)
+	require.Equal(t, bmock.Address(), eth2Cl.Address())
+
+	err = eth2wrap.SetBeaconNodes(eth2Cl, time.Second, [4]byte{}, nil, nil, nil)
+	require.ErrorContains(t, err, "beacon node endpoints empty")
+
+	err = eth2wrap.SetBeaconNodes(bmock, time.Second, [4]byte{}, nil, []string{srv.URL}, nil)
+	require.ErrorContains(t, err, "doesn't support replacing beacon nodes")
+}
+
 // TestOneTimeout tests the case where one of the servers times out.
 func TestOneTimeout(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
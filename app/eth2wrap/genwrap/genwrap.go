@@ -52,6 +52,7 @@ type Client interface {
     eth2exp.ProposerConfigProvider
     BlockAttestationsProvider
     NodePeerCountProvider
+    RewardsProvider
 
     CachedValidatorsProvider
     SetValidatorCache(func(context.Context) (ActiveValidators, CompleteValidators, error))
@@ -71,11 +72,11 @@ type Client interface {
 		{{if .Latency}}defer latency(ctx, label, {{.Log}})() {{end}}
 
 
-		{{.ResultNames}} := {{.DoFunc}}(ctx, m.clients, m.fallbacks,
+		{{.ResultNames}} := {{.DoFunc}}(ctx, m.clients(), m.fallbacks(),
 			func(ctx context.Context, args provideArgs) ({{.ResultTypes}}){
 				return args.client.{{.Name}}({{.ParamNames}})
 			},
-			{{.SuccessFunc}} m.selector,
+			{{.SuccessFunc}} m.selector, m.degrader, label,
 		)
 
 		if err != nil {
@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -41,6 +42,51 @@ type NodePeerCountProvider interface {
 	NodePeerCount(ctx context.Context) (int, error)
 }
 
+// RewardsProvider is the interface for providing validator rewards.
+// It is a standard beacon API endpoint not implemented by eth2client.
+// See https://ethereum.github.io/beacon-APIs/#/Rewards.
+type RewardsProvider interface {
+	// AttestationRewards provides the attestation rewards of the validators for the epoch.
+	AttestationRewards(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (*AttestationRewards, error)
+	// BlockRewards provides the proposer rewards of the block or nil if no block exists.
+	BlockRewards(ctx context.Context, blockID string) (*BlockRewards, error)
+}
+
+// AttestationRewards are the actual and ideal attestation rewards of validators for an epoch in gwei.
+// Penalties are negative rewards.
+type AttestationRewards struct {
+	IdealRewards []IdealAttestationReward `json:"ideal_rewards"`
+	TotalRewards []AttestationReward      `json:"total_rewards"`
+}
+
+// IdealAttestationReward is the reward of a validator with the effective balance attesting perfectly.
+type IdealAttestationReward struct {
+	EffectiveBalance eth2p0.Gwei `json:"effective_balance,string"`
+	Head             int64       `json:"head,string"`
+	Target           int64       `json:"target,string"`
+	Source           int64       `json:"source,string"`
+	Inactivity       int64       `json:"inactivity,string"`
+}
+
+// AttestationReward is the actual attestation reward of a validator.
+type AttestationReward struct {
+	ValidatorIndex eth2p0.ValidatorIndex `json:"validator_index,string"`
+	Head           int64                 `json:"head,string"`
+	Target         int64                 `json:"target,string"`
+	Source         int64                 `json:"source,string"`
+	Inactivity     int64                 `json:"inactivity,string"`
+}
+
+// BlockRewards are the proposer rewards of a block in gwei.
+type BlockRewards struct {
+	ProposerIndex     eth2p0.ValidatorIndex `json:"proposer_index,string"`
+	Total             int64                 `json:"total,string"`
+	Attestations      int64                 `json:"attestations,string"`
+	SyncAggregate     int64                 `json:"sync_aggregate,string"`
+	ProposerSlashings int64                 `json:"proposer_slashings,string"`
+	AttesterSlashings int64                 `json:"attester_slashings,string"`
+}
+
 // NewHTTPAdapterForT returns a http adapter for testing non-eth2service methods as it is nil.
 func NewHTTPAdapterForT(_ *testing.T, address string, headers map[string]string, timeout time.Duration) Client {
 	return newHTTPAdapter(nil, address, headers, timeout)
@@ -234,6 +280,54 @@ func (h *httpAdapter) NodePeerCount(ctx context.Context) (int, error) {
 	return resp.Data.Connected, nil
 }
 
+// AttestationRewards provides the attestation rewards of the validators for the epoch.
+// See https://ethereum.github.io/beacon-APIs/#/Rewards/getAttestationsRewards.
+func (h *httpAdapter) AttestationRewards(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (*AttestationRewards, error) {
+	ids := make([]string, 0, len(indices))
+	for _, index := range indices {
+		ids = append(ids, strconv.FormatUint(uint64(index), 10))
+	}
+
+	reqBody, err := json.Marshal(ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal attestation rewards request")
+	}
+
+	path := fmt.Sprintf("/eth/v1/beacon/rewards/attestations/%d", epoch)
+	respBody, err := httpPost(ctx, h.address, path, bytes.NewReader(reqBody), h.headers, h.timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "request attestation rewards")
+	}
+
+	var resp attestationRewardsJSON
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to parse attestation rewards response")
+	}
+
+	return &resp.Data, nil
+}
+
+// BlockRewards provides the proposer rewards of the block or nil if no block exists.
+// See https://ethereum.github.io/beacon-APIs/#/Rewards/getBlockRewards.
+func (h *httpAdapter) BlockRewards(ctx context.Context, blockID string) (*BlockRewards, error) {
+	path := fmt.Sprintf("/eth/v1/beacon/rewards/blocks/%s", blockID)
+	respBody, statusCode, err := httpGet(ctx, h.address, path, h.headers, h.timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "request block rewards")
+	} else if statusCode == http.StatusNotFound {
+		return nil, nil // No block for slot, so no rewards.
+	} else if statusCode != http.StatusOK {
+		return nil, errors.New("request block rewards failed", z.Int("status", statusCode), z.Str("body", string(respBody)))
+	}
+
+	var resp blockRewardsJSON
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to parse block rewards response")
+	}
+
+	return &resp.Data, nil
+}
+
 // Domain returns the signing domain for a given domain type.
 // After EIP-7044, the VOLUNTARY_EXIT domain must always return a domain relative to the Capella hardfork.
 // This method returns just that for that domain type, otherwise follows the standard go-eth2-client flow.
@@ -262,6 +356,14 @@ type attestationsJSON struct {
 	Data []*eth2p0.Attestation `json:"data"`
 }
 
+type attestationRewardsJSON struct {
+	Data AttestationRewards `json:"data"`
+}
+
+type blockRewardsJSON struct {
+	Data BlockRewards `json:"data"`
+}
+
 type peerCountJSON struct {
 	Data struct {
 		Connected int `json:"connected,string"`
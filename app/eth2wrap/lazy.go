@@ -201,6 +201,24 @@ func (l *lazy) BlockAttestations(ctx context.Context, stateID string) ([]*eth2p0
 	return cl.BlockAttestations(ctx, stateID)
 }
 
+func (l *lazy) AttestationRewards(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (*AttestationRewards, error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.AttestationRewards(ctx, epoch, indices)
+}
+
+func (l *lazy) BlockRewards(ctx context.Context, blockID string) (*BlockRewards, error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.BlockRewards(ctx, blockID)
+}
+
 func (l *lazy) NodePeerCount(ctx context.Context) (int, error) {
 	cl, err := l.getOrCreateClient(ctx)
 	if err != nil {
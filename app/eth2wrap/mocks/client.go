@@ -198,6 +198,36 @@ func (_m *Client) AttestationData(ctx context.Context, opts *api.AttestationData
 	return r0, r1
 }
 
+// AttestationRewards provides a mock function with given fields: ctx, epoch, indices
+func (_m *Client) AttestationRewards(ctx context.Context, epoch phase0.Epoch, indices []phase0.ValidatorIndex) (*eth2wrap.AttestationRewards, error) {
+	ret := _m.Called(ctx, epoch, indices)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AttestationRewards")
+	}
+
+	var r0 *eth2wrap.AttestationRewards
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, phase0.Epoch, []phase0.ValidatorIndex) (*eth2wrap.AttestationRewards, error)); ok {
+		return rf(ctx, epoch, indices)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, phase0.Epoch, []phase0.ValidatorIndex) *eth2wrap.AttestationRewards); ok {
+		r0 = rf(ctx, epoch, indices)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*eth2wrap.AttestationRewards)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, phase0.Epoch, []phase0.ValidatorIndex) error); ok {
+		r1 = rf(ctx, epoch, indices)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AttesterDuties provides a mock function with given fields: ctx, opts
 func (_m *Client) AttesterDuties(ctx context.Context, opts *api.AttesterDutiesOpts) (*api.Response[[]*v1.AttesterDuty], error) {
 	ret := _m.Called(ctx, opts)
@@ -288,6 +318,36 @@ func (_m *Client) BlockAttestations(ctx context.Context, stateID string) ([]*pha
 	return r0, r1
 }
 
+// BlockRewards provides a mock function with given fields: ctx, blockID
+func (_m *Client) BlockRewards(ctx context.Context, blockID string) (*eth2wrap.BlockRewards, error) {
+	ret := _m.Called(ctx, blockID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockRewards")
+	}
+
+	var r0 *eth2wrap.BlockRewards
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*eth2wrap.BlockRewards, error)); ok {
+		return rf(ctx, blockID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *eth2wrap.BlockRewards); ok {
+		r0 = rf(ctx, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*eth2wrap.BlockRewards)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CompleteValidators provides a mock function with given fields: ctx
 func (_m *Client) CompleteValidators(ctx context.Context) (eth2wrap.CompleteValidators, error) {
 	ret := _m.Called(ctx)
@@ -4,6 +4,8 @@ package eth2wrap
 
 import (
 	"context"
+	"sync/atomic"
+	"time"
 
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
@@ -12,35 +14,66 @@ import (
 
 // NewMultiForT creates a new mutil client for testing.
 func NewMultiForT(clients []Client, fallbacks []Client) Client {
-	return &multi{
-		clients:   clients,
-		fallbacks: fallbacks,
-		selector:  newBestSelector(bestPeriod),
-	}
+	m := newMulti(clients, fallbacks)
+	return &m
 }
 
-func newMulti(clients []Client, fallbacks []Client) Client {
-	return multi{
-		clients:   clients,
-		fallbacks: fallbacks,
-		selector:  newBestSelector(bestPeriod),
+func newMulti(clients []Client, fallbacks []Client) multi {
+	m := multi{
+		backends: new(atomic.Pointer[backends]),
+		selector: newBestSelector(bestPeriod),
+		degrader: newDegrader(time.Now),
 	}
+	m.setBackends(clients, fallbacks)
+
+	return m
 }
 
 // multi implements Client by wrapping multiple clients, calling them in parallel
 // and returning the first successful response.
 // It also adds prometheus metrics and error wrapping.
 // It also implements a "best client" selector.
+// It also sheds lower priority requests when the beacon nodes are overloaded.
 // When any of the Clients specified fails a request, it will re-try it on the specified
 // fallback endpoints, if any.
+// The backend clients can be replaced at runtime, e.g. when reloading the beacon node endpoints.
 type multi struct {
+	backends *atomic.Pointer[backends]
+	selector *bestSelector
+	degrader *degrader
+}
+
+// backends are the clients and fallback clients of a multi client.
+type backends struct {
 	clients   []Client
 	fallbacks []Client
-	selector  *bestSelector
+	valCache  func(context.Context) (ActiveValidators, CompleteValidators, error)
+}
+
+// setBackends replaces the clients and fallback clients. Requests in flight complete with the previous clients.
+func (m multi) setBackends(clients []Client, fallbacks []Client) {
+	next := &backends{clients: clients, fallbacks: fallbacks}
+	if prev := m.backends.Load(); prev != nil && prev.valCache != nil {
+		next.valCache = prev.valCache
+		for _, cl := range clients {
+			cl.SetValidatorCache(prev.valCache)
+		}
+	}
+
+	m.backends.Store(next)
+	m.selector.Reset()
+}
+
+func (m multi) clients() []Client {
+	return m.backends.Load().clients
+}
+
+func (m multi) fallbacks() []Client {
+	return m.backends.Load().fallbacks
 }
 
 func (m multi) SetForkVersion(forkVersion [4]byte) {
-	for _, cl := range m.clients {
+	for _, cl := range m.clients() {
 		cl.SetForkVersion(forkVersion)
 	}
 }
@@ -52,14 +85,14 @@ func (multi) Name() string {
 func (m multi) Address() string {
 	address, ok := m.selector.BestAddress()
 	if !ok {
-		return m.clients[0].Address()
+		return m.clients()[0].Address()
 	}
 
 	return address
 }
 
 func (m multi) IsActive() bool {
-	for _, cl := range m.clients {
+	for _, cl := range m.clients() {
 		if cl.IsActive() {
 			return true
 		}
@@ -69,7 +102,7 @@ func (m multi) IsActive() bool {
 }
 
 func (m multi) IsSynced() bool {
-	for _, cl := range m.clients {
+	for _, cl := range m.clients() {
 		if cl.IsSynced() {
 			return true
 		}
@@ -79,7 +112,10 @@ func (m multi) IsSynced() bool {
 }
 
 func (m multi) SetValidatorCache(valCache func(context.Context) (ActiveValidators, CompleteValidators, error)) {
-	for _, cl := range m.clients {
+	prev := m.backends.Load()
+	m.backends.Store(&backends{clients: prev.clients, fallbacks: prev.fallbacks, valCache: valCache})
+
+	for _, cl := range prev.clients {
 		cl.SetValidatorCache(valCache)
 	}
 }
@@ -88,11 +124,11 @@ func (m multi) ActiveValidators(ctx context.Context) (ActiveValidators, error) {
 	const label = "active_validators"
 	// No latency since this is a cached endpoint.
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (ActiveValidators, error) {
 			return args.client.ActiveValidators(ctx)
 		},
-		nil, nil,
+		nil, nil, nil, label,
 	)
 	if err != nil {
 		incError(label)
@@ -106,11 +142,11 @@ func (m multi) CompleteValidators(ctx context.Context) (CompleteValidators, erro
 	const label = "complete_validators"
 	// No latency since this is a cached endpoint.
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (CompleteValidators, error) {
 			return args.client.CompleteValidators(ctx)
 		},
-		nil, nil,
+		nil, nil, nil, label,
 	)
 	if err != nil {
 		incError(label)
@@ -124,11 +160,11 @@ func (m multi) ProposerConfig(ctx context.Context) (*eth2exp.ProposerConfigRespo
 	const label = "proposer_config"
 	defer latency(ctx, label, false)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (*eth2exp.ProposerConfigResponse, error) {
 			return args.client.ProposerConfig(ctx)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 	if err != nil {
 		incError(label)
@@ -142,11 +178,11 @@ func (m multi) AggregateBeaconCommitteeSelections(ctx context.Context, selection
 	const label = "aggregate_beacon_committee_selections"
 	defer latency(ctx, label, false)()
 
-	res0, err := provide(ctx, m.clients, m.fallbacks,
+	res0, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) ([]*eth2exp.BeaconCommitteeSelection, error) {
 			return args.client.AggregateBeaconCommitteeSelections(ctx, selections)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 	if err != nil {
 		incError(label)
@@ -160,12 +196,12 @@ func (m multi) AggregateSyncCommitteeSelections(ctx context.Context, selections
 	const label = "aggregate_sync_committee_selections"
 	defer latency(ctx, label, false)()
 
-	res, err := provide(ctx, m.clients, m.fallbacks,
+	res, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) ([]*eth2exp.SyncCommitteeSelection, error) {
 			return args.client.AggregateSyncCommitteeSelections(ctx, selections)
 		},
 
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 	if err != nil {
 		incError(label)
@@ -179,11 +215,47 @@ func (m multi) BlockAttestations(ctx context.Context, stateID string) ([]*eth2p0
 	const label = "block_attestations"
 	defer latency(ctx, label, false)()
 
-	res, err := provide(ctx, m.clients, m.fallbacks,
+	res, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) ([]*eth2p0.Attestation, error) {
 			return args.client.BlockAttestations(ctx, stateID)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res, err
+}
+
+func (m multi) AttestationRewards(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (*AttestationRewards, error) {
+	const label = "attestation_rewards"
+	defer latency(ctx, label, false)()
+
+	res, err := provide(ctx, m.clients(), m.fallbacks(),
+		func(ctx context.Context, args provideArgs) (*AttestationRewards, error) {
+			return args.client.AttestationRewards(ctx, epoch, indices)
+		},
+		nil, m.selector, m.degrader, label,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res, err
+}
+
+func (m multi) BlockRewards(ctx context.Context, blockID string) (*BlockRewards, error) {
+	const label = "block_rewards"
+	defer latency(ctx, label, false)()
+
+	res, err := provide(ctx, m.clients(), m.fallbacks(),
+		func(ctx context.Context, args provideArgs) (*BlockRewards, error) {
+			return args.client.BlockRewards(ctx, blockID)
+		},
+		nil, m.selector, m.degrader, label,
 	)
 	if err != nil {
 		incError(label)
@@ -197,11 +269,11 @@ func (m multi) NodePeerCount(ctx context.Context) (int, error) {
 	const label = "node_peer_count"
 	defer latency(ctx, label, false)()
 
-	res, err := provide(ctx, m.clients, m.fallbacks,
+	res, err := provide(ctx, m.clients(), m.fallbacks(),
 		func(ctx context.Context, args provideArgs) (int, error) {
 			return args.client.NodePeerCount(ctx)
 		},
-		nil, m.selector,
+		nil, m.selector, m.degrader, label,
 	)
 	if err != nil {
 		incError(label)
@@ -83,6 +83,16 @@ func (c *ValidatorCache) Trim() {
 	c.complete = nil
 }
 
+// SetPubKeys replaces the validator public keys to cache and trims the cache.
+func (c *ValidatorCache) SetPubKeys(pubkeys []eth2p0.BLSPubKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pubkeys = pubkeys
+	c.active = nil
+	c.complete = nil
+}
+
 // activeCached returns the cached active validators and true if they are available.
 func (c *ValidatorCache) activeCached() (ActiveValidators, bool) {
 	c.mu.RLock()
@@ -0,0 +1,138 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package eventbus provides a typed bus of cluster events (duty lifecycle, consensus failures and peer connections)
+// delivered asynchronously to external subscribers like webhooks and NATS, enabling custom automation around cluster events.
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// bufferSize is the number of events buffered per subscriber before events are dropped.
+const bufferSize = 1024
+
+// Type is the type of event.
+type Type string
+
+const (
+	TypeDutyScheduled    Type = "duty_scheduled"
+	TypeDutyDecided      Type = "duty_decided"
+	TypeDutyBroadcast    Type = "duty_broadcast"
+	TypeDutyFailed       Type = "duty_failed"
+	TypeConsensusFailed  Type = "consensus_failed"
+	TypePeerConnected    Type = "peer_connected"
+	TypePeerDisconnected Type = "peer_disconnected"
+
+	// TypeValidatorStateChanged is a validator lifecycle state transition, e.g. from pending to active.
+	TypeValidatorStateChanged Type = "validator_state_changed"
+)
+
+// Event is a cluster event. Fields not applicable to the event type are omitted.
+type Event struct {
+	Type Type      `json:"type"`
+	Time time.Time `json:"time"`
+	// Slot and Duty identify the duty of duty and consensus events, e.g. "attester".
+	Slot uint64 `json:"slot,omitempty"`
+	Duty string `json:"duty,omitempty"`
+	// Peer is the peer name of peer events.
+	Peer string `json:"peer,omitempty"`
+	// Reason and Error describe the cause of failure events.
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// Validator, State and PreviousState describe validator lifecycle events.
+	Validator     string `json:"validator,omitempty"`
+	State         string `json:"state,omitempty"`
+	PreviousState string `json:"previous_state,omitempty"`
+}
+
+// Subscriber handles events. Returned errors are logged and instrumented.
+type Subscriber func(context.Context, Event) error
+
+// New returns a new event bus.
+func New() *Bus {
+	return new(Bus)
+}
+
+// Bus delivers published events to subscribers.
+// Each subscriber receives events in order from its own buffer, so slow subscribers don't block
+// publishers or other subscribers. Events are dropped if a subscriber's buffer is full.
+type Bus struct {
+	mu   sync.Mutex
+	subs []subscription
+}
+
+// subscription is a named subscriber and its event buffer.
+type subscription struct {
+	name   string
+	fn     Subscriber
+	buffer chan Event
+}
+
+// Subscribe registers a named subscriber. It must be called before Run.
+func (b *Bus) Subscribe(name string, fn Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subs = append(b.subs, subscription{
+		name:   name,
+		fn:     fn,
+		buffer: make(chan Event, bufferSize),
+	})
+}
+
+// Publish publishes the event to all subscribers without blocking. The event time defaults to now.
+func (b *Bus) Publish(evt Event) {
+	if evt.Time.IsZero() {
+		evt.Time = time.Now()
+	}
+
+	publishedCounter.WithLabelValues(string(evt.Type)).Inc()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subs {
+		select {
+		case sub.buffer <- evt:
+		default:
+			droppedCounter.WithLabelValues(sub.name).Inc()
+		}
+	}
+}
+
+// Run delivers events to subscribers until the context is cancelled.
+func (b *Bus) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "eventbus")
+
+	b.mu.Lock()
+	subs := b.subs
+	b.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, sub := range subs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case evt := <-sub.buffer:
+					if err := sub.fn(ctx, evt); err != nil && ctx.Err() == nil {
+						errorsCounter.WithLabelValues(sub.name).Inc()
+						log.Warn(ctx, "Failed to deliver event", err,
+							z.Str("subscriber", sub.name), z.Str("type", string(evt.Type)))
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,86 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := New()
+
+	received := make(chan Event)
+	bus.Subscribe("test", func(_ context.Context, evt Event) error {
+		received <- evt
+		return nil
+	})
+
+	go bus.Run(ctx)
+
+	for i := range 10 {
+		bus.Publish(Event{Type: TypeDutyScheduled, Slot: uint64(i)})
+	}
+
+	for i := range 10 {
+		evt := <-received
+		require.Equal(t, TypeDutyScheduled, evt.Type)
+		require.EqualValues(t, i, evt.Slot)
+		require.False(t, evt.Time.IsZero())
+	}
+}
+
+func TestBusFullBuffer(t *testing.T) {
+	bus := New()
+	bus.Subscribe("idle", func(context.Context, Event) error { return nil })
+
+	// Events are dropped instead of blocking publishers when the subscriber buffer is full.
+	for range bufferSize + 1 {
+		bus.Publish(Event{Type: TypePeerConnected})
+	}
+
+	require.Len(t, bus.subs[0].buffer, bufferSize)
+}
+
+func TestWebhook(t *testing.T) {
+	received := make(chan Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		var evt Event
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&evt))
+		received <- evt
+	}))
+	defer srv.Close()
+
+	evt := Event{
+		Type:   TypeDutyFailed,
+		Time:   time.Unix(1000, 0).UTC(),
+		Slot:   99,
+		Duty:   "attester",
+		Reason: "no_consensus",
+	}
+
+	webhook := NewWebhook(srv.URL)
+	require.NoError(t, webhook(context.Background(), evt))
+	require.Equal(t, evt, <-received)
+}
+
+func TestWebhookError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	webhook := NewWebhook(srv.URL)
+	require.ErrorContains(t, webhook(context.Background(), Event{Type: TypePeerConnected}), "webhook returned error")
+}
@@ -0,0 +1,32 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eventbus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	publishedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "eventbus",
+		Name:      "published_total",
+		Help:      "Total number of events published by type",
+	}, []string{"type"})
+
+	droppedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "eventbus",
+		Name:      "dropped_total",
+		Help:      "Total number of events dropped due to full subscriber buffers by subscriber",
+	}, []string{"subscriber"})
+
+	errorsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "eventbus",
+		Name:      "errors_total",
+		Help:      "Total number of events that failed to be delivered by subscriber",
+	}, []string{"subscriber"})
+)
@@ -0,0 +1,77 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// timeout is the timeout of delivering a single event.
+const timeout = 10 * time.Second
+
+// NewWebhook returns a subscriber posting JSON encoded events to the URL.
+func NewWebhook(url string) Subscriber {
+	client := &http.Client{Timeout: timeout}
+
+	return func(ctx context.Context, evt Event) error {
+		b, err := json.Marshal(evt)
+		if err != nil {
+			return errors.Wrap(err, "marshal event")
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+		if err != nil {
+			return errors.Wrap(err, "create webhook request")
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return errors.Wrap(err, "post webhook")
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode/100 != 2 {
+			return errors.New("webhook returned error", z.Int("status", resp.StatusCode))
+		}
+
+		return nil
+	}
+}
+
+// NewNATS returns a subscriber publishing JSON encoded events to the NATS server at url
+// on the subject suffixed with the event type, e.g. "charon.events.duty_failed".
+// Connection failures are retried in the background, so the server need not be available on startup.
+func NewNATS(url, subject string) (Subscriber, error) {
+	conn, err := nats.Connect(url,
+		nats.Name("charon"),
+		nats.Timeout(timeout),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "connect to nats", z.Str("url", url))
+	}
+
+	return func(_ context.Context, evt Event) error {
+		b, err := json.Marshal(evt)
+		if err != nil {
+			return errors.Wrap(err, "marshal event")
+		}
+
+		if err := conn.Publish(subject+"."+string(evt.Type), b); err != nil {
+			return errors.Wrap(err, "publish to nats")
+		}
+
+		return nil
+	}, nil
+}
@@ -0,0 +1,161 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/eventbus"
+	"github.com/obolnetwork/charon/app/lifecycle"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/vallifecycle"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/tracker"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// wireEventBus returns an event bus delivering events to the configured webhooks and NATS server
+// and publishing cluster peer connection events. It returns nil if no subscribers are configured.
+func wireEventBus(life *lifecycle.Manager, conf Config, tcpNode host.Host, peerIDs []peer.ID) (*eventbus.Bus, error) {
+	if len(conf.EventWebhookURLs) == 0 && conf.EventNATSURL == "" {
+		return nil, nil
+	}
+
+	bus := eventbus.New()
+	for _, url := range conf.EventWebhookURLs {
+		bus.Subscribe("webhook:"+url, eventbus.NewWebhook(url))
+	}
+
+	if conf.EventNATSURL != "" {
+		sub, err := eventbus.NewNATS(conf.EventNATSURL, conf.EventNATSSubject)
+		if err != nil {
+			return nil, err
+		}
+		bus.Subscribe("nats", sub)
+	}
+
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartEventBus, lifecycle.HookFuncCtx(bus.Run))
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartEventBus, lifecycle.HookFuncCtx(func(ctx context.Context) {
+		publishPeerEvents(ctx, tcpNode, peerIDs, bus)
+	}))
+
+	return bus, nil
+}
+
+// publishPeerEvents publishes connection events of cluster peers until the context is cancelled.
+func publishPeerEvents(ctx context.Context, tcpNode host.Host, peerIDs []peer.ID, bus *eventbus.Bus) {
+	sub, err := tcpNode.EventBus().Subscribe(new(event.EvtPeerConnectednessChanged))
+	if err != nil {
+		log.Error(ctx, "Subscribe libp2p peer connectedness events", err)
+		return
+	}
+	defer sub.Close()
+
+	clusterPeers := make(map[peer.ID]bool)
+	for _, pID := range peerIDs {
+		clusterPeers[pID] = true
+	}
+
+	// connected tracks the last published state per peer, since relay and direct connections emit separate events.
+	connected := make(map[peer.ID]bool)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-sub.Out():
+			evt, ok := e.(event.EvtPeerConnectednessChanged)
+			if !ok || !clusterPeers[evt.Peer] {
+				continue
+			}
+
+			isConnected := evt.Connectedness == network.Connected || evt.Connectedness == network.Limited
+			if isConnected == connected[evt.Peer] {
+				continue
+			}
+			connected[evt.Peer] = isConnected
+
+			typ := eventbus.TypePeerDisconnected
+			if isConnected {
+				typ = eventbus.TypePeerConnected
+			}
+
+			bus.Publish(eventbus.Event{Type: typ, Peer: p2p.PeerName(evt.Peer)})
+		}
+	}
+}
+
+// wireDutyEvents publishes duty scheduled and decided events to the bus and returns
+// the broadcaster wrapped to publish duty broadcast events.
+func wireDutyEvents(bus *eventbus.Bus, sched core.Scheduler, cons core.Consensus, broadcaster core.Broadcaster) core.Broadcaster {
+	sched.SubscribeDuties(func(_ context.Context, duty core.Duty, _ core.DutyDefinitionSet) error {
+		bus.Publish(dutyEvent(eventbus.TypeDutyScheduled, duty))
+		return nil
+	})
+	cons.Subscribe(func(_ context.Context, duty core.Duty, _ core.UnsignedDataSet) error {
+		bus.Publish(dutyEvent(eventbus.TypeDutyDecided, duty))
+		return nil
+	})
+
+	return eventBroadcaster{Broadcaster: broadcaster, bus: bus}
+}
+
+// failedDutyEvents returns a tracker option publishing failed duty events to the bus.
+// Duties failing in the consensus step are published as consensus failures.
+func failedDutyEvents(bus *eventbus.Bus) tracker.Option {
+	return tracker.WithFailedDutyCallback(func(_ context.Context, duty core.Duty, step string, reasonCode string, err error) {
+		evt := dutyEvent(eventbus.TypeDutyFailed, duty)
+		if step == "consensus" {
+			evt.Type = eventbus.TypeConsensusFailed
+		}
+
+		evt.Reason = reasonCode
+		if err != nil {
+			evt.Error = err.Error()
+		}
+
+		bus.Publish(evt)
+	})
+}
+
+// eventBroadcaster wraps a broadcaster publishing duty broadcast events on success.
+type eventBroadcaster struct {
+	core.Broadcaster
+	bus *eventbus.Bus
+}
+
+func (b eventBroadcaster) Broadcast(ctx context.Context, duty core.Duty, set core.SignedDataSet) error {
+	if err := b.Broadcaster.Broadcast(ctx, duty, set); err != nil {
+		return err
+	}
+
+	b.bus.Publish(dutyEvent(eventbus.TypeDutyBroadcast, duty))
+
+	return nil
+}
+
+// dutyEvent returns a new event of the duty.
+func dutyEvent(typ eventbus.Type, duty core.Duty) eventbus.Event {
+	return eventbus.Event{
+		Type: typ,
+		Slot: duty.Slot,
+		Duty: duty.Type.String(),
+	}
+}
+
+// validatorLifecycleEvents returns a validator lifecycle subscriber publishing state change events to the bus.
+func validatorLifecycleEvents(bus *eventbus.Bus) func(context.Context, vallifecycle.Transition) {
+	return func(_ context.Context, t vallifecycle.Transition) {
+		bus.Publish(eventbus.Event{
+			Type:          eventbus.TypeValidatorStateChanged,
+			Validator:     t.PubKey.String(),
+			State:         string(t.To),
+			PreviousState: string(t.From),
+		})
+	}
+}
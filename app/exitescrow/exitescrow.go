@@ -0,0 +1,272 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package exitescrow seals, opens and combines presigned partial voluntary exits held in escrow,
+// e.g. for compliance or staking-as-a-service providers.
+//
+// Each operator presigns the partial exits of its validators at a future exit epoch and seals them in an escrow file,
+// encrypted with an EIP-2335 keystore encryptor using the escrow passphrase and wrapped in a JSON envelope.
+// The escrow files of at least threshold operators are later opened and combined into full exits.
+package exitescrow
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+const (
+	// formatVersion is the escrow envelope and content format version.
+	formatVersion = "v1"
+	// MinPassphraseLen is the minimum length of escrow passphrases.
+	MinPassphraseLen = 12
+	// FileGlob is the glob pattern matching escrow file names, see FileName.
+	FileGlob = "exit-escrow-*.json"
+)
+
+// PartialExit is a presigned partial voluntary exit of a validator.
+type PartialExit struct {
+	PublicKey         string                     `json:"public_key"`
+	SignedExitMessage eth2p0.SignedVoluntaryExit `json:"signed_exit_message"`
+}
+
+// Escrow is the content of an escrow file; the presigned partial exits of a single operator.
+type Escrow struct {
+	// ClusterHash is the 0x-prefixed hex initial mutation hash of the cluster.
+	ClusterHash string        `json:"cluster_hash"`
+	ShareIdx    int           `json:"share_index"`
+	ExitEpoch   uint64        `json:"exit_epoch"`
+	Exits       []PartialExit `json:"exits"`
+}
+
+// envelope is the JSON format of the escrow file, the escrow metadata is included in plaintext for inspection.
+type envelope struct {
+	Version     string         `json:"version"`
+	CreatedAt   time.Time      `json:"created_at"`
+	ClusterHash string         `json:"cluster_hash"`
+	ShareIdx    int            `json:"share_index"`
+	ExitEpoch   uint64         `json:"exit_epoch"`
+	Validators  int            `json:"validators"`
+	Crypto      map[string]any `json:"crypto"`
+}
+
+// FileName returns the name of the escrow file of the operator with the share index.
+func FileName(shareIdx int) string {
+	return fmt.Sprintf("exit-escrow-%d.json", shareIdx)
+}
+
+// ClusterHash returns the cluster hash of the escrow of the cluster.
+func ClusterHash(cluster *manifestpb.Cluster) string {
+	return fmt.Sprintf("%#x", cluster.GetInitialMutationHash())
+}
+
+// Seal returns the passphrase-encrypted escrow file.
+func Seal(escrow Escrow, passphrase string, opts keystore.Options) ([]byte, error) {
+	if len(passphrase) < MinPassphraseLen {
+		return nil, errors.New("escrow passphrase too short", z.Int("min_length", MinPassphraseLen))
+	} else if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(escrow)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal escrow")
+	}
+
+	crypto, err := keystorev4.New(opts.EncryptorOptions()...).Encrypt(content, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypt escrow")
+	}
+
+	b, err := json.MarshalIndent(envelope{
+		Version:     formatVersion,
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+		ClusterHash: escrow.ClusterHash,
+		ShareIdx:    escrow.ShareIdx,
+		ExitEpoch:   escrow.ExitEpoch,
+		Validators:  len(escrow.Exits),
+		Crypto:      crypto,
+	}, "", " ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal escrow envelope")
+	}
+
+	return b, nil
+}
+
+// Open decrypts the escrow file and returns its content.
+func Open(b []byte, passphrase string) (Escrow, error) {
+	var env envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return Escrow{}, errors.Wrap(err, "unmarshal escrow envelope")
+	} else if env.Version != formatVersion {
+		return Escrow{}, errors.New("unsupported escrow version", z.Str("version", env.Version))
+	}
+
+	content, err := keystorev4.New().Decrypt(env.Crypto, passphrase)
+	if err != nil {
+		return Escrow{}, errors.Wrap(err, "decrypt escrow, invalid passphrase or corrupted escrow")
+	}
+
+	var escrow Escrow
+	if err := json.Unmarshal(content, &escrow); err != nil {
+		return Escrow{}, errors.Wrap(err, "unmarshal escrow")
+	}
+
+	// The plaintext metadata isn't authenticated, so ensure it matches the encrypted content.
+	if escrow.ClusterHash != env.ClusterHash || escrow.ShareIdx != env.ShareIdx || escrow.ExitEpoch != env.ExitEpoch {
+		return Escrow{}, errors.New("escrow envelope mismatches encrypted content")
+	}
+
+	return escrow, nil
+}
+
+// Combine verifies the partial exits of the escrows and aggregates them into the full exit of each validator.
+// It returns an error if the partial exits of any validator in the escrows are below threshold.
+// The rootFunc returns the signing root of a voluntary exit.
+func Combine(cluster *manifestpb.Cluster, escrows []Escrow, rootFunc func(eth2p0.VoluntaryExit) ([32]byte, error),
+) (map[core.PubKey]eth2p0.SignedVoluntaryExit, error) {
+	pubShares := make(map[core.PubKey][][]byte)
+	for _, val := range cluster.GetValidators() {
+		pubkey, err := core.PubKeyFromBytes(val.GetPublicKey())
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid validator public key in cluster lock")
+		}
+		pubShares[pubkey] = val.GetPubShares()
+	}
+
+	partials := make(map[core.PubKey]map[int]eth2p0.SignedVoluntaryExit)
+	seen := make(map[int]bool)
+	for _, escrow := range escrows {
+		if escrow.ClusterHash != ClusterHash(cluster) {
+			return nil, errors.New("escrow of another cluster", z.Str("cluster_hash", escrow.ClusterHash), z.Int("share_index", escrow.ShareIdx))
+		} else if escrow.ShareIdx < 1 || escrow.ShareIdx > len(cluster.GetOperators()) {
+			return nil, errors.New("invalid escrow share index", z.Int("share_index", escrow.ShareIdx))
+		} else if seen[escrow.ShareIdx] {
+			return nil, errors.New("duplicate escrow share index", z.Int("share_index", escrow.ShareIdx))
+		}
+		seen[escrow.ShareIdx] = true
+
+		for _, exit := range escrow.Exits {
+			pubkey, err := parsePubKey(exit.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+
+			shares, ok := pubShares[pubkey]
+			if !ok {
+				return nil, errors.New("escrow validator not in cluster lock", z.Redact(z.Str("validator", exit.PublicKey)))
+			} else if exit.SignedExitMessage.Message == nil {
+				return nil, errors.New("escrow exit missing message", z.Redact(z.Str("validator", exit.PublicKey)))
+			}
+
+			if err := verify(shares[escrow.ShareIdx-1], exit.SignedExitMessage, rootFunc); err != nil {
+				return nil, errors.Wrap(err, "invalid partial exit signature", z.Redact(z.Str("validator", exit.PublicKey)), z.Int("share_index", escrow.ShareIdx))
+			}
+
+			if partials[pubkey] == nil {
+				partials[pubkey] = make(map[int]eth2p0.SignedVoluntaryExit)
+			}
+			partials[pubkey][escrow.ShareIdx] = exit.SignedExitMessage
+		}
+	}
+
+	resp := make(map[core.PubKey]eth2p0.SignedVoluntaryExit)
+	for pubkey, exits := range partials {
+		full, err := aggregate(pubkey, exits, int(cluster.GetThreshold()), rootFunc)
+		if err != nil {
+			return nil, err
+		}
+
+		resp[pubkey] = full
+	}
+
+	return resp, nil
+}
+
+// aggregate returns the full exit aggregated from the partial exits by share index of the validator.
+func aggregate(pubkey core.PubKey, exits map[int]eth2p0.SignedVoluntaryExit, threshold int,
+	rootFunc func(eth2p0.VoluntaryExit) ([32]byte, error),
+) (eth2p0.SignedVoluntaryExit, error) {
+	var msg *eth2p0.VoluntaryExit
+	sigs := make(map[int]tbls.Signature)
+	for shareIdx, exit := range exits {
+		if msg == nil {
+			msg = exit.Message
+		} else if *msg != *exit.Message {
+			return eth2p0.SignedVoluntaryExit{}, errors.New("mismatching partial exit messages, presigned at different epochs",
+				z.Redact(z.Str("validator", pubkey.String())))
+		}
+
+		sig, err := tblsconv.SignatureFromBytes(exit.Signature[:])
+		if err != nil {
+			return eth2p0.SignedVoluntaryExit{}, errors.Wrap(err, "parse partial exit signature")
+		}
+		sigs[shareIdx] = sig
+	}
+
+	if len(sigs) < threshold {
+		return eth2p0.SignedVoluntaryExit{}, errors.New("insufficient presigned partial exits",
+			z.Redact(z.Str("validator", pubkey.String())), z.Int("partial_exits", len(sigs)), z.Int("threshold", threshold))
+	}
+
+	sig, err := tbls.ThresholdAggregate(sigs)
+	if err != nil {
+		return eth2p0.SignedVoluntaryExit{}, errors.Wrap(err, "aggregate partial exit signatures")
+	}
+
+	full := eth2p0.SignedVoluntaryExit{Message: msg, Signature: eth2p0.BLSSignature(sig)}
+
+	pkBytes, err := pubkey.Bytes()
+	if err != nil {
+		return eth2p0.SignedVoluntaryExit{}, err
+	}
+
+	if err := verify(pkBytes, full, rootFunc); err != nil {
+		return eth2p0.SignedVoluntaryExit{}, errors.Wrap(err, "invalid full exit signature", z.Redact(z.Str("validator", pubkey.String())))
+	}
+
+	return full, nil
+}
+
+// verify returns an error if the exit isn't signed by the public key.
+func verify(pubkey []byte, exit eth2p0.SignedVoluntaryExit, rootFunc func(eth2p0.VoluntaryExit) ([32]byte, error)) error {
+	pk, err := tblsconv.PubkeyFromBytes(pubkey)
+	if err != nil {
+		return err
+	}
+
+	sig, err := tblsconv.SignatureFromBytes(exit.Signature[:])
+	if err != nil {
+		return err
+	}
+
+	root, err := rootFunc(*exit.Message)
+	if err != nil {
+		return err
+	}
+
+	return tbls.Verify(pk, root[:], sig)
+}
+
+// parsePubKey returns the validator public key from the hex string with optional 0x prefix.
+func parsePubKey(pubkey string) (core.PubKey, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(pubkey, "0x"))
+	if err != nil {
+		return "", errors.Wrap(err, "decode validator public key", z.Redact(z.Str("validator", pubkey)))
+	}
+
+	return core.PubKeyFromBytes(b)
+}
@@ -0,0 +1,152 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package exitescrow
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const testPassphrase = "correct horse battery staple"
+
+var testOpts = keystore.Options{KDF: keystore.KDFPbkdf2, CostPower: 10}
+
+func TestSealOpen(t *testing.T) {
+	escrow := Escrow{
+		ClusterHash: "0x1234",
+		ShareIdx:    2,
+		ExitEpoch:   100,
+		Exits: []PartialExit{{
+			PublicKey: "0xabcd",
+			SignedExitMessage: eth2p0.SignedVoluntaryExit{
+				Message:   &eth2p0.VoluntaryExit{Epoch: 100, ValidatorIndex: 7},
+				Signature: eth2p0.BLSSignature{1, 2, 3},
+			},
+		}},
+	}
+
+	b, err := Seal(escrow, testPassphrase, testOpts)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "0xabcd")
+
+	opened, err := Open(b, testPassphrase)
+	require.NoError(t, err)
+	require.Equal(t, escrow, opened)
+
+	_, err = Open(b, "wrong passphrase")
+	require.ErrorContains(t, err, "invalid passphrase")
+
+	_, err = Seal(escrow, "short", testOpts)
+	require.ErrorContains(t, err, "escrow passphrase too short")
+
+	// Tampered plaintext metadata is detected.
+	var env envelope
+	require.NoError(t, json.Unmarshal(b, &env))
+	env.ExitEpoch = 1
+	tampered, err := json.Marshal(env)
+	require.NoError(t, err)
+
+	_, err = Open(tampered, testPassphrase)
+	require.ErrorContains(t, err, "escrow envelope mismatches encrypted content")
+}
+
+func TestCombine(t *testing.T) {
+	lock, _, keyShares := cluster.NewForT(t, 2, 3, 4, 0, rand.New(rand.NewSource(0)))
+	cl, err := manifest.NewClusterFromLockForT(t, lock)
+	require.NoError(t, err)
+
+	rootFunc := func(exit eth2p0.VoluntaryExit) ([32]byte, error) {
+		return exit.HashTreeRoot()
+	}
+
+	// newEscrow returns the escrow of the operator with the share index, signing the exits of all validators.
+	newEscrow := func(t *testing.T, shareIdx int, epoch eth2p0.Epoch) Escrow {
+		t.Helper()
+
+		escrow := Escrow{ClusterHash: ClusterHash(cl), ShareIdx: shareIdx, ExitEpoch: uint64(epoch)}
+		for i, val := range lock.Validators {
+			msg := &eth2p0.VoluntaryExit{Epoch: epoch, ValidatorIndex: eth2p0.ValidatorIndex(i)}
+			root, err := rootFunc(*msg)
+			require.NoError(t, err)
+
+			sig, err := tbls.Sign(keyShares[i][shareIdx-1], root[:])
+			require.NoError(t, err)
+
+			escrow.Exits = append(escrow.Exits, PartialExit{
+				PublicKey:         val.PublicKeyHex(),
+				SignedExitMessage: eth2p0.SignedVoluntaryExit{Message: msg, Signature: eth2p0.BLSSignature(sig)},
+			})
+		}
+
+		return escrow
+	}
+
+	t.Run("threshold", func(t *testing.T) {
+		exits, err := Combine(cl, []Escrow{newEscrow(t, 1, 10), newEscrow(t, 3, 10), newEscrow(t, 4, 10)}, rootFunc)
+		require.NoError(t, err)
+		require.Len(t, exits, len(lock.Validators))
+
+		for i, val := range lock.Validators {
+			pubkey, err := val.PublicKey()
+			require.NoError(t, err)
+
+			exit := exits[parsePubKeyForT(t, val.PublicKeyHex())]
+			require.EqualValues(t, i, exit.Message.ValidatorIndex)
+
+			root, err := rootFunc(*exit.Message)
+			require.NoError(t, err)
+			require.NoError(t, tbls.Verify(pubkey, root[:], tbls.Signature(exit.Signature)))
+		}
+	})
+
+	t.Run("insufficient", func(t *testing.T) {
+		_, err := Combine(cl, []Escrow{newEscrow(t, 1, 10), newEscrow(t, 2, 10)}, rootFunc)
+		require.ErrorContains(t, err, "insufficient presigned partial exits")
+	})
+
+	t.Run("mismatching epochs", func(t *testing.T) {
+		_, err := Combine(cl, []Escrow{newEscrow(t, 1, 10), newEscrow(t, 2, 10), newEscrow(t, 3, 11)}, rootFunc)
+		require.ErrorContains(t, err, "mismatching partial exit messages")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		escrow := newEscrow(t, 2, 10)
+		escrow.ShareIdx = 1
+
+		_, err := Combine(cl, []Escrow{escrow}, rootFunc)
+		require.ErrorContains(t, err, "invalid partial exit signature")
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := Combine(cl, []Escrow{newEscrow(t, 1, 10), newEscrow(t, 1, 10)}, rootFunc)
+		require.ErrorContains(t, err, "duplicate escrow share index")
+	})
+
+	t.Run("other cluster", func(t *testing.T) {
+		escrow := newEscrow(t, 1, 10)
+		escrow.ClusterHash = ClusterHash(&manifestpb.Cluster{InitialMutationHash: []byte{1}})
+
+		_, err := Combine(cl, []Escrow{escrow}, rootFunc)
+		require.ErrorContains(t, err, "escrow of another cluster")
+	})
+}
+
+func parsePubKeyForT(t *testing.T, pubkey string) core.PubKey {
+	t.Helper()
+
+	resp, err := parsePubKey(pubkey)
+	require.NoError(t, err)
+
+	return resp
+}
@@ -0,0 +1,85 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/featureset"
+	"github.com/obolnetwork/charon/app/peerinfo"
+	pbv1 "github.com/obolnetwork/charon/app/peerinfo/peerinfopb/v1"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// FeaturesStatus is the response of the monitoring API features endpoint.
+type FeaturesStatus struct {
+	// Quorum is the number of nodes that must enable a negotiated feature for it to be activated.
+	Quorum   int             `json:"quorum"`
+	Nodes    []NodeFeatures  `json:"nodes"`
+	Features []FeatureStatus `json:"features"`
+}
+
+// NodeFeatures identifies a node in the cluster as known by the queried node.
+type NodeFeatures struct {
+	Peer     string `json:"peer"`
+	Nickname string `json:"nickname"`
+	Self     bool   `json:"self"`
+	// Reported is false if the node didn't share its enabled features (yet), e.g. if it runs an older version.
+	Reported bool `json:"reported"`
+}
+
+// FeatureStatus is the state of a feature on the queried node and the nodes supporting it.
+type FeatureStatus struct {
+	featureset.State
+
+	// Supported are the indexes of the nodes that enabled the feature.
+	Supported []int `json:"supported"`
+}
+
+// newFeaturesHandler returns a handler serving the state of all features on this node and the features
+// enabled by each node in the cluster, as shared via the peerinfo protocol.
+func newFeaturesHandler(tcpNode host.Host, peerIDs []peer.ID, peerInfo *peerinfo.PeerInfo) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeJSONResponse(w, http.StatusOK, featuresStatus(featureset.States(), peerIDs, tcpNode.ID(), latestPeerInfos(tcpNode.ID(), peerInfo)))
+	}
+}
+
+// featuresStatus returns the features status given the local feature states and the latest peer info of each node.
+func featuresStatus(states []featureset.State, peerIDs []peer.ID, self peer.ID, infos map[peer.ID]*pbv1.PeerInfo) FeaturesStatus {
+	resp := FeaturesStatus{
+		Quorum: cluster.Threshold(len(peerIDs)),
+		Nodes:  []NodeFeatures{},
+	}
+
+	for _, state := range states {
+		resp.Features = append(resp.Features, FeatureStatus{State: state, Supported: []int{}})
+	}
+
+	for i, pID := range peerIDs {
+		node := NodeFeatures{
+			Peer: p2p.PeerName(pID),
+			Self: pID == self,
+		}
+
+		info := infos[pID]
+		if info != nil {
+			node.Nickname = info.GetNickname()
+			node.Reported = node.Self || len(info.GetFeatures()) > 0
+		}
+
+		resp.Nodes = append(resp.Nodes, node)
+
+		for j, feature := range resp.Features {
+			if slices.Contains(info.GetFeatures(), string(feature.Feature)) {
+				resp.Features[j].Supported = append(resp.Features[j].Supported, i)
+			}
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,50 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/featureset"
+	pbv1 "github.com/obolnetwork/charon/app/peerinfo/peerinfopb/v1"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestFeaturesStatus(t *testing.T) {
+	var peerIDs []peer.ID
+	for i := range 4 {
+		pID, err := p2p.PeerIDFromKey(testutil.GenerateInsecureK1Key(t, i).PubKey())
+		require.NoError(t, err)
+		peerIDs = append(peerIDs, pID)
+	}
+
+	states := []featureset.State{
+		{Feature: featureset.EagerDoubleLinear, Status: "stable", EnabledLocally: true, Enabled: true},
+		{Feature: featureset.Linear, Status: "alpha", Negotiated: true, EnabledLocally: true},
+	}
+
+	infos := map[peer.ID]*pbv1.PeerInfo{
+		peerIDs[0]: {Nickname: "self", Features: []string{"eager_double_linear", "linear"}},
+		peerIDs[1]: {Nickname: "peer1", Features: []string{"eager_double_linear"}},
+		peerIDs[2]: {Nickname: "old"}, // Older versions don't share features.
+	}
+
+	status := featuresStatus(states, peerIDs, peerIDs[0], infos)
+	require.Equal(t, 3, status.Quorum)
+
+	require.Len(t, status.Nodes, 4)
+	require.Equal(t, NodeFeatures{Peer: p2p.PeerName(peerIDs[0]), Nickname: "self", Self: true, Reported: true}, status.Nodes[0])
+	require.True(t, status.Nodes[1].Reported)
+	require.False(t, status.Nodes[2].Reported)
+	require.Equal(t, "old", status.Nodes[2].Nickname)
+	require.False(t, status.Nodes[3].Reported)
+
+	require.Equal(t, []FeatureStatus{
+		{State: states[0], Supported: []int{0, 1}},
+		{State: states[1], Supported: []int{0}},
+	}, status.Features)
+}
@@ -113,12 +113,14 @@ func EnableForT(t *testing.T, feature Feature) {
 	initMu.Lock()
 	defer initMu.Unlock()
 
-	cache := state[feature]
+	cache, clusterCache := state[feature], clusterEnabled[feature]
 	t.Cleanup(func() {
 		state[feature] = cache
+		clusterEnabled[feature] = clusterCache
 	})
 
 	state[feature] = enable
+	clusterEnabled[feature] = true // Tests assume a quorum of peers enabled negotiated features.
 }
 
 // DisableForT disables a feature for testing.
@@ -3,7 +3,12 @@
 // Package featureset defines a set of global features and their rollout status.
 package featureset
 
-import "sync"
+import (
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+)
 
 //go:generate stringer -type=status -trimprefix=status
 
@@ -64,16 +69,120 @@ var (
 		// Add all features and there status here.
 	}
 
+	// rollout defines the rollout status of each feature, unaffected by config overrides.
+	rollout = maps.Clone(state)
+
+	// negotiated defines the features that affect cluster-wide behaviour, like consensus, so they are only
+	// activated once a quorum of peers enabled them.
+	negotiated = map[Feature]bool{
+		Linear: true,
+	}
+
+	// clusterEnabled defines the negotiated features enabled by a quorum of peers.
+	clusterEnabled = make(map[Feature]bool)
+
 	// minStatus defines the minimum enabled status.
 	minStatus = statusStable
 
 	initMu sync.Mutex
 )
 
-// Enabled returns true if the feature is enabled.
+// Enabled returns true if the feature is enabled. Negotiated features are only enabled
+// if also enabled by a quorum of peers.
 func Enabled(feature Feature) bool {
 	initMu.Lock()
 	defer initMu.Unlock()
 
+	return enabledLocally(feature) && (!negotiated[feature] || clusterEnabled[feature])
+}
+
+// EnabledLocally returns true if the feature is enabled on this node, irrespective of its peers.
+func EnabledLocally(feature Feature) bool {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	return enabledLocally(feature)
+}
+
+func enabledLocally(feature Feature) bool {
 	return state[feature] >= minStatus
 }
+
+// Advertised returns the features enabled on this node, as advertised to peers, ordered by name.
+func Advertised() []Feature {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	var resp []Feature
+	for feature := range state {
+		if enabledLocally(feature) {
+			resp = append(resp, feature)
+		}
+	}
+	sort.Slice(resp, func(i, j int) bool { return resp[i] < resp[j] })
+
+	return resp
+}
+
+// SetClusterEnabled sets the features enabled by a quorum of peers, activating the negotiated features
+// also enabled on this node. It returns the negotiated features that were activated and deactivated.
+func SetClusterEnabled(features []Feature) (activated []Feature, deactivated []Feature) {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	next := make(map[Feature]bool)
+	for _, feature := range features {
+		if negotiated[feature] {
+			next[feature] = true
+		}
+	}
+
+	for feature := range negotiated {
+		if !enabledLocally(feature) || next[feature] == clusterEnabled[feature] {
+			continue
+		}
+
+		if next[feature] {
+			activated = append(activated, feature)
+		} else {
+			deactivated = append(deactivated, feature)
+		}
+	}
+
+	clusterEnabled = next
+
+	return activated, deactivated
+}
+
+// State is the state of a feature on this node.
+type State struct {
+	Feature Feature `json:"feature"`
+	// Status is the rollout status of the feature: alpha, beta or stable.
+	Status string `json:"status"`
+	// Negotiated is true if the feature is only enabled once a quorum of peers enabled it.
+	Negotiated bool `json:"negotiated"`
+	// EnabledLocally is true if the feature is enabled on this node by its status or config.
+	EnabledLocally bool `json:"enabled_locally"`
+	// Enabled is true if the feature is active.
+	Enabled bool `json:"enabled"`
+}
+
+// States returns the state of all features ordered by name.
+func States() []State {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	var resp []State
+	for feature := range state {
+		resp = append(resp, State{
+			Feature:        feature,
+			Status:         strings.ToLower(rollout[feature].String()),
+			Negotiated:     negotiated[feature],
+			EnabledLocally: enabledLocally(feature),
+			Enabled:        enabledLocally(feature) && (!negotiated[feature] || clusterEnabled[feature]),
+		})
+	}
+	sort.Slice(resp, func(i, j int) bool { return resp[i].Feature < resp[j].Feature })
+
+	return resp
+}
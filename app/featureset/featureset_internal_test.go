@@ -25,3 +25,38 @@ func TestAllFeatureStatus(t *testing.T) {
 		require.Positive(t, status)
 	}
 }
+
+func TestNegotiated(t *testing.T) {
+	cacheState, cacheCluster := state[Linear], clusterEnabled
+	t.Cleanup(func() {
+		state[Linear] = cacheState
+		clusterEnabled = cacheCluster
+	})
+
+	state[Linear] = enable
+	clusterEnabled = make(map[Feature]bool)
+
+	// Negotiated features are only enabled once a quorum of peers enabled them.
+	require.True(t, EnabledLocally(Linear))
+	require.False(t, Enabled(Linear))
+	require.Contains(t, Advertised(), Linear)
+
+	activated, deactivated := SetClusterEnabled([]Feature{Linear, EagerDoubleLinear})
+	require.Equal(t, []Feature{Linear}, activated)
+	require.Empty(t, deactivated)
+	require.True(t, Enabled(Linear))
+
+	// Non-negotiated features aren't affected.
+	require.Equal(t, EnabledLocally(EagerDoubleLinear), Enabled(EagerDoubleLinear))
+
+	activated, deactivated = SetClusterEnabled(nil)
+	require.Empty(t, activated)
+	require.Equal(t, []Feature{Linear}, deactivated)
+	require.False(t, Enabled(Linear))
+
+	for _, s := range States() {
+		if s.Feature == Linear {
+			require.Equal(t, State{Feature: Linear, Status: "alpha", Negotiated: true, EnabledLocally: true}, s)
+		}
+	}
+}
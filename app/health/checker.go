@@ -5,6 +5,7 @@ package health
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -29,7 +30,7 @@ const (
 func NewChecker(metadata Metadata, gatherer prometheus.Gatherer, numValidators int) *Checker {
 	return &Checker{
 		metadata:      metadata,
-		checks:        checks,
+		checks:        append([]Check(nil), checks...),
 		gatherer:      gatherer,
 		scrapePeriod:  scrapePeriod,
 		maxScrapes:    maxScrapes,
@@ -38,10 +39,22 @@ func NewChecker(metadata Metadata, gatherer prometheus.Gatherer, numValidators i
 	}
 }
 
+// Result is the latest result of a health check.
+type Result struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Severity    Severity  `json:"severity"`
+	Failing     bool      `json:"failing"`
+	Error       string    `json:"error,omitempty"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
 // Checker is a health checker.
 type Checker struct {
+	mu            sync.Mutex
+	results       []Result
 	metadata      Metadata
-	checks        []check
+	checks        []Check
 	metrics       [][]*pb.MetricFamily
 	gatherer      prometheus.Gatherer
 	scrapePeriod  time.Duration
@@ -50,6 +63,19 @@ type Checker struct {
 	numValidators int
 }
 
+// Register adds a health check to the checker, it must be called before Run.
+func (c *Checker) Register(check Check) {
+	c.checks = append(c.checks, check)
+}
+
+// Results returns the latest results of all health checks, or nil if the checks have not run yet.
+func (c *Checker) Results() []Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Result(nil), c.results...)
+}
+
 // Run runs the health checker until the context is canceled.
 func (c *Checker) Run(ctx context.Context) {
 	ctx = log.WithTopic(ctx, "health")
@@ -71,22 +97,38 @@ func (c *Checker) Run(ctx context.Context) {
 	}
 }
 
-// instrument runs all health checks and updates the check gauge.
+// instrument runs all health checks, updates the check gauge and stores the results.
 func (c *Checker) instrument(ctx context.Context) {
+	now := time.Now()
+	results := make([]Result, 0, len(c.checks))
 	for _, check := range c.checks {
+		result := Result{
+			Name:        check.Name,
+			Description: check.Description,
+			Severity:    check.Severity,
+			CheckedAt:   now,
+		}
+
 		failing, err := check.Func(newQueryFunc(c.metrics), c.metadata)
 		if err != nil {
 			log.Warn(ctx, "Health check failed", err, z.Str("check", check.Name), c.logFilter)
+			result.Error = err.Error()
 			// Clear checks that fail
 		}
 
 		var val float64
 		if failing {
 			val = 1
+			result.Failing = true
 		}
 
 		checkGauge.WithLabelValues(string(check.Severity), check.Name).Set(val)
+		results = append(results, result)
 	}
+
+	c.mu.Lock()
+	c.results = results
+	c.mu.Unlock()
 }
 
 // scrape scrapes metrics from the gatherer.
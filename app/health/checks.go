@@ -3,16 +3,25 @@
 package health
 
 import (
+	"time"
+
 	pb "github.com/prometheus/client_model/go"
 )
 
-// severity is the severity of a health check.
-type severity string
+const (
+	// maxClockOffset is the maximum clock offset to any peer before the clock_skew check fails.
+	maxClockOffset = time.Second
+	// maxSyncDistance is the maximum beacon node sync distance in slots before the beacon_node_sync_distance check fails.
+	maxSyncDistance = 4
+)
+
+// Severity is the severity of a health check.
+type Severity string
 
 const (
-	severityCritical severity = "critical"
-	severityWarning  severity = "warning"
-	severityInfo     severity = "info"
+	SeverityCritical Severity = "critical"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
 )
 
 // Metadata contains metadata about the charon cluster.
@@ -22,28 +31,28 @@ type Metadata struct {
 	QuorumPeers   int
 }
 
-// check is a health check.
-type check struct {
+// Check is a named health check.
+type Check struct {
 	// Name of the health check.
 	Name string
 	// Description of the health check.
 	Description string
 	// Severity of the health check.
-	Severity severity
+	Severity Severity
 	// Func returns true if the health check is failing, false otherwise.
-	Func func(query, Metadata) (bool, error)
+	Func func(Query, Metadata) (bool, error)
 }
 
-// query abstracts the function to query the metric store returning a value by reducing the selected time series for a given metric name.
-type query func(name string, selector labelSelector, reducer seriesReducer) (float64, error)
+// Query abstracts the function to query the metric store returning a value by reducing the selected time series for a given metric name.
+type Query func(name string, selector labelSelector, reducer seriesReducer) (float64, error)
 
-// checks is a list of health checks.
-var checks = []check{
+// checks is the list of default health checks registered with every checker.
+var checks = []Check{
 	{
 		Name:        "high_error_log_rate",
 		Description: "High rate of error logs. Please check the logs for more details.",
-		Severity:    severityWarning,
-		Func: func(q query, m Metadata) (bool, error) {
+		Severity:    SeverityWarning,
+		Func: func(q Query, m Metadata) (bool, error) {
 			increase, err := q("app_log_error_total", sumLabels(), increase)
 			if err != nil {
 				return false, err
@@ -55,8 +64,8 @@ var checks = []check{
 	{
 		Name:        "high_warning_log_rate",
 		Description: "High rate of warning logs. Please check the logs for more details.",
-		Severity:    severityWarning,
-		Func: func(q query, m Metadata) (bool, error) {
+		Severity:    SeverityWarning,
+		Func: func(q Query, m Metadata) (bool, error) {
 			increase, err := q("app_log_warning_total", sumLabels(), increase)
 			if err != nil {
 				return false, err
@@ -68,8 +77,8 @@ var checks = []check{
 	{
 		Name:        "beacon_node_syncing",
 		Description: "Beacon Node in syncing state.",
-		Severity:    severityCritical,
-		Func: func(q query, _ Metadata) (bool, error) {
+		Severity:    SeverityCritical,
+		Func: func(q Query, _ Metadata) (bool, error) {
 			maxVal, err := q("app_monitoring_beacon_node_syncing", noLabels, gaugeMax)
 			if err != nil {
 				return false, err
@@ -81,8 +90,8 @@ var checks = []check{
 	{
 		Name:        "insufficient_connected_peers",
 		Description: "Not connected to at least quorum peers. Check logs for networking issue or coordinate with peers.",
-		Severity:    severityCritical,
-		Func: func(q query, m Metadata) (bool, error) {
+		Severity:    SeverityCritical,
+		Func: func(q Query, m Metadata) (bool, error) {
 			maxVal, err := q("p2p_ping_success", countNonZeroLabels, gaugeMax)
 			if err != nil {
 				return false, err
@@ -96,8 +105,8 @@ var checks = []check{
 	{
 		Name:        "pending_validators",
 		Description: "Pending validators detected. Activate them to start validating.",
-		Severity:    severityInfo,
-		Func: func(q query, _ Metadata) (bool, error) {
+		Severity:    SeverityInfo,
+		Func: func(q Query, _ Metadata) (bool, error) {
 			maxVal, err := q("core_scheduler_validator_status",
 				countLabels(l("status", "pending")),
 				gaugeMax)
@@ -111,8 +120,8 @@ var checks = []check{
 	{
 		Name:        "proposal_failures",
 		Description: "Proposal failures detected. See <link to troubleshoot proposal failures>.",
-		Severity:    severityWarning,
-		Func: func(q query, _ Metadata) (bool, error) {
+		Severity:    SeverityWarning,
+		Func: func(q Query, _ Metadata) (bool, error) {
 			increase, err := q("core_tracker_failed_duties_total",
 				sumLabels(l("duty", ".*proposal")), increase)
 			if err != nil {
@@ -125,8 +134,8 @@ var checks = []check{
 	{
 		Name:        "high_registration_failures_rate",
 		Description: "High rate of failed validator registrations. Please check the logs for more details.",
-		Severity:    severityWarning,
-		Func: func(q query, _ Metadata) (bool, error) {
+		Severity:    SeverityWarning,
+		Func: func(q Query, _ Metadata) (bool, error) {
 			increase, err := q("core_bcast_recast_errors_total", sumLabels(), increase)
 			if err != nil {
 				return false, err
@@ -138,8 +147,8 @@ var checks = []check{
 	{
 		Name:        "metrics_high_cardinality",
 		Description: "Metrics reached high cardinality threshold. Please check metrics reported by app_health_metrics_high_cardinality.",
-		Severity:    severityWarning,
-		Func: func(q query, _ Metadata) (bool, error) {
+		Severity:    SeverityWarning,
+		Func: func(q Query, _ Metadata) (bool, error) {
 			maxVal, err := q("app_health_metrics_high_cardinality", sumLabels(), gaugeMax)
 			if err != nil {
 				return false, err
@@ -148,6 +157,114 @@ var checks = []check{
 			return maxVal > 0, nil
 		},
 	},
+	{
+		Name:        "clock_skew",
+		Description: "Clock offset to some peers exceeds one second. Ensure NTP is configured and coordinate with peers.",
+		Severity:    SeverityWarning,
+		Func: func(q Query, _ Metadata) (bool, error) {
+			maxVal, err := q("app_peerinfo_clock_offset_seconds", maxAbsLabels, gaugeMax)
+			if err != nil {
+				return false, err
+			}
+
+			return maxVal > maxClockOffset.Seconds(), nil
+		},
+	},
+	{
+		Name:        "clock_unsynchronized",
+		Description: "System clock is not synchronised to an external time reference or drifting. Ensure NTP (e.g. chrony) is configured.",
+		Severity:    SeverityWarning,
+		Func: func(q Query, _ Metadata) (bool, error) {
+			maxVal, err := q("app_clock_warning", noLabels, gaugeMax)
+			if err != nil {
+				return false, err
+			}
+
+			return maxVal == 1, nil
+		},
+	},
+	{
+		Name:        "low_disk_space",
+		Description: "Less than 1GB of free disk space available for the data directory. Free up disk space.",
+		Severity:    SeverityCritical,
+		Func: func(q Query, _ Metadata) (bool, error) {
+			maxVal, err := q("app_selfmonitor_warning", selectLabel(l("resource", "disk")), gaugeMax)
+			if err != nil {
+				return false, err
+			}
+
+			return maxVal == 1, nil
+		},
+	},
+	{
+		Name:        "high_memory_usage",
+		Description: "Charon uses more than 80% of the system memory. Check for memory leaks or increase the memory.",
+		Severity:    SeverityWarning,
+		Func: func(q Query, _ Metadata) (bool, error) {
+			maxVal, err := q("app_selfmonitor_warning", selectLabel(l("resource", "memory")), gaugeMax)
+			if err != nil {
+				return false, err
+			}
+
+			return maxVal == 1, nil
+		},
+	},
+	{
+		Name:        "high_file_descriptor_usage",
+		Description: "Charon uses more than 80% of its open files limit. Increase the limit (ulimit -n).",
+		Severity:    SeverityWarning,
+		Func: func(q Query, _ Metadata) (bool, error) {
+			maxVal, err := q("app_selfmonitor_warning", selectLabel(l("resource", "file_descriptors")), gaugeMax)
+			if err != nil {
+				return false, err
+			}
+
+			return maxVal == 1, nil
+		},
+	},
+	{
+		Name:        "beacon_node_sync_distance",
+		Description: "Beacon node is behind the head of the chain. Check the beacon node logs and peers.",
+		Severity:    SeverityWarning,
+		Func: func(q Query, _ Metadata) (bool, error) {
+			maxVal, err := q("app_beacon_node_sync_distance", noLabels, gaugeMax)
+			if err != nil {
+				return false, err
+			}
+
+			return maxVal > maxSyncDistance, nil
+		},
+	},
+	{
+		Name:        "vc_not_seen",
+		Description: "No requests received from the validator client recently. Check the validator client is running and connected.",
+		Severity:    SeverityCritical,
+		Func: func(q Query, m Metadata) (bool, error) {
+			if m.NumValidators == 0 {
+				return false, nil
+			}
+
+			// The start time gauge is always present, so it indicates whether enough scrapes exist.
+			scrapes, err := q("app_start_time_secs", noLabels, count)
+			if err != nil {
+				return false, err
+			} else if scrapes < 2 {
+				return false, nil
+			}
+
+			increase, err := q("core_validatorapi_request_total", sumLabels(), increase)
+			if err != nil {
+				return false, err
+			}
+
+			return increase == 0, nil
+		},
+	},
+}
+
+// DefaultChecks returns the default health checks registered with every checker.
+func DefaultChecks() []Check {
+	return append([]Check(nil), checks...)
 }
 
 // l is a concise convenience function to create a label pair.
@@ -3,6 +3,7 @@
 package health
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -395,7 +396,7 @@ func TestMetricsHighCardinalityCheck(t *testing.T) {
 	})
 }
 
-func testCheck(t *testing.T, m Metadata, checkName string, expect bool, metrics []*pb.MetricFamily) {
+func testCheck(t *testing.T, m Metadata, checkName string, expect bool, metrics ...[]*pb.MetricFamily) {
 	t.Helper()
 
 	randomFamFoo := genFam("foo",
@@ -406,29 +407,22 @@ func testCheck(t *testing.T, m Metadata, checkName string, expect bool, metrics
 		genGauge(genLabels("bar", "bar1"), 1, 1, 4),
 		genGauge(genLabels("bar", "bar2"), 1, 1, 1),
 	)
+	metrics = append(metrics, randomFamFoo, randomFamBar)
 
 	var maxVal int
-	if len(metrics) > maxVal {
-		maxVal = len(metrics)
-	}
-	if len(randomFamFoo) > maxVal {
-		maxVal = len(randomFamFoo)
-	}
-	if len(randomFamBar) > maxVal {
-		maxVal = len(randomFamBar)
+	for _, fams := range metrics {
+		if len(fams) > maxVal {
+			maxVal = len(fams)
+		}
 	}
 
 	multiFams := make([][]*pb.MetricFamily, maxVal)
 	for i := range maxVal {
 		var fam []*pb.MetricFamily
-		if i < len(metrics) {
-			fam = append(fam, metrics[i])
-		}
-		if i < len(randomFamFoo) {
-			fam = append(fam, randomFamFoo[i])
-		}
-		if i < len(randomFamBar) {
-			fam = append(fam, randomFamBar[i])
+		for _, fams := range metrics {
+			if i < len(fams) {
+				fam = append(fam, fams[i])
+			}
 		}
 
 		multiFams[i] = fam
@@ -449,6 +443,181 @@ func testCheck(t *testing.T, m Metadata, checkName string, expect bool, metrics
 	require.Fail(t, "check not found")
 }
 
+func TestClockSkewCheck(t *testing.T) {
+	m := Metadata{}
+	checkName := "clock_skew"
+	metricName := "app_peerinfo_clock_offset_seconds"
+
+	t.Run("no data", func(t *testing.T) {
+		testCheck(t, m, checkName, false, nil)
+	})
+
+	t.Run("small offsets", func(t *testing.T) {
+		testCheck(t, m, checkName, false,
+			genFam(metricName,
+				genGauge(genLabels("peer", "1"), 0, 1, 0),
+				genGauge(genLabels("peer", "2"), 0, -1, 0),
+			),
+		)
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		testCheck(t, m, checkName, true,
+			genFam(metricName,
+				genGauge(genLabels("peer", "1"), 0, 0, 0),
+				genGauge(genLabels("peer", "2"), 0, -2, 0),
+			),
+		)
+	})
+
+	t.Run("positive offset", func(t *testing.T) {
+		testCheck(t, m, checkName, true,
+			genFam(metricName,
+				genGauge(genLabels("peer", "1"), 3, 3, 3),
+			),
+		)
+	})
+}
+
+func TestBNSyncDistanceCheck(t *testing.T) {
+	m := Metadata{}
+	checkName := "beacon_node_sync_distance"
+	metricName := "app_beacon_node_sync_distance"
+
+	t.Run("no data", func(t *testing.T) {
+		testCheck(t, m, checkName, false, nil)
+	})
+
+	t.Run("near head", func(t *testing.T) {
+		testCheck(t, m, checkName, false,
+			genFam(metricName, genGauge(nil, 0, 1, 4)),
+		)
+	})
+
+	t.Run("far behind", func(t *testing.T) {
+		testCheck(t, m, checkName, true,
+			genFam(metricName, genGauge(nil, 0, 10, 0)),
+		)
+	})
+}
+
+func TestVCNotSeenCheck(t *testing.T) {
+	m := Metadata{NumValidators: 1}
+	checkName := "vc_not_seen"
+	metricName := "core_validatorapi_request_total"
+	startFam := genFam("app_start_time_secs", genGauge(nil, 1, 1, 1))
+
+	t.Run("no data", func(t *testing.T) {
+		testCheck(t, m, checkName, false, nil)
+	})
+
+	t.Run("no requests", func(t *testing.T) {
+		testCheck(t, m, checkName, true, startFam)
+	})
+
+	t.Run("too few scrapes", func(t *testing.T) {
+		testCheck(t, m, checkName, false, genFam("app_start_time_secs", genGauge(nil, 1)))
+	})
+
+	t.Run("constant requests", func(t *testing.T) {
+		testCheck(t, m, checkName, true, startFam,
+			genFam(metricName, genCounter(genLabels("endpoint", "attester_duties"), 5, 5, 5)),
+		)
+	})
+
+	t.Run("increasing requests", func(t *testing.T) {
+		testCheck(t, m, checkName, false, startFam,
+			genFam(metricName,
+				genCounter(genLabels("endpoint", "attester_duties"), 5, 5, 5),
+				genCounter(genLabels("endpoint", "proposal"), 1, 1, 2),
+			),
+		)
+	})
+
+	t.Run("no validators", func(t *testing.T) {
+		testCheck(t, Metadata{}, checkName, false, startFam)
+	})
+}
+
+func TestClockUnsynchronizedCheck(t *testing.T) {
+	m := Metadata{}
+	checkName := "clock_unsynchronized"
+	metricName := "app_clock_warning"
+
+	t.Run("no data", func(t *testing.T) {
+		testCheck(t, m, checkName, false, nil)
+	})
+
+	t.Run("synced", func(t *testing.T) {
+		testCheck(t, m, checkName, false,
+			genFam(metricName, genGauge(nil, 0, 0, 0)),
+		)
+	})
+
+	t.Run("drifting", func(t *testing.T) {
+		testCheck(t, m, checkName, true,
+			genFam(metricName, genGauge(nil, 0, 1, 0)),
+		)
+	})
+}
+
+func TestResourceWarningChecks(t *testing.T) {
+	m := Metadata{}
+	metricName := "app_selfmonitor_warning"
+
+	for checkName, resource := range map[string]string{
+		"low_disk_space":             "disk",
+		"high_memory_usage":          "memory",
+		"high_file_descriptor_usage": "file_descriptors",
+	} {
+		t.Run(checkName, func(t *testing.T) {
+			testCheck(t, m, checkName, false, nil)
+
+			testCheck(t, m, checkName, false,
+				genFam(metricName,
+					genGauge(genLabels("resource", resource), 0, 0, 0),
+					genGauge(genLabels("resource", "goroutines"), 1, 1, 1),
+				),
+			)
+
+			testCheck(t, m, checkName, true,
+				genFam(metricName,
+					genGauge(genLabels("resource", resource), 0, 1, 0),
+					genGauge(genLabels("resource", "goroutines"), 0, 0, 0),
+				),
+			)
+		})
+	}
+}
+
+func TestCheckerResults(t *testing.T) {
+	checker := NewChecker(Metadata{}, nil, 1)
+	require.Empty(t, checker.Results())
+
+	checker.Register(Check{
+		Name:        "custom",
+		Description: "Custom check",
+		Severity:    SeverityInfo,
+		Func: func(Query, Metadata) (bool, error) {
+			return true, nil
+		},
+	})
+
+	checker.instrument(context.Background())
+
+	results := checker.Results()
+	require.Len(t, results, len(checks)+1)
+	for _, result := range results[:len(checks)] {
+		require.False(t, result.Failing, result.Name)
+	}
+
+	custom := results[len(checks)]
+	require.Equal(t, "custom", custom.Name)
+	require.Equal(t, SeverityInfo, custom.Severity)
+	require.True(t, custom.Failing)
+	require.False(t, custom.CheckedAt.IsZero())
+}
+
 func genFam(name string, metrics ...[]*pb.Metric) []*pb.MetricFamily {
 	typ := pb.MetricType_COUNTER
 	if metrics[0][0].GetGauge() != nil {
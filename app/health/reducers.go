@@ -42,3 +42,8 @@ func gaugeMax(samples []*pb.Metric) (float64, error) {
 
 	return maxVal, nil
 }
+
+// count returns the number of samples in a time series.
+func count(samples []*pb.Metric) (float64, error) {
+	return float64(len(samples)), nil
+}
@@ -3,6 +3,7 @@
 package health
 
 import (
+	"math"
 	"regexp"
 
 	pb "github.com/prometheus/client_model/go"
@@ -56,6 +57,24 @@ func countNonZeroLabels(metricsFam *pb.MetricFamily) (*pb.Metric, error) {
 	return gauge, nil
 }
 
+// maxAbsLabels returns a gauge metric with the maximum absolute value of all metrics.
+func maxAbsLabels(metricsFam *pb.MetricFamily) (*pb.Metric, error) {
+	timestamp := metricsFam.GetMetric()[0].GetTimestampMs()
+	gauge := &pb.Metric{
+		Gauge:       new(pb.Gauge),
+		TimestampMs: &timestamp,
+	}
+
+	for _, metric := range metricsFam.GetMetric() {
+		value := math.Abs(metric.GetGauge().GetValue() + metric.GetCounter().GetValue())
+		if value > gauge.GetGauge().GetValue() {
+			gauge.Gauge.Value = &value
+		}
+	}
+
+	return gauge, nil
+}
+
 // noLabels return the only metric in the family, or an error if there is not exactly one metric.
 func noLabels(metricsFam *pb.MetricFamily) (*pb.Metric, error) {
 	if len(metricsFam.GetMetric()) != 1 {
@@ -110,7 +129,7 @@ func sumLabels(labels ...*pb.LabelPair) func(metricsFam *pb.MetricFamily) (*pb.M
 }
 
 // selectLabel returns a selector that returns the first metric that matches all of the label pairs.
-func selectLabel(labels ...*pb.LabelPair) func(metricsFam *pb.MetricFamily) (*pb.Metric, error) { //nolint: unused // This is used in the future.
+func selectLabel(labels ...*pb.LabelPair) func(metricsFam *pb.MetricFamily) (*pb.Metric, error) {
 	return func(metricsFam *pb.MetricFamily) (*pb.Metric, error) {
 		var found *pb.Metric
 		for _, metric := range metricsFam.GetMetric() {
@@ -0,0 +1,208 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package httpauth protects HTTP servers with basic authentication, (mutual) TLS and IP allowlists,
+// for operators that must expose HTTP APIs like the monitoring API across networks.
+package httpauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// Config defines HTTP server access control. The zero value allows all requests over plain HTTP.
+type Config struct {
+	// BasicAuth enables basic authentication with the "username:password" credentials.
+	BasicAuth string
+	// TLSCertFile and TLSKeyFile enable TLS with the PEM encoded certificate and private key.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSClientCAFile enables mutual TLS, requiring client certificates signed by the PEM encoded CA certificates.
+	TLSClientCAFile string
+	// AllowedIPs restricts requests to remote IP addresses or CIDR ranges, e.g. "10.0.0.0/8".
+	AllowedIPs []string
+}
+
+// TLSEnabled returns true if TLS is configured.
+func (c Config) TLSEnabled() bool {
+	return c.TLSCertFile != ""
+}
+
+// Validate returns an error if the config is invalid.
+func (c Config) Validate() error {
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return errors.New("both tls certificate and key files must be specified")
+	}
+
+	if c.TLSClientCAFile != "" && !c.TLSEnabled() {
+		return errors.New("tls client ca file requires tls certificate and key files")
+	}
+
+	if c.BasicAuth != "" {
+		if user, _, ok := strings.Cut(c.BasicAuth, ":"); !ok || user == "" {
+			return errors.New("basic auth credentials must be formatted as username:password")
+		}
+	}
+
+	_, err := parsePrefixes(c.AllowedIPs)
+
+	return err
+}
+
+// Wrap returns the handler wrapped with the IP allowlist and basic authentication checks of the config.
+func Wrap(handler http.Handler, conf Config) (http.Handler, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	if conf.BasicAuth != "" {
+		handler = basicAuth(handler, conf.BasicAuth)
+	}
+
+	if len(conf.AllowedIPs) > 0 {
+		prefixes, err := parsePrefixes(conf.AllowedIPs)
+		if err != nil {
+			return nil, err
+		}
+
+		handler = allowIPs(handler, prefixes)
+	}
+
+	return handler, nil
+}
+
+// TLSConfig returns the server TLS config or nil if TLS is not enabled.
+// Client certificates are required and verified if a client CA file is configured.
+func TLSConfig(conf Config) (*tls.Config, error) {
+	if !conf.TLSEnabled() {
+		return nil, nil //nolint:nilnil // Nil TLS config disables TLS.
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(conf.TLSCertFile, conf.TLSKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "load tls certificate", z.Str("cert", conf.TLSCertFile), z.Str("key", conf.TLSKeyFile))
+	}
+
+	resp := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if conf.TLSClientCAFile != "" {
+		pem, err := os.ReadFile(conf.TLSClientCAFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "read tls client ca file", z.Str("path", conf.TLSClientCAFile))
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates in tls client ca file", z.Str("path", conf.TLSClientCAFile))
+		}
+
+		resp.ClientCAs = pool
+		resp.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return resp, nil
+}
+
+// basicAuth returns the handler wrapped to require the "username:password" basic auth credentials.
+func basicAuth(handler http.Handler, credentials string) http.Handler {
+	expectUser, expectPass, _ := strings.Cut(credentials, ":")
+	expectUserHash := sha256.Sum256([]byte(expectUser))
+	expectPassHash := sha256.Sum256([]byte(expectPass))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+
+		// Compare hashes in constant time to not leak the credentials or their lengths via timing.
+		userHash := sha256.Sum256([]byte(user))
+		passHash := sha256.Sum256([]byte(pass))
+		userMatch := subtle.ConstantTimeCompare(userHash[:], expectUserHash[:]) == 1
+		passMatch := subtle.ConstantTimeCompare(passHash[:], expectPassHash[:]) == 1
+
+		if !ok || !userMatch || !passMatch {
+			w.Header().Set("WWW-Authenticate", `Basic realm="charon", charset="UTF-8"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// allowIPs returns the handler wrapped to only allow requests from remote addresses in the prefixes.
+// Note that forwarding headers like X-Forwarded-For are ignored since they can be spoofed.
+func allowIPs(handler http.Handler, prefixes []netip.Prefix) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowed(r.RemoteAddr, prefixes) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// allowed returns true if the remote "ip:port" address is contained in any of the prefixes.
+func allowed(remoteAddr string, prefixes []netip.Prefix) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap() // Match IPv4-mapped IPv6 addresses against IPv4 prefixes.
+
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parsePrefixes parses the IP addresses or CIDR ranges as prefixes.
+func parsePrefixes(allowed []string) ([]netip.Prefix, error) {
+	var resp []netip.Prefix
+	for _, s := range allowed {
+		if strings.Contains(s, "/") {
+			prefix, err := netip.ParsePrefix(s)
+			if err != nil {
+				return nil, errors.Wrap(err, "parse allowed cidr", z.Str("cidr", s))
+			}
+
+			resp = append(resp, prefix.Masked())
+
+			continue
+		}
+
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse allowed ip", z.Redact(z.Str("ip", s)))
+		}
+
+		addr = addr.Unmap()
+		resp = append(resp, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,200 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package httpauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Config{}.Validate())
+	require.NoError(t, Config{BasicAuth: "user:pass:word", AllowedIPs: []string{"10.0.0.1", "10.0.0.0/8", "::1"}}.Validate())
+	require.ErrorContains(t, Config{BasicAuth: "user"}.Validate(), "username:password")
+	require.ErrorContains(t, Config{BasicAuth: ":pass"}.Validate(), "username:password")
+	require.ErrorContains(t, Config{TLSCertFile: "cert.pem"}.Validate(), "both tls certificate and key")
+	require.ErrorContains(t, Config{TLSClientCAFile: "ca.pem"}.Validate(), "requires tls certificate")
+	require.ErrorContains(t, Config{AllowedIPs: []string{"10.0.0/8"}}.Validate(), "parse allowed cidr")
+	require.ErrorContains(t, Config{AllowedIPs: []string{"localhost"}}.Validate(), "parse allowed ip")
+}
+
+func TestBasicAuth(t *testing.T) {
+	handler, err := Wrap(okHandler, Config{BasicAuth: "user:secret"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		Name   string
+		User   string
+		Pass   string
+		Status int
+	}{
+		{Name: "valid", User: "user", Pass: "secret", Status: http.StatusOK},
+		{Name: "wrong password", User: "user", Pass: "wrong", Status: http.StatusUnauthorized},
+		{Name: "wrong user", User: "admin", Pass: "secret", Status: http.StatusUnauthorized},
+		{Name: "missing", Status: http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if test.User != "" {
+				req.SetBasicAuth(test.User, test.Pass)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, test.Status, rec.Code)
+		})
+	}
+}
+
+func TestAllowedIPs(t *testing.T) {
+	handler, err := Wrap(okHandler, Config{AllowedIPs: []string{"10.0.0.0/8", "192.168.1.1", "2001:db8::/32"}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		RemoteAddr string
+		Status     int
+	}{
+		{RemoteAddr: "10.1.2.3:1234", Status: http.StatusOK},
+		{RemoteAddr: "192.168.1.1:1234", Status: http.StatusOK},
+		{RemoteAddr: "[::ffff:10.1.2.3]:1234", Status: http.StatusOK},
+		{RemoteAddr: "[2001:db8::1]:1234", Status: http.StatusOK},
+		{RemoteAddr: "192.168.1.2:1234", Status: http.StatusForbidden},
+		{RemoteAddr: "[::1]:1234", Status: http.StatusForbidden},
+		{RemoteAddr: "invalid", Status: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.RemoteAddr, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			req.RemoteAddr = test.RemoteAddr
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, test.Status, rec.Code)
+		})
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+
+	ca, caKey := newCert(t, nil, nil, true)
+	server, serverKey := newCert(t, ca, caKey, false)
+	client, clientKey := newCert(t, ca, caKey, false)
+
+	conf := Config{
+		TLSCertFile:     writePEM(t, dir, "server.crt", "CERTIFICATE", server.Raw),
+		TLSKeyFile:      writePEM(t, dir, "server.key", "EC PRIVATE KEY", marshalKey(t, serverKey)),
+		TLSClientCAFile: writePEM(t, dir, "ca.crt", "CERTIFICATE", ca.Raw),
+	}
+
+	tlsConfig, err := TLSConfig(conf)
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(okHandler)
+	srv.TLS = tlsConfig
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: certs,
+			MinVersion:   tls.VersionTLS12,
+		}}}
+	}
+
+	// Requests with client certificates signed by the CA succeed.
+	resp, err := newClient(tls.Certificate{Certificate: [][]byte{client.Raw}, PrivateKey: clientKey}).Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Requests without client certificates fail.
+	_, err = newClient().Get(srv.URL) //nolint:bodyclose // Request fails.
+	require.Error(t, err)
+}
+
+func TestTLSDisabled(t *testing.T) {
+	tlsConfig, err := TLSConfig(Config{})
+	require.NoError(t, err)
+	require.Nil(t, tlsConfig)
+}
+
+// newCert returns a new certificate for localhost signed by the parent, or self-signed if parent is nil.
+func newCert(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func marshalKey(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+
+	b, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return b
+}
+
+func writePEM(t *testing.T, dir, name, typ string, b []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: b}), 0o600))
+
+	return path
+}
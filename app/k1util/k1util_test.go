@@ -71,6 +71,48 @@ func TestK1Util(t *testing.T) {
 	require.True(t, key.PubKey().IsEqual(recovered))
 }
 
+func TestSigner(t *testing.T) {
+	key := k1.PrivKeyFromBytes(fromHex(t, privKey1))
+	digest := fromHex(t, digest1)
+
+	signer := k1util.NewSigner(key)
+	require.True(t, key.PubKey().IsEqual(signer.PubKey()))
+
+	sig, err := signer.Sign(digest)
+	require.NoError(t, err)
+	require.Equal(t, fromHex(t, sig1), sig)
+
+	local, ok := k1util.PrivKeyFromSigner(signer)
+	require.True(t, ok)
+	require.Equal(t, key, local)
+}
+
+func TestSignatureFrom64(t *testing.T) {
+	key := k1.PrivKeyFromBytes(fromHex(t, privKey1))
+	digest := fromHex(t, digest1)
+	expect := fromHex(t, sig1)
+
+	sig, err := k1util.SignatureFrom64(key.PubKey(), digest, expect[:64])
+	require.NoError(t, err)
+	require.Equal(t, expect, sig)
+
+	// High S signatures are normalised.
+	var s k1.ModNScalar
+	s.SetByteSlice(expect[32:64])
+	s.Negate()
+	highS := append([]byte(nil), expect[:64]...)
+	s.PutBytesUnchecked(highS[32:])
+
+	sig, err = k1util.SignatureFrom64(key.PubKey(), digest, highS)
+	require.NoError(t, err)
+	require.Equal(t, expect, sig)
+
+	other, err := k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	_, err = k1util.SignatureFrom64(other.PubKey(), digest, expect[:64])
+	require.ErrorContains(t, err, "signature not by public key")
+}
+
 func TestRandom(t *testing.T) {
 	key, err := k1.GeneratePrivateKey()
 	require.NoError(t, err)
@@ -0,0 +1,166 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package k1util
+
+import (
+	"bytes"
+	"context"
+	"encoding/asn1"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// pkcs11Tool is the OpenSC PKCS#11 command line tool used to access tokens.
+const pkcs11Tool = "pkcs11-tool"
+
+// PKCS11Config defines the PKCS#11 token and secp256k1 key used by a PKCS11 signer.
+type PKCS11Config struct {
+	// Module is the path to the PKCS#11 module (shared library) of the HSM.
+	Module string
+	// TokenLabel is the label of the token (slot) containing the key.
+	TokenLabel string
+	// KeyID is the hex encoded CKA_ID of the key.
+	KeyID string
+	// PINFile is the path to the file containing the user PIN of the token.
+	PINFile string
+}
+
+// Enabled returns true if a PKCS#11 module is configured.
+func (c PKCS11Config) Enabled() bool {
+	return c.Module != ""
+}
+
+// NewPKCS11Signer returns a signer using the secp256k1 key stored in the PKCS#11 token.
+// The key never leaves the token, it is accessed via the OpenSC pkcs11-tool which must be installed.
+func NewPKCS11Signer(ctx context.Context, conf PKCS11Config) (Signer, error) {
+	if conf.KeyID == "" {
+		return nil, errors.New("missing pkcs11 key id")
+	}
+
+	if _, err := exec.LookPath(pkcs11Tool); err != nil {
+		return nil, errors.Wrap(err, "pkcs11-tool not found, install OpenSC")
+	}
+
+	pin, err := os.ReadFile(conf.PINFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "read pkcs11 pin file", z.Str("path", conf.PINFile))
+	}
+
+	s := pkcs11Signer{
+		ctx:  ctx,
+		conf: conf,
+		pin:  strings.TrimSpace(string(pin)),
+	}
+
+	der, err := s.run("--read-object", "--type", "pubkey")
+	if err != nil {
+		return nil, errors.Wrap(err, "read pkcs11 public key")
+	}
+
+	s.pubkey, err = parsePKCS11PubKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+type pkcs11Signer struct {
+	ctx    context.Context
+	conf   PKCS11Config
+	pin    string
+	pubkey *k1.PublicKey
+}
+
+func (s pkcs11Signer) PubKey() *k1.PublicKey {
+	return s.pubkey
+}
+
+func (s pkcs11Signer) Sign(hash []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "charon-pkcs11")
+	if err != nil {
+		return nil, errors.Wrap(err, "create temp dir")
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "hash")
+	if err := os.WriteFile(input, hash, 0o600); err != nil {
+		return nil, errors.Wrap(err, "write hash")
+	}
+
+	sig, err := s.run("--sign", "--mechanism", "ECDSA", "--login", "--pin", "env:CHARON_PKCS11_PIN",
+		"--input-file", input)
+	if err != nil {
+		return nil, errors.Wrap(err, "pkcs11 sign")
+	}
+
+	return SignatureFrom64(s.pubkey, hash, sig)
+}
+
+// run executes pkcs11-tool with the key selection and provided arguments and returns its output.
+func (s pkcs11Signer) run(args ...string) ([]byte, error) {
+	output, err := os.CreateTemp("", "charon-pkcs11-output")
+	if err != nil {
+		return nil, errors.Wrap(err, "create temp file")
+	}
+	_ = output.Close()
+	defer os.Remove(output.Name())
+
+	args = append([]string{"--module", s.conf.Module, "--id", s.conf.KeyID, "--output-file", output.Name()}, args...)
+	if s.conf.TokenLabel != "" {
+		args = append(args, "--token-label", s.conf.TokenLabel)
+	}
+
+	cmd := exec.CommandContext(s.ctx, pkcs11Tool, args...)
+	cmd.Env = append(os.Environ(), "CHARON_PKCS11_PIN="+s.pin)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrap(err, "pkcs11-tool", z.Str("stderr", strings.TrimSpace(stderr.String())))
+	}
+
+	b, err := os.ReadFile(output.Name())
+	if err != nil {
+		return nil, errors.Wrap(err, "read pkcs11-tool output")
+	}
+
+	return b, nil
+}
+
+// parsePKCS11PubKey returns the secp256k1 public key from either a DER encoded SubjectPublicKeyInfo
+// or a DER encoded EC point octet string (CKA_EC_POINT) as returned by different OpenSC versions.
+func parsePKCS11PubKey(der []byte) (*k1.PublicKey, error) {
+	var spki struct {
+		Algorithm asn1.RawValue
+		PublicKey asn1.BitString
+	}
+	if rest, err := asn1.Unmarshal(der, &spki); err == nil && len(rest) == 0 {
+		pubkey, err := k1.ParsePubKey(spki.PublicKey.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse pkcs11 public key")
+		}
+
+		return pubkey, nil
+	}
+
+	var point []byte
+	if rest, err := asn1.Unmarshal(der, &point); err != nil || len(rest) != 0 {
+		return nil, errors.New("invalid pkcs11 public key encoding")
+	}
+
+	pubkey, err := k1.ParsePubKey(point)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse pkcs11 public key")
+	}
+
+	return pubkey, nil
+}
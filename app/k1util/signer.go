@@ -0,0 +1,76 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package k1util
+
+import (
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// Signer signs hashes with a secp256k1 private key which may be held externally, e.g. in a HSM.
+type Signer interface {
+	// PubKey returns the public key of the signing key.
+	PubKey() *k1.PublicKey
+	// Sign returns a 65 byte signature of the 32 byte hash in the [R || S || V] format where V is 0 or 1.
+	Sign(hash []byte) ([]byte, error)
+}
+
+// NewSigner returns a signer using the in-memory private key.
+func NewSigner(key *k1.PrivateKey) Signer {
+	return localSigner{key: key}
+}
+
+// PrivKeyFromSigner returns the in-memory private key of the signer or false if the key is held externally.
+func PrivKeyFromSigner(signer Signer) (*k1.PrivateKey, bool) {
+	local, ok := signer.(localSigner)
+	if !ok {
+		return nil, false
+	}
+
+	return local.key, true
+}
+
+type localSigner struct {
+	key *k1.PrivateKey
+}
+
+func (s localSigner) PubKey() *k1.PublicKey {
+	return s.key.PubKey()
+}
+
+func (s localSigner) Sign(hash []byte) ([]byte, error) {
+	return Sign(s.key, hash)
+}
+
+// SignatureFrom64 returns the 65 byte [R || S || V] signature from the 64 byte [R || S] signature of the hash
+// by the public key, as produced by external signers without recovery ids. High S values are normalised.
+func SignatureFrom64(pubkey *k1.PublicKey, hash []byte, sig []byte) ([]byte, error) {
+	if len(sig) != 2*scalarLen {
+		return nil, errors.New("signature not 64 bytes")
+	}
+
+	s, err := to32Scalar(sig[scalarLen:])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature S")
+	}
+
+	if s.IsOverHalfOrder() {
+		s.Negate()
+	}
+
+	resp := make([]byte, k1SigLen)
+	copy(resp, sig[:scalarLen])
+	s.PutBytesUnchecked(resp[scalarLen:k1RecIdx])
+
+	for _, recID := range []byte{0, 1} {
+		resp[k1RecIdx] = recID
+
+		recovered, err := Recover(hash, resp)
+		if err == nil && recovered.IsEqual(pubkey) {
+			return resp, nil
+		}
+	}
+
+	return nil, errors.New("signature not by public key")
+}
@@ -19,10 +19,12 @@ const (
 	StartRelay
 	StartMonitoringAPI
 	StartDebugAPI
+	StartAdminAPI
 	StartValidatorAPI
 	StartP2PPing
 	StartP2PRouters
 	StartForceDirectConns
+	StartP2PPeerExpiry
 	StartP2PConsensus
 	StartSimulator
 	StartScheduler
@@ -30,6 +32,20 @@ const (
 	StartPeerInfo
 	StartParSigDB
 	StartStackSnipe
+	StartManifestWatch
+	StartSigningPolicy
+	StartTelemetry
+	StartProfiling
+	StartSelfMonitor
+	StartMetricsPush
+	StartEventBus
+	StartClockMonitor
+	StartDepositWatch
+	StartValidatorLifecycle
+	StartRewards
+	StartSLAReport
+	StartConfigReload
+	StartSystemd // Notify systemd of readiness once all other components started.
 )
 
 // Global ordering of stop hooks; follows dependency tree from root to leaves.
@@ -39,6 +55,7 @@ const (
 	StopRetryer
 	StopDutyDB
 	StopBeaconMock // Close this before validator API, since it can hold long-lived connections.
+	StopAdminAPI
 	StopValidatorAPI
 	StopTracing // Low level services...
 	StopP2PPeerDB
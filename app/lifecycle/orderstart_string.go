@@ -16,22 +16,38 @@ func _() {
 	_ = x[StartRelay-3]
 	_ = x[StartMonitoringAPI-4]
 	_ = x[StartDebugAPI-5]
-	_ = x[StartValidatorAPI-6]
-	_ = x[StartP2PPing-7]
-	_ = x[StartP2PRouters-8]
-	_ = x[StartForceDirectConns-9]
-	_ = x[StartP2PConsensus-10]
-	_ = x[StartSimulator-11]
-	_ = x[StartScheduler-12]
-	_ = x[StartP2PEventCollector-13]
-	_ = x[StartPeerInfo-14]
-	_ = x[StartParSigDB-15]
-	_ = x[StartStackSnipe-16]
+	_ = x[StartAdminAPI-6]
+	_ = x[StartValidatorAPI-7]
+	_ = x[StartP2PPing-8]
+	_ = x[StartP2PRouters-9]
+	_ = x[StartForceDirectConns-10]
+	_ = x[StartP2PPeerExpiry-11]
+	_ = x[StartP2PConsensus-12]
+	_ = x[StartSimulator-13]
+	_ = x[StartScheduler-14]
+	_ = x[StartP2PEventCollector-15]
+	_ = x[StartPeerInfo-16]
+	_ = x[StartParSigDB-17]
+	_ = x[StartStackSnipe-18]
+	_ = x[StartManifestWatch-19]
+	_ = x[StartSigningPolicy-20]
+	_ = x[StartTelemetry-21]
+	_ = x[StartProfiling-22]
+	_ = x[StartSelfMonitor-23]
+	_ = x[StartMetricsPush-24]
+	_ = x[StartEventBus-25]
+	_ = x[StartClockMonitor-26]
+	_ = x[StartDepositWatch-27]
+	_ = x[StartValidatorLifecycle-28]
+	_ = x[StartRewards-29]
+	_ = x[StartSLAReport-30]
+	_ = x[StartConfigReload-31]
+	_ = x[StartSystemd-32]
 }
 
-const _OrderStart_name = "TrackerPrivkeyLockAggSigDBRelayMonitoringAPIDebugAPIValidatorAPIP2PPingP2PRoutersForceDirectConnsP2PConsensusSimulatorSchedulerP2PEventCollectorPeerInfoParSigDBStackSnipe"
+const _OrderStart_name = "TrackerPrivkeyLockAggSigDBRelayMonitoringAPIDebugAPIAdminAPIValidatorAPIP2PPingP2PRoutersForceDirectConnsP2PPeerExpiryP2PConsensusSimulatorSchedulerP2PEventCollectorPeerInfoParSigDBStackSnipeManifestWatchSigningPolicyTelemetryProfilingSelfMonitorMetricsPushEventBusClockMonitorDepositWatchValidatorLifecycleRewardsSLAReportConfigReloadSystemd"
 
-var _OrderStart_index = [...]uint8{0, 7, 18, 26, 31, 44, 52, 64, 71, 81, 97, 109, 118, 127, 144, 152, 160, 170}
+var _OrderStart_index = [...]uint16{0, 7, 18, 26, 31, 44, 52, 60, 72, 79, 89, 105, 118, 130, 139, 148, 165, 173, 181, 191, 204, 217, 226, 235, 246, 257, 265, 277, 289, 307, 314, 323, 335, 342}
 
 func (i OrderStart) String() string {
 	if i < 0 || i >= OrderStart(len(_OrderStart_index)-1) {
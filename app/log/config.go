@@ -5,7 +5,6 @@ package log
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"os"
 	"strings"
 	"sync"
@@ -19,7 +18,6 @@ import (
 	"go.uber.org/zap/buffer"
 	"go.uber.org/zap/zapcore"
 	"golang.org/x/term"
-	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log/loki"
@@ -31,10 +29,12 @@ const (
 	padLength         = 40
 	keyStack          = "stacktrace"
 	keyTopic          = "topic"
-
-	// maxOnDiskBackupAmt is the max amount of backups to keep on disk, before
-	// the oldest gets deleted.
-	maxOnDiskBackupAmt = 10
+	keyTime           = "ts"
+	keyLevel          = "level"
+	keyLogger         = "logger"
+	keyCaller         = "caller"
+	keyMessage        = "msg"
+	keyPretty         = "pretty"
 )
 
 const (
@@ -52,27 +52,18 @@ type zapLogger interface {
 	Core() zapcore.Core
 }
 
-// lumberjackSink implements zap.Sink.
-type lumberjackSink struct {
-	*lumberjack.Logger
-}
-
-// Sync implements zap.Sink.
-func (lumberjackSink) Sync() error {
-	return nil
-}
-
 var (
 	initMu sync.RWMutex
 	// logger is the global logger.
 	logger zapLogger = newDefaultLogger()
+	// globalLevel is the global logger level which can be changed at runtime via SetLevel.
+	globalLevel = zap.NewAtomicLevel()
 	// stopFuncs are the global logger stop functions.
 	stopFuncs []func(context.Context)
 	// lokiLabels are the global loki logger labels.
 	lokiLabels map[string]string
 
-	padding         = strings.Repeat(" ", padLength)
-	registerZapSink sync.Once
+	padding = strings.Repeat(" ", padLength)
 )
 
 // getLokiLabels returns the global loki logger labels and whether they are populated.
@@ -112,6 +103,25 @@ type Config struct {
 	LokiAddresses []string // URLs for loki logging spout
 	LokiService   string   // Value of the service label pushed with loki logs.
 	LogOutputPath string   // Path in which zap will write on-disk logs.
+	// LogOutputMaxSize is the maximum size in megabytes of the on-disk log file before it is rotated.
+	LogOutputMaxSize int
+	// LogOutputMaxAge is the maximum age of rotated on-disk log files before they are deleted, zero retains them.
+	LogOutputMaxAge time.Duration
+	// LogOutputMaxBackups is the maximum number of rotated on-disk log files to retain, zero retains all.
+	LogOutputMaxBackups int
+	// LogOutputRotateInterval is the interval at which the on-disk log file is rotated irrespective of size, zero disables it.
+	LogOutputRotateInterval time.Duration
+	// LogOutputCompress enables gzip compression of rotated on-disk log files.
+	LogOutputCompress bool
+	// TopicLevels are log level overrides by topic, e.g. {"bcast": "debug"}.
+	TopicLevels map[string]string
+	// ErrorSampleLimit is the maximum number of identical warn and error logs per minute, excess logs are dropped.
+	// Zero disables sampling.
+	ErrorSampleLimit int
+	// WindowsEventSource is the Windows event log source info, warn and error logs are written to, empty disables it.
+	WindowsEventSource string
+	// Privacy enables redaction of sensitive field values, like IP addresses and validator public keys.
+	Privacy bool
 }
 
 // ZapLevel returns the zapcore level.
@@ -163,22 +173,13 @@ func InitLogger(config Config) error {
 		return err
 	}
 
-	var registerError error
-	registerZapSink.Do(func() {
-		registerError = zap.RegisterSink("lumberjack", func(u *url.URL) (zap.Sink, error) {
-			return lumberjackSink{
-				Logger: &lumberjack.Logger{
-					Filename:   u.Path,
-					MaxBackups: maxOnDiskBackupAmt,
-					Compress:   true,
-				},
-			}, nil
-		})
-	})
-
-	if registerError != nil {
-		return errors.Wrap(err, "zap register sink lumberjack")
+	topicLevels, err := parseTopicLevels(config.TopicLevels)
+	if err != nil {
+		return err
 	}
+	globalLevel.SetLevel(level)
+	z.SetPrivacy(config.Privacy)
+	wrapCore := wrapCoreFunc(globalLevel, topicLevels, config.ErrorSampleLimit)
 
 	writer, _, err := zap.Open("stderr")
 	if err != nil {
@@ -186,30 +187,51 @@ func InitLogger(config Config) error {
 	}
 
 	callerSkip := defaultCallerSkip
-	if len(config.LokiAddresses) > 0 {
+	if len(config.LokiAddresses) > 0 || config.WindowsEventSource != "" {
 		callerSkip++
 	}
 
 	if config.Format == "console" {
 		cores := []zapcore.Core{
-			newConsoleLogger(level, color, writer),
+			wrapCore(newConsoleLogger(zapcore.DebugLevel, color, writer)),
 		}
 
 		if config.LogOutputPath != "" {
-			fileWriter, _, err := zap.Open("lumberjack:" + config.LogOutputPath)
-			if err != nil {
-				return errors.Wrap(err, "open file writer")
-			}
+			fileWriter, stopFile := newFileWriter(config)
+			stopFuncs = append(stopFuncs, stopFile)
 
 			cores = append(cores, newFileLogger(fileWriter))
 		}
 
 		logger = zap.New(zapcore.NewTee(cores...))
 	} else {
-		logger, err = newStructuredLogger(config.Format, level, color, writer, callerSkip)
+		structured, err := newStructuredLogger(config.Format, zapcore.DebugLevel, color, writer, callerSkip)
+		if err != nil {
+			return err
+		}
+		logger = structured.WithOptions(zap.WrapCore(wrapCore))
+	}
+
+	// Create a multi logger if logs are also sent elsewhere.
+	loggers := multiLogger{logger}
+
+	if config.WindowsEventSource != "" {
+		// Event log logger is opinionated: info level, logfmt format.
+		eventLogEncoder, err := newStructuredEncoder("logfmt", false)
+		if err != nil {
+			return err
+		}
+		eventLogCore, stopEventLog, err := newEventLogCore(eventLogEncoder, config.WindowsEventSource)
 		if err != nil {
 			return err
 		}
+		eventLogger := zap.New(wrapCore(eventLogCore),
+			zap.WithCaller(true),
+			zap.AddCallerSkip(callerSkip),
+		)
+
+		stopFuncs = append(stopFuncs, stopEventLog)
+		loggers = append(loggers, eventLogger)
 	}
 
 	if len(config.LokiAddresses) > 0 {
@@ -220,31 +242,44 @@ func InitLogger(config Config) error {
 			Warn(ctx, msg, err, filter)
 		}
 
-		// Create a multi logger
-		loggers := multiLogger{logger}
 		for _, address := range config.LokiAddresses {
 			lokiCl := loki.New(address, config.LokiService, logFunc, getLokiLabels)
-			// Direct-to-loki logger is opinionated: debug level, logfmt format, colored pretty field.
-			lokiLogger, err := newStructuredLogger("logfmt",
-				zapcore.DebugLevel,
-				true,
-				lokiWriter{cl: lokiCl},
-				callerSkip)
+			// Direct-to-loki logger is opinionated: debug level, logfmt format, colored pretty field, topic stream label.
+			lokiEncoder, err := newStructuredEncoder("logfmt", true)
 			if err != nil {
 				return err
 			}
+			lokiLogger := zap.New(newLokiCore(lokiEncoder, lokiCl),
+				zap.WithCaller(true),
+				zap.AddCallerSkip(callerSkip),
+			)
 
 			stopFuncs = append(stopFuncs, lokiCl.Stop)
 			loggers = append(loggers, lokiLogger)
 			go lokiCl.Run()
 		}
+	}
 
+	if len(loggers) > 1 {
 		logger = loggers
 	}
 
 	return nil
 }
 
+// SetLevel sets the level of the global logger at runtime, e.g. when reloading config.
+// Topic level overrides are retained.
+func SetLevel(level string) error {
+	l, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return errors.Wrap(err, "parse level")
+	}
+
+	globalLevel.SetLevel(l)
+
+	return nil
+}
+
 // WithClock returns a function that uses the provided clock to encode log timestamps.
 func WithClock(clock clockwork.Clock) func(config *zapcore.EncoderConfig) {
 	return func(config *zapcore.EncoderConfig) {
@@ -303,8 +338,30 @@ func Stop(ctx context.Context) {
 
 // newStructuredLogger returns an opinionated logfmt or json logger.
 func newStructuredLogger(format string, level zapcore.Level, color bool, ws zapcore.WriteSyncer, callerSkip int, opts ...func(*zapcore.EncoderConfig)) (*zap.Logger, error) {
+	structured, err := newStructuredEncoder(format, color, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return zap.New(
+		zapcore.NewCore(structured, ws, zap.NewAtomicLevelAt(level)),
+		zap.WithCaller(true),
+		zap.AddCallerSkip(callerSkip),
+	), nil
+}
+
+// newStructuredEncoder returns an opinionated logfmt or json encoder.
+func newStructuredEncoder(format string, color bool, opts ...func(*zapcore.EncoderConfig)) (zapcore.Encoder, error) {
 	encConfig := zap.NewProductionEncoderConfig()
 	encConfig.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+	// Pin the field names so log aggregation pipelines don't break if zap defaults change.
+	encConfig.TimeKey = keyTime
+	encConfig.LevelKey = keyLevel
+	encConfig.NameKey = keyLogger
+	encConfig.CallerKey = keyCaller
+	encConfig.FunctionKey = zapcore.OmitKey
+	encConfig.MessageKey = keyMessage
+	encConfig.StacktraceKey = keyStack
 
 	for _, opt := range opts {
 		opt(&encConfig)
@@ -320,16 +377,10 @@ func newStructuredLogger(format string, level zapcore.Level, color bool, ws zapc
 		return nil, errors.New("invalid logger format; not console, logfmt or json", z.Str("format", format))
 	}
 
-	structured := structuredEncoder{
+	return structuredEncoder{
 		Encoder:        encoder,
 		consoleEncoder: newConsoleEncoder(false, color, false),
-	}
-
-	return zap.New(
-		zapcore.NewCore(structured, ws, zap.NewAtomicLevelAt(level)),
-		zap.WithCaller(true),
-		zap.AddCallerSkip(callerSkip),
-	), nil
+	}, nil
 }
 
 // newDefaultLogger returns an opinionated console logger writing to stderr.
@@ -390,7 +441,7 @@ func (e structuredEncoder) EncodeEntry(ent zapcore.Entry, fields []zap.Field) (*
 	if err != nil {
 		return nil, err
 	}
-	fields = append(fields, zap.String("pretty", pretty.String()))
+	fields = append(fields, zap.String(keyPretty, pretty.String()))
 
 	for i, f := range fields {
 		if f.Key == keyStack {
@@ -67,6 +67,38 @@ func TestLokiCaller(t *testing.T) {
 	<-done
 }
 
+func TestLokiTopic(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, r.Body.Close())
+		req := decode(t, b)
+		require.Len(t, req.GetStreams(), 2)
+		// Streams are sorted by topic, no topic first.
+		require.NotContains(t, req.GetStreams()[0].GetLabels(), "topic=")
+		require.Contains(t, req.GetStreams()[1].GetLabels(), `topic="bcast"`)
+		require.Contains(t, req.GetStreams()[1].GetLabels(), `cluster_peer="peer"`)
+		require.Contains(t, req.GetStreams()[1].GetEntries()[0].GetLine(), "bcast log")
+		close(done)
+	}))
+
+	SetLokiLabels(map[string]string{"cluster_peer": "peer"})
+
+	err := InitLogger(Config{
+		Level:         "info",
+		Format:        "console",
+		LokiAddresses: []string{srv.URL},
+		LokiService:   "test",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	Info(ctx, "no topic log")
+	Info(WithTopic(ctx, "bcast"), "bcast log")
+	<-done
+}
+
 func decode(t *testing.T, b []byte) *pbv1.PushRequest {
 	t.Helper()
 
@@ -0,0 +1,18 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+//go:build !windows
+
+package log
+
+import (
+	"context"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// newEventLogCore returns an error since the Windows event log is only supported on Windows.
+func newEventLogCore(zapcore.Encoder, string) (zapcore.Core, func(context.Context), error) {
+	return nil, nil, errors.New("windows event log only supported on windows")
+}
@@ -0,0 +1,89 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package log
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/sys/windows/svc/eventlog"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// eventID is the Windows event log event ID of all logs, charon doesn't register a message file.
+const eventID = 1
+
+// newEventLogCore returns an info level core writing logs encoded by the encoder to the Windows event log source,
+// and a function to close it. The source is registered if it doesn't exist yet, which requires administrator rights.
+func newEventLogCore(enc zapcore.Encoder, source string) (zapcore.Core, func(context.Context), error) {
+	// Best effort registration, it fails if the source already exists.
+	_ = eventlog.InstallAsEventCreate(source, eventlog.Error|eventlog.Warning|eventlog.Info)
+
+	elog, err := eventlog.Open(source)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "open windows event log", z.Str("source", source))
+	}
+
+	core := eventLogCore{
+		LevelEnabler: zapcore.InfoLevel,
+		enc:          enc,
+		elog:         elog,
+	}
+
+	return core, func(context.Context) { _ = elog.Close() }, nil
+}
+
+// eventLogCore implements zapcore.Core writing logs to the Windows event log.
+type eventLogCore struct {
+	zapcore.LevelEnabler
+	enc  zapcore.Encoder
+	elog *eventlog.Log
+}
+
+func (c eventLogCore) With(fields []zapcore.Field) zapcore.Core {
+	c.enc = c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(c.enc)
+	}
+
+	return c
+}
+
+func (c eventLogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+func (c eventLogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	msg := strings.TrimSpace(buf.String())
+
+	switch {
+	case ent.Level >= zapcore.ErrorLevel:
+		err = c.elog.Error(eventID, msg)
+	case ent.Level == zapcore.WarnLevel:
+		err = c.elog.Warning(eventID, msg)
+	default:
+		err = c.elog.Info(eventID, msg)
+	}
+	if err != nil {
+		return errors.Wrap(err, "write windows event log")
+	}
+
+	return nil
+}
+
+func (eventLogCore) Sync() error {
+	return nil
+}
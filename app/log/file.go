@@ -0,0 +1,74 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package log
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// lumberjackSink implements zapcore.WriteSyncer.
+type lumberjackSink struct {
+	*lumberjack.Logger
+}
+
+// Sync implements zapcore.WriteSyncer.
+func (lumberjackSink) Sync() error {
+	return nil
+}
+
+// newFileWriter returns a rotating on-disk log writer and a function to stop it.
+// The log file is rotated when it exceeds the maximum size and at every rotation interval if configured.
+func newFileWriter(config Config) (zapcore.WriteSyncer, func(context.Context)) {
+	l := &lumberjack.Logger{
+		Filename:   config.LogOutputPath,
+		MaxSize:    config.LogOutputMaxSize,
+		MaxAge:     maxAgeDays(config.LogOutputMaxAge),
+		MaxBackups: config.LogOutputMaxBackups,
+		Compress:   config.LogOutputCompress,
+	}
+
+	if config.LogOutputRotateInterval <= 0 {
+		return lumberjackSink{Logger: l}, func(context.Context) {
+			_ = l.Close()
+		}
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(config.LogOutputRotateInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				_ = l.Rotate() // Best effort, lumberjack rotates on the next write if this fails.
+			}
+		}
+	}()
+
+	return lumberjackSink{Logger: l}, func(context.Context) {
+		close(stop)
+		<-done
+		_ = l.Close()
+	}
+}
+
+// maxAgeDays returns the max age rounded up to whole days as supported by lumberjack.
+func maxAgeDays(maxAge time.Duration) int {
+	const day = 24 * time.Hour
+	if maxAge <= 0 {
+		return 0
+	}
+
+	return int((maxAge + day - 1) / day)
+}
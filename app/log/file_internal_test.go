@@ -0,0 +1,54 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package log
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWriterRotateInterval(t *testing.T) {
+	dir := t.TempDir()
+
+	writer, stop := newFileWriter(Config{
+		LogOutputPath:           filepath.Join(dir, "charon.log"),
+		LogOutputMaxSize:        100,
+		LogOutputRotateInterval: time.Millisecond * 10,
+		LogOutputCompress:       true,
+	})
+
+	_, err := writer.Write([]byte("first\n"))
+	require.NoError(t, err)
+
+	// The log file is rotated and the backup compressed.
+	require.Eventually(t, func() bool {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+
+		for _, entry := range entries {
+			if strings.HasSuffix(entry.Name(), ".log.gz") {
+				return true
+			}
+		}
+
+		return false
+	}, time.Second, time.Millisecond*10)
+
+	stop(context.Background())
+
+	_, err = os.Stat(filepath.Join(dir, "charon.log"))
+	require.NoError(t, err)
+}
+
+func TestMaxAgeDays(t *testing.T) {
+	require.Equal(t, 0, maxAgeDays(0))
+	require.Equal(t, 1, maxAgeDays(time.Hour))
+	require.Equal(t, 1, maxAgeDays(24*time.Hour))
+	require.Equal(t, 2, maxAgeDays(25*time.Hour))
+}
@@ -0,0 +1,157 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package log
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// errorSampleTick is the interval over which identical warn and error logs are sampled.
+const errorSampleTick = time.Minute
+
+// parseTopicLevels returns the parsed log level overrides by topic.
+func parseTopicLevels(topicLevels map[string]string) (map[string]zapcore.Level, error) {
+	resp := make(map[string]zapcore.Level)
+	for topic, level := range topicLevels {
+		l, err := zapcore.ParseLevel(level)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse topic level", z.Str("topic", topic))
+		}
+		resp[topic] = l
+	}
+
+	return resp, nil
+}
+
+// newTopicLevelCore returns a core that filters logs of the wrapped core by the level, overriding it for logs of the provided topics.
+// The wrapped core must be enabled for all levels.
+func newTopicLevelCore(core zapcore.Core, level zapcore.LevelEnabler, topicLevels map[string]zapcore.Level) zapcore.Core {
+	return topicLevelCore{
+		Core:        core,
+		level:       level,
+		topicLevels: topicLevels,
+	}
+}
+
+// topicLevelCore wraps a core and filters logs by the level of their "topic" field,
+// defaulting to the level if the topic has no override.
+type topicLevelCore struct {
+	zapcore.Core
+	level       zapcore.LevelEnabler
+	topicLevels map[string]zapcore.Level
+	// topic is the topic field added via With.
+	topic string
+}
+
+// Enabled returns true if the level is enabled for any topic.
+func (c topicLevelCore) Enabled(level zapcore.Level) bool {
+	if c.level.Enabled(level) {
+		return true
+	}
+
+	for _, topicLevel := range c.topicLevels {
+		if level >= topicLevel {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c topicLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	if topic, ok := topicFromFields(fields); ok {
+		c.topic = topic
+	}
+	c.Core = c.Core.With(fields)
+
+	return c
+}
+
+func (c topicLevelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+func (c topicLevelCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	topic := c.topic
+	if t, ok := topicFromFields(fields); ok {
+		topic = t
+	}
+
+	if topicLevel, ok := c.topicLevels[topic]; ok {
+		if ent.Level < topicLevel {
+			return nil
+		}
+	} else if !c.level.Enabled(ent.Level) {
+		return nil
+	}
+
+	return c.Core.Write(ent, fields)
+}
+
+// topicFromFields returns the value of the "topic" field and true if present.
+func topicFromFields(fields []zapcore.Field) (string, bool) {
+	for _, f := range fields {
+		if f.Key == keyTopic {
+			return f.String, true
+		}
+	}
+
+	return "", false
+}
+
+// newErrorSamplerCore returns a core that drops identical (by level and message) warn and error logs
+// exceeding the limit per minute, it returns the core as is if the limit is zero.
+// Debug and info logs are not sampled.
+func newErrorSamplerCore(core zapcore.Core, limit int) zapcore.Core {
+	if limit <= 0 {
+		return core
+	}
+
+	hook := zapcore.SamplerHook(func(ent zapcore.Entry, dec zapcore.SamplingDecision) {
+		if dec&zapcore.LogDropped != 0 {
+			sampledCounter.WithLabelValues(ent.Level.String()).Inc()
+		}
+	})
+
+	return errorSamplerCore{
+		Core:    core,
+		sampler: zapcore.NewSamplerWithOptions(core, errorSampleTick, limit, 0, hook),
+	}
+}
+
+// errorSamplerCore wraps a core and samples warn and error logs via the sampler core.
+type errorSamplerCore struct {
+	zapcore.Core
+	sampler zapcore.Core
+}
+
+func (c errorSamplerCore) With(fields []zapcore.Field) zapcore.Core {
+	return errorSamplerCore{
+		Core:    c.Core.With(fields),
+		sampler: c.sampler.With(fields),
+	}
+}
+
+func (c errorSamplerCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level >= zapcore.WarnLevel {
+		return c.sampler.Check(ent, ce)
+	}
+
+	return c.Core.Check(ent, ce)
+}
+
+// wrapCoreFunc returns a function, see zap.WrapCore, wrapping the core with the topic level and error sampler cores.
+func wrapCoreFunc(level zapcore.LevelEnabler, topicLevels map[string]zapcore.Level, sampleLimit int) func(zapcore.Core) zapcore.Core {
+	return func(core zapcore.Core) zapcore.Core {
+		return newErrorSamplerCore(newTopicLevelCore(core, level, topicLevels), sampleLimit)
+	}
+}
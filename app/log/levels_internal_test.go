@@ -0,0 +1,100 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/obolnetwork/charon/app/z"
+)
+
+func TestTopicLevels(t *testing.T) {
+	topicLevels, err := parseTopicLevels(map[string]string{"bcast": "debug", "p2p": "error"})
+	require.NoError(t, err)
+
+	_, err = parseTopicLevels(map[string]string{"bcast": "invalid"})
+	require.ErrorContains(t, err, "parse topic level")
+
+	inner, logs := observer.New(zapcore.DebugLevel)
+	ctx := WithLogger(context.Background(), zap.New(wrapCoreFunc(zapcore.InfoLevel, topicLevels, 0)(inner)))
+
+	Debug(WithTopic(ctx, "bcast"), "bcast debug")
+	Debug(WithTopic(ctx, "sched"), "sched debug")
+	Info(WithTopic(ctx, "sched"), "sched info")
+	Warn(WithTopic(ctx, "p2p"), "p2p warn", nil)
+	Error(WithTopic(ctx, "p2p"), "p2p error", nil)
+	Debug(ctx, "no topic debug")
+
+	var msgs []string
+	for _, entry := range logs.All() {
+		msgs = append(msgs, entry.Message)
+	}
+	require.Equal(t, []string{"bcast debug", "sched info", "p2p error"}, msgs)
+}
+
+func TestLazyFields(t *testing.T) {
+	inner, logs := observer.New(zapcore.DebugLevel)
+	ctx := WithLogger(context.Background(), zap.New(wrapCoreFunc(zapcore.InfoLevel, nil, 0)(inner)))
+
+	var evaluated []string
+	lazy := func(name string) z.Field {
+		return z.Lazy("lazy", func() any {
+			evaluated = append(evaluated, name)
+			return name
+		})
+	}
+
+	Debug(ctx, "filtered", lazy("debug"))
+	Info(ctx, "logged", lazy("info"))
+
+	require.Equal(t, []string{"info"}, evaluated)
+	require.Len(t, logs.All(), 1)
+}
+
+func TestErrorSampling(t *testing.T) {
+	inner, logs := observer.New(zapcore.DebugLevel)
+	ctx := WithLogger(context.Background(), zap.New(wrapCoreFunc(zapcore.DebugLevel, nil, 2)(inner)))
+
+	for range 5 {
+		Debug(ctx, "debug")
+		Warn(ctx, "warn", nil)
+		Error(ctx, "error", nil)
+	}
+	Error(ctx, "other error", nil)
+
+	counts := make(map[string]int)
+	for _, entry := range logs.All() {
+		counts[entry.Message]++
+	}
+	require.Equal(t, map[string]int{"debug": 5, "warn": 2, "error": 2, "other error": 1}, counts)
+}
+
+func TestDynamicLevel(t *testing.T) {
+	topicLevels, err := parseTopicLevels(map[string]string{"p2p": "error"})
+	require.NoError(t, err)
+
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	inner, logs := observer.New(zapcore.DebugLevel)
+	ctx := WithLogger(context.Background(), zap.New(wrapCoreFunc(level, topicLevels, 0)(inner)))
+
+	Debug(ctx, "debug before")
+	Info(ctx, "info before")
+
+	level.SetLevel(zapcore.DebugLevel)
+	Debug(ctx, "debug after")
+	Warn(WithTopic(ctx, "p2p"), "p2p warn after", nil)
+
+	var msgs []string
+	for _, entry := range logs.All() {
+		msgs = append(msgs, entry.Message)
+	}
+	require.Equal(t, []string{"info before", "debug after"}, msgs)
+
+	require.ErrorContains(t, SetLevel("invalid"), "parse level")
+}
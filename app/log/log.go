@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/z"
@@ -77,9 +78,19 @@ func getLogger(ctx context.Context) zapLogger {
 	return logger
 }
 
+// enabled returns true if the level is enabled for any topic or if the span is recording.
+// This avoids unwrapping, and therefore evaluating, the fields of logs that are filtered out.
+func enabled(ctx context.Context, level zapcore.Level) bool {
+	return getLogger(ctx).Core().Enabled(level) || trace.SpanFromContext(ctx).IsRecording()
+}
+
 // Debug logs the message and fields (incl fields in the context) at Debug level.
 // Debug should be used for most logging.
 func Debug(ctx context.Context, msg string, fields ...z.Field) {
+	if !enabled(ctx, zapcore.DebugLevel) {
+		return
+	}
+
 	zfl, ok := unwrapDedup(ctx, fields...)
 	if !ok {
 		return
@@ -91,6 +102,10 @@ func Debug(ctx context.Context, msg string, fields ...z.Field) {
 // Info logs the message and fields (incl fields in the context) at Info level.
 // Info should only be used for high level important events.
 func Info(ctx context.Context, msg string, fields ...z.Field) {
+	if !enabled(ctx, zapcore.InfoLevel) {
+		return
+	}
+
 	zfl, ok := unwrapDedup(ctx, fields...)
 	if !ok {
 		return
@@ -116,6 +131,8 @@ func Warn(ctx context.Context, msg string, err error, fields ...z.Field) {
 		return
 	}
 
+	incErrorCodeCounter("warn", err)
+
 	err = errors.SkipWrap(err, msg, 2, fields...)
 	zfl, ok := unwrapDedup(ctx, errFields(err))
 	if !ok {
@@ -142,6 +159,8 @@ func Error(ctx context.Context, msg string, err error, fields ...z.Field) {
 		return
 	}
 
+	incErrorCodeCounter("error", err)
+
 	err = errors.SkipWrap(err, msg, 2, fields...)
 	zfl, ok := unwrapDedup(ctx, errFields(err))
 	if !ok {
@@ -4,6 +4,7 @@ package loki
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,27 +19,29 @@ import (
 // to reduce the number of push requests to Loki aggregating multiple
 // entries in a single batch request.
 type batch struct {
-	entries   []*pbv1.Entry
+	// entries are the entries by topic, each topic is sent as a separate stream.
+	entries   map[string][]*pbv1.Entry
 	bytes     int
 	createdAt time.Time
 }
 
 func newBatch(entries ...*pbv1.Entry) *batch {
 	b := &batch{
+		entries:   make(map[string][]*pbv1.Entry),
 		createdAt: time.Now(),
 	}
 
 	for _, entry := range entries {
-		b.Add(entry)
+		b.Add("", entry)
 	}
 
 	return b
 }
 
-// Add an entry to the batch.
-func (b *batch) Add(entry *pbv1.Entry) {
+// Add an entry of the topic to the batch.
+func (b *batch) Add(topic string, entry *pbv1.Entry) {
 	b.bytes += len(entry.GetLine())
-	b.entries = append(b.entries, entry)
+	b.entries[topic] = append(b.entries[topic], entry)
 }
 
 // Size returns the current batch size in bytes.
@@ -53,12 +56,33 @@ func (b batch) Age() time.Duration {
 
 // Encode the batch as snappy-compressed push request, and returns
 // the encoded bytes and the number of encoded entries.
+// Entries of each non-empty topic are sent as a separate stream with an additional "topic" label.
 func (b batch) Encode(labels map[string]string) ([]byte, error) {
+	topics := make([]string, 0, len(b.entries))
+	for topic := range b.entries {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	var streams []*pbv1.Stream
+	for _, topic := range topics {
+		streamLabels := labels
+		if topic != "" {
+			streamLabels = make(map[string]string, len(labels)+1)
+			for k, v := range labels {
+				streamLabels[k] = v
+			}
+			streamLabels["topic"] = topic
+		}
+
+		streams = append(streams, &pbv1.Stream{
+			Labels:  fmtLabels(streamLabels),
+			Entries: b.entries[topic],
+		})
+	}
+
 	buf, err := proto.Marshal(&pbv1.PushRequest{
-		Streams: []*pbv1.Stream{{
-			Labels:  fmtLabels(labels),
-			Entries: b.entries,
-		}},
+		Streams: streams,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "marshal loki proto")
@@ -58,7 +58,7 @@ func newInternal(endpoint string, serviceLabel string, batchWait time.Duration,
 		endpoint:       endpoint,
 		done:           make(chan struct{}),
 		quit:           make(chan struct{}),
-		input:          make(chan string),
+		input:          make(chan entry),
 		batchMax:       batchMax,
 		batchWait:      batchWait,
 		maxLogLineLen:  maxLogLineLen,
@@ -68,9 +68,15 @@ func newInternal(endpoint string, serviceLabel string, batchWait time.Duration,
 	}
 }
 
+// entry is a log line of a topic.
+type entry struct {
+	topic string
+	line  string
+}
+
 // Client for pushing logs in snappy-compressed protos over HTTP.
 type Client struct {
-	input          chan string
+	input          chan entry
 	quit           chan struct{}
 	done           chan struct{}
 	endpoint       string
@@ -103,21 +109,24 @@ func (c *Client) Run() {
 
 	for {
 		select {
-		case line := <-c.input:
-			if len(line) > c.maxLogLineLen && c.maxLogLineLen != 0 {
+		case e := <-c.input:
+			if len(e.line) > c.maxLogLineLen && c.maxLogLineLen != 0 {
 				continue // Silently drop log line longer than maxLogLineLen if set
 			}
 
-			batch.Add(&pbv1.Entry{
+			batch.Add(e.topic, &pbv1.Entry{
 				Timestamp: timestamppb.Now(),
-				Line:      line,
+				Line:      e.line,
 			})
 
 			if batch.Size() > c.batchMax {
 				batch = newBatch() // Just silently drop, there should have been multiple error logs below.
 			}
 		case <-c.quit:
-			_ = send(ctx, client, c.endpoint, batch, c.labels()) // On shutdown just try to send once as best effort.
+			if batch.Size() > 0 {
+				_ = send(ctx, client, c.endpoint, batch, c.labels()) // On shutdown just try to send once as best effort.
+			}
+
 			return
 		case <-ticker.C:
 			// Do not send if the batch is empty or too young or labels not ready yet.
@@ -152,8 +161,14 @@ func (c *Client) Run() {
 
 // Add enqueues a line for sending to loki.
 func (c *Client) Add(line string) {
+	c.AddTopic("", line)
+}
+
+// AddTopic enqueues a line of the topic for sending to loki. Lines of each topic
+// are sent as a separate stream labelled by topic.
+func (c *Client) AddTopic(topic, line string) {
 	select {
-	case c.input <- line:
+	case c.input <- entry{topic: topic, line: line}:
 	case <-c.quit:
 	}
 }
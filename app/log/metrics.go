@@ -7,6 +7,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/promauto"
 )
 
@@ -25,6 +26,20 @@ var (
 		Help:        "Total count of logged warnings by topic",
 		ConstLabels: nil,
 	}, []string{"topic"})
+
+	errorCodeCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "log",
+		Name:      "error_codes_total",
+		Help:      "Total count of logged warnings and errors with error codes by level and code",
+	}, []string{"level", "code"})
+
+	sampledCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "log",
+		Name:      "sampled_total",
+		Help:      "Total count of identical warn and error logs dropped by sampling by level",
+	}, []string{"level"})
 )
 
 func incWarnCounter(ctx context.Context) {
@@ -34,3 +49,12 @@ func incWarnCounter(ctx context.Context) {
 func incErrorCounter(ctx context.Context) {
 	errorCounter.WithLabelValues(metricsTopicFromCtx(ctx)).Inc()
 }
+
+func incErrorCodeCounter(level string, err error) {
+	code, ok := errors.CodeOf(err)
+	if !ok {
+		return
+	}
+
+	errorCodeCounter.WithLabelValues(level, code.String()).Inc()
+}
@@ -38,17 +38,64 @@ func (m multiLogger) Error(msg string, fields ...zap.Field) {
 	}
 }
 
-// lokiWriter wraps a loki client and implements zap.SyncWriter.
-type lokiWriter struct {
-	cl *loki.Client
+// newLokiCore returns a debug level core sending logs encoded by the encoder to the loki client,
+// streamed by their "topic" field.
+func newLokiCore(enc zapcore.Encoder, cl *loki.Client) zapcore.Core {
+	return lokiCore{
+		LevelEnabler: zapcore.DebugLevel,
+		enc:          enc,
+		cl:           cl,
+	}
+}
+
+// lokiCore implements zapcore.Core sending logs to a loki client.
+type lokiCore struct {
+	zapcore.LevelEnabler
+	enc zapcore.Encoder
+	cl  *loki.Client
+	// topic is the topic field added via With.
+	topic string
+}
+
+func (c lokiCore) With(fields []zapcore.Field) zapcore.Core {
+	if topic, ok := topicFromFields(fields); ok {
+		c.topic = topic
+	}
+
+	c.enc = c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(c.enc)
+	}
+
+	return c
 }
 
-func (l lokiWriter) Write(line []byte) (n int, err error) {
-	l.cl.Add(string(line))
-	return len(line), nil
+func (c lokiCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+func (c lokiCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	topic := c.topic
+	if t, ok := topicFromFields(fields); ok {
+		topic = t
+	}
+
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	c.cl.AddTopic(topic, buf.String())
+
+	return nil
 }
 
-func (lokiWriter) Sync() error {
+func (lokiCore) Sync() error {
 	return nil
 }
 
@@ -0,0 +1,215 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package manifestwatch provides hot reloading of the cluster manifest at runtime.
+package manifestwatch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+)
+
+// New returns a new watcher of the cluster manifest and legacy lock files for signed mutations extending the
+// provided currently applied cluster DAG. The files are polled every period.
+func New(manifestFile, lockFile string, dag *manifestpb.SignedMutationList, period time.Duration) (*Watcher, error) {
+	return newWatcher(func() (*manifestpb.SignedMutationList, error) {
+		return manifest.LoadDAG(manifestFile, lockFile, nil)
+	}, dag, period)
+}
+
+func newWatcher(loadFunc func() (*manifestpb.SignedMutationList, error), dag *manifestpb.SignedMutationList, period time.Duration) (*Watcher, error) {
+	cluster, err := manifest.Materialise(dag)
+	if err != nil {
+		return nil, errors.Wrap(err, "materialise cluster manifest")
+	}
+
+	return &Watcher{
+		loadFunc:   loadFunc,
+		period:     period,
+		dag:        dag,
+		cluster:    cluster,
+		warnFilter: log.Filter(),
+	}, nil
+}
+
+// Watcher polls the cluster manifest for new signed mutations extending the applied cluster DAG.
+// Valid mutations are applied at the next epoch boundary by calling the subscribers with the new cluster.
+//
+// Only mutations that leave the cluster operators, threshold and existing validator keys unchanged are
+// applied, e.g., added validators or updated validator addresses. Other changes require a restart.
+type Watcher struct {
+	loadFunc   func() (*manifestpb.SignedMutationList, error)
+	period     time.Duration
+	warnFilter z.Field
+	subs       []func(context.Context, *manifestpb.Cluster) error
+
+	mu             sync.Mutex
+	dag            *manifestpb.SignedMutationList
+	cluster        *manifestpb.Cluster
+	pending        *manifestpb.SignedMutationList
+	pendingCluster *manifestpb.Cluster
+	rejected       *manifestpb.SignedMutationList
+}
+
+// Subscribe registers a callback function called with the new cluster when mutations are applied.
+// Subscribers must be idempotent since they are retried at the next epoch boundary if any subscriber fails.
+// Note this should be called *before* Run.
+func (w *Watcher) Subscribe(fn func(context.Context, *manifestpb.Cluster) error) {
+	w.subs = append(w.subs, fn)
+}
+
+// Run blocks and polls the cluster manifest until the context is closed.
+func (w *Watcher) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "manifest")
+
+	ticker := time.NewTicker(w.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll(ctx)
+		}
+	}
+}
+
+// poll loads the cluster manifest from disk and stores it as pending if it validly extends the applied cluster DAG.
+func (w *Watcher) poll(ctx context.Context) {
+	next, err := w.loadFunc()
+	if err != nil {
+		log.Warn(ctx, "Failed loading cluster manifest for reload", err, w.warnFilter)
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if proto.Equal(next, w.dag) {
+		w.pending, w.pendingCluster = nil, nil // Pending mutations reverted.
+		return
+	} else if proto.Equal(next, w.pending) || proto.Equal(next, w.rejected) {
+		return // Nothing new.
+	}
+
+	cluster, err := verifyExtension(w.dag, w.cluster, next)
+	if err != nil {
+		w.rejected = next
+		rejectedCounter.Inc()
+		log.Warn(ctx, "Rejected cluster manifest reload, restart required to apply changes", err)
+
+		return
+	}
+
+	w.rejected = nil
+	w.pending, w.pendingCluster = next, cluster
+
+	log.Info(ctx, "New cluster manifest mutations loaded, applying at next epoch boundary",
+		z.Int("mutations", len(next.GetMutations())-len(w.dag.GetMutations())),
+		z.Int("validators", len(cluster.GetValidators())),
+	)
+}
+
+// SlotTicked applies pending mutations at the first slot of each epoch.
+// It is a scheduler slot subscriber.
+func (w *Watcher) SlotTicked(ctx context.Context, slot core.Slot) error {
+	if !slot.FirstInEpoch() {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.pending == nil {
+		return nil
+	}
+
+	ctx = log.WithTopic(ctx, "manifest")
+
+	for _, sub := range w.subs {
+		if err := sub(ctx, proto.Clone(w.pendingCluster).(*manifestpb.Cluster)); err != nil {
+			return errors.Wrap(err, "apply cluster manifest mutations, retrying next epoch")
+		}
+	}
+
+	for _, mutation := range w.pending.GetMutations()[len(w.dag.GetMutations()):] {
+		appliedCounter.WithLabelValues(mutation.GetMutation().GetType()).Inc()
+	}
+
+	log.Info(ctx, "Applied cluster manifest mutations",
+		z.Int("mutations", len(w.pending.GetMutations())-len(w.dag.GetMutations())),
+		z.Int("validators", len(w.pendingCluster.GetValidators())),
+		z.U64("epoch", slot.Epoch()),
+		z.Str("latest_mutation_hash", fmt.Sprintf("%#x", w.pendingCluster.GetLatestMutationHash())),
+	)
+
+	w.dag, w.cluster = w.pending, w.pendingCluster
+	w.pending, w.pendingCluster = nil, nil
+
+	return nil
+}
+
+// verifyExtension returns the materialised next cluster if the next DAG appends valid mutations to the previous DAG
+// that can be applied at runtime. It returns an error otherwise.
+func verifyExtension(prevDAG *manifestpb.SignedMutationList, prev *manifestpb.Cluster, nextDAG *manifestpb.SignedMutationList) (*manifestpb.Cluster, error) {
+	if len(nextDAG.GetMutations()) <= len(prevDAG.GetMutations()) {
+		return nil, errors.New("cluster manifest doesn't extend current manifest")
+	}
+
+	for i, mutation := range prevDAG.GetMutations() {
+		if !proto.Equal(mutation, nextDAG.GetMutations()[i]) {
+			return nil, errors.New("cluster manifest mutation history changed", z.Int("index", i))
+		}
+	}
+
+	next, err := manifest.Materialise(nextDAG)
+	if err != nil {
+		return nil, errors.Wrap(err, "materialise cluster manifest")
+	}
+
+	// Only validators may change.
+	prevOther := proto.Clone(prev).(*manifestpb.Cluster)
+	nextOther := proto.Clone(next).(*manifestpb.Cluster)
+	for _, c := range []*manifestpb.Cluster{prevOther, nextOther} {
+		c.LatestMutationHash = nil
+		c.Validators = nil
+	}
+	if !proto.Equal(prevOther, nextOther) {
+		return nil, errors.New("cluster changes other than validators not supported")
+	}
+
+	if len(next.GetValidators()) < len(prev.GetValidators()) {
+		return nil, errors.New("removing validators not supported")
+	}
+
+	for i, val := range prev.GetValidators() {
+		nextVal := next.GetValidators()[i]
+		if !bytes.Equal(val.GetPublicKey(), nextVal.GetPublicKey()) {
+			return nil, errors.New("existing validator public key changed", z.Int("validator_index", i))
+		}
+
+		if len(val.GetPubShares()) != len(nextVal.GetPubShares()) {
+			return nil, errors.New("existing validator public shares changed", z.Int("validator_index", i))
+		}
+
+		for j, pubShare := range val.GetPubShares() {
+			if !bytes.Equal(pubShare, nextVal.GetPubShares()[j]) {
+				return nil, errors.New("existing validator public shares changed", z.Int("validator_index", i))
+			}
+		}
+	}
+
+	return next, nil
+}
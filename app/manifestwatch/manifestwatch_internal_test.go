@@ -0,0 +1,175 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifestwatch
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestWatcher(t *testing.T) {
+	ctx := context.Background()
+	dag, added := newAddValidatorsDAGs(t, 1)
+
+	var (
+		mu   sync.Mutex
+		next = dag
+	)
+	loadFunc := func() (*manifestpb.SignedMutationList, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return next, nil
+	}
+
+	w, err := newWatcher(loadFunc, dag, time.Hour)
+	require.NoError(t, err)
+
+	var (
+		applied []*manifestpb.Cluster
+		subErr  error
+	)
+	w.Subscribe(func(_ context.Context, c *manifestpb.Cluster) error {
+		if subErr != nil {
+			return subErr
+		}
+		applied = append(applied, c)
+
+		return nil
+	})
+
+	slot := func(s uint64) core.Slot {
+		return core.Slot{Slot: s, SlotsPerEpoch: 16}
+	}
+
+	// Unchanged manifest isn't applied.
+	w.poll(ctx)
+	require.NoError(t, w.SlotTicked(ctx, slot(16)))
+	require.Empty(t, applied)
+
+	mu.Lock()
+	next = added
+	mu.Unlock()
+	w.poll(ctx)
+
+	// Pending mutations are only applied at epoch boundaries.
+	require.NoError(t, w.SlotTicked(ctx, slot(17)))
+	require.Empty(t, applied)
+
+	// Failed subscribers are retried at the next epoch boundary.
+	subErr = errors.New("failed")
+	require.ErrorContains(t, w.SlotTicked(ctx, slot(32)), "failed")
+	require.Empty(t, applied)
+	subErr = nil
+
+	require.NoError(t, w.SlotTicked(ctx, slot(48)))
+	require.Len(t, applied, 1)
+	require.Len(t, applied[0].GetValidators(), 2)
+
+	// Applied mutations aren't applied again.
+	w.poll(ctx)
+	require.NoError(t, w.SlotTicked(ctx, slot(64)))
+	require.Len(t, applied, 1)
+}
+
+func TestVerifyExtension(t *testing.T) {
+	dag, added := newAddValidatorsDAGs(t, 1)
+
+	prev, err := manifest.Materialise(dag)
+	require.NoError(t, err)
+
+	t.Run("added validators", func(t *testing.T) {
+		c, err := verifyExtension(dag, prev, added)
+		require.NoError(t, err)
+		require.Len(t, c.GetValidators(), 2)
+	})
+
+	t.Run("not extending", func(t *testing.T) {
+		_, err := verifyExtension(dag, prev, dag)
+		require.ErrorContains(t, err, "cluster manifest doesn't extend current manifest")
+	})
+
+	t.Run("history changed", func(t *testing.T) {
+		otherDAG, otherAdded := newAddValidatorsDAGs(t, 2)
+		require.False(t, proto.Equal(dag, otherDAG))
+
+		_, err := verifyExtension(dag, prev, otherAdded)
+		require.ErrorContains(t, err, "cluster manifest mutation history changed")
+	})
+
+	t.Run("invalid mutation", func(t *testing.T) {
+		invalid := proto.Clone(added).(*manifestpb.SignedMutationList)
+		invalid.Mutations[1].Mutation.Parent = make([]byte, 32)
+
+		_, err := verifyExtension(dag, prev, invalid)
+		require.ErrorContains(t, err, "materialise cluster manifest")
+	})
+}
+
+// newAddValidatorsDAGs returns a cluster DAG with a single validator and the DAG extended by
+// a signed add validators mutation adding another validator.
+func newAddValidatorsDAGs(t *testing.T, seed int) (*manifestpb.SignedMutationList, *manifestpb.SignedMutationList) {
+	t.Helper()
+
+	const nodes = 4
+
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, _ := cluster.NewForT(t, 1, 3, nodes, seed, random)
+	other, _, _ := cluster.NewForT(t, 1, 3, nodes, seed+100, random)
+
+	legacy, err := manifest.NewLegacyLockForT(t, lock)
+	require.NoError(t, err)
+
+	dag := &manifestpb.SignedMutationList{Mutations: []*manifestpb.SignedMutation{legacy}}
+	c, err := manifest.Materialise(dag)
+	require.NoError(t, err)
+
+	val, err := manifest.ValidatorToProto(other.Validators[0], other.ValidatorAddresses[0])
+	require.NoError(t, err)
+
+	addVals := newAddValidators(t, c.GetLatestMutationHash(), []*manifestpb.Validator{val}, p2pKeys)
+
+	added := proto.Clone(dag).(*manifestpb.SignedMutationList)
+	added.Mutations = append(added.Mutations, addVals)
+
+	return dag, added
+}
+
+func newAddValidators(t *testing.T, parent []byte, vals []*manifestpb.Validator, p2pKeys []*k1.PrivateKey) *manifestpb.SignedMutation {
+	t.Helper()
+
+	genVals, err := manifest.NewGenValidators(parent, vals)
+	require.NoError(t, err)
+
+	genHash, err := manifest.Hash(genVals)
+	require.NoError(t, err)
+
+	var approvals []*manifestpb.SignedMutation
+	for _, key := range p2pKeys {
+		approval, err := manifest.SignNodeApproval(genHash, key)
+		require.NoError(t, err)
+
+		approvals = append(approvals, approval)
+	}
+
+	nodeApprovals, err := manifest.NewNodeApprovalsComposite(approvals)
+	require.NoError(t, err)
+
+	addVals, err := manifest.NewAddValidators(genVals, nodeApprovals)
+	require.NoError(t, err)
+
+	return addVals
+}
@@ -0,0 +1,25 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifestwatch
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	appliedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "manifest",
+		Name:      "mutations_applied_total",
+		Help:      "Total number of cluster manifest mutations applied at runtime by type",
+	}, []string{"type"})
+
+	rejectedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "manifest",
+		Name:      "reloads_rejected_total",
+		Help:      "Total number of rejected cluster manifest reloads that require a restart to apply",
+	})
+)
@@ -0,0 +1,75 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package memlock provides functions to lock sensitive key material into non-swappable memory
+// and to zeroize it after use.
+package memlock
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// warnOnce ensures the insufficient mlock limit warning is only logged once.
+var warnOnce sync.Once
+
+// Zero overwrites the buffer with zeros.
+func Zero(b []byte) {
+	clear(b)
+	runtime.KeepAlive(b)
+}
+
+// Required returns the locked memory in bytes required to lock a buffer of the provided size.
+func Required(size int) uint64 {
+	pageSize := os.Getpagesize()
+
+	// A buffer may span an additional page if not page aligned.
+	return uint64((size/pageSize + 2) * pageSize)
+}
+
+// CheckLimit logs a warning if the locked memory limit of the process is lower than required bytes.
+func CheckLimit(ctx context.Context, required uint64) {
+	limit, ok := limit()
+	if !ok || limit >= required {
+		return
+	}
+
+	warnOnce.Do(func() {
+		log.Warn(ctx, "Insufficient locked memory limit, key shares may be swapped to disk. "+
+			"Increase the limit via `ulimit -l`, docker's `--ulimit memlock=-1` or grant CAP_IPC_LOCK", nil,
+			z.U64("limit", limit), z.U64("required", required))
+	})
+}
+
+// ProtectKeys locks the key shares into non-swappable memory and returns a function that zeroizes and unlocks them.
+// Failing to lock memory only results in a warning, since key shares remain usable.
+func ProtectKeys(ctx context.Context, keys []tbls.PrivateKey) func() {
+	if len(keys) == 0 {
+		return func() {}
+	}
+
+	b := unsafe.Slice(&keys[0][0], len(keys)*len(keys[0]))
+
+	locked := true
+	if err := lock(b); err != nil {
+		locked = false
+		warnOnce.Do(func() {
+			log.Warn(ctx, "Failed to lock key share memory, key shares may be swapped to disk. "+
+				"Increase the limit via `ulimit -l`, docker's `--ulimit memlock=-1` or grant CAP_IPC_LOCK", err,
+				z.Int("required", int(Required(len(b)))))
+		})
+	}
+
+	return func() {
+		Zero(b)
+		if locked {
+			_ = unlock(b)
+		}
+	}
+}
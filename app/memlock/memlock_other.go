@@ -0,0 +1,22 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+//go:build !unix
+
+package memlock
+
+import "github.com/obolnetwork/charon/app/errors"
+
+// lock returns an error since locking memory isn't supported on this platform.
+func lock([]byte) error {
+	return errors.New("memory locking not supported")
+}
+
+// unlock is a no-op since locking memory isn't supported on this platform.
+func unlock([]byte) error {
+	return nil
+}
+
+// limit returns false since locking memory isn't supported on this platform.
+func limit() (uint64, bool) {
+	return 0, false
+}
@@ -0,0 +1,50 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package memlock_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/memlock"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestProtectKeys(t *testing.T) {
+	ctx := context.Background()
+
+	var keys []tbls.PrivateKey
+	for range 3 {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+		keys = append(keys, secret)
+	}
+
+	release := memlock.ProtectKeys(ctx, keys)
+
+	// Keys remain usable while protected.
+	_, err := tbls.Sign(keys[0], []byte("data"))
+	require.NoError(t, err)
+
+	release()
+
+	for _, key := range keys {
+		require.Equal(t, tbls.PrivateKey{}, key)
+	}
+
+	// Empty key slices are a noop.
+	memlock.ProtectKeys(ctx, nil)()
+}
+
+func TestZero(t *testing.T) {
+	b := []byte{1, 2, 3}
+	memlock.Zero(b)
+	require.Equal(t, []byte{0, 0, 0}, b)
+}
+
+func TestRequired(t *testing.T) {
+	require.Greater(t, memlock.Required(32), uint64(32))
+	require.GreaterOrEqual(t, memlock.Required(1<<20), uint64(1<<20))
+}
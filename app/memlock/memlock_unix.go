@@ -0,0 +1,39 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+//go:build unix
+
+package memlock
+
+import (
+	"golang.org/x/sys/unix"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// lock locks the memory pages containing the buffer into RAM, preventing them from being swapped.
+func lock(b []byte) error {
+	if err := unix.Mlock(b); err != nil {
+		return errors.Wrap(err, "mlock")
+	}
+
+	return nil
+}
+
+// unlock unlocks the memory pages containing the buffer.
+func unlock(b []byte) error {
+	if err := unix.Munlock(b); err != nil {
+		return errors.Wrap(err, "munlock")
+	}
+
+	return nil
+}
+
+// limit returns the soft locked memory limit of the process in bytes.
+func limit() (uint64, bool) {
+	var rlimit unix.Rlimit
+	if err := unix.Getrlimit(unix.RLIMIT_MEMLOCK, &rlimit); err != nil {
+		return 0, false
+	}
+
+	return rlimit.Cur, true
+}
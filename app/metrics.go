@@ -75,6 +75,20 @@ var (
 		Help:      "Gauge set to the peer count of the upstream beacon node",
 	})
 
+	beaconNodeSyncingGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "monitoring",
+		Name:      "beacon_node_syncing",
+		Help:      "Gauge set to 1 if the upstream beacon node is syncing, else 0",
+	})
+
+	beaconNodeSyncDistanceGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "beacon_node",
+		Name:      "sync_distance",
+		Help:      "Gauge set to the sync distance in slots of the upstream beacon node",
+	})
+
 	beaconNodeVersionGauge = promauto.NewResetGaugeVec(prometheus.GaugeOpts{
 		Namespace: "app",
 		Subsystem: "beacon_node",
@@ -112,6 +126,13 @@ var (
 		Name:      "params",
 		Help:      "Parameters for each component of the validator stack in which this Charon instance is deployed into",
 	}, []string{"component", "cli_parameters"})
+
+	configReloadCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "config",
+		Name:      "reloads_total",
+		Help:      "Total number of runtime config reloads by result (success or error)",
+	}, []string{"result"})
 )
 
 func initStartupMetrics(peerName string, threshold, numOperators, numValidators int, network string) {
@@ -0,0 +1,16 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package metricspush
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var pushErrors = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "app",
+	Subsystem: "metricspush",
+	Name:      "errors_total",
+	Help:      "Total number of failed metrics pushes",
+})
@@ -0,0 +1,210 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package metricspush pushes metrics via Prometheus remote write or to a Pushgateway on an interval,
+// for nodes behind NAT without inbound access for Prometheus scraping.
+package metricspush
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	pb "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	remotepb "github.com/obolnetwork/charon/app/metricspush/remotepb/v1"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// job is the Pushgateway job name of charon metrics.
+const job = "charon"
+
+// Config defines the metrics push config.
+type Config struct {
+	// RemoteWriteURL enables pushing metrics to this Prometheus remote write endpoint.
+	RemoteWriteURL string
+	// PushgatewayURL enables pushing metrics to this Pushgateway.
+	PushgatewayURL string
+	// Interval is the interval between pushes.
+	Interval time.Duration
+}
+
+// Enabled returns true if any push target is configured.
+func (c Config) Enabled() bool {
+	return c.RemoteWriteURL != "" || c.PushgatewayURL != ""
+}
+
+// New returns a new metrics pusher pushing the metrics gathered from the gatherer.
+// The instance identifies the node in the Pushgateway grouping key, since all nodes push to the same job.
+func New(config Config, gatherer prometheus.Gatherer, instance string) *Pusher {
+	p := &Pusher{
+		config:   config,
+		gatherer: gatherer,
+		client:   &http.Client{Timeout: config.Interval},
+	}
+
+	if config.PushgatewayURL != "" {
+		p.gateway = push.New(config.PushgatewayURL, job).
+			Gatherer(gatherer).
+			Grouping("instance", instance).
+			Client(p.client)
+	}
+
+	return p
+}
+
+// Pusher pushes metrics on an interval.
+type Pusher struct {
+	config   Config
+	gatherer prometheus.Gatherer
+	client   *http.Client
+	gateway  *push.Pusher
+}
+
+// Run pushes metrics every interval until the context is cancelled.
+func (p *Pusher) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "metricspush")
+	log.Info(ctx, "Pushing metrics",
+		z.Str("remote_write_url", p.config.RemoteWriteURL),
+		z.Str("pushgateway_url", p.config.PushgatewayURL),
+		z.Str("interval", p.config.Interval.String()))
+
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.push(ctx); err != nil {
+				pushErrors.Inc()
+				log.Warn(ctx, "Failed to push metrics", err)
+			}
+		}
+	}
+}
+
+// push pushes metrics to all configured targets.
+func (p *Pusher) push(ctx context.Context) error {
+	if p.config.RemoteWriteURL != "" {
+		if err := p.remoteWrite(ctx, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	if p.gateway != nil {
+		if err := p.gateway.PushContext(ctx); err != nil {
+			return errors.Wrap(err, "push to pushgateway")
+		}
+	}
+
+	return nil
+}
+
+// remoteWrite gathers and pushes metrics to the remote write endpoint.
+func (p *Pusher) remoteWrite(ctx context.Context, now time.Time) error {
+	fams, err := p.gatherer.Gather()
+	if err != nil {
+		return errors.Wrap(err, "gather metrics")
+	}
+
+	b, err := proto.Marshal(&remotepb.WriteRequest{Timeseries: toTimeSeries(fams, now)})
+	if err != nil {
+		return errors.Wrap(err, "marshal write request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.RemoteWriteURL, bytes.NewReader(snappy.Encode(nil, b)))
+	if err != nil {
+		return errors.Wrap(err, "create remote write request")
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "remote write")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return errors.New("remote write endpoint returned error", z.Int("status", resp.StatusCode))
+	}
+
+	return nil
+}
+
+// toTimeSeries converts the metric families to remote write time series with a single sample at now.
+// Histograms and summaries are converted to their classic _bucket/quantile, _sum and _count series.
+func toTimeSeries(fams []*pb.MetricFamily, now time.Time) []*remotepb.TimeSeries {
+	ts := now.UnixMilli()
+
+	var resp []*remotepb.TimeSeries
+	add := func(name string, labels []*pb.LabelPair, value float64, extra ...string) {
+		series := &remotepb.TimeSeries{
+			Labels:  []*remotepb.Label{{Name: "__name__", Value: name}},
+			Samples: []*remotepb.Sample{{Value: value, Timestamp: ts}},
+		}
+		for _, label := range labels {
+			series.Labels = append(series.Labels, &remotepb.Label{Name: label.GetName(), Value: label.GetValue()})
+		}
+		for i := 0; i+1 < len(extra); i += 2 {
+			series.Labels = append(series.Labels, &remotepb.Label{Name: extra[i], Value: extra[i+1]})
+		}
+		sort.Slice(series.Labels, func(i, j int) bool {
+			return series.Labels[i].GetName() < series.Labels[j].GetName()
+		})
+
+		resp = append(resp, series)
+	}
+
+	for _, fam := range fams {
+		name := fam.GetName()
+		for _, metric := range fam.GetMetric() {
+			labels := metric.GetLabel()
+			switch fam.GetType() {
+			case pb.MetricType_COUNTER:
+				add(name, labels, metric.GetCounter().GetValue())
+			case pb.MetricType_GAUGE:
+				add(name, labels, metric.GetGauge().GetValue())
+			case pb.MetricType_UNTYPED:
+				add(name, labels, metric.GetUntyped().GetValue())
+			case pb.MetricType_HISTOGRAM, pb.MetricType_GAUGE_HISTOGRAM:
+				hist := metric.GetHistogram()
+				for _, bucket := range hist.GetBucket() {
+					if math.IsInf(bucket.GetUpperBound(), 1) {
+						continue // The +Inf bucket is added below from the sample count.
+					}
+					add(name+"_bucket", labels, float64(bucket.GetCumulativeCount()), "le", formatFloat(bucket.GetUpperBound()))
+				}
+				add(name+"_bucket", labels, float64(hist.GetSampleCount()), "le", "+Inf")
+				add(name+"_sum", labels, hist.GetSampleSum())
+				add(name+"_count", labels, float64(hist.GetSampleCount()))
+			case pb.MetricType_SUMMARY:
+				summary := metric.GetSummary()
+				for _, quantile := range summary.GetQuantile() {
+					add(name, labels, quantile.GetValue(), "quantile", formatFloat(quantile.GetQuantile()))
+				}
+				add(name+"_sum", labels, summary.GetSampleSum())
+				add(name+"_count", labels, float64(summary.GetSampleCount()))
+			}
+		}
+	}
+
+	return resp
+}
+
+// formatFloat formats the float as Prometheus does for le and quantile label values.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
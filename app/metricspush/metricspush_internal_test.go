@@ -0,0 +1,104 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package metricspush
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	remotepb "github.com/obolnetwork/charon/app/metricspush/remotepb/v1"
+)
+
+func TestRemoteWrite(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_total"}, []string{"duty"})
+	hist := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_seconds", Buckets: []float64{0.5, 1}})
+	registry.MustRegister(counter, hist)
+
+	counter.WithLabelValues("attester").Add(2)
+	hist.Observe(0.7)
+
+	requests := make(chan *remotepb.WriteRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		require.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+
+		compressed, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		b, err := snappy.Decode(nil, compressed)
+		require.NoError(t, err)
+
+		req := new(remotepb.WriteRequest)
+		require.NoError(t, proto.Unmarshal(b, req))
+		requests <- req
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	pusher := New(Config{RemoteWriteURL: srv.URL, Interval: time.Minute}, registry, "peer")
+
+	now := time.Unix(1000, 0)
+	require.NoError(t, pusher.remoteWrite(context.Background(), now))
+
+	// Render each series as "name{labels} value" for readable assertions.
+	var actual []string
+	for _, series := range (<-requests).GetTimeseries() {
+		var labels []string
+		for _, label := range series.GetLabels() {
+			labels = append(labels, label.GetName()+"="+label.GetValue())
+		}
+		require.Len(t, series.GetSamples(), 1)
+		require.Equal(t, now.UnixMilli(), series.GetSamples()[0].GetTimestamp())
+
+		actual = append(actual, strings.Join(labels, ",")+" "+formatFloat(series.GetSamples()[0].GetValue()))
+	}
+
+	require.Equal(t, []string{
+		"__name__=test_seconds_bucket,le=0.5 0",
+		"__name__=test_seconds_bucket,le=1 1",
+		"__name__=test_seconds_bucket,le=+Inf 1",
+		"__name__=test_seconds_sum 0.7",
+		"__name__=test_seconds_count 1",
+		"__name__=test_total,duty=attester 2",
+	}, actual)
+}
+
+func TestRemoteWriteError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	pusher := New(Config{RemoteWriteURL: srv.URL, Interval: time.Minute}, prometheus.NewRegistry(), "peer")
+	require.ErrorContains(t, pusher.push(context.Background()), "remote write endpoint returned error")
+}
+
+func TestPushgateway(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_gauge"})
+	registry.MustRegister(gauge)
+	gauge.Set(1)
+
+	paths := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		paths <- r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	pusher := New(Config{PushgatewayURL: srv.URL, Interval: time.Minute}, registry, "peer")
+	require.NoError(t, pusher.push(context.Background()))
+	require.Equal(t, "/metrics/job/charon/instance/peer", <-paths)
+}
@@ -0,0 +1,270 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package monitoring generates the recommended Prometheus alert rules and Grafana dashboard
+// from the metrics and health checks of this charon version, so they never drift from the emitted metrics.
+package monitoring
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/health"
+	"github.com/obolnetwork/charon/app/promauto"
+	"github.com/obolnetwork/charon/app/version"
+)
+
+// selector is the label selector of the dashboard cluster and peer variables.
+const selector = `{cluster_name="$cluster_name",cluster_peer=~"$cluster_peer"}`
+
+// rule is a Prometheus alerting rule.
+type rule struct {
+	Alert       string            `yaml:"alert"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+
+	// metric is the charon metric queried by the rule.
+	metric string
+}
+
+// ruleGroup is a Prometheus rule group.
+type ruleGroup struct {
+	Name  string `yaml:"name"`
+	Rules []rule `yaml:"rules"`
+}
+
+// rules returns the recommended alerting rules; one per health check and a few additional ones.
+func rules() []rule {
+	resp := []rule{
+		{
+			Alert:  "CharonNotReady",
+			Expr:   "app_monitoring_readyz != 1",
+			For:    "5m",
+			Labels: map[string]string{"severity": string(health.SeverityCritical)},
+			Annotations: map[string]string{
+				"summary":     "Charon node {{ $labels.cluster_peer }} is not ready.",
+				"description": "The /ready endpoint of the monitoring API returns the reason the node isn't ready.",
+			},
+			metric: "app_monitoring_readyz",
+		},
+		{
+			Alert:  "CharonPeerParticipationLow",
+			Expr:   "core_tracker_peer_participation_ratio < 0.9",
+			For:    "15m",
+			Labels: map[string]string{"severity": string(health.SeverityWarning)},
+			Annotations: map[string]string{
+				"summary":     "Peer {{ $labels.peer }} contributed less than 90% of expected {{ $labels.duty }} partial signatures.",
+				"description": "Coordinate with the peer's operator to investigate missing participation.",
+			},
+			metric: "core_tracker_peer_participation_ratio",
+		},
+	}
+
+	for _, check := range health.DefaultChecks() {
+		resp = append(resp, rule{
+			Alert:  "Charon" + camelCase(check.Name),
+			Expr:   fmt.Sprintf(`app_health_checks{name=%q} == 1`, check.Name),
+			For:    "2m",
+			Labels: map[string]string{"severity": string(check.Severity)},
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("Charon node {{ $labels.cluster_peer }} health check %s is failing.", check.Name),
+				"description": check.Description,
+			},
+			metric: "app_health_checks",
+		})
+	}
+
+	return resp
+}
+
+// AlertRules returns the recommended Prometheus alert rules file in YAML.
+func AlertRules() ([]byte, error) {
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "# Charon %s alert rules generated by 'charon alpha export-monitoring'.\n", version.Version)
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	err := enc.Encode(struct {
+		Groups []ruleGroup `yaml:"groups"`
+	}{
+		Groups: []ruleGroup{{Name: "charon", Rules: rules()}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal alert rules")
+	} else if err := enc.Close(); err != nil {
+		return nil, errors.Wrap(err, "close alert rules encoder")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// panel is a Grafana dashboard panel, either a row or a time series.
+type panel struct {
+	ID          int         `json:"id"`
+	Type        string      `json:"type"`
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	GridPos     gridPos     `json:"gridPos"`
+	Datasource  *datasource `json:"datasource,omitempty"`
+	Targets     []target    `json:"targets,omitempty"`
+	Collapsed   bool        `json:"collapsed,omitempty"`
+	Panels      []panel     `json:"panels,omitempty"`
+}
+
+type gridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type target struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+}
+
+type variable struct {
+	Name       string      `json:"name"`
+	Label      string      `json:"label"`
+	Type       string      `json:"type"`
+	Query      string      `json:"query"`
+	Datasource *datasource `json:"datasource,omitempty"`
+	Multi      bool        `json:"multi,omitempty"`
+	IncludeAll bool        `json:"includeAll,omitempty"`
+	Refresh    int         `json:"refresh,omitempty"`
+}
+
+// Dashboard returns the Grafana dashboard JSON with a collapsed row per metric subsystem
+// containing a time series panel per metric.
+func Dashboard() ([]byte, error) {
+	metas := promauto.Metas()
+	sort.Slice(metas, func(i, j int) bool {
+		return metas[i].FQName() < metas[j].FQName()
+	})
+
+	var (
+		rows   []panel
+		id     int
+		groups = make(map[string]int) // Index of the row of each subsystem.
+		dsVar  = &datasource{Type: "prometheus", UID: "${datasource}"}
+	)
+	for _, meta := range metas {
+		if meta.Namespace == "go" || meta.Namespace == "promauto" {
+			continue
+		}
+
+		group := meta.Namespace
+		if meta.Subsystem != "" {
+			group += "_" + meta.Subsystem
+		}
+
+		idx, ok := groups[group]
+		if !ok {
+			id++
+			idx = len(rows)
+			groups[group] = idx
+			rows = append(rows, panel{
+				ID:        id,
+				Type:      "row",
+				Title:     group,
+				Collapsed: true,
+				GridPos:   gridPos{H: 1, W: 24, Y: idx},
+			})
+		}
+
+		id++
+		n := len(rows[idx].Panels)
+		rows[idx].Panels = append(rows[idx].Panels, panel{
+			ID:          id,
+			Type:        "timeseries",
+			Title:       meta.FQName(),
+			Description: meta.Help,
+			GridPos:     gridPos{H: 8, W: 12, X: (n % 2) * 12, Y: idx + 1 + (n/2)*8},
+			Datasource:  dsVar,
+			Targets:     []target{metricTarget(meta)},
+		})
+	}
+
+	b, err := json.MarshalIndent(map[string]any{
+		"title":         "Charon Metrics",
+		"uid":           "charon-metrics",
+		"description":   fmt.Sprintf("Charon %s metrics generated by 'charon alpha export-monitoring'.", version.Version),
+		"tags":          []string{"charon", version.Version.String()},
+		"editable":      true,
+		"schemaVersion": 39,
+		"time":          map[string]string{"from": "now-6h", "to": "now"},
+		"refresh":       "30s",
+		"templating": map[string]any{
+			"list": []variable{
+				{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"},
+				{
+					Name: "cluster_name", Label: "Cluster", Type: "query", Datasource: dsVar, Refresh: 2,
+					Query: "label_values(app_version, cluster_name)",
+				},
+				{
+					Name: "cluster_peer", Label: "Peer", Type: "query", Datasource: dsVar, Refresh: 2, Multi: true, IncludeAll: true,
+					Query: `label_values(app_version{cluster_name="$cluster_name"}, cluster_peer)`,
+				},
+			},
+		},
+		"panels": rows,
+	}, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal dashboard")
+	}
+
+	return b, nil
+}
+
+// metricTarget returns the panel query of the metric; the rate of counters, the 99th percentile of histograms and the value of gauges.
+func metricTarget(meta promauto.Meta) target {
+	by := append([]string{"cluster_peer"}, meta.Labels...)
+
+	legend := make([]string, 0, len(by))
+	for _, label := range by {
+		legend = append(legend, "{{"+label+"}}")
+	}
+
+	var expr string
+	switch meta.Type {
+	case "Counter":
+		expr = fmt.Sprintf("sum by (%s) (rate(%s%s[$__rate_interval]))", strings.Join(by, ", "), meta.FQName(), selector)
+	case "Histogram":
+		expr = fmt.Sprintf("histogram_quantile(0.99, sum by (le, %s) (rate(%s_bucket%s[$__rate_interval])))", strings.Join(by, ", "), meta.FQName(), selector)
+	default:
+		expr = meta.FQName() + selector
+	}
+
+	return target{
+		RefID:        "A",
+		Expr:         expr,
+		LegendFormat: strings.Join(legend, " "),
+	}
+}
+
+// camelCase returns the snake case string in camel case, e.g. "clock_skew" returns "ClockSkew".
+func camelCase(s string) string {
+	var resp string
+	for _, word := range strings.Split(s, "_") {
+		if word == "" {
+			continue
+		}
+		resp += strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	return resp
+}
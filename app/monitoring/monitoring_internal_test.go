@@ -0,0 +1,89 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package monitoring
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	_ "github.com/obolnetwork/charon/app" // Register all charon metrics.
+	"github.com/obolnetwork/charon/app/health"
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+func TestAlertRules(t *testing.T) {
+	metrics := make(map[string]bool)
+	for _, meta := range promauto.GetMetasForT(t) {
+		metrics[meta.FQName()] = true
+	}
+
+	// Ensure all rules query metrics emitted by charon.
+	names := make(map[string]bool)
+	for _, rule := range rules() {
+		require.True(t, metrics[rule.metric], "unknown metric %s of rule %s", rule.metric, rule.Alert)
+		require.Contains(t, rule.Expr, rule.metric)
+		require.NotEmpty(t, rule.Labels["severity"])
+		require.False(t, names[rule.Alert], "duplicate rule %s", rule.Alert)
+		names[rule.Alert] = true
+	}
+	require.Len(t, names, len(health.DefaultChecks())+2)
+	require.True(t, names["CharonClockSkew"])
+
+	b, err := AlertRules()
+	require.NoError(t, err)
+
+	var file struct {
+		Groups []struct {
+			Name  string           `yaml:"name"`
+			Rules []map[string]any `yaml:"rules"`
+		} `yaml:"groups"`
+	}
+	require.NoError(t, yaml.Unmarshal(b, &file))
+	require.Len(t, file.Groups, 1)
+	require.Len(t, file.Groups[0].Rules, len(names))
+}
+
+func TestDashboard(t *testing.T) {
+	b, err := Dashboard()
+	require.NoError(t, err)
+
+	var dashboard struct {
+		Title  string  `json:"title"`
+		Panels []panel `json:"panels"`
+	}
+	require.NoError(t, json.Unmarshal(b, &dashboard))
+	require.Equal(t, "Charon Metrics", dashboard.Title)
+
+	exprs := make(map[string]string)
+	ids := make(map[int]bool)
+	for _, row := range dashboard.Panels {
+		require.Equal(t, "row", row.Type)
+		require.False(t, ids[row.ID])
+		ids[row.ID] = true
+
+		for _, p := range row.Panels {
+			require.False(t, ids[p.ID])
+			ids[p.ID] = true
+			exprs[p.Title] = p.Targets[0].Expr
+		}
+	}
+
+	require.Equal(t,
+		`core_tracker_peer_participation_ratio{cluster_name="$cluster_name",cluster_peer=~"$cluster_peer"}`,
+		exprs["core_tracker_peer_participation_ratio"])
+	require.Equal(t,
+		`sum by (cluster_peer, duty) (rate(core_tracker_failed_duties_total{cluster_name="$cluster_name",cluster_peer=~"$cluster_peer"}[$__rate_interval]))`,
+		exprs["core_tracker_failed_duties_total"])
+	require.Equal(t,
+		`histogram_quantile(0.99, sum by (le, cluster_peer, endpoint) (rate(app_eth2_latency_seconds_bucket{cluster_name="$cluster_name",cluster_peer=~"$cluster_peer"}[$__rate_interval])))`,
+		exprs["app_eth2_latency_seconds"])
+}
+
+func TestCamelCase(t *testing.T) {
+	require.Equal(t, "ClockSkew", camelCase("clock_skew"))
+	require.Equal(t, "VcNotSeen", camelCase("vc_not_seen"))
+	require.Equal(t, "Foo", camelCase("_foo_"))
+}
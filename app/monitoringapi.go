@@ -4,8 +4,8 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
-	"net/http/pprof"
 	"sync"
 	"time"
 
@@ -18,11 +18,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/obolnetwork/charon/app/depositwatch"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/health"
+	"github.com/obolnetwork/charon/app/httpauth"
 	"github.com/obolnetwork/charon/app/lifecycle"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/peerinfo"
+	"github.com/obolnetwork/charon/app/profiling"
+	"github.com/obolnetwork/charon/app/rewards"
+	"github.com/obolnetwork/charon/app/vallifecycle"
+	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/core"
 )
@@ -44,12 +51,13 @@ var (
 
 // wireMonitoringAPI constructs the monitoring API and registers it with the life cycle manager.
 // It serves prometheus metrics, pprof profiling and the runtime enr.
-func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, promAddr, debugAddr string,
-	tcpNode host.Host, eth2Cl eth2wrap.Client,
+func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, promAddr, debugAddr string, pprof bool,
+	auth httpauth.Config, tcpNode host.Host, eth2Cl eth2wrap.Client,
 	peerIDs []peer.ID, registry *prometheus.Registry, consensusDebugger http.Handler,
 	pubkeys []core.PubKey, seenPubkeys <-chan core.PubKey, vapiCalls <-chan struct{},
-	numValidators int,
-) {
+	numValidators int, peerInfo *peerinfo.PeerInfo, duties *dutiesStatus, deposits *depositwatch.Watcher,
+	valLifecycle *vallifecycle.Tracker, rewardsTracker *rewards.Tracker,
+) error {
 	beaconNodeVersionMetric(ctx, eth2Cl, clockwork.NewRealClock())
 
 	mux := http.NewServeMux()
@@ -64,8 +72,29 @@ func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, promAddr, d
 		writeResponse(w, http.StatusOK, "ok")
 	}))
 
-	readyErrFunc := startReadyChecker(ctx, tcpNode, eth2Cl, peerIDs, clockwork.NewRealClock(),
+	// Liveness only depends on the process being up, so it doesn't fail while the beacon node is syncing.
+	mux.HandleFunc("/live", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSONResponse(w, http.StatusOK, liveStatus{
+			Live:    true,
+			Version: version.Version.String(),
+		})
+	})
+
+	readyFunc := startReadyChecker(ctx, tcpNode, eth2Cl, peerIDs, clockwork.NewRealClock(),
 		pubkeys, seenPubkeys, vapiCalls)
+	readyErrFunc := func() error {
+		return readyFunc().Err()
+	}
+
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, _ *http.Request) {
+		status := readyFunc()
+		if !status.Ready {
+			writeJSONResponse(w, http.StatusServiceUnavailable, status)
+			return
+		}
+
+		writeJSONResponse(w, http.StatusOK, status)
+	})
 
 	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
 		readyErr := readyErrFunc()
@@ -77,31 +106,47 @@ func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, promAddr, d
 		writeResponse(w, http.StatusOK, "ok")
 	})
 
-	server := &http.Server{
-		Addr:              promAddr,
-		Handler:           mux,
-		ReadHeaderTimeout: time.Second,
-	}
-
-	// Create and start health checker.
+	// Create the health checker and serve the latest results of its checks.
 	checker := health.NewChecker(health.Metadata{
 		NumValidators: len(pubkeys),
 		NumPeers:      len(peerIDs),
 		QuorumPeers:   cluster.Threshold(len(peerIDs)),
 	}, registry, numValidators)
 
+	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSONResponse(w, http.StatusOK, newHealthStatus(checker.Results()))
+	})
+
+	// Optionally serve pprof endpoints on the monitoring API, e.g. for Parca to scrape continuous profiles.
+	if pprof {
+		profiling.RegisterHandlers(mux)
+	}
+
+	// Share this node's status with peers and serve the status of all nodes in the cluster.
+	peerInfo.SetStatusFunc(newLocalStatusFunc(readyErrFunc, registry, len(pubkeys)))
+	mux.Handle("/cluster/status", newClusterStatusHandler(tcpNode, peerIDs, peerInfo, deposits, valLifecycle))
+	mux.Handle("/cluster/upgrade", newClusterUpgradeHandler(tcpNode, peerIDs, peerInfo))
+	mux.Handle("/features", newFeaturesHandler(tcpNode, peerIDs, peerInfo))
+
+	// Serve the upcoming duties and recent duty failures.
+	mux.Handle("/duties", newDutiesHandler(duties))
+
+	// Serve the rewards of the cluster validators if enabled.
+	mux.Handle("/rewards", newRewardsHandler(rewardsTracker))
+
+	// Protect the monitoring API with the configured authentication, TLS and IP allowlist.
+	server, serve, err := newHTTPServer(promAddr, mux, auth)
+	if err != nil {
+		return err
+	}
+
 	if debugAddr != "" {
 		debugMux := http.NewServeMux()
 
 		// Serve sniffed consensus instances messages in gzipped protobuf format.
 		debugMux.Handle("/debug/consensus", consensusDebugger)
 
-		// Copied from net/http/pprof/pprof.go
-		debugMux.HandleFunc("/debug/pprof/", pprof.Index)
-		debugMux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
-		debugMux.HandleFunc("/debug/pprof/profile", pprof.Profile)
-		debugMux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
-		debugMux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+		profiling.RegisterHandlers(debugMux)
 
 		debugServer := &http.Server{
 			Addr:              debugAddr,
@@ -113,19 +158,82 @@ func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, promAddr, d
 		life.RegisterStop(lifecycle.StopDebugAPI, lifecycle.HookFunc(debugServer.Shutdown))
 	}
 
-	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartMonitoringAPI, httpServeHook(server.ListenAndServe))
+	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartMonitoringAPI, serve)
 	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartMonitoringAPI, lifecycle.HookFuncCtx(checker.Run))
 	life.RegisterStop(lifecycle.StopMonitoringAPI, lifecycle.HookFunc(server.Shutdown))
+
+	return nil
+}
+
+// HealthStatus is the status object served by the /health endpoint.
+type HealthStatus struct {
+	// Healthy is false if any critical health check is failing.
+	Healthy bool            `json:"healthy"`
+	Checks  []health.Result `json:"checks"`
+}
+
+// newHealthStatus returns the health status of the provided health check results.
+func newHealthStatus(results []health.Result) HealthStatus {
+	status := HealthStatus{Healthy: true, Checks: results}
+	for _, result := range results {
+		if result.Failing && result.Severity == health.SeverityCritical {
+			status.Healthy = false
+		}
+	}
+
+	return status
+}
+
+// liveStatus is the status object served by the /live endpoint.
+type liveStatus struct {
+	// Live is always true, since the endpoint is served if the process is up.
+	Live bool `json:"live"`
+	// Version is the charon version.
+	Version string `json:"version"`
+}
+
+// ReadyStatus is the status object served by the /ready endpoint.
+type ReadyStatus struct {
+	// Ready is true if the node is operational, i.e., all checks pass.
+	Ready bool `json:"ready"`
+	// Error is the reason the node is not ready, empty if ready.
+	Error string `json:"error,omitempty"`
+	// Checks are the results of the individual readiness checks.
+	Checks ReadyChecks `json:"checks"`
+
+	err error
+}
+
+// Err returns the reason the node is not ready, or nil if ready.
+func (s ReadyStatus) Err() error {
+	return s.err
+}
+
+// ReadyChecks are the results of the individual readiness checks.
+type ReadyChecks struct {
+	// BeaconNodeUp is true if the beacon node API is reachable.
+	BeaconNodeUp bool `json:"beacon_node_up"`
+	// BeaconNodeSynced is true if the beacon node is synced and not too far behind the head slot.
+	BeaconNodeSynced bool `json:"beacon_node_synced"`
+	// BeaconNodePeers is true if the beacon node has peers, or its peer count is not known yet.
+	BeaconNodePeers bool `json:"beacon_node_peers"`
+	// QuorumPeersConnected is true if quorum peers are connected via the P2P network.
+	QuorumPeersConnected bool `json:"quorum_peers_connected"`
+	// VCConnected is true if a validator client called the validator API in the previous epoch.
+	VCConnected bool `json:"vc_connected"`
+	// VCValidatorsLoaded is true if the validator client has loaded the keystores of all validators,
+	// i.e., it requested duties of all validators in the previous epoch.
+	VCValidatorsLoaded bool `json:"vc_validators_loaded"`
 }
 
-// startReadyChecker returns function which returns an error resulting from ready checks periodically.
+// startReadyChecker returns function which returns the ready status resulting from ready checks periodically.
 func startReadyChecker(ctx context.Context, tcpNode host.Host, eth2Cl eth2wrap.Client, peerIDs []peer.ID,
 	clock clockwork.Clock, pubkeys []core.PubKey, seenPubkeys <-chan core.PubKey, vapiCalls <-chan struct{},
-) func() error {
+) func() ReadyStatus {
 	const minNotConnected = 6 // Require 6 rounds (1min) of too few connected
 	var (
 		mu                 sync.Mutex
-		readyErr           = errReadyUninitialised
+		status             = ReadyStatus{Error: errReadyUninitialised.Error(), err: errReadyUninitialised}
 		notConnectedRounds = minNotConnected // Start as not connected.
 	)
 	go func() {
@@ -144,7 +252,9 @@ func startReadyChecker(ctx context.Context, tcpNode host.Host, eth2Cl eth2wrap.C
 		// beaconNodePeerCount queries beacon node peer count and sets the peer count gauge if err is nil.
 		beaconNodePeerCount := func() {
 			peerCount, err := eth2Cl.NodePeerCount(ctx)
-			if err != nil {
+			if errors.Is(err, eth2wrap.ErrShed) {
+				return // Retain previous peer count while the beacon node is overloaded.
+			} else if err != nil {
 				log.Warn(ctx, "Failed to get beacon node peer count", err)
 				return
 			}
@@ -172,9 +282,19 @@ func startReadyChecker(ctx context.Context, tcpNode host.Host, eth2Cl eth2wrap.C
 					notConnectedRounds++
 				}
 
-				syncing, syncDistance, err := beaconNodeSyncing(ctx, eth2Cl)
+				syncing, syncDistance, syncErr := beaconNodeSyncing(ctx, eth2Cl)
+				if syncErr == nil {
+					var syncingVal float64
+					if syncing {
+						syncingVal = 1
+					}
+					beaconNodeSyncingGauge.Set(syncingVal)
+					beaconNodeSyncDistanceGauge.Set(float64(syncDistance))
+				}
+
+				var err error
 				//nolint:revive // skip max-control-nesting for monitoring
-				if err != nil {
+				if syncErr != nil {
 					err = errReadyBeaconNodeDown
 					readyzGauge.Set(readyzBeaconNodeDown)
 				} else if syncing {
@@ -199,8 +319,22 @@ func startReadyChecker(ctx context.Context, tcpNode host.Host, eth2Cl eth2wrap.C
 					readyzGauge.Set(readyzReady)
 				}
 
+				checks := ReadyChecks{
+					BeaconNodeUp:         syncErr == nil,
+					BeaconNodeSynced:     syncErr == nil && !syncing && syncDistance <= bnFarBehindSlots,
+					BeaconNodePeers:      bnPeerCount == nil || *bnPeerCount > 0,
+					QuorumPeersConnected: notConnectedRounds < minNotConnected,
+					VCConnected:          prevVAPICount > 0,
+					VCValidatorsLoaded:   len(prevPKs) >= len(pubkeys),
+				}
+
+				var errStr string
+				if err != nil {
+					errStr = err.Error()
+				}
+
 				mu.Lock()
-				readyErr = err
+				status = ReadyStatus{Ready: err == nil, Error: errStr, Checks: checks, err: err}
 				mu.Unlock()
 			case pubkey := <-seenPubkeys:
 				currPKs[pubkey] = true
@@ -210,11 +344,11 @@ func startReadyChecker(ctx context.Context, tcpNode host.Host, eth2Cl eth2wrap.C
 		}
 	}()
 
-	return func() error {
+	return func() ReadyStatus {
 		mu.Lock()
 		defer mu.Unlock()
 
-		return readyErr
+		return status
 	}
 }
 
@@ -235,7 +369,9 @@ func beaconNodeVersionMetric(ctx context.Context, eth2Cl eth2wrap.Client, clock
 
 	setNodeVersion := func() {
 		eth2Resp, err := eth2Cl.NodeVersion(ctx, &eth2api.NodeVersionOpts{})
-		if err != nil {
+		if errors.Is(err, eth2wrap.ErrShed) {
+			return // Retain previous version while the beacon node is overloaded.
+		} else if err != nil {
 			log.Error(ctx, "Failed to get beacon node version", err)
 			return
 		}
@@ -289,7 +425,68 @@ func stackComponents(names []string, cliParams []string) {
 	}
 }
 
+// newHTTPServer returns a server of the handler protected with the authentication, TLS and IP allowlist
+// of the config, and its lifecycle serve hook.
+func newHTTPServer(addr string, handler http.Handler, auth httpauth.Config) (*http.Server, httpServeHook, error) {
+	handler, err := httpauth.Wrap(handler, auth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig, err := httpauth.TLSConfig(auth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: time.Second,
+	}
+
+	if tlsConfig != nil {
+		return server, func() error {
+			return server.ListenAndServeTLS("", "") // Certificates are provided by the TLS config.
+		}, nil
+	}
+
+	return server, server.ListenAndServe, nil
+}
+
+// newRewardsHandler returns a handler serving the rewards of the cluster validators as JSON,
+// or as CSV if the format query parameter is "csv". It responds with not found if tracker is nil.
+func newRewardsHandler(tracker *rewards.Tracker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if tracker == nil {
+			writeResponse(w, http.StatusNotFound, "rewards tracking disabled, see --rewards-tracking")
+			return
+		}
+
+		report := tracker.Report()
+		if r.URL.Query().Get("format") != "csv" {
+			writeJSONResponse(w, http.StatusOK, report)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/csv")
+		_ = report.WriteCSV(w)
+	}
+}
+
 func writeResponse(w http.ResponseWriter, status int, msg string) {
 	w.WriteHeader(status)
 	_, _ = w.Write([]byte(msg))
 }
+
+func writeJSONResponse(w http.ResponseWriter, status int, resp any) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		writeResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
@@ -145,7 +145,7 @@ func TestStartChecker(t *testing.T) {
 			clock := clockwork.NewFakeClock()
 			seenPubkeys := make(chan core.PubKey)
 			vapiCalls := make(chan struct{})
-			readyErrFunc := startReadyChecker(ctx, hosts[0], bmock, peers, clock,
+			readyFunc := startReadyChecker(ctx, hosts[0], bmock, peers, clock,
 				pubkeys, seenPubkeys, vapiCalls)
 
 			for _, pubkey := range tt.seenPubkeys {
@@ -166,7 +166,7 @@ func TestStartChecker(t *testing.T) {
 			if tt.err != nil {
 				require.Eventually(t, func() bool {
 					advanceClock(t, ctx, clock, 10*time.Second)
-					err = readyErrFunc()
+					err = readyFunc().Err()
 					if !errors.Is(err, tt.err) {
 						t.Logf("Ignoring unexpected error, got=%v, want=%v", err, tt.err)
 						return false
@@ -174,11 +174,24 @@ func TestStartChecker(t *testing.T) {
 
 					return true
 				}, waitFor, tickInterval)
+
+				status := readyFunc()
+				require.False(t, status.Ready)
+				require.Equal(t, tt.err.Error(), status.Error)
 			} else {
 				require.Eventually(t, func() bool {
 					advanceClock(t, ctx, clock, 12*time.Second)
-					return readyErrFunc() == nil
+					return readyFunc().Err() == nil
 				}, waitFor, tickInterval)
+
+				require.Equal(t, ReadyStatus{Ready: true, Checks: ReadyChecks{
+					BeaconNodeUp:         true,
+					BeaconNodeSynced:     true,
+					BeaconNodePeers:      true,
+					QuorumPeersConnected: true,
+					VCConnected:          true,
+					VCValidatorsLoaded:   true,
+				}}, readyFunc())
 			}
 		})
 	}
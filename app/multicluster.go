@@ -0,0 +1,168 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// ClusterConfig defines the per-cluster config of a charon process serving multiple clusters.
+// Unset files default to the standard file names in the data directory.
+type ClusterConfig struct {
+	// Name identifies the cluster in logs.
+	Name             string   `json:"name"`
+	DataDir          string   `json:"data_dir"`
+	LockFile         string   `json:"lock_file"`
+	ManifestFile     string   `json:"manifest_file"`
+	PrivKeyFile      string   `json:"private_key_file"`
+	ValidatorAPIAddr string   `json:"validator_api_address"`
+	MonitoringAddr   string   `json:"monitoring_address"`
+	DebugAddr        string   `json:"debug_address"`
+	AdminAddr        string   `json:"admin_address"`
+	P2PTCPAddrs      []string `json:"p2p_tcp_addresses"`
+	Nickname         string   `json:"nickname"`
+}
+
+// clustersFile is the json file defining the clusters served by a single charon process.
+type clustersFile struct {
+	Clusters []ClusterConfig `json:"clusters"`
+}
+
+// LoadClustersFile returns the cluster configs defined in the json clusters file.
+func LoadClustersFile(filename string) ([]ClusterConfig, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "read clusters file", z.Str("path", filename))
+	}
+
+	var file clustersFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, errors.Wrap(err, "unmarshal clusters file", z.Str("path", filename))
+	}
+
+	if len(file.Clusters) == 0 {
+		return nil, errors.New("no clusters in clusters file", z.Str("path", filename))
+	}
+
+	return file.Clusters, nil
+}
+
+// clusterConfigs returns the node config of each cluster by applying its cluster config to the base config.
+// It returns an error if clusters aren't uniquely named or share files or listening addresses.
+func clusterConfigs(base Config, clusters []ClusterConfig) ([]Config, error) {
+	var (
+		resp   []Config
+		unique = make(map[string]string) // Maps unique names, files and addresses to the cluster using it.
+	)
+	for i, cluster := range clusters {
+		if cluster.Name == "" {
+			return nil, errors.New("cluster name not set", z.Int("index", i))
+		}
+
+		conf := base
+		conf.ClustersFile = ""
+		conf.LockFile = orDefault(cluster.LockFile, filepath.Join(cluster.DataDir, "cluster-lock.json"))
+		conf.ManifestFile = orDefault(cluster.ManifestFile, filepath.Join(cluster.DataDir, "cluster-manifest.pb"))
+		conf.PrivKeyFile = orDefault(cluster.PrivKeyFile, filepath.Join(cluster.DataDir, "charon-enr-private-key"))
+		if base.ParticipationFile != "" {
+			conf.ParticipationFile = filepath.Join(cluster.DataDir, "participation.json")
+		}
+		if base.KeyAttestationsDir != "" {
+			conf.KeyAttestationsDir = filepath.Join(cluster.DataDir, "key_attestations")
+		}
+		if base.SLAFile != "" {
+			conf.SLAFile = filepath.Join(cluster.DataDir, "sla.json")
+		}
+		if base.SLAReportDir != "" {
+			conf.SLAReportDir = filepath.Join(base.SLAReportDir, cluster.Name)
+		}
+		conf.ValidatorAPIAddr = cluster.ValidatorAPIAddr
+		conf.MonitoringAddr = cluster.MonitoringAddr
+		conf.DebugAddr = cluster.DebugAddr
+		conf.AdminAddr = cluster.AdminAddr
+		conf.P2P.TCPAddrs = cluster.P2PTCPAddrs
+		conf.Nickname = orDefault(cluster.Nickname, base.Nickname)
+
+		if conf.ValidatorAPIAddr == "" || conf.MonitoringAddr == "" {
+			return nil, errors.New("cluster validator api and monitoring addresses must be set", z.Str("cluster", cluster.Name))
+		}
+
+		if err := validateAdminAuth(conf); err != nil {
+			return nil, errors.Wrap(err, "invalid cluster admin api", z.Str("cluster", cluster.Name))
+		}
+
+		keys := []string{"name:" + cluster.Name, "lock:" + conf.LockFile, "manifest:" + conf.ManifestFile,
+			"key:" + conf.PrivKeyFile, "addr:" + conf.ValidatorAPIAddr, "addr:" + conf.MonitoringAddr}
+		if conf.DebugAddr != "" {
+			keys = append(keys, "addr:"+conf.DebugAddr)
+		}
+		if conf.AdminAddr != "" {
+			keys = append(keys, "addr:"+conf.AdminAddr)
+		}
+		for _, addr := range conf.P2P.TCPAddrs {
+			keys = append(keys, "addr:"+addr)
+		}
+
+		for _, key := range keys {
+			if other, ok := unique[key]; ok {
+				return nil, errors.New("clusters share a name, file or address",
+					z.Str("value", key), z.Str("cluster", cluster.Name), z.Str("other_cluster", other))
+			}
+			unique[key] = cluster.Name
+		}
+
+		resp = append(resp, conf)
+	}
+
+	return resp, nil
+}
+
+// runMulti runs a charon node for each cluster in the clusters file in this process.
+// Each node has its own key shares, P2P host, validator API and monitoring API listeners, while sharing the
+// remaining base config. All nodes are stopped if any of them fails.
+func runMulti(ctx context.Context, base Config) error {
+	clusters, err := LoadClustersFile(base.ClustersFile)
+	if err != nil {
+		return err
+	}
+
+	confs, err := clusterConfigs(base, clusters)
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Running multiple clusters", z.Int("clusters", len(confs)))
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, conf := range confs {
+		name := clusters[i].Name
+		eg.Go(func() error {
+			err := Run(log.WithCtx(ctx, z.Str("cluster", name)), conf)
+			if err != nil {
+				return errors.Wrap(err, "run cluster", z.Str("cluster", name))
+			}
+
+			return nil
+		})
+	}
+
+	return eg.Wait()
+}
+
+// orDefault returns s if not empty, otherwise the default.
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+
+	return s
+}
@@ -0,0 +1,91 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterConfigs(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "clusters.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"clusters": [
+		{"name": "a", "data_dir": "/data/a", "validator_api_address": "127.0.0.1:3600", "monitoring_address": "127.0.0.1:3620", "p2p_tcp_addresses": ["0.0.0.0:3610"]},
+		{"name": "b", "lock_file": "/b/lock.json", "validator_api_address": "127.0.0.1:3601", "monitoring_address": "127.0.0.1:3621", "nickname": "bee"}
+	]}`), 0o644))
+
+	clusters, err := LoadClustersFile(file)
+	require.NoError(t, err)
+	require.Len(t, clusters, 2)
+
+	base := Config{
+		ClustersFile:       file,
+		BeaconNodeAddrs:    []string{"http://beacon:5052"},
+		Nickname:           "base",
+		KeyAttestationsDir: ".charon/key_attestations",
+	}
+
+	confs, err := clusterConfigs(base, clusters)
+	require.NoError(t, err)
+	require.Len(t, confs, 2)
+
+	require.Empty(t, confs[0].ClustersFile)
+	require.Equal(t, "/data/a/cluster-lock.json", confs[0].LockFile)
+	require.Equal(t, "/data/a/cluster-manifest.pb", confs[0].ManifestFile)
+	require.Equal(t, "/data/a/charon-enr-private-key", confs[0].PrivKeyFile)
+	require.Equal(t, "/data/a/key_attestations", confs[0].KeyAttestationsDir)
+	require.Equal(t, "127.0.0.1:3600", confs[0].ValidatorAPIAddr)
+	require.Equal(t, []string{"0.0.0.0:3610"}, confs[0].P2P.TCPAddrs)
+	require.Equal(t, "base", confs[0].Nickname)
+	require.Equal(t, base.BeaconNodeAddrs, confs[0].BeaconNodeAddrs)
+
+	require.Equal(t, "/b/lock.json", confs[1].LockFile)
+	require.Equal(t, "cluster-manifest.pb", confs[1].ManifestFile)
+	require.Equal(t, "key_attestations", confs[1].KeyAttestationsDir)
+	require.Equal(t, "bee", confs[1].Nickname)
+
+	t.Run("shared address", func(t *testing.T) {
+		clusters := []ClusterConfig{clusters[0], clusters[1]}
+		clusters[1].MonitoringAddr = clusters[0].MonitoringAddr
+
+		_, err := clusterConfigs(base, clusters)
+		require.ErrorContains(t, err, "clusters share a name, file or address")
+	})
+
+	t.Run("missing address", func(t *testing.T) {
+		clusters := []ClusterConfig{{Name: "a", DataDir: "/data/a"}}
+
+		_, err := clusterConfigs(base, clusters)
+		require.ErrorContains(t, err, "cluster validator api and monitoring addresses must be set")
+	})
+
+	t.Run("admin address without auth", func(t *testing.T) {
+		clusters := []ClusterConfig{clusters[0]}
+		clusters[0].AdminAddr = "127.0.0.1:3640"
+
+		_, err := clusterConfigs(base, clusters)
+		require.ErrorContains(t, err, "admin API requires authentication")
+
+		base := base
+		base.AdminAuth.BasicAuth = "admin:secret"
+		_, err = clusterConfigs(base, clusters)
+		require.ErrorContains(t, err, "admin API basic auth requires TLS")
+
+		base.AdminAuth.TLSCertFile = "admin.crt"
+		base.AdminAuth.TLSKeyFile = "admin.key"
+		confs, err := clusterConfigs(base, clusters)
+		require.NoError(t, err)
+		require.Equal(t, "127.0.0.1:3640", confs[0].AdminAddr)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte(`{"clusters": []}`), 0o644))
+
+		_, err := LoadClustersFile(file)
+		require.ErrorContains(t, err, "no clusters in clusters file")
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package obolapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+)
+
+const (
+	configHashPath = "{config_hash}"
+
+	definitionTmpl = "/dv/" + configHashPath
+	lockTmpl       = "/lock/" + lockHashPath
+)
+
+// definitionURL returns the cluster definition Obol API URL for a given config hash.
+func definitionURL(configHash []byte) string {
+	return strings.NewReplacer(configHashPath, "0x"+hex.EncodeToString(configHash)).Replace(definitionTmpl)
+}
+
+// lockURL returns the cluster lock Obol API URL for a given lock hash.
+func lockURL(lockHash []byte) string {
+	return strings.NewReplacer(lockHashPath, "0x"+hex.EncodeToString(lockHash)).Replace(lockTmpl)
+}
+
+// GetDefinition fetches the cluster definition identified by the config hash from the Obol API.
+// It verifies that the config hash of the returned definition matches, but doesn't verify its signatures.
+// It respects the timeout specified in the Client instance.
+func (c Client) GetDefinition(ctx context.Context, configHash []byte) (cluster.Definition, error) {
+	b, err := c.getBytes(ctx, definitionURL(configHash))
+	if err != nil {
+		return cluster.Definition{}, err
+	}
+
+	var def cluster.Definition
+	if err := json.Unmarshal(b, &def); err != nil {
+		return cluster.Definition{}, errors.Wrap(err, "unmarshal definition")
+	}
+
+	if !bytes.Equal(def.ConfigHash, configHash) {
+		return cluster.Definition{}, errors.New("fetched definition config hash mismatch")
+	}
+
+	return def, nil
+}
+
+// GetLock fetches the cluster lock identified by the lock hash from the Obol API.
+// It verifies that the lock hash of the returned lock matches, but doesn't verify its signatures.
+// It respects the timeout specified in the Client instance.
+func (c Client) GetLock(ctx context.Context, lockHash []byte) (cluster.Lock, error) {
+	b, err := c.getBytes(ctx, lockURL(lockHash))
+	if err != nil {
+		return cluster.Lock{}, err
+	}
+
+	var lock cluster.Lock
+	if err := json.Unmarshal(b, &lock); err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "unmarshal lock")
+	}
+
+	if !bytes.Equal(lock.LockHash, lockHash) {
+		return cluster.Lock{}, errors.New("fetched lock hash mismatch")
+	}
+
+	return lock, nil
+}
+
+// getBytes returns the response body of a GET request to the provided Obol API path.
+func (c Client) getBytes(ctx context.Context, path string) ([]byte, error) {
+	u := c.url()
+	u.Path = path
+
+	ctx, cancel := context.WithTimeout(ctx, c.reqTimeout)
+	defer cancel()
+
+	respBody, err := httpGet(ctx, u, nil)
+	if errors.Is(err, ErrNoExit) {
+		return nil, errors.New("cluster document not found", z.Str("path", path))
+	} else if err != nil {
+		return nil, errors.Wrap(err, "http Obol API GET request")
+	}
+
+	defer respBody.Close()
+
+	b, err := io.ReadAll(respBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "read response body")
+	}
+
+	return b, nil
+}
@@ -31,6 +31,7 @@ const (
 
 	partialExitTmpl = "/exp/partial_exits/" + lockHashPath
 	fullExitTmpl    = fullExitBaseTmpl + fullExitEndTmp
+	exitStatusTmpl  = partialExitTmpl + "/" + valPubkeyPath
 )
 
 var ErrNoExit = errors.New("no exit for the given validator public key")
@@ -48,6 +49,16 @@ func bearerString(data []byte) string {
 	return fmt.Sprintf("Bearer %#x", data)
 }
 
+// exitStatusURL returns the partial exit status Obol API URL for a given lock hash and validator public key.
+func exitStatusURL(valPubkey, lockHash string) string {
+	return strings.NewReplacer(
+		valPubkeyPath,
+		valPubkey,
+		lockHashPath,
+		lockHash,
+	).Replace(exitStatusTmpl)
+}
+
 // fullExitURL returns the full exit Obol API URL for a given validator public key.
 func fullExitURL(valPubkey, lockHash string, shareIndex uint64) string {
 	return strings.NewReplacer(
@@ -209,3 +220,60 @@ func (c Client) GetFullExit(ctx context.Context, valPubkey string, lockHash []by
 		},
 	}, nil
 }
+
+// ExitStatus is the progress of the partial exits submitted for a validator.
+type ExitStatus struct {
+	Epoch          eth2p0.Epoch
+	ValidatorIndex eth2p0.ValidatorIndex
+	// ShareIndices are the share indices of the operators that submitted a partial exit, in ascending order.
+	ShareIndices []uint64
+}
+
+// GetExitStatus gets the status of the partial exits submitted for a given validator public key and lock hash.
+// It returns ErrNoExit if no partial exits were submitted for the validator.
+// It respects the timeout specified in the Client instance.
+func (c Client) GetExitStatus(ctx context.Context, valPubkey string, lockHash []byte) (ExitStatus, error) {
+	if _, err := from0x(valPubkey, 48); err != nil { // public key is 48 bytes long
+		return ExitStatus{}, errors.Wrap(err, "validator pubkey to bytes")
+	}
+
+	path := exitStatusURL(valPubkey, "0x"+hex.EncodeToString(lockHash))
+
+	u, err := url.ParseRequestURI(c.baseURL)
+	if err != nil {
+		return ExitStatus{}, errors.Wrap(err, "bad Obol API url")
+	}
+
+	u.Path = path
+
+	ctx, cancel := context.WithTimeout(ctx, c.reqTimeout)
+	defer cancel()
+
+	respBody, err := httpGet(ctx, u, nil)
+	if err != nil {
+		return ExitStatus{}, errors.Wrap(err, "http Obol API GET request")
+	}
+
+	defer respBody.Close()
+
+	var sr ExitStatusResponse
+	if err := json.NewDecoder(respBody).Decode(&sr); err != nil {
+		return ExitStatus{}, errors.Wrap(err, "json unmarshal error")
+	}
+
+	epochUint64, err := strconv.ParseUint(sr.Epoch, 10, 64)
+	if err != nil {
+		return ExitStatus{}, errors.Wrap(err, "epoch parsing")
+	}
+
+	shareIndices := append([]uint64(nil), sr.ShareIndices...)
+	sort.Slice(shareIndices, func(i, j int) bool {
+		return shareIndices[i] < shareIndices[j]
+	})
+
+	return ExitStatus{
+		Epoch:          eth2p0.Epoch(epochUint64),
+		ValidatorIndex: sr.ValidatorIndex,
+		ShareIndices:   shareIndices,
+	}, nil
+}
@@ -151,6 +151,14 @@ type FullExitResponse struct {
 	Signatures     []string              `json:"signatures"`
 }
 
+// ExitStatusResponse contains the epoch, validator index and the share indices of the operators that submitted
+// a partial exit for a validator. It doesn't contain any signatures, so doesn't require authentication.
+type ExitStatusResponse struct {
+	Epoch          string                `json:"epoch"`
+	ValidatorIndex eth2p0.ValidatorIndex `json:"validator_index"`
+	ShareIndices   []uint64              `json:"share_indices"`
+}
+
 // FullExitAuthBlob represents the data required by Obol API to download the full exit blobs.
 type FullExitAuthBlob struct {
 	LockHash        []byte
@@ -208,6 +208,85 @@ func TestAPIFlowMissingSig(t *testing.T) {
 	}
 }
 
+func TestExitStatus(t *testing.T) {
+	kn := 4
+
+	beaconMock, err := beaconmock.New()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, beaconMock.Close())
+	}()
+
+	mockEth2Cl := eth2Client(t, context.Background(), beaconMock.Address())
+
+	handler, addLockFiles := obolapimock.MockServer(false, mockEth2Cl)
+	srv := httptest.NewServer(handler)
+
+	defer srv.Close()
+
+	random := rand.New(rand.NewSource(int64(0)))
+
+	lock, identityKeys, shares := cluster.NewForT(
+		t,
+		1,
+		kn-1,
+		kn,
+		0,
+		random,
+	)
+
+	addLockFiles(lock)
+
+	exitMsg := eth2p0.SignedVoluntaryExit{
+		Message: &eth2p0.VoluntaryExit{
+			Epoch:          42,
+			ValidatorIndex: 42,
+		},
+	}
+
+	sigRoot, err := exitMsg.Message.HashTreeRoot()
+	require.NoError(t, err)
+
+	domain, err := signing.GetDomain(context.Background(), mockEth2Cl, signing.DomainExit, exitEpoch)
+	require.NoError(t, err)
+
+	sigData, err := (&eth2p0.SigningData{ObjectRoot: sigRoot, Domain: domain}).HashTreeRoot()
+	require.NoError(t, err)
+
+	cl, err := obolapi.New(srv.URL)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	valPubkey := lock.Validators[0].PublicKeyHex()
+
+	_, err = cl.GetExitStatus(ctx, valPubkey, lock.LockHash)
+	require.ErrorIs(t, err, obolapi.ErrNoExit)
+
+	// Only the second and fourth operators submit partial exits.
+	for _, idx := range []int{3, 1} {
+		signature, err := tbls.Sign(shares[0][idx], sigData[:])
+		require.NoError(t, err)
+
+		exitMsg := exitMsg
+		exitMsg.Signature = eth2p0.BLSSignature(signature)
+
+		exit := obolapi.ExitBlob{
+			PublicKey:         valPubkey,
+			SignedExitMessage: exitMsg,
+		}
+
+		require.NoError(t, cl.PostPartialExits(ctx, lock.LockHash, uint64(idx+1), identityKeys[idx], exit))
+	}
+
+	status, err := cl.GetExitStatus(ctx, valPubkey, lock.LockHash)
+	require.NoError(t, err)
+	require.Equal(t, obolapi.ExitStatus{
+		Epoch:          42,
+		ValidatorIndex: 42,
+		ShareIndices:   []uint64{2, 4},
+	}, status)
+}
+
 func eth2Client(t *testing.T, ctx context.Context, bnURL string) eth2wrap.Client {
 	t.Helper()
 
@@ -20,6 +20,7 @@ import (
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
 	pbv1 "github.com/obolnetwork/charon/app/peerinfo/peerinfopb/v1"
+	"github.com/obolnetwork/charon/app/privkeylock"
 	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/p2p"
@@ -91,22 +92,6 @@ func newInternal(tcpNode host.Host, peers []peer.ID, version version.SemVer, loc
 ) *PeerInfo {
 	startTime := timestamppb.New(nowFunc())
 
-	// Register a simple handler that returns our info and ignores the request.
-	registerHandler("peerinfo", tcpNode, protocolID2,
-		func() proto.Message { return new(pbv1.PeerInfo) },
-		func(context.Context, peer.ID, proto.Message) (proto.Message, bool, error) {
-			return &pbv1.PeerInfo{
-				CharonVersion:     version.String(),
-				LockHash:          lockHash,
-				GitHash:           gitHash,
-				SentAt:            timestamppb.New(nowFunc()),
-				StartedAt:         startTime,
-				BuilderApiEnabled: builderAPIEnabled,
-				Nickname:          nickname,
-			}, true, nil
-		},
-	)
-
 	// Maps peers to their nickname
 	nicknames := map[string]string{p2p.PeerName(tcpNode.ID()): nickname}
 
@@ -118,12 +103,13 @@ func newInternal(tcpNode host.Host, peers []peer.ID, version version.SemVer, loc
 		versionFilters[peerID] = log.Filter()
 	}
 
-	return &PeerInfo{
+	p := &PeerInfo{
 		sendFunc:          sendFunc,
 		tcpNode:           tcpNode,
 		peers:             peers,
 		version:           version,
 		lockHash:          lockHash,
+		gitHash:           gitHash,
 		startTime:         startTime,
 		builderAPIEnabled: builderAPIEnabled,
 		metricSubmitter:   metricSubmitter,
@@ -132,7 +118,29 @@ func newInternal(tcpNode host.Host, peers []peer.ID, version version.SemVer, loc
 		lockHashFilters:   lockHashFilters,
 		versionFilters:    versionFilters,
 		nicknames:         nicknames,
+		received:          make(map[peer.ID]Received),
+		instances:         privkeylock.NewInstanceDetector(tcpNode.ID(), startTime.AsTime()),
 	}
+
+	// Register a simple handler that returns our info and stores the request's info.
+	registerHandler("peerinfo", tcpNode, protocolID2,
+		func() proto.Message { return new(pbv1.PeerInfo) },
+		func(ctx context.Context, pID peer.ID, req proto.Message) (proto.Message, bool, error) {
+			if info, ok := req.(*pbv1.PeerInfo); ok {
+				p.storeReceived(ctx, pID, info)
+			}
+
+			return p.localInfo(nowFunc()), true, nil
+		},
+	)
+
+	return p
+}
+
+// Received is the latest peer info received from a peer.
+type Received struct {
+	Info       *pbv1.PeerInfo
+	ReceivedAt time.Time
 }
 
 type PeerInfo struct {
@@ -151,6 +159,146 @@ type PeerInfo struct {
 	versionFilters    map[peer.ID]z.Field
 	nicknames         map[string]string
 	nicknamesMu       sync.RWMutex
+	instances         *privkeylock.InstanceDetector
+
+	statusMu      sync.Mutex
+	statusFunc    func() *pbv1.NodeStatus
+	upgradeTarget string
+	features      []string
+	received      map[peer.ID]Received
+}
+
+// SetStatusFunc sets the function returning this node's status shared with peers.
+func (p *PeerInfo) SetStatusFunc(fn func() *pbv1.NodeStatus) {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	p.statusFunc = fn
+}
+
+// SetUpgradeTarget sets the charon version the operator intends to upgrade to, shared with peers.
+func (p *PeerInfo) SetUpgradeTarget(target string) {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	p.upgradeTarget = target
+}
+
+// SetFeatures sets the feature set features enabled on this node, shared with peers.
+func (p *PeerInfo) SetFeatures(features []string) {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	p.features = features
+}
+
+// Received returns the latest peer info received from each peer, either as request or response.
+func (p *PeerInfo) Received() map[peer.ID]Received {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	resp := make(map[peer.ID]Received, len(p.received))
+	for pID, r := range p.received {
+		resp[pID] = r
+	}
+
+	return resp
+}
+
+// Instances returns the detector of peer instances running on multiple hosts simultaneously.
+func (p *PeerInfo) Instances() *privkeylock.InstanceDetector {
+	return p.instances
+}
+
+// Local returns this node's own peer info as shared with its peers.
+func (p *PeerInfo) Local() *pbv1.PeerInfo {
+	return p.localInfo(p.nowFunc())
+}
+
+// storeReceived stores the peer info received from the peer,
+// observing the peer's instance and processing the duplicate instances it reported.
+func (p *PeerInfo) storeReceived(ctx context.Context, pID peer.ID, info *pbv1.PeerInfo) {
+	now := p.nowFunc()
+
+	p.statusMu.Lock()
+	p.received[pID] = Received{Info: info, ReceivedAt: now}
+	p.statusMu.Unlock()
+
+	if info.GetStartedAt() != nil {
+		p.instances.Observe(ctx, pID, info.GetStartedAt().AsTime(), info.GetStandby(), now)
+	}
+
+	var duplicates []privkeylock.Instance
+	for _, duplicate := range info.GetDuplicateInstances() {
+		dupID, err := peer.IDFromBytes(duplicate.GetPeerId())
+		if err != nil || duplicate.GetStartedAt() == nil {
+			continue // Ignore invalid duplicates.
+		}
+
+		duplicates = append(duplicates, privkeylock.Instance{PeerID: dupID, StartedAt: duplicate.GetStartedAt().AsTime()})
+	}
+	p.instances.Report(ctx, duplicates)
+
+	var holders []privkeylock.Instance
+	for _, holder := range info.GetLeaseHolders() {
+		holderID, err := peer.IDFromBytes(holder.GetPeerId())
+		if err != nil || holder.GetStartedAt() == nil {
+			continue // Ignore invalid lease holders.
+		}
+
+		holders = append(holders, privkeylock.Instance{PeerID: holderID, StartedAt: holder.GetStartedAt().AsTime()})
+	}
+	p.instances.Grant(ctx, pID, holders)
+}
+
+// localInfo returns this node's peer info.
+func (p *PeerInfo) localInfo(now time.Time) *pbv1.PeerInfo {
+	p.nicknamesMu.RLock()
+	nickname := p.nicknames[p2p.PeerName(p.tcpNode.ID())]
+	p.nicknamesMu.RUnlock()
+
+	p.statusMu.Lock()
+	statusFunc := p.statusFunc
+	upgradeTarget := p.upgradeTarget
+	features := p.features
+	p.statusMu.Unlock()
+
+	var status *pbv1.NodeStatus
+	if statusFunc != nil {
+		status = statusFunc()
+	}
+
+	var duplicates []*pbv1.DuplicateInstance
+	for _, duplicate := range p.instances.Duplicates() {
+		duplicates = append(duplicates, &pbv1.DuplicateInstance{
+			PeerId:    []byte(duplicate.PeerID),
+			StartedAt: timestamppb.New(duplicate.StartedAt),
+		})
+	}
+
+	var holders []*pbv1.LeaseHolder
+	for _, holder := range p.instances.Leases() {
+		holders = append(holders, &pbv1.LeaseHolder{
+			PeerId:    []byte(holder.PeerID),
+			StartedAt: timestamppb.New(holder.StartedAt),
+		})
+	}
+
+	return &pbv1.PeerInfo{
+		CharonVersion:      p.version.String(),
+		LockHash:           p.lockHash,
+		GitHash:            p.gitHash,
+		SentAt:             timestamppb.New(now),
+		StartedAt:          p.startTime,
+		BuilderApiEnabled:  p.builderAPIEnabled,
+		Nickname:           nickname,
+		Status:             status,
+		UpgradeTarget:      upgradeTarget,
+		Features:           features,
+		DuplicateInstances: duplicates,
+		Standby:            p.instances.Standby(),
+		LeaseHolders:       holders,
+	}
 }
 
 // Run runs the peer info protocol until the context is cancelled.
@@ -177,15 +325,7 @@ func (p *PeerInfo) sendOnce(ctx context.Context, now time.Time) {
 			continue // Do not send to self.
 		}
 
-		req := &pbv1.PeerInfo{
-			CharonVersion:     p.version.String(),
-			LockHash:          p.lockHash,
-			GitHash:           p.gitHash,
-			SentAt:            timestamppb.New(now),
-			StartedAt:         p.startTime,
-			BuilderApiEnabled: p.builderAPIEnabled,
-			Nickname:          p.nicknames[p2p.PeerName(p.tcpNode.ID())],
-		}
+		req := p.localInfo(now)
 
 		go func(peerID peer.ID) {
 			var rtt time.Duration
@@ -202,6 +342,8 @@ func (p *PeerInfo) sendOnce(ctx context.Context, now time.Time) {
 				return
 			}
 
+			p.storeReceived(ctx, peerID, resp)
+
 			name := p2p.PeerName(peerID)
 
 			p.nicknamesMu.Lock()
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/app/peerinfo"
+	pbv1 "github.com/obolnetwork/charon/app/peerinfo/peerinfopb/v1"
 	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/testutil"
@@ -135,6 +136,13 @@ func TestPeerInfo(t *testing.T) {
 
 		peerInfo := peerinfo.NewForT(t, tcpNodes[i], peers, node.Version, node.LockHash, gitCommit, p2p.SendReceive, p2p.RegisterHandler,
 			tickProvider, nowFunc(i), metricSubmitter, true, baseNickname+p2p.PeerName(peers[i]))
+		peerInfo.SetStatusFunc(func() *pbv1.NodeStatus {
+			return &pbv1.NodeStatus{Validators: int32(i)}
+		})
+		if i == 0 {
+			peerInfo.SetUpgradeTarget("v9.9")
+			peerInfo.SetFeatures([]string{"linear"})
+		}
 
 		peerInfos = append(peerInfos, peerInfo)
 	}
@@ -148,6 +156,24 @@ func TestPeerInfo(t *testing.T) {
 
 	<-ctx.Done()
 	cancel()
+
+	// Node 0 received the statuses of the peers that responded, and those peers received its status in its request.
+	received := peerInfos[0].Received()
+	require.NotEmpty(t, received)
+	for i := 1; i < n; i++ {
+		r, ok := received[peers[i]]
+		if !ok {
+			continue
+		}
+		require.EqualValues(t, i, r.Info.GetStatus().GetValidators())
+
+		r, ok = peerInfos[i].Received()[peers[0]]
+		require.True(t, ok)
+		require.EqualValues(t, 0, r.Info.GetStatus().GetValidators())
+		require.Equal(t, baseNickname+p2p.PeerName(peers[0]), r.Info.GetNickname())
+		require.Equal(t, "v9.9", r.Info.GetUpgradeTarget())
+		require.Equal(t, []string{"linear"}, r.Info.GetFeatures())
+	}
 }
 
 func semver(t *testing.T, v string) version.SemVer {
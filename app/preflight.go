@@ -0,0 +1,484 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/lifecycle"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const (
+	// preflightPeerTimeout is the time allowed to connect to relays and peers.
+	preflightPeerTimeout = time.Minute
+	// preflightConsensusTimeout is the time allowed for the test consensus round to decide.
+	preflightConsensusTimeout = 10 * time.Second
+)
+
+// PreflightCheck is the result of a single preflight check.
+type PreflightCheck struct {
+	Name     string
+	Passed   bool
+	Skipped  bool
+	Detail   string
+	Duration time.Duration
+}
+
+// Preflight performs the startup steps of the node without scheduling any duties: it validates the config,
+// loads the private key, verifies the cluster lock, connects to the beacon node and relays, pings all peers
+// and runs a local test consensus round on dummy data. It returns the results of all checks, with checks
+// depending on failed checks marked as skipped.
+func Preflight(ctx context.Context, conf Config) []PreflightCheck {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var p preflight
+
+	p.run("config", func() (string, error) {
+		if err := validateURLs(conf); err != nil {
+			return "", err
+		}
+		if err := validateListenAddrs(conf); err != nil {
+			return "", err
+		}
+
+		return "urls, addresses and files are valid", validateFiles(conf)
+	})
+
+	var cluster *manifestpb.Cluster
+	lockOK := p.run("cluster_lock", func() (string, error) {
+		var err error
+		if _, cluster, err = loadClusterManifest(ctx, conf); err != nil {
+			return "", err
+		}
+
+		if cluster, _, err = applyKeyAttestations(ctx, conf, cluster); err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("cluster %q, %d validators, %d operators, threshold %d", cluster.GetName(),
+			len(cluster.GetValidators()), len(cluster.GetOperators()), cluster.GetThreshold()), nil
+	})
+
+	var (
+		p2pKey k1util.Signer
+		peers  []p2p.Peer
+	)
+	keyOK := lockOK && p.run("private_key", func() (string, error) {
+		var err error
+		if p2pKey, err = loadP2PSigner(ctx, conf); err != nil {
+			return "", err
+		}
+
+		if peers, err = manifest.ClusterPeers(cluster); err != nil {
+			return "", err
+		}
+
+		if err := p2p.VerifyP2PPubKey(peers, p2pKey.PubKey()); err != nil {
+			return "", err
+		}
+
+		peerID, err := p2p.PeerIDFromKey(p2pKey.PubKey())
+		if err != nil {
+			return "", err
+		}
+
+		nodeIdx, err := manifest.ClusterNodeIdx(cluster, peerID)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("peer %s at index %d", p2p.PeerName(peerID), nodeIdx.PeerIdx), nil
+	})
+	if !lockOK {
+		p.skip("private_key", "cluster lock failed")
+	}
+
+	if lockOK {
+		p.run("beacon_node", func() (string, error) {
+			return preflightBeaconNode(ctx, conf, cluster)
+		})
+	} else {
+		p.skip("beacon_node", "cluster lock failed")
+	}
+
+	var (
+		tcpNode host.Host
+		relays  []*p2p.MutablePeer
+	)
+	p2pOK := keyOK && p.run("p2p", func() (string, error) {
+		var err error
+		relays, err = p2p.NewRelays(ctx, conf.P2P.Relays, hex7(cluster.GetInitialMutationHash()))
+		if err != nil {
+			return "", err
+		}
+
+		if tcpNode, err = preflightTCPNode(ctx, conf, peers, relays, p2pKey); err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("listening on %v", tcpNode.Addrs()), nil
+	})
+	if tcpNode != nil {
+		defer tcpNode.Close()
+	}
+	if !keyOK {
+		p.skip("p2p", "private key failed")
+	}
+
+	if p2pOK {
+		if preflightRelays(ctx, &p, tcpNode, relays) {
+			preflightPeers(ctx, &p, tcpNode, peers, relays)
+		} else {
+			p.skip("peers", "relays failed")
+		}
+	} else {
+		p.skip("relays", "p2p failed")
+		p.skip("peers", "p2p failed")
+	}
+
+	nodes := len(peers)
+	if lockOK {
+		nodes = len(cluster.GetOperators())
+	}
+	if nodes > 0 {
+		p.run("consensus", func() (string, error) {
+			return preflightConsensus(ctx, nodes)
+		})
+	} else {
+		p.skip("consensus", "cluster lock failed")
+	}
+
+	return p.checks
+}
+
+// preflight accumulates the results of preflight checks.
+type preflight struct {
+	mu     sync.Mutex
+	checks []PreflightCheck
+}
+
+// run runs the check and records its result, returning true if it passed.
+func (p *preflight) run(name string, fn func() (string, error)) bool {
+	t0 := time.Now()
+	detail, err := fn()
+
+	check := PreflightCheck{
+		Name:     name,
+		Passed:   err == nil,
+		Detail:   detail,
+		Duration: time.Since(t0),
+	}
+	if err != nil {
+		check.Detail = err.Error()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.checks = append(p.checks, check)
+
+	return err == nil
+}
+
+// skip records the check as skipped due to the reason.
+func (p *preflight) skip(name, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.checks = append(p.checks, PreflightCheck{Name: name, Skipped: true, Detail: "skipped: " + reason})
+}
+
+// preflightBeaconNode returns a summary of the beacon node or an error if it is unreachable, syncing
+// or on another network than the cluster.
+func preflightBeaconNode(ctx context.Context, conf Config, cluster *manifestpb.Cluster) (string, error) {
+	eth2Cl, _, err := newETH2Client(ctx, conf, new(lifecycle.Manager), cluster, cluster.GetForkVersion(),
+		conf.BeaconNodeTimeout, conf.BeaconNodeSubmitTimeout)
+	if err != nil {
+		return "", err
+	}
+
+	versionResp, err := eth2Cl.NodeVersion(ctx, &eth2api.NodeVersionOpts{})
+	if err != nil {
+		return "", errors.Wrap(err, "beacon node version")
+	}
+
+	genesis, err := eth2Cl.Genesis(ctx, &eth2api.GenesisOpts{})
+	if err != nil {
+		return "", errors.Wrap(err, "beacon node genesis")
+	}
+
+	if !bytes.Equal(genesis.Data.GenesisForkVersion[:], cluster.GetForkVersion()) {
+		return "", errors.New("beacon node network doesn't match cluster lock",
+			z.Hex("beacon_node_fork_version", genesis.Data.GenesisForkVersion[:]),
+			z.Hex("lock_fork_version", cluster.GetForkVersion()))
+	}
+
+	syncing, syncDistance, err := beaconNodeSyncing(ctx, eth2Cl)
+	if err != nil {
+		return "", errors.Wrap(err, "beacon node syncing")
+	} else if syncing {
+		return "", errors.New("beacon node is syncing", z.U64("sync_distance", uint64(syncDistance)))
+	}
+
+	peerCount, err := eth2Cl.NodePeerCount(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "beacon node peer count")
+	} else if peerCount == 0 {
+		return "", errors.New("beacon node has zero peers")
+	}
+
+	return fmt.Sprintf("%s, synced, %d peers", versionResp.Data, peerCount), nil
+}
+
+// preflightTCPNode returns a new libp2p host listening on the configured addresses, only allowing
+// connections to cluster peers and relays.
+func preflightTCPNode(ctx context.Context, conf Config, peers []p2p.Peer, relays []*p2p.MutablePeer, p2pKey k1util.Signer) (host.Host, error) {
+	var peerIDs []peer.ID
+	for _, p := range peers {
+		peerIDs = append(peerIDs, p.ID)
+	}
+
+	connGater, err := p2p.NewConnGater(peerIDs, relays)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append([]libp2p.Option{libp2p.ResourceManager(new(network.NullResourceManager))}, conf.TestConfig.LibP2POpts...)
+
+	return p2p.NewTCPNodeWithSigner(ctx, conf.P2P, p2pKey, connGater, false, opts...)
+}
+
+// preflightRelays waits for the relays to resolve and connects to them, recording a check per relay.
+// It returns true if at least one relay is connected or if no relays are configured.
+func preflightRelays(ctx context.Context, p *preflight, tcpNode host.Host, relays []*p2p.MutablePeer) bool {
+	if len(relays) == 0 {
+		p.skip("relays", "no relays configured")
+		return true
+	}
+
+	var connected bool
+	for i, relay := range relays {
+		if p.run(fmt.Sprintf("relay %d", i), func() (string, error) {
+			ctx, cancel := context.WithTimeout(ctx, preflightPeerTimeout)
+			defer cancel()
+
+			relayPeer, ok := relay.Peer()
+			for !ok {
+				select {
+				case <-ctx.Done():
+					return "", errors.New("relay address not resolved")
+				case <-time.After(100 * time.Millisecond):
+					relayPeer, ok = relay.Peer()
+				}
+			}
+
+			if err := tcpNode.Connect(ctx, relayPeer.AddrInfo()); err != nil {
+				return "", errors.Wrap(err, "connect to relay", z.Str("relay", relayPeer.Name))
+			}
+
+			return fmt.Sprintf("connected to %s via %v", relayPeer.Name, relayPeer.Addrs), nil
+		}) {
+			connected = true
+		}
+	}
+
+	return connected
+}
+
+// preflightPeers reserves relay slots and pings all peers concurrently, recording a check per peer.
+func preflightPeers(ctx context.Context, p *preflight, tcpNode host.Host, peers []p2p.Peer, relays []*p2p.MutablePeer) {
+	var peerIDs []peer.ID
+	for _, pr := range peers {
+		peerIDs = append(peerIDs, pr.ID)
+	}
+
+	for _, relay := range relays {
+		go p2p.NewRelayReserver(tcpNode, relay)(ctx)
+	}
+	go p2p.NewRelayRouter(tcpNode, peerIDs, relays)(ctx)
+
+	svc := ping.NewPingService(tcpNode)
+
+	var wg sync.WaitGroup
+	for _, pr := range peers {
+		if pr.ID == tcpNode.ID() {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			p.run("peer "+pr.Name, func() (string, error) {
+				return preflightPing(ctx, svc, tcpNode, pr.ID)
+			})
+		}()
+	}
+	wg.Wait()
+}
+
+// preflightPing pings the peer until it succeeds or preflightPeerTimeout elapses, returning the round trip time
+// and connection type.
+func preflightPing(ctx context.Context, svc *ping.PingService, tcpNode host.Host, peerID peer.ID) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, preflightPeerTimeout)
+	defer cancel()
+
+	lastErr := errors.New("peer not reachable")
+	for {
+		pingCtx, pingCancel := context.WithCancel(ctx)
+		select {
+		case <-ctx.Done():
+			pingCancel()
+			return "", errors.Wrap(lastErr, "ping timeout")
+		case result := <-svc.Ping(pingCtx, peerID):
+			pingCancel()
+			if result.Error == nil {
+				connType := "direct"
+				for _, conn := range tcpNode.Network().ConnsToPeer(peerID) {
+					if p2p.IsRelayAddr(conn.RemoteMultiaddr()) {
+						connType = "relay"
+					}
+				}
+
+				return fmt.Sprintf("rtt %s (%s)", result.RTT.Round(time.Millisecond), connType), nil
+			}
+
+			lastErr = result.Error
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// preflightConsensus runs a local QBFT consensus round on dummy data between simulated processes of
+// the cluster's size and returns an error if they don't all decide the same value in time.
+// No consensus messages are sent to peers, so running peers' duties are never affected.
+func preflightConsensus(ctx context.Context, nodes int) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, preflightConsensusTimeout)
+	defer cancel()
+
+	type value = [32]byte
+
+	var (
+		inputs   = make([]chan preflightMsg, nodes)
+		decided  = make(chan value, nodes)
+		instance = time.Now().UnixNano()
+		dummy    = sha256.Sum256([]byte("charon preflight"))
+	)
+	for i := range inputs {
+		inputs[i] = make(chan preflightMsg, nodes*100)
+	}
+
+	def := qbft.Definition[int64, value]{
+		IsLeader: func(_ int64, round, process int64) bool {
+			return round%int64(nodes) == process
+		},
+		NewTimer: func(round int64) (<-chan time.Time, func()) {
+			timer := time.NewTimer(time.Duration(round) * time.Second)
+			return timer.C, func() { timer.Stop() }
+		},
+		Decide: func(_ context.Context, _ int64, val value, _ []qbft.Msg[int64, value]) {
+			decided <- val
+		},
+		LogUponRule:    func(context.Context, int64, int64, int64, qbft.Msg[int64, value], qbft.UponRule) {},
+		LogRoundChange: func(context.Context, int64, int64, int64, int64, qbft.UponRule, []qbft.Msg[int64, value]) {},
+		LogUnjust:      func(context.Context, int64, int64, qbft.Msg[int64, value]) {},
+		Nodes:          nodes,
+		FIFOLimit:      100,
+	}
+
+	t0 := time.Now()
+	for i := range nodes {
+		receive := make(chan qbft.Msg[int64, value], nodes*100)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case msg := <-inputs[i]:
+					receive <- msg
+				}
+			}
+		}()
+
+		transport := qbft.Transport[int64, value]{
+			Broadcast: func(ctx context.Context, typ qbft.MsgType, instance int64, source int64, round int64,
+				val value, pr int64, pv value, justification []qbft.Msg[int64, value],
+			) error {
+				msg := preflightMsg{typ: typ, instance: instance, source: source, round: round,
+					value: val, pr: pr, pv: pv, justification: justification}
+				for _, input := range inputs {
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case input <- msg:
+					}
+				}
+
+				return nil
+			},
+			Receive: receive,
+		}
+
+		go func() {
+			_ = qbft.Run(ctx, def, transport, instance, int64(i), qbft.InputValue(dummy))
+		}()
+	}
+
+	for range nodes {
+		select {
+		case <-ctx.Done():
+			return "", errors.New("test consensus round timeout")
+		case val := <-decided:
+			if val != dummy {
+				return "", errors.New("test consensus round decided unexpected value")
+			}
+		}
+	}
+
+	return fmt.Sprintf("%d simulated peers decided in %s", nodes, time.Since(t0).Round(time.Millisecond)), nil
+}
+
+// preflightMsg is a QBFT message of the preflight test consensus round.
+type preflightMsg struct {
+	typ           qbft.MsgType
+	instance      int64
+	source        int64
+	round         int64
+	value         [32]byte
+	pr            int64
+	pv            [32]byte
+	justification []qbft.Msg[int64, [32]byte]
+}
+
+func (m preflightMsg) Type() qbft.MsgType                         { return m.typ }
+func (m preflightMsg) Instance() int64                            { return m.instance }
+func (m preflightMsg) Source() int64                              { return m.source }
+func (m preflightMsg) Round() int64                               { return m.round }
+func (m preflightMsg) Value() [32]byte                            { return m.value }
+func (m preflightMsg) PreparedRound() int64                       { return m.pr }
+func (m preflightMsg) PreparedValue() [32]byte                    { return m.pv }
+func (m preflightMsg) Justification() []qbft.Msg[int64, [32]byte] { return m.justification }
@@ -0,0 +1,74 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestPreflight(t *testing.T) {
+	dir := t.TempDir()
+	lock, _, _ := cluster.NewForT(t, 1, 3, 4, 0, rand.New(rand.NewSource(0)))
+
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	require.NoError(t, os.WriteFile(lockFile, b, 0o644))
+
+	keyFile := filepath.Join(dir, "other-private-key")
+	require.NoError(t, k1util.Save(testutil.GenerateInsecureK1Key(t, 99), keyFile))
+
+	conf := Config{
+		LockFile:          lockFile,
+		ManifestFile:      filepath.Join(dir, "cluster-manifest.pb"),
+		PrivKeyFile:       keyFile,
+		BeaconNodeAddrs:   []string{"http://127.0.0.1:1"},
+		BeaconNodeTimeout: time.Second,
+		ValidatorAPIAddr:  "127.0.0.1:3600",
+		MonitoringAddr:    "127.0.0.1:3620",
+	}
+
+	results := make(map[string]string)
+	for _, check := range Preflight(context.Background(), conf) {
+		switch {
+		case check.Skipped:
+			results[check.Name] = "skipped"
+		case check.Passed:
+			results[check.Name] = "pass"
+		default:
+			results[check.Name] = "fail"
+		}
+	}
+
+	require.Equal(t, map[string]string{
+		"config":       "pass",
+		"cluster_lock": "pass",
+		"private_key":  "fail",
+		"beacon_node":  "fail",
+		"p2p":          "skipped",
+		"relays":       "skipped",
+		"peers":        "skipped",
+		"consensus":    "pass",
+	}, results)
+}
+
+func TestPreflightConsensus(t *testing.T) {
+	for _, nodes := range []int{1, 4, 7} {
+		detail, err := preflightConsensus(context.Background(), nodes)
+		require.NoError(t, err)
+		require.Contains(t, detail, "simulated peers decided")
+	}
+}
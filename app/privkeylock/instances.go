@@ -0,0 +1,178 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package privkeylock
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// instanceWindow is the duration after which an instance of a peer that hasn't been seen is considered stopped.
+const instanceWindow = 10 * time.Minute
+
+// Instance identifies a running charon instance by its peer ID and start time.
+type Instance struct {
+	PeerID    peer.ID
+	StartedAt time.Time
+}
+
+// seenTimes are the first and last times an instance was seen and whether it runs in standby mode.
+type seenTimes struct {
+	First   time.Time
+	Last    time.Time
+	Standby bool
+}
+
+// NewInstanceDetector returns a new instance detector for this node's instance, identified by its peer ID and start time.
+func NewInstanceDetector(self peer.ID, startedAt time.Time) *InstanceDetector {
+	return &InstanceDetector{
+		self:       Instance{PeerID: self, StartedAt: startedAt.UTC()},
+		seen:       make(map[Instance]seenTimes),
+		duplicates: make(map[Instance]time.Time),
+		holders:    make(map[peer.ID]Instance),
+		grants:     make(map[peer.ID]Instance),
+	}
+}
+
+// InstanceDetector extends the private key lock file across hosts by detecting when the same peer identity
+// appears online from two hosts simultaneously, e.g. after a failover while the original host is still running.
+//
+// The file lock only protects against multiple instances on the same host, so the detector relies on the cluster
+// peers instead: each peer observes the start time of the instances of other peers exchanging peer info with it.
+// If an older instance is still seen after a newer instance of the same peer was first seen, both are running
+// and the newer instance is flagged as the duplicate. Peers share the duplicates they detected,
+// so the newer instance learns about it and enters a safe non-signing mode, preventing double signing.
+// The older instance keeps running, since it may already have signed duties.
+type InstanceDetector struct {
+	self Instance
+
+	mu         sync.Mutex
+	seen       map[Instance]seenTimes
+	duplicates map[Instance]time.Time
+	detected   bool
+	subs       []func(context.Context)
+
+	// Leadership lease state, see EnableLeases.
+	standby         bool
+	quorum          int
+	failoverTimeout time.Duration
+	holders         map[peer.ID]Instance
+	grants          map[peer.ID]Instance
+	leader          bool
+	leaseSubs       []func(context.Context)
+}
+
+// Subscribe registers a function called once when this node's instance is reported as a duplicate.
+func (d *InstanceDetector) Subscribe(fn func(context.Context)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.subs = append(d.subs, fn)
+}
+
+// Observe records that the instance of the peer with the start time and standby mode was seen now.
+func (d *InstanceDetector) Observe(ctx context.Context, pID peer.ID, startedAt time.Time, standby bool, now time.Time) {
+	if pID == d.self.PeerID {
+		return // Other instances of this node are reported by peers.
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	instance := Instance{PeerID: pID, StartedAt: startedAt.UTC()}
+
+	seen, ok := d.seen[instance]
+	if !ok {
+		seen.First = now
+	}
+	seen.Last = now
+	seen.Standby = standby
+	d.seen[instance] = seen
+
+	for other, otherSeen := range d.seen {
+		if now.Sub(otherSeen.Last) > instanceWindow {
+			delete(d.seen, other)
+			continue
+		} else if other.PeerID != pID || other == instance {
+			continue
+		} else if otherSeen.Standby || seen.Standby {
+			continue // Standby instances don't sign without the leadership lease.
+		}
+
+		older, newer := other, instance
+		olderSeen, newerSeen := otherSeen, seen
+		if instance.StartedAt.Before(other.StartedAt) {
+			older, newer = instance, other
+			olderSeen, newerSeen = seen, otherSeen
+		}
+
+		if !olderSeen.Last.After(newerSeen.First) {
+			continue // Older instance stopped before newer instance started, i.e. a restart or failover.
+		}
+
+		if _, ok := d.duplicates[newer]; !ok {
+			log.Error(ctx, "Duplicate peer instance detected, same peer identity running on multiple hosts", nil,
+				z.Str("peer", p2p.PeerName(pID)),
+				z.Any("older_started_at", older.StartedAt),
+				z.Any("newer_started_at", newer.StartedAt))
+		}
+		d.duplicates[newer] = now
+	}
+
+	for duplicate, detectedAt := range d.duplicates {
+		if now.Sub(detectedAt) > instanceWindow {
+			delete(d.duplicates, duplicate)
+		}
+	}
+
+	if d.quorum > 0 {
+		d.updateHolder(ctx, pID, now)
+	}
+}
+
+// Report processes the duplicate instances reported by a peer,
+// notifying the subscribers once if this node's instance is reported.
+func (d *InstanceDetector) Report(ctx context.Context, duplicates []Instance) {
+	d.mu.Lock()
+	var subs []func(context.Context)
+	for _, duplicate := range duplicates {
+		if duplicate.PeerID == d.self.PeerID && duplicate.StartedAt.Equal(d.self.StartedAt) && !d.detected {
+			d.detected = true
+			subs = d.subs
+		}
+	}
+	d.mu.Unlock()
+
+	for _, sub := range subs {
+		sub(ctx)
+	}
+}
+
+// Duplicates returns the duplicate instances recently detected by this node, ordered by peer ID and start time.
+func (d *InstanceDetector) Duplicates() []Instance {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	resp := make([]Instance, 0, len(d.duplicates))
+	for duplicate := range d.duplicates {
+		resp = append(resp, duplicate)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].PeerID != resp[j].PeerID {
+			return resp[i].PeerID < resp[j].PeerID
+		}
+
+		return resp[i].StartedAt.Before(resp[j].StartedAt)
+	})
+
+	return resp
+}
@@ -0,0 +1,56 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package privkeylock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstanceDetector(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	const (
+		self  = peer.ID("self")
+		peerA = peer.ID("peerA")
+		peerB = peer.ID("peerB")
+	)
+
+	d := NewInstanceDetector(self, now)
+
+	var detected int
+	d.Subscribe(func(context.Context) { detected++ })
+
+	// Peer A restarts: the old instance stops before the new instance starts.
+	oldA, newA := now.Add(-time.Hour), now.Add(time.Minute)
+	d.Observe(ctx, peerA, oldA, false, now)
+	d.Observe(ctx, peerA, oldA, false, now.Add(30*time.Second))
+	d.Observe(ctx, peerA, newA, false, now.Add(time.Minute))
+	d.Observe(ctx, peerA, newA, false, now.Add(2*time.Minute))
+	require.Empty(t, d.Duplicates())
+
+	// Peer B fails over while the original instance keeps running.
+	oldB, newB := now.Add(-time.Hour), now.Add(time.Minute)
+	d.Observe(ctx, peerB, oldB, false, now)
+	d.Observe(ctx, peerB, newB, false, now.Add(time.Minute))
+	d.Observe(ctx, peerB, oldB, false, now.Add(90*time.Second))
+	require.Equal(t, []Instance{{PeerID: peerB, StartedAt: newB.UTC()}}, d.Duplicates())
+
+	// Duplicates expire after the window.
+	d.Observe(ctx, peerB, newB, false, now.Add(time.Minute+2*instanceWindow))
+	require.Empty(t, d.Duplicates())
+
+	// Other instances of this node are ignored.
+	d.Report(ctx, []Instance{{PeerID: self, StartedAt: now.Add(-time.Hour)}})
+	require.Zero(t, detected)
+
+	// This instance reported as duplicate notifies subscribers once.
+	d.Report(ctx, []Instance{{PeerID: self, StartedAt: now}})
+	d.Report(ctx, []Instance{{PeerID: self, StartedAt: now}})
+	require.Equal(t, 1, detected)
+}
@@ -0,0 +1,171 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package privkeylock
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// EnableLeases enables the cluster-coordinated leadership lease allowing an operator to run a warm standby instance.
+//
+// Each node grants the lease of each peer to one of the peer's instances: the current holder while it is seen
+// within the failover timeout, otherwise preferably a primary (non-standby) instance, then the oldest standby instance.
+// Nodes share their grants via peer info. A standby instance acquires the lease and starts signing once
+// a quorum of peers (see LeaseQuorum) granted it the lease, i.e. after the primary instance failed.
+// A primary instance that loses the lease to another instance of the same peer identity steps down, e.g. when restarted after a failover.
+//
+// Standby instances are only granted the lease once this node has been running for the failover timeout,
+// so a restarted node doesn't grant the lease to a standby instance before seeing the primary instance.
+func (d *InstanceDetector) EnableLeases(standby bool, quorum int, failoverTimeout time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.standby = standby
+	d.quorum = quorum
+	d.failoverTimeout = failoverTimeout
+}
+
+// LeaseQuorum returns the number of peers that must grant an instance the leadership lease in a cluster
+// of the provided number of peers: a strict majority of the other peers, so no two instances of the same
+// peer identity can acquire the lease at the same time.
+func LeaseQuorum(peers int) int {
+	return (peers-1)/2 + 1
+}
+
+// Standby returns true if this node's instance runs in standby mode.
+func (d *InstanceDetector) Standby() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.standby
+}
+
+// SubscribeLease registers a function called once when this node's standby instance acquires the leadership lease.
+func (d *InstanceDetector) SubscribeLease(fn func(context.Context)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.leaseSubs = append(d.leaseSubs, fn)
+}
+
+// Leases returns the instances of peers this node grants the leadership lease to, ordered by peer ID.
+func (d *InstanceDetector) Leases() []Instance {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	resp := make([]Instance, 0, len(d.holders))
+	for _, holder := range d.holders {
+		resp = append(resp, holder)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].PeerID < resp[j].PeerID
+	})
+
+	return resp
+}
+
+// Grant processes the leadership lease holders granted by a peer. A standby instance granted the lease
+// by a quorum of peers acquires it, notifying the lease subscribers once. A primary instance whose lease was
+// granted to another instance of the same peer identity by a quorum of peers steps down,
+// notifying the duplicate instance subscribers once.
+func (d *InstanceDetector) Grant(ctx context.Context, from peer.ID, holders []Instance) {
+	d.mu.Lock()
+	if d.quorum == 0 {
+		d.mu.Unlock()
+		return
+	}
+
+	delete(d.grants, from)
+	for _, holder := range holders {
+		if holder.PeerID == d.self.PeerID {
+			d.grants[from] = Instance{PeerID: holder.PeerID, StartedAt: holder.StartedAt.UTC()}
+		}
+	}
+
+	var self, other int
+	for _, grant := range d.grants {
+		if grant.StartedAt.Equal(d.self.StartedAt) {
+			self++
+		} else {
+			other++
+		}
+	}
+
+	var subs []func(context.Context)
+	if d.standby && !d.leader && self >= d.quorum {
+		d.leader = true
+		subs = d.leaseSubs
+		log.Info(ctx, "Standby instance acquired leadership lease", z.Int("grants", self))
+	} else if !d.standby && !d.detected && other >= d.quorum {
+		d.detected = true
+		subs = d.subs
+		log.Warn(ctx, "Leadership lease granted to another instance of this node", nil, z.Int("grants", other))
+	}
+	d.mu.Unlock()
+
+	for _, sub := range subs {
+		sub(ctx)
+	}
+}
+
+// updateHolder updates the leadership lease holder of the peer. It must be called with the lock held.
+func (d *InstanceDetector) updateHolder(ctx context.Context, pID peer.ID, now time.Time) {
+	live := func(instance Instance) bool {
+		seen, ok := d.seen[instance]
+		return ok && now.Sub(seen.Last) <= d.failoverTimeout
+	}
+
+	prev, ok := d.holders[pID]
+	if ok && live(prev) {
+		return
+	}
+
+	var (
+		best  Instance
+		found bool
+	)
+	for instance, seen := range d.seen {
+		if instance.PeerID != pID || !live(instance) {
+			continue
+		} else if seen.Standby && now.Sub(d.self.StartedAt) < d.failoverTimeout {
+			continue // Not running long enough to grant the lease to standby instances.
+		}
+
+		if !found || better(instance, seen.Standby, best, d.seen[best].Standby) {
+			best, found = instance, true
+		}
+	}
+
+	if !found {
+		delete(d.holders, pID)
+		return
+	}
+
+	d.holders[pID] = best
+
+	if ok && best != prev {
+		log.Warn(ctx, "Granting leadership lease to new peer instance", nil,
+			z.Str("peer", p2p.PeerName(pID)),
+			z.Bool("standby", d.seen[best].Standby),
+			z.Any("started_at", best.StartedAt))
+	}
+}
+
+// better returns true if instance a is preferred over b as leadership lease holder,
+// preferring primary instances, then older instances.
+func better(a Instance, aStandby bool, b Instance, bStandby bool) bool {
+	if aStandby != bStandby {
+		return !aStandby
+	}
+
+	return a.StartedAt.Before(b.StartedAt)
+}
@@ -0,0 +1,122 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package privkeylock
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeases(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	const (
+		self  = peer.ID("self")
+		peerA = peer.ID("peerA")
+		peerB = peer.ID("peerB")
+	)
+
+	// Granting side: this node grants the lease of peer A.
+	d := NewInstanceDetector(self, now)
+	d.EnableLeases(false, 2, time.Minute)
+
+	primary, standby := now.Add(-time.Hour), now.Add(-time.Minute)
+	d.Observe(ctx, peerA, standby, true, now)
+	require.Empty(t, d.Leases()) // Not running long enough to grant standby instances.
+
+	d.Observe(ctx, peerA, primary, false, now.Add(time.Second))
+	d.Observe(ctx, peerA, standby, true, now.Add(2*time.Second))
+	require.Equal(t, []Instance{{PeerID: peerA, StartedAt: primary.UTC()}}, d.Leases())
+	require.Empty(t, d.Duplicates()) // Standby instances aren't duplicates.
+
+	// Primary fails, standby is granted the lease after the failover timeout.
+	d.Observe(ctx, peerA, standby, true, now.Add(30*time.Second))
+	require.Equal(t, []Instance{{PeerID: peerA, StartedAt: primary.UTC()}}, d.Leases())
+
+	d.Observe(ctx, peerA, standby, true, now.Add(2*time.Minute))
+	require.Equal(t, []Instance{{PeerID: peerA, StartedAt: standby.UTC()}}, d.Leases())
+
+	// Restarted primary doesn't take the lease back from the live standby.
+	restarted := now.Add(3 * time.Minute)
+	d.Observe(ctx, peerA, restarted, false, now.Add(3*time.Minute))
+	require.Equal(t, []Instance{{PeerID: peerA, StartedAt: standby.UTC()}}, d.Leases())
+
+	// Standby side: acquires the lease once a quorum of peers granted it.
+	s := NewInstanceDetector(self, now)
+	s.EnableLeases(true, 2, time.Minute)
+	require.True(t, s.Standby())
+
+	var leader int
+	s.SubscribeLease(func(context.Context) { leader++ })
+
+	s.Grant(ctx, peerA, []Instance{{PeerID: self, StartedAt: now}})
+	s.Grant(ctx, peerB, []Instance{{PeerID: self, StartedAt: primary}})
+	require.Zero(t, leader)
+
+	s.Grant(ctx, peerB, []Instance{{PeerID: self, StartedAt: now}})
+	s.Grant(ctx, peerB, []Instance{{PeerID: self, StartedAt: now}})
+	require.Equal(t, 1, leader)
+
+	// Primary side: steps down once a quorum of peers granted another instance.
+	p := NewInstanceDetector(self, now)
+	p.EnableLeases(false, 2, time.Minute)
+
+	var stepDown int
+	p.Subscribe(func(context.Context) { stepDown++ })
+
+	p.Grant(ctx, peerA, []Instance{{PeerID: self, StartedAt: standby}})
+	require.Zero(t, stepDown)
+	p.Grant(ctx, peerB, []Instance{{PeerID: self, StartedAt: standby}})
+	require.Equal(t, 1, stepDown)
+}
+
+func TestLeaseQuorum(t *testing.T) {
+	for peers, quorum := range map[int]int{1: 1, 2: 1, 3: 2, 4: 2, 5: 3, 6: 3, 7: 4, 10: 5} {
+		require.Equal(t, quorum, LeaseQuorum(peers), "peers=%d", peers)
+	}
+}
+
+func TestLeaseSplitBrain(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	const self = peer.ID("self")
+
+	// Two standby instances of this node, each granted the lease by a different subset of the other peers.
+	for _, peers := range []int{3, 4, 5, 7, 10} {
+		others := make([]peer.ID, peers-1)
+		for i := range others {
+			others[i] = peer.ID(fmt.Sprintf("peer%d", i))
+		}
+
+		for split := 0; split <= len(others); split++ {
+			standbyA, standbyB := now, now.Add(time.Second)
+			a := NewInstanceDetector(self, standbyA)
+			b := NewInstanceDetector(self, standbyB)
+
+			var leaders int
+			for _, d := range []*InstanceDetector{a, b} {
+				d.EnableLeases(true, LeaseQuorum(peers), time.Minute)
+				d.SubscribeLease(func(context.Context) { leaders++ })
+			}
+
+			for i, other := range others {
+				holder := []Instance{{PeerID: self, StartedAt: standbyA}}
+				if i >= split {
+					holder = []Instance{{PeerID: self, StartedAt: standbyB}}
+				}
+
+				a.Grant(ctx, other, holder)
+				b.Grant(ctx, other, holder)
+			}
+
+			require.LessOrEqual(t, leaders, 1, "peers=%d, split=%d", peers, split)
+		}
+	}
+}
@@ -64,12 +64,16 @@ type Service struct {
 }
 
 // Run runs the service, updating the lock file every second and deleting it on context cancellation.
+// Failed updates are retried until the lock file becomes stale, since files are transiently locked
+// by other processes like anti-virus scanners on Windows.
 func (s Service) Run() error {
 	defer close(s.done)
 
 	tick := time.NewTicker(s.updatePeriod)
 	defer tick.Stop()
 
+	lastUpdate := time.Now()
+
 	for {
 		select {
 		case <-s.quit:
@@ -80,9 +84,16 @@ func (s Service) Run() error {
 			return nil
 		case <-tick.C:
 			// Overwrite lockfile with new metadata
-			if err := writeFile(s.path, s.command, time.Now()); err != nil {
+			now := time.Now()
+			if err := writeFile(s.path, s.command, now); err != nil {
+				if now.Sub(lastUpdate) < staleDuration-s.updatePeriod {
+					continue // Retry before another instance considers the lock file stale.
+				}
+
 				return err
 			}
+
+			lastUpdate = now
 		}
 	}
 }
@@ -52,11 +52,34 @@ func TestService(t *testing.T) {
 	require.ErrorIs(t, openErr, os.ErrNotExist)
 }
 
+func TestServiceRetriesUpdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "privkeylocktest")
+
+	svc, err := New(path, "test")
+	require.NoError(t, err)
+	svc.updatePeriod = time.Millisecond
+
+	// Replace the file with a directory so updates fail.
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, os.Mkdir(path, 0o755))
+
+	var eg errgroup.Group
+	eg.Go(svc.Run)
+
+	// Run retries failed updates until the file becomes stale.
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, os.Remove(path))
+	assertFileExists(t, path)
+
+	svc.Close()
+	require.NoError(t, eg.Wait())
+}
+
 func assertFileExists(t *testing.T, path string) {
 	t.Helper()
 
 	assert.Eventually(t, func() bool {
-		_, openErr := os.Open(path)
-		return openErr == nil
+		_, statErr := os.Stat(path)
+		return statErr == nil
 	}, time.Second, time.Millisecond)
 }
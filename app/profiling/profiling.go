@@ -0,0 +1,156 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package profiling provides runtime pprof endpoints and continuous profiling by pushing
+// profiles to a Pyroscope server, so performance regressions on production clusters can be
+// diagnosed without restarting with special builds.
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"net/url"
+	"runtime"
+	rpprof "runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// cpuSampleRate is the default go CPU profiling rate in hertz.
+const cpuSampleRate = 100
+
+// RegisterHandlers registers the pprof handlers at /debug/pprof/ on the mux.
+func RegisterHandlers(mux *http.ServeMux) {
+	// Copied from net/http/pprof/pprof.go
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+}
+
+// NewPusher returns a new pusher of CPU and heap profiles to the Pyroscope server at addr.
+// Profiles are labelled with the service name and the labels.
+func NewPusher(addr, service string, labels map[string]string, interval time.Duration) *Pusher {
+	return &Pusher{
+		addr:     strings.TrimSuffix(addr, "/"),
+		name:     appName(service, labels),
+		interval: interval,
+		client:   &http.Client{Timeout: interval},
+	}
+}
+
+// Pusher continuously pushes profiles to a Pyroscope server.
+type Pusher struct {
+	addr     string
+	name     string
+	interval time.Duration
+	client   *http.Client
+}
+
+// Run profiles the CPU for each interval and pushes the CPU and heap profiles at the end of each interval
+// until the context is cancelled.
+func (p *Pusher) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "profiling")
+	log.Info(ctx, "Continuous profiling enabled", z.Redact(z.Str("address", p.addr)))
+
+	for ctx.Err() == nil {
+		if err := p.profile(ctx); err != nil {
+			log.Warn(ctx, "Failed to push profiles", err)
+		}
+	}
+}
+
+// profile profiles the CPU for an interval and pushes the CPU and heap profiles.
+func (p *Pusher) profile(ctx context.Context) error {
+	from := time.Now()
+
+	var cpu bytes.Buffer
+	cpuErr := rpprof.StartCPUProfile(&cpu)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(p.interval):
+	}
+
+	if cpuErr == nil {
+		rpprof.StopCPUProfile()
+	}
+
+	until := time.Now()
+
+	if ctx.Err() != nil {
+		return nil //nolint:nilerr // Profiles of partial intervals are dropped on shutdown.
+	}
+
+	if cpuErr != nil {
+		// CPU profiling fails if already enabled, e.g. by a pprof endpoint request, only push the heap profile.
+		log.Debug(ctx, "Skipping CPU profile", z.Err(cpuErr))
+	} else if err := p.push(ctx, cpu.Bytes(), from, until, cpuSampleRate); err != nil {
+		return errors.Wrap(err, "push cpu profile")
+	}
+
+	runtime.GC() // Heap profiles report statistics as of the most recently completed garbage collection.
+
+	var heap bytes.Buffer
+	if err := rpprof.Lookup("heap").WriteTo(&heap, 0); err != nil {
+		return errors.Wrap(err, "write heap profile")
+	}
+
+	if err := p.push(ctx, heap.Bytes(), from, until, 0); err != nil {
+		return errors.Wrap(err, "push heap profile")
+	}
+
+	return nil
+}
+
+// push posts the pprof encoded profile to the Pyroscope ingest API.
+func (p *Pusher) push(ctx context.Context, profile []byte, from, until time.Time, sampleRate int) error {
+	query := url.Values{
+		"name":    {p.name},
+		"from":    {strconv.FormatInt(from.Unix(), 10)},
+		"until":   {strconv.FormatInt(until.Unix(), 10)},
+		"format":  {"pprof"},
+		"spyName": {"gospy"},
+	}
+	if sampleRate > 0 {
+		query.Set("sampleRate", strconv.Itoa(sampleRate))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.addr+"/ingest?"+query.Encode(), bytes.NewReader(profile))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "post profile")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return errors.New("profiling server returned error", z.Int("status", resp.StatusCode))
+	}
+
+	return nil
+}
+
+// appName returns the Pyroscope application name with sorted labels, e.g. "charon{cluster_peer=foo}".
+func appName(service string, labels map[string]string) string {
+	var pairs []string
+	for k, v := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(pairs)
+
+	return service + "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,69 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package profiling
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppName(t *testing.T) {
+	require.Equal(t, "charon{}", appName("charon", nil))
+	require.Equal(t, "charon{a=1,b=2}", appName("charon", map[string]string{"b": "2", "a": "1"}))
+}
+
+func TestPusher(t *testing.T) {
+	type ingest struct {
+		Name       string
+		SampleRate string
+		Size       int
+	}
+	ingests := make(chan ingest, 2)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ingest", r.URL.Path)
+		require.Equal(t, "pprof", r.URL.Query().Get("format"))
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		ingests <- ingest{
+			Name:       r.URL.Query().Get("name"),
+			SampleRate: r.URL.Query().Get("sampleRate"),
+			Size:       len(b),
+		}
+	}))
+	defer srv.Close()
+
+	pusher := NewPusher(srv.URL+"/", "charon", map[string]string{"cluster_peer": "foo"}, time.Millisecond*10)
+	require.NoError(t, pusher.profile(context.Background()))
+
+	cpu := <-ingests
+	require.Equal(t, "charon{cluster_peer=foo}", cpu.Name)
+	require.Equal(t, "100", cpu.SampleRate)
+	require.Positive(t, cpu.Size)
+
+	heap := <-ingests
+	require.Equal(t, "charon{cluster_peer=foo}", heap.Name)
+	require.Empty(t, heap.SampleRate)
+	require.Positive(t, heap.Size)
+}
+
+func TestRegisterHandlers(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterHandlers(mux)
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/debug/pprof/cmdline")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -50,6 +50,14 @@ func GetMetasForT(t *testing.T) []Meta {
 	return metas
 }
 
+// Metas returns the metadata of all metrics registered with promauto.
+func Metas() []Meta {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return append([]Meta(nil), metas...)
+}
+
 // NewRegistry returns a new registry containing all promauto created metrics and
 // built-in Go process metrics wrapping everything with the provided labels.
 func NewRegistry(labels prometheus.Labels) (*prometheus.Registry, error) {
@@ -0,0 +1,93 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+var feeRecipientRegex = regexp.MustCompile("^0x[0-9a-fA-F]{40}$")
+
+// proposerConfigFile is the JSON proposer config file overriding the fee recipients of the cluster lock,
+// similar to validator client proposer config files.
+type proposerConfigFile struct {
+	ProposerConfig map[string]proposerOptions `json:"proposer_config"`
+	DefaultConfig  *proposerOptions           `json:"default_config"`
+}
+
+type proposerOptions struct {
+	FeeRecipient string `json:"fee_recipient"`
+}
+
+// proposerConfig contains the fee recipient overrides of a proposer config file.
+type proposerConfig struct {
+	feeRecipients       map[core.PubKey]string
+	defaultFeeRecipient string
+}
+
+// FeeRecipient returns the fee recipient of the validator: the validator override, else the default override,
+// else the cluster lock fee recipient.
+func (c proposerConfig) FeeRecipient(pubkey core.PubKey, lockFeeRecipient string) string {
+	if feeRecipient, ok := c.feeRecipients[pubkey]; ok {
+		return feeRecipient
+	} else if c.defaultFeeRecipient != "" {
+		return c.defaultFeeRecipient
+	}
+
+	return lockFeeRecipient
+}
+
+// loadProposerConfig returns the proposer config of the JSON file, keyed by validator (root) public key.
+func loadProposerConfig(file string) (proposerConfig, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return proposerConfig{}, errors.Wrap(err, "read proposer config file", z.Str("path", file))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	var raw proposerConfigFile
+	if err := dec.Decode(&raw); err != nil {
+		return proposerConfig{}, errors.Wrap(err, "unmarshal proposer config file", z.Str("path", file))
+	}
+
+	resp := proposerConfig{feeRecipients: make(map[core.PubKey]string)}
+
+	if raw.DefaultConfig != nil {
+		if !feeRecipientRegex.MatchString(raw.DefaultConfig.FeeRecipient) {
+			return proposerConfig{}, errors.New("invalid default fee recipient", z.Str("fee_recipient", raw.DefaultConfig.FeeRecipient))
+		}
+
+		resp.defaultFeeRecipient = raw.DefaultConfig.FeeRecipient
+	}
+
+	for pubkeyHex, opts := range raw.ProposerConfig {
+		b, err := hex.DecodeString(strings.TrimPrefix(pubkeyHex, "0x"))
+		if err != nil {
+			return proposerConfig{}, errors.Wrap(err, "decode proposer config public key", z.Redact(z.Str("pubkey", pubkeyHex)))
+		}
+
+		pubkey, err := core.PubKeyFromBytes(b)
+		if err != nil {
+			return proposerConfig{}, errors.Wrap(err, "invalid proposer config public key", z.Redact(z.Str("pubkey", pubkeyHex)))
+		}
+
+		if !feeRecipientRegex.MatchString(opts.FeeRecipient) {
+			return proposerConfig{}, errors.New("invalid fee recipient", z.Redact(z.Str("pubkey", pubkeyHex)), z.Str("fee_recipient", opts.FeeRecipient))
+		}
+
+		resp.feeRecipients[pubkey] = opts.FeeRecipient
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,361 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/manifestwatch"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/bcast"
+	"github.com/obolnetwork/charon/core/fetcher"
+	"github.com/obolnetwork/charon/core/validatorapi"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+// validatorSet contains the public keys, public shares and fee recipients of the cluster validators.
+type validatorSet struct {
+	corePubkeys       []core.PubKey
+	eth2Pubkeys       []eth2p0.BLSPubKey
+	allPubSharesByKey map[core.PubKey]map[int]tbls.PublicKey // map[pubkey]map[shareIdx]pubshare
+	feeRecipients     map[core.PubKey]string
+	validators        []*manifestpb.Validator
+}
+
+// newValidatorSet returns the validator set of the provided cluster validators.
+func newValidatorSet(vals []*manifestpb.Validator) (*validatorSet, error) {
+	resp := &validatorSet{
+		allPubSharesByKey: make(map[core.PubKey]map[int]tbls.PublicKey),
+		feeRecipients:     make(map[core.PubKey]string),
+		validators:        vals,
+	}
+	for _, val := range vals {
+		pubkey, err := manifest.ValidatorPublicKey(val)
+		if err != nil {
+			return nil, err
+		}
+
+		corePubkey, err := core.PubKeyFromBytes(pubkey[:])
+		if err != nil {
+			return nil, err
+		}
+
+		allPubShares := make(map[int]tbls.PublicKey)
+		for i, b := range val.GetPubShares() {
+			pubshare, err := tblsconv.PubkeyFromBytes(b)
+			if err != nil {
+				return nil, err
+			}
+
+			// share index is 1-indexed
+			allPubShares[i+1] = pubshare
+		}
+
+		resp.eth2Pubkeys = append(resp.eth2Pubkeys, eth2p0.BLSPubKey(pubkey))
+		resp.corePubkeys = append(resp.corePubkeys, corePubkey)
+		resp.allPubSharesByKey[corePubkey] = allPubShares
+		resp.feeRecipients[corePubkey] = val.GetFeeRecipientAddress()
+	}
+
+	return resp, nil
+}
+
+// wireManifestReload subscribes the components depending on the cluster validators to cluster manifest reloads.
+func wireManifestReload(watcher *manifestwatch.Watcher, eth2Cl eth2wrap.Client,
+	valSet *atomic.Pointer[validatorSet], valCache *eth2wrap.ValidatorCache, vapi *validatorapi.Component,
+	recaster *bcast.Recaster, builderEnabled func() bool,
+) {
+	watcher.Subscribe(func(ctx context.Context, cluster *manifestpb.Cluster) error {
+		prevVals := len(valSet.Load().corePubkeys)
+
+		next, err := newValidatorSet(cluster.GetValidators())
+		if err != nil {
+			return err
+		}
+
+		if err := vapi.SetPubShares(next.allPubSharesByKey); err != nil {
+			return errors.Wrap(err, "set validator api public shares")
+		}
+
+		valCache.SetPubKeys(next.eth2Pubkeys)
+		valSet.Store(next)
+		validatorsGauge.Set(float64(len(next.corePubkeys)))
+
+		if builderEnabled() {
+			// Newer registrations replace existing ones, older ones are ignored.
+			if err := storeBuilderRegistrations(ctx, eth2Cl, recaster, cluster.GetValidators()); err != nil {
+				return err
+			}
+		}
+
+		log.Info(ctx, "Reloaded cluster validators",
+			z.Int("validators", len(next.corePubkeys)),
+			z.Int("added", len(next.corePubkeys)-prevVals),
+		)
+
+		return nil
+	})
+}
+
+// configReloadPeriod is the period the runtime config and proposer config file are polled for changes.
+const configReloadPeriod = 10 * time.Second
+
+// RuntimeConfig defines the config that is hot reloaded without restarting the node.
+type RuntimeConfig struct {
+	LogLevel                string
+	BeaconNodeAddrs         []string
+	FallbackBeaconNodeAddrs []string
+	BuilderAPI              bool
+	ProposerConfigFile      string
+}
+
+// RuntimeConfig returns the hot reloadable subset of the config.
+func (c Config) RuntimeConfig() RuntimeConfig {
+	return RuntimeConfig{
+		LogLevel:                c.Log.Level,
+		BeaconNodeAddrs:         c.BeaconNodeAddrs,
+		FallbackBeaconNodeAddrs: c.FallbackBeaconNodeAddrs,
+		BuilderAPI:              c.BuilderAPI,
+		ProposerConfigFile:      c.ProposerConfigFile,
+	}
+}
+
+// newConfigReloader returns a new config reloader of the config, loading the proposer config file if configured.
+func newConfigReloader(conf Config) (*configReloader, error) {
+	r := &configReloader{
+		load:           conf.ReloadConfig,
+		current:        conf.RuntimeConfig(),
+		proposerConfig: new(atomic.Pointer[proposerConfig]),
+		trigger:        make(chan struct{}, 1),
+	}
+	r.proposerConfig.Store(new(proposerConfig))
+
+	if conf.ProposerConfigFile != "" {
+		pc, err := loadProposerConfig(conf.ProposerConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		r.proposerConfig.Store(&pc)
+	}
+
+	return r, nil
+}
+
+// configReloader hot reloads the runtime config on SIGHUP, on trigger and periodically, notifying subscribers of changes.
+type configReloader struct {
+	load           func() (RuntimeConfig, error)
+	mu             sync.Mutex // Protects current, which is only updated by Run.
+	current        RuntimeConfig
+	proposerConfig *atomic.Pointer[proposerConfig]
+	subs           []func(ctx context.Context, prev, next RuntimeConfig) error
+	trigger        chan struct{}
+}
+
+// Subscribe registers a function called with the previous and next runtime config when it changes.
+// Note this should be called *before* Run.
+func (r *configReloader) Subscribe(fn func(ctx context.Context, prev, next RuntimeConfig) error) {
+	r.subs = append(r.subs, fn)
+}
+
+// FeeRecipient returns the fee recipient of the validator, applying the proposer config overrides to the cluster lock fee recipient.
+func (r *configReloader) FeeRecipient(pubkey core.PubKey, lockFeeRecipient string) string {
+	return r.proposerConfig.Load().FeeRecipient(pubkey, lockFeeRecipient)
+}
+
+// Current returns the current runtime config.
+func (r *configReloader) Current() RuntimeConfig {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.current
+}
+
+// Trigger requests Run to reload the runtime config, e.g. via the admin API. It doesn't block if a reload is already pending.
+func (r *configReloader) Trigger() {
+	select {
+	case r.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Run blocks and reloads the runtime config on SIGHUP, on trigger and every period until the context is closed.
+func (r *configReloader) Run(ctx context.Context) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	ticker := time.NewTicker(configReloadPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			log.Info(ctx, "Reloading config on SIGHUP")
+			r.reload(ctx)
+		case <-r.trigger:
+			log.Info(ctx, "Reloading config on admin API request")
+			r.reload(ctx)
+		case <-ticker.C:
+			r.reload(ctx)
+		}
+	}
+}
+
+// reload reloads the runtime config and proposer config file, notifying subscribers if the runtime config changed.
+// Invalid config is ignored, retaining the previous config.
+func (r *configReloader) reload(ctx context.Context) {
+	next := r.current
+	if r.load != nil {
+		var err error
+		next, err = r.load()
+		if err != nil {
+			log.Warn(ctx, "Ignoring invalid reloaded config", err)
+			configReloadCounter.WithLabelValues("error").Inc()
+
+			return
+		}
+	}
+
+	if err := r.reloadProposerConfig(ctx, next.ProposerConfigFile); err != nil {
+		log.Warn(ctx, "Ignoring invalid reloaded proposer config file", err)
+		configReloadCounter.WithLabelValues("error").Inc()
+
+		return
+	}
+
+	if reflect.DeepEqual(r.current, next) {
+		return
+	}
+
+	prev := r.current
+	r.mu.Lock()
+	r.current = next
+	r.mu.Unlock()
+
+	result := "success"
+	for _, sub := range r.subs {
+		if err := sub(ctx, prev, next); err != nil {
+			log.Warn(ctx, "Failed applying reloaded config", err)
+			result = "error"
+		}
+	}
+	configReloadCounter.WithLabelValues(result).Inc()
+
+	log.Info(ctx, "Reloaded runtime config",
+		z.Str("log_level", next.LogLevel),
+		z.Any("beacon_node_endpoints", redactURLs(next.BeaconNodeAddrs)),
+		z.Any("fallback_beacon_node_endpoints", redactURLs(next.FallbackBeaconNodeAddrs)),
+		z.Bool("builder_api", next.BuilderAPI),
+		z.Str("proposer_config_file", next.ProposerConfigFile),
+	)
+}
+
+// reloadProposerConfig reloads the proposer config file, or clears the overrides if the file is empty.
+func (r *configReloader) reloadProposerConfig(ctx context.Context, file string) error {
+	next := new(proposerConfig)
+	if file != "" {
+		pc, err := loadProposerConfig(file)
+		if err != nil {
+			return err
+		}
+		next = &pc
+	}
+
+	if reflect.DeepEqual(r.proposerConfig.Load(), next) {
+		return nil
+	}
+
+	r.proposerConfig.Store(next)
+	log.Info(ctx, "Reloaded proposer config fee recipients",
+		z.Int("validators", len(next.feeRecipients)),
+		z.Bool("default", next.defaultFeeRecipient != ""))
+
+	return nil
+}
+
+// reloadLogLevel is a config reloader subscriber that sets the log level.
+func reloadLogLevel(_ context.Context, prev, next RuntimeConfig) error {
+	if prev.LogLevel == next.LogLevel {
+		return nil
+	}
+
+	return log.SetLevel(next.LogLevel)
+}
+
+// reloadBeaconNodes returns a config reloader subscriber that replaces the beacon node endpoints of the eth2 clients.
+func reloadBeaconNodes(conf Config, forkVersion []byte, eth2Cl, submissionEth2Cl eth2wrap.Client) func(context.Context, RuntimeConfig, RuntimeConfig) error {
+	return func(_ context.Context, prev, next RuntimeConfig) error {
+		if slices.Equal(prev.BeaconNodeAddrs, next.BeaconNodeAddrs) &&
+			slices.Equal(prev.FallbackBeaconNodeAddrs, next.FallbackBeaconNodeAddrs) {
+			return nil
+		}
+
+		headers, err := eth2util.ParseBeaconNodeHeaders(conf.BeaconNodeHeaders)
+		if err != nil {
+			return err
+		}
+
+		err = eth2wrap.SetBeaconNodes(eth2Cl, conf.BeaconNodeTimeout, [4]byte(forkVersion), headers, next.BeaconNodeAddrs, next.FallbackBeaconNodeAddrs)
+		if err != nil {
+			return errors.Wrap(err, "set beacon nodes")
+		}
+
+		err = eth2wrap.SetBeaconNodes(submissionEth2Cl, conf.BeaconNodeSubmitTimeout, [4]byte(forkVersion), headers, next.BeaconNodeAddrs, next.FallbackBeaconNodeAddrs)
+		if err != nil {
+			return errors.Wrap(err, "set submission beacon nodes")
+		}
+
+		return nil
+	}
+}
+
+// reloadBuilderAPI returns a config reloader subscriber that enables or disables the builder API.
+// The pre-generated builder registrations of the current validators are stored in the recaster when enabled.
+func reloadBuilderAPI(eth2Cl eth2wrap.Client, fetch *fetcher.Fetcher, vapi *validatorapi.Component,
+	recaster *bcast.Recaster, valSet *atomic.Pointer[validatorSet],
+) func(context.Context, RuntimeConfig, RuntimeConfig) error {
+	return func(ctx context.Context, prev, next RuntimeConfig) error {
+		if prev.BuilderAPI == next.BuilderAPI {
+			return nil
+		}
+
+		fetch.SetBuilderEnabled(next.BuilderAPI)
+		vapi.SetBuilderEnabled(next.BuilderAPI)
+
+		if !next.BuilderAPI {
+			return nil
+		}
+
+		return storeBuilderRegistrations(ctx, eth2Cl, recaster, valSet.Load().validators)
+	}
+}
+
+// redactURLs returns the URLs with any passwords redacted.
+func redactURLs(vals []string) []string {
+	var resp []string
+	for _, val := range vals {
+		resp = append(resp, redactURL(val))
+	}
+
+	return resp
+}
@@ -0,0 +1,130 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+const (
+	lockFeeRecipient    = "0x0000000000000000000000000000000000000001"
+	defaultFeeRecipient = "0x0000000000000000000000000000000000000002"
+	valFeeRecipient     = "0x0000000000000000000000000000000000000003"
+)
+
+func TestLoadProposerConfig(t *testing.T) {
+	pubkey := testutil.RandomCorePubKey(t)
+	other := testutil.RandomCorePubKey(t)
+
+	tests := []struct {
+		Name     string
+		JSON     string
+		ErrorMsg string
+	}{
+		{
+			Name: "valid",
+			JSON: `{"proposer_config": {"` + string(pubkey) + `": {"fee_recipient": "` + valFeeRecipient + `"}}, "default_config": {"fee_recipient": "` + defaultFeeRecipient + `"}}`,
+		},
+		{
+			Name:     "unknown field",
+			JSON:     `{"default_config": {"fee_recipient": "` + defaultFeeRecipient + `", "gas_limit": 1}}`,
+			ErrorMsg: "unmarshal proposer config file",
+		},
+		{
+			Name:     "invalid default fee recipient",
+			JSON:     `{"default_config": {"fee_recipient": "0x01"}}`,
+			ErrorMsg: "invalid default fee recipient",
+		},
+		{
+			Name:     "invalid public key",
+			JSON:     `{"proposer_config": {"0x01": {"fee_recipient": "` + valFeeRecipient + `"}}}`,
+			ErrorMsg: "invalid proposer config public key",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "proposer-config.json")
+			require.NoError(t, os.WriteFile(file, []byte(test.JSON), 0o644))
+
+			pc, err := loadProposerConfig(file)
+			if test.ErrorMsg != "" {
+				require.ErrorContains(t, err, test.ErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, valFeeRecipient, pc.FeeRecipient(pubkey, lockFeeRecipient))
+			require.Equal(t, defaultFeeRecipient, pc.FeeRecipient(other, lockFeeRecipient))
+			require.Equal(t, lockFeeRecipient, proposerConfig{}.FeeRecipient(other, lockFeeRecipient))
+		})
+	}
+}
+
+func TestConfigReloader(t *testing.T) {
+	ctx := context.Background()
+	pubkey := testutil.RandomCorePubKey(t)
+
+	file := filepath.Join(t.TempDir(), "proposer-config.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"default_config": {"fee_recipient": "`+defaultFeeRecipient+`"}}`), 0o644))
+
+	var (
+		next    = RuntimeConfig{LogLevel: "info", BeaconNodeAddrs: []string{"http://bn1"}}
+		loadErr error
+	)
+
+	reloader, err := newConfigReloader(Config{
+		Log:             log.Config{Level: "info"},
+		BeaconNodeAddrs: []string{"http://bn1"},
+		ReloadConfig: func() (RuntimeConfig, error) {
+			return next, loadErr
+		},
+	})
+	require.NoError(t, err)
+
+	var notified []RuntimeConfig
+	reloader.Subscribe(func(_ context.Context, _, next RuntimeConfig) error {
+		notified = append(notified, next)
+		return nil
+	})
+
+	// Unchanged config doesn't notify subscribers.
+	reloader.reload(ctx)
+	require.Empty(t, notified)
+	require.Equal(t, lockFeeRecipient, reloader.FeeRecipient(pubkey, lockFeeRecipient))
+
+	// Changed config notifies subscribers and loads the proposer config.
+	next = RuntimeConfig{LogLevel: "debug", BeaconNodeAddrs: []string{"http://bn2"}, BuilderAPI: true, ProposerConfigFile: file}
+	reloader.reload(ctx)
+	require.Equal(t, []RuntimeConfig{next}, notified)
+	require.Equal(t, defaultFeeRecipient, reloader.FeeRecipient(pubkey, lockFeeRecipient))
+
+	// Proposer config file changes are picked up without runtime config changes.
+	require.NoError(t, os.WriteFile(file, []byte(`{"proposer_config": {"`+string(pubkey)+`": {"fee_recipient": "`+valFeeRecipient+`"}}}`), 0o644))
+	reloader.reload(ctx)
+	require.Len(t, notified, 1)
+	require.Equal(t, valFeeRecipient, reloader.FeeRecipient(pubkey, lockFeeRecipient))
+
+	// Invalid config is ignored.
+	prev := next
+	next, loadErr = RuntimeConfig{LogLevel: "error"}, errors.New("invalid config")
+	reloader.reload(ctx)
+	require.Len(t, notified, 1)
+	require.Equal(t, prev, reloader.current)
+
+	// Invalid proposer config is ignored.
+	require.NoError(t, os.WriteFile(file, []byte(`{`), 0o644))
+	next, loadErr = RuntimeConfig{LogLevel: "error", ProposerConfigFile: file}, nil
+	reloader.reload(ctx)
+	require.Len(t, notified, 1)
+	require.Equal(t, valFeeRecipient, reloader.FeeRecipient(pubkey, lockFeeRecipient))
+}
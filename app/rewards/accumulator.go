@@ -0,0 +1,71 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package rewards
+
+import (
+	"sort"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// newAccumulator returns a new empty accumulator.
+func newAccumulator() *accumulator {
+	return &accumulator{
+		validators: make(map[eth2p0.ValidatorIndex]*Validator),
+	}
+}
+
+// accumulator sums the rewards of the validators over the processed epochs. It is not thread safe.
+type accumulator struct {
+	started    bool
+	fromEpoch  eth2p0.Epoch
+	toEpoch    eth2p0.Epoch
+	validators map[eth2p0.ValidatorIndex]*Validator
+	updatedAt  time.Time
+}
+
+// Epoch extends the epoch range to include the epoch.
+func (a *accumulator) Epoch(epoch eth2p0.Epoch) {
+	if !a.started {
+		a.started = true
+		a.fromEpoch = epoch
+	}
+	a.toEpoch = epoch
+	a.updatedAt = time.Now()
+}
+
+// Validator returns the rewards of the validator, creating it if not present.
+func (a *accumulator) Validator(index eth2p0.ValidatorIndex, pubkey eth2p0.BLSPubKey) *Validator {
+	val, ok := a.validators[index]
+	if !ok {
+		val = &Validator{PubKey: pubkey.String(), Index: uint64(index)}
+		a.validators[index] = val
+	}
+
+	return val
+}
+
+// Empty returns true if no epochs were processed.
+func (a *accumulator) Empty() bool {
+	return !a.started
+}
+
+// Report returns the report of the accumulated rewards ordered by validator index.
+func (a *accumulator) Report() Report {
+	resp := Report{
+		FromEpoch:  uint64(a.fromEpoch),
+		ToEpoch:    uint64(a.toEpoch),
+		Validators: []Validator{},
+		UpdatedAt:  a.updatedAt,
+	}
+	for _, val := range a.validators {
+		resp.Validators = append(resp.Validators, *val)
+	}
+
+	sort.Slice(resp.Validators, func(i, j int) bool {
+		return resp.Validators[i].Index < resp.Validators[j].Index
+	})
+
+	return resp
+}
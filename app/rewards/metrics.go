@@ -0,0 +1,42 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package rewards
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	earnedGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "rewards",
+		Name:      "earned_gwei",
+		Help:      "Attestation and proposal rewards in gwei earned by the validator since startup, penalties are negative",
+	}, []string{"pubkey"})
+
+	missedGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "rewards",
+		Name:      "missed_gwei",
+		Help:      "Estimated attestation and proposal rewards in gwei missed by the validator since startup",
+	}, []string{"pubkey"})
+
+	epochGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "rewards",
+		Name:      "epoch",
+		Help:      "Last epoch of which validator rewards were processed",
+	})
+)
+
+// instrumentReport sets the metrics of the report.
+func instrumentReport(report Report) {
+	for _, val := range report.Validators {
+		earnedGauge.WithLabelValues(val.PubKey).Set(float64(val.EarnedGwei()))
+		missedGauge.WithLabelValues(val.PubKey).Set(float64(val.MissedGwei()))
+	}
+
+	epochGauge.Set(float64(report.ToEpoch))
+}
@@ -0,0 +1,384 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package rewards computes the attestation and proposal rewards of the cluster validators per epoch from
+// beacon node data and estimates the rewards they missed, allowing operators to split rewards and quantify
+// underperformance. The rewards are served via the monitoring API and written to periodic CSV and JSON reports.
+package rewards
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// pollPeriod is the period at which new epochs are processed.
+	pollPeriod = time.Minute
+	// epochLag is the number of epochs the processed epoch lags the current epoch, since attestation rewards
+	// of an epoch are only available once the following epoch is complete.
+	epochLag = 2
+)
+
+// Validator is the rewards of a cluster validator in gwei over a range of epochs. Penalties are negative rewards.
+type Validator struct {
+	PubKey                string `json:"pubkey"`
+	Index                 uint64 `json:"index"`
+	AttestationGwei       int64  `json:"attestation_gwei"`
+	MissedAttestationGwei int64  `json:"missed_attestation_gwei"`
+	Proposals             int    `json:"proposals"`
+	ProposalGwei          int64  `json:"proposal_gwei"`
+	MissedProposals       int    `json:"missed_proposals"`
+	// MissedProposalGwei is estimated as the average reward of the cluster's proposals.
+	MissedProposalGwei int64 `json:"missed_proposal_gwei"`
+}
+
+// EarnedGwei returns the total rewards earned by the validator.
+func (v Validator) EarnedGwei() int64 {
+	return v.AttestationGwei + v.ProposalGwei
+}
+
+// MissedGwei returns the total estimated rewards missed by the validator.
+func (v Validator) MissedGwei() int64 {
+	return v.MissedAttestationGwei + v.MissedProposalGwei
+}
+
+// Report is the rewards of the cluster validators over the inclusive epoch range.
+type Report struct {
+	FromEpoch  uint64      `json:"from_epoch"`
+	ToEpoch    uint64      `json:"to_epoch"`
+	Validators []Validator `json:"validators"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// WriteCSV writes the report as CSV with a header row and a row per validator.
+func (r Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	rows := [][]string{{
+		"from_epoch", "to_epoch", "pubkey", "index", "attestation_gwei", "missed_attestation_gwei",
+		"proposals", "proposal_gwei", "missed_proposals", "missed_proposal_gwei", "earned_gwei", "missed_gwei",
+	}}
+	for _, val := range r.Validators {
+		rows = append(rows, []string{
+			strconv.FormatUint(r.FromEpoch, 10),
+			strconv.FormatUint(r.ToEpoch, 10),
+			val.PubKey,
+			strconv.FormatUint(val.Index, 10),
+			strconv.FormatInt(val.AttestationGwei, 10),
+			strconv.FormatInt(val.MissedAttestationGwei, 10),
+			strconv.Itoa(val.Proposals),
+			strconv.FormatInt(val.ProposalGwei, 10),
+			strconv.Itoa(val.MissedProposals),
+			strconv.FormatInt(val.MissedProposalGwei, 10),
+			strconv.FormatInt(val.EarnedGwei(), 10),
+			strconv.FormatInt(val.MissedGwei(), 10),
+		})
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return errors.Wrap(err, "write csv")
+	}
+
+	return nil
+}
+
+// New returns a new rewards tracker writing reports of each period to the report directory if not empty.
+func New(eth2Cl eth2wrap.Client, reportDir string, reportPeriod time.Duration) *Tracker {
+	return &Tracker{
+		eth2Cl:       eth2Cl,
+		reportDir:    reportDir,
+		reportPeriod: reportPeriod,
+		total:        newAccumulator(),
+		period:       newAccumulator(),
+	}
+}
+
+// Tracker tracks the rewards of the cluster validators since startup.
+type Tracker struct {
+	eth2Cl       eth2wrap.Client
+	reportDir    string
+	reportPeriod time.Duration
+
+	mu        sync.Mutex
+	started   bool
+	nextEpoch eth2p0.Epoch
+	total     *accumulator
+	period    *accumulator
+	// proposalSum and proposalCount of the cluster's proposals estimate missed proposal rewards.
+	proposalSum   int64
+	proposalCount int64
+}
+
+// Run processes completed epochs and writes periodic reports until the context is cancelled.
+func (t *Tracker) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "rewards")
+
+	ticker := time.NewTicker(pollPeriod)
+	defer ticker.Stop()
+
+	lastReport := time.Now()
+
+	for {
+		if err := t.update(ctx); err != nil && ctx.Err() == nil {
+			log.Warn(ctx, "Failed updating validator rewards", err)
+		}
+
+		if t.reportDir != "" && time.Since(lastReport) >= t.reportPeriod {
+			if err := t.writeReport(); err != nil {
+				log.Warn(ctx, "Failed writing validator rewards report", err)
+			}
+			lastReport = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Report returns the rewards of the cluster validators since startup.
+func (t *Tracker) Report() Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.total.Report()
+}
+
+// update processes all completed epochs not processed yet, starting at the latest completed epoch on startup.
+func (t *Tracker) update(ctx context.Context) error {
+	genesis, err := t.eth2Cl.GenesisTime(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetch genesis time")
+	}
+
+	slotDuration, err := t.eth2Cl.SlotDuration(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetch slot duration")
+	}
+
+	slotsPerEpoch, err := t.eth2Cl.SlotsPerEpoch(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetch slots per epoch")
+	}
+
+	currentEpoch := uint64(time.Since(genesis)/slotDuration) / slotsPerEpoch
+	if currentEpoch < epochLag {
+		return nil
+	}
+	target := eth2p0.Epoch(currentEpoch - epochLag)
+
+	t.mu.Lock()
+	if !t.started {
+		t.started = true
+		t.nextEpoch = target
+	}
+	next := t.nextEpoch
+	t.mu.Unlock()
+
+	for epoch := next; epoch <= target; epoch++ {
+		if err := t.processEpoch(ctx, epoch, slotsPerEpoch); err != nil {
+			return errors.Wrap(err, "process epoch", z.U64("epoch", uint64(epoch)))
+		}
+	}
+
+	return nil
+}
+
+// processEpoch adds the attestation and proposal rewards of the active cluster validators in the epoch.
+func (t *Tracker) processEpoch(ctx context.Context, epoch eth2p0.Epoch, slotsPerEpoch uint64) error {
+	vals, err := t.eth2Cl.CompleteValidators(ctx)
+	if err != nil {
+		return err
+	}
+
+	var indices []eth2p0.ValidatorIndex
+	for index, val := range vals {
+		if val != nil && val.Validator != nil && val.Status.IsActive() {
+			indices = append(indices, index)
+		}
+	}
+
+	if len(indices) == 0 {
+		t.mu.Lock()
+		t.nextEpoch = epoch + 1
+		t.mu.Unlock()
+
+		return nil
+	}
+
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	attRewards, err := t.eth2Cl.AttestationRewards(ctx, epoch, indices)
+	if err != nil {
+		return err
+	}
+
+	ideals := make(map[eth2p0.Gwei]int64)
+	for _, ideal := range attRewards.IdealRewards {
+		ideals[ideal.EffectiveBalance] = ideal.Head + ideal.Target + ideal.Source + ideal.Inactivity
+	}
+
+	eth2Resp, err := t.eth2Cl.ProposerDuties(ctx, &eth2api.ProposerDutiesOpts{
+		Epoch:   epoch,
+		Indices: indices,
+	})
+	if err != nil {
+		return errors.Wrap(err, "fetch proposer duties")
+	}
+
+	type proposal struct {
+		index  eth2p0.ValidatorIndex
+		reward int64
+		missed bool
+	}
+
+	var proposals []proposal
+	for _, duty := range eth2Resp.Data {
+		if duty == nil || uint64(duty.Slot)/slotsPerEpoch != uint64(epoch) {
+			continue
+		} else if val, ok := vals[duty.ValidatorIndex]; !ok || val == nil || val.Validator == nil {
+			continue // Not a cluster validator.
+		}
+
+		block, err := t.eth2Cl.BlockRewards(ctx, strconv.FormatUint(uint64(duty.Slot), 10))
+		if err != nil {
+			return err
+		}
+
+		if block == nil || block.ProposerIndex != duty.ValidatorIndex {
+			proposals = append(proposals, proposal{index: duty.ValidatorIndex, missed: true})
+			continue
+		}
+
+		proposals = append(proposals, proposal{index: duty.ValidatorIndex, reward: block.Total})
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.nextEpoch > epoch {
+		return nil // Already processed.
+	}
+
+	for _, acc := range []*accumulator{t.total, t.period} {
+		acc.Epoch(epoch)
+	}
+
+	for _, reward := range attRewards.TotalRewards {
+		val, ok := vals[reward.ValidatorIndex]
+		if !ok || val == nil || val.Validator == nil {
+			continue
+		}
+
+		actual := reward.Head + reward.Target + reward.Source + reward.Inactivity
+		missed := max(0, ideals[val.Validator.EffectiveBalance]-actual)
+
+		for _, acc := range []*accumulator{t.total, t.period} {
+			v := acc.Validator(reward.ValidatorIndex, val.Validator.PublicKey)
+			v.AttestationGwei += actual
+			v.MissedAttestationGwei += missed
+		}
+	}
+
+	for _, p := range proposals {
+		if p.missed {
+			continue
+		}
+
+		t.proposalSum += p.reward
+		t.proposalCount++
+
+		for _, acc := range []*accumulator{t.total, t.period} {
+			v := acc.Validator(p.index, vals[p.index].Validator.PublicKey)
+			v.Proposals++
+			v.ProposalGwei += p.reward
+		}
+	}
+
+	for _, p := range proposals {
+		if !p.missed {
+			continue
+		}
+
+		var estimate int64
+		if t.proposalCount > 0 {
+			estimate = t.proposalSum / t.proposalCount
+		}
+
+		log.Warn(ctx, "Missed block proposal", nil, z.U64("epoch", uint64(epoch)),
+			z.U64("validator_index", uint64(p.index)), z.I64("estimated_reward_gwei", estimate))
+
+		for _, acc := range []*accumulator{t.total, t.period} {
+			v := acc.Validator(p.index, vals[p.index].Validator.PublicKey)
+			v.MissedProposals++
+			v.MissedProposalGwei += estimate
+		}
+	}
+
+	t.nextEpoch = epoch + 1
+	instrumentReport(t.total.Report())
+
+	return nil
+}
+
+// writeReport writes the rewards of the current period as JSON and CSV files to the report directory
+// and starts a new period on success.
+func (t *Tracker) writeReport() error {
+	t.mu.Lock()
+	report := t.period.Report()
+	empty := t.period.Empty()
+	t.mu.Unlock()
+
+	if empty {
+		return nil
+	}
+
+	if err := os.MkdirAll(t.reportDir, 0o755); err != nil {
+		return errors.Wrap(err, "create report dir")
+	}
+
+	base := filepath.Join(t.reportDir, fmt.Sprintf("rewards-%d-%d", report.FromEpoch, report.ToEpoch))
+
+	b, err := json.MarshalIndent(report, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal report")
+	}
+
+	if err := os.WriteFile(base+".json", b, 0o644); err != nil { //nolint:gosec // Not a secret.
+		return errors.Wrap(err, "write json report")
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(base+".csv", buf.Bytes(), 0o644); err != nil { //nolint:gosec // Not a secret.
+		return errors.Wrap(err, "write csv report")
+	}
+
+	t.mu.Lock()
+	t.period = newAccumulator()
+	t.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package rewards
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		slotsPerEpoch = 32
+		epoch         = 10
+	)
+
+	var pubkey1, pubkey2 eth2p0.BLSPubKey
+	pubkey1[0], pubkey2[0] = 1, 2
+
+	bmock, err := beaconmock.New(beaconmock.WithValidatorSet(beaconmock.ValidatorSet{
+		1: {Index: 1, Status: eth2v1.ValidatorStateActiveOngoing, Validator: &eth2p0.Validator{PublicKey: pubkey1, EffectiveBalance: 32e9}},
+		2: {Index: 2, Status: eth2v1.ValidatorStateActiveOngoing, Validator: &eth2p0.Validator{PublicKey: pubkey2, EffectiveBalance: 32e9}},
+	}))
+	require.NoError(t, err)
+
+	bmock.AttestationRewardsFunc = func(_ context.Context, e eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (*eth2wrap.AttestationRewards, error) {
+		require.EqualValues(t, epoch, e)
+		require.Equal(t, []eth2p0.ValidatorIndex{1, 2}, indices)
+
+		return &eth2wrap.AttestationRewards{
+			IdealRewards: []eth2wrap.IdealAttestationReward{{EffectiveBalance: 32e9, Head: 2000, Target: 4000, Source: 2000}},
+			TotalRewards: []eth2wrap.AttestationReward{
+				{ValidatorIndex: 1, Head: 2000, Target: 4000, Source: 2000},
+				{ValidatorIndex: 2, Target: -4000, Source: -2000},
+			},
+		}, nil
+	}
+	bmock.ProposerDutiesFunc = func(context.Context, eth2p0.Epoch, []eth2p0.ValidatorIndex) ([]*eth2v1.ProposerDuty, error) {
+		return []*eth2v1.ProposerDuty{
+			{ValidatorIndex: 1, Slot: epoch*slotsPerEpoch + 1},
+			{ValidatorIndex: 2, Slot: epoch*slotsPerEpoch + 2},
+			{ValidatorIndex: 3, Slot: epoch*slotsPerEpoch + 3}, // Not a cluster validator.
+		}, nil
+	}
+	bmock.BlockRewardsFunc = func(_ context.Context, blockID string) (*eth2wrap.BlockRewards, error) {
+		if blockID == "321" {
+			return &eth2wrap.BlockRewards{ProposerIndex: 1, Total: 40_000_000}, nil
+		}
+
+		return nil, nil // Missed.
+	}
+
+	tracker := New(bmock, t.TempDir(), 0)
+	require.NoError(t, tracker.processEpoch(ctx, epoch, slotsPerEpoch))
+
+	expect := []Validator{
+		{
+			PubKey:          pubkey1.String(),
+			Index:           1,
+			AttestationGwei: 8000,
+			Proposals:       1,
+			ProposalGwei:    40_000_000,
+		},
+		{
+			PubKey:                pubkey2.String(),
+			Index:                 2,
+			AttestationGwei:       -6000,
+			MissedAttestationGwei: 14_000,
+			MissedProposals:       1,
+			MissedProposalGwei:    40_000_000,
+		},
+	}
+
+	report := tracker.Report()
+	require.EqualValues(t, epoch, report.FromEpoch)
+	require.EqualValues(t, epoch, report.ToEpoch)
+	require.Equal(t, expect, report.Validators)
+	require.EqualValues(t, 40_008_000, report.Validators[0].EarnedGwei())
+	require.EqualValues(t, 40_014_000, report.Validators[1].MissedGwei())
+
+	// Processing an epoch twice is ignored.
+	require.NoError(t, tracker.processEpoch(ctx, epoch, slotsPerEpoch))
+	require.Equal(t, expect, tracker.Report().Validators)
+
+	// Write the period report and start a new period.
+	require.NoError(t, tracker.writeReport())
+
+	b, err := os.ReadFile(filepath.Join(tracker.reportDir, "rewards-10-10.json"))
+	require.NoError(t, err)
+	var jsonReport Report
+	require.NoError(t, json.Unmarshal(b, &jsonReport))
+	require.Equal(t, expect, jsonReport.Validators)
+
+	b, err = os.ReadFile(filepath.Join(tracker.reportDir, "rewards-10-10.csv"))
+	require.NoError(t, err)
+	require.Len(t, strings.Split(strings.TrimSpace(string(b)), "\n"), 3)
+
+	require.True(t, tracker.period.Empty())
+	require.Equal(t, expect, tracker.Report().Validators)
+}
+
+func TestWriteCSV(t *testing.T) {
+	report := Report{
+		FromEpoch: 1,
+		ToEpoch:   2,
+		Validators: []Validator{{
+			PubKey:                "0x01",
+			Index:                 3,
+			AttestationGwei:       100,
+			MissedAttestationGwei: 10,
+			Proposals:             1,
+			ProposalGwei:          1000,
+			MissedProposals:       2,
+			MissedProposalGwei:    2000,
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteCSV(&buf))
+	require.Equal(t, "from_epoch,to_epoch,pubkey,index,attestation_gwei,missed_attestation_gwei,proposals,"+
+		"proposal_gwei,missed_proposals,missed_proposal_gwei,earned_gwei,missed_gwei\n"+
+		"1,2,0x01,3,100,10,1,1000,2,2000,1100,2010\n", buf.String())
+}
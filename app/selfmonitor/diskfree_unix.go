@@ -0,0 +1,22 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+//go:build unix
+
+package selfmonitor
+
+import (
+	"golang.org/x/sys/unix"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// diskFree returns the free disk space in bytes available to unprivileged users of the file system containing dir.
+func diskFree(dir string) (uint64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(dir, &stat); err != nil {
+		return 0, errors.Wrap(err, "statfs", z.Str("dir", dir))
+	}
+
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil //nolint:gosec,unconvert // Bsize is signed on some platforms.
+}
@@ -0,0 +1,25 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package selfmonitor
+
+import (
+	"golang.org/x/sys/windows"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// diskFree returns the free disk space in bytes available to the current user of the volume containing dir.
+func diskFree(dir string) (uint64, error) {
+	path, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, errors.Wrap(err, "convert dir", z.Str("dir", dir))
+	}
+
+	var free uint64
+	if err := windows.GetDiskFreeSpaceEx(path, &free, nil, nil); err != nil {
+		return 0, errors.Wrap(err, "get disk free space", z.Str("dir", dir))
+	}
+
+	return free, nil
+}
@@ -0,0 +1,25 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package selfmonitor
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	diskFreeGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "selfmonitor",
+		Name:      "disk_free_bytes",
+		Help:      "Free disk space in bytes of the file system containing the data directory",
+	})
+
+	warningGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "selfmonitor",
+		Name:      "warning",
+		Help:      "Set to 1 if the resource usage reached its warning threshold, else 0. Resources are disk, memory, goroutines and file_descriptors.",
+	}, []string{"resource"})
+)
@@ -0,0 +1,197 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package selfmonitor monitors the resources used by the charon process; data directory disk space,
+// memory, goroutines and file descriptors, logging warnings and instrumenting metrics before hard failures occur.
+package selfmonitor
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/prometheus/procfs"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// period is the period between resource samples.
+	period = 30 * time.Second
+	// minDiskFree is the minimum free disk space of the data directory in bytes.
+	minDiskFree = 1 << 30
+	// maxMemoryRatio is the maximum ratio of the resident memory to the total system memory.
+	maxMemoryRatio = 0.8
+	// maxGoroutines is the maximum number of goroutines, exceeding it usually indicates a leak.
+	maxGoroutines = 10_000
+	// maxFDRatio is the maximum ratio of open file descriptors to the soft open files limit.
+	maxFDRatio = 0.8
+)
+
+// resource is a monitored resource.
+type resource string
+
+const (
+	resourceDisk       resource = "disk"
+	resourceMemory     resource = "memory"
+	resourceGoroutines resource = "goroutines"
+	resourceFDs        resource = "file_descriptors"
+)
+
+// sample is a sample of the resources used by the process. Zero values indicate unavailable resources,
+// except for disk space which is unavailable if HasDisk is false.
+type sample struct {
+	HasDisk     bool
+	DiskFree    uint64
+	RSS         uint64
+	MemoryTotal uint64
+	Goroutines  int
+	FDs         uint64
+	FDLimit     uint64
+}
+
+// New returns a new self monitor of the process and the file system containing the data directory.
+func New(dataDir string) *Monitor {
+	return &Monitor{
+		dataDir:  dataDir,
+		period:   period,
+		sampler:  newSampler(dataDir),
+		warnings: make(map[resource]bool),
+	}
+}
+
+// Monitor periodically samples the resources used by the process.
+type Monitor struct {
+	dataDir  string
+	period   time.Duration
+	sampler  func(context.Context) sample
+	warnings map[resource]bool
+}
+
+// Run samples resources every period until the context is cancelled.
+func (m *Monitor) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "selfmon")
+
+	ticker := time.NewTicker(m.period)
+	defer ticker.Stop()
+
+	for {
+		m.check(ctx, m.sampler(ctx))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check instruments the sample and logs a warning when a resource reaches its threshold
+// and an info log when it recovers.
+func (m *Monitor) check(ctx context.Context, s sample) {
+	if s.HasDisk {
+		diskFreeGauge.Set(float64(s.DiskFree))
+	}
+
+	m.warn(ctx, resourceDisk, s.HasDisk && s.DiskFree < minDiskFree,
+		"Low free disk space in data directory, charon may fail to persist state",
+		z.Str("data_dir", m.dataDir), z.U64("free_mb", s.DiskFree>>20))
+
+	m.warn(ctx, resourceMemory, s.MemoryTotal > 0 && float64(s.RSS) > maxMemoryRatio*float64(s.MemoryTotal),
+		"High memory usage, charon may be killed by the OS",
+		z.U64("rss_mb", s.RSS>>20), z.U64("total_mb", s.MemoryTotal>>20))
+
+	m.warn(ctx, resourceGoroutines, s.Goroutines > maxGoroutines,
+		"High number of goroutines, possibly leaking",
+		z.Int("goroutines", s.Goroutines))
+
+	m.warn(ctx, resourceFDs, s.FDLimit > 0 && float64(s.FDs) > maxFDRatio*float64(s.FDLimit),
+		"High number of open file descriptors, charon may fail to open connections and files",
+		z.U64("open", s.FDs), z.U64("limit", s.FDLimit))
+}
+
+// warn sets the warning gauge of the resource and logs on transitions.
+func (m *Monitor) warn(ctx context.Context, res resource, warning bool, msg string, fields ...z.Field) {
+	if warning {
+		warningGauge.WithLabelValues(string(res)).Set(1)
+	} else {
+		warningGauge.WithLabelValues(string(res)).Set(0)
+	}
+
+	if warning && !m.warnings[res] {
+		log.Warn(ctx, msg, nil, fields...)
+	} else if !warning && m.warnings[res] {
+		log.Info(ctx, "Resource usage recovered", append(fields, z.Str("resource", string(res)))...)
+	}
+
+	m.warnings[res] = warning
+}
+
+// newSampler returns a function sampling the resources used by the process.
+// Resources that are not available on the platform are omitted.
+func newSampler(dataDir string) func(context.Context) sample {
+	var sampled bool
+
+	return func(ctx context.Context) sample {
+		s := sample{Goroutines: runtime.NumGoroutine()}
+
+		// Only log errors of the first sample since they are usually platform specific.
+		free, err := diskFree(dataDir)
+		if err != nil && !sampled {
+			log.Debug(ctx, "Failed to sample disk space", z.Err(err))
+		} else if err == nil {
+			s.HasDisk = true
+			s.DiskFree = free
+		}
+
+		if err := sampleProc(&s); err != nil && !sampled {
+			log.Debug(ctx, "Failed to sample process resources", z.Err(err))
+		}
+
+		sampled = true
+
+		return s
+	}
+}
+
+// sampleProc populates the memory and file descriptor fields of the sample from procfs.
+func sampleProc(s *sample) error {
+	proc, err := procfs.Self()
+	if err != nil {
+		return errors.Wrap(err, "procfs self")
+	}
+
+	stat, err := proc.Stat()
+	if err != nil {
+		return errors.Wrap(err, "proc stat")
+	}
+	s.RSS = uint64(stat.ResidentMemory()) //nolint:gosec // Resident memory is never negative.
+
+	fds, err := proc.FileDescriptorsLen()
+	if err != nil {
+		return errors.Wrap(err, "proc file descriptors")
+	}
+	s.FDs = uint64(fds) //nolint:gosec // Length is never negative.
+
+	limits, err := proc.Limits()
+	if err != nil {
+		return errors.Wrap(err, "proc limits")
+	}
+	s.FDLimit = limits.OpenFiles
+
+	fs, err := procfs.NewDefaultFS()
+	if err != nil {
+		return errors.Wrap(err, "procfs")
+	}
+
+	meminfo, err := fs.Meminfo()
+	if err != nil {
+		return errors.Wrap(err, "meminfo")
+	}
+	if meminfo.MemTotal != nil {
+		s.MemoryTotal = *meminfo.MemTotal << 10 // Kilobytes to bytes.
+	}
+
+	return nil
+}
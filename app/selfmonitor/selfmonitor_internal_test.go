@@ -0,0 +1,66 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package selfmonitor
+
+import (
+	"context"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/obolnetwork/charon/app/log"
+)
+
+func TestCheck(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	ctx := log.WithLogger(context.Background(), zap.New(core))
+
+	m := New(t.TempDir())
+
+	healthy := sample{
+		HasDisk:     true,
+		DiskFree:    2 * minDiskFree,
+		RSS:         1 << 20,
+		MemoryTotal: 1 << 30,
+		Goroutines:  100,
+		FDs:         10,
+		FDLimit:     1024,
+	}
+
+	m.check(ctx, healthy)
+	require.Zero(t, logs.Len())
+	require.InDelta(t, 2*minDiskFree, promtestutil.ToFloat64(diskFreeGauge), 0)
+
+	unhealthy := healthy
+	unhealthy.DiskFree = minDiskFree / 2
+	unhealthy.FDs = 1000
+
+	// Warnings are only logged once.
+	m.check(ctx, unhealthy)
+	m.check(ctx, unhealthy)
+	require.Equal(t, 2, logs.FilterLevelExact(zapcore.WarnLevel).Len())
+	require.Equal(t, 1, logs.FilterMessageSnippet("disk space").Len())
+	require.Equal(t, 1, logs.FilterMessageSnippet("file descriptors").Len())
+	require.InDelta(t, 1, promtestutil.ToFloat64(warningGauge.WithLabelValues(string(resourceDisk))), 0)
+	require.InDelta(t, 1, promtestutil.ToFloat64(warningGauge.WithLabelValues(string(resourceFDs))), 0)
+	require.InDelta(t, 0, promtestutil.ToFloat64(warningGauge.WithLabelValues(string(resourceMemory))), 0)
+
+	m.check(ctx, healthy)
+	require.Equal(t, 2, logs.FilterMessage("Resource usage recovered").Len())
+	require.InDelta(t, 0, promtestutil.ToFloat64(warningGauge.WithLabelValues(string(resourceDisk))), 0)
+
+	// Unavailable resources don't result in warnings.
+	m.check(ctx, sample{})
+	require.Equal(t, 2, logs.FilterLevelExact(zapcore.WarnLevel).Len())
+}
+
+func TestSampler(t *testing.T) {
+	s := newSampler(t.TempDir())(context.Background())
+	require.True(t, s.HasDisk)
+	require.Positive(t, s.DiskFree)
+	require.Positive(t, s.Goroutines)
+}
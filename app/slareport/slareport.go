@@ -0,0 +1,320 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package slareport attributes duty participation to each operator of the cluster and generates signed
+// performance reports over ranges of UTC days, suitable for multi-organization clusters with contractual SLAs.
+// Reports are signed by the generating node's p2p identity key, so counterparties can verify their origin.
+package slareport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// checkPeriod is the period at which the report of the previous day is written if missing.
+const checkPeriod = 10 * time.Minute
+
+// Cluster identifies the cluster and its operators in order of operator index.
+type Cluster struct {
+	Name      string
+	Hash      []byte
+	Peers     []p2p.Peer
+	Addresses []string
+}
+
+// NewCluster returns the cluster identity of the cluster manifest.
+func NewCluster(cluster *manifestpb.Cluster) (Cluster, error) {
+	peers, err := manifest.ClusterPeers(cluster)
+	if err != nil {
+		return Cluster{}, err
+	}
+
+	var addresses []string
+	for _, operator := range cluster.GetOperators() {
+		addresses = append(addresses, operator.GetAddress())
+	}
+
+	return Cluster{
+		Name:      cluster.GetName(),
+		Hash:      cluster.GetInitialMutationHash(),
+		Peers:     peers,
+		Addresses: addresses,
+	}, nil
+}
+
+// DutySummary is the number of analysed and failed duties of a duty type.
+type DutySummary struct {
+	Duty   string `json:"duty"`
+	Total  int    `json:"total"`
+	Failed int    `json:"failed"`
+}
+
+// OperatorDuty is the participation of an operator in the duties of a duty type.
+type OperatorDuty struct {
+	Duty         string `json:"duty"`
+	Participated int    `json:"participated"`
+	Expected     int    `json:"expected"`
+}
+
+// Operator is the duty participation of a cluster operator.
+type Operator struct {
+	Index        int            `json:"index"`
+	PeerName     string         `json:"peer_name"`
+	PeerID       string         `json:"peer_id"`
+	Address      string         `json:"address,omitempty"`
+	Participated int            `json:"participated"`
+	Expected     int            `json:"expected"`
+	Ratio        float64        `json:"ratio"`
+	Duties       []OperatorDuty `json:"duties"`
+}
+
+// Report is the duty participation of each operator of the cluster over the inclusive range of UTC days.
+type Report struct {
+	ClusterName string        `json:"cluster_name"`
+	ClusterHash string        `json:"cluster_hash"`
+	From        string        `json:"from"`
+	To          string        `json:"to"`
+	Duties      []DutySummary `json:"duties"`
+	Operators   []Operator    `json:"operators"`
+	GeneratedAt time.Time     `json:"generated_at"`
+}
+
+// SignedReport is a report signed by the p2p identity key of the generating node.
+type SignedReport struct {
+	Report Report `json:"report"`
+	// Signer is the peer ID of the generating node.
+	Signer string `json:"signer"`
+	// Signature is the hex encoded 65 byte secp256k1 signature of the sha256 hash of the JSON encoded report.
+	Signature string `json:"signature"`
+}
+
+// Generate returns the report of the cluster's duty participation in the store over the inclusive range of UTC days.
+func Generate(store *Store, cluster Cluster, from, to time.Time) (Report, error) {
+	if to.Before(from) {
+		return Report{}, errors.New("report end before start", z.Str("from", from.Format(DateFormat)), z.Str("to", to.Format(DateFormat)))
+	}
+
+	counts := store.Counts(from, to)
+
+	duties := make([]string, 0, len(counts))
+	for duty := range counts {
+		duties = append(duties, duty)
+	}
+	sort.Strings(duties)
+
+	report := Report{
+		ClusterName: cluster.Name,
+		ClusterHash: "0x" + hex.EncodeToString(cluster.Hash),
+		From:        from.UTC().Format(DateFormat),
+		To:          to.UTC().Format(DateFormat),
+		Duties:      []DutySummary{},
+		Operators:   []Operator{},
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	for _, duty := range duties {
+		report.Duties = append(report.Duties, DutySummary{
+			Duty:   duty,
+			Total:  counts[duty].Total,
+			Failed: counts[duty].Failed,
+		})
+	}
+
+	for i, peer := range cluster.Peers {
+		op := Operator{
+			Index:    i,
+			PeerName: peer.Name,
+			PeerID:   peer.ID.String(),
+			Duties:   []OperatorDuty{},
+		}
+		if i < len(cluster.Addresses) {
+			op.Address = cluster.Addresses[i]
+		}
+
+		for _, duty := range duties {
+			participated := counts[duty].Participated[i]
+			op.Participated += participated
+			op.Expected += counts[duty].Total
+			op.Duties = append(op.Duties, OperatorDuty{
+				Duty:         duty,
+				Participated: participated,
+				Expected:     counts[duty].Total,
+			})
+		}
+
+		if op.Expected > 0 {
+			op.Ratio = float64(op.Participated) / float64(op.Expected)
+		}
+
+		report.Operators = append(report.Operators, op)
+	}
+
+	return report, nil
+}
+
+// Sign returns the report signed by the p2p identity key.
+func Sign(report Report, key k1util.Signer) (SignedReport, error) {
+	hash, err := hashReport(report)
+	if err != nil {
+		return SignedReport{}, err
+	}
+
+	sig, err := key.Sign(hash)
+	if err != nil {
+		return SignedReport{}, errors.Wrap(err, "sign report")
+	}
+
+	signer, err := p2p.PeerIDFromKey(key.PubKey())
+	if err != nil {
+		return SignedReport{}, err
+	}
+
+	return SignedReport{
+		Report:    report,
+		Signer:    signer.String(),
+		Signature: "0x" + hex.EncodeToString(sig),
+	}, nil
+}
+
+// Verify returns an error if the report's signature isn't valid for its signer.
+func Verify(signed SignedReport) error {
+	hash, err := hashReport(signed.Report)
+	if err != nil {
+		return err
+	}
+
+	sig, err := hex.DecodeString(strings.TrimPrefix(signed.Signature, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "decode report signature")
+	}
+
+	pubkey, err := k1util.Recover(hash, sig)
+	if err != nil {
+		return errors.Wrap(err, "recover report signer")
+	}
+
+	signer, err := p2p.PeerIDFromKey(pubkey)
+	if err != nil {
+		return err
+	}
+
+	if signer.String() != signed.Signer {
+		return errors.New("invalid report signature", z.Str("signer", signed.Signer))
+	}
+
+	return nil
+}
+
+// hashReport returns the sha256 hash of the JSON encoded report.
+func hashReport(report Report) ([]byte, error) {
+	b, err := json.Marshal(report)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal report")
+	}
+
+	hash := sha256.Sum256(b)
+
+	return hash[:], nil
+}
+
+// Filename returns the filename of the signed report of the range of UTC days.
+func Filename(from, to time.Time) string {
+	return "sla-report-" + from.UTC().Format(DateFormat) + "-" + to.UTC().Format(DateFormat) + ".json"
+}
+
+// NewReporter returns a reporter writing the signed report of each completed UTC day to the report directory.
+func NewReporter(store *Store, cluster Cluster, key k1util.Signer, reportDir string) *Reporter {
+	return &Reporter{
+		store:     store,
+		cluster:   cluster,
+		key:       key,
+		reportDir: reportDir,
+	}
+}
+
+// Reporter writes daily signed reports.
+type Reporter struct {
+	store     *Store
+	cluster   Cluster
+	key       k1util.Signer
+	reportDir string
+}
+
+// Run writes the report of the previous UTC day if missing periodically until the context is cancelled,
+// persisting the store on exit.
+func (r *Reporter) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "slareport")
+
+	ticker := time.NewTicker(checkPeriod)
+	defer ticker.Stop()
+
+	for {
+		yesterday := time.Now().UTC().AddDate(0, 0, -1)
+		if err := r.writeReport(ctx, yesterday); err != nil {
+			log.Warn(ctx, "Failed writing sla report", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := r.store.Save(); err != nil {
+				log.Warn(ctx, "Failed to save sla participation", err)
+			}
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeReport writes the signed report of the UTC day if it doesn't exist and the day has participation.
+func (r *Reporter) writeReport(ctx context.Context, day time.Time) error {
+	if r.reportDir == "" || len(r.store.Counts(day, day)) == 0 {
+		return nil
+	}
+
+	path := filepath.Join(r.reportDir, Filename(day, day))
+	if _, err := os.Stat(path); err == nil {
+		return nil // Already written.
+	}
+
+	report, err := Generate(r.store, r.cluster, day, day)
+	if err != nil {
+		return err
+	}
+
+	signed, err := Sign(report, r.key)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(signed, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal signed report")
+	}
+
+	if err := os.MkdirAll(r.reportDir, 0o755); err != nil {
+		return errors.Wrap(err, "create report dir")
+	}
+
+	if err := os.WriteFile(path, b, 0o644); err != nil { //nolint:gosec // Not a secret.
+		return errors.Wrap(err, "write sla report")
+	}
+
+	log.Info(ctx, "Wrote signed sla report", z.Str("path", path))
+
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package slareport
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "sla.json")
+
+	store, err := NewStore(path)
+	require.NoError(t, err)
+
+	day1 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	store.now = func() time.Time { return day1 }
+	store.Observe(ctx, core.NewAttesterDuty(1), false, "", "", nil, []int{1, 2, 3})
+	store.Observe(ctx, core.NewAttesterDuty(2), true, "consensus", "", nil, []int{1})
+	store.Observe(ctx, core.NewAggregatorDuty(2), false, "", "", nil, nil) // Noop duty ignored.
+
+	store.now = func() time.Time { return day2 }
+	store.Observe(ctx, core.NewAttesterDuty(3), false, "", "", nil, []int{2, 3})
+	store.Observe(ctx, core.NewProposerDuty(3), false, "", "", nil, []int{1, 2})
+
+	require.Equal(t, map[string]DutyCounts{
+		"attester": {Total: 2, Failed: 1, Participated: map[int]int{0: 2, 1: 1, 2: 1}},
+	}, store.Counts(day1, day1))
+
+	require.Equal(t, map[string]DutyCounts{
+		"attester": {Total: 3, Failed: 1, Participated: map[int]int{0: 2, 1: 2, 2: 2}},
+		"proposer": {Total: 1, Participated: map[int]int{0: 1, 1: 1}},
+	}, store.Counts(day1, day2))
+
+	require.Empty(t, store.Counts(day2.AddDate(0, 0, 1), day2.AddDate(0, 0, 1)))
+
+	// Counts are restored from disk.
+	require.NoError(t, store.Save())
+	restored, err := NewStore(path)
+	require.NoError(t, err)
+	require.Equal(t, store.Counts(day1, day2), restored.Counts(day1, day2))
+
+	// Days older than the retention period are pruned.
+	store.now = func() time.Time { return day2.AddDate(0, 0, retention+1) }
+	store.Observe(ctx, core.NewAttesterDuty(4), false, "", "", nil, []int{1})
+	require.Empty(t, store.Counts(day1, day2))
+}
+
+func TestReport(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store, err := NewStore("")
+	require.NoError(t, err)
+	store.now = func() time.Time { return day }
+	store.Observe(ctx, core.NewAttesterDuty(1), false, "", "", nil, []int{1, 2})
+	store.Observe(ctx, core.NewAttesterDuty(2), false, "", "", nil, []int{1, 2})
+	store.Observe(ctx, core.NewProposerDuty(2), false, "", "", nil, []int{2})
+
+	var peers []p2p.Peer
+	for i := range 3 {
+		peerID, err := p2p.PeerIDFromKey(testutil.GenerateInsecureK1Key(t, i).PubKey())
+		require.NoError(t, err)
+		peers = append(peers, p2p.Peer{ID: peerID, Index: i, Name: p2p.PeerName(peerID)})
+	}
+
+	cluster := Cluster{Name: "test", Hash: []byte{0xab}, Peers: peers, Addresses: []string{"0x01", "0x02", "0x03"}}
+
+	_, err = Generate(store, cluster, day, day.AddDate(0, 0, -1))
+	require.ErrorContains(t, err, "report end before start")
+
+	report, err := Generate(store, cluster, day, day)
+	require.NoError(t, err)
+	require.Equal(t, "0xab", report.ClusterHash)
+	require.Equal(t, "2024-01-01", report.From)
+	require.Equal(t, []DutySummary{{Duty: "attester", Total: 2}, {Duty: "proposer", Total: 1}}, report.Duties)
+	require.Len(t, report.Operators, 3)
+
+	require.Equal(t, Operator{
+		Index:        1,
+		PeerName:     peers[1].Name,
+		PeerID:       peers[1].ID.String(),
+		Address:      "0x02",
+		Participated: 3,
+		Expected:     3,
+		Ratio:        1,
+		Duties: []OperatorDuty{
+			{Duty: "attester", Participated: 2, Expected: 2},
+			{Duty: "proposer", Participated: 1, Expected: 1},
+		},
+	}, report.Operators[1])
+	require.InDelta(t, 2.0/3, report.Operators[0].Ratio, 1e-9)
+	require.Zero(t, report.Operators[2].Participated)
+
+	// Signed reports are verified after a JSON roundtrip.
+	key := testutil.GenerateInsecureK1Key(t, 0)
+	signed, err := Sign(report, k1util.NewSigner(key))
+	require.NoError(t, err)
+	require.Equal(t, peers[0].ID.String(), signed.Signer)
+
+	b, err := json.Marshal(signed)
+	require.NoError(t, err)
+	var decoded SignedReport
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.NoError(t, Verify(decoded))
+
+	// Tampered reports are rejected.
+	decoded.Report.Operators[2].Participated = 3
+	require.ErrorContains(t, Verify(decoded), "invalid report signature")
+
+	// The reporter writes the daily report once.
+	dir := t.TempDir()
+	reporter := NewReporter(store, cluster, k1util.NewSigner(key), dir)
+	require.NoError(t, reporter.writeReport(ctx, day))
+	require.NoError(t, reporter.writeReport(ctx, day.AddDate(0, 0, 1))) // No participation, so no report.
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "sla-report-2024-01-01-2024-01-01.json", entries[0].Name())
+
+	b, err = os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.NoError(t, Verify(decoded))
+}
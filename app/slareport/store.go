@@ -0,0 +1,196 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package slareport
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+const (
+	// DateFormat is the format of the UTC days participation is stored and reported by, e.g. 2024-01-31.
+	DateFormat = "2006-01-02"
+	// retention is the number of days participation is stored for.
+	retention = 400
+	// saveInterval is the minimum interval between persisting the store.
+	saveInterval = time.Minute
+)
+
+// DutyCounts are the duty participation counts of the cluster for a duty type on a day.
+type DutyCounts struct {
+	// Total is the number of analysed duties.
+	Total int `json:"total"`
+	// Failed is the number of failed duties.
+	Failed int `json:"failed"`
+	// Participated is the number of duties each operator contributed partial signatures to by operator index.
+	Participated map[int]int `json:"participated"`
+}
+
+// NewStore returns a store persisted to the file at path, loading existing counts if the file exists.
+// An empty path results in an in-memory store.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:      path,
+		days:      make(map[string]map[string]*DutyCounts),
+		now:       time.Now,
+		lastSaved: time.Now(),
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read sla file", z.Str("path", path))
+	}
+
+	if err := json.Unmarshal(b, &s.days); err != nil {
+		return nil, errors.Wrap(err, "unmarshal sla file", z.Str("path", path))
+	}
+
+	return s, nil
+}
+
+// Store stores the daily duty participation counts of each operator.
+type Store struct {
+	mu        sync.Mutex
+	path      string
+	days      map[string]map[string]*DutyCounts // Counts by UTC date and duty type.
+	now       func() time.Time
+	lastSaved time.Time
+}
+
+// Observe adds the outcome of the analysed duty to the current day's counts, where shares are the share indexes
+// of the operators that contributed partial signatures. It is a tracker analysed duty callback,
+// see tracker.WithAnalysedDutyCallback.
+func (s *Store) Observe(ctx context.Context, duty core.Duty, failed bool, _ string, _ string, _ error, shares []int) {
+	if len(shares) == 0 && !failed {
+		return // Ignore noop duties, e.g. aggregation without selected validators.
+	}
+
+	s.mu.Lock()
+	now := s.now().UTC()
+	date := now.Format(DateFormat)
+
+	if _, ok := s.days[date]; !ok {
+		s.days[date] = make(map[string]*DutyCounts)
+		s.pruneUnsafe(now)
+	}
+
+	counts, ok := s.days[date][duty.Type.String()]
+	if !ok {
+		counts = &DutyCounts{Participated: make(map[int]int)}
+		s.days[date][duty.Type.String()] = counts
+	}
+
+	counts.Total++
+	if failed {
+		counts.Failed++
+	}
+	for _, share := range shares {
+		counts.Participated[share-1]++ // Operator indexes are share indexes minus one.
+	}
+
+	elapsed := time.Since(s.lastSaved) >= saveInterval
+	s.mu.Unlock()
+
+	if !elapsed {
+		return
+	}
+
+	if err := s.Save(); err != nil {
+		log.Warn(ctx, "Failed to save sla participation", err)
+	}
+}
+
+// pruneUnsafe deletes the days older than the retention period.
+// It is unsafe since it assumes the lock is held.
+func (s *Store) pruneUnsafe(now time.Time) {
+	oldest := now.AddDate(0, 0, -retention).Format(DateFormat)
+	for date := range s.days {
+		if date < oldest {
+			delete(s.days, date)
+		}
+	}
+}
+
+// Counts returns the participation counts by duty type summed over the inclusive range of UTC days.
+func (s *Store) Counts(from, to time.Time) map[string]DutyCounts {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fromDate, toDate := from.UTC().Format(DateFormat), to.UTC().Format(DateFormat)
+
+	resp := make(map[string]DutyCounts)
+	for date, duties := range s.days {
+		if date < fromDate || date > toDate {
+			continue
+		}
+
+		for duty, counts := range duties {
+			sum, ok := resp[duty]
+			if !ok {
+				sum.Participated = make(map[int]int)
+			}
+
+			sum.Total += counts.Total
+			sum.Failed += counts.Failed
+			for idx, n := range counts.Participated {
+				sum.Participated[idx] += n
+			}
+
+			resp[duty] = sum
+		}
+	}
+
+	return resp
+}
+
+// Save persists the store to disk, it is a noop for in-memory stores.
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := json.Marshal(s.days)
+	if err != nil {
+		return errors.Wrap(err, "marshal sla participation")
+	}
+
+	// Write to a temporary file and rename it to avoid corrupting the file on crashes.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "create temporary sla file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "write temporary sla file")
+	} else if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "close temporary sla file")
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return errors.Wrap(err, "rename sla file", z.Str("path", s.path))
+	}
+
+	s.lastSaved = time.Now()
+
+	return nil
+}
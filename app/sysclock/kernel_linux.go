@@ -0,0 +1,43 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+//go:build linux
+
+package sysclock
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+const (
+	// staUnsync is the kernel clock status flag indicating the clock is not synchronised.
+	staUnsync = 0x0040
+	// staNano is the kernel clock status flag indicating the offset is in nanoseconds instead of microseconds.
+	staNano = 0x2000
+	// timeError is the adjtimex clock state indicating the clock is not synchronised.
+	timeError = 5
+)
+
+// kernelHealth returns the kernel's time synchronisation health by reading (not modifying) the adjtimex state.
+func kernelHealth() (Health, error) {
+	var tx unix.Timex
+	state, err := unix.Adjtimex(&tx)
+	if err != nil {
+		return Health{}, errors.Wrap(err, "adjtimex")
+	}
+
+	offset := time.Duration(tx.Offset) * time.Microsecond
+	if tx.Status&staNano != 0 {
+		offset = time.Duration(tx.Offset)
+	}
+
+	return Health{
+		Source:   "adjtimex",
+		Synced:   state != timeError && tx.Status&staUnsync == 0,
+		Offset:   offset,
+		MaxError: time.Duration(tx.Maxerror) * time.Microsecond,
+	}, nil
+}
@@ -0,0 +1,12 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+//go:build !linux
+
+package sysclock
+
+import "github.com/obolnetwork/charon/app/errors"
+
+// kernelHealth returns an error since reading the kernel's time synchronisation state isn't supported on this platform.
+func kernelHealth() (Health, error) {
+	return Health{}, errors.New("clock health not supported")
+}
@@ -0,0 +1,39 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package sysclock
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	syncedGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "clock",
+		Name:      "synced",
+		Help:      "Set to 1 if the system clock is synchronised to an external time reference, else 0",
+	})
+
+	offsetGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "clock",
+		Name:      "offset_seconds",
+		Help:      "Estimated offset of the system clock from the external time reference in seconds",
+	})
+
+	maxErrorGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "clock",
+		Name:      "max_error_seconds",
+		Help:      "Maximum error of the system clock in seconds",
+	})
+
+	warningGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "clock",
+		Name:      "warning",
+		Help:      "Set to 1 if the system clock is not synchronised or drifting, else 0",
+	})
+)
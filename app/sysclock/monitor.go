@@ -0,0 +1,94 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package sysclock
+
+import (
+	"context"
+	"time"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// period is the period between clock health samples.
+	period = time.Minute
+	// maxOffset is the maximum absolute offset from the external time reference.
+	maxOffset = 100 * time.Millisecond
+	// maxError is the maximum error of the clock.
+	maxError = time.Second
+)
+
+// NewMonitor returns a new monitor of the clock's health.
+func NewMonitor(clock Clock) *Monitor {
+	return &Monitor{
+		clock:  clock,
+		period: period,
+	}
+}
+
+// Monitor periodically instruments the clock's health and logs warnings on drift.
+type Monitor struct {
+	clock   Clock
+	period  time.Duration
+	warning bool
+}
+
+// Run samples the clock's health every period until the context is cancelled.
+// It returns immediately if the clock's health is not available on this platform.
+func (m *Monitor) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "clock")
+
+	ticker := m.clock.NewTicker(m.period)
+	defer ticker.Stop()
+
+	for {
+		health, err := m.clock.Health()
+		if err != nil {
+			log.Debug(ctx, "Clock health not available", z.Err(err))
+			return
+		}
+
+		m.check(ctx, health)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Chan():
+		}
+	}
+}
+
+// check instruments the health and logs a warning when the clock becomes unsynchronised or drifts
+// and an info log when it recovers.
+func (m *Monitor) check(ctx context.Context, health Health) {
+	if health.Synced {
+		syncedGauge.Set(1)
+	} else {
+		syncedGauge.Set(0)
+	}
+	offsetGauge.Set(health.Offset.Seconds())
+	maxErrorGauge.Set(health.MaxError.Seconds())
+
+	warning := !health.Synced || health.Offset.Abs() > maxOffset || health.MaxError > maxError
+	if warning {
+		warningGauge.Set(1)
+	} else {
+		warningGauge.Set(0)
+	}
+
+	fields := []z.Field{
+		z.Str("source", health.Source),
+		z.Bool("synced", health.Synced),
+		z.Str("offset", health.Offset.String()),
+		z.Str("max_error", health.MaxError.String()),
+	}
+
+	if warning && !m.warning {
+		log.Warn(ctx, "System clock not synchronised or drifting, duties may be performed at the wrong time. Ensure NTP (e.g. chrony) is configured", nil, fields...)
+	} else if !warning && m.warning {
+		log.Info(ctx, "System clock synchronised", fields...)
+	}
+
+	m.warning = warning
+}
@@ -0,0 +1,79 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package sysclock abstracts time access behind a clock that also reports the health of its time source,
+// e.g. the kernel's NTP/PTP synchronisation state as disciplined by chrony or ntpd. Tests inject fake clocks
+// with synthetic health to drive time dependent components deterministically.
+package sysclock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+// Health is the synchronisation health of a clock's time source.
+type Health struct {
+	// Source identifies the time source, e.g. "adjtimex".
+	Source string
+	// Synced is true if the time source is synchronised to an external reference.
+	Synced bool
+	// Offset is the estimated offset of the clock from the external reference.
+	Offset time.Duration
+	// MaxError is the maximum error of the clock.
+	MaxError time.Duration
+}
+
+// Clock is a clock that reports the synchronisation health of its time source.
+type Clock interface {
+	clockwork.Clock
+
+	// Health returns the current time source health or an error if it is not available on this platform.
+	Health() (Health, error)
+}
+
+// New returns the system clock reporting the kernel's time synchronisation health.
+func New() Clock {
+	return system{Clock: clockwork.NewRealClock()}
+}
+
+// system is the system clock.
+type system struct {
+	clockwork.Clock
+}
+
+func (system) Health() (Health, error) {
+	return kernelHealth()
+}
+
+// NewFake returns a new fake clock with the synthetic health.
+func NewFake(health Health) *Fake {
+	return &Fake{
+		FakeClock: clockwork.NewFakeClock(),
+		health:    health,
+	}
+}
+
+// Fake is a fake clock with synthetic health for testing.
+type Fake struct {
+	*clockwork.FakeClock
+
+	mu     sync.Mutex
+	health Health
+}
+
+// Health returns the synthetic health.
+func (f *Fake) Health() (Health, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.health, nil
+}
+
+// SetHealth sets the synthetic health.
+func (f *Fake) SetHealth(health Health) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.health = health
+}
@@ -0,0 +1,71 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package sysclock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitorCheck(t *testing.T) {
+	ctx := context.Background()
+	m := NewMonitor(NewFake(Health{}))
+
+	tests := []struct {
+		Name    string
+		Health  Health
+		Warning bool
+	}{
+		{Name: "synced", Health: Health{Synced: true, Offset: time.Millisecond, MaxError: 10 * time.Millisecond}},
+		{Name: "unsynced", Health: Health{Synced: false}, Warning: true},
+		{Name: "negative offset", Health: Health{Synced: true, Offset: -200 * time.Millisecond}, Warning: true},
+		{Name: "max error", Health: Health{Synced: true, MaxError: 2 * time.Second}, Warning: true},
+		{Name: "recovered", Health: Health{Synced: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			m.check(ctx, test.Health)
+			require.Equal(t, test.Warning, m.warning)
+
+			expect := 0.0
+			if test.Warning {
+				expect = 1
+			}
+			require.InDelta(t, expect, testutil.ToFloat64(warningGauge), 0)
+			require.InDelta(t, test.Health.Offset.Seconds(), testutil.ToFloat64(offsetGauge), 0)
+		})
+	}
+}
+
+func TestMonitorRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := NewFake(Health{Source: "fake", Synced: true})
+	m := NewMonitor(clock)
+
+	done := make(chan struct{})
+	go func() {
+		m.Run(ctx)
+		close(done)
+	}()
+
+	// Health is sampled on start and every period.
+	clock.BlockUntil(1)
+	require.InDelta(t, 1, testutil.ToFloat64(syncedGauge), 0)
+
+	clock.SetHealth(Health{Source: "fake"})
+	clock.Advance(period)
+
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(syncedGauge) == 0
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,131 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/daemon"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// wedgedSlots is the number of slot durations without a scheduler slot tick after which charon is considered wedged.
+const wedgedSlots = 3
+
+// newSystemdNotifier returns a new systemd notifier using the NOTIFY_SOCKET and WATCHDOG_USEC environment
+// variables set by systemd.
+func newSystemdNotifier() (*systemdNotifier, error) {
+	interval, err := daemon.SdWatchdogEnabled(false)
+	if err != nil {
+		return nil, errors.Wrap(err, "check systemd watchdog")
+	}
+
+	return &systemdNotifier{
+		notify: func(state string) (bool, error) {
+			return daemon.SdNotify(false, state)
+		},
+		watchdogInterval: interval,
+		nowFunc:          time.Now,
+	}, nil
+}
+
+// systemdNotifier notifies systemd of the service state via sd_notify if charon runs as a Type=notify systemd service.
+// It notifies readiness once all other components started, so dependent units are started after charon.
+// If WatchdogSec is configured, it sends watchdog heartbeats only while the scheduler is ticking slots,
+// so systemd restarts a wedged node.
+type systemdNotifier struct {
+	notify           func(state string) (bool, error)
+	watchdogInterval time.Duration // Zero if the watchdog is disabled.
+	nowFunc          func() time.Time
+
+	mu          sync.Mutex
+	lastTick    time.Time
+	maxTickWait time.Duration
+}
+
+// SlotTicked records the time of the latest scheduler slot tick.
+func (n *systemdNotifier) SlotTicked(_ context.Context, slot core.Slot) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.lastTick = n.nowFunc()
+	n.maxTickWait = slot.SlotDuration * wedgedSlots
+
+	return nil
+}
+
+// live returns an error if the scheduler stopped ticking slots. It returns nil before the first slot tick,
+// since the scheduler only starts ticking once the beacon node is synced.
+func (n *systemdNotifier) live() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.lastTick.IsZero() {
+		return nil
+	}
+
+	if since := n.nowFunc().Sub(n.lastTick); since > n.maxTickWait {
+		return errors.New("scheduler stopped ticking slots", z.Any("since_last_tick", since))
+	}
+
+	return nil
+}
+
+// Run notifies systemd that charon is ready, sends watchdog heartbeats while live and notifies systemd
+// when stopping. It returns immediately if charon doesn't run as a systemd notify service.
+func (n *systemdNotifier) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "systemd")
+
+	if ok, err := n.notify(daemon.SdNotifyReady); err != nil {
+		log.Warn(ctx, "Failed to notify systemd of readiness", err)
+		return
+	} else if !ok {
+		return
+	}
+
+	log.Debug(ctx, "Notified systemd of readiness", z.Any("watchdog_interval", n.watchdogInterval))
+
+	var heartbeats <-chan time.Time
+	if n.watchdogInterval > 0 {
+		// Send heartbeats at half the interval, as recommended by sd_watchdog_enabled(3).
+		ticker := time.NewTicker(n.watchdogInterval / 2)
+		defer ticker.Stop()
+		heartbeats = ticker.C
+	}
+
+	var wedged bool
+	for {
+		select {
+		case <-ctx.Done():
+			if _, err := n.notify(daemon.SdNotifyStopping); err != nil {
+				log.Warn(ctx, "Failed to notify systemd of stopping", err)
+			}
+
+			return
+		case <-heartbeats:
+			if err := n.live(); err != nil {
+				if !wedged {
+					log.Error(ctx, "Skipping systemd watchdog heartbeats, systemd will restart charon", err)
+				}
+				wedged = true
+
+				continue
+			}
+
+			if wedged {
+				log.Info(ctx, "Resuming systemd watchdog heartbeats")
+			}
+			wedged = false
+
+			if _, err := n.notify(daemon.SdNotifyWatchdog); err != nil {
+				log.Warn(ctx, "Failed to send systemd watchdog heartbeat", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/daemon"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestSystemdNotifierLive(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	notifier := &systemdNotifier{nowFunc: func() time.Time { return now }}
+
+	// Live before the first slot tick.
+	require.NoError(t, notifier.live())
+
+	require.NoError(t, notifier.SlotTicked(ctx, core.Slot{Slot: 1, SlotDuration: 12 * time.Second}))
+	now = now.Add(wedgedSlots * 12 * time.Second)
+	require.NoError(t, notifier.live())
+
+	// Wedged if the scheduler stops ticking.
+	now = now.Add(time.Second)
+	require.ErrorContains(t, notifier.live(), "scheduler stopped ticking slots")
+
+	require.NoError(t, notifier.SlotTicked(ctx, core.Slot{Slot: 5, SlotDuration: 12 * time.Second}))
+	require.NoError(t, notifier.live())
+}
+
+func TestSystemdNotifierRun(t *testing.T) {
+	t.Run("not systemd", func(t *testing.T) {
+		var states []string
+		notifier := &systemdNotifier{
+			notify: func(state string) (bool, error) {
+				states = append(states, state)
+				return false, nil
+			},
+			watchdogInterval: time.Millisecond,
+			nowFunc:          time.Now,
+		}
+
+		// Returns immediately if NOTIFY_SOCKET isn't set.
+		notifier.Run(context.Background())
+		require.Equal(t, []string{daemon.SdNotifyReady}, states)
+	})
+
+	t.Run("watchdog", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		states := make(chan string)
+		notifier := &systemdNotifier{
+			notify: func(state string) (bool, error) {
+				states <- state
+				return true, nil
+			},
+			watchdogInterval: time.Millisecond,
+			nowFunc:          time.Now,
+		}
+
+		done := make(chan struct{})
+		go func() {
+			notifier.Run(ctx)
+			close(done)
+		}()
+
+		require.Equal(t, daemon.SdNotifyReady, <-states)
+		require.Equal(t, daemon.SdNotifyWatchdog, <-states)
+		require.Equal(t, daemon.SdNotifyWatchdog, <-states)
+
+		cancel()
+		for state := range states {
+			if state == daemon.SdNotifyStopping {
+				break
+			}
+			require.Equal(t, daemon.SdNotifyWatchdog, state)
+		}
+		<-done
+	})
+
+	t.Run("wedged", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		states := make(chan string, 1)
+		notifier := &systemdNotifier{
+			notify: func(state string) (bool, error) {
+				states <- state
+				return true, nil
+			},
+			watchdogInterval: time.Millisecond,
+			nowFunc:          time.Now,
+		}
+		require.NoError(t, notifier.SlotTicked(ctx, core.Slot{Slot: 1, SlotDuration: time.Nanosecond}))
+
+		done := make(chan struct{})
+		go func() {
+			notifier.Run(ctx)
+			close(done)
+		}()
+
+		require.Equal(t, daemon.SdNotifyReady, <-states)
+
+		// No heartbeats are sent while wedged.
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		require.Equal(t, daemon.SdNotifyStopping, <-states)
+		<-done
+	})
+}
@@ -0,0 +1,208 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package telemetry periodically reports anonymized cluster health aggregates to a configurable endpoint,
+// helping maintainers spot fleet-wide regressions. It is opt-in and disabled unless an endpoint is configured.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	successMetric = "core_tracker_success_duties_total"
+	expectMetric  = "core_tracker_expect_duties_total"
+	timeout       = 10 * time.Second
+)
+
+var (
+	// clusterSizeBuckets are the lower bounds of the reported cluster size buckets.
+	clusterSizeBuckets = []int{1, 4, 7, 10, 16}
+	// validatorBuckets are the lower bounds of the reported number of validators buckets.
+	validatorBuckets = []int{0, 1, 10, 100, 1000}
+)
+
+// Report is an anonymized telemetry report. It doesn't contain any identifying data like
+// cluster hashes, peer names, validator public keys or IP addresses.
+type Report struct {
+	// Version is the charon version.
+	Version string `json:"version"`
+	// ClusterSize is the bucketed number of nodes in the cluster, e.g. "4-6".
+	ClusterSize string `json:"cluster_size"`
+	// Validators is the bucketed number of validators in the cluster, e.g. "10-99".
+	Validators string `json:"validators"`
+	// DutySuccessRate is the ratio of successful to expected duties by duty type since the previous report.
+	DutySuccessRate map[string]float64 `json:"duty_success_rate"`
+}
+
+// New returns a new telemetry reporter posting reports to the endpoint every interval.
+func New(endpoint string, interval time.Duration, gatherer prometheus.Gatherer, clusterSize, numValidators int) *Reporter {
+	return &Reporter{
+		endpoint:      endpoint,
+		interval:      interval,
+		gatherer:      gatherer,
+		clusterSize:   clusterSize,
+		numValidators: numValidators,
+		client:        &http.Client{Timeout: timeout},
+		prevSuccess:   make(map[string]float64),
+		prevExpect:    make(map[string]float64),
+	}
+}
+
+// Reporter periodically reports anonymized telemetry.
+type Reporter struct {
+	endpoint      string
+	interval      time.Duration
+	gatherer      prometheus.Gatherer
+	clusterSize   int
+	numValidators int
+	client        *http.Client
+
+	// prevSuccess and prevExpect are the duty counters by duty type at the previous report.
+	prevSuccess map[string]float64
+	prevExpect  map[string]float64
+}
+
+// Run reports telemetry every interval until the context is cancelled.
+func (r *Reporter) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "telemetry")
+	log.Info(ctx, "Anonymized telemetry reporting enabled", z.Str("endpoint", r.endpoint))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.report(ctx); err != nil {
+				log.Warn(ctx, "Failed to report telemetry", err)
+			}
+		}
+	}
+}
+
+// report gathers and posts a telemetry report.
+func (r *Reporter) report(ctx context.Context) error {
+	report, err := r.newReport()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		return errors.Wrap(err, "marshal telemetry report")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "create telemetry request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "post telemetry report")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return errors.New("telemetry endpoint returned error", z.Int("status", resp.StatusCode))
+	}
+
+	log.Debug(ctx, "Reported telemetry")
+
+	return nil
+}
+
+// newReport returns a new report with the duty success rates since the previous report.
+func (r *Reporter) newReport() (Report, error) {
+	success, expect, err := r.dutyCounts()
+	if err != nil {
+		return Report{}, err
+	}
+
+	rates := make(map[string]float64)
+	for duty, total := range expect {
+		expected := total - r.prevExpect[duty]
+		if expected <= 0 {
+			continue
+		}
+
+		rates[duty] = min((success[duty]-r.prevSuccess[duty])/expected, 1)
+	}
+
+	r.prevSuccess, r.prevExpect = success, expect
+
+	return Report{
+		Version:         version.Version.String(),
+		ClusterSize:     bucket(r.clusterSize, clusterSizeBuckets),
+		Validators:      bucket(r.numValidators, validatorBuckets),
+		DutySuccessRate: rates,
+	}, nil
+}
+
+// dutyCounts returns the cumulative successful and expected duty counters by duty type.
+func (r *Reporter) dutyCounts() (map[string]float64, map[string]float64, error) {
+	fams, err := r.gatherer.Gather()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "gather metrics")
+	}
+
+	var (
+		success = make(map[string]float64)
+		expect  = make(map[string]float64)
+	)
+	for _, fam := range fams {
+		var counts map[string]float64
+		switch fam.GetName() {
+		case successMetric:
+			counts = success
+		case expectMetric:
+			counts = expect
+		default:
+			continue
+		}
+
+		for _, metric := range fam.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "duty" {
+					counts[label.GetValue()] += metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	return success, expect, nil
+}
+
+// bucket returns the bucket containing n given the ascending lower bounds of the buckets, e.g. "4-6" or "16+".
+func bucket(n int, bounds []int) string {
+	for i := len(bounds) - 1; i >= 0; i-- {
+		if n < bounds[i] {
+			continue
+		}
+
+		if i == len(bounds)-1 {
+			return fmt.Sprintf("%d+", bounds[i])
+		} else if bounds[i+1]-1 == bounds[i] {
+			return fmt.Sprint(bounds[i])
+		}
+
+		return fmt.Sprintf("%d-%d", bounds[i], bounds[i+1]-1)
+	}
+
+	return fmt.Sprintf("<%d", bounds[0])
+}
@@ -0,0 +1,79 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/version"
+)
+
+func TestBucket(t *testing.T) {
+	require.Equal(t, "<1", bucket(0, clusterSizeBuckets))
+	require.Equal(t, "1-3", bucket(3, clusterSizeBuckets))
+	require.Equal(t, "4-6", bucket(4, clusterSizeBuckets))
+	require.Equal(t, "10-15", bucket(15, clusterSizeBuckets))
+	require.Equal(t, "16+", bucket(100, clusterSizeBuckets))
+
+	require.Equal(t, "0", bucket(0, validatorBuckets))
+	require.Equal(t, "1-9", bucket(1, validatorBuckets))
+	require.Equal(t, "1000+", bucket(5000, validatorBuckets))
+}
+
+func TestReport(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	success := prometheus.NewCounterVec(prometheus.CounterOpts{Name: successMetric}, []string{"duty"})
+	expect := prometheus.NewCounterVec(prometheus.CounterOpts{Name: expectMetric}, []string{"duty"})
+	registry.MustRegister(success, expect)
+
+	reports := make(chan Report, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		var report Report
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&report))
+		reports <- report
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	reporter := New(srv.URL, time.Hour, registry, 4, 25)
+
+	success.WithLabelValues("attester").Add(9)
+	expect.WithLabelValues("attester").Add(10)
+	expect.WithLabelValues("proposer").Add(1)
+
+	require.NoError(t, reporter.report(context.Background()))
+	require.Equal(t, Report{
+		Version:         version.Version.String(),
+		ClusterSize:     "4-6",
+		Validators:      "10-99",
+		DutySuccessRate: map[string]float64{"attester": 0.9, "proposer": 0},
+	}, <-reports)
+
+	// Rates are calculated since the previous report, duty types without expected duties are omitted.
+	success.WithLabelValues("attester").Add(10)
+	expect.WithLabelValues("attester").Add(10)
+
+	require.NoError(t, reporter.report(context.Background()))
+	require.Equal(t, map[string]float64{"attester": 1}, (<-reports).DutySuccessRate)
+}
+
+func TestReportError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	reporter := New(srv.URL, time.Hour, prometheus.NewRegistry(), 4, 1)
+	require.ErrorContains(t, reporter.report(context.Background()), "telemetry endpoint returned error")
+}
@@ -7,10 +7,13 @@ import (
 	"context"
 	"io"
 	"net"
+	"strings"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
@@ -19,8 +22,13 @@ import (
 	"github.com/obolnetwork/charon/app/errors"
 )
 
-// tracer is the global app level tracer, it defaults to a noop tracer.
-var tracer = trace.NewNoopTracerProvider().Tracer("")
+var (
+	// tracer is the global app level tracer, it defaults to a noop tracer.
+	tracer = trace.NewNoopTracerProvider().Tracer("")
+
+	// propagator propagates span contexts across nodes as W3C trace context.
+	propagator = propagation.TraceContext{}
+)
 
 // Start creates a span and a context.Context containing the newly-created span from the global tracer.
 // See go.opentelemetry.io/otel/trace#Start for more details.
@@ -36,6 +44,30 @@ func RootedCtx(ctx context.Context, traceID trace.TraceID) context.Context {
 	}))
 }
 
+// Inject returns the span context of the context as a W3C trace context carrier
+// for propagation to peers, or nil if the context doesn't contain a valid span context.
+func Inject(ctx context.Context) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+
+	carrier := make(propagation.MapCarrier)
+	propagator.Inject(ctx, carrier)
+
+	return carrier
+}
+
+// Extract returns a copy of the context containing the remote span context of the W3C trace context
+// carrier received from a peer. All spans started from the context will be children of the remote span.
+// The context is returned as is if the carrier doesn't contain a valid span context.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+
+	return propagator.Extract(ctx, propagation.MapCarrier(carrier))
+}
+
 // Init initialises the global tracer via the option(s) defaulting to a noop tracer. It returns a shutdown function.
 func Init(opts ...func(*options)) (func(context.Context) error, error) {
 	var o options
@@ -43,43 +75,48 @@ func Init(opts ...func(*options)) (func(context.Context) error, error) {
 		opt(&o)
 	}
 
-	if o.expFunc == nil {
+	if len(o.expFuncs) == 0 {
 		return func(context.Context) error {
 			return nil
 		}, nil
 	}
 
-	exp, err := o.expFunc()
-	if err != nil {
-		return nil, err
+	var exps []sdktrace.SpanExporter
+	for _, expFunc := range o.expFuncs {
+		exp, err := expFunc()
+		if err != nil {
+			return nil, err
+		}
+		exps = append(exps, exp)
 	}
 
-	tp := newTraceProvider(exp, o.jaegerService)
+	tp := newTraceProvider(exps, o.service)
 
 	// Set globals
 	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagator)
 	tracer = tp.Tracer("")
 
 	return tp.Shutdown, nil
 }
 
 type options struct {
-	jaegerService string
-	expFunc       func() (sdktrace.SpanExporter, error)
+	service  string
+	expFuncs []func() (sdktrace.SpanExporter, error)
 }
 
 // WithStdOut returns an option to configure an OpenTelemetry exporter for tracing
 // telemetry to be written to an output destination as JSON.
 func WithStdOut(w io.Writer) func(*options) {
 	return func(o *options) {
-		o.expFunc = func() (sdktrace.SpanExporter, error) {
+		o.expFuncs = append(o.expFuncs, func() (sdktrace.SpanExporter, error) {
 			ex, err := stdouttrace.New(stdouttrace.WithWriter(w))
 			if err != nil {
-				return nil, errors.Wrap(err, "jaeger exporter")
+				return nil, errors.Wrap(err, "stdout exporter")
 			}
 
 			return ex, nil
-		}
+		})
 	}
 }
 
@@ -94,16 +131,22 @@ func WithJaegerOrNoop(jaegerAddr string) func(*options) {
 }
 
 // WithJaegerService returns an option to configure the jaeger service name.
+// Deprecated: Use WithServiceName.
 func WithJaegerService(service string) func(*options) {
+	return WithServiceName(service)
+}
+
+// WithServiceName returns an option to configure the service name of the exported traces.
+func WithServiceName(service string) func(*options) {
 	return func(o *options) {
-		o.jaegerService = service
+		o.service = service
 	}
 }
 
 // WithJaeger returns an option to configure an OpenTelemetry tracing exporter for Jaeger.
 func WithJaeger(addr string) func(*options) {
 	return func(o *options) {
-		o.expFunc = func() (sdktrace.SpanExporter, error) {
+		o.expFuncs = append(o.expFuncs, func() (sdktrace.SpanExporter, error) {
 			host, port, err := net.SplitHostPort(addr)
 			if err != nil {
 				return nil, errors.Wrap(err, "parse jaeger address (host:port)")
@@ -118,21 +161,59 @@ func WithJaeger(addr string) func(*options) {
 			}
 
 			return ex, nil
-		}
+		})
 	}
 }
 
-func newTraceProvider(exp sdktrace.SpanExporter, service string) *sdktrace.TracerProvider {
+// WithOTLPOrNoop returns an option to configure an OpenTelemetry tracing exporter for an OTLP gRPC collector,
+// e.g. Grafana Tempo, if the address is not empty, else the default noop tracer is retained.
+func WithOTLPOrNoop(otlpAddr string) func(*options) {
+	if otlpAddr == "" {
+		return func(*options) {}
+	}
+
+	return WithOTLP(otlpAddr)
+}
+
+// WithOTLP returns an option to configure an OpenTelemetry tracing exporter for an OTLP gRPC collector.
+// The address is either a plaintext host:port or a http(s):// URL. Additional OTLP exporter configuration
+// like headers is supported via the standard OTEL_EXPORTER_OTLP_* environment variables.
+func WithOTLP(addr string) func(*options) {
+	return func(o *options) {
+		o.expFuncs = append(o.expFuncs, func() (sdktrace.SpanExporter, error) {
+			opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpointURL(addr)}
+			if !strings.Contains(addr, "://") {
+				if _, _, err := net.SplitHostPort(addr); err != nil {
+					return nil, errors.Wrap(err, "parse otlp address (host:port)")
+				}
+
+				opts = []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(addr), otlptracegrpc.WithInsecure()}
+			}
+
+			// The exporter connects lazily, so this doesn't block on the collector being available.
+			ex, err := otlptracegrpc.New(context.Background(), opts...)
+			if err != nil {
+				return nil, errors.Wrap(err, "otlp exporter")
+			}
+
+			return ex, nil
+		})
+	}
+}
+
+func newTraceProvider(exps []sdktrace.SpanExporter, service string) *sdktrace.TracerProvider {
 	r := resource.NewWithAttributes(
 		semconv.SchemaURL,
 		semconv.ServiceNameKey.String(service),
 	)
 
-	tp := sdktrace.NewTracerProvider(
+	opts := []sdktrace.TracerProviderOption{
 		sdktrace.WithSampler(sdktrace.AlwaysSample()),
-		sdktrace.WithBatcher(exp),
 		sdktrace.WithResource(r),
-	)
+	}
+	for _, exp := range exps {
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	}
 
-	return tp
+	return sdktrace.NewTracerProvider(opts...)
 }
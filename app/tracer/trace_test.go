@@ -48,6 +48,37 @@ func TestStdOutTracer(t *testing.T) {
 	require.Equal(t, "root", m["Name"])
 }
 
+func TestPropagation(t *testing.T) {
+	ctx := context.Background()
+	require.Nil(t, tracer.Inject(ctx))
+	require.Equal(t, ctx, tracer.Extract(ctx, nil))
+
+	local := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3},
+		SpanID:     trace.SpanID{4, 5, 6},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	carrier := tracer.Inject(trace.ContextWithSpanContext(ctx, local))
+	require.NotEmpty(t, carrier)
+
+	remote := trace.SpanContextFromContext(tracer.Extract(ctx, carrier))
+	require.True(t, remote.IsRemote())
+	require.Equal(t, local.TraceID(), remote.TraceID())
+	require.Equal(t, local.SpanID(), remote.SpanID())
+}
+
+func TestOTLPAddress(t *testing.T) {
+	_, err := tracer.Init(tracer.WithOTLP("invalid"))
+	require.ErrorContains(t, err, "parse otlp address")
+
+	for _, addr := range []string{"localhost:4317", "https://localhost:4317"} {
+		stop, err := tracer.Init(tracer.WithOTLP(addr))
+		require.NoError(t, err)
+		require.NoError(t, stop(context.Background()))
+	}
+}
+
 func inner(ctx context.Context) {
 	var span trace.Span
 	_, span = tracer.Start(ctx, "inner")
@@ -0,0 +1,196 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"os"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/httpauth"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/core/policy"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil/beaconmock" // Allow testutil
+)
+
+// ValidateConfig validates the config without starting the node. It checks that URLs and listening addresses
+// are well-formed, that configured files exist and are valid, and that the cluster lock or manifest is valid
+// and contains the node's private key.
+func ValidateConfig(ctx context.Context, conf Config) error {
+	if conf.ClustersFile != "" {
+		clusters, err := LoadClustersFile(conf.ClustersFile)
+		if err != nil {
+			return err
+		}
+
+		confs, err := clusterConfigs(conf, clusters)
+		if err != nil {
+			return err
+		}
+
+		for i, clusterConf := range confs {
+			if err := ValidateConfig(ctx, clusterConf); err != nil {
+				return errors.Wrap(err, "validate cluster config", z.Str("cluster", clusters[i].Name))
+			}
+		}
+
+		return nil
+	}
+
+	if err := validateURLs(conf); err != nil {
+		return err
+	}
+
+	if err := validateListenAddrs(conf); err != nil {
+		return err
+	}
+
+	if err := validateFiles(conf); err != nil {
+		return err
+	}
+
+	_, cluster, err := loadClusterManifest(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	p2pKey, err := loadP2PSigner(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	peers, err := manifest.ClusterPeers(cluster)
+	if err != nil {
+		return err
+	}
+
+	if err := p2p.VerifyP2PPubKey(peers, p2pKey.PubKey()); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Config is valid",
+		z.Str("cluster_name", cluster.GetName()),
+		z.Int("validators", len(cluster.GetValidators())),
+		z.Int("peers", len(peers)))
+
+	return nil
+}
+
+// validateURLs returns an error if any of the configured URLs are invalid.
+func validateURLs(conf Config) error {
+	urls := map[string][]string{
+		"beacon-node-endpoints":          conf.BeaconNodeAddrs,
+		"fallback-beacon-node-endpoints": conf.FallbackBeaconNodeAddrs,
+		"event-webhook-urls":             conf.EventWebhookURLs,
+		"loki-addresses":                 conf.Log.LokiAddresses,
+		"remote-signer-address":          {conf.RemoteSignerAddr},
+		"profiling-push-address":         {conf.ProfilingPushAddr},
+		"telemetry-endpoint":             {conf.TelemetryEndpoint},
+		"metrics-remote-write-url":       {conf.MetricsRemoteWriteURL},
+		"metrics-pushgateway-url":        {conf.MetricsPushgatewayURL},
+		"event-nats-url":                 {conf.EventNATSURL},
+	}
+
+	for flag, vals := range urls {
+		for _, val := range vals {
+			if val == "" {
+				continue
+			}
+
+			u, err := url.ParseRequestURI(val)
+			if err != nil {
+				return errors.Wrap(err, "invalid url", z.Str("flag", flag), z.Str("url", redactURL(val)))
+			} else if u.Host == "" {
+				return errors.New("url without host", z.Str("flag", flag), z.Str("url", redactURL(val)))
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateListenAddrs returns an error if any of the configured listening addresses are invalid.
+func validateListenAddrs(conf Config) error {
+	addrs := map[string]string{
+		"validator-api-address": conf.ValidatorAPIAddr,
+		"monitoring-address":    conf.MonitoringAddr,
+		"debug-address":         conf.DebugAddr,
+		"admin-address":         conf.AdminAddr,
+	}
+
+	for flag, addr := range addrs {
+		if addr == "" {
+			continue
+		}
+
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return errors.Wrap(err, "invalid listening address", z.Str("flag", flag), z.Redact(z.Str("address", addr)))
+		}
+	}
+
+	if _, err := conf.P2P.ParseTCPAddrs(); err != nil {
+		return errors.Wrap(err, "invalid p2p tcp addresses")
+	}
+
+	if err := validateAdminAuth(conf); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateFiles returns an error if any of the configured files are missing or invalid.
+func validateFiles(conf Config) error {
+	if !conf.P2PPKCS11.Enabled() {
+		if _, err := os.Stat(conf.PrivKeyFile); err != nil {
+			return errors.Wrap(err, "private key file not found", z.Str("path", conf.PrivKeyFile))
+		}
+	}
+
+	if _, err := httpauth.TLSConfig(conf.MonitoringAuth); err != nil {
+		return errors.Wrap(err, "invalid monitoring auth config")
+	}
+
+	if _, err := httpauth.TLSConfig(conf.ValidatorAPIAuth); err != nil {
+		return errors.Wrap(err, "invalid validator api auth config")
+	}
+
+	if _, err := httpauth.TLSConfig(conf.AdminAuth); err != nil {
+		return errors.Wrap(err, "invalid admin auth config")
+	}
+
+	if conf.SigningPolicyFile != "" {
+		if _, err := policy.New(conf.SigningPolicyFile, signingPolicyReloadPeriod); err != nil {
+			return err
+		}
+	}
+
+	if conf.ProposerConfigFile != "" {
+		if _, err := loadProposerConfig(conf.ProposerConfigFile); err != nil {
+			return err
+		}
+	}
+
+	if conf.SimnetBMockScenarioFile != "" {
+		if _, err := beaconmock.LoadScenario(conf.SimnetBMockScenarioFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// redactURL returns the URL with any password redacted.
+func redactURL(val string) string {
+	u, err := url.Parse(val)
+	if err != nil {
+		return val
+	}
+
+	return u.Redacted()
+}
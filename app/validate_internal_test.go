@@ -0,0 +1,105 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestValidateConfig(t *testing.T) {
+	dir := t.TempDir()
+	lock, p2pKeys, _ := cluster.NewForT(t, 1, 3, 4, 0, rand.New(rand.NewSource(0)))
+
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	require.NoError(t, os.WriteFile(lockFile, b, 0o644))
+
+	keyFile := filepath.Join(dir, "charon-enr-private-key")
+	require.NoError(t, k1util.Save(p2pKeys[0], keyFile))
+
+	otherKeyFile := filepath.Join(dir, "other-private-key")
+	require.NoError(t, k1util.Save(testutil.GenerateInsecureK1Key(t, 99), otherKeyFile))
+
+	valid := Config{
+		LockFile:         lockFile,
+		ManifestFile:     filepath.Join(dir, "cluster-manifest.pb"),
+		PrivKeyFile:      keyFile,
+		BeaconNodeAddrs:  []string{"http://beacon:5052"},
+		ValidatorAPIAddr: "127.0.0.1:3600",
+		MonitoringAddr:   "127.0.0.1:3620",
+	}
+
+	tests := []struct {
+		Name     string
+		Update   func(*Config)
+		ErrorMsg string
+	}{
+		{
+			Name: "valid",
+		},
+		{
+			Name:     "invalid beacon node url",
+			Update:   func(c *Config) { c.BeaconNodeAddrs = []string{"beacon node"} },
+			ErrorMsg: "invalid url",
+		},
+		{
+			Name:     "webhook url without host",
+			Update:   func(c *Config) { c.EventWebhookURLs = []string{"http:///path"} },
+			ErrorMsg: "url without host",
+		},
+		{
+			Name:     "invalid listening address",
+			Update:   func(c *Config) { c.ValidatorAPIAddr = "3600" },
+			ErrorMsg: "invalid listening address",
+		},
+		{
+			Name:     "missing private key",
+			Update:   func(c *Config) { c.PrivKeyFile = filepath.Join(dir, "missing") },
+			ErrorMsg: "private key file not found",
+		},
+		{
+			Name:     "missing lock",
+			Update:   func(c *Config) { c.LockFile = filepath.Join(dir, "missing.json") },
+			ErrorMsg: "load cluster manifest",
+		},
+		{
+			Name:     "private key not in lock",
+			Update:   func(c *Config) { c.PrivKeyFile = otherKeyFile },
+			ErrorMsg: "unknown private key provided",
+		},
+		{
+			Name:     "missing signing policy",
+			Update:   func(c *Config) { c.SigningPolicyFile = filepath.Join(dir, "missing-policy.json") },
+			ErrorMsg: "read signing policy file",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			conf := valid
+			if test.Update != nil {
+				test.Update(&conf)
+			}
+
+			err := ValidateConfig(context.Background(), conf)
+			if test.ErrorMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.ErrorMsg)
+			}
+		})
+	}
+}
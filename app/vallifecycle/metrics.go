@@ -0,0 +1,38 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package vallifecycle
+
+import (
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	validatorsGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "validator_lifecycle",
+		Name:      "validators",
+		Help:      "Number of cluster validators by lifecycle state; created, deposited, pending, active, exiting, exited or withdrawn",
+	}, []string{"state"})
+
+	transitionsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "validator_lifecycle",
+		Name:      "transitions_total",
+		Help:      "Total number of cluster validator lifecycle state transitions by new state",
+	}, []string{"state"})
+)
+
+// instrumentStates sets the validators gauge of the states.
+func instrumentStates(states map[eth2p0.BLSPubKey]Validator) {
+	counts := make(map[State]int)
+	for _, val := range states {
+		counts[val.State]++
+	}
+
+	for _, state := range States() {
+		validatorsGauge.WithLabelValues(string(state)).Set(float64(counts[state]))
+	}
+}
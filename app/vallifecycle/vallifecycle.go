@@ -0,0 +1,247 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package vallifecycle tracks each cluster validator through its lifecycle; created, deposited, pending, active,
+// exiting, exited and withdrawn, using beacon node validator states. Transitions are notified to subscribers,
+// e.g. the event bus, and instrumented as metrics.
+package vallifecycle
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// pollPeriod is the period at which the validator states are queried from the beacon node.
+const pollPeriod = time.Minute
+
+// State is a validator lifecycle state.
+type State string
+
+const (
+	// StateCreated is a validator created by the DKG that isn't known by the beacon node nor deposited yet.
+	StateCreated State = "created"
+	// StateDeposited is a validator with a deposit that isn't eligible for activation yet.
+	StateDeposited State = "deposited"
+	// StatePending is a validator in the activation queue.
+	StatePending State = "pending"
+	// StateActive is an active validator performing duties.
+	StateActive State = "active"
+	// StateExiting is an active validator that initiated a voluntary exit or was slashed.
+	StateExiting State = "exiting"
+	// StateExited is an exited validator whose balance isn't withdrawn yet.
+	StateExited State = "exited"
+	// StateWithdrawn is an exited validator whose balance was withdrawn.
+	StateWithdrawn State = "withdrawn"
+)
+
+// States returns all states in lifecycle order.
+func States() []State {
+	return []State{StateCreated, StateDeposited, StatePending, StateActive, StateExiting, StateExited, StateWithdrawn}
+}
+
+// order returns the position of the state in the lifecycle.
+func (s State) order() int {
+	for i, state := range States() {
+		if s == state {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// stateFromBeacon returns the lifecycle state of the beacon node validator state.
+func stateFromBeacon(state eth2v1.ValidatorState) (State, bool) {
+	switch state {
+	case eth2v1.ValidatorStatePendingInitialized:
+		return StateDeposited, true
+	case eth2v1.ValidatorStatePendingQueued:
+		return StatePending, true
+	case eth2v1.ValidatorStateActiveOngoing:
+		return StateActive, true
+	case eth2v1.ValidatorStateActiveExiting, eth2v1.ValidatorStateActiveSlashed:
+		return StateExiting, true
+	case eth2v1.ValidatorStateExitedUnslashed, eth2v1.ValidatorStateExitedSlashed, eth2v1.ValidatorStateWithdrawalPossible:
+		return StateExited, true
+	case eth2v1.ValidatorStateWithdrawalDone:
+		return StateWithdrawn, true
+	default:
+		return "", false
+	}
+}
+
+// Validator is the lifecycle state of a cluster validator.
+type Validator struct {
+	PubKey string    `json:"pubkey"`
+	State  State     `json:"state"`
+	Since  time.Time `json:"since"`
+}
+
+// Transition is a validator lifecycle state transition.
+type Transition struct {
+	PubKey eth2p0.BLSPubKey
+	From   State
+	To     State
+}
+
+// New returns a new lifecycle tracker of the validators, which all start in the created state.
+func New(pubkeys []eth2p0.BLSPubKey, eth2Cl eth2client.ValidatorsProvider) *Tracker {
+	now := time.Now()
+
+	states := make(map[eth2p0.BLSPubKey]Validator)
+	for _, pubkey := range pubkeys {
+		states[pubkey] = Validator{PubKey: pubkey.String(), State: StateCreated, Since: now}
+	}
+
+	return &Tracker{
+		pubkeys:   pubkeys,
+		eth2Cl:    eth2Cl,
+		deposited: func(eth2p0.BLSPubKey) bool { return false },
+		states:    states,
+	}
+}
+
+// Tracker tracks the lifecycle states of the cluster validators.
+// States only transition forward, skipping states not observed between polls.
+type Tracker struct {
+	pubkeys []eth2p0.BLSPubKey
+	eth2Cl  eth2client.ValidatorsProvider
+
+	mu        sync.Mutex
+	deposited func(eth2p0.BLSPubKey) bool
+	states    map[eth2p0.BLSPubKey]Validator
+	subs      []func(context.Context, Transition)
+}
+
+// SetDepositedFunc sets the function returning true if a deposit of the validator was observed on the
+// execution layer, allowing validators to transition to deposited before the beacon node processed the deposit.
+func (t *Tracker) SetDepositedFunc(fn func(eth2p0.BLSPubKey) bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.deposited = fn
+}
+
+// Subscribe registers a function called on each validator state transition.
+func (t *Tracker) Subscribe(fn func(context.Context, Transition)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.subs = append(t.subs, fn)
+}
+
+// Run updates the validator states periodically until the context is cancelled.
+func (t *Tracker) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "vallifecycle")
+
+	ticker := time.NewTicker(pollPeriod)
+	defer ticker.Stop()
+
+	for {
+		if err := t.update(ctx); err != nil && ctx.Err() == nil {
+			log.Warn(ctx, "Failed updating validator lifecycle states", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Validators returns the lifecycle states of all cluster validators ordered by public key.
+func (t *Tracker) Validators() []Validator {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	resp := make([]Validator, 0, len(t.states))
+	for _, val := range t.states {
+		resp = append(resp, val)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].PubKey < resp[j].PubKey
+	})
+
+	return resp
+}
+
+// update queries the beacon node validator states and transitions the validators.
+func (t *Tracker) update(ctx context.Context) error {
+	eth2Resp, err := t.eth2Cl.Validators(ctx, &eth2api.ValidatorsOpts{
+		State:   "head",
+		PubKeys: t.pubkeys,
+	})
+	if err != nil {
+		return errors.Wrap(err, "fetch validators")
+	}
+
+	beaconStates := make(map[eth2p0.BLSPubKey]State)
+	for _, val := range eth2Resp.Data {
+		if val == nil || val.Validator == nil {
+			continue
+		}
+
+		if state, ok := stateFromBeacon(val.Status); ok {
+			beaconStates[val.Validator.PublicKey] = state
+		}
+	}
+
+	t.mu.Lock()
+	deposited := t.deposited
+	t.mu.Unlock()
+
+	for _, pubkey := range t.pubkeys {
+		state, ok := beaconStates[pubkey]
+		if !ok && deposited(pubkey) {
+			state = StateDeposited
+		} else if !ok {
+			continue
+		}
+
+		t.transition(ctx, pubkey, state)
+	}
+
+	t.mu.Lock()
+	instrumentStates(t.states)
+	t.mu.Unlock()
+
+	return nil
+}
+
+// transition transitions the validator to the state if it is later in the lifecycle than its current state
+// and notifies the subscribers. Earlier states are ignored, e.g. from a lagging fallback beacon node.
+func (t *Tracker) transition(ctx context.Context, pubkey eth2p0.BLSPubKey, state State) {
+	t.mu.Lock()
+	val, ok := t.states[pubkey]
+	if !ok || state.order() <= val.State.order() {
+		t.mu.Unlock()
+		return
+	}
+
+	from := val.State
+	val.State = state
+	val.Since = time.Now()
+	t.states[pubkey] = val
+	subs := t.subs
+	t.mu.Unlock()
+
+	transitionsCounter.WithLabelValues(string(state)).Inc()
+	log.Info(ctx, "Validator lifecycle state transition", z.Redact(z.Str("pubkey", pubkey.String())),
+		z.Str("from", string(from)), z.Str("to", string(state)))
+
+	for _, sub := range subs {
+		sub(ctx, Transition{PubKey: pubkey, From: from, To: state})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package vallifecycle
+
+import (
+	"context"
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+
+	var val1, val2, val3 eth2p0.BLSPubKey
+	for i, pk := range []*eth2p0.BLSPubKey{&val1, &val2, &val3} {
+		pk[0] = byte(i + 1)
+	}
+
+	beaconStates := map[eth2p0.BLSPubKey]eth2v1.ValidatorState{
+		val1: eth2v1.ValidatorStatePendingQueued,
+	}
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+	bmock.ValidatorsFunc = func(context.Context, *eth2api.ValidatorsOpts) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error) {
+		resp := make(map[eth2p0.ValidatorIndex]*eth2v1.Validator)
+		for pubkey, state := range beaconStates {
+			idx := eth2p0.ValidatorIndex(pubkey[0])
+			resp[idx] = &eth2v1.Validator{Index: idx, Status: state, Validator: &eth2p0.Validator{PublicKey: pubkey}}
+		}
+
+		return resp, nil
+	}
+
+	tracker := New([]eth2p0.BLSPubKey{val1, val2, val3}, bmock)
+	tracker.SetDepositedFunc(func(pubkey eth2p0.BLSPubKey) bool {
+		return pubkey == val2
+	})
+
+	var transitions []Transition
+	tracker.Subscribe(func(_ context.Context, transition Transition) {
+		transitions = append(transitions, transition)
+	})
+
+	states := func() []State {
+		var resp []State
+		for _, val := range tracker.Validators() {
+			resp = append(resp, val.State)
+		}
+
+		return resp
+	}
+
+	require.Equal(t, []State{StateCreated, StateCreated, StateCreated}, states())
+
+	require.NoError(t, tracker.update(ctx))
+	require.Equal(t, []State{StatePending, StateDeposited, StateCreated}, states())
+	require.Equal(t, []Transition{
+		{PubKey: val1, From: StateCreated, To: StatePending},
+		{PubKey: val2, From: StateCreated, To: StateDeposited},
+	}, transitions)
+
+	// Skipped states and unchanged states.
+	transitions = nil
+	beaconStates[val1] = eth2v1.ValidatorStateExitedUnslashed
+	require.NoError(t, tracker.update(ctx))
+	require.Equal(t, []State{StateExited, StateDeposited, StateCreated}, states())
+	require.Equal(t, []Transition{{PubKey: val1, From: StatePending, To: StateExited}}, transitions)
+
+	// Earlier states are ignored.
+	transitions = nil
+	beaconStates[val1] = eth2v1.ValidatorStateActiveOngoing
+	require.NoError(t, tracker.update(ctx))
+	require.Equal(t, []State{StateExited, StateDeposited, StateCreated}, states())
+	require.Empty(t, transitions)
+}
+
+func TestStateFromBeacon(t *testing.T) {
+	_, ok := stateFromBeacon(eth2v1.ValidatorStateUnknown)
+	require.False(t, ok)
+
+	for beacon, expect := range map[eth2v1.ValidatorState]State{
+		eth2v1.ValidatorStatePendingInitialized: StateDeposited,
+		eth2v1.ValidatorStatePendingQueued:      StatePending,
+		eth2v1.ValidatorStateActiveOngoing:      StateActive,
+		eth2v1.ValidatorStateActiveSlashed:      StateExiting,
+		eth2v1.ValidatorStateWithdrawalPossible: StateExited,
+		eth2v1.ValidatorStateWithdrawalDone:     StateWithdrawn,
+	} {
+		state, ok := stateFromBeacon(beacon)
+		require.True(t, ok)
+		require.Equal(t, expect, state)
+	}
+}
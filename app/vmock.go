@@ -4,6 +4,7 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -17,16 +18,26 @@ import (
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/web3signer"
 	"github.com/obolnetwork/charon/testutil/validatormock" // Allow testutil
 )
 
-// wireValidatorMock wires the validator mock if enabled. It connects via http validatorapi.Router.
+// wireValidatorMock wires the validator mock if enabled, either as simnet validator client or as internal validator
+// client signing via a remote signer. It connects via http validatorapi.Router.
 func wireValidatorMock(ctx context.Context, conf Config, eth2Cl eth2wrap.Client, pubshares []eth2p0.BLSPubKey, sched core.Scheduler) error {
-	if !conf.SimnetVMock {
+	if !conf.SimnetVMock && conf.RemoteSignerAddr == "" {
 		return nil
 	}
 
-	signer, err := newVMockSigner(conf, pubshares)
+	var (
+		signer validatormock.SignFunc
+		err    error
+	)
+	if conf.RemoteSignerAddr != "" {
+		signer, err = newRemoteSigner(ctx, conf.RemoteSignerAddr, eth2Cl, pubshares)
+	} else {
+		signer, err = newVMockSigner(conf, pubshares)
+	}
 	if err != nil {
 		return err
 	}
@@ -52,8 +63,13 @@ func wireValidatorMock(ctx context.Context, conf Config, eth2Cl eth2wrap.Client,
 		return errors.New("fetch slots per epoch")
 	}
 
+	opts := conf.TestConfig.SimnetVMockOpts
+	if conf.SimnetVMockReportFile != "" {
+		opts = append(opts, validatormock.WithReportFile(conf.SimnetVMockReportFile))
+	}
+
 	vmock := validatormock.New(ctx, newVMockEth2Provider(conf, pubshares), signer, pubshares, genesisTime, slotDuration,
-		slotsPerEpoch, conf.BuilderAPI)
+		slotsPerEpoch, conf.BuilderAPI, opts...)
 	sched.SubscribeSlots(vmock.SlotTicked)
 
 	return nil
@@ -131,7 +147,7 @@ func newVMockSigner(conf Config, pubshares []eth2p0.BLSPubKey) (validatormock.Si
 		return nil, errors.New("some validator mock keys missing", z.Int("expect", len(pubshares)), z.Int("found", len(secrets)))
 	}
 	for i, pubshare := range pubshares {
-		_, err := signer(pubshare, []byte("test signing"))
+		_, err := signer(pubshare, []byte("test signing"), nil)
 		if err != nil {
 			return nil, errors.Wrap(err, "validator mock key missing", z.Int("index", i))
 		}
@@ -139,3 +155,48 @@ func newVMockSigner(conf Config, pubshares []eth2p0.BLSPubKey) (validatormock.Si
 
 	return signer, nil
 }
+
+// newRemoteSigner returns a validator mock sign function requesting partial signatures from the Web3Signer-compatible
+// remote signer holding the key shares, which applies slashing protection. It returns an error if the remote signer
+// doesn't hold all public shares.
+func newRemoteSigner(ctx context.Context, addr string, eth2Cl eth2wrap.Client, pubshares []eth2p0.BLSPubKey) (validatormock.SignFunc, error) {
+	cl := web3signer.NewClient(addr)
+
+	remoteKeys, err := cl.PublicKeys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch remote signer public keys")
+	}
+
+	available := make(map[eth2p0.BLSPubKey]bool)
+	for _, pubkey := range remoteKeys {
+		available[pubkey] = true
+	}
+	for i, pubshare := range pubshares {
+		if !available[pubshare] {
+			return nil, errors.New("remote signer missing public share", z.Int("index", i), z.Redact(z.Str("pubshare", fmt.Sprintf("%#x", pubshare))))
+		}
+	}
+
+	genesis, err := eth2Cl.Genesis(ctx, &eth2api.GenesisOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch genesis")
+	}
+	genesisValidatorsRoot := genesis.Data.GenesisValidatorsRoot
+
+	return func(pubshare eth2p0.BLSPubKey, data []byte, msg any) (eth2p0.BLSSignature, error) {
+		fork, err := eth2Cl.Fork(ctx, &eth2api.ForkOpts{State: "head"})
+		if err != nil {
+			return eth2p0.BLSSignature{}, errors.Wrap(err, "fetch fork")
+		}
+
+		req, err := web3signer.NewSignRequest(msg, &web3signer.ForkInfo{
+			Fork:                  fork.Data,
+			GenesisValidatorsRoot: genesisValidatorsRoot,
+		}, data)
+		if err != nil {
+			return eth2p0.BLSSignature{}, err
+		}
+
+		return cl.Sign(ctx, pubshare, req)
+	}, nil
+}
@@ -0,0 +1,7 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package winsvc runs charon as a native Windows service controlled by the Windows service control manager.
+package winsvc
+
+// ServiceName is the name of the charon Windows service.
+const ServiceName = "charon"
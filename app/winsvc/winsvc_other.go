@@ -0,0 +1,21 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+//go:build !windows
+
+package winsvc
+
+import (
+	"context"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// IsService returns false since Windows services are only supported on Windows.
+func IsService() bool {
+	return false
+}
+
+// Run returns an error since Windows services are only supported on Windows.
+func Run(context.Context, string, func(context.Context) error) error {
+	return errors.New("windows services only supported on windows")
+}
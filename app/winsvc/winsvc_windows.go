@@ -0,0 +1,89 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package winsvc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/windows/svc"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// exitCodeFailed is the service specific exit code reported if the service fails,
+// which triggers the service's recovery actions.
+const exitCodeFailed = 1
+
+// IsService returns true if the process was started by the Windows service control manager.
+func IsService() bool {
+	ok, err := svc.IsWindowsService()
+
+	return err == nil && ok
+}
+
+// Run runs the function as the named Windows service until it returns or the service is stopped, which cancels
+// its context. The working directory is changed to the executable's directory, since services start in the
+// system directory, so relative paths like the default .charon data directory are resolved next to charon.exe.
+func Run(ctx context.Context, name string, fn func(context.Context) error) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "get executable path")
+	}
+
+	if err := os.Chdir(filepath.Dir(exe)); err != nil {
+		return errors.Wrap(err, "change working directory")
+	}
+
+	h := &handler{ctx: ctx, fn: fn}
+	if err := svc.Run(name, h); err != nil {
+		return errors.Wrap(err, "run windows service")
+	}
+
+	return h.err
+}
+
+// handler implements svc.Handler running a function.
+type handler struct {
+	ctx context.Context
+	fn  func(context.Context) error
+	err error
+}
+
+// Execute runs the function, reporting the service status to the service control manager,
+// and cancels the function's context on stop and shutdown requests.
+func (h *handler) Execute(_ []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	status <- svc.Status{State: svc.StartPending}
+
+	ctx, cancel := context.WithCancel(h.ctx)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.fn(ctx)
+	}()
+
+	status <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+
+	for {
+		select {
+		case err := <-done:
+			h.err = err
+			if err != nil {
+				return true, exitCodeFailed
+			}
+
+			return false, 0
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				status <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				status <- svc.Status{State: svc.StopPending}
+				cancel()
+			default:
+			}
+		}
+	}
+}
@@ -7,13 +7,25 @@ package z
 import (
 	"fmt"
 	"slices"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 )
 
+// redacted replaces the values of redacted fields in privacy mode.
+const redacted = "[REDACTED]"
+
+// privacy enables redaction of fields wrapped with Redact.
+var privacy atomic.Bool
+
 // Field wraps one or more zap fields.
 type Field func(add func(zap.Field))
 
+// SetPrivacy enables or disables privacy mode, which redacts the values of fields wrapped with Redact.
+func SetPrivacy(enabled bool) {
+	privacy.Store(enabled)
+}
+
 // Fields returns the fields of an internal structured error.
 func Fields(err error) []Field {
 	type structErr interface {
@@ -137,5 +149,29 @@ func Any(key string, val any) Field {
 	}
 }
 
+// Lazy returns a wrapped zap string field with the string version of the value returned by fn.
+// Since fields are only unwrapped when logged, fn isn't called for logs filtered by level.
+// It should be used for values that are expensive to construct on hot paths.
+func Lazy(key string, fn func() any) Field {
+	return func(add func(zap.Field)) {
+		add(zap.String(key, fmt.Sprint(fn())))
+	}
+}
+
+// Redact returns the field with its values replaced by "[REDACTED]" if privacy mode is enabled.
+// It should wrap fields with sensitive values, like IP addresses and validator public keys.
+func Redact(field Field) Field {
+	return func(add func(zap.Field)) {
+		if !privacy.Load() {
+			field(add)
+			return
+		}
+
+		field(func(f zap.Field) {
+			add(zap.String(f.Key, redacted))
+		})
+	}
+}
+
 // Skip is a noop wrapped zap field similar to zap.Skip.
 var Skip = func(func(zap.Field)) {}
@@ -136,3 +136,31 @@ func unwrap(fields ...z.Field) []zap.Field {
 
 	return resp
 }
+
+func TestLazyRedact(t *testing.T) {
+	var called bool
+	lazy := z.Lazy("lazy", func() any {
+		called = true
+		return 123
+	})
+	require.False(t, called)
+
+	unwrap := func(field z.Field) zap.Field {
+		var resp zap.Field
+		field(func(f zap.Field) { resp = f })
+
+		return resp
+	}
+
+	require.Equal(t, zap.String("lazy", "123"), unwrap(lazy))
+	require.True(t, called)
+
+	field := z.Redact(z.Str("ip", "1.2.3.4"))
+	require.Equal(t, zap.String("ip", "1.2.3.4"), unwrap(field))
+
+	z.SetPrivacy(true)
+	defer z.SetPrivacy(false)
+
+	require.Equal(t, zap.String("ip", "[REDACTED]"), unwrap(field))
+	require.Equal(t, zap.String("lazy", "[REDACTED]"), unwrap(z.Redact(lazy)))
+}
@@ -294,13 +294,13 @@ func (d Definition) Peers() ([]p2p.Peer, error) {
 	dedup := make(map[string]bool)
 	for i, operator := range d.Operators {
 		if dedup[operator.ENR] {
-			return nil, errors.New("definition contains duplicate peer enrs", z.Str("enr", operator.ENR))
+			return nil, errors.New("definition contains duplicate peer enrs", z.Redact(z.Str("enr", operator.ENR)))
 		}
 		dedup[operator.ENR] = true
 
 		record, err := enr.Parse(operator.ENR)
 		if err != nil {
-			return nil, errors.Wrap(err, "decode enr", z.Str("enr", operator.ENR))
+			return nil, errors.Wrap(err, "decode enr", z.Redact(z.Str("enr", operator.ENR)))
 		}
 
 		p, err := p2p.NewPeerFromENR(record, i)
@@ -27,36 +27,61 @@ import (
 
 // FetchDefinition fetches cluster definition file from a remote URI.
 func FetchDefinition(ctx context.Context, url string) (Definition, error) {
+	buf, err := fetchFile(ctx, url)
+	if err != nil {
+		return Definition{}, err
+	}
+
+	var res Definition
+	if err := json.Unmarshal(buf, &res); err != nil {
+		return Definition{}, errors.Wrap(err, "unmarshal definition")
+	}
+
+	return res, nil
+}
+
+// FetchLock fetches cluster lock file from a remote URI.
+func FetchLock(ctx context.Context, url string) (Lock, error) {
+	buf, err := fetchFile(ctx, url)
+	if err != nil {
+		return Lock{}, err
+	}
+
+	var res Lock
+	if err := json.Unmarshal(buf, &res); err != nil {
+		return Lock{}, errors.Wrap(err, "unmarshal lock")
+	}
+
+	return res, nil
+}
+
+// fetchFile returns the contents of the file at the remote URI.
+func fetchFile(ctx context.Context, url string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return Definition{}, errors.Wrap(err, "create http request")
+		return nil, errors.Wrap(err, "create http request")
 	}
 
 	resp, err := new(http.Client).Do(req)
 	if err != nil {
-		return Definition{}, errors.Wrap(err, "fetch file")
+		return nil, errors.Wrap(err, "fetch file")
 	}
 
 	if resp.StatusCode/100 != 2 {
-		return Definition{}, errors.New("http error", z.Int("status_code", resp.StatusCode))
+		return nil, errors.New("http error", z.Int("status_code", resp.StatusCode))
 	}
 
 	defer resp.Body.Close()
 
 	buf, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return Definition{}, errors.Wrap(err, "read response body")
+		return nil, errors.Wrap(err, "read response body")
 	}
 
-	var res Definition
-	if err := json.Unmarshal(buf, &res); err != nil {
-		return Definition{}, errors.Wrap(err, "unmarshal definition")
-	}
-
-	return res, nil
+	return buf, nil
 }
 
 // CreateValidatorKeysDir creates a new directory for validator keys.
@@ -25,13 +25,13 @@ func ClusterPeers(c *manifestpb.Cluster) ([]p2p.Peer, error) {
 	dedup := make(map[string]bool)
 	for i, operator := range c.GetOperators() {
 		if dedup[operator.GetEnr()] {
-			return nil, errors.New("cluster contains duplicate peer enrs", z.Str("enr", operator.GetEnr()))
+			return nil, errors.New("cluster contains duplicate peer enrs", z.Redact(z.Str("enr", operator.GetEnr())))
 		}
 		dedup[operator.GetEnr()] = true
 
 		record, err := enr.Parse(operator.GetEnr())
 		if err != nil {
-			return nil, errors.Wrap(err, "decode enr", z.Str("enr", operator.GetEnr()))
+			return nil, errors.Wrap(err, "decode enr", z.Redact(z.Str("enr", operator.GetEnr())))
 		}
 
 		p, err := p2p.NewPeerFromENR(record, i)
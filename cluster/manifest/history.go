@@ -0,0 +1,214 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/enr"
+)
+
+// HistoryEntry describes a signed mutation of a cluster manifest DAG for auditing.
+type HistoryEntry struct {
+	Hash   string `json:"hash"`
+	Parent string `json:"parent"`
+	Type   string `json:"type"`
+	// Signer is the 0x-prefixed public key of the signer, empty for unsigned and composite mutations.
+	Signer string `json:"signer,omitempty"`
+	// SignerOperator is the index of the cluster operator that signed the mutation, nil if not an operator.
+	SignerOperator *int `json:"signer_operator,omitempty"`
+	// SignerAddress is the Ethereum address of the cluster operator that signed the mutation.
+	SignerAddress string `json:"signer_address,omitempty"`
+	// Timestamp is when the mutation was created or signed, nil if not recorded by the mutation.
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	// Summary is a human-readable description of the mutation, or of the cluster changes for top-level mutations.
+	Summary string `json:"summary,omitempty"`
+	// Children are the mutations of composite mutations.
+	Children []HistoryEntry `json:"children,omitempty"`
+}
+
+// History returns the audit trail of the cluster manifest DAG, one entry per top-level mutation.
+// Signers are identified as cluster operators before or after the mutation is applied.
+func History(dag *manifestpb.SignedMutationList) ([]HistoryEntry, error) {
+	if len(dag.GetMutations()) == 0 {
+		return nil, errors.New("empty raw DAG")
+	}
+
+	var (
+		prev = new(manifestpb.Cluster)
+		resp []HistoryEntry
+	)
+	for i, signed := range dag.GetMutations() {
+		next, err := Transform(proto.Clone(prev).(*manifestpb.Cluster), signed)
+		if err != nil {
+			return nil, errors.Wrap(err, "transform mutation", z.Int("index", i))
+		}
+
+		entry, err := historyEntry(signed, prev.GetOperators(), next.GetOperators())
+		if err != nil {
+			return nil, errors.Wrap(err, "mutation history", z.Int("index", i))
+		}
+
+		if MutationType(signed.GetMutation().GetType()) != TypeLegacyLock {
+			entry.Summary = clusterChanges(prev, next)
+		}
+
+		resp = append(resp, entry)
+		prev = next
+	}
+
+	return resp, nil
+}
+
+// historyEntry returns the history entry of the signed mutation and its children.
+// Signers are either existing operators, or new operators approving their addition or key rotation.
+func historyEntry(signed *manifestpb.SignedMutation, prevOps, nextOps []*manifestpb.Operator) (HistoryEntry, error) {
+	hash, err := Hash(signed)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+
+	entry := HistoryEntry{
+		Hash:   to0xHex(hash),
+		Parent: to0xHex(signed.GetMutation().GetParent()),
+		Type:   signed.GetMutation().GetType(),
+	}
+
+	if len(signed.GetSigner()) > 0 {
+		entry.Signer = to0xHex(signed.GetSigner())
+		entry.SignerOperator, entry.SignerAddress = signerOperator(signed.GetSigner(), nextOps, prevOps)
+	}
+
+	data := signed.GetMutation().GetData()
+
+	switch MutationType(entry.Type) {
+	case TypeLegacyLock:
+		legacyLock := new(manifestpb.LegacyLock)
+		if err := data.UnmarshalTo(legacyLock); err != nil {
+			return HistoryEntry{}, errors.Wrap(err, "unmarshal legacy lock")
+		}
+
+		var lock cluster.Lock
+		if err := json.Unmarshal(legacyLock.GetJson(), &lock); err != nil {
+			return HistoryEntry{}, errors.Wrap(err, "unmarshal lock")
+		}
+
+		if ts, err := time.Parse(time.RFC3339, lock.Timestamp); err == nil {
+			entry.Timestamp = &ts
+		}
+
+		entry.Summary = fmt.Sprintf("Cluster %q created with %d operators, threshold %d and %d validators",
+			lock.Name, len(lock.Operators), lock.Threshold, len(lock.Validators))
+	case TypeNodeApproval:
+		timestamp := new(timestamppb.Timestamp)
+		if err := data.UnmarshalTo(timestamp); err != nil {
+			return HistoryEntry{}, errors.Wrap(err, "unmarshal node approval timestamp")
+		}
+
+		ts := timestamp.AsTime()
+		entry.Timestamp = &ts
+		entry.Summary = "Approved parent mutation"
+	case TypeGenValidators:
+		vals := new(manifestpb.ValidatorList)
+		if err := data.UnmarshalTo(vals); err != nil {
+			return HistoryEntry{}, errors.Wrap(err, "unmarshal validators")
+		}
+
+		entry.Summary = fmt.Sprintf("Generated %d validators", len(vals.GetValidators()))
+	case TypeReshareOperators:
+		change := new(manifestpb.OperatorChange)
+		if err := data.UnmarshalTo(change); err != nil {
+			return HistoryEntry{}, errors.Wrap(err, "unmarshal operator change")
+		}
+
+		entry.Summary = fmt.Sprintf("Reshared %d validators to %d operators with threshold %d",
+			len(change.GetValidators()), len(change.GetOperators()), change.GetThreshold())
+	case TypeOperatorKeyRotation:
+		rotation := new(manifestpb.OperatorKeyRotation)
+		if err := data.UnmarshalTo(rotation); err != nil {
+			return HistoryEntry{}, errors.Wrap(err, "unmarshal operator key rotation")
+		}
+
+		entry.Summary = fmt.Sprintf("Rotated operator ENR %s to %s", rotation.GetOldEnr(), rotation.GetNewEnr())
+	default:
+		list := new(manifestpb.SignedMutationList)
+		if !data.MessageIs(list) {
+			break
+		}
+
+		if err := data.UnmarshalTo(list); err != nil {
+			return HistoryEntry{}, errors.Wrap(err, "unmarshal signed mutation list")
+		}
+
+		for _, child := range list.GetMutations() {
+			childEntry, err := historyEntry(child, prevOps, nextOps)
+			if err != nil {
+				return HistoryEntry{}, err
+			}
+
+			entry.Children = append(entry.Children, childEntry)
+		}
+	}
+
+	return entry, nil
+}
+
+// signerOperator returns the index and address of the operator with the signer public key in the first
+// operator list containing it, or nil if not found.
+func signerOperator(signer []byte, operatorLists ...[]*manifestpb.Operator) (*int, string) {
+	for _, operators := range operatorLists {
+		for i, op := range operators {
+			record, err := enr.Parse(op.GetEnr())
+			if err != nil || !bytes.Equal(record.PubKey.SerializeCompressed(), signer) {
+				continue
+			}
+
+			return &i, op.GetAddress()
+		}
+	}
+
+	return nil, ""
+}
+
+// clusterChanges returns a human-readable description of the changes between the previous and next cluster.
+func clusterChanges(prev, next *manifestpb.Cluster) string {
+	var changes []string
+	if len(prev.GetOperators()) != len(next.GetOperators()) {
+		changes = append(changes, fmt.Sprintf("operators %d -> %d", len(prev.GetOperators()), len(next.GetOperators())))
+	} else {
+		var replaced int
+		for i, op := range next.GetOperators() {
+			if op.GetEnr() != prev.GetOperators()[i].GetEnr() {
+				replaced++
+			}
+		}
+		if replaced > 0 {
+			changes = append(changes, fmt.Sprintf("%d operator ENRs replaced", replaced))
+		}
+	}
+
+	if prev.GetThreshold() != next.GetThreshold() {
+		changes = append(changes, fmt.Sprintf("threshold %d -> %d", prev.GetThreshold(), next.GetThreshold()))
+	}
+
+	if len(prev.GetValidators()) != len(next.GetValidators()) {
+		changes = append(changes, fmt.Sprintf("validators %d -> %d", len(prev.GetValidators()), len(next.GetValidators())))
+	}
+
+	if len(changes) == 0 {
+		return "No cluster changes"
+	}
+
+	return strings.Join(changes, ", ")
+}
@@ -0,0 +1,77 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestHistory(t *testing.T) {
+	setIncrementingTime(t)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, _ := cluster.NewForT(t, 2, 3, 4, seed, random)
+
+	legacyLock, err := manifest.NewLegacyLockForT(t, lock)
+	require.NoError(t, err)
+	dag := &manifestpb.SignedMutationList{Mutations: []*manifestpb.SignedMutation{legacyLock}}
+
+	c, err := manifest.Materialise(dag)
+	require.NoError(t, err)
+
+	newKey := testutil.GenerateInsecureK1Key(t, 100)
+	record, err := enr.New(newKey)
+	require.NoError(t, err)
+
+	rotation, err := manifest.NewOperatorKeyRotation(c.GetLatestMutationHash(), c.GetOperators()[0].GetEnr(), record.String())
+	require.NoError(t, err)
+	dag.Mutations = append(dag.Mutations, newRotateOperatorKeyForT(t, rotation, secrets[1:], newKey))
+
+	history, err := manifest.History(dag)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	// Legacy lock
+	require.Equal(t, fmt.Sprintf("%#x", lock.LockHash), history[0].Hash)
+	require.EqualValues(t, manifest.TypeLegacyLock, history[0].Type)
+	require.Contains(t, history[0].Summary, "created with 4 operators, threshold 3 and 2 validators")
+	require.Empty(t, history[0].Signer)
+
+	// Rotate operator key: rotation, quorum approvals of operators 1-3 and the new key approval of operator 0.
+	rotate := history[1]
+	require.EqualValues(t, manifest.TypeRotateOperatorKey, rotate.Type)
+	require.Equal(t, history[0].Hash, rotate.Parent)
+	require.Equal(t, "1 operator ENRs replaced", rotate.Summary)
+	require.Len(t, rotate.Children, 3)
+
+	require.EqualValues(t, manifest.TypeOperatorKeyRotation, rotate.Children[0].Type)
+	require.Contains(t, rotate.Children[0].Summary, record.String())
+
+	approvals := rotate.Children[1].Children
+	require.Len(t, approvals, 3)
+	for i, approval := range approvals {
+		require.EqualValues(t, manifest.TypeNodeApproval, approval.Type)
+		require.Equal(t, rotate.Children[0].Hash, approval.Parent)
+		require.NotNil(t, approval.SignerOperator)
+		require.Equal(t, i+1, *approval.SignerOperator)
+		require.Equal(t, lock.Operators[i+1].Address, approval.SignerAddress)
+		require.NotNil(t, approval.Timestamp)
+		require.True(t, approval.Timestamp.After(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+	}
+
+	newKeyApproval := rotate.Children[2]
+	require.NotNil(t, newKeyApproval.SignerOperator)
+	require.Equal(t, 0, *newKeyApproval.SignerOperator)
+}
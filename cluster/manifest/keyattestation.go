@@ -0,0 +1,205 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/enr"
+)
+
+// keyAttestationDomain separates key attestation signatures from other signatures of operator keys.
+const keyAttestationDomain = "charon/operator_key_attestation/v1"
+
+// KeyAttestation is an attestation by an operator's previous ENR private key of its new ENR.
+// Unlike the rotate operator key mutation, it doesn't require approval of the other operators, so it is only
+// accepted until it expires. It allows an operator to replace its ENR private key without a cluster redefinition,
+// while the other operators approve a permanent rotation of the key during the grace period.
+type KeyAttestation struct {
+	// ClusterHash is the initial mutation hash (lock hash) of the cluster.
+	ClusterHash string `json:"cluster_hash"`
+	// OldENR is the ENR of the operator's previous key.
+	OldENR string `json:"old_enr"`
+	// NewENR is the ENR of the operator's new key.
+	NewENR string `json:"new_enr"`
+	// NotAfter is the end of the grace period, after which the attestation isn't accepted anymore.
+	NotAfter time.Time `json:"not_after"`
+	// Signature is the signature of the attestation by the operator's previous key.
+	Signature string `json:"signature"`
+}
+
+// NewKeyAttestation returns a key attestation of the new ENR signed by the operator's previous key
+// for the cluster, expiring after notAfter.
+func NewKeyAttestation(oldKey *k1.PrivateKey, clusterHash []byte, newENR string, notAfter time.Time) (KeyAttestation, error) {
+	oldRecord, err := enr.New(oldKey)
+	if err != nil {
+		return KeyAttestation{}, err
+	}
+
+	att := KeyAttestation{
+		ClusterHash: to0xHex(clusterHash),
+		OldENR:      oldRecord.String(),
+		NewENR:      newENR,
+		NotAfter:    notAfter.UTC().Truncate(time.Second),
+	}
+
+	if _, err := enr.Parse(newENR); err != nil {
+		return KeyAttestation{}, errors.Wrap(err, "invalid new operator enr")
+	}
+
+	sig, err := k1util.Sign(oldKey, att.digest())
+	if err != nil {
+		return KeyAttestation{}, err
+	}
+
+	att.Signature = to0xHex(sig)
+
+	return att, nil
+}
+
+// digest returns the hash of the attestation signed by the operator's previous key.
+func (a KeyAttestation) digest() []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte(keyAttestationDomain))
+	_, _ = h.Write([]byte(a.ClusterHash))
+	_, _ = h.Write([]byte(a.OldENR))
+	_, _ = h.Write([]byte(a.NewENR))
+	_, _ = h.Write(binary.BigEndian.AppendUint64(nil, uint64(a.NotAfter.Unix())))
+
+	return h.Sum(nil)
+}
+
+// Verify returns an error if the attestation isn't signed by the operator's previous key for the cluster,
+// or if it expired at the provided time.
+func (a KeyAttestation) Verify(clusterHash []byte, now time.Time) error {
+	if a.ClusterHash != to0xHex(clusterHash) {
+		return errors.New("key attestation for different cluster", z.Str("cluster_hash", a.ClusterHash))
+	}
+
+	if !now.Before(a.NotAfter) {
+		return errors.New("key attestation expired", z.Any("not_after", a.NotAfter))
+	}
+
+	oldRecord, err := enr.Parse(a.OldENR)
+	if err != nil {
+		return errors.Wrap(err, "invalid old operator enr")
+	}
+
+	if _, err := enr.Parse(a.NewENR); err != nil {
+		return errors.Wrap(err, "invalid new operator enr")
+	}
+
+	sig, err := from0xHex(a.Signature, 65)
+	if err != nil {
+		return errors.Wrap(err, "invalid key attestation signature")
+	}
+
+	if ok, err := k1util.Verify65(oldRecord.PubKey, a.digest(), sig); err != nil {
+		return errors.Wrap(err, "verify key attestation signature")
+	} else if !ok {
+		return errors.New("invalid key attestation signature")
+	}
+
+	return nil
+}
+
+// ApplyKeyAttestations returns a copy of the cluster with the ENRs of the attested operators replaced by their
+// new ENRs and the applied attestations. It returns an error if any attestation is invalid or expired at the
+// provided time. Attestations of key rotations already made permanent are ignored.
+// Operators are matched by public key, since their ENRs may advertise different addresses.
+func ApplyKeyAttestations(c *manifestpb.Cluster, atts []KeyAttestation, now time.Time) (*manifestpb.Cluster, []KeyAttestation, error) {
+	c = proto.Clone(c).(*manifestpb.Cluster)
+
+	var applied []KeyAttestation
+	for _, att := range atts {
+		if err := att.Verify(c.GetInitialMutationHash(), now); err != nil {
+			return nil, nil, err
+		}
+
+		oldRecord, err := enr.Parse(att.OldENR)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid old operator enr")
+		}
+
+		newRecord, err := enr.Parse(att.NewENR)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid new operator enr")
+		}
+
+		opIdx, rotated := -1, false
+		for i, op := range c.GetOperators() {
+			record, err := enr.Parse(op.GetEnr())
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "invalid operator enr", z.Redact(z.Str("enr", op.GetEnr())))
+			}
+
+			if record.PubKey.IsEqual(newRecord.PubKey) {
+				rotated = true
+			} else if record.PubKey.IsEqual(oldRecord.PubKey) {
+				opIdx = i
+			}
+		}
+
+		if rotated && opIdx < 0 {
+			continue // The key rotation was already made permanent.
+		} else if rotated {
+			return nil, nil, errors.New("attested operator key already in cluster", z.Redact(z.Str("enr", att.NewENR)))
+		} else if opIdx < 0 {
+			return nil, nil, errors.New("attested operator not in cluster", z.Redact(z.Str("enr", att.OldENR)))
+		}
+
+		c.Operators[opIdx] = &manifestpb.Operator{
+			Address: c.GetOperators()[opIdx].GetAddress(),
+			Enr:     att.NewENR,
+		}
+
+		applied = append(applied, att)
+	}
+
+	return c, applied, nil
+}
+
+// LoadKeyAttestations returns all key attestations (*.json files) in the directory.
+// It returns no attestations if the directory doesn't exist.
+func LoadKeyAttestations(dir string) ([]KeyAttestation, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "glob key attestations")
+	}
+
+	var resp []KeyAttestation
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "read key attestation", z.Str("file", file))
+		}
+
+		var att KeyAttestation
+		if err := json.Unmarshal(b, &att); err != nil {
+			return nil, errors.Wrap(err, "unmarshal key attestation", z.Str("file", file))
+		}
+
+		resp = append(resp, att)
+	}
+
+	return resp, nil
+}
+
+// KeyAttestationFile returns the file name of the key attestation.
+func KeyAttestationFile(att KeyAttestation) string {
+	h := sha256.Sum256([]byte(att.NewENR))
+	return fmt.Sprintf("key-attestation-%x.json", h[:4])
+}
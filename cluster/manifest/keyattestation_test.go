@@ -0,0 +1,95 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestKeyAttestation(t *testing.T) {
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, _ := cluster.NewForT(t, 2, 3, 4, seed, random)
+
+	c, err := manifest.NewClusterFromLockForT(t, lock)
+	require.NoError(t, err)
+
+	newKey := testutil.GenerateInsecureK1Key(t, 100)
+	record, err := enr.New(newKey)
+	require.NoError(t, err)
+
+	now := time.Now()
+	notAfter := now.Add(time.Hour)
+
+	att, err := manifest.NewKeyAttestation(secrets[1], c.GetInitialMutationHash(), record.String(), notAfter)
+	require.NoError(t, err)
+
+	t.Run("apply", func(t *testing.T) {
+		applied, atts, err := manifest.ApplyKeyAttestations(c, []manifest.KeyAttestation{att}, now)
+		require.NoError(t, err)
+		require.Len(t, atts, 1)
+
+		require.Equal(t, record.String(), applied.GetOperators()[1].GetEnr())
+		require.Equal(t, c.GetOperators()[1].GetAddress(), applied.GetOperators()[1].GetAddress())
+		require.NotEqual(t, record.String(), c.GetOperators()[1].GetEnr(), "cluster not modified")
+		testutil.RequireProtoEqual(t, c.GetOperators()[0], applied.GetOperators()[0])
+
+		// Attestations of permanent key rotations are ignored.
+		again, atts, err := manifest.ApplyKeyAttestations(applied, []manifest.KeyAttestation{att}, now)
+		require.NoError(t, err)
+		require.Empty(t, atts)
+		testutil.RequireProtoEqual(t, applied, again)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		_, _, err := manifest.ApplyKeyAttestations(c, []manifest.KeyAttestation{att}, notAfter)
+		require.ErrorContains(t, err, "key attestation expired")
+	})
+
+	t.Run("different cluster", func(t *testing.T) {
+		require.ErrorContains(t, att.Verify([]byte("other"), now), "key attestation for different cluster")
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := att
+		tampered.NotAfter = notAfter.Add(time.Hour)
+		require.ErrorContains(t, tampered.Verify(c.GetInitialMutationHash(), now), "invalid key attestation signature")
+	})
+
+	t.Run("not in cluster", func(t *testing.T) {
+		att, err := manifest.NewKeyAttestation(testutil.GenerateInsecureK1Key(t, 101), c.GetInitialMutationHash(), record.String(), notAfter)
+		require.NoError(t, err)
+
+		_, _, err = manifest.ApplyKeyAttestations(c, []manifest.KeyAttestation{att}, now)
+		require.ErrorContains(t, err, "attested operator not in cluster")
+	})
+
+	t.Run("load", func(t *testing.T) {
+		dir := t.TempDir()
+
+		atts, err := manifest.LoadKeyAttestations(filepath.Join(dir, "missing"))
+		require.NoError(t, err)
+		require.Empty(t, atts)
+
+		b, err := json.Marshal(att)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, manifest.KeyAttestationFile(att)), b, 0o644))
+
+		atts, err = manifest.LoadKeyAttestations(dir)
+		require.NoError(t, err)
+		require.Len(t, atts, 1)
+		require.NoError(t, atts[0].Verify(c.GetInitialMutationHash(), now))
+	})
+}
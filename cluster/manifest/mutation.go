@@ -32,6 +32,14 @@ const (
 	TypeNodeApprovals MutationType = "dv/node_approvals/v0.0.1"
 	TypeGenValidators MutationType = "dv/gen_validators/v0.0.1"
 	TypeAddValidators MutationType = "dv/add_validators/v0.0.1"
+
+	TypeReshareOperators MutationType = "dv/reshare_operators/v0.0.1"
+	TypeQuorumApprovals  MutationType = "dv/quorum_approvals/v0.0.1"
+	TypeAddOperator      MutationType = "dv/add_operator/v0.0.1"
+	TypeRemoveOperator   MutationType = "dv/remove_operator/v0.0.1"
+
+	TypeOperatorKeyRotation MutationType = "dv/operator_key_rotation/v0.0.1"
+	TypeRotateOperatorKey   MutationType = "dv/rotate_operator_key/v0.0.1"
 )
 
 type mutationDef struct {
@@ -66,4 +74,28 @@ func init() {
 	mutationDefs[TypeAddValidators] = mutationDef{
 		TransformFunc: transformAddValidators,
 	}
+
+	mutationDefs[TypeReshareOperators] = mutationDef{
+		TransformFunc: transformReshareOperators,
+	}
+
+	mutationDefs[TypeQuorumApprovals] = mutationDef{
+		TransformFunc: transformQuorumApprovals,
+	}
+
+	mutationDefs[TypeAddOperator] = mutationDef{
+		TransformFunc: transformAddOperator,
+	}
+
+	mutationDefs[TypeRemoveOperator] = mutationDef{
+		TransformFunc: transformRemoveOperator,
+	}
+
+	mutationDefs[TypeOperatorKeyRotation] = mutationDef{
+		TransformFunc: transformOperatorKeyRotation,
+	}
+
+	mutationDefs[TypeRotateOperatorKey] = mutationDef{
+		TransformFunc: transformRotateOperatorKey,
+	}
 }
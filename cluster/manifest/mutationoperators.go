@@ -0,0 +1,338 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest
+
+import (
+	"bytes"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+)
+
+// NewReshareOperators creates a new reshare operators mutation that replaces the cluster operators, threshold
+// and validator public shares after the validator keys were reshared to the provided operators.
+func NewReshareOperators(parent []byte, operators []*manifestpb.Operator, threshold int, validators []*manifestpb.Validator) (*manifestpb.SignedMutation, error) {
+	if len(parent) != hashLen {
+		return nil, errors.New("invalid parent hash")
+	}
+
+	change := &manifestpb.OperatorChange{
+		Operators:  operators,
+		Threshold:  int32(threshold),
+		Validators: validators,
+	}
+
+	if err := verifyOperatorChange(change); err != nil {
+		return nil, errors.Wrap(err, "verify operator change")
+	}
+
+	changeAny, err := anypb.New(change)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal operator change")
+	}
+
+	return &manifestpb.SignedMutation{
+		Mutation: &manifestpb.Mutation{
+			Parent: parent,
+			Type:   string(TypeReshareOperators),
+			Data:   changeAny,
+		},
+		// No signer or signature.
+	}, nil
+}
+
+// verifyOperatorChange returns an error if the operator change is internally inconsistent.
+func verifyOperatorChange(change *manifestpb.OperatorChange) error {
+	numOps := len(change.GetOperators())
+	if numOps == 0 {
+		return errors.New("no operators")
+	}
+
+	if change.GetThreshold() <= 0 || int(change.GetThreshold()) > numOps {
+		return errors.New("invalid threshold", z.I64("threshold", int64(change.GetThreshold())), z.Int("operators", numOps))
+	}
+
+	dedup := make(map[string]bool)
+	for _, op := range change.GetOperators() {
+		if dedup[op.GetEnr()] {
+			return errors.New("duplicate operator enr", z.Redact(z.Str("enr", op.GetEnr())))
+		}
+		dedup[op.GetEnr()] = true
+	}
+
+	if len(change.GetValidators()) == 0 {
+		return errors.New("no validators")
+	}
+
+	for i, val := range change.GetValidators() {
+		if len(val.GetPubShares()) != numOps {
+			return errors.New("validator public shares count doesn't match operators",
+				z.Int("validator_index", i), z.Int("pub_shares", len(val.GetPubShares())), z.Int("operators", numOps))
+		}
+	}
+
+	return nil
+}
+
+// transformReshareOperators replaces the cluster operators, threshold and validator public shares.
+// The validators' public keys, addresses and builder registrations must remain unchanged.
+func transformReshareOperators(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	if err := verifyEmptySig(signed); err != nil {
+		return c, errors.Wrap(err, "verify empty sig")
+	}
+
+	if MutationType(signed.GetMutation().GetType()) != TypeReshareOperators {
+		return c, errors.New("invalid mutation type")
+	}
+
+	change := new(manifestpb.OperatorChange)
+	if err := signed.GetMutation().GetData().UnmarshalTo(change); err != nil {
+		return c, errors.Wrap(err, "unmarshal operator change")
+	}
+
+	if err := verifyOperatorChange(change); err != nil {
+		return c, errors.Wrap(err, "verify operator change")
+	}
+
+	if len(change.GetValidators()) != len(c.GetValidators()) {
+		return c, errors.New("reshared validators count mismatch")
+	}
+
+	for i, val := range change.GetValidators() {
+		prev := c.GetValidators()[i]
+		if !bytes.Equal(prev.GetPublicKey(), val.GetPublicKey()) {
+			return c, errors.New("reshared validator public key mismatch", z.Int("validator_index", i))
+		} else if prev.GetFeeRecipientAddress() != val.GetFeeRecipientAddress() {
+			return c, errors.New("reshared validator fee recipient mismatch", z.Int("validator_index", i))
+		} else if prev.GetWithdrawalAddress() != val.GetWithdrawalAddress() {
+			return c, errors.New("reshared validator withdrawal address mismatch", z.Int("validator_index", i))
+		} else if !bytes.Equal(prev.GetBuilderRegistrationJson(), val.GetBuilderRegistrationJson()) {
+			return c, errors.New("reshared validator builder registration mismatch", z.Int("validator_index", i))
+		}
+	}
+
+	c.Operators = change.GetOperators()
+	c.Threshold = change.GetThreshold()
+	c.Validators = change.GetValidators()
+
+	return c, nil
+}
+
+// NewQuorumApprovalsComposite returns a new composite quorum approvals mutation.
+// Unlike node approvals, it only requires approvals by a threshold of existing operators.
+func NewQuorumApprovalsComposite(approvals []*manifestpb.SignedMutation) (*manifestpb.SignedMutation, error) {
+	nodeApprovals, err := NewNodeApprovalsComposite(approvals)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeApprovals.Mutation.Type = string(TypeQuorumApprovals)
+
+	return nodeApprovals, nil
+}
+
+// transformQuorumApprovals verifies that the quorum approvals are signed by at least threshold distinct cluster operators.
+func transformQuorumApprovals(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	if err := verifyEmptySig(signed); err != nil {
+		return c, errors.Wrap(err, "verify empty sig")
+	}
+
+	if MutationType(signed.GetMutation().GetType()) != TypeQuorumApprovals {
+		return c, errors.New("invalid mutation type")
+	}
+
+	list := new(manifestpb.SignedMutationList)
+	if err := signed.GetMutation().GetData().UnmarshalTo(list); err != nil {
+		return c, errors.New("invalid quorum approvals data")
+	}
+
+	peers, err := ClusterPeers(c)
+	if err != nil {
+		return c, errors.Wrap(err, "get peers")
+	}
+
+	operators := make(map[string]bool)
+	for _, p := range peers {
+		pubkey, err := p.PublicKey()
+		if err != nil {
+			return c, errors.Wrap(err, "get peer public key")
+		}
+
+		operators[string(pubkey.SerializeCompressed())] = true
+	}
+
+	approved := make(map[string]bool)
+	for _, approval := range list.GetMutations() {
+		if !bytes.Equal(signed.GetMutation().GetParent(), approval.GetMutation().GetParent()) {
+			return c, errors.New("mismatching quorum approvals parent")
+		}
+
+		signer := string(approval.GetSigner())
+		if !operators[signer] {
+			return c, errors.New("quorum approval signer not a cluster operator")
+		} else if approved[signer] {
+			return c, errors.New("duplicate quorum approval signer")
+		}
+		approved[signer] = true
+
+		c, err = Transform(c, approval)
+		if err != nil {
+			return c, errors.Wrap(err, "transform node approval")
+		}
+	}
+
+	if len(approved) < int(c.GetThreshold()) {
+		return c, errors.New("insufficient quorum approvals",
+			z.Int("approvals", len(approved)), z.I64("threshold", int64(c.GetThreshold())))
+	}
+
+	return c, nil
+}
+
+// NewAddOperator creates a new composite add operator mutation from the provided reshare operators
+// mutation adding a single operator and the quorum approvals of the existing operators.
+func NewAddOperator(reshare, quorumApprovals *manifestpb.SignedMutation) (*manifestpb.SignedMutation, error) {
+	return newOperatorChange(TypeAddOperator, reshare, quorumApprovals)
+}
+
+// NewRemoveOperator creates a new composite remove operator mutation from the provided reshare operators
+// mutation removing a single operator and the quorum approvals of the existing operators.
+func NewRemoveOperator(reshare, quorumApprovals *manifestpb.SignedMutation) (*manifestpb.SignedMutation, error) {
+	return newOperatorChange(TypeRemoveOperator, reshare, quorumApprovals)
+}
+
+// newOperatorChange creates a new composite operator change mutation of the provided type.
+func newOperatorChange(typ MutationType, reshare, quorumApprovals *manifestpb.SignedMutation) (*manifestpb.SignedMutation, error) {
+	if MutationType(reshare.GetMutation().GetType()) != TypeReshareOperators {
+		return nil, errors.New("invalid reshare operators mutation type")
+	}
+
+	if MutationType(quorumApprovals.GetMutation().GetType()) != TypeQuorumApprovals {
+		return nil, errors.New("invalid quorum approvals mutation type")
+	}
+
+	dataAny, err := anypb.New(&manifestpb.SignedMutationList{
+		Mutations: []*manifestpb.SignedMutation{reshare, quorumApprovals},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal signed mutation list")
+	}
+
+	return &manifestpb.SignedMutation{
+		Mutation: &manifestpb.Mutation{
+			Parent: reshare.GetMutation().GetParent(),
+			Type:   string(typ),
+			Data:   dataAny,
+		},
+		// Composite mutations have no signer or signature.
+	}, nil
+}
+
+// transformAddOperator transforms the cluster manifest with the add operator mutation.
+func transformAddOperator(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	return transformOperatorChange(c, signed, TypeAddOperator, func(prev, next []*manifestpb.Operator) bool {
+		return len(next) == len(prev)+1 && operatorsEqual(prev, next[:len(prev)])
+	})
+}
+
+// transformRemoveOperator transforms the cluster manifest with the remove operator mutation.
+func transformRemoveOperator(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	return transformOperatorChange(c, signed, TypeRemoveOperator, func(prev, next []*manifestpb.Operator) bool {
+		if len(next) != len(prev)-1 {
+			return false
+		}
+
+		for i := range prev {
+			remaining := append(append([]*manifestpb.Operator(nil), prev[:i]...), prev[i+1:]...)
+			if operatorsEqual(remaining, next) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// transformOperatorChange verifies the quorum approvals by the existing operators, ensures the change of operators
+// is valid according to validChange and then applies the reshare operators mutation.
+func transformOperatorChange(c *manifestpb.Cluster, signed *manifestpb.SignedMutation, typ MutationType,
+	validChange func(prev, next []*manifestpb.Operator) bool,
+) (*manifestpb.Cluster, error) {
+	if err := verifyEmptySig(signed); err != nil {
+		return c, errors.Wrap(err, "verify empty sig")
+	}
+
+	if MutationType(signed.GetMutation().GetType()) != typ {
+		return c, errors.New("invalid mutation type")
+	}
+
+	list := new(manifestpb.SignedMutationList)
+	if err := signed.GetMutation().GetData().UnmarshalTo(list); err != nil {
+		return c, errors.Wrap(err, "unmarshal signed mutation list")
+	} else if len(list.GetMutations()) != 2 {
+		return c, errors.New("invalid mutation list length")
+	}
+
+	reshare := list.GetMutations()[0]
+	quorumApprovals := list.GetMutations()[1]
+
+	if MutationType(reshare.GetMutation().GetType()) != TypeReshareOperators {
+		return c, errors.New("invalid reshare operators mutation type")
+	}
+	if !bytes.Equal(signed.GetMutation().GetParent(), reshare.GetMutation().GetParent()) {
+		return c, errors.New("invalid reshare operators parent")
+	}
+
+	if MutationType(quorumApprovals.GetMutation().GetType()) != TypeQuorumApprovals {
+		return c, errors.New("invalid quorum approvals mutation type")
+	}
+
+	reshareHash, err := Hash(reshare)
+	if err != nil {
+		return c, errors.Wrap(err, "hash reshare operators")
+	}
+	if !bytes.Equal(reshareHash, quorumApprovals.GetMutation().GetParent()) {
+		return c, errors.New("invalid quorum approvals parent")
+	}
+
+	change := new(manifestpb.OperatorChange)
+	if err := reshare.GetMutation().GetData().UnmarshalTo(change); err != nil {
+		return c, errors.Wrap(err, "unmarshal operator change")
+	}
+
+	if !validChange(c.GetOperators(), change.GetOperators()) {
+		return c, errors.New("invalid operator change", z.Str("type", typ.String()))
+	}
+
+	// Approvals are verified against the existing operators before they are replaced.
+	c, err = Transform(c, quorumApprovals)
+	if err != nil {
+		return c, errors.Wrap(err, "transform quorum approvals")
+	}
+
+	c, err = Transform(c, reshare)
+	if err != nil {
+		return c, errors.Wrap(err, "transform reshare operators")
+	}
+
+	return c, nil
+}
+
+// operatorsEqual returns true if the operator lists are identical.
+func operatorsEqual(a, b []*manifestpb.Operator) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
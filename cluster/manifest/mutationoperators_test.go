@@ -0,0 +1,219 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest_test
+
+import (
+	"math/rand"
+	"testing"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+//go:generate go test . -update -run=TestAddOperator
+
+func TestAddOperator(t *testing.T) {
+	setIncrementingTime(t)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, _ := cluster.NewForT(t, 2, 3, 4, seed, random)
+
+	c, err := manifest.NewClusterFromLockForT(t, lock)
+	require.NoError(t, err)
+
+	newKey := testutil.GenerateInsecureK1Key(t, 100)
+	record, err := enr.New(newKey)
+	require.NoError(t, err)
+
+	operators := append(cloneOperators(c), &manifestpb.Operator{Enr: record.String()})
+	reshare, err := manifest.NewReshareOperators(testutil.RandomBytes32Seed(random), operators, 4,
+		reshareValidators(c, len(operators), random))
+	require.NoError(t, err)
+
+	addOperator := newOperatorChangeForT(t, manifest.NewAddOperator, reshare, secrets[:3])
+
+	t.Run("proto", func(t *testing.T) {
+		testutil.RequireGoldenProto(t, addOperator)
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		b, err := proto.Marshal(addOperator)
+		require.NoError(t, err)
+
+		addOperator2 := new(manifestpb.SignedMutation)
+		require.NoError(t, proto.Unmarshal(b, addOperator2))
+
+		testutil.RequireProtoEqual(t, addOperator, addOperator2)
+	})
+
+	t.Run("transform", func(t *testing.T) {
+		c, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		c, err = manifest.Transform(c, addOperator)
+		require.NoError(t, err)
+
+		require.Len(t, c.GetOperators(), 5)
+		require.Equal(t, record.String(), c.GetOperators()[4].GetEnr())
+		require.EqualValues(t, 4, c.GetThreshold())
+		for _, val := range c.GetValidators() {
+			require.Len(t, val.GetPubShares(), 5)
+		}
+	})
+
+	t.Run("insufficient approvals", func(t *testing.T) {
+		c, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(c, newOperatorChangeForT(t, manifest.NewAddOperator, reshare, secrets[:2]))
+		require.ErrorContains(t, err, "insufficient quorum approvals")
+	})
+
+	t.Run("non-operator approval", func(t *testing.T) {
+		c, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		signers := []*k1.PrivateKey{secrets[0], secrets[1], newKey}
+		_, err = manifest.Transform(c, newOperatorChangeForT(t, manifest.NewAddOperator, reshare, signers))
+		require.ErrorContains(t, err, "quorum approval signer not a cluster operator")
+	})
+
+	t.Run("remove type", func(t *testing.T) {
+		c, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(c, newOperatorChangeForT(t, manifest.NewRemoveOperator, reshare, secrets[:3]))
+		require.ErrorContains(t, err, "invalid operator change")
+	})
+}
+
+func TestRemoveOperator(t *testing.T) {
+	setIncrementingTime(t)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, _ := cluster.NewForT(t, 2, 3, 4, seed, random)
+
+	c, err := manifest.NewClusterFromLockForT(t, lock)
+	require.NoError(t, err)
+
+	operators := cloneOperators(c)
+	operators = append(operators[:1], operators[2:]...) // Remove the second operator.
+
+	reshare, err := manifest.NewReshareOperators(c.GetLatestMutationHash(), operators, 2,
+		reshareValidators(c, len(operators), random))
+	require.NoError(t, err)
+
+	t.Run("transform", func(t *testing.T) {
+		c, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		// Approvals by the remaining operators.
+		signers := []*k1.PrivateKey{secrets[0], secrets[2], secrets[3]}
+		c, err = manifest.Transform(c, newOperatorChangeForT(t, manifest.NewRemoveOperator, reshare, signers))
+		require.NoError(t, err)
+
+		testutil.RequireProtosEqual(t, operators, c.GetOperators())
+		require.EqualValues(t, 2, c.GetThreshold())
+	})
+
+	t.Run("duplicate approval", func(t *testing.T) {
+		c, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		signers := []*k1.PrivateKey{secrets[0], secrets[2], secrets[2]}
+		_, err = manifest.Transform(c, newOperatorChangeForT(t, manifest.NewRemoveOperator, reshare, signers))
+		require.ErrorContains(t, err, "duplicate quorum approval signer")
+	})
+
+	t.Run("changed validator", func(t *testing.T) {
+		c, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		vals := reshareValidators(c, len(operators), random)
+		vals[0].PublicKey = testutil.RandomBytes48()
+
+		reshare, err := manifest.NewReshareOperators(c.GetLatestMutationHash(), operators, 2, vals)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(c, newOperatorChangeForT(t, manifest.NewRemoveOperator, reshare, secrets[:3]))
+		require.ErrorContains(t, err, "reshared validator public key mismatch")
+	})
+}
+
+func TestNewReshareOperators(t *testing.T) {
+	parent := testutil.RandomBytes32()
+	ops := []*manifestpb.Operator{{Enr: "a"}, {Enr: "b"}}
+	vals := []*manifestpb.Validator{{PubShares: [][]byte{{1}, {2}}}}
+
+	_, err := manifest.NewReshareOperators(parent, ops, 3, vals)
+	require.ErrorContains(t, err, "invalid threshold")
+
+	_, err = manifest.NewReshareOperators(parent, []*manifestpb.Operator{{Enr: "a"}, {Enr: "a"}}, 2, vals)
+	require.ErrorContains(t, err, "duplicate operator enr")
+
+	_, err = manifest.NewReshareOperators(parent, append(ops, &manifestpb.Operator{Enr: "c"}), 2, vals)
+	require.ErrorContains(t, err, "validator public shares count doesn't match operators")
+
+	_, err = manifest.NewReshareOperators(parent, ops, 2, vals)
+	require.NoError(t, err)
+}
+
+// cloneOperators returns a copy of the cluster operators.
+func cloneOperators(c *manifestpb.Cluster) []*manifestpb.Operator {
+	var resp []*manifestpb.Operator
+	for _, op := range c.GetOperators() {
+		resp = append(resp, proto.Clone(op).(*manifestpb.Operator))
+	}
+
+	return resp
+}
+
+// reshareValidators returns a copy of the cluster validators with random public shares for numOps operators.
+func reshareValidators(c *manifestpb.Cluster, numOps int, random *rand.Rand) []*manifestpb.Validator {
+	var resp []*manifestpb.Validator
+	for _, val := range c.GetValidators() {
+		val = proto.Clone(val).(*manifestpb.Validator)
+		val.PubShares = nil
+		for range numOps {
+			val.PubShares = append(val.PubShares, testutil.RandomBytes48Seed(random))
+		}
+		resp = append(resp, val)
+	}
+
+	return resp
+}
+
+// newOperatorChangeForT returns a composite operator change mutation approved by the provided signers.
+func newOperatorChangeForT(t *testing.T, newFunc func(reshare, approvals *manifestpb.SignedMutation) (*manifestpb.SignedMutation, error),
+	reshare *manifestpb.SignedMutation, signers []*k1.PrivateKey,
+) *manifestpb.SignedMutation {
+	t.Helper()
+
+	reshareHash, err := manifest.Hash(reshare)
+	require.NoError(t, err)
+
+	var approvals []*manifestpb.SignedMutation
+	for _, signer := range signers {
+		approval, err := manifest.SignNodeApproval(reshareHash, signer)
+		require.NoError(t, err)
+
+		approvals = append(approvals, approval)
+	}
+
+	quorumApprovals, err := manifest.NewQuorumApprovalsComposite(approvals)
+	require.NoError(t, err)
+
+	resp, err := newFunc(reshare, quorumApprovals)
+	require.NoError(t, err)
+
+	return resp
+}
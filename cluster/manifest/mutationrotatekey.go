@@ -0,0 +1,221 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest
+
+import (
+	"bytes"
+
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/enr"
+)
+
+// NewOperatorKeyRotation creates a new operator key rotation mutation that replaces the ENR of
+// a single operator after the operator replaced its ENR private key.
+func NewOperatorKeyRotation(parent []byte, oldENR, newENR string) (*manifestpb.SignedMutation, error) {
+	if len(parent) != hashLen {
+		return nil, errors.New("invalid parent hash")
+	}
+
+	rotation := &manifestpb.OperatorKeyRotation{
+		OldEnr: oldENR,
+		NewEnr: newENR,
+	}
+
+	if err := verifyOperatorKeyRotation(rotation); err != nil {
+		return nil, errors.Wrap(err, "verify operator key rotation")
+	}
+
+	rotationAny, err := anypb.New(rotation)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal operator key rotation")
+	}
+
+	return &manifestpb.SignedMutation{
+		Mutation: &manifestpb.Mutation{
+			Parent: parent,
+			Type:   string(TypeOperatorKeyRotation),
+			Data:   rotationAny,
+		},
+		// No signer or signature.
+	}, nil
+}
+
+// verifyOperatorKeyRotation returns an error if the operator key rotation is invalid.
+func verifyOperatorKeyRotation(rotation *manifestpb.OperatorKeyRotation) error {
+	if _, err := enr.Parse(rotation.GetNewEnr()); err != nil {
+		return errors.Wrap(err, "invalid new operator enr", z.Redact(z.Str("enr", rotation.GetNewEnr())))
+	}
+
+	if rotation.GetOldEnr() == rotation.GetNewEnr() {
+		return errors.New("operator enr unchanged")
+	}
+
+	return nil
+}
+
+// transformOperatorKeyRotation replaces the ENR of the rotated operator.
+func transformOperatorKeyRotation(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	if err := verifyEmptySig(signed); err != nil {
+		return c, errors.Wrap(err, "verify empty sig")
+	}
+
+	if MutationType(signed.GetMutation().GetType()) != TypeOperatorKeyRotation {
+		return c, errors.New("invalid mutation type")
+	}
+
+	rotation := new(manifestpb.OperatorKeyRotation)
+	if err := signed.GetMutation().GetData().UnmarshalTo(rotation); err != nil {
+		return c, errors.Wrap(err, "unmarshal operator key rotation")
+	}
+
+	if err := verifyOperatorKeyRotation(rotation); err != nil {
+		return c, errors.Wrap(err, "verify operator key rotation")
+	}
+
+	opIdx := -1
+	for i, op := range c.GetOperators() {
+		if op.GetEnr() == rotation.GetNewEnr() {
+			return c, errors.New("new operator enr already in cluster", z.Redact(z.Str("enr", rotation.GetNewEnr())))
+		} else if op.GetEnr() == rotation.GetOldEnr() {
+			opIdx = i
+		}
+	}
+
+	if opIdx < 0 {
+		return c, errors.New("rotated operator not in cluster", z.Redact(z.Str("enr", rotation.GetOldEnr())))
+	}
+
+	// The operator keeps its position, address and validator key shares.
+	c.Operators[opIdx] = &manifestpb.Operator{
+		Address: c.GetOperators()[opIdx].GetAddress(),
+		Enr:     rotation.GetNewEnr(),
+	}
+
+	return c, nil
+}
+
+// NewRotateOperatorKey creates a new composite rotate operator key mutation from the provided operator key rotation
+// mutation, the quorum approvals of the other existing operators and the node approval signed by the operator's
+// new key, proving possession of the new key.
+func NewRotateOperatorKey(rotation, quorumApprovals, newKeyApproval *manifestpb.SignedMutation) (*manifestpb.SignedMutation, error) {
+	if MutationType(rotation.GetMutation().GetType()) != TypeOperatorKeyRotation {
+		return nil, errors.New("invalid operator key rotation mutation type")
+	}
+
+	if MutationType(quorumApprovals.GetMutation().GetType()) != TypeQuorumApprovals {
+		return nil, errors.New("invalid quorum approvals mutation type")
+	}
+
+	if err := verifyNodeApproval(newKeyApproval); err != nil {
+		return nil, errors.Wrap(err, "verify new key approval")
+	}
+
+	dataAny, err := anypb.New(&manifestpb.SignedMutationList{
+		Mutations: []*manifestpb.SignedMutation{rotation, quorumApprovals, newKeyApproval},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal signed mutation list")
+	}
+
+	return &manifestpb.SignedMutation{
+		Mutation: &manifestpb.Mutation{
+			Parent: rotation.GetMutation().GetParent(),
+			Type:   string(TypeRotateOperatorKey),
+			Data:   dataAny,
+		},
+		// Composite mutations have no signer or signature.
+	}, nil
+}
+
+// transformRotateOperatorKey verifies the approvals of the operator key rotation by a quorum of the other existing
+// operators and by the operator's new key, then applies the operator key rotation mutation.
+// The operator's previous key may not approve its own rotation, since it may have been compromised.
+func transformRotateOperatorKey(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	if err := verifyEmptySig(signed); err != nil {
+		return c, errors.Wrap(err, "verify empty sig")
+	}
+
+	if MutationType(signed.GetMutation().GetType()) != TypeRotateOperatorKey {
+		return c, errors.New("invalid mutation type")
+	}
+
+	list := new(manifestpb.SignedMutationList)
+	if err := signed.GetMutation().GetData().UnmarshalTo(list); err != nil {
+		return c, errors.Wrap(err, "unmarshal signed mutation list")
+	} else if len(list.GetMutations()) != 3 {
+		return c, errors.New("invalid mutation list length")
+	}
+
+	rotationMutation := list.GetMutations()[0]
+	quorumApprovals := list.GetMutations()[1]
+	newKeyApproval := list.GetMutations()[2]
+
+	if MutationType(rotationMutation.GetMutation().GetType()) != TypeOperatorKeyRotation {
+		return c, errors.New("invalid operator key rotation mutation type")
+	}
+	if !bytes.Equal(signed.GetMutation().GetParent(), rotationMutation.GetMutation().GetParent()) {
+		return c, errors.New("invalid operator key rotation parent")
+	}
+
+	rotationHash, err := Hash(rotationMutation)
+	if err != nil {
+		return c, errors.Wrap(err, "hash operator key rotation")
+	}
+
+	if MutationType(quorumApprovals.GetMutation().GetType()) != TypeQuorumApprovals {
+		return c, errors.New("invalid quorum approvals mutation type")
+	}
+	if !bytes.Equal(rotationHash, quorumApprovals.GetMutation().GetParent()) {
+		return c, errors.New("invalid quorum approvals parent")
+	}
+
+	if err := verifyNodeApproval(newKeyApproval); err != nil {
+		return c, errors.Wrap(err, "verify new key approval")
+	}
+	if !bytes.Equal(rotationHash, newKeyApproval.GetMutation().GetParent()) {
+		return c, errors.New("invalid new key approval parent")
+	}
+
+	rotation := new(manifestpb.OperatorKeyRotation)
+	if err := rotationMutation.GetMutation().GetData().UnmarshalTo(rotation); err != nil {
+		return c, errors.Wrap(err, "unmarshal operator key rotation")
+	}
+
+	newRecord, err := enr.Parse(rotation.GetNewEnr())
+	if err != nil {
+		return c, errors.Wrap(err, "invalid new operator enr")
+	}
+	if !bytes.Equal(newRecord.PubKey.SerializeCompressed(), newKeyApproval.GetSigner()) {
+		return c, errors.New("new key approval not signed by new operator key")
+	}
+
+	if oldRecord, err := enr.Parse(rotation.GetOldEnr()); err == nil {
+		approvals := new(manifestpb.SignedMutationList)
+		if err := quorumApprovals.GetMutation().GetData().UnmarshalTo(approvals); err != nil {
+			return c, errors.Wrap(err, "unmarshal quorum approvals")
+		}
+
+		for _, approval := range approvals.GetMutations() {
+			if bytes.Equal(oldRecord.PubKey.SerializeCompressed(), approval.GetSigner()) {
+				return c, errors.New("rotated operator key may not approve its own rotation")
+			}
+		}
+	}
+
+	// Approvals are verified against the existing operators before the operator's key is replaced.
+	c, err = Transform(c, quorumApprovals)
+	if err != nil {
+		return c, errors.Wrap(err, "transform quorum approvals")
+	}
+
+	c, err = Transform(c, rotationMutation)
+	if err != nil {
+		return c, errors.Wrap(err, "transform operator key rotation")
+	}
+
+	return c, nil
+}
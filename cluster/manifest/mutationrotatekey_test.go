@@ -0,0 +1,133 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest_test
+
+import (
+	"math/rand"
+	"testing"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRotateOperatorKey(t *testing.T) {
+	setIncrementingTime(t)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, _ := cluster.NewForT(t, 2, 3, 4, seed, random)
+
+	c, err := manifest.NewClusterFromLockForT(t, lock)
+	require.NoError(t, err)
+
+	newKey := testutil.GenerateInsecureK1Key(t, 100)
+	record, err := enr.New(newKey)
+	require.NoError(t, err)
+
+	// Rotate the key of the first operator.
+	oldENR := c.GetOperators()[0].GetEnr()
+	rotation, err := manifest.NewOperatorKeyRotation(c.GetLatestMutationHash(), oldENR, record.String())
+	require.NoError(t, err)
+
+	t.Run("transform", func(t *testing.T) {
+		c, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		rotate := newRotateOperatorKeyForT(t, rotation, secrets[1:], newKey)
+
+		b, err := proto.Marshal(rotate)
+		require.NoError(t, err)
+		rotate2 := new(manifestpb.SignedMutation)
+		require.NoError(t, proto.Unmarshal(b, rotate2))
+		testutil.RequireProtoEqual(t, rotate, rotate2)
+
+		prev := proto.Clone(c).(*manifestpb.Cluster)
+		c, err = manifest.Transform(c, rotate)
+		require.NoError(t, err)
+
+		require.Equal(t, record.String(), c.GetOperators()[0].GetEnr())
+		require.Equal(t, prev.GetOperators()[0].GetAddress(), c.GetOperators()[0].GetAddress())
+		testutil.RequireProtosEqual(t, prev.GetOperators()[1:], c.GetOperators()[1:])
+		testutil.RequireProtosEqual(t, prev.GetValidators(), c.GetValidators())
+		require.Equal(t, prev.GetThreshold(), c.GetThreshold())
+	})
+
+	t.Run("insufficient approvals", func(t *testing.T) {
+		c, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(c, newRotateOperatorKeyForT(t, rotation, secrets[1:3], newKey))
+		require.ErrorContains(t, err, "insufficient quorum approvals")
+	})
+
+	t.Run("old key approval", func(t *testing.T) {
+		c, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(c, newRotateOperatorKeyForT(t, rotation, secrets[:3], newKey))
+		require.ErrorContains(t, err, "rotated operator key may not approve its own rotation")
+	})
+
+	t.Run("new key approval by other key", func(t *testing.T) {
+		c, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		otherKey := testutil.GenerateInsecureK1Key(t, 101)
+		_, err = manifest.Transform(c, newRotateOperatorKeyForT(t, rotation, secrets[1:], otherKey))
+		require.ErrorContains(t, err, "new key approval not signed by new operator key")
+	})
+
+	t.Run("unknown operator", func(t *testing.T) {
+		c, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		otherRecord, err := enr.New(testutil.GenerateInsecureK1Key(t, 101))
+		require.NoError(t, err)
+
+		rotation, err := manifest.NewOperatorKeyRotation(c.GetLatestMutationHash(), otherRecord.String(), record.String())
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(c, newRotateOperatorKeyForT(t, rotation, secrets[1:], newKey))
+		require.ErrorContains(t, err, "rotated operator not in cluster")
+	})
+
+	t.Run("existing operator enr", func(t *testing.T) {
+		_, err := manifest.NewOperatorKeyRotation(c.GetLatestMutationHash(), oldENR, oldENR)
+		require.ErrorContains(t, err, "operator enr unchanged")
+	})
+}
+
+// newRotateOperatorKeyForT returns a composite rotate operator key mutation approved by the provided signers
+// and the new operator key.
+func newRotateOperatorKeyForT(t *testing.T, rotation *manifestpb.SignedMutation, signers []*k1.PrivateKey, newKey *k1.PrivateKey) *manifestpb.SignedMutation {
+	t.Helper()
+
+	rotationHash, err := manifest.Hash(rotation)
+	require.NoError(t, err)
+
+	var approvals []*manifestpb.SignedMutation
+	for _, signer := range signers {
+		approval, err := manifest.SignNodeApproval(rotationHash, signer)
+		require.NoError(t, err)
+
+		approvals = append(approvals, approval)
+	}
+
+	quorumApprovals, err := manifest.NewQuorumApprovalsComposite(approvals)
+	require.NoError(t, err)
+
+	newKeyApproval, err := manifest.SignNodeApproval(rotationHash, newKey)
+	require.NoError(t, err)
+
+	resp, err := manifest.NewRotateOperatorKey(rotation, quorumApprovals, newKeyApproval)
+	require.NoError(t, err)
+
+	return resp
+}
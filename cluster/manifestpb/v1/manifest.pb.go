@@ -563,6 +563,121 @@ func (*Empty) Descriptor() ([]byte, []int) {
 	return file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP(), []int{8}
 }
 
+// OperatorChange represents the cluster operators, threshold and validator public shares after resharing the
+// validator keys to a changed set of operators.
+type OperatorChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operators     []*Operator            `protobuf:"bytes,1,rep,name=operators,proto3" json:"operators,omitempty"`   // Operators is the new list of operators of the cluster.
+	Threshold     int32                  `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`  // Threshold is the new threshold of the cluster.
+	Validators    []*Validator           `protobuf:"bytes,3,rep,name=validators,proto3" json:"validators,omitempty"` // Validators is the list of validators of the cluster with reshared public shares.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperatorChange) Reset() {
+	*x = OperatorChange{}
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperatorChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorChange) ProtoMessage() {}
+
+func (x *OperatorChange) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorChange.ProtoReflect.Descriptor instead.
+func (*OperatorChange) Descriptor() ([]byte, []int) {
+	return file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OperatorChange) GetOperators() []*Operator {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
+
+func (x *OperatorChange) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *OperatorChange) GetValidators() []*Validator {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+// OperatorKeyRotation represents the replacement of a single operator's ENR private key.
+type OperatorKeyRotation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldEnr        string                 `protobuf:"bytes,1,opt,name=old_enr,json=oldEnr,proto3" json:"old_enr,omitempty"` // OldEnr is the ENR of the operator's previous private key.
+	NewEnr        string                 `protobuf:"bytes,2,opt,name=new_enr,json=newEnr,proto3" json:"new_enr,omitempty"` // NewEnr is the ENR of the operator's new private key.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperatorKeyRotation) Reset() {
+	*x = OperatorKeyRotation{}
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperatorKeyRotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorKeyRotation) ProtoMessage() {}
+
+func (x *OperatorKeyRotation) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorKeyRotation.ProtoReflect.Descriptor instead.
+func (*OperatorKeyRotation) Descriptor() ([]byte, []int) {
+	return file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OperatorKeyRotation) GetOldEnr() string {
+	if x != nil {
+		return x.OldEnr
+	}
+	return ""
+}
+
+func (x *OperatorKeyRotation) GetNewEnr() string {
+	if x != nil {
+		return x.NewEnr
+	}
+	return ""
+}
+
 var File_cluster_manifestpb_v1_manifest_proto protoreflect.FileDescriptor
 
 var file_cluster_manifestpb_v1_manifest_proto_rawDesc = string([]byte{
@@ -646,11 +761,26 @@ var file_cluster_manifestpb_v1_manifest_proto_rawDesc = string([]byte{
 	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x20, 0x0a, 0x0a, 0x4c, 0x65, 0x67, 0x61, 0x63,
 	0x79, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0c, 0x52, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x68, 0x61,
-	0x72, 0x6f, 0x6e, 0x2f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2f, 0x6d, 0x61, 0x6e, 0x69,
-	0x66, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x74, 0x79, 0x22, 0xaf, 0x01, 0x0a, 0x0e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x3d, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x12, 0x40, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x22, 0x47, 0x0a, 0x13, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x4b, 0x65, 0x79, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x6f,
+	0x6c, 0x64, 0x5f, 0x65, 0x6e, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x6c,
+	0x64, 0x45, 0x6e, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x6e, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x65, 0x77, 0x45, 0x6e, 0x72, 0x42, 0x35, 0x5a,
+	0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x68, 0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x63,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2f, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x70,
+	0x62, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -665,31 +795,35 @@ func file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP() []byte {
 	return file_cluster_manifestpb_v1_manifest_proto_rawDescData
 }
 
-var file_cluster_manifestpb_v1_manifest_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_cluster_manifestpb_v1_manifest_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_cluster_manifestpb_v1_manifest_proto_goTypes = []any{
-	(*Cluster)(nil),            // 0: cluster.manifestpb.v1.Cluster
-	(*Mutation)(nil),           // 1: cluster.manifestpb.v1.Mutation
-	(*SignedMutation)(nil),     // 2: cluster.manifestpb.v1.SignedMutation
-	(*SignedMutationList)(nil), // 3: cluster.manifestpb.v1.SignedMutationList
-	(*Operator)(nil),           // 4: cluster.manifestpb.v1.Operator
-	(*Validator)(nil),          // 5: cluster.manifestpb.v1.Validator
-	(*ValidatorList)(nil),      // 6: cluster.manifestpb.v1.ValidatorList
-	(*LegacyLock)(nil),         // 7: cluster.manifestpb.v1.LegacyLock
-	(*Empty)(nil),              // 8: cluster.manifestpb.v1.Empty
-	(*anypb.Any)(nil),          // 9: google.protobuf.Any
+	(*Cluster)(nil),             // 0: cluster.manifestpb.v1.Cluster
+	(*Mutation)(nil),            // 1: cluster.manifestpb.v1.Mutation
+	(*SignedMutation)(nil),      // 2: cluster.manifestpb.v1.SignedMutation
+	(*SignedMutationList)(nil),  // 3: cluster.manifestpb.v1.SignedMutationList
+	(*Operator)(nil),            // 4: cluster.manifestpb.v1.Operator
+	(*Validator)(nil),           // 5: cluster.manifestpb.v1.Validator
+	(*ValidatorList)(nil),       // 6: cluster.manifestpb.v1.ValidatorList
+	(*LegacyLock)(nil),          // 7: cluster.manifestpb.v1.LegacyLock
+	(*Empty)(nil),               // 8: cluster.manifestpb.v1.Empty
+	(*OperatorChange)(nil),      // 9: cluster.manifestpb.v1.OperatorChange
+	(*OperatorKeyRotation)(nil), // 10: cluster.manifestpb.v1.OperatorKeyRotation
+	(*anypb.Any)(nil),           // 11: google.protobuf.Any
 }
 var file_cluster_manifestpb_v1_manifest_proto_depIdxs = []int32{
-	4, // 0: cluster.manifestpb.v1.Cluster.operators:type_name -> cluster.manifestpb.v1.Operator
-	5, // 1: cluster.manifestpb.v1.Cluster.validators:type_name -> cluster.manifestpb.v1.Validator
-	9, // 2: cluster.manifestpb.v1.Mutation.data:type_name -> google.protobuf.Any
-	1, // 3: cluster.manifestpb.v1.SignedMutation.mutation:type_name -> cluster.manifestpb.v1.Mutation
-	2, // 4: cluster.manifestpb.v1.SignedMutationList.mutations:type_name -> cluster.manifestpb.v1.SignedMutation
-	5, // 5: cluster.manifestpb.v1.ValidatorList.validators:type_name -> cluster.manifestpb.v1.Validator
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	4,  // 0: cluster.manifestpb.v1.Cluster.operators:type_name -> cluster.manifestpb.v1.Operator
+	5,  // 1: cluster.manifestpb.v1.Cluster.validators:type_name -> cluster.manifestpb.v1.Validator
+	11, // 2: cluster.manifestpb.v1.Mutation.data:type_name -> google.protobuf.Any
+	1,  // 3: cluster.manifestpb.v1.SignedMutation.mutation:type_name -> cluster.manifestpb.v1.Mutation
+	2,  // 4: cluster.manifestpb.v1.SignedMutationList.mutations:type_name -> cluster.manifestpb.v1.SignedMutation
+	5,  // 5: cluster.manifestpb.v1.ValidatorList.validators:type_name -> cluster.manifestpb.v1.Validator
+	4,  // 6: cluster.manifestpb.v1.OperatorChange.operators:type_name -> cluster.manifestpb.v1.Operator
+	5,  // 7: cluster.manifestpb.v1.OperatorChange.validators:type_name -> cluster.manifestpb.v1.Validator
+	8,  // [8:8] is the sub-list for method output_type
+	8,  // [8:8] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_cluster_manifestpb_v1_manifest_proto_init() }
@@ -703,7 +837,7 @@ func file_cluster_manifestpb_v1_manifest_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cluster_manifestpb_v1_manifest_proto_rawDesc), len(file_cluster_manifestpb_v1_manifest_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
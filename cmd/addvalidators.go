@@ -563,7 +563,7 @@ func printPubkeys(ctx context.Context, vals []*manifestpb.Validator) {
 			log.Error(ctx, "Cannot log validator public key", err)
 			continue
 		}
-		log.Debug(ctx, "Created new validator", z.Str("pubkey", pk))
+		log.Debug(ctx, "Created new validator", z.Redact(z.Str("pubkey", pk)))
 	}
 }
 
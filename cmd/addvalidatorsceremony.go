@@ -0,0 +1,56 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/dkg"
+)
+
+func newAddValidatorsCeremonyCmd(runFunc func(context.Context, dkg.AddValidatorsConfig) error) *cobra.Command {
+	var config dkg.AddValidatorsConfig
+
+	cmd := &cobra.Command{
+		Use:   "add-validators",
+		Short: "Add new distributed validators to an existing cluster",
+		Long: `Participate in a ceremony adding new distributed validators to an existing cluster. All operators run a DKG
+generating key shares for the new validators and approve the new cluster manifest containing them. The new cluster
+manifest, all validator keys and the deposit data of the new validators are written to the output directory.
+Note that all operators should run this command at the same time with identical flags.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printLicense(cmd.Context())
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	cmd.Flags().IntVar(&config.NumValidators, "num-validators", 1, "The count of new distributed validators to add to the cluster.")
+	cmd.Flags().StringSliceVar(&config.FeeRecipientAddrs, "fee-recipient-addresses", nil, "Comma separated list of Ethereum addresses of the fee recipient for each new validator. Either provide a single fee recipient address or fee recipient addresses for each validator.")
+	cmd.Flags().StringSliceVar(&config.WithdrawalAddrs, "withdrawal-addresses", nil, "Comma separated list of Ethereum addresses to receive the returned stake and accrued rewards for each new validator. Either provide a single withdrawal address or withdrawal addresses for each validator.")
+	bindDataDirFlag(cmd.Flags(), &config.DataDir)
+	cmd.Flags().StringVar(&config.OutputDir, "output-dir", ".charon/added", "The directory where the new cluster manifest, validator keys and deposit data are stored.")
+	bindNoVerifyFlag(cmd.Flags(), &config.NoVerify)
+	bindP2PFlags(cmd, &config.P2P)
+	bindLogFlags(cmd.Flags(), &config.Log)
+	bindShutdownDelayFlag(cmd.Flags(), &config.ShutdownDelay)
+
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 1*time.Minute, "Timeout for the add validators process, should be increased if it times out.")
+
+	mustMarkFlagRequired(cmd, "fee-recipient-addresses")
+	mustMarkFlagRequired(cmd, "withdrawal-addresses")
+
+	return cmd
+}
@@ -0,0 +1,109 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+)
+
+// attestKeyConfig is the config of the attest operator key command.
+type attestKeyConfig struct {
+	PrivKeyFile    string
+	LockFile       string
+	ManifestFile   string
+	NewOperatorENR string
+	GracePeriod    time.Duration
+	OutputDir      string
+}
+
+func newAttestOperatorKeyCmd(runFunc func(context.Context, io.Writer, attestKeyConfig) error) *cobra.Command {
+	var config attestKeyConfig
+
+	cmd := &cobra.Command{
+		Use:   "attest-operator-key",
+		Short: "Attest a new ENR private key of this operator using its previous key",
+		Long: `Signs an attestation of this operator's new ENR with its previous charon-enr-private-key. Cluster nodes accept
+the new key in place of the previous key until the grace period ends, avoiding a cluster redefinition. All operators
+must copy the attestation to their key attestations directory (--key-attestations-dir of charon run) and restart their
+nodes. The key rotation must be approved by the cluster using 'charon alpha rotate-operator-key' before the grace period ends.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.PrivKeyFile, "private-key-file", ".charon/charon-enr-private-key", "The path to the operator's previous charon enr private key file.")
+	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file.")
+	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.NewOperatorENR, "new-operator-enr", "", "The ENR of the operator's new key.")
+	cmd.Flags().DurationVar(&config.GracePeriod, "grace-period", 7*24*time.Hour, "The period the new key is accepted for, the key rotation must be approved by the cluster before it ends.")
+	cmd.Flags().StringVar(&config.OutputDir, "output-dir", ".charon/key_attestations", "The directory where the key attestation is written.")
+
+	mustMarkFlagRequired(cmd, "new-operator-enr")
+
+	return cmd
+}
+
+// runAttestOperatorKey signs an attestation of the new operator ENR with the operator's previous key and writes it
+// to the output directory.
+func runAttestOperatorKey(_ context.Context, w io.Writer, config attestKeyConfig) error {
+	if config.GracePeriod <= 0 {
+		return errors.New("invalid non-positive --grace-period", z.Any("grace_period", config.GracePeriod))
+	}
+
+	key, err := k1util.Load(config.PrivKeyFile)
+	if err != nil {
+		return errors.Wrap(err, "load previous private key")
+	}
+
+	cluster, err := loadClusterManifest(config.ManifestFile, config.LockFile)
+	if err != nil {
+		return err
+	}
+
+	att, err := manifest.NewKeyAttestation(key, cluster.GetInitialMutationHash(), config.NewOperatorENR, time.Now().Add(config.GracePeriod))
+	if err != nil {
+		return err
+	}
+
+	// Ensure the attestation is accepted by the cluster.
+	if _, applied, err := manifest.ApplyKeyAttestations(cluster, []manifest.KeyAttestation{att}, time.Now()); err != nil {
+		return err
+	} else if len(applied) == 0 {
+		return errors.New("new operator key already in cluster")
+	}
+
+	b, err := json.MarshalIndent(att, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal key attestation")
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0o755); err != nil {
+		return errors.Wrap(err, "create output dir")
+	}
+
+	file := filepath.Join(config.OutputDir, manifest.KeyAttestationFile(att))
+	//nolint:gosec // Key attestations are public.
+	if err := os.WriteFile(file, b, 0o444); err != nil {
+		return errors.Wrap(err, "write key attestation")
+	}
+
+	_, _ = fmt.Fprintf(w, "Wrote key attestation valid until %s: %s\n", att.NotAfter.Format(time.RFC3339), file)
+	_, _ = fmt.Fprintln(w, "Copy it to the key attestations directory of all operators and restart their nodes,")
+	_, _ = fmt.Fprintln(w, "then approve the key rotation with 'charon alpha rotate-operator-key' before the grace period ends.")
+
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestAttestOperatorKey(t *testing.T) {
+	seed := 0
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, _ := cluster.NewForT(t, 1, 3, 4, seed, random)
+
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(lockFile, b, 0o644))
+
+	keyFile := filepath.Join(dir, "charon-enr-private-key")
+	require.NoError(t, k1util.Save(p2pKeys[2], keyFile))
+
+	newRecord, err := enr.New(testutil.GenerateInsecureK1Key(t, 100))
+	require.NoError(t, err)
+
+	config := attestKeyConfig{
+		PrivKeyFile:    keyFile,
+		LockFile:       lockFile,
+		NewOperatorENR: newRecord.String(),
+		GracePeriod:    time.Hour,
+		OutputDir:      filepath.Join(dir, "key_attestations"),
+	}
+	require.NoError(t, runAttestOperatorKey(context.Background(), io.Discard, config))
+
+	atts, err := manifest.LoadKeyAttestations(config.OutputDir)
+	require.NoError(t, err)
+	require.Len(t, atts, 1)
+
+	c, err := loadClusterManifest("", lockFile)
+	require.NoError(t, err)
+
+	c, applied, err := manifest.ApplyKeyAttestations(c, atts, time.Now())
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	require.Equal(t, newRecord.String(), c.GetOperators()[2].GetEnr())
+
+	t.Run("already in cluster", func(t *testing.T) {
+		config := config
+		config.NewOperatorENR = lock.Operators[0].ENR
+		err := runAttestOperatorKey(context.Background(), io.Discard, config)
+		require.ErrorContains(t, err, "attested operator key already in cluster")
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+)
+
+type backupConfig struct {
+	DataDir                string
+	BackupFile             string
+	PassphraseFile         string
+	SlashingProtectionFile string
+	KeystoreOptions        keystore.Options
+	Log                    log.Config
+}
+
+func newBackupCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "backup",
+		Short: "Create and restore encrypted node backups",
+		Long:  "Creates and restores passphrase-encrypted backups of a node's key shares, cluster lock and manifest, ENR private key and slashing protection history.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// bindBackupFlags binds the flags shared by the backup create and restore commands.
+func bindBackupFlags(flags *pflag.FlagSet, config *backupConfig, dataDirUsage string) {
+	flags.StringVar(&config.DataDir, "data-dir", ".charon", dataDirUsage)
+	flags.StringVar(&config.BackupFile, "backup-file", "charon-backup.json", "The path to the encrypted backup file.")
+	flags.StringVar(&config.PassphraseFile, "passphrase-file", "", "The path to a file containing the backup passphrase.")
+	bindLogFlags(flags, &config.Log)
+}
+
+// loadPassphrase returns the passphrase in the file, trimming trailing newlines.
+func loadPassphrase(file string) (string, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return "", errors.Wrap(err, "read passphrase file", z.Str("file", file))
+	}
+
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
@@ -0,0 +1,70 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/backup"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+func newBackupCreateCmd(runFunc func(context.Context, backupConfig) error) *cobra.Command {
+	var config backupConfig
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an encrypted backup of the node",
+		Long: `Creates a single passphrase-encrypted backup file of the node's key shares, cluster lock and manifest,
+ENR private key and optionally the validator client's slashing protection history (EIP-3076 interchange file),
+including an integrity manifest of all files.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindBackupFlags(cmd.Flags(), &config, "The charon data directory to back up.")
+	cmd.Flags().StringVar(&config.SlashingProtectionFile, "slashing-protection-file", "", "Optional path to the validator client's EIP-3076 slashing protection interchange file to include in the backup.")
+	bindKeystoreFlags(cmd.Flags(), &config.KeystoreOptions)
+	mustMarkFlagRequired(cmd, "passphrase-file")
+
+	return cmd
+}
+
+func runBackupCreate(ctx context.Context, config backupConfig) error {
+	if _, err := os.Stat(config.BackupFile); err == nil {
+		return errors.New("backup file already exists", z.Str("file", config.BackupFile))
+	}
+
+	passphrase, err := loadPassphrase(config.PassphraseFile)
+	if err != nil {
+		return err
+	}
+
+	b, manifest, err := backup.Create(config.DataDir, config.SlashingProtectionFile, passphrase, config.KeystoreOptions)
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec // Backup is encrypted, but still restrict read access.
+	if err := os.WriteFile(config.BackupFile, b, 0o400); err != nil {
+		return errors.Wrap(err, "write backup file", z.Str("file", config.BackupFile))
+	}
+
+	log.Info(ctx, "Created encrypted backup, store it and the passphrase separately",
+		z.Str("file", config.BackupFile), z.Int("files", len(manifest.Files)))
+
+	return nil
+}
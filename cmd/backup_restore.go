@@ -0,0 +1,71 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/backup"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+func newBackupRestoreCmd(runFunc func(context.Context, backupConfig) error) *cobra.Command {
+	var config backupConfig
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the node from an encrypted backup",
+		Long: `Decrypts the backup file, verifies the integrity of all files against its manifest and restores them
+to the data directory. Existing files are never overwritten.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindBackupFlags(cmd.Flags(), &config, "The charon data directory to restore to.")
+	mustMarkFlagRequired(cmd, "passphrase-file")
+
+	return cmd
+}
+
+func runBackupRestore(ctx context.Context, config backupConfig) error {
+	b, err := os.ReadFile(config.BackupFile)
+	if err != nil {
+		return errors.Wrap(err, "read backup file", z.Str("file", config.BackupFile))
+	}
+
+	passphrase, err := loadPassphrase(config.PassphraseFile)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := backup.Restore(b, passphrase, config.DataDir)
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Restored backup, verify the key shares with 'charon keys verify'",
+		z.Str("data_dir", config.DataDir), z.Int("files", len(manifest.Files)),
+		z.Str("charon_version", manifest.CharonVersion), z.Any("created_at", manifest.CreatedAt))
+
+	for _, file := range manifest.Files {
+		if file.Path == backup.SlashingProtectionFile {
+			log.Info(ctx, "Import the restored slashing protection history into the validator client before it starts signing",
+				z.Str("file", file.Path))
+		}
+	}
+
+	return nil
+}
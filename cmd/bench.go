@@ -0,0 +1,19 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newBenchCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "bench",
+		Short: "Benchmark the performance of charon components on this machine",
+		Long:  `Bench subcommands measure the performance of charon components on the operator's hardware.`,
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
@@ -0,0 +1,179 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+type benchBLSConfig struct {
+	Backends      []string
+	Duration      time.Duration
+	AggregateSize int
+}
+
+func newBenchBLSCmd(runFunc func(io.Writer, benchBLSConfig) error) *cobra.Command {
+	var config benchBLSConfig
+
+	cmd := &cobra.Command{
+		Use:   "bls",
+		Short: "Compare the throughput of the BLS signature implementations",
+		Long: `Measures the sign, verify, aggregate and threshold aggregate throughput of the available BLS signature implementations
+on this machine. Select the fastest implementation via the global --bls-backend flag. Note that all implementations sign with herumi.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&config.Backends, "backends", tbls.Backends(), fmt.Sprintf("Comma separated list of BLS implementations to benchmark; %s.", strings.Join(tbls.Backends(), ", ")))
+	cmd.Flags().DurationVar(&config.Duration, "duration", time.Second, "Duration to run each benchmarked operation for.")
+	cmd.Flags().IntVar(&config.AggregateSize, "aggregate-size", 4, "Number of signatures to aggregate, e.g. the number of operators in the cluster.")
+
+	return cmd
+}
+
+// benchBLSResult is the result of benchmarking a single operation.
+type benchBLSResult struct {
+	Backend   string
+	Operation string
+	Ops       int
+	Elapsed   time.Duration
+}
+
+func runBenchBLS(w io.Writer, config benchBLSConfig) error {
+	if config.Duration <= 0 {
+		return errors.New("duration must be positive")
+	} else if config.AggregateSize < 2 {
+		return errors.New("aggregate size must be at least 2")
+	}
+
+	var results []benchBLSResult
+	for _, name := range config.Backends {
+		impl, err := tbls.Backend(name)
+		if err != nil {
+			return err
+		}
+
+		res, err := benchBLSBackend(impl, config.Duration, config.AggregateSize)
+		if err != nil {
+			return errors.Wrap(err, "benchmark bls backend")
+		}
+
+		for i := range res {
+			res[i].Backend = name
+		}
+
+		results = append(results, res...)
+	}
+
+	return writeBenchBLS(w, results)
+}
+
+// benchBLSBackend benchmarks the operations of the implementation, each for the provided duration.
+func benchBLSBackend(impl tbls.Implementation, duration time.Duration, aggregateSize int) ([]benchBLSResult, error) {
+	data := []byte("charon bls benchmark")
+
+	secret, err := impl.GenerateSecretKey()
+	if err != nil {
+		return nil, err
+	}
+
+	pubkey, err := impl.SecretToPublicKey(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := impl.Sign(secret, data)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := impl.ThresholdSplit(secret, uint(aggregateSize), uint(aggregateSize))
+	if err != nil {
+		return nil, err
+	}
+
+	partials := make(map[int]tbls.Signature)
+	var sigs []tbls.Signature
+	for idx, share := range shares {
+		partial, err := impl.Sign(share, data)
+		if err != nil {
+			return nil, err
+		}
+
+		partials[idx] = partial
+		sigs = append(sigs, partial)
+	}
+
+	ops := []struct {
+		Name string
+		Func func() error
+	}{
+		{"sign", func() error {
+			_, err := impl.Sign(secret, data)
+			return err
+		}},
+		{"verify", func() error {
+			return impl.Verify(pubkey, data, sig)
+		}},
+		{"aggregate", func() error {
+			_, err := impl.Aggregate(sigs)
+			return err
+		}},
+		{"threshold-aggregate", func() error {
+			_, err := impl.ThresholdAggregate(partials)
+			return err
+		}},
+	}
+
+	var resp []benchBLSResult
+	for _, op := range ops {
+		var (
+			count int
+			t0    = time.Now()
+		)
+		for time.Since(t0) < duration {
+			if err := op.Func(); err != nil {
+				return nil, errors.Wrap(err, "benchmark "+op.Name)
+			}
+			count++
+		}
+
+		resp = append(resp, benchBLSResult{
+			Operation: op.Name,
+			Ops:       count,
+			Elapsed:   time.Since(t0),
+		})
+	}
+
+	return resp, nil
+}
+
+// writeBenchBLS writes the benchmark results as a table.
+func writeBenchBLS(w io.Writer, results []benchBLSResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "BACKEND\tOPERATION\tOPS/SEC\tLATENCY")
+
+	for _, res := range results {
+		perSec := float64(res.Ops) / res.Elapsed.Seconds()
+		latency := res.Elapsed / time.Duration(res.Ops)
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%.1f\t%s\n", res.Backend, res.Operation, perSec, latency.Round(time.Microsecond))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, "write bls benchmark")
+	}
+
+	return nil
+}
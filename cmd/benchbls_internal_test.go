@@ -0,0 +1,35 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestRunBenchBLS(t *testing.T) {
+	var buf bytes.Buffer
+	err := runBenchBLS(&buf, benchBLSConfig{
+		Backends:      tbls.Backends(),
+		Duration:      time.Millisecond,
+		AggregateSize: 3,
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1+4*len(tbls.Backends()))
+	require.Contains(t, lines[0], "OPS/SEC")
+
+	for _, backend := range tbls.Backends() {
+		require.Contains(t, buf.String(), backend)
+	}
+
+	err = runBenchBLS(&buf, benchBLSConfig{Backends: []string{"unknown"}, Duration: time.Millisecond, AggregateSize: 3})
+	require.ErrorContains(t, err, "unknown bls backend")
+}
@@ -0,0 +1,19 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newClusterCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "cluster",
+		Short: "Inspect a running distributed validator cluster",
+		Long:  `Cluster subcommands provide insight into the state of a distributed validator cluster and its nodes.`,
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
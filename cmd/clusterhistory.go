@@ -0,0 +1,104 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/cluster/manifest"
+)
+
+type clusterHistoryConfig struct {
+	ManifestFile string
+	LockFile     string
+	JSON         bool
+}
+
+func newClusterHistoryCmd(runFunc func(io.Writer, clusterHistoryConfig) error) *cobra.Command {
+	var config clusterHistoryConfig
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Print the mutation history of a cluster manifest",
+		Long: `Prints the audit trail of all mutations of a cluster manifest, from the legacy cluster lock to the latest
+mutation, including which operators signed each mutation, when and how it changed the cluster.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			config.JSON = config.JSON || isJSONOutput(cmd)
+			return runFunc(cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster.")
+	cmd.Flags().BoolVar(&config.JSON, "json", false, "Print the history in JSON form. Equivalent to the global --output=json flag.")
+
+	supportJSONOutput(cmd)
+
+	return cmd
+}
+
+func runClusterHistory(w io.Writer, config clusterHistoryConfig) error {
+	dag, err := loadDAGFromDisk(config.ManifestFile, config.LockFile)
+	if err != nil {
+		return err
+	}
+
+	history, err := manifest.History(dag)
+	if err != nil {
+		return err
+	}
+
+	if config.JSON {
+		return writeJSON(w, history)
+	}
+
+	var sb strings.Builder
+	for i, entry := range history {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		_, _ = fmt.Fprintf(&sb, "#%d %s\n", i, entry.Hash)
+		writeHistoryEntry(&sb, entry, "  ")
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return errors.Wrap(err, "write cluster history")
+	}
+
+	return nil
+}
+
+// writeHistoryEntry writes the history entry and its children with the provided indentation.
+func writeHistoryEntry(sb *strings.Builder, entry manifest.HistoryEntry, indent string) {
+	_, _ = fmt.Fprintf(sb, "%sType:      %s\n", indent, entry.Type)
+	_, _ = fmt.Fprintf(sb, "%sParent:    %s\n", indent, entry.Parent)
+
+	if entry.Signer != "" {
+		signer := entry.Signer
+		if entry.SignerOperator != nil {
+			signer = fmt.Sprintf("operator %d (%s) %s", *entry.SignerOperator, entry.SignerAddress, entry.Signer)
+		}
+		_, _ = fmt.Fprintf(sb, "%sSigner:    %s\n", indent, signer)
+	}
+
+	if entry.Timestamp != nil {
+		_, _ = fmt.Fprintf(sb, "%sTimestamp: %s\n", indent, entry.Timestamp.UTC().Format(time.RFC3339))
+	}
+
+	if entry.Summary != "" {
+		_, _ = fmt.Fprintf(sb, "%sSummary:   %s\n", indent, entry.Summary)
+	}
+
+	for _, child := range entry.Children {
+		_, _ = fmt.Fprintf(sb, "%s- %s\n", indent, child.Hash)
+		writeHistoryEntry(sb, child, indent+"  ")
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+//go:generate go test . -run=TestClusterHistory -update
+
+func TestClusterHistory(t *testing.T) {
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, _, _ := cluster.NewForT(t, 1, 3, 4, seed, random, func(definition *cluster.Definition) {
+		definition.Timestamp = "2022-07-19T18:19:58+02:00" // Make deterministic
+	})
+
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	require.NoError(t, os.WriteFile(lockFile, b, 0o644))
+
+	config := clusterHistoryConfig{
+		ManifestFile: filepath.Join(dir, "cluster-manifest.pb"),
+		LockFile:     lockFile,
+	}
+
+	var text bytes.Buffer
+	require.NoError(t, runClusterHistory(&text, config))
+	testutil.RequireGoldenBytes(t, text.Bytes())
+
+	config.JSON = true
+	var jsonOut bytes.Buffer
+	require.NoError(t, runClusterHistory(&jsonOut, config))
+
+	var history []manifest.HistoryEntry
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &history))
+	require.Len(t, history, 1)
+	require.EqualValues(t, manifest.TypeLegacyLock, history[0].Type)
+}
@@ -0,0 +1,205 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/depositwatch"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/vallifecycle"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+type clusterStatusConfig struct {
+	MonitoringAddr string
+	Timeout        time.Duration
+	JSON           bool
+}
+
+func newClusterStatusCmd(runFunc func(context.Context, io.Writer, clusterStatusConfig) error) *cobra.Command {
+	var config clusterStatusConfig
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Print the health of all nodes in the cluster",
+		Long: `Queries the monitoring API of the local charon node for its own status and the latest statuses its peers shared
+via P2P, and prints a cluster-wide health table including readiness, beacon node sync state, validator counts,
+versions and duty performance.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			config.JSON = isJSONOutput(cmd)
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.MonitoringAddr, "monitoring-address", "http://127.0.0.1:3620", "The address of the local charon node's monitoring API.")
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 10*time.Second, "Timeout for querying the monitoring API.")
+
+	supportJSONOutput(cmd)
+
+	return cmd
+}
+
+func runClusterStatus(ctx context.Context, w io.Writer, config clusterStatusConfig) error {
+	status, err := fetchClusterStatus(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	if config.JSON {
+		return writeJSON(w, status)
+	}
+
+	return writeClusterStatus(w, status)
+}
+
+// fetchClusterStatus returns the cluster status from the monitoring API.
+func fetchClusterStatus(ctx context.Context, config clusterStatusConfig) (app.ClusterStatus, error) {
+	var status app.ClusterStatus
+	if err := getMonitoringJSON(ctx, config.MonitoringAddr, config.Timeout, "cluster/status", nil, &status); err != nil {
+		return app.ClusterStatus{}, err
+	}
+
+	return status, nil
+}
+
+// getMonitoringJSON queries the monitoring API endpoint and decodes the json response into resp.
+// Responses with non-2xx status codes are only decoded if included in allowedCodes.
+func getMonitoringJSON(ctx context.Context, monitoringAddr string, timeout time.Duration, path string, query url.Values, resp any, allowedCodes ...int) error {
+	endpoint, err := url.JoinPath(monitoringAddr, path)
+	if err != nil {
+		return errors.Wrap(err, "invalid monitoring address", z.Redact(z.Str("address", monitoringAddr)))
+	}
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return errors.Wrap(err, "create http request")
+	}
+
+	httpResp, err := new(http.Client).Do(req)
+	if err != nil {
+		return errors.Wrap(err, "query monitoring api", z.Redact(z.Str("address", monitoringAddr)))
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode/100 != 2 && !slices.Contains(allowedCodes, httpResp.StatusCode) {
+		body, _ := io.ReadAll(httpResp.Body)
+		return errors.New("http error", z.Int("status_code", httpResp.StatusCode), z.Str("body", string(body)))
+	}
+
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return errors.Wrap(err, "decode monitoring api response", z.Str("path", path))
+	}
+
+	return nil
+}
+
+// writeClusterStatus writes the cluster status as a table with one row per node.
+func writeClusterStatus(w io.Writer, status app.ClusterStatus) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "PEER\tNICKNAME\tCONNECTED\tVERSION\tREADY\tBEACON SYNCED\tVALIDATORS\tDUTIES OK\tDUTIES FAILED\tUPDATED")
+
+	var ready int
+	for _, node := range status.Nodes {
+		name := node.Peer
+		if node.Self {
+			name += " (self)"
+		}
+
+		row := []any{name, orUnknown(node.Nickname), yesNo(node.Connected), orUnknown(node.Version)}
+		if node.Reported {
+			readyStr := "yes"
+			if node.ReadyError != "" {
+				readyStr = "no: " + node.ReadyError
+			} else {
+				ready++
+			}
+
+			row = append(row, readyStr, yesNo(node.BeaconSynced), strconv.Itoa(node.Validators),
+				strconv.FormatUint(node.DutiesSucceeded, 10), strconv.FormatUint(node.DutiesFailed, 10))
+		} else {
+			row = append(row, "?", "?", "?", "?", "?")
+		}
+
+		updated := "never"
+		if !node.UpdatedAt.IsZero() {
+			updated = node.UpdatedAt.UTC().Format(time.RFC3339)
+		}
+		row = append(row, updated)
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", row...)
+	}
+
+	_, _ = fmt.Fprintf(tw, "\nReady nodes: %d/%d\n", ready, len(status.Nodes))
+
+	if status.Deposits != nil {
+		counts := status.Deposits.Counts()
+		_, _ = fmt.Fprintf(tw, "Validators: %d not deposited, %d deposited, %d pending, %d active, %d exited (block %d)\n",
+			counts[depositwatch.StatusNotDeposited], counts[depositwatch.StatusDeposited], counts[depositwatch.StatusPending],
+			counts[depositwatch.StatusActive], counts[depositwatch.StatusExited], status.Deposits.LastBlock)
+	}
+
+	if len(status.Validators) > 0 {
+		_, _ = fmt.Fprintf(tw, "Validator lifecycle: %s\n", lifecycleCounts(status.Validators))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, "write cluster status")
+	}
+
+	return nil
+}
+
+// lifecycleCounts returns the number of validators per lifecycle state in lifecycle order, omitting empty states.
+func lifecycleCounts(vals []vallifecycle.Validator) string {
+	counts := make(map[vallifecycle.State]int)
+	for _, val := range vals {
+		counts[val.State]++
+	}
+
+	var parts []string
+	for _, state := range vallifecycle.States() {
+		if counts[state] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[state], state))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "?"
+	}
+
+	return s
+}
@@ -0,0 +1,71 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/depositwatch"
+	"github.com/obolnetwork/charon/app/vallifecycle"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+//go:generate go test . -run=TestClusterStatus -update
+
+func TestClusterStatus(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	status := app.ClusterStatus{Nodes: []app.NodeStatus{
+		{
+			Peer: "happy-face", Nickname: "alice", Self: true, Connected: true, Version: "v1.2.0",
+			Reported: true, BeaconSynced: true, Validators: 10, DutiesSucceeded: 100, DutiesFailed: 1, UpdatedAt: updatedAt,
+		},
+		{
+			Peer: "pleasant-state", Connected: true, Version: "v1.1.0",
+			Reported: true, ReadyError: "beacon node not synced", Validators: 10, UpdatedAt: updatedAt,
+		},
+		{
+			Peer: "ashamed-family", Connected: true, Version: "v1.0.0", UpdatedAt: updatedAt,
+		},
+		{
+			Peer: "frantic-mirror",
+		},
+	}, Deposits: &depositwatch.Report{
+		Validators: []depositwatch.Validator{
+			{PubKey: "0x01", Status: depositwatch.StatusActive, DepositedGwei: 32e9},
+			{PubKey: "0x02", Status: depositwatch.StatusPending, DepositedGwei: 32e9},
+			{PubKey: "0x03", Status: depositwatch.StatusNotDeposited},
+		},
+		LastBlock: 1234,
+		UpdatedAt: updatedAt,
+	}, Validators: []vallifecycle.Validator{
+		{PubKey: "0x01", State: vallifecycle.StateActive, Since: updatedAt},
+		{PubKey: "0x02", State: vallifecycle.StatePending, Since: updatedAt},
+		{PubKey: "0x03", State: vallifecycle.StateCreated, Since: updatedAt},
+	}}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/cluster/status", r.URL.Path)
+		require.NoError(t, json.NewEncoder(w).Encode(status))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	err := runClusterStatus(context.Background(), &buf, clusterStatusConfig{MonitoringAddr: srv.URL, Timeout: time.Second})
+	require.NoError(t, err)
+
+	testutil.RequireGoldenBytes(t, buf.Bytes())
+
+	srv.Close()
+	err = runClusterStatus(context.Background(), &buf, clusterStatusConfig{MonitoringAddr: srv.URL, Timeout: time.Second})
+	require.ErrorContains(t, err, "query monitoring api")
+}
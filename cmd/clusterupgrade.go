@@ -0,0 +1,118 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+type clusterUpgradeConfig struct {
+	MonitoringAddr string
+	Target         string
+	Timeout        time.Duration
+	JSON           bool
+}
+
+func newClusterUpgradeCmd(runFunc func(context.Context, io.Writer, clusterUpgradeConfig) error) *cobra.Command {
+	var config clusterUpgradeConfig
+
+	cmd := &cobra.Command{
+		Use:   "upgrade-status",
+		Short: "Print the cluster's readiness to upgrade to a target charon version",
+		Long: `Queries the monitoring API of the local charon node for the charon versions and upgrade targets its peers shared
+via P2P, and prints whether a quorum of operators signalled the intent to upgrade to the target version using
+'charon run --upgrade-target', and whether all nodes already run it.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			config.JSON = isJSONOutput(cmd)
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.MonitoringAddr, "monitoring-address", "http://127.0.0.1:3620", "The address of the local charon node's monitoring API.")
+	cmd.Flags().StringVar(&config.Target, "target", "", "The target charon version, e.g. v1.3. Defaults to the local node's upgrade target, or the highest target signalled by any node.")
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 10*time.Second, "Timeout for querying the monitoring API.")
+
+	supportJSONOutput(cmd)
+
+	return cmd
+}
+
+func runClusterUpgrade(ctx context.Context, w io.Writer, config clusterUpgradeConfig) error {
+	var query url.Values
+	if config.Target != "" {
+		query = url.Values{"target": {config.Target}}
+	}
+
+	var status app.UpgradeStatus
+	if err := getMonitoringJSON(ctx, config.MonitoringAddr, config.Timeout, "cluster/upgrade", query, &status); err != nil {
+		return err
+	}
+
+	if config.JSON {
+		return writeJSON(w, status)
+	}
+
+	return writeClusterUpgrade(w, status)
+}
+
+// writeClusterUpgrade writes the cluster upgrade status as a table with one row per node followed by a summary.
+func writeClusterUpgrade(w io.Writer, status app.UpgradeStatus) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "PEER\tNICKNAME\tVERSION\tUPGRADE TARGET\tREADY\tUPGRADED")
+
+	for _, node := range status.Nodes {
+		name := node.Peer
+		if node.Self {
+			name += " (self)"
+		}
+
+		readyStr, upgradedStr := "?", "?"
+		if node.Reported && status.Target != "" {
+			readyStr, upgradedStr = yesNo(node.Ready), yesNo(node.Upgraded)
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", name, orUnknown(node.Nickname), orUnknown(node.Version),
+			orNone(node.Target), readyStr, upgradedStr)
+	}
+
+	_, _ = fmt.Fprintln(tw)
+
+	switch {
+	case status.Target == "":
+		_, _ = fmt.Fprintln(tw, "No upgrade target signalled by any node.")
+	case status.AllUpgraded:
+		_, _ = fmt.Fprintf(tw, "All nodes upgraded to %s.\n", status.Target)
+	case status.QuorumReady:
+		_, _ = fmt.Fprintf(tw, "Quorum ready to upgrade to %s: %d/%d ready (quorum %d), %d upgraded.\n",
+			status.Target, status.Ready, len(status.Nodes), status.Quorum, status.Upgraded)
+	default:
+		_, _ = fmt.Fprintf(tw, "Waiting for quorum to upgrade to %s: %d/%d ready (quorum %d), %d upgraded.\n",
+			status.Target, status.Ready, len(status.Nodes), status.Quorum, status.Upgraded)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, "write cluster upgrade status")
+	}
+
+	return nil
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+
+	return s
+}
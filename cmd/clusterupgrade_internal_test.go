@@ -0,0 +1,49 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+//go:generate go test . -run=TestClusterUpgrade -update
+
+func TestClusterUpgrade(t *testing.T) {
+	status := app.UpgradeStatus{
+		Target:      "v1.3",
+		Quorum:      3,
+		Ready:       3,
+		Upgraded:    1,
+		QuorumReady: true,
+		Nodes: []app.NodeUpgrade{
+			{Peer: "happy-face", Nickname: "alice", Self: true, Reported: true, Version: "v1.2.0", Target: "v1.3", Ready: true},
+			{Peer: "pleasant-state", Reported: true, Version: "v1.3.0", Ready: true, Upgraded: true},
+			{Peer: "ashamed-family", Reported: true, Version: "v1.2.0", Target: "v1.3", Ready: true},
+			{Peer: "frantic-mirror"},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/cluster/upgrade", r.URL.Path)
+		require.Equal(t, "v1.3", r.URL.Query().Get("target"))
+		require.NoError(t, json.NewEncoder(w).Encode(status))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	err := runClusterUpgrade(context.Background(), &buf, clusterUpgradeConfig{MonitoringAddr: srv.URL, Target: "v1.3", Timeout: time.Second})
+	require.NoError(t, err)
+
+	testutil.RequireGoldenBytes(t, buf.Bytes())
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -19,6 +20,7 @@ import (
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cmd/relay"
 	"github.com/obolnetwork/charon/dkg"
+	"github.com/obolnetwork/charon/tbls"
 )
 
 const (
@@ -30,6 +32,9 @@ const (
 	envPrefix   = "charon"
 	httpScheme  = "http"
 	httpsScheme = "https"
+
+	// configAnnotation marks flags set via the config file or environment variables.
+	configAnnotation = "charon_config"
 )
 
 // New returns a new root cobra command that handles our command line tool.
@@ -37,9 +42,12 @@ func New() *cobra.Command {
 	return newRootCmd(
 		newVersionCmd(runVersionCmd),
 		newEnrCmd(runNewENR),
+		newInitCmd(runInit),
 		newRunCmd(app.Run, false),
 		newRelayCmd(relay.Run),
-		newDKGCmd(dkg.Run),
+		newDKGCmd(dkg.Run,
+			newVerifyTranscriptCmd(runVerifyTranscript),
+		),
 		newCreateCmd(
 			newCreateDKGCmd(runCreateDKG),
 			newCreateEnrCmd(runCreateEnrCmd),
@@ -48,7 +56,22 @@ func New() *cobra.Command {
 		newCombineCmd(newCombineFunc),
 		newAlphaCmd(
 			newAddValidatorsCmd(runAddValidatorsSolo),
+			newAddValidatorsCeremonyCmd(dkg.RunAddValidators),
 			newViewClusterManifestCmd(runViewClusterManifest),
+			newFetchClusterCmd(runFetchCluster),
+			newRefreshCmd(
+				newRefreshRunCmd(dkg.RunScheduledRefresh),
+				newRefreshVerifyCmd(runRefreshVerify),
+			),
+			newAddOperatorCmd(dkg.RunReshare),
+			newRemoveOperatorCmd(dkg.RunReshare),
+			newRotateOperatorKeyCmd(dkg.RunRotateKey),
+			newAttestOperatorKeyCmd(runAttestOperatorKey),
+			newKeystoreBenchmarkCmd(runKeystoreBenchmark),
+			newExportMonitoringCmd(runExportMonitoring),
+			newBenchCmd(
+				newBenchBLSCmd(runBenchBLS),
+			),
 			newTestCmd(
 				newTestAllCmd(runTestAll),
 				newTestPeersCmd(runTestPeers),
@@ -63,23 +86,72 @@ func New() *cobra.Command {
 			newSignPartialExitCmd(runSignPartialExit),
 			newBcastFullExitCmd(runBcastFullExit),
 			newFetchExitCmd(runFetchExit),
+			newExitStatusCmd(runExitStatus),
+			newPresignExitCmd(runPresignExit),
+			newBcastPresignedExitCmd(runBcastPresignedExit),
+		),
+		newKeysCmd(
+			newKeysImportCmd(runKeysImport),
+			newKeysExportCmd(runKeysExport),
+			newKeysVerifyCmd(runKeysVerify),
+		),
+		newBackupCmd(
+			newBackupCreateCmd(runBackupCreate),
+			newBackupRestoreCmd(runBackupRestore),
+		),
+		newClusterCmd(
+			newClusterStatusCmd(runClusterStatus),
+			newClusterUpgradeCmd(runClusterUpgrade),
+			newClusterHistoryCmd(runClusterHistory),
+		),
+		newDashboardCmd(runDashboard),
+		newFeaturesCmd(runFeatures),
+		newMigrateCmd(runMigrate),
+		newReportCmd(
+			newReportGenerateCmd(runReportGenerate),
+		),
+		newUpdateCmd(runUpdate),
+		newCompletionCmd(runCompletion),
+		newDocsCmd(
+			newDocsManCmd(runDocsMan),
 		),
 		newUnsafeCmd(newRunCmd(app.Run, true)),
 	)
 }
 
 func newRootCmd(cmds ...*cobra.Command) *cobra.Command {
+	var blsBackend, configFile, output string
+
 	root := &cobra.Command{
 		Use:   "charon",
 		Short: "Charon - Proof of Stake Ethereum Distributed Validator Client",
 		Long:  `Charon enables the operation of Ethereum validators in a fault tolerant manner by splitting the validating keys across a group of trusted parties using threshold cryptography.`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
-			return initializeConfig(cmd)
+			if err := initializeConfig(cmd, configFile); err != nil {
+				return err
+			}
+
+			if err := validateOutput(cmd, output); err != nil {
+				return err
+			}
+
+			return tbls.SetBackend(blsBackend)
 		},
 	}
 
+	root.PersistentFlags().StringVar(&blsBackend, "bls-backend", tbls.BackendHerumi,
+		fmt.Sprintf("The BLS signature verification and aggregation implementation to use; %s. Signing always uses herumi. Use 'charon alpha bench bls' to compare their performance.", strings.Join(tbls.Backends(), ", ")))
+
+	root.PersistentFlags().StringVar(&configFile, "config", "",
+		"The path to a YAML, TOML or JSON config file defining flag values by flag name, e.g. 'beacon-node-endpoints: [http://bn:5052]'. "+
+			"Unknown keys are rejected. Flags and CHARON_ prefixed environment variables take precedence. Defaults to an optional charon config file in the working directory.")
+
+	root.PersistentFlags().StringVar(&output, outputFlag, outputText,
+		"The output format of command results; text or json. The json format writes structured results to stdout for automation, while logs are written to stderr. Commands without structured results reject json.")
+
 	root.AddCommand(cmds...)
-	root.SilenceErrors = true // Disable default error printing.
+	root.SilenceErrors = true                       // Disable default error printing.
+	root.CompletionOptions.DisableDefaultCmd = true // Replaced by the completion command.
 
 	titledHelp(root)
 	silenceUsage(root)
@@ -103,20 +175,34 @@ func silenceUsage(cmd *cobra.Command) {
 }
 
 // initializeConfig sets up the general viper config and binds the cobra flags to the viper flags.
-func initializeConfig(cmd *cobra.Command) error {
+// If configFile is empty, an optional config file in the working directory is read, otherwise
+// the config file must exist and may only contain keys of the command's flags.
+func initializeConfig(cmd *cobra.Command, configFile string) error {
 	v := viper.New()
 
-	v.SetConfigName(defaultConfigFilename)
-	v.AddConfigPath(".")
-
-	// Attempt to read the config file, gracefully ignoring errors
-	// caused by a config file not being found. Return an error
-	// if we cannot parse the config file.
-	if err := v.ReadInConfig(); err != nil {
-		// It's okay if there isn't a config file
-		var cfgError viper.ConfigFileNotFoundError
-		if ok := errors.As(err, &cfgError); !ok {
-			return errors.Wrap(err, "read config")
+	if configFile != "" {
+		v.SetConfigFile(configFile)
+
+		if err := v.ReadInConfig(); err != nil {
+			return errors.Wrap(err, "read config", z.Str("path", configFile))
+		}
+
+		if err := verifyConfigKeys(cmd, v.AllKeys()); err != nil {
+			return errors.Wrap(err, "invalid config", z.Str("path", configFile))
+		}
+	} else {
+		v.SetConfigName(defaultConfigFilename)
+		v.AddConfigPath(".")
+
+		// Attempt to read the config file, gracefully ignoring errors
+		// caused by a config file not being found. Return an error
+		// if we cannot parse the config file.
+		if err := v.ReadInConfig(); err != nil {
+			// It's okay if there isn't a config file
+			var cfgError viper.ConfigFileNotFoundError
+			if ok := errors.As(err, &cfgError); !ok {
+				return errors.Wrap(err, "read config")
+			}
 		}
 	}
 
@@ -128,6 +214,41 @@ func initializeConfig(cmd *cobra.Command) error {
 	return bindFlags(cmd, v)
 }
 
+// verifyConfigKeys returns an error if any of the config file keys doesn't match a flag of the command.
+// Viper flattens config file maps into nested keys, so "flag.key" matches map-valued flags like log-topic-levels.
+func verifyConfigKeys(cmd *cobra.Command, keys []string) error {
+	known := make(map[string]bool)
+	maps := make(map[string]bool)
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		known[f.Name] = true
+		known[strings.ReplaceAll(f.Name, "_", ".")] = true
+
+		if isMapFlag(f) {
+			maps[f.Name] = true
+		}
+	})
+
+	var unknown []string
+	for _, key := range keys {
+		if known[key] {
+			continue
+		}
+
+		if flag, _, ok := strings.Cut(key, "."); ok && maps[flag] {
+			continue
+		}
+
+		unknown = append(unknown, key)
+	}
+
+	if len(unknown) > 0 {
+		return errors.New("unknown config keys, see 'charon "+cmd.Name()+" --help' for supported flags",
+			z.Any("keys", unknown))
+	}
+
+	return nil
+}
+
 // bindFlags binds each cobra flag to its associated viper configuration (config file and environment variable).
 func bindFlags(cmd *cobra.Command, v *viper.Viper) error {
 	var lastErr error
@@ -149,10 +270,11 @@ func bindFlags(cmd *cobra.Command, v *viper.Viper) error {
 				continue
 			}
 
-			val := v.Get(name)
-			err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val))
+			err := cmd.Flags().Set(f.Name, configValue(v.Get(name)))
 			if err != nil {
 				lastErr = err
+			} else if err := cmd.Flags().SetAnnotation(f.Name, configAnnotation, []string{"true"}); err != nil {
+				lastErr = err
 			}
 
 			break
@@ -162,6 +284,44 @@ func bindFlags(cmd *cobra.Command, v *viper.Viper) error {
 	return lastErr
 }
 
+// configValue returns the config value as a flag value, joining config file lists as comma separated values.
+func configValue(val any) string {
+	var vals []string
+	switch val := val.(type) {
+	case []any:
+		for _, elem := range val {
+			vals = append(vals, fmt.Sprintf("%v", elem))
+		}
+	case map[string]any:
+		for k, elem := range val {
+			vals = append(vals, fmt.Sprintf("%s=%v", k, elem))
+		}
+		sort.Strings(vals)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+
+	return strings.Join(vals, ",")
+}
+
+// isMapFlag returns true if the flag accepts comma separated key=value pairs,
+// which may be provided as a map in the config file.
+func isMapFlag(f *pflag.Flag) bool {
+	switch f.Value.Type() {
+	case "stringToString", "stringToInt", "stringToInt64", "stringSlice":
+		return true
+	default:
+		return false
+	}
+}
+
+// isConfigFlag returns true if the flag value was provided via the config file or environment variables
+// instead of via the command line.
+func isConfigFlag(f *pflag.Flag) bool {
+	_, ok := f.Annotations[configAnnotation]
+	return ok
+}
+
 // titledHelp updates the command (and child commands) help flag usage to title case.
 func titledHelp(cmd *cobra.Command) {
 	cmd.InitDefaultHelpFlag()
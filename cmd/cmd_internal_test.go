@@ -6,6 +6,7 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -20,6 +21,22 @@ import (
 	"github.com/obolnetwork/charon/testutil"
 )
 
+// chdirTempCharonDir changes the working directory for the duration of the test to a temporary
+// directory containing a .charon directory, so tests don't create files in the package directory.
+func chdirTempCharonDir(t *testing.T) {
+	t.Helper()
+
+	dir := testutil.CreateTempCharonDir(t)
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Dir(dir)))
+
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(wd))
+	})
+}
+
 func TestCmdFlags(t *testing.T) {
 	tests := []struct {
 		Name          string
@@ -60,6 +77,10 @@ func TestCmdFlags(t *testing.T) {
 					Format:      "console",
 					Color:       "auto",
 					LokiService: "charon",
+
+					LogOutputMaxSize:    100,
+					LogOutputMaxBackups: 10,
+					LogOutputCompress:   true,
 				},
 				P2P: p2p.Config{
 					Relays:   []string{"https://0.relay.obol.tech", "https://2.relay.obol.dev", "https://1.relay.obol.tech"},
@@ -72,6 +93,7 @@ func TestCmdFlags(t *testing.T) {
 				},
 				LockFile:                ".charon/cluster-lock.json",
 				ManifestFile:            ".charon/cluster-manifest.pb",
+				KeyAttestationsDir:      ".charon/key_attestations",
 				PrivKeyFile:             ".charon/charon-enr-private-key",
 				PrivKeyLocking:          false,
 				SimnetValidatorKeysDir:  ".charon/validator_keys",
@@ -81,8 +103,18 @@ func TestCmdFlags(t *testing.T) {
 				BeaconNodeAddrs:         []string{"http://beacon.node"},
 				BeaconNodeTimeout:       2 * time.Second,
 				BeaconNodeSubmitTimeout: 2 * time.Second,
+				ManifestReloadInterval:  time.Minute,
+				ParticipationFile:       ".charon/participation.json",
+				SLAFile:                 ".charon/sla.json",
+				StandbyFailoverTimeout:  3 * time.Minute,
+				RewardsReportPeriod:     24 * time.Hour,
+				ProfilingPushInterval:   15 * time.Second,
+				TelemetryInterval:       time.Hour,
+				MetricsPushInterval:     30 * time.Second,
+				EventNATSSubject:        "charon.events",
 				JaegerAddr:              "",
 				JaegerService:           "charon",
+				OTLPServiceName:         "charon",
 			},
 		},
 		{
@@ -111,6 +143,10 @@ func TestCmdFlags(t *testing.T) {
 					Format:      "console",
 					Color:       "auto",
 					LokiService: "charon",
+
+					LogOutputMaxSize:    100,
+					LogOutputMaxBackups: 10,
+					LogOutputCompress:   true,
 				},
 				P2P: p2p.Config{
 					Relays:   []string{"https://0.relay.obol.tech", "https://2.relay.obol.dev", "https://1.relay.obol.tech"},
@@ -123,6 +159,7 @@ func TestCmdFlags(t *testing.T) {
 				},
 				LockFile:                ".charon/cluster-lock.json",
 				ManifestFile:            ".charon/cluster-manifest.pb",
+				KeyAttestationsDir:      ".charon/key_attestations",
 				PrivKeyFile:             ".charon/charon-enr-private-key",
 				PrivKeyLocking:          false,
 				SimnetValidatorKeysDir:  ".charon/validator_keys",
@@ -132,8 +169,18 @@ func TestCmdFlags(t *testing.T) {
 				BeaconNodeAddrs:         []string{"http://beacon.node"},
 				BeaconNodeTimeout:       2 * time.Second,
 				BeaconNodeSubmitTimeout: 2 * time.Second,
+				ManifestReloadInterval:  time.Minute,
+				ParticipationFile:       ".charon/participation.json",
+				SLAFile:                 ".charon/sla.json",
+				StandbyFailoverTimeout:  3 * time.Minute,
+				RewardsReportPeriod:     24 * time.Hour,
+				ProfilingPushInterval:   15 * time.Second,
+				TelemetryInterval:       time.Hour,
+				MetricsPushInterval:     30 * time.Second,
+				EventNATSSubject:        "charon.events",
 				JaegerAddr:              "",
 				JaegerService:           "charon",
+				OTLPServiceName:         "charon",
 				TestConfig: app.TestConfig{
 					P2PFuzz: true,
 				},
@@ -155,12 +202,14 @@ func TestCmdFlags(t *testing.T) {
 				}),
 				newRunCmd(func(_ context.Context, config app.Config) error {
 					require.NotNil(t, test.AppConfig)
+					require.NotNil(t, config.AdminExitFunc)
+					config.AdminExitFunc = nil // Funcs aren't comparable.
 					require.Equal(t, *test.AppConfig, config)
 
 					return nil
 				}, false),
 				newCreateCmd(
-					newCreateEnrCmd(func(_ io.Writer, datadir string) error {
+					newCreateEnrCmd(func(_ io.Writer, datadir string, _ bool) error {
 						require.Equal(t, test.Datadir, datadir)
 
 						return nil
@@ -168,6 +217,8 @@ func TestCmdFlags(t *testing.T) {
 				),
 				newUnsafeCmd(newRunCmd(func(_ context.Context, config app.Config) error {
 					require.NotNil(t, test.AppConfig)
+					require.NotNil(t, config.AdminExitFunc)
+					config.AdminExitFunc = nil // Funcs aren't comparable.
 					require.Equal(t, *test.AppConfig, config)
 
 					return nil
@@ -179,7 +230,7 @@ func TestCmdFlags(t *testing.T) {
 				require.NoError(t, os.Setenv(k, v))
 			}
 
-			_ = testutil.CreateTempCharonDir(t)
+			chdirTempCharonDir(t)
 			if test.AppConfig != nil {
 				_, err := p2p.NewSavedPrivKey(test.AppConfig.PrivKeyFile)
 				require.NoError(t, err)
@@ -201,6 +252,177 @@ func TestCmdFlags(t *testing.T) {
 	}
 }
 
+func TestConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig := func(t *testing.T, name, content string) string {
+		t.Helper()
+
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		return path
+	}
+
+	yamlFile := writeConfig(t, "charon.yaml", `
+beacon-node-endpoints:
+  - http://bn1:5052
+  - http://bn2:5052
+nickname: from-file
+validator-api-address: 0.0.0.0:3600
+beacon-node-timeout: 5s
+log-topic-levels:
+  bcast: debug
+  p2p: warn
+beacon-node-headers:
+  authorization: basic
+`)
+	tomlFile := writeConfig(t, "charon.toml", `
+beacon-node-endpoints = ["http://bn1:5052"]
+nickname = "from-toml"
+`)
+	unknownFile := writeConfig(t, "unknown.yaml", `
+beacon-node-endpoints: [http://bn1:5052]
+beacon-node-endpoint: http://bn2:5052
+`)
+
+	tests := []struct {
+		Name     string
+		Args     []string
+		Envs     map[string]string
+		Nickname string
+		ErrorMsg string
+	}{
+		{
+			Name:     "yaml",
+			Args:     slice("--config", yamlFile),
+			Nickname: "from-file",
+		},
+		{
+			Name:     "toml",
+			Args:     slice("--config", tomlFile),
+			Nickname: "from-toml",
+		},
+		{
+			Name:     "env overrides file",
+			Args:     slice("--config", yamlFile),
+			Envs:     map[string]string{"CHARON_NICKNAME": "from-env"},
+			Nickname: "from-env",
+		},
+		{
+			Name:     "flag overrides env and file",
+			Args:     slice("--config", yamlFile, "--nickname", "from-flag"),
+			Envs:     map[string]string{"CHARON_NICKNAME": "from-env"},
+			Nickname: "from-flag",
+		},
+		{
+			Name:     "unknown key",
+			Args:     slice("--config", unknownFile),
+			ErrorMsg: "unknown config keys",
+		},
+		{
+			Name:     "missing file",
+			Args:     slice("--config", filepath.Join(dir, "missing.yaml")),
+			ErrorMsg: "read config",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			for k, v := range test.Envs {
+				t.Setenv(k, v)
+			}
+
+			var conf app.Config
+			root := newRootCmd(newRunCmd(func(_ context.Context, config app.Config) error {
+				conf = config
+				return nil
+			}, false))
+			root.SetArgs(append(slice("run"), test.Args...))
+
+			err := root.Execute()
+			if test.ErrorMsg != "" {
+				require.ErrorContains(t, err, test.ErrorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.Nickname, conf.Nickname)
+			require.NotEmpty(t, conf.BeaconNodeAddrs)
+		})
+	}
+
+	t.Run("lists and durations", func(t *testing.T) {
+		var conf app.Config
+		root := newRootCmd(newRunCmd(func(_ context.Context, config app.Config) error {
+			conf = config
+			return nil
+		}, false))
+		root.SetArgs(slice("run", "--config", yamlFile))
+		require.NoError(t, root.Execute())
+
+		require.Equal(t, []string{"http://bn1:5052", "http://bn2:5052"}, conf.BeaconNodeAddrs)
+		require.Equal(t, "0.0.0.0:3600", conf.ValidatorAPIAddr)
+		require.Equal(t, 5*time.Second, conf.BeaconNodeTimeout)
+	})
+
+	t.Run("maps", func(t *testing.T) {
+		var conf app.Config
+		root := newRootCmd(newRunCmd(func(_ context.Context, config app.Config) error {
+			conf = config
+			return nil
+		}, false))
+		root.SetArgs(slice("run", "--config", yamlFile))
+		require.NoError(t, root.Execute())
+
+		require.Equal(t, map[string]string{"bcast": "debug", "p2p": "warn"}, conf.Log.TopicLevels)
+		require.Equal(t, []string{"authorization=basic"}, conf.BeaconNodeHeaders)
+	})
+}
+
+func TestConfigReload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "charon.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+beacon-node-endpoints: [http://bn1:5052]
+fallback-beacon-node-endpoints: [http://fallback1:5052]
+log-level: info
+`), 0o600))
+
+	var conf app.Config
+	root := newRootCmd(newRunCmd(func(_ context.Context, config app.Config) error {
+		conf = config
+		return nil
+	}, false))
+	root.SetArgs(slice("run", "--config", file, "--fallback-beacon-node-endpoints", "http://fallback2:5052", "--validate-config=false"))
+	require.NoError(t, root.Execute())
+	require.NotNil(t, conf.ReloadConfig)
+
+	runtimeConf, err := conf.ReloadConfig()
+	require.NoError(t, err)
+	require.Equal(t, conf.RuntimeConfig(), runtimeConf)
+
+	require.NoError(t, os.WriteFile(file, []byte(`
+beacon-node-endpoints: [http://bn2:5052, http://bn3:5052]
+fallback-beacon-node-endpoints: [http://fallback1:5052]
+log-level: debug
+builder-api: true
+proposer-config-file: proposer-config.json
+`), 0o600))
+
+	runtimeConf, err = conf.ReloadConfig()
+	require.NoError(t, err)
+	require.Equal(t, app.RuntimeConfig{
+		LogLevel:                "debug",
+		BeaconNodeAddrs:         []string{"http://bn2:5052", "http://bn3:5052"},
+		FallbackBeaconNodeAddrs: []string{"http://fallback2:5052"}, // Command line flags are retained.
+		BuilderAPI:              true,
+		ProposerConfigFile:      "proposer-config.json",
+	}, runtimeConf)
+
+	require.NoError(t, os.WriteFile(file, []byte(`beacon-node-endpoint: http://bn1:5052`), 0o600))
+	_, err = conf.ReloadConfig()
+	require.ErrorContains(t, err, "unknown config keys")
+}
+
 func TestFlagsToLogFields(t *testing.T) {
 	set := pflag.NewFlagSet("test", pflag.PanicOnError)
 	bindLokiFlags(set, &log.Config{})
@@ -4,20 +4,24 @@ package cmd
 
 import (
 	"context"
+	"io"
+	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/term"
 
 	"github.com/obolnetwork/charon/cmd/combine"
 	"github.com/obolnetwork/charon/eth2util"
 )
 
-func newCombineCmd(runFunc func(ctx context.Context, clusterDir, outputDir string, force, noverify bool, testnetConfig eth2util.Network) error) *cobra.Command {
+func newCombineCmd(runFunc func(ctx context.Context, clusterDir, outputDir string, force, noverify bool, testnetConfig eth2util.Network, progress, jsonOutput io.Writer) error) *cobra.Command {
 	var (
 		clusterDir string
 		outputDir  string
 		force      bool
 		noverify   bool
+		progress   bool
 
 		testnetConfig eth2util.Network
 	)
@@ -28,6 +32,12 @@ func newCombineCmd(runFunc func(ctx context.Context, clusterDir, outputDir strin
 		Long:  "Combines the private key shares from a threshold of operators in a distributed validator cluster into a set of validator private keys that can be imported into a standard Ethereum validator client.\n\nWarning: running the resulting private keys in a validator alongside the original distributed validator cluster *will* result in slashing.",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			// Only render the progress bar if stderr is a terminal.
+			var progressOutput io.Writer
+			if progress && term.IsTerminal(int(os.Stderr.Fd())) {
+				progressOutput = os.Stderr
+			}
+
 			return runFunc(
 				cmd.Context(),
 				clusterDir,
@@ -35,6 +45,8 @@ func newCombineCmd(runFunc func(ctx context.Context, clusterDir, outputDir strin
 				force,
 				noverify,
 				testnetConfig,
+				progressOutput,
+				jsonWriter(cmd),
 			)
 		},
 	}
@@ -48,12 +60,26 @@ func newCombineCmd(runFunc func(ctx context.Context, clusterDir, outputDir strin
 	)
 
 	bindNoVerifyFlag(cmd.Flags(), &noverify)
+	cmd.Flags().BoolVar(&progress, "progress", true, "Show a progress bar of the recombined validators. Only applies if stderr is a terminal.")
+
+	supportJSONOutput(cmd)
 
 	return cmd
 }
 
-func newCombineFunc(ctx context.Context, clusterDir, outputDir string, force, noverify bool, testnetConfig eth2util.Network) error {
-	return combine.Combine(ctx, clusterDir, outputDir, force, noverify, testnetConfig)
+// newCombineFunc combines the private key shares, rendering a progress bar to progress if it isn't nil
+// and writing the output directory and combined validators as JSON if jsonOutput isn't nil.
+func newCombineFunc(ctx context.Context, clusterDir, outputDir string, force, noverify bool, testnetConfig eth2util.Network, progress, jsonOutput io.Writer) error {
+	if jsonOutput == nil {
+		return combine.Combine(ctx, clusterDir, outputDir, force, noverify, testnetConfig, combine.WithProgress(progress))
+	}
+
+	var result combine.Result
+	if err := combine.Combine(ctx, clusterDir, outputDir, force, noverify, testnetConfig, combine.WithProgress(progress), combine.WithResult(&result)); err != nil {
+		return err
+	}
+
+	return writeJSON(jsonOutput, result)
 }
 
 func bindCombineFlags(flags *pflag.FlagSet, clusterDir, outputDir *string, force *bool, config *eth2util.Network) {
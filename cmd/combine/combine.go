@@ -5,11 +5,16 @@ package combine
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/forkjoin"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
@@ -21,15 +26,22 @@ import (
 	"github.com/obolnetwork/charon/tbls/tblsconv"
 )
 
+// maxWorkers is the maximum number of validators recombined concurrently. It bounds memory usage,
+// since decrypting a single scrypt keystore requires up to 256MB.
+const maxWorkers = 8
+
 // Combine combines validator private key shares contained in inputDir, and writes the original BLS12-381 private keys.
 // Combine is cluster-aware: it'll recombine all the validator keys listed in the "Validator" field of the lock file.
 // To do so place all the cluster nodes' ".charon" directories in inputDir renaming each.
 // Note all nodes directories must be preset and all validator private key shares must be present.
 //
 // Combine will create a new directory named after "outputDir", which will contain Keystore files.
+// Validators are recombined and stored one at a time, decrypting only a threshold of key shares each,
+// so memory usage is bounded for clusters with thousands of validators.
 func Combine(ctx context.Context, inputDir, outputDir string, force, noverify bool, testnetConfig eth2util.Network, opts ...func(*options)) error {
 	o := options{
-		keyStoreFunc: keystore.StoreKeys,
+		keyStoreFunc: keystore.StoreKey,
+		workers:      min(runtime.GOMAXPROCS(0), maxWorkers),
 	}
 
 	for _, opt := range opts {
@@ -70,104 +82,180 @@ func Combine(ctx context.Context, inputDir, outputDir string, force, noverify bo
 		return errors.Wrap(err, "cannot open manifest file")
 	}
 
-	privkeys := make(map[int][]tbls.PrivateKey)
-
+	// Index the key share files of each node without decrypting them, so keys are only decrypted when recombined.
+	var keyFiles []map[int]string
 	for _, pkp := range possibleKeyPaths {
-		log.Info(ctx, "Loading keystore", z.Str("path", pkp))
-
-		keyFiles, err := keystore.LoadFilesUnordered(pkp)
+		files, err := keystore.FilesByIndex(pkp)
 		if err != nil {
 			return errors.Wrap(err, "cannot load private key share", z.Str("path", pkp))
 		}
 
-		secrets, err := keyFiles.SequencedKeys()
-		if err != nil {
-			return errors.Wrap(err, "order private key shares")
+		for idx, file := range files {
+			if idx >= len(cluster.GetValidators()) {
+				return errors.New("out of sequence keystore index", z.Int("index", idx), z.Str("filename", file))
+			}
 		}
 
-		for idx, secret := range secrets {
-			privkeys[idx] = append(privkeys[idx], secret)
-		}
+		keyFiles = append(keyFiles, files)
 	}
 
-	var combinedKeys []tbls.PrivateKey
+	if err := prepareOutputDir(outputDir, force); err != nil {
+		return err
+	}
 
-	for valIdx := range len(privkeys) {
-		pkSet := privkeys[valIdx]
+	log.Info(ctx, "Recombining validators", z.Int("validators", len(cluster.GetValidators())), z.Int("workers", o.workers))
 
-		if len(pkSet) < int(cluster.GetThreshold()) {
-			return errors.New(
-				"insufficient private key shares found for validator",
-				z.Int("validator_index", valIdx),
-				z.Int("expected", int(cluster.GetThreshold())),
-				z.Int("actual", len(pkSet)),
-			)
-		}
+	var prog *progress
+	if o.progress != nil {
+		prog = newProgress(o.progress, len(cluster.GetValidators()), time.Now)
 
-		log.Info(ctx, "Recombining private key shares", z.Int("validator_index", valIdx))
-		shares, err := shareIdxByPubkeys(cluster, pkSet, valIdx)
-		if err != nil {
-			return err
-		}
+		progCtx, cancel := context.WithCancel(ctx)
+		stopped := make(chan struct{})
+		go func() {
+			prog.Run(progCtx)
+			close(stopped)
+		}()
 
-		secret, err := tbls.RecoverSecret(shares, uint(len(cluster.GetOperators())), uint(cluster.GetThreshold()))
-		if err != nil {
-			return errors.Wrap(err, "cannot recover private key share", z.Int("validator_index", valIdx))
-		}
+		defer func() {
+			cancel()
+			<-stopped
+		}()
+	}
 
-		// require that the generated secret pubkey matches what's in the lockfile for the valIdx validator
-		val := cluster.GetValidators()[valIdx]
+	// Recombine and store validator keys one at a time with bounded concurrency,
+	// so memory usage doesn't grow with the number of validators.
+	var valIdxs []int
+	for valIdx := range len(cluster.GetValidators()) {
+		valIdxs = append(valIdxs, valIdx)
+	}
 
-		valPk, err := tblsconv.PubkeyFromBytes(val.GetPublicKey())
-		if err != nil {
-			return errors.Wrap(err, "public key for validator from manifest", z.Int("validator_index", valIdx))
-		}
+	results, cancel := forkjoin.NewWithInputs(ctx,
+		func(ctx context.Context, valIdx int) (struct{}, error) {
+			if err := recombine(ctx, cluster, keyFiles, valIdx, outputDir, o.keyStoreFunc); err != nil {
+				return struct{}{}, err
+			}
 
-		genPubkey, err := tbls.SecretToPublicKey(secret)
-		if err != nil {
-			return errors.Wrap(err, "public key for validator from generated secret", z.Int("validator_index", valIdx))
-		}
+			prog.Inc()
 
-		if valPk != genPubkey {
-			return errors.New("unexpected resulting combined validator public key",
-				z.Int("validator_index", valIdx), z.Hex("actual", genPubkey[:]), z.Hex("expected", valPk[:]))
-		}
+			return struct{}{}, nil
+		},
+		valIdxs,
+		forkjoin.WithWorkers(o.workers),
+	)
+	defer cancel()
 
-		combinedKeys = append(combinedKeys, secret)
+	if _, err := results.Flatten(); err != nil {
+		return err
 	}
 
+	if o.result != nil {
+		o.result.OutputDir = outputDir
+		o.result.Validators = nil
+		for _, val := range cluster.GetValidators() {
+			o.result.Validators = append(o.result.Validators, fmt.Sprintf("%#x", val.GetPublicKey()))
+		}
+	}
+
+	return nil
+}
+
+// prepareOutputDir ensures the output directory exists and doesn't contain keystores, removing them if force is true.
+func prepareOutputDir(outputDir string, force bool) error {
 	if err := os.MkdirAll(outputDir, 0o755); err != nil {
 		return errors.Wrap(err, "ensure output directory exists", z.Str("output_dir", outputDir))
 	}
 
-	ksPath := filepath.Join(outputDir, "keystore-0.json")
-	_, err = os.Stat(ksPath)
-	if err == nil && !force {
-		return errors.New("refusing to overwrite existing private key share", z.Str("path", ksPath))
+	matches, err := filepath.Glob(filepath.Join(outputDir, "keystore-*.json"))
+	if err != nil {
+		return errors.Wrap(err, "can't match keystore files")
 	}
 
-	if force {
-		matches, err := filepath.Glob(filepath.Join(outputDir, "keystore-*.json"))
-		if err != nil {
-			return errors.Wrap(err, "can't match keystore files")
+	if len(matches) > 0 && !force {
+		return errors.New("refusing to overwrite existing private key share", z.Str("path", matches[0]))
+	} else if !force {
+		return nil
+	}
+
+	passMatches, err := filepath.Glob(filepath.Join(outputDir, "keystore-*.txt"))
+	if err != nil {
+		return errors.Wrap(err, "can't match keystore password files")
+	}
+
+	matches = append(matches, passMatches...)
+
+	for _, match := range matches {
+		if err := os.RemoveAll(match); err != nil {
+			return errors.Wrap(err, "can't remove existing keystore file")
 		}
+	}
 
-		passMatches, err := filepath.Glob(filepath.Join(outputDir, "keystore-*.txt"))
-		if err != nil {
-			return errors.Wrap(err, "can't match keystore password files")
+	return nil
+}
+
+// recombine decrypts a threshold of the private key shares of the validator, recovers and verifies its private key
+// and stores it in the output directory.
+func recombine(ctx context.Context, cluster *manifestpb.Cluster, keyFiles []map[int]string, valIdx int, outputDir string,
+	keyStoreFunc func(secret tbls.PrivateKey, dir string, index int) error,
+) error {
+	var pkSet []tbls.PrivateKey
+	for _, files := range keyFiles {
+		if len(pkSet) == int(cluster.GetThreshold()) {
+			break // Only a threshold of shares is required, skip decrypting the rest.
 		}
 
-		matches = append(matches, passMatches...)
+		file, ok := files[valIdx]
+		if !ok {
+			continue
+		}
 
-		for _, match := range matches {
-			if err := os.RemoveAll(match); err != nil {
-				return errors.Wrap(err, "can't remove existing keystore file")
-			}
+		keyFile, err := keystore.LoadFile(file)
+		if err != nil {
+			return errors.Wrap(err, "cannot load private key share", z.Str("path", file))
 		}
+
+		pkSet = append(pkSet, keyFile.PrivateKey)
+	}
+
+	if len(pkSet) < int(cluster.GetThreshold()) {
+		return errors.New(
+			"insufficient private key shares found for validator",
+			z.Int("validator_index", valIdx),
+			z.Int("expected", int(cluster.GetThreshold())),
+			z.Int("actual", len(pkSet)),
+		)
+	}
+
+	log.Debug(ctx, "Recombining private key shares", z.Int("validator_index", valIdx))
+	shares, err := shareIdxByPubkeys(cluster, pkSet, valIdx)
+	if err != nil {
+		return err
+	}
+
+	secret, err := tbls.RecoverSecret(shares, uint(len(cluster.GetOperators())), uint(cluster.GetThreshold()))
+	if err != nil {
+		return errors.Wrap(err, "cannot recover private key share", z.Int("validator_index", valIdx))
+	}
+
+	// require that the generated secret pubkey matches what's in the lockfile for the valIdx validator
+	val := cluster.GetValidators()[valIdx]
+
+	valPk, err := tblsconv.PubkeyFromBytes(val.GetPublicKey())
+	if err != nil {
+		return errors.Wrap(err, "public key for validator from manifest", z.Int("validator_index", valIdx))
 	}
 
-	if err := o.keyStoreFunc(combinedKeys, outputDir); err != nil {
-		return errors.Wrap(err, "cannot store keystore")
+	genPubkey, err := tbls.SecretToPublicKey(secret)
+	if err != nil {
+		return errors.Wrap(err, "public key for validator from generated secret", z.Int("validator_index", valIdx))
+	}
+
+	if valPk != genPubkey {
+		return errors.New("unexpected resulting combined validator public key",
+			z.Int("validator_index", valIdx), z.Hex("actual", genPubkey[:]), z.Hex("expected", valPk[:]))
+	}
+
+	if err := keyStoreFunc(secret, outputDir, valIdx); err != nil {
+		return errors.Wrap(err, "cannot store keystore", z.Int("validator_index", valIdx))
 	}
 
 	return nil
@@ -214,14 +302,39 @@ func shareIdxByPubkeys(cluster *manifestpb.Cluster, secrets []tbls.PrivateKey, v
 // WithInsecureKeysForT is a functional option for Combine that will use the insecure keystore.StoreKeysInsecure function.
 func WithInsecureKeysForT(_ *testing.T) func(*options) {
 	return func(o *options) {
-		o.keyStoreFunc = func(secrets []tbls.PrivateKey, dir string) error {
-			return keystore.StoreKeysInsecure(secrets, dir, keystore.ConfirmInsecureKeys)
+		o.keyStoreFunc = func(secret tbls.PrivateKey, dir string, index int) error {
+			return keystore.StoreKeyInsecure(secret, dir, index, keystore.ConfirmInsecureKeys)
 		}
 	}
 }
 
+// WithResult is a functional option for Combine that populates the result after storing the combined keys.
+func WithResult(result *Result) func(*options) {
+	return func(o *options) {
+		o.result = result
+	}
+}
+
+// WithProgress is a functional option for Combine that renders a progress bar of the recombined validators to w,
+// which should be a terminal. A nil w disables the progress bar.
+func WithProgress(w io.Writer) func(*options) {
+	return func(o *options) {
+		o.progress = w
+	}
+}
+
+// Result is the result of a successful Combine.
+type Result struct {
+	OutputDir string `json:"output_dir"`
+	// Validators are the 0x-prefixed public keys of the combined validator private keys, in keystore order.
+	Validators []string `json:"validators"`
+}
+
 type options struct {
-	keyStoreFunc func(secrets []tbls.PrivateKey, dir string) error
+	keyStoreFunc func(secret tbls.PrivateKey, dir string, index int) error
+	result       *Result
+	progress     io.Writer
+	workers      int
 }
 
 // loadManifest loads a cluster manifest from one of the charon directories contained in dir.
@@ -334,13 +334,21 @@ func combineTest(
 		}
 	}
 
-	err := combine.Combine(context.Background(), dir, od, true, noVerify, testnetConfig, combine.WithInsecureKeysForT(t))
+	var (
+		result   combine.Result
+		progress bytes.Buffer
+	)
+	err := combine.Combine(context.Background(), dir, od, true, noVerify, testnetConfig,
+		combine.WithInsecureKeysForT(t), combine.WithResult(&result), combine.WithProgress(&progress))
 	if wantErr {
 		require.Error(t, err)
 		return
 	}
 
 	require.NoError(t, err)
+	require.Contains(t, progress.String(), fmt.Sprintf("] %d/%d 100%%", len(expectedData), len(expectedData)))
+	require.Equal(t, od, result.OutputDir)
+	require.Len(t, result.Validators, len(expectedData))
 
 	keyFiles, err := keystore.LoadFilesUnordered(od)
 	require.NoError(t, err)
@@ -356,6 +364,7 @@ func combineTest(
 	for _, exp := range expectedData {
 		require.Contains(t, keysMap, exp.pubkey)
 		require.Equal(t, exp.secret, keysMap[exp.pubkey])
+		require.Contains(t, result.Validators, exp.pubkey)
 	}
 
 	require.Len(t, keysMap, len(expectedData))
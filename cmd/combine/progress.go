@@ -0,0 +1,102 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package combine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	progressRefreshPeriod = 500 * time.Millisecond
+	progressBarWidth      = 30
+)
+
+// progress tracks the number of recombined validators and renders it as a progress bar to a terminal.
+type progress struct {
+	w       io.Writer
+	total   int
+	start   time.Time
+	nowFunc func() time.Time
+	done    atomic.Int64
+}
+
+// newProgress returns a new progress bar of total validators rendered to w.
+func newProgress(w io.Writer, total int, nowFunc func() time.Time) *progress {
+	return &progress{
+		w:       w,
+		total:   total,
+		start:   nowFunc(),
+		nowFunc: nowFunc,
+	}
+}
+
+// Inc increments the number of recombined validators. It is safe to call on a nil progress.
+func (p *progress) Inc() {
+	if p == nil {
+		return
+	}
+
+	p.done.Add(1)
+}
+
+// Run renders the progress bar periodically until the context is cancelled, rendering a final frame on return.
+// It is safe to call on a nil progress.
+func (p *progress) Run(ctx context.Context) {
+	if p == nil {
+		return
+	}
+
+	ticker := time.NewTicker(progressRefreshPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			_, _ = fmt.Fprintf(p.w, "\r%s\n", p.render())
+			return
+		case <-ticker.C:
+			_, _ = fmt.Fprintf(p.w, "\r%s", p.render())
+		}
+	}
+}
+
+// render returns the progress bar, e.g. "[=========>          ] 1500/5000  30% 12.5/s ETA 4m40s".
+func (p *progress) render() string {
+	done := int(p.done.Load())
+
+	var filled int
+	if p.total > 0 {
+		filled = done * progressBarWidth / p.total
+	}
+
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+
+	percent := 100
+	if p.total > 0 {
+		percent = done * 100 / p.total
+	}
+
+	elapsed := p.nowFunc().Sub(p.start)
+
+	var rate float64
+	if elapsed > 0 {
+		rate = float64(done) / elapsed.Seconds()
+	}
+
+	eta := "?"
+	if done == p.total {
+		eta = "0s"
+	} else if rate > 0 {
+		eta = time.Duration(float64(p.total-done) / rate * float64(time.Second)).Round(time.Second).String()
+	}
+
+	return fmt.Sprintf("[%s] %d/%d %3d%% %.1f/s ETA %s", bar, done, p.total, percent, rate, eta)
+}
@@ -0,0 +1,31 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package combine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressRender(t *testing.T) {
+	now := time.Unix(0, 0)
+	p := newProgress(nil, 100, func() time.Time { return now })
+
+	require.Equal(t, "[>                             ] 0/100   0% 0.0/s ETA ?", p.render())
+
+	for range 30 {
+		p.Inc()
+	}
+	now = now.Add(10 * time.Second)
+	require.Equal(t, "[=========>                    ] 30/100  30% 3.0/s ETA 23s", p.render())
+
+	for range 70 {
+		p.Inc()
+	}
+	require.Equal(t, "[==============================] 100/100 100% 10.0/s ETA 0s", p.render())
+
+	var nilProgress *progress
+	nilProgress.Inc()
+}
@@ -0,0 +1,59 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// newCompletionCmd returns the shell completion command, replacing cobra's default completion command.
+func newCompletionCmd(runFunc func(io.Writer, *cobra.Command, string) error) *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish",
+		Short: "Generate shell completion scripts",
+		Long: `Generates the shell completion script of all charon commands and flags for the specified shell.
+
+Load completions in the current bash session:
+  source <(charon completion bash)
+
+Load completions for every new bash session (requires the bash-completion package):
+  charon completion bash > /etc/bash_completion.d/charon
+
+Load completions for every new zsh session (requires compinit):
+  charon completion zsh > "${fpath[1]}/_charon"
+
+Load completions for every new fish session:
+  charon completion fish > ~/.config/fish/completions/charon.fish`,
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.OutOrStdout(), cmd.Root(), args[0])
+		},
+	}
+}
+
+// runCompletion writes the completion script of the root command for the shell.
+func runCompletion(w io.Writer, root *cobra.Command, shell string) error {
+	var err error
+	switch shell {
+	case "bash":
+		err = root.GenBashCompletionV2(w, true)
+	case "zsh":
+		err = root.GenZshCompletion(w)
+	case "fish":
+		err = root.GenFishCompletion(w, true)
+	default:
+		return errors.New("unsupported shell", z.Str("shell", shell))
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "generate completion", z.Str("shell", shell))
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletion(t *testing.T) {
+	tests := []struct {
+		Shell    string
+		Contains string
+		ErrorMsg string
+	}{
+		{Shell: "bash", Contains: "__start_charon"},
+		{Shell: "zsh", Contains: "#compdef charon"},
+		{Shell: "fish", Contains: "complete -c charon"},
+		{Shell: "powershell", ErrorMsg: "invalid argument"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			root := New()
+			root.SetOut(&buf)
+			root.SetArgs(slice("completion", test.Shell))
+
+			err := root.Execute()
+			if test.ErrorMsg != "" {
+				require.ErrorContains(t, err, test.ErrorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Contains(t, buf.String(), test.Contains)
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/spf13/pflag"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
+	"github.com/obolnetwork/charon/app/datadir"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/app/log"
@@ -35,12 +36,14 @@ import (
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/core/consensus/protocols"
+	"github.com/obolnetwork/charon/dkg"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/deposit"
 	"github.com/obolnetwork/charon/eth2util/enr"
 	"github.com/obolnetwork/charon/eth2util/keymanager"
 	"github.com/obolnetwork/charon/eth2util/keystore"
 	"github.com/obolnetwork/charon/eth2util/registration"
+	"github.com/obolnetwork/charon/eth2util/web3signer"
 	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
@@ -72,11 +75,18 @@ type clusterConfig struct {
 	NumDVs            int
 
 	DepositAmounts []int // Amounts specified in ETH (integers).
+	Compounding    bool
+	DepositBatch   bool
 
-	SplitKeys    bool
-	SplitKeysDir string
+	SplitKeys                       bool
+	SplitKeysDir                    string
+	SplitKeysMnemonicFile           string
+	SplitKeysMnemonicPassphraseFile string
+	SplitKeysMnemonicStart          int
 
-	InsecureKeys bool
+	InsecureKeys    bool
+	OutputFormat    string
+	KeystoreOptions keystore.Options
 
 	PublishAddr string
 	Publish     bool
@@ -85,9 +95,23 @@ type clusterConfig struct {
 
 	TargetGasLimit uint
 
+	// JSON writes the created cluster as JSON instead of text, see the global --output flag.
+	JSON bool
+
 	testnetConfig eth2util.Network
 }
 
+// createClusterResult is the JSON output of the create cluster command.
+type createClusterResult struct {
+	ClusterDir string `json:"cluster_dir"`
+	// NodeDirs are the directories containing each node's private key, cluster lock and keys.
+	NodeDirs []string `json:"node_dirs"`
+	LockHash string   `json:"lock_hash"`
+	// Validators are the 0x-prefixed public keys of the created distributed validators.
+	Validators   []string `json:"validators"`
+	DashboardURL string   `json:"dashboard_url,omitempty"`
+}
+
 func newCreateClusterCmd(runFunc func(context.Context, io.Writer, clusterConfig) error) *cobra.Command {
 	var conf clusterConfig
 
@@ -97,12 +121,17 @@ func newCreateClusterCmd(runFunc func(context.Context, io.Writer, clusterConfig)
 		Long: "Creates a local charon cluster configuration including validator keys, charon p2p keys, cluster-lock.json and deposit-data.json file(s). " +
 			"See flags for supported features.",
 		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			conf.JSON = isJSONOutput(cmd)
+
 			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
 		},
 	}
 
 	bindClusterFlags(cmd.Flags(), &conf)
+	bindTestnetChainSpecFlag(cmd, &conf.testnetConfig)
 	bindInsecureFlags(cmd.Flags(), &conf.InsecureKeys)
+	bindOutputFormatFlag(cmd.Flags(), &conf.OutputFormat)
+	bindKeystoreFlags(cmd.Flags(), &conf.KeystoreOptions)
 
 	wrapPreRunE(cmd, func(cmd *cobra.Command, _ []string) error {
 		thresholdPresent := cmd.Flags().Lookup("threshold").Changed
@@ -120,6 +149,8 @@ func newCreateClusterCmd(runFunc func(context.Context, io.Writer, clusterConfig)
 		return nil
 	})
 
+	supportJSONOutput(cmd)
+
 	return cmd
 }
 
@@ -133,17 +164,23 @@ func bindClusterFlags(flags *pflag.FlagSet, config *clusterConfig) {
 	flags.IntVar(&config.Threshold, "threshold", 0, "Optional override of threshold required for signature reconstruction. Defaults to ceil(n*2/3) if zero. Warning, non-default values decrease security.")
 	flags.StringSliceVar(&config.FeeRecipientAddrs, "fee-recipient-addresses", nil, "Comma separated list of Ethereum addresses of the fee recipient for each validator. Either provide a single fee recipient address or fee recipient addresses for each validator.")
 	flags.StringSliceVar(&config.WithdrawalAddrs, "withdrawal-addresses", nil, "Comma separated list of Ethereum addresses to receive the returned stake and accrued rewards for each validator. Either provide a single withdrawal address or withdrawal addresses for each validator.")
-	flags.StringVar(&config.Network, "network", "", "Ethereum network to create validators for. Options: mainnet, goerli, sepolia, holesky, gnosis, chiado.")
+	flags.StringVar(&config.Network, "network", "", "Ethereum network to create validators for. Options: mainnet, goerli, sepolia, holesky, hoodi, gnosis, chiado.")
 	flags.IntVar(&config.NumDVs, "num-validators", 0, "The number of distributed validators needed in the cluster.")
 	flags.BoolVar(&config.SplitKeys, "split-existing-keys", false, "Split an existing validator's private key into a set of distributed validator private key shares. Does not re-create deposit data for this key.")
 	flags.StringVar(&config.SplitKeysDir, "split-keys-dir", "", "Directory containing keys to split. Expects keys in keystore-*.json and passwords in keystore-*.txt. Requires --split-existing-keys.")
+	flags.StringVar(&config.SplitKeysMnemonicFile, "split-keys-mnemonic-file", "", "File containing a BIP-39 mnemonic to derive the keys to split from instead of --split-keys-dir. Derives --num-validators keys at EIP-2334 paths m/12381/3600/i/0/0. Requires --split-existing-keys.")
+	flags.StringVar(&config.SplitKeysMnemonicPassphraseFile, "split-keys-mnemonic-passphrase-file", "", "Optional file containing the BIP-39 passphrase of --split-keys-mnemonic-file.")
+	flags.IntVar(&config.SplitKeysMnemonicStart, "split-keys-mnemonic-start-index", 0, "Validator index i of the first key derived from --split-keys-mnemonic-file.")
 	flags.StringVar(&config.PublishAddr, "publish-address", "https://api.obol.tech/v1", "The URL to publish the lock file to.")
 	flags.BoolVar(&config.Publish, "publish", false, "Publish lock file to obol-api.")
 	flags.StringVar(&config.testnetConfig.Name, "testnet-name", "", "Name of the custom test network.")
 	flags.StringVar(&config.testnetConfig.GenesisForkVersionHex, "testnet-fork-version", "", "Genesis fork version of the custom test network (in hex).")
 	flags.Uint64Var(&config.testnetConfig.ChainID, "testnet-chain-id", 0, "Chain ID of the custom test network.")
 	flags.Int64Var(&config.testnetConfig.GenesisTimestamp, "testnet-genesis-timestamp", 0, "Genesis timestamp of the custom test network.")
-	flags.IntSliceVar(&config.DepositAmounts, "deposit-amounts", nil, "List of partial deposit amounts (integers) in ETH. Values must sum up to exactly 32ETH.")
+	flags.StringVar(&config.testnetConfig.CapellaHardFork, "testnet-capella-hard-fork", "", "Capella hard fork version of the custom test network.")
+	flags.IntSliceVar(&config.DepositAmounts, "deposit-amounts", nil, "List of partial deposit amounts (integers) in ETH. Values must sum up to exactly 32ETH, or between 32ETH and 2048ETH with --compounding.")
+	flags.BoolVar(&config.Compounding, "compounding", false, "Create deposit data with 0x02 compounding withdrawal credentials, supporting deposit amounts of up to 2048ETH.")
+	flags.BoolVar(&config.DepositBatch, "deposit-batch", false, "Also write deposit-batch.json and deposit-batch.csv files containing all deposits for submission via batch deposit contracts.")
 	flags.StringVar(&config.ConsensusProtocol, "consensus-protocol", "", "Preferred consensus protocol name for the cluster. Selected automatically when not specified.")
 	flags.UintVar(&config.TargetGasLimit, "target-gas-limit", 36000000, "Preferred target gas limit for transactions.")
 }
@@ -162,6 +199,11 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 
 	var def cluster.Definition
 	if conf.DefFile != "" { // Load definition from DefFile
+		// Custom testnets must be supported before verifying the definition's signatures.
+		if conf.testnetConfig.IsNonZero() {
+			eth2util.AddTestNetwork(conf.testnetConfig)
+		}
+
 		def, err = loadDefinition(ctx, conf.DefFile)
 		if err != nil {
 			return err
@@ -181,8 +223,19 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 	// secrets we read.
 	// If SplitKeys wasn't set, we wouldn't have reached this part of code because validateCreateConfig()
 	// would've already errored.
+	// The sources are the keystore file names or mnemonic paths of the split keys.
+	var sources []string
 	if conf.SplitKeys {
-		secrets, err = getKeys(conf.SplitKeysDir)
+		if conf.SplitKeysMnemonicFile != "" {
+			numDVs := conf.NumDVs
+			if conf.DefFile != "" {
+				numDVs = def.NumValidators
+			}
+
+			secrets, sources, err = getMnemonicKeys(conf, numDVs)
+		} else {
+			secrets, sources, err = getKeys(conf.SplitKeysDir)
+		}
 		if err != nil {
 			return err
 		}
@@ -255,9 +308,15 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 
 	keysToDisk := len(conf.KeymanagerAddrs) == 0
 	if keysToDisk { // Save keys to disk
-		if err = writeKeysToDisk(numNodes, conf.ClusterDir, conf.InsecureKeys, shareSets); err != nil {
+		if err = writeKeysToDisk(numNodes, conf.ClusterDir, conf.InsecureKeys, conf.KeystoreOptions, shareSets); err != nil {
 			return err
 		}
+
+		if conf.OutputFormat == dkg.OutputFormatWeb3Signer {
+			if err = writeWeb3SignerConfigs(numNodes, conf.ClusterDir, def.ForkVersion); err != nil {
+				return err
+			}
+		}
 	} else { // Or else save keys to keymanager
 		if err = writeKeysToKeymanager(ctx, conf, numNodes, shareSets); err != nil {
 			return err
@@ -269,7 +328,7 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 		return err
 	}
 
-	depositDatas, err := createDepositDatas(def.WithdrawalAddresses(), network, secrets, depositAmounts)
+	depositDatas, err := createDepositDatas(def.WithdrawalAddresses(), network, secrets, depositAmounts, conf.Compounding)
 	if err != nil {
 		return err
 	}
@@ -279,6 +338,17 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 		return err
 	}
 
+	if conf.DepositBatch {
+		batch, err := deposit.BatchDepositDatas(depositDatas, depositAmounts)
+		if err != nil {
+			return err
+		}
+
+		if err = deposit.WriteClusterDepositBatchFiles(batch, network, conf.ClusterDir, numNodes); err != nil {
+			return err
+		}
+	}
+
 	valRegs, err := createValidatorRegistrations(ctx, def.FeeRecipientAddresses(), secrets, def.ForkVersion, conf.SplitKeys, conf.TargetGasLimit)
 	if err != nil {
 		return err
@@ -329,10 +399,23 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 	}
 
 	if conf.SplitKeys {
-		writeWarning(w)
+		if err := writeMigration(conf.ClusterDir, network, lock, sources); err != nil {
+			return err
+		}
+
+		if conf.JSON {
+			log.Warn(ctx, "Splitting keys, make sure any existing validator has been shut down for at least 2 finalised epochs "+
+				"before starting the charon cluster, otherwise slashing could occur", nil)
+		} else {
+			writeWarning(w)
+		}
+	}
+
+	if conf.JSON {
+		return writeCreateClusterResult(w, conf.ClusterDir, numNodes, lock, dashboardURL)
 	}
 
-	if err := writeOutput(w, conf.SplitKeys, conf.ClusterDir, numNodes, keysToDisk); err != nil {
+	if err := writeOutput(w, conf.SplitKeys, conf.ClusterDir, numNodes, keysToDisk, conf.DepositBatch); err != nil {
 		return err
 	}
 
@@ -362,10 +445,29 @@ func validateCreateConfig(ctx context.Context, conf clusterConfig) error {
 		return errors.New("number of --keymanager-addresses do not match --keymanager-auth-tokens. Please fix configuration flags")
 	}
 
+	switch conf.OutputFormat {
+	case "", dkg.OutputFormatDefault:
+	case dkg.OutputFormatWeb3Signer:
+		if len(conf.KeymanagerAddrs) > 0 {
+			return errors.New("--output-format=web3signer not supported with --keymanager-addresses. Please fix configuration flags")
+		}
+	default:
+		return errors.New("invalid --output-format", z.Str("format", conf.OutputFormat))
+	}
+
+	if err := conf.KeystoreOptions.Validate(); err != nil {
+		return err
+	}
+
 	if len(conf.DepositAmounts) > 0 {
 		amounts := deposit.EthsToGweis(conf.DepositAmounts)
 
-		if err := deposit.VerifyDepositAmounts(amounts); err != nil {
+		verifyFunc := deposit.VerifyDepositAmounts
+		if conf.Compounding {
+			verifyFunc = deposit.VerifyCompoundingDepositAmounts
+		}
+
+		if err := verifyFunc(amounts); err != nil {
 			return err
 		}
 	}
@@ -373,15 +475,25 @@ func validateCreateConfig(ctx context.Context, conf clusterConfig) error {
 	for _, addr := range conf.KeymanagerAddrs {
 		keymanagerURL, err := url.ParseRequestURI(addr)
 		if err != nil {
-			return errors.Wrap(err, "failed to parse keymanager addr", z.Str("addr", addr))
+			return errors.Wrap(err, "failed to parse keymanager addr", z.Redact(z.Str("addr", addr)))
 		}
 
 		if keymanagerURL.Scheme == httpScheme {
-			log.Warn(ctx, "Keymanager URL does not use https protocol", nil, z.Str("addr", addr))
+			log.Warn(ctx, "Keymanager URL does not use https protocol", nil, z.Redact(z.Str("addr", addr)))
 		}
 	}
 
-	if conf.SplitKeys {
+	if conf.SplitKeysMnemonicFile != "" && !conf.SplitKeys {
+		return errors.New("--split-keys-mnemonic-file requires --split-existing-keys. Please fix configuration flags")
+	}
+
+	if conf.SplitKeys && conf.SplitKeysMnemonicFile != "" {
+		if conf.SplitKeysDir != "" {
+			return errors.New("can't specify both --split-keys-dir and --split-keys-mnemonic-file. Please fix configuration flags")
+		} else if conf.NumDVs == 0 && conf.DefFile == "" {
+			return errors.New("missing --num-validators flag, required with --split-keys-mnemonic-file")
+		}
+	} else if conf.SplitKeys {
 		if conf.NumDVs != 0 {
 			return errors.New("can't specify --num-validators with --split-existing-keys. Please fix configuration flags")
 		}
@@ -420,7 +532,8 @@ func detectNodeDirs(clusterDir string, nodeAmount int) error {
 }
 
 // signDepositDatas returns a list of DepositData for each partial deposit amount.
-func signDepositDatas(secrets []tbls.PrivateKey, withdrawalAddresses []string, network string, depositAmounts []eth2p0.Gwei) ([][]eth2p0.DepositData, error) {
+// Deposit messages use 0x02 compounding withdrawal credentials if compounding is true.
+func signDepositDatas(secrets []tbls.PrivateKey, withdrawalAddresses []string, network string, depositAmounts []eth2p0.Gwei, compounding bool) ([][]eth2p0.DepositData, error) {
 	if len(secrets) != len(withdrawalAddresses) {
 		return nil, errors.New("insufficient withdrawal addresses")
 	}
@@ -428,6 +541,11 @@ func signDepositDatas(secrets []tbls.PrivateKey, withdrawalAddresses []string, n
 		return nil, errors.New("empty deposit amounts")
 	}
 
+	newMsgFunc := deposit.NewMessage
+	if compounding {
+		newMsgFunc = deposit.NewCompoundingMessage
+	}
+
 	var dd [][]eth2p0.DepositData
 	for _, depositAmount := range depositAmounts {
 		var datas []eth2p0.DepositData
@@ -442,7 +560,7 @@ func signDepositDatas(secrets []tbls.PrivateKey, withdrawalAddresses []string, n
 				return nil, errors.Wrap(err, "secret to pubkey")
 			}
 
-			msg, err := deposit.NewMessage(eth2p0.BLSPubKey(pk), withdrawalAddr, depositAmount)
+			msg, err := newMsgFunc(eth2p0.BLSPubKey(pk), withdrawalAddr, depositAmount)
 			if err != nil {
 				return nil, err
 			}
@@ -581,20 +699,6 @@ func writeWarning(w io.Writer) {
 	_, _ = w.Write([]byte(sb.String()))
 }
 
-// getKeys fetches secret keys from splitKeysDir.
-func getKeys(splitKeysDir string) ([]tbls.PrivateKey, error) {
-	if splitKeysDir == "" {
-		return nil, errors.New("--split-keys-dir required when splitting keys")
-	}
-
-	files, err := keystore.LoadFilesUnordered(splitKeysDir)
-	if err != nil {
-		return nil, err
-	}
-
-	return files.SequencedKeys()
-}
-
 // generateKeys generates numDVs amount of tbls.PrivateKeys.
 func generateKeys(numDVs int) ([]tbls.PrivateKey, error) {
 	var secrets []tbls.PrivateKey
@@ -611,7 +715,7 @@ func generateKeys(numDVs int) ([]tbls.PrivateKey, error) {
 }
 
 // createDepositDatas creates a slice of deposit datas using the provided parameters and returns it.
-func createDepositDatas(withdrawalAddresses []string, network string, secrets []tbls.PrivateKey, depositAmounts []eth2p0.Gwei) ([][]eth2p0.DepositData, error) {
+func createDepositDatas(withdrawalAddresses []string, network string, secrets []tbls.PrivateKey, depositAmounts []eth2p0.Gwei, compounding bool) ([][]eth2p0.DepositData, error) {
 	if len(secrets) != len(withdrawalAddresses) {
 		return nil, errors.New("insufficient withdrawal addresses")
 	}
@@ -620,7 +724,7 @@ func createDepositDatas(withdrawalAddresses []string, network string, secrets []
 	}
 	depositAmounts = deposit.DedupAmounts(depositAmounts)
 
-	return signDepositDatas(secrets, withdrawalAddresses, network, depositAmounts)
+	return signDepositDatas(secrets, withdrawalAddresses, network, depositAmounts, compounding)
 }
 
 // createValidatorRegistrations creates a slice of builder validator registrations using the provided parameters and returns it.
@@ -637,7 +741,8 @@ func createValidatorRegistrations(ctx context.Context, feeAddresses []string, se
 	return signValidatorRegistrations(secrets, feeAddresses, forkVersion, useCurrentTimestamp, targetGasLimit)
 }
 
-// writeLock creates a cluster lock and writes it to disk for all peers.
+// writeLock creates a cluster lock and writes it to disk for all peers, stamping their data directories
+// with the current schema version.
 func writeLock(lock cluster.Lock, clusterDir string, numNodes int) error {
 	b, err := json.MarshalIndent(lock, "", " ")
 	if err != nil {
@@ -650,6 +755,10 @@ func writeLock(lock cluster.Lock, clusterDir string, numNodes int) error {
 		if err != nil {
 			return errors.Wrap(err, "write cluster lock")
 		}
+
+		if err := datadir.Init(nodeDir(clusterDir, i)); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -714,7 +823,7 @@ func getValidators(
 
 		depositDatasList, ok := depositDatasMap[dv]
 		if !ok {
-			return nil, errors.New("deposit data not found for dv", z.Str("dv", hex.EncodeToString(dv[:])))
+			return nil, errors.New("deposit data not found for dv", z.Redact(z.Str("dv", hex.EncodeToString(dv[:]))))
 		}
 
 		for _, dd := range depositDatasList {
@@ -761,7 +870,7 @@ func writeKeysToKeymanager(ctx context.Context, conf clusterConfig, numNodes int
 			}
 			passwords = append(passwords, password)
 
-			var opts []keystorev4.Option
+			opts := conf.KeystoreOptions.EncryptorOptions()
 			if conf.InsecureKeys {
 				opts = append(opts, keystorev4.WithCost(new(testing.T), 4))
 			}
@@ -774,12 +883,12 @@ func writeKeysToKeymanager(ctx context.Context, conf clusterConfig, numNodes int
 
 		err := clients[i].ImportKeystores(ctx, keystores, passwords)
 		if err != nil {
-			log.Error(ctx, "Failed to import keys", err, z.Str("addr", conf.KeymanagerAddrs[i]))
+			log.Error(ctx, "Failed to import keys", err, z.Redact(z.Str("addr", conf.KeymanagerAddrs[i])))
 			return err
 		}
 
 		log.Info(ctx, "Imported key shares to keymanager",
-			z.Str("node", fmt.Sprintf("node%d", i)), z.Str("addr", conf.KeymanagerAddrs[i]))
+			z.Str("node", fmt.Sprintf("node%d", i)), z.Redact(z.Str("addr", conf.KeymanagerAddrs[i])))
 	}
 
 	log.Info(ctx, "Imported all validator keys to respective keymanagers")
@@ -788,7 +897,7 @@ func writeKeysToKeymanager(ctx context.Context, conf clusterConfig, numNodes int
 }
 
 // writeKeysToDisk writes validator keyshares to disk. It assumes that the directory for each node already exists.
-func writeKeysToDisk(numNodes int, clusterDir string, insecureKeys bool, shareSets [][]tbls.PrivateKey) error {
+func writeKeysToDisk(numNodes int, clusterDir string, insecureKeys bool, opts keystore.Options, shareSets [][]tbls.PrivateKey) error {
 	for i := range numNodes {
 		var secrets []tbls.PrivateKey
 		for _, shares := range shareSets {
@@ -805,7 +914,7 @@ func writeKeysToDisk(numNodes int, clusterDir string, insecureKeys bool, shareSe
 				return err
 			}
 		} else {
-			if err := keystore.StoreKeys(secrets, keysDir); err != nil {
+			if err := keystore.StoreKeysWithOptions(secrets, keysDir, opts); err != nil {
 				return err
 			}
 		}
@@ -814,6 +923,24 @@ func writeKeysToDisk(numNodes int, clusterDir string, insecureKeys bool, shareSe
 	return nil
 }
 
+// writeWeb3SignerConfigs writes Web3Signer key configs and slashing protection interchange files for each node's
+// validator keys. It assumes that the keys have already been written to disk.
+func writeWeb3SignerConfigs(numNodes int, clusterDir string, forkVersion []byte) error {
+	gvr, err := eth2util.ForkVersionToGenesisValidatorsRoot(forkVersion)
+	if err != nil {
+		return err
+	}
+
+	for i := range numNodes {
+		dir := nodeDir(clusterDir, i)
+		if err := web3signer.Write(filepath.Join(dir, "web3signer"), filepath.Join(dir, "validator_keys"), gvr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // getOperators returns a list of `n` operators and their respective identity private keys.
 // It also creates a new directory corresponding to each node.
 func getOperators(n int, clusterDir string) ([]cluster.Operator, []*k1.PrivateKey, error) {
@@ -887,7 +1014,7 @@ func newPeer(clusterDir string, peerIdx int) (enr.Record, *k1.PrivateKey, error)
 }
 
 // writeOutput writes the cluster generation output.
-func writeOutput(out io.Writer, splitKeys bool, clusterDir string, numNodes int, keysToDisk bool) error {
+func writeOutput(out io.Writer, splitKeys bool, clusterDir string, numNodes int, keysToDisk bool, depositBatch bool) error {
 	absClusterDir, err := filepath.Abs(clusterDir)
 	if err != nil {
 		return errors.Wrap(err, "absolute path retrieval")
@@ -902,17 +1029,48 @@ func writeOutput(out io.Writer, splitKeys bool, clusterDir string, numNodes int,
 	_, _ = sb.WriteString("│  ├─ charon-enr-private-key\tCharon networking private key for node authentication\n")
 	_, _ = sb.WriteString("│  ├─ cluster-lock.json\t\tCluster lock defines the cluster lock file which is signed by all nodes\n")
 	_, _ = sb.WriteString("│  ├─ deposit-data-*.json\tDeposit data files are used to activate a Distributed Validator on the DV Launchpad\n")
+	if depositBatch {
+		_, _ = sb.WriteString("│  ├─ deposit-batch.*\t\tAll deposits combined for submission via batch deposit contracts\n")
+	}
 	if keysToDisk {
 		_, _ = sb.WriteString("│  ├─ validator_keys\t\tValidator keystores and password\n")
 		_, _ = sb.WriteString("│  │  ├─ keystore-*.json\tValidator private share key for duty signing\n")
 		_, _ = sb.WriteString("│  │  ├─ keystore-*.txt\t\tKeystore password files for keystore-*.json\n")
 	}
+	if splitKeys {
+		_, _ = sb.WriteString("├─ migration.json\t\tRecord of the existing validators split into the cluster\n")
+		_, _ = sb.WriteString("├─ migration.md\t\t\tSlashing, exit and withdrawal safety checklist for migrating existing validators\n")
+	}
 
 	_, _ = fmt.Fprint(out, sb.String())
 
 	return nil
 }
 
+// writeCreateClusterResult writes the created cluster as JSON.
+func writeCreateClusterResult(out io.Writer, clusterDir string, numNodes int, lock cluster.Lock, dashboardURL string) error {
+	absClusterDir, err := filepath.Abs(clusterDir)
+	if err != nil {
+		return errors.Wrap(err, "absolute path retrieval")
+	}
+
+	result := createClusterResult{
+		ClusterDir:   absClusterDir,
+		NodeDirs:     []string{},
+		LockHash:     fmt.Sprintf("%#x", lock.LockHash),
+		Validators:   []string{},
+		DashboardURL: dashboardURL,
+	}
+	for i := range numNodes {
+		result.NodeDirs = append(result.NodeDirs, nodeDir(absClusterDir, i))
+	}
+	for _, val := range lock.Validators {
+		result.Validators = append(result.Validators, fmt.Sprintf("%#x", val.PubKey))
+	}
+
+	return writeJSON(out, result)
+}
+
 // nodeDir returns a node directory.
 func nodeDir(clusterDir string, i int) string {
 	return fmt.Sprintf("%s/node%d", clusterDir, i)
@@ -1079,7 +1237,7 @@ func writeLockToAPI(ctx context.Context, publishAddr string, lock cluster.Lock)
 		return "", err
 	}
 
-	log.Info(ctx, "Published lock file", z.Str("addr", publishAddr))
+	log.Info(ctx, "Published lock file", z.Redact(z.Str("addr", publishAddr)))
 
 	return cl.LaunchpadURLForLock(lock), nil
 }
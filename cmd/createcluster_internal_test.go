@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
 	"math/rand"
 	"net/http"
@@ -27,6 +28,7 @@ import (
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/deposit"
 	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/mnemonic"
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
 	"github.com/obolnetwork/charon/testutil"
@@ -79,6 +81,29 @@ func TestCreateCluster(t *testing.T) {
 				DepositAmounts: []int{8, 8, 8, 8},
 			},
 		},
+		{
+			Name: "compounding batched deposits",
+			Config: clusterConfig{
+				NumNodes:       4,
+				Threshold:      3,
+				NumDVs:         2,
+				Network:        eth2util.Goerli.Name,
+				DepositAmounts: []int{32, 16, 16},
+				Compounding:    true,
+				DepositBatch:   true,
+			},
+		},
+		{
+			Name: "compounding amounts without compounding",
+			Config: clusterConfig{
+				NumNodes:       4,
+				Threshold:      3,
+				NumDVs:         1,
+				Network:        eth2util.Goerli.Name,
+				DepositAmounts: []int{32, 16, 16},
+			},
+			expectedErr: "sum of partial deposit amounts must sum up to 32ETH",
+		},
 		{
 			Name: "splitkeys",
 			Config: clusterConfig{
@@ -189,6 +214,41 @@ func TestCreateCluster(t *testing.T) {
 			},
 			expectedErr: "can't specify --num-validators with --split-existing-keys. Please fix configuration flags",
 		},
+		{
+			Name: "splitkeys with both keys dir and mnemonic",
+			Config: clusterConfig{
+				NumNodes:              4,
+				Threshold:             3,
+				SplitKeys:             true,
+				SplitKeysDir:          "keys",
+				SplitKeysMnemonicFile: "mnemonic.txt",
+				NumDVs:                1,
+				Network:               defaultNetwork,
+			},
+			expectedErr: "can't specify both --split-keys-dir and --split-keys-mnemonic-file",
+		},
+		{
+			Name: "splitkeys mnemonic without numdvs",
+			Config: clusterConfig{
+				NumNodes:              4,
+				Threshold:             3,
+				SplitKeys:             true,
+				SplitKeysMnemonicFile: "mnemonic.txt",
+				Network:               defaultNetwork,
+			},
+			expectedErr: "missing --num-validators flag, required with --split-keys-mnemonic-file",
+		},
+		{
+			Name: "mnemonic without splitkeys",
+			Config: clusterConfig{
+				NumNodes:              4,
+				Threshold:             3,
+				SplitKeysMnemonicFile: "mnemonic.txt",
+				NumDVs:                1,
+				Network:               defaultNetwork,
+			},
+			expectedErr: "--split-keys-mnemonic-file requires --split-existing-keys",
+		},
 		{
 			Name: "goerli",
 			Config: clusterConfig{
@@ -290,6 +350,29 @@ func TestCreateCluster(t *testing.T) {
 				TargetGasLimit: 36000000,
 			},
 		},
+		{
+			Name: "web3signer output format",
+			Config: clusterConfig{
+				NumNodes:     3,
+				Threshold:    2,
+				NumDVs:       2,
+				Network:      eth2util.Holesky.Name,
+				OutputFormat: "web3signer",
+			},
+		},
+		{
+			Name: "web3signer output format with keymanager",
+			Config: clusterConfig{
+				NumNodes:             3,
+				Threshold:            2,
+				NumDVs:               2,
+				Network:              eth2util.Holesky.Name,
+				OutputFormat:         "web3signer",
+				KeymanagerAddrs:      []string{"https://1.1.1.1", "https://2.2.2.2", "https://3.3.3.3"},
+				KeymanagerAuthTokens: []string{"token1", "token2", "token3"},
+			},
+			expectedErr: "--output-format=web3signer not supported with --keymanager-addresses",
+		},
 	}
 
 	for _, test := range tests {
@@ -607,6 +690,65 @@ func TestSplitKeys(t *testing.T) {
 	}
 }
 
+func TestSplitKeysMnemonic(t *testing.T) {
+	const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	dir := t.TempDir()
+	mnemonicFile := filepath.Join(dir, "mnemonic.txt")
+	require.NoError(t, os.WriteFile(mnemonicFile, []byte(testMnemonic+"\n"), 0o600))
+
+	conf := clusterConfig{
+		Name:                   "test split mnemonic",
+		NumNodes:               minNodes,
+		Threshold:              3,
+		NumDVs:                 2,
+		FeeRecipientAddrs:      []string{zeroAddress},
+		WithdrawalAddrs:        []string{zeroAddress},
+		ClusterDir:             filepath.Join(dir, "cluster"),
+		SplitKeys:              true,
+		SplitKeysMnemonicFile:  mnemonicFile,
+		SplitKeysMnemonicStart: 1,
+		InsecureKeys:           true,
+		Network:                eth2util.Goerli.Name,
+		TargetGasLimit:         30000000,
+	}
+
+	var buf bytes.Buffer
+	err := runCreateCluster(context.Background(), &buf, conf)
+	testutil.RequireNoError(t, err)
+	require.Contains(t, buf.String(), "migration.md")
+
+	b, err := os.ReadFile(path.Join(nodeDir(conf.ClusterDir, 0), "cluster-lock.json"))
+	require.NoError(t, err)
+
+	var lock cluster.Lock
+	require.NoError(t, json.Unmarshal(b, &lock))
+	require.NoError(t, lock.VerifySignatures())
+
+	secrets, err := mnemonic.ValidatorKeys(testMnemonic, "", 1, 2)
+	require.NoError(t, err)
+
+	b, err = os.ReadFile(filepath.Join(conf.ClusterDir, migrationJSONFile))
+	require.NoError(t, err)
+
+	var m migration
+	require.NoError(t, json.Unmarshal(b, &m))
+	require.Equal(t, fmt.Sprintf("%#x", lock.LockHash), m.LockHash)
+	require.Len(t, m.Validators, 2)
+
+	for i, secret := range secrets {
+		pubkey, err := tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+		require.Equal(t, pubkey[:], lock.Validators[i].PubKey)
+		require.Equal(t, lock.Validators[i].PublicKeyHex(), m.Validators[i].PublicKey)
+		require.Equal(t, mnemonic.Path(i+1), m.Validators[i].Source)
+	}
+
+	md, err := os.ReadFile(filepath.Join(conf.ClusterDir, migrationMDFile))
+	require.NoError(t, err)
+	require.Contains(t, string(md), mnemonic.Path(2))
+}
+
 func TestMultipleAddresses(t *testing.T) {
 	t.Run("insufficient fee recipient addresses", func(t *testing.T) {
 		err := runCreateCluster(context.Background(), io.Discard, clusterConfig{
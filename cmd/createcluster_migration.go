@@ -0,0 +1,170 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/mnemonic"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const (
+	migrationJSONFile = "migration.json"
+	migrationMDFile   = "migration.md"
+)
+
+// migration is the machine-readable record of existing validators split into a distributed validator cluster.
+type migration struct {
+	CreatedAt  time.Time            `json:"created_at"`
+	Network    string               `json:"network"`
+	LockHash   string               `json:"lock_hash"`
+	Threshold  int                  `json:"threshold"`
+	Operators  int                  `json:"operators"`
+	Validators []migrationValidator `json:"validators"`
+}
+
+// migrationValidator is a validator in the migration record.
+type migrationValidator struct {
+	PublicKey string `json:"public_key"`
+	// Source is the keystore file name or EIP-2334 mnemonic path the existing validator key was loaded from.
+	Source string `json:"source"`
+	// WithdrawalAddress is the withdrawal address in the cluster lock, it doesn't change the existing withdrawal credentials.
+	WithdrawalAddress string `json:"withdrawal_address"`
+}
+
+// getKeys fetches secret keys from splitKeysDir, returning their keystore file names as sources.
+func getKeys(splitKeysDir string) ([]tbls.PrivateKey, []string, error) {
+	if splitKeysDir == "" {
+		return nil, nil, errors.New("--split-keys-dir or --split-keys-mnemonic-file required when splitting keys")
+	}
+
+	files, err := keystore.LoadFilesUnordered(splitKeysDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secrets, err := files.SequencedKeys()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sources := make([]string, len(files))
+	for _, file := range files {
+		sources[file.FileIndex] = filepath.Base(file.Filename)
+	}
+
+	return secrets, sources, nil
+}
+
+// getMnemonicKeys derives numDVs secret keys from the mnemonic file, returning their EIP-2334 paths as sources.
+func getMnemonicKeys(conf clusterConfig, numDVs int) ([]tbls.PrivateKey, []string, error) {
+	words, err := loadPassphrase(conf.SplitKeysMnemonicFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var passphrase string
+	if conf.SplitKeysMnemonicPassphraseFile != "" {
+		passphrase, err = loadPassphrase(conf.SplitKeysMnemonicPassphraseFile)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	secrets, err := mnemonic.ValidatorKeys(words, passphrase, conf.SplitKeysMnemonicStart, numDVs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sources []string
+	for i := range numDVs {
+		sources = append(sources, mnemonic.Path(conf.SplitKeysMnemonicStart+i))
+	}
+
+	return secrets, sources, nil
+}
+
+// writeMigration writes the migration.json and migration.md artifacts documenting the slashing, exit and withdrawal
+// safety of the existing validators split into the cluster to the cluster directory.
+func writeMigration(clusterDir string, network string, lock cluster.Lock, sources []string) error {
+	m := migration{
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Network:   network,
+		LockHash:  fmt.Sprintf("%#x", lock.LockHash),
+		Threshold: lock.Threshold,
+		Operators: len(lock.Operators),
+	}
+
+	withdrawalAddrs := lock.WithdrawalAddresses()
+	for i, val := range lock.Validators {
+		m.Validators = append(m.Validators, migrationValidator{
+			PublicKey:         val.PublicKeyHex(),
+			Source:            sources[i],
+			WithdrawalAddress: withdrawalAddrs[i],
+		})
+	}
+
+	b, err := json.MarshalIndent(m, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal migration")
+	}
+
+	//nolint:gosec // Migration artifacts don't contain secrets.
+	if err := os.WriteFile(filepath.Join(clusterDir, migrationJSONFile), b, 0o444); err != nil {
+		return errors.Wrap(err, "write migration json")
+	}
+
+	//nolint:gosec // Migration artifacts don't contain secrets.
+	if err := os.WriteFile(filepath.Join(clusterDir, migrationMDFile), []byte(migrationMarkdown(m)), 0o444); err != nil {
+		return errors.Wrap(err, "write migration markdown")
+	}
+
+	return nil
+}
+
+// migrationMarkdown returns the human-readable migration checklist.
+func migrationMarkdown(m migration) string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "# Validator Migration\n\n")
+	_, _ = fmt.Fprintf(&sb, "Created %s on %s. %d existing validators were split into a %d-of-%d distributed validator cluster with lock hash `%s`.\n\n",
+		m.CreatedAt.Format(time.RFC3339), m.Network, len(m.Validators), m.Threshold, m.Operators, m.LockHash)
+
+	_, _ = sb.WriteString("## Validators\n\n")
+	_, _ = sb.WriteString("| # | Public key | Source | Lock withdrawal address |\n")
+	_, _ = sb.WriteString("|---|------------|--------|-------------------------|\n")
+	for i, val := range m.Validators {
+		_, _ = fmt.Fprintf(&sb, "| %d | `%s` | `%s` | `%s` |\n", i, val.PublicKey, val.Source, val.WithdrawalAddress)
+	}
+
+	_, _ = sb.WriteString(`
+## Slashing Safety
+
+- [ ] Stop the existing validator client and delete the validator keys from it, so it can't sign again after a restart.
+- [ ] Wait for at least 2 finalised epochs after the last attestation of the existing validators before starting the
+      charon cluster.
+- [ ] Never run the original keys and the charon cluster at the same time. Enable doppelganger protection in the
+      validator clients of the cluster if supported.
+
+## Exit and Withdrawal Safety
+
+- [ ] The migration doesn't change withdrawal credentials. Stake is withdrawn to the withdrawal credentials of the
+      original deposit, not the withdrawal addresses in the cluster lock. Verify the withdrawal credentials of each
+      validator on the beacon chain.
+- [ ] Don't submit the deposit data files of already deposited validators, additional deposits are top-ups.
+- [ ] The original private keys, or mnemonic, can still sign voluntary exits and withdrawal credential changes. Store
+      them offline as a backup until the cluster operates reliably.
+`)
+	_, _ = fmt.Fprintf(&sb, "- [ ] Exits require the partial signatures of %d operators. Presign exits with `charon exit presign`, so the\n", m.Threshold)
+	_, _ = sb.WriteString("      validators can still be exited if operators become unavailable.\n")
+
+	return sb.String()
+}
@@ -6,6 +6,8 @@ import (
 	"context"
 	crand "crypto/rand"
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"path"
 
@@ -35,9 +37,20 @@ type createDKGConfig struct {
 	OperatorENRs      []string
 	ConsensusProtocol string
 	TargetGasLimit    uint
+	TestnetConfig     eth2util.Network
+	JSON              bool
 }
 
-func newCreateDKGCmd(runFunc func(context.Context, createDKGConfig) error) *cobra.Command {
+// createDKGResult is the JSON output of the create dkg command.
+type createDKGResult struct {
+	DefinitionFile string `json:"definition_file"`
+	DefinitionHash string `json:"definition_hash"`
+	Name           string `json:"name"`
+	NumValidators  int    `json:"num_validators"`
+	Threshold      int    `json:"threshold"`
+}
+
+func newCreateDKGCmd(runFunc func(context.Context, io.Writer, createDKGConfig) error) *cobra.Command {
 	var config createDKGConfig
 
 	cmd := &cobra.Command{
@@ -46,11 +59,13 @@ func newCreateDKGCmd(runFunc func(context.Context, createDKGConfig) error) *cobr
 		Long:  `Create a cluster definition file that will be used by all participants of a DKG.`,
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
-			return runFunc(cmd.Context(), config)
+			config.JSON = isJSONOutput(cmd)
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
 		},
 	}
 
 	bindCreateDKGFlags(cmd, &config)
+	bindTestnetFlags(cmd, &config.TestnetConfig)
 
 	wrapPreRunE(cmd, func(cmd *cobra.Command, _ []string) error {
 		thresholdPresent := cmd.Flags().Lookup("threshold").Changed
@@ -68,6 +83,8 @@ func newCreateDKGCmd(runFunc func(context.Context, createDKGConfig) error) *cobr
 		return nil
 	})
 
+	supportJSONOutput(cmd)
+
 	return cmd
 }
 
@@ -80,7 +97,7 @@ func bindCreateDKGFlags(cmd *cobra.Command, config *createDKGConfig) {
 	cmd.Flags().IntVarP(&config.Threshold, "threshold", "t", 0, "Optional override of threshold required for signature reconstruction. Defaults to ceil(n*2/3) if zero. Warning, non-default values decrease security.")
 	cmd.Flags().StringSliceVar(&config.FeeRecipientAddrs, "fee-recipient-addresses", nil, "Comma separated list of Ethereum addresses of the fee recipient for each validator. Either provide a single fee recipient address or fee recipient addresses for each validator.")
 	cmd.Flags().StringSliceVar(&config.WithdrawalAddrs, "withdrawal-addresses", nil, "Comma separated list of Ethereum addresses to receive the returned stake and accrued rewards for each validator. Either provide a single withdrawal address or withdrawal addresses for each validator.")
-	cmd.Flags().StringVar(&config.Network, "network", defaultNetwork, "Ethereum network to create validators for. Options: mainnet, goerli, sepolia, holesky, gnosis, chiado.")
+	cmd.Flags().StringVar(&config.Network, "network", defaultNetwork, "Ethereum network to create validators for. Options: mainnet, goerli, sepolia, holesky, hoodi, gnosis, chiado. Overridden by custom testnet flags.")
 	cmd.Flags().StringVar(&config.DKGAlgo, "dkg-algorithm", "default", "DKG algorithm to use; default, frost")
 	cmd.Flags().IntSliceVar(&config.DepositAmounts, "deposit-amounts", nil, "List of partial deposit amounts (integers) in ETH. Values must sum up to exactly 32ETH.")
 	cmd.Flags().StringSliceVar(&config.OperatorENRs, operatorENRs, nil, "[REQUIRED] Comma-separated list of each operator's Charon ENR address.")
@@ -96,12 +113,18 @@ func mustMarkFlagRequired(cmd *cobra.Command, flag string) {
 	}
 }
 
-func runCreateDKG(ctx context.Context, conf createDKGConfig) (err error) {
+func runCreateDKG(ctx context.Context, w io.Writer, conf createDKGConfig) (err error) {
 	// Map prater to goerli to ensure backwards compatibility with older cluster definitions.
 	if conf.Network == eth2util.Prater {
 		conf.Network = eth2util.Goerli.Name
 	}
 
+	// Custom testnet configuration overrides the network.
+	if conf.TestnetConfig.IsNonZero() {
+		eth2util.AddTestNetwork(conf.TestnetConfig)
+		conf.Network = conf.TestnetConfig.Name
+	}
+
 	if err = validateDKGConfig(len(conf.OperatorENRs), conf.Network, conf.DepositAmounts, conf.ConsensusProtocol); err != nil {
 		return err
 	}
@@ -170,11 +193,23 @@ func runCreateDKG(ctx context.Context, conf createDKGConfig) (err error) {
 	// Best effort creation of output dir, but error when writing the file.
 	_ = os.MkdirAll(conf.OutputDir, 0o755)
 
+	defFile := path.Join(conf.OutputDir, "cluster-definition.json")
+
 	//nolint:gosec // File needs to be read-only for everybody
-	if err := os.WriteFile(path.Join(conf.OutputDir, "cluster-definition.json"), b, 0o444); err != nil {
+	if err := os.WriteFile(defFile, b, 0o444); err != nil {
 		return errors.Wrap(err, "write definition")
 	}
 
+	if conf.JSON {
+		return writeJSON(w, createDKGResult{
+			DefinitionFile: defFile,
+			DefinitionHash: fmt.Sprintf("%#x", def.DefinitionHash),
+			Name:           def.Name,
+			NumValidators:  def.NumValidators,
+			Threshold:      def.Threshold,
+		})
+	}
+
 	return nil
 }
 
@@ -183,9 +218,9 @@ func validateWithdrawalAddrs(addrs []string, network string) error {
 	for _, addr := range addrs {
 		checksumAddr, err := eth2util.ChecksumAddress(addr)
 		if err != nil {
-			return errors.Wrap(err, "invalid withdrawal address", z.Str("addr", addr))
+			return errors.Wrap(err, "invalid withdrawal address", z.Redact(z.Str("addr", addr)))
 		} else if checksumAddr != addr {
-			return errors.New("invalid checksummed address", z.Str("addr", addr))
+			return errors.New("invalid checksummed address", z.Redact(z.Str("addr", addr)))
 		}
 
 		// We cannot allow a zero withdrawal address on mainnet or gnosis.
@@ -4,6 +4,9 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"path"
 	"strings"
@@ -11,6 +14,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/testutil"
 )
@@ -39,7 +43,7 @@ func TestCreateDkgValid(t *testing.T) {
 		},
 	}
 
-	err := runCreateDKG(context.Background(), conf)
+	err := runCreateDKG(context.Background(), io.Discard, conf)
 	require.NoError(t, err)
 }
 
@@ -115,7 +119,7 @@ func TestCreateDkgInvalid(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.errMsg, func(t *testing.T) {
-			err := runCreateDKG(context.Background(), test.conf)
+			err := runCreateDKG(context.Background(), io.Discard, test.conf)
 			require.EqualError(t, err, test.errMsg)
 		})
 	}
@@ -169,6 +173,30 @@ func TestExistingClusterDefinition(t *testing.T) {
 	require.EqualError(t, cmd.Execute(), "existing cluster-definition.json found. Try again after deleting it")
 }
 
+func TestCreateDkgTestnetChainSpec(t *testing.T) {
+	dir := t.TempDir()
+	chainSpec := path.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(chainSpec, []byte("CONFIG_NAME: 'devnet'\nGENESIS_FORK_VERSION: 0x10000910\n"+
+		"MIN_GENESIS_TIME: 1695902100\nDEPOSIT_CHAIN_ID: 3151908\n"), 0o600))
+
+	var enrs []string
+	for range minNodes {
+		enrs = append(enrs, "enr:-JG4QG472ZVvl8ySSnUK9uNVDrP_hjkUrUqIxUC75aayzmDVQedXkjbqc7QKyOOS71VmlqnYzri_taV8ZesFYaoQSIOGAYHtv1WsgmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQKwwq_CAld6oVKOrixE-JzMtvvNgb9yyI-_rwq4NFtajIN0Y3CCDhqDdWRwgg4u")
+	}
+
+	cmd := newCreateCmd(newCreateDKGCmd(runCreateDKG))
+	cmd.SetArgs([]string{"dkg", "--operator-enrs=" + strings.Join(enrs, ","), "--fee-recipient-addresses=" + validEthAddr,
+		"--withdrawal-addresses=" + validEthAddr, "--output-dir=" + dir, "--testnet-chain-spec=" + chainSpec})
+	require.NoError(t, cmd.Execute())
+
+	b, err := os.ReadFile(path.Join(dir, "cluster-definition.json"))
+	require.NoError(t, err)
+
+	var def cluster.Definition
+	require.NoError(t, json.Unmarshal(b, &def))
+	require.Equal(t, "0x10000910", fmt.Sprintf("%#x", def.ForkVersion))
+}
+
 func TestValidateWithdrawalAddr(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		addrs := []string{validEthAddr}
@@ -15,7 +15,7 @@ import (
 	"github.com/obolnetwork/charon/p2p"
 )
 
-func newCreateEnrCmd(runFunc func(io.Writer, string) error) *cobra.Command {
+func newCreateEnrCmd(runFunc func(io.Writer, string, bool) error) *cobra.Command {
 	var dataDir string
 
 	cmd := &cobra.Command{
@@ -23,18 +23,21 @@ func newCreateEnrCmd(runFunc func(io.Writer, string) error) *cobra.Command {
 		Short: "Create an Ethereum Node Record (ENR) private key to identify this charon client",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
-			return runFunc(cmd.OutOrStdout(), dataDir)
+			return runFunc(cmd.OutOrStdout(), dataDir, isJSONOutput(cmd))
 		},
 	}
 
 	bindDataDirFlag(cmd.Flags(), &dataDir)
 
+	supportJSONOutput(cmd)
+
 	return cmd
 }
 
 // runCreateEnrCmd stores a new charon-enr-private-key to disk and prints the ENR for the provided config.
-// It returns an error if the key already exists.
-func runCreateEnrCmd(w io.Writer, dataDir string) error {
+// It returns an error if the key already exists. The ENR is printed as JSON if jsonOutput is true,
+// without the backup warning.
+func runCreateEnrCmd(w io.Writer, dataDir string, jsonOutput bool) error {
 	_, err := p2p.LoadPrivKey(dataDir)
 	if err == nil {
 		return errors.New("charon-enr-private-key already exists", z.Str("enr_path", p2p.KeyPath(dataDir)))
@@ -50,6 +53,10 @@ func runCreateEnrCmd(w io.Writer, dataDir string) error {
 		return err
 	}
 
+	if jsonOutput {
+		return writeJSON(w, newENRResult(r, key, dataDir))
+	}
+
 	keyPath := p2p.KeyPath(dataDir)
 
 	_, _ = fmt.Fprintf(w, "Created ENR private key: %s\n", keyPath)
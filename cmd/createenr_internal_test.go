@@ -12,6 +12,6 @@ import (
 func TestRunCreateEnr(t *testing.T) {
 	temp := t.TempDir()
 
-	err := runCreateEnrCmd(io.Discard, temp)
+	err := runCreateEnrCmd(io.Discard, temp, false)
 	require.NoError(t, err)
 }
@@ -0,0 +1,295 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/health"
+	"github.com/obolnetwork/charon/app/version"
+)
+
+const (
+	// maxDashboardRows is the maximum number of upcoming duties and recent failures rendered.
+	maxDashboardRows = 10
+
+	ansiClear = "\033[H\033[2J"
+	ansiBold  = "\033[1m"
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiReset = "\033[0m"
+)
+
+type dashboardConfig struct {
+	MonitoringAddr string
+	Timeout        time.Duration
+	Refresh        time.Duration
+}
+
+func newDashboardCmd(runFunc func(context.Context, io.Writer, dashboardConfig) error) *cobra.Command {
+	var config dashboardConfig
+
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Render a live terminal dashboard of a running node",
+		Long: `Connects to the monitoring API of a running charon node and renders a live terminal dashboard of peer
+connectivity, beacon node sync state, upcoming duties, recent duty failures, health and version warnings.
+Press Ctrl-C to quit.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.MonitoringAddr, "monitoring-address", "http://127.0.0.1:3620", "The address of the charon node's monitoring API.")
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 5*time.Second, "Timeout for querying the monitoring API.")
+	cmd.Flags().DurationVar(&config.Refresh, "refresh", 5*time.Second, "The dashboard refresh interval.")
+
+	wrapPreRunE(cmd, func(*cobra.Command, []string) error {
+		if config.Refresh <= 0 {
+			return errors.New("refresh interval must be positive")
+		}
+
+		return nil
+	})
+
+	return cmd
+}
+
+// runDashboard renders the dashboard every refresh interval until the context is cancelled.
+// The screen is cleared and colours are used if writing to a terminal.
+func runDashboard(ctx context.Context, w io.Writer, config dashboardConfig) error {
+	f, ok := w.(*os.File)
+	tty := ok && term.IsTerminal(int(f.Fd()))
+
+	ticker := time.NewTicker(config.Refresh)
+	defer ticker.Stop()
+
+	for {
+		data := fetchDashboard(ctx, config)
+
+		var buf bytes.Buffer
+		if tty {
+			_, _ = buf.WriteString(ansiClear)
+		}
+		writeDashboard(&buf, config, data, time.Now(), tty)
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return errors.Wrap(err, "write dashboard")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// dashboardData is the data rendered by the dashboard, with the errors of the endpoints that couldn't be queried.
+type dashboardData struct {
+	Ready   app.ReadyStatus
+	Health  app.HealthStatus
+	Cluster app.ClusterStatus
+	Duties  app.DutiesStatus
+	Errors  map[string]error
+}
+
+// fetchDashboard queries all the monitoring API endpoints rendered by the dashboard.
+func fetchDashboard(ctx context.Context, config dashboardConfig) dashboardData {
+	data := dashboardData{Errors: make(map[string]error)}
+
+	get := func(path string, resp any, allowedCodes ...int) {
+		err := getMonitoringJSON(ctx, config.MonitoringAddr, config.Timeout, path, nil, resp, allowedCodes...)
+		if err != nil {
+			data.Errors[path] = err
+		}
+	}
+
+	get("ready", &data.Ready, http.StatusServiceUnavailable)
+	get("health", &data.Health)
+	get("cluster/status", &data.Cluster)
+	get("duties", &data.Duties)
+
+	return data
+}
+
+// writeDashboard writes the dashboard, colouring yes/no values if color is true.
+func writeDashboard(w io.Writer, config dashboardConfig, data dashboardData, now time.Time, color bool) {
+	styled := func(style, s string) string {
+		if !color {
+			return s
+		}
+
+		return style + s + ansiReset
+	}
+	check := func(b bool) string {
+		if b {
+			return styled(ansiGreen, "yes")
+		}
+
+		return styled(ansiRed, "no")
+	}
+	header := func(title string) {
+		_, _ = fmt.Fprintf(w, "\n%s\n", styled(ansiBold, title))
+	}
+	sectionErr := func(path string) bool {
+		err, ok := data.Errors[path]
+		if ok {
+			_, _ = fmt.Fprintf(w, "  %s\n", styled(ansiRed, "unavailable: "+err.Error()))
+		}
+
+		return ok
+	}
+
+	_, _ = fmt.Fprintf(w, "%s  %s  %s  (refresh %s, Ctrl-C to quit)\n", styled(ansiBold, "Charon dashboard"),
+		config.MonitoringAddr, now.UTC().Format(time.RFC3339), config.Refresh)
+
+	header("NODE")
+	if !sectionErr("ready") {
+		ready := check(true)
+		if !data.Ready.Ready {
+			ready = styled(ansiRed, "no: "+data.Ready.Error)
+		}
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintf(tw, "  Ready:\t%s\n", ready)
+		_, _ = fmt.Fprintf(tw, "  Beacon node up:\t%s\n", check(data.Ready.Checks.BeaconNodeUp))
+		_, _ = fmt.Fprintf(tw, "  Beacon node synced:\t%s\n", check(data.Ready.Checks.BeaconNodeSynced))
+		_, _ = fmt.Fprintf(tw, "  Beacon node peers:\t%s\n", check(data.Ready.Checks.BeaconNodePeers))
+		_, _ = fmt.Fprintf(tw, "  Validator client connected:\t%s\n", check(data.Ready.Checks.VCConnected))
+		_, _ = fmt.Fprintf(tw, "  Validators loaded:\t%s\n", check(data.Ready.Checks.VCValidatorsLoaded))
+		_ = tw.Flush()
+	}
+
+	var connected int
+	for _, node := range data.Cluster.Nodes {
+		if node.Connected {
+			connected++
+		}
+	}
+
+	header(fmt.Sprintf("PEERS (%d/%d connected)", connected, len(data.Cluster.Nodes)))
+	if !sectionErr("cluster/status") {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "  PEER\tNICKNAME\tCONNECTED\tVERSION\tREADY\tBEACON SYNCED")
+		for _, node := range data.Cluster.Nodes {
+			name := node.Peer
+			if node.Self {
+				name += " (self)"
+			}
+
+			ready, synced := "?", "?"
+			if node.Reported {
+				ready, synced = check(node.ReadyError == ""), check(node.BeaconSynced)
+			}
+
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\t%s\n", name, orUnknown(node.Nickname),
+				check(node.Connected), orUnknown(node.Version), ready, synced)
+		}
+		_ = tw.Flush()
+	}
+
+	header(fmt.Sprintf("UPCOMING DUTIES (slot %d)", data.Duties.Slot))
+	if !sectionErr("duties") {
+		if len(data.Duties.Upcoming) == 0 {
+			_, _ = fmt.Fprintln(w, "  none")
+		} else {
+			tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(tw, "  SLOT\tIN SLOTS\tDUTY\tVALIDATORS")
+			for i, duty := range data.Duties.Upcoming {
+				if i == maxDashboardRows {
+					_, _ = fmt.Fprintf(tw, "  ...\t\t%d more\t\n", len(data.Duties.Upcoming)-maxDashboardRows)
+					break
+				}
+
+				_, _ = fmt.Fprintf(tw, "  %d\t%d\t%s\t%d\n", duty.Slot, duty.Slot-data.Duties.Slot, duty.Duty, duty.Validators)
+			}
+			_ = tw.Flush()
+		}
+	}
+
+	header("RECENT FAILURES")
+	if !sectionErr("duties") {
+		if len(data.Duties.Failures) == 0 {
+			_, _ = fmt.Fprintln(w, "  none")
+		} else {
+			tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(tw, "  TIME\tSLOT\tDUTY\tSTEP\tREASON")
+			for i, failure := range data.Duties.Failures {
+				if i == maxDashboardRows {
+					break
+				}
+
+				_, _ = fmt.Fprintf(tw, "  %s\t%d\t%s\t%s\t%s\n", failure.Time.UTC().Format(time.TimeOnly),
+					failure.Slot, failure.Duty, failure.Step, failure.Reason)
+			}
+			_ = tw.Flush()
+		}
+	}
+
+	header("WARNINGS")
+	warnings := dashboardWarnings(data)
+	if len(warnings) == 0 {
+		_, _ = fmt.Fprintln(w, "  none")
+	}
+	for _, warning := range warnings {
+		_, _ = fmt.Fprintf(w, "  %s\n", styled(ansiRed, warning))
+	}
+}
+
+// dashboardWarnings returns the failing health checks and version warnings of the cluster.
+func dashboardWarnings(data dashboardData) []string {
+	var resp []string
+	for _, result := range data.Health.Checks {
+		if result.Failing && result.Severity != health.SeverityInfo {
+			resp = append(resp, fmt.Sprintf("[%s] %s: %s", result.Severity, result.Name, result.Description))
+		}
+	}
+
+	// Warn about nodes running older minor versions than the latest version in the cluster.
+	var (
+		latest   version.SemVer
+		versions = make(map[string]version.SemVer)
+	)
+	for _, node := range data.Cluster.Nodes {
+		v, err := version.Parse(node.Version)
+		if err != nil {
+			continue
+		}
+
+		versions[node.Peer] = v
+		if version.Compare(v, latest) > 0 {
+			latest = v
+		}
+	}
+
+	for _, node := range data.Cluster.Nodes {
+		v, ok := versions[node.Peer]
+		if !ok {
+			continue
+		}
+
+		if version.Compare(v.Minor(), latest.Minor()) < 0 {
+			resp = append(resp, fmt.Sprintf("[version] %s runs %s, behind the cluster's latest %s", node.Peer, v, latest))
+		}
+
+		if node.Self && v.PreRelease() {
+			resp = append(resp, fmt.Sprintf("[version] %s runs pre-release %s, not recommended for production", node.Peer, v))
+		}
+	}
+
+	return resp
+}
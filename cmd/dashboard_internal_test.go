@@ -0,0 +1,101 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/health"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+//go:generate go test . -run=TestDashboard -update
+
+func TestDashboard(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	responses := map[string]any{
+		"/ready": app.ReadyStatus{
+			Error: "vc not connected",
+			Checks: app.ReadyChecks{
+				BeaconNodeUp:         true,
+				BeaconNodeSynced:     true,
+				BeaconNodePeers:      true,
+				QuorumPeersConnected: true,
+			},
+		},
+		"/health": app.HealthStatus{Healthy: true, Checks: []health.Result{
+			{Name: "high_beacon_node_sse_head_delay", Description: "Beacon node SSE head events are delayed.", Severity: health.SeverityWarning, Failing: true},
+			{Name: "pending_validators", Description: "Validators are pending activation.", Severity: health.SeverityInfo, Failing: true},
+			{Name: "insufficient_connected_peers", Description: "Not connected to at least quorum peers.", Severity: health.SeverityCritical},
+		}},
+		"/cluster/status": app.ClusterStatus{Nodes: []app.NodeStatus{
+			{Peer: "happy-face", Nickname: "alice", Self: true, Connected: true, Version: "v1.2.0", Reported: true, BeaconSynced: true},
+			{Peer: "pleasant-state", Connected: true, Version: "v1.1.0", Reported: true, ReadyError: "beacon node not synced"},
+			{Peer: "frantic-mirror"},
+		}},
+		"/duties": app.DutiesStatus{
+			Slot: 100,
+			Upcoming: []app.UpcomingDuty{
+				{Slot: 100, Duty: "attester", Validators: 3},
+				{Slot: 102, Duty: "proposer", Validators: 1},
+			},
+			Failures: []app.FailedDuty{
+				{Time: now.Add(-time.Minute), Slot: 95, Duty: "attester", Step: "fetcher", Reason: "fetch_bn_error", Error: "beacon node timeout"},
+			},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.URL.Path == "/ready" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	config := dashboardConfig{MonitoringAddr: srv.URL, Timeout: time.Second, Refresh: time.Second}
+
+	t.Run("all", func(t *testing.T) {
+		data := fetchDashboard(context.Background(), config)
+		require.Empty(t, data.Errors)
+
+		var buf bytes.Buffer
+		writeDashboard(&buf, dashboardConfig{MonitoringAddr: "http://127.0.0.1:3620", Refresh: time.Second}, data, now, false)
+		testutil.RequireGoldenBytes(t, buf.Bytes())
+	})
+
+	t.Run("missing duties", func(t *testing.T) {
+		delete(responses, "/duties")
+
+		data := fetchDashboard(context.Background(), config)
+		require.Len(t, data.Errors, 1)
+		require.ErrorContains(t, data.Errors["duties"], "http error")
+	})
+
+	t.Run("run until cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var buf bytes.Buffer
+		require.NoError(t, runDashboard(ctx, &buf, config))
+		require.Contains(t, buf.String(), "Charon dashboard")
+		require.NotContains(t, buf.String(), ansiClear) // Not a terminal.
+	})
+}
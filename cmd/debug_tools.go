@@ -3,7 +3,14 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/httpauth"
 )
 
 // bindDebugMonitoringFlags binds Prometheus monitoring and debug address CLI flags. The debug address defaults to an empty address.
@@ -11,3 +18,28 @@ func bindDebugMonitoringFlags(cmd *cobra.Command, monitorAddr, debugAddr *string
 	cmd.Flags().StringVar(monitorAddr, "monitoring-address", defaultMonitorAddr, "Listening address (ip and port) for the monitoring API (prometheus).")
 	cmd.Flags().StringVar(debugAddr, "debug-address", "", "Listening address (ip and port) for the pprof and QBFT debug API. It is not enabled by default.")
 }
+
+// bindHTTPAuthFlags binds the basic auth, TLS and IP allowlist CLI flags of the named API, prefixed with the prefix.
+func bindHTTPAuthFlags(cmd *cobra.Command, config *httpauth.Config, prefix string, name string) {
+	envVar := "CHARON_" + strings.ToUpper(strings.ReplaceAll(prefix, "-", "_")) + "_BASIC_AUTH"
+
+	cmd.Flags().StringVar(&config.BasicAuth, prefix+"-basic-auth", "", fmt.Sprintf("Enables basic authentication of the %s with these username:password credentials. Prefer the %s env var to avoid leaking credentials via process arguments.", name, envVar))
+	cmd.Flags().StringVar(&config.TLSCertFile, prefix+"-tls-cert-file", "", fmt.Sprintf("Enables TLS on the %s using this PEM encoded certificate file. Requires --%s-tls-key-file.", name, prefix))
+	cmd.Flags().StringVar(&config.TLSKeyFile, prefix+"-tls-key-file", "", fmt.Sprintf("The PEM encoded private key file of the %s TLS certificate.", name))
+	cmd.Flags().StringVar(&config.TLSClientCAFile, prefix+"-tls-client-ca-file", "", fmt.Sprintf("Enables mutual TLS on the %s, requiring client certificates signed by the CA certificates in this PEM encoded file.", name))
+	cmd.Flags().StringSliceVar(&config.AllowedIPs, prefix+"-allowed-ips", nil, fmt.Sprintf("Comma separated list of IP addresses or CIDR ranges allowed to access the %s, e.g. 10.0.0.0/8. All are allowed by default.", name))
+
+	wrapPreRunE(cmd, func(*cobra.Command, []string) error {
+		if err := config.Validate(); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid %s auth flags", strings.ReplaceAll(prefix, "-", " ")))
+		}
+
+		return nil
+	})
+}
+
+// bindAdminFlags binds the admin API address and auth CLI flags.
+func bindAdminFlags(cmd *cobra.Command, config *app.Config) {
+	cmd.Flags().StringVar(&config.AdminAddr, "admin-address", "", "Listening address (ip and port) for the admin API, allowing operators to pause and resume validators, trigger exits and config reloads and inspect the node state. It is not enabled by default and requires --admin-tls-client-ca-file, or --admin-basic-auth with TLS.")
+	bindHTTPAuthFlags(cmd, &config.AdminAuth, "admin", "admin API")
+}
@@ -4,17 +4,26 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"time"
 
 	libp2plog "github.com/ipfs/go-log/v2"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/term"
 
+	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/dkg"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/vault"
 )
 
-func newDKGCmd(runFunc func(context.Context, dkg.Config) error) *cobra.Command {
+func newDKGCmd(runFunc func(context.Context, dkg.Config) error, cmds ...*cobra.Command) *cobra.Command {
 	var config dkg.Config
 
 	cmd := &cobra.Command{
@@ -25,6 +34,12 @@ distributed validator key shares and a final cluster lock configuration. Note th
 this command at the same time.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			// The progress display replaces info logs, so only enable it if stderr is a terminal.
+			config.ProgressDisplay = config.ProgressDisplay && term.IsTerminal(int(os.Stderr.Fd()))
+			if config.ProgressDisplay && !cmd.Flags().Changed("log-level") {
+				config.Log.Level = "warn"
+			}
+
 			if err := log.InitLogger(config.Log); err != nil {
 				return err
 			}
@@ -33,29 +48,93 @@ this command at the same time.`,
 			printLicense(cmd.Context())
 			printFlags(cmd.Context(), cmd.Flags())
 
-			return runFunc(cmd.Context(), config)
+			if err := runFunc(cmd.Context(), config); err != nil {
+				return err
+			}
+
+			if w := jsonWriter(cmd); w != nil {
+				return writeDKGResult(w, config.DataDir)
+			}
+
+			return nil
 		},
 	}
 
 	bindDataDirFlag(cmd.Flags(), &config.DataDir)
 	bindKeymanagerFlags(cmd.Flags(), &config.KeymanagerAddr, &config.KeymanagerAuthToken)
+	bindVaultFlags(cmd.Flags(), &config.Vault)
 	bindDefDirFlag(cmd.Flags(), &config.DefFile)
 	bindNoVerifyFlag(cmd.Flags(), &config.NoVerify)
 	bindP2PFlags(cmd, &config.P2P)
 	bindLogFlags(cmd.Flags(), &config.Log)
 	bindPublishFlags(cmd.Flags(), &config)
 	bindShutdownDelayFlag(cmd.Flags(), &config.ShutdownDelay)
+	bindOutputFormatFlag(cmd.Flags(), &config.OutputFormat)
+	bindKeystoreFlags(cmd.Flags(), &config.KeystoreOptions)
+	bindTestnetFlags(cmd, &config.TestnetConfig)
 
 	cmd.Flags().DurationVar(&config.Timeout, "timeout", 1*time.Minute, "Timeout for the DKG process, should be increased if DKG times out.")
+	cmd.Flags().BoolVar(&config.ProgressDisplay, "progress", true, "Show a live display of each peer's connection state, ceremony step and received messages instead of info logs. Only applies if stderr is a terminal.")
+	cmd.Flags().BoolVar(&config.Compounding, "compounding", false, "Create deposit data with 0x02 compounding withdrawal credentials. All operators must use the same value.")
+	cmd.Flags().BoolVar(&config.DepositBatch, "deposit-batch", false, "Also write deposit-batch.json and deposit-batch.csv files containing all deposits for submission via batch deposit contracts.")
+	cmd.Flags().StringVar(&config.OfflineDir, "offline-dir", "", "Enables an offline DKG ceremony without P2P networking by exchanging message files via this directory. Files written by this node must be copied to all other operators' offline directories.")
+
+	cmd.AddCommand(cmds...)
+
+	supportJSONOutput(cmd)
 
 	return cmd
 }
 
+// dkgResult is the JSON output of the dkg command.
+type dkgResult struct {
+	LockFile string `json:"lock_file"`
+	LockHash string `json:"lock_hash"`
+	Name     string `json:"name"`
+	// Validators are the 0x-prefixed public keys of the created distributed validators.
+	Validators []string `json:"validators"`
+}
+
+// writeDKGResult writes the result of the cluster lock created in the data dir as JSON.
+func writeDKGResult(w io.Writer, dataDir string) error {
+	lockFile := filepath.Join(dataDir, "cluster-lock.json")
+
+	cl, err := loadClusterManifest("", lockFile)
+	if err != nil {
+		return errors.Wrap(err, "load created cluster lock", z.Str("lock_file", lockFile))
+	}
+
+	result := dkgResult{
+		LockFile:   lockFile,
+		LockHash:   fmt.Sprintf("%#x", cl.GetInitialMutationHash()),
+		Name:       cl.GetName(),
+		Validators: []string{},
+	}
+	for _, val := range cl.GetValidators() {
+		result.Validators = append(result.Validators, fmt.Sprintf("%#x", val.GetPublicKey()))
+	}
+
+	return writeJSON(w, result)
+}
+
 func bindKeymanagerFlags(flags *pflag.FlagSet, addr, authToken *string) {
 	flags.StringVar(addr, "keymanager-address", "", "The keymanager URL to import validator keyshares.")
 	flags.StringVar(authToken, "keymanager-auth-token", "", "Authentication bearer token to interact with keymanager API. Don't include the \"Bearer\" symbol, only include the api-token.")
 }
 
+func bindVaultFlags(flags *pflag.FlagSet, config *vault.Config) {
+	flags.StringVar(&config.Addr, "vault-address", "", "The HashiCorp Vault server URL to store or load validator key shares instead of the validator keys directory.")
+	flags.StringVar(&config.AuthMethod, "vault-auth-method", vault.AuthToken, "The vault authentication method; token, approle, kubernetes.")
+	flags.StringVar(&config.AuthMount, "vault-auth-mount", "", "The path the vault authentication method is mounted at. Defaults to the authentication method name.")
+	flags.StringVar(&config.Token, "vault-token", "", "The vault token used by the token authentication method.")
+	flags.StringVar(&config.Role, "vault-role", "", "The vault AppRole role ID or Kubernetes role name.")
+	flags.StringVar(&config.SecretFile, "vault-secret-file", "/var/run/secrets/kubernetes.io/serviceaccount/token", "The file containing the vault AppRole secret ID or the Kubernetes service account token.")
+	flags.StringVar(&config.KVMount, "vault-kv-mount", "secret", "The path the vault KV version 2 secrets engine is mounted at.")
+	flags.StringVar(&config.Path, "vault-path", "charon/validator_keys", "The vault KV path containing the validator key shares.")
+	flags.StringVar(&config.TransitKey, "vault-transit-key", "", "Optional vault Transit key name to encrypt validator key shares with before storing them in KV.")
+	flags.StringVar(&config.TransitMount, "vault-transit-mount", "transit", "The path the vault Transit secrets engine is mounted at.")
+}
+
 func bindDefDirFlag(flags *pflag.FlagSet, dataDir *string) {
 	flags.StringVar(dataDir, "definition-file", ".charon/cluster-definition.json", "The path to the cluster definition file or an HTTP URL.")
 }
@@ -70,6 +149,15 @@ func bindPublishFlags(flags *pflag.FlagSet, config *dkg.Config) {
 	flags.BoolVar(&config.Publish, "publish", false, "Publish the created cluster to a remote API.")
 }
 
+func bindOutputFormatFlag(flags *pflag.FlagSet, format *string) {
+	flags.StringVar(format, "output-format", dkg.OutputFormatDefault, "Key share output format. Options: default, web3signer. The web3signer format additionally writes Web3Signer key config files and a slashing protection interchange file to the web3signer directory.")
+}
+
+func bindKeystoreFlags(flags *pflag.FlagSet, opts *keystore.Options) {
+	flags.StringVar(&opts.KDF, "keystore-kdf", keystore.KDFPbkdf2, "Key derivation function of the key share keystores. Options: pbkdf2, scrypt.")
+	flags.UintVar(&opts.CostPower, "keystore-kdf-cost-power", keystore.DefaultCostPower, "Key derivation cost of the key share keystores as a power of 2 (pbkdf2 iterations or scrypt n), between 10 and 20. Lower values speed up validator client imports at the cost of security. See 'charon alpha keystore-benchmark'.")
+}
+
 func bindShutdownDelayFlag(flags *pflag.FlagSet, shutdownDelay *time.Duration) {
 	flags.DurationVar(shutdownDelay, "shutdown-delay", time.Second, "Graceful shutdown delay.")
 }
@@ -0,0 +1,71 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+type docsManConfig struct {
+	OutputDir string
+}
+
+func newDocsCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "docs",
+		Short: "Generate documentation",
+		Long:  "Generates documentation of all charon commands and flags.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+func newDocsManCmd(runFunc func(*cobra.Command, docsManConfig) error) *cobra.Command {
+	var config docsManConfig
+
+	cmd := &cobra.Command{
+		Use:   "man",
+		Short: "Generate manpages",
+		Long: `Generates a manpage for each charon command in the output directory, e.g. to install with:
+  charon docs man --output-dir=/usr/local/share/man/man1`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Root(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.OutputDir, "output-dir", "man", "The directory to write the manpages to. It is created if it doesn't exist.")
+
+	return cmd
+}
+
+// runDocsMan writes the manpages of the root command and all its subcommands to the output directory.
+func runDocsMan(root *cobra.Command, config docsManConfig) error {
+	if err := os.MkdirAll(config.OutputDir, 0o755); err != nil {
+		return errors.Wrap(err, "create output directory", z.Str("dir", config.OutputDir))
+	}
+
+	header := &doc.GenManHeader{
+		Title:   "CHARON",
+		Section: "1",
+		Source:  "Charon " + version.Version.String(),
+		Manual:  "Charon Manual",
+	}
+
+	root.DisableAutoGenTag = true
+
+	if err := doc.GenManTree(root, header, config.OutputDir); err != nil {
+		return errors.Wrap(err, "generate manpages", z.Str("dir", config.OutputDir))
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocsMan(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "man")
+
+	root := New()
+	root.SetArgs(slice("docs", "man", "--output-dir", dir))
+	require.NoError(t, root.Execute())
+
+	for _, name := range []string{"charon.1", "charon-run.1", "charon-cluster-status.1", "charon-docs-man.1"} {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		require.Contains(t, string(b), `.TH "CHARON"`)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "charon-run.1"))
+	require.NoError(t, err)
+	require.Contains(t, string(b), "beacon-node-endpoints")
+}
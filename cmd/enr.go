@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"net"
 	"strings"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
@@ -18,45 +19,76 @@ import (
 	"github.com/obolnetwork/charon/p2p"
 )
 
-func newEnrCmd(runFunc func(io.Writer, string, bool) error) *cobra.Command {
-	var (
-		dataDir string
-		verbose bool
-	)
+// enrConfig is the config of the enr command.
+type enrConfig struct {
+	DataDir       string
+	Verbose       bool
+	ExternalIPs   []string
+	ExternalHosts []string
+	TCPPort       int
+	Seq           int
+}
+
+func newEnrCmd(runFunc func(io.Writer, enrConfig, bool) error) *cobra.Command {
+	var config enrConfig
 
 	cmd := &cobra.Command{
 		Use:   "enr",
 		Short: "Print the ENR that identifies this client",
-		Long:  `Prints an Ethereum Node Record (ENR) from this client's charon-enr-private-key. This serves as a public key that identifies this client to its peers.`,
-		Args:  cobra.NoArgs,
+		Long: `Prints an Ethereum Node Record (ENR) from this client's charon-enr-private-key. This serves as a public key that identifies this client to its peers. ` +
+			`Optionally advertises an external IPv4 address, IPv6 address and DNS hostname in the ENR, increase --seq whenever the advertised addresses rotate.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
-			return runFunc(cmd.OutOrStdout(), dataDir, verbose)
+			return runFunc(cmd.OutOrStdout(), config, isJSONOutput(cmd))
 		},
 	}
 
-	bindDataDirFlag(cmd.Flags(), &dataDir)
-	bindEnrFlags(cmd.Flags(), &verbose)
+	bindDataDirFlag(cmd.Flags(), &config.DataDir)
+	bindEnrFlags(cmd.Flags(), &config.Verbose)
+	cmd.Flags().StringSliceVar(&config.ExternalIPs, "p2p-external-ip", nil, "Comma-separated list of external IP addresses to advertise in the ENR, at most one IPv4 and one IPv6 address.")
+	cmd.Flags().StringSliceVar(&config.ExternalHosts, "p2p-external-hostname", nil, "External DNS hostname to advertise in the ENR.")
+	cmd.Flags().IntVar(&config.TCPPort, "p2p-tcp-port", 3610, "External libp2p TCP port to advertise in the ENR with the external addresses.")
+	cmd.Flags().IntVar(&config.Seq, "seq", 0, "ENR sequence number. Increase it whenever the advertised addresses change so peers replace the previous record.")
+
+	supportJSONOutput(cmd)
 
 	return cmd
 }
 
+// enrResult is the JSON output of the enr commands.
+type enrResult struct {
+	ENR            string `json:"enr"`
+	PublicKey      string `json:"public_key"`
+	PrivateKeyFile string `json:"private_key_file"`
+}
+
 // runNewENR loads the p2pkey from disk and prints the ENR for the provided config.
-func runNewENR(w io.Writer, dataDir string, verbose bool) error {
-	key, err := p2p.LoadPrivKey(dataDir)
+// The ENR, public key and key path are printed as JSON if jsonOutput is true.
+func runNewENR(w io.Writer, config enrConfig, jsonOutput bool) error {
+	opts, err := enrAddrOptions(config)
+	if err != nil {
+		return err
+	}
+
+	key, err := p2p.LoadPrivKey(config.DataDir)
 	if errors.Is(err, fs.ErrNotExist) {
-		return errors.New("private key not found. If this is your first time running this client, create one with `charon create enr`.", z.Str("enr_path", p2p.KeyPath(dataDir))) //nolint:revive
+		return errors.New("private key not found. If this is your first time running this client, create one with `charon create enr`.", z.Str("enr_path", p2p.KeyPath(config.DataDir))) //nolint:revive
 	} else if err != nil {
 		return err
 	}
 
-	r, err := enr.New(key)
+	r, err := enr.New(key, opts...)
 	if err != nil {
 		return err
 	}
 
+	if jsonOutput {
+		return writeJSON(w, newENRResult(r, key, config.DataDir))
+	}
+
 	_, _ = fmt.Fprintln(w, r.String())
 
-	if !verbose {
+	if !config.Verbose {
 		return nil
 	}
 
@@ -65,6 +97,65 @@ func runNewENR(w io.Writer, dataDir string, verbose bool) error {
 	return nil
 }
 
+// enrAddrOptions returns the ENR options advertising the configured external addresses and sequence number.
+// ENRs support at most one IPv4 address, one IPv6 address and one hostname.
+func enrAddrOptions(config enrConfig) ([]enr.Option, error) {
+	if config.Seq < 0 {
+		return nil, errors.New("invalid negative --seq", z.Int("seq", config.Seq))
+	}
+
+	opts := []enr.Option{enr.WithSeq(config.Seq)}
+	if len(config.ExternalIPs) == 0 && len(config.ExternalHosts) == 0 {
+		return opts, nil
+	}
+
+	if config.TCPPort <= 0 || config.TCPPort > 65535 {
+		return nil, errors.New("invalid --p2p-tcp-port", z.Int("port", config.TCPPort))
+	}
+
+	var hasIP4, hasIP6 bool
+	for _, ipStr := range config.ExternalIPs {
+		ip := net.ParseIP(ipStr)
+		if ip == nil {
+			return nil, errors.New("invalid --p2p-external-ip", z.Redact(z.Str("ip", ipStr)))
+		}
+
+		if ip.To4() != nil {
+			if hasIP4 {
+				return nil, errors.New("only one IPv4 --p2p-external-ip supported in ENR")
+			}
+			opts = append(opts, enr.WithIP(ip), enr.WithTCP(config.TCPPort))
+			hasIP4 = true
+		} else {
+			if hasIP6 {
+				return nil, errors.New("only one IPv6 --p2p-external-ip supported in ENR")
+			}
+			opts = append(opts, enr.WithIP6(ip), enr.WithTCP6(config.TCPPort))
+			hasIP6 = true
+		}
+	}
+
+	if len(config.ExternalHosts) > 1 {
+		return nil, errors.New("only one --p2p-external-hostname supported in ENR")
+	} else if len(config.ExternalHosts) == 1 {
+		opts = append(opts, enr.WithDNS(config.ExternalHosts[0]))
+		if !hasIP4 {
+			opts = append(opts, enr.WithTCP(config.TCPPort))
+		}
+	}
+
+	return opts, nil
+}
+
+// newENRResult returns the JSON output of the ENR of the key stored in the data dir.
+func newENRResult(r enr.Record, key *k1.PrivateKey, dataDir string) enrResult {
+	return enrResult{
+		ENR:            r.String(),
+		PublicKey:      fmt.Sprintf("%#x", key.PubKey().SerializeCompressed()),
+		PrivateKeyFile: p2p.KeyPath(dataDir),
+	}
+}
+
 // writeExpandedEnr writes the expanded form of ENR to the terminal.
 func writeExpandedEnr(w io.Writer, r enr.Record, privKey *k1.PrivateKey) {
 	var sb strings.Builder
@@ -72,6 +163,18 @@ func writeExpandedEnr(w io.Writer, r enr.Record, privKey *k1.PrivateKey) {
 	_, _ = sb.WriteString("***************** Decoded ENR (see https://enr-viewer.com/ for additional fields) **********************\n")
 	_, _ = sb.WriteString(fmt.Sprintf("secp256k1 pubkey: %#x\n", privKey.PubKey().SerializeCompressed()))
 	_, _ = sb.WriteString(fmt.Sprintf("signature: %#x\n", r.Signature))
+	_, _ = sb.WriteString(fmt.Sprintf("seq: %d\n", r.Seq()))
+	if ip, ok := r.IP(); ok {
+		tcp, _ := r.TCP()
+		_, _ = sb.WriteString(fmt.Sprintf("ip: %s tcp: %d\n", ip, tcp))
+	}
+	if ip6, ok := r.IP6(); ok {
+		tcp6, _ := r.TCP6()
+		_, _ = sb.WriteString(fmt.Sprintf("ip6: %s tcp6: %d\n", ip6, tcp6))
+	}
+	if host, ok := r.DNS(); ok {
+		_, _ = sb.WriteString(fmt.Sprintf("dns: %s\n", host))
+	}
 	_, _ = sb.WriteString("********************************************************************************************************\n")
 	_, _ = sb.WriteString("\n")
 
@@ -3,20 +3,65 @@
 package cmd
 
 import (
+	"bytes"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/enr"
 	"github.com/obolnetwork/charon/p2p"
 )
 
 func TestRunNewEnr(t *testing.T) {
 	temp := t.TempDir()
 
-	got := runNewENR(io.Discard, temp, false)
+	got := runNewENR(io.Discard, enrConfig{DataDir: temp}, false)
 	expected := errors.New("private key not found. If this is your first time running this client, create one with `charon create enr`.", z.Str("enr_path", p2p.KeyPath(temp)))
 	require.Equal(t, expected.Error(), got.Error())
 }
+
+func TestRunNewEnrAddrs(t *testing.T) {
+	temp := t.TempDir()
+	_, err := p2p.NewSavedPrivKey(temp)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = runNewENR(&buf, enrConfig{
+		DataDir:       temp,
+		ExternalIPs:   []string{"1.2.3.4", "2001:db8::1"},
+		ExternalHosts: []string{"charon.example.com"},
+		TCPPort:       3610,
+		Seq:           2,
+	}, false)
+	require.NoError(t, err)
+
+	r, err := enr.Parse(strings.TrimSpace(buf.String()))
+	require.NoError(t, err)
+	require.Equal(t, 2, r.Seq())
+
+	ip, ok := r.IP()
+	require.True(t, ok)
+	require.Equal(t, "1.2.3.4", ip.String())
+
+	ip6, ok := r.IP6()
+	require.True(t, ok)
+	require.Equal(t, "2001:db8::1", ip6.String())
+
+	tcp6, ok := r.TCP6()
+	require.True(t, ok)
+	require.Equal(t, 3610, tcp6)
+
+	host, ok := r.DNS()
+	require.True(t, ok)
+	require.Equal(t, "charon.example.com", host)
+
+	err = runNewENR(io.Discard, enrConfig{DataDir: temp, ExternalIPs: []string{"1.2.3.4", "5.6.7.8"}, TCPPort: 3610}, false)
+	require.ErrorContains(t, err, "only one IPv4 --p2p-external-ip supported in ENR")
+
+	err = runNewENR(io.Discard, enrConfig{DataDir: temp, ExternalHosts: []string{"a.com", "b.com"}, TCPPort: 3610}, false)
+	require.ErrorContains(t, err, "only one --p2p-external-hostname supported in ENR")
+}
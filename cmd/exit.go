@@ -4,6 +4,9 @@ package cmd
 
 import (
 	"context"
+	"io"
+	"slices"
+	"strings"
 	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
@@ -14,10 +17,18 @@ import (
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/keystore"
 	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/eth2util/vault"
 	"github.com/obolnetwork/charon/tbls"
 )
 
+const (
+	defaultPublishAddress = "https://api.obol.tech/v1"
+	defaultPublishTimeout = 5 * time.Minute
+	defaultExitEpoch      = 162304
+)
+
 type exitConfig struct {
 	BeaconNodeEndpoints     []string
 	ValidatorPubkey         string
@@ -37,9 +48,54 @@ type exitConfig struct {
 	ExitFromFileDir         string
 	Log                     log.Config
 	All                     bool
+	Wait                    bool
 	testnetConfig           eth2util.Network
 	BeaconNodeHeaders       []string
 	FallbackBeaconNodeAddrs []string
+	Vault                   vault.Config
+	EscrowDir               string
+	PassphraseFile          string
+	// EscrowOptions are the key derivation options of sealed escrow files, the zero value results in the EIP-2335 defaults.
+	EscrowOptions keystore.Options
+	// JSONOutput is the writer of the exit results as JSON if the global --output flag is json, otherwise nil.
+	JSONOutput io.Writer
+}
+
+// exitResult is the result of an exit command for a validator in JSON output.
+type exitResult struct {
+	PublicKey      string   `json:"public_key"`
+	ValidatorIndex *uint64  `json:"validator_index,omitempty"`
+	ExitEpoch      *uint64  `json:"exit_epoch,omitempty"`
+	Path           string   `json:"path,omitempty"`
+	Submitted      []string `json:"submitted,omitempty"`
+	Missing        []string `json:"missing,omitempty"`
+	Threshold      int      `json:"threshold,omitempty"`
+}
+
+// newExitResult returns the result of the validator's signed exit message.
+func newExitResult(pubkey string, exit eth2p0.SignedVoluntaryExit) exitResult {
+	index, epoch := uint64(exit.Message.ValidatorIndex), uint64(exit.Message.Epoch)
+
+	return exitResult{
+		PublicKey:      pubkey,
+		ValidatorIndex: &index,
+		ExitEpoch:      &epoch,
+	}
+}
+
+// writeExitResults writes the results ordered by public key as JSON, if JSON output is enabled.
+func writeExitResults(config exitConfig, results []exitResult) error {
+	if config.JSONOutput == nil {
+		return nil
+	}
+
+	slices.SortFunc(results, func(a, b exitResult) int {
+		return strings.Compare(a.PublicKey, b.PublicKey)
+	})
+
+	return writeJSON(config.JSONOutput, struct {
+		Validators []exitResult `json:"validators"`
+	}{Validators: append([]exitResult{}, results...)})
 }
 
 func newExitCmd(cmds ...*cobra.Command) *cobra.Command {
@@ -78,6 +134,9 @@ const (
 	testnetCapellaHardFork
 	beaconNodeHeaders
 	fallbackBeaconNodeAddrs
+	wait
+	escrowDir
+	passphraseFile
 )
 
 func (ef exitFlag) String() string {
@@ -124,6 +183,12 @@ func (ef exitFlag) String() string {
 		return "beacon-node-headers"
 	case fallbackBeaconNodeAddrs:
 		return "fallback-beacon-node-endpoints"
+	case wait:
+		return "wait"
+	case escrowDir:
+		return "escrow-dir"
+	case passphraseFile:
+		return "passphrase-file"
 	default:
 		return "unknown"
 	}
@@ -148,7 +213,7 @@ func bindExitFlags(cmd *cobra.Command, config *exitConfig, flags []exitCLIFlag)
 
 		switch flag {
 		case publishAddress:
-			cmd.Flags().StringVar(&config.PublishAddress, publishAddress.String(), "https://api.obol.tech/v1", maybeRequired("The URL of the remote API."))
+			cmd.Flags().StringVar(&config.PublishAddress, publishAddress.String(), defaultPublishAddress, maybeRequired("The URL of the remote API."))
 		case beaconNodeEndpoints:
 			cmd.Flags().StringSliceVar(&config.BeaconNodeEndpoints, beaconNodeEndpoints.String(), nil, maybeRequired("Comma separated list of one or more beacon node endpoint URLs."))
 		case privateKeyPath:
@@ -160,7 +225,7 @@ func bindExitFlags(cmd *cobra.Command, config *exitConfig, flags []exitCLIFlag)
 		case validatorPubkey:
 			cmd.Flags().StringVar(&config.ValidatorPubkey, validatorPubkey.String(), "", maybeRequired("Public key of the validator to exit, must be present in the cluster lock manifest. If --validator-index is also provided, validator liveliness won't be checked on the beacon chain."))
 		case exitEpoch:
-			cmd.Flags().Uint64Var(&config.ExitEpoch, exitEpoch.String(), 162304, maybeRequired("Exit epoch at which the validator will exit, must be the same across all the partial exits."))
+			cmd.Flags().Uint64Var(&config.ExitEpoch, exitEpoch.String(), defaultExitEpoch, maybeRequired("Exit epoch at which the validator will exit, must be the same across all the partial exits."))
 		case exitFromFile:
 			cmd.Flags().StringVar(&config.ExitFromFilePath, exitFromFile.String(), "", maybeRequired("Retrieves a signed exit message from a pre-prepared file instead of --publish-address."))
 		case exitFromDir:
@@ -170,7 +235,7 @@ func bindExitFlags(cmd *cobra.Command, config *exitConfig, flags []exitCLIFlag)
 		case fetchedExitPath:
 			cmd.Flags().StringVar(&config.FetchedExitPath, fetchedExitPath.String(), "./", maybeRequired("Path to store fetched signed exit messages."))
 		case publishTimeout:
-			cmd.Flags().DurationVar(&config.PublishTimeout, publishTimeout.String(), 5*time.Minute, "Timeout for publishing a signed exit to the publish-address API.")
+			cmd.Flags().DurationVar(&config.PublishTimeout, publishTimeout.String(), defaultPublishTimeout, "Timeout for publishing a signed exit to the publish-address API.")
 		case validatorIndex:
 			cmd.Flags().Uint64Var(&config.ValidatorIndex, validatorIndex.String(), 0, "Validator index of the validator to exit, the associated public key must be present in the cluster lock manifest. If --validator-public-key is also provided, validator existence won't be checked on the beacon chain.")
 		case all:
@@ -189,6 +254,12 @@ func bindExitFlags(cmd *cobra.Command, config *exitConfig, flags []exitCLIFlag)
 			cmd.Flags().StringSliceVar(&config.BeaconNodeHeaders, "beacon-node-headers", nil, "Comma separated list of headers formatted as header=value")
 		case fallbackBeaconNodeAddrs:
 			cmd.Flags().StringSliceVar(&config.FallbackBeaconNodeAddrs, "fallback-beacon-node-endpoints", nil, "A list of beacon nodes to use if the primary list are offline or unhealthy.")
+		case escrowDir:
+			cmd.Flags().StringVar(&config.EscrowDir, escrowDir.String(), ".charon/exit_escrow", maybeRequired("Path to the directory containing the encrypted presigned partial exit escrow files."))
+		case passphraseFile:
+			cmd.Flags().StringVar(&config.PassphraseFile, passphraseFile.String(), "", maybeRequired("Path to the file containing the escrow passphrase, at least 12 characters."))
+		case wait:
+			cmd.Flags().BoolVar(&config.Wait, wait.String(), false, "Wait until the partial exit threshold and the exit epoch are reached, logging the progress, and broadcast each exit as soon as possible. Allows scheduling exits at a future exit epoch.")
 		}
 
 		if f.required {
@@ -47,6 +47,8 @@ func newBcastFullExitCmd(runFunc func(context.Context, exitConfig) error) *cobra
 
 			printFlags(cmd.Context(), cmd.Flags())
 
+			config.JSONOutput = jsonWriter(cmd)
+
 			return runFunc(cmd.Context(), config)
 		},
 	}
@@ -71,6 +73,7 @@ func newBcastFullExitCmd(runFunc func(context.Context, exitConfig) error) *cobra
 		{testnetCapellaHardFork, false},
 		{beaconNodeHeaders, false},
 		{fallbackBeaconNodeAddrs, false},
+		{wait, false},
 	})
 
 	bindLogFlags(cmd.Flags(), &config.Log)
@@ -80,6 +83,11 @@ func newBcastFullExitCmd(runFunc func(context.Context, exitConfig) error) *cobra
 		exitFilePresent := cmd.Flags().Lookup(exitFromFile.String()).Changed
 		exitDirPresent := cmd.Flags().Lookup(exitFromDir.String()).Changed
 
+		if config.Wait && (exitFilePresent || exitDirPresent) {
+			//nolint:revive // we use our own version of the errors package.
+			return errors.New(fmt.Sprintf("%s is only supported when retrieving exits from the remote API, not with %s or %s.", wait.String(), exitFromFile.String(), exitFromDir.String()))
+		}
+
 		if !valPubkPresent && !config.All {
 			//nolint:revive,perfsprint // we use our own version of the errors package; keep consistency with other checks.
 			return errors.New(fmt.Sprintf("%s must be specified when exiting single validator.", validatorPubkey.String()))
@@ -108,6 +116,8 @@ func newBcastFullExitCmd(runFunc func(context.Context, exitConfig) error) *cobra
 		return nil
 	})
 
+	supportJSONOutput(cmd)
+
 	return cmd
 }
 
@@ -138,6 +148,10 @@ func runBcastFullExit(ctx context.Context, config exitConfig) error {
 		return errors.Wrap(err, "create eth2 client for specified beacon node(s)", z.Any("beacon_nodes_endpoints", config.BeaconNodeEndpoints))
 	}
 
+	if config.Wait {
+		return waitAndBcastExits(ctx, config, cl, identityKey, eth2Cl)
+	}
+
 	fullExits := make(map[core.PubKey]eth2p0.SignedVoluntaryExit)
 	if config.All {
 		if config.ExitFromFileDir != "" {
@@ -166,7 +180,7 @@ func runBcastFullExit(ctx context.Context, config exitConfig) error {
 			for _, validator := range cl.GetValidators() {
 				validatorPubKeyHex := fmt.Sprintf("0x%x", validator.GetPublicKey())
 
-				valCtx := log.WithCtx(ctx, z.Str("validator_public_key", validatorPubKeyHex))
+				valCtx := log.WithCtx(ctx, z.Redact(z.Str("validator_public_key", validatorPubKeyHex)))
 				exit, err := fetchFullExit(valCtx, "", config, cl, identityKey, validatorPubKeyHex)
 				if err != nil {
 					if errors.Is(err, obolapi.ErrNoExit) {
@@ -184,10 +198,10 @@ func runBcastFullExit(ctx context.Context, config exitConfig) error {
 			}
 		}
 	} else {
-		valCtx := log.WithCtx(ctx, z.Str("validator_public_key", config.ValidatorPubkey), z.Str("validator_exit_file", config.ExitFromFilePath))
+		valCtx := log.WithCtx(ctx, z.Redact(z.Str("validator_public_key", config.ValidatorPubkey)), z.Str("validator_exit_file", config.ExitFromFilePath))
 		exit, err := fetchFullExit(valCtx, strings.TrimSpace(config.ExitFromFilePath), config, cl, identityKey, config.ValidatorPubkey)
 		if err != nil {
-			return errors.Wrap(err, "fetch full exit for validator", z.Str("validator_public_key", config.ValidatorPubkey), z.Str("validator_exit_file", config.ExitFromFilePath))
+			return errors.Wrap(err, "fetch full exit for validator", z.Redact(z.Str("validator_public_key", config.ValidatorPubkey)), z.Str("validator_exit_file", config.ExitFromFilePath))
 		}
 		var validatorPubKey core.PubKey
 		if len(strings.TrimSpace(config.ExitFromFilePath)) != 0 {
@@ -201,7 +215,12 @@ func runBcastFullExit(ctx context.Context, config exitConfig) error {
 		fullExits[validatorPubKey] = exit
 	}
 
-	return broadcastExitsToBeacon(ctx, eth2Cl, fullExits)
+	results, err := broadcastExitsToBeacon(ctx, eth2Cl, fullExits)
+	if err != nil {
+		return err
+	}
+
+	return writeExitResults(config, results)
 }
 
 func validatorPubKeyFromFileName(fileName string) (core.PubKey, error) {
@@ -210,7 +229,7 @@ func validatorPubKeyFromFileName(fileName string) (core.PubKey, error) {
 	validatorPubKeyHex := strings.TrimPrefix(strings.TrimSuffix(fileNameChecked, fileExtension), "exit-0x")
 	validatorPubKeyBytes, err := hex.DecodeString(validatorPubKeyHex)
 	if err != nil {
-		return "", errors.Wrap(err, "decode public key hex from file name", z.Str("public_key", validatorPubKeyHex))
+		return "", errors.Wrap(err, "decode public key hex from file name", z.Redact(z.Str("public_key", validatorPubKeyHex)))
 	}
 	validatorPubKey, err := core.PubKeyFromBytes(validatorPubKeyBytes)
 	if err != nil {
@@ -235,24 +254,25 @@ func fetchFullExit(ctx context.Context, exitFilePath string, config exitConfig,
 	return fullExit, err
 }
 
-func broadcastExitsToBeacon(ctx context.Context, eth2Cl eth2wrap.Client, exits map[core.PubKey]eth2p0.SignedVoluntaryExit) error {
+// broadcastExitsToBeacon verifies and submits the full exits to the beacon node, returning the results of the submitted exits.
+func broadcastExitsToBeacon(ctx context.Context, eth2Cl eth2wrap.Client, exits map[core.PubKey]eth2p0.SignedVoluntaryExit) ([]exitResult, error) {
 	for validator, fullExit := range exits {
-		valCtx := log.WithCtx(ctx, z.Str("validator", validator.String()))
+		valCtx := log.WithCtx(ctx, z.Redact(z.Str("validator", validator.String())))
 
 		rawPkBytes, err := validator.Bytes()
 		if err != nil {
-			return errors.Wrap(err, "serialize validator key bytes", z.Str("validator", validator.String()))
+			return nil, errors.Wrap(err, "serialize validator key bytes", z.Redact(z.Str("validator", validator.String())))
 		}
 
 		pubkey, err := tblsconv.PubkeyFromBytes(rawPkBytes)
 		if err != nil {
-			return errors.Wrap(err, "convert validator key bytes to BLS public key")
+			return nil, errors.Wrap(err, "convert validator key bytes to BLS public key")
 		}
 
 		// parse signature
 		signature, err := tblsconv.SignatureFromBytes(fullExit.Signature[:])
 		if err != nil {
-			return errors.Wrap(err, "parse BLS signature from bytes", z.Str("exit_signature", fullExit.Signature.String()))
+			return nil, errors.Wrap(err, "parse BLS signature from bytes", z.Str("exit_signature", fullExit.Signature.String()))
 		}
 
 		exitRoot, err := sigDataForExit(
@@ -262,23 +282,127 @@ func broadcastExitsToBeacon(ctx context.Context, eth2Cl eth2wrap.Client, exits m
 			fullExit.Message.Epoch,
 		)
 		if err != nil {
-			return errors.Wrap(err, "calculate hash tree root for exit message for verification")
+			return nil, errors.Wrap(err, "calculate hash tree root for exit message for verification")
 		}
 
 		if err := tbls.Verify(pubkey, exitRoot[:], signature); err != nil {
-			return errors.Wrap(err, "exit message signature not verified")
+			return nil, errors.Wrap(err, "exit message signature not verified")
 		}
 	}
 
+	var results []exitResult
 	for validator, fullExit := range exits {
-		valCtx := log.WithCtx(ctx, z.Str("validator", validator.String()))
+		valCtx := log.WithCtx(ctx, z.Redact(z.Str("validator", validator.String())))
 		if err := eth2Cl.SubmitVoluntaryExit(valCtx, &fullExit); err != nil {
-			return errors.Wrap(err, "submit voluntary exit")
+			return nil, errors.Wrap(err, "submit voluntary exit")
 		}
 		log.Info(valCtx, "Successfully submitted voluntary exit for validator")
+
+		results = append(results, newExitResult(validator.String(), fullExit))
+	}
+
+	return results, nil
+}
+
+// exitWaitPeriod is the period between polls of the Obol API when waiting for exits.
+var exitWaitPeriod = time.Minute
+
+// waitAndBcastExits polls the Obol API until the partial exit threshold and the exit epoch are reached for all selected
+// validators, broadcasting each full exit as soon as possible.
+func waitAndBcastExits(ctx context.Context, config exitConfig, cl *manifestpb.Cluster, identityKey *k1.PrivateKey, eth2Cl eth2wrap.Client) error {
+	oAPI, err := obolapi.New(config.PublishAddress, obolapi.WithTimeout(config.PublishTimeout))
+	if err != nil {
+		return errors.Wrap(err, "create Obol API client", z.Str("publish_address", config.PublishAddress))
+	}
+
+	pending, err := exitValidators(config, cl)
+	if err != nil {
+		return err
+	}
+
+	var results []exitResult
+	for {
+		epoch, err := currentEpoch(ctx, eth2Cl)
+		if err != nil {
+			return err
+		}
+
+		var remaining []string
+		for _, validator := range pending {
+			progress, err := fetchExitProgress(ctx, oAPI, cl, validator)
+			if err != nil {
+				return err
+			}
+
+			logExitProgress(ctx, progress)
+
+			if !progress.ThresholdReached() {
+				remaining = append(remaining, validator)
+				continue
+			} else if progress.Status.Epoch > epoch {
+				log.Info(ctx, "Exit scheduled for future epoch",
+					z.Redact(z.Str("validator_public_key", validator)),
+					z.U64("exit_epoch", uint64(progress.Status.Epoch)),
+					z.U64("current_epoch", uint64(epoch)),
+				)
+				remaining = append(remaining, validator)
+
+				continue
+			}
+
+			valCtx := log.WithCtx(ctx, z.Redact(z.Str("validator_public_key", validator)))
+			exit, err := exitFromObolAPI(valCtx, validator, config.PublishAddress, config.PublishTimeout, cl, identityKey)
+			if err != nil {
+				return errors.Wrap(err, "fetch full exit for validator", z.Redact(z.Str("validator_public_key", validator)))
+			}
+
+			submitted, err := broadcastExitsToBeacon(valCtx, eth2Cl, map[core.PubKey]eth2p0.SignedVoluntaryExit{core.PubKey(validator): exit})
+			if err != nil {
+				return err
+			}
+
+			results = append(results, submitted...)
+		}
+
+		pending = remaining
+		if len(pending) == 0 {
+			return writeExitResults(config, results)
+		}
+
+		log.Info(ctx, "Waiting for remaining exits", z.Int("remaining", len(pending)), z.Str("next_poll", exitWaitPeriod.String()))
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "waiting for exits")
+		case <-time.After(exitWaitPeriod):
+		}
+	}
+}
+
+// currentEpoch returns the current epoch of the beacon chain.
+func currentEpoch(ctx context.Context, eth2Cl eth2wrap.Client) (eth2p0.Epoch, error) {
+	genesis, err := eth2Cl.GenesisTime(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "fetch genesis time")
 	}
 
-	return nil
+	slotDuration, err := eth2Cl.SlotDuration(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "fetch slot duration")
+	}
+
+	slotsPerEpoch, err := eth2Cl.SlotsPerEpoch(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "fetch slots per epoch")
+	}
+
+	if time.Now().Before(genesis) {
+		return 0, nil
+	}
+
+	slot := uint64(time.Since(genesis) / slotDuration)
+
+	return eth2p0.Epoch(slot / slotsPerEpoch), nil
 }
 
 // exitFromObolAPI fetches an eth2p0.SignedVoluntaryExit message from publishAddr for the given validatorPubkey.
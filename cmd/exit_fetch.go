@@ -38,6 +38,8 @@ func newFetchExitCmd(runFunc func(context.Context, exitConfig) error) *cobra.Com
 
 			printFlags(cmd.Context(), cmd.Flags())
 
+			config.JSONOutput = jsonWriter(cmd)
+
 			return runFunc(cmd.Context(), config)
 		},
 	}
@@ -75,6 +77,8 @@ func newFetchExitCmd(runFunc func(context.Context, exitConfig) error) *cobra.Com
 		return nil
 	})
 
+	supportJSONOutput(cmd)
+
 	return cmd
 }
 
@@ -118,11 +122,12 @@ func runFetchExit(ctx context.Context, config exitConfig) error {
 		return errors.Wrap(err, "determine operator index from cluster lock for supplied identity key")
 	}
 
+	var results []exitResult
 	if config.All {
 		for _, validator := range cl.GetValidators() {
 			validatorPubKeyHex := fmt.Sprintf("0x%x", validator.GetPublicKey())
 
-			valCtx := log.WithCtx(ctx, z.Str("validator", validatorPubKeyHex))
+			valCtx := log.WithCtx(ctx, z.Redact(z.Str("validator", validatorPubKeyHex)))
 
 			log.Info(valCtx, "Retrieving full exit message")
 
@@ -136,49 +141,62 @@ func runFetchExit(ctx context.Context, config exitConfig) error {
 				return errors.Wrap(err, "broadcast full exit for all validators from public key")
 			}
 
-			err = writeExitToFile(valCtx, validatorPubKeyHex, config.FetchedExitPath, fullExit)
+			path, err := writeExitToFile(valCtx, validatorPubKeyHex, config.FetchedExitPath, fullExit)
 			if err != nil {
 				return err
 			}
+
+			results = append(results, fetchedExitResult(validatorPubKeyHex, path, fullExit))
 		}
 	} else {
 		validator := core.PubKey(config.ValidatorPubkey)
 		if _, err := validator.Bytes(); err != nil {
-			return errors.Wrap(err, "convert validator pubkey to bytes", z.Str("validator_public_key", config.ValidatorPubkey))
+			return errors.Wrap(err, "convert validator pubkey to bytes", z.Redact(z.Str("validator_public_key", config.ValidatorPubkey)))
 		}
 
-		ctx = log.WithCtx(ctx, z.Str("validator", validator.String()))
+		ctx = log.WithCtx(ctx, z.Redact(z.Str("validator", validator.String())))
 
 		log.Info(ctx, "Retrieving full exit message")
 
 		fullExit, err := oAPI.GetFullExit(ctx, config.ValidatorPubkey, cl.GetInitialMutationHash(), shareIdx, identityKey)
 		if err != nil {
-			return errors.Wrap(err, "load full exit data from Obol API", z.Str("validator_public_key", config.ValidatorPubkey))
+			return errors.Wrap(err, "load full exit data from Obol API", z.Redact(z.Str("validator_public_key", config.ValidatorPubkey)))
 		}
 
-		err = writeExitToFile(ctx, config.ValidatorPubkey, config.FetchedExitPath, fullExit)
+		path, err := writeExitToFile(ctx, config.ValidatorPubkey, config.FetchedExitPath, fullExit)
 		if err != nil {
 			return err
 		}
+
+		results = append(results, fetchedExitResult(config.ValidatorPubkey, path, fullExit))
 	}
 
-	return nil
+	return writeExitResults(config, results)
+}
+
+// fetchedExitResult returns the result of the full exit stored at path.
+func fetchedExitResult(pubkey string, path string, fullExit obolapi.ExitBlob) exitResult {
+	result := newExitResult(pubkey, fullExit.SignedExitMessage)
+	result.Path = path
+
+	return result
 }
 
-func writeExitToFile(ctx context.Context, valPubKey string, exitPath string, fullExit obolapi.ExitBlob) error {
+// writeExitToFile stores the signed exit message in the exit path and returns the path of the file.
+func writeExitToFile(ctx context.Context, valPubKey string, exitPath string, fullExit obolapi.ExitBlob) (string, error) {
 	fetchedExitFname := fmt.Sprintf("exit-%s.json", valPubKey)
 	fetchedExitPath := filepath.Join(exitPath, fetchedExitFname)
 
 	exitData, err := json.Marshal(fullExit.SignedExitMessage)
 	if err != nil {
-		return errors.Wrap(err, "signed exit message marshal")
+		return "", errors.Wrap(err, "signed exit message marshal")
 	}
 
 	if err := os.WriteFile(fetchedExitPath, exitData, 0o600); err != nil {
-		return errors.Wrap(err, "store signed exit message")
+		return "", errors.Wrap(err, "store signed exit message")
 	}
 
 	log.Info(ctx, "Stored signed exit message", z.Str("path", fetchedExitPath))
 
-	return nil
+	return fetchedExitPath, nil
 }
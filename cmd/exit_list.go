@@ -34,6 +34,8 @@ func newListActiveValidatorsCmd(runFunc func(context.Context, exitConfig) error)
 
 			printFlags(cmd.Context(), cmd.Flags())
 
+			config.JSONOutput = jsonWriter(cmd)
+
 			return runFunc(cmd.Context(), config)
 		},
 	}
@@ -59,6 +61,8 @@ func newListActiveValidatorsCmd(runFunc func(context.Context, exitConfig) error)
 		return eth2util.ValidateBeaconNodeHeaders(config.BeaconNodeHeaders)
 	})
 
+	supportJSONOutput(cmd)
+
 	return cmd
 }
 
@@ -74,6 +78,15 @@ func runListActiveValidatorsCmd(ctx context.Context, config exitConfig) error {
 		return err
 	}
 
+	if config.JSONOutput != nil {
+		var results []exitResult
+		for _, validator := range valList {
+			results = append(results, exitResult{PublicKey: validator})
+		}
+
+		return writeExitResults(config, results)
+	}
+
 	for _, validator := range valList {
 		if config.PlaintextOutput {
 			//nolint:forbidigo // used for plaintext printing
@@ -81,7 +94,7 @@ func runListActiveValidatorsCmd(ctx context.Context, config exitConfig) error {
 			continue
 		}
 
-		log.Info(ctx, "Validator", z.Str("validator_public_key", validator))
+		log.Info(ctx, "Validator", z.Redact(z.Str("validator_public_key", validator)))
 	}
 
 	return nil
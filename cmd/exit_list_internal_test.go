@@ -3,6 +3,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -85,6 +86,19 @@ func Test_runListActiveVals(t *testing.T) {
 	}
 
 	require.NoError(t, runListActiveValidatorsCmd(ctx, config))
+
+	t.Run("json output", func(t *testing.T) {
+		var buf bytes.Buffer
+		config.JSONOutput = &buf
+		require.NoError(t, runListActiveValidatorsCmd(ctx, config))
+
+		var result struct {
+			Validators []exitResult `json:"validators"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.Validators, valAmt)
+		require.Contains(t, result.Validators, exitResult{PublicKey: fmt.Sprintf("%#x", lock.Validators[0].PubKey)})
+	})
 }
 
 func Test_listActiveVals(t *testing.T) {
@@ -0,0 +1,371 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/exitescrow"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/memlock"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+)
+
+func newPresignExitCmd(runFunc func(context.Context, exitConfig) error) *cobra.Command {
+	var config exitConfig
+
+	cmd := &cobra.Command{
+		Use:   "presign",
+		Short: "Presign partial exit messages of all validators into an encrypted escrow file",
+		Long: `Signs partial exit messages of all validators in the cluster at a future exit epoch and stores them in a passphrase-encrypted escrow file, ` +
+			`e.g. for compliance or staking-as-a-service escrow. The escrow files of at least threshold operators can later be combined and broadcasted using 'charon exit broadcast-presigned'.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			config.JSONOutput = jsonWriter(cmd)
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindExitFlags(cmd, &config, []exitCLIFlag{
+		{privateKeyPath, false},
+		{lockFilePath, false},
+		{validatorKeysDir, false},
+		{exitEpoch, true},
+		{beaconNodeEndpoints, true},
+		{beaconNodeTimeout, false},
+		{escrowDir, false},
+		{passphraseFile, true},
+		{testnetName, false},
+		{testnetForkVersion, false},
+		{testnetChainID, false},
+		{testnetGenesisTimestamp, false},
+		{testnetCapellaHardFork, false},
+		{beaconNodeHeaders, false},
+		{fallbackBeaconNodeAddrs, false},
+	})
+
+	bindLogFlags(cmd.Flags(), &config.Log)
+	bindVaultFlags(cmd.Flags(), &config.Vault)
+
+	wrapPreRunE(cmd, func(*cobra.Command, []string) error {
+		return eth2util.ValidateBeaconNodeHeaders(config.BeaconNodeHeaders)
+	})
+
+	supportJSONOutput(cmd)
+
+	return cmd
+}
+
+func runPresignExit(ctx context.Context, config exitConfig) error {
+	// Check if custom testnet configuration is provided.
+	if config.testnetConfig.IsNonZero() {
+		// Add testnet config to supported networks.
+		eth2util.AddTestNetwork(config.testnetConfig)
+	}
+
+	passphrase, err := loadPassphrase(config.PassphraseFile)
+	if err != nil {
+		return err
+	}
+
+	identityKey, err := k1util.Load(config.PrivateKeyPath)
+	if err != nil {
+		return errors.Wrap(err, "load identity key", z.Str("private_key_path", config.PrivateKeyPath))
+	}
+
+	cl, err := loadClusterManifest("", config.LockFilePath)
+	if err != nil {
+		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath))
+	}
+
+	shareIdx, err := keystore.ShareIdxForCluster(cl, *identityKey.PubKey())
+	if err != nil {
+		return errors.Wrap(err, "determine operator index from cluster lock for supplied identity key")
+	}
+
+	escrowPath := filepath.Join(config.EscrowDir, exitescrow.FileName(int(shareIdx)))
+	if _, err := os.Stat(escrowPath); err == nil {
+		return errors.New("escrow file already exists, refusing to overwrite", z.Str("path", escrowPath))
+	}
+
+	valKeys, err := loadValidatorKeys(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer memlock.ProtectKeys(ctx, valKeys)()
+
+	shares, err := keystore.KeysharesToValidatorPubkey(cl, valKeys)
+	if err != nil {
+		return errors.Wrap(err, "match local validator key shares with their counterparty in cluster lock")
+	}
+
+	beaconNodeHeaders, err := eth2util.ParseBeaconNodeHeaders(config.BeaconNodeHeaders)
+	if err != nil {
+		return err
+	}
+
+	eth2Cl, err := eth2Client(ctx, config.FallbackBeaconNodeAddrs, beaconNodeHeaders, config.BeaconNodeEndpoints, config.BeaconNodeTimeout, [4]byte(cl.GetForkVersion()))
+	if err != nil {
+		return errors.Wrap(err, "create eth2 client for specified beacon node(s)", z.Any("beacon_nodes_endpoints", config.BeaconNodeEndpoints))
+	}
+
+	exits, err := presignExits(ctx, config, eth2Cl, shares)
+	if err != nil {
+		return err
+	}
+
+	b, err := exitescrow.Seal(exitescrow.Escrow{
+		ClusterHash: exitescrow.ClusterHash(cl),
+		ShareIdx:    int(shareIdx),
+		ExitEpoch:   config.ExitEpoch,
+		Exits:       exits,
+	}, passphrase, config.EscrowOptions)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(config.EscrowDir, 0o755); err != nil {
+		return errors.Wrap(err, "create escrow directory", z.Str("escrow_dir", config.EscrowDir))
+	}
+
+	if err := os.WriteFile(escrowPath, b, 0o400); err != nil {
+		return errors.Wrap(err, "write escrow file", z.Str("path", escrowPath))
+	}
+
+	log.Info(ctx, "Stored presigned partial exits in escrow file",
+		z.Str("path", escrowPath), z.Int("validators", len(exits)), z.U64("exit_epoch", config.ExitEpoch))
+
+	var results []exitResult
+	for _, exit := range exits {
+		result := newExitResult(exit.PublicKey, exit.SignedExitMessage)
+		result.Path = escrowPath
+		results = append(results, result)
+	}
+
+	return writeExitResults(config, results)
+}
+
+// presignExits returns the partial exits of all validators with key shares that are known to the beacon node.
+// Validators unknown to the beacon node, e.g. not yet deposited, are skipped since their index isn't known.
+func presignExits(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, shares keystore.ValidatorShares) ([]exitescrow.PartialExit, error) {
+	var valsEth2 []eth2p0.BLSPubKey
+	for pk := range shares {
+		eth2PK, err := pk.ToETH2()
+		if err != nil {
+			return nil, errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Redact(z.Str("core_pubkey", pk.String())))
+		}
+		valsEth2 = append(valsEth2, eth2PK)
+	}
+
+	rawValData, err := queryBeaconForValidator(ctx, eth2Cl, valsEth2, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch all validators indices from beacon")
+	}
+
+	indices := make(map[core.PubKey]eth2p0.ValidatorIndex)
+	for _, val := range rawValData.Data {
+		indices[core.PubKeyFrom48Bytes(val.Validator.PublicKey)] = val.Index
+	}
+
+	var resp []exitescrow.PartialExit
+	for pk, share := range shares {
+		eth2PK, err := pk.ToETH2()
+		if err != nil {
+			return nil, errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Redact(z.Str("core_pubkey", pk.String())))
+		}
+
+		valIdx, ok := indices[pk]
+		if !ok {
+			log.Warn(ctx, "Skipping validator not found on beacon node", nil, z.Redact(z.Str("validator_public_key", pk.String())))
+			continue
+		}
+
+		exitMsg, err := signExit(ctx, eth2Cl, valIdx, share.Share, eth2p0.Epoch(config.ExitEpoch))
+		if err != nil {
+			return nil, errors.Wrap(err, "sign partial exit message", z.Redact(z.Str("validator_public_key", pk.String())), z.U64("validator_index", uint64(valIdx)))
+		}
+
+		resp = append(resp, exitescrow.PartialExit{
+			PublicKey:         eth2PK.String(),
+			SignedExitMessage: exitMsg,
+		})
+	}
+
+	if len(resp) == 0 {
+		return nil, errors.New("no validators found on beacon node")
+	}
+
+	return resp, nil
+}
+
+func newBcastPresignedExitCmd(runFunc func(context.Context, exitConfig) error) *cobra.Command {
+	var config exitConfig
+
+	cmd := &cobra.Command{
+		Use:   "broadcast-presigned",
+		Short: "Combine and broadcast presigned exit messages from escrow files",
+		Long: `Decrypts the presigned partial exit escrow files in the escrow directory, combines the partial exits of at least threshold operators ` +
+			`into full exit messages and broadcasts them to the configured beacon node. All validators are exited unless --validator-public-key is provided.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			config.JSONOutput = jsonWriter(cmd)
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindExitFlags(cmd, &config, []exitCLIFlag{
+		{lockFilePath, false},
+		{validatorPubkey, false},
+		{beaconNodeEndpoints, true},
+		{beaconNodeTimeout, false},
+		{escrowDir, false},
+		{passphraseFile, true},
+		{testnetName, false},
+		{testnetForkVersion, false},
+		{testnetChainID, false},
+		{testnetGenesisTimestamp, false},
+		{testnetCapellaHardFork, false},
+		{beaconNodeHeaders, false},
+		{fallbackBeaconNodeAddrs, false},
+	})
+
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	wrapPreRunE(cmd, func(*cobra.Command, []string) error {
+		return eth2util.ValidateBeaconNodeHeaders(config.BeaconNodeHeaders)
+	})
+
+	supportJSONOutput(cmd)
+
+	return cmd
+}
+
+func runBcastPresignedExit(ctx context.Context, config exitConfig) error {
+	// Check if custom testnet configuration is provided.
+	if config.testnetConfig.IsNonZero() {
+		// Add testnet config to supported networks.
+		eth2util.AddTestNetwork(config.testnetConfig)
+	}
+
+	passphrase, err := loadPassphrase(config.PassphraseFile)
+	if err != nil {
+		return err
+	}
+
+	cl, err := loadClusterManifest("", config.LockFilePath)
+	if err != nil {
+		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath))
+	}
+
+	escrows, err := openEscrows(config.EscrowDir, passphrase, config.ValidatorPubkey)
+	if err != nil {
+		return err
+	}
+
+	beaconNodeHeaders, err := eth2util.ParseBeaconNodeHeaders(config.BeaconNodeHeaders)
+	if err != nil {
+		return err
+	}
+
+	eth2Cl, err := eth2Client(ctx, config.FallbackBeaconNodeAddrs, beaconNodeHeaders, config.BeaconNodeEndpoints, config.BeaconNodeTimeout, [4]byte(cl.GetForkVersion()))
+	if err != nil {
+		return errors.Wrap(err, "create eth2 client for specified beacon node(s)", z.Any("beacon_nodes_endpoints", config.BeaconNodeEndpoints))
+	}
+
+	exits, err := exitescrow.Combine(cl, escrows, func(exit eth2p0.VoluntaryExit) ([32]byte, error) {
+		return sigDataForExit(ctx, exit, eth2Cl, exit.Epoch)
+	})
+	if err != nil {
+		return errors.Wrap(err, "combine presigned partial exits")
+	}
+
+	if len(exits) == 0 {
+		return errors.New("no presigned exits found in escrow files", z.Str("escrow_dir", config.EscrowDir))
+	}
+
+	epoch, err := currentEpoch(ctx, eth2Cl)
+	if err != nil {
+		return err
+	}
+
+	for validator, exit := range exits {
+		if exit.Message.Epoch > epoch {
+			return errors.New("presigned exit epoch not reached yet",
+				z.Redact(z.Str("validator", validator.String())), z.U64("exit_epoch", uint64(exit.Message.Epoch)), z.U64("current_epoch", uint64(epoch)))
+		}
+	}
+
+	results, err := broadcastExitsToBeacon(ctx, eth2Cl, exits)
+	if err != nil {
+		return err
+	}
+
+	return writeExitResults(config, results)
+}
+
+// openEscrows returns the decrypted escrows in the escrow directory,
+// only including the partial exits of the validator if not empty.
+func openEscrows(dir string, passphrase string, validator string) ([]exitescrow.Escrow, error) {
+	files, err := filepath.Glob(filepath.Join(dir, exitescrow.FileGlob))
+	if err != nil {
+		return nil, errors.Wrap(err, "list escrow files")
+	} else if len(files) == 0 {
+		return nil, errors.New("no escrow files found", z.Str("escrow_dir", dir))
+	}
+
+	var resp []exitescrow.Escrow
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "read escrow file", z.Str("path", file))
+		}
+
+		escrow, err := exitescrow.Open(b, passphrase)
+		if err != nil {
+			return nil, errors.Wrap(err, "open escrow file", z.Str("path", file))
+		}
+
+		if validator != "" {
+			var filtered []exitescrow.PartialExit
+			for _, exit := range escrow.Exits {
+				if strings.EqualFold(strings.TrimPrefix(exit.PublicKey, "0x"), strings.TrimPrefix(validator, "0x")) {
+					filtered = append(filtered, exit)
+				}
+			}
+			escrow.Exits = filtered
+		}
+
+		resp = append(resp, escrow)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,166 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/exitescrow"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestPresignExit(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		valAmt      = 3
+		operatorAmt = 4
+	)
+
+	lock, enrs, keyShares := cluster.NewForT(t, valAmt, 3, operatorAmt, 0, rand.New(rand.NewSource(0)))
+
+	operatorShares := make([][]tbls.PrivateKey, operatorAmt)
+	for opIdx := range operatorAmt {
+		for _, share := range keyShares {
+			operatorShares[opIdx] = append(operatorShares[opIdx], share[opIdx])
+		}
+	}
+
+	mBytes, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	// Only the first two validators are deposited.
+	validatorSet := beaconmock.ValidatorSet{}
+	for idx, v := range lock.Validators[:2] {
+		validatorSet[eth2p0.ValidatorIndex(idx)] = &eth2v1.Validator{
+			Index:   eth2p0.ValidatorIndex(idx),
+			Balance: 42,
+			Status:  eth2v1.ValidatorStateActiveOngoing,
+			Validator: &eth2p0.Validator{
+				PublicKey:             eth2p0.BLSPubKey(v.PubKey),
+				WithdrawalCredentials: testutil.RandomBytes32(),
+			},
+		}
+	}
+
+	beaconMock, err := beaconmock.New(
+		beaconmock.WithValidatorSet(validatorSet),
+		beaconmock.WithEndpoint("/eth/v1/beacon/pool/voluntary_exits", ""),
+	)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, beaconMock.Close())
+	}()
+
+	root := t.TempDir()
+	writeAllLockData(t, root, operatorAmt, enrs, operatorShares, mBytes)
+
+	passphraseFile := filepath.Join(root, "passphrase")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte("correct horse battery staple\n"), 0o600))
+
+	// presign presigns the exits of the operator into the escrow dir at the exit epoch.
+	presign := func(t *testing.T, opIdx int, escrowDir string, epoch uint64) error {
+		t.Helper()
+
+		baseDir := filepath.Join(root, fmt.Sprintf("op%d", opIdx))
+
+		return runPresignExit(ctx, exitConfig{
+			BeaconNodeEndpoints: []string{beaconMock.Address()},
+			PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
+			ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
+			LockFilePath:        filepath.Join(baseDir, "cluster-lock.json"),
+			ExitEpoch:           epoch,
+			BeaconNodeTimeout:   10 * time.Second,
+			EscrowDir:           escrowDir,
+			PassphraseFile:      passphraseFile,
+			EscrowOptions:       keystore.Options{KDF: keystore.KDFPbkdf2, CostPower: 10},
+		})
+	}
+
+	broadcastConfig := func(escrowDir string) exitConfig {
+		return exitConfig{
+			BeaconNodeEndpoints: []string{beaconMock.Address()},
+			LockFilePath:        filepath.Join(root, "op0", "cluster-lock.json"),
+			BeaconNodeTimeout:   10 * time.Second,
+			EscrowDir:           escrowDir,
+			PassphraseFile:      passphraseFile,
+		}
+	}
+
+	t.Run("threshold", func(t *testing.T) {
+		escrowDir := filepath.Join(t.TempDir(), "escrow")
+		for opIdx := range 3 {
+			require.NoError(t, presign(t, opIdx, escrowDir, 0))
+		}
+
+		require.ErrorContains(t, presign(t, 0, escrowDir, 0), "escrow file already exists")
+
+		config := broadcastConfig(escrowDir)
+		var out bytes.Buffer
+		config.JSONOutput = &out
+		require.NoError(t, runBcastPresignedExit(ctx, config))
+
+		var resp struct {
+			Validators []exitResult `json:"validators"`
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &resp))
+		require.Len(t, resp.Validators, 2)
+
+		config = broadcastConfig(escrowDir)
+		config.ValidatorPubkey = lock.Validators[1].PublicKeyHex()
+		config.JSONOutput = &out
+		out.Reset()
+		require.NoError(t, runBcastPresignedExit(ctx, config))
+		require.NoError(t, json.Unmarshal(out.Bytes(), &resp))
+		require.Len(t, resp.Validators, 1)
+		require.EqualValues(t, 1, *resp.Validators[0].ValidatorIndex)
+	})
+
+	t.Run("insufficient", func(t *testing.T) {
+		escrowDir := filepath.Join(t.TempDir(), "escrow")
+		for opIdx := range 2 {
+			require.NoError(t, presign(t, opIdx, escrowDir, 0))
+		}
+
+		require.ErrorContains(t, runBcastPresignedExit(ctx, broadcastConfig(escrowDir)), "insufficient presigned partial exits")
+	})
+
+	t.Run("future epoch", func(t *testing.T) {
+		escrowDir := filepath.Join(t.TempDir(), "escrow")
+		for opIdx := range 3 {
+			require.NoError(t, presign(t, opIdx, escrowDir, 1<<30))
+		}
+
+		require.ErrorContains(t, runBcastPresignedExit(ctx, broadcastConfig(escrowDir)), "presigned exit epoch not reached yet")
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		escrowDir := filepath.Join(t.TempDir(), "escrow")
+		require.NoError(t, presign(t, 0, escrowDir, 0))
+
+		config := broadcastConfig(escrowDir)
+		config.PassphraseFile = filepath.Join(t.TempDir(), "wrong")
+		require.NoError(t, os.WriteFile(config.PassphraseFile, []byte("incorrect horse battery staple"), 0o600))
+
+		require.ErrorContains(t, runBcastPresignedExit(ctx, config), "invalid passphrase")
+
+		_, err := os.Stat(filepath.Join(escrowDir, exitescrow.FileName(1)))
+		require.NoError(t, err)
+	})
+}
@@ -5,6 +5,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
@@ -12,15 +13,19 @@ import (
 	libp2plog "github.com/ipfs/go-log/v2"
 	"github.com/spf13/cobra"
 
+	"github.com/obolnetwork/charon/app"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/memlock"
 	"github.com/obolnetwork/charon/app/obolapi"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/vault"
+	"github.com/obolnetwork/charon/tbls"
 )
 
 func newSignPartialExitCmd(runFunc func(context.Context, exitConfig) error) *cobra.Command {
@@ -39,6 +44,8 @@ func newSignPartialExitCmd(runFunc func(context.Context, exitConfig) error) *cob
 
 			printFlags(cmd.Context(), cmd.Flags())
 
+			config.JSONOutput = jsonWriter(cmd)
+
 			return runFunc(cmd.Context(), config)
 		},
 	}
@@ -65,6 +72,7 @@ func newSignPartialExitCmd(runFunc func(context.Context, exitConfig) error) *cob
 	})
 
 	bindLogFlags(cmd.Flags(), &config.Log)
+	bindVaultFlags(cmd.Flags(), &config.Vault)
 
 	wrapPreRunE(cmd, func(cmd *cobra.Command, _ []string) error {
 		valIdxPresent := cmd.Flags().Lookup(validatorIndex.String()).Changed
@@ -91,9 +99,43 @@ func newSignPartialExitCmd(runFunc func(context.Context, exitConfig) error) *cob
 		return nil
 	})
 
+	supportJSONOutput(cmd)
+
 	return cmd
 }
 
+// adminExit signs and publishes partial exits of the validators, or all validators if none provided,
+// on behalf of the admin API of a running node using its config and the validator keys next to its private key.
+func adminExit(ctx context.Context, conf app.Config, pubkeys []string) error {
+	config := exitConfig{
+		BeaconNodeEndpoints:     conf.BeaconNodeAddrs,
+		BeaconNodeTimeout:       conf.BeaconNodeTimeout,
+		BeaconNodeHeaders:       conf.BeaconNodeHeaders,
+		FallbackBeaconNodeAddrs: conf.FallbackBeaconNodeAddrs,
+		PrivateKeyPath:          conf.PrivKeyFile,
+		ValidatorKeysDir:        filepath.Join(filepath.Dir(conf.PrivKeyFile), "validator_keys"),
+		LockFilePath:            conf.LockFile,
+		PublishAddress:          defaultPublishAddress,
+		PublishTimeout:          defaultPublishTimeout,
+		ExitEpoch:               defaultExitEpoch,
+		testnetConfig:           conf.TestnetConfig,
+		All:                     len(pubkeys) == 0,
+	}
+
+	if config.All {
+		return runSignPartialExit(ctx, config)
+	}
+
+	for _, pubkey := range pubkeys {
+		config.ValidatorPubkey = pubkey
+		if err := runSignPartialExit(ctx, config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func runSignPartialExit(ctx context.Context, config exitConfig) error {
 	// Check if custom testnet configuration is provided.
 	if config.testnetConfig.IsNonZero() {
@@ -111,15 +153,11 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath))
 	}
 
-	rawValKeys, err := keystore.LoadFilesUnordered(config.ValidatorKeysDir)
-	if err != nil {
-		return errors.Wrap(err, "load keystore, check if path exists", z.Str("validator_keys_dir", config.ValidatorKeysDir))
-	}
-
-	valKeys, err := rawValKeys.SequencedKeys()
+	valKeys, err := loadValidatorKeys(ctx, config)
 	if err != nil {
-		return errors.Wrap(err, "load keystore")
+		return err
 	}
+	defer memlock.ProtectKeys(ctx, valKeys)()
 
 	shares, err := keystore.KeysharesToValidatorPubkey(cl, valKeys)
 	if err != nil {
@@ -150,7 +188,7 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 		ctx = log.WithCtx(ctx, z.U64("validator_index", config.ValidatorIndex))
 	}
 	if config.ValidatorPubkey != "" {
-		ctx = log.WithCtx(ctx, z.Str("validator_pubkey", config.ValidatorPubkey))
+		ctx = log.WithCtx(ctx, z.Redact(z.Str("validator_pubkey", config.ValidatorPubkey)))
 	}
 
 	if config.SkipBeaconNodeCheck {
@@ -174,7 +212,12 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 		return errors.Wrap(err, "http POST partial exit message to Obol API")
 	}
 
-	return nil
+	var results []exitResult
+	for _, blob := range exitBlobs {
+		results = append(results, newExitResult(blob.PublicKey, blob.SignedExitMessage))
+	}
+
+	return writeExitResults(config, results)
 }
 
 func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, shares keystore.ValidatorShares) ([]obolapi.ExitBlob, error) {
@@ -187,7 +230,7 @@ func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2
 
 	ourShare, ok := shares[validator]
 	if !ok {
-		return nil, errors.New("validator not present in cluster lock", z.Str("validator", validator.String()))
+		return nil, errors.New("validator not present in cluster lock", z.Redact(z.Str("validator", validator.String())))
 	}
 
 	valIndex, err := fetchValidatorIndex(ctx, config, eth2Cl)
@@ -195,11 +238,11 @@ func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2
 		return nil, errors.Wrap(err, "fetch validator index")
 	}
 
-	log.Info(ctx, "Signing partial exit message for validator", z.Str("validator_public_key", valEth2.String()), z.U64("validator_index", uint64(valIndex)))
+	log.Info(ctx, "Signing partial exit message for validator", z.Redact(z.Str("validator_public_key", valEth2.String())), z.U64("validator_index", uint64(valIndex)))
 
 	exitMsg, err := signExit(ctx, eth2Cl, valIndex, ourShare.Share, eth2p0.Epoch(config.ExitEpoch))
 	if err != nil {
-		return nil, errors.Wrap(err, "sign partial exit message", z.Str("validator_public_key", valEth2.String()), z.U64("validator_index", uint64(valIndex)), z.Int("exit_epoch", int(config.ExitEpoch)))
+		return nil, errors.Wrap(err, "sign partial exit message", z.Redact(z.Str("validator_public_key", valEth2.String())), z.U64("validator_index", uint64(valIndex)), z.Int("exit_epoch", int(config.ExitEpoch)))
 	}
 
 	return []obolapi.ExitBlob{
@@ -215,7 +258,7 @@ func signAllValidatorsExits(ctx context.Context, config exitConfig, eth2Cl eth2w
 	for pk := range shares {
 		eth2PK, err := pk.ToETH2()
 		if err != nil {
-			return nil, errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Str("pub_key", eth2PK.String()))
+			return nil, errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Redact(z.Str("pub_key", eth2PK.String())))
 		}
 		valsEth2 = append(valsEth2, eth2PK)
 	}
@@ -228,7 +271,7 @@ func signAllValidatorsExits(ctx context.Context, config exitConfig, eth2Cl eth2w
 	for _, val := range rawValData.Data {
 		share, ok := shares[core.PubKeyFrom48Bytes(val.Validator.PublicKey)]
 		if !ok {
-			return nil, errors.New("validator public key not found in cluster lock", z.Str("validator_public_key", val.Validator.PublicKey.String()))
+			return nil, errors.New("validator public key not found in cluster lock", z.Redact(z.Str("validator_public_key", val.Validator.PublicKey.String())))
 		}
 		share.Index = int(val.Index)
 		shares[core.PubKeyFrom48Bytes(val.Validator.PublicKey)] = share
@@ -240,18 +283,18 @@ func signAllValidatorsExits(ctx context.Context, config exitConfig, eth2Cl eth2w
 	for pk, share := range shares {
 		exitMsg, err := signExit(ctx, eth2Cl, eth2p0.ValidatorIndex(share.Index), share.Share, eth2p0.Epoch(config.ExitEpoch))
 		if err != nil {
-			return nil, errors.Wrap(err, "sign partial exit message", z.Str("validator_public_key", pk.String()), z.Int("validator_index", share.Index), z.Int("exit_epoch", int(config.ExitEpoch)))
+			return nil, errors.Wrap(err, "sign partial exit message", z.Redact(z.Str("validator_public_key", pk.String())), z.Int("validator_index", share.Index), z.Int("exit_epoch", int(config.ExitEpoch)))
 		}
 		eth2PK, err := pk.ToETH2()
 		if err != nil {
-			return nil, errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Str("core_pubkey", pk.String()))
+			return nil, errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Redact(z.Str("core_pubkey", pk.String())))
 		}
 		exitBlob := obolapi.ExitBlob{
 			PublicKey:         eth2PK.String(),
 			SignedExitMessage: exitMsg,
 		}
 		exitBlobs = append(exitBlobs, exitBlob)
-		log.Info(ctx, "Successfully signed exit message", z.Str("validator_public_key", pk.String()), z.Int("validator_index", share.Index))
+		log.Info(ctx, "Successfully signed exit message", z.Redact(z.Str("validator_public_key", pk.String())), z.Int("validator_index", share.Index))
 	}
 
 	return exitBlobs, nil
@@ -261,7 +304,7 @@ func fetchValidatorBLSPubKey(ctx context.Context, config exitConfig, eth2Cl eth2
 	if config.ValidatorPubkey != "" {
 		valEth2, err := core.PubKey(config.ValidatorPubkey).ToETH2()
 		if err != nil {
-			return eth2p0.BLSPubKey{}, errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Str("core_pubkey", config.ValidatorPubkey))
+			return eth2p0.BLSPubKey{}, errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Redact(z.Str("core_pubkey", config.ValidatorPubkey)))
 		}
 
 		return valEth2, nil
@@ -288,12 +331,12 @@ func fetchValidatorIndex(ctx context.Context, config exitConfig, eth2Cl eth2wrap
 
 	valEth2, err := core.PubKey(config.ValidatorPubkey).ToETH2()
 	if err != nil {
-		return 0, errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Str("core_pubkey", config.ValidatorPubkey))
+		return 0, errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Redact(z.Str("core_pubkey", config.ValidatorPubkey)))
 	}
 
 	rawValData, err := queryBeaconForValidator(ctx, eth2Cl, []eth2p0.BLSPubKey{valEth2}, nil)
 	if err != nil {
-		return 0, errors.Wrap(err, "fetch validator index from beacon", z.Str("beacon_address", eth2Cl.Address()), z.Str("validator_pubkey", valEth2.String()))
+		return 0, errors.Wrap(err, "fetch validator index from beacon", z.Str("beacon_address", eth2Cl.Address()), z.Redact(z.Str("validator_pubkey", valEth2.String())))
 	}
 
 	for _, val := range rawValData.Data {
@@ -302,7 +345,7 @@ func fetchValidatorIndex(ctx context.Context, config exitConfig, eth2Cl eth2wrap
 		}
 	}
 
-	return 0, errors.New("validator public key not found in beacon node response", z.Str("beacon_address", eth2Cl.Address()), z.Str("validator_pubkey", valEth2.String()), z.Any("raw_response", rawValData))
+	return 0, errors.New("validator public key not found in beacon node response", z.Str("beacon_address", eth2Cl.Address()), z.Redact(z.Str("validator_pubkey", valEth2.String())), z.Any("raw_response", rawValData))
 }
 
 func queryBeaconForValidator(ctx context.Context, eth2Cl eth2wrap.Client, pubKeys []eth2p0.BLSPubKey, indices []eth2p0.ValidatorIndex) (*eth2api.Response[map[eth2p0.ValidatorIndex]*eth2v1.Validator], error) {
@@ -319,3 +362,27 @@ func queryBeaconForValidator(ctx context.Context, eth2Cl eth2wrap.Client, pubKey
 
 	return rawValData, nil
 }
+
+// loadValidatorKeys returns the validator key shares from vault if enabled, otherwise from the validator keys directory.
+func loadValidatorKeys(ctx context.Context, config exitConfig) ([]tbls.PrivateKey, error) {
+	if config.Vault.Enabled() {
+		cl, err := vault.New(ctx, config.Vault)
+		if err != nil {
+			return nil, errors.Wrap(err, "authenticate with vault")
+		}
+
+		return cl.LoadKeys(ctx)
+	}
+
+	rawValKeys, err := keystore.LoadFilesUnordered(config.ValidatorKeysDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "load keystore, check if path exists", z.Str("validator_keys_dir", config.ValidatorKeysDir))
+	}
+
+	valKeys, err := rawValKeys.SequencedKeys()
+	if err != nil {
+		return nil, errors.Wrap(err, "load keystore")
+	}
+
+	return valKeys, nil
+}
@@ -0,0 +1,226 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+func newExitStatusCmd(runFunc func(context.Context, exitConfig) error) *cobra.Command {
+	var config exitConfig
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the partial exit progress of distributed validators",
+		Long:  `Shows which operators submitted partial exit messages for distributed validators to the remote API, and whether the threshold required for a full exit was reached.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			config.JSONOutput = jsonWriter(cmd)
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindExitFlags(cmd, &config, []exitCLIFlag{
+		{publishAddress, false},
+		{lockFilePath, false},
+		{validatorPubkey, false},
+		{all, false},
+		{publishTimeout, false},
+		{testnetName, false},
+		{testnetForkVersion, false},
+		{testnetChainID, false},
+		{testnetGenesisTimestamp, false},
+		{testnetCapellaHardFork, false},
+	})
+
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	wrapPreRunE(cmd, func(cmd *cobra.Command, _ []string) error {
+		valPubkPresent := cmd.Flags().Lookup(validatorPubkey.String()).Changed
+
+		if !valPubkPresent && !config.All {
+			//nolint:revive,perfsprint // we use our own version of the errors package; keep consistency with other checks.
+			return errors.New(fmt.Sprintf("%s must be specified when showing the status of a single validator.", validatorPubkey.String()))
+		}
+
+		if config.All && valPubkPresent {
+			//nolint:revive // we use our own version of the errors package.
+			return errors.New(fmt.Sprintf("%s should not be specified when %s is, as it is obsolete and misleading.", validatorPubkey.String(), all.String()))
+		}
+
+		return nil
+	})
+
+	supportJSONOutput(cmd)
+
+	return cmd
+}
+
+// exitProgress is the partial exit progress of a validator.
+type exitProgress struct {
+	Validator string
+	// Status is nil if no partial exits were submitted for the validator.
+	Status *obolapi.ExitStatus
+	// Submitted and Missing are the names of the operators that did and didn't submit a partial exit.
+	Submitted []string
+	Missing   []string
+	Threshold int
+}
+
+// ThresholdReached returns true if enough partial exits were submitted to aggregate a full exit.
+func (p exitProgress) ThresholdReached() bool {
+	return len(p.Submitted) >= p.Threshold
+}
+
+// Result returns the partial exit progress as an exit result.
+func (p exitProgress) Result() exitResult {
+	resp := exitResult{
+		PublicKey: p.Validator,
+		Submitted: p.Submitted,
+		Missing:   p.Missing,
+		Threshold: p.Threshold,
+	}
+
+	if p.Status != nil {
+		index, epoch := uint64(p.Status.ValidatorIndex), uint64(p.Status.Epoch)
+		resp.ValidatorIndex, resp.ExitEpoch = &index, &epoch
+	}
+
+	return resp
+}
+
+func runExitStatus(ctx context.Context, config exitConfig) error {
+	// Check if custom testnet configuration is provided.
+	if config.testnetConfig.IsNonZero() {
+		// Add testnet config to supported networks.
+		eth2util.AddTestNetwork(config.testnetConfig)
+	}
+
+	cl, err := loadClusterManifest("", config.LockFilePath)
+	if err != nil {
+		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath))
+	}
+
+	oAPI, err := obolapi.New(config.PublishAddress, obolapi.WithTimeout(config.PublishTimeout))
+	if err != nil {
+		return errors.Wrap(err, "create Obol API client", z.Str("publish_address", config.PublishAddress))
+	}
+
+	validators, err := exitValidators(config, cl)
+	if err != nil {
+		return err
+	}
+
+	var results []exitResult
+	for _, validator := range validators {
+		progress, err := fetchExitProgress(ctx, oAPI, cl, validator)
+		if err != nil {
+			return err
+		}
+
+		logExitProgress(ctx, progress)
+
+		results = append(results, progress.Result())
+	}
+
+	return writeExitResults(config, results)
+}
+
+// exitValidators returns the 0x-prefixed public keys of the validators selected by the config.
+func exitValidators(config exitConfig, cl *manifestpb.Cluster) ([]string, error) {
+	if !config.All {
+		if _, err := core.PubKey(config.ValidatorPubkey).Bytes(); err != nil {
+			return nil, errors.Wrap(err, "convert validator pubkey to bytes", z.Redact(z.Str("validator_public_key", config.ValidatorPubkey)))
+		}
+
+		return []string{config.ValidatorPubkey}, nil
+	}
+
+	var resp []string
+	for _, validator := range cl.GetValidators() {
+		resp = append(resp, fmt.Sprintf("0x%x", validator.GetPublicKey()))
+	}
+
+	return resp, nil
+}
+
+// fetchExitProgress returns the partial exit progress of the validator from the Obol API.
+func fetchExitProgress(ctx context.Context, oAPI obolapi.Client, cl *manifestpb.Cluster, validator string) (exitProgress, error) {
+	peers, err := manifest.ClusterPeers(cl)
+	if err != nil {
+		return exitProgress{}, err
+	}
+
+	resp := exitProgress{
+		Validator: validator,
+		Threshold: int(cl.GetThreshold()),
+	}
+
+	status, err := oAPI.GetExitStatus(ctx, validator, cl.GetInitialMutationHash())
+	if errors.Is(err, obolapi.ErrNoExit) {
+		for _, p := range peers {
+			resp.Missing = append(resp.Missing, p.Name)
+		}
+
+		return resp, nil
+	} else if err != nil {
+		return exitProgress{}, errors.Wrap(err, "fetch exit status from Obol API", z.Redact(z.Str("validator_public_key", validator)))
+	}
+
+	resp.Status = &status
+	for _, p := range peers {
+		if slices.Contains(status.ShareIndices, uint64(p.ShareIdx())) {
+			resp.Submitted = append(resp.Submitted, p.Name)
+		} else {
+			resp.Missing = append(resp.Missing, p.Name)
+		}
+	}
+
+	return resp, nil
+}
+
+// logExitProgress logs the partial exit progress of a validator.
+func logExitProgress(ctx context.Context, progress exitProgress) {
+	ctx = log.WithCtx(ctx, z.Redact(z.Str("validator_public_key", progress.Validator)))
+
+	if progress.Status == nil {
+		log.Info(ctx, "No partial exits submitted yet", z.Int("threshold", progress.Threshold))
+		return
+	}
+
+	fields := []z.Field{
+		z.U64("exit_epoch", uint64(progress.Status.Epoch)),
+		z.U64("validator_index", uint64(progress.Status.ValidatorIndex)),
+		z.Str("progress", fmt.Sprintf("%d/%d", len(progress.Submitted), progress.Threshold)),
+		z.Any("submitted", progress.Submitted),
+		z.Any("missing", progress.Missing),
+	}
+
+	if progress.ThresholdReached() {
+		log.Info(ctx, "Partial exit threshold reached", fields...)
+	} else {
+		log.Info(ctx, "Waiting for partial exits", fields...)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+	"github.com/obolnetwork/charon/testutil/obolapimock"
+)
+
+func Test_runExitStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	lock, configFunc, publishAddr := setupExitStatusTest(t)
+	config := configFunc(0)
+
+	dag, err := manifest.NewDAGFromLockForT(t, lock)
+	require.NoError(t, err)
+	cl, err := manifest.Materialise(dag)
+	require.NoError(t, err)
+
+	oAPI, err := obolapi.New(publishAddr)
+	require.NoError(t, err)
+
+	validator := lock.Validators[0].PublicKeyHex()
+
+	progress, err := fetchExitProgress(ctx, oAPI, cl, validator)
+	require.NoError(t, err)
+	require.Nil(t, progress.Status)
+	require.Len(t, progress.Missing, len(lock.Operators))
+	require.False(t, progress.ThresholdReached())
+
+	for opIdx := range lock.Threshold {
+		config := configFunc(opIdx)
+		config.ValidatorPubkey = validator
+		require.NoError(t, runSignPartialExit(ctx, config))
+
+		progress, err := fetchExitProgress(ctx, oAPI, cl, validator)
+		require.NoError(t, err)
+		require.NotNil(t, progress.Status)
+		require.EqualValues(t, config.ExitEpoch, progress.Status.Epoch)
+		require.Len(t, progress.Submitted, opIdx+1)
+		require.Len(t, progress.Missing, len(lock.Operators)-opIdx-1)
+		require.Equal(t, opIdx+1 == lock.Threshold, progress.ThresholdReached())
+	}
+
+	config.All = true
+	require.NoError(t, runExitStatus(ctx, config))
+
+	config.All = false
+	config.ValidatorPubkey = "bad"
+	require.ErrorContains(t, runExitStatus(ctx, config), "convert validator pubkey to bytes")
+}
+
+func Test_waitAndBcastExits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("threshold and epoch reached", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		lock, configFunc, _ := setupExitStatusTest(t)
+
+		for opIdx := range lock.Threshold {
+			config := configFunc(opIdx)
+			config.All = true
+			require.NoError(t, runSignPartialExit(ctx, config))
+		}
+
+		config := configFunc(0)
+		config.All = true
+		config.Wait = true
+		require.NoError(t, runBcastFullExit(ctx, config))
+	})
+
+	t.Run("scheduled for future epoch", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		lock, configFunc, _ := setupExitStatusTest(t)
+
+		for opIdx := range lock.Threshold {
+			config := configFunc(opIdx)
+			config.ValidatorPubkey = lock.Validators[0].PublicKeyHex()
+			config.ExitEpoch = 1 << 40
+			require.NoError(t, runSignPartialExit(ctx, config))
+		}
+
+		config := configFunc(0)
+		config.ValidatorPubkey = lock.Validators[0].PublicKeyHex()
+		config.Wait = true
+		require.ErrorContains(t, runBcastFullExit(ctx, config), "waiting for exits")
+	})
+
+	t.Run("threshold not reached", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		lock, configFunc, _ := setupExitStatusTest(t)
+
+		config := configFunc(0)
+		config.ValidatorPubkey = lock.Validators[0].PublicKeyHex()
+		require.NoError(t, runSignPartialExit(ctx, config))
+
+		config.Wait = true
+		require.ErrorContains(t, runBcastFullExit(ctx, config), "waiting for exits")
+	})
+}
+
+// setupExitStatusTest returns a cluster lock, a function returning the exit config of each operator and
+// the Obol API mock address.
+func setupExitStatusTest(t *testing.T) (cluster.Lock, func(opIdx int) exitConfig, string) {
+	t.Helper()
+
+	const (
+		valAmt      = 2
+		operatorAmt = 4
+	)
+
+	random := rand.New(rand.NewSource(int64(0)))
+
+	lock, enrs, keyShares := cluster.NewForT(t, valAmt, operatorAmt-1, operatorAmt, 0, random)
+
+	operatorShares := make([][]tbls.PrivateKey, operatorAmt)
+	for opIdx := range operatorAmt {
+		for _, share := range keyShares {
+			operatorShares[opIdx] = append(operatorShares[opIdx], share[opIdx])
+		}
+	}
+
+	mBytes, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	validatorSet := beaconmock.ValidatorSet{}
+	for idx, v := range lock.Validators {
+		validatorSet[eth2p0.ValidatorIndex(idx)] = &eth2v1.Validator{
+			Index:   eth2p0.ValidatorIndex(idx),
+			Balance: 42,
+			Status:  eth2v1.ValidatorStateActiveOngoing,
+			Validator: &eth2p0.Validator{
+				PublicKey:             eth2p0.BLSPubKey(v.PubKey),
+				WithdrawalCredentials: testutil.RandomBytes32(),
+			},
+		}
+	}
+
+	beaconMock, err := beaconmock.New(
+		beaconmock.WithValidatorSet(validatorSet),
+		beaconmock.WithEndpoint("/eth/v1/beacon/pool/voluntary_exits", ""),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, beaconMock.Close())
+	})
+
+	eth2Cl, err := eth2Client(context.Background(), []string{}, map[string]string{}, []string{beaconMock.Address()}, 10*time.Second, [4]byte(lock.ForkVersion))
+	require.NoError(t, err)
+
+	handler, addLockFiles := obolapimock.MockServer(false, eth2Cl)
+	srv := httptest.NewServer(handler)
+	addLockFiles(lock)
+	t.Cleanup(srv.Close)
+
+	root := t.TempDir()
+	writeAllLockData(t, root, operatorAmt, enrs, operatorShares, mBytes)
+
+	configFunc := func(opIdx int) exitConfig {
+		baseDir := filepath.Join(root, fmt.Sprintf("op%d", opIdx))
+
+		return exitConfig{
+			BeaconNodeEndpoints: []string{beaconMock.Address()},
+			PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
+			ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
+			LockFilePath:        filepath.Join(baseDir, "cluster-lock.json"),
+			PublishAddress:      srv.URL,
+			ExitEpoch:           194048,
+			BeaconNodeTimeout:   30 * time.Second,
+			PublishTimeout:      10 * time.Second,
+		}
+	}
+
+	return lock, configFunc, srv.URL
+}
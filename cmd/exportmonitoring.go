@@ -0,0 +1,81 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/monitoring"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	alertRulesFile = "charon-alert-rules.yml"
+	dashboardFile  = "charon-dashboard.json"
+)
+
+type exportMonitoringConfig struct {
+	OutputDir string
+}
+
+func newExportMonitoringCmd(runFunc func(io.Writer, exportMonitoringConfig) error) *cobra.Command {
+	var config exportMonitoringConfig
+
+	cmd := &cobra.Command{
+		Use:   "export-monitoring",
+		Short: "Export recommended Prometheus alert rules and Grafana dashboard",
+		Long: `Writes the recommended Prometheus alert rules and a Grafana dashboard matching the metrics emitted by this charon version.
+Re-export them after upgrading charon so that dashboards and alerts never drift from the emitted metrics.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.OutputDir, "output-dir", "monitoring", "The directory to write the alert rules and dashboard files to.")
+
+	return cmd
+}
+
+func runExportMonitoring(w io.Writer, config exportMonitoringConfig) error {
+	rules, err := monitoring.AlertRules()
+	if err != nil {
+		return err
+	}
+
+	dashboard, err := monitoring.Dashboard()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0o755); err != nil {
+		return errors.Wrap(err, "create output directory", z.Str("dir", config.OutputDir))
+	}
+
+	files := []struct {
+		Name    string
+		Content []byte
+	}{
+		{Name: alertRulesFile, Content: rules},
+		{Name: dashboardFile, Content: dashboard},
+	}
+
+	for _, file := range files {
+		path := filepath.Join(config.OutputDir, file.Name)
+		if err := os.WriteFile(path, file.Content, 0o644); err != nil { //nolint:gosec // Monitoring config isn't sensitive.
+			return errors.Wrap(err, "write file", z.Str("path", path))
+		}
+
+		if _, err := fmt.Fprintf(w, "Wrote %s\n", path); err != nil {
+			return errors.Wrap(err, "write output")
+		}
+	}
+
+	return nil
+}
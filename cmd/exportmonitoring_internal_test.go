@@ -0,0 +1,26 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunExportMonitoring(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "monitoring")
+
+	var buf bytes.Buffer
+	require.NoError(t, runExportMonitoring(&buf, exportMonitoringConfig{OutputDir: dir}))
+
+	for _, file := range []string{alertRulesFile, dashboardFile} {
+		b, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err)
+		require.NotEmpty(t, b)
+		require.Contains(t, buf.String(), filepath.Join(dir, file))
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+type featuresConfig struct {
+	MonitoringAddr string
+	Timeout        time.Duration
+	JSON           bool
+}
+
+func newFeaturesCmd(runFunc func(context.Context, io.Writer, featuresConfig) error) *cobra.Command {
+	var config featuresConfig
+
+	cmd := &cobra.Command{
+		Use:   "features",
+		Short: "Print the feature set state and the features enabled by each peer",
+		Long: `Queries the monitoring API of the local charon node for the state of its features and the features its peers
+enabled via --feature-set flags, as shared via P2P. Negotiated features affect cluster-wide behaviour, so they are only
+active once a quorum of nodes enabled them.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			config.JSON = isJSONOutput(cmd)
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.MonitoringAddr, "monitoring-address", "http://127.0.0.1:3620", "The address of the local charon node's monitoring API.")
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 10*time.Second, "Timeout for querying the monitoring API.")
+
+	supportJSONOutput(cmd)
+
+	return cmd
+}
+
+func runFeatures(ctx context.Context, w io.Writer, config featuresConfig) error {
+	var status app.FeaturesStatus
+	if err := getMonitoringJSON(ctx, config.MonitoringAddr, config.Timeout, "features", nil, &status); err != nil {
+		return err
+	}
+
+	if config.JSON {
+		return writeJSON(w, status)
+	}
+
+	return writeFeatures(w, status)
+}
+
+// writeFeatures writes the features as a table with one row per feature and a column per node
+// indicating whether it enabled the feature, followed by a legend of the nodes.
+func writeFeatures(w io.Writer, status app.FeaturesStatus) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := []string{"FEATURE", "STATUS", "NEGOTIATED", "LOCAL", "ACTIVE", "SUPPORT"}
+	for i := range status.Nodes {
+		header = append(header, fmt.Sprintf("N%d", i))
+	}
+	_, _ = fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, feature := range status.Features {
+		row := []string{
+			string(feature.Feature),
+			feature.Status,
+			yesNo(feature.Negotiated),
+			yesNo(feature.EnabledLocally),
+			yesNo(feature.Enabled),
+			fmt.Sprintf("%d/%d", len(feature.Supported), len(status.Nodes)),
+		}
+
+		for i, node := range status.Nodes {
+			supported := "?"
+			if node.Reported {
+				supported = yesNo(slices.Contains(feature.Supported, i))
+			}
+			row = append(row, supported)
+		}
+
+		_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	_, _ = fmt.Fprintln(tw)
+	_, _ = fmt.Fprintln(tw, "NODE\tPEER\tNICKNAME")
+
+	for i, node := range status.Nodes {
+		name := node.Peer
+		if node.Self {
+			name += " (self)"
+		}
+
+		_, _ = fmt.Fprintf(tw, "N%d\t%s\t%s\n", i, name, orUnknown(node.Nickname))
+	}
+
+	_, _ = fmt.Fprintf(tw, "\nNegotiated features are active once enabled by a quorum of %d nodes.\n", status.Quorum)
+
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, "write features")
+	}
+
+	return nil
+}
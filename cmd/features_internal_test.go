@@ -0,0 +1,59 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/featureset"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+//go:generate go test . -run=TestFeatures -update
+
+func TestFeatures(t *testing.T) {
+	status := app.FeaturesStatus{
+		Quorum: 3,
+		Nodes: []app.NodeFeatures{
+			{Peer: "happy-face", Nickname: "alice", Self: true, Reported: true},
+			{Peer: "pleasant-state", Reported: true},
+			{Peer: "ashamed-family", Reported: true},
+			{Peer: "frantic-mirror"},
+		},
+		Features: []app.FeatureStatus{
+			{
+				State:     featureset.State{Feature: featureset.EagerDoubleLinear, Status: "stable", EnabledLocally: true, Enabled: true},
+				Supported: []int{0, 1, 2},
+			},
+			{
+				State:     featureset.State{Feature: featureset.Linear, Status: "alpha", Negotiated: true, EnabledLocally: true},
+				Supported: []int{0, 2},
+			},
+			{
+				State:     featureset.State{Feature: featureset.MockAlpha, Status: "alpha"},
+				Supported: []int{},
+			},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/features", r.URL.Path)
+		require.NoError(t, json.NewEncoder(w).Encode(status))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	err := runFeatures(context.Background(), &buf, featuresConfig{MonitoringAddr: srv.URL, Timeout: time.Second})
+	require.NoError(t, err)
+
+	testutil.RequireGoldenBytes(t, buf.Bytes())
+}
@@ -0,0 +1,305 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+)
+
+type fetchClusterConfig struct {
+	Source      string
+	Lock        bool
+	Hash        string
+	PublishAddr string
+	OutputFile  string
+	Update      bool
+	Log         log.Config
+}
+
+func newFetchClusterCmd(runFunc func(context.Context, io.Writer, fetchClusterConfig) error) *cobra.Command {
+	var config fetchClusterConfig
+
+	cmd := &cobra.Command{
+		Use:   "fetch-cluster",
+		Short: "Fetch and pin a cluster definition or lock",
+		Long: `Fetches a cluster definition or lock by URL, or by its hash from the remote API, verifies its hashes and
+signatures and pins it to the output file. If the output file already exists and the fetched document differs, the
+differences are printed and the pinned file is only replaced when --update is specified.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.Source, "source", "", "The URL of the cluster document, or its 0x-prefixed config hash (definition) or lock hash (lock) to fetch it from the remote API.")
+	cmd.Flags().BoolVar(&config.Lock, "lock", false, "Fetch a cluster lock instead of a cluster definition.")
+	cmd.Flags().StringVar(&config.Hash, "hash", "", "The expected 0x-prefixed config hash (definition) or lock hash (lock) of a document fetched by URL.")
+	cmd.Flags().StringVar(&config.PublishAddr, "publish-address", "https://api.obol.tech/v1", "The URL of the remote API.")
+	cmd.Flags().StringVar(&config.OutputFile, "output-file", "", "The path the fetched document is pinned to. Defaults to .charon/cluster-definition.json or .charon/cluster-lock.json.")
+	cmd.Flags().BoolVar(&config.Update, "update", false, "Replace the pinned output file if the fetched document differs.")
+
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	mustMarkFlagRequired(cmd, "source")
+
+	return cmd
+}
+
+func runFetchCluster(ctx context.Context, w io.Writer, conf fetchClusterConfig) error {
+	if conf.OutputFile == "" {
+		conf.OutputFile = filepath.Join(".charon", "cluster-definition.json")
+		if conf.Lock {
+			conf.OutputFile = filepath.Join(".charon", "cluster-lock.json")
+		}
+	}
+
+	var expectedHash []byte
+	if conf.Hash != "" {
+		var err error
+		expectedHash, err = parseClusterHash(conf.Hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	fetched, hash, err := fetchClusterDoc(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	if expectedHash != nil && !bytes.Equal(expectedHash, hash) {
+		return errors.New("fetched cluster document hash mismatch",
+			z.Str("expected", conf.Hash), z.Str("actual", fmt.Sprintf("%#x", hash)))
+	}
+
+	pinned, err := os.ReadFile(conf.OutputFile)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "read pinned file")
+	} else if err == nil {
+		if bytes.Equal(pinned, fetched) {
+			log.Info(ctx, "Pinned cluster document unchanged", z.Str("path", conf.OutputFile), z.Str("hash", fmt.Sprintf("%#x", hash)))
+			return nil
+		}
+
+		diffs, err := jsonDiff(pinned, fetched)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(w, "Fetched cluster document differs from %s:\n%s\n", conf.OutputFile, strings.Join(diffs, "\n")); err != nil {
+			return errors.Wrap(err, "write diff")
+		}
+
+		if !conf.Update {
+			return errors.New("fetched cluster document differs from pinned file, run with --update to replace it", z.Str("path", conf.OutputFile))
+		}
+
+		// Pinned files are read-only.
+		if err := os.Remove(conf.OutputFile); err != nil {
+			return errors.Wrap(err, "remove pinned file")
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(conf.OutputFile), 0o755); err != nil {
+		return errors.Wrap(err, "create output directory")
+	}
+
+	//nolint:gosec // File needs to be read-only for everybody
+	if err := os.WriteFile(conf.OutputFile, fetched, 0o444); err != nil {
+		return errors.Wrap(err, "write pinned file")
+	}
+
+	log.Info(ctx, "Pinned cluster document", z.Str("path", conf.OutputFile), z.Str("hash", fmt.Sprintf("%#x", hash)))
+
+	return nil
+}
+
+// fetchClusterDoc returns the verified cluster definition or lock as indented json and its config or lock hash.
+func fetchClusterDoc(ctx context.Context, conf fetchClusterConfig) ([]byte, []byte, error) {
+	var (
+		fromAPI  = !validURI(conf.Source)
+		hash     []byte
+		oAPI     obolapi.Client
+		err      error
+		document any
+	)
+	if fromAPI {
+		hash, err = parseClusterHash(conf.Source)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "source is neither a http(s) URL nor a hash")
+		}
+
+		oAPI, err = obolapi.New(conf.PublishAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if conf.Lock {
+		var lock cluster.Lock
+		if fromAPI {
+			lock, err = oAPI.GetLock(ctx, hash)
+		} else {
+			lock, err = cluster.FetchLock(ctx, conf.Source)
+		}
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "fetch lock")
+		}
+
+		if err := lock.VerifyHashes(); err != nil {
+			return nil, nil, errors.Wrap(err, "cluster lock hash verification failed")
+		}
+		if err := lock.VerifySignatures(); err != nil {
+			return nil, nil, errors.Wrap(err, "cluster lock signature verification failed")
+		}
+
+		document, hash = lock, lock.LockHash
+	} else {
+		var def cluster.Definition
+		if fromAPI {
+			def, err = oAPI.GetDefinition(ctx, hash)
+		} else {
+			def, err = cluster.FetchDefinition(ctx, conf.Source)
+		}
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "fetch definition")
+		}
+
+		if err := def.VerifyHashes(); err != nil {
+			return nil, nil, errors.Wrap(err, "cluster definition hash verification failed")
+		}
+		if err := def.VerifySignatures(); err != nil {
+			return nil, nil, errors.Wrap(err, "cluster definition signature verification failed")
+		}
+
+		document, hash = def, def.ConfigHash
+	}
+
+	b, err := json.MarshalIndent(document, "", " ")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "marshal cluster document")
+	}
+
+	return b, hash, nil
+}
+
+// parseClusterHash returns the bytes of a 0x-prefixed 32 byte hash.
+func parseClusterHash(hash string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(hash, "0x"))
+	if err != nil || !strings.HasPrefix(hash, "0x") || len(b) != 32 {
+		return nil, errors.New("invalid 0x-prefixed 32 byte hash", z.Str("hash", hash))
+	}
+
+	return b, nil
+}
+
+// jsonDiff returns the sorted differences between two json documents, one per changed field path.
+func jsonDiff(prev, next []byte) ([]string, error) {
+	var prevVal, nextVal any
+	if err := json.Unmarshal(prev, &prevVal); err != nil {
+		return nil, errors.Wrap(err, "unmarshal previous json")
+	}
+	if err := json.Unmarshal(next, &nextVal); err != nil {
+		return nil, errors.Wrap(err, "unmarshal next json")
+	}
+
+	var resp []string
+	diffValues("", prevVal, nextVal, &resp)
+	sort.Strings(resp)
+
+	return resp, nil
+}
+
+// diffValues appends the differences between the json values at the field path to diffs.
+func diffValues(path string, prev, next any, diffs *[]string) {
+	prevMap, prevOK := prev.(map[string]any)
+	nextMap, nextOK := next.(map[string]any)
+	if prevOK && nextOK {
+		keys := make(map[string]bool)
+		for k := range prevMap {
+			keys[k] = true
+		}
+		for k := range nextMap {
+			keys[k] = true
+		}
+
+		for k := range keys {
+			p, pOK := prevMap[k]
+			n, nOK := nextMap[k]
+			switch {
+			case !pOK:
+				*diffs = append(*diffs, fmt.Sprintf("+ %s: %s", joinPath(path, k), jsonString(n)))
+			case !nOK:
+				*diffs = append(*diffs, fmt.Sprintf("- %s: %s", joinPath(path, k), jsonString(p)))
+			default:
+				diffValues(joinPath(path, k), p, n, diffs)
+			}
+		}
+
+		return
+	}
+
+	prevList, prevOK := prev.([]any)
+	nextList, nextOK := next.([]any)
+	if prevOK && nextOK {
+		for i := range max(len(prevList), len(nextList)) {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(prevList):
+				*diffs = append(*diffs, fmt.Sprintf("+ %s: %s", elemPath, jsonString(nextList[i])))
+			case i >= len(nextList):
+				*diffs = append(*diffs, fmt.Sprintf("- %s: %s", elemPath, jsonString(prevList[i])))
+			default:
+				diffValues(elemPath, prevList[i], nextList[i], diffs)
+			}
+		}
+
+		return
+	}
+
+	if prevStr, nextStr := jsonString(prev), jsonString(next); prevStr != nextStr {
+		*diffs = append(*diffs, fmt.Sprintf("~ %s: %s -> %s", path, prevStr, nextStr))
+	}
+}
+
+// joinPath returns the field path of the key within the parent path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// jsonString returns the compact json representation of the value.
+func jsonString(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,129 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/testutil/obolapimock"
+)
+
+func TestFetchCluster(t *testing.T) {
+	ctx := context.Background()
+
+	lock, _, _ := cluster.NewForT(t, 1, 3, 4, 0, rand.New(rand.NewSource(0)))
+	other, _, _ := cluster.NewForT(t, 1, 3, 4, 1, rand.New(rand.NewSource(1)))
+
+	handler, addLockFiles := obolapimock.MockServer(false, nil)
+	addLockFiles(lock)
+	addLockFiles(other)
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+
+	t.Run("definition by hash", func(t *testing.T) {
+		conf := fetchClusterConfig{
+			Source:      fmt.Sprintf("%#x", lock.ConfigHash),
+			PublishAddr: srv.URL,
+			OutputFile:  filepath.Join(dir, "cluster-definition.json"),
+		}
+		require.NoError(t, runFetchCluster(ctx, new(bytes.Buffer), conf))
+
+		b, err := os.ReadFile(conf.OutputFile)
+		require.NoError(t, err)
+
+		var def cluster.Definition
+		require.NoError(t, json.Unmarshal(b, &def))
+		require.Equal(t, lock.Definition.DefinitionHash, def.DefinitionHash)
+
+		// Fetching again leaves the pinned file unchanged.
+		require.NoError(t, runFetchCluster(ctx, new(bytes.Buffer), conf))
+	})
+
+	t.Run("lock by hash", func(t *testing.T) {
+		conf := fetchClusterConfig{
+			Source:      fmt.Sprintf("%#x", lock.LockHash),
+			Lock:        true,
+			PublishAddr: srv.URL,
+			OutputFile:  filepath.Join(dir, "cluster-lock.json"),
+		}
+		require.NoError(t, runFetchCluster(ctx, new(bytes.Buffer), conf))
+
+		// A changed remote lock isn't pinned without --update.
+		conf.Source = fmt.Sprintf("%#x", other.LockHash)
+		var diff bytes.Buffer
+		err := runFetchCluster(ctx, &diff, conf)
+		require.ErrorContains(t, err, "fetched cluster document differs from pinned file")
+		require.Contains(t, diff.String(), fmt.Sprintf("~ lock_hash: \"%#x\" -> \"%#x\"", lock.LockHash, other.LockHash))
+
+		conf.Update = true
+		require.NoError(t, runFetchCluster(ctx, new(bytes.Buffer), conf))
+
+		b, err := os.ReadFile(conf.OutputFile)
+		require.NoError(t, err)
+
+		var pinned cluster.Lock
+		require.NoError(t, json.Unmarshal(b, &pinned))
+		require.Equal(t, other.LockHash, pinned.LockHash)
+	})
+
+	t.Run("unknown hash", func(t *testing.T) {
+		conf := fetchClusterConfig{
+			Source:      fmt.Sprintf("%#x", make([]byte, 32)),
+			Lock:        true,
+			PublishAddr: srv.URL,
+			OutputFile:  filepath.Join(dir, "unknown.json"),
+		}
+		require.ErrorContains(t, runFetchCluster(ctx, new(bytes.Buffer), conf), "cluster document not found")
+	})
+
+	t.Run("lock by url", func(t *testing.T) {
+		lockSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			require.NoError(t, json.NewEncoder(w).Encode(lock))
+		}))
+		t.Cleanup(lockSrv.Close)
+
+		conf := fetchClusterConfig{
+			Source:     lockSrv.URL,
+			Lock:       true,
+			Hash:       fmt.Sprintf("%#x", other.LockHash),
+			OutputFile: filepath.Join(dir, "url", "cluster-lock.json"),
+		}
+		require.ErrorContains(t, runFetchCluster(ctx, new(bytes.Buffer), conf), "fetched cluster document hash mismatch")
+
+		conf.Hash = fmt.Sprintf("%#x", lock.LockHash)
+		require.NoError(t, runFetchCluster(ctx, new(bytes.Buffer), conf))
+	})
+
+	t.Run("invalid source", func(t *testing.T) {
+		conf := fetchClusterConfig{Source: "cluster-lock.json"}
+		require.ErrorContains(t, runFetchCluster(ctx, new(bytes.Buffer), conf), "source is neither a http(s) URL nor a hash")
+	})
+}
+
+func TestJSONDiff(t *testing.T) {
+	diffs, err := jsonDiff(
+		[]byte(`{"a":1,"b":{"c":"x","d":[1,2]},"e":true}`),
+		[]byte(`{"a":1,"b":{"c":"y","d":[1]},"f":null}`),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"+ f: null",
+		"- b.d[1]: 2",
+		"- e: true",
+		`~ b.c: "x" -> "y"`,
+	}, diffs)
+}
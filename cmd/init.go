@@ -0,0 +1,297 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+type initConfig struct {
+	OutputFile string
+}
+
+func newInitCmd(runFunc func(context.Context, io.Reader, io.Writer, initConfig) error) *cobra.Command {
+	var config initConfig
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Set up a new charon node interactively",
+		Long: `Walks a new operator through creating the data directory and ENR private key, selecting relays, configuring
+beacon nodes and optionally joining a cluster definition, then writes a validated config file used by 'charon run'.
+Press enter to accept the default shown in brackets.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.OutputFile, "output-file", defaultConfigFilename+".yaml", "The path of the config file to write. The default is read by charon commands run in the same directory.")
+
+	return cmd
+}
+
+// runInit runs the setup wizard, prompting the operator on r and writing the config file.
+func runInit(ctx context.Context, r io.Reader, w io.Writer, config initConfig) error {
+	prompt := newPrompter(r, w)
+
+	_, _ = fmt.Fprintln(w, "Welcome to charon! This wizard sets up a new charon node.")
+
+	if _, err := os.Stat(config.OutputFile); err == nil {
+		overwrite, err := prompt.Confirm(fmt.Sprintf("Config file %s already exists, overwrite it?", config.OutputFile), false)
+		if err != nil {
+			return err
+		} else if !overwrite {
+			return errors.New("config file already exists", z.Str("path", config.OutputFile))
+		}
+	}
+
+	// Step 1: Data directory.
+	_, _ = fmt.Fprintln(w, "\n[1/5] Data directory")
+	dataDir, err := prompt.Ask("Directory to store the private key and cluster files", ".charon")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return errors.Wrap(err, "create data directory", z.Str("dir", dataDir))
+	}
+
+	// Step 2: ENR private key.
+	_, _ = fmt.Fprintln(w, "\n[2/5] ENR private key")
+	key, err := p2p.LoadPrivKey(dataDir)
+	if err == nil {
+		_, _ = fmt.Fprintf(w, "Using existing ENR private key: %s\n", p2p.KeyPath(dataDir))
+	} else if key, err = p2p.NewSavedPrivKey(dataDir); err != nil {
+		return err
+	} else {
+		_, _ = fmt.Fprintf(w, "Created ENR private key: %s\n", p2p.KeyPath(dataDir))
+		writeEnrWarning(w, p2p.KeyPath(dataDir))
+	}
+
+	record, err := enr.New(key)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "Your ENR, share it with your cluster creator: %s\n", record.String())
+
+	// The run command's flags define the config keys and defaults.
+	runCmd := newRunCmd(func(context.Context, app.Config) error { return nil }, false)
+
+	// Step 3: Relays.
+	_, _ = fmt.Fprintln(w, "\n[3/5] Relays")
+	defaultRelays := strings.Trim(runCmd.Flags().Lookup("p2p-relays").DefValue, "[]")
+	relays, err := prompt.AskList("Comma separated libp2p relay URLs or multiaddrs", defaultRelays, func(relay string) error {
+		if strings.HasPrefix(relay, "http") && !validURI(relay) {
+			return errors.New("invalid relay url", z.Str("relay", relay))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Step 4: Beacon nodes.
+	_, _ = fmt.Fprintln(w, "\n[4/5] Beacon nodes")
+	beaconNodes, err := prompt.AskList("Comma separated beacon node endpoint URLs", "", func(addr string) error {
+		if !validURI(addr) {
+			return errors.New("invalid beacon node url", z.Str("url", addr))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Step 5: Cluster definition.
+	_, _ = fmt.Fprintln(w, "\n[5/5] Cluster definition")
+	defFile, err := prompt.Ask("Cluster definition file or URL to join, leave empty to skip", "")
+	if err != nil {
+		return err
+	}
+
+	if defFile != "" {
+		if err := initDefinition(ctx, w, dataDir, defFile, record.String()); err != nil {
+			return err
+		}
+	}
+
+	v := viper.New()
+	v.Set("private-key-file", p2p.KeyPath(dataDir))
+	v.Set("lock-file", filepath.Join(dataDir, "cluster-lock.json"))
+	v.Set("manifest-file", filepath.Join(dataDir, "cluster-manifest.pb"))
+	v.Set("p2p-relays", relays)
+	v.Set("beacon-node-endpoints", beaconNodes)
+
+	if err := v.WriteConfigAs(config.OutputFile); err != nil {
+		return errors.Wrap(err, "write config file", z.Str("path", config.OutputFile))
+	}
+
+	if err := initializeConfig(runCmd, config.OutputFile); err != nil {
+		return errors.Wrap(err, "validate config file")
+	} else if runCmd.PreRunE != nil {
+		if err := runCmd.PreRunE(runCmd, nil); err != nil {
+			return errors.Wrap(err, "validate config file")
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "\nWrote config file: %s\n", config.OutputFile)
+	if defFile != "" {
+		_, _ = fmt.Fprintln(w, "Next, run 'charon dkg' with the other operators to create the cluster, then 'charon run' to start the node.")
+	} else {
+		_, _ = fmt.Fprintf(w, "Next, share your ENR with your cluster creator and join the cluster's DKG, or copy a cluster lock to %s, then run 'charon run'.\n", dataDir)
+	}
+
+	return nil
+}
+
+// initDefinition loads and verifies the cluster definition, and writes it to the data directory where 'charon dkg'
+// reads it by default. It warns if the ENR isn't an operator of the cluster.
+func initDefinition(ctx context.Context, w io.Writer, dataDir string, defFile string, enrStr string) error {
+	def, err := loadDefinition(ctx, defFile)
+	if err != nil {
+		return err
+	}
+
+	operator := -1
+	for i, op := range def.Operators {
+		if op.ENR == enrStr {
+			operator = i
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "Cluster %q: %d operators, %d validators, threshold %d\n", def.Name, len(def.Operators), def.NumValidators, def.Threshold)
+	if operator < 0 {
+		_, _ = fmt.Fprintln(w, "Warning: your ENR is not an operator of this cluster yet, ask your cluster creator to add it")
+	} else {
+		_, _ = fmt.Fprintf(w, "Your ENR is operator %d of this cluster\n", operator)
+	}
+
+	b, err := json.MarshalIndent(def, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal definition")
+	}
+
+	defPath := filepath.Join(dataDir, "cluster-definition.json")
+	if err := os.WriteFile(defPath, b, 0o444); err != nil { //nolint:gosec // File needs to be read-only for everybody
+		return errors.Wrap(err, "write definition", z.Str("path", defPath))
+	}
+
+	_, _ = fmt.Fprintf(w, "Saved cluster definition: %s\n", defPath)
+
+	return nil
+}
+
+// prompter asks the operator questions on the reader, writing the questions to the writer.
+type prompter struct {
+	r *bufio.Reader
+	w io.Writer
+}
+
+func newPrompter(r io.Reader, w io.Writer) prompter {
+	return prompter{r: bufio.NewReader(r), w: w}
+}
+
+// Ask returns the trimmed answer to the question, or the default if the answer is empty.
+// The default is also returned if the input ends.
+func (p prompter) Ask(question, def string) (string, error) {
+	if def != "" {
+		_, _ = fmt.Fprintf(p.w, "%s [%s]: ", question, def)
+	} else {
+		_, _ = fmt.Fprintf(p.w, "%s: ", question)
+	}
+
+	line, err := p.r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", errors.Wrap(err, "read answer")
+	} else if errors.Is(err, io.EOF) {
+		_, _ = fmt.Fprintln(p.w)
+	}
+
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+
+	return def, nil
+}
+
+// AskList returns the non-empty comma separated answers to the question after validating each, asking again
+// if the answer is empty or invalid. An error is returned if the input ends without a valid answer.
+func (p prompter) AskList(question, def string, validate func(string) error) ([]string, error) {
+	for {
+		answer, err := p.Ask(question, def)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			resp     []string
+			firstErr error
+		)
+		for _, item := range strings.Split(answer, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+
+			if err := validate(item); err != nil && firstErr == nil {
+				firstErr = err
+			}
+
+			resp = append(resp, item)
+		}
+
+		if firstErr == nil && len(resp) > 0 {
+			return resp, nil
+		}
+
+		if firstErr == nil {
+			firstErr = errors.New("at least one value required")
+		}
+
+		_, _ = fmt.Fprintf(p.w, "Invalid answer: %v\n", firstErr)
+
+		if _, err := p.r.Peek(1); err != nil {
+			return nil, firstErr
+		}
+	}
+}
+
+// Confirm returns true if the answer to the yes/no question is yes, or the default if the answer is empty.
+func (p prompter) Confirm(question string, def bool) (bool, error) {
+	defStr := "y/N"
+	if def {
+		defStr = "Y/n"
+	}
+
+	answer, err := p.Ask(question+" ("+defStr+")", "")
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(answer) {
+	case "":
+		return def, nil
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
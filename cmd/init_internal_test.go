@@ -0,0 +1,82 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+func TestInit(t *testing.T) {
+	dir := t.TempDir()
+	dataDir := filepath.Join(dir, ".charon")
+	config := initConfig{OutputFile: filepath.Join(dir, "charon.yaml")}
+
+	answers := strings.Join([]string{
+		dataDir,
+		"",                                 // Default relays.
+		"bn:5052",                          // Invalid beacon node url.
+		"http://bn1:5052, http://bn2:5052", // Valid beacon node urls.
+		"",                                 // No cluster definition.
+	}, "\n")
+
+	var out bytes.Buffer
+	require.NoError(t, runInit(context.Background(), strings.NewReader(answers), &out, config))
+	require.Contains(t, out.String(), "Invalid answer: invalid beacon node url")
+	require.Contains(t, out.String(), "Wrote config file")
+	require.FileExists(t, p2p.KeyPath(dataDir))
+
+	v := viper.New()
+	v.SetConfigFile(config.OutputFile)
+	require.NoError(t, v.ReadInConfig())
+	require.Equal(t, []string{"http://bn1:5052", "http://bn2:5052"}, v.GetStringSlice("beacon-node-endpoints"))
+	require.Len(t, v.GetStringSlice("p2p-relays"), 3)
+	require.Equal(t, p2p.KeyPath(dataDir), v.GetString("private-key-file"))
+	require.Equal(t, filepath.Join(dataDir, "cluster-lock.json"), v.GetString("lock-file"))
+
+	t.Run("existing config", func(t *testing.T) {
+		err := runInit(context.Background(), strings.NewReader("n\n"), new(bytes.Buffer), config)
+		require.ErrorContains(t, err, "config file already exists")
+	})
+
+	t.Run("join definition", func(t *testing.T) {
+		lock, _, _ := cluster.NewForT(t, 1, 3, 4, 0, rand.New(rand.NewSource(0)))
+		b, err := json.Marshal(lock.Definition)
+		require.NoError(t, err)
+
+		defFile := filepath.Join(dir, "definition.json")
+		require.NoError(t, os.WriteFile(defFile, b, 0o644))
+
+		answers := strings.Join([]string{
+			"y",     // Overwrite config.
+			dataDir, // Reuses existing key.
+			"https://relay.example.com",
+			"http://bn:5052",
+			defFile,
+		}, "\n")
+
+		var out bytes.Buffer
+		require.NoError(t, runInit(context.Background(), strings.NewReader(answers), &out, config))
+		require.Contains(t, out.String(), "Using existing ENR private key")
+		require.Contains(t, out.String(), "your ENR is not an operator of this cluster")
+		require.FileExists(t, filepath.Join(dataDir, "cluster-definition.json"))
+		require.Contains(t, out.String(), "run 'charon dkg'")
+	})
+
+	t.Run("missing beacon nodes", func(t *testing.T) {
+		err := runInit(context.Background(), strings.NewReader("y\n"+dataDir+"\n\n\n"), new(bytes.Buffer), config)
+		require.ErrorContains(t, err, "at least one value required")
+	})
+}
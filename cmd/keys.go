@@ -0,0 +1,50 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/keystore/layout"
+)
+
+type keysConfig struct {
+	Format           string
+	Dir              string
+	ValidatorKeysDir string
+	LockFile         string
+	KeystoreOptions  keystore.Options
+	Log              log.Config
+}
+
+func newKeysCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "keys",
+		Short: "Import, export and verify validator key shares",
+		Long:  "Converts validator key shares between charon's validator_keys directory and validator client or remote signer keystore directory layouts, and verifies their integrity.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// bindKeysFlags binds the flags shared by the keys import and export commands.
+func bindKeysFlags(flags *pflag.FlagSet, config *keysConfig, dirUsage string) {
+	var formats []string
+	for _, format := range layout.Formats() {
+		formats = append(formats, string(format))
+	}
+
+	flags.StringVar(&config.Format, "format", string(layout.FormatEIP2335), fmt.Sprintf("Keystore directory layout. Options: %s.", strings.Join(formats, ", ")))
+	flags.StringVar(&config.Dir, "dir", "", dirUsage)
+	flags.StringVar(&config.ValidatorKeysDir, "validator-keys-dir", ".charon/validator_keys", "Path to charon's validator_keys directory containing keystore-N.json and keystore-N.txt files.")
+	bindKeystoreFlags(flags, &config.KeystoreOptions)
+	bindLogFlags(flags, &config.Log)
+}
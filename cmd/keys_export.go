@@ -0,0 +1,61 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/memlock"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/keystore/layout"
+)
+
+func newKeysExportCmd(runFunc func(context.Context, keysConfig) error) *cobra.Command {
+	var config keysConfig
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export key shares to a validator client keystore directory",
+		Long: `Exports the key shares in charon's validator_keys directory to a new directory using the keystore layout
+of the provided validator client or remote signer. Keystores are re-encrypted with new random passwords.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindKeysFlags(cmd.Flags(), &config, "The directory to export the keystores to.")
+	mustMarkFlagRequired(cmd, "dir")
+
+	return cmd
+}
+
+func runKeysExport(ctx context.Context, config keysConfig) error {
+	format := layout.Format(config.Format)
+	if err := format.Validate(); err != nil {
+		return err
+	}
+
+	secrets, err := layout.Load(config.ValidatorKeysDir, layout.FormatEIP2335)
+	if err != nil {
+		return err
+	}
+	defer memlock.ProtectKeys(ctx, secrets)()
+
+	if err := layout.Store(secrets, config.Dir, format, config.KeystoreOptions); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Exported key shares", z.Int("count", len(secrets)), z.Str("format", config.Format), z.Str("dir", config.Dir))
+
+	return nil
+}
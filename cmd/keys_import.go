@@ -0,0 +1,119 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/memlock"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/keystore/layout"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func newKeysImportCmd(runFunc func(context.Context, keysConfig) error) *cobra.Command {
+	var config keysConfig
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import key shares from a validator client keystore directory",
+		Long: `Imports the key shares in a directory using the keystore layout of the provided validator client or remote signer
+into charon's validator_keys directory. The key shares are ordered by the validators in the cluster lock
+and re-encrypted with new random passwords.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindKeysFlags(cmd.Flags(), &config, "The directory to import the keystores from.")
+	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the order of the key shares.")
+	mustMarkFlagRequired(cmd, "dir")
+
+	return cmd
+}
+
+func runKeysImport(ctx context.Context, config keysConfig) error {
+	format := layout.Format(config.Format)
+	if err := format.Validate(); err != nil {
+		return err
+	}
+
+	lock, err := loadLockFile(config.LockFile)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := layout.Load(config.Dir, format)
+	if err != nil {
+		return err
+	}
+	defer memlock.ProtectKeys(ctx, secrets)()
+
+	ordered, err := orderSharesByLock(lock, secrets)
+	if err != nil {
+		return err
+	}
+	defer memlock.ProtectKeys(ctx, ordered)()
+
+	if err := layout.Store(ordered, config.ValidatorKeysDir, layout.FormatEIP2335, config.KeystoreOptions); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Imported key shares", z.Int("count", len(ordered)), z.Str("format", config.Format), z.Str("dir", config.ValidatorKeysDir))
+
+	return nil
+}
+
+// orderSharesByLock returns the key shares ordered by the lock's validators.
+// It returns an error if a validator's share is missing or if a share doesn't belong to any validator.
+func orderSharesByLock(lock cluster.Lock, secrets []tbls.PrivateKey) ([]tbls.PrivateKey, error) {
+	byPubshare := make(map[string]tbls.PrivateKey)
+	for _, secret := range secrets {
+		pubshare, err := tbls.SecretToPublicKey(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		byPubshare[hex.EncodeToString(pubshare[:])] = secret
+	}
+
+	var resp []tbls.PrivateKey
+	for _, val := range lock.Validators {
+		var found bool
+		for _, pubshare := range val.PubShares {
+			secret, ok := byPubshare[hex.EncodeToString(pubshare)]
+			if !ok {
+				continue
+			}
+
+			resp = append(resp, secret)
+			found = true
+
+			break
+		}
+
+		if !found {
+			return nil, errors.New("missing key share for validator", z.Redact(z.Str("pubkey", val.PublicKeyHex())))
+		}
+	}
+
+	if len(resp) != len(secrets) {
+		return nil, errors.New("key shares not matching cluster lock validators",
+			z.Int("shares", len(secrets)), z.Int("validators", len(lock.Validators)))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,149 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/keystore/layout"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestKeysExportImport(t *testing.T) {
+	ctx := context.Background()
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, _, shares := cluster.NewForT(t, 3, 3, 4, seed, random)
+
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(lockFile, b, 0o644))
+
+	var secrets []tbls.PrivateKey
+	for _, valShares := range shares {
+		secrets = append(secrets, valShares[1])
+	}
+
+	opts := keystore.Options{CostPower: 10}
+	keysDir := filepath.Join(dir, "validator_keys")
+	require.NoError(t, layout.Store(secrets, keysDir, layout.FormatEIP2335, opts))
+
+	exportDir := filepath.Join(dir, "lighthouse")
+	err = runKeysExport(ctx, keysConfig{
+		Format:           string(layout.FormatLighthouse),
+		Dir:              exportDir,
+		ValidatorKeysDir: keysDir,
+		KeystoreOptions:  opts,
+	})
+	require.NoError(t, err)
+
+	importConfig := keysConfig{
+		Format:           string(layout.FormatLighthouse),
+		Dir:              exportDir,
+		ValidatorKeysDir: filepath.Join(dir, "imported"),
+		LockFile:         lockFile,
+		KeystoreOptions:  opts,
+	}
+	require.NoError(t, runKeysImport(ctx, importConfig))
+
+	imported, err := layout.Load(importConfig.ValidatorKeysDir, layout.FormatEIP2335)
+	require.NoError(t, err)
+	require.Equal(t, secrets, imported)
+
+	// Importing again fails since the validator keys directory isn't empty.
+	require.ErrorContains(t, runKeysImport(ctx, importConfig), "keystore files already exist")
+
+	// Importing fails if key shares are missing.
+	partialDir := filepath.Join(dir, "teku")
+	require.NoError(t, layout.Store(secrets[:2], partialDir, layout.FormatTeku, opts))
+	importConfig.Format = string(layout.FormatTeku)
+	importConfig.Dir = partialDir
+	importConfig.ValidatorKeysDir = filepath.Join(dir, "partial")
+	require.ErrorContains(t, runKeysImport(ctx, importConfig), "missing key share for validator")
+
+	importConfig.Format = "vouch"
+	require.ErrorContains(t, runKeysImport(ctx, importConfig), "unsupported keystore format")
+}
+
+func TestKeysVerify(t *testing.T) {
+	ctx := context.Background()
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, _, shares := cluster.NewForT(t, 3, 3, 4, seed, random)
+
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(lockFile, b, 0o644))
+
+	storeShares := func(t *testing.T, secrets ...tbls.PrivateKey) keysConfig {
+		t.Helper()
+
+		keysDir := filepath.Join(t.TempDir(), "validator_keys")
+		require.NoError(t, layout.Store(secrets, keysDir, layout.FormatEIP2335, keystore.Options{CostPower: 10}))
+
+		return keysConfig{ValidatorKeysDir: keysDir, LockFile: lockFile}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runKeysVerify(ctx, &buf, storeShares(t, shares[0][1], shares[1][1], shares[2][1])))
+		require.Contains(t, buf.String(), "Verified 3 key shares of 3 validators with threshold 3 of 4")
+	})
+
+	t.Run("swapped", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := runKeysVerify(ctx, &buf, storeShares(t, shares[1][1], shares[0][1], shares[2][1]))
+		require.ErrorContains(t, err, "key share verification failed")
+		require.Contains(t, buf.String(), "keystore-0.json: public share")
+		require.Contains(t, buf.String(), "but for validator 1")
+		require.Contains(t, buf.String(), "keystore-1.json: public share")
+	})
+
+	t.Run("other operator", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := runKeysVerify(ctx, &buf, storeShares(t, shares[0][1], shares[1][2], shares[2][1]))
+		require.ErrorContains(t, err, "key share verification failed")
+		require.Contains(t, buf.String(), "keystore-1.json: public share of operator 2, while other key shares are of operator 1")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := runKeysVerify(ctx, &buf, storeShares(t, shares[0][1], shares[1][1]))
+		require.ErrorContains(t, err, "key share verification failed")
+		require.Contains(t, buf.String(), "keystore-2.json: missing key share for validator 2")
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		config := storeShares(t, shares[0][1], shares[1][1], shares[2][1])
+		require.NoError(t, os.WriteFile(filepath.Join(config.ValidatorKeysDir, "keystore-1.txt"), []byte("wrong"), 0o600))
+
+		var buf bytes.Buffer
+		err := runKeysVerify(ctx, &buf, config)
+		require.ErrorContains(t, err, "key share verification failed")
+		require.Contains(t, buf.String(), "keystore-1.json: keystore decryption")
+	})
+
+	t.Run("inconsistent public shares", func(t *testing.T) {
+		val := lock.Validators[0]
+		val.PubShares = append([][]byte(nil), val.PubShares...)
+		val.PubShares[3] = lock.Validators[1].PubShares[3]
+
+		mismatches := verifyPubShares(0, val, lock.Threshold)
+		require.Len(t, mismatches, 1)
+		require.Contains(t, mismatches[0], "public shares of operators 1-3 don't reconstruct the public key")
+	})
+}
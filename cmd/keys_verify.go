@@ -0,0 +1,202 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/memlock"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+func newKeysVerifyCmd(runFunc func(context.Context, io.Writer, keysConfig) error) *cobra.Command {
+	var config keysConfig
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the integrity of the local key shares",
+		Long: `Verifies that each keystore in charon's validator_keys directory decrypts, that its public share matches
+the public share registered for this node in the cluster lock, and that the cluster lock's public shares
+reconstruct each validator's public key, reporting all mismatches.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.ValidatorKeysDir, "validator-keys-dir", ".charon/validator_keys", "Path to charon's validator_keys directory containing keystore-N.json and keystore-N.txt files.")
+	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file.")
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	return cmd
+}
+
+func runKeysVerify(ctx context.Context, w io.Writer, config keysConfig) error {
+	lock, err := loadLockFile(config.LockFile)
+	if err != nil {
+		return err
+	}
+
+	files, err := filepath.Glob(filepath.Join(config.ValidatorKeysDir, "keystore-*.json"))
+	if err != nil {
+		return errors.Wrap(err, "read keystore files")
+	} else if len(files) == 0 {
+		return errors.New("no keystores found", z.Str("dir", config.ValidatorKeysDir))
+	}
+	sort.Strings(files)
+
+	var (
+		mismatches []string
+		keys       []tbls.PrivateKey
+		fileIdxs   []int
+	)
+	for _, file := range files {
+		keyFile, err := keystore.LoadFile(file)
+		if err != nil {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %v", filepath.Base(file), err))
+			continue
+		} else if !keyFile.HasIndex() {
+			mismatches = append(mismatches, filepath.Base(file)+": unknown keystore index, filename not 'keystore-%d.json'")
+			continue
+		}
+
+		keys = append(keys, keyFile.PrivateKey)
+		fileIdxs = append(fileIdxs, keyFile.FileIndex)
+	}
+	defer memlock.ProtectKeys(ctx, keys)()
+
+	mismatches = append(mismatches, verifyKeyShares(lock, keys, fileIdxs)...)
+
+	for i, val := range lock.Validators {
+		mismatches = append(mismatches, verifyPubShares(i, val, lock.Threshold)...)
+	}
+
+	if len(mismatches) > 0 {
+		_, _ = fmt.Fprintf(w, "Key share verification failed with %d mismatches:\n", len(mismatches))
+		for _, mismatch := range mismatches {
+			_, _ = fmt.Fprintf(w, "  %s\n", mismatch)
+		}
+
+		return errors.New("key share verification failed", z.Int("mismatches", len(mismatches)))
+	}
+
+	_, _ = fmt.Fprintf(w, "Verified %d key shares of %d validators with threshold %d of %d\n",
+		len(keys), len(lock.Validators), lock.Threshold, len(lock.Operators))
+
+	return nil
+}
+
+// verifyKeyShares returns the mismatches between the key shares (by keystore file index)
+// and the public shares registered in the cluster lock. All key shares must belong to the same operator.
+func verifyKeyShares(lock cluster.Lock, keys []tbls.PrivateKey, fileIdxs []int) []string {
+	var (
+		mismatches []string
+		shareIdx   = -1
+		found      = make(map[int]bool)
+	)
+	for i, key := range keys {
+		fileIdx := fileIdxs[i]
+		filename := fmt.Sprintf("keystore-%d.json", fileIdx)
+
+		if fileIdx >= len(lock.Validators) {
+			mismatches = append(mismatches, fmt.Sprintf("%s: no validator %d in cluster lock with %d validators", filename, fileIdx, len(lock.Validators)))
+			continue
+		}
+		found[fileIdx] = true
+
+		pubshare, err := tbls.SecretToPublicKey(key)
+		if err != nil {
+			mismatches = append(mismatches, fmt.Sprintf("%s: invalid key share: %v", filename, err))
+			continue
+		}
+
+		val := lock.Validators[fileIdx]
+
+		idx := pubShareIndex(val, pubshare)
+		if idx < 0 {
+			mismatch := fmt.Sprintf("%s: public share %#x not registered for validator %d %s", filename, pubshare, fileIdx, val.PublicKeyHex())
+			for j, other := range lock.Validators {
+				if pubShareIndex(other, pubshare) >= 0 {
+					mismatch += fmt.Sprintf(", but for validator %d %s", j, other.PublicKeyHex())
+				}
+			}
+
+			mismatches = append(mismatches, mismatch)
+
+			continue
+		}
+
+		if shareIdx < 0 {
+			shareIdx = idx
+		} else if idx != shareIdx {
+			mismatches = append(mismatches, fmt.Sprintf("%s: public share of operator %d, while other key shares are of operator %d", filename, idx, shareIdx))
+		}
+	}
+
+	for i, val := range lock.Validators {
+		if !found[i] {
+			mismatches = append(mismatches, fmt.Sprintf("keystore-%d.json: missing key share for validator %d %s", i, i, val.PublicKeyHex()))
+		}
+	}
+
+	return mismatches
+}
+
+// pubShareIndex returns the operator index of the public share in the validator or -1 if not found.
+func pubShareIndex(val cluster.DistValidator, pubshare tbls.PublicKey) int {
+	for i, b := range val.PubShares {
+		if tbls.PublicKey(b) == pubshare {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// verifyPubShares returns the mismatches if consecutive threshold subsets of the validator's public shares
+// don't reconstruct the validator's public key. This detects any public share inconsistent with the others.
+func verifyPubShares(valIdx int, val cluster.DistValidator, threshold int) []string {
+	pubkey, err := tblsconv.PubkeyFromBytes(val.PubKey)
+	if err != nil {
+		return []string{fmt.Sprintf("validator %d: invalid public key: %v", valIdx, err)}
+	}
+
+	var mismatches []string
+	for start := 0; start+threshold <= len(val.PubShares); start++ {
+		pubshares := make(map[int]tbls.PublicKey)
+		for i := start; i < start+threshold; i++ {
+			pubshare, err := tblsconv.PubkeyFromBytes(val.PubShares[i])
+			if err != nil {
+				return []string{fmt.Sprintf("validator %d: invalid public share of operator %d: %v", valIdx, i, err)}
+			}
+
+			pubshares[i+1] = pubshare // Share indexes are 1-indexed
+		}
+
+		recovered, err := tbls.RecoverPublicKey(pubshares)
+		if err != nil {
+			mismatches = append(mismatches, fmt.Sprintf("validator %d: recover public key from shares of operators %d-%d: %v", valIdx, start, start+threshold-1, err))
+		} else if recovered != pubkey {
+			mismatches = append(mismatches, fmt.Sprintf("validator %d %s: public shares of operators %d-%d don't reconstruct the public key",
+				valIdx, val.PublicKeyHex(), start, start+threshold-1))
+		}
+	}
+
+	return mismatches
+}
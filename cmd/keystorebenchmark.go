@@ -0,0 +1,170 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+type keystoreBenchmarkConfig struct {
+	Options          keystore.Options
+	NumValidators    int
+	ValidatorKeysDir string
+}
+
+func newKeystoreBenchmarkCmd(runFunc func(io.Writer, keystoreBenchmarkConfig) error) *cobra.Command {
+	var config keystoreBenchmarkConfig
+
+	cmd := &cobra.Command{
+		Use:   "keystore-benchmark",
+		Short: "Benchmark keystore key derivation options and validate existing keystores",
+		Long: `Measures the keystore encryption and decryption duration of the provided key derivation function and cost,
+estimating the time a validator client requires to import the keystores of a cluster.
+Optionally validates and decrypts existing keystores, reporting their key derivation function and cost.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.OutOrStdout(), config)
+		},
+	}
+
+	bindKeystoreFlags(cmd.Flags(), &config.Options)
+	cmd.Flags().IntVar(&config.NumValidators, "num-validators", 1, "The number of validators to estimate the keystore import duration for.")
+	cmd.Flags().StringVar(&config.ValidatorKeysDir, "validator-keys-dir", "", "Optional path to a directory containing existing keystores and password files to validate.")
+
+	return cmd
+}
+
+func runKeystoreBenchmark(w io.Writer, config keystoreBenchmarkConfig) error {
+	if err := config.Options.Validate(); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+
+	if err := benchmarkKeystoreOptions(&sb, config.Options, config.NumValidators); err != nil {
+		return err
+	}
+
+	if config.ValidatorKeysDir != "" {
+		if err := validateKeystores(&sb, config.ValidatorKeysDir); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return errors.Wrap(err, "write keystore benchmark")
+	}
+
+	return nil
+}
+
+// benchmarkKeystoreOptions stores and loads a random keystore using the options and writes the durations.
+func benchmarkKeystoreOptions(w io.Writer, opts keystore.Options, numValidators int) error {
+	secret, err := tbls.GenerateSecretKey()
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "charon-keystore-benchmark")
+	if err != nil {
+		return errors.Wrap(err, "create temp dir")
+	}
+	defer os.RemoveAll(dir)
+
+	t0 := time.Now()
+	if err := keystore.StoreKeysWithOptions([]tbls.PrivateKey{secret}, dir, opts); err != nil {
+		return err
+	}
+	encrypt := time.Since(t0)
+
+	t0 = time.Now()
+	if _, err := keystore.LoadFilesUnordered(dir); err != nil {
+		return err
+	}
+	decrypt := time.Since(t0)
+
+	_, _ = fmt.Fprintf(w, "Key derivation: %s\n", formatKDF(opts))
+	_, _ = fmt.Fprintf(w, "Encrypt duration: %s\n", encrypt.Round(time.Millisecond))
+	_, _ = fmt.Fprintf(w, "Decrypt duration: %s\n", decrypt.Round(time.Millisecond))
+	_, _ = fmt.Fprintf(w, "Estimated sequential import of %d validators: %s\n",
+		numValidators, (decrypt * time.Duration(numValidators)).Round(time.Millisecond))
+
+	return nil
+}
+
+// validateKeystores decrypts all keystores in the directory and writes the key derivation functions in use.
+func validateKeystores(w io.Writer, dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "keystore-*.json"))
+	if err != nil {
+		return errors.Wrap(err, "read keystore files")
+	}
+
+	counts := make(map[string]int)
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return errors.Wrap(err, "read keystore", z.Str("filename", file))
+		}
+
+		var store keystore.Keystore
+		if err := json.Unmarshal(b, &store); err != nil {
+			return errors.Wrap(err, "unmarshal keystore", z.Str("filename", file))
+		}
+
+		opts, err := keystore.KDFOptions(store)
+		if err != nil {
+			return errors.Wrap(err, "keystore kdf", z.Str("filename", file))
+		}
+
+		counts[formatKDF(opts)]++
+	}
+
+	t0 := time.Now()
+	keyFiles, err := keystore.LoadFilesUnordered(dir)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "\nValidated %d keystores in %s\n", len(keyFiles), time.Since(t0).Round(time.Millisecond))
+
+	var kdfs []string
+	for kdf := range counts {
+		kdfs = append(kdfs, kdf)
+	}
+	sort.Strings(kdfs)
+
+	for _, kdf := range kdfs {
+		_, _ = fmt.Fprintf(w, "  %s: %d keystores\n", kdf, counts[kdf])
+	}
+
+	return nil
+}
+
+// formatKDF returns a human-readable key derivation function and cost.
+func formatKDF(opts keystore.Options) string {
+	kdf := opts.KDF
+	if kdf == "" {
+		kdf = keystore.KDFPbkdf2
+	}
+
+	costPower := opts.CostPower
+	if costPower == 0 {
+		costPower = keystore.DefaultCostPower
+	}
+
+	return fmt.Sprintf("%s with cost 2^%d", kdf, costPower)
+}
@@ -0,0 +1,40 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestKeystoreBenchmark(t *testing.T) {
+	var secrets []tbls.PrivateKey
+	for range 2 {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+		secrets = append(secrets, secret)
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, keystore.StoreKeysWithOptions(secrets, dir, keystore.Options{KDF: keystore.KDFScrypt, CostPower: 10}))
+
+	var out bytes.Buffer
+	err := runKeystoreBenchmark(&out, keystoreBenchmarkConfig{
+		Options:          keystore.Options{KDF: keystore.KDFPbkdf2, CostPower: 12},
+		NumValidators:    100,
+		ValidatorKeysDir: dir,
+	})
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "Key derivation: pbkdf2 with cost 2^12")
+	require.Contains(t, out.String(), "Estimated sequential import of 100 validators")
+	require.Contains(t, out.String(), "Validated 2 keystores")
+	require.Contains(t, out.String(), "scrypt with cost 2^10: 2 keystores")
+
+	err = runKeystoreBenchmark(&out, keystoreBenchmarkConfig{Options: keystore.Options{KDF: "md5"}})
+	require.ErrorContains(t, err, "invalid keystore kdf")
+}
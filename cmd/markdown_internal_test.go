@@ -127,6 +127,12 @@ This document enumerates and explains various duty failure reasons instrumented
 These reasons are logged and reported via the 'core_tracker_failed_duty_reasons_total'
 prometheus counter when the tracker component detects duty failures.
 
+These reasons are the local view of a duty failure by each node, which is often caused by other nodes.
+Nodes therefore also exchange their observations of failed duties via the root cause protocol and log
+the cluster-consistent root cause, e.g. "peer 3 (name) never sent partial signature" or "all 4/4 peers failed at fetcher: fetch_bn_error".
+Root causes are reported via the 'core_rootcause_failed_duties_total' prometheus counter by code:
+'all_failed', 'peer_absent', 'partial_failure', 'mixed_failure' or 'no_peer_observations'.
+
 By understanding these failure reasons, operators can better monitor, troubleshoot, and
 maintain system performance.
 
@@ -0,0 +1,83 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/datadir"
+	"github.com/obolnetwork/charon/app/log"
+)
+
+type migrateConfig struct {
+	DataDir string
+	DryRun  bool
+	Log     log.Config
+}
+
+func newMigrateCmd(runFunc func(context.Context, io.Writer, migrateConfig) error) *cobra.Command {
+	var config migrateConfig
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the data directory to the latest schema version",
+		Long: `Performs the pending forward migrations of the data directory's layout and file formats, e.g. cluster manifest
+and keystore layout, recording the schema version in the data directory. Charon refuses to run on data directories
+with a newer schema version than it supports, preventing silent corruption after a downgrade.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	bindDataDirFlag(cmd.Flags(), &config.DataDir)
+	cmd.Flags().BoolVar(&config.DryRun, "dry-run", false, "Only print the pending migrations without applying them.")
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	return cmd
+}
+
+func runMigrate(ctx context.Context, w io.Writer, config migrateConfig) error {
+	current, err := datadir.Version(config.DataDir)
+	if err != nil {
+		return err
+	}
+
+	migrations, err := datadir.Pending(config.DataDir)
+	if err != nil {
+		return err
+	}
+
+	if len(migrations) == 0 {
+		_, _ = fmt.Fprintf(w, "Data dir schema version %d is up to date\n", current)
+		return nil
+	}
+
+	if !config.DryRun {
+		migrations, err = datadir.Migrate(ctx, config.DataDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	verb := "Migrated"
+	if config.DryRun {
+		verb = "Pending migration"
+	}
+
+	for _, m := range migrations {
+		_, _ = fmt.Fprintf(w, "%s to version %d: %s\n", verb, m.Version, m.Description)
+	}
+
+	return nil
+}
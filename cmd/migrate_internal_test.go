@@ -0,0 +1,34 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/datadir"
+)
+
+func TestRunMigrate(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	require.NoError(t, runMigrate(ctx, &buf, migrateConfig{DataDir: dir, DryRun: true}))
+	require.Contains(t, buf.String(), "Pending migration to version 2")
+
+	v, err := datadir.Version(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+
+	buf.Reset()
+	require.NoError(t, runMigrate(ctx, &buf, migrateConfig{DataDir: dir}))
+	require.Contains(t, buf.String(), "Migrated to version 2")
+
+	buf.Reset()
+	require.NoError(t, runMigrate(ctx, &buf, migrateConfig{DataDir: dir}))
+	require.Contains(t, buf.String(), "is up to date")
+}
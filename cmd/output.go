@@ -0,0 +1,75 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	outputFlag = "output"
+	outputText = "text"
+	outputJSON = "json"
+
+	// jsonOutputAnnotation is the annotation of commands supporting the json output format.
+	jsonOutputAnnotation = "json_output"
+)
+
+// supportJSONOutput marks the command as supporting the json output format, which other commands reject.
+func supportJSONOutput(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+
+	cmd.Annotations[jsonOutputAnnotation] = "true"
+}
+
+// validateOutput returns an error if the output format isn't supported by the command.
+func validateOutput(cmd *cobra.Command, output string) error {
+	if output != outputText && output != outputJSON {
+		return errors.New("invalid --output value, supported values are text and json", z.Str("output", output))
+	}
+
+	if output == outputJSON && cmd.Annotations[jsonOutputAnnotation] == "" {
+		return errors.New("--output=json not supported by this command", z.Str("command", cmd.CommandPath()))
+	}
+
+	return nil
+}
+
+// isJSONOutput returns true if the global --output flag of the command is json.
+func isJSONOutput(cmd *cobra.Command) bool {
+	f := cmd.Flags().Lookup(outputFlag)
+
+	return f != nil && f.Value.String() == outputJSON
+}
+
+// jsonWriter returns the command's output writer if the global --output flag is json, otherwise nil.
+func jsonWriter(cmd *cobra.Command) io.Writer {
+	if !isJSONOutput(cmd) {
+		return nil
+	}
+
+	return cmd.OutOrStdout()
+}
+
+// writeJSON writes the value as indented JSON followed by a newline.
+func writeJSON(w io.Writer, v any) error {
+	b, err := json.MarshalIndent(v, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal json output")
+	}
+
+	if _, err := fmt.Fprintln(w, string(b)); err != nil {
+		return errors.Wrap(err, "write json output")
+	}
+
+	return nil
+}
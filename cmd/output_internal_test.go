@@ -0,0 +1,114 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+func TestJSONOutput(t *testing.T) {
+	execute := func(t *testing.T, args ...string) ([]byte, error) {
+		t.Helper()
+
+		var buf bytes.Buffer
+		root := New()
+		root.SetOut(&buf)
+		root.SetArgs(args)
+
+		err := root.Execute()
+
+		return buf.Bytes(), err
+	}
+
+	t.Run("version", func(t *testing.T) {
+		out, err := execute(t, "version", "--output=json")
+		require.NoError(t, err)
+
+		var result versionResult
+		require.NoError(t, json.Unmarshal(out, &result))
+		require.Equal(t, version.Version.String(), result.Version)
+		require.Empty(t, result.ConsensusProtocols)
+	})
+
+	t.Run("create enr and enr", func(t *testing.T) {
+		dataDir := filepath.Join(t.TempDir(), ".charon")
+
+		out, err := execute(t, "create", "enr", "--data-dir", dataDir, "--output", "json")
+		require.NoError(t, err)
+
+		var created enrResult
+		require.NoError(t, json.Unmarshal(out, &created))
+		require.Equal(t, p2p.KeyPath(dataDir), created.PrivateKeyFile)
+		require.Contains(t, created.ENR, "enr:")
+
+		out, err = execute(t, "enr", "--data-dir", dataDir, "--output", "json")
+		require.NoError(t, err)
+
+		var loaded enrResult
+		require.NoError(t, json.Unmarshal(out, &loaded))
+		require.Equal(t, created, loaded)
+	})
+
+	t.Run("create cluster", func(t *testing.T) {
+		clusterDir := t.TempDir()
+
+		out, err := execute(t, "create", "cluster", "--cluster-dir", clusterDir, "--nodes=3", "--num-validators=2",
+			"--network=holesky", "--insecure-keys", "--output=json",
+			"--fee-recipient-addresses=0x000000000000000000000000000000000000dEaD",
+			"--withdrawal-addresses=0x000000000000000000000000000000000000dEaD")
+		require.NoError(t, err)
+
+		var result createClusterResult
+		require.NoError(t, json.Unmarshal(out, &result))
+		require.Equal(t, clusterDir, result.ClusterDir)
+		require.Len(t, result.NodeDirs, 3)
+		require.Len(t, result.Validators, 2)
+		require.FileExists(t, filepath.Join(result.NodeDirs[0], "cluster-lock.json"))
+	})
+
+	t.Run("create dkg", func(t *testing.T) {
+		outputDir := t.TempDir()
+		enrs := []string{
+			"enr:-JG4QFI0llFYxSoTAHm24OrbgoVx77dL6Ehl1Ydys39JYoWcBhiHrRhtGXDTaygWNsEWFb1cL7a1Bk0klIdaNuXplKWGAYGv0Gt7gmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQL6bcis0tFXnbqG4KuywxT5BLhtmijPFApKCDJNl3mXFYN0Y3CCDhqDdWRwgg4u",
+			"enr:-JG4QPnqHa7FU3PBqGxpV5L0hjJrTUqv8Wl6_UTHt-rELeICWjvCfcVfwmax8xI_eJ0ntI3ly9fgxAsmABud6-yBQiuGAYGv0iYPgmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQMLLCMZ5Oqi_sdnBfdyhmysZMfFm78PgF7Y9jitTJPSroN0Y3CCPoODdWRwgj6E",
+			"enr:-JG4QDKNYm_JK-w6NuRcUFKvJAlq2L4CwkECelzyCVrMWji4YnVRn8AqQEL5fTQotPL2MKxiKNmn2k6XEINtq-6O3Z2GAYGvzr_LgmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQKlO7fSaBa3h48CdM-qb_Xb2_hSrJOy6nNjR0mapAqMboN0Y3CCDhqDdWRwgg4u",
+		}
+
+		out, err := execute(t, "create", "dkg", "--output-dir", outputDir, "--output=json",
+			"--fee-recipient-addresses=0x000000000000000000000000000000000000dEaD",
+			"--withdrawal-addresses=0x000000000000000000000000000000000000dEaD",
+			"--operator-enrs="+strings.Join(enrs, ","))
+		require.NoError(t, err)
+
+		var result createDKGResult
+		require.NoError(t, json.Unmarshal(out, &result))
+		require.Equal(t, filepath.Join(outputDir, "cluster-definition.json"), result.DefinitionFile)
+		require.Equal(t, 2, result.Threshold)
+		require.FileExists(t, result.DefinitionFile)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := execute(t, "run", "--output=json")
+		require.ErrorContains(t, err, "--output=json not supported by this command")
+	})
+
+	t.Run("text", func(t *testing.T) {
+		out, err := execute(t, "version")
+		require.NoError(t, err)
+		require.False(t, json.Valid(out))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := execute(t, "version", "--output=yaml")
+		require.ErrorContains(t, err, "invalid --output value")
+	})
+}
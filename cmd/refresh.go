@@ -0,0 +1,265 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/dkg"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+type refreshVerifyConfig struct {
+	DataDir   string
+	OutputDir string
+	Log       log.Config
+}
+
+func newRefreshCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "refresh",
+		Short: "Proactively refresh distributed validator key shares.",
+		Long: `Proactively refresh distributed validator key shares. Refreshing re-randomises all nodes' key shares while keeping
+the distributed validator public keys unchanged, which bounds the useful lifetime of any leaked key share material.`,
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+func newRefreshRunCmd(runFunc func(context.Context, dkg.RefreshConfig) error) *cobra.Command {
+	var config dkg.RefreshConfig
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Participate in a key share refresh ceremony",
+		Long: `Participate in a key share refresh ceremony that replaces this node's validator key shares with new shares
+of the same distributed validators. The refreshed cluster lock and validator keys are written to the output directory.
+Note that all other cluster operators should run this command at the same time, and that all operators must switch
+to the refreshed files at the same time since refreshed and previous key shares cannot be combined.
+With --interval, the command keeps running and participates in a refresh ceremony every interval, starting at
+the same epoch on all nodes. It stops the node with --stop-command before each ceremony and starts it again with
+--start-command afterwards. Each ceremony refreshes the output of the previous one and writes to an epoch-<epoch>
+subdirectory of the output directory, linked as its 'current' subdirectory, from which the node and validator client
+must load the cluster lock and validator keys. Superseded refreshed key shares are securely deleted.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printLicense(cmd.Context())
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindDataDirFlag(cmd.Flags(), &config.DataDir)
+	bindRefreshOutputDirFlag(cmd, &config.OutputDir)
+	bindNoVerifyFlag(cmd.Flags(), &config.NoVerify)
+	bindP2PFlags(cmd, &config.P2P)
+	bindLogFlags(cmd.Flags(), &config.Log)
+	bindShutdownDelayFlag(cmd.Flags(), &config.ShutdownDelay)
+
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 1*time.Minute, "Timeout for the key share refresh process, should be increased if it times out.")
+	cmd.Flags().DurationVar(&config.Interval, "interval", 0, "Periodically refresh key shares every interval, aligned to epoch boundaries. Zero runs a single refresh ceremony immediately.")
+	cmd.Flags().StringVar(&config.StopCommand, "stop-command", "", "The shell command that stops the node before each periodic refresh ceremony, e.g. 'systemctl stop charon'.")
+	cmd.Flags().StringVar(&config.StartCommand, "start-command", "", "The shell command that starts the node with the current refreshed key shares after each periodic refresh ceremony, e.g. 'systemctl start charon'.")
+	bindTestnetFlags(cmd, &config.TestnetConfig)
+
+	return cmd
+}
+
+func newRefreshVerifyCmd(runFunc func(context.Context, refreshVerifyConfig) error) *cobra.Command {
+	var config refreshVerifyConfig
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the output of a key share refresh ceremony",
+		Long: `Verify that the refreshed cluster lock and validator keys in the output directory are a valid refresh of the
+cluster lock and validator keys in the data directory: same distributed validators, new public shares, valid signatures
+and refreshed validator keys matching this node's refreshed public shares.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindDataDirFlag(cmd.Flags(), &config.DataDir)
+	bindRefreshOutputDirFlag(cmd, &config.OutputDir)
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	return cmd
+}
+
+func bindRefreshOutputDirFlag(cmd *cobra.Command, outputDir *string) {
+	cmd.Flags().StringVar(outputDir, "output-dir", ".charon/refreshed", "The directory where the refreshed cluster lock and validator keys are stored.")
+}
+
+// runRefreshVerify verifies the refreshed cluster lock and validator keys in the output directory
+// against the existing cluster lock in the data directory.
+func runRefreshVerify(ctx context.Context, conf refreshVerifyConfig) error {
+	oldLock, err := loadLockFile(filepath.Join(conf.DataDir, "cluster-lock.json"))
+	if err != nil {
+		return err
+	}
+
+	newLock, err := loadLockFile(filepath.Join(conf.OutputDir, "cluster-lock.json"))
+	if err != nil {
+		return err
+	}
+
+	if err := verifyRefreshedLock(oldLock, newLock); err != nil {
+		return err
+	}
+
+	key, err := p2p.LoadPrivKey(conf.DataDir)
+	if err != nil {
+		return err
+	}
+
+	pID, err := p2p.PeerIDFromKey(key.PubKey())
+	if err != nil {
+		return err
+	}
+
+	nodeIdx, err := newLock.NodeIdx(pID)
+	if err != nil {
+		return errors.Wrap(err, "private key not matching refreshed cluster lock file")
+	}
+
+	if err := verifyRefreshedKeys(filepath.Join(conf.OutputDir, "validator_keys"), newLock, nodeIdx); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Refreshed cluster lock and validator keys verified successfully",
+		z.Str("output_dir", conf.OutputDir),
+		z.Int("validators", len(newLock.Validators)),
+	)
+
+	return nil
+}
+
+// loadLockFile returns the cluster lock at the provided path after verifying its hashes.
+func loadLockFile(path string) (cluster.Lock, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "read cluster lock", z.Str("path", path))
+	}
+
+	var lock cluster.Lock
+	if err := json.Unmarshal(b, &lock); err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "unmarshal cluster lock", z.Str("path", path))
+	}
+
+	if err := lock.VerifyHashes(); err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "verify cluster lock hashes", z.Str("path", path))
+	}
+
+	return lock, nil
+}
+
+// verifyRefreshedLock returns an error if newLock isn't a valid refresh of oldLock.
+func verifyRefreshedLock(oldLock, newLock cluster.Lock) error {
+	if !bytes.Equal(oldLock.DefinitionHash, newLock.DefinitionHash) {
+		return errors.New("refreshed cluster lock definition mismatch")
+	}
+
+	if bytes.Equal(oldLock.LockHash, newLock.LockHash) {
+		return errors.New("cluster lock not refreshed, lock hashes are identical")
+	}
+
+	if len(oldLock.Validators) != len(newLock.Validators) {
+		return errors.New("refreshed cluster lock validator count mismatch")
+	}
+
+	for i, oldVal := range oldLock.Validators {
+		newVal := newLock.Validators[i]
+
+		if !bytes.Equal(oldVal.PubKey, newVal.PubKey) {
+			return errors.New("refreshed validator public key mismatch", z.Int("validator_index", i))
+		}
+
+		if !reflect.DeepEqual(oldVal.PartialDepositData, newVal.PartialDepositData) {
+			return errors.New("refreshed validator deposit data mismatch", z.Int("validator_index", i))
+		}
+
+		if !reflect.DeepEqual(oldVal.BuilderRegistration, newVal.BuilderRegistration) {
+			return errors.New("refreshed validator builder registration mismatch", z.Int("validator_index", i))
+		}
+
+		if len(oldVal.PubShares) != len(newVal.PubShares) {
+			return errors.New("refreshed validator public shares count mismatch", z.Int("validator_index", i))
+		}
+
+		for j := range oldVal.PubShares {
+			if bytes.Equal(oldVal.PubShares[j], newVal.PubShares[j]) {
+				return errors.New("validator public share not refreshed", z.Int("validator_index", i), z.Int("peer_index", j))
+			}
+		}
+	}
+
+	if err := newLock.VerifySignatures(); err != nil {
+		return errors.Wrap(err, "verify refreshed cluster lock signatures")
+	}
+
+	return nil
+}
+
+// verifyRefreshedKeys returns an error if the validator keys in keysDir don't match this node's public shares in lock.
+func verifyRefreshedKeys(keysDir string, lock cluster.Lock, nodeIdx cluster.NodeIdx) error {
+	keyFiles, err := keystore.LoadFilesUnordered(keysDir)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := keyFiles.SequencedKeys()
+	if err != nil {
+		return err
+	}
+
+	if len(secrets) != len(lock.Validators) {
+		return errors.New("refreshed validator keys count mismatch",
+			z.Int("keys", len(secrets)), z.Int("validators", len(lock.Validators)))
+	}
+
+	for i, val := range lock.Validators {
+		pubShare, err := val.PublicShare(nodeIdx.PeerIdx)
+		if err != nil {
+			return err
+		}
+
+		pubkey, err := tbls.SecretToPublicKey(secrets[i])
+		if err != nil {
+			return err
+		}
+
+		if pubkey != pubShare {
+			return errors.New("refreshed validator key doesn't match refreshed public share", z.Int("validator_index", i))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestRefreshVerify(t *testing.T) {
+	const (
+		nodes     = 4
+		threshold = 3
+		vals      = 2
+		nodeIdx   = 1
+	)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, secretShares := cluster.NewForT(t, vals, threshold, nodes, seed, random)
+
+	newLock, newShares := resplitLock(t, lock, p2pKeys, secretShares, threshold)
+
+	setup := func(t *testing.T, oldLock, newLock cluster.Lock, newShares [][]tbls.PrivateKey) refreshVerifyConfig {
+		t.Helper()
+
+		dataDir := t.TempDir()
+		outputDir := filepath.Join(dataDir, "refreshed")
+
+		require.NoError(t, k1util.Save(p2pKeys[nodeIdx], p2p.KeyPath(dataDir)))
+		writeTestLock(t, dataDir, oldLock)
+		writeTestLock(t, outputDir, newLock)
+
+		var secrets []tbls.PrivateKey
+		for _, shares := range newShares {
+			secrets = append(secrets, shares[nodeIdx])
+		}
+		keysDir, err := cluster.CreateValidatorKeysDir(outputDir)
+		require.NoError(t, err)
+		require.NoError(t, keystore.StoreKeysInsecure(secrets, keysDir, keystore.ConfirmInsecureKeys))
+
+		return refreshVerifyConfig{
+			DataDir:   dataDir,
+			OutputDir: outputDir,
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		conf := setup(t, lock, newLock, newShares)
+		require.NoError(t, runRefreshVerify(context.Background(), conf))
+	})
+
+	t.Run("not refreshed", func(t *testing.T) {
+		conf := setup(t, lock, lock, secretShares)
+		require.ErrorContains(t, runRefreshVerify(context.Background(), conf), "lock hashes are identical")
+	})
+
+	t.Run("previous keys", func(t *testing.T) {
+		conf := setup(t, lock, newLock, secretShares)
+		require.ErrorContains(t, runRefreshVerify(context.Background(), conf), "refreshed validator key doesn't match refreshed public share")
+	})
+
+	t.Run("different validators", func(t *testing.T) {
+		otherLock, _, _ := cluster.NewForT(t, vals, threshold, nodes, seed+1, random)
+		conf := setup(t, otherLock, newLock, newShares)
+		require.ErrorContains(t, runRefreshVerify(context.Background(), conf), "definition mismatch")
+	})
+}
+
+// resplitLock returns a copy of lock with new secret shares of the same validator private keys.
+func resplitLock(t *testing.T, lock cluster.Lock, p2pKeys []*k1.PrivateKey, secretShares [][]tbls.PrivateKey, threshold int) (cluster.Lock, [][]tbls.PrivateKey) {
+	t.Helper()
+
+	var (
+		newShares [][]tbls.PrivateKey
+		vals      []cluster.DistValidator
+	)
+	for vIdx, val := range lock.Validators {
+		oldShares := make(map[int]tbls.PrivateKey)
+		for i, s := range secretShares[vIdx] {
+			oldShares[i+1] = s
+		}
+
+		secret, err := tbls.RecoverSecret(oldShares, uint(len(oldShares)), uint(threshold))
+		require.NoError(t, err)
+
+		split, err := tbls.ThresholdSplit(secret, uint(len(oldShares)), uint(threshold))
+		require.NoError(t, err)
+
+		var (
+			shares    []tbls.PrivateKey
+			pubShares [][]byte
+		)
+		for i := 1; i <= len(split); i++ {
+			pubShare, err := tbls.SecretToPublicKey(split[i])
+			require.NoError(t, err)
+
+			shares = append(shares, split[i])
+			pubShares = append(pubShares, pubShare[:])
+		}
+
+		val.PubShares = pubShares
+		vals = append(vals, val)
+		newShares = append(newShares, shares)
+	}
+
+	lock.Validators = vals
+	lock, err := lock.SetLockHash()
+	require.NoError(t, err)
+
+	var sigs []tbls.Signature
+	for _, shares := range newShares {
+		for _, s := range shares {
+			sig, err := tbls.Sign(s, lock.LockHash)
+			require.NoError(t, err)
+			sigs = append(sigs, sig)
+		}
+	}
+
+	aggSig, err := tbls.Aggregate(sigs)
+	require.NoError(t, err)
+	lock.SignatureAggregate = aggSig[:]
+
+	lock.NodeSignatures = nil
+	for _, p2pKey := range p2pKeys {
+		nodeSig, err := k1util.Sign(p2pKey, lock.LockHash)
+		require.NoError(t, err)
+		lock.NodeSignatures = append(lock.NodeSignatures, nodeSig)
+	}
+
+	return lock, newShares
+}
+
+func writeTestLock(t *testing.T, dir string, lock cluster.Lock) {
+	t.Helper()
+
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cluster-lock.json"), b, 0o644))
+}
@@ -4,6 +4,7 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	libp2plog "github.com/ipfs/go-log/v2"
 	"github.com/spf13/cobra"
@@ -36,6 +37,8 @@ func newRelayCmd(runFunc func(context.Context, relay.Config) error) *cobra.Comma
 	bindDataDirFlag(cmd.Flags(), &config.DataDir)
 	bindRelayFlag(cmd, &config)
 	bindDebugMonitoringFlags(cmd, &config.MonitoringAddr, &config.DebugAddr, "")
+	cmd.Flags().StringVar(&config.AdminAddr, "admin-address", "", "Listening address (ip and port) for the relay admin API, allowing operators to update per-cluster quotas at runtime via PUT /quotas. It is not enabled by default and requires --admin-tls-client-ca-file, or --admin-basic-auth with TLS.")
+	bindHTTPAuthFlags(cmd, &config.AdminAuth, "admin", "relay admin API")
 	bindP2PFlags(cmd, &config.P2PConfig)
 	bindLogFlags(cmd.Flags(), &config.LogConfig)
 	bindLokiFlags(cmd.Flags(), &config.LogConfig)
@@ -51,6 +54,10 @@ func bindRelayFlag(cmd *cobra.Command, config *relay.Config) {
 	// Decrease defaults after this has been addressed https://github.com/libp2p/go-libp2p/issues/1713
 	cmd.Flags().IntVar(&config.MaxResPerPeer, "p2p-max-reservations", 512, "Updates max circuit reservations per peer (each valid for 30min)")
 	cmd.Flags().IntVar(&config.MaxConns, "p2p-max-connections", 16384, "Libp2p maximum number of peers that can connect to this relay.")
+	cmd.Flags().IntVar(&config.MaxPeersPerCluster, "p2p-max-peers-per-cluster", 0, "Default maximum number of peers per cluster that can connect to this relay, zero is unlimited. Adjustable at runtime via the admin server /quotas API.")
+	cmd.Flags().StringSliceVar(&config.HAPeerURLs, "ha-peer-urls", nil, "Comma separated list of monitoring server URLs of the other relay instances behind the same DNS name. Enables high-availability mode: instances share reservation state and route all peers of a cluster to the same instance.")
+	cmd.Flags().DurationVar(&config.HAFailoverTimeout, "ha-failover-timeout", 2*time.Minute, "Duration after which unreachable relay instances of the high-availability group are considered down and their clusters are routed to other instances.")
+	cmd.Flags().StringVar(&config.GeoIPFile, "geoip-file", "", "Optional CSV file mapping IP prefixes to countries (e.g. 1.2.3.0/24,DE) used for the geographic distribution of the monitoring server /stats/clusters API.")
 
 	var advertisePriv bool
 	cmd.Flags().BoolVar(&advertisePriv, "p2p-advertise-private-addresses", false, "Enable advertising of libp2p auto-detected private addresses. This doesn't affect manually provided p2p-external-ip/hostname.")
@@ -0,0 +1,367 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// haGossipPeriod is the period at which relay instances of a high-availability group exchange state.
+const haGossipPeriod = 5 * time.Second
+
+// reservationTracker is a relay ACL filter that allows all reservations and connections,
+// recording the peers with active circuit reservations.
+type reservationTracker struct {
+	ttl time.Duration
+
+	mu           sync.Mutex
+	reservations map[peer.ID]time.Time
+}
+
+// newReservationTracker returns a new reservation tracker for reservations valid for ttl.
+func newReservationTracker(ttl time.Duration) *reservationTracker {
+	return &reservationTracker{
+		ttl:          ttl,
+		reservations: make(map[peer.ID]time.Time),
+	}
+}
+
+func (t *reservationTracker) AllowReserve(pID peer.ID, _ ma.Multiaddr) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.reservations[pID] = time.Now().Add(t.ttl)
+
+	return true
+}
+
+func (*reservationTracker) AllowConnect(peer.ID, ma.Multiaddr, peer.ID) bool {
+	return true
+}
+
+// Reserved returns the peers with unexpired reservations.
+func (t *reservationTracker) Reserved() []peer.ID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var resp []peer.ID
+	for pID, expiry := range t.reservations {
+		if time.Now().After(expiry) {
+			delete(t.reservations, pID)
+			continue
+		}
+
+		resp = append(resp, pID)
+	}
+
+	return resp
+}
+
+// haInstance is the reservation state of a relay instance shared within a high-availability group.
+type haInstance struct {
+	PeerID string   `json:"peer_id"`
+	Addrs  []string `json:"addrs"`
+	// Clusters is the number of peers with reservations by cluster hash.
+	Clusters  map[string]int `json:"clusters"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// haGroup shares reservation state between multiple relay instances behind one DNS name.
+//
+// Circuit reservations are held by the libp2p relay service of a single instance and can't be transferred.
+// Instead, the group routes all peers of a cluster resolving the relay URL to the instance already
+// holding reservations of the cluster, so peers can connect to each other.
+// Since instances also share the state of other instances, a restarted instance restores its previous
+// state from the group, so peers of its clusters reconnecting after the restart re-reserve at
+// the same instance instead of being split across instances.
+type haGroup struct {
+	self            peer.ID
+	addrs           func() ([]ma.Multiaddr, error)
+	tracker         *reservationTracker
+	stats           *clusterStats
+	peerURLs        []string
+	failoverTimeout time.Duration
+	startedAt       time.Time
+
+	mu          sync.Mutex
+	instances   map[string]haInstance
+	restored    map[string]int
+	unreachable map[string]bool
+}
+
+// newHAGroup returns a new high-availability group of this relay instance and the instances at peerURLs.
+// Instances not updating their state for longer than failoverTimeout are considered down.
+func newHAGroup(self peer.ID, addrs func() ([]ma.Multiaddr, error), tracker *reservationTracker,
+	stats *clusterStats, peerURLs []string, failoverTimeout time.Duration,
+) *haGroup {
+	return &haGroup{
+		self:            self,
+		addrs:           addrs,
+		tracker:         tracker,
+		stats:           stats,
+		peerURLs:        peerURLs,
+		failoverTimeout: failoverTimeout,
+		startedAt:       time.Now(),
+		instances:       make(map[string]haInstance),
+		unreachable:     make(map[string]bool),
+	}
+}
+
+// Run exchanges state with the other instances periodically until the context is cancelled.
+func (g *haGroup) Run(ctx context.Context) {
+	ticker := time.NewTicker(haGossipPeriod)
+	defer ticker.Stop()
+
+	for {
+		for _, peerURL := range g.peerURLs {
+			g.gossip(ctx, peerURL)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// gossip fetches and merges the state known by the instance at the URL.
+func (g *haGroup) gossip(ctx context.Context, peerURL string) {
+	instances, err := fetchHAState(ctx, peerURL)
+	if ctx.Err() != nil {
+		return
+	}
+
+	g.mu.Lock()
+	wasUnreachable := g.unreachable[peerURL]
+	g.unreachable[peerURL] = err != nil
+	g.mu.Unlock()
+
+	if err != nil {
+		if !wasUnreachable {
+			log.Warn(ctx, "HA relay instance unreachable", err, z.Str("url", peerURL))
+		}
+
+		return
+	} else if wasUnreachable {
+		log.Info(ctx, "HA relay instance reachable again", z.Str("url", peerURL))
+	}
+
+	g.merge(ctx, instances)
+}
+
+// merge merges the instances into the known state, retaining the most recent state of each instance.
+// The state of a previous run of this instance is restored once.
+func (g *haGroup) merge(ctx context.Context, instances []haInstance) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, instance := range instances {
+		if instance.PeerID == g.self.String() {
+			if g.restored == nil && instance.UpdatedAt.Before(g.startedAt) {
+				g.restored = instance.Clusters
+				log.Info(ctx, "Restored previous reservation state from HA relay group", z.Any("clusters", instance.Clusters))
+			}
+
+			continue
+		}
+
+		if prev, ok := g.instances[instance.PeerID]; ok && !instance.UpdatedAt.After(prev.UpdatedAt) {
+			continue
+		}
+
+		g.instances[instance.PeerID] = instance
+	}
+}
+
+// Self returns the current state of this instance.
+// The restored state of its previous run is included until the failover timeout after startup,
+// allowing peers to reconnect and re-reserve.
+func (g *haGroup) Self() (haInstance, error) {
+	addrs, err := g.addrs()
+	if err != nil {
+		return haInstance{}, err
+	}
+
+	clusters := make(map[string]int)
+	for _, pID := range g.tracker.Reserved() {
+		if clusterHash, ok := g.stats.Cluster(pID); ok {
+			clusters[clusterHash]++
+		}
+	}
+
+	g.mu.Lock()
+	if time.Since(g.startedAt) < g.failoverTimeout {
+		for clusterHash, count := range g.restored {
+			clusters[clusterHash] = max(clusters[clusterHash], count)
+		}
+	}
+	g.mu.Unlock()
+
+	instance := haInstance{
+		PeerID:    g.self.String(),
+		Clusters:  clusters,
+		UpdatedAt: time.Now(),
+	}
+	for _, addr := range addrs {
+		instance.Addrs = append(instance.Addrs, addr.String())
+	}
+
+	return instance, nil
+}
+
+// State returns the state of all instances known by this instance, this instance first.
+// The state of instances not updated for longer than the reservation TTL is dropped,
+// since their reservations expired.
+func (g *haGroup) State() ([]haInstance, error) {
+	self, err := g.Self()
+	if err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	resp := []haInstance{self}
+	for pID, instance := range g.instances {
+		if time.Since(instance.UpdatedAt) > g.tracker.ttl {
+			delete(g.instances, pID)
+			continue
+		}
+
+		resp = append(resp, instance)
+	}
+
+	others := resp[1:]
+	sort.Slice(others, func(i, j int) bool {
+		return others[i].PeerID < others[j].PeerID
+	})
+
+	return resp, nil
+}
+
+// Route returns the instance peers of the cluster should use.
+// That is the live instance holding the most reservations of the cluster, preferring this instance.
+func (g *haGroup) Route(clusterHash string) (haInstance, error) {
+	instances, err := g.State()
+	if err != nil {
+		return haInstance{}, err
+	}
+
+	best := instances[0]
+	for _, instance := range instances[1:] {
+		if time.Since(instance.UpdatedAt) > g.failoverTimeout {
+			continue // Instance down.
+		}
+
+		if instance.Clusters[clusterHash] > best.Clusters[clusterHash] {
+			best = instance
+		}
+	}
+
+	return best, nil
+}
+
+// fetchHAState returns the state known by the relay instance at the URL.
+func fetchHAState(ctx context.Context, peerURL string) ([]haInstance, error) {
+	ctx, cancel := context.WithTimeout(ctx, haGossipPeriod)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(peerURL, "/")+"/ha/state", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "new request")
+	}
+
+	resp, err := new(http.Client).Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "query ha state")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return nil, errors.New("non-200 response querying ha state", z.Int("status_code", resp.StatusCode))
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read ha state")
+	}
+
+	var instances []haInstance
+	if err := json.Unmarshal(b, &instances); err != nil {
+		return nil, errors.Wrap(err, "unmarshal ha state")
+	}
+
+	return instances, nil
+}
+
+// newHAStateHandler returns a http handler serving the state known by this instance to the other instances.
+func newHAStateHandler(g *haGroup) func(ctx context.Context) ([]byte, error) {
+	return func(context.Context) ([]byte, error) {
+		instances, err := g.State()
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := json.Marshal(instances)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal json")
+		}
+
+		return b, nil
+	}
+}
+
+// newHAMultiaddrHandler returns a http handler serving the multiaddrs of the instance
+// the cluster of the requesting peer (identified by the Charon-Cluster header) should use.
+func newHAMultiaddrHandler(g *haGroup) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		clusterHash := r.Header.Get("Charon-Cluster")
+
+		instance, err := g.Route(clusterHash)
+		if err != nil {
+			log.Error(r.Context(), "Handler error", err, z.Str("path", r.URL.Path))
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		if instance.PeerID != g.self.String() {
+			log.Debug(r.Context(), "Routing cluster to HA relay instance",
+				z.Str("cluster_hash", clusterHash), z.Str("relay_peer", peerNameFromStr(instance.PeerID)))
+		}
+
+		b, err := json.Marshal(instance.Addrs)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write(b)
+	}
+}
+
+// peerNameFromStr returns the peer name of the peer ID string or the string itself if invalid.
+func peerNameFromStr(id string) string {
+	pID, err := peer.Decode(id)
+	if err != nil {
+		return id
+	}
+
+	return p2p.PeerName(pID)
+}
@@ -0,0 +1,119 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHAGroup(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		selfID  = peer.ID("self")
+		otherID = peer.ID("other")
+		downID  = peer.ID("down")
+	)
+
+	stats := newClusterStats(nil, 0)
+	tracker := newReservationTracker(time.Hour)
+
+	selfAddr := "/ip4/1.1.1.1/tcp/3610"
+	addrs := func() ([]ma.Multiaddr, error) {
+		return []ma.Multiaddr{ma.StringCast(selfAddr)}, nil
+	}
+
+	g := newHAGroup(selfID, addrs, tracker, stats, nil, time.Minute)
+
+	// Peers reserved on this instance are counted by cluster.
+	stats.Connected("p1", nil)
+	require.True(t, stats.SetCluster("p1", "aaa"))
+	require.True(t, tracker.AllowReserve("p1", nil))
+	require.True(t, tracker.AllowReserve("p2", nil)) // Unknown cluster.
+
+	self, err := g.Self()
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"aaa": 1}, self.Clusters)
+	require.Equal(t, []string{selfAddr}, self.Addrs)
+
+	g.merge(ctx, []haInstance{
+		{PeerID: otherID.String(), Addrs: []string{"other"}, Clusters: map[string]int{"aaa": 1, "bbb": 2}, UpdatedAt: time.Now()},
+		{PeerID: downID.String(), Addrs: []string{"down"}, Clusters: map[string]int{"ccc": 2}, UpdatedAt: time.Now().Add(-2 * time.Minute)},
+		// State of the previous run of this instance.
+		{PeerID: selfID.String(), Clusters: map[string]int{"ddd": 3}, UpdatedAt: time.Now().Add(-time.Second)},
+	})
+
+	route := func(clusterHash string) string {
+		instance, err := g.Route(clusterHash)
+		require.NoError(t, err)
+
+		return instance.PeerID
+	}
+
+	require.Equal(t, selfID.String(), route("aaa"))  // Ties prefer this instance.
+	require.Equal(t, otherID.String(), route("bbb")) // Other instance holds reservations.
+	require.Equal(t, selfID.String(), route("ccc"))  // Instance holding reservations is down.
+	require.Equal(t, selfID.String(), route("ddd"))  // Restored from previous run.
+	require.Equal(t, selfID.String(), route(""))
+
+	// Older state is ignored.
+	g.merge(ctx, []haInstance{
+		{PeerID: otherID.String(), Clusters: map[string]int{}, UpdatedAt: time.Now().Add(-time.Second)},
+	})
+	require.Equal(t, otherID.String(), route("bbb"))
+
+	instances, err := g.State()
+	require.NoError(t, err)
+	require.Len(t, instances, 3)
+	require.Equal(t, selfID.String(), instances[0].PeerID)
+	require.Equal(t, map[string]int{"aaa": 1, "ddd": 3}, instances[0].Clusters)
+}
+
+func TestHAGossip(t *testing.T) {
+	ctx := context.Background()
+
+	stats := newClusterStats(nil, 0)
+	addrs := func() ([]ma.Multiaddr, error) { return nil, nil }
+
+	remote := newHAGroup("remote", addrs, newReservationTracker(time.Hour), stats, nil, time.Minute)
+	remote.merge(ctx, []haInstance{
+		{PeerID: peer.ID("local").String(), Clusters: map[string]int{"aaa": 2}, UpdatedAt: time.Now().Add(-time.Minute)},
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /ha/state", wrapHandler(newHAStateHandler(remote)))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	local := newHAGroup("local", addrs, newReservationTracker(time.Hour), stats, []string{srv.URL}, time.Minute)
+	local.gossip(ctx, srv.URL)
+
+	instance, err := local.Self()
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"aaa": 2}, instance.Clusters)
+
+	// Unreachable instances are recorded.
+	local.gossip(ctx, "http://127.0.0.1:0")
+	require.True(t, local.unreachable["http://127.0.0.1:0"])
+
+	// Multiaddr handler routes clusters.
+	handler := newHAMultiaddrHandler(local)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Charon-Cluster", "aaa")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Empty(t, resp)
+}
@@ -48,6 +48,13 @@ var (
 		Name:      "ping_latency",
 		Help:      "Ping latency by peer and cluster",
 	}, []string{"peer", "peer_cluster"})
+
+	quotaRejectionsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "relay",
+		Subsystem: "p2p",
+		Name:      "quota_rejections_total",
+		Help:      "Total number of peers disconnected for exceeding the max peers quota of their cluster",
+	}, []string{"peer_cluster"})
 )
 
 // newBandwidthCounter returns a new bandwidth counter that stops counting when the context is cancelled.
@@ -27,7 +27,7 @@ import (
 )
 
 // startP2P returns a started libp2p host or an error.
-func startP2P(ctx context.Context, config Config, key *k1.PrivateKey, reporter metrics.Reporter) (host.Host, *prometheus.Registry, error) {
+func startP2P(ctx context.Context, config Config, key *k1.PrivateKey, reporter metrics.Reporter, acl relay.ACLFilter) (host.Host, *prometheus.Registry, error) {
 	if len(config.P2PConfig.TCPAddrs) == 0 {
 		return nil, nil, errors.New("p2p TCP addresses required")
 	}
@@ -67,7 +67,7 @@ func startP2P(ctx context.Context, config Config, key *k1.PrivateKey, reporter m
 
 	// This enables relay metrics: https://github.com/libp2p/go-libp2p/blob/master/p2p/protocol/circuitv2/relay/metrics.go
 	mt := relay.NewMetricsTracer(relay.WithRegisterer(promRegistry))
-	relayService, err := relay.New(tcpNode, relay.WithResources(relayResources), relay.WithMetricsTracer(mt))
+	relayService, err := relay.New(tcpNode, relay.WithResources(relayResources), relay.WithMetricsTracer(mt), relay.WithACL(acl))
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "new relay service")
 	}
@@ -83,8 +83,9 @@ func startP2P(ctx context.Context, config Config, key *k1.PrivateKey, reporter m
 
 const unknownCluster = "unknown"
 
-// monitorConnections blocks instrumenting peer connection metrics until the context is closed.
-func monitorConnections(ctx context.Context, tcpNode host.Host, bwTuples <-chan bwTuple) {
+// monitorConnections blocks instrumenting peer connection metrics and cluster stats until the context is closed.
+// Peers exceeding their cluster's max peers quota are disconnected.
+func monitorConnections(ctx context.Context, tcpNode host.Host, bwTuples <-chan bwTuple, stats *clusterStats) {
 	// peerState tracks connection data per peer.
 	type peerState struct {
 		Active      int
@@ -122,6 +123,7 @@ func monitorConnections(ctx context.Context, tcpNode host.Host, bwTuples <-chan
 			if !ok {
 				continue // Peer not connected anymore
 			}
+			stats.AddBytes(tuple.ID, tuple.Size, tuple.Sent)
 			if tuple.Sent {
 				networkTXCounter.WithLabelValues(state.Name, state.ClusterHash).Add(float64(tuple.Size))
 			} else {
@@ -133,6 +135,19 @@ func monitorConnections(ctx context.Context, tcpNode host.Host, bwTuples <-chan
 			if !ok {
 				continue // Peer not connected anymore
 			}
+
+			if !stats.SetCluster(info.ID, info.ClusterHash) {
+				log.Warn(ctx, "Disconnecting peer exceeding cluster max peers quota", nil,
+					z.Str("peer", state.Name), z.Str("peer_cluster", info.ClusterHash))
+				quotaRejectionsCounter.WithLabelValues(info.ClusterHash).Inc()
+
+				go func(p peer.ID) {
+					_ = tcpNode.Network().ClosePeer(p)
+				}(info.ID)
+
+				continue
+			}
+
 			state.ClusterHash = info.ClusterHash
 
 			newConnsCounter.WithLabelValues(state.Name, state.ClusterHash).Add(float64(state.New))
@@ -146,9 +161,11 @@ func monitorConnections(ctx context.Context, tcpNode host.Host, bwTuples <-chan
 			state := peers[e.Peer]
 			state.Name = p2p.PeerName(e.Peer)
 			if e.Connected {
+				stats.Connected(e.Peer, e.Addr)
 				state.Active++
 				state.New++
 			} else {
+				stats.Disconnected(e.Peer)
 				state.Active--
 			}
 			peers[e.Peer] = state
@@ -215,6 +232,7 @@ func hex7(input []byte) string {
 type connEvent struct {
 	Connected bool
 	Peer      peer.ID
+	Addr      ma.Multiaddr
 }
 
 // connLogger implements network.Notifiee and only sends logEvents on a channel since
@@ -227,6 +245,7 @@ func (l connLogger) Connected(_ network.Network, conn network.Conn) {
 	l.events <- connEvent{
 		Connected: true,
 		Peer:      conn.RemotePeer(),
+		Addr:      conn.RemoteMultiaddr(),
 	}
 }
 
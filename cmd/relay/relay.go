@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"net/http/pprof"
 	"os"
 	"sort"
 	"sync"
@@ -16,12 +15,15 @@ import (
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/relay"
 	ma "github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/httpauth"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/profiling"
 	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/eth2util/enr"
@@ -30,10 +32,13 @@ import (
 
 // Config defines the config of the relay.
 type Config struct {
-	DataDir         string
-	HTTPAddr        string
-	MonitoringAddr  string
-	DebugAddr       string
+	DataDir        string
+	HTTPAddr       string
+	MonitoringAddr string
+	DebugAddr      string
+	// AdminAddr enables the admin API updating cluster quotas on this listening address, protected by AdminAuth.
+	AdminAddr       string
+	AdminAuth       httpauth.Config
 	P2PConfig       p2p.Config
 	LogConfig       log.Config
 	AutoP2PKey      bool
@@ -41,6 +46,14 @@ type Config struct {
 	MaxConns        int
 	FilterPrivAddrs bool
 	LibP2PLogLevel  string
+	// MaxPeersPerCluster is the default max number of peers per cluster hash, zero is unlimited.
+	MaxPeersPerCluster int
+	// GeoIPFile is the optional CSV file mapping IP prefixes to countries.
+	GeoIPFile string
+	// HAPeerURLs are the monitoring server URLs of the other relay instances of a high-availability group.
+	HAPeerURLs []string
+	// HAFailoverTimeout is the duration after which unreachable instances of the group are considered down.
+	HAFailoverTimeout time.Duration
 }
 
 // Run starts an Obol libp2p-tcp-relay and udp-discv5 bootnode.
@@ -52,6 +65,12 @@ func Run(ctx context.Context, config Config) error {
 
 	version.LogInfo(ctx, "Charon relay starting")
 
+	if config.AdminAddr != "" && config.AdminAuth.BasicAuth == "" && config.AdminAuth.TLSClientCAFile == "" {
+		return errors.New("relay admin API requires authentication, set --admin-basic-auth or --admin-tls-client-ca-file")
+	} else if config.AdminAddr != "" && config.AdminAuth.BasicAuth != "" && !config.AdminAuth.TLSEnabled() {
+		return errors.New("relay admin API basic auth requires TLS, set --admin-tls-cert-file and --admin-tls-key-file")
+	}
+
 	key, err := p2p.LoadPrivKey(config.DataDir)
 	if errors.Is(err, os.ErrNotExist) {
 		if !config.AutoP2PKey {
@@ -68,25 +87,51 @@ func Run(ctx context.Context, config Config) error {
 		return err
 	}
 
+	if len(config.HAPeerURLs) > 0 && config.MonitoringAddr == "" {
+		return errors.New("monitoring address required for relay high-availability group")
+	}
+
+	geo, err := loadGeoIP(config.GeoIPFile)
+	if err != nil {
+		return err
+	}
+
+	stats := newClusterStats(geo, config.MaxPeersPerCluster)
+
 	bwTuples := make(chan bwTuple)
 	counter := newBandwidthCounter(ctx, bwTuples)
 
-	tcpNode, promRegistry, err := startP2P(ctx, config, key, counter)
+	tracker := newReservationTracker(relay.DefaultResources().ReservationTTL)
+
+	tcpNode, promRegistry, err := startP2P(ctx, config, key, counter, tracker)
 	if err != nil {
 		return err
 	}
 
-	go monitorConnections(ctx, tcpNode, bwTuples)
+	go monitorConnections(ctx, tcpNode, bwTuples, stats)
+
+	var ha *haGroup
+	if len(config.HAPeerURLs) > 0 {
+		ha = newHAGroup(tcpNode.ID(), func() ([]ma.Multiaddr, error) { return p2pMultiaddrs(tcpNode) },
+			tracker, stats, config.HAPeerURLs, config.HAFailoverTimeout)
+		go ha.Run(ctx)
 
-	// Start serving HTTP: ENR and monitoring.
-	serverErr := make(chan error, 3) // Buffer for 3 servers.
+		log.Info(ctx, "Relay high-availability group enabled", z.Any("peer_urls", config.HAPeerURLs))
+	}
+
+	// Start serving HTTP: ENR, monitoring and admin.
+	serverErr := make(chan error, 4) // Buffer for 4 servers.
 	go func() {
 		if config.HTTPAddr == "" {
 			return
 		}
 
 		mux := http.NewServeMux()
-		mux.HandleFunc("/", wrapHandler(newMultiaddrHandler(tcpNode)))
+		if ha != nil {
+			mux.HandleFunc("/", newHAMultiaddrHandler(ha))
+		} else {
+			mux.HandleFunc("/", wrapHandler(newMultiaddrHandler(tcpNode)))
+		}
 		mux.HandleFunc("/enr", wrapHandler(newENRHandler(ctx, tcpNode, key, config.P2PConfig)))
 		server := http.Server{Addr: config.HTTPAddr, Handler: mux, ReadHeaderTimeout: time.Second}
 		serverErr <- server.ListenAndServe()
@@ -99,25 +144,39 @@ func Run(ctx context.Context, config Config) error {
 			mux.Handle("/metrics", promhttp.InstrumentMetricHandler(
 				promRegistry, promhttp.HandlerFor(promRegistry, promhttp.HandlerOpts{}),
 			))
+			// Serve cluster stats and quotas API for capacity planning.
+			mux.HandleFunc("GET /stats/clusters", newClusterStatsHandler(stats))
+			mux.HandleFunc("GET /quotas", newQuotasHandler(stats))
+			if ha != nil {
+				mux.HandleFunc("GET /ha/state", wrapHandler(newHAStateHandler(ha)))
+			}
 
-			log.Info(ctx, "Monitoring server started", z.Str("address", config.MonitoringAddr))
+			log.Info(ctx, "Monitoring server started", z.Redact(z.Str("address", config.MonitoringAddr)))
 			server := http.Server{Addr: config.MonitoringAddr, Handler: mux, ReadHeaderTimeout: time.Second}
 			serverErr <- server.ListenAndServe()
 		}()
 	}
 
+	if config.AdminAddr != "" {
+		server, serve, err := newAdminServer(config.AdminAddr, config.AdminAuth, stats)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			log.Info(ctx, "Admin server started", z.Redact(z.Str("address", config.AdminAddr)))
+			serverErr <- serve()
+		}()
+		defer server.Close()
+	}
+
 	if config.DebugAddr != "" {
 		go func() {
 			debugMux := http.NewServeMux()
 
-			// Copied from net/http/pprof/pprof.go
-			debugMux.HandleFunc("/debug/pprof/", pprof.Index)
-			debugMux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
-			debugMux.HandleFunc("/debug/pprof/profile", pprof.Profile)
-			debugMux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
-			debugMux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+			profiling.RegisterHandlers(debugMux)
 
-			log.Info(ctx, "Debug server started", z.Str("address", config.DebugAddr))
+			log.Info(ctx, "Debug server started", z.Redact(z.Str("address", config.DebugAddr)))
 
 			server := http.Server{Addr: config.DebugAddr, Handler: debugMux, ReadHeaderTimeout: time.Second}
 			serverErr <- server.ListenAndServe()
@@ -147,26 +206,28 @@ func Run(ctx context.Context, config Config) error {
 	}
 }
 
-// newENRHandler returns a handler that returns the node's ID and public address encoded as a ENR.
+// newENRHandler returns a handler that returns the node's ID and public addresses encoded as a ENR.
+// The ENR includes the first external (or detected) IPv4 and IPv6 address as well as the first external hostname.
 func newENRHandler(ctx context.Context, tcpNode host.Host, p2pKey *k1.PrivateKey, config p2p.Config) func(ctx context.Context) ([]byte, error) {
 	// Resolve external hostname periodically.
 	var (
-		extHostMu sync.Mutex
-		extHostIP net.IP
+		extHostMu  sync.Mutex
+		extHostIPs []net.IP
 	)
 	go func() {
-		if config.ExternalHost == "" {
+		if len(config.ExternalHosts) == 0 {
 			return
 		}
 
+		extHost := config.ExternalHosts[0]
 		resolveExtHost := func() {
-			ip, err := net.LookupIP(config.ExternalHost)
+			ips, err := net.LookupIP(extHost)
 			if err != nil {
-				log.Warn(ctx, "Failed to resolve external host", err, z.Str("host", config.ExternalHost))
+				log.Warn(ctx, "Failed to resolve external host", err, z.Redact(z.Str("host", extHost)))
 				return
 			}
 			extHostMu.Lock()
-			extHostIP = ip[0]
+			extHostIPs = ips
 			extHostMu.Unlock()
 		}
 
@@ -185,12 +246,21 @@ func newENRHandler(ctx context.Context, tcpNode host.Host, p2pKey *k1.PrivateKey
 		}
 	}()
 
-	// getExtHostIP returns the external host IP and true if it is set.
-	getExtHostIP := func() (net.IP, bool) {
+	// getExtIPs returns the external IPs if set, else the external host IPs.
+	getExtIPs := func() []net.IP {
+		var ips []net.IP
+		for _, ip := range config.ExternalIPs {
+			ips = append(ips, net.ParseIP(ip))
+		}
+
+		if len(ips) > 0 {
+			return ips
+		}
+
 		extHostMu.Lock()
 		defer extHostMu.Unlock()
 
-		return extHostIP, len(extHostIP) != 0
+		return extHostIPs
 	}
 
 	return func(context.Context) ([]byte, error) {
@@ -220,15 +290,30 @@ func newENRHandler(ctx context.Context, tcpNode host.Host, p2pKey *k1.PrivateKey
 			return nil, errors.New("invalid TCP address")
 		}
 
-		// Override IP with external IP or external hostname if set.
-		if config.ExternalIP != "" {
-			tcpAddr.IP = net.ParseIP(config.ExternalIP)
-		} else if extHostIP, ok := getExtHostIP(); ok {
-			tcpAddr.IP = extHostIP
+		// Override IP with external IPs or external hostname IPs if set.
+		ips := getExtIPs()
+		if len(ips) == 0 {
+			ips = []net.IP{tcpAddr.IP}
+		}
+
+		opts := []enr.Option{enr.WithUDP(9999)} // Include invalid dummy UDP port so v0.13 can parse the ENR.
+		var hasIP4, hasIP6 bool
+		for _, ip := range ips {
+			if ip.To4() != nil && !hasIP4 {
+				opts = append(opts, enr.WithIP(ip), enr.WithTCP(tcpAddr.Port))
+				hasIP4 = true
+			} else if ip.To4() == nil && !hasIP6 {
+				opts = append(opts, enr.WithIP6(ip), enr.WithTCP6(tcpAddr.Port))
+				hasIP6 = true
+			}
+		}
+
+		if len(config.ExternalHosts) > 0 {
+			opts = append(opts, enr.WithDNS(config.ExternalHosts[0]))
 		}
 
 		// Build the ENR
-		r, err := enr.New(p2pKey, enr.WithIP(tcpAddr.IP), enr.WithTCP(tcpAddr.Port), enr.WithUDP(9999)) // Include invalid dummy UDP port so v0.13 can parse the ENR.
+		r, err := enr.New(p2pKey, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -240,14 +325,9 @@ func newENRHandler(ctx context.Context, tcpNode host.Host, p2pKey *k1.PrivateKey
 // newMultiaddrHandler returns a handler that returns the nodes multiaddrs (as json array).
 func newMultiaddrHandler(tcpNode host.Host) func(ctx context.Context) ([]byte, error) {
 	return func(context.Context) ([]byte, error) {
-		p2pAddr, err := ma.NewMultiaddr(fmt.Sprintf("/p2p/%s", tcpNode.ID()))
+		addrs, err := p2pMultiaddrs(tcpNode)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to create p2p multiaddr")
-		}
-
-		var addrs []ma.Multiaddr
-		for _, addr := range tcpNode.Addrs() {
-			addrs = append(addrs, addr.Encapsulate(p2pAddr))
+			return nil, err
 		}
 
 		b, err := json.Marshal(addrs)
@@ -259,6 +339,21 @@ func newMultiaddrHandler(tcpNode host.Host) func(ctx context.Context) ([]byte, e
 	}
 }
 
+// p2pMultiaddrs returns the node's addresses encapsulating its peer ID.
+func p2pMultiaddrs(tcpNode host.Host) ([]ma.Multiaddr, error) {
+	p2pAddr, err := ma.NewMultiaddr(fmt.Sprintf("/p2p/%s", tcpNode.ID()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create p2p multiaddr")
+	}
+
+	var addrs []ma.Multiaddr
+	for _, addr := range tcpNode.Addrs() {
+		addrs = append(addrs, addr.Encapsulate(p2pAddr))
+	}
+
+	return addrs, nil
+}
+
 // wrapHandler returns a http handler by wrapping the provided function with error handling.
 func wrapHandler(handler func(ctx context.Context) (response []byte, err error)) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
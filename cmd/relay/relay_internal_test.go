@@ -68,6 +68,22 @@ func TestRunBootnodeAutoP2P(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRunAdminRequiresAuth(t *testing.T) {
+	config := Config{
+		DataDir:    t.TempDir(),
+		AutoP2PKey: true,
+		LogConfig:  log.DefaultConfig(),
+		AdminAddr:  testutil.AvailableAddr(t).String(),
+	}
+
+	err := Run(context.Background(), config)
+	require.ErrorContains(t, err, "relay admin API requires authentication")
+
+	config.AdminAuth.BasicAuth = "admin:secret"
+	err = Run(context.Background(), config)
+	require.ErrorContains(t, err, "relay admin API basic auth requires TLS")
+}
+
 func TestServeAddrs(t *testing.T) {
 	t.Run("multiaddrs", func(t *testing.T) {
 		testServeAddrs(t,
@@ -124,8 +140,8 @@ func TestServeAddrs(t *testing.T) {
 	t.Run("enr_ext_ip", func(t *testing.T) {
 		testServeAddrs(t,
 			p2p.Config{
-				TCPAddrs:   []string{testutil.AvailableAddr(t).String()},
-				ExternalIP: "222.222.222.222",
+				TCPAddrs:    []string{testutil.AvailableAddr(t).String()},
+				ExternalIPs: []string{"222.222.222.222"},
 			},
 			"enr",
 			func(t *testing.T, data []byte) bool {
@@ -148,8 +164,8 @@ func TestServeAddrs(t *testing.T) {
 	t.Run("enr_ext_host", func(t *testing.T) {
 		testServeAddrs(t,
 			p2p.Config{
-				TCPAddrs:     []string{testutil.AvailableAddr(t).String()},
-				ExternalHost: "www.google.com",
+				TCPAddrs:      []string{testutil.AvailableAddr(t).String()},
+				ExternalHosts: []string{"www.google.com"},
 			},
 			"enr",
 			func(t *testing.T, data []byte) bool {
@@ -0,0 +1,452 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package relay
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/httpauth"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const unknownCountry = "unknown"
+
+// clusterStats tracks relay usage statistics and enforces peer quotas by cluster hash.
+// It is safe for concurrent use.
+type clusterStats struct {
+	geo geoIP
+
+	mu           sync.Mutex
+	defaultQuota int
+	quotas       map[string]int
+	peers        map[peer.ID]*peerStat
+	clusters     map[string]*clusterTotals
+}
+
+// peerStat is the state of a connected peer.
+type peerStat struct {
+	Active  int
+	Cluster string
+	Country string
+}
+
+// clusterTotals are the cumulative totals of a cluster.
+type clusterTotals struct {
+	Connections   int64
+	SentBytes     int64
+	ReceivedBytes int64
+}
+
+// clusterSnapshot is the JSON response of the cluster stats API.
+type clusterSnapshot struct {
+	ClusterHash       string         `json:"cluster_hash"`
+	Peers             int            `json:"peers"`
+	ActiveConnections int            `json:"active_connections"`
+	TotalConnections  int64          `json:"total_connections"`
+	SentBytes         int64          `json:"sent_bytes"`
+	ReceivedBytes     int64          `json:"received_bytes"`
+	Countries         map[string]int `json:"countries"`
+	MaxPeers          int            `json:"max_peers"`
+}
+
+// quotasJSON is the JSON request and response of the quotas API.
+// A max peers quota of zero is unlimited.
+type quotasJSON struct {
+	DefaultMaxPeers int            `json:"default_max_peers"`
+	Clusters        map[string]int `json:"clusters"`
+}
+
+// newClusterStats returns a new cluster stats with the default max peers per cluster quota.
+func newClusterStats(geo geoIP, defaultQuota int) *clusterStats {
+	return &clusterStats{
+		geo:          geo,
+		defaultQuota: defaultQuota,
+		quotas:       make(map[string]int),
+		peers:        make(map[peer.ID]*peerStat),
+		clusters:     make(map[string]*clusterTotals),
+	}
+}
+
+// Connected records a new connection to the peer from the remote address.
+func (s *clusterStats) Connected(pID peer.ID, addr ma.Multiaddr) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.peers[pID]
+	if !ok {
+		state = &peerStat{Country: s.geo.Country(addr)}
+		s.peers[pID] = state
+	}
+	state.Active++
+
+	if state.Cluster != "" {
+		s.totals(state.Cluster).Connections++
+	}
+}
+
+// Disconnected records a closed connection to the peer.
+func (s *clusterStats) Disconnected(pID peer.ID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.peers[pID]
+	if !ok {
+		return
+	}
+
+	state.Active--
+	if state.Active <= 0 {
+		delete(s.peers, pID)
+	}
+}
+
+// SetCluster assigns the peer to the cluster, attributing its active connections to the cluster.
+// It returns false if the cluster's max peers quota is reached, in which case the peer isn't assigned.
+// Peers already assigned to the cluster are never rejected, so lowering a quota doesn't disconnect admitted peers.
+func (s *clusterStats) SetCluster(pID peer.ID, clusterHash string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.peers[pID]
+	if !ok || state.Cluster == clusterHash {
+		return true
+	}
+
+	if quota := s.quota(clusterHash); quota > 0 {
+		var count int
+		for _, other := range s.peers {
+			if other.Cluster == clusterHash {
+				count++
+			}
+		}
+
+		if count >= quota {
+			return false
+		}
+	}
+
+	state.Cluster = clusterHash
+	s.totals(clusterHash).Connections += int64(state.Active)
+
+	return true
+}
+
+// AddBytes attributes network bytes sent to or received from the peer to its cluster.
+func (s *clusterStats) AddBytes(pID peer.ID, size int64, sent bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.peers[pID]
+	if !ok || state.Cluster == "" {
+		return
+	}
+
+	if sent {
+		s.totals(state.Cluster).SentBytes += size
+	} else {
+		s.totals(state.Cluster).ReceivedBytes += size
+	}
+}
+
+// Cluster returns the cluster hash of the connected peer and true, or false if not known.
+func (s *clusterStats) Cluster(pID peer.ID) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.peers[pID]
+	if !ok || state.Cluster == "" {
+		return "", false
+	}
+
+	return state.Cluster, true
+}
+
+// Snapshot returns the current stats of all clusters sorted by cluster hash.
+func (s *clusterStats) Snapshot() []clusterSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots := make(map[string]*clusterSnapshot)
+	get := func(clusterHash string) *clusterSnapshot {
+		snapshot, ok := snapshots[clusterHash]
+		if !ok {
+			snapshot = &clusterSnapshot{
+				ClusterHash: clusterHash,
+				Countries:   make(map[string]int),
+				MaxPeers:    s.quota(clusterHash),
+			}
+			snapshots[clusterHash] = snapshot
+		}
+
+		return snapshot
+	}
+
+	for clusterHash, totals := range s.clusters {
+		snapshot := get(clusterHash)
+		snapshot.TotalConnections = totals.Connections
+		snapshot.SentBytes = totals.SentBytes
+		snapshot.ReceivedBytes = totals.ReceivedBytes
+	}
+
+	for _, state := range s.peers {
+		if state.Cluster == "" {
+			continue // Cluster not known yet.
+		}
+
+		snapshot := get(state.Cluster)
+		snapshot.Peers++
+		snapshot.ActiveConnections += state.Active
+		snapshot.Countries[state.Country]++
+	}
+
+	resp := make([]clusterSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		resp = append(resp, *snapshot)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].ClusterHash < resp[j].ClusterHash
+	})
+
+	return resp
+}
+
+// Quotas returns the current max peers quotas.
+func (s *clusterStats) Quotas() quotasJSON {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clusters := make(map[string]int)
+	for clusterHash, quota := range s.quotas {
+		clusters[clusterHash] = quota
+	}
+
+	return quotasJSON{
+		DefaultMaxPeers: s.defaultQuota,
+		Clusters:        clusters,
+	}
+}
+
+// SetQuotas replaces the max peers quotas.
+func (s *clusterStats) SetQuotas(quotas quotasJSON) error {
+	if quotas.DefaultMaxPeers < 0 {
+		return errors.New("negative default max peers quota")
+	}
+
+	clusters := make(map[string]int)
+	for clusterHash, quota := range quotas.Clusters {
+		if quota < 0 {
+			return errors.New("negative cluster max peers quota", z.Str("cluster_hash", clusterHash))
+		}
+		clusters[clusterHash] = quota
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.defaultQuota = quotas.DefaultMaxPeers
+	s.quotas = clusters
+
+	return nil
+}
+
+// quota returns the max peers quota of the cluster. The default quota doesn't apply to peers with unknown cluster,
+// since all peers not supporting the peerinfo protocol share it. It must be called with the lock held.
+func (s *clusterStats) quota(clusterHash string) int {
+	if quota, ok := s.quotas[clusterHash]; ok {
+		return quota
+	} else if clusterHash == unknownCluster {
+		return 0
+	}
+
+	return s.defaultQuota
+}
+
+// totals returns the totals of the cluster. It must be called with the lock held.
+func (s *clusterStats) totals(clusterHash string) *clusterTotals {
+	totals, ok := s.clusters[clusterHash]
+	if !ok {
+		totals = new(clusterTotals)
+		s.clusters[clusterHash] = totals
+	}
+
+	return totals
+}
+
+// geoIP maps IP prefixes to ISO country codes.
+type geoIP []geoPrefix
+
+// geoPrefix is an IP prefix and its country.
+type geoPrefix struct {
+	Prefix  netip.Prefix
+	Country string
+}
+
+// loadGeoIP returns the geoIP mapping from a CSV file with "prefix,country" lines, e.g. "1.2.3.0/24,DE".
+// Empty lines and lines starting with # are ignored. It returns an empty mapping if the filename is empty.
+func loadGeoIP(filename string) (geoIP, error) {
+	if filename == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "open geoip file")
+	}
+	defer f.Close()
+
+	return parseGeoIP(f)
+}
+
+// parseGeoIP returns the geoIP mapping from "prefix,country" CSV lines.
+func parseGeoIP(r io.Reader) (geoIP, error) {
+	var resp geoIP
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		prefixStr, country, ok := strings.Cut(text, ",")
+		if !ok {
+			return nil, errors.New("invalid geoip line", z.Int("line", line))
+		}
+
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(prefixStr))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid geoip prefix", z.Int("line", line))
+		}
+
+		resp = append(resp, geoPrefix{
+			Prefix:  prefix.Masked(),
+			Country: strings.ToUpper(strings.TrimSpace(country)),
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read geoip file")
+	}
+
+	// Sort by decreasing prefix length, so the first match is the most specific.
+	sort.SliceStable(resp, func(i, j int) bool {
+		return resp[i].Prefix.Bits() > resp[j].Prefix.Bits()
+	})
+
+	return resp, nil
+}
+
+// Country returns the country of the multiaddr's IP or "unknown".
+func (g geoIP) Country(addr ma.Multiaddr) string {
+	if len(g) == 0 || addr == nil {
+		return unknownCountry
+	}
+
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return unknownCountry
+	}
+
+	nip, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return unknownCountry
+	}
+	nip = nip.Unmap()
+
+	for _, p := range g {
+		if p.Prefix.Contains(nip) {
+			return p.Country
+		}
+	}
+
+	return unknownCountry
+}
+
+// newClusterStatsHandler returns a http handler serving the stats of all clusters.
+func newClusterStatsHandler(stats *clusterStats) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, stats.Snapshot())
+	}
+}
+
+// newAdminServer returns the relay admin API server and its serve function. It serves the quotas API, allowing
+// max peers quotas to be updated at runtime, on its own authenticated listener, since the monitoring server
+// is typically exposed for scraping and must remain read-only.
+func newAdminServer(addr string, auth httpauth.Config, stats *clusterStats) (*http.Server, func() error, error) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /quotas", newQuotasHandler(stats))
+	mux.HandleFunc("PUT /quotas", newQuotasHandler(stats))
+
+	handler, err := httpauth.Wrap(mux, auth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig, err := httpauth.TLSConfig(auth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	server := &http.Server{Addr: addr, Handler: handler, TLSConfig: tlsConfig, ReadHeaderTimeout: time.Second}
+	if tlsConfig != nil {
+		return server, func() error {
+			return server.ListenAndServeTLS("", "") // Certificates are provided by the TLS config.
+		}, nil
+	}
+
+	return server, server.ListenAndServe, nil
+}
+
+// newQuotasHandler returns a http handler serving and updating the max peers quotas.
+func newQuotasHandler(stats *clusterStats) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeJSON(w, stats.Quotas())
+			return
+		}
+
+		var quotas quotasJSON
+		if err := json.NewDecoder(r.Body).Decode(&quotas); err != nil {
+			http.Error(w, "invalid quotas json", http.StatusBadRequest)
+			return
+		}
+
+		if err := stats.SetQuotas(quotas); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		log.Info(r.Context(), "Relay cluster quotas updated",
+			z.Int("default_max_peers", quotas.DefaultMaxPeers), z.Any("clusters", quotas.Clusters))
+
+		writeJSON(w, stats.Quotas())
+	}
+}
+
+// writeJSON writes the JSON encoded response.
+func writeJSON(w http.ResponseWriter, resp any) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, "marshal response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,161 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package relay
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/httpauth"
+)
+
+func TestClusterStats(t *testing.T) {
+	geo, err := parseGeoIP(strings.NewReader("# comment\n1.2.0.0/16,de\n1.2.3.0/24,FR\n\n2001:db8::/32,US\n"))
+	require.NoError(t, err)
+
+	stats := newClusterStats(geo, 2)
+
+	addr := func(s string) ma.Multiaddr {
+		return ma.StringCast(s)
+	}
+
+	stats.Connected("p1", addr("/ip4/1.2.3.4/tcp/3610"))
+	stats.Connected("p1", addr("/ip4/1.2.3.4/tcp/3611"))
+	stats.Connected("p2", addr("/ip4/1.2.4.4/tcp/3610"))
+	stats.Connected("p3", addr("/ip6/2001:db8::1/tcp/3610"))
+	stats.Connected("p4", addr("/ip4/8.8.8.8/tcp/3610"))
+
+	// Bytes of peers with unknown cluster aren't attributed.
+	stats.AddBytes("p1", 100, true)
+
+	require.True(t, stats.SetCluster("p1", "aaa"))
+	require.True(t, stats.SetCluster("p2", "aaa"))
+	require.False(t, stats.SetCluster("p3", "aaa")) // Quota reached.
+	require.True(t, stats.SetCluster("p1", "aaa"))  // Already admitted.
+	require.True(t, stats.SetCluster("p3", "bbb"))
+	require.True(t, stats.SetCluster("p4", unknownCluster)) // Default quota doesn't apply to unknown.
+
+	stats.AddBytes("p1", 10, true)
+	stats.AddBytes("p2", 20, false)
+	stats.Disconnected("p1")
+
+	require.Equal(t, []clusterSnapshot{
+		{
+			ClusterHash:       "aaa",
+			Peers:             2,
+			ActiveConnections: 2,
+			TotalConnections:  3,
+			SentBytes:         10,
+			ReceivedBytes:     20,
+			Countries:         map[string]int{"FR": 1, "DE": 1},
+			MaxPeers:          2,
+		},
+		{
+			ClusterHash:       "bbb",
+			Peers:             1,
+			ActiveConnections: 1,
+			TotalConnections:  1,
+			Countries:         map[string]int{"US": 1},
+			MaxPeers:          2,
+		},
+		{
+			ClusterHash:       unknownCluster,
+			Peers:             1,
+			ActiveConnections: 1,
+			TotalConnections:  1,
+			Countries:         map[string]int{unknownCountry: 1},
+		},
+	}, stats.Snapshot())
+
+	// Increase the quota of the cluster at runtime.
+	require.NoError(t, stats.SetQuotas(quotasJSON{DefaultMaxPeers: 2, Clusters: map[string]int{"aaa": 3}}))
+	require.True(t, stats.SetCluster("p3", "aaa"))
+
+	require.ErrorContains(t, stats.SetQuotas(quotasJSON{DefaultMaxPeers: -1}), "negative default max peers quota")
+}
+
+func TestAdminServer(t *testing.T) {
+	stats := newClusterStats(nil, 0)
+
+	server, _, err := newAdminServer("", httpauth.Config{BasicAuth: "admin:secret"}, stats)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(server.Handler)
+	defer srv.Close()
+
+	put := func(credentials string) int {
+		req, err := http.NewRequest(http.MethodPut, srv.URL+"/quotas", strings.NewReader(`{"default_max_peers":4}`))
+		require.NoError(t, err)
+		if credentials != "" {
+			user, pass, _ := strings.Cut(credentials, ":")
+			req.SetBasicAuth(user, pass)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	require.Equal(t, http.StatusUnauthorized, put(""))
+	require.Equal(t, http.StatusUnauthorized, put("admin:wrong"))
+	require.Zero(t, stats.Quotas().DefaultMaxPeers)
+
+	require.Equal(t, http.StatusOK, put("admin:secret"))
+	require.Equal(t, 4, stats.Quotas().DefaultMaxPeers)
+}
+
+func TestQuotasHandler(t *testing.T) {
+	stats := newClusterStats(nil, 0)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /quotas", newQuotasHandler(stats))
+	mux.HandleFunc("PUT /quotas", newQuotasHandler(stats))
+	mux.HandleFunc("GET /stats/clusters", newClusterStatsHandler(stats))
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	put := func(body string) *http.Response {
+		req, err := http.NewRequest(http.MethodPut, srv.URL+"/quotas", strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+
+		return resp
+	}
+
+	require.Equal(t, http.StatusOK, put(`{"default_max_peers":4,"clusters":{"aaa":8}}`).StatusCode)
+	require.Equal(t, http.StatusBadRequest, put(`{"clusters":{"aaa":-1}}`).StatusCode)
+	require.Equal(t, http.StatusBadRequest, put(`invalid`).StatusCode)
+
+	resp, err := http.Get(srv.URL + "/quotas")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var quotas quotasJSON
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&quotas))
+	require.Equal(t, quotasJSON{DefaultMaxPeers: 4, Clusters: map[string]int{"aaa": 8}}, quotas)
+
+	stats.Connected(peer.ID("p1"), nil)
+	require.True(t, stats.SetCluster("p1", "aaa"))
+
+	resp, err = http.Get(srv.URL + "/stats/clusters")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var snapshots []clusterSnapshot
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&snapshots))
+	require.Len(t, snapshots, 1)
+	require.Equal(t, 8, snapshots[0].MaxPeers)
+	require.Equal(t, map[string]int{unknownCountry: 1}, snapshots[0].Countries)
+}
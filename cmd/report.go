@@ -0,0 +1,138 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/slareport"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// reportGenerateConfig is the config of the report generate command.
+type reportGenerateConfig struct {
+	PrivKeyFile  string
+	LockFile     string
+	ManifestFile string
+	SLAFile      string
+	From         string
+	To           string
+	OutputFile   string
+}
+
+func newReportCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "report",
+		Short: "Generate signed operator performance reports",
+		Long:  `Report subcommands generate signed reports of each operator's duty participation for contractual SLAs.`,
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+func newReportGenerateCmd(runFunc func(context.Context, io.Writer, reportGenerateConfig) error) *cobra.Command {
+	var config reportGenerateConfig
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a signed SLA report of each operator's duty participation",
+		Long: `Generates a report attributing the cluster's duty participation to each operator over the inclusive range
+of UTC days, from the participation recorded by this node (--sla-file of charon run). The report is signed by this
+node's charon-enr-private-key, so counterparties of multi-organization clusters can verify its origin.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.PrivKeyFile, "private-key-file", ".charon/charon-enr-private-key", "The path to the charon enr private key file signing the report.")
+	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file.")
+	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.SLAFile, "sla-file", ".charon/sla.json", "The path to the file with the daily duty participation of each operator recorded by charon run.")
+	cmd.Flags().StringVar(&config.From, "from", "", "The first UTC day of the report, e.g. 2024-01-01.")
+	cmd.Flags().StringVar(&config.To, "to", "", "The last UTC day of the report, e.g. 2024-01-31. Defaults to the first day.")
+	cmd.Flags().StringVar(&config.OutputFile, "output-file", "", "The path the signed JSON report is written to. Defaults to stdout.")
+
+	mustMarkFlagRequired(cmd, "from")
+
+	return cmd
+}
+
+// runReportGenerate generates the signed SLA report of the configured days and writes it to the output file or w.
+func runReportGenerate(_ context.Context, w io.Writer, config reportGenerateConfig) error {
+	from, err := time.Parse(slareport.DateFormat, config.From)
+	if err != nil {
+		return errors.Wrap(err, "invalid --from day", z.Str("from", config.From))
+	}
+
+	to := from
+	if config.To != "" {
+		to, err = time.Parse(slareport.DateFormat, config.To)
+		if err != nil {
+			return errors.Wrap(err, "invalid --to day", z.Str("to", config.To))
+		}
+	}
+
+	if _, err := os.Stat(config.SLAFile); err != nil {
+		return errors.Wrap(err, "sla file not found, is --sla-file of charon run enabled?", z.Str("path", config.SLAFile))
+	}
+
+	store, err := slareport.NewStore(config.SLAFile)
+	if err != nil {
+		return err
+	}
+
+	cluster, err := loadClusterManifest(config.ManifestFile, config.LockFile)
+	if err != nil {
+		return err
+	}
+
+	slaCluster, err := slareport.NewCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	key, err := k1util.Load(config.PrivKeyFile)
+	if err != nil {
+		return errors.Wrap(err, "load private key")
+	}
+
+	report, err := slareport.Generate(store, slaCluster, from, to)
+	if err != nil {
+		return err
+	}
+
+	signed, err := slareport.Sign(report, k1util.NewSigner(key))
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(signed, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal signed report")
+	}
+
+	if config.OutputFile == "" {
+		_, _ = fmt.Fprintln(w, string(b))
+		return nil
+	}
+
+	if err := os.WriteFile(config.OutputFile, b, 0o644); err != nil { //nolint:gosec // Not a secret.
+		return errors.Wrap(err, "write sla report", z.Str("path", config.OutputFile))
+	}
+
+	_, _ = fmt.Fprintf(w, "Wrote signed SLA report of %s to %s to %s\n", report.From, report.To, config.OutputFile)
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/slareport"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+func TestReportGenerate(t *testing.T) {
+	seed := 0
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, _ := cluster.NewForT(t, 1, 3, 4, seed, random)
+
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(lockFile, b, 0o644))
+
+	keyFile := filepath.Join(dir, "charon-enr-private-key")
+	require.NoError(t, k1util.Save(p2pKeys[1], keyFile))
+
+	slaFile := filepath.Join(dir, "sla.json")
+	store, err := slareport.NewStore(slaFile)
+	require.NoError(t, err)
+	store.Observe(context.Background(), core.NewAttesterDuty(1), false, "", "", nil, []int{1, 2, 3})
+	require.NoError(t, store.Save())
+
+	today := time.Now().UTC().Format(slareport.DateFormat)
+
+	config := reportGenerateConfig{
+		PrivKeyFile: keyFile,
+		LockFile:    lockFile,
+		SLAFile:     slaFile,
+		From:        today,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, runReportGenerate(context.Background(), &buf, config))
+
+	var signed slareport.SignedReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &signed))
+	require.NoError(t, slareport.Verify(signed))
+
+	signer, err := p2p.PeerIDFromKey(p2pKeys[1].PubKey())
+	require.NoError(t, err)
+	require.Equal(t, signer.String(), signed.Signer)
+	require.Equal(t, today, signed.Report.To)
+	require.Len(t, signed.Report.Operators, 4)
+	require.Equal(t, 1, signed.Report.Operators[0].Participated)
+	require.Zero(t, signed.Report.Operators[3].Participated)
+
+	t.Run("output file", func(t *testing.T) {
+		config := config
+		config.OutputFile = filepath.Join(dir, "report.json")
+		require.NoError(t, runReportGenerate(context.Background(), &buf, config))
+
+		b, err := os.ReadFile(config.OutputFile)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(b, &signed))
+		require.NoError(t, slareport.Verify(signed))
+	})
+
+	t.Run("invalid day", func(t *testing.T) {
+		config := config
+		config.To = "yesterday"
+		require.ErrorContains(t, runReportGenerate(context.Background(), &buf, config), "invalid --to day")
+	})
+
+	t.Run("missing sla file", func(t *testing.T) {
+		config := config
+		config.SLAFile = filepath.Join(dir, "missing.json")
+		require.ErrorContains(t, runReportGenerate(context.Background(), &buf, config), "sla file not found")
+	})
+}
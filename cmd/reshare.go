@@ -0,0 +1,85 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/dkg"
+)
+
+func newAddOperatorCmd(runFunc func(context.Context, dkg.ReshareConfig) error) *cobra.Command {
+	var config dkg.ReshareConfig
+
+	cmd := &cobra.Command{
+		Use:   "add-operator",
+		Short: "Add an operator to an existing cluster",
+		Long: `Participate in a ceremony adding a single operator to an existing cluster. The existing operators reshare their
+validator key shares to the new set of operators, without changing the distributed validator public keys. The new
+cluster manifest, approved by all existing operators, and validator keys are written to the output directory.
+Note that all existing operators and the new operator should run this command at the same time. The new operator's
+data directory must contain the existing cluster lock or manifest and its charon-enr-private-key.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runReshareCmd(cmd, runFunc, config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.AddOperatorENR, "operator-enr", "", "The ENR of the operator to add to the cluster.")
+	bindReshareFlags(cmd, &config)
+
+	return cmd
+}
+
+func newRemoveOperatorCmd(runFunc func(context.Context, dkg.ReshareConfig) error) *cobra.Command {
+	var config dkg.ReshareConfig
+
+	cmd := &cobra.Command{
+		Use:   "remove-operator",
+		Short: "Remove an operator from an existing cluster",
+		Long: `Participate in a ceremony removing a single operator from an existing cluster. The remaining operators reshare
+their validator key shares among themselves, without changing the distributed validator public keys, making the
+removed operator's key shares useless. The new cluster manifest, approved by all remaining operators, and validator
+keys are written to the output directory. Note that all remaining operators should run this command at the same time,
+and that at least the cluster threshold of operators must remain.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runReshareCmd(cmd, runFunc, config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.RemoveOperatorENR, "operator-enr", "", "The ENR of the operator to remove from the cluster.")
+	bindReshareFlags(cmd, &config)
+
+	return cmd
+}
+
+func bindReshareFlags(cmd *cobra.Command, config *dkg.ReshareConfig) {
+	bindDataDirFlag(cmd.Flags(), &config.DataDir)
+	cmd.Flags().StringVar(&config.OutputDir, "output-dir", ".charon/reshared", "The directory where the new cluster manifest and validator keys are stored.")
+	bindNoVerifyFlag(cmd.Flags(), &config.NoVerify)
+	bindP2PFlags(cmd, &config.P2P)
+	bindLogFlags(cmd.Flags(), &config.Log)
+	bindShutdownDelayFlag(cmd.Flags(), &config.ShutdownDelay)
+
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 1*time.Minute, "Timeout for the operator change process, should be increased if it times out.")
+
+	mustMarkFlagRequired(cmd, "operator-enr")
+}
+
+func runReshareCmd(cmd *cobra.Command, runFunc func(context.Context, dkg.ReshareConfig) error, config dkg.ReshareConfig) error {
+	if err := log.InitLogger(config.Log); err != nil {
+		return err
+	}
+	libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+	printLicense(cmd.Context())
+	printFlags(cmd.Context(), cmd.Flags())
+
+	return runFunc(cmd.Context(), config)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/dkg"
+)
+
+func newRotateOperatorKeyCmd(runFunc func(context.Context, dkg.RotateKeyConfig) error) *cobra.Command {
+	var config dkg.RotateKeyConfig
+
+	cmd := &cobra.Command{
+		Use:   "rotate-operator-key",
+		Short: "Rotate the ENR private key of a cluster operator",
+		Long: `Participate in a ceremony replacing the charon-enr-private-key of a single operator, for example after it was
+lost or compromised. The operator participates using its new charon-enr-private-key, while all other operators approve
+the rotation using their existing keys. The operator's validator key shares remain unchanged. The new cluster manifest
+is written to the output directory. Note that all operators should run this command at the same time, and that all
+data directories must contain the existing cluster lock or manifest.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printLicense(cmd.Context())
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.OperatorENR, "operator-enr", "", "The ENR of the operator's previous key being rotated.")
+	cmd.Flags().StringVar(&config.NewOperatorENR, "new-operator-enr", "", "The ENR of the operator's new key.")
+	bindDataDirFlag(cmd.Flags(), &config.DataDir)
+	cmd.Flags().StringVar(&config.OutputDir, "output-dir", ".charon/rotated", "The directory where the new cluster manifest is stored.")
+	bindNoVerifyFlag(cmd.Flags(), &config.NoVerify)
+	bindP2PFlags(cmd, &config.P2P)
+	bindLogFlags(cmd.Flags(), &config.Log)
+	bindShutdownDelayFlag(cmd.Flags(), &config.ShutdownDelay)
+
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 1*time.Minute, "Timeout for the operator key rotation process, should be increased if it times out.")
+
+	mustMarkFlagRequired(cmd, "operator-enr")
+	mustMarkFlagRequired(cmd, "new-operator-enr")
+
+	return cmd
+}
@@ -4,7 +4,11 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"net/url"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	libp2plog "github.com/ipfs/go-log/v2"
@@ -14,7 +18,9 @@ import (
 	"github.com/obolnetwork/charon/app"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/featureset"
+	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/p2p"
@@ -24,7 +30,11 @@ import (
 const eth2ClientTimeout = time.Second * 2
 
 func newRunCmd(runFunc func(context.Context, app.Config) error, unsafe bool) *cobra.Command {
-	var conf app.Config
+	var (
+		conf           app.Config
+		validateConfig bool
+		dryRun         bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "run",
@@ -39,31 +49,162 @@ func newRunCmd(runFunc func(context.Context, app.Config) error, unsafe bool) *co
 			printLicense(cmd.Context())
 			printFlags(cmd.Context(), cmd.Flags())
 
+			if validateConfig {
+				return app.ValidateConfig(cmd.Context(), conf)
+			}
+
+			if dryRun {
+				checks := app.Preflight(cmd.Context(), conf)
+				writePreflightReport(cmd.OutOrStdout(), checks)
+
+				for _, check := range checks {
+					if !check.Passed && !check.Skipped {
+						return errors.New("preflight failed", z.Str("check", check.Name))
+					}
+				}
+
+				return nil
+			}
+
+			if configFile, _ := cmd.Flags().GetString("config"); configFile != "" {
+				conf.ReloadConfig = newConfigReloader(cmd, configFile, unsafe)
+			}
+
+			conf.AdminExitFunc = adminExit
+
 			return runFunc(cmd.Context(), conf)
 		},
 	}
 
+	bindRunCmdFlags(cmd, &conf, unsafe)
+
+	cmd.Flags().BoolVar(&validateConfig, "validate-config", false, "Validates the full config, including files, URLs, listening addresses and the cluster lock matching the private key, then exits without starting the node.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Performs a full preflight of the node without scheduling any duties: loads the keys, verifies the cluster lock, connects to the beacon node, relays and peers and runs a test consensus round on dummy data, then exits with a pass/fail report.")
+
+	return cmd
+}
+
+// writePreflightReport writes the preflight checks as a table.
+func writePreflightReport(w io.Writer, checks []app.PreflightCheck) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CHECK\tRESULT\tDURATION\tDETAIL")
+
+	var failed int
+	for _, check := range checks {
+		result := "pass"
+		if check.Skipped {
+			result = "skipped"
+		} else if !check.Passed {
+			result = "fail"
+			failed++
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", check.Name, result, check.Duration.Round(time.Millisecond), check.Detail)
+	}
+	_ = tw.Flush()
+
+	if failed > 0 {
+		_, _ = fmt.Fprintf(w, "\nPreflight failed: %d of %d checks failed\n", failed, len(checks))
+	} else {
+		_, _ = fmt.Fprintln(w, "\nPreflight passed")
+	}
+}
+
+// bindRunCmdFlags binds all the run command flags to the config.
+func bindRunCmdFlags(cmd *cobra.Command, conf *app.Config, unsafe bool) {
 	if unsafe {
-		bindUnsafeRunFlags(cmd, &conf)
+		bindUnsafeRunFlags(cmd, conf)
 	}
 
 	bindPrivKeyFlag(cmd, &conf.PrivKeyFile, &conf.PrivKeyLocking)
-	bindRunFlags(cmd, &conf)
+	bindPKCS11Flags(cmd, &conf.P2PPKCS11)
+	bindRunFlags(cmd, conf)
+	bindTestnetFlags(cmd, &conf.TestnetConfig)
 	bindDebugMonitoringFlags(cmd, &conf.MonitoringAddr, &conf.DebugAddr, "127.0.0.1:3620")
+	bindHTTPAuthFlags(cmd, &conf.MonitoringAuth, "monitoring", "monitoring API")
+	bindHTTPAuthFlags(cmd, &conf.ValidatorAPIAuth, "validator-api", "validator API")
+	bindAdminFlags(cmd, conf)
 	bindNoVerifyFlag(cmd.Flags(), &conf.NoVerify)
 	bindP2PFlags(cmd, &conf.P2P)
 	bindLogFlags(cmd.Flags(), &conf.Log)
 	bindLokiFlags(cmd.Flags(), &conf.Log)
 	bindFeatureFlags(cmd.Flags(), &conf.Feature)
+}
 
-	return cmd
+// newConfigReloader returns a function that reloads the runtime config from the config file and environment variables.
+// Flags provided via the command line take precedence and are retained.
+func newConfigReloader(cmd *cobra.Command, configFile string, unsafe bool) func() (app.RuntimeConfig, error) {
+	return func() (app.RuntimeConfig, error) {
+		var conf app.Config
+		reloaded := &cobra.Command{Use: cmd.Use}
+		bindRunCmdFlags(reloaded, &conf, unsafe)
+
+		var err error
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			target := reloaded.Flags().Lookup(f.Name)
+			if target == nil {
+				// Root and command only flags aren't reloaded, but are valid config keys.
+				reloaded.Flags().String(f.Name, "", f.Usage)
+				return
+			}
+
+			if !f.Changed || isConfigFlag(f) || err != nil {
+				return
+			}
+
+			err = copyFlag(reloaded.Flags(), target, f)
+		})
+		if err != nil {
+			return app.RuntimeConfig{}, err
+		}
+
+		if err := initializeConfig(reloaded, configFile); err != nil {
+			return app.RuntimeConfig{}, err
+		}
+
+		if reloaded.PreRunE != nil {
+			if err := reloaded.PreRunE(reloaded, nil); err != nil {
+				return app.RuntimeConfig{}, err
+			}
+		}
+
+		return conf.RuntimeConfig(), nil
+	}
+}
+
+// copyFlag sets the target flag in the flag set to the value of the source flag.
+func copyFlag(flags *pflag.FlagSet, target, source *pflag.Flag) error {
+	if sliceVal, ok := source.Value.(pflag.SliceValue); ok {
+		targetVal, ok := target.Value.(pflag.SliceValue)
+		if !ok {
+			return errors.New("mismatching flag types", z.Str("flag", source.Name))
+		}
+
+		if err := targetVal.Replace(sliceVal.GetSlice()); err != nil {
+			return errors.Wrap(err, "copy flag", z.Str("flag", source.Name))
+		}
+		target.Changed = true
+
+		return nil
+	}
+
+	val := source.Value.String()
+	if source.Value.Type() == "stringToString" {
+		val = strings.TrimSuffix(strings.TrimPrefix(val, "["), "]")
+	}
+
+	if err := flags.Set(source.Name, val); err != nil {
+		return errors.Wrap(err, "copy flag", z.Str("flag", source.Name))
+	}
+
+	return nil
 }
 
 // bindLokiFlags binds the loki flags to the config.
 // Note all commands that use this MUST also call log.SetLokiLabels
 // or logs will not be sent to loki.
 func bindLokiFlags(flags *pflag.FlagSet, config *log.Config) {
-	flags.StringSliceVar(&config.LokiAddresses, "loki-addresses", nil, "Enables sending of logfmt structured logs to these Loki log aggregation server addresses. This is in addition to normal stderr logs.")
+	flags.StringSliceVar(&config.LokiAddresses, "loki-addresses", nil, "Enables sending of logfmt structured logs to these Loki log aggregation server addresses. This is in addition to normal stderr logs. Logs are labelled by service, cluster hash, peer name and topic.")
 	flags.StringVar(&config.LokiService, "loki-service", "charon", "Service label sent with logs to Loki.")
 }
 
@@ -74,37 +215,85 @@ func bindNoVerifyFlag(flags *pflag.FlagSet, config *bool) {
 func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
 	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.KeyAttestationsDir, "key-attestations-dir", ".charon/key_attestations", "The directory containing operator key attestations. Attested operator keys replace the operators' previous keys until the attestations expire.")
 	cmd.Flags().StringSliceVar(&config.BeaconNodeAddrs, "beacon-node-endpoints", nil, "Comma separated list of one or more beacon node endpoint URLs.")
 	cmd.Flags().DurationVar(&config.BeaconNodeTimeout, "beacon-node-timeout", eth2ClientTimeout, "Timeout for the HTTP requests Charon makes to the configured beacon nodes.")
 	cmd.Flags().DurationVar(&config.BeaconNodeSubmitTimeout, "beacon-node-submit-timeout", eth2ClientTimeout, "Timeout for the submission-related HTTP requests Charon makes to the configured beacon nodes.")
 	cmd.Flags().StringVar(&config.ValidatorAPIAddr, "validator-api-address", "127.0.0.1:3600", "Listening address (ip and port) for validator-facing traffic proxying the beacon-node API.")
 	cmd.Flags().StringVar(&config.JaegerAddr, "jaeger-address", "", "Listening address for jaeger tracing.")
 	cmd.Flags().StringVar(&config.JaegerService, "jaeger-service", "charon", "Service name used for jaeger tracing.")
+	cmd.Flags().StringVar(&config.OTLPAddr, "otlp-address", "", "OTLP gRPC collector address for tracing, e.g. Grafana Tempo, either a plaintext host:port or a http(s):// URL. Trace context is propagated to peers so duties can be traced across all cluster nodes.")
+	cmd.Flags().StringVar(&config.OTLPServiceName, "otlp-service-name", "charon", "Service name used for OTLP tracing.")
 	cmd.Flags().BoolVar(&config.SimnetBMock, "simnet-beacon-mock", false, "Enables an internal mock beacon node for running a simnet.")
 	cmd.Flags().BoolVar(&config.SimnetVMock, "simnet-validator-mock", false, "Enables an internal mock validator client when running a simnet. Requires simnet-beacon-mock.")
+	cmd.Flags().StringVar(&config.SimnetVMockReportFile, "simnet-validator-mock-report-file", "", "The path to which the internal mock validator client writes its JSON duty report every epoch and on shutdown.")
 	cmd.Flags().StringVar(&config.SimnetValidatorKeysDir, "simnet-validator-keys-dir", ".charon/validator_keys", "The directory containing the simnet validator key shares.")
+	cmd.Flags().StringVar(&config.RemoteSignerAddr, "remote-signer-address", "", "Enables charon's internal validator client, requesting partial signatures from this Web3Signer-compatible remote signer holding the node's key shares (e.g. in a HSM or cloud KMS) and applying slashing protection. No other validator client should be connected.")
 	cmd.Flags().BoolVar(&config.BuilderAPI, "builder-api", false, "Enables the builder api. Will only produce builder blocks. Builder API must also be enabled on the validator client. Beacon node must be connected to a builder-relay to access the builder network.")
 	cmd.Flags().BoolVar(&config.SyntheticBlockProposals, "synthetic-block-proposals", false, "Enables additional synthetic block proposal duties. Used for testing of rare duties.")
 	cmd.Flags().DurationVar(&config.SimnetSlotDuration, "simnet-slot-duration", time.Second, "Configures slot duration in simnet beacon mock.")
 	cmd.Flags().BoolVar(&config.SimnetBMockFuzz, "simnet-beacon-mock-fuzz", false, "Configures simnet beaconmock to return fuzzed responses.")
-	cmd.Flags().StringVar(&config.TestnetConfig.Name, "testnet-name", "", "Name of the custom test network.")
-	cmd.Flags().StringVar(&config.TestnetConfig.GenesisForkVersionHex, "testnet-fork-version", "", "Genesis fork version in hex of the custom test network.")
-	cmd.Flags().Uint64Var(&config.TestnetConfig.ChainID, "testnet-chain-id", 0, "Chain ID of the custom test network.")
-	cmd.Flags().Int64Var(&config.TestnetConfig.GenesisTimestamp, "testnet-genesis-timestamp", 0, "Genesis timestamp of the custom test network.")
-	cmd.Flags().StringVar(&config.TestnetConfig.CapellaHardFork, "testnet-capella-hard-fork", "", "Capella hard fork version of the custom test network.")
+	cmd.Flags().StringVar(&config.SimnetBMockScenarioFile, "simnet-beacon-mock-scenario-file", "", "The path to a JSON scenario file scripting simnet beacon mock events: reorgs, delayed blocks, late attestation data and slashed or exited validators.")
 	cmd.Flags().StringVar(&config.ProcDirectory, "proc-directory", "", "Directory to look into in order to detect other stack components running on the host.")
 	cmd.Flags().StringVar(&config.ConsensusProtocol, "consensus-protocol", "", "Preferred consensus protocol name for the node. Selected automatically when not specified.")
 	cmd.Flags().StringVar(&config.Nickname, "nickname", "", "Human friendly peer nickname. Maximum 32 characters.")
 	cmd.Flags().StringSliceVar(&config.BeaconNodeHeaders, "beacon-node-headers", nil, "Comma separated list of headers formatted as header=value")
 	cmd.Flags().StringSliceVar(&config.FallbackBeaconNodeAddrs, "fallback-beacon-node-endpoints", nil, "A list of beacon nodes to use if the primary list are offline or unhealthy.")
+	cmd.Flags().StringVar(&config.ClustersFile, "clusters-file", "", "The path to a JSON file defining multiple clusters served by this process, each with its own cluster files, private key and listening addresses. Overrides the cluster file, private key and listening address flags.")
+	cmd.Flags().StringVar(&config.UpgradeTarget, "upgrade-target", "", "Signals the operator's intent to upgrade to the target charon version (e.g. v1.3) to all peers. See 'charon cluster upgrade-status'.")
+	cmd.Flags().BoolVar(&config.Standby, "standby", false, "Runs the node in warm standby mode for the operator's primary node using the same private key and key shares. The node stays connected and synced but doesn't sign until a majority of the other peers grants it the leadership lease after the primary node failed.")
+	cmd.Flags().DurationVar(&config.StandbyFailoverTimeout, "standby-failover-timeout", 3*time.Minute, "Duration after which peers' standby nodes are granted the leadership lease if their primary node isn't seen.")
+	cmd.Flags().BoolVar(&config.UpgradeGateConsensus, "upgrade-gate-consensus-protocol", false, "Only prefer the --consensus-protocol once all peers run at least the --upgrade-target version.")
+	cmd.Flags().StringVar(&config.SigningPolicyFile, "signing-policy-file", "", "The path to a JSON signing policy file denying the node's partial signatures by fee recipient, gas limit, graffiti, exit window or duty type. The file is reloaded on changes.")
+	cmd.Flags().StringVar(&config.ProposerConfigFile, "proposer-config-file", "", "The path to a JSON proposer config file overriding the cluster lock fee recipients per validator public key (\"proposer_config\") or for all validators (\"default_config\"). The file is reloaded on changes.")
+	cmd.Flags().StringVar(&config.ExecutionRPCEndpoint, "execution-rpc-endpoint", "", "Enables watching the deposit contract for deposits of the cluster validators via this execution layer JSON-RPC endpoint, reporting their activation progress via metrics and 'charon cluster status'.")
+	cmd.Flags().Uint64Var(&config.DepositStartBlock, "deposit-start-block", 0, "The execution layer block to start scanning for deposit contract events from, e.g. the block of the cluster creation.")
+	cmd.Flags().BoolVar(&config.RewardsTracking, "rewards-tracking", false, "Enables tracking the attestation and proposal rewards and estimated missed rewards of the cluster validators via the beacon node rewards API, served at /rewards on the monitoring API.")
+	cmd.Flags().StringVar(&config.RewardsReportDir, "rewards-report-dir", "", "Directory to write periodic CSV and JSON validator rewards reports to when rewards tracking is enabled. Set to empty to disable.")
+	cmd.Flags().DurationVar(&config.RewardsReportPeriod, "rewards-report-period", 24*time.Hour, "Period covered by each validator rewards report.")
+	cmd.Flags().StringVar(&config.ParticipationFile, "participation-file", ".charon/participation.json", "The path to the file persisting per peer duty participation counters, so participation metrics remain accurate across restarts. Set to empty to disable.")
+	cmd.Flags().StringVar(&config.SLAFile, "sla-file", ".charon/sla.json", "The path to the file persisting the daily duty participation of each operator for SLA reports, see 'charon report generate'. Set to empty to disable.")
+	cmd.Flags().StringVar(&config.SLAReportDir, "sla-report-dir", "", "Directory to write the signed SLA report of each operator's duty participation for each completed UTC day to.")
+	cmd.Flags().BoolVar(&config.MonitoringPprof, "monitoring-pprof", false, "Enables serving pprof endpoints at /debug/pprof/ on the monitoring API, e.g. for Parca to scrape continuous profiles.")
+	cmd.Flags().StringVar(&config.ProfilingPushAddr, "profiling-push-address", "", "Enables continuous profiling by pushing CPU and heap profiles to this Pyroscope server URL.")
+	cmd.Flags().DurationVar(&config.ProfilingPushInterval, "profiling-push-interval", 15*time.Second, "Interval of CPU profiling and pushing profiles to the Pyroscope server.")
+	cmd.Flags().StringVar(&config.TelemetryEndpoint, "telemetry-endpoint", "", "Opt-in to periodically reporting anonymized cluster health aggregates (charon version, cluster size and validator count buckets and duty success rates) to this HTTP endpoint. Disabled by default.")
+	cmd.Flags().DurationVar(&config.TelemetryInterval, "telemetry-interval", time.Hour, "Interval of reporting anonymized telemetry to the telemetry endpoint.")
+	cmd.Flags().StringVar(&config.MetricsRemoteWriteURL, "metrics-remote-write-url", "", "Enables pushing metrics to this Prometheus remote write endpoint URL, for nodes without inbound access for scraping.")
+	cmd.Flags().StringVar(&config.MetricsPushgatewayURL, "metrics-pushgateway-url", "", "Enables pushing metrics to this Prometheus Pushgateway URL, for nodes without inbound access for scraping.")
+	cmd.Flags().DurationVar(&config.MetricsPushInterval, "metrics-push-interval", 30*time.Second, "Interval of pushing metrics to the remote write endpoint or Pushgateway.")
+	cmd.Flags().StringSliceVar(&config.EventWebhookURLs, "event-webhook-urls", nil, "Comma separated list of webhook URLs to post JSON encoded cluster events to, e.g. duty scheduled, decided, broadcast and failed, consensus failures and peer (dis)connections.")
+	cmd.Flags().StringVar(&config.EventNATSURL, "event-nats-url", "", "Enables publishing JSON encoded cluster events to this NATS server URL.")
+	cmd.Flags().StringVar(&config.EventNATSSubject, "event-nats-subject", "charon.events", "NATS subject prefix of published cluster events, suffixed with the event type, e.g. charon.events.duty_failed.")
+	cmd.Flags().DurationVar(&config.ManifestReloadInterval, "manifest-reload-interval", time.Minute, "Interval to check the cluster manifest file for new signed mutations (e.g. added validators) which are applied at the next epoch boundary without restarting. Set to 0 to disable.")
 
 	wrapPreRunE(cmd, func(*cobra.Command, []string) error {
 		if len(config.BeaconNodeAddrs) == 0 && !config.SimnetBMock {
 			return errors.New("either flag 'beacon-node-endpoints' or flag 'simnet-beacon-mock=true' must be specified")
 		}
+		if config.RemoteSignerAddr != "" && config.SimnetVMock {
+			return errors.New("flags 'remote-signer-address' and 'simnet-validator-mock' are mutually exclusive")
+		}
+		if config.ProfilingPushAddr != "" && config.ProfilingPushInterval <= 0 {
+			return errors.New("flag 'profiling-push-interval' must be positive")
+		}
+		if config.TelemetryEndpoint != "" && config.TelemetryInterval <= 0 {
+			return errors.New("flag 'telemetry-interval' must be positive")
+		}
+		if (config.MetricsRemoteWriteURL != "" || config.MetricsPushgatewayURL != "") && config.MetricsPushInterval <= 0 {
+			return errors.New("flag 'metrics-push-interval' must be positive")
+		}
 		if len(config.Nickname) > 32 {
 			return errors.New("flag 'nickname' can not exceed 32 characters")
 		}
+		if config.UpgradeTarget != "" {
+			if _, err := version.Parse(config.UpgradeTarget); err != nil {
+				return errors.Wrap(err, "invalid flag 'upgrade-target'")
+			}
+		}
+		if config.UpgradeGateConsensus && (config.UpgradeTarget == "" || config.ConsensusProtocol == "") {
+			return errors.New("flag 'upgrade-gate-consensus-protocol' requires flags 'upgrade-target' and 'consensus-protocol'")
+		}
 		err := eth2util.ValidateBeaconNodeHeaders(config.BeaconNodeHeaders)
 		if err != nil {
 			return err
@@ -120,7 +309,23 @@ func bindUnsafeRunFlags(cmd *cobra.Command, config *app.Config) {
 
 func bindPrivKeyFlag(cmd *cobra.Command, privKeyFile *string, privkeyLockEnabled *bool) {
 	cmd.Flags().StringVar(privKeyFile, "private-key-file", ".charon/charon-enr-private-key", "The path to the charon enr private key file.")
-	cmd.Flags().BoolVar(privkeyLockEnabled, "private-key-file-lock", false, "Enables private key locking to prevent multiple instances using the same key.")
+	cmd.Flags().BoolVar(privkeyLockEnabled, "private-key-file-lock", false, "Enables private key locking to prevent multiple instances using the same key, or the same data dir if the key is held by a PKCS#11 HSM.")
+}
+
+// bindPKCS11Flags binds the flags configuring a PKCS#11 HSM held p2p identity (ENR) key.
+func bindPKCS11Flags(cmd *cobra.Command, config *k1util.PKCS11Config) {
+	cmd.Flags().StringVar(&config.Module, "p2p-pkcs11-module", "", "Path to the PKCS#11 module (shared library) of a HSM holding the secp256k1 p2p identity (ENR) key. Overrides the private key file. Requires OpenSC pkcs11-tool.")
+	cmd.Flags().StringVar(&config.TokenLabel, "p2p-pkcs11-token-label", "", "Label of the PKCS#11 token containing the p2p identity key.")
+	cmd.Flags().StringVar(&config.KeyID, "p2p-pkcs11-key-id", "", "Hex encoded ID of the p2p identity key in the PKCS#11 token.")
+	cmd.Flags().StringVar(&config.PINFile, "p2p-pkcs11-pin-file", "", "The path to the file containing the PKCS#11 token user PIN.")
+
+	wrapPreRunE(cmd, func(*cobra.Command, []string) error {
+		if config.Enabled() && (config.KeyID == "" || config.PINFile == "") {
+			return errors.New("flag 'p2p-pkcs11-module' requires flags 'p2p-pkcs11-key-id' and 'p2p-pkcs11-pin-file'")
+		}
+
+		return nil
+	})
 }
 
 func bindLogFlags(flags *pflag.FlagSet, config *log.Config) {
@@ -128,12 +333,21 @@ func bindLogFlags(flags *pflag.FlagSet, config *log.Config) {
 	flags.StringVar(&config.Level, "log-level", "info", "Log level; debug, info, warn or error")
 	flags.StringVar(&config.Color, "log-color", "auto", "Log color; auto, force, disable.")
 	flags.StringVar(&config.LogOutputPath, "log-output-path", "", "Path in which to write on-disk logs.")
+	flags.IntVar(&config.LogOutputMaxSize, "log-output-max-size", 100, "Maximum size in megabytes of the on-disk log file before it is rotated.")
+	flags.DurationVar(&config.LogOutputMaxAge, "log-output-max-age", 0, "Maximum age of rotated on-disk log files before they are deleted, rounded up to whole days. Zero retains them.")
+	flags.IntVar(&config.LogOutputMaxBackups, "log-output-max-backups", 10, "Maximum number of rotated on-disk log files to retain. Zero retains all.")
+	flags.DurationVar(&config.LogOutputRotateInterval, "log-output-rotate-interval", 0, "Interval at which the on-disk log file is rotated irrespective of its size, e.g. 24h. Zero disables time based rotation.")
+	flags.BoolVar(&config.LogOutputCompress, "log-output-compress", true, "Enables gzip compression of rotated on-disk log files.")
+	flags.StringToStringVar(&config.TopicLevels, "log-topic-levels", nil, "Comma separated log level overrides by topic, e.g. bcast=debug,p2p=warn.")
+	flags.IntVar(&config.ErrorSampleLimit, "log-error-sample-limit", 0, "Maximum number of identical warn and error logs per minute, excess logs are dropped. Zero disables sampling.")
+	flags.BoolVar(&config.Privacy, "log-privacy", false, "Redacts sensitive values, like peer IP addresses and validator public keys, from logs.")
+	flags.StringVar(&config.WindowsEventSource, "log-windows-event-source", "", "Windows event log source to write info, warn and error logs to, e.g. charon. Only supported on Windows. Empty disables it.")
 }
 
 func bindP2PFlags(cmd *cobra.Command, config *p2p.Config) {
 	cmd.Flags().StringSliceVar(&config.Relays, "p2p-relays", []string{"https://0.relay.obol.tech", "https://2.relay.obol.dev", "https://1.relay.obol.tech"}, "Comma-separated list of libp2p relay URLs or multiaddrs.")
-	cmd.Flags().StringVar(&config.ExternalIP, "p2p-external-ip", "", "The IP address advertised by libp2p. This may be used to advertise an external IP.")
-	cmd.Flags().StringVar(&config.ExternalHost, "p2p-external-hostname", "", "The DNS hostname advertised by libp2p. This may be used to advertise an external DNS.")
+	cmd.Flags().StringSliceVar(&config.ExternalIPs, "p2p-external-ip", nil, "Comma-separated list of IP addresses (v4 or v6) advertised by libp2p. This may be used to advertise external IPs.")
+	cmd.Flags().StringSliceVar(&config.ExternalHosts, "p2p-external-hostname", nil, "Comma-separated list of DNS hostnames advertised by libp2p. This may be used to advertise external DNS names. Hostnames are resolved periodically and connected peers are updated when the resolved addresses change.")
 	cmd.Flags().StringSliceVar(&config.TCPAddrs, "p2p-tcp-address", nil, "Comma-separated list of listening TCP addresses (ip and port) for libP2P traffic. Empty default doesn't bind to local port therefore only supports outgoing connections.")
 	cmd.Flags().BoolVar(&config.DisableReuseport, "p2p-disable-reuseport", false, "Disables TCP port reuse for outgoing libp2p connections.")
 
@@ -141,11 +355,11 @@ func bindP2PFlags(cmd *cobra.Command, config *p2p.Config) {
 		for _, relay := range config.Relays {
 			u, err := url.Parse(relay)
 			if err != nil {
-				return errors.Wrap(err, "parse relay address", z.Str("address", relay))
+				return errors.Wrap(err, "parse relay address", z.Redact(z.Str("address", relay)))
 			}
 
 			if u.Scheme == httpScheme {
-				log.Warn(cmd.Context(), "Insecure relay address provided, not HTTPS", nil, z.Str("address", relay))
+				log.Warn(cmd.Context(), "Insecure relay address provided, not HTTPS", nil, z.Redact(z.Str("address", relay)))
 			}
 		}
 
@@ -159,6 +373,39 @@ func bindFeatureFlags(flags *pflag.FlagSet, config *featureset.Config) {
 	flags.StringVar(&config.MinStatus, "feature-set", "stable", "Minimum feature set to enable by default: alpha, beta, or stable. Warning: modify at own risk.")
 }
 
+// bindTestnetFlags binds the custom test network flags to the network config.
+// Networks defined by a chain spec file are loaded before the command runs, with explicit testnet flags taking precedence.
+func bindTestnetFlags(cmd *cobra.Command, config *eth2util.Network) {
+	cmd.Flags().StringVar(&config.Name, "testnet-name", "", "Name of the custom test network.")
+	cmd.Flags().StringVar(&config.GenesisForkVersionHex, "testnet-fork-version", "", "Genesis fork version in hex of the custom test network.")
+	cmd.Flags().Uint64Var(&config.ChainID, "testnet-chain-id", 0, "Chain ID of the custom test network.")
+	cmd.Flags().Int64Var(&config.GenesisTimestamp, "testnet-genesis-timestamp", 0, "Genesis timestamp of the custom test network.")
+	cmd.Flags().StringVar(&config.CapellaHardFork, "testnet-capella-hard-fork", "", "Capella hard fork version of the custom test network.")
+	bindTestnetChainSpecFlag(cmd, config)
+}
+
+// bindTestnetChainSpecFlag binds the custom test network chain spec file flag, merging the network it defines
+// into the network config before the command runs.
+func bindTestnetChainSpecFlag(cmd *cobra.Command, config *eth2util.Network) {
+	var chainSpec string
+	cmd.Flags().StringVar(&chainSpec, "testnet-chain-spec", "", "Path to the consensus layer chain spec file (config.yaml) of a custom test network, e.g. a Kurtosis or ephemery devnet. Other testnet flags take precedence.")
+
+	wrapPreRunE(cmd, func(*cobra.Command, []string) error {
+		if chainSpec == "" {
+			return nil
+		}
+
+		network, err := eth2util.LoadNetworkSpec(chainSpec)
+		if err != nil {
+			return err
+		}
+
+		*config = eth2util.MergeNetwork(*config, network)
+
+		return nil
+	})
+}
+
 // wrapPreRunE wraps the provided preRunE function.
 func wrapPreRunE(cmd *cobra.Command, fn func(cmd *cobra.Command, args []string) error) {
 	preRunE := cmd.PreRunE // Allow multiple wraps of PreRunE.
@@ -3,9 +3,11 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -58,7 +60,7 @@ func TestBindPrivKeyFlag(t *testing.T) {
 			if test.WantErr {
 				require.Error(t, root.Execute())
 			} else {
-				_ = testutil.CreateTempCharonDir(t)
+				chdirTempCharonDir(t)
 				_, err := p2p.NewSavedPrivKey(".charon/charon-enr-private-key")
 				require.NoError(t, err)
 				require.NoError(t, root.Execute())
@@ -66,3 +68,15 @@ func TestBindPrivKeyFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestWritePreflightReport(t *testing.T) {
+	checks := []app.PreflightCheck{
+		{Name: "config", Passed: true, Detail: "urls, addresses and files are valid", Duration: time.Millisecond},
+		{Name: "beacon_node", Detail: "beacon node is syncing", Duration: 2 * time.Second},
+		{Name: "peers", Skipped: true, Detail: "skipped: p2p failed"},
+	}
+
+	var buf bytes.Buffer
+	writePreflightReport(&buf, checks)
+	testutil.RequireGoldenBytes(t, buf.Bytes())
+}
@@ -79,6 +79,13 @@ func bindTestLogFlags(flags *pflag.FlagSet, config *log.Config) {
 	flags.StringVar(&config.Level, "log-level", "info", "Log level; debug, info, warn or error")
 	flags.StringVar(&config.Color, "log-color", "auto", "Log color; auto, force, disable.")
 	flags.StringVar(&config.LogOutputPath, "log-output-path", "", "Path in which to write on-disk logs.")
+	flags.IntVar(&config.LogOutputMaxSize, "log-output-max-size", 100, "Maximum size in megabytes of the on-disk log file before it is rotated.")
+	flags.DurationVar(&config.LogOutputMaxAge, "log-output-max-age", 0, "Maximum age of rotated on-disk log files before they are deleted, rounded up to whole days. Zero retains them.")
+	flags.IntVar(&config.LogOutputMaxBackups, "log-output-max-backups", 10, "Maximum number of rotated on-disk log files to retain. Zero retains all.")
+	flags.DurationVar(&config.LogOutputRotateInterval, "log-output-rotate-interval", 0, "Interval at which the on-disk log file is rotated irrespective of its size, e.g. 24h. Zero disables time based rotation.")
+	flags.BoolVar(&config.LogOutputCompress, "log-output-compress", true, "Enables gzip compression of rotated on-disk log files.")
+	flags.StringToStringVar(&config.TopicLevels, "log-topic-levels", nil, "Comma separated log level overrides by topic, e.g. bcast=debug,p2p=warn.")
+	flags.IntVar(&config.ErrorSampleLimit, "log-error-sample-limit", 0, "Maximum number of identical warn and error logs per minute, excess logs are dropped. Zero disables sampling.")
 }
 
 func listTestCases(cmd *cobra.Command) []string {
@@ -727,7 +727,7 @@ func startTCPNode(ctx context.Context, conf testPeersConfig) (host.Host, func(),
 	for i, enrString := range enrs {
 		enrRecord, err := enr.Parse(enrString)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "decode enr", z.Str("enr", enrString))
+			return nil, nil, errors.Wrap(err, "decode enr", z.Redact(z.Str("enr", enrString)))
 		}
 
 		p2pPeer, err := p2p.NewPeerFromENR(enrRecord, i)
@@ -850,7 +850,7 @@ func dialLibp2pTCPIP(ctx context.Context, address string) error {
 		return errors.Wrap(err, "io copy")
 	}
 	if !strings.Contains(buf.String(), "/multistream/1.0.0") {
-		return errors.New("multistream not found", z.Any("found", buf.String()), z.Any("address", address))
+		return errors.New("multistream not found", z.Any("found", buf.String()), z.Redact(z.Any("address", address)))
 	}
 
 	err = conn.Close()
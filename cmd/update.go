@@ -0,0 +1,368 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/openpgp" //nolint:staticcheck // Only used to verify detached release signatures.
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// updateChecksumsAsset is the release asset containing the sha256 checksums of all binaries.
+	updateChecksumsAsset = "checksums.txt"
+	// updateSignatureAsset is the release asset containing the detached OpenPGP signature of the checksums asset,
+	// created by 'gpg --detach-sign checksums.txt' in the release workflow.
+	updateSignatureAsset = "checksums.txt.sig"
+	// updatePollInterval is the interval at which the node's duties are queried while waiting to restart.
+	updatePollInterval = time.Second
+)
+
+// releaseSigningKey is the armored OpenPGP public key of the Obol charon release signing key.
+//
+//go:embed release-signing-key.asc
+var releaseSigningKey []byte
+
+type updateConfig struct {
+	ReleaseURL     string
+	BinaryPath     string
+	CheckOnly      bool
+	RestartCommand string
+	MonitoringAddr string
+	QuietSlots     int
+	RestartTimeout time.Duration
+	Timeout        time.Duration
+
+	// signingKey overrides the embedded release signing key, used for testing.
+	signingKey []byte
+}
+
+func newUpdateCmd(runFunc func(context.Context, io.Writer, updateConfig) error) *cobra.Command {
+	var config updateConfig
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update the charon binary to the latest release",
+		Long: `Checks for a newer charon release, downloads the binary for this platform, verifies the signature of the
+release checksums with the Obol release signing key built into charon and the checksum of the binary, and replaces the charon binary, keeping the previous binary with
+an '.old' suffix. The running node keeps using the previous binary until it is restarted.
+
+If a restart command is provided, it waits until the local node has no duties scheduled in the next slots
+before running it, e.g. 'systemctl restart charon'.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.ReleaseURL, "release-url", "https://api.github.com/repos/ObolNetwork/charon/releases/latest", "The URL of the GitHub API release to update to.")
+	cmd.Flags().StringVar(&config.BinaryPath, "binary-path", "", "The path of the charon binary to replace. Defaults to the running executable.")
+	cmd.Flags().BoolVar(&config.CheckOnly, "check-only", false, "Only checks whether a newer release is available, without downloading it.")
+	cmd.Flags().StringVar(&config.RestartCommand, "restart-command", "", "The shell command that restarts the node after the binary was replaced, e.g. 'systemctl restart charon'. It is run once the node has no upcoming duties. Disabled if empty.")
+	cmd.Flags().StringVar(&config.MonitoringAddr, "monitoring-address", "http://127.0.0.1:3620", "The address of the local charon node's monitoring API, used to wait for a duty-free moment to restart.")
+	cmd.Flags().IntVar(&config.QuietSlots, "restart-quiet-slots", 2, "The number of slots, including the current slot, without any duties required to restart the node.")
+	cmd.Flags().DurationVar(&config.RestartTimeout, "restart-timeout", 10*time.Minute, "The maximum time to wait for a duty-free moment to restart the node.")
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", time.Minute, "Timeout for each release and monitoring API request.")
+
+	wrapPreRunE(cmd, func(*cobra.Command, []string) error {
+		if config.QuietSlots <= 0 {
+			return errors.New("restart quiet slots must be positive")
+		}
+
+		return nil
+	})
+
+	return cmd
+}
+
+// updateRelease is the subset of a GitHub API release used to update.
+type updateRelease struct {
+	TagName string `json:"tag_name"`
+	Assets  []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// assetURL returns the download URL of the named asset.
+func (r updateRelease) assetURL(name string) (string, error) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset.URL, nil
+		}
+	}
+
+	return "", errors.New("release asset not found", z.Str("asset", name), z.Str("release", r.TagName))
+}
+
+// runUpdate updates the charon binary to the latest release and optionally restarts the node.
+func runUpdate(ctx context.Context, w io.Writer, config updateConfig) error {
+	var release updateRelease
+	b, err := updateDownload(ctx, config.ReleaseURL, config.Timeout)
+	if err != nil {
+		return err
+	} else if err := json.Unmarshal(b, &release); err != nil {
+		return errors.Wrap(err, "decode release")
+	}
+
+	latest, err := version.Parse(release.TagName)
+	if err != nil {
+		return errors.Wrap(err, "parse release version")
+	}
+
+	if !isNewerRelease(latest, version.Version) {
+		_, _ = fmt.Fprintf(w, "charon %s is up to date, latest release is %s\n", version.Version, latest)
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "charon %s is available, current version is %s\n", latest, version.Version)
+	if config.CheckOnly {
+		return nil
+	}
+
+	binaryPath := config.BinaryPath
+	if binaryPath == "" {
+		if binaryPath, err = os.Executable(); err != nil {
+			return errors.Wrap(err, "get executable path")
+		}
+	}
+
+	binary, err := downloadVerifiedBinary(ctx, config, release)
+	if err != nil {
+		return err
+	}
+
+	if err := stageBinary(binaryPath, binary); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "Updated %s to charon %s, previous binary kept at %s.old\n", binaryPath, latest, binaryPath)
+
+	if config.RestartCommand == "" {
+		_, _ = fmt.Fprintln(w, "Restart the node to run the new version")
+		return nil
+	}
+
+	if err := waitDutyFree(ctx, config); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "Node has no upcoming duties, restarting with: %s\n", config.RestartCommand)
+
+	restart := exec.CommandContext(ctx, "sh", "-c", config.RestartCommand)
+	restart.Stdout = w
+	restart.Stderr = w
+	if err := restart.Run(); err != nil {
+		return errors.Wrap(err, "run restart command")
+	}
+
+	return nil
+}
+
+// isNewerRelease returns true if the latest release is newer than the current version.
+// A release is newer than a pre-release of the same version, e.g. v1.2.0 is newer than v1.2-dev.
+func isNewerRelease(latest, current version.SemVer) bool {
+	if c := version.Compare(latest, current); c != 0 {
+		return c > 0
+	}
+
+	return current.PreRelease() && !latest.PreRelease()
+}
+
+// downloadVerifiedBinary returns the release binary for this platform after verifying the signature of the
+// release checksums with the release signing key and the checksum of the binary.
+func downloadVerifiedBinary(ctx context.Context, config updateConfig, release updateRelease) ([]byte, error) {
+	signingKey := releaseSigningKey
+	if len(config.signingKey) > 0 {
+		signingKey = config.signingKey
+	}
+
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(signingKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "parse release signing key")
+	}
+
+	download := func(name string) ([]byte, error) {
+		assetURL, err := release.assetURL(name)
+		if err != nil {
+			return nil, err
+		}
+
+		return updateDownload(ctx, assetURL, config.Timeout)
+	}
+
+	checksums, err := download(updateChecksumsAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := download(updateSignatureAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyReleaseSignature(keyring, checksums, sig); err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("charon-%s-%s-%s", release.TagName, runtime.GOOS, runtime.GOARCH)
+
+	want, err := releaseChecksum(checksums, name)
+	if err != nil {
+		return nil, err
+	}
+
+	binary, err := download(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if got := sha256.Sum256(binary); hex.EncodeToString(got[:]) != want {
+		return nil, errors.New("binary checksum mismatch", z.Str("asset", name),
+			z.Str("want", want), z.Str("got", hex.EncodeToString(got[:])))
+	}
+
+	return binary, nil
+}
+
+// verifyReleaseSignature returns an error if the binary or armored detached signature of the checksums
+// isn't signed by the keyring.
+func verifyReleaseSignature(keyring openpgp.EntityList, checksums []byte, sig []byte) error {
+	check := openpgp.CheckDetachedSignature
+	if bytes.HasPrefix(bytes.TrimSpace(sig), []byte("-----BEGIN")) {
+		check = openpgp.CheckArmoredDetachedSignature
+	}
+
+	if _, err := check(keyring, bytes.NewReader(checksums), bytes.NewReader(sig)); err != nil {
+		return errors.Wrap(err, "invalid checksums signature")
+	}
+
+	return nil
+}
+
+// releaseChecksum returns the hex encoded sha256 checksum of the named asset from
+// checksums formatted as output by sha256sum.
+func releaseChecksum(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+
+	return "", errors.New("release checksum not found", z.Str("asset", name))
+}
+
+// stageBinary replaces the binary at path with the new binary, keeping the previous binary with an '.old' suffix.
+// The new binary is written next to the previous binary and renamed, so the replacement is atomic.
+func stageBinary(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrap(err, "stat binary", z.Str("path", path))
+	}
+
+	staged := path + ".new"
+	//nolint:gosec // Binaries must be executable.
+	if err := os.WriteFile(staged, binary, info.Mode().Perm()|0o111); err != nil {
+		return errors.Wrap(err, "write staged binary", z.Str("path", staged))
+	}
+
+	previous, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "read binary", z.Str("path", path))
+	}
+
+	if err := os.WriteFile(path+".old", previous, info.Mode().Perm()); err != nil {
+		return errors.Wrap(err, "backup binary", z.Str("path", path+".old"))
+	}
+
+	if err := os.Rename(staged, path); err != nil {
+		return errors.Wrap(err, "replace binary", z.Str("path", path))
+	}
+
+	return nil
+}
+
+// waitDutyFree blocks until the local node has no duties scheduled in the next quiet slots, or until the restart timeout.
+func waitDutyFree(ctx context.Context, config updateConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, config.RestartTimeout)
+	defer cancel()
+
+	for {
+		var status app.DutiesStatus
+		err := getMonitoringJSON(ctx, config.MonitoringAddr, config.Timeout, "duties", nil, &status)
+		if err == nil && isDutyFree(status, config.QuietSlots) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return errors.Wrap(err, "timeout waiting for duty-free moment")
+			}
+
+			return errors.New("timeout waiting for duty-free moment")
+		case <-time.After(updatePollInterval):
+		}
+	}
+}
+
+// isDutyFree returns true if no duties are scheduled in the current slot and the following quiet slots.
+func isDutyFree(status app.DutiesStatus, quietSlots int) bool {
+	for _, duty := range status.Upcoming {
+		if duty.Slot < status.Slot+uint64(quietSlots) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// updateDownload returns the body of the HTTP GET response of the URL.
+func updateDownload(ctx context.Context, rawURL string, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "create http request")
+	}
+
+	resp, err := new(http.Client).Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "download", z.Str("url", rawURL))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read response", z.Str("url", rawURL))
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return nil, errors.New("http error", z.Str("url", rawURL), z.Int("status_code", resp.StatusCode))
+	}
+
+	return body, nil
+}
@@ -0,0 +1,224 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"        //nolint:staticcheck // Used to sign test releases.
+	"golang.org/x/crypto/openpgp/armor"  //nolint:staticcheck // Used to sign test releases.
+	"golang.org/x/crypto/openpgp/packet" //nolint:staticcheck // Used to sign test releases.
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/version"
+)
+
+func TestUpdate(t *testing.T) {
+	const tag = "v99.0.0"
+
+	key, armoredKey := newTestSigningKey(t)
+	binaryName := fmt.Sprintf("charon-%s-%s-%s", tag, runtime.GOOS, runtime.GOARCH)
+	newBinary := []byte("new charon binary")
+
+	checksum := sha256.Sum256(newBinary)
+	checksums := []byte(fmt.Sprintf("%x  charon-%s-other-arch\n%x  %s\n", sha256.Sum256(nil), tag, checksum, binaryName))
+
+	var sig bytes.Buffer
+	require.NoError(t, openpgp.DetachSign(&sig, key, bytes.NewReader(checksums), nil))
+
+	var duties app.DutiesStatus
+	assets := map[string][]byte{
+		updateChecksumsAsset: checksums,
+		updateSignatureAsset: sig.Bytes(),
+		binaryName:           newBinary,
+	}
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/release":
+			release := map[string]any{"tag_name": tag}
+			var list []map[string]string
+			for name := range assets {
+				list = append(list, map[string]string{"name": name, "browser_download_url": srv.URL + "/assets/" + name})
+			}
+			release["assets"] = list
+			require.NoError(t, json.NewEncoder(w).Encode(release))
+		case "/duties":
+			require.NoError(t, json.NewEncoder(w).Encode(duties))
+		default:
+			b, ok := assets[filepath.Base(r.URL.Path)]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(b)
+		}
+	}))
+	defer srv.Close()
+
+	newConfig := func(t *testing.T) updateConfig {
+		t.Helper()
+
+		binaryPath := filepath.Join(t.TempDir(), "charon")
+		require.NoError(t, os.WriteFile(binaryPath, []byte("old charon binary"), 0o755))
+
+		return updateConfig{
+			ReleaseURL:     srv.URL + "/release",
+			BinaryPath:     binaryPath,
+			MonitoringAddr: srv.URL,
+			QuietSlots:     2,
+			RestartTimeout: time.Second,
+			Timeout:        time.Second,
+			signingKey:     armoredKey,
+		}
+	}
+
+	t.Run("check only", func(t *testing.T) {
+		config := newConfig(t)
+		config.CheckOnly = true
+
+		var buf bytes.Buffer
+		require.NoError(t, runUpdate(context.Background(), &buf, config))
+		require.Contains(t, buf.String(), "charon v99.0.0 is available")
+
+		b, err := os.ReadFile(config.BinaryPath)
+		require.NoError(t, err)
+		require.Equal(t, "old charon binary", string(b))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		config := newConfig(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, runUpdate(context.Background(), &buf, config))
+		require.Contains(t, buf.String(), "Restart the node")
+
+		b, err := os.ReadFile(config.BinaryPath)
+		require.NoError(t, err)
+		require.Equal(t, newBinary, b)
+
+		b, err = os.ReadFile(config.BinaryPath + ".old")
+		require.NoError(t, err)
+		require.Equal(t, "old charon binary", string(b))
+
+		info, err := os.Stat(config.BinaryPath)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		config := newConfig(t)
+		restarted := filepath.Join(t.TempDir(), "restarted")
+		config.RestartCommand = "touch " + restarted
+
+		var buf bytes.Buffer
+		require.NoError(t, runUpdate(context.Background(), &buf, config))
+		require.FileExists(t, restarted)
+	})
+
+	t.Run("restart timeout", func(t *testing.T) {
+		duties = app.DutiesStatus{Slot: 10, Upcoming: []app.UpcomingDuty{{Slot: 11, Duty: "attester", Validators: 1}}}
+		defer func() { duties = app.DutiesStatus{} }()
+
+		config := newConfig(t)
+		restarted := filepath.Join(t.TempDir(), "restarted")
+		config.RestartCommand = "touch " + restarted
+
+		err := runUpdate(context.Background(), new(bytes.Buffer), config)
+		require.ErrorContains(t, err, "timeout waiting for duty-free moment")
+		require.NoFileExists(t, restarted)
+	})
+
+	t.Run("wrong signing key", func(t *testing.T) {
+		config := newConfig(t)
+		_, config.signingKey = newTestSigningKey(t)
+
+		err := runUpdate(context.Background(), new(bytes.Buffer), config)
+		require.ErrorContains(t, err, "invalid checksums signature")
+	})
+
+	t.Run("embedded signing key", func(t *testing.T) {
+		config := newConfig(t)
+		config.signingKey = nil
+
+		err := runUpdate(context.Background(), new(bytes.Buffer), config)
+		require.ErrorContains(t, err, "invalid checksums signature")
+	})
+
+	t.Run("armored signature", func(t *testing.T) {
+		var armored bytes.Buffer
+		require.NoError(t, openpgp.ArmoredDetachSign(&armored, key, bytes.NewReader(checksums), nil))
+		assets[updateSignatureAsset] = armored.Bytes()
+		defer func() { assets[updateSignatureAsset] = sig.Bytes() }()
+
+		require.NoError(t, runUpdate(context.Background(), new(bytes.Buffer), newConfig(t)))
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		assets[binaryName] = []byte("tampered charon binary")
+		defer func() { assets[binaryName] = newBinary }()
+
+		config := newConfig(t)
+
+		err := runUpdate(context.Background(), new(bytes.Buffer), config)
+		require.ErrorContains(t, err, "binary checksum mismatch")
+
+		b, err := os.ReadFile(config.BinaryPath)
+		require.NoError(t, err)
+		require.Equal(t, "old charon binary", string(b))
+	})
+}
+
+func TestIsNewerRelease(t *testing.T) {
+	parse := func(v string) version.SemVer {
+		t.Helper()
+		sv, err := version.Parse(v)
+		require.NoError(t, err)
+
+		return sv
+	}
+
+	require.True(t, isNewerRelease(parse("v1.3.0"), parse("v1.2.1")))
+	require.True(t, isNewerRelease(parse("v1.2.2"), parse("v1.2.1")))
+	require.True(t, isNewerRelease(parse("v1.2.0"), parse("v1.2-dev")))
+	require.False(t, isNewerRelease(parse("v1.2.1"), parse("v1.2.1")))
+	require.False(t, isNewerRelease(parse("v1.2-rc1"), parse("v1.2-dev")))
+	require.False(t, isNewerRelease(parse("v1.1.5"), parse("v1.2.0")))
+}
+
+func TestIsDutyFree(t *testing.T) {
+	status := app.DutiesStatus{Slot: 10, Upcoming: []app.UpcomingDuty{{Slot: 12, Duty: "proposer", Validators: 1}}}
+
+	require.True(t, isDutyFree(status, 2))
+	require.False(t, isDutyFree(status, 3))
+	require.True(t, isDutyFree(app.DutiesStatus{}, 2))
+}
+
+// newTestSigningKey returns a new OpenPGP release signing key and its armored public key.
+func newTestSigningKey(t *testing.T) (*openpgp.Entity, []byte) {
+	t.Helper()
+
+	key, err := openpgp.NewEntity("test", "", "test@obol.tech", &packet.Config{RSABits: 1024})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, key.Serialize(w))
+	require.NoError(t, w.Close())
+
+	return key, buf.Bytes()
+}
@@ -0,0 +1,84 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/dkg"
+)
+
+type verifyTranscriptConfig struct {
+	TranscriptFile string
+	LockFile       string
+	Log            log.Config
+}
+
+func newVerifyTranscriptCmd(runFunc func(context.Context, verifyTranscriptConfig) error) *cobra.Command {
+	var config verifyTranscriptConfig
+
+	cmd := &cobra.Command{
+		Use:   "verify-transcript",
+		Short: "Verify a DKG ceremony transcript",
+		Long: `Verify that a DKG ceremony transcript proves that the distributed validator keys and public shares in the cluster
+lock were generated honestly by all operators. This includes verifying each participant's commitments and proof of
+knowledge, the derived public keys and public shares and all operators' signatures over the transcript. No access to
+key shares is required.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.TranscriptFile, "transcript-file", ".charon/"+dkg.TranscriptFile, "The path to the DKG transcript file.")
+	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file.")
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	return cmd
+}
+
+// runVerifyTranscript verifies the DKG transcript against the cluster lock.
+func runVerifyTranscript(ctx context.Context, conf verifyTranscriptConfig) error {
+	lock, err := loadLockFile(conf.LockFile)
+	if err != nil {
+		return err
+	}
+
+	if err := lock.VerifySignatures(); err != nil {
+		return errors.Wrap(err, "verify cluster lock signatures")
+	}
+
+	b, err := os.ReadFile(conf.TranscriptFile)
+	if err != nil {
+		return errors.Wrap(err, "read transcript", z.Str("path", conf.TranscriptFile))
+	}
+
+	var transcript dkg.Transcript
+	if err := json.Unmarshal(b, &transcript); err != nil {
+		return errors.Wrap(err, "unmarshal transcript", z.Str("path", conf.TranscriptFile))
+	}
+
+	if err := dkg.VerifyTranscript(transcript, lock); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "DKG transcript verified successfully",
+		z.Str("lock_hash", fmt.Sprintf("%#x", lock.LockHash)),
+		z.Int("validators", len(lock.Validators)),
+		z.Int("operators", len(lock.Operators)),
+	)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/dkg"
+)
+
+func TestVerifyTranscript(t *testing.T) {
+	ctx := context.Background()
+
+	lock, _, _ := cluster.NewForT(t, 1, 3, 4, 0, rand.New(rand.NewSource(0)))
+	dir := t.TempDir()
+	writeTestLock(t, dir, lock)
+
+	conf := verifyTranscriptConfig{
+		TranscriptFile: filepath.Join(dir, dkg.TranscriptFile),
+		LockFile:       filepath.Join(dir, "cluster-lock.json"),
+	}
+
+	t.Run("missing transcript", func(t *testing.T) {
+		err := runVerifyTranscript(ctx, conf)
+		require.ErrorContains(t, err, "read transcript")
+	})
+
+	t.Run("other cluster", func(t *testing.T) {
+		b, err := json.Marshal(dkg.Transcript{
+			DefinitionHash: []byte("other definition"),
+			Threshold:      lock.Threshold,
+		})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(conf.TranscriptFile, b, 0o644))
+
+		err = runVerifyTranscript(ctx, conf)
+		require.ErrorContains(t, err, "transcript definition hash mismatch")
+	})
+}
@@ -16,6 +16,17 @@ import (
 
 type versionConfig struct {
 	Verbose bool
+	JSON    bool
+}
+
+// versionResult is the JSON output of the version command.
+type versionResult struct {
+	Version            string            `json:"version"`
+	GitCommitHash      string            `json:"git_commit_hash"`
+	GitCommitTime      string            `json:"git_commit_time"`
+	Package            string            `json:"package,omitempty"`
+	Dependencies       map[string]string `json:"dependencies,omitempty"`
+	ConsensusProtocols []string          `json:"consensus_protocols,omitempty"`
 }
 
 // newVersionCmd returns the version command.
@@ -27,12 +38,15 @@ func newVersionCmd(runFunc func(io.Writer, versionConfig)) *cobra.Command {
 		Short: "Print version and exit",
 		Long:  "Output version info",
 		Run: func(cmd *cobra.Command, args []string) { //nolint:revive // keep args variable name for clarity
+			conf.JSON = isJSONOutput(cmd)
 			runFunc(cmd.OutOrStdout(), conf)
 		},
 	}
 
 	bindVersionFlags(cmd.Flags(), &conf)
 
+	supportJSONOutput(cmd)
+
 	return cmd
 }
 
@@ -42,6 +56,12 @@ func bindVersionFlags(flags *pflag.FlagSet, config *versionConfig) {
 
 func runVersionCmd(out io.Writer, config versionConfig) {
 	hash, timestamp := version.GitCommit()
+
+	if config.JSON {
+		_ = writeJSON(out, newVersionResult(config.Verbose, hash, timestamp))
+		return
+	}
+
 	_, _ = fmt.Fprintf(out, "%v [git_commit_hash=%s,git_commit_time=%s]\n", version.Version, hash, timestamp)
 
 	if !config.Verbose {
@@ -70,3 +90,34 @@ func runVersionCmd(out io.Writer, config versionConfig) {
 		_, _ = fmt.Fprintf(out, "\t%v\n", protocol)
 	}
 }
+
+// newVersionResult returns the JSON output of the version command, including build info if verbose.
+func newVersionResult(verbose bool, hash, timestamp string) versionResult {
+	resp := versionResult{
+		Version:       version.Version.String(),
+		GitCommitHash: hash,
+		GitCommitTime: timestamp,
+	}
+
+	if !verbose {
+		return resp
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		resp.Package = buildInfo.Path
+		resp.Dependencies = make(map[string]string)
+
+		for _, dep := range buildInfo.Deps {
+			for dep.Replace != nil {
+				dep = dep.Replace
+			}
+			resp.Dependencies[dep.Path] = dep.Version
+		}
+	}
+
+	for _, protocol := range protocols.Protocols() {
+		resp.ConsensusProtocols = append(resp.ConsensusProtocols, string(protocol))
+	}
+
+	return resp
+}
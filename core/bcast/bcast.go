@@ -104,7 +104,7 @@ func (b Broadcaster) Broadcast(ctx context.Context, duty core.Duty, set core.Sig
 		if err == nil {
 			log.Info(ctx, "Successfully submitted block proposal to beacon node",
 				z.Any("delay", b.delayFunc(duty.Slot)),
-				z.Any("pubkey", pubkey),
+				z.Redact(z.Any("pubkey", pubkey)),
 				z.Bool("blinded", block.Blinded),
 			)
 		}
@@ -149,7 +149,7 @@ func (b Broadcaster) Broadcast(ctx context.Context, duty core.Duty, set core.Sig
 			if err == nil {
 				log.Info(ctx, "Successfully submitted voluntary exit to beacon node",
 					z.Any("delay", b.delayFunc(duty.Slot)),
-					z.Any("pubkey", pubkey),
+					z.Redact(z.Any("pubkey", pubkey)),
 				)
 			}
 		}
@@ -6,11 +6,11 @@ import (
 	"context"
 	"sync"
 
-	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/protocol"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/core/consensus/qbft"
 	"github.com/obolnetwork/charon/p2p"
@@ -22,7 +22,7 @@ type consensusController struct {
 	tcpNode          host.Host
 	sender           *p2p.Sender
 	peers            []p2p.Peer
-	p2pKey           *k1.PrivateKey
+	p2pKey           k1util.Signer
 	gaterFunc        core.DutyGaterFunc
 	deadlineFunc     core.DeadlineFunc
 	debugger         Debugger
@@ -37,7 +37,7 @@ type consensusController struct {
 
 // NewConsensusController creates a new consensus controller with the default consensus protocol.
 func NewConsensusController(ctx context.Context, tcpNode host.Host, sender *p2p.Sender,
-	peers []p2p.Peer, p2pKey *k1.PrivateKey, deadlineFunc core.DeadlineFunc,
+	peers []p2p.Peer, p2pKey k1util.Signer, deadlineFunc core.DeadlineFunc,
 	gaterFunc core.DutyGaterFunc, debugger Debugger,
 ) (core.ConsensusController, error) {
 	qbftDeadliner := core.NewDeadliner(ctx, "consensus.qbft", deadlineFunc)
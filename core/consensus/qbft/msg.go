@@ -179,7 +179,7 @@ func verifyMsgSig(msg *pbv1.QBFTMsg, pubkey *k1.PublicKey) (bool, error) {
 }
 
 // signMsg returns a copy of the proto message with a populated signature signed by the provided private key.
-func signMsg(msg *pbv1.QBFTMsg, privkey *k1.PrivateKey) (*pbv1.QBFTMsg, error) {
+func signMsg(msg *pbv1.QBFTMsg, privkey k1util.Signer) (*pbv1.QBFTMsg, error) {
 	clone, ok := proto.Clone(msg).(*pbv1.QBFTMsg)
 	if !ok {
 		return nil, errors.New("type assert qbft msg")
@@ -191,7 +191,7 @@ func signMsg(msg *pbv1.QBFTMsg, privkey *k1.PrivateKey) (*pbv1.QBFTMsg, error) {
 		return nil, err
 	}
 
-	clone.Signature, err = k1util.Sign(privkey, hash[:])
+	clone.Signature, err = privkey.Sign(hash[:])
 	if err != nil {
 		return nil, errors.Wrap(err, "sign")
 	}
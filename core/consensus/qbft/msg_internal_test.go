@@ -13,6 +13,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/core"
 	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
 	coreqbft "github.com/obolnetwork/charon/core/qbft"
@@ -45,7 +46,7 @@ func TestSigning(t *testing.T) {
 
 	msg := newRandomQBFTMsg(t)
 
-	signed, err := signMsg(msg, privkey)
+	signed, err := signMsg(msg, k1util.NewSigner(privkey))
 	require.NoError(t, err)
 
 	ok, err := verifyMsgSig(signed, privkey.PubKey())
@@ -7,9 +7,9 @@ import (
 	"fmt"
 	"strings"
 	"sync"
-	"time"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/jonboulle/clockwork"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -19,7 +19,9 @@ import (
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/featureset"
+	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/tracer"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/core/consensus/metrics"
@@ -119,9 +121,22 @@ func newDefinition(nodes int, subs func() []subscriber, roundTimer utils.RoundTi
 	}
 }
 
+// Option configures a Consensus.
+type Option func(*Consensus)
+
+// WithClock returns an option that uses the clock for round timers and instrumentation instead of the real clock,
+// e.g. a fake clock in tests.
+func WithClock(clock clockwork.Clock) Option {
+	return func(c *Consensus) {
+		c.clock = clock
+		c.timerFunc = utils.GetTimerFuncWithClock(clock)
+	}
+}
+
 // NewConsensus returns a new consensus QBFT component.
-func NewConsensus(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKey *k1.PrivateKey,
+func NewConsensus(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKey k1util.Signer,
 	deadliner core.Deadliner, gaterFunc core.DutyGaterFunc, snifferFunc func(*pbv1.SniffedConsensusInstance),
+	opts ...Option,
 ) (*Consensus, error) {
 	// Extract peer pubkeys.
 	keys := make(map[int64]*k1.PublicKey)
@@ -149,10 +164,15 @@ func NewConsensus(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKe
 		gaterFunc:   gaterFunc,
 		dropFilter:  log.Filter(),
 		timerFunc:   utils.GetTimerFunc(),
+		clock:       clockwork.NewRealClock(),
 		metrics:     metrics.NewConsensusMetrics(protocols.QBFTv2ProtocolID),
 	}
 	c.mutable.instances = make(map[core.Duty]*utils.InstanceIO[Msg])
 
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	return c, nil
 }
 
@@ -164,13 +184,14 @@ type Consensus struct {
 	peerLabels  []string
 	peers       []p2p.Peer
 	pubkeys     map[int64]*k1.PublicKey
-	privkey     *k1.PrivateKey
+	privkey     k1util.Signer
 	subs        []subscriber
 	deadliner   core.Deadliner
 	snifferFunc func(*pbv1.SniffedConsensusInstance)
 	gaterFunc   core.DutyGaterFunc
 	dropFilter  z.Field // Filter buffer overflow errors (possible DDoS)
 	timerFunc   utils.TimerFunc
+	clock       clockwork.Clock
 	metrics     metrics.ConsensusMetrics
 
 	// Mutable state
@@ -292,7 +313,7 @@ func (c *Consensus) propose(ctx context.Context, duty core.Duty, value proto.Mes
 	}
 
 	// Instrument consensus duration using decidedAt output.
-	proposedAt := time.Now()
+	proposedAt := c.clock.Now()
 	defer func() {
 		select {
 		case decidedAt := <-inst.DecidedAtCh:
@@ -338,6 +359,8 @@ func (c *Consensus) Participate(ctx context.Context, duty core.Duty) error {
 
 // Broadcast implements Broadcaster interface.
 func (c *Consensus) Broadcast(ctx context.Context, msg *pbv1.QBFTConsensusMsg) error {
+	msg.TraceContext = tracer.Inject(ctx)
+
 	for _, peer := range c.peers {
 		if peer.ID == c.tcpNode.ID() {
 			// Do not broadcast to self
@@ -391,7 +414,7 @@ func (c *Consensus) runInstance(ctx context.Context, duty core.Duty) (err error)
 	decideCallback := func(qcommit []qbft.Msg[core.Duty, [32]byte]) {
 		round := qcommit[0].Round()
 		decided = true
-		inst.DecidedAtCh <- time.Now()
+		inst.DecidedAtCh <- c.clock.Now()
 
 		leaderIndex := leader(duty, round, nodes)
 		leaderName := c.peers[leaderIndex].Name
@@ -436,7 +459,7 @@ func (c *Consensus) runInstance(ctx context.Context, duty core.Duty) (err error)
 	if !decided {
 		c.metrics.IncConsensusTimeout(duty.Type.String(), string(roundTimer.Type()))
 
-		return errors.New("consensus timeout", z.Str("duty", duty.String()))
+		return errors.WithCode(errors.New("consensus timeout", z.Str("duty", duty.String())), errors.CodeConsensusTimeout)
 	}
 
 	return nil
@@ -444,7 +467,7 @@ func (c *Consensus) runInstance(ctx context.Context, duty core.Duty) (err error)
 
 // handle processes an incoming consensus wire message.
 func (c *Consensus) handle(ctx context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
-	t0 := time.Now()
+	t0 := c.clock.Now()
 
 	pbMsg, ok := req.(*pbv1.QBFTConsensusMsg)
 	if !ok || pbMsg == nil {
@@ -462,6 +485,9 @@ func (c *Consensus) handle(ctx context.Context, _ peer.ID, req proto.Message) (p
 		return nil, false, errors.New("invalid duty", z.Any("duty", duty))
 	}
 
+	ctx, span := core.StartDutyTrace(tracer.Extract(ctx, pbMsg.GetTraceContext()), duty, "core/qbft.Handle")
+	defer span.End()
+
 	for _, justification := range pbMsg.GetJustification() {
 		if err := verifyMsg(justification, c.pubkeys); err != nil {
 			return nil, false, errors.Wrap(err, "invalid justification")
@@ -490,7 +516,7 @@ func (c *Consensus) handle(ctx context.Context, _ peer.ID, req proto.Message) (p
 	if ctx.Err() != nil {
 		return nil, false, errors.Wrap(ctx.Err(), "receive cancelled during verification",
 			z.Any("duty", duty),
-			z.Any("after", time.Since(t0)),
+			z.Any("after", c.clock.Since(t0)),
 		)
 	}
 
@@ -503,7 +529,7 @@ func (c *Consensus) handle(ctx context.Context, _ peer.ID, req proto.Message) (p
 		return nil, false, nil
 	case <-ctx.Done():
 		return nil, false, errors.Wrap(ctx.Err(), "timeout enqueuing receive buffer",
-			z.Any("duty", duty), z.Any("after", time.Since(t0)))
+			z.Any("duty", duty), z.Any("after", c.clock.Since(t0)))
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -372,7 +373,7 @@ func TestQBFTConsensus_handle(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			var tc Consensus
+			tc := Consensus{clock: clockwork.NewRealClock()}
 			deadliner := coremocks.NewDeadliner(t)
 			deadliner.On("Add", mock.Anything).Maybe().Return(true)
 			tc.deadliner = deadliner
@@ -456,6 +457,7 @@ func TestQBFTConsensusHandle(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Consensus{
 				gaterFunc: func(core.Duty) bool { return true },
+				clock:     clockwork.NewRealClock(),
 			}
 
 			_, _, err := c.handle(ctx, "", tt.msg)
@@ -472,7 +474,7 @@ func TestInstanceIO_MaybeStart(t *testing.T) {
 	})
 
 	t.Run("MaybeStart after handle", func(t *testing.T) {
-		var c Consensus
+		c := Consensus{clock: clockwork.NewRealClock()}
 		deadliner := coremocks.NewDeadliner(t)
 		deadliner.On("Add", mock.Anything).Return(true)
 		c.deadliner = deadliner
@@ -503,7 +505,7 @@ func TestInstanceIO_MaybeStart(t *testing.T) {
 	t.Run("Call Propose after handle", func(t *testing.T) {
 		ctx := context.Background()
 
-		var c Consensus
+		c := Consensus{clock: clockwork.NewRealClock()}
 		deadliner := coremocks.NewDeadliner(t)
 		deadliner.On("Add", mock.Anything).Return(true)
 		c.deadliner = deadliner
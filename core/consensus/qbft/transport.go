@@ -6,11 +6,11 @@ import (
 	"context"
 	"sync"
 
-	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/core"
 	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
 	"github.com/obolnetwork/charon/core/qbft"
@@ -25,7 +25,7 @@ type broadcaster interface {
 type transport struct {
 	// Immutable state
 	broadcaster broadcaster
-	privkey     *k1.PrivateKey
+	privkey     k1util.Signer
 	recvBuffer  chan qbft.Msg[core.Duty, [32]byte] // Instance inner receive buffer.
 	sniffer     *sniffer
 
@@ -36,7 +36,7 @@ type transport struct {
 }
 
 // newTransport creates a new qbftTransport.
-func newTransport(broadcaster broadcaster, privkey *k1.PrivateKey, valueCh <-chan proto.Message,
+func newTransport(broadcaster broadcaster, privkey k1util.Signer, valueCh <-chan proto.Message,
 	recvBuffer chan qbft.Msg[core.Duty, [32]byte], sniffer *sniffer,
 ) *transport {
 	return &transport{
@@ -176,7 +176,7 @@ func (t *transport) RecvBuffer() chan qbft.Msg[core.Duty, [32]byte] {
 func createMsg(typ qbft.MsgType, duty core.Duty,
 	peerIdx int64, round int64, vHash [32]byte, pr int64, pvHash [32]byte,
 	values map[[32]byte]*anypb.Any, justification []qbft.Msg[core.Duty, [32]byte],
-	privkey *k1.PrivateKey,
+	privkey k1util.Signer,
 ) (Msg, error) {
 	pbMsg := &pbv1.QBFTMsg{
 		Type:              int64(typ),
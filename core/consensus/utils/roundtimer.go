@@ -24,28 +24,24 @@ type TimerFunc func(core.Duty) RoundTimer
 
 // GetTimerFunc returns a timer function based on the enabled features.
 func GetTimerFunc() TimerFunc {
-	if featureset.Enabled(featureset.Linear) {
-		return func(duty core.Duty) RoundTimer {
-			// Linear timer only affects Proposer duty
-			if duty.Type == core.DutyProposer {
-				return NewLinearRoundTimer()
-			} else if featureset.Enabled(featureset.EagerDoubleLinear) {
-				return NewDoubleEagerLinearRoundTimer()
-			}
-
-			return NewIncreasingRoundTimer()
+	return GetTimerFuncWithClock(clockwork.NewRealClock())
+}
+
+// GetTimerFuncWithClock returns a timer function based on the enabled features using a custom clock.
+// Features are evaluated per duty, since negotiated features are activated at runtime.
+func GetTimerFuncWithClock(clock clockwork.Clock) TimerFunc {
+	return func(duty core.Duty) RoundTimer {
+		// Linear timer only affects Proposer duty
+		if featureset.Enabled(featureset.Linear) && duty.Type == core.DutyProposer {
+			return NewLinearRoundTimerWithClock(clock)
 		}
-	}
 
-	if featureset.Enabled(featureset.EagerDoubleLinear) {
-		return func(core.Duty) RoundTimer {
-			return NewDoubleEagerLinearRoundTimer()
+		if featureset.Enabled(featureset.EagerDoubleLinear) {
+			return NewDoubleEagerLinearRoundTimerWithClock(clock)
 		}
-	}
 
-	// Default to increasing round timer.
-	return func(core.Duty) RoundTimer {
-		return NewIncreasingRoundTimer()
+		// Default to increasing round timer.
+		return NewIncreasingRoundTimerWithClock(clock)
 	}
 }
 
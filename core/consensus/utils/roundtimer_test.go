@@ -195,3 +195,21 @@ func TestGetTimerFunc(t *testing.T) {
 	require.Equal(t, utils.TimerLinear, timerFunc(core.NewProposerDuty(1)).Type())
 	require.Equal(t, utils.TimerLinear, timerFunc(core.NewProposerDuty(2)).Type())
 }
+
+func TestGetTimerFuncWithClock(t *testing.T) {
+	featureset.DisableForT(t, featureset.EagerDoubleLinear)
+
+	fakeClock := clockwork.NewFakeClock()
+	timerC, stop := utils.GetTimerFuncWithClock(fakeClock)(core.NewAttesterDuty(0)).Timer(1)
+	defer stop()
+
+	// Timers are driven by the injected clock only.
+	select {
+	case <-timerC:
+		require.Fail(t, "timer fired before clock advanced")
+	default:
+	}
+
+	fakeClock.Advance(time.Second)
+	<-timerC
+}
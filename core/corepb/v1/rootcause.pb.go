@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: core/corepb/v1/rootcause.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RootCauseRequest requests a peer's tracker observation of a duty in the root cause protocol.
+type RootCauseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Duty          *Duty                  `protobuf:"bytes,1,opt,name=duty,proto3" json:"duty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RootCauseRequest) Reset() {
+	*x = RootCauseRequest{}
+	mi := &file_core_corepb_v1_rootcause_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RootCauseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RootCauseRequest) ProtoMessage() {}
+
+func (x *RootCauseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_rootcause_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RootCauseRequest.ProtoReflect.Descriptor instead.
+func (*RootCauseRequest) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_rootcause_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RootCauseRequest) GetDuty() *Duty {
+	if x != nil {
+		return x.Duty
+	}
+	return nil
+}
+
+// RootCauseResponse contains the peer's tracker observation of the requested duty.
+type RootCauseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Observation   *DutyObservation       `protobuf:"bytes,1,opt,name=observation,proto3" json:"observation,omitempty"` // Not set if the peer didn't analyse the duty (yet).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RootCauseResponse) Reset() {
+	*x = RootCauseResponse{}
+	mi := &file_core_corepb_v1_rootcause_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RootCauseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RootCauseResponse) ProtoMessage() {}
+
+func (x *RootCauseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_rootcause_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RootCauseResponse.ProtoReflect.Descriptor instead.
+func (*RootCauseResponse) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_rootcause_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RootCauseResponse) GetObservation() *DutyObservation {
+	if x != nil {
+		return x.Observation
+	}
+	return nil
+}
+
+// DutyObservation defines the outcome of a duty as analysed by a peer's tracker.
+type DutyObservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Failed        bool                   `protobuf:"varint,1,opt,name=failed,proto3" json:"failed,omitempty"`
+	Step          string                 `protobuf:"bytes,2,opt,name=step,proto3" json:"step,omitempty"`     // Step where the duty failed.
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Failure reason code.
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Shares        []int32                `protobuf:"varint,5,rep,packed,name=shares,proto3" json:"shares,omitempty"` // Share indexes of the partial signatures received by the peer.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DutyObservation) Reset() {
+	*x = DutyObservation{}
+	mi := &file_core_corepb_v1_rootcause_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DutyObservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DutyObservation) ProtoMessage() {}
+
+func (x *DutyObservation) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_rootcause_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DutyObservation.ProtoReflect.Descriptor instead.
+func (*DutyObservation) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_rootcause_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DutyObservation) GetFailed() bool {
+	if x != nil {
+		return x.Failed
+	}
+	return false
+}
+
+func (x *DutyObservation) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *DutyObservation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DutyObservation) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DutyObservation) GetShares() []int32 {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+var File_core_corepb_v1_rootcause_proto protoreflect.FileDescriptor
+
+var file_core_corepb_v1_rootcause_proto_rawDesc = string([]byte{
+	0x0a, 0x1e, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31,
+	0x2f, 0x72, 0x6f, 0x6f, 0x74, 0x63, 0x61, 0x75, 0x73, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31,
+	0x1a, 0x19, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31,
+	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3c, 0x0a, 0x10, 0x52,
+	0x6f, 0x6f, 0x74, 0x43, 0x61, 0x75, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x28, 0x0a, 0x04, 0x64, 0x75, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x75, 0x74, 0x79, 0x52, 0x04, 0x64, 0x75, 0x74, 0x79, 0x22, 0x56, 0x0a, 0x11, 0x52, 0x6f, 0x6f,
+	0x74, 0x43, 0x61, 0x75, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41,
+	0x0a, 0x0b, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75, 0x74, 0x79, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x83, 0x01, 0x0a, 0x0f, 0x44, 0x75, 0x74, 0x79, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x74, 0x65,
+	0x70, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x2f, 0x63, 0x68, 0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_core_corepb_v1_rootcause_proto_rawDescOnce sync.Once
+	file_core_corepb_v1_rootcause_proto_rawDescData []byte
+)
+
+func file_core_corepb_v1_rootcause_proto_rawDescGZIP() []byte {
+	file_core_corepb_v1_rootcause_proto_rawDescOnce.Do(func() {
+		file_core_corepb_v1_rootcause_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_core_corepb_v1_rootcause_proto_rawDesc), len(file_core_corepb_v1_rootcause_proto_rawDesc)))
+	})
+	return file_core_corepb_v1_rootcause_proto_rawDescData
+}
+
+var file_core_corepb_v1_rootcause_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_core_corepb_v1_rootcause_proto_goTypes = []any{
+	(*RootCauseRequest)(nil),  // 0: core.corepb.v1.RootCauseRequest
+	(*RootCauseResponse)(nil), // 1: core.corepb.v1.RootCauseResponse
+	(*DutyObservation)(nil),   // 2: core.corepb.v1.DutyObservation
+	(*Duty)(nil),              // 3: core.corepb.v1.Duty
+}
+var file_core_corepb_v1_rootcause_proto_depIdxs = []int32{
+	3, // 0: core.corepb.v1.RootCauseRequest.duty:type_name -> core.corepb.v1.Duty
+	2, // 1: core.corepb.v1.RootCauseResponse.observation:type_name -> core.corepb.v1.DutyObservation
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_core_corepb_v1_rootcause_proto_init() }
+func file_core_corepb_v1_rootcause_proto_init() {
+	if File_core_corepb_v1_rootcause_proto != nil {
+		return
+	}
+	file_core_corepb_v1_core_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_corepb_v1_rootcause_proto_rawDesc), len(file_core_corepb_v1_rootcause_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_core_corepb_v1_rootcause_proto_goTypes,
+		DependencyIndexes: file_core_corepb_v1_rootcause_proto_depIdxs,
+		MessageInfos:      file_core_corepb_v1_rootcause_proto_msgTypes,
+	}.Build()
+	File_core_corepb_v1_rootcause_proto = out.File
+	file_core_corepb_v1_rootcause_proto_goTypes = nil
+	file_core_corepb_v1_rootcause_proto_depIdxs = nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"sync/atomic"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
@@ -23,11 +24,13 @@ import (
 
 // New returns a new fetcher instance.
 func New(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string, builderEnabled bool) (*Fetcher, error) {
-	return &Fetcher{
+	f := &Fetcher{
 		eth2Cl:           eth2Cl,
 		feeRecipientFunc: feeRecipientFunc,
-		builderEnabled:   builderEnabled,
-	}, nil
+	}
+	f.builderEnabled.Store(builderEnabled)
+
+	return f, nil
 }
 
 // Fetcher fetches proposed duty data.
@@ -37,7 +40,12 @@ type Fetcher struct {
 	subs             []func(context.Context, core.Duty, core.UnsignedDataSet) error
 	aggSigDBFunc     func(context.Context, core.Duty, core.PubKey) (core.SignedData, error)
 	awaitAttDataFunc func(ctx context.Context, slot, commIdx uint64) (*eth2p0.AttestationData, error)
-	builderEnabled   bool
+	builderEnabled   atomic.Bool
+}
+
+// SetBuilderEnabled enables or disables prioritising builder blocks, e.g. when reloading config.
+func (f *Fetcher) SetBuilderEnabled(enabled bool) {
+	f.builderEnabled.Store(enabled)
 }
 
 // Subscribe registers a callback for fetched duties.
@@ -181,10 +189,10 @@ func (f *Fetcher) fetchAggregatorData(ctx context.Context, slot uint64, defSet c
 		if err != nil {
 			return core.UnsignedDataSet{}, err
 		} else if !ok {
-			log.Debug(ctx, "Attester not selected for aggregation duty", z.Any("pubkey", pubkey))
+			log.Debug(ctx, "Attester not selected for aggregation duty", z.Redact(z.Any("pubkey", pubkey)))
 			continue
 		}
-		log.Info(ctx, "Resolved attester aggregation duty", z.Any("pubkey", pubkey))
+		log.Info(ctx, "Resolved attester aggregation duty", z.Redact(z.Any("pubkey", pubkey)))
 
 		aggAtt, ok := aggAttByCommIdx[attDef.CommitteeIndex]
 		if ok {
@@ -252,7 +260,7 @@ func (f *Fetcher) fetchProposerData(ctx context.Context, slot uint64, defSet cor
 		copy(graffiti[:], fmt.Sprintf("charon/%v-%s", version.Version, commitSHA))
 
 		var bbf uint64
-		if f.builderEnabled {
+		if f.builderEnabled.Load() {
 			// This gives maximum priority to builder blocks:
 			// https://ethereum.github.io/beacon-APIs/#/Validator/produceBlockV3
 			bbf = math.MaxUint64
@@ -306,7 +314,7 @@ func (f *Fetcher) fetchContributionData(ctx context.Context, slot uint64, defSet
 		if err != nil {
 			return core.UnsignedDataSet{}, err
 		} else if !ok {
-			log.Debug(ctx, "Sync committee member not selected for contribution aggregation duty", z.Any("pubkey", pubkey))
+			log.Debug(ctx, "Sync committee member not selected for contribution aggregation duty", z.Redact(z.Any("pubkey", pubkey)))
 			continue
 		}
 
@@ -340,7 +348,7 @@ func (f *Fetcher) fetchContributionData(ctx context.Context, slot uint64, defSet
 			// This could happen if the beacon node didn't subscribe to the correct subnet.
 			return core.UnsignedDataSet{}, errors.New("sync committee contribution not found by root (retryable)", z.U64("subcommidx", subcommIdx), z.Hex("root", blockRoot[:]))
 		}
-		log.Info(ctx, "Resolved sync committee contribution duty", z.Any("pubkey", pubkey))
+		log.Info(ctx, "Resolved sync committee contribution duty", z.Redact(z.Any("pubkey", pubkey)))
 
 		resp[pubkey] = core.SyncContribution{
 			SyncCommitteeContribution: *contribution,
@@ -22,16 +22,18 @@ const (
 	topicVersion  = "version"
 	topicProtocol = "protocol"
 	topicProposal = "proposal"
+	topicFeature  = "feature"
 
 	// maxResults limits the number of results to keep.
 	maxResults = 100
 
 	TopicProtocol = topicProtocol
+	TopicFeature  = topicFeature
 )
 
 // New returns a new infosync component.
 func New(prioritiser *priority.Component, versions []version.SemVer, protocols []protocol.ID,
-	proposals []core.ProposalType,
+	proposals []core.ProposalType, features []featureset.Feature,
 ) *Component {
 	// Add a mock alpha protocol if alpha features enabled in order to test infosync in prod.
 	// TODO(corver): Remove this once we have an actual use case.
@@ -44,6 +46,7 @@ func New(prioritiser *priority.Component, versions []version.SemVer, protocols [
 		versions:    versions,
 		protocols:   protocols,
 		proposals:   proposals,
+		features:    features,
 	}
 
 	prioritiser.Subscribe(func(ctx context.Context, duty core.Duty, results []priority.TopicResult) error {
@@ -60,6 +63,8 @@ func New(prioritiser *priority.Component, versions []version.SemVer, protocols [
 					res.protocols = append(res.protocols, protocol.ID(prio))
 				case topicProposal:
 					res.proposals = append(res.proposals, core.ProposalType(prio))
+				case topicFeature:
+					res.features = append(res.features, featureset.Feature(prio))
 				}
 			}
 		}
@@ -81,6 +86,7 @@ type Component struct {
 	versions    []version.SemVer
 	protocols   []protocol.ID
 	proposals   []core.ProposalType
+	features    []featureset.Feature
 
 	mu      sync.Mutex
 	results []result
@@ -124,6 +130,15 @@ func (c *Component) Proposals(slot uint64) []core.ProposalType {
 	return resp
 }
 
+// SetProtocols replaces the local protocols proposed in subsequent info syncs, for example to prefer
+// a new protocol once all peers support it.
+func (c *Component) SetProtocols(protocols []protocol.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.protocols = protocols
+}
+
 // addResult adds the result to the results if it is different from the last result.
 func (c *Component) addResult(result result) {
 	c.mu.Lock()
@@ -143,6 +158,10 @@ func (c *Component) addResult(result result) {
 }
 
 func (c *Component) Trigger(ctx context.Context, slot uint64) error {
+	c.mu.Lock()
+	protocols := c.protocols
+	c.mu.Unlock()
+
 	return c.prioritiser.Prioritise(ctx, core.NewInfoSyncDuty(slot),
 		priority.TopicProposal{
 			Topic:      topicVersion,
@@ -150,11 +169,15 @@ func (c *Component) Trigger(ctx context.Context, slot uint64) error {
 		},
 		priority.TopicProposal{
 			Topic:      topicProtocol,
-			Priorities: protocolsToStrings(c.protocols),
+			Priorities: protocolsToStrings(protocols),
 		},
 		priority.TopicProposal{
 			Topic:      topicProposal,
 			Priorities: proposalsToStrings(c.proposals),
+		},
+		priority.TopicProposal{
+			Topic:      topicFeature,
+			Priorities: featuresToStrings(c.features),
 		})
 }
 
@@ -188,12 +211,23 @@ func proposalsToStrings(proposals []core.ProposalType) []string {
 	return resp
 }
 
+// featuresToStrings returns the features as strings.
+func featuresToStrings(features []featureset.Feature) []string {
+	var resp []string
+	for _, feature := range features {
+		resp = append(resp, string(feature))
+	}
+
+	return resp
+}
+
 // result is a cluster-wide agreed-upon infosync result.
 type result struct {
 	slot      uint64
 	versions  []string
 	protocols []protocol.ID
 	proposals []core.ProposalType
+	features  []featureset.Feature
 }
 
 // Equal returns true if the results are equal.
@@ -201,5 +235,6 @@ func (x result) Equal(y result) bool {
 	return x.slot == y.slot &&
 		fmt.Sprint(x.versions) == fmt.Sprint(y.versions) &&
 		fmt.Sprint(x.protocols) == fmt.Sprint(y.protocols) &&
-		fmt.Sprint(x.proposals) == fmt.Sprint(y.proposals)
+		fmt.Sprint(x.proposals) == fmt.Sprint(y.proposals) &&
+		fmt.Sprint(x.features) == fmt.Sprint(y.features)
 }
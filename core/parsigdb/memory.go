@@ -94,7 +94,7 @@ func (db *MemDB) StoreExternal(ctx context.Context, duty core.Duty, signedSet co
 
 		log.Debug(ctx, "Partial signed data stored",
 			z.Int("count", len(sigs)),
-			z.Any("pubkey", pubkey))
+			z.Redact(z.Any("pubkey", pubkey)))
 
 		// Check if sufficient matching partial signed data has been received.
 		psigs, ok, err := getThresholdMatching(duty.Type, sigs, db.threshold)
@@ -153,7 +153,7 @@ func (db *MemDB) store(k key, value core.ParSignedData) ([]core.ParSignedData, b
 				return nil, false, err
 			} else if !equal {
 				return nil, false, errors.New("mismatching partial signed data",
-					z.Any("pubkey", k.PubKey), z.Int("share_idx", s.ShareIdx))
+					z.Redact(z.Any("pubkey", k.PubKey)), z.Int("share_idx", s.ShareIdx))
 			}
 
 			return nil, false, nil
@@ -13,6 +13,7 @@ import (
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/tracer"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
 	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
@@ -87,7 +88,7 @@ func (m *ParSigEx) handle(ctx context.Context, _ peer.ID, req proto.Message) (pr
 		return nil, false, errors.Wrap(err, "convert parsigex proto")
 	}
 
-	ctx, span := core.StartDutyTrace(ctx, duty, "core/parsigex.Handle")
+	ctx, span := core.StartDutyTrace(tracer.Extract(ctx, pb.GetTraceContext()), duty, "core/parsigex.Handle")
 	defer span.End()
 
 	// Verify partial signature
@@ -118,8 +119,9 @@ func (m *ParSigEx) Broadcast(ctx context.Context, duty core.Duty, set core.ParSi
 	}
 
 	msg := pbv1.ParSigExMsg{
-		Duty:    core.DutyToProto(duty),
-		DataSet: pb,
+		Duty:         core.DutyToProto(duty),
+		DataSet:      pb,
+		TraceContext: tracer.Inject(ctx),
 	}
 
 	for i, p := range m.peers {
@@ -144,8 +146,17 @@ func (m *ParSigEx) Subscribe(fn func(context.Context, core.Duty, core.ParSignedD
 
 // NewEth2Verifier returns a partial signature verification function for core workflow eth2 signatures.
 func NewEth2Verifier(eth2Cl eth2wrap.Client, pubSharesByKey map[core.PubKey]map[int]tbls.PublicKey) (func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error, error) {
-	return func(ctx context.Context, duty core.Duty, pubkey core.PubKey, data core.ParSignedData) error {
+	return NewEth2VerifierFunc(eth2Cl, func(pubkey core.PubKey) (map[int]tbls.PublicKey, bool) {
 		pubshares, ok := pubSharesByKey[pubkey]
+		return pubshares, ok
+	})
+}
+
+// NewEth2VerifierFunc returns a partial signature verification function for core workflow eth2 signatures
+// that looks up the public shares of each validator via pubSharesFunc. This supports validators being added at runtime.
+func NewEth2VerifierFunc(eth2Cl eth2wrap.Client, pubSharesFunc func(core.PubKey) (map[int]tbls.PublicKey, bool)) (func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error, error) {
+	return func(ctx context.Context, duty core.Duty, pubkey core.PubKey, data core.ParSignedData) error {
+		pubshares, ok := pubSharesFunc(pubkey)
 		if !ok {
 			return errors.New("unknown pubkey, not part of cluster lock")
 		}
@@ -162,7 +173,7 @@ func NewEth2Verifier(eth2Cl eth2wrap.Client, pubSharesByKey map[core.PubKey]map[
 
 		err := core.VerifyEth2SignedData(ctx, eth2Cl, eth2Signed, pubshare)
 		if err != nil {
-			return errors.Wrap(err, "invalid signature", z.Str("duty", duty.String()))
+			return errors.WithCode(errors.Wrap(err, "invalid signature", z.Str("duty", duty.String())), errors.CodeInvalidPartialSignature)
 		}
 
 		return nil
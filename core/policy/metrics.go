@@ -0,0 +1,25 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package policy
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	deniedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "policy",
+		Name:      "denied_total",
+		Help:      "Total number of partial signatures denied by the signing policy by duty type and rule",
+	}, []string{"duty", "rule"})
+
+	reloadCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "policy",
+		Name:      "reloads_total",
+		Help:      "Total number of signing policy file reloads",
+	})
+)
@@ -0,0 +1,379 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package policy provides a local signing policy engine that is evaluated before the node's own partial
+// signatures are stored and broadcast to peers. Partial signatures denied by the policy are dropped,
+// so the node doesn't contribute to the threshold signature.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// Config is the signing policy file format.
+type Config struct {
+	// DenyFeeRecipients lists the fee recipient addresses of denied proposals and builder registrations.
+	DenyFeeRecipients []string `json:"deny_fee_recipients"`
+	// MaxGasLimit is the maximum gas limit of proposals and builder registrations, zero disables the rule.
+	MaxGasLimit uint64 `json:"max_gas_limit"`
+	// DenyGraffiti lists regular expressions matching denied proposal graffiti.
+	DenyGraffiti []string `json:"deny_graffiti"`
+	// DenyExitWindows lists the time windows during which voluntary exits are denied.
+	DenyExitWindows []Window `json:"deny_exit_windows"`
+	// DisabledDuties lists the duty types for which all partial signatures are denied, e.g. "exit".
+	DisabledDuties []string `json:"disabled_duties"`
+}
+
+// Window is a time window, a zero From or Until leaves the window open-ended.
+type Window struct {
+	From  time.Time `json:"from"`
+	Until time.Time `json:"until"`
+}
+
+// contains returns true if the time is within the window.
+func (w Window) contains(t time.Time) bool {
+	if !w.From.IsZero() && t.Before(w.From) {
+		return false
+	}
+
+	return w.Until.IsZero() || t.Before(w.Until)
+}
+
+// rules are the compiled rules of a policy config.
+type rules struct {
+	denyFeeRecipients map[string]bool
+	maxGasLimit       uint64
+	denyGraffiti      []*regexp.Regexp
+	denyExitWindows   []Window
+	disabledDuties    map[core.DutyType]bool
+}
+
+// compile returns the compiled rules of the config or an error if it is invalid.
+func compile(conf Config) (rules, error) {
+	resp := rules{
+		denyFeeRecipients: make(map[string]bool),
+		maxGasLimit:       conf.MaxGasLimit,
+		denyExitWindows:   conf.DenyExitWindows,
+		disabledDuties:    make(map[core.DutyType]bool),
+	}
+
+	for _, addr := range conf.DenyFeeRecipients {
+		if !feeRecipientRegex.MatchString(addr) {
+			return rules{}, errors.New("invalid deny fee recipient address", z.Redact(z.Str("address", addr)))
+		}
+
+		resp.denyFeeRecipients[strings.ToLower(addr)] = true
+	}
+
+	for _, pattern := range conf.DenyGraffiti {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return rules{}, errors.Wrap(err, "invalid deny graffiti pattern", z.Str("pattern", pattern))
+		}
+
+		resp.denyGraffiti = append(resp.denyGraffiti, re)
+	}
+
+	for _, window := range conf.DenyExitWindows {
+		if !window.From.IsZero() && !window.Until.IsZero() && !window.From.Before(window.Until) {
+			return rules{}, errors.New("invalid deny exit window, from must be before until",
+				z.Any("from", window.From), z.Any("until", window.Until))
+		}
+	}
+
+	dutyTypes := make(map[string]core.DutyType)
+	for _, dutyType := range core.AllDutyTypes() {
+		dutyTypes[dutyType.String()] = dutyType
+	}
+
+	for _, name := range conf.DisabledDuties {
+		dutyType, ok := dutyTypes[name]
+		if !ok {
+			return rules{}, errors.New("invalid disabled duty type", z.Str("duty", name))
+		}
+
+		resp.disabledDuties[dutyType] = true
+	}
+
+	return resp, nil
+}
+
+var feeRecipientRegex = regexp.MustCompile("^0x[0-9a-fA-F]{40}$")
+
+// Load returns the signing policy config from the JSON file.
+func Load(file string) (Config, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return Config{}, errors.Wrap(err, "read signing policy file", z.Str("file", file))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	var conf Config
+	if err := dec.Decode(&conf); err != nil {
+		return Config{}, errors.Wrap(err, "unmarshal signing policy file", z.Str("file", file))
+	}
+
+	return conf, nil
+}
+
+// New returns a new signing policy engine of the policy file which is polled for changes every period.
+func New(file string, period time.Duration) (*Engine, error) {
+	conf, err := Load(file)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := compile(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Engine{
+		file:    file,
+		period:  period,
+		nowFunc: time.Now,
+		conf:    conf,
+		rules:   r,
+	}, nil
+}
+
+// Engine evaluates partial signatures against the signing policy, hot reloading the policy file on changes.
+type Engine struct {
+	file    string
+	period  time.Duration
+	nowFunc func() time.Time
+
+	mu    sync.RWMutex
+	conf  Config
+	rules rules
+}
+
+// Run blocks and polls the policy file for changes until the context is closed.
+// Invalid policy files are ignored, retaining the previous policy.
+func (e *Engine) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "policy")
+
+	ticker := time.NewTicker(e.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.reload(ctx); err != nil {
+				log.Warn(ctx, "Ignoring invalid signing policy file, retaining previous policy", err, z.Str("file", e.file))
+			}
+		}
+	}
+}
+
+// reload loads and applies the policy file if it changed.
+func (e *Engine) reload(ctx context.Context) error {
+	conf, err := Load(e.file)
+	if err != nil {
+		return err
+	}
+
+	r, err := compile(conf)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if equalConfigs(e.conf, conf) {
+		return nil
+	}
+
+	e.conf = conf
+	e.rules = r
+	reloadCounter.Inc()
+
+	log.Info(ctx, "Reloaded signing policy", z.Str("file", e.file))
+
+	return nil
+}
+
+// Filter returns the partial signatures of the set allowed by the signing policy.
+// Denied partial signatures are logged, an error is returned if all of them are denied.
+func (e *Engine) Filter(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) (core.ParSignedDataSet, error) {
+	e.mu.RLock()
+	r := e.rules
+	e.mu.RUnlock()
+
+	var lastRule string
+	resp := make(core.ParSignedDataSet)
+	for pubkey, data := range set {
+		rule, reason := evaluate(r, duty, data, e.nowFunc())
+		if rule == "" {
+			resp[pubkey] = data
+			continue
+		}
+
+		lastRule = rule
+		deniedCounter.WithLabelValues(duty.Type.String(), rule).Inc()
+		log.Warn(ctx, "Partial signature denied by signing policy", nil,
+			z.Any("duty", duty), z.Redact(z.Any("pubkey", pubkey)), z.Str("rule", rule), z.Str("reason", reason))
+	}
+
+	if len(resp) == 0 && len(set) > 0 {
+		return nil, errors.New("partial signatures denied by signing policy", z.Any("duty", duty), z.Str("rule", lastRule))
+	}
+
+	return resp, nil
+}
+
+// CheckExit returns an error if signing voluntary exits is currently denied by the signing policy.
+// It is used to reject exits before they are signed, e.g. when requested via the admin API.
+func (e *Engine) CheckExit(ctx context.Context) error {
+	e.mu.RLock()
+	r := e.rules
+	e.mu.RUnlock()
+
+	rule, reason := evaluateExit(r, e.nowFunc())
+	if rule == "" {
+		return nil
+	}
+
+	deniedCounter.WithLabelValues(core.DutyExit.String(), rule).Inc()
+	log.Warn(ctx, "Voluntary exit denied by signing policy", nil, z.Str("rule", rule), z.Str("reason", reason))
+
+	return errors.New("voluntary exits denied by signing policy", z.Str("rule", rule), z.Str("reason", reason))
+}
+
+// evaluateExit returns the denying rule and reason of voluntary exits or empty strings if they are allowed.
+func evaluateExit(r rules, now time.Time) (string, string) {
+	if r.disabledDuties[core.DutyExit] {
+		return "disabled_duty", "duty type disabled"
+	}
+
+	for _, window := range r.denyExitWindows {
+		if window.contains(now) {
+			return "exit_window", "voluntary exits denied during window"
+		}
+	}
+
+	return "", ""
+}
+
+// evaluate returns the denying rule and reason of the partial signature or empty strings if it is allowed.
+func evaluate(r rules, duty core.Duty, data core.ParSignedData, now time.Time) (string, string) {
+	if r.disabledDuties[duty.Type] {
+		return "disabled_duty", "duty type disabled"
+	}
+
+	switch signed := data.SignedData.(type) {
+	case core.VersionedSignedProposal:
+		return evaluateProposal(r, signed)
+	case core.VersionedSignedValidatorRegistration:
+		if signed.V1 == nil || signed.V1.Message == nil {
+			return "", ""
+		}
+
+		return evaluateExecution(r, signed.V1.Message.FeeRecipient, signed.V1.Message.GasLimit)
+	case core.SignedVoluntaryExit:
+		return evaluateExit(r, now)
+	}
+
+	return "", ""
+}
+
+// evaluateProposal returns the denying rule and reason of the proposal or empty strings if it is allowed.
+func evaluateProposal(r rules, proposal core.VersionedSignedProposal) (string, string) {
+	graffiti, payload := proposalFields(proposal)
+
+	graffitiStr := string(bytes.TrimRight(graffiti[:], "\x00"))
+	for _, re := range r.denyGraffiti {
+		if re.MatchString(graffitiStr) {
+			return "graffiti", "graffiti matches " + re.String()
+		}
+	}
+
+	if payload == nil {
+		return "", ""
+	}
+
+	return evaluateExecution(r, payload.feeRecipient, payload.gasLimit)
+}
+
+// evaluateExecution returns the denying rule and reason of the execution fields or empty strings if they are allowed.
+func evaluateExecution(r rules, feeRecipient bellatrix.ExecutionAddress, gasLimit uint64) (string, string) {
+	if r.denyFeeRecipients[strings.ToLower(feeRecipient.String())] {
+		return "fee_recipient", "fee recipient denied"
+	}
+
+	if r.maxGasLimit > 0 && gasLimit > r.maxGasLimit {
+		return "gas_limit", "gas limit exceeds maximum"
+	}
+
+	return "", ""
+}
+
+// executionFields are the policy relevant fields of an execution payload (header).
+type executionFields struct {
+	feeRecipient bellatrix.ExecutionAddress
+	gasLimit     uint64
+}
+
+// proposalFields returns the graffiti and execution payload fields of the proposal, the latter being nil pre-bellatrix.
+func proposalFields(p core.VersionedSignedProposal) ([32]byte, *executionFields) {
+	// No block nil checks since `NewVersionedSignedProposal` assumed.
+	switch p.Version {
+	case eth2spec.DataVersionPhase0:
+		return p.Phase0.Message.Body.Graffiti, nil
+	case eth2spec.DataVersionAltair:
+		return p.Altair.Message.Body.Graffiti, nil
+	case eth2spec.DataVersionBellatrix:
+		if p.Blinded {
+			body := p.BellatrixBlinded.Message.Body
+			return body.Graffiti, &executionFields{body.ExecutionPayloadHeader.FeeRecipient, body.ExecutionPayloadHeader.GasLimit}
+		}
+
+		body := p.Bellatrix.Message.Body
+		return body.Graffiti, &executionFields{body.ExecutionPayload.FeeRecipient, body.ExecutionPayload.GasLimit}
+	case eth2spec.DataVersionCapella:
+		if p.Blinded {
+			body := p.CapellaBlinded.Message.Body
+			return body.Graffiti, &executionFields{body.ExecutionPayloadHeader.FeeRecipient, body.ExecutionPayloadHeader.GasLimit}
+		}
+
+		body := p.Capella.Message.Body
+		return body.Graffiti, &executionFields{body.ExecutionPayload.FeeRecipient, body.ExecutionPayload.GasLimit}
+	case eth2spec.DataVersionDeneb:
+		if p.Blinded {
+			body := p.DenebBlinded.Message.Body
+			return body.Graffiti, &executionFields{body.ExecutionPayloadHeader.FeeRecipient, body.ExecutionPayloadHeader.GasLimit}
+		}
+
+		body := p.Deneb.SignedBlock.Message.Body
+		return body.Graffiti, &executionFields{body.ExecutionPayload.FeeRecipient, body.ExecutionPayload.GasLimit}
+	default:
+		return [32]byte{}, nil
+	}
+}
+
+// equalConfigs returns true if the configs are equal.
+func equalConfigs(a, b Config) bool {
+	ab, err1 := json.Marshal(a)
+	bb, err2 := json.Marshal(b)
+
+	return err1 == nil && err2 == nil && bytes.Equal(ab, bb)
+}
@@ -0,0 +1,216 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestFilter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	proposal := testutil.RandomCapellaCoreVersionedSignedProposal()
+	payload := proposal.Capella.Message.Body.ExecutionPayload
+	payload.GasLimit = 30_000_000
+	payload.FeeRecipient = bellatrix.ExecutionAddress{0x01}
+	copy(proposal.Capella.Message.Body.Graffiti[:], "obol rocks")
+
+	registration := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	registration.V1.Message.GasLimit = 36_000_000
+
+	tests := []struct {
+		name   string
+		conf   Config
+		duty   core.Duty
+		data   core.SignedData
+		denied bool
+	}{
+		{
+			name: "empty policy",
+			duty: core.NewProposerDuty(1),
+			data: proposal,
+		},
+		{
+			name:   "denied fee recipient",
+			conf:   Config{DenyFeeRecipients: []string{payload.FeeRecipient.String()}},
+			duty:   core.NewProposerDuty(1),
+			data:   proposal,
+			denied: true,
+		},
+		{
+			name: "allowed fee recipient",
+			conf: Config{DenyFeeRecipients: []string{bellatrix.ExecutionAddress{}.String()}},
+			duty: core.NewProposerDuty(1),
+			data: proposal,
+		},
+		{
+			name:   "proposal gas limit",
+			conf:   Config{MaxGasLimit: 20_000_000},
+			duty:   core.NewProposerDuty(1),
+			data:   proposal,
+			denied: true,
+		},
+		{
+			name:   "registration gas limit",
+			conf:   Config{MaxGasLimit: 30_000_000},
+			duty:   core.NewBuilderRegistrationDuty(1),
+			data:   registration,
+			denied: true,
+		},
+		{
+			name:   "graffiti",
+			conf:   Config{DenyGraffiti: []string{"(?i)^OBOL"}},
+			duty:   core.NewProposerDuty(1),
+			data:   proposal,
+			denied: true,
+		},
+		{
+			name:   "exit window",
+			conf:   Config{DenyExitWindows: []Window{{From: now.Add(-time.Hour)}}},
+			duty:   core.NewVoluntaryExit(1),
+			data:   core.NewSignedVoluntaryExit(testutil.RandomExit()),
+			denied: true,
+		},
+		{
+			name: "outside exit window",
+			conf: Config{DenyExitWindows: []Window{{From: now.Add(-2 * time.Hour), Until: now.Add(-time.Hour)}}},
+			duty: core.NewVoluntaryExit(1),
+			data: core.NewSignedVoluntaryExit(testutil.RandomExit()),
+		},
+		{
+			name:   "disabled duty",
+			conf:   Config{DisabledDuties: []string{"proposer"}},
+			duty:   core.NewProposerDuty(1),
+			data:   proposal,
+			denied: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := newForT(t, test.conf)
+			e.nowFunc = func() time.Time { return now }
+
+			set := core.ParSignedDataSet{testutil.RandomCorePubKey(t): core.ParSignedData{SignedData: test.data, ShareIdx: 1}}
+
+			allowed, err := e.Filter(ctx, test.duty, set)
+			if test.denied {
+				require.ErrorContains(t, err, "denied by signing policy")
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, set, allowed)
+		})
+	}
+}
+
+func TestFilterPartial(t *testing.T) {
+	allowed := testutil.RandomCapellaCoreVersionedSignedProposal()
+	denied := testutil.RandomCapellaCoreVersionedSignedProposal()
+	allowed.Capella.Message.Body.ExecutionPayload.FeeRecipient = bellatrix.ExecutionAddress{0x01}
+	denied.Capella.Message.Body.ExecutionPayload.FeeRecipient = bellatrix.ExecutionAddress{0x02}
+
+	e := newForT(t, Config{DenyFeeRecipients: []string{denied.Capella.Message.Body.ExecutionPayload.FeeRecipient.String()}})
+
+	allowedKey, deniedKey := testutil.RandomCorePubKey(t), testutil.RandomCorePubKey(t)
+	resp, err := e.Filter(context.Background(), core.NewProposerDuty(1), core.ParSignedDataSet{
+		allowedKey: core.ParSignedData{SignedData: allowed, ShareIdx: 1},
+		deniedKey:  core.ParSignedData{SignedData: denied, ShareIdx: 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	require.Contains(t, resp, allowedKey)
+}
+
+func TestCheckExit(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, test := range []struct {
+		name   string
+		conf   Config
+		denied bool
+	}{
+		{name: "empty policy"},
+		{name: "exit window", conf: Config{DenyExitWindows: []Window{{Until: now.Add(time.Hour)}}}, denied: true},
+		{name: "outside exit window", conf: Config{DenyExitWindows: []Window{{From: now.Add(time.Hour)}}}},
+		{name: "disabled exits", conf: Config{DisabledDuties: []string{"exit"}}, denied: true},
+		{name: "other disabled duty", conf: Config{DisabledDuties: []string{"proposer"}}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			e := newForT(t, test.conf)
+			e.nowFunc = func() time.Time { return now }
+
+			err := e.CheckExit(ctx)
+			if test.denied {
+				require.ErrorContains(t, err, "voluntary exits denied by signing policy")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+	e := newForT(t, Config{})
+
+	writeConfig(t, e.file, Config{DisabledDuties: []string{"exit"}})
+	require.NoError(t, e.reload(ctx))
+	require.True(t, e.rules.disabledDuties[core.DutyExit])
+
+	// Invalid policies retain the previous policy.
+	writeConfig(t, e.file, Config{DisabledDuties: []string{"unknown"}})
+	require.ErrorContains(t, e.reload(ctx), "invalid disabled duty type")
+	require.True(t, e.rules.disabledDuties[core.DutyExit])
+
+	require.NoError(t, os.WriteFile(e.file, []byte(`{"unknown_rule": true}`), 0o644))
+	require.ErrorContains(t, e.reload(ctx), "unmarshal signing policy file")
+}
+
+func TestInvalidConfig(t *testing.T) {
+	now := time.Now()
+
+	for _, conf := range []Config{
+		{DenyFeeRecipients: []string{"0x1234"}},
+		{DenyGraffiti: []string{"("}},
+		{DenyExitWindows: []Window{{From: now, Until: now.Add(-time.Hour)}}},
+		{DisabledDuties: []string{"proposal"}},
+	} {
+		_, err := compile(conf)
+		require.Error(t, err)
+	}
+}
+
+func newForT(t *testing.T, conf Config) *Engine {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "policy.json")
+	writeConfig(t, file, conf)
+
+	e, err := New(file, time.Hour)
+	require.NoError(t, err)
+
+	return e
+}
+
+func writeConfig(t *testing.T, file string, conf Config) {
+	t.Helper()
+
+	b, err := json.Marshal(conf)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, b, 0o644))
+}
@@ -52,7 +52,7 @@ type ScoredPriority struct {
 // NewComponent returns a new priority component.
 func NewComponent(ctx context.Context, tcpNode host.Host, peers []peer.ID, minRequired int, sendFunc p2p.SendReceiveFunc,
 	registerHandlerFunc p2p.RegisterHandlerFunc, consensus Consensus,
-	exchangeTimeout time.Duration, privkey *k1.PrivateKey, deadlineFunc func(duty core.Duty) (time.Time, bool),
+	exchangeTimeout time.Duration, privkey k1util.Signer, deadlineFunc func(duty core.Duty) (time.Time, bool),
 ) (*Component, error) {
 	verifier, err := newMsgVerifier(peers)
 	if err != nil {
@@ -76,7 +76,7 @@ func NewComponent(ctx context.Context, tcpNode host.Host, peers []peer.ID, minRe
 // friendly API (hiding the underlying protobuf types) and does signing.
 type Component struct {
 	peerID       peer.ID
-	privkey      *k1.PrivateKey
+	privkey      k1util.Signer
 	prioritiser  *Prioritiser
 	deadlineFunc func(duty core.Duty) (time.Time, bool)
 }
@@ -144,7 +144,7 @@ func (c *Component) Prioritise(ctx context.Context, duty core.Duty, proposals ..
 }
 
 // signMsg returns a copy of the proto message with a populated signature signed by the provided private key.
-func signMsg(msg *pbv1.PriorityMsg, privkey *k1.PrivateKey) (*pbv1.PriorityMsg, error) {
+func signMsg(msg *pbv1.PriorityMsg, privkey k1util.Signer) (*pbv1.PriorityMsg, error) {
 	clone, ok := proto.Clone(msg).(*pbv1.PriorityMsg)
 	if !ok {
 		return nil, errors.New("type assert priority msg")
@@ -156,7 +156,7 @@ func signMsg(msg *pbv1.PriorityMsg, privkey *k1.PrivateKey) (*pbv1.PriorityMsg,
 		return nil, err
 	}
 
-	clone.Signature, err = k1util.Sign(privkey, hash[:])
+	clone.Signature, err = privkey.Sign(hash[:])
 	if err != nil {
 		return nil, errors.Wrap(err, "sign")
 	}
@@ -0,0 +1,139 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package rootcause
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// Root cause codes.
+const (
+	// codeNoPeerObservations indicates no peer responded, so only the local observation is known.
+	codeNoPeerObservations = "no_peer_observations"
+	// codePeerAbsent indicates that partial signatures of some peers were not received by any peer.
+	codePeerAbsent = "peer_absent"
+	// codeAllFailed indicates that all responding peers failed at the same step for the same reason.
+	codeAllFailed = "all_failed"
+	// codePartialFailure indicates that only some of the responding peers failed.
+	codePartialFailure = "partial_failure"
+	// codeMixedFailure indicates that all responding peers failed, but at different steps or for different reasons.
+	codeMixedFailure = "mixed_failure"
+)
+
+// correlate returns the cluster-consistent root cause of a failed duty given the observations by peer index.
+// The observations must include the local observation.
+func correlate(peers []p2p.Peer, observations map[int]Observation) RootCause {
+	var (
+		reported []p2p.Peer // Peers that reported observations, in peer order.
+		failed   []p2p.Peer // Peers that reported the duty failed, in peer order.
+	)
+	for _, p := range peers {
+		obs, ok := observations[p.Index]
+		if !ok {
+			continue
+		}
+
+		reported = append(reported, p)
+		if obs.Failed {
+			failed = append(failed, p)
+		}
+	}
+
+	if len(reported) <= 1 {
+		return RootCause{
+			Code:    codeNoPeerObservations,
+			Summary: "no peer observations received; " + describeFailures(failed, observations),
+		}
+	}
+
+	if absent := absentPeers(peers, observations); len(absent) > 0 {
+		return RootCause{
+			Code:    codePeerAbsent,
+			Summary: strings.Join(absent, "; "),
+		}
+	}
+
+	if len(failed) < len(reported) {
+		return RootCause{
+			Code:    codePartialFailure,
+			Summary: fmt.Sprintf("%d/%d peers failed: %s", len(failed), len(reported), describeFailures(failed, observations)),
+		}
+	}
+
+	first := observations[failed[0].Index]
+	for _, p := range failed[1:] {
+		if obs := observations[p.Index]; obs.Step != first.Step || obs.Reason != first.Reason {
+			return RootCause{
+				Code:    codeMixedFailure,
+				Summary: fmt.Sprintf("%d/%d peers failed differently: %s", len(failed), len(reported), describeFailures(failed, observations)),
+			}
+		}
+	}
+
+	return RootCause{
+		Code:    codeAllFailed,
+		Summary: fmt.Sprintf("all %d/%d peers failed at %s: %s", len(failed), len(peers), first.Step, first.Reason),
+	}
+}
+
+// absentPeers returns descriptions of the peers whose partial signatures were not received by any other peer.
+// It returns nil if no peer received any partial signatures, since the duty then failed before signing.
+func absentPeers(peers []p2p.Peer, observations map[int]Observation) []string {
+	var signed bool
+	for _, obs := range observations {
+		if len(obs.Shares) > 0 {
+			signed = true
+			break
+		}
+	}
+
+	if !signed {
+		return nil
+	}
+
+	var resp []string
+	for _, p := range peers {
+		var received bool
+		for idx, obs := range observations {
+			if idx != p.Index && slices.Contains(obs.Shares, p.ShareIdx()) {
+				received = true
+				break
+			}
+		}
+
+		if received {
+			continue
+		}
+
+		own, ok := observations[p.Index]
+		switch {
+		case ok && slices.Contains(own.Shares, p.ShareIdx()):
+			resp = append(resp, peerName(p)+" partial signature not received by peers")
+		case ok && own.Failed:
+			resp = append(resp, fmt.Sprintf("%s never sent partial signature, failed at %s: %s", peerName(p), own.Step, own.Reason))
+		default:
+			resp = append(resp, peerName(p)+" never sent partial signature")
+		}
+	}
+
+	return resp
+}
+
+// describeFailures returns a description of the step and reason each of the failed peers failed at.
+func describeFailures(failed []p2p.Peer, observations map[int]Observation) string {
+	var resp []string
+	for _, p := range failed {
+		obs := observations[p.Index]
+		resp = append(resp, fmt.Sprintf("%s at %s: %s", peerName(p), obs.Step, obs.Reason))
+	}
+
+	return strings.Join(resp, ", ")
+}
+
+func peerName(p p2p.Peer) string {
+	return fmt.Sprintf("peer %d (%s)", p.Index, p.Name)
+}
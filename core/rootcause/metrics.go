@@ -0,0 +1,16 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package rootcause
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var rootCauseCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "core",
+	Subsystem: "rootcause",
+	Name:      "failed_duties_total",
+	Help:      "Total number of failed duties by duty type and cluster-consistent root cause code",
+}, []string{"duty", "code"})
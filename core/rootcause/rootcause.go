@@ -0,0 +1,256 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package rootcause implements the root cause protocol that correlates duty failures across the cluster.
+//
+// Protocol overview:
+//   - Each peer's tracker analyses each duty and stores its local observation: whether it failed, the failed step
+//     and reason, and the share indexes of the partial signatures it received.
+//   - When a duty fails locally, the peer waits for the other peers to analyse the duty and then requests their
+//     observations of the duty.
+//   - The observations of all responding peers are correlated into a cluster-consistent root cause,
+//     e.g. "peer 3 never sent partial signature" or "all peers failed at fetcher".
+package rootcause
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const (
+	protocolID1 = "/charon/rootcause/1.0.0"
+
+	// queryDelay is the delay after a local duty failure before requesting peer observations,
+	// allowing peers to analyse the duty.
+	queryDelay = 5 * time.Second
+	// queryTimeout is the timeout of requesting peer observations.
+	queryTimeout = 10 * time.Second
+	// retainSlots is the number of slots observations are retained for peers to request.
+	retainSlots = 64
+)
+
+// Protocols returns the supported protocols of this package in order of precedence.
+func Protocols() []protocol.ID {
+	return []protocol.ID{protocolID1}
+}
+
+// Observation is the outcome of a duty as analysed by a peer's tracker.
+type Observation struct {
+	Failed bool
+	Step   string
+	Reason string
+	Err    string
+	// Shares are the share indexes of the partial signatures received by the peer, including its own.
+	Shares []int
+}
+
+// RootCause is the cluster-consistent root cause of a failed duty.
+type RootCause struct {
+	// Code is a short identifier of the kind of root cause, see correlate.
+	Code string
+	// Summary is a human-readable description of the root cause.
+	Summary string
+}
+
+// New returns a new root cause correlator and registers the protocol handler.
+func New(tcpNode host.Host, peers []p2p.Peer, sendFunc p2p.SendReceiveFunc,
+	registerHandlerFunc p2p.RegisterHandlerFunc,
+) *Correlator {
+	c := &Correlator{
+		tcpNode:      tcpNode,
+		peers:        peers,
+		sendFunc:     sendFunc,
+		queryDelay:   queryDelay,
+		observations: make(map[core.Duty]Observation),
+	}
+
+	registerHandlerFunc("rootcause", tcpNode, protocolID1,
+		func() proto.Message { return new(pbv1.RootCauseRequest) },
+		func(_ context.Context, _ peer.ID, msg proto.Message) (proto.Message, bool, error) {
+			req, ok := msg.(*pbv1.RootCauseRequest)
+			if !ok || req.GetDuty() == nil {
+				return nil, false, errors.New("invalid root cause request")
+			}
+
+			return c.handle(req), true, nil
+		})
+
+	return c
+}
+
+// Correlator stores local duty observations, serves them to peers and correlates
+// peer observations of locally failed duties into cluster-consistent root causes.
+type Correlator struct {
+	tcpNode    host.Host
+	peers      []p2p.Peer
+	sendFunc   p2p.SendReceiveFunc
+	queryDelay time.Duration
+	subs       []func(context.Context, core.Duty, RootCause)
+
+	mu           sync.Mutex
+	observations map[core.Duty]Observation
+}
+
+// Subscribe registers a root cause subscriber function.
+// This is not thread safe and MUST NOT be called after the tracker started.
+func (c *Correlator) Subscribe(fn func(context.Context, core.Duty, RootCause)) {
+	c.subs = append(c.subs, fn)
+}
+
+// Observe stores the local observation of the analysed duty and correlates the root cause if the duty failed.
+// It is a tracker analysed duty callback, see tracker.WithAnalysedDutyCallback.
+func (c *Correlator) Observe(ctx context.Context, duty core.Duty, failed bool, step string, reasonCode string, err error, shares []int) {
+	obs := Observation{
+		Failed: failed,
+		Step:   step,
+		Reason: reasonCode,
+		Shares: shares,
+	}
+	if err != nil {
+		obs.Err = err.Error()
+	}
+
+	c.store(duty, obs)
+
+	if !failed {
+		return
+	}
+
+	go c.correlate(ctx, duty, obs)
+}
+
+// store stores the observation, deleting observations older than retainSlots.
+func (c *Correlator) store(duty core.Duty, obs Observation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.observations[duty] = obs
+
+	for d := range c.observations {
+		if d.Slot+retainSlots < duty.Slot {
+			delete(c.observations, d)
+		}
+	}
+}
+
+// handle returns the local observation of the requested duty.
+func (c *Correlator) handle(req *pbv1.RootCauseRequest) *pbv1.RootCauseResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	obs, ok := c.observations[core.DutyFromProto(req.GetDuty())]
+	if !ok {
+		return &pbv1.RootCauseResponse{}
+	}
+
+	return &pbv1.RootCauseResponse{Observation: observationToProto(obs)}
+}
+
+// correlate requests the observations of the failed duty from all peers and reports the correlated root cause.
+func (c *Correlator) correlate(ctx context.Context, duty core.Duty, own Observation) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(c.queryDelay):
+	}
+
+	observations := c.query(ctx, duty)
+	for _, p := range c.peers {
+		if p.ID == c.tcpNode.ID() {
+			observations[p.Index] = own
+		}
+	}
+
+	cause := correlate(c.peers, observations)
+
+	log.Warn(ctx, "Cluster duty failure root cause", nil,
+		z.Any("duty", duty),
+		z.Str("root_cause", cause.Summary),
+		z.Str("code", cause.Code),
+		z.Int("observations", len(observations)),
+	)
+	rootCauseCounter.WithLabelValues(duty.Type.String(), cause.Code).Inc()
+
+	for _, sub := range c.subs {
+		sub(ctx, duty, cause)
+	}
+}
+
+// query returns the observations of the duty by peer index of all peers that responded in time.
+func (c *Correlator) query(ctx context.Context, duty core.Duty) map[int]Observation {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		resp = make(map[int]Observation)
+	)
+
+	for _, p := range c.peers {
+		if p.ID == c.tcpNode.ID() {
+			continue // Do not send to self
+		}
+
+		wg.Add(1)
+		go func(p p2p.Peer) {
+			defer wg.Done()
+
+			res := new(pbv1.RootCauseResponse)
+			err := c.sendFunc(ctx, c.tcpNode, p.ID, &pbv1.RootCauseRequest{Duty: core.DutyToProto(duty)}, res, protocolID1)
+			if err != nil || res.GetObservation() == nil {
+				// No need to log errors, since transport will do it.
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			resp[p.Index] = observationFromProto(res.GetObservation())
+		}(p)
+	}
+
+	wg.Wait()
+
+	return resp
+}
+
+func observationToProto(obs Observation) *pbv1.DutyObservation {
+	pb := &pbv1.DutyObservation{
+		Failed: obs.Failed,
+		Step:   obs.Step,
+		Reason: obs.Reason,
+		Error:  obs.Err,
+	}
+	for _, share := range obs.Shares {
+		pb.Shares = append(pb.Shares, int32(share))
+	}
+
+	return pb
+}
+
+func observationFromProto(pb *pbv1.DutyObservation) Observation {
+	obs := Observation{
+		Failed: pb.GetFailed(),
+		Step:   pb.GetStep(),
+		Reason: pb.GetReason(),
+		Err:    pb.GetError(),
+	}
+	for _, share := range pb.GetShares() {
+		obs.Shares = append(obs.Shares, int(share))
+	}
+
+	return obs
+}
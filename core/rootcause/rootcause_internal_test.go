@@ -0,0 +1,145 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package rootcause
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestCorrelate(t *testing.T) {
+	var peers []p2p.Peer
+	for i := range 4 {
+		peers = append(peers, p2p.Peer{Index: i, Name: []string{"a", "b", "c", "d"}[i]})
+	}
+
+	succeeded := Observation{Shares: []int{1, 2, 3}}
+	insufficient := Observation{Failed: true, Step: "parsig_db_external", Reason: "insufficient_peer_signatures", Shares: []int{1, 2}}
+	fetchFailed := Observation{Failed: true, Step: "fetcher", Reason: "fetch_bn_error"}
+
+	tests := []struct {
+		name         string
+		observations map[int]Observation
+		code         string
+		summary      string
+	}{
+		{
+			name:         "no peer observations",
+			observations: map[int]Observation{0: fetchFailed},
+			code:         codeNoPeerObservations,
+			summary:      "no peer observations received; peer 0 (a) at fetcher: fetch_bn_error",
+		},
+		{
+			name:         "all failed beacon fetch",
+			observations: map[int]Observation{0: fetchFailed, 1: fetchFailed, 2: fetchFailed, 3: fetchFailed},
+			code:         codeAllFailed,
+			summary:      "all 4/4 peers failed at fetcher: fetch_bn_error",
+		},
+		{
+			name:         "peer never sent partial signature",
+			observations: map[int]Observation{0: insufficient, 1: insufficient},
+			code:         codePeerAbsent,
+			summary:      "peer 2 (c) never sent partial signature; peer 3 (d) never sent partial signature",
+		},
+		{
+			name:         "peer failed and never sent partial signature",
+			observations: map[int]Observation{0: insufficient, 1: insufficient, 2: fetchFailed},
+			code:         codePeerAbsent,
+			summary:      "peer 2 (c) never sent partial signature, failed at fetcher: fetch_bn_error; peer 3 (d) never sent partial signature",
+		},
+		{
+			name: "partial signature not received",
+			observations: map[int]Observation{
+				0: succeeded, 1: succeeded, 2: succeeded,
+				3: {Failed: true, Step: "parsig_db_external", Reason: "insufficient_peer_signatures", Shares: []int{4}},
+			},
+			code:    codePeerAbsent,
+			summary: "peer 3 (d) partial signature not received by peers",
+		},
+		{
+			name: "partial failure",
+			observations: map[int]Observation{
+				0: {Failed: true, Step: "bcast", Reason: "broadcast_bn_error", Shares: []int{1, 2, 3, 4}},
+				1: {Shares: []int{1, 2, 3, 4}},
+			},
+			code:    codePartialFailure,
+			summary: "1/2 peers failed: peer 0 (a) at bcast: broadcast_bn_error",
+		},
+		{
+			name: "mixed failure",
+			observations: map[int]Observation{
+				0: fetchFailed,
+				1: {Failed: true, Step: "consensus", Reason: "no_consensus"},
+			},
+			code:    codeMixedFailure,
+			summary: "2/2 peers failed differently: peer 0 (a) at fetcher: fetch_bn_error, peer 1 (b) at consensus: no_consensus",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cause := correlate(peers, test.observations)
+			require.Equal(t, test.code, cause.Code)
+			require.Equal(t, test.summary, cause.Summary)
+		})
+	}
+}
+
+func TestCorrelator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const n = 3
+
+	var (
+		tcpNodes []host.Host
+		peers    []p2p.Peer
+	)
+	for i := range n {
+		tcpNode := testutil.CreateHost(t, testutil.AvailableAddr(t))
+		for _, other := range tcpNodes {
+			tcpNode.Peerstore().AddAddrs(other.ID(), other.Addrs(), peerstore.PermanentAddrTTL)
+			other.Peerstore().AddAddrs(tcpNode.ID(), tcpNode.Addrs(), peerstore.PermanentAddrTTL)
+		}
+		tcpNodes = append(tcpNodes, tcpNode)
+		peers = append(peers, p2p.Peer{ID: tcpNode.ID(), Index: i, Name: p2p.PeerName(tcpNode.ID())})
+	}
+
+	duty := core.NewAttesterDuty(99)
+	causes := make(chan RootCause, 1)
+
+	var correlators []*Correlator
+	for i := range n {
+		c := New(tcpNodes[i], peers, p2p.SendReceive, p2p.RegisterHandler)
+		c.queryDelay = 0
+		correlators = append(correlators, c)
+	}
+
+	correlators[0].Subscribe(func(_ context.Context, d core.Duty, cause RootCause) {
+		require.Equal(t, duty, d)
+		causes <- cause
+	})
+
+	// Peers 1 and 2 failed fetching from the beacon node before peer 0.
+	fetchErr := errors.New("beacon node down")
+	correlators[1].Observe(ctx, duty, true, "fetcher", "fetch_bn_error", fetchErr, nil)
+	correlators[2].Observe(ctx, duty, true, "fetcher", "fetch_bn_error", fetchErr, nil)
+	correlators[0].Observe(ctx, duty, true, "fetcher", "fetch_bn_error", fetchErr, nil)
+
+	cause := <-causes
+	require.Equal(t, codeAllFailed, cause.Code)
+	require.Equal(t, "all 3/3 peers failed at fetcher: fetch_bn_error", cause.Summary)
+
+	// Old observations are deleted.
+	correlators[0].Observe(ctx, core.NewAttesterDuty(99+retainSlots+1), false, "unknown", "unknown", nil, []int{1, 2, 3})
+	require.Len(t, correlators[0].observations, 1)
+}
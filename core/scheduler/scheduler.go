@@ -335,7 +335,7 @@ func (s *Scheduler) resolveAttDuties(ctx context.Context, slot core.Slot, vals v
 		log.Info(ctx, "Resolved attester duty",
 			z.U64("slot", uint64(attDuty.Slot)),
 			z.U64("vidx", uint64(attDuty.ValidatorIndex)),
-			z.Any("pubkey", pubkey),
+			z.Redact(z.Any("pubkey", pubkey)),
 			z.U64("epoch", slot.Epoch()),
 		)
 
@@ -402,7 +402,7 @@ func (s *Scheduler) resolveProDuties(ctx context.Context, slot core.Slot, vals v
 		log.Info(ctx, "Resolved proposer duty",
 			z.U64("slot", uint64(proDuty.Slot)),
 			z.U64("vidx", uint64(proDuty.ValidatorIndex)),
-			z.Any("pubkey", pubkey),
+			z.Redact(z.Any("pubkey", pubkey)),
 			z.U64("epoch", slot.Epoch()),
 		)
 	}
@@ -457,7 +457,7 @@ func (s *Scheduler) resolveSyncCommDuties(ctx context.Context, slot core.Slot, v
 
 		log.Info(ctx, "Resolved sync committee duty",
 			z.U64("vidx", uint64(vIdx)),
-			z.Any("pubkey", pubkey),
+			z.Redact(z.Any("pubkey", pubkey)),
 			z.U64("epoch", slot.Epoch()),
 		)
 	}
@@ -465,6 +465,21 @@ func (s *Scheduler) resolveSyncCommDuties(ctx context.Context, slot core.Slot, v
 	return nil
 }
 
+// UpcomingDuties returns the resolved duties from the slot (inclusive) and their number of validators.
+func (s *Scheduler) UpcomingDuties(slot uint64) map[core.Duty]int {
+	s.dutiesMutex.Lock()
+	defer s.dutiesMutex.Unlock()
+
+	resp := make(map[core.Duty]int)
+	for duty, defSet := range s.duties {
+		if duty.Slot >= slot {
+			resp[duty] = len(defSet)
+		}
+	}
+
+	return resp
+}
+
 func (s *Scheduler) getDutyDefinitionSet(duty core.Duty) (core.DutyDefinitionSet, bool) {
 	s.dutiesMutex.Lock()
 	defer s.dutiesMutex.Unlock()
@@ -355,6 +355,10 @@ func TestScheduler_GetDuty(t *testing.T) {
 		for _, pubKey := range pubKeys {
 			require.NotNil(t, res[pubKey])
 		}
+
+		upcoming := sched.UpcomingDuties(slot)
+		require.Equal(t, len(pubKeys), upcoming[core.NewAttesterDuty(slot)])
+		require.Empty(t, sched.UpcomingDuties(slot+100))
 	})
 
 	slotsPerEpoch, err := eth2Cl.SlotsPerEpoch(ctx)
@@ -56,7 +56,7 @@ func (a *Aggregator) Aggregate(ctx context.Context, duty core.Duty, set map[core
 	for pubkey, parSigs := range set {
 		signed, err := a.aggregate(ctx, pubkey, parSigs)
 		if err != nil {
-			return errors.Wrap(err, "threshold aggregate", z.Any("pubkey", pubkey))
+			return errors.Wrap(err, "threshold aggregate", z.Redact(z.Any("pubkey", pubkey)))
 		}
 
 		output[pubkey] = signed
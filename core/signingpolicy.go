@@ -0,0 +1,27 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+)
+
+// SigningPolicyFilter returns the partial signatures of the set allowed by a signing policy.
+type SigningPolicyFilter func(context.Context, Duty, ParSignedDataSet) (ParSignedDataSet, error)
+
+// WithSigningPolicy wraps the internal partial signature store so that only the node's own partial signatures
+// allowed by the signing policy filter are stored and broadcast to peers.
+func WithSigningPolicy(filter SigningPolicyFilter) WireOption {
+	return func(w *wireFuncs) {
+		clone := *w
+
+		w.ParSigDBStoreInternal = func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+			allowed, err := filter(ctx, duty, set)
+			if err != nil {
+				return err
+			}
+
+			return clone.ParSigDBStoreInternal(ctx, duty, allowed)
+		}
+	}
+}
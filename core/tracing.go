@@ -18,6 +18,8 @@ import (
 // StartDutyTrace returns a context and span rooted to the duty traceID and wrapped in a duty span.
 // This creates a new trace root and should generally only be called when a new duty is scheduled
 // or when a duty is received from the VC or peer.
+// If the context contains the remote span of the duty propagated by a peer (see tracer.Extract),
+// the duty span is a child of the remote span, linking the duty trace across nodes.
 func StartDutyTrace(ctx context.Context, duty Duty, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
 	h := fnv.New128a()
 	_, _ = h.Write([]byte(duty.String()))
@@ -25,8 +27,13 @@ func StartDutyTrace(ctx context.Context, duty Duty, spanName string, opts ...tra
 	var traceID trace.TraceID
 	copy(traceID[:], h.Sum(nil))
 
+	parent := tracer.RootedCtx(ctx, traceID)
+	if remote := trace.SpanContextFromContext(ctx); remote.IsRemote() && remote.TraceID() == traceID {
+		parent = ctx
+	}
+
 	var outerSpan, innerSpan trace.Span
-	ctx, outerSpan = tracer.Start(tracer.RootedCtx(ctx, traceID), "core/duty."+strings.Title(duty.Type.String()))
+	ctx, outerSpan = tracer.Start(parent, "core/duty."+strings.Title(duty.Type.String()))
 	ctx, innerSpan = tracer.Start(ctx, spanName, opts...)
 
 	slotStr := strconv.FormatUint(duty.Slot, 10)
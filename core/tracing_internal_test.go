@@ -0,0 +1,60 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/obolnetwork/charon/app/tracer"
+)
+
+func TestStartDutyTraceRemote(t *testing.T) {
+	ctx := context.Background()
+	duty := NewAttesterDuty(1)
+
+	var buf bytes.Buffer
+	stop, err := tracer.Init(tracer.WithStdOut(&buf))
+	require.NoError(t, err)
+
+	// Start the duty trace on the "sender" and propagate it to the "receiver".
+	senderCtx, senderSpan := StartDutyTrace(ctx, duty, "sender")
+	carrier := tracer.Inject(senderCtx)
+	senderSpan.End()
+
+	receiverCtx, receiverSpan := StartDutyTrace(tracer.Extract(ctx, carrier), duty, "receiver")
+	receiverSpan.End()
+
+	// Remote spans of other duties are ignored.
+	_, otherSpan := StartDutyTrace(tracer.Extract(ctx, carrier), NewProposerDuty(1), "other")
+	otherSpan.End()
+
+	require.NoError(t, stop(ctx))
+
+	type span struct {
+		Name        string
+		SpanContext struct{ TraceID, SpanID string }
+		Parent      struct{ TraceID, SpanID string }
+	}
+
+	spans := make(map[string]span)
+	d := json.NewDecoder(&buf)
+	for d.More() {
+		var s span
+		require.NoError(t, d.Decode(&s))
+		spans[s.Name] = s
+	}
+
+	senderSC := trace.SpanContextFromContext(senderCtx)
+	require.Equal(t, senderSC.TraceID(), trace.SpanContextFromContext(receiverCtx).TraceID())
+
+	// The receiver duty span is a child of the sender span.
+	require.Equal(t, senderSC.SpanID().String(), spans["core/duty.Attester"].Parent.SpanID)
+	require.Equal(t, spans["core/duty.Attester"].SpanContext.SpanID, spans["receiver"].Parent.SpanID)
+	require.NotEqual(t, senderSC.TraceID().String(), spans["other"].SpanContext.TraceID)
+}
@@ -229,7 +229,7 @@ func (i *inclusionCore) CheckBlock(ctx context.Context, block block) {
 
 			log.Info(ctx, msg,
 				z.U64("block_slot", block.Slot),
-				z.Any("pubkey", sub.Pubkey),
+				z.Redact(z.Any("pubkey", sub.Pubkey)),
 				z.Any("broadcast_delay", sub.Delay),
 			)
 
@@ -292,7 +292,7 @@ func reportMissed(ctx context.Context, sub submission) {
 		}
 
 		log.Warn(ctx, msg, nil,
-			z.Any("pubkey", sub.Pubkey),
+			z.Redact(z.Any("pubkey", sub.Pubkey)),
 			z.U64("attestation_slot", sub.Duty.Slot),
 			z.Any("broadcast_delay", sub.Delay),
 		)
@@ -307,7 +307,7 @@ func reportMissed(ctx context.Context, sub submission) {
 			}
 
 			log.Warn(ctx, msg, nil,
-				z.Any("pubkey", sub.Pubkey),
+				z.Redact(z.Any("pubkey", sub.Pubkey)),
 				z.U64("block_slot", sub.Duty.Slot),
 				z.Any("broadcast_delay", sub.Delay),
 			)
@@ -333,7 +333,7 @@ func reportAttInclusion(ctx context.Context, sub submission, block block) {
 	log.Info(ctx, msg,
 		z.U64("block_slot", blockSlot),
 		z.U64("attestation_slot", attSlot),
-		z.Any("pubkey", sub.Pubkey),
+		z.Redact(z.Any("pubkey", sub.Pubkey)),
 		z.U64("inclusion_delay", inclDelay),
 		z.Any("broadcast_delay", sub.Delay),
 		z.Int("aggregate_len", len(aggIndices)),
@@ -45,6 +45,27 @@ var (
 		Help:      "Total number of expected participations (fail + success) by peer and duty type",
 	}, []string{"duty", "peer"})
 
+	peerParSigs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "peer_partial_signatures_total",
+		Help:      "Total number of partial signatures contributed by peer and duty type, persisted across restarts",
+	}, []string{"duty", "peer"})
+
+	peerParSigsExpected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "peer_partial_signatures_expected_total",
+		Help:      "Total number of partial signatures expected by peer and duty type, persisted across restarts",
+	}, []string{"duty", "peer"})
+
+	peerParticipationRatio = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "peer_participation_ratio",
+		Help:      "Rolling ratio of contributed to expected partial signatures over approximately the last 100 duties by peer and duty type, persisted across restarts",
+	}, []string{"duty", "peer"})
+
 	dutyFailed = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "core",
 		Subsystem: "tracker",
@@ -0,0 +1,164 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// participationWindow is the approximate number of duties the rolling participation ratio is calculated over.
+	participationWindow = 100
+	// participationSaveInterval is the minimum interval between persisting participation counters.
+	participationSaveInterval = time.Minute
+)
+
+// participationCount is the persisted participation of a peer for a duty type.
+type participationCount struct {
+	// Contributed is the total number of partial signatures contributed.
+	Contributed float64 `json:"contributed"`
+	// Expected is the total number of partial signatures expected.
+	Expected float64 `json:"expected"`
+	// Ratio is the exponentially weighted moving average of contributed over expected partial signatures per duty.
+	Ratio float64 `json:"ratio"`
+}
+
+// ParticipationStore stores per peer participation counters, persisting them to disk so that
+// participation metrics remain accurate across restarts.
+type ParticipationStore struct {
+	mu        sync.Mutex
+	path      string
+	counts    map[string]map[string]*participationCount // Counts by duty type and peer name.
+	lastSaved time.Time
+}
+
+// NewParticipationStore returns a participation store persisted to the file at path, loading
+// existing counters if the file exists. An empty path results in an in-memory store.
+func NewParticipationStore(path string) (*ParticipationStore, error) {
+	s := &ParticipationStore{
+		path:      path,
+		counts:    make(map[string]map[string]*participationCount),
+		lastSaved: time.Now(),
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read participation file", z.Str("path", path))
+	}
+
+	if err := json.Unmarshal(b, &s.counts); err != nil {
+		return nil, errors.Wrap(err, "unmarshal participation file", z.Str("path", path))
+	}
+
+	return s, nil
+}
+
+// get returns the participation count of the peer for the duty type, creating it if it doesn't exist.
+func (s *ParticipationStore) get(duty, peer string) participationCount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.getUnsafe(duty, peer)
+}
+
+// add adds the contributed and expected partial signatures of a single duty to the peer's counters
+// and returns the updated participation count.
+func (s *ParticipationStore) add(duty, peer string, contributed, expected int) participationCount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := s.getUnsafe(duty, peer)
+
+	if expected > 0 {
+		ratio := min(float64(contributed)/float64(expected), 1)
+		if count.Expected == 0 {
+			count.Ratio = ratio
+		} else {
+			count.Ratio += (ratio - count.Ratio) / participationWindow
+		}
+	}
+
+	count.Contributed += float64(contributed)
+	count.Expected += float64(expected)
+
+	return *count
+}
+
+// getUnsafe returns the participation count of the peer for the duty type, creating it if it doesn't exist.
+// It is unsafe since it assumes the lock is held.
+func (s *ParticipationStore) getUnsafe(duty, peer string) *participationCount {
+	if _, ok := s.counts[duty]; !ok {
+		s.counts[duty] = make(map[string]*participationCount)
+	}
+
+	count, ok := s.counts[duty][peer]
+	if !ok {
+		count = new(participationCount)
+		s.counts[duty][peer] = count
+	}
+
+	return count
+}
+
+// maybeSave persists the counters if the save interval elapsed since the last save.
+func (s *ParticipationStore) maybeSave() error {
+	s.mu.Lock()
+	elapsed := time.Since(s.lastSaved) >= participationSaveInterval
+	s.mu.Unlock()
+
+	if !elapsed {
+		return nil
+	}
+
+	return s.Save()
+}
+
+// Save persists the counters to disk, it is a noop for in-memory stores.
+func (s *ParticipationStore) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := json.Marshal(s.counts)
+	if err != nil {
+		return errors.Wrap(err, "marshal participation counts")
+	}
+
+	// Write to a temporary file and rename it to avoid corrupting the file on crashes.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "create temporary participation file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "write temporary participation file")
+	} else if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "close temporary participation file")
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return errors.Wrap(err, "rename participation file", z.Str("path", s.path))
+	}
+
+	s.lastSaved = time.Now()
+
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+func TestParticipationStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "participation.json")
+
+	store, err := NewParticipationStore(path)
+	require.NoError(t, err)
+
+	count := store.add("attester", "peer0", 2, 2)
+	require.InDelta(t, 1, count.Ratio, 0)
+
+	count = store.add("attester", "peer0", 0, 2)
+	require.InDelta(t, 1-1.0/participationWindow, count.Ratio, 1e-9)
+	require.InDelta(t, 2, count.Contributed, 0)
+	require.InDelta(t, 4, count.Expected, 0)
+
+	// Ratio is unchanged by duties without expected partial signatures.
+	count = store.add("attester", "peer0", 0, 0)
+	require.InDelta(t, 1-1.0/participationWindow, count.Ratio, 1e-9)
+
+	// Counters are only saved once the save interval elapsed.
+	require.NoError(t, store.maybeSave())
+	_, err = os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, store.Save())
+
+	loaded, err := NewParticipationStore(path)
+	require.NoError(t, err)
+	require.Equal(t, count, loaded.get("attester", "peer0"))
+	require.Equal(t, participationCount{}, loaded.get("proposer", "peer0"))
+
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0o644))
+	_, err = NewParticipationStore(path)
+	require.ErrorContains(t, err, "unmarshal participation file")
+}
+
+func TestParticipationReporterRestore(t *testing.T) {
+	store, err := NewParticipationStore("")
+	require.NoError(t, err)
+	store.add("randao", "restore-peer", 10, 20)
+
+	peers := []p2p.Peer{{Name: "restore-peer", Index: 0}}
+	report := newParticipationReporter(peers, store)
+
+	require.InDelta(t, 10, promtestutil.ToFloat64(peerParSigs.WithLabelValues("randao", "restore-peer")), 0)
+	require.InDelta(t, 20, promtestutil.ToFloat64(peerParSigsExpected.WithLabelValues("randao", "restore-peer")), 0)
+	require.InDelta(t, 0.5, promtestutil.ToFloat64(peerParticipationRatio.WithLabelValues("randao", "restore-peer")), 0)
+
+	report(context.Background(), core.NewRandaoDuty(1), false, map[int]int{1: 1}, nil, 1)
+
+	require.InDelta(t, 11, promtestutil.ToFloat64(peerParSigs.WithLabelValues("randao", "restore-peer")), 0)
+	require.InDelta(t, 21, promtestutil.ToFloat64(peerParSigsExpected.WithLabelValues("randao", "restore-peer")), 0)
+	require.InDelta(t, 0.5+0.5/participationWindow, promtestutil.ToFloat64(peerParticipationRatio.WithLabelValues("randao", "restore-peer")), 1e-9)
+}
@@ -6,6 +6,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 
@@ -104,22 +106,68 @@ type Tracker struct {
 
 	// participationReporter instruments duty peer participation.
 	participationReporter func(ctx context.Context, duty core.Duty, failed bool, participatedShares map[int]int, unexpectedPeers map[int]int, expectedPerPeer int)
+
+	// participation stores the persisted peer participation counters.
+	participation *ParticipationStore
+
+	// analysedDutyCallbacks are called with the outcome of each analysed duty.
+	analysedDutyCallbacks []func(ctx context.Context, duty core.Duty, failed bool, step string, reasonCode string, err error, shares []int)
+}
+
+// Option configures a Tracker.
+type Option func(*Tracker)
+
+// WithParticipationStore returns an option that persists peer participation counters in the store.
+func WithParticipationStore(store *ParticipationStore) Option {
+	return func(t *Tracker) {
+		t.participation = store
+	}
+}
+
+// WithFailedDutyCallback returns an option that calls the callback with the failed step and reason code of each failed duty.
+func WithFailedDutyCallback(callback func(ctx context.Context, duty core.Duty, step string, reasonCode string, err error)) Option {
+	return func(t *Tracker) {
+		report := t.failedDutyReporter
+		t.failedDutyReporter = func(ctx context.Context, duty core.Duty, failed bool, step step, reason reason, err error) {
+			report(ctx, duty, failed, step, reason, err)
+
+			if failed {
+				callback(ctx, duty, step.String(), reason.Code, err)
+			}
+		}
+	}
+}
+
+// WithAnalysedDutyCallback returns an option that calls the callback with the outcome of each analysed duty,
+// including the share indexes of the partial signatures received for the duty.
+func WithAnalysedDutyCallback(callback func(ctx context.Context, duty core.Duty, failed bool, step string, reasonCode string, err error, shares []int)) Option {
+	return func(t *Tracker) {
+		t.analysedDutyCallbacks = append(t.analysedDutyCallbacks, callback)
+	}
 }
 
 // New returns a new Tracker. The deleter deadliner must return well after analyser deadliner since duties of the same slot are often analysed together.
-func New(analyser core.Deadliner, deleter core.Deadliner, peers []p2p.Peer, fromSlot uint64) *Tracker {
+func New(analyser core.Deadliner, deleter core.Deadliner, peers []p2p.Peer, fromSlot uint64, opts ...Option) *Tracker {
+	inMemory, _ := NewParticipationStore("") // In-memory stores never error.
+
 	t := &Tracker{
-		input:                 make(chan event),
-		events:                make(map[core.Duty][]event),
-		quit:                  make(chan struct{}),
-		analyser:              analyser,
-		deleter:               deleter,
-		fromSlot:              fromSlot,
-		parSigReporter:        reportParSigs,
-		failedDutyReporter:    newFailedDutyReporter(),
-		participationReporter: newParticipationReporter(peers),
+		input:              make(chan event),
+		events:             make(map[core.Duty][]event),
+		quit:               make(chan struct{}),
+		analyser:           analyser,
+		deleter:            deleter,
+		fromSlot:           fromSlot,
+		parSigReporter:     reportParSigs,
+		failedDutyReporter: newFailedDutyReporter(),
+		participation:      inMemory,
+	}
+
+	for _, opt := range opts {
+		opt(t)
 	}
 
+	t.participationReporter = newParticipationReporter(peers, t.participation)
+
 	return t
 }
 
@@ -128,6 +176,11 @@ func New(analyser core.Deadliner, deleter core.Deadliner, peers []p2p.Peer, from
 func (t *Tracker) Run(ctx context.Context) error {
 	ctx = log.WithTopic(ctx, "tracker")
 	defer close(t.quit)
+	defer func() {
+		if err := t.participation.Save(); err != nil {
+			log.Warn(ctx, "Failed to save participation counters", err)
+		}
+	}()
 
 	ignoreUnsupported := newUnsupportedIgnorer()
 
@@ -161,6 +214,13 @@ func (t *Tracker) Run(ctx context.Context) error {
 			// Analyse peer participation
 			participatedShares, unexpectedShares, expectedPerPeer := analyseParticipation(duty, t.events)
 			t.participationReporter(ctx, duty, failed, participatedShares, unexpectedShares, expectedPerPeer)
+
+			if len(t.analysedDutyCallbacks) > 0 {
+				shares := slices.Sorted(maps.Keys(participatedShares))
+				for _, callback := range t.analysedDutyCallbacks {
+					callback(ctx, duty, failed, failedStep.String(), reason.Code, failedErr, shares)
+				}
+			}
 		case duty := <-t.deleter.C():
 			delete(t.events, duty)
 		}
@@ -612,7 +672,8 @@ func isParSigEventExpected(duty core.Duty, pubkey core.PubKey, allEvents map[cor
 
 // newParticipationReporter returns a new participation reporter function which logs and instruments peer participation
 // and unexpectedPeers.
-func newParticipationReporter(peers []p2p.Peer) func(context.Context, core.Duty, bool, map[int]int, map[int]int, int) {
+// Persisted peer participation counters are restored from the store and updated for each duty.
+func newParticipationReporter(peers []p2p.Peer, store *ParticipationStore) func(context.Context, core.Duty, bool, map[int]int, map[int]int, int) {
 	// prevAbsent is the set of peers who didn't participate in the last duty per type.
 	prevAbsent := make(map[core.DutyType][]string)
 
@@ -624,6 +685,14 @@ func newParticipationReporter(peers []p2p.Peer) func(context.Context, core.Duty,
 			participationSuccessLegacy.WithLabelValues(duty, peer.Name).Add(0)
 			participationMissed.WithLabelValues(duty, peer.Name).Add(0)
 			participationExpect.WithLabelValues(duty, peer.Name).Add(0)
+
+			// Restore persisted counters so they don't reset on restart.
+			count := store.get(duty, peer.Name)
+			peerParSigs.WithLabelValues(duty, peer.Name).Add(count.Contributed)
+			peerParSigsExpected.WithLabelValues(duty, peer.Name).Add(count.Expected)
+			if count.Expected > 0 {
+				peerParticipationRatio.WithLabelValues(duty, peer.Name).Set(count.Ratio)
+			}
 		}
 	}
 
@@ -640,6 +709,13 @@ func newParticipationReporter(peers []p2p.Peer) func(context.Context, core.Duty,
 			participationExpect.WithLabelValues(duty.Type.String(), peer.Name).Add(float64(expectedPerPeer))
 			participationMissed.WithLabelValues(duty.Type.String(), peer.Name).Add(float64(expectedPerPeer - participatedShares[peer.ShareIdx()]))
 
+			count := store.add(duty.Type.String(), peer.Name, participatedShares[peer.ShareIdx()], expectedPerPeer)
+			peerParSigs.WithLabelValues(duty.Type.String(), peer.Name).Add(float64(participatedShares[peer.ShareIdx()]))
+			peerParSigsExpected.WithLabelValues(duty.Type.String(), peer.Name).Add(float64(expectedPerPeer))
+			if count.Expected > 0 {
+				peerParticipationRatio.WithLabelValues(duty.Type.String(), peer.Name).Set(count.Ratio)
+			}
+
 			if participatedShares[peer.ShareIdx()] > 0 {
 				participationGauge.WithLabelValues(duty.Type.String(), peer.Name).Set(1)
 			} else if unexpectedShares[peer.ShareIdx()] > 0 {
@@ -662,6 +738,10 @@ func newParticipationReporter(peers []p2p.Peer) func(context.Context, core.Duty,
 		}
 
 		prevAbsent[duty.Type] = absentPeers
+
+		if err := store.maybeSave(); err != nil {
+			log.Warn(ctx, "Failed to save participation counters", err)
+		}
 	}
 }
 
@@ -856,12 +936,12 @@ func reportParSigs(ctx context.Context, duty core.Duty, parsigMsgs parsigsByMsg)
 
 		if expectInconsistentParSigs(duty.Type) {
 			log.Debug(ctx, "Inconsistent sync committee partial signed data",
-				z.Any("pubkey", pubkey),
+				z.Redact(z.Any("pubkey", pubkey)),
 				z.Any("duty", duty),
 				z.Any("data", indexesByJSON))
 		} else {
 			log.Warn(ctx, "Inconsistent partial signed data", nil,
-				z.Any("pubkey", pubkey),
+				z.Redact(z.Any("pubkey", pubkey)),
 				z.Any("duty", duty),
 				z.Any("data", indexesByJSON))
 		}
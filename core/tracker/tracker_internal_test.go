@@ -93,7 +93,14 @@ func TestTrackerFailedDuty(t *testing.T) {
 			}
 		}
 
-		tr := New(analyser, deleter, []p2p.Peer{}, 0)
+		var analysed int
+		tr := New(analyser, deleter, []p2p.Peer{}, 0, WithAnalysedDutyCallback(
+			func(_ context.Context, duty core.Duty, failed bool, _ string, _ string, _ error, shares []int) {
+				require.Equal(t, testData[0].duty, duty)
+				require.False(t, failed)
+				require.Equal(t, []int{1}, shares)
+				analysed++
+			}))
 		tr.failedDutyReporter = failedDutyReporter
 		tr.participationReporter = func(_ context.Context, _ core.Duty, failed bool, _ map[int]int, _ map[int]int, _ int) {
 			require.False(t, failed)
@@ -123,6 +130,7 @@ func TestTrackerFailedDuty(t *testing.T) {
 		}()
 
 		require.ErrorIs(t, tr.Run(ctx), context.Canceled)
+		require.Equal(t, len(testData), analysed)
 	})
 }
 
@@ -396,6 +404,26 @@ func TestDutyFailedStep(t *testing.T) {
 	})
 }
 
+func TestFailedDutyCallback(t *testing.T) {
+	type failure struct {
+		Duty   core.Duty
+		Step   string
+		Reason string
+	}
+
+	var failures []failure
+	tr := New(testDeadliner{}, testDeadliner{}, nil, 0, WithFailedDutyCallback(
+		func(_ context.Context, duty core.Duty, step string, reasonCode string, _ error) {
+			failures = append(failures, failure{Duty: duty, Step: step, Reason: reasonCode})
+		}))
+
+	ctx := context.Background()
+	tr.failedDutyReporter(ctx, core.NewAttesterDuty(1), false, zero, reason{}, nil)
+	tr.failedDutyReporter(ctx, core.NewAttesterDuty(2), true, consensus, reasonNoConsensus, errors.New("timeout"))
+
+	require.Equal(t, []failure{{Duty: core.NewAttesterDuty(2), Step: "consensus", Reason: reasonNoConsensus.Code}}, failures)
+}
+
 func TestTrackerParticipation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	slots := []int{1, 2, 3}
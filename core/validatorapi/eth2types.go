@@ -21,6 +21,8 @@ import (
 type errorResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	// ErrorCode is the charon error code of the failure if known, e.g. E2101.
+	ErrorCode string `json:"error_code,omitempty"`
 	// TODO(corver): Maybe add stacktraces field for debugging.
 }
 
@@ -84,7 +84,8 @@ type Handler interface {
 // NewRouter returns a new validator http server router. The http router
 // translates http requests related to the distributed validator to the Handler.
 // All other requests are reverse-proxied to the beacon-node address.
-func NewRouter(ctx context.Context, h Handler, eth2Cl eth2wrap.Client, builderEnabled bool) (*mux.Router, error) {
+// The builderEnabled function returns whether builder blocks are prioritised, which may change at runtime.
+func NewRouter(ctx context.Context, h Handler, eth2Cl eth2wrap.Client, builderEnabled func() bool) (*mux.Router, error) {
 	// Register subset of distributed validator related endpoints.
 	endpoints := []struct {
 		Name    string
@@ -641,7 +642,7 @@ func respond404(endpoint string) handlerFunc {
 }
 
 // proposeBlockV3 returns a handler function returning an unsigned BeaconBlock or BlindedBeaconBlock.
-func proposeBlockV3(p eth2client.ProposalProvider, builderEnabled bool) handlerFunc {
+func proposeBlockV3(p eth2client.ProposalProvider, builderEnabled func() bool) handlerFunc {
 	return func(ctx context.Context, params map[string]string, query url.Values, _ contentType, _ []byte) (any, http.Header, error) {
 		slot, randao, graffiti, err := getProposeBlockParams(params, query)
 		if err != nil {
@@ -649,7 +650,7 @@ func proposeBlockV3(p eth2client.ProposalProvider, builderEnabled bool) handlerF
 		}
 
 		var bbf uint64
-		if builderEnabled {
+		if builderEnabled() {
 			// This gives maximum priority to builder blocks:
 			// https://ethereum.github.io/beacon-APIs/#/Validator/produceBlockV3
 			bbf = math.MaxUint64
@@ -1120,7 +1121,7 @@ func getBeaconNodeAddress(addrProvider addressProvider) (*url.URL, error) {
 	addr := addrProvider.Address()
 	targetURL, err := url.ParseRequestURI(addr)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid beacon node address", z.Str("address", addr))
+		return nil, errors.Wrap(err, "invalid beacon node address", z.Redact(z.Str("address", addr)))
 	}
 
 	return targetURL, nil
@@ -1168,6 +1169,9 @@ func writeError(ctx context.Context, w http.ResponseWriter, endpoint string, err
 		Message: aerr.Message,
 		// TODO(corver): Add support for debug mode error and stacktraces.
 	}
+	if code, ok := errors.CodeOf(aerr.Err); ok {
+		res.ErrorCode = code.String()
+	}
 
 	b, err2 := json.Marshal(res)
 	if err2 != nil {
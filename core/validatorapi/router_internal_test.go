@@ -87,7 +87,7 @@ func TestRouterIntegration(t *testing.T) {
 		t.Skip("Skipping integration test since BEACON_URL not found")
 	}
 
-	r, err := NewRouter(context.Background(), Handler(nil), testBeaconAddr{addr: beaconURL}, true)
+	r, err := NewRouter(context.Background(), Handler(nil), testBeaconAddr{addr: beaconURL}, func() bool { return true })
 	require.NoError(t, err)
 
 	server := httptest.NewServer(r)
@@ -139,6 +139,30 @@ func TestRawRouter(t *testing.T) {
 		testRawRouter(t, handler, callback)
 	})
 
+	t.Run("error code", func(t *testing.T) {
+		handler := testHandler{
+			AttesterDutiesFunc: func(context.Context, *eth2api.AttesterDutiesOpts) (*eth2api.Response[[]*eth2v1.AttesterDuty], error) {
+				return nil, errors.WithCode(errors.New("beacon api timeout"), errors.CodeBeaconTimeout)
+			},
+		}
+
+		callback := func(ctx context.Context, baseURL string) {
+			res, err := http.Post(baseURL+"/eth/v1/validator/duties/attester/1", "application/json", bytes.NewReader([]byte(`["1"]`)))
+			require.NoError(t, err)
+
+			var errRes errorResponse
+			err = json.NewDecoder(res.Body).Decode(&errRes)
+			require.NoError(t, err)
+			require.Equal(t, errRes, errorResponse{
+				Code:      http.StatusInternalServerError,
+				Message:   "Internal server error",
+				ErrorCode: "E2101",
+			})
+		}
+
+		testRawRouter(t, handler, callback)
+	})
+
 	t.Run("missing query params", func(t *testing.T) {
 		handler := testHandler{}
 
@@ -613,7 +637,7 @@ func TestRouter(t *testing.T) {
 		proxy := httptest.NewServer(h.newBeaconHandler(t))
 		defer proxy.Close()
 
-		r, err := NewRouter(ctx, h, testBeaconAddr{addr: proxy.URL}, true)
+		r, err := NewRouter(ctx, h, testBeaconAddr{addr: proxy.URL}, func() bool { return true })
 		require.NoError(t, err)
 
 		server := httptest.NewServer(r)
@@ -1383,7 +1407,7 @@ func TestBeaconCommitteeSelections(t *testing.T) {
 	proxy := httptest.NewServer(handler.newBeaconHandler(t))
 	defer proxy.Close()
 
-	r, err := NewRouter(ctx, handler, testBeaconAddr{addr: proxy.URL}, true)
+	r, err := NewRouter(ctx, handler, testBeaconAddr{addr: proxy.URL}, func() bool { return true })
 	require.NoError(t, err)
 
 	server := httptest.NewServer(r)
@@ -1445,7 +1469,7 @@ func TestSubmitAggregateAttestations(t *testing.T) {
 	proxy := httptest.NewServer(handler.newBeaconHandler(t))
 	defer proxy.Close()
 
-	r, err := NewRouter(ctx, handler, testBeaconAddr{addr: proxy.URL}, true)
+	r, err := NewRouter(ctx, handler, testBeaconAddr{addr: proxy.URL}, func() bool { return true })
 	require.NoError(t, err)
 
 	server := httptest.NewServer(r)
@@ -1737,7 +1761,7 @@ func testRouter(t *testing.T, handler testHandler, callback func(context.Context
 
 	ctx := context.Background()
 
-	r, err := NewRouter(ctx, handler, testBeaconAddr{addr: proxy.URL}, true)
+	r, err := NewRouter(ctx, handler, testBeaconAddr{addr: proxy.URL}, func() bool { return true })
 	require.NoError(t, err)
 
 	server := httptest.NewServer(r)
@@ -1765,7 +1789,7 @@ func testRawRouterEx(t *testing.T, handler testHandler, callback func(context.Co
 	proxy := httptest.NewServer(handler.newBeaconHandler(t))
 	defer proxy.Close()
 
-	r, err := NewRouter(context.Background(), handler, testBeaconAddr{addr: proxy.URL}, builderEnabled)
+	r, err := NewRouter(context.Background(), handler, testBeaconAddr{addr: proxy.URL}, func() bool { return builderEnabled })
 	require.NoError(t, err)
 
 	server := httptest.NewServer(r)
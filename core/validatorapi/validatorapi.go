@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"runtime"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -79,7 +80,7 @@ func NewComponentInsecure(_ *testing.T, eth2Cl eth2wrap.Client, shareIdx int) (*
 	return &Component{
 		eth2Cl:         eth2Cl,
 		shareIdx:       shareIdx,
-		builderEnabled: false,
+		builderEnabled: new(atomic.Bool),
 		insecureTest:   true,
 	}, nil
 }
@@ -88,38 +89,24 @@ func NewComponentInsecure(_ *testing.T, eth2Cl eth2wrap.Client, shareIdx int) (*
 func NewComponent(eth2Cl eth2wrap.Client, allPubSharesByKey map[core.PubKey]map[int]tbls.PublicKey,
 	shareIdx int, feeRecipientFunc func(core.PubKey) string, builderEnabled bool, targetGasLimit uint, seenPubkeys func(core.PubKey),
 ) (*Component, error) {
-	var (
-		sharesByKey     = make(map[eth2p0.BLSPubKey]eth2p0.BLSPubKey)
-		keysByShare     = make(map[eth2p0.BLSPubKey]eth2p0.BLSPubKey)
-		sharesByCoreKey = make(map[core.PubKey]tbls.PublicKey)
-		coreSharesByKey = make(map[core.PubKey]core.PubKey)
-	)
-	for corePubkey, shares := range allPubSharesByKey {
-		pubshare := shares[shareIdx]
-		coreShare, err := core.PubKeyFromBytes(pubshare[:])
-		if err != nil {
-			return nil, err
-		}
+	c := &Component{
+		eth2Cl:           eth2Cl,
+		shareIdx:         shareIdx,
+		feeRecipientFunc: feeRecipientFunc,
+		builderEnabled:   new(atomic.Bool),
+		targetGasLimit:   targetGasLimit,
+		swallowRegFilter: log.Filter(),
+		pubShares:        new(atomic.Pointer[pubShareSet]),
+	}
 
-		cpBytes, err := corePubkey.Bytes()
-		if err != nil {
-			return nil, err
-		}
-		pubkey, err := tblsconv.PubkeyFromBytes(cpBytes)
-		if err != nil {
-			return nil, err
-		}
-		eth2Pubkey := eth2p0.BLSPubKey(pubkey)
+	c.builderEnabled.Store(builderEnabled)
 
-		eth2Share := eth2p0.BLSPubKey(pubshare)
-		sharesByCoreKey[corePubkey] = pubshare
-		coreSharesByKey[corePubkey] = coreShare
-		sharesByKey[eth2Pubkey] = eth2Share
-		keysByShare[eth2Share] = eth2Pubkey
+	if err := c.SetPubShares(allPubSharesByKey); err != nil {
+		return nil, err
 	}
 
-	getVerifyShareFunc := func(pubkey core.PubKey) (tbls.PublicKey, error) {
-		pubshare, ok := sharesByCoreKey[pubkey]
+	c.getVerifyShareFunc = func(pubkey core.PubKey) (tbls.PublicKey, error) {
+		pubshare, ok := c.pubShares.Load().sharesByCoreKey[pubkey]
 		if !ok {
 			return tbls.PublicKey{}, errors.New("unknown public key")
 		}
@@ -127,8 +114,8 @@ func NewComponent(eth2Cl eth2wrap.Client, allPubSharesByKey map[core.PubKey]map[
 		return pubshare, nil
 	}
 
-	getPubShareFunc := func(pubkey eth2p0.BLSPubKey) (eth2p0.BLSPubKey, bool) {
-		share, ok := sharesByKey[pubkey]
+	c.getPubShareFunc = func(pubkey eth2p0.BLSPubKey) (eth2p0.BLSPubKey, bool) {
+		share, ok := c.pubShares.Load().sharesByKey[pubkey]
 
 		if seenPubkeys != nil {
 			seenPubkeys(core.PubKeyFrom48Bytes(pubkey))
@@ -137,10 +124,12 @@ func NewComponent(eth2Cl eth2wrap.Client, allPubSharesByKey map[core.PubKey]map[
 		return share, ok
 	}
 
-	getPubKeyFunc := func(share eth2p0.BLSPubKey) (eth2p0.BLSPubKey, error) {
-		key, ok := keysByShare[share]
+	c.getPubKeyFunc = func(share eth2p0.BLSPubKey) (eth2p0.BLSPubKey, error) {
+		set := c.pubShares.Load()
+
+		key, ok := set.keysByShare[share]
 		if !ok {
-			for _, shares := range allPubSharesByKey {
+			for _, shares := range set.allPubSharesByKey {
 				for keyshareIdx, pubshare := range shares {
 					if eth2p0.BLSPubKey(pubshare) == share {
 						return eth2p0.BLSPubKey{}, errors.New("mismatching validator client key share index, Mth key share submitted to Nth charon peer",
@@ -159,18 +148,71 @@ func NewComponent(eth2Cl eth2wrap.Client, allPubSharesByKey map[core.PubKey]map[
 		return key, nil
 	}
 
-	return &Component{
-		getVerifyShareFunc: getVerifyShareFunc,
-		getPubShareFunc:    getPubShareFunc,
-		getPubKeyFunc:      getPubKeyFunc,
-		sharesByKey:        coreSharesByKey,
-		eth2Cl:             eth2Cl,
-		shareIdx:           shareIdx,
-		feeRecipientFunc:   feeRecipientFunc,
-		builderEnabled:     builderEnabled,
-		targetGasLimit:     targetGasLimit,
-		swallowRegFilter:   log.Filter(),
-	}, nil
+	return c, nil
+}
+
+// BuilderEnabled returns true if the builder API is enabled.
+func (c Component) BuilderEnabled() bool {
+	return c.builderEnabled.Load()
+}
+
+// SetBuilderEnabled enables or disables the builder API, e.g. when reloading config.
+// It is safe to call concurrently with validator API requests.
+func (c Component) SetBuilderEnabled(enabled bool) {
+	c.builderEnabled.Store(enabled)
+}
+
+// SetPubShares replaces the public shares of all validators by root public key.
+// It is safe to call concurrently with validator API requests, e.g. when the cluster manifest is reloaded.
+func (c *Component) SetPubShares(allPubSharesByKey map[core.PubKey]map[int]tbls.PublicKey) error {
+	set := &pubShareSet{
+		allPubSharesByKey: allPubSharesByKey,
+		sharesByKey:       make(map[eth2p0.BLSPubKey]eth2p0.BLSPubKey),
+		keysByShare:       make(map[eth2p0.BLSPubKey]eth2p0.BLSPubKey),
+		sharesByCoreKey:   make(map[core.PubKey]tbls.PublicKey),
+		coreSharesByKey:   make(map[core.PubKey]core.PubKey),
+	}
+	for corePubkey, shares := range allPubSharesByKey {
+		pubshare := shares[c.shareIdx]
+		coreShare, err := core.PubKeyFromBytes(pubshare[:])
+		if err != nil {
+			return err
+		}
+
+		cpBytes, err := corePubkey.Bytes()
+		if err != nil {
+			return err
+		}
+		pubkey, err := tblsconv.PubkeyFromBytes(cpBytes)
+		if err != nil {
+			return err
+		}
+		eth2Pubkey := eth2p0.BLSPubKey(pubkey)
+
+		eth2Share := eth2p0.BLSPubKey(pubshare)
+		set.sharesByCoreKey[corePubkey] = pubshare
+		set.coreSharesByKey[corePubkey] = coreShare
+		set.sharesByKey[eth2Pubkey] = eth2Share
+		set.keysByShare[eth2Share] = eth2Pubkey
+	}
+
+	c.pubShares.Store(set)
+
+	return nil
+}
+
+// pubShareSet contains lookups of validator public keys and this node's public shares.
+type pubShareSet struct {
+	// allPubSharesByKey contains all public shares by share index by root public key.
+	allPubSharesByKey map[core.PubKey]map[int]tbls.PublicKey
+	// sharesByKey contains this node's public shares by root public key.
+	sharesByKey map[eth2p0.BLSPubKey]eth2p0.BLSPubKey
+	// keysByShare contains root public keys by this node's public shares.
+	keysByShare map[eth2p0.BLSPubKey]eth2p0.BLSPubKey
+	// sharesByCoreKey contains this node's public shares by root public key.
+	sharesByCoreKey map[core.PubKey]tbls.PublicKey
+	// coreSharesByKey contains this node's public shares by root public key.
+	coreSharesByKey map[core.PubKey]core.PubKey
 }
 
 type Component struct {
@@ -178,7 +220,7 @@ type Component struct {
 	shareIdx         int
 	insecureTest     bool
 	feeRecipientFunc func(core.PubKey) string
-	builderEnabled   bool
+	builderEnabled   *atomic.Bool
 	targetGasLimit   uint
 	swallowRegFilter z.Field
 
@@ -189,8 +231,8 @@ type Component struct {
 	getPubShareFunc func(eth2p0.BLSPubKey) (eth2p0.BLSPubKey, bool)
 	// getPubKeyFunc returns the root public key for a public share.
 	getPubKeyFunc func(eth2p0.BLSPubKey) (eth2p0.BLSPubKey, error)
-	// sharesByKey contains this node's public shares (value) by root public (key)
-	sharesByKey map[core.PubKey]core.PubKey
+	// pubShares contains the validator public key and public share lookups.
+	pubShares *atomic.Pointer[pubShareSet]
 
 	// Registered input functions
 
@@ -613,7 +655,7 @@ func (c Component) submitRegistration(ctx context.Context, registration *eth2api
 
 	if _, ok := c.getPubShareFunc(eth2Pubkey); !ok {
 		log.Debug(ctx, "Swallowing non-dv registration, "+
-			"this is a known limitation for many validator clients", z.Any("pubkey", pubkey), c.swallowRegFilter)
+			"this is a known limitation for many validator clients", z.Redact(z.Any("pubkey", pubkey)), c.swallowRegFilter)
 
 		return nil
 	}
@@ -661,7 +703,7 @@ func (c Component) SubmitValidatorRegistrations(ctx context.Context, registratio
 	}
 
 	// Swallow unexpected validator registrations from VCs (for ex: vouch)
-	if !c.builderEnabled {
+	if !c.builderEnabled.Load() {
 		return nil
 	}
 
@@ -1312,7 +1354,12 @@ func (c Component) ProposerConfig(ctx context.Context) (*eth2exp.ProposerConfigR
 	}
 	timestamp = timestamp.Add(slotDuration) // Use slot 1 for timestamp to override pre-generated registrations.
 
-	for pubkey, pubshare := range c.sharesByKey {
+	var sharesByKey map[core.PubKey]core.PubKey
+	if c.pubShares != nil {
+		sharesByKey = c.pubShares.Load().coreSharesByKey
+	}
+
+	for pubkey, pubshare := range sharesByKey {
 		eth2Share, err := pubshare.ToETH2()
 		if err != nil {
 			return nil, err
@@ -1321,7 +1368,7 @@ func (c Component) ProposerConfig(ctx context.Context) (*eth2exp.ProposerConfigR
 		resp.Proposers[eth2Share] = eth2exp.ProposerConfig{
 			FeeRecipient: c.feeRecipientFunc(pubkey),
 			Builder: eth2exp.Builder{
-				Enabled:  c.builderEnabled,
+				Enabled:  c.builderEnabled.Load(),
 				GasLimit: targetGasLimit,
 				Overrides: map[string]string{
 					"timestamp":  strconv.FormatInt(timestamp.Unix(), 10),
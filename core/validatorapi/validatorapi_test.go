@@ -286,7 +286,7 @@ func TestSignAndVerify(t *testing.T) {
 	require.NoError(t, err)
 
 	// Sign
-	sig, err := signer(eth2Pubkey, sigDataBytes[:])
+	sig, err := signer(eth2Pubkey, sigDataBytes[:], attData)
 	require.NoError(t, err)
 
 	// Assert signature
@@ -1599,6 +1599,39 @@ func TestComponent_TekuProposerConfig(t *testing.T) {
 	}, resp)
 }
 
+func TestComponent_SetPubShares(t *testing.T) {
+	ctx := context.Background()
+	const shareIdx = 1
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	vapi, err := validatorapi.NewComponent(bmock, make(map[core.PubKey]map[int]tbls.PublicKey), shareIdx, func(core.PubKey) string {
+		return ""
+	}, false, 30000000, nil)
+	require.NoError(t, err)
+
+	resp, err := vapi.ProposerConfig(ctx)
+	require.NoError(t, err)
+	require.Empty(t, resp.Proposers)
+
+	// Add a validator at runtime.
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+	pubkey, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+	corePubKey, err := core.PubKeyFromBytes(pubkey[:])
+	require.NoError(t, err)
+
+	err = vapi.SetPubShares(map[core.PubKey]map[int]tbls.PublicKey{corePubKey: {shareIdx: pubkey}})
+	require.NoError(t, err)
+
+	resp, err = vapi.ProposerConfig(ctx)
+	require.NoError(t, err)
+	require.Len(t, resp.Proposers, 1)
+	require.Contains(t, resp.Proposers, eth2p0.BLSPubKey(pubkey))
+}
+
 func TestComponent_AggregateBeaconCommitteeSelections(t *testing.T) {
 	ctx := context.Background()
 
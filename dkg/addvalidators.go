@@ -0,0 +1,324 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/dkg/bcast"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const addValidatorsApprovalID = "/charon/dkg/add_validators/1.0.0/approval"
+
+// AddValidatorsConfig defines the config of a ceremony adding new distributed validators to an existing cluster.
+type AddValidatorsConfig struct {
+	// DataDir is the charon data directory containing the existing cluster lock or manifest, p2p key and validator keys.
+	DataDir string
+	// OutputDir is the directory the new cluster manifest, validator keys and deposit data are written to.
+	OutputDir string
+	// NumValidators is the number of distributed validators to add.
+	NumValidators int
+	// FeeRecipientAddrs is either a single fee recipient address or one per new validator.
+	FeeRecipientAddrs []string
+	// WithdrawalAddrs is either a single withdrawal address or one per new validator.
+	WithdrawalAddrs []string
+	NoVerify        bool
+	P2P             p2p.Config
+	Log             log.Config
+	ShutdownDelay   time.Duration
+	Timeout         time.Duration
+
+	TestConfig TestConfig
+}
+
+// RunAddValidators executes a ceremony that generates new distributed validators for an existing cluster.
+// All operators of the cluster run a DKG for the new validators and approve the resulting add validators
+// cluster manifest mutation. The new cluster manifest, all validator keys (existing and new) and the deposit
+// data of the new validators are written to the output directory.
+func RunAddValidators(ctx context.Context, conf AddValidatorsConfig) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ctx = log.WithTopic(ctx, "addvals")
+
+	if conf.NumValidators <= 0 {
+		return errors.New("insufficient number of validators to add", z.Int("num_validators", conf.NumValidators))
+	}
+
+	feeRecipients, err := expandAddresses(conf.FeeRecipientAddrs, conf.NumValidators, "fee recipient")
+	if err != nil {
+		return err
+	}
+
+	withdrawalAddrs, err := expandAddresses(conf.WithdrawalAddrs, conf.NumValidators, "withdrawal")
+	if err != nil {
+		return err
+	}
+
+	unlock, err := lockPrivKey(ctx, conf.DataDir, "charon alpha add-validators")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	version.LogInfo(ctx, "Charon add validators starting")
+
+	dag, err := loadClusterDAG(ctx, conf.DataDir, conf.NoVerify, conf.TestConfig)
+	if err != nil {
+		return err
+	}
+
+	c, err := manifest.Materialise(dag)
+	if err != nil {
+		return errors.Wrap(err, "materialise cluster manifest")
+	}
+
+	network, err := eth2util.ForkVersionToNetwork(c.GetForkVersion())
+	if err != nil {
+		return err
+	}
+
+	peers, err := manifest.ClusterPeers(c)
+	if err != nil {
+		return err
+	}
+
+	key, pID, err := loadP2PKey(conf.DataDir, conf.TestConfig)
+	if err != nil {
+		return err
+	}
+
+	nodeIdx, err := manifest.ClusterNodeIdx(c, pID)
+	if err != nil {
+		return errors.Wrap(err, "private key not matching any cluster operator")
+	}
+
+	var (
+		peerIDs []peer.ID
+		peerMap = make(map[peer.ID]cluster.NodeIdx)
+	)
+	for _, p := range peers {
+		peerIDs = append(peerIDs, p.ID)
+		peerMap[p.ID] = cluster.NodeIdx{PeerIdx: p.Index, ShareIdx: p.ShareIdx()}
+	}
+
+	if err := checkWrites(conf.OutputDir); err != nil {
+		return err
+	}
+
+	existing, err := loadClusterShares(conf.DataDir, c, nodeIdx, true)
+	if err != nil {
+		return err
+	}
+
+	ceremonyHash := addValidatorsCeremonyHash(c, feeRecipients, withdrawalAddrs)
+
+	log.Info(ctx, "Starting local P2P networking peer",
+		z.Int("existing_validators", len(c.GetValidators())),
+		z.Int("new_validators", conf.NumValidators),
+	)
+
+	tcpNode, shutdown, err := setupP2P(ctx, key, Config{P2P: conf.P2P, TestConfig: conf.TestConfig}, peers, ceremonyHash)
+	if err != nil {
+		return err
+	}
+	defer shutdown()
+
+	ex := newExchanger(tcpNode, nodeIdx.PeerIdx, peerIDs, conf.NumValidators,
+		[]sigType{sigDepositData, sigValidatorRegistration}, conf.Timeout)
+
+	caster := bcast.New(tcpNode, peerIDs, key)
+
+	// register bcast callbacks for frostp2p
+	tp, err := newFrostP2P(tcpNode, peerMap, caster, int(c.GetThreshold()), conf.NumValidators)
+	if err != nil {
+		return errors.Wrap(err, "frost error")
+	}
+
+	// register bcast callbacks for node approvals of the new cluster manifest
+	approvalCaster := newApprovalBcast(peers, peerIDs, caster, addValidatorsApprovalID)
+
+	// Sync on the ceremony hash to ensure all peers add the same validators.
+	ctx, nextStepSync, stopSync, err := syncPeers(ctx, tcpNode, key, ceremonyHash, peerIDs, cancel, nil, conf.TestConfig, "adding validators")
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "All peers connected, starting DKG ceremony")
+
+	shares, err := runFrostParallel(ctx, tp, uint32(conf.NumValidators), uint32(len(peers)),
+		uint32(c.GetThreshold()), uint32(nodeIdx.ShareIdx), fmt.Sprintf("%#x", ceremonyHash))
+	if err != nil {
+		return err
+	}
+
+	// DKG was step 1, advance to step 2
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	depositDatas, err := signAndAggDepositData(ctx, ex, shares, withdrawalAddrs, network, nodeIdx,
+		[]eth2p0.Gwei{deposit.MaxDepositAmount}, false)
+	if err != nil {
+		return err
+	}
+
+	valRegs, err := signAndAggValidatorRegistrations(ctx, ex, shares, feeRecipients,
+		uint64(c.GetTargetGasLimit()), nodeIdx, c.GetForkVersion())
+	if err != nil {
+		return errors.Wrap(err, "builder validator registrations pre-generation")
+	}
+
+	log.Debug(ctx, "Aggregated deposit data and builder validator registration signatures")
+	// Signature aggregation was step 2, advance to step 3
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	distVals, err := createDistValidators(shares, depositDatas, valRegs)
+	if err != nil {
+		return err
+	}
+
+	var vals []*manifestpb.Validator
+	for i, dv := range distVals {
+		val, err := manifest.ValidatorToProto(dv, cluster.ValidatorAddresses{
+			FeeRecipientAddress: feeRecipients[i],
+			WithdrawalAddress:   withdrawalAddrs[i],
+		})
+		if err != nil {
+			return err
+		}
+
+		vals = append(vals, val)
+	}
+
+	genVals, err := manifest.NewGenValidators(c.GetLatestMutationHash(), vals)
+	if err != nil {
+		return err
+	}
+
+	genHash, err := manifest.Hash(genVals)
+	if err != nil {
+		return err
+	}
+
+	approvals, err := approvalCaster.exchange(ctx, key, genHash, true)
+	if err != nil {
+		return errors.Wrap(err, "node approval exchange")
+	}
+
+	nodeApprovals, err := manifest.NewNodeApprovalsComposite(approvals)
+	if err != nil {
+		return err
+	}
+
+	addVals, err := manifest.NewAddValidators(genVals, nodeApprovals)
+	if err != nil {
+		return err
+	}
+
+	dag.Mutations = append(dag.Mutations, addVals)
+
+	if _, err := manifest.Materialise(dag); err != nil {
+		return errors.Wrap(err, "invalid new cluster manifest")
+	}
+
+	log.Debug(ctx, "Exchanged node approvals")
+	// Node approvals was step 3, advance to step 4
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	// Write keystores, cluster manifest and deposit data after all approvals have been exchanged
+	// to prevent partial data writes in case of peer connection lost.
+	if err := writeKeysToDisk(Config{DataDir: conf.OutputDir, TestConfig: conf.TestConfig}, append(existing, shares...)); err != nil {
+		return err
+	}
+	log.Debug(ctx, "Saved keyshares to disk")
+
+	if err := writeClusterManifest(conf.OutputDir, dag); err != nil {
+		return err
+	}
+	log.Debug(ctx, "Saved cluster manifest to disk")
+
+	for _, dd := range depositDatas {
+		if err := deposit.WriteDepositDataFile(dd, network, conf.OutputDir); err != nil {
+			return err
+		}
+		log.Debug(ctx, "Saved deposit data file to disk", z.Str("filepath", deposit.GetDepositFilePath(conf.OutputDir, dd[0].Amount)))
+	}
+
+	// Disk write was step 4, advance to step 5
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	if err := stopCeremony(ctx, stopSync, conf.ShutdownDelay, conf.TestConfig); err != nil {
+		return err
+	}
+
+	addHash, err := manifest.Hash(addVals)
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Successfully completed add validators ceremony 🎉",
+		z.Str("output_dir", conf.OutputDir),
+		z.Str("mutation_hash", fmt.Sprintf("%#x", addHash)),
+	)
+	log.Info(ctx, "All operators must replace their cluster files and validator_keys with the new files "+
+		"before activating the new validators.")
+
+	return nil
+}
+
+// expandAddresses returns the addresses for each of the numVals validators, either as provided or by repeating
+// a single provided address.
+func expandAddresses(addrs []string, numVals int, name string) ([]string, error) {
+	if len(addrs) == 1 {
+		resp := make([]string, numVals)
+		for i := range resp {
+			resp[i] = addrs[0]
+		}
+
+		return resp, nil
+	} else if len(addrs) != numVals {
+		return nil, errors.New("mismatching number of validators and "+name+" addresses",
+			z.Int("num_validators", numVals), z.Int("addresses", len(addrs)))
+	}
+
+	return addrs, nil
+}
+
+// addValidatorsCeremonyHash returns a hash uniquely identifying the validators being added to the cluster.
+func addValidatorsCeremonyHash(c *manifestpb.Cluster, feeRecipients, withdrawalAddrs []string) []byte {
+	h := sha256.New()
+	_, _ = h.Write(c.GetLatestMutationHash())
+	_, _ = h.Write([]byte(manifest.TypeAddValidators))
+	_, _ = h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(feeRecipients))))
+
+	for i := range feeRecipients {
+		_, _ = h.Write([]byte(feeRecipients[i]))
+		_, _ = h.Write([]byte(withdrawalAddrs[i]))
+	}
+
+	return h.Sum(nil)
+}
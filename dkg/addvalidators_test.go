@@ -0,0 +1,139 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/dkg"
+	dkgsync "github.com/obolnetwork/charon/dkg/sync"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestAddValidators(t *testing.T) {
+	const (
+		nodes     = 3
+		threshold = 2
+		vals      = 1
+		newVals   = 2
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, secretShares := cluster.NewForT(t, vals, threshold, nodes, seed, random)
+
+	dir := t.TempDir()
+	relayAddr := startRelay(ctx, t)
+
+	conf := dkg.AddValidatorsConfig{
+		NumValidators:     newVals,
+		FeeRecipientAddrs: []string{testutil.RandomETHAddressSeed(random)},
+		WithdrawalAddrs:   []string{testutil.RandomETHAddressSeed(random), testutil.RandomETHAddressSeed(random)},
+		P2P:               p2p.Config{Relays: []string{relayAddr}},
+		Log:               log.DefaultConfig(),
+		TestConfig: dkg.TestConfig{
+			Lock: &lock,
+			StoreKeysFunc: func(secrets []tbls.PrivateKey, dir string) error {
+				return keystore.StoreKeysInsecure(secrets, dir, keystore.ConfirmInsecureKeys)
+			},
+			SyncOpts: []func(*dkgsync.Client){dkgsync.WithPeriod(time.Millisecond * 50)},
+		},
+		ShutdownDelay: 1 * time.Second,
+		Timeout:       8 * time.Second,
+	}
+
+	var eg errgroup.Group
+	for i, key := range p2pKeys {
+		conf := conf
+		conf.DataDir = path.Join(dir, fmt.Sprintf("node%d", i))
+		conf.OutputDir = path.Join(conf.DataDir, "added")
+		conf.P2P.TCPAddrs = []string{testutil.AvailableAddr(t).String()}
+
+		require.NoError(t, os.MkdirAll(conf.DataDir, 0o755))
+		require.NoError(t, k1util.Save(key, p2p.KeyPath(conf.DataDir)))
+
+		var nodeSecrets []tbls.PrivateKey
+		for _, shares := range secretShares {
+			nodeSecrets = append(nodeSecrets, shares[i])
+		}
+		keysDir, err := cluster.CreateValidatorKeysDir(conf.DataDir)
+		require.NoError(t, err)
+		require.NoError(t, keystore.StoreKeysInsecure(nodeSecrets, keysDir, keystore.ConfirmInsecureKeys))
+
+		eg.Go(func() error {
+			err := dkg.RunAddValidators(peerCtx(ctx, i), conf)
+			if err != nil {
+				cancel()
+			}
+
+			return err
+		})
+	}
+
+	err := eg.Wait()
+	testutil.SkipIfBindErr(t, err)
+	testutil.RequireNoError(t, err)
+
+	var nodeKeys [][]tbls.PrivateKey
+	for i := range p2pKeys {
+		outputDir := path.Join(dir, fmt.Sprintf("node%d", i), "added")
+
+		c, err := manifest.LoadCluster(path.Join(outputDir, "cluster-manifest.pb"), path.Join(outputDir, "cluster-lock.json"), nil)
+		require.NoError(t, err)
+		require.Len(t, c.GetValidators(), vals+newVals)
+		require.Equal(t, lock.Validators[0].PubKey, c.GetValidators()[0].GetPublicKey())
+		require.Equal(t, conf.FeeRecipientAddrs[0], c.GetValidators()[vals+1].GetFeeRecipientAddress())
+		require.Equal(t, conf.WithdrawalAddrs[1], c.GetValidators()[vals+1].GetWithdrawalAddress())
+
+		keyFiles, err := keystore.LoadFilesUnordered(path.Join(outputDir, "validator_keys"))
+		require.NoError(t, err)
+		keys, err := keyFiles.SequencedKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, vals+newVals)
+
+		for vIdx, val := range c.GetValidators() {
+			pubShare, err := tbls.SecretToPublicKey(keys[vIdx])
+			require.NoError(t, err)
+			require.Equal(t, val.GetPubShares()[i], pubShare[:])
+		}
+
+		nodeKeys = append(nodeKeys, keys)
+	}
+
+	// The new shares recover the new validator private keys.
+	outputDir := path.Join(dir, "node0", "added")
+	c, err := manifest.LoadCluster(path.Join(outputDir, "cluster-manifest.pb"), path.Join(outputDir, "cluster-lock.json"), nil)
+	require.NoError(t, err)
+
+	for vIdx := vals; vIdx < vals+newVals; vIdx++ {
+		shares := make(map[int]tbls.PrivateKey)
+		for i := range p2pKeys {
+			shares[i+1] = nodeKeys[i][vIdx]
+		}
+		secret, err := tbls.RecoverSecret(shares, nodes, threshold)
+		require.NoError(t, err)
+
+		pubkey, err := tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+		require.Equal(t, c.GetValidators()[vIdx].GetPublicKey(), pubkey[:])
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/privkeylock"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// The helpers below are shared by the ceremonies run over DKG p2p: the DKG itself and the
+// add validators, reshare, key share refresh and operator key rotation ceremonies.
+
+// lockPrivKey locks the p2p private key in the data dir for the command until the returned function is called.
+func lockPrivKey(ctx context.Context, dataDir, command string) (func(), error) {
+	lockSvc, err := privkeylock.New(p2p.KeyPath(dataDir)+".lock", command)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		if err := lockSvc.Run(); err != nil {
+			log.Error(ctx, "Error locking private key file", err)
+		}
+	}()
+
+	return lockSvc.Close, nil
+}
+
+// loadP2PKey returns the test p2p key if configured, otherwise the p2p key in the data dir, and its peer ID.
+func loadP2PKey(dataDir string, testConfig TestConfig) (*k1.PrivateKey, peer.ID, error) {
+	key := testConfig.P2PKey
+	if key == nil {
+		var err error
+		key, err = p2p.LoadPrivKey(dataDir)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	pID, err := p2p.PeerIDFromKey(key.PubKey())
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, pID, nil
+}
+
+// syncPeers waits for all peers to connect and starts the sync protocol on the ceremony hash. It returns
+// the context to use for the rest of the ceremony, which reports connection failures as failures of the action
+// (e.g. "DKG"), and the functions to advance to the next ceremony step and stop the sync protocol.
+func syncPeers(ctx context.Context, tcpNode host.Host, key *k1.PrivateKey, hash []byte, peerIDs []peer.ID,
+	onFailure func(), prog *progress, testConfig TestConfig, action string,
+) (context.Context, func(context.Context) error, func(context.Context) error, error) {
+	log.Info(ctx, "Waiting to connect to all peers...")
+
+	// Improve UX of "context cancelled" errors when sync fails.
+	ctx = errors.WithCtxErr(ctx, "p2p connection failed, please retry "+action)
+
+	nextStepSync, stopSync, err := startSyncProtocol(ctx, tcpNode, key, hash, peerIDs, onFailure, prog, testConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return ctx, nextStepSync, stopSync, nil
+}
+
+// stopCeremony stops the sync protocol and waits for the shutdown delay, allowing all peers to complete the ceremony.
+func stopCeremony(ctx context.Context, stopSync func(context.Context) error, shutdownDelay time.Duration, testConfig TestConfig) error {
+	if err := stopSync(ctx); err != nil {
+		return errors.Wrap(err, "sync shutdown") // Consider increasing --shutdown-delay if this occurs often.
+	}
+
+	if testConfig.ShutdownCallback != nil {
+		testConfig.ShutdownCallback()
+	}
+	log.Debug(ctx, "Graceful shutdown delay", z.Int("seconds", int(shutdownDelay.Seconds())))
+	time.Sleep(shutdownDelay)
+
+	return nil
+}
@@ -0,0 +1,351 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/dkg/bcast"
+	dkgpb "github.com/obolnetwork/charon/dkg/dkgpb/v1"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const (
+	checkpointFile  = "dkg-checkpoint.json"
+	checkpointMsgID = "/charon/dkg/checkpoint"
+)
+
+// checkpointStep defines the last completed DKG step persisted in a checkpoint.
+type checkpointStep int
+
+const (
+	checkpointNone checkpointStep = iota
+	checkpointKeygen
+	checkpointDepositData
+	checkpointValidatorRegistrations
+	checkpointLockHash
+	checkpointNodeSigs
+)
+
+// checkpoint is the DKG protocol state persisted to disk after each completed step,
+// allowing an interrupted ceremony to be resumed from the last step completed by all peers.
+type checkpoint struct {
+	DefinitionHash []byte                                      `json:"definition_hash"`
+	Step           checkpointStep                              `json:"step"`
+	Shares         []checkpointShare                           `json:"shares,omitempty"`
+	DepositDatas   [][]eth2p0.DepositData                      `json:"deposit_datas,omitempty"`
+	ValRegs        []core.VersionedSignedValidatorRegistration `json:"validator_registrations,omitempty"`
+	Lock           *cluster.Lock                               `json:"lock,omitempty"`
+	Transcript     *Transcript                                 `json:"transcript,omitempty"`
+}
+
+// checkpointShare is the json representation of a share.
+type checkpointShare struct {
+	PubKey       []byte         `json:"pubkey"`
+	SecretShare  []byte         `json:"secret_share"`
+	PublicShares map[int][]byte `json:"public_shares"`
+}
+
+// Digest returns a hash of the checkpointed distributed validator public keys, or nil if no keys were generated yet.
+func (c checkpoint) Digest() []byte {
+	if c.Step < checkpointKeygen {
+		return nil
+	}
+
+	h := sha256.New()
+	for _, s := range c.Shares {
+		_, _ = h.Write(s.PubKey)
+	}
+
+	return h.Sum(nil)
+}
+
+// GetShares returns the checkpointed shares.
+func (c checkpoint) GetShares() ([]share, error) {
+	var resp []share
+	for _, s := range c.Shares {
+		pubkey, err := tblsPubKey(s.PubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(s.SecretShare) != len(tbls.PrivateKey{}) {
+			return nil, errors.New("invalid checkpoint secret share length")
+		}
+
+		var secret tbls.PrivateKey
+		copy(secret[:], s.SecretShare)
+
+		pubShares := make(map[int]tbls.PublicKey)
+		for idx, b := range s.PublicShares {
+			pubShares[idx], err = tblsPubKey(b)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp = append(resp, share{
+			PubKey:       pubkey,
+			SecretShare:  secret,
+			PublicShares: pubShares,
+		})
+	}
+
+	return resp, nil
+}
+
+// SetShares sets the checkpointed shares.
+func (c *checkpoint) SetShares(shares []share) {
+	c.Shares = nil
+	for _, s := range shares {
+		pubShares := make(map[int][]byte)
+		for idx, pubShare := range s.PublicShares {
+			pubShares[idx] = pubShare[:]
+		}
+
+		c.Shares = append(c.Shares, checkpointShare{
+			PubKey:       s.PubKey[:],
+			SecretShare:  s.SecretShare[:],
+			PublicShares: pubShares,
+		})
+	}
+}
+
+// Truncate removes all state of steps after the provided step.
+func (c *checkpoint) Truncate(step checkpointStep) {
+	if c.Step <= step {
+		return
+	}
+
+	c.Step = step
+
+	if step < checkpointKeygen {
+		c.Shares = nil
+		c.Transcript = nil
+	} else if step < checkpointNodeSigs && c.Transcript != nil {
+		transcript := *c.Transcript
+		transcript.Signatures = nil
+		c.Transcript = &transcript
+	}
+	if step < checkpointDepositData {
+		c.DepositDatas = nil
+	}
+	if step < checkpointValidatorRegistrations {
+		c.ValRegs = nil
+	}
+	if step < checkpointLockHash {
+		c.Lock = nil
+	} else if step < checkpointNodeSigs {
+		lock := *c.Lock
+		lock.NodeSignatures = nil
+		c.Lock = &lock
+	}
+}
+
+func tblsPubKey(b []byte) (tbls.PublicKey, error) {
+	if len(b) != len(tbls.PublicKey{}) {
+		return tbls.PublicKey{}, errors.New("invalid checkpoint public key length")
+	}
+
+	var resp tbls.PublicKey
+	copy(resp[:], b)
+
+	return resp, nil
+}
+
+// loadCheckpoint returns the checkpoint stored in the data directory or an empty checkpoint if none exists.
+// It returns an error if the checkpoint belongs to a different cluster definition.
+func loadCheckpoint(dataDir string, defHash []byte) (checkpoint, error) {
+	b, err := os.ReadFile(filepath.Join(dataDir, checkpointFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return checkpoint{DefinitionHash: defHash}, nil
+	} else if err != nil {
+		return checkpoint{}, errors.Wrap(err, "read dkg checkpoint")
+	}
+
+	var resp checkpoint
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return checkpoint{}, errors.Wrap(err, "unmarshal dkg checkpoint")
+	}
+
+	if !bytes.Equal(resp.DefinitionHash, defHash) {
+		return checkpoint{}, errors.New("dkg checkpoint doesn't match cluster definition, remove it to start a new ceremony",
+			z.Str("path", filepath.Join(dataDir, checkpointFile)))
+	}
+
+	if resp.Step < checkpointNone || resp.Step > checkpointNodeSigs {
+		return checkpoint{}, errors.New("invalid dkg checkpoint step", z.Int("step", int(resp.Step)))
+	}
+
+	return resp, nil
+}
+
+// saveCheckpoint atomically writes the checkpoint to the data directory.
+// The checkpoint contains secret key shares and is therefore only readable by the owner.
+func saveCheckpoint(dataDir string, cp checkpoint) error {
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return errors.Wrap(err, "marshal dkg checkpoint")
+	}
+
+	tmpFile := filepath.Join(dataDir, checkpointFile+".tmp")
+	if err := os.WriteFile(tmpFile, b, 0o600); err != nil {
+		return errors.Wrap(err, "write dkg checkpoint")
+	}
+
+	if err := os.Rename(tmpFile, filepath.Join(dataDir, checkpointFile)); err != nil {
+		return errors.Wrap(err, "rename dkg checkpoint")
+	}
+
+	return nil
+}
+
+// removeCheckpoint deletes the checkpoint from the data directory.
+func removeCheckpoint(dataDir string) error {
+	err := os.Remove(filepath.Join(dataDir, checkpointFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errors.Wrap(err, "remove dkg checkpoint")
+	}
+
+	return nil
+}
+
+// checkpointBcast handles agreeing on the DKG step to resume from via the bcast protocol.
+type checkpointBcast struct {
+	mu      sync.Mutex
+	steps   map[peer.ID]checkpointStep
+	digests map[peer.ID][]byte
+
+	bcastFunc bcast.BroadcastFunc
+	peers     []peer.ID
+	selfID    peer.ID
+}
+
+// newCheckpointBcast returns a new instance of checkpointBcast.
+// It registers bcast handlers on bcastComp.
+func newCheckpointBcast(selfID peer.ID, peers []peer.ID, bcastComp broadcaster) *checkpointBcast {
+	ret := &checkpointBcast{
+		steps:     make(map[peer.ID]checkpointStep),
+		digests:   make(map[peer.ID][]byte),
+		bcastFunc: bcastComp.Broadcast,
+		peers:     peers,
+		selfID:    selfID,
+	}
+
+	bcastComp.RegisterMessageIDFuncs(checkpointMsgID, ret.broadcastCallback, ret.checkMessage)
+
+	return ret
+}
+
+// broadcastCallback is the bcast.Callback for checkpointBcast.
+func (c *checkpointBcast) broadcastCallback(_ context.Context, pID peer.ID, _ string, msg proto.Message) error {
+	cpMsg, ok := msg.(*dkgpb.MsgCheckpoint)
+	if !ok {
+		return errors.New("invalid checkpoint message type")
+	}
+
+	step := checkpointStep(cpMsg.GetStep())
+	if step > checkpointNodeSigs {
+		return errors.New("invalid checkpoint step", z.Int("step", int(step)))
+	}
+
+	c.set(pID, step, cpMsg.GetDigest())
+
+	return nil
+}
+
+// checkMessage is the bcast.CheckMessage for checkpointBcast.
+func (*checkpointBcast) checkMessage(_ context.Context, peerID peer.ID, msgAny *anypb.Any) error {
+	var msg dkgpb.MsgCheckpoint
+	if err := msgAny.UnmarshalTo(&msg); err != nil {
+		return errors.Wrap(err, "checkpoint request malformed", z.Str("peer_id", peerID.String()))
+	}
+
+	return nil
+}
+
+func (c *checkpointBcast) set(pID peer.ID, step checkpointStep, digest []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.steps[pID] = step
+	c.digests[pID] = digest
+}
+
+// resumeStep returns the step all peers can resume from once all peers' checkpoint steps have been received.
+func (c *checkpointBcast) resumeStep() (checkpointStep, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.steps) != len(c.peers) {
+		return 0, false, nil
+	}
+
+	resp := checkpointNodeSigs
+	for _, step := range c.steps {
+		resp = min(resp, step)
+	}
+
+	if resp >= checkpointKeygen {
+		selfDigest := c.digests[c.selfID]
+		for pID, digest := range c.digests {
+			if !bytes.Equal(selfDigest, digest) {
+				return 0, false, errors.New("peer dkg checkpoint contains different validator keys, remove all checkpoints to start a new ceremony",
+					z.Str("peer", pID.String()))
+			}
+		}
+	}
+
+	return resp, true, nil
+}
+
+// exchange broadcasts the local checkpoint step and returns the step all peers can resume from;
+// the minimum checkpoint step of all peers.
+func (c *checkpointBcast) exchange(ctx context.Context, cp checkpoint) (checkpointStep, error) {
+	msg := &dkgpb.MsgCheckpoint{
+		Step:   uint32(cp.Step),
+		Digest: cp.Digest(),
+	}
+
+	if err := c.bcastFunc(ctx, checkpointMsgID, msg); err != nil {
+		return 0, errors.Wrap(err, "checkpoint broadcast")
+	}
+
+	c.set(c.selfID, cp.Step, msg.GetDigest())
+
+	tick := time.NewTicker(100 * time.Millisecond)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-tick.C:
+			step, ok, err := c.resumeStep()
+			if err != nil {
+				return 0, err
+			} else if ok {
+				log.Debug(ctx, "Agreed on dkg checkpoint step", z.Int("step", int(step)))
+				return step, nil
+			}
+		}
+	}
+}
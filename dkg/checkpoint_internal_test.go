@@ -0,0 +1,133 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	defHash := testutil.RandomBytes32()
+
+	cp, err := loadCheckpoint(dir, defHash)
+	require.NoError(t, err)
+	require.Equal(t, checkpointNone, cp.Step)
+	require.Nil(t, cp.Digest())
+
+	_, nodeShares := splitTestShares(t, 2, 3, 2)
+	shares := nodeShares[1]
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, _, _ := cluster.NewForT(t, 2, 2, 3, seed, random)
+
+	cp.SetShares(shares)
+	cp.Step = checkpointNodeSigs
+	cp.Lock = &lock
+	require.NoError(t, saveCheckpoint(dir, cp))
+
+	info, err := os.Stat(filepath.Join(dir, checkpointFile))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := loadCheckpoint(dir, defHash)
+	require.NoError(t, err)
+	require.Equal(t, checkpointNodeSigs, loaded.Step)
+	require.Equal(t, cp.Digest(), loaded.Digest())
+	require.Equal(t, lock.LockHash, loaded.Lock.LockHash)
+	require.Equal(t, lock.NodeSignatures, loaded.Lock.NodeSignatures)
+
+	loadedShares, err := loaded.GetShares()
+	require.NoError(t, err)
+	require.Equal(t, shares, loadedShares)
+
+	_, err = loadCheckpoint(dir, testutil.RandomBytes32())
+	require.ErrorContains(t, err, "dkg checkpoint doesn't match cluster definition")
+
+	require.NoError(t, removeCheckpoint(dir))
+	require.NoError(t, removeCheckpoint(dir))
+
+	cp, err = loadCheckpoint(dir, defHash)
+	require.NoError(t, err)
+	require.Equal(t, checkpointNone, cp.Step)
+}
+
+func TestCheckpointTruncate(t *testing.T) {
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, _, _ := cluster.NewForT(t, 1, 2, 3, seed, random)
+	_, nodeShares := splitTestShares(t, 1, 3, 2)
+
+	newCP := func() checkpoint {
+		var cp checkpoint
+		cp.SetShares(nodeShares[1])
+		cp.Step = checkpointNodeSigs
+		cp.DepositDatas = make([][]eth2p0.DepositData, 1)
+		cp.Lock = &lock
+
+		return cp
+	}
+
+	cp := newCP()
+	cp.Truncate(checkpointLockHash)
+	require.Equal(t, checkpointLockHash, cp.Step)
+	require.NotNil(t, cp.Lock)
+	require.Empty(t, cp.Lock.NodeSignatures)
+	require.NotEmpty(t, cp.Shares)
+
+	cp = newCP()
+	cp.Truncate(checkpointKeygen)
+	require.Equal(t, checkpointKeygen, cp.Step)
+	require.Nil(t, cp.Lock)
+	require.Nil(t, cp.DepositDatas)
+	require.NotEmpty(t, cp.Shares)
+
+	cp = newCP()
+	cp.Truncate(checkpointNone)
+	require.Empty(t, cp.Shares)
+	require.Nil(t, cp.Digest())
+}
+
+func TestCheckpointResumeStep(t *testing.T) {
+	peers := []peer.ID{"peer0", "peer1", "peer2"}
+	c := &checkpointBcast{
+		steps:   make(map[peer.ID]checkpointStep),
+		digests: make(map[peer.ID][]byte),
+		peers:   peers,
+		selfID:  peers[0],
+	}
+
+	c.set(peers[0], checkpointLockHash, []byte("digest"))
+	c.set(peers[1], checkpointDepositData, []byte("digest"))
+
+	_, ok, err := c.resumeStep()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	c.set(peers[2], checkpointNone, nil)
+	step, ok, err := c.resumeStep()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, checkpointNone, step)
+
+	c.set(peers[2], checkpointKeygen, []byte("digest"))
+	step, ok, err = c.resumeStep()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, checkpointKeygen, step)
+
+	c.set(peers[2], checkpointKeygen, []byte("other"))
+	_, _, err = c.resumeStep()
+	require.ErrorContains(t, err, "different validator keys")
+}
@@ -0,0 +1,141 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/dkg"
+	dkgsync "github.com/obolnetwork/charon/dkg/sync"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestDKGResume(t *testing.T) {
+	const (
+		nodes = 3
+		vals  = 2
+		// interruptStep is the checkpoint step after which the ceremony is interrupted (deposit data).
+		interruptStep = 2
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, keys, _ := cluster.NewForT(t, vals, nodes, nodes, seed, random)
+	def := lock.Definition
+	dir := t.TempDir()
+
+	relayAddr := startRelay(ctx, t)
+
+	run := func(ctx context.Context, checkpointCallback func(int)) error {
+		var eg errgroup.Group
+		for i := range nodes {
+			conf := dkg.Config{
+				DataDir: path.Join(dir, fmt.Sprintf("node%d", i)),
+				P2P: p2p.Config{
+					Relays:   []string{relayAddr},
+					TCPAddrs: []string{testutil.AvailableAddr(t).String()},
+				},
+				Log: log.DefaultConfig(),
+				TestConfig: dkg.TestConfig{
+					Def: &def,
+					StoreKeysFunc: func(secrets []tbls.PrivateKey, dir string) error {
+						return keystore.StoreKeysInsecure(secrets, dir, keystore.ConfirmInsecureKeys)
+					},
+					SyncOpts:           []func(*dkgsync.Client){dkgsync.WithPeriod(time.Millisecond * 50)},
+					CheckpointCallback: checkpointCallback,
+				},
+				ShutdownDelay: 1 * time.Second,
+				Timeout:       8 * time.Second,
+			}
+
+			eg.Go(func() error {
+				return dkg.Run(peerCtx(ctx, i), conf)
+			})
+		}
+
+		return eg.Wait()
+	}
+
+	for i := range nodes {
+		dataDir := path.Join(dir, fmt.Sprintf("node%d", i))
+		require.NoError(t, os.MkdirAll(dataDir, 0o755))
+		require.NoError(t, k1util.Save(keys[i], p2p.KeyPath(dataDir)))
+	}
+
+	// Interrupt the ceremony once the first node persisted the deposit data checkpoint.
+	interruptCtx, interrupt := context.WithCancel(ctx)
+	err := run(interruptCtx, func(step int) {
+		if step == interruptStep {
+			interrupt()
+		}
+	})
+	testutil.SkipIfBindErr(t, err)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// All nodes completed key generation before being interrupted.
+	var pubkeys [][]byte
+	for i := range nodes {
+		b, err := os.ReadFile(path.Join(dir, fmt.Sprintf("node%d", i), "dkg-checkpoint.json"))
+		require.NoError(t, err)
+
+		var cp struct {
+			Step   int `json:"step"`
+			Shares []struct {
+				PubKey []byte `json:"pubkey"`
+			} `json:"shares"`
+		}
+		require.NoError(t, json.Unmarshal(b, &cp))
+		require.GreaterOrEqual(t, cp.Step, 1)
+		require.LessOrEqual(t, cp.Step, interruptStep)
+		require.Len(t, cp.Shares, vals)
+
+		pubkeys = nil
+		for _, s := range cp.Shares {
+			pubkeys = append(pubkeys, s.PubKey)
+		}
+	}
+
+	// Resume the ceremony.
+	err = run(ctx, nil)
+	testutil.SkipIfBindErr(t, err)
+	testutil.RequireNoError(t, err)
+
+	verifyDKGResults(t, def, dir)
+
+	for i := range nodes {
+		nodeDir := path.Join(dir, fmt.Sprintf("node%d", i))
+
+		_, err := os.Stat(path.Join(nodeDir, "dkg-checkpoint.json"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+
+		b, err := os.ReadFile(path.Join(nodeDir, "cluster-lock.json"))
+		require.NoError(t, err)
+
+		var resumedLock cluster.Lock
+		require.NoError(t, json.Unmarshal(b, &resumedLock))
+
+		// The resumed ceremony reused the checkpointed keys instead of generating new ones.
+		for vIdx, val := range resumedLock.Validators {
+			require.Equal(t, pubkeys[vIdx], val.PubKey)
+		}
+	}
+}
@@ -15,13 +15,17 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/obolnetwork/charon/app/datadir"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/deposit"
 	"github.com/obolnetwork/charon/eth2util/keymanager"
 	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/vault"
+	"github.com/obolnetwork/charon/eth2util/web3signer"
 	"github.com/obolnetwork/charon/tbls"
 )
 
@@ -38,7 +42,7 @@ func loadDefinition(ctx context.Context, conf Config) (cluster.Definition, error
 	var def cluster.Definition
 	if err == nil && parsedURL.Host != "" {
 		if !strings.HasPrefix(parsedURL.Scheme, "https") {
-			log.Warn(ctx, "Definition file URL does not use https protocol", nil, z.Str("addr", conf.DefFile))
+			log.Warn(ctx, "Definition file URL does not use https protocol", nil, z.Redact(z.Str("addr", conf.DefFile)))
 		}
 
 		var err error
@@ -94,7 +98,7 @@ func loadDefinition(ctx context.Context, conf Config) (cluster.Definition, error
 }
 
 // writeKeysToKeymanager writes validator private keyshares for the node to the provided keymanager address.
-func writeKeysToKeymanager(ctx context.Context, keymanagerURL, authToken string, shares []share) error {
+func writeKeysToKeymanager(ctx context.Context, keymanagerURL, authToken string, opts keystore.Options, shares []share) error {
 	var (
 		keystores []keystore.Keystore
 		passwords []string
@@ -107,7 +111,7 @@ func writeKeysToKeymanager(ctx context.Context, keymanagerURL, authToken string,
 		}
 		passwords = append(passwords, password)
 
-		store, err := keystore.Encrypt(s.SecretShare, password, rand.Reader)
+		store, err := keystore.Encrypt(s.SecretShare, password, rand.Reader, opts.EncryptorOptions()...)
 		if err != nil {
 			return err
 		}
@@ -123,6 +127,16 @@ func writeKeysToKeymanager(ctx context.Context, keymanagerURL, authToken string,
 	return nil
 }
 
+// writeKeysToVault stores validator private keyshares for the node in vault.
+func writeKeysToVault(ctx context.Context, cl *vault.Client, shares []share) error {
+	var secrets []tbls.PrivateKey
+	for _, s := range shares {
+		secrets = append(secrets, s.SecretShare)
+	}
+
+	return cl.StoreKeys(ctx, secrets)
+}
+
 // writeKeysToDisk writes validator private keyshares for the node to disk.
 func writeKeysToDisk(conf Config, shares []share) error {
 	var secrets []tbls.PrivateKey
@@ -135,7 +149,9 @@ func writeKeysToDisk(conf Config, shares []share) error {
 		return err
 	}
 
-	storeKeysFunc := keystore.StoreKeys
+	storeKeysFunc := func(secrets []tbls.PrivateKey, dir string) error {
+		return keystore.StoreKeysWithOptions(secrets, dir, conf.KeystoreOptions)
+	}
 	if conf.TestConfig.StoreKeysFunc != nil {
 		storeKeysFunc = conf.TestConfig.StoreKeysFunc
 	}
@@ -143,20 +159,31 @@ func writeKeysToDisk(conf Config, shares []share) error {
 	return storeKeysFunc(secrets, keysDir)
 }
 
-// writeLock writes the lock file to disk.
-func writeLock(datadir string, lock cluster.Lock) error {
+// writeWeb3SignerConfig writes Web3Signer key configs and a slashing protection interchange file for the
+// validator keys in the data directory to the web3signer directory.
+func writeWeb3SignerConfig(dataDir string, forkVersion []byte) error {
+	gvr, err := eth2util.ForkVersionToGenesisValidatorsRoot(forkVersion)
+	if err != nil {
+		return err
+	}
+
+	return web3signer.Write(filepath.Join(dataDir, "web3signer"), filepath.Join(dataDir, "validator_keys"), gvr)
+}
+
+// writeLock writes the lock file to disk and stamps the data directory with the current schema version.
+func writeLock(dataDir string, lock cluster.Lock) error {
 	b, err := json.MarshalIndent(lock, "", " ")
 	if err != nil {
 		return errors.Wrap(err, "marshal lock")
 	}
 
 	//nolint:gosec // File needs to be read-only for everybody
-	err = os.WriteFile(path.Join(datadir, "cluster-lock.json"), b, 0o444) // Read-only
+	err = os.WriteFile(path.Join(dataDir, "cluster-lock.json"), b, 0o444) // Read-only
 	if err != nil {
 		return errors.Wrap(err, "write lock")
 	}
 
-	return nil
+	return datadir.Init(dataDir)
 }
 
 func checkClearDataDir(dataDir string) error {
@@ -179,6 +206,7 @@ func checkClearDataDir(dataDir string) error {
 	disallowedEntities := map[string]struct{}{
 		"validator_keys":    {},
 		"cluster-lock.json": {},
+		"web3signer":        {},
 	}
 
 	necessaryEntities := map[string]bool{
@@ -245,3 +273,50 @@ func randomHex64() (string, error) {
 
 	return hex.EncodeToString(b), nil
 }
+
+// secureDeleteDir overwrites all files in the directory with zeros before removing it, so deleted key shares can't
+// be recovered from the file system. Note this is best effort on journaling or copy-on-write file systems and SSDs.
+func secureDeleteDir(dir string) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return errors.Wrap(err, "file info", z.Str("path", path))
+		}
+
+		// Key shares and cluster locks are read-only.
+		if err := os.Chmod(path, 0o600); err != nil {
+			return errors.Wrap(err, "make file writable", z.Str("path", path))
+		}
+
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return errors.Wrap(err, "open file", z.Str("path", path))
+		}
+		defer f.Close()
+
+		if _, err := f.Write(make([]byte, info.Size())); err != nil {
+			return errors.Wrap(err, "overwrite file", z.Str("path", path))
+		}
+
+		if err := f.Sync(); err != nil {
+			return errors.Wrap(err, "sync file", z.Str("path", path))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return errors.Wrap(err, "remove dir", z.Str("dir", dir))
+	}
+
+	return nil
+}
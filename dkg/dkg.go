@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
@@ -21,9 +22,9 @@ import (
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/memlock"
 	"github.com/obolnetwork/charon/app/obolapi"
 	"github.com/obolnetwork/charon/app/peerinfo"
-	"github.com/obolnetwork/charon/app/privkeylock"
 	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
@@ -33,24 +34,49 @@ import (
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/deposit"
 	"github.com/obolnetwork/charon/eth2util/keymanager"
+	"github.com/obolnetwork/charon/eth2util/keystore"
 	"github.com/obolnetwork/charon/eth2util/registration"
+	"github.com/obolnetwork/charon/eth2util/vault"
 	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
 )
 
+const (
+	// OutputFormatDefault writes key shares as EIP-2335 keystores to the validator_keys directory.
+	OutputFormatDefault = "default"
+	// OutputFormatWeb3Signer additionally writes Web3Signer key configs and a slashing protection interchange file.
+	OutputFormatWeb3Signer = "web3signer"
+)
+
 type Config struct {
 	DefFile       string
 	NoVerify      bool
 	DataDir       string
+	OfflineDir    string
+	OutputFormat  string
 	P2P           p2p.Config
 	Log           log.Config
 	ShutdownDelay time.Duration
 	Timeout       time.Duration
+	// ProgressDisplay renders a live display of the ceremony progress of all peers to stderr.
+	ProgressDisplay bool
+	// Compounding creates deposit data with 0x02 compounding withdrawal credentials.
+	// All operators must use the same value, since deposit data signatures are aggregated.
+	Compounding bool
+	// DepositBatch additionally writes batched deposit files containing all deposits.
+	DepositBatch bool
+	// TestnetConfig defines a custom test network the cluster definition's fork version may refer to.
+	TestnetConfig eth2util.Network
+	// KeystoreOptions defines the key derivation function and cost of the key share keystores.
+	KeystoreOptions keystore.Options
 
 	KeymanagerAddr      string
 	KeymanagerAuthToken string
 
+	// Vault stores the key shares in HashiCorp Vault instead of on disk if enabled.
+	Vault vault.Config
+
 	PublishAddr    string
 	PublishTimeout time.Duration
 	Publish        bool
@@ -63,12 +89,16 @@ type TestConfig struct {
 	// Def provides the cluster definition explicitly, skips loading from disk.
 	Def *cluster.Definition
 	// P2PKey provides the p2p privkey explicitly, skips loading from disk.
-	P2PKey           *k1.PrivateKey
+	P2PKey *k1.PrivateKey
+	// Lock provides the cluster lock explicitly, skips loading from disk. Only used by RunRefresh and RunReshare.
+	Lock             *cluster.Lock
 	SyncCallback     func(connected int, id peer.ID)
 	StoreKeysFunc    func(secrets []tbls.PrivateKey, dir string) error
 	TCPNodeCallback  func(host.Host)
 	ShutdownCallback func()
 	SyncOpts         []func(*sync.Client)
+	// CheckpointCallback is called after each DKG checkpoint is persisted to disk.
+	CheckpointCallback func(step int)
 }
 
 // HasTestConfig returns true if any of the test config fields are set.
@@ -85,26 +115,19 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	ctx = log.WithTopic(ctx, "dkg")
 
-	{
-		// Setup private key locking.
-		lockSvc, err := privkeylock.New(p2p.KeyPath(conf.DataDir)+".lock", "charon dkg")
-		if err != nil {
-			return err
-		}
-
-		// Start it async
-		go func() {
-			if err := lockSvc.Run(); err != nil {
-				log.Error(ctx, "Error locking private key file", err)
-			}
-		}()
-
-		// Stop it on exit.
-		defer lockSvc.Close()
+	unlock, err := lockPrivKey(ctx, conf.DataDir, "charon dkg")
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
 	version.LogInfo(ctx, "Charon DKG starting")
 
+	// Custom testnets must be supported before verifying the definition's signatures.
+	if conf.TestnetConfig.IsNonZero() {
+		eth2util.AddTestNetwork(conf.TestnetConfig)
+	}
+
 	def, err := loadDefinition(ctx, conf)
 	if err != nil {
 		return err
@@ -119,6 +142,16 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return err
 	}
 
+	if err := validateOutputFormat(conf.OutputFormat, conf.KeymanagerAddr, def.ForkVersion); err != nil {
+		return err
+	}
+
+	if err := conf.KeystoreOptions.Validate(); err != nil {
+		return err
+	}
+
+	memlock.CheckLimit(ctx, memlock.Required(def.NumValidators*len(tbls.PrivateKey{})))
+
 	// Check if keymanager address is reachable.
 	if conf.KeymanagerAddr != "" {
 		cl := keymanager.New(conf.KeymanagerAddr, conf.KeymanagerAuthToken)
@@ -127,6 +160,21 @@ func Run(ctx context.Context, conf Config) (err error) {
 		}
 	}
 
+	// Authenticate with vault before the ceremony to fail early.
+	var vaultCl *vault.Client
+	if conf.Vault.Enabled() {
+		if conf.KeymanagerAddr != "" {
+			return errors.New("key shares cannot be stored in both a keymanager and vault")
+		} else if conf.OutputFormat == OutputFormatWeb3Signer {
+			return errors.New("web3signer output format not supported when storing keys in vault")
+		}
+
+		vaultCl, err = vault.New(ctx, conf.Vault)
+		if err != nil {
+			return errors.Wrap(err, "authenticate with vault")
+		}
+	}
+
 	if !conf.HasTestConfig() {
 		if err = checkClearDataDir(conf.DataDir); err != nil {
 			return err
@@ -137,6 +185,11 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return err
 	}
 
+	cp, err := loadCheckpoint(conf.DataDir, def.DefinitionHash)
+	if err != nil {
+		return err
+	}
+
 	network, err := eth2util.ForkVersionToNetwork(def.ForkVersion)
 	if err != nil {
 		return err
@@ -153,89 +206,210 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	defHash := fmt.Sprintf("%#x", def.DefinitionHash)
 
-	key := conf.TestConfig.P2PKey
-	if key == nil {
-		var err error
-		key, err = p2p.LoadPrivKey(conf.DataDir)
+	key, pID, err := loadP2PKey(conf.DataDir, conf.TestConfig)
+	if err != nil {
+		return err
+	}
+
+	peerMap := make(map[peer.ID]cluster.NodeIdx)
+	for _, p := range peers {
+		nodeIdx, err := def.NodeIdx(p.ID)
 		if err != nil {
 			return err
 		}
+		peerMap[p.ID] = nodeIdx
 	}
 
-	pID, err := p2p.PeerIDFromKey(key.PubKey())
-	if err != nil {
-		return err
+	sigTypes := []sigType{
+		sigLock,
+		sigDepositData,
+		sigValidatorRegistration,
 	}
 
-	log.Info(ctx, "Starting local P2P networking peer")
+	prog := newProgress(peers, peerMap[pID].PeerIdx, conf.OfflineDir)
+	if conf.ProgressDisplay {
+		stopDisplay := startProgressDisplay(ctx, prog)
+		defer stopDisplay()
+	}
 
-	logPeerSummary(ctx, pID, peers, def.Operators)
+	var (
+		nodeIdx             cluster.NodeIdx
+		ex                  *exchanger
+		tp                  fTransport
+		nodeSigCaster       *nodeSigBcast
+		transcriptSigCaster *nodeSigBcast
+		nextStepSync        func(context.Context) error
+		stopSync            func(context.Context) error
+	)
+	if conf.OfflineDir != "" {
+		if cp.Step > checkpointNone {
+			return errors.New("resuming an offline dkg ceremony isn't supported, remove the checkpoint to start a new ceremony",
+				z.Str("path", filepath.Join(conf.DataDir, checkpointFile)))
+		}
 
-	tcpNode, shutdown, err := setupP2P(ctx, key, conf, peers, def.DefinitionHash)
-	if err != nil {
-		return err
-	}
-	defer shutdown()
+		nodeIdx, err = def.NodeIdx(pID)
+		if err != nil {
+			return errors.Wrap(err, "private key not matching definition file")
+		}
 
-	nodeIdx, err := def.NodeIdx(tcpNode.ID())
-	if err != nil {
-		return errors.Wrap(err, "private key not matching definition file")
-	}
+		logPeerSummary(ctx, pID, peers, def.Operators)
 
-	peerIDs, err := def.PeerIDs()
-	if err != nil {
-		return errors.Wrap(err, "get peer IDs")
-	}
+		offlineEx, err := newOfflineExchange(conf.OfflineDir, def.DefinitionHash, key, peers, nodeIdx.PeerIdx)
+		if err != nil {
+			return err
+		}
+		offlineEx.SetReceivedFunc(prog.receivedFrom)
 
-	ex := newExchanger(tcpNode, nodeIdx.PeerIdx, peerIDs, def.NumValidators, []sigType{
-		sigLock,
-		sigDepositData,
-		sigValidatorRegistration,
-	}, conf.Timeout)
+		ex = newOfflineExchanger(offlineEx, len(peers), def.NumValidators, sigTypes)
 
-	// Register Frost libp2p handlers
-	peerMap := make(map[peer.ID]cluster.NodeIdx)
-	for _, p := range peers {
-		nodeIdx, err := def.NodeIdx(p.ID)
+		tp, err = newFrostOffline(pID, peerMap, offlineEx, def.Threshold, def.NumValidators)
+		if err != nil {
+			return errors.Wrap(err, "frost error")
+		}
+
+		nodeSigCaster = newNodeSigBcast(peers, nodeIdx, offlineEx)
+		transcriptSigCaster = newTranscriptSigBcast(peers, nodeIdx, offlineEx)
+
+		// Offline ceremony steps are implicitly synchronised by waiting for all peers' message files.
+		noopSync := func(context.Context) error { return nil }
+		nextStepSync, stopSync = noopSync, noopSync
+
+		go offlineEx.Run(ctx)
+
+		log.Info(ctx, "Starting offline DKG ceremony, copy all message files written by this node to all other operators' "+
+			"offline directories and theirs to this node's offline directory", z.Str("dir", conf.OfflineDir))
+	} else {
+		log.Info(ctx, "Starting local P2P networking peer")
+
+		logPeerSummary(ctx, pID, peers, def.Operators)
+
+		tcpNode, shutdown, err := setupP2P(ctx, key, conf, peers, def.DefinitionHash)
 		if err != nil {
 			return err
 		}
-		peerMap[p.ID] = nodeIdx
+		defer shutdown()
+
+		nodeIdx, err = def.NodeIdx(tcpNode.ID())
+		if err != nil {
+			return errors.Wrap(err, "private key not matching definition file")
+		}
+
+		peerIDs, err := def.PeerIDs()
+		if err != nil {
+			return errors.Wrap(err, "get peer IDs")
+		}
+
+		ex = newExchanger(tcpNode, nodeIdx.PeerIdx, peerIDs, def.NumValidators, sigTypes, conf.Timeout)
+		ex.sigex.Subscribe(prog.receivedParSigs)
+
+		caster := progressCaster{broadcaster: bcast.New(tcpNode, peerIDs, key), prog: prog}
+
+		// register bcast callbacks for frostp2p
+		tp, err = newFrostP2P(tcpNode, peerMap, caster, def.Threshold, def.NumValidators)
+		if err != nil {
+			return errors.Wrap(err, "frost error")
+		}
+
+		// register bcast callbacks for lock hash k1 signature handler
+		nodeSigCaster = newNodeSigBcast(peers, nodeIdx, caster)
+
+		// register bcast callbacks for transcript hash k1 signature handler
+		transcriptSigCaster = newTranscriptSigBcast(peers, nodeIdx, caster)
+
+		// register bcast callbacks for checkpoint resume step agreement
+		cpCaster := newCheckpointBcast(tcpNode.ID(), peerIDs, caster)
+
+		ctx, nextStepSync, stopSync, err = syncPeers(ctx, tcpNode, key, def.DefinitionHash, peerIDs, cancel, prog, conf.TestConfig, "DKG")
+		if err != nil {
+			return err
+		}
+
+		// Resume from the last step checkpointed by all peers.
+		resumeStep, err := cpCaster.exchange(ctx, cp)
+		if err != nil {
+			return err
+		}
+		cp.Truncate(resumeStep)
+
+		if cp.Step > checkpointNone {
+			log.Info(ctx, "All peers connected, resuming DKG ceremony from checkpoint", z.Int("step", int(cp.Step)))
+		} else {
+			log.Info(ctx, "All peers connected, starting DKG ceremony")
+		}
 	}
 
-	caster := bcast.New(tcpNode, peerIDs, key)
+	// Track this node's ceremony step, all peers are now at the first step.
+	prog.nextStep()
+	syncStep := nextStepSync
+	nextStepSync = func(ctx context.Context) error {
+		if err := syncStep(ctx); err != nil {
+			return err
+		}
+		prog.nextStep()
 
-	// register bcast callbacks for frostp2p
-	tp, err := newFrostP2P(tcpNode, peerMap, caster, def.Threshold, def.NumValidators)
-	if err != nil {
-		return errors.Wrap(err, "frost error")
+		return nil
 	}
 
-	// register bcast callbacks for lock hash k1 signature handler
-	nodeSigCaster := newNodeSigBcast(peers, nodeIdx, caster)
+	saveCP := func(step checkpointStep) error {
+		cp.Step = step
 
-	log.Info(ctx, "Waiting to connect to all peers...")
+		// Offline ceremonies can't be resumed, so don't persist secret key shares to a checkpoint.
+		if conf.OfflineDir == "" {
+			if err := saveCheckpoint(conf.DataDir, cp); err != nil {
+				return err
+			}
+		}
 
-	// Improve UX of "context cancelled" errors when sync fails.
-	ctx = errors.WithCtxErr(ctx, "p2p connection failed, please retry DKG")
+		if conf.TestConfig.CheckpointCallback != nil {
+			conf.TestConfig.CheckpointCallback(int(step))
+		}
 
-	nextStepSync, stopSync, err := startSyncProtocol(ctx, tcpNode, key, def.DefinitionHash, peerIDs, cancel, conf.TestConfig)
-	if err != nil {
-		return err
+		return nil
 	}
 
-	log.Info(ctx, "All peers connected, starting DKG ceremony")
-
-	var shares []share
-	switch def.DKGAlgorithm {
-	case "default", "frost":
-		shares, err = runFrostParallel(ctx, tp, uint32(def.NumValidators), uint32(len(peerMap)),
-			uint32(def.Threshold), uint32(nodeIdx.ShareIdx), defHash)
+	var (
+		shares     []share
+		transcript Transcript
+	)
+	defer func() {
+		// Zeroize the secret key shares once they have been persisted.
+		for i := range shares {
+			memlock.Zero(shares[i].SecretShare[:])
+		}
+	}()
+	if cp.Step >= checkpointKeygen {
+		shares, err = cp.GetShares()
 		if err != nil {
 			return err
 		}
-	default:
-		return errors.New("unsupported dkg algorithm")
+
+		if cp.Transcript == nil {
+			return errors.New("dkg checkpoint missing transcript")
+		}
+		transcript = *cp.Transcript
+	} else {
+		switch def.DKGAlgorithm {
+		case "default", "frost":
+			recorder := newTranscriptRecorder(tp)
+			shares, err = runFrostParallel(ctx, recorder, uint32(def.NumValidators), uint32(len(peerMap)),
+				uint32(def.Threshold), uint32(nodeIdx.ShareIdx), defHash)
+			if err != nil {
+				return err
+			}
+
+			transcript, err = newTranscript(def.DefinitionHash, defHash, def.Threshold, len(peerMap), shares, recorder)
+			if err != nil {
+				return err
+			}
+		default:
+			return errors.New("unsupported dkg algorithm")
+		}
+
+		cp.SetShares(shares)
+		cp.Transcript = &transcript
+		if err := saveCP(checkpointKeygen); err != nil {
+			return err
+		}
 	}
 
 	// DKG was step 1, advance to step 2
@@ -250,9 +424,17 @@ func Run(ctx context.Context, conf Config) (err error) {
 	} else {
 		depositAmounts = deposit.DedupAmounts(depositAmounts)
 	}
-	depositDatas, err := signAndAggDepositData(ctx, ex, shares, def.WithdrawalAddresses(), network, nodeIdx, depositAmounts)
-	if err != nil {
-		return err
+	depositDatas := cp.DepositDatas
+	if cp.Step < checkpointDepositData {
+		depositDatas, err = signAndAggDepositData(ctx, ex, shares, def.WithdrawalAddresses(), network, nodeIdx, depositAmounts, conf.Compounding)
+		if err != nil {
+			return err
+		}
+
+		cp.DepositDatas = depositDatas
+		if err := saveCP(checkpointDepositData); err != nil {
+			return err
+		}
 	}
 
 	log.Debug(ctx, "Aggregated deposit data signatures")
@@ -262,17 +444,25 @@ func Run(ctx context.Context, conf Config) (err error) {
 	}
 
 	// Sign, exchange and aggregate builder validator registration signatures.
-	valRegs, err := signAndAggValidatorRegistrations(
-		ctx,
-		ex,
-		shares,
-		def.FeeRecipientAddresses(),
-		uint64(def.TargetGasLimit),
-		nodeIdx,
-		def.ForkVersion,
-	)
-	if err != nil {
-		return errors.Wrap(err, "builder validator registrations pre-generation")
+	valRegs := cp.ValRegs
+	if cp.Step < checkpointValidatorRegistrations {
+		valRegs, err = signAndAggValidatorRegistrations(
+			ctx,
+			ex,
+			shares,
+			def.FeeRecipientAddresses(),
+			uint64(def.TargetGasLimit),
+			nodeIdx,
+			def.ForkVersion,
+		)
+		if err != nil {
+			return errors.Wrap(err, "builder validator registrations pre-generation")
+		}
+
+		cp.ValRegs = valRegs
+		if err := saveCP(checkpointValidatorRegistrations); err != nil {
+			return err
+		}
 	}
 
 	log.Debug(ctx, "Aggregated builder validator registration signatures")
@@ -282,9 +472,19 @@ func Run(ctx context.Context, conf Config) (err error) {
 	}
 
 	// Sign, exchange and aggregate Lock Hash signatures
-	lock, err := signAndAggLockHash(ctx, shares, def, nodeIdx, ex, depositDatas, valRegs)
-	if err != nil {
-		return err
+	var lock cluster.Lock
+	if cp.Step >= checkpointLockHash {
+		lock = *cp.Lock
+	} else {
+		lock, err = signAndAggLockHash(ctx, shares, def, nodeIdx, ex, depositDatas, valRegs)
+		if err != nil {
+			return err
+		}
+
+		cp.Lock = &lock
+		if err := saveCP(checkpointLockHash); err != nil {
+			return err
+		}
 	}
 
 	log.Debug(ctx, "Aggregated lock hash signatures")
@@ -294,13 +494,37 @@ func Run(ctx context.Context, conf Config) (err error) {
 	}
 
 	// Sign, exchange K1 signatures over Lock Hash
-	lock.NodeSignatures, err = nodeSigCaster.exchange(ctx, key, lock.LockHash)
-	if err != nil {
-		return errors.Wrap(err, "k1 lock hash signature exchange")
-	}
+	if cp.Step < checkpointNodeSigs {
+		lock.NodeSignatures, err = nodeSigCaster.exchange(ctx, key, lock.LockHash)
+		if err != nil {
+			return errors.Wrap(err, "k1 lock hash signature exchange")
+		}
 
-	if !cluster.SupportNodeSignatures(lock.Version) {
-		lock.NodeSignatures = nil
+		if !cluster.SupportNodeSignatures(lock.Version) {
+			lock.NodeSignatures = nil
+		}
+
+		// Sign, exchange K1 signatures over Transcript Hash
+		transcriptHash, err := transcript.Hash()
+		if err != nil {
+			return err
+		}
+
+		sigs, err := transcriptSigCaster.exchange(ctx, key, transcriptHash)
+		if err != nil {
+			return errors.Wrap(err, "k1 transcript signature exchange")
+		}
+
+		transcript.Signatures = nil
+		for _, sig := range sigs {
+			transcript.Signatures = append(transcript.Signatures, sig)
+		}
+
+		cp.Lock = &lock
+		cp.Transcript = &transcript
+		if err := saveCP(checkpointNodeSigs); err != nil {
+			return err
+		}
 	}
 
 	log.Debug(ctx, "Exchanged node signatures")
@@ -313,21 +537,37 @@ func Run(ctx context.Context, conf Config) (err error) {
 		if err := lock.VerifySignatures(); err != nil {
 			return errors.Wrap(err, "invalid lock file")
 		}
+
+		if err := VerifyTranscript(transcript, lock); err != nil {
+			return errors.Wrap(err, "invalid dkg transcript")
+		}
 	}
 
 	// Write keystores, deposit data and cluster lock files after exchange of partial signatures in order
 	// to prevent partial data writes in case of peer connection lost
 
 	if conf.KeymanagerAddr != "" { // Save to keymanager
-		if err = writeKeysToKeymanager(ctx, conf.KeymanagerAddr, conf.KeymanagerAuthToken, shares); err != nil {
+		if err = writeKeysToKeymanager(ctx, conf.KeymanagerAddr, conf.KeymanagerAuthToken, conf.KeystoreOptions, shares); err != nil {
 			return err
 		}
 		log.Debug(ctx, "Imported keyshares to keymanager", z.Str("keymanager_address", conf.KeymanagerAddr))
+	} else if vaultCl != nil { // Save to vault
+		if err = writeKeysToVault(ctx, vaultCl, shares); err != nil {
+			return err
+		}
+		log.Debug(ctx, "Stored keyshares in vault", z.Str("vault_path", conf.Vault.Path))
 	} else { // Else save to disk
 		if err = writeKeysToDisk(conf, shares); err != nil {
 			return err
 		}
 		log.Debug(ctx, "Saved keyshares to disk")
+
+		if conf.OutputFormat == OutputFormatWeb3Signer {
+			if err = writeWeb3SignerConfig(conf.DataDir, def.ForkVersion); err != nil {
+				return err
+			}
+			log.Debug(ctx, "Saved web3signer key configs and slashing protection to disk")
+		}
 	}
 
 	// dashboardURL is the Launchpad dashboard url for a given lock file.
@@ -346,6 +586,11 @@ func Run(ctx context.Context, conf Config) (err error) {
 	}
 	log.Debug(ctx, "Saved lock file to disk")
 
+	if err = writeTranscript(conf.DataDir, transcript); err != nil {
+		return err
+	}
+	log.Debug(ctx, "Saved transcript file to disk")
+
 	// The loop across partial amounts (shall be unique)
 	for _, dd := range depositDatas {
 		if err := deposit.WriteDepositDataFile(dd, network, conf.DataDir); err != nil {
@@ -354,27 +599,46 @@ func Run(ctx context.Context, conf Config) (err error) {
 		log.Debug(ctx, "Saved deposit data file to disk", z.Str("filepath", deposit.GetDepositFilePath(conf.DataDir, dd[0].Amount)))
 	}
 
+	if conf.DepositBatch {
+		batchAmounts := def.DepositAmounts
+		if len(batchAmounts) == 0 {
+			batchAmounts = []eth2p0.Gwei{deposit.MaxDepositAmount}
+		}
+
+		batch, err := deposit.BatchDepositDatas(depositDatas, batchAmounts)
+		if err != nil {
+			return err
+		}
+
+		if err := deposit.WriteDepositBatchFiles(batch, network, conf.DataDir); err != nil {
+			return err
+		}
+		log.Debug(ctx, "Saved deposit batch files to disk")
+	}
+
 	// Signature verification and disk key write was step 6, advance to step 7
 	if err := nextStepSync(ctx); err != nil {
 		return err
 	}
 
-	if err = stopSync(ctx); err != nil {
-		return errors.Wrap(err, "sync shutdown") // Consider increasing --shutdown-delay if this occurs often.
+	if err := removeCheckpoint(conf.DataDir); err != nil {
+		return err
 	}
 
-	if conf.TestConfig.ShutdownCallback != nil {
-		conf.TestConfig.ShutdownCallback()
+	if err := stopCeremony(ctx, stopSync, conf.ShutdownDelay, conf.TestConfig); err != nil {
+		return err
 	}
-	log.Debug(ctx, "Graceful shutdown delay", z.Int("seconds", int(conf.ShutdownDelay.Seconds())))
-	time.Sleep(conf.ShutdownDelay)
 
 	log.Info(ctx, "Successfully completed DKG ceremony 🎉")
+	summary := []string{"Successfully completed DKG ceremony 🎉"}
 
 	if dashboardURL != "" {
 		log.Info(ctx, "You can find your newly-created cluster dashboard here: "+dashboardURL)
+		summary = append(summary, "You can find your newly-created cluster dashboard here: "+dashboardURL)
 	}
 
+	prog.setFooter(summary...)
+
 	return nil
 }
 
@@ -429,7 +693,7 @@ func setupP2P(ctx context.Context, key *k1.PrivateKey, conf Config, peers []p2p.
 // startSyncProtocol sets up a sync protocol server and clients for each peer and returns a step sync and shutdown functions
 // when all peers are connected.
 func startSyncProtocol(ctx context.Context, tcpNode host.Host, key *k1.PrivateKey, defHash []byte,
-	peerIDs []peer.ID, onFailure func(), testConfig TestConfig,
+	peerIDs []peer.ID, onFailure func(), prog *progress, testConfig TestConfig,
 ) (func(context.Context) error, func(context.Context) error, error) {
 	// Sign definition hash with charon-enr-private-key
 	// Note: libp2p signing does another hash of the defHash.
@@ -446,6 +710,7 @@ func startSyncProtocol(ctx context.Context, tcpNode host.Host, key *k1.PrivateKe
 	server.Start(ctx)
 
 	var clients []*sync.Client
+	clientsByPeer := make(map[peer.ID]*sync.Client)
 	for _, pID := range peerIDs {
 		if tcpNode.ID() == pID {
 			continue
@@ -455,6 +720,7 @@ func startSyncProtocol(ctx context.Context, tcpNode host.Host, key *k1.PrivateKe
 
 		client := sync.NewClient(tcpNode, pID, hashSig, minorVersion, testConfig.SyncOpts...)
 		clients = append(clients, client)
+		clientsByPeer[pID] = client
 
 		go func() {
 			err := client.Run(ctx)
@@ -465,6 +731,19 @@ func startSyncProtocol(ctx context.Context, tcpNode host.Host, key *k1.PrivateKe
 		}()
 	}
 
+	if prog != nil {
+		prog.setSyncStatus(func(pID peer.ID) peerSyncStatus {
+			step, ok := server.PeerStep(pID)
+			client, connected := clientsByPeer[pID]
+
+			return peerSyncStatus{
+				Connected: connected && client.IsConnected(),
+				Step:      step,
+				HasStep:   ok,
+			}
+		})
+	}
+
 	// Check if all clients are connected.
 	for {
 		// Return if there is a context error.
@@ -568,6 +847,12 @@ func signAndAggLockHash(ctx context.Context, shares []share, def cluster.Definit
 		return cluster.Lock{}, err
 	}
 
+	return signAndAggLock(ctx, shares, lock, nodeIdx, ex)
+}
+
+// signAndAggLock returns the provided cluster lock with aggregated signature after signing, exchange and aggregation
+// of partial signatures over its lock hash.
+func signAndAggLock(ctx context.Context, shares []share, lock cluster.Lock, nodeIdx cluster.NodeIdx, ex *exchanger) (cluster.Lock, error) {
 	lockHashSig, err := signLockHash(nodeIdx.ShareIdx, shares, lock.LockHash)
 	if err != nil {
 		return cluster.Lock{}, err
@@ -606,12 +891,12 @@ func signAndAggLockHash(ctx context.Context, shares []share, def cluster.Definit
 // signAndAggDepositData returns the deposit datas for each DV after signing, exchange and aggregation of partial signatures.
 func signAndAggDepositData(ctx context.Context, ex *exchanger, shares []share,
 	withdrawalAddresses []string, network string,
-	nodeIdx cluster.NodeIdx, depositAmounts []eth2p0.Gwei,
+	nodeIdx cluster.NodeIdx, depositAmounts []eth2p0.Gwei, compounding bool,
 ) ([][]eth2p0.DepositData, error) {
 	var depositDataForAmounts [][]eth2p0.DepositData
 
 	for i, amount := range depositAmounts {
-		parSig, despositMsgs, err := signDepositMsgs(shares, nodeIdx.ShareIdx, withdrawalAddresses, network, amount)
+		parSig, despositMsgs, err := signDepositMsgs(shares, nodeIdx.ShareIdx, withdrawalAddresses, network, amount, compounding)
 		if err != nil {
 			return nil, err
 		}
@@ -679,7 +964,7 @@ func aggLockHashSig(data map[core.PubKey][]core.ParSignedData, shares map[core.P
 			if !ok {
 				// peerIdx is 0-indexed while shareIdx is 1-indexed
 				return tbls.Signature{}, nil, errors.New("invalid pubkey in lock hash partial signature from peer",
-					z.Int("peerIdx", s.ShareIdx-1), z.Str("pubkey", pk.String()))
+					z.Int("peerIdx", s.ShareIdx-1), z.Redact(z.Str("pubkey", pk.String())))
 			}
 
 			pubshare, ok := sh.PublicShares[s.ShareIdx]
@@ -690,7 +975,7 @@ func aggLockHashSig(data map[core.PubKey][]core.ParSignedData, shares map[core.P
 			err = tbls.Verify(pubshare, hash, sig)
 			if err != nil {
 				return tbls.Signature{}, nil, errors.Wrap(err, "invalid lock hash partial signature from peer",
-					z.Int("peerIdx", s.ShareIdx-1), z.Str("pubkey", pk.String()))
+					z.Int("peerIdx", s.ShareIdx-1), z.Redact(z.Str("pubkey", pk.String())))
 			}
 
 			sigs = append(sigs, sig)
@@ -728,7 +1013,13 @@ func signLockHash(shareIdx int, shares []share, hash []byte) (core.ParSignedData
 }
 
 // signDepositMsgs returns a partially signed dataset containing signatures of the deposit message signing root.
-func signDepositMsgs(shares []share, shareIdx int, withdrawalAddresses []string, network string, amount eth2p0.Gwei) (core.ParSignedDataSet, map[core.PubKey]eth2p0.DepositMessage, error) {
+// Deposit messages use 0x02 compounding withdrawal credentials if compounding is true.
+func signDepositMsgs(shares []share, shareIdx int, withdrawalAddresses []string, network string, amount eth2p0.Gwei, compounding bool) (core.ParSignedDataSet, map[core.PubKey]eth2p0.DepositMessage, error) {
+	newMsgFunc := deposit.NewMessage
+	if compounding {
+		newMsgFunc = deposit.NewCompoundingMessage
+	}
+
 	msgs := make(map[core.PubKey]eth2p0.DepositMessage)
 	set := make(core.ParSignedDataSet)
 	for i, share := range shares {
@@ -746,7 +1037,7 @@ func signDepositMsgs(shares []share, shareIdx int, withdrawalAddresses []string,
 			return nil, nil, err
 		}
 
-		msg, err := deposit.NewMessage(pubkey, withdrawalHex, amount)
+		msg, err := newMsgFunc(pubkey, withdrawalHex, amount)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -859,7 +1150,7 @@ func aggDepositData(data map[core.PubKey][]core.ParSignedData, shares []share,
 			if !ok {
 				return nil, errors.New("invalid pubkey in deposit data partial signature from peer",
 					z.Int("peerIdx", s.ShareIdx-1), // peerIdx is 0-indexed while shareIdx is 1-indexed
-					z.Str("pubkey", pk.String()))
+					z.Redact(z.Str("pubkey", pk.String())))
 			}
 
 			pubshare, ok := pubshares[s.ShareIdx]
@@ -870,7 +1161,7 @@ func aggDepositData(data map[core.PubKey][]core.ParSignedData, shares []share,
 			err = tbls.Verify(pubshare, sigRoot[:], sig)
 			if err != nil {
 				return nil, errors.New("invalid deposit data partial signature from peer",
-					z.Int("peerIdx", s.ShareIdx-1), z.Str("pubkey", pk.String()))
+					z.Int("peerIdx", s.ShareIdx-1), z.Redact(z.Str("pubkey", pk.String())))
 			}
 
 			psigs[s.ShareIdx] = sig
@@ -943,7 +1234,7 @@ func aggValidatorRegistrations(
 			if !ok {
 				return nil, errors.New("invalid pubkey in validator registrations partial signature from peer",
 					z.Int("peerIdx", s.ShareIdx-1), // peerIdx is 0-indexed while shareIdx is 1-indexed
-					z.Str("pubkey", pk.String()))
+					z.Redact(z.Str("pubkey", pk.String())))
 			}
 
 			pubshare, ok := pubshares[s.ShareIdx]
@@ -954,7 +1245,7 @@ func aggValidatorRegistrations(
 			err = tbls.Verify(pubshare, sigRoot[:], sig)
 			if err != nil {
 				return nil, errors.New("invalid validator registration partial signature from peer",
-					z.Int("peerIdx", s.ShareIdx-1), z.Str("pubkey", pk.String()))
+					z.Int("peerIdx", s.ShareIdx-1), z.Redact(z.Str("pubkey", pk.String())))
 			}
 
 			psigs[s.ShareIdx] = sig
@@ -1032,7 +1323,7 @@ func createDistValidators(shares []share, depositDatas [][]eth2p0.DepositData, v
 
 		depositDatasList, ok := depositDatasMap[tbls.PublicKey(msg.PubKey)]
 		if !ok {
-			return nil, errors.New("deposit data not found for pubkey", z.Str("pubkey", hex.EncodeToString(msg.PubKey)))
+			return nil, errors.New("deposit data not found for pubkey", z.Redact(z.Str("pubkey", hex.EncodeToString(msg.PubKey))))
 		}
 
 		for _, dd := range depositDatasList {
@@ -1083,22 +1374,42 @@ func validateKeymanagerFlags(ctx context.Context, addr, authToken string) error
 
 	keymanagerURL, err := url.Parse(addr)
 	if err != nil {
-		return errors.Wrap(err, "failed to parse keymanager addr", z.Str("addr", addr))
+		return errors.Wrap(err, "failed to parse keymanager addr", z.Redact(z.Str("addr", addr)))
 	}
 
 	if keymanagerURL.Scheme == "http" {
-		log.Warn(ctx, "Keymanager URL does not use https protocol", nil, z.Str("addr", addr))
+		log.Warn(ctx, "Keymanager URL does not use https protocol", nil, z.Redact(z.Str("addr", addr)))
 	}
 
 	return nil
 }
 
+// validateOutputFormat returns an error if the key share output format is invalid or not supported.
+func validateOutputFormat(format string, keymanagerAddr string, forkVersion []byte) error {
+	switch format {
+	case "", OutputFormatDefault:
+		return nil
+	case OutputFormatWeb3Signer:
+		if keymanagerAddr != "" {
+			return errors.New("web3signer output format not supported when importing keys to a keymanager")
+		}
+
+		if _, err := eth2util.ForkVersionToGenesisValidatorsRoot(forkVersion); err != nil {
+			return errors.Wrap(err, "web3signer output format not supported for network")
+		}
+
+		return nil
+	default:
+		return errors.New("invalid output format", z.Str("format", format))
+	}
+}
+
 // logPeerSummary logs peer summary with peer names and their ethereum addresses.
 func logPeerSummary(ctx context.Context, currentPeer peer.ID, peers []p2p.Peer, operators []cluster.Operator) {
 	for i, p := range peers {
 		opts := []z.Field{z.Str("peer", p.Name), z.Int("index", p.Index)}
 		if operators[i].Address != "" {
-			opts = append(opts, z.Str("address", operators[i].Address))
+			opts = append(opts, z.Redact(z.Str("address", operators[i].Address)))
 		}
 		if p.ID == currentPeer {
 			opts = append(opts, z.Str("you", "⭐️"))
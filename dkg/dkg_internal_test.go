@@ -197,3 +197,56 @@ func TestValidateKeymanagerFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOutputFormat(t *testing.T) {
+	holesky, err := eth2util.NetworkToForkVersionBytes(eth2util.Holesky.Name)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		format         string
+		keymanagerAddr string
+		forkVersion    []byte
+		errMsg         string
+	}{
+		{
+			name:        "Empty format",
+			forkVersion: holesky,
+		},
+		{
+			name:        "Web3signer format",
+			format:      OutputFormatWeb3Signer,
+			forkVersion: holesky,
+		},
+		{
+			name:           "Web3signer format with keymanager",
+			format:         OutputFormatWeb3Signer,
+			keymanagerAddr: "https://keymanager@example.com",
+			forkVersion:    holesky,
+			errMsg:         "web3signer output format not supported when importing keys to a keymanager",
+		},
+		{
+			name:        "Web3signer format with unknown network",
+			format:      OutputFormatWeb3Signer,
+			forkVersion: []byte{1, 2, 3, 4},
+			errMsg:      "web3signer output format not supported for network",
+		},
+		{
+			name:        "Invalid format",
+			format:      "invalid",
+			forkVersion: holesky,
+			errMsg:      "invalid output format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOutputFormat(tt.format, tt.keymanagerAddr, tt.forkVersion)
+			if tt.errMsg != "" {
+				require.ErrorContains(t, err, tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
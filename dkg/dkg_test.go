@@ -89,6 +89,7 @@ func TestDKG(t *testing.T) {
 		dkgAlgo        string
 		version        string // Defaults to latest if empty
 		depositAmounts []eth2p0.Gwei
+		compounding    bool
 		keymanager     bool
 		publish        bool
 	}{
@@ -111,6 +112,15 @@ func TestDKG(t *testing.T) {
 				8 * deposit.OneEthInGwei,
 			},
 		},
+		{
+			name:        "with_compounding_batched_deposits",
+			dkgAlgo:     "frost",
+			compounding: true,
+			depositAmounts: []eth2p0.Gwei{
+				16 * deposit.OneEthInGwei,
+				16 * deposit.OneEthInGwei,
+			},
+		},
 		{
 			name:       "dkg with keymanager",
 			dkgAlgo:    "frost",
@@ -143,15 +153,21 @@ func TestDKG(t *testing.T) {
 			lock, keys, _ := cluster.NewForT(t, vals, nodes, nodes, seed, random, opts...)
 			dir := t.TempDir()
 
-			testDKG(t, lock.Definition, dir, keys, test.keymanager, test.publish)
+			testDKG(t, lock.Definition, dir, keys, test.keymanager, test.publish, func(conf *dkg.Config) {
+				conf.Compounding = test.compounding
+				conf.DepositBatch = test.compounding
+			})
 			if !test.keymanager {
 				verifyDKGResults(t, lock.Definition, dir)
 			}
+			if test.compounding {
+				verifyCompoundingDeposits(t, lock.Definition, dir)
+			}
 		})
 	}
 }
 
-func testDKG(t *testing.T, def cluster.Definition, dir string, p2pKeys []*k1.PrivateKey, keymanager bool, publish bool) {
+func testDKG(t *testing.T, def cluster.Definition, dir string, p2pKeys []*k1.PrivateKey, keymanager bool, publish bool, opts ...func(*dkg.Config)) {
 	t.Helper()
 
 	require.NoError(t, def.VerifySignatures())
@@ -181,6 +197,10 @@ func testDKG(t *testing.T, def cluster.Definition, dir string, p2pKeys []*k1.Pri
 		Timeout:        8 * time.Second,
 	}
 
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
 	allReceivedKeystores := make(chan struct{}) // Receives struct{} for each `numNodes` keystore intercepted by the keymanager server
 	if keymanager {
 		const testAuthToken = "test-auth-token"
@@ -371,6 +391,13 @@ func verifyDKGResults(t *testing.T, def cluster.Definition, dir string) {
 		locks = append(locks, lock)
 
 		verifyDistValidators(t, lock, def)
+
+		transcriptFile, err := os.ReadFile(path.Join(dataDir, dkg.TranscriptFile))
+		require.NoError(t, err)
+
+		var transcript dkg.Transcript
+		require.NoError(t, json.Unmarshal(transcriptFile, &transcript))
+		require.NoError(t, dkg.VerifyTranscript(transcript, lock))
 	}
 
 	// Ensure locks hashes are identical.
@@ -408,6 +435,37 @@ func verifyDKGResults(t *testing.T, def cluster.Definition, dir string) {
 	}
 }
 
+// verifyCompoundingDeposits asserts that all nodes created deposit datas with compounding withdrawal credentials
+// and deposit batch files containing a deposit for each deposit amount of each validator.
+func verifyCompoundingDeposits(t *testing.T, def cluster.Definition, dir string) {
+	t.Helper()
+
+	for i := range len(def.Operators) {
+		dataDir := path.Join(dir, fmt.Sprintf("node%d", i))
+
+		lockFile, err := os.ReadFile(path.Join(dataDir, "cluster-lock.json"))
+		require.NoError(t, err)
+
+		var lock cluster.Lock
+		require.NoError(t, json.Unmarshal(lockFile, &lock))
+
+		for _, val := range lock.Validators {
+			for _, pdd := range val.PartialDepositData {
+				require.EqualValues(t, 0x02, pdd.WithdrawalCredentials[0])
+			}
+		}
+
+		batchFile, err := os.ReadFile(path.Join(dataDir, deposit.BatchCSVFile))
+		require.NoError(t, err)
+
+		rows := strings.Split(strings.TrimSpace(string(batchFile)), "\n")
+		require.Len(t, rows, 1+len(def.DepositAmounts)*def.NumValidators) // Header and one row per deposit.
+
+		_, err = os.Stat(path.Join(dataDir, deposit.BatchJSONFile))
+		require.NoError(t, err)
+	}
+}
+
 func verifyDistValidators(t *testing.T, lock cluster.Lock, def cluster.Definition) {
 	t.Helper()
 
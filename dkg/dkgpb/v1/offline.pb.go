@@ -0,0 +1,180 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: dkg/dkgpb/v1/offline.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OfflineMsg is a signed DKG message exchanged as a file during an offline DKG ceremony.
+type OfflineMsg struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DefinitionHash []byte                 `protobuf:"bytes,1,opt,name=definition_hash,json=definitionHash,proto3" json:"definition_hash,omitempty"`    // Hash of the cluster definition of the ceremony.
+	MsgId          string                 `protobuf:"bytes,2,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"`                               // Protocol message ID.
+	SourceShareIdx uint32                 `protobuf:"varint,3,opt,name=source_share_idx,json=sourceShareIdx,proto3" json:"source_share_idx,omitempty"` // Share index of the sender.
+	TargetShareIdx uint32                 `protobuf:"varint,4,opt,name=target_share_idx,json=targetShareIdx,proto3" json:"target_share_idx,omitempty"` // Share index of the recipient, zero for messages broadcast to all peers.
+	Payload        []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`                                        // Marshalled google.protobuf.Any message, encrypted to the recipient if targeted.
+	Signature      []byte                 `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`                                    // K1 signature of the sender over the hash of all the above fields.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OfflineMsg) Reset() {
+	*x = OfflineMsg{}
+	mi := &file_dkg_dkgpb_v1_offline_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfflineMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfflineMsg) ProtoMessage() {}
+
+func (x *OfflineMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkgpb_v1_offline_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfflineMsg.ProtoReflect.Descriptor instead.
+func (*OfflineMsg) Descriptor() ([]byte, []int) {
+	return file_dkg_dkgpb_v1_offline_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OfflineMsg) GetDefinitionHash() []byte {
+	if x != nil {
+		return x.DefinitionHash
+	}
+	return nil
+}
+
+func (x *OfflineMsg) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *OfflineMsg) GetSourceShareIdx() uint32 {
+	if x != nil {
+		return x.SourceShareIdx
+	}
+	return 0
+}
+
+func (x *OfflineMsg) GetTargetShareIdx() uint32 {
+	if x != nil {
+		return x.TargetShareIdx
+	}
+	return 0
+}
+
+func (x *OfflineMsg) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *OfflineMsg) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+var File_dkg_dkgpb_v1_offline_proto protoreflect.FileDescriptor
+
+var file_dkg_dkgpb_v1_offline_proto_rawDesc = string([]byte{
+	0x0a, 0x1a, 0x64, 0x6b, 0x67, 0x2f, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x2f, 0x6f,
+	0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x64, 0x6b,
+	0x67, 0x2e, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x22, 0xd8, 0x01, 0x0a, 0x0a, 0x4f,
+	0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x65, 0x66,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x73, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x49, 0x64, 0x78, 0x12, 0x28, 0x0a, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x49, 0x64, 0x78, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f,
+	0x63, 0x68, 0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x64, 0x6b, 0x67, 0x2f, 0x64, 0x6b, 0x67, 0x70, 0x62,
+	0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_dkg_dkgpb_v1_offline_proto_rawDescOnce sync.Once
+	file_dkg_dkgpb_v1_offline_proto_rawDescData []byte
+)
+
+func file_dkg_dkgpb_v1_offline_proto_rawDescGZIP() []byte {
+	file_dkg_dkgpb_v1_offline_proto_rawDescOnce.Do(func() {
+		file_dkg_dkgpb_v1_offline_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dkg_dkgpb_v1_offline_proto_rawDesc), len(file_dkg_dkgpb_v1_offline_proto_rawDesc)))
+	})
+	return file_dkg_dkgpb_v1_offline_proto_rawDescData
+}
+
+var file_dkg_dkgpb_v1_offline_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_dkg_dkgpb_v1_offline_proto_goTypes = []any{
+	(*OfflineMsg)(nil), // 0: dkg.dkgpb.v1.OfflineMsg
+}
+var file_dkg_dkgpb_v1_offline_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_dkg_dkgpb_v1_offline_proto_init() }
+func file_dkg_dkgpb_v1_offline_proto_init() {
+	if File_dkg_dkgpb_v1_offline_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dkg_dkgpb_v1_offline_proto_rawDesc), len(file_dkg_dkgpb_v1_offline_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_dkg_dkgpb_v1_offline_proto_goTypes,
+		DependencyIndexes: file_dkg_dkgpb_v1_offline_proto_depIdxs,
+		MessageInfos:      file_dkg_dkgpb_v1_offline_proto_msgTypes,
+	}.Build()
+	File_dkg_dkgpb_v1_offline_proto = out.File
+	file_dkg_dkgpb_v1_offline_proto_goTypes = nil
+	file_dkg_dkgpb_v1_offline_proto_depIdxs = nil
+}
@@ -0,0 +1,303 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: dkg/dkgpb/v1/refresh.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RefreshCasts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Casts         []*RefreshCast         `protobuf:"bytes,1,rep,name=casts,proto3" json:"casts,omitempty"` // One per validator
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshCasts) Reset() {
+	*x = RefreshCasts{}
+	mi := &file_dkg_dkgpb_v1_refresh_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshCasts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshCasts) ProtoMessage() {}
+
+func (x *RefreshCasts) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkgpb_v1_refresh_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshCasts.ProtoReflect.Descriptor instead.
+func (*RefreshCasts) Descriptor() ([]byte, []int) {
+	return file_dkg_dkgpb_v1_refresh_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RefreshCasts) GetCasts() []*RefreshCast {
+	if x != nil {
+		return x.Casts
+	}
+	return nil
+}
+
+type RefreshCast struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *FrostMsgKey           `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Commitments   [][]byte               `protobuf:"bytes,2,rep,name=commitments,proto3" json:"commitments,omitempty"` // Feldman commitments of the zero polynomial excluding the constant term.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshCast) Reset() {
+	*x = RefreshCast{}
+	mi := &file_dkg_dkgpb_v1_refresh_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshCast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshCast) ProtoMessage() {}
+
+func (x *RefreshCast) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkgpb_v1_refresh_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshCast.ProtoReflect.Descriptor instead.
+func (*RefreshCast) Descriptor() ([]byte, []int) {
+	return file_dkg_dkgpb_v1_refresh_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RefreshCast) GetKey() *FrostMsgKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *RefreshCast) GetCommitments() [][]byte {
+	if x != nil {
+		return x.Commitments
+	}
+	return nil
+}
+
+type RefreshP2P struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*RefreshShare        `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"` // One per validator
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshP2P) Reset() {
+	*x = RefreshP2P{}
+	mi := &file_dkg_dkgpb_v1_refresh_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshP2P) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshP2P) ProtoMessage() {}
+
+func (x *RefreshP2P) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkgpb_v1_refresh_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshP2P.ProtoReflect.Descriptor instead.
+func (*RefreshP2P) Descriptor() ([]byte, []int) {
+	return file_dkg_dkgpb_v1_refresh_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RefreshP2P) GetShares() []*RefreshShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type RefreshShare struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *FrostMsgKey           `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshShare) Reset() {
+	*x = RefreshShare{}
+	mi := &file_dkg_dkgpb_v1_refresh_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshShare) ProtoMessage() {}
+
+func (x *RefreshShare) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkgpb_v1_refresh_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshShare.ProtoReflect.Descriptor instead.
+func (*RefreshShare) Descriptor() ([]byte, []int) {
+	return file_dkg_dkgpb_v1_refresh_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RefreshShare) GetKey() *FrostMsgKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *RefreshShare) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+var File_dkg_dkgpb_v1_refresh_proto protoreflect.FileDescriptor
+
+var file_dkg_dkgpb_v1_refresh_proto_rawDesc = string([]byte{
+	0x0a, 0x1a, 0x64, 0x6b, 0x67, 0x2f, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x2f, 0x72,
+	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x64, 0x6b,
+	0x67, 0x2e, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x1a, 0x18, 0x64, 0x6b, 0x67, 0x2f,
+	0x64, 0x6b, 0x67, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x2f, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3f, 0x0a, 0x0c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x43,
+	0x61, 0x73, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x05, 0x63, 0x61, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x43, 0x61, 0x73, 0x74, 0x52, 0x05,
+	0x63, 0x61, 0x73, 0x74, 0x73, 0x22, 0x5c, 0x0a, 0x0b, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x43, 0x61, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x72, 0x6f, 0x73, 0x74, 0x4d, 0x73, 0x67, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x22, 0x40, 0x0a, 0x0a, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x50, 0x32,
+	0x50, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x06, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x73, 0x22, 0x51, 0x0a, 0x0c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x2b, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x46, 0x72, 0x6f, 0x73, 0x74, 0x4d, 0x73, 0x67, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x2f, 0x63, 0x68, 0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x64, 0x6b, 0x67, 0x2f, 0x64, 0x6b,
+	0x67, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_dkg_dkgpb_v1_refresh_proto_rawDescOnce sync.Once
+	file_dkg_dkgpb_v1_refresh_proto_rawDescData []byte
+)
+
+func file_dkg_dkgpb_v1_refresh_proto_rawDescGZIP() []byte {
+	file_dkg_dkgpb_v1_refresh_proto_rawDescOnce.Do(func() {
+		file_dkg_dkgpb_v1_refresh_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dkg_dkgpb_v1_refresh_proto_rawDesc), len(file_dkg_dkgpb_v1_refresh_proto_rawDesc)))
+	})
+	return file_dkg_dkgpb_v1_refresh_proto_rawDescData
+}
+
+var file_dkg_dkgpb_v1_refresh_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_dkg_dkgpb_v1_refresh_proto_goTypes = []any{
+	(*RefreshCasts)(nil), // 0: dkg.dkgpb.v1.RefreshCasts
+	(*RefreshCast)(nil),  // 1: dkg.dkgpb.v1.RefreshCast
+	(*RefreshP2P)(nil),   // 2: dkg.dkgpb.v1.RefreshP2P
+	(*RefreshShare)(nil), // 3: dkg.dkgpb.v1.RefreshShare
+	(*FrostMsgKey)(nil),  // 4: dkg.dkgpb.v1.FrostMsgKey
+}
+var file_dkg_dkgpb_v1_refresh_proto_depIdxs = []int32{
+	1, // 0: dkg.dkgpb.v1.RefreshCasts.casts:type_name -> dkg.dkgpb.v1.RefreshCast
+	4, // 1: dkg.dkgpb.v1.RefreshCast.key:type_name -> dkg.dkgpb.v1.FrostMsgKey
+	3, // 2: dkg.dkgpb.v1.RefreshP2P.shares:type_name -> dkg.dkgpb.v1.RefreshShare
+	4, // 3: dkg.dkgpb.v1.RefreshShare.key:type_name -> dkg.dkgpb.v1.FrostMsgKey
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_dkg_dkgpb_v1_refresh_proto_init() }
+func file_dkg_dkgpb_v1_refresh_proto_init() {
+	if File_dkg_dkgpb_v1_refresh_proto != nil {
+		return
+	}
+	file_dkg_dkgpb_v1_frost_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dkg_dkgpb_v1_refresh_proto_rawDesc), len(file_dkg_dkgpb_v1_refresh_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_dkg_dkgpb_v1_refresh_proto_goTypes,
+		DependencyIndexes: file_dkg_dkgpb_v1_refresh_proto_depIdxs,
+		MessageInfos:      file_dkg_dkgpb_v1_refresh_proto_msgTypes,
+	}.Build()
+	File_dkg_dkgpb_v1_refresh_proto = out.File
+	file_dkg_dkgpb_v1_refresh_proto_goTypes = nil
+	file_dkg_dkgpb_v1_refresh_proto_depIdxs = nil
+}
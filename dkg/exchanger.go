@@ -46,9 +46,16 @@ type dataByPubkey struct {
 	lock    sync.Mutex
 }
 
+// parSigExchanger exchanges partially signed data sets with peers.
+// It is implemented by parsigex.ParSigEx for online and offlineParSigEx for offline ceremonies.
+type parSigExchanger interface {
+	Broadcast(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error
+	Subscribe(fn func(context.Context, core.Duty, core.ParSignedDataSet) error)
+}
+
 // exchanger is responsible for exchanging partial signatures between peers on libp2p.
 type exchanger struct {
-	sigex         *parsigex.ParSigEx
+	sigex         parSigExchanger
 	sigdb         *parsigdb.MemDB
 	sigTypes      map[sigType]bool
 	sigData       dataByPubkey
@@ -62,13 +69,28 @@ func newExchanger(tcpNode host.Host, peerIdx int, peers []peer.ID, vals int, sig
 		return nil
 	}
 
+	dutyGaterFunc := newSigTypeGater(sigTypes)
+	sigex := parsigex.NewParSigEx(tcpNode, p2p.Send, peerIdx, peers, noopVerifier, dutyGaterFunc, p2p.WithSendTimeout(timeout), p2p.WithReceiveTimeout(timeout))
+
+	return newExchangerWithSigEx(sigex, dutyGaterFunc, len(peers), vals, sigTypes)
+}
+
+// newOfflineExchanger returns an exchanger that exchanges partial signatures via offline message files.
+func newOfflineExchanger(ex *offlineExchange, numPeers int, vals int, sigTypes []sigType) *exchanger {
+	dutyGaterFunc := newSigTypeGater(sigTypes)
+
+	return newExchangerWithSigEx(newOfflineParSigEx(ex, dutyGaterFunc), dutyGaterFunc, numPeers, vals, sigTypes)
+}
+
+// newSigTypeGater returns a duty gater function that only allows signature duties of the provided sig types.
+func newSigTypeGater(sigTypes []sigType) func(duty core.Duty) bool {
 	st := make(map[sigType]bool)
 
 	for _, sigType := range sigTypes {
 		st[sigType] = true
 	}
 
-	dutyGaterFunc := func(duty core.Duty) bool {
+	return func(duty core.Duty) bool {
 		if duty.Type != core.DutySignature {
 			return false
 		}
@@ -79,11 +101,19 @@ func newExchanger(tcpNode host.Host, peerIdx int, peers []peer.ID, vals int, sig
 
 		return st[sigType(duty.Slot)]
 	}
+}
+
+func newExchangerWithSigEx(sigex parSigExchanger, dutyGaterFunc func(duty core.Duty) bool, numPeers int, vals int, sigTypes []sigType) *exchanger {
+	st := make(map[sigType]bool)
+
+	for _, sigType := range sigTypes {
+		st[sigType] = true
+	}
 
 	ex := &exchanger{
 		// threshold is len(peers) to wait until we get all the partial sigs from all the peers per DV
-		sigdb:    parsigdb.NewMemDB(len(peers), noopDeadliner{}),
-		sigex:    parsigex.NewParSigEx(tcpNode, p2p.Send, peerIdx, peers, noopVerifier, dutyGaterFunc, p2p.WithSendTimeout(timeout), p2p.WithReceiveTimeout(timeout)),
+		sigdb:    parsigdb.NewMemDB(numPeers, noopDeadliner{}),
+		sigex:    sigex,
 		sigTypes: st,
 		sigData: dataByPubkey{
 			store:   sigTypeStore{},
@@ -4,11 +4,14 @@ package dkg
 
 import (
 	"context"
+	"runtime"
 	"sort"
+	"sync"
 
 	"github.com/coinbase/kryptology/pkg/core/curves"
 	"github.com/coinbase/kryptology/pkg/dkg/frost"
 	"github.com/coinbase/kryptology/pkg/sharing"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
@@ -112,19 +115,23 @@ func newFrostParticipants(numValidators, numNodes, threshold, shareIdx uint32, d
 	return resp, nil
 }
 
-// round1 executes round 1 for each validator and returns all round 1
+// round1 executes round 1 for each validator concurrently and returns all round 1
 // broadcast and p2p messages for all validators.
 func round1(validators map[uint32]*frost.DkgParticipant) (map[msgKey]frost.Round1Bcast, map[msgKey]sharing.ShamirShare, error) {
 	var (
+		mu          sync.Mutex
 		castResults = make(map[msgKey]frost.Round1Bcast)
 		p2pResults  = make(map[msgKey]sharing.ShamirShare)
 	)
-	for vIdx, v := range validators {
+	err := forEachValidator(validators, func(vIdx uint32, v *frost.DkgParticipant) error {
 		cast, p2p, err := v.Round1(nil)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "exec round 1")
+			return errors.Wrap(err, "exec round 1")
 		}
 
+		mu.Lock()
+		defer mu.Unlock()
+
 		castResults[msgKey{
 			ValIdx:   vIdx,
 			SourceID: v.Id,
@@ -138,58 +145,94 @@ func round1(validators map[uint32]*frost.DkgParticipant) (map[msgKey]frost.Round
 				TargetID: targetID,
 			}] = *shamirShare
 		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return castResults, p2pResults, nil
 }
 
-// round2 executes round 2 for each validator and returns all round 2
+// round2 executes round 2 for each validator concurrently and returns all round 2
 // broadcast messages for all validators.
 func round2(
 	validators map[uint32]*frost.DkgParticipant,
 	castR1 map[msgKey]frost.Round1Bcast,
 	p2pR1 map[msgKey]sharing.ShamirShare,
 ) (map[msgKey]frost.Round2Bcast, error) {
-	castResults := make(map[msgKey]frost.Round2Bcast)
-	for vIdx, v := range validators {
-		castR2, err := v.Round2(getRound2Inputs(castR1, p2pR1, vIdx))
+	castInputs, shareInputs := getRound2Inputs(castR1, p2pR1)
+
+	var (
+		mu          sync.Mutex
+		castResults = make(map[msgKey]frost.Round2Bcast)
+	)
+	err := forEachValidator(validators, func(vIdx uint32, v *frost.DkgParticipant) error {
+		castR2, err := v.Round2(castInputs[vIdx], shareInputs[vIdx])
 		if err != nil {
-			return nil, errors.Wrap(err, "exec round 2")
+			return errors.Wrap(err, "exec round 2")
 		}
 
+		mu.Lock()
+		defer mu.Unlock()
+
 		castResults[msgKey{
 			ValIdx:   vIdx,
 			SourceID: v.Id,
 			TargetID: 0, // Broadcast
 		}] = *castR2
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return castResults, nil
 }
 
-// getRound2Inputs returns the round 2 inputs of the vIdx'th validator.
+// getRound2Inputs returns the round 2 inputs of all validators by validator index.
 func getRound2Inputs(
 	castR1 map[msgKey]frost.Round1Bcast,
 	p2pR1 map[msgKey]sharing.ShamirShare,
-	vIdx uint32,
-) (map[uint32]*frost.Round1Bcast, map[uint32]*sharing.ShamirShare) {
-	castMap := make(map[uint32]*frost.Round1Bcast)
+) (map[uint32]map[uint32]*frost.Round1Bcast, map[uint32]map[uint32]*sharing.ShamirShare) {
+	castMaps := make(map[uint32]map[uint32]*frost.Round1Bcast)
 	for key, cast := range castR1 {
-		if key.ValIdx != vIdx {
-			continue
+		castMap, ok := castMaps[key.ValIdx]
+		if !ok {
+			castMap = make(map[uint32]*frost.Round1Bcast)
+			castMaps[key.ValIdx] = castMap
 		}
 		castMap[key.SourceID] = &cast
 	}
 
-	shareMap := make(map[uint32]*sharing.ShamirShare)
+	shareMaps := make(map[uint32]map[uint32]*sharing.ShamirShare)
 	for key, share := range p2pR1 {
-		if key.ValIdx != vIdx {
-			continue
+		shareMap, ok := shareMaps[key.ValIdx]
+		if !ok {
+			shareMap = make(map[uint32]*sharing.ShamirShare)
+			shareMaps[key.ValIdx] = shareMap
 		}
 		shareMap[key.SourceID] = &share
 	}
 
-	return castMap, shareMap
+	return castMaps, shareMaps
+}
+
+// forEachValidator calls fn for each validator concurrently, bounded by the number of usable CPUs
+// since the frost rounds are CPU bound. It returns the first error.
+func forEachValidator(validators map[uint32]*frost.DkgParticipant, fn func(vIdx uint32, v *frost.DkgParticipant) error) error {
+	var eg errgroup.Group
+	eg.SetLimit(runtime.GOMAXPROCS(0))
+
+	for vIdx, v := range validators {
+		eg.Go(func() error {
+			return fn(vIdx, v)
+		})
+	}
+
+	return eg.Wait()
 }
 
 // makeShares returns a slice of shares (one for each validator) from the DKG participants and round 2 results.
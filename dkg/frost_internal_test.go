@@ -4,6 +4,7 @@ package dkg
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -141,3 +142,31 @@ func (t *frostMemTransport) Round2(ctx context.Context, bcast map[msgKey]frost.R
 		time.Sleep(time.Millisecond)
 	}
 }
+
+func BenchmarkFrostDKG(b *testing.B) {
+	const nodes = 4
+
+	for _, vals := range []int{1, 100, 1000} {
+		b.Run(fmt.Sprintf("validators_%d", vals), func(b *testing.B) {
+			for range b.N {
+				ctx, cancel := context.WithCancel(context.Background())
+				tp := &frostMemTransport{nodes: nodes}
+
+				var eg errgroup.Group
+				for i := range nodes {
+					eg.Go(func() error {
+						_, err := runFrostParallel(ctx, tp, uint32(vals), nodes, 3, uint32(i+1), "test context")
+						if err != nil {
+							cancel()
+						}
+
+						return err
+					})
+				}
+
+				require.NoError(b, eg.Wait())
+				cancel()
+			}
+		})
+	}
+}
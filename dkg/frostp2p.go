@@ -13,6 +13,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
@@ -37,8 +38,54 @@ func frostMessageIDs() []string {
 }
 
 // newFrostP2P returns a p2p frost transport implementation.
-// It registers bcast handlers on bcastComp.
-func newFrostP2P(tcpNode host.Host, peers map[peer.ID]cluster.NodeIdx, bcastComp *bcast.Component, threshold, numVals int) (*frostP2P, error) {
+// It registers bcast handlers on caster.
+func newFrostP2P(tcpNode host.Host, peers map[peer.ID]cluster.NodeIdx, caster broadcaster, threshold, numVals int) (*frostP2P, error) {
+	registerP2P := func(handler p2p.HandlerFunc) {
+		p2p.RegisterHandler("frost", tcpNode, round1P2PID,
+			func() proto.Message { return new(pb.FrostRound1P2P) },
+			handler,
+		)
+	}
+
+	sendP2P := func(ctx context.Context, pID peer.ID, msg proto.Message) error {
+		return p2p.Send(ctx, tcpNode, round1P2PID, pID, msg)
+	}
+
+	return newFrostTransport(tcpNode.ID(), peers, caster, registerP2P, sendP2P, threshold, numVals)
+}
+
+// newFrostOffline returns a frost transport implementation exchanging messages via offline message files.
+// It registers handlers on the offline exchange.
+func newFrostOffline(selfID peer.ID, peers map[peer.ID]cluster.NodeIdx, ex *offlineExchange, threshold, numVals int) (*frostP2P, error) {
+	registerP2P := func(handler p2p.HandlerFunc) {
+		callback := func(ctx context.Context, pID peer.ID, _ string, msg proto.Message) error {
+			_, _, err := handler(ctx, pID, msg)
+			return err
+		}
+
+		checkMsg := func(_ context.Context, _ peer.ID, msgAny *anypb.Any) error {
+			var msg pb.FrostRound1P2P
+			if err := msgAny.UnmarshalTo(&msg); err != nil {
+				return errors.Wrap(err, "frost check message fail")
+			}
+
+			return nil
+		}
+
+		ex.RegisterMessageIDFuncs(string(round1P2PID), callback, checkMsg)
+	}
+
+	sendP2P := func(ctx context.Context, pID peer.ID, msg proto.Message) error {
+		return ex.Send(ctx, string(round1P2PID), pID, msg)
+	}
+
+	return newFrostTransport(selfID, peers, ex, registerP2P, sendP2P, threshold, numVals)
+}
+
+// newFrostTransport returns a frost transport using the provided broadcaster and p2p register and send functions.
+func newFrostTransport(selfID peer.ID, peers map[peer.ID]cluster.NodeIdx, caster broadcaster,
+	registerP2P func(p2p.HandlerFunc), sendP2P func(context.Context, peer.ID, proto.Message) error, threshold, numVals int,
+) (*frostP2P, error) {
 	var (
 		round1CastsRecv = make(chan *pb.FrostRound1Casts, len(peers))
 		round1P2PRecv   = make(chan *pb.FrostRound1P2P, len(peers))
@@ -53,10 +100,7 @@ func newFrostP2P(tcpNode host.Host, peers map[peer.ID]cluster.NodeIdx, bcastComp
 	}
 
 	// Register round 1 p2p protocol handlers.
-	p2p.RegisterHandler("frost", tcpNode, round1P2PID,
-		func() proto.Message { return new(pb.FrostRound1P2P) },
-		newP2PCallback(tcpNode, peers, round1P2PRecv, numVals),
-	)
+	registerP2P(newP2PCallback(selfID, peers, round1P2PRecv, numVals))
 
 	bcastCallback := newBcastCallback(peers, round1CastsRecv, round2CastsRecv, threshold, numVals)
 
@@ -66,13 +110,14 @@ func newFrostP2P(tcpNode host.Host, peers map[peer.ID]cluster.NodeIdx, bcastComp
 			return nil, err
 		}
 
-		bcastComp.RegisterMessageIDFuncs(frostMsgID, bcastCallback, checkMsg)
+		caster.RegisterMessageIDFuncs(frostMsgID, bcastCallback, checkMsg)
 	}
 
 	return &frostP2P{
-		tcpNode:         tcpNode,
+		selfID:          selfID,
 		peers:           peersByShareIdx,
-		bcastFunc:       bcastComp.Broadcast,
+		bcastFunc:       caster.Broadcast,
+		sendFunc:        sendP2P,
 		round1CastsRecv: round1CastsRecv,
 		round1P2PRecv:   round1P2PRecv,
 		round2CastsRecv: round2CastsRecv,
@@ -196,7 +241,7 @@ func newBcastCallback(peers map[peer.ID]cluster.NodeIdx, round1CastsRecv chan *p
 }
 
 // newP2PCallback returns a callback for P2P messages in round 1 of frost protocol.
-func newP2PCallback(tcpNode host.Host, peers map[peer.ID]cluster.NodeIdx, round1P2PRecv chan *pb.FrostRound1P2P, numVals int) p2p.HandlerFunc {
+func newP2PCallback(selfID peer.ID, peers map[peer.ID]cluster.NodeIdx, round1P2PRecv chan *pb.FrostRound1P2P, numVals int) p2p.HandlerFunc {
 	var (
 		mu             sync.Mutex
 		dedupRound1P2P = make(map[peer.ID]bool)
@@ -214,7 +259,7 @@ func newP2PCallback(tcpNode host.Host, peers map[peer.ID]cluster.NodeIdx, round1
 		for _, share := range msg.GetShares() {
 			if int(share.GetKey().GetSourceId()) != peers[pID].ShareIdx {
 				return nil, false, errors.New("invalid round 1 p2p source ID")
-			} else if int(share.GetKey().GetTargetId()) != peers[tcpNode.ID()].ShareIdx {
+			} else if int(share.GetKey().GetTargetId()) != peers[selfID].ShareIdx {
 				return nil, false, errors.New("invalid round 1 p2p target ID")
 			} else if int(share.GetKey().GetValIdx()) < 0 || int(share.GetKey().GetValIdx()) >= numVals {
 				return nil, false, errors.New("invalid round 1 p2p validator index")
@@ -235,9 +280,10 @@ func newP2PCallback(tcpNode host.Host, peers map[peer.ID]cluster.NodeIdx, round1
 
 // frostP2P implements frost transport.
 type frostP2P struct {
-	tcpNode         host.Host
+	selfID          peer.ID
 	peers           map[uint32]peer.ID // map[shareIdx)peerID
 	bcastFunc       bcast.BroadcastFunc
+	sendFunc        func(context.Context, peer.ID, proto.Message) error
 	round1CastsRecv chan *pb.FrostRound1Casts
 	round1P2PRecv   chan *pb.FrostRound1P2P
 	round2CastsRecv chan *pb.FrostRound2Casts
@@ -247,7 +293,7 @@ type frostP2P struct {
 // and the round 1 P2P sends to this node.
 func (f *frostP2P) Round1(ctx context.Context, castR1 map[msgKey]frost.Round1Bcast, p2pR1 map[msgKey]sharing.ShamirShare,
 ) (map[msgKey]frost.Round1Bcast, map[msgKey]sharing.ShamirShare, error) {
-	// Build broadcast message
+	// Build broadcast message batching the casts of all validators
 	casts := new(pb.FrostRound1Casts)
 	for key, cast := range castR1 {
 		cast := round1CastToProto(key, cast)
@@ -276,16 +322,19 @@ func (f *frostP2P) Round1(ctx context.Context, castR1 map[msgKey]frost.Round1Bca
 		p2pMsgs[pID] = p2pMsg
 	}
 
-	// Send messages to all peers
+	// Send batched messages containing the shares of all validators to all peers concurrently.
+	var eg errgroup.Group
 	for pID, p2pMsg := range p2pMsgs {
-		if pID == f.tcpNode.ID() {
+		if pID == f.selfID {
 			return nil, nil, errors.New("bug: unexpected p2p message to self")
 		}
 
-		err := p2p.Send(ctx, f.tcpNode, round1P2PID, pID, p2pMsg)
-		if err != nil {
-			return nil, nil, err
-		}
+		eg.Go(func() error {
+			return f.sendFunc(ctx, pID, p2pMsg)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
 	}
 
 	// Wait for all incoming messages
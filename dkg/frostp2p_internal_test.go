@@ -243,7 +243,7 @@ func TestP2PCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			round1P2PRecv := make(chan *pb.FrostRound1P2P, len(peers))
 
-			callbackFunc := newP2PCallback(tcpNodes[0], peerMap, round1P2PRecv, numVals)
+			callbackFunc := newP2PCallback(tcpNodes[0].ID(), peerMap, round1P2PRecv, numVals)
 
 			if tt.invalidRound1P2PMsg {
 				_, _, err := callbackFunc(ctx, peers[0], nil)
@@ -22,14 +22,15 @@ import (
 	"github.com/obolnetwork/charon/p2p"
 )
 
-const nodeSigMsgID = "/charon/dkg/node_sig"
-
-func nodeSigMsgIDs() []string {
-	return []string{nodeSigMsgID}
-}
+const (
+	nodeSigMsgID       = "/charon/dkg/node_sig"
+	transcriptSigMsgID = "/charon/dkg/transcript_sig"
+)
 
-// nodeSigBcast handles broadcasting of K1 signatures over the lock hash via the bcast protocol.
+// nodeSigBcast handles broadcasting of K1 signatures over the lock hash (or other hash) via the bcast protocol.
 type nodeSigBcast struct {
+	msgID string
+
 	sigs     [][]byte
 	sigsLock sync.Mutex
 
@@ -42,14 +43,35 @@ type nodeSigBcast struct {
 	lhLock       sync.Mutex
 }
 
-// newNodeSigBcast returns a new instance of nodeSigBcast.
+// newNodeSigBcast returns a new instance of nodeSigBcast for K1 signatures over the lock hash.
 // It registers bcast handlers on bcastComp.
 func newNodeSigBcast(
 	peers []p2p.Peer,
 	nodeIdx cluster.NodeIdx,
-	bcastComp *bcast.Component,
+	bcastComp broadcaster,
+) *nodeSigBcast {
+	return newK1SigBcast(peers, nodeIdx, bcastComp, nodeSigMsgID)
+}
+
+// newTranscriptSigBcast returns a new instance of nodeSigBcast for K1 signatures over the DKG transcript hash.
+// It registers bcast handlers on bcastComp.
+func newTranscriptSigBcast(
+	peers []p2p.Peer,
+	nodeIdx cluster.NodeIdx,
+	bcastComp broadcaster,
+) *nodeSigBcast {
+	return newK1SigBcast(peers, nodeIdx, bcastComp, transcriptSigMsgID)
+}
+
+// newK1SigBcast returns a new instance of nodeSigBcast using the provided bcast message ID.
+func newK1SigBcast(
+	peers []p2p.Peer,
+	nodeIdx cluster.NodeIdx,
+	bcastComp broadcaster,
+	msgID string,
 ) *nodeSigBcast {
 	ret := &nodeSigBcast{
+		msgID:      msgID,
 		sigs:       make([][]byte, len(peers)),
 		bcastFunc:  bcastComp.Broadcast,
 		peers:      peers,
@@ -57,9 +79,7 @@ func newNodeSigBcast(
 		lockHashCh: make(chan []byte),
 	}
 
-	for _, k1Sig := range nodeSigMsgIDs() {
-		bcastComp.RegisterMessageIDFuncs(k1Sig, ret.broadcastCallback, ret.checkMessage)
-	}
+	bcastComp.RegisterMessageIDFuncs(msgID, ret.broadcastCallback, ret.checkMessage)
 
 	return ret
 }
@@ -179,7 +199,7 @@ func (n *nodeSigBcast) exchange(
 
 	log.Debug(ctx, "Exchanging node signatures")
 
-	if err := n.bcastFunc(ctx, nodeSigMsgID, bcastData); err != nil {
+	if err := n.bcastFunc(ctx, n.msgID, bcastData); err != nil {
 		return nil, errors.Wrap(err, "k1 lock hash signature broadcast")
 	}
 
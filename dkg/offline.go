@@ -0,0 +1,501 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/dkg/bcast"
+	dkgpb "github.com/obolnetwork/charon/dkg/dkgpb/v1"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const (
+	offlineFileExt     = ".pb"
+	offlinePollPeriod  = 500 * time.Millisecond
+	offlineParSigMsgID = "/charon/dkg/parsigex"
+)
+
+// broadcaster abstracts the broadcasting of DKG protocol messages to all peers.
+// It is implemented by bcast.Component for online and offlineExchange for offline ceremonies.
+type broadcaster interface {
+	Broadcast(ctx context.Context, msgID string, msg proto.Message) error
+	RegisterMessageIDFuncs(msgID string, callback bcast.Callback, checkMessage bcast.CheckMessage)
+}
+
+// offlineHandler handles a message received via offline message files.
+type offlineHandler struct {
+	callback     bcast.Callback
+	checkMessage bcast.CheckMessage
+}
+
+// offlineExchange exchanges DKG protocol messages between peers as files in a directory instead of via the P2P network.
+// Operators transfer the files written by their node to all other nodes' directories via any out-of-band channel.
+// All messages are signed by the sender's p2p key and messages targeted at a single peer are encrypted to its p2p key.
+type offlineExchange struct {
+	dir     string
+	defHash []byte
+	key     *k1.PrivateKey
+	peers   []p2p.Peer
+	selfIdx int
+
+	mu           sync.Mutex
+	seq          int
+	handlers     map[string]offlineHandler
+	done         map[string]bool
+	warned       map[string]bool
+	receivedFunc func(peer.ID)
+}
+
+// newOfflineExchange returns a new offline exchange writing and reading message files to and from dir.
+// It returns an error if dir already contains messages from this node, since those are from a previous ceremony.
+func newOfflineExchange(dir string, defHash []byte, key *k1.PrivateKey, peers []p2p.Peer, selfIdx int) (*offlineExchange, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "create offline dkg directory")
+	}
+
+	existing, err := filepath.Glob(filepath.Join(dir, offlineFilePrefix(selfIdx)+"*"))
+	if err != nil {
+		return nil, errors.Wrap(err, "glob offline dkg directory")
+	} else if len(existing) > 0 {
+		return nil, errors.New("offline dkg directory contains messages from a previous ceremony, please use an empty directory",
+			z.Str("dir", dir))
+	}
+
+	return &offlineExchange{
+		dir:      dir,
+		defHash:  defHash,
+		key:      key,
+		peers:    peers,
+		selfIdx:  selfIdx,
+		handlers: make(map[string]offlineHandler),
+		done:     make(map[string]bool),
+		warned:   make(map[string]bool),
+	}, nil
+}
+
+// RegisterMessageIDFuncs registers the callback and message check functions for the provided message ID.
+// Registering must be done before calling Run.
+func (e *offlineExchange) RegisterMessageIDFuncs(msgID string, callback bcast.Callback, checkMessage bcast.CheckMessage) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.handlers[msgID] = offlineHandler{
+		callback:     callback,
+		checkMessage: checkMessage,
+	}
+}
+
+// SetReceivedFunc sets a function called with the source peer of each received message.
+// It must be called before calling Run.
+func (e *offlineExchange) SetReceivedFunc(fn func(peer.ID)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.receivedFunc = fn
+}
+
+// Broadcast writes a message file for all peers.
+func (e *offlineExchange) Broadcast(ctx context.Context, msgID string, msg proto.Message) error {
+	return e.write(ctx, msgID, 0, msg)
+}
+
+// Send writes a message file for the peer with the provided ID, encrypted to its p2p key.
+func (e *offlineExchange) Send(ctx context.Context, msgID string, pID peer.ID, msg proto.Message) error {
+	for _, p := range e.peers {
+		if p.ID == pID {
+			return e.write(ctx, msgID, p.ShareIdx(), msg)
+		}
+	}
+
+	return errors.New("unknown peer", z.Str("peer", p2p.PeerName(pID)))
+}
+
+// write marshals, signs and writes a message file to the directory.
+func (e *offlineExchange) write(ctx context.Context, msgID string, targetShareIdx int, msg proto.Message) error {
+	anyMsg, err := anypb.New(msg)
+	if err != nil {
+		return errors.Wrap(err, "new any")
+	}
+
+	payload, err := proto.Marshal(anyMsg)
+	if err != nil {
+		return errors.Wrap(err, "marshal any")
+	}
+
+	if targetShareIdx != 0 {
+		pubkey, err := e.peers[targetShareIdx-1].PublicKey()
+		if err != nil {
+			return err
+		}
+
+		payload, err = encryptK1(pubkey, payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	offlineMsg := &dkgpb.OfflineMsg{
+		DefinitionHash: e.defHash,
+		MsgId:          msgID,
+		SourceShareIdx: uint32(e.selfIdx + 1),
+		TargetShareIdx: uint32(targetShareIdx),
+		Payload:        payload,
+	}
+
+	offlineMsg.Signature, err = k1util.Sign(e.key, hashOfflineMsg(offlineMsg))
+	if err != nil {
+		return err
+	}
+
+	b, err := proto.Marshal(offlineMsg)
+	if err != nil {
+		return errors.Wrap(err, "marshal offline message")
+	}
+
+	e.mu.Lock()
+	e.seq++
+	filename := fmt.Sprintf("%s%03d%s", offlineFilePrefix(e.selfIdx), e.seq, offlineFileExt)
+	e.done[filename] = true
+	e.mu.Unlock()
+
+	// Write atomically so partially written files are never read.
+	tmpFile := filepath.Join(e.dir, filename+".tmp")
+	if err := os.WriteFile(tmpFile, b, 0o444); err != nil {
+		return errors.Wrap(err, "write offline message")
+	}
+
+	if err := os.Rename(tmpFile, filepath.Join(e.dir, filename)); err != nil {
+		return errors.Wrap(err, "rename offline message")
+	}
+
+	log.Info(ctx, "Wrote offline DKG message file, copy it to all other operators' offline directories",
+		z.Str("file", filepath.Join(e.dir, filename)))
+
+	return nil
+}
+
+// Run polls the directory for message files from peers until the context is closed.
+func (e *offlineExchange) Run(ctx context.Context) {
+	ticker := time.NewTicker(offlinePollPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.poll(ctx)
+		}
+	}
+}
+
+// poll processes all new message files in the directory.
+func (e *offlineExchange) poll(ctx context.Context) {
+	entries, err := os.ReadDir(e.dir)
+	if err != nil {
+		log.Warn(ctx, "Failed reading offline dkg directory", err, z.Str("dir", e.dir))
+		return
+	}
+
+	for _, entry := range entries {
+		filename := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(filename, offlineFileExt) || e.isDone(filename) {
+			continue
+		}
+
+		retry, err := e.process(ctx, filename)
+		if err != nil && retry {
+			// The file may still be in the process of being copied, so retry, but only warn once.
+			if !e.warned[filename] {
+				log.Warn(ctx, "Failed processing offline dkg message file, will retry", err, z.Str("file", filename))
+			}
+			e.warned[filename] = true
+
+			continue
+		} else if err != nil {
+			log.Warn(ctx, "Ignoring invalid offline dkg message file", err, z.Str("file", filename))
+		}
+
+		e.setDone(filename)
+	}
+}
+
+// process reads, verifies and handles a message file. It returns true if processing the file should be retried.
+func (e *offlineExchange) process(ctx context.Context, filename string) (bool, error) {
+	b, err := os.ReadFile(filepath.Join(e.dir, filename))
+	if err != nil {
+		return true, errors.Wrap(err, "read offline message")
+	}
+
+	msg := new(dkgpb.OfflineMsg)
+	if err := proto.Unmarshal(b, msg); err != nil {
+		return true, errors.Wrap(err, "unmarshal offline message")
+	}
+
+	if !bytes.Equal(msg.GetDefinitionHash(), e.defHash) {
+		return false, errors.New("offline message from a different cluster definition")
+	}
+
+	sourceIdx := int(msg.GetSourceShareIdx()) - 1
+	if sourceIdx < 0 || sourceIdx >= len(e.peers) {
+		return false, errors.New("invalid offline message source", z.U64("source_share_idx", uint64(msg.GetSourceShareIdx())))
+	} else if sourceIdx == e.selfIdx {
+		return false, nil // Own message
+	}
+
+	source := e.peers[sourceIdx]
+
+	pubkey, err := source.PublicKey()
+	if err != nil {
+		return false, err
+	}
+
+	if ok, err := k1util.Verify65(pubkey, hashOfflineMsg(msg), msg.GetSignature()); err != nil {
+		return true, err
+	} else if !ok {
+		return true, errors.New("invalid offline message signature", z.Str("peer", source.Name))
+	}
+
+	payload := msg.GetPayload()
+	switch int(msg.GetTargetShareIdx()) {
+	case 0: // Broadcast
+	case e.selfIdx + 1:
+		payload, err = decryptK1(e.key, payload)
+		if err != nil {
+			return false, err
+		}
+	default:
+		return false, nil // Message for another peer
+	}
+
+	anyMsg := new(anypb.Any)
+	if err := proto.Unmarshal(payload, anyMsg); err != nil {
+		return false, errors.Wrap(err, "unmarshal offline message payload")
+	}
+
+	e.mu.Lock()
+	handler, ok := e.handlers[msg.GetMsgId()]
+	receivedFunc := e.receivedFunc
+	e.mu.Unlock()
+	if !ok {
+		return false, errors.New("unknown offline message id", z.Str("msg_id", msg.GetMsgId()))
+	}
+
+	if err := handler.checkMessage(ctx, source.ID, anyMsg); err != nil {
+		return false, err
+	}
+
+	inner, err := anyMsg.UnmarshalNew()
+	if err != nil {
+		return false, errors.Wrap(err, "unmarshal offline message any")
+	}
+
+	log.Debug(ctx, "Received offline dkg message", z.Str("peer", source.Name), z.Str("msg_id", msg.GetMsgId()))
+
+	if receivedFunc != nil {
+		receivedFunc(source.ID)
+	}
+
+	return false, handler.callback(ctx, source.ID, msg.GetMsgId(), inner)
+}
+
+func (e *offlineExchange) isDone(filename string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.done[filename]
+}
+
+func (e *offlineExchange) setDone(filename string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.done[filename] = true
+}
+
+// offlineFilePrefix returns the message file name prefix of the peer with the provided index.
+func offlineFilePrefix(peerIdx int) string {
+	return fmt.Sprintf("node%d-", peerIdx)
+}
+
+// hashOfflineMsg returns the hash of the offline message fields excluding the signature.
+func hashOfflineMsg(msg *dkgpb.OfflineMsg) []byte {
+	h := sha256.New()
+	_, _ = h.Write(msg.GetDefinitionHash())
+	_ = binary.Write(h, binary.BigEndian, uint32(len(msg.GetMsgId())))
+	_, _ = h.Write([]byte(msg.GetMsgId()))
+	_ = binary.Write(h, binary.BigEndian, msg.GetSourceShareIdx())
+	_ = binary.Write(h, binary.BigEndian, msg.GetTargetShareIdx())
+	_, _ = h.Write(msg.GetPayload())
+
+	return h.Sum(nil)
+}
+
+// encryptK1 encrypts the plaintext to the secp256k1 public key using ECIES with an ephemeral key and AES-GCM.
+// The ciphertext is the ephemeral compressed public key followed by the nonce and the sealed plaintext.
+func encryptK1(pubkey *k1.PublicKey, plaintext []byte) ([]byte, error) {
+	ephemeral, err := k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate ephemeral key")
+	}
+
+	ephemeralPub := ephemeral.PubKey().SerializeCompressed()
+
+	gcm, err := newK1GCM(ephemeral, pubkey, ephemeralPub)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "generate nonce")
+	}
+
+	var resp []byte
+	resp = append(resp, ephemeralPub...)
+	resp = append(resp, nonce...)
+
+	return gcm.Seal(resp, nonce, plaintext, ephemeralPub), nil
+}
+
+// decryptK1 decrypts a ciphertext created by encryptK1 with the secp256k1 private key.
+func decryptK1(key *k1.PrivateKey, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < k1.PubKeyBytesLenCompressed {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	ephemeralPub := ciphertext[:k1.PubKeyBytesLenCompressed]
+	pubkey, err := k1.ParsePubKey(ephemeralPub)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse ephemeral key")
+	}
+
+	gcm, err := newK1GCM(key, pubkey, ephemeralPub)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext = ciphertext[k1.PubKeyBytesLenCompressed:]
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	plaintext, err := gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], ephemeralPub)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypt")
+	}
+
+	return plaintext, nil
+}
+
+// newK1GCM returns an AES-GCM cipher keyed by the ECDH shared secret of the provided keys.
+func newK1GCM(key *k1.PrivateKey, pubkey *k1.PublicKey, ephemeralPub []byte) (cipher.AEAD, error) {
+	secret := sha256.Sum256(append(k1.GenerateSharedSecret(key, pubkey), ephemeralPub...))
+
+	block, err := aes.NewCipher(secret[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "new aes cipher")
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "new gcm")
+	}
+
+	return gcm, nil
+}
+
+// offlineParSigEx exchanges partial signatures via offline message files.
+// It implements the same interface as parsigex.ParSigEx.
+type offlineParSigEx struct {
+	bcastFunc bcast.BroadcastFunc
+	gaterFunc core.DutyGaterFunc
+	subs      []func(context.Context, core.Duty, core.ParSignedDataSet) error
+}
+
+// newOfflineParSigEx returns a new offlineParSigEx registering its handlers on the offline exchange.
+func newOfflineParSigEx(ex *offlineExchange, gaterFunc core.DutyGaterFunc) *offlineParSigEx {
+	resp := &offlineParSigEx{
+		bcastFunc: ex.Broadcast,
+		gaterFunc: gaterFunc,
+	}
+
+	ex.RegisterMessageIDFuncs(offlineParSigMsgID, resp.handle, func(_ context.Context, _ peer.ID, msgAny *anypb.Any) error {
+		var msg pbv1.ParSigExMsg
+		if err := msgAny.UnmarshalTo(&msg); err != nil {
+			return errors.Wrap(err, "parsigex message malformed")
+		}
+
+		return nil
+	})
+
+	return resp
+}
+
+func (m *offlineParSigEx) handle(ctx context.Context, _ peer.ID, _ string, msg proto.Message) error {
+	pb, ok := msg.(*pbv1.ParSigExMsg)
+	if !ok || pb.GetDuty() == nil || pb.GetDataSet() == nil {
+		return errors.New("invalid parsigex message")
+	}
+
+	duty := core.DutyFromProto(pb.GetDuty())
+	if !m.gaterFunc(duty) {
+		return errors.New("invalid duty")
+	}
+
+	set, err := core.ParSignedDataSetFromProto(duty.Type, pb.GetDataSet())
+	if err != nil {
+		return errors.Wrap(err, "convert parsigex proto")
+	}
+
+	for _, sub := range m.subs {
+		if err := sub(ctx, duty, set); err != nil {
+			log.Error(ctx, "Subscribe error", err)
+		}
+	}
+
+	return nil
+}
+
+// Broadcast writes the partially signed data set to a message file for all peers.
+func (m *offlineParSigEx) Broadcast(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+	pb, err := core.ParSignedDataSetToProto(set)
+	if err != nil {
+		return err
+	}
+
+	return m.bcastFunc(ctx, offlineParSigMsgID, &pbv1.ParSigExMsg{
+		Duty:    core.DutyToProto(duty),
+		DataSet: pb,
+	})
+}
+
+// Subscribe registers a callback when a partially signed data set is received from a peer.
+// This is not thread safe, it must be called before starting the offline exchange.
+func (m *offlineParSigEx) Subscribe(fn func(context.Context, core.Duty, core.ParSignedDataSet) error) {
+	m.subs = append(m.subs, fn)
+}
@@ -0,0 +1,118 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/cluster"
+	dkgpb "github.com/obolnetwork/charon/dkg/dkgpb/v1"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestEncryptK1(t *testing.T) {
+	key := testutil.GenerateInsecureK1Key(t, 0)
+	other := testutil.GenerateInsecureK1Key(t, 1)
+
+	plaintext := []byte("secret share")
+	ciphertext, err := encryptK1(key.PubKey(), plaintext)
+	require.NoError(t, err)
+	require.NotContains(t, string(ciphertext), string(plaintext))
+
+	decrypted, err := decryptK1(key, ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+
+	_, err = decryptK1(other, ciphertext)
+	require.ErrorContains(t, err, "decrypt")
+
+	ciphertext[len(ciphertext)-1] ^= 0xff
+	_, err = decryptK1(key, ciphertext)
+	require.ErrorContains(t, err, "decrypt")
+}
+
+func TestOfflineExchange(t *testing.T) {
+	ctx := context.Background()
+
+	const msgID = "test"
+
+	lock, keys, _ := cluster.NewForT(t, 1, 3, 3, 0, rand.New(rand.NewSource(0)))
+	peers, err := lock.Peers()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+
+	var exchanges []*offlineExchange
+	for i := range peers {
+		ex, err := newOfflineExchange(dir, lock.DefinitionHash, keys[i], peers, i)
+		require.NoError(t, err)
+		exchanges = append(exchanges, ex)
+	}
+
+	received := make(map[int][]string)
+	for i, ex := range exchanges {
+		ex.RegisterMessageIDFuncs(msgID,
+			func(_ context.Context, pID peer.ID, _ string, msg proto.Message) error {
+				received[i] = append(received[i], pID.String()+":"+msg.(*dkgpb.MsgCheckpoint).String())
+				return nil
+			},
+			func(context.Context, peer.ID, *anypb.Any) error { return nil },
+		)
+	}
+
+	// Broadcast from node 0 and send to node 1 only.
+	require.NoError(t, exchanges[0].Broadcast(ctx, msgID, &dkgpb.MsgCheckpoint{Step: 1}))
+	require.NoError(t, exchanges[0].Send(ctx, msgID, peers[1].ID, &dkgpb.MsgCheckpoint{Step: 2}))
+
+	for _, ex := range exchanges {
+		ex.poll(ctx)
+	}
+
+	require.Empty(t, received[0])
+	require.Len(t, received[1], 2)
+	require.Len(t, received[2], 1)
+
+	// Files are only processed once.
+	exchanges[1].poll(ctx)
+	require.Len(t, received[1], 2)
+
+	// Restarting with messages from a previous ceremony fails.
+	_, err = newOfflineExchange(dir, lock.DefinitionHash, keys[0], peers, 0)
+	require.ErrorContains(t, err, "previous ceremony")
+
+	t.Run("tampered", func(t *testing.T) {
+		b, err := os.ReadFile(filepath.Join(dir, "node0-001.pb"))
+		require.NoError(t, err)
+
+		msg := new(dkgpb.OfflineMsg)
+		require.NoError(t, proto.Unmarshal(b, msg))
+		msg.SourceShareIdx = 2 // Impersonate node 1
+
+		b, err = proto.Marshal(msg)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "node1-999.pb"), b, 0o644))
+
+		retry, err := exchanges[2].process(ctx, "node1-999.pb")
+		require.True(t, retry)
+		require.ErrorContains(t, err, "invalid offline message signature")
+	})
+
+	t.Run("other cluster", func(t *testing.T) {
+		ex, err := newOfflineExchange(t.TempDir(), []byte("other definition"), keys[2], peers, 2)
+		require.NoError(t, err)
+		ex.dir = dir
+
+		retry, err := ex.process(ctx, "node0-001.pb")
+		require.False(t, retry)
+		require.ErrorContains(t, err, "different cluster definition")
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/dkg"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestOfflineDKG(t *testing.T) {
+	const (
+		nodes = 3
+		vals  = 2
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, keys, _ := cluster.NewForT(t, vals, nodes, nodes, seed, random)
+	def := lock.Definition
+	dir := t.TempDir()
+
+	var offlineDirs []string
+	for i := range nodes {
+		offlineDirs = append(offlineDirs, path.Join(dir, fmt.Sprintf("offline%d", i)))
+	}
+
+	// Simulate operators carrying message files between the nodes' offline directories.
+	go sneakernet(ctx, t, offlineDirs)
+
+	var eg errgroup.Group
+	for i := range nodes {
+		conf := dkg.Config{
+			DataDir:    path.Join(dir, fmt.Sprintf("node%d", i)),
+			OfflineDir: offlineDirs[i],
+			Log:        log.DefaultConfig(),
+			TestConfig: dkg.TestConfig{
+				Def:    &def,
+				P2PKey: keys[i],
+				StoreKeysFunc: func(secrets []tbls.PrivateKey, dir string) error {
+					return keystore.StoreKeysInsecure(secrets, dir, keystore.ConfirmInsecureKeys)
+				},
+			},
+		}
+		require.NoError(t, os.MkdirAll(conf.DataDir, 0o755))
+
+		eg.Go(func() error {
+			return dkg.Run(peerCtx(ctx, i), conf)
+		})
+	}
+
+	testutil.RequireNoError(t, eg.Wait())
+
+	verifyDKGResults(t, def, dir)
+
+	// Offline ceremonies don't persist checkpoints.
+	for i := range nodes {
+		_, err := os.Stat(path.Join(dir, fmt.Sprintf("node%d", i), "dkg-checkpoint.json"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	}
+}
+
+// sneakernet periodically copies all message files written by each node to all other nodes' directories.
+func sneakernet(ctx context.Context, t *testing.T, dirs []string) {
+	t.Helper()
+
+	for ctx.Err() == nil {
+		time.Sleep(100 * time.Millisecond)
+
+		for i, src := range dirs {
+			files, _ := filepath.Glob(filepath.Join(src, fmt.Sprintf("node%d-*.pb", i)))
+			for _, file := range files {
+				for j, dst := range dirs {
+					target := filepath.Join(dst, filepath.Base(file))
+					if i == j {
+						continue
+					} else if _, err := os.Stat(target); err == nil {
+						continue
+					}
+
+					b, err := os.ReadFile(file)
+					if err != nil {
+						continue
+					}
+
+					_ = os.WriteFile(target, b, 0o444)
+				}
+			}
+		}
+	}
+}
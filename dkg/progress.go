@@ -0,0 +1,269 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/dkg/bcast"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const progressRefreshPeriod = 500 * time.Millisecond
+
+// progressSteps are the names of the DKG ceremony steps in the order they are synchronised between peers.
+// Step 0 is connecting to all peers, the last step is done.
+var progressSteps = []string{
+	"connecting",
+	"key generation",
+	"deposit data",
+	"registrations",
+	"lock hash",
+	"node signatures",
+	"writing files",
+	"done",
+}
+
+// stepName returns the name of the DKG ceremony step.
+func stepName(step int) string {
+	if step < 0 || step >= len(progressSteps) {
+		return fmt.Sprintf("step %d", step)
+	}
+
+	return progressSteps[step]
+}
+
+// peerSyncStatus is the sync protocol status of a peer.
+type peerSyncStatus struct {
+	Connected bool
+	Step      int
+	HasStep   bool
+}
+
+// peerProgress is the data exchange progress of a peer.
+type peerProgress struct {
+	Received int
+	LastRecv time.Time
+}
+
+// progress tracks the DKG ceremony progress of this node and each peer's connection state,
+// ceremony step and received messages. It renders it as a live terminal display.
+type progress struct {
+	peers      []p2p.Peer
+	selfIdx    int
+	offlineDir string
+	nowFunc    func() time.Time
+
+	mu         sync.Mutex
+	start      time.Time
+	step       int
+	syncStatus func(peer.ID) peerSyncStatus
+	received   map[peer.ID]*peerProgress
+	footer     []string
+	lines      int // Number of lines rendered in the previous frame.
+}
+
+// newProgress returns a new progress tracker for the peers.
+func newProgress(peers []p2p.Peer, selfIdx int, offlineDir string) *progress {
+	received := make(map[peer.ID]*peerProgress)
+	for _, p := range peers {
+		received[p.ID] = new(peerProgress)
+	}
+
+	return &progress{
+		peers:      peers,
+		selfIdx:    selfIdx,
+		offlineDir: offlineDir,
+		nowFunc:    time.Now,
+		start:      time.Now(),
+		received:   received,
+	}
+}
+
+// setSyncStatus sets the function returning the sync protocol status of peers.
+func (p *progress) setSyncStatus(fn func(peer.ID) peerSyncStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.syncStatus = fn
+}
+
+// nextStep advances this node's current ceremony step.
+func (p *progress) nextStep() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.step++
+}
+
+// setFooter sets lines displayed below the peer table.
+func (p *progress) setFooter(lines ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.footer = lines
+}
+
+// receivedFrom records a message received from the peer.
+func (p *progress) receivedFrom(pID peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	prog, ok := p.received[pID]
+	if !ok {
+		return
+	}
+
+	prog.Received++
+	prog.LastRecv = p.nowFunc()
+}
+
+// receivedParSigs records partial signatures received from the peer identified by share index in the set.
+func (p *progress) receivedParSigs(_ context.Context, _ core.Duty, set core.ParSignedDataSet) error {
+	for _, psig := range set {
+		for _, node := range p.peers {
+			if node.ShareIdx() == psig.ShareIdx {
+				p.receivedFrom(node.ID)
+			}
+		}
+
+		break // All partial signatures in a set are from the same peer.
+	}
+
+	return nil
+}
+
+// wrapCallback returns a bcast callback that records received messages before calling the wrapped callback.
+func (p *progress) wrapCallback(callback bcast.Callback) bcast.Callback {
+	return func(ctx context.Context, pID peer.ID, msgID string, msg proto.Message) error {
+		p.receivedFrom(pID)
+		return callback(ctx, pID, msgID, msg)
+	}
+}
+
+// progressCaster wraps a broadcaster recording all messages received via it.
+type progressCaster struct {
+	broadcaster
+	prog *progress
+}
+
+// RegisterMessageIDFuncs registers the callback wrapped to record received messages.
+func (c progressCaster) RegisterMessageIDFuncs(msgID string, callback bcast.Callback, checkMessage bcast.CheckMessage) {
+	c.broadcaster.RegisterMessageIDFuncs(msgID, c.prog.wrapCallback(callback), checkMessage)
+}
+
+// Run renders the progress display to w until the context is cancelled, then renders a final frame.
+func (p *progress) Run(ctx context.Context, w io.Writer) {
+	ticker := time.NewTicker(progressRefreshPeriod)
+	defer ticker.Stop()
+
+	for {
+		p.redraw(w)
+
+		select {
+		case <-ctx.Done():
+			p.redraw(w)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// redraw clears the previous frame and renders the current one.
+func (p *progress) redraw(w io.Writer) {
+	frame := p.render()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.lines > 0 {
+		// Move cursor to the start of the previous frame and clear it.
+		_, _ = fmt.Fprintf(w, "\x1b[%dA\x1b[J", p.lines)
+	}
+
+	_, _ = io.WriteString(w, frame)
+	p.lines = strings.Count(frame, "\n")
+}
+
+// render returns the current progress display.
+func (p *progress) render() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.nowFunc()
+
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "DKG ceremony: %s (step %d/%d, elapsed %s)\n",
+		stepName(p.step), p.step, len(progressSteps)-1, now.Sub(p.start).Truncate(time.Second))
+	if p.offlineDir != "" {
+		_, _ = fmt.Fprintf(&buf, "Offline ceremony: copy all message files written by this node in %s to all other operators and theirs to it\n", p.offlineDir)
+	}
+
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PEER\tCONNECTION\tSTEP\tRECEIVED\tLAST MESSAGE")
+
+	for i, node := range p.peers {
+		if i == p.selfIdx {
+			_, _ = fmt.Fprintf(tw, "%s (you)\t-\t%s\t-\t-\n", node.Name, stepName(p.step))
+			continue
+		}
+
+		connection, step := "connecting", "-"
+		if p.offlineDir != "" {
+			connection = "offline"
+		} else if p.syncStatus != nil {
+			status := p.syncStatus(node.ID)
+			if status.Connected {
+				connection = "connected"
+			}
+
+			if status.HasStep {
+				step = stepName(status.Step)
+			}
+		}
+
+		recv := p.received[node.ID]
+		lastMsg := "-"
+		if !recv.LastRecv.IsZero() {
+			lastMsg = now.Sub(recv.LastRecv).Truncate(time.Second).String() + " ago"
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", node.Name, connection, step, recv.Received, lastMsg)
+	}
+
+	_ = tw.Flush()
+
+	for _, line := range p.footer {
+		_, _ = fmt.Fprintln(&buf, line)
+	}
+
+	return buf.String()
+}
+
+// startProgressDisplay renders the progress display to stderr until the returned stop function is called.
+func startProgressDisplay(ctx context.Context, prog *progress) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		prog.Run(ctx, os.Stderr)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/dkg/bcast"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestProgress(t *testing.T) {
+	var peers []p2p.Peer
+	for i, name := range []string{"alpha", "bravo", "charlie"} {
+		pID, err := p2p.PeerIDFromKey(testutil.GenerateInsecureK1Key(t, i).PubKey())
+		require.NoError(t, err)
+
+		peers = append(peers, p2p.Peer{ID: pID, Index: i, Name: name})
+	}
+
+	start := time.Unix(1700000000, 0)
+	now := start
+
+	prog := newProgress(peers, 0, "")
+	prog.start = start
+	prog.nowFunc = func() time.Time { return now }
+
+	require.Contains(t, prog.render(), "DKG ceremony: connecting (step 0/7, elapsed 0s)")
+	require.Contains(t, prog.render(), "bravo        connecting  -           0         -")
+	require.Contains(t, newProgress(peers, 0, "/offline").render(), "bravo        offline     -           0         -")
+
+	prog.setSyncStatus(func(pID peer.ID) peerSyncStatus {
+		if pID == peers[1].ID {
+			return peerSyncStatus{Connected: true, Step: 2, HasStep: true}
+		}
+
+		return peerSyncStatus{}
+	})
+	prog.nextStep()
+	prog.nextStep()
+
+	// Record a bcast message and a partial signature set from bravo.
+	var called bool
+	caster := progressCaster{broadcaster: &testCaster{}, prog: prog}
+	caster.RegisterMessageIDFuncs("test", func(context.Context, peer.ID, string, proto.Message) error {
+		called = true
+		return nil
+	}, nil)
+	require.NoError(t, caster.broadcaster.(*testCaster).callback(context.Background(), peers[1].ID, "test", nil))
+	require.True(t, called)
+
+	set := core.ParSignedDataSet{testutil.RandomCorePubKey(t): core.ParSignedData{ShareIdx: peers[1].ShareIdx()}}
+	require.NoError(t, prog.receivedParSigs(context.Background(), core.Duty{}, set))
+
+	now = start.Add(90 * time.Second)
+
+	require.Equal(t, `DKG ceremony: deposit data (step 2/7, elapsed 1m30s)
+PEER         CONNECTION  STEP          RECEIVED  LAST MESSAGE
+alpha (you)  -           deposit data  -         -
+bravo        connected   deposit data  2         1m30s ago
+charlie      connecting  -             0         -
+`, prog.render())
+
+	prog.setFooter("done")
+	require.Contains(t, prog.render(), "charlie      connecting  -             0         -\ndone\n")
+
+	// Subsequent frames clear the previous frame.
+	var buf bytes.Buffer
+	prog.redraw(&buf)
+	require.NotContains(t, buf.String(), "\x1b[")
+	buf.Reset()
+	prog.redraw(&buf)
+	require.Contains(t, buf.String(), "\x1b[6A\x1b[J")
+}
+
+// testCaster is a broadcaster that stores the registered callback.
+type testCaster struct {
+	callback bcast.Callback
+}
+
+func (*testCaster) Broadcast(context.Context, string, proto.Message) error {
+	return nil
+}
+
+func (c *testCaster) RegisterMessageIDFuncs(_ string, callback bcast.Callback, _ bcast.CheckMessage) {
+	c.callback = callback
+}
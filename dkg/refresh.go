@@ -0,0 +1,366 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/dkg/bcast"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// refreshCommand is the command locking the private key during key share refresh ceremonies.
+const refreshCommand = "charon alpha refresh"
+
+// RefreshConfig defines the config of a proactive key share refresh ceremony.
+type RefreshConfig struct {
+	// DataDir is the charon data directory containing the existing cluster lock, p2p key and validator keys.
+	DataDir string
+	// SharesDir is the directory containing the cluster lock and validator keys to refresh, it defaults to DataDir.
+	SharesDir string
+	// OutputDir is the directory the refreshed cluster lock and validator keys are written to.
+	OutputDir     string
+	NoVerify      bool
+	P2P           p2p.Config
+	Log           log.Config
+	ShutdownDelay time.Duration
+	Timeout       time.Duration
+	// Interval enables periodic refresh ceremonies every interval, see RunScheduledRefresh. Zero runs a single ceremony.
+	Interval time.Duration
+	// StopCommand is the shell command stopping the node before each periodic refresh ceremony.
+	StopCommand string
+	// StartCommand is the shell command starting the node after each periodic refresh ceremony.
+	StartCommand string
+	// TestnetConfig defines the custom network of the cluster, used to schedule periodic refresh ceremonies.
+	TestnetConfig eth2util.Network
+
+	TestConfig TestConfig
+}
+
+// RunRefresh executes a proactive key share refresh ceremony. All nodes in the cluster jointly re-randomise their
+// validator key shares without changing the distributed validator public keys. The refreshed key shares and cluster
+// lock are written to the output directory; the previous shares become useless when combined with refreshed shares.
+func RunRefresh(ctx context.Context, conf RefreshConfig) error {
+	ctx = log.WithTopic(ctx, "refresh")
+
+	unlock, err := lockPrivKey(ctx, conf.DataDir, refreshCommand)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return runRefresh(ctx, conf)
+}
+
+// runRefresh executes a proactive key share refresh ceremony, the caller must hold the private key lock.
+func runRefresh(ctx context.Context, conf RefreshConfig) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	version.LogInfo(ctx, "Charon key share refresh starting")
+
+	lock, err := loadRefreshLock(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	if lock.Threshold < 2 {
+		return errors.New("key share refresh requires a threshold of at least 2", z.Int("threshold", lock.Threshold))
+	}
+
+	if err := checkWrites(conf.OutputDir); err != nil {
+		return err
+	}
+
+	peers, err := lock.Peers()
+	if err != nil {
+		return err
+	}
+
+	key, pID, err := loadP2PKey(conf.DataDir, conf.TestConfig)
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Starting local P2P networking peer")
+
+	logPeerSummary(ctx, pID, peers, lock.Operators)
+
+	tcpNode, shutdown, err := setupP2P(ctx, key, Config{P2P: conf.P2P, TestConfig: conf.TestConfig}, peers, lock.DefinitionHash)
+	if err != nil {
+		return err
+	}
+	defer shutdown()
+
+	nodeIdx, err := lock.NodeIdx(tcpNode.ID())
+	if err != nil {
+		return errors.Wrap(err, "private key not matching cluster lock file")
+	}
+
+	shares, err := loadRefreshShares(conf.sharesDir(), lock, nodeIdx)
+	if err != nil {
+		return err
+	}
+
+	peerIDs, err := lock.PeerIDs()
+	if err != nil {
+		return errors.Wrap(err, "get peer IDs")
+	}
+
+	ex := newExchanger(tcpNode, nodeIdx.PeerIdx, peerIDs, lock.NumValidators, []sigType{sigLock}, conf.Timeout)
+
+	peerMap := make(map[peer.ID]cluster.NodeIdx)
+	for _, p := range peers {
+		nodeIdx, err := lock.NodeIdx(p.ID)
+		if err != nil {
+			return err
+		}
+		peerMap[p.ID] = nodeIdx
+	}
+
+	caster := bcast.New(tcpNode, peerIDs, key)
+
+	// register bcast callbacks for refreshp2p
+	tp := newRefreshP2P(tcpNode, peerMap, caster, lock.Threshold, lock.NumValidators)
+
+	// register bcast callbacks for lock hash k1 signature handler
+	nodeSigCaster := newNodeSigBcast(peers, nodeIdx, caster)
+
+	// Sync on the existing lock hash to ensure all peers refresh the same cluster.
+	ctx, nextStepSync, stopSync, err := syncPeers(ctx, tcpNode, key, lock.LockHash, peerIDs, cancel, nil, conf.TestConfig, "key share refresh")
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "All peers connected, starting key share refresh ceremony")
+
+	refreshed, err := runRefreshParallel(ctx, tp, shares, uint32(len(peerMap)), uint32(lock.Threshold), uint32(nodeIdx.ShareIdx))
+	if err != nil {
+		return err
+	}
+
+	// Refresh was step 1, advance to step 2
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	newLock, err := refreshedLock(lock, refreshed)
+	if err != nil {
+		return err
+	}
+
+	// Sign, exchange and aggregate Lock Hash signatures
+	newLock, err = signAndAggLock(ctx, refreshed, newLock, nodeIdx, ex)
+	if err != nil {
+		return err
+	}
+
+	log.Debug(ctx, "Aggregated lock hash signatures")
+	// Lock hash aggregate was step 2, advance to step 3
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	// Sign, exchange K1 signatures over Lock Hash
+	newLock.NodeSignatures, err = nodeSigCaster.exchange(ctx, key, newLock.LockHash)
+	if err != nil {
+		return errors.Wrap(err, "k1 lock hash signature exchange")
+	}
+
+	if !cluster.SupportNodeSignatures(newLock.Version) {
+		newLock.NodeSignatures = nil
+	}
+
+	log.Debug(ctx, "Exchanged node signatures")
+	// Node signatures was step 3, advance to step 4
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	if !conf.NoVerify {
+		if err := newLock.VerifySignatures(); err != nil {
+			return errors.Wrap(err, "invalid refreshed lock file")
+		}
+	}
+
+	// Write keystores and cluster lock files after all signatures have been exchanged
+	// to prevent partial data writes in case of peer connection lost.
+	if err := writeKeysToDisk(Config{DataDir: conf.OutputDir, TestConfig: conf.TestConfig}, refreshed); err != nil {
+		return err
+	}
+	log.Debug(ctx, "Saved refreshed keyshares to disk")
+
+	if err := writeLock(conf.OutputDir, newLock); err != nil {
+		return err
+	}
+	log.Debug(ctx, "Saved refreshed lock file to disk")
+
+	// Disk write was step 4, advance to step 5
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	if err := stopCeremony(ctx, stopSync, conf.ShutdownDelay, conf.TestConfig); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Successfully completed key share refresh ceremony 🎉",
+		z.Str("output_dir", conf.OutputDir),
+		z.Str("lock_hash", fmt.Sprintf("%#x", newLock.LockHash)),
+	)
+	log.Info(ctx, "All operators must replace their cluster-lock.json and validator_keys with the refreshed "+
+		"files at the same time and then securely delete the previous key shares. "+
+		"Refreshed and previous key shares cannot be used together.")
+
+	return nil
+}
+
+// sharesDir returns the directory containing the cluster lock and validator keys to refresh.
+func (c RefreshConfig) sharesDir() string {
+	if c.SharesDir != "" {
+		return c.SharesDir
+	}
+
+	return c.DataDir
+}
+
+// loadRefreshLock returns the existing cluster lock from the shares directory. It returns the test lock if configured.
+func loadRefreshLock(ctx context.Context, conf RefreshConfig) (cluster.Lock, error) {
+	if conf.TestConfig.Lock != nil {
+		return *conf.TestConfig.Lock, nil
+	}
+
+	lockFile := filepath.Join(conf.sharesDir(), "cluster-lock.json")
+
+	b, err := os.ReadFile(lockFile)
+	if err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "read cluster lock", z.Str("path", lockFile))
+	}
+
+	var lock cluster.Lock
+	if err := json.Unmarshal(b, &lock); err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "unmarshal cluster lock", z.Str("path", lockFile))
+	}
+
+	if err := lock.VerifyHashes(); err != nil && !conf.NoVerify {
+		return cluster.Lock{}, errors.Wrap(err, "cluster lock hash verification failed. Run with --no-verify to bypass verification at own risk")
+	} else if err != nil && conf.NoVerify {
+		log.Warn(ctx, "Ignoring failed cluster lock hash verification due to --no-verify flag", err)
+	}
+
+	if err := lock.VerifySignatures(); err != nil && !conf.NoVerify {
+		return cluster.Lock{}, errors.Wrap(err, "cluster lock signature verification failed. Run with --no-verify to bypass verification at own risk")
+	} else if err != nil && conf.NoVerify {
+		log.Warn(ctx, "Ignoring failed cluster lock signature verification due to --no-verify flag", err)
+	}
+
+	log.Info(ctx, "Cluster lock loaded from disk", z.Str("path", lockFile),
+		z.Str("lock_hash", fmt.Sprintf("%#x", lock.LockHash)))
+
+	return lock, nil
+}
+
+// loadRefreshShares returns this node's existing shares of all validators in the lock,
+// after verifying that the validator keys on disk match the public shares in the lock.
+func loadRefreshShares(dataDir string, lock cluster.Lock, nodeIdx cluster.NodeIdx) ([]share, error) {
+	keyFiles, err := keystore.LoadFilesUnordered(filepath.Join(dataDir, "validator_keys"))
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := keyFiles.SequencedKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secrets) != len(lock.Validators) {
+		return nil, errors.New("validator keys count doesn't match cluster lock",
+			z.Int("keys", len(secrets)), z.Int("validators", len(lock.Validators)))
+	}
+
+	var shares []share
+	for vIdx, val := range lock.Validators {
+		pubkey, err := val.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+
+		pubShares := make(map[int]tbls.PublicKey)
+		for peerIdx := range val.PubShares {
+			pubShare, err := val.PublicShare(peerIdx)
+			if err != nil {
+				return nil, err
+			}
+
+			pubShares[peerIdx+1] = pubShare // Share indexes are 1-indexed
+		}
+
+		pubShare, err := tbls.SecretToPublicKey(secrets[vIdx])
+		if err != nil {
+			return nil, err
+		}
+
+		if pubShare != pubShares[nodeIdx.ShareIdx] {
+			return nil, errors.New("validator key doesn't match cluster lock public share",
+				z.Int("validator_index", vIdx), z.Redact(z.Str("pubkey", val.PublicKeyHex())))
+		}
+
+		shares = append(shares, share{
+			PubKey:       pubkey,
+			SecretShare:  secrets[vIdx],
+			PublicShares: pubShares,
+		})
+	}
+
+	return shares, nil
+}
+
+// refreshedLock returns a copy of the lock with the public shares replaced by the refreshed public shares
+// and a recalculated lock hash. The aggregate and node signatures are cleared.
+func refreshedLock(lock cluster.Lock, shares []share) (cluster.Lock, error) {
+	if len(shares) != len(lock.Validators) {
+		return cluster.Lock{}, errors.New("refreshed shares count doesn't match cluster lock")
+	}
+
+	var vals []cluster.DistValidator
+	for vIdx, val := range lock.Validators {
+		if !bytes.Equal(val.PubKey, shares[vIdx].PubKey[:]) {
+			return cluster.Lock{}, errors.New("refreshed share public key mismatch", z.Int("validator_index", vIdx))
+		}
+
+		var pubShares [][]byte
+		for shareIdx := 1; shareIdx <= len(shares[vIdx].PublicShares); shareIdx++ {
+			pubShare, ok := shares[vIdx].PublicShares[shareIdx]
+			if !ok {
+				return cluster.Lock{}, errors.New("missing refreshed public share", z.Int("share_idx", shareIdx))
+			}
+
+			pubShares = append(pubShares, pubShare[:])
+		}
+
+		val.PubShares = pubShares
+		vals = append(vals, val)
+	}
+
+	lock.Validators = vals
+	lock.SignatureAggregate = nil
+	lock.NodeSignatures = nil
+
+	return lock.SetLockHash()
+}
@@ -0,0 +1,326 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/kryptology/pkg/core/curves"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestRefreshShares(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const (
+		nodes     = 4
+		threshold = 3
+		vals      = 2
+	)
+
+	secrets, nodeShares := splitTestShares(t, vals, nodes, threshold)
+
+	tp := &refreshMemTransport{nodes: nodes}
+
+	var (
+		eg        errgroup.Group
+		mu        sync.Mutex
+		refreshed = make(map[int][]share)
+	)
+	for i := range nodes {
+		eg.Go(func() error {
+			shares, err := runRefreshParallel(ctx, tp, nodeShares[i+1], nodes, threshold, uint32(i+1))
+			if err != nil {
+				cancel()
+				return err
+			}
+
+			mu.Lock()
+			refreshed[i+1] = shares
+			mu.Unlock()
+
+			return nil
+		})
+	}
+	require.NoError(t, eg.Wait())
+
+	for vIdx := range vals {
+		secretShares := make(map[int]tbls.PrivateKey)
+		for shareIdx := 1; shareIdx <= nodes; shareIdx++ {
+			oldShare := nodeShares[shareIdx][vIdx]
+			newShare := refreshed[shareIdx][vIdx]
+
+			require.Equal(t, oldShare.PubKey, newShare.PubKey)
+			require.NotEqual(t, oldShare.SecretShare, newShare.SecretShare)
+
+			pubShare, err := tbls.SecretToPublicKey(newShare.SecretShare)
+			require.NoError(t, err)
+
+			// All nodes agree on the refreshed public shares.
+			for _, other := range refreshed {
+				require.Equal(t, pubShare, other[vIdx].PublicShares[shareIdx])
+			}
+
+			secretShares[shareIdx] = newShare.SecretShare
+		}
+
+		recovered, err := tbls.RecoverSecret(secretShares, nodes, threshold)
+		require.NoError(t, err)
+		require.Equal(t, secrets[vIdx], recovered)
+
+		// Mixing refreshed and previous shares doesn't recover the secret.
+		mixed := map[int]tbls.PrivateKey{
+			1: nodeShares[1][vIdx].SecretShare,
+			2: secretShares[2],
+			3: secretShares[3],
+		}
+		recovered, err = tbls.RecoverSecret(mixed, nodes, threshold)
+		require.NoError(t, err)
+		require.NotEqual(t, secrets[vIdx], recovered)
+	}
+}
+
+func TestRefreshInvalidSubShare(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const (
+		nodes     = 3
+		threshold = 2
+		vals      = 1
+	)
+
+	_, nodeShares := splitTestShares(t, vals, nodes, threshold)
+
+	tp := &refreshMemTransport{
+		nodes: nodes,
+		tamper: func(key msgKey, subShare curves.Scalar) curves.Scalar {
+			if key.SourceID == 1 && key.TargetID == 2 {
+				return subShare.Add(curve.Scalar.One())
+			}
+
+			return subShare
+		},
+	}
+
+	errs := make(chan error, nodes)
+	var wg sync.WaitGroup
+	for i := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := runRefreshParallel(ctx, tp, nodeShares[i+1], nodes, threshold, uint32(i+1))
+			if err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var count int
+	for err := range errs {
+		require.ErrorContains(t, err, "invalid refresh sub-share")
+		count++
+	}
+	require.Equal(t, 1, count)
+}
+
+func TestRefreshThreshold(t *testing.T) {
+	_, nodeShares := splitTestShares(t, 1, 2, 2)
+
+	_, err := runRefreshParallel(context.Background(), &refreshMemTransport{nodes: 2}, nodeShares[1], 2, 1, 1)
+	require.ErrorContains(t, err, "threshold of at least 2")
+}
+
+// splitTestShares returns random validator secrets and each node's shares indexed by share index.
+func splitTestShares(t *testing.T, vals, nodes, threshold int) ([]tbls.PrivateKey, map[int][]share) {
+	t.Helper()
+
+	var secrets []tbls.PrivateKey
+	nodeShares := make(map[int][]share)
+	for range vals {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+
+		pubkey, err := tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+
+		secretShares, err := tbls.ThresholdSplit(secret, uint(nodes), uint(threshold))
+		require.NoError(t, err)
+
+		pubShares := make(map[int]tbls.PublicKey)
+		for shareIdx, secretShare := range secretShares {
+			pubShares[shareIdx], err = tbls.SecretToPublicKey(secretShare)
+			require.NoError(t, err)
+		}
+
+		for shareIdx, secretShare := range secretShares {
+			nodeShares[shareIdx] = append(nodeShares[shareIdx], share{
+				PubKey:       pubkey,
+				SecretShare:  secretShare,
+				PublicShares: pubShares,
+			})
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nodeShares
+}
+
+type refreshMemTransport struct {
+	mu     sync.Mutex
+	nodes  int
+	tamper func(msgKey, curves.Scalar) curves.Scalar
+
+	count  int
+	casts  map[msgKey][]curves.Point
+	shares map[uint32]map[msgKey]curves.Scalar
+}
+
+func (t *refreshMemTransport) Exchange(ctx context.Context, castOut map[msgKey][]curves.Point, p2pOut map[msgKey]curves.Scalar,
+) (map[msgKey][]curves.Point, map[msgKey]curves.Scalar, error) {
+	var sourceID uint32
+	for key := range castOut {
+		sourceID = key.SourceID
+	}
+
+	return t.exchange(ctx, sourceID, castOut, p2pOut)
+}
+
+// exchange returns all commitments and the sub-shares sent to the provided share index once all nodes called it.
+func (t *refreshMemTransport) exchange(ctx context.Context, shareIdx uint32, castOut map[msgKey][]curves.Point, p2pOut map[msgKey]curves.Scalar,
+) (map[msgKey][]curves.Point, map[msgKey]curves.Scalar, error) {
+	t.mu.Lock()
+
+	if t.count == 0 {
+		t.casts = make(map[msgKey][]curves.Point)
+		t.shares = make(map[uint32]map[msgKey]curves.Scalar)
+	}
+
+	for key, comms := range castOut {
+		t.casts[key] = comms
+	}
+
+	for key, subShare := range p2pOut {
+		shares, ok := t.shares[key.TargetID]
+		if !ok {
+			shares = make(map[msgKey]curves.Scalar)
+			t.shares[key.TargetID] = shares
+		}
+
+		if t.tamper != nil {
+			subShare = t.tamper(key, subShare)
+		}
+		shares[key] = subShare
+	}
+
+	t.count++
+	t.mu.Unlock()
+
+	// Wait for all calls to come in, then return shared result.
+	for {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		t.mu.Lock()
+		if t.count == t.nodes {
+			t.mu.Unlock()
+			return t.casts, t.shares[shareIdx], nil
+		}
+		t.mu.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestNextRefreshEpoch(t *testing.T) {
+	genesis := time.Unix(1606824023, 0)
+	epoch := 384 * time.Second
+
+	tests := []struct {
+		name      string
+		interval  time.Duration
+		now       time.Time
+		wantEpoch uint64
+	}{
+		{"before genesis", time.Hour, genesis.Add(-time.Hour), 10},
+		{"sub epoch interval", time.Second, genesis.Add(epoch / 2), 1},
+		{"rounds up interval", time.Hour, genesis.Add(epoch), 10},
+		{"on boundary", epoch * 10, genesis.Add(epoch * 10), 20},
+		{"within interval", epoch * 10, genesis.Add(epoch*25 + time.Second), 30},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next, start := nextRefreshEpoch(genesis, epoch, test.interval, test.now)
+			require.Equal(t, test.wantEpoch, next)
+			require.Equal(t, genesis.Add(time.Duration(next)*epoch), start)
+			require.True(t, start.After(test.now))
+		})
+	}
+}
+
+func TestRefreshGenesis(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 1, 3, 4, 0, rand.New(rand.NewSource(0)))
+
+	lock.ForkVersion = []byte{0x01, 0x01, 0x70, 0x00} // Holesky
+	genesis, epoch, err := refreshGenesis(lock, eth2util.Network{})
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(eth2util.Holesky.GenesisTimestamp, 0), genesis)
+	require.Equal(t, 384*time.Second, epoch)
+
+	lock.ForkVersion = []byte{0x10, 0x00, 0x00, 0x38}
+	_, _, err = refreshGenesis(lock, eth2util.Network{})
+	require.ErrorContains(t, err, "unknown cluster network")
+
+	testnet := eth2util.Network{
+		ChainID:               1337,
+		Name:                  "devnet",
+		GenesisForkVersionHex: "0x10000038",
+		GenesisTimestamp:      1700000000,
+	}
+	genesis, epoch, err = refreshGenesis(lock, testnet)
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(testnet.GenesisTimestamp, 0), genesis)
+	require.Equal(t, 384*time.Second, epoch)
+
+	testnet.GenesisForkVersionHex = "0x10000039"
+	_, _, err = refreshGenesis(lock, testnet)
+	require.ErrorContains(t, err, "testnet fork version doesn't match cluster lock")
+}
+
+func TestLinkCurrentRefresh(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, epochDir := range []string{"epoch-10", "epoch-20"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, epochDir), 0o755))
+		require.NoError(t, linkCurrentRefresh(dir, epochDir))
+
+		target, err := os.Readlink(filepath.Join(dir, currentRefreshDir))
+		require.NoError(t, err)
+		require.Equal(t, epochDir, target)
+	}
+}
+
+func TestSecureDeleteDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "epoch-10")
+	keysDir := filepath.Join(dir, "validator_keys")
+	require.NoError(t, os.MkdirAll(keysDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cluster-lock.json"), []byte("{}"), 0o444))
+	require.NoError(t, os.WriteFile(filepath.Join(keysDir, "keystore-0.json"), []byte("secret"), 0o400))
+
+	require.NoError(t, secureDeleteDir(dir))
+	require.NoDirExists(t, dir)
+}
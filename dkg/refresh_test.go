@@ -0,0 +1,146 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/dkg"
+	dkgsync "github.com/obolnetwork/charon/dkg/sync"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRefresh(t *testing.T) {
+	const (
+		nodes     = 3
+		threshold = 2
+		vals      = 2
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, secretShares := cluster.NewForT(t, vals, threshold, nodes, seed, random)
+	require.NoError(t, lock.VerifySignatures())
+
+	dir := t.TempDir()
+	relayAddr := startRelay(ctx, t)
+
+	conf := dkg.RefreshConfig{
+		P2P: p2p.Config{
+			Relays: []string{relayAddr},
+		},
+		Log: log.DefaultConfig(),
+		TestConfig: dkg.TestConfig{
+			Lock: &lock,
+			StoreKeysFunc: func(secrets []tbls.PrivateKey, dir string) error {
+				return keystore.StoreKeysInsecure(secrets, dir, keystore.ConfirmInsecureKeys)
+			},
+			SyncOpts: []func(*dkgsync.Client){dkgsync.WithPeriod(time.Millisecond * 50)},
+		},
+		ShutdownDelay: 1 * time.Second,
+		Timeout:       8 * time.Second,
+	}
+
+	var eg errgroup.Group
+	for i := range nodes {
+		conf := conf
+		conf.DataDir = path.Join(dir, fmt.Sprintf("node%d", i))
+		conf.OutputDir = path.Join(conf.DataDir, "refreshed")
+		conf.P2P.TCPAddrs = []string{testutil.AvailableAddr(t).String()}
+
+		require.NoError(t, os.MkdirAll(conf.DataDir, 0o755))
+		require.NoError(t, k1util.Save(p2pKeys[i], p2p.KeyPath(conf.DataDir)))
+
+		var nodeSecrets []tbls.PrivateKey
+		for _, shares := range secretShares {
+			nodeSecrets = append(nodeSecrets, shares[i])
+		}
+		keysDir, err := cluster.CreateValidatorKeysDir(conf.DataDir)
+		require.NoError(t, err)
+		require.NoError(t, keystore.StoreKeysInsecure(nodeSecrets, keysDir, keystore.ConfirmInsecureKeys))
+
+		eg.Go(func() error {
+			err := dkg.RunRefresh(peerCtx(ctx, i), conf)
+			if err != nil {
+				cancel()
+			}
+
+			return err
+		})
+	}
+
+	err := eg.Wait()
+	testutil.SkipIfBindErr(t, err)
+	testutil.RequireNoError(t, err)
+
+	var (
+		refreshedLocks []cluster.Lock
+		refreshedKeys  [][]tbls.PrivateKey
+	)
+	for i := range nodes {
+		outputDir := path.Join(dir, fmt.Sprintf("node%d", i), "refreshed")
+
+		b, err := os.ReadFile(path.Join(outputDir, "cluster-lock.json"))
+		require.NoError(t, err)
+
+		var refreshedLock cluster.Lock
+		require.NoError(t, json.Unmarshal(b, &refreshedLock))
+		require.NoError(t, refreshedLock.VerifyHashes())
+		require.NoError(t, refreshedLock.VerifySignatures())
+		refreshedLocks = append(refreshedLocks, refreshedLock)
+
+		keyFiles, err := keystore.LoadFilesUnordered(path.Join(outputDir, "validator_keys"))
+		require.NoError(t, err)
+		keys, err := keyFiles.SequencedKeys()
+		require.NoError(t, err)
+		refreshedKeys = append(refreshedKeys, keys)
+	}
+
+	for i, refreshedLock := range refreshedLocks {
+		require.Equal(t, refreshedLocks[0].LockHash, refreshedLock.LockHash)
+
+		for vIdx, val := range refreshedLock.Validators {
+			require.Equal(t, lock.Validators[vIdx].PubKey, val.PubKey)
+			require.NotEqual(t, lock.Validators[vIdx].PubShares[i], val.PubShares[i])
+
+			pubShare, err := tbls.SecretToPublicKey(refreshedKeys[i][vIdx])
+			require.NoError(t, err)
+			require.Equal(t, val.PubShares[i], pubShare[:])
+		}
+	}
+
+	// The refreshed shares still recover the original validator private keys.
+	for vIdx := range vals {
+		oldShares := make(map[int]tbls.PrivateKey)
+		newShares := make(map[int]tbls.PrivateKey)
+		for i := range nodes {
+			oldShares[i+1] = secretShares[vIdx][i]
+			newShares[i+1] = refreshedKeys[i][vIdx]
+		}
+
+		oldSecret, err := tbls.RecoverSecret(oldShares, nodes, threshold)
+		require.NoError(t, err)
+		newSecret, err := tbls.RecoverSecret(newShares, nodes, threshold)
+		require.NoError(t, err)
+		require.Equal(t, oldSecret, newSecret)
+	}
+}
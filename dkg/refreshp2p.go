@@ -0,0 +1,338 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"path"
+	"sync"
+
+	"github.com/coinbase/kryptology/pkg/core/curves"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/dkg/bcast"
+	pb "github.com/obolnetwork/charon/dkg/dkgpb/v1"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+var (
+	refreshCastID = string(refreshProtocol("cast"))
+	refreshP2PID  = refreshProtocol("p2p")
+)
+
+// newRefreshP2P returns a p2p refresh transport implementation.
+// It registers bcast handlers on bcastComp.
+func newRefreshP2P(tcpNode host.Host, peers map[peer.ID]cluster.NodeIdx, bcastComp *bcast.Component, threshold, numVals int) *refreshP2P {
+	senders := make(map[peer.ID]bool)
+	for pID := range peers {
+		senders[pID] = true
+	}
+
+	return newSubShareP2P(tcpNode, peers, senders, bcastComp, threshold-1, numVals, refreshCastID, refreshP2PID)
+}
+
+// newSubShareP2P returns a p2p transport implementation of commitment broadcasts and sub-shares sent by the provided
+// senders to all peers using the provided bcast message ID and p2p protocol ID. It registers bcast handlers on bcastComp.
+func newSubShareP2P(tcpNode host.Host, peers map[peer.ID]cluster.NodeIdx, senders map[peer.ID]bool, bcastComp *bcast.Component,
+	numComms, numVals int, castID string, p2pID protocol.ID,
+) *refreshP2P {
+	var (
+		castsRecv = make(chan *pb.RefreshCasts, len(peers))
+		p2pRecv   = make(chan *pb.RefreshP2P, len(peers))
+	)
+
+	peersByShareIdx := make(map[uint32]peer.ID)
+	for pID, nodeIdx := range peers {
+		peersByShareIdx[uint32(nodeIdx.ShareIdx)] = pID
+	}
+
+	p2p.RegisterHandler("refresh", tcpNode, p2pID,
+		func() proto.Message { return new(pb.RefreshP2P) },
+		newRefreshP2PCallback(tcpNode, peers, senders, p2pRecv, numVals),
+	)
+
+	bcastComp.RegisterMessageIDFuncs(castID,
+		newRefreshBcastCallback(peers, senders, castsRecv, castID, numComms, numVals),
+		func(_ context.Context, _ peer.ID, msgAny *anypb.Any) error {
+			if err := msgAny.UnmarshalTo(new(pb.RefreshCasts)); err != nil {
+				return errors.Wrap(err, "refresh check message fail")
+			}
+
+			return nil
+		},
+	)
+
+	return &refreshP2P{
+		tcpNode:   tcpNode,
+		peers:     peersByShareIdx,
+		senders:   senders,
+		castID:    castID,
+		p2pID:     p2pID,
+		bcastFunc: bcastComp.Broadcast,
+		castsRecv: castsRecv,
+		p2pRecv:   p2pRecv,
+	}
+}
+
+// newRefreshBcastCallback returns a callback for refresh commitment broadcasts.
+func newRefreshBcastCallback(peers map[peer.ID]cluster.NodeIdx, senders map[peer.ID]bool, castsRecv chan *pb.RefreshCasts,
+	castID string, numComms, numVals int,
+) bcast.Callback {
+	var (
+		mu    sync.Mutex
+		dedup = make(map[peer.ID]bool)
+	)
+
+	return func(ctx context.Context, pID peer.ID, msgID string, m proto.Message) error {
+		if msgID != castID {
+			return errors.New("bug: unexpected invalid message ID")
+		} else if !senders[pID] {
+			return errors.New("unexpected refresh cast sender", z.Any("peer", p2p.PeerName(pID)))
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if dedup[pID] {
+			log.Debug(ctx, "Ignoring duplicate refresh cast message", z.Any("peer", p2p.PeerName(pID)))
+			return nil
+		}
+		dedup[pID] = true
+
+		msg, ok := m.(*pb.RefreshCasts)
+		if !ok {
+			return errors.New("invalid refresh casts message")
+		}
+
+		for _, cast := range msg.GetCasts() {
+			if int(cast.GetKey().GetSourceId()) != peers[pID].ShareIdx {
+				return errors.New("invalid refresh cast source ID")
+			} else if cast.GetKey().GetTargetId() != 0 {
+				return errors.New("invalid refresh cast target ID")
+			} else if int(cast.GetKey().GetValIdx()) < 0 || int(cast.GetKey().GetValIdx()) >= numVals {
+				return errors.New("invalid refresh cast validator index")
+			}
+
+			if len(cast.GetCommitments()) != numComms {
+				return errors.New("invalid amount of refresh commitments",
+					z.Int("received", len(cast.GetCommitments())),
+					z.Int("expected", numComms),
+				)
+			}
+		}
+
+		castsRecv <- msg
+
+		return nil
+	}
+}
+
+// newRefreshP2PCallback returns a callback for direct refresh sub-share messages.
+func newRefreshP2PCallback(tcpNode host.Host, peers map[peer.ID]cluster.NodeIdx, senders map[peer.ID]bool,
+	p2pRecv chan *pb.RefreshP2P, numVals int,
+) p2p.HandlerFunc {
+	var (
+		mu    sync.Mutex
+		dedup = make(map[peer.ID]bool)
+	)
+
+	return func(ctx context.Context, pID peer.ID, req proto.Message) (proto.Message, bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		msg, ok := req.(*pb.RefreshP2P)
+		if !ok {
+			return nil, false, errors.New("invalid refresh p2p message")
+		} else if !senders[pID] {
+			return nil, false, errors.New("unexpected refresh p2p sender")
+		}
+
+		for _, share := range msg.GetShares() {
+			if int(share.GetKey().GetSourceId()) != peers[pID].ShareIdx {
+				return nil, false, errors.New("invalid refresh p2p source ID")
+			} else if int(share.GetKey().GetTargetId()) != peers[tcpNode.ID()].ShareIdx {
+				return nil, false, errors.New("invalid refresh p2p target ID")
+			} else if int(share.GetKey().GetValIdx()) < 0 || int(share.GetKey().GetValIdx()) >= numVals {
+				return nil, false, errors.New("invalid refresh p2p validator index")
+			}
+		}
+
+		if dedup[pID] {
+			log.Debug(ctx, "Ignoring duplicate refresh p2p message", z.Any("peer", p2p.PeerName(pID)))
+			return nil, false, nil
+		}
+		dedup[pID] = true
+
+		p2pRecv <- msg
+
+		return nil, false, nil
+	}
+}
+
+// refreshP2P implements the refresh transport.
+type refreshP2P struct {
+	tcpNode   host.Host
+	peers     map[uint32]peer.ID // map[shareIdx]peerID
+	senders   map[peer.ID]bool   // Peers sending commitments and sub-shares.
+	castID    string
+	p2pID     protocol.ID
+	bcastFunc bcast.BroadcastFunc
+	castsRecv chan *pb.RefreshCasts
+	p2pRecv   chan *pb.RefreshP2P
+}
+
+// Exchange returns the received commitment broadcasts from all senders and the P2P sub-shares sent to this node.
+func (r *refreshP2P) Exchange(ctx context.Context, castOut map[msgKey][]curves.Point, p2pOut map[msgKey]curves.Scalar,
+) (map[msgKey][]curves.Point, map[msgKey]curves.Scalar, error) {
+	isSender := r.senders[r.tcpNode.ID()]
+	if !isSender && (len(castOut) > 0 || len(p2pOut) > 0) {
+		return nil, nil, errors.New("bug: unexpected refresh messages from non-sender")
+	}
+
+	if isSender {
+		// Build broadcast message
+		casts := new(pb.RefreshCasts)
+		for key, comms := range castOut {
+			casts.Casts = append(casts.Casts, refreshCastToProto(key, comms))
+		}
+		// Broadcast reliably to others
+		if err := r.bcastFunc(ctx, r.castID, casts); err != nil {
+			return nil, nil, err
+		}
+		r.castsRecv <- casts // Send to self
+	}
+
+	// Build P2P messages to send directly to peers.
+	p2pMsgs := make(map[peer.ID]*pb.RefreshP2P)
+	for key, subShare := range p2pOut {
+		pID, ok := r.peers[key.TargetID]
+		if !ok {
+			return nil, nil, errors.New("unknown target")
+		} else if pID == r.tcpNode.ID() {
+			return nil, nil, errors.New("bug: unexpected p2p message to self")
+		}
+
+		p2pMsg, ok := p2pMsgs[pID]
+		if !ok {
+			p2pMsg = new(pb.RefreshP2P)
+			p2pMsgs[pID] = p2pMsg
+		}
+		p2pMsg.Shares = append(p2pMsg.Shares, &pb.RefreshShare{
+			Key:   keyToProto(key),
+			Value: subShare.Bytes(),
+		})
+	}
+
+	for pID, p2pMsg := range p2pMsgs {
+		if err := p2p.Send(ctx, r.tcpNode, r.p2pID, pID, p2pMsg); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Wait for all incoming messages, sub-shares are received from all other senders.
+	expectP2P := len(r.senders)
+	if isSender {
+		expectP2P--
+	}
+
+	var (
+		castsRecvs []*pb.RefreshCasts
+		p2pRecvs   []*pb.RefreshP2P
+	)
+	for len(castsRecvs) != len(r.senders) || len(p2pRecvs) != expectP2P {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case msg := <-r.castsRecv:
+			castsRecvs = append(castsRecvs, msg)
+		case msg := <-r.p2pRecv:
+			p2pRecvs = append(p2pRecvs, msg)
+		}
+	}
+
+	return makeRefreshResponse(castsRecvs, p2pRecvs)
+}
+
+// makeRefreshResponse returns the refresh response from the list of received messages.
+func makeRefreshResponse(casts []*pb.RefreshCasts, p2ps []*pb.RefreshP2P) (map[msgKey][]curves.Point, map[msgKey]curves.Scalar, error) {
+	var (
+		castMap = make(map[msgKey][]curves.Point)
+		p2pMap  = make(map[msgKey]curves.Scalar)
+	)
+	for _, msg := range casts {
+		for _, castPB := range msg.GetCasts() {
+			key, comms, err := refreshCastFromProto(castPB)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			castMap[key] = comms
+		}
+	}
+
+	for _, msg := range p2ps {
+		for _, sharePB := range msg.GetShares() {
+			key, err := keyFromProto(sharePB.GetKey())
+			if err != nil {
+				return nil, nil, err
+			}
+
+			subShare, err := curve.Scalar.SetBytes(sharePB.GetValue())
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "decode refresh sub-share")
+			}
+
+			p2pMap[key] = subShare
+		}
+	}
+
+	return castMap, p2pMap, nil
+}
+
+func refreshCastToProto(key msgKey, comms []curves.Point) *pb.RefreshCast {
+	var commBytes [][]byte
+	for _, comm := range comms {
+		commBytes = append(commBytes, comm.ToAffineCompressed())
+	}
+
+	return &pb.RefreshCast{
+		Key:         keyToProto(key),
+		Commitments: commBytes,
+	}
+}
+
+func refreshCastFromProto(cast *pb.RefreshCast) (msgKey, []curves.Point, error) {
+	if cast == nil {
+		return msgKey{}, nil, errors.New("refresh cast cannot be nil")
+	}
+
+	var comms []curves.Point
+	for _, comm := range cast.GetCommitments() {
+		c, err := curve.Point.FromAffineCompressed(comm)
+		if err != nil {
+			return msgKey{}, nil, errors.Wrap(err, "decode commitment")
+		}
+
+		comms = append(comms, c)
+	}
+
+	key, err := keyFromProto(cast.GetKey())
+	if err != nil {
+		return msgKey{}, nil, err
+	}
+
+	return key, comms, nil
+}
+
+// refreshProtocol returns the refresh protocol ID including the provided suffixes.
+func refreshProtocol(suffix string) protocol.ID {
+	return protocol.ID(path.Join("/charon/dkg/refresh/1.0.0/", suffix))
+}
@@ -0,0 +1,238 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+const (
+	// currentRefreshDir is the output subdirectory linking to the latest refreshed cluster lock and validator keys.
+	currentRefreshDir = "current"
+	// refreshLockAttempts is the number of attempts to lock the private key after stopping the node,
+	// exceeding the time after which the lock of a crashed node becomes stale.
+	refreshLockAttempts = 10
+)
+
+// RunScheduledRefresh executes proactive key share refresh ceremonies every conf.Interval. Ceremonies start on the
+// first slot of epochs that are multiples of the interval (rounded up to whole epochs) since genesis, so all nodes
+// agree on the schedule without further coordination. It runs a single ceremony immediately if conf.Interval is zero.
+//
+// Since the ceremony uses the node's p2p key, the node is stopped with conf.StopCommand before each ceremony and
+// started with conf.StartCommand afterwards, so all nodes switch to the refreshed key shares at the same time.
+// Ceremonies are chained: each ceremony refreshes the output of the previous one. The output is written to an
+// "epoch-<epoch>" subdirectory of the output directory and linked as its "current" subdirectory, from which the
+// node and validator client must load the cluster lock and validator keys. Superseded refreshed key shares are
+// securely deleted, the key shares in the data directory are kept. A failed ceremony is logged and retried at the
+// next scheduled epoch.
+func RunScheduledRefresh(ctx context.Context, conf RefreshConfig) error {
+	if conf.Interval <= 0 {
+		return RunRefresh(ctx, conf)
+	}
+
+	ctx = log.WithTopic(ctx, "refresh")
+
+	lock, err := loadRefreshLock(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	genesis, epochDuration, err := refreshGenesis(lock, conf.TestnetConfig)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(conf.OutputDir, 0o755); err != nil {
+		return errors.Wrap(err, "create output dir")
+	}
+
+	for {
+		epoch, start := nextRefreshEpoch(genesis, epochDuration, conf.Interval, time.Now())
+
+		log.Info(ctx, "Next key share refresh ceremony scheduled",
+			z.U64("epoch", epoch), z.Str("start", start.UTC().Format(time.RFC3339)))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Until(start)):
+		}
+
+		if err := runScheduledRefresh(ctx, conf, epoch); ctx.Err() != nil {
+			return nil
+		} else if err != nil {
+			log.Error(ctx, "Scheduled key share refresh ceremony failed, retrying at next scheduled epoch", err,
+				z.U64("epoch", epoch))
+		}
+	}
+}
+
+// runScheduledRefresh stops the node and refreshes the current key shares into the epoch's output subdirectory.
+// It then links the refreshed key shares as the current key shares, securely deletes the superseded ones and
+// starts the node again, also if the ceremony failed.
+func runScheduledRefresh(ctx context.Context, conf RefreshConfig, epoch uint64) (err error) {
+	if err := runNodeCommand(ctx, "stop", conf.StopCommand); err != nil {
+		return err
+	}
+	defer func() {
+		// Start the node even if the scheduler is shutting down.
+		if startErr := runNodeCommand(context.WithoutCancel(ctx), "start", conf.StartCommand); err == nil {
+			err = startErr
+		}
+	}()
+
+	unlock, err := lockStoppedNodePrivKey(ctx, conf.DataDir)
+	if err != nil {
+		return err
+	}
+	defer unlock() // Unlock before starting the node.
+
+	roundConf := conf
+	roundConf.SharesDir = conf.DataDir
+	roundConf.OutputDir = filepath.Join(conf.OutputDir, fmt.Sprintf("epoch-%d", epoch))
+
+	prev, err := os.Readlink(filepath.Join(conf.OutputDir, currentRefreshDir))
+	if err == nil {
+		roundConf.SharesDir = filepath.Join(conf.OutputDir, prev)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "read current refreshed key shares link")
+	}
+
+	if err := runRefresh(ctx, roundConf); err != nil {
+		// Remove any partially written refreshed key shares.
+		if delErr := secureDeleteDir(roundConf.OutputDir); delErr != nil {
+			log.Warn(ctx, "Failed deleting partially refreshed key shares", delErr, z.Str("dir", roundConf.OutputDir))
+		}
+
+		return err
+	}
+
+	if err := linkCurrentRefresh(conf.OutputDir, filepath.Base(roundConf.OutputDir)); err != nil {
+		return err
+	}
+
+	if prev != "" {
+		if err := secureDeleteDir(roundConf.SharesDir); err != nil {
+			return errors.Wrap(err, "delete superseded key shares")
+		}
+
+		log.Info(ctx, "Securely deleted superseded key shares", z.Str("dir", roundConf.SharesDir))
+	}
+
+	return nil
+}
+
+// runNodeCommand runs the shell command stopping or starting the node, it does nothing if the command is empty.
+func runNodeCommand(ctx context.Context, action, command string) error {
+	if command == "" {
+		return nil
+	}
+
+	log.Info(ctx, "Running node "+action+" command", z.Str("command", command))
+
+	out, err := exec.CommandContext(ctx, "sh", "-c", command).CombinedOutput()
+	if err != nil {
+		return errors.Wrap(err, "run node "+action+" command", z.Str("output", string(out)))
+	}
+
+	return nil
+}
+
+// lockStoppedNodePrivKey locks the p2p private key, retrying until the stopped node released its lock
+// or its lock became stale.
+func lockStoppedNodePrivKey(ctx context.Context, dataDir string) (func(), error) {
+	for attempt := 1; ; attempt++ {
+		unlock, err := lockPrivKey(ctx, dataDir, refreshCommand)
+		if err == nil || attempt >= refreshLockAttempts {
+			return unlock, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// linkCurrentRefresh atomically links the current subdirectory of the output directory to the epoch subdirectory.
+func linkCurrentRefresh(outputDir, epochDir string) error {
+	tmp := filepath.Join(outputDir, currentRefreshDir+".tmp")
+	_ = os.Remove(tmp)
+
+	if err := os.Symlink(epochDir, tmp); err != nil {
+		return errors.Wrap(err, "create current refreshed key shares link")
+	}
+
+	if err := os.Rename(tmp, filepath.Join(outputDir, currentRefreshDir)); err != nil {
+		return errors.Wrap(err, "replace current refreshed key shares link")
+	}
+
+	return nil
+}
+
+// refreshGenesis returns the genesis time and epoch duration of the cluster's network. Custom networks are
+// defined by the testnet config and assumed to have 12 second slots and 32 slot epochs.
+func refreshGenesis(lock cluster.Lock, testnet eth2util.Network) (time.Time, time.Duration, error) {
+	if testnet.IsNonZero() {
+		if !strings.EqualFold(strings.TrimPrefix(testnet.GenesisForkVersionHex, "0x"), hex.EncodeToString(lock.ForkVersion)) {
+			return time.Time{}, 0, errors.New("testnet fork version doesn't match cluster lock",
+				z.Str("testnet_fork_version", testnet.GenesisForkVersionHex),
+				z.Str("lock_fork_version", fmt.Sprintf("%#x", lock.ForkVersion)))
+		}
+
+		return time.Unix(testnet.GenesisTimestamp, 0), networkEpochDuration(testnet.Name), nil
+	}
+
+	network, err := eth2util.ForkVersionToNetwork(lock.ForkVersion)
+	if err != nil {
+		return time.Time{}, 0, errors.Wrap(err, "unknown cluster network, configure the custom network with the testnet flags")
+	}
+
+	genesis, err := eth2util.NetworkToGenesisTime(network)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	return genesis, networkEpochDuration(network), nil
+}
+
+// nextRefreshEpoch returns the first epoch after now that is a multiple of the interval in epochs
+// (rounded up to at least one epoch), and the start time of that epoch.
+func nextRefreshEpoch(genesis time.Time, epochDuration, interval time.Duration, now time.Time) (uint64, time.Time) {
+	intervalEpochs := uint64((interval + epochDuration - 1) / epochDuration)
+	if intervalEpochs == 0 {
+		intervalEpochs = 1
+	}
+
+	var current uint64
+	if now.After(genesis) {
+		current = uint64(now.Sub(genesis) / epochDuration)
+	}
+
+	next := (current/intervalEpochs + 1) * intervalEpochs
+
+	return next, genesis.Add(time.Duration(next) * epochDuration)
+}
+
+// networkEpochDuration returns the epoch duration of the network.
+func networkEpochDuration(network string) time.Duration {
+	if network == eth2util.Gnosis.Name || network == eth2util.Chiado.Name {
+		return 16 * 5 * time.Second // 16 slots of 5 seconds.
+	}
+
+	return 32 * 12 * time.Second // 32 slots of 12 seconds.
+}
@@ -0,0 +1,253 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"crypto/rand"
+	"io"
+
+	"github.com/coinbase/kryptology/pkg/core/curves"
+	"github.com/coinbase/kryptology/pkg/sharing"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// rTransport abstracts the transport of proactive share refresh messages.
+type rTransport interface {
+	// Exchange returns the results of the refresh communication; the received commitment broadcasts from
+	// all nodes (including this node) and the P2P sub-shares sent to this node by all other nodes.
+	Exchange(context.Context, map[msgKey][]curves.Point, map[msgKey]curves.Scalar) (
+		map[msgKey][]curves.Point, map[msgKey]curves.Scalar, error)
+}
+
+// zeroPoly is a random polynomial of degree threshold-1 with a zero constant term.
+// Adding its evaluations to existing shares re-randomises the shares without changing the shared secret.
+type zeroPoly struct {
+	// coeffs are the coefficients a_1..a_{t-1}; the constant term a_0 is always zero.
+	coeffs []curves.Scalar
+}
+
+// newZeroPoly returns a new random zero polynomial for the provided threshold.
+func newZeroPoly(threshold uint32, random io.Reader) zeroPoly {
+	var coeffs []curves.Scalar
+	for range threshold - 1 {
+		coeffs = append(coeffs, curve.Scalar.Random(random))
+	}
+
+	return zeroPoly{coeffs: coeffs}
+}
+
+// Evaluate returns the polynomial evaluated at the provided share ID.
+func (p zeroPoly) Evaluate(id uint32) curves.Scalar {
+	x := curve.Scalar.New(int(id))
+	xi := x
+	resp := curve.Scalar.Zero()
+	for _, coeff := range p.coeffs {
+		resp = resp.Add(coeff.Mul(xi))
+		xi = xi.Mul(x)
+	}
+
+	return resp
+}
+
+// Commitments returns the Feldman commitments of the polynomial coefficients (excluding the zero constant term).
+func (p zeroPoly) Commitments() []curves.Point {
+	var resp []curves.Point
+	for _, coeff := range p.coeffs {
+		resp = append(resp, curve.ScalarBaseMult(coeff))
+	}
+
+	return resp
+}
+
+// evalCommitments returns the public point of the zero polynomial committed to by comms, evaluated at the provided share ID.
+func evalCommitments(comms []curves.Point, id uint32) curves.Point {
+	x := curve.Scalar.New(int(id))
+	xi := x
+	resp := curve.NewIdentityPoint()
+	for _, comm := range comms {
+		resp = resp.Add(comm.Mul(xi))
+		xi = xi.Mul(x)
+	}
+
+	return resp
+}
+
+// runRefreshParallel refreshes the key shares of all the provided distributed validators in parallel (sharing transport rounds)
+// and returns a list of new shares (one for each distributed validator). The group public keys remain unchanged.
+func runRefreshParallel(ctx context.Context, tp rTransport, shares []share, numNodes, threshold, shareIdx uint32) ([]share, error) {
+	if threshold < 2 {
+		return nil, errors.New("refresh requires a threshold of at least 2")
+	}
+
+	var (
+		castOut  = make(map[msgKey][]curves.Point)
+		p2pOut   = make(map[msgKey]curves.Scalar)
+		selfEval = make(map[uint32]curves.Scalar)
+	)
+	for vIdx := range uint32(len(shares)) {
+		poly := newZeroPoly(threshold, rand.Reader)
+
+		castOut[msgKey{ValIdx: vIdx, SourceID: shareIdx}] = poly.Commitments()
+
+		for targetID := uint32(1); targetID <= numNodes; targetID++ {
+			if targetID == shareIdx {
+				selfEval[vIdx] = poly.Evaluate(targetID)
+				continue
+			}
+
+			p2pOut[msgKey{ValIdx: vIdx, SourceID: shareIdx, TargetID: targetID}] = poly.Evaluate(targetID)
+		}
+	}
+
+	log.Debug(ctx, "Sending refresh messages")
+
+	castIn, p2pIn, err := tp.Exchange(ctx, castOut, p2pOut)
+	if err != nil {
+		return nil, errors.Wrap(err, "transport refresh")
+	}
+
+	log.Debug(ctx, "Received refresh results")
+
+	var resp []share
+	for vIdx, s := range shares {
+		refreshed, err := refreshShare(s, uint32(vIdx), selfEval[uint32(vIdx)], castIn, p2pIn, numNodes, threshold, shareIdx)
+		if err != nil {
+			return nil, errors.Wrap(err, "refresh share", z.Int("validator_index", vIdx))
+		}
+
+		resp = append(resp, refreshed)
+	}
+
+	return resp, nil
+}
+
+// refreshShare returns the refreshed share of the vIdx'th validator after verifying all received sub-shares
+// against their commitments and ensuring the group public key remains unchanged.
+func refreshShare(s share, vIdx uint32, selfEval curves.Scalar, castIn map[msgKey][]curves.Point,
+	p2pIn map[msgKey]curves.Scalar, numNodes, threshold, shareIdx uint32,
+) (share, error) {
+	secret, err := curve.Scalar.SetBytes(s.SecretShare[:])
+	if err != nil {
+		return share{}, errors.Wrap(err, "secret share to scalar")
+	}
+
+	secret = secret.Add(selfEval)
+
+	// Collect commitments by source ID.
+	comms := make(map[uint32][]curves.Point)
+	for sourceID := uint32(1); sourceID <= numNodes; sourceID++ {
+		c, ok := castIn[msgKey{ValIdx: vIdx, SourceID: sourceID}]
+		if !ok {
+			return share{}, errors.New("missing refresh commitments", z.Uint("source_id", uint(sourceID)))
+		} else if len(c) != int(threshold)-1 {
+			return share{}, errors.New("invalid amount of refresh commitments", z.Uint("source_id", uint(sourceID)),
+				z.Int("received", len(c)), z.Uint("expected", uint(threshold-1)))
+		}
+		comms[sourceID] = c
+	}
+
+	// Verify and add received sub-shares.
+	for sourceID := uint32(1); sourceID <= numNodes; sourceID++ {
+		if sourceID == shareIdx {
+			continue
+		}
+
+		subShare, ok := p2pIn[msgKey{ValIdx: vIdx, SourceID: sourceID, TargetID: shareIdx}]
+		if !ok {
+			return share{}, errors.New("missing refresh sub-share", z.Uint("source_id", uint(sourceID)))
+		}
+
+		if !curve.ScalarBaseMult(subShare).Equal(evalCommitments(comms[sourceID], shareIdx)) {
+			return share{}, errors.New("invalid refresh sub-share", z.Uint("source_id", uint(sourceID)))
+		}
+
+		secret = secret.Add(subShare)
+	}
+
+	// Calculate the new public shares of all nodes.
+	pubShares := make(map[int]tbls.PublicKey)
+	pubSharePoints := make(map[uint32]curves.Point)
+	for id := uint32(1); id <= numNodes; id++ {
+		oldPubShare, ok := s.PublicShares[int(id)]
+		if !ok {
+			return share{}, errors.New("missing public share", z.Uint("share_idx", uint(id)))
+		}
+
+		point, err := curve.Point.FromAffineCompressed(oldPubShare[:])
+		if err != nil {
+			return share{}, errors.Wrap(err, "public share to point")
+		}
+
+		for sourceID := uint32(1); sourceID <= numNodes; sourceID++ {
+			point = point.Add(evalCommitments(comms[sourceID], id))
+		}
+
+		pubShare, err := pointToPubKey(point)
+		if err != nil {
+			return share{}, err
+		}
+
+		pubShares[int(id)] = pubShare
+		pubSharePoints[id] = point
+	}
+
+	if !curve.ScalarBaseMult(secret).Equal(pubSharePoints[shareIdx]) {
+		return share{}, errors.New("refreshed secret share doesn't match refreshed public share")
+	}
+
+	if err := verifyGroupPubKey(s.PubKey, pubSharePoints, threshold); err != nil {
+		return share{}, err
+	}
+
+	secretShare, err := scalarToSecretShare(secret)
+	if err != nil {
+		return share{}, err
+	}
+
+	return share{
+		PubKey:       s.PubKey,
+		SecretShare:  secretShare,
+		PublicShares: pubShares,
+	}, nil
+}
+
+// verifyGroupPubKey returns an error if the group public key cannot be recovered
+// from the first threshold public shares via lagrange interpolation.
+func verifyGroupPubKey(pubkey tbls.PublicKey, pubShares map[uint32]curves.Point, threshold uint32) error {
+	shamir, err := sharing.NewShamir(threshold, uint32(len(pubShares)), curve)
+	if err != nil {
+		return errors.Wrap(err, "new shamir")
+	}
+
+	var ids []uint32
+	for id := uint32(1); id <= threshold; id++ {
+		ids = append(ids, id)
+	}
+
+	coeffs, err := shamir.LagrangeCoeffs(ids)
+	if err != nil {
+		return errors.Wrap(err, "lagrange coefficients")
+	}
+
+	recovered := curve.NewIdentityPoint()
+	for _, id := range ids {
+		recovered = recovered.Add(pubShares[id].Mul(coeffs[id]))
+	}
+
+	recoveredPubKey, err := pointToPubKey(recovered)
+	if err != nil {
+		return err
+	}
+
+	if recoveredPubKey != pubkey {
+		return errors.New("refreshed public shares do not match group public key",
+			z.Redact(z.Hex("pubkey", pubkey[:])))
+	}
+
+	return nil
+}
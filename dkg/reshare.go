@@ -0,0 +1,654 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/datadir"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/dkg/bcast"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+var (
+	reshareCastID     = string(reshareProtocol("cast"))
+	reshareP2PID      = reshareProtocol("p2p")
+	reshareApprovalID = string(reshareProtocol("approval"))
+)
+
+// ReshareConfig defines the config of a ceremony adding or removing a single cluster operator.
+// Exactly one of AddOperatorENR or RemoveOperatorENR must be set.
+type ReshareConfig struct {
+	// DataDir is the charon data directory containing the existing cluster lock or manifest, p2p key and
+	// validator keys. The data directory of an operator being added doesn't contain validator keys.
+	DataDir string
+	// OutputDir is the directory the new cluster manifest and validator keys are written to.
+	OutputDir string
+	// AddOperatorENR is the ENR of the operator to add to the cluster.
+	AddOperatorENR string
+	// RemoveOperatorENR is the ENR of the operator to remove from the cluster.
+	RemoveOperatorENR string
+	NoVerify          bool
+	P2P               p2p.Config
+	Log               log.Config
+	ShutdownDelay     time.Duration
+	Timeout           time.Duration
+
+	TestConfig TestConfig
+}
+
+// RunReshare executes a ceremony that adds or removes a single operator to or from the cluster. The existing operators
+// that remain in the cluster reshare their validator key shares to the new set of operators without changing the
+// distributed validator public keys. The change is recorded as a cluster manifest mutation approved by all remaining
+// existing operators. The new cluster manifest and key shares are written to the output directory.
+func RunReshare(ctx context.Context, conf ReshareConfig) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ctx = log.WithTopic(ctx, "reshare")
+
+	if (conf.AddOperatorENR == "") == (conf.RemoveOperatorENR == "") {
+		return errors.New("exactly one operator to add or remove required")
+	}
+
+	unlock, err := lockPrivKey(ctx, conf.DataDir, "charon alpha reshare")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	version.LogInfo(ctx, "Charon operator change starting")
+
+	dag, err := loadClusterDAG(ctx, conf.DataDir, conf.NoVerify, conf.TestConfig)
+	if err != nil {
+		return err
+	}
+
+	prevCluster, err := manifest.Materialise(dag)
+	if err != nil {
+		return errors.Wrap(err, "materialise cluster manifest")
+	}
+
+	newOps, err := changedOperators(prevCluster.GetOperators(), conf.AddOperatorENR, conf.RemoveOperatorENR)
+	if err != nil {
+		return err
+	}
+
+	newCluster := &manifestpb.Cluster{Operators: newOps}
+	newPeers, err := manifest.ClusterPeers(newCluster)
+	if err != nil {
+		return err
+	}
+
+	newThreshold := cluster.Threshold(len(newOps))
+
+	key, pID, err := loadP2PKey(conf.DataDir, conf.TestConfig)
+	if err != nil {
+		return err
+	}
+
+	nodeIdx, err := manifest.ClusterNodeIdx(newCluster, pID)
+	if err != nil {
+		return errors.Wrap(err, "private key not matching any remaining or added operator")
+	}
+
+	// Dealers are the existing operators remaining in the cluster, they reshare their existing key shares.
+	var (
+		dealers   = make(map[uint32]uint32) // map[newShareIdx]existingShareIdx
+		senders   = make(map[peer.ID]bool)
+		peerMap   = make(map[peer.ID]cluster.NodeIdx)
+		peerIDs   []peer.ID
+		prevIdx   cluster.NodeIdx
+		isDealer  bool
+		dealerIDs []peer.ID
+	)
+	for _, p := range newPeers {
+		peerIDs = append(peerIDs, p.ID)
+		peerMap[p.ID] = cluster.NodeIdx{PeerIdx: p.Index, ShareIdx: p.ShareIdx()}
+
+		existingIdx, err := manifest.ClusterNodeIdx(prevCluster, p.ID)
+		if err != nil {
+			continue // Added operator.
+		}
+
+		dealers[uint32(p.ShareIdx())] = uint32(existingIdx.ShareIdx)
+		senders[p.ID] = true
+		dealerIDs = append(dealerIDs, p.ID)
+
+		if p.ID == pID {
+			prevIdx = existingIdx
+			isDealer = true
+		}
+	}
+
+	if len(dealers) < int(prevCluster.GetThreshold()) {
+		return errors.New("insufficient remaining operators to reshare validator keys",
+			z.Int("remaining", len(dealers)), z.I64("threshold", int64(prevCluster.GetThreshold())))
+	}
+
+	if err := checkWrites(conf.OutputDir); err != nil {
+		return err
+	}
+
+	shares, err := loadClusterShares(conf.DataDir, prevCluster, prevIdx, isDealer)
+	if err != nil {
+		return err
+	}
+
+	mutationType := manifest.TypeAddOperator
+	if conf.RemoveOperatorENR != "" {
+		mutationType = manifest.TypeRemoveOperator
+	}
+
+	ceremonyHash, err := reshareCeremonyHash(prevCluster, mutationType, newOps)
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Starting local P2P networking peer",
+		z.Str("change", mutationType.String()),
+		z.Int("operators", len(newOps)),
+		z.Int("threshold", newThreshold),
+		z.Bool("existing_operator", isDealer),
+	)
+
+	tcpNode, shutdown, err := setupP2P(ctx, key, Config{P2P: conf.P2P, TestConfig: conf.TestConfig}, newPeers, ceremonyHash)
+	if err != nil {
+		return err
+	}
+	defer shutdown()
+
+	caster := bcast.New(tcpNode, peerIDs, key)
+
+	// register bcast callbacks for resharing
+	tp := newSubShareP2P(tcpNode, peerMap, senders, caster, newThreshold, len(prevCluster.GetValidators()), reshareCastID, reshareP2PID)
+
+	// register bcast callbacks for node approvals of the new cluster manifest
+	approvalCaster := newApprovalBcast(newPeers, dealerIDs, caster, reshareApprovalID)
+
+	// Sync on the ceremony hash to ensure all peers perform the same operator change.
+	ctx, nextStepSync, stopSync, err := syncPeers(ctx, tcpNode, key, ceremonyHash, peerIDs, cancel, nil, conf.TestConfig, "operator change")
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "All peers connected, starting key reshare ceremony")
+
+	reshared, err := runReshareParallel(ctx, tp, shares, dealers, uint32(len(newOps)), uint32(newThreshold), uint32(nodeIdx.ShareIdx))
+	if err != nil {
+		return err
+	}
+
+	// Reshare was step 1, advance to step 2
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	vals, err := reshareValidators(prevCluster.GetValidators(), reshared)
+	if err != nil {
+		return err
+	}
+
+	reshare, err := manifest.NewReshareOperators(prevCluster.GetLatestMutationHash(), newOps, newThreshold, vals)
+	if err != nil {
+		return err
+	}
+
+	reshareHash, err := manifest.Hash(reshare)
+	if err != nil {
+		return err
+	}
+
+	approvals, err := approvalCaster.exchange(ctx, key, reshareHash, isDealer)
+	if err != nil {
+		return errors.Wrap(err, "node approval exchange")
+	}
+
+	quorumApprovals, err := manifest.NewQuorumApprovalsComposite(approvals)
+	if err != nil {
+		return err
+	}
+
+	var change *manifestpb.SignedMutation
+	if mutationType == manifest.TypeAddOperator {
+		change, err = manifest.NewAddOperator(reshare, quorumApprovals)
+	} else {
+		change, err = manifest.NewRemoveOperator(reshare, quorumApprovals)
+	}
+	if err != nil {
+		return err
+	}
+
+	dag.Mutations = append(dag.Mutations, change)
+
+	if _, err := manifest.Materialise(dag); err != nil {
+		return errors.Wrap(err, "invalid new cluster manifest")
+	}
+
+	log.Debug(ctx, "Exchanged node approvals")
+	// Node approvals was step 2, advance to step 3
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	// Write keystores and cluster manifest after all approvals have been exchanged
+	// to prevent partial data writes in case of peer connection lost.
+	if err := writeKeysToDisk(Config{DataDir: conf.OutputDir, TestConfig: conf.TestConfig}, reshared); err != nil {
+		return err
+	}
+	log.Debug(ctx, "Saved reshared keyshares to disk")
+
+	if err := writeClusterManifest(conf.OutputDir, dag); err != nil {
+		return err
+	}
+	log.Debug(ctx, "Saved cluster manifest to disk")
+
+	// Disk write was step 3, advance to step 4
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	if err := stopCeremony(ctx, stopSync, conf.ShutdownDelay, conf.TestConfig); err != nil {
+		return err
+	}
+
+	changeHash, err := manifest.Hash(change)
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Successfully completed operator change ceremony 🎉",
+		z.Str("output_dir", conf.OutputDir),
+		z.Str("mutation_hash", fmt.Sprintf("%#x", changeHash)),
+	)
+	log.Info(ctx, "All remaining and added operators must replace their cluster files and validator_keys with the "+
+		"new files at the same time and then securely delete the previous key shares. "+
+		"New and previous key shares cannot be used together.")
+
+	return nil
+}
+
+// loadClusterDAG returns the existing cluster DAG from the cluster manifest or lock in the data directory.
+// It returns a DAG of the test lock if configured.
+func loadClusterDAG(ctx context.Context, dataDir string, noVerify bool, testConfig TestConfig) (*manifestpb.SignedMutationList, error) {
+	if testConfig.Lock != nil {
+		b, err := json.Marshal(testConfig.Lock)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal test lock")
+		}
+
+		legacy, err := manifest.NewRawLegacyLock(b)
+		if err != nil {
+			return nil, err
+		}
+
+		return &manifestpb.SignedMutationList{Mutations: []*manifestpb.SignedMutation{legacy}}, nil
+	}
+
+	verifyLock := func(lock cluster.Lock) error {
+		if err := lock.VerifyHashes(); err != nil && !noVerify {
+			return errors.Wrap(err, "cluster lock hash verification failed. Run with --no-verify to bypass verification at own risk")
+		} else if err != nil && noVerify {
+			log.Warn(ctx, "Ignoring failed cluster lock hash verification due to --no-verify flag", err)
+		}
+
+		if err := lock.VerifySignatures(); err != nil && !noVerify {
+			return errors.Wrap(err, "cluster lock signature verification failed. Run with --no-verify to bypass verification at own risk")
+		} else if err != nil && noVerify {
+			log.Warn(ctx, "Ignoring failed cluster lock signature verification due to --no-verify flag", err)
+		}
+
+		return nil
+	}
+
+	return manifest.LoadDAG(
+		filepath.Join(dataDir, "cluster-manifest.pb"),
+		filepath.Join(dataDir, "cluster-lock.json"),
+		verifyLock,
+	)
+}
+
+// changedOperators returns the new list of operators after adding or removing the operator identified by ENR.
+func changedOperators(operators []*manifestpb.Operator, addENR, removeENR string) ([]*manifestpb.Operator, error) {
+	if addENR != "" {
+		if _, err := enr.Parse(addENR); err != nil {
+			return nil, errors.Wrap(err, "invalid operator enr", z.Redact(z.Str("enr", addENR)))
+		}
+
+		for _, op := range operators {
+			if op.GetEnr() == addENR {
+				return nil, errors.New("operator already in cluster", z.Redact(z.Str("enr", addENR)))
+			}
+		}
+
+		resp := append([]*manifestpb.Operator(nil), operators...)
+
+		return append(resp, &manifestpb.Operator{Enr: addENR}), nil
+	}
+
+	var resp []*manifestpb.Operator
+	for _, op := range operators {
+		if op.GetEnr() != removeENR {
+			resp = append(resp, op)
+		}
+	}
+
+	if len(resp) == len(operators) {
+		return nil, errors.New("operator not in cluster", z.Redact(z.Str("enr", removeENR)))
+	}
+
+	return resp, nil
+}
+
+// reshareCeremonyHash returns a hash uniquely identifying the operator change of the cluster.
+func reshareCeremonyHash(c *manifestpb.Cluster, typ manifest.MutationType, operators []*manifestpb.Operator) ([]byte, error) {
+	h := sha256.New()
+	_, _ = h.Write(c.GetLatestMutationHash())
+	_, _ = h.Write([]byte(typ))
+
+	for _, op := range operators {
+		b, err := proto.Marshal(op)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal operator")
+		}
+		_, _ = h.Write(b)
+	}
+
+	return h.Sum(nil), nil
+}
+
+// loadClusterShares returns this node's existing shares of all validators in the cluster. If withSecrets is true,
+// the shares include the secret share loaded from the validator keys in the data directory after verifying that
+// they match the cluster's public shares. Otherwise, they only contain public keys.
+func loadClusterShares(dataDir string, c *manifestpb.Cluster, nodeIdx cluster.NodeIdx, withSecrets bool) ([]share, error) {
+	var secrets []tbls.PrivateKey
+	if withSecrets {
+		keyFiles, err := keystore.LoadFilesUnordered(filepath.Join(dataDir, "validator_keys"))
+		if err != nil {
+			return nil, err
+		}
+
+		secrets, err = keyFiles.SequencedKeys()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(secrets) != len(c.GetValidators()) {
+			return nil, errors.New("validator keys count doesn't match cluster",
+				z.Int("keys", len(secrets)), z.Int("validators", len(c.GetValidators())))
+		}
+	}
+
+	var shares []share
+	for vIdx, val := range c.GetValidators() {
+		pubkey, err := manifest.ValidatorPublicKey(val)
+		if err != nil {
+			return nil, err
+		}
+
+		pubShares := make(map[int]tbls.PublicKey)
+		for peerIdx := range val.GetPubShares() {
+			pubShare, err := manifest.ValidatorPublicShare(val, peerIdx)
+			if err != nil {
+				return nil, err
+			}
+
+			pubShares[peerIdx+1] = pubShare // Share indexes are 1-indexed
+		}
+
+		s := share{
+			PubKey:       pubkey,
+			PublicShares: pubShares,
+		}
+
+		if withSecrets {
+			pubShare, err := tbls.SecretToPublicKey(secrets[vIdx])
+			if err != nil {
+				return nil, err
+			}
+
+			if pubShare != pubShares[nodeIdx.ShareIdx] {
+				return nil, errors.New("validator key doesn't match cluster public share",
+					z.Int("validator_index", vIdx), z.Redact(z.Str("pubkey", manifest.ValidatorPublicKeyHex(val))))
+			}
+
+			s.SecretShare = secrets[vIdx]
+		}
+
+		shares = append(shares, s)
+	}
+
+	return shares, nil
+}
+
+// reshareValidators returns a copy of the validators with the public shares replaced by the reshared public shares.
+func reshareValidators(vals []*manifestpb.Validator, shares []share) ([]*manifestpb.Validator, error) {
+	if len(shares) != len(vals) {
+		return nil, errors.New("reshared shares count doesn't match cluster validators")
+	}
+
+	var resp []*manifestpb.Validator
+	for vIdx, val := range vals {
+		if !bytes.Equal(val.GetPublicKey(), shares[vIdx].PubKey[:]) {
+			return nil, errors.New("reshared share public key mismatch", z.Int("validator_index", vIdx))
+		}
+
+		var pubShares [][]byte
+		for shareIdx := 1; shareIdx <= len(shares[vIdx].PublicShares); shareIdx++ {
+			pubShare, ok := shares[vIdx].PublicShares[shareIdx]
+			if !ok {
+				return nil, errors.New("missing reshared public share", z.Int("share_idx", shareIdx))
+			}
+
+			pubShares = append(pubShares, pubShare[:])
+		}
+
+		val = proto.Clone(val).(*manifestpb.Validator)
+		val.PubShares = pubShares
+		resp = append(resp, val)
+	}
+
+	return resp, nil
+}
+
+// writeClusterManifest writes the cluster manifest to the output directory and stamps it with the current data dir
+// schema version. It also writes the legacy cluster lock if the manifest is based on one, since its cluster hash
+// still identifies the cluster.
+func writeClusterManifest(outputDir string, dag *manifestpb.SignedMutationList) error {
+	b, err := proto.Marshal(dag)
+	if err != nil {
+		return errors.Wrap(err, "marshal cluster manifest")
+	}
+
+	//nolint:gosec // File needs to be read-only for everybody
+	if err := os.WriteFile(filepath.Join(outputDir, "cluster-manifest.pb"), b, 0o444); err != nil {
+		return errors.Wrap(err, "write cluster manifest")
+	}
+
+	initial := dag.GetMutations()[0]
+	if manifest.MutationType(initial.GetMutation().GetType()) != manifest.TypeLegacyLock {
+		return datadir.Init(outputDir)
+	}
+
+	legacyLock := new(manifestpb.LegacyLock)
+	if err := initial.GetMutation().GetData().UnmarshalTo(legacyLock); err != nil {
+		return errors.Wrap(err, "unmarshal legacy lock")
+	}
+
+	var lock cluster.Lock
+	if err := json.Unmarshal(legacyLock.GetJson(), &lock); err != nil {
+		return errors.Wrap(err, "unmarshal cluster lock")
+	}
+
+	return writeLock(outputDir, lock)
+}
+
+// approvalBcast handles broadcasting of node approvals of the new cluster manifest via the bcast protocol.
+type approvalBcast struct {
+	msgID     string
+	bcastFunc bcast.BroadcastFunc
+	peers     map[peer.ID]p2p.Peer
+	approvers []peer.ID
+
+	mu        sync.Mutex
+	approvals map[peer.ID]*manifestpb.SignedMutation
+}
+
+// newApprovalBcast returns a new approval broadcaster collecting approvals from the approvers
+// using the provided bcast message ID. It registers bcast handlers on bcastComp.
+func newApprovalBcast(peers []p2p.Peer, approvers []peer.ID, bcastComp *bcast.Component, msgID string) *approvalBcast {
+	peerMap := make(map[peer.ID]p2p.Peer)
+	for _, p := range peers {
+		peerMap[p.ID] = p
+	}
+
+	ret := &approvalBcast{
+		msgID:     msgID,
+		bcastFunc: bcastComp.Broadcast,
+		peers:     peerMap,
+		approvers: approvers,
+		approvals: make(map[peer.ID]*manifestpb.SignedMutation),
+	}
+
+	bcastComp.RegisterMessageIDFuncs(msgID, ret.broadcastCallback, ret.checkMessage)
+
+	return ret
+}
+
+// broadcastCallback stores approvals received from approvers after verifying the signer.
+func (a *approvalBcast) broadcastCallback(_ context.Context, pID peer.ID, _ string, msg proto.Message) error {
+	approval, ok := msg.(*manifestpb.SignedMutation)
+	if !ok {
+		return errors.New("invalid node approval type")
+	}
+
+	var isApprover bool
+	for _, approver := range a.approvers {
+		isApprover = isApprover || approver == pID
+	}
+	if !isApprover {
+		return errors.New("unexpected node approval sender", z.Str("peer", p2p.PeerName(pID)))
+	}
+
+	pubkey, err := a.peers[pID].PublicKey()
+	if err != nil {
+		return errors.Wrap(err, "get peer public key")
+	}
+
+	if manifest.MutationType(approval.GetMutation().GetType()) != manifest.TypeNodeApproval {
+		return errors.New("invalid node approval mutation type")
+	} else if !bytes.Equal(pubkey.SerializeCompressed(), approval.GetSigner()) {
+		return errors.New("invalid node approval signer")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.approvals[pID] = approval
+
+	return nil
+}
+
+// checkMessage returns an error if the message isn't a signed mutation.
+func (*approvalBcast) checkMessage(_ context.Context, peerID peer.ID, msgAny *anypb.Any) error {
+	if err := msgAny.UnmarshalTo(new(manifestpb.SignedMutation)); err != nil {
+		return errors.Wrap(err, "node approval malformed", z.Str("peer_id", peerID.String()))
+	}
+
+	return nil
+}
+
+// exchange broadcasts this node's approval of the parent mutation if it is an approver and returns the approvals
+// of all approvers in order once received.
+func (a *approvalBcast) exchange(ctx context.Context, key *k1.PrivateKey, parent []byte, isApprover bool) ([]*manifestpb.SignedMutation, error) {
+	if isApprover {
+		approval, err := manifest.SignNodeApproval(parent, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := a.bcastFunc(ctx, a.msgID, approval); err != nil {
+			return nil, errors.Wrap(err, "node approval broadcast")
+		}
+
+		pID, err := p2p.PeerIDFromKey(key.PubKey())
+		if err != nil {
+			return nil, err
+		}
+
+		a.mu.Lock()
+		a.approvals[pID] = approval
+		a.mu.Unlock()
+	}
+
+	tick := time.NewTicker(100 * time.Millisecond)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-tick.C:
+			approvals, ok := a.allApprovals()
+			if !ok {
+				continue
+			}
+
+			for _, approval := range approvals {
+				if !bytes.Equal(parent, approval.GetMutation().GetParent()) {
+					return nil, errors.New("node approval of different cluster manifest")
+				}
+			}
+
+			return approvals, nil
+		}
+	}
+}
+
+// allApprovals returns the approvals of all approvers and true if all have been received.
+func (a *approvalBcast) allApprovals() ([]*manifestpb.SignedMutation, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var resp []*manifestpb.SignedMutation
+	for _, approver := range a.approvers {
+		approval, ok := a.approvals[approver]
+		if !ok {
+			return nil, false
+		}
+
+		resp = append(resp, approval)
+	}
+
+	return resp, true
+}
+
+// reshareProtocol returns the reshare protocol ID including the provided suffixes.
+func reshareProtocol(suffix string) protocol.ID {
+	return protocol.ID(path.Join("/charon/dkg/reshare/1.0.0/", suffix))
+}
@@ -0,0 +1,153 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/coinbase/kryptology/pkg/core/curves"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestReshareShares(t *testing.T) {
+	tests := []struct {
+		name         string
+		nodes        int
+		threshold    int
+		newThreshold int
+		// newToOld maps the new share index of each new node to its existing share index, zero for new nodes.
+		newToOld map[uint32]uint32
+	}{
+		{
+			name:         "add operator",
+			nodes:        4,
+			threshold:    3,
+			newThreshold: 4,
+			newToOld:     map[uint32]uint32{1: 1, 2: 2, 3: 3, 4: 4, 5: 0},
+		},
+		{
+			name:         "remove operator",
+			nodes:        4,
+			threshold:    3,
+			newThreshold: 2,
+			newToOld:     map[uint32]uint32{1: 1, 2: 3, 3: 4},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			const vals = 2
+
+			secrets, nodeShares := splitTestShares(t, vals, test.nodes, test.threshold)
+
+			dealers := make(map[uint32]uint32)
+			for newIdx, oldIdx := range test.newToOld {
+				if oldIdx != 0 {
+					dealers[newIdx] = oldIdx
+				}
+			}
+
+			newNodes := len(test.newToOld)
+			tp := &refreshMemTransport{nodes: newNodes}
+
+			var (
+				eg       errgroup.Group
+				mu       sync.Mutex
+				reshared = make(map[uint32][]share)
+			)
+			for newIdx, oldIdx := range test.newToOld {
+				shares := nodeShares[int(oldIdx)]
+				if oldIdx == 0 {
+					// New nodes only know the public shares.
+					for _, s := range nodeShares[1] {
+						shares = append(shares, share{PubKey: s.PubKey, PublicShares: s.PublicShares})
+					}
+				}
+
+				eg.Go(func() error {
+					resp, err := runReshareParallel(ctx, nodeTransport{mem: tp, shareIdx: newIdx}, shares, dealers,
+						uint32(newNodes), uint32(test.newThreshold), newIdx)
+					if err != nil {
+						cancel()
+						return err
+					}
+
+					mu.Lock()
+					reshared[newIdx] = resp
+					mu.Unlock()
+
+					return nil
+				})
+			}
+			require.NoError(t, eg.Wait())
+
+			for vIdx := range vals {
+				secretShares := make(map[int]tbls.PrivateKey)
+				for shareIdx := uint32(1); shareIdx <= uint32(newNodes); shareIdx++ {
+					newShare := reshared[shareIdx][vIdx]
+					require.Equal(t, nodeShares[1][vIdx].PubKey, newShare.PubKey)
+					require.Len(t, newShare.PublicShares, newNodes)
+
+					pubShare, err := tbls.SecretToPublicKey(newShare.SecretShare)
+					require.NoError(t, err)
+
+					// All nodes agree on the reshared public shares.
+					for _, other := range reshared {
+						require.Equal(t, pubShare, other[vIdx].PublicShares[int(shareIdx)])
+					}
+
+					secretShares[int(shareIdx)] = newShare.SecretShare
+				}
+
+				recovered, err := tbls.RecoverSecret(secretShares, uint(newNodes), uint(test.newThreshold))
+				require.NoError(t, err)
+				require.Equal(t, secrets[vIdx], recovered)
+			}
+		})
+	}
+}
+
+func TestReshareInvalidCommitment(t *testing.T) {
+	_, nodeShares := splitTestShares(t, 1, 3, 2)
+
+	// Dealer 1 claims to be dealer 2 by using its share index.
+	dealers := map[uint32]uint32{1: 2, 2: 2}
+	tp := &refreshMemTransport{nodes: 2}
+
+	errs := make(chan error, 2)
+	var wg sync.WaitGroup
+	for _, shareIdx := range []uint32{1, 2} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := runReshareParallel(context.Background(), nodeTransport{mem: tp, shareIdx: shareIdx},
+				nodeShares[int(shareIdx)], dealers, 2, 2, shareIdx)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.ErrorContains(t, err, "reshare commitment doesn't match existing public share")
+	}
+}
+
+// nodeTransport is a reshare transport of a single node using a shared in-memory transport.
+type nodeTransport struct {
+	mem      *refreshMemTransport
+	shareIdx uint32
+}
+
+func (t nodeTransport) Exchange(ctx context.Context, castOut map[msgKey][]curves.Point, p2pOut map[msgKey]curves.Scalar,
+) (map[msgKey][]curves.Point, map[msgKey]curves.Scalar, error) {
+	return t.mem.exchange(ctx, t.shareIdx, castOut, p2pOut)
+}
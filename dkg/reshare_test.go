@@ -0,0 +1,165 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/dkg"
+	dkgsync "github.com/obolnetwork/charon/dkg/sync"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestReshareAddOperator(t *testing.T) {
+	const (
+		nodes     = 3
+		threshold = 2
+		vals      = 2
+	)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, secretShares := cluster.NewForT(t, vals, threshold, nodes, seed, random)
+
+	newKey := testutil.GenerateInsecureK1Key(t, 100)
+	record, err := enr.New(newKey)
+	require.NoError(t, err)
+
+	keys := append(p2pKeys, newKey)
+	testReshare(t, lock, keys, secretShares, dkg.ReshareConfig{AddOperatorENR: record.String()})
+}
+
+func TestReshareRemoveOperator(t *testing.T) {
+	const (
+		nodes     = 4
+		threshold = 3
+		vals      = 2
+	)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, secretShares := cluster.NewForT(t, vals, threshold, nodes, seed, random)
+
+	// Remove the second operator, it doesn't participate.
+	keys := append([]*k1.PrivateKey{p2pKeys[0]}, p2pKeys[2:]...)
+	for i := range secretShares {
+		secretShares[i] = append([]tbls.PrivateKey{secretShares[i][0]}, secretShares[i][2:]...)
+	}
+
+	testReshare(t, lock, keys, secretShares, dkg.ReshareConfig{RemoveOperatorENR: lock.Operators[1].ENR})
+}
+
+// testReshare runs a reshare ceremony for the participating nodes identified by keys and verifies that the new
+// key shares recover the original validator keys. Nodes without secret shares are added operators.
+func testReshare(t *testing.T, lock cluster.Lock, keys []*k1.PrivateKey, secretShares [][]tbls.PrivateKey, conf dkg.ReshareConfig) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	relayAddr := startRelay(ctx, t)
+
+	conf.P2P = p2p.Config{Relays: []string{relayAddr}}
+	conf.Log = log.DefaultConfig()
+	conf.TestConfig = dkg.TestConfig{
+		Lock: &lock,
+		StoreKeysFunc: func(secrets []tbls.PrivateKey, dir string) error {
+			return keystore.StoreKeysInsecure(secrets, dir, keystore.ConfirmInsecureKeys)
+		},
+		SyncOpts: []func(*dkgsync.Client){dkgsync.WithPeriod(time.Millisecond * 50)},
+	}
+	conf.ShutdownDelay = 1 * time.Second
+	conf.Timeout = 8 * time.Second
+
+	var eg errgroup.Group
+	for i, key := range keys {
+		conf := conf
+		conf.DataDir = path.Join(dir, fmt.Sprintf("node%d", i))
+		conf.OutputDir = path.Join(conf.DataDir, "reshared")
+		conf.P2P.TCPAddrs = []string{testutil.AvailableAddr(t).String()}
+
+		require.NoError(t, os.MkdirAll(conf.DataDir, 0o755))
+		require.NoError(t, k1util.Save(key, p2p.KeyPath(conf.DataDir)))
+
+		if i < len(secretShares[0]) {
+			var nodeSecrets []tbls.PrivateKey
+			for _, shares := range secretShares {
+				nodeSecrets = append(nodeSecrets, shares[i])
+			}
+			keysDir, err := cluster.CreateValidatorKeysDir(conf.DataDir)
+			require.NoError(t, err)
+			require.NoError(t, keystore.StoreKeysInsecure(nodeSecrets, keysDir, keystore.ConfirmInsecureKeys))
+		}
+
+		eg.Go(func() error {
+			err := dkg.RunReshare(peerCtx(ctx, i), conf)
+			if err != nil {
+				cancel()
+			}
+
+			return err
+		})
+	}
+
+	err := eg.Wait()
+	testutil.SkipIfBindErr(t, err)
+	testutil.RequireNoError(t, err)
+
+	var newKeys [][]tbls.PrivateKey
+	for i := range keys {
+		outputDir := path.Join(dir, fmt.Sprintf("node%d", i), "reshared")
+
+		c, err := manifest.LoadCluster(path.Join(outputDir, "cluster-manifest.pb"), path.Join(outputDir, "cluster-lock.json"), nil)
+		require.NoError(t, err)
+		require.Len(t, c.GetOperators(), len(keys))
+		require.EqualValues(t, cluster.Threshold(len(keys)), c.GetThreshold())
+
+		keyFiles, err := keystore.LoadFilesUnordered(path.Join(outputDir, "validator_keys"))
+		require.NoError(t, err)
+		nodeKeys, err := keyFiles.SequencedKeys()
+		require.NoError(t, err)
+
+		for vIdx, val := range c.GetValidators() {
+			require.Equal(t, lock.Validators[vIdx].PubKey, val.GetPublicKey())
+
+			pubShare, err := tbls.SecretToPublicKey(nodeKeys[vIdx])
+			require.NoError(t, err)
+			require.Equal(t, val.GetPubShares()[i], pubShare[:])
+		}
+
+		newKeys = append(newKeys, nodeKeys)
+	}
+
+	// The new shares recover the original validator private keys.
+	for vIdx, val := range lock.Validators {
+		newShares := make(map[int]tbls.PrivateKey)
+		for i := range keys {
+			newShares[i+1] = newKeys[i][vIdx]
+		}
+		newSecret, err := tbls.RecoverSecret(newShares, uint(len(keys)), uint(cluster.Threshold(len(keys))))
+		require.NoError(t, err)
+
+		pubkey, err := tbls.SecretToPublicKey(newSecret)
+		require.NoError(t, err)
+		require.Equal(t, val.PubKey, pubkey[:])
+	}
+}
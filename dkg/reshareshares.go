@@ -0,0 +1,204 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"crypto/rand"
+	"sort"
+
+	"github.com/coinbase/kryptology/pkg/core/curves"
+	"github.com/coinbase/kryptology/pkg/sharing"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// evalFullCommitments returns the public point of the polynomial committed to by comms
+// (including the constant term) evaluated at the provided share ID.
+func evalFullCommitments(comms []curves.Point, id uint32) curves.Point {
+	return comms[0].Add(evalCommitments(comms[1:], id))
+}
+
+// runReshareParallel reshares the key shares of all the provided distributed validators from the dealers to
+// a new set of nodes in parallel (sharing transport rounds) and returns a list of new shares (one for each
+// distributed validator). The group public keys remain unchanged.
+//
+// The dealers map contains the new share index of each dealer mapped to its existing share index. Each dealer
+// shares its existing secret share with a new random polynomial of degree newThreshold-1, the new secret shares
+// are the lagrange interpolation of the received sub-shares at the dealers' existing share indexes.
+// The input shares of non-dealers only contain the group and existing public shares.
+func runReshareParallel(ctx context.Context, tp rTransport, shares []share, dealers map[uint32]uint32,
+	newNumNodes, newThreshold, shareIdx uint32,
+) ([]share, error) {
+	if newThreshold < 2 {
+		return nil, errors.New("reshare requires a new threshold of at least 2")
+	} else if len(dealers) < 2 {
+		return nil, errors.New("reshare requires at least 2 dealers")
+	}
+
+	_, isDealer := dealers[shareIdx]
+
+	var (
+		castOut  = make(map[msgKey][]curves.Point)
+		p2pOut   = make(map[msgKey]curves.Scalar)
+		selfEval = make(map[uint32]curves.Scalar)
+	)
+	for vIdx := range uint32(len(shares)) {
+		if !isDealer {
+			break
+		}
+
+		secret, err := curve.Scalar.SetBytes(shares[vIdx].SecretShare[:])
+		if err != nil {
+			return nil, errors.Wrap(err, "secret share to scalar")
+		}
+
+		poly := newZeroPoly(newThreshold, rand.Reader)
+
+		castOut[msgKey{ValIdx: vIdx, SourceID: shareIdx}] = append([]curves.Point{curve.ScalarBaseMult(secret)}, poly.Commitments()...)
+
+		for targetID := uint32(1); targetID <= newNumNodes; targetID++ {
+			eval := secret.Add(poly.Evaluate(targetID))
+			if targetID == shareIdx {
+				selfEval[vIdx] = eval
+				continue
+			}
+
+			p2pOut[msgKey{ValIdx: vIdx, SourceID: shareIdx, TargetID: targetID}] = eval
+		}
+	}
+
+	log.Debug(ctx, "Sending reshare messages", z.Bool("dealer", isDealer))
+
+	castIn, p2pIn, err := tp.Exchange(ctx, castOut, p2pOut)
+	if err != nil {
+		return nil, errors.Wrap(err, "transport reshare")
+	}
+
+	log.Debug(ctx, "Received reshare results")
+
+	var resp []share
+	for vIdx, s := range shares {
+		reshared, err := reshareShare(s, uint32(vIdx), selfEval[uint32(vIdx)], castIn, p2pIn, dealers, newNumNodes, newThreshold, shareIdx)
+		if err != nil {
+			return nil, errors.Wrap(err, "reshare share", z.Int("validator_index", vIdx))
+		}
+
+		resp = append(resp, reshared)
+	}
+
+	return resp, nil
+}
+
+// reshareShare returns the new share of the vIdx'th validator after verifying all received sub-shares against
+// their commitments, the commitments against the existing public shares, and ensuring the group public key
+// remains unchanged.
+func reshareShare(s share, vIdx uint32, selfEval curves.Scalar, castIn map[msgKey][]curves.Point,
+	p2pIn map[msgKey]curves.Scalar, dealers map[uint32]uint32, newNumNodes, newThreshold, shareIdx uint32,
+) (share, error) {
+	var (
+		dealerIDs []uint32 // New share indexes of dealers.
+		oldIDs    []uint32 // Existing share indexes of dealers.
+	)
+	for newID, oldID := range dealers {
+		dealerIDs = append(dealerIDs, newID)
+		oldIDs = append(oldIDs, oldID)
+	}
+	sort.Slice(dealerIDs, func(i, j int) bool { return dealerIDs[i] < dealerIDs[j] })
+
+	shamir, err := sharing.NewShamir(uint32(len(oldIDs)), uint32(len(s.PublicShares)), curve)
+	if err != nil {
+		return share{}, errors.Wrap(err, "new shamir")
+	}
+
+	coeffs, err := shamir.LagrangeCoeffs(oldIDs)
+	if err != nil {
+		return share{}, errors.Wrap(err, "lagrange coefficients")
+	}
+
+	// Collect and verify commitments by dealer share ID.
+	comms := make(map[uint32][]curves.Point)
+	for _, dealerID := range dealerIDs {
+		oldID := dealers[dealerID]
+
+		c, ok := castIn[msgKey{ValIdx: vIdx, SourceID: dealerID}]
+		if !ok {
+			return share{}, errors.New("missing reshare commitments", z.Uint("source_id", uint(dealerID)))
+		} else if len(c) != int(newThreshold) {
+			return share{}, errors.New("invalid amount of reshare commitments", z.Uint("source_id", uint(dealerID)),
+				z.Int("received", len(c)), z.Uint("expected", uint(newThreshold)))
+		}
+
+		oldPubShare, ok := s.PublicShares[int(oldID)]
+		if !ok {
+			return share{}, errors.New("missing public share", z.Uint("share_idx", uint(oldID)))
+		}
+
+		if pubShare, err := pointToPubKey(c[0]); err != nil {
+			return share{}, err
+		} else if pubShare != oldPubShare {
+			return share{}, errors.New("reshare commitment doesn't match existing public share", z.Uint("source_id", uint(dealerID)))
+		}
+
+		comms[dealerID] = c
+	}
+
+	// Verify and interpolate received sub-shares.
+	secret := curve.Scalar.Zero()
+	for _, dealerID := range dealerIDs {
+		subShare := selfEval
+		if dealerID != shareIdx {
+			var ok bool
+			subShare, ok = p2pIn[msgKey{ValIdx: vIdx, SourceID: dealerID, TargetID: shareIdx}]
+			if !ok {
+				return share{}, errors.New("missing reshare sub-share", z.Uint("source_id", uint(dealerID)))
+			}
+		}
+
+		if !curve.ScalarBaseMult(subShare).Equal(evalFullCommitments(comms[dealerID], shareIdx)) {
+			return share{}, errors.New("invalid reshare sub-share", z.Uint("source_id", uint(dealerID)))
+		}
+
+		secret = secret.Add(subShare.Mul(coeffs[dealers[dealerID]]))
+	}
+
+	// Calculate the new public shares of all nodes.
+	pubShares := make(map[int]tbls.PublicKey)
+	pubSharePoints := make(map[uint32]curves.Point)
+	for id := uint32(1); id <= newNumNodes; id++ {
+		point := curve.NewIdentityPoint()
+		for _, dealerID := range dealerIDs {
+			point = point.Add(evalFullCommitments(comms[dealerID], id).Mul(coeffs[dealers[dealerID]]))
+		}
+
+		pubShare, err := pointToPubKey(point)
+		if err != nil {
+			return share{}, err
+		}
+
+		pubShares[int(id)] = pubShare
+		pubSharePoints[id] = point
+	}
+
+	if !curve.ScalarBaseMult(secret).Equal(pubSharePoints[shareIdx]) {
+		return share{}, errors.New("reshared secret share doesn't match reshared public share")
+	}
+
+	if err := verifyGroupPubKey(s.PubKey, pubSharePoints, newThreshold); err != nil {
+		return share{}, err
+	}
+
+	secretShare, err := scalarToSecretShare(secret)
+	if err != nil {
+		return share{}, err
+	}
+
+	return share{
+		PubKey:       s.PubKey,
+		SecretShare:  secretShare,
+		PublicShares: pubShares,
+	}, nil
+}
@@ -0,0 +1,223 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/dkg/bcast"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+var rotateKeyApprovalID = "/charon/dkg/rotate_key/1.0.0/approval"
+
+// RotateKeyConfig defines the config of a ceremony rotating the ENR private key of a single cluster operator.
+type RotateKeyConfig struct {
+	// DataDir is the charon data directory containing the existing cluster lock or manifest and p2p key.
+	// The data directory of the rotating operator contains its new p2p key.
+	DataDir string
+	// OutputDir is the directory the new cluster manifest is written to.
+	OutputDir string
+	// OperatorENR is the ENR of the operator's previous key being rotated.
+	OperatorENR string
+	// NewOperatorENR is the ENR of the operator's new key.
+	NewOperatorENR string
+	NoVerify       bool
+	P2P            p2p.Config
+	Log            log.Config
+	ShutdownDelay  time.Duration
+	Timeout        time.Duration
+
+	TestConfig TestConfig
+}
+
+// RunRotateKey executes a ceremony that replaces the ENR private key of a single operator, for example after it
+// was lost or compromised. The operator participates with its new key, the other operators approve the rotation.
+// The change is recorded as a cluster manifest mutation approved by all other operators and by the operator's new key.
+// The operator's validator key shares remain unchanged. The new cluster manifest is written to the output directory.
+func RunRotateKey(ctx context.Context, conf RotateKeyConfig) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if conf.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, conf.Timeout)
+		defer cancel()
+	}
+
+	ctx = log.WithTopic(ctx, "rotate-key")
+
+	unlock, err := lockPrivKey(ctx, conf.DataDir, "charon alpha rotate-operator-key")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	version.LogInfo(ctx, "Charon operator key rotation starting")
+
+	dag, err := loadClusterDAG(ctx, conf.DataDir, conf.NoVerify, conf.TestConfig)
+	if err != nil {
+		return err
+	}
+
+	prevCluster, err := manifest.Materialise(dag)
+	if err != nil {
+		return errors.Wrap(err, "materialise cluster manifest")
+	}
+
+	rotation, err := manifest.NewOperatorKeyRotation(prevCluster.GetLatestMutationHash(), conf.OperatorENR, conf.NewOperatorENR)
+	if err != nil {
+		return err
+	}
+
+	// The rotation is applied to a copy of the cluster to determine the peers of the ceremony.
+	newCluster, err := manifest.Transform(proto.Clone(prevCluster).(*manifestpb.Cluster), rotation)
+	if err != nil {
+		return err
+	}
+
+	newPeers, err := manifest.ClusterPeers(newCluster)
+	if err != nil {
+		return err
+	}
+
+	key, pID, err := loadP2PKey(conf.DataDir, conf.TestConfig)
+	if err != nil {
+		return err
+	}
+
+	if _, err := manifest.ClusterNodeIdx(newCluster, pID); err != nil {
+		return errors.Wrap(err, "private key not matching any operator or the new operator key")
+	}
+
+	// Approvers are all other existing operators and the rotating operator's new key.
+	var (
+		peerIDs   []peer.ID
+		rotatedID peer.ID
+	)
+	for i, p := range newPeers {
+		peerIDs = append(peerIDs, p.ID)
+		if newCluster.GetOperators()[i].GetEnr() == conf.NewOperatorENR {
+			rotatedID = p.ID
+		}
+	}
+
+	if len(peerIDs)-1 < int(prevCluster.GetThreshold()) {
+		return errors.New("insufficient other operators to approve key rotation",
+			z.Int("operators", len(peerIDs)-1), z.I64("threshold", int64(prevCluster.GetThreshold())))
+	}
+
+	if err := checkWrites(conf.OutputDir); err != nil {
+		return err
+	}
+
+	rotationHash, err := manifest.Hash(rotation)
+	if err != nil {
+		return err
+	}
+
+	ceremonyHash := sha256.Sum256(append([]byte(manifest.TypeRotateOperatorKey), rotationHash...))
+
+	log.Info(ctx, "Starting local P2P networking peer",
+		z.Redact(z.Str("operator_enr", conf.OperatorENR)),
+		z.Redact(z.Str("new_operator_enr", conf.NewOperatorENR)),
+		z.Bool("rotating_operator", pID == rotatedID),
+	)
+
+	tcpNode, shutdown, err := setupP2P(ctx, key, Config{P2P: conf.P2P, TestConfig: conf.TestConfig}, newPeers, ceremonyHash[:])
+	if err != nil {
+		return err
+	}
+	defer shutdown()
+
+	caster := bcast.New(tcpNode, peerIDs, key)
+
+	// register bcast callbacks for approvals of the key rotation
+	approvalCaster := newApprovalBcast(newPeers, peerIDs, caster, rotateKeyApprovalID)
+
+	// Sync on the ceremony hash to ensure all peers perform the same key rotation.
+	ctx, nextStepSync, stopSync, err := syncPeers(ctx, tcpNode, key, ceremonyHash[:], peerIDs, cancel, nil, conf.TestConfig, "operator key rotation")
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "All peers connected, exchanging key rotation approvals")
+
+	approvals, err := approvalCaster.exchange(ctx, key, rotationHash, true)
+	if err != nil {
+		return errors.Wrap(err, "node approval exchange")
+	}
+
+	var (
+		quorum         []*manifestpb.SignedMutation
+		newKeyApproval *manifestpb.SignedMutation
+	)
+	for i, approval := range approvals {
+		if peerIDs[i] == rotatedID {
+			newKeyApproval = approval
+		} else {
+			quorum = append(quorum, approval)
+		}
+	}
+
+	quorumApprovals, err := manifest.NewQuorumApprovalsComposite(quorum)
+	if err != nil {
+		return err
+	}
+
+	rotate, err := manifest.NewRotateOperatorKey(rotation, quorumApprovals, newKeyApproval)
+	if err != nil {
+		return err
+	}
+
+	dag.Mutations = append(dag.Mutations, rotate)
+
+	if _, err := manifest.Materialise(dag); err != nil {
+		return errors.Wrap(err, "invalid new cluster manifest")
+	}
+
+	log.Debug(ctx, "Exchanged node approvals")
+	// Node approvals was step 1, advance to step 2
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	if err := writeClusterManifest(conf.OutputDir, dag); err != nil {
+		return err
+	}
+	log.Debug(ctx, "Saved cluster manifest to disk")
+
+	// Disk write was step 2, advance to step 3
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	if err := stopCeremony(ctx, stopSync, conf.ShutdownDelay, conf.TestConfig); err != nil {
+		return err
+	}
+
+	rotateHash, err := manifest.Hash(rotate)
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Successfully completed operator key rotation ceremony 🎉",
+		z.Str("output_dir", conf.OutputDir),
+		z.Str("mutation_hash", fmt.Sprintf("%#x", rotateHash)),
+	)
+	log.Info(ctx, "All operators must replace their cluster files with the new cluster manifest. "+
+		"The rotating operator must restart its node with the new charon-enr-private-key.")
+
+	return nil
+}
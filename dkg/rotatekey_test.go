@@ -0,0 +1,104 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/dkg"
+	dkgsync "github.com/obolnetwork/charon/dkg/sync"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRotateKey(t *testing.T) {
+	const (
+		nodes     = 4
+		threshold = 3
+		vals      = 2
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, _ := cluster.NewForT(t, vals, threshold, nodes, seed, random)
+
+	// The second operator replaces its key.
+	newKey := testutil.GenerateInsecureK1Key(t, 100)
+	record, err := enr.New(newKey)
+	require.NoError(t, err)
+
+	keys := append([]*k1.PrivateKey{}, p2pKeys...)
+	keys[1] = newKey
+
+	dir := t.TempDir()
+	relayAddr := startRelay(ctx, t)
+
+	conf := dkg.RotateKeyConfig{
+		OperatorENR:    lock.Operators[1].ENR,
+		NewOperatorENR: record.String(),
+		P2P:            p2p.Config{Relays: []string{relayAddr}},
+		Log:            log.DefaultConfig(),
+		TestConfig: dkg.TestConfig{
+			Lock:     &lock,
+			SyncOpts: []func(*dkgsync.Client){dkgsync.WithPeriod(time.Millisecond * 50)},
+		},
+		ShutdownDelay: 1 * time.Second,
+		Timeout:       8 * time.Second,
+	}
+
+	var eg errgroup.Group
+	for i, key := range keys {
+		conf := conf
+		conf.DataDir = path.Join(dir, fmt.Sprintf("node%d", i))
+		conf.OutputDir = path.Join(conf.DataDir, "rotated")
+		conf.P2P.TCPAddrs = []string{testutil.AvailableAddr(t).String()}
+
+		require.NoError(t, os.MkdirAll(conf.DataDir, 0o755))
+		require.NoError(t, k1util.Save(key, p2p.KeyPath(conf.DataDir)))
+
+		eg.Go(func() error {
+			err := dkg.RunRotateKey(peerCtx(ctx, i), conf)
+			if err != nil {
+				cancel()
+			}
+
+			return err
+		})
+	}
+
+	err = eg.Wait()
+	testutil.SkipIfBindErr(t, err)
+	testutil.RequireNoError(t, err)
+
+	for i := range keys {
+		outputDir := path.Join(dir, fmt.Sprintf("node%d", i), "rotated")
+
+		c, err := manifest.LoadCluster(path.Join(outputDir, "cluster-manifest.pb"), path.Join(outputDir, "cluster-lock.json"), nil)
+		require.NoError(t, err)
+		require.Len(t, c.GetOperators(), nodes)
+		require.Equal(t, record.String(), c.GetOperators()[1].GetEnr())
+
+		for vIdx, val := range c.GetValidators() {
+			require.Equal(t, lock.Validators[vIdx].PubKey, val.GetPublicKey())
+			require.Equal(t, lock.Validators[vIdx].PubShares, val.GetPubShares())
+		}
+	}
+}
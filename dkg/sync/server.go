@@ -142,6 +142,16 @@ func (s *Server) AwaitAllAtStep(ctx context.Context, step int) error {
 	}
 }
 
+// PeerStep returns the last step reported by the peer and true or false if the peer hasn't reported a step yet.
+func (s *Server) PeerStep(pID peer.ID) (int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	step, ok := s.steps[pID]
+
+	return step, ok
+}
+
 // isConnected returns the shared connected state for the peer.
 func (s *Server) isConnected(pID peer.ID) bool {
 	s.mu.RLock()
@@ -40,6 +40,13 @@ func TestUpdateStep(t *testing.T) {
 
 		err = server.updateStep("bravo", 2)
 		require.NoError(t, err) // next step is allowed
+
+		step, ok := server.PeerStep("bravo")
+		require.True(t, ok)
+		require.Equal(t, 2, step)
+
+		_, ok = server.PeerStep("unknown")
+		require.False(t, ok)
 	})
 
 	t.Run("peer step is behind", func(t *testing.T) {
@@ -0,0 +1,385 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/coinbase/kryptology/pkg/core/curves"
+	"github.com/coinbase/kryptology/pkg/dkg/frost"
+	"github.com/coinbase/kryptology/pkg/sharing"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+)
+
+// TranscriptFile is the file name of the DKG transcript written to the data directory.
+const TranscriptFile = "dkg-transcript.json"
+
+// Transcript is a privacy-preserving record of the public values exchanged during a DKG ceremony.
+// It contains no secret material, but allows anyone to verify that the distributed validator keys
+// and public shares in a cluster lock were generated honestly by all participants.
+type Transcript struct {
+	// DefinitionHash is the hash of the cluster definition the ceremony was executed for.
+	DefinitionHash hexBytes `json:"definition_hash"`
+	// DKGContext is the context string used in the participants' proofs of knowledge.
+	DKGContext string `json:"dkg_context"`
+	// Threshold is the number of shares required to reconstruct a validator key.
+	Threshold int `json:"threshold"`
+	// Validators contains the public values exchanged for each distributed validator.
+	Validators []TranscriptValidator `json:"validators"`
+	// Signatures are the participants' K1 signatures over the transcript hash, ordered by peer index.
+	Signatures []hexBytes `json:"signatures,omitempty"`
+}
+
+// TranscriptValidator contains the public values exchanged for a single distributed validator.
+type TranscriptValidator struct {
+	PubKey       hexBytes                `json:"distributed_public_key"`
+	Participants []TranscriptParticipant `json:"participants"`
+}
+
+// TranscriptParticipant contains the public values broadcast by a single participant for a distributed validator.
+type TranscriptParticipant struct {
+	// ShareIdx is the 1-indexed participant share index.
+	ShareIdx int `json:"share_idx"`
+	// Commitments are the participant's Feldman commitments to its secret polynomial coefficients.
+	Commitments []hexBytes `json:"commitments"`
+	// ProofW and ProofC are the participant's Schnorr proof of knowledge of its secret constant term.
+	ProofW hexBytes `json:"proof_w"`
+	ProofC hexBytes `json:"proof_c"`
+	// VerificationKey is the group public key as calculated by the participant.
+	VerificationKey hexBytes `json:"verification_key"`
+	// PublicShare is the participant's public key share.
+	PublicShare hexBytes `json:"public_share"`
+}
+
+// Hash returns the hash of the transcript excluding the signatures.
+func (t Transcript) Hash() ([]byte, error) {
+	t.Signatures = nil
+
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal transcript")
+	}
+
+	h := sha256.Sum256(b)
+
+	return h[:], nil
+}
+
+// VerifyTranscript returns an error if the transcript doesn't prove that the distributed validator keys and
+// public shares in the lock were generated honestly by all the lock's operators.
+func VerifyTranscript(t Transcript, lock cluster.Lock) error {
+	if !bytes.Equal(t.DefinitionHash, lock.DefinitionHash) {
+		return errors.New("transcript definition hash mismatch")
+	}
+
+	if t.Threshold != lock.Threshold {
+		return errors.New("transcript threshold mismatch", z.Int("transcript", t.Threshold), z.Int("lock", lock.Threshold))
+	}
+
+	if len(t.Validators) != len(lock.Validators) {
+		return errors.New("transcript validator count mismatch",
+			z.Int("transcript", len(t.Validators)), z.Int("lock", len(lock.Validators)))
+	}
+
+	for vIdx, val := range t.Validators {
+		if err := verifyTranscriptValidator(val, lock.Validators[vIdx], t.DKGContext, len(lock.Operators), t.Threshold); err != nil {
+			return errors.Wrap(err, "verify transcript validator", z.Int("validator_index", vIdx))
+		}
+	}
+
+	return verifyTranscriptSignatures(t, lock)
+}
+
+// verifyTranscriptValidator returns an error if the public values of the validator are invalid or don't match the lock.
+func verifyTranscriptValidator(val TranscriptValidator, lockVal cluster.DistValidator, dkgCtx string, numNodes, threshold int) error {
+	if len(val.Participants) != numNodes {
+		return errors.New("invalid participant count", z.Int("count", len(val.Participants)))
+	}
+
+	if len(lockVal.PubShares) != numNodes {
+		return errors.New("invalid lock public shares count", z.Int("count", len(lockVal.PubShares)))
+	}
+
+	commitments := make(map[int][]curves.Point)
+	groupKey := curve.NewIdentityPoint()
+	for i, p := range val.Participants {
+		if p.ShareIdx != i+1 {
+			return errors.New("invalid participant share index", z.Int("share_idx", p.ShareIdx))
+		}
+
+		comms, err := verifyParticipantProof(p, dkgCtx, threshold)
+		if err != nil {
+			return errors.Wrap(err, "verify participant", z.Int("share_idx", p.ShareIdx))
+		}
+
+		commitments[p.ShareIdx] = comms
+		groupKey = groupKey.Add(comms[0])
+	}
+
+	if !bytes.Equal(groupKey.ToAffineCompressed(), lockVal.PubKey) || !bytes.Equal(val.PubKey, lockVal.PubKey) {
+		return errors.New("commitments don't match distributed public key")
+	}
+
+	for _, p := range val.Participants {
+		if !bytes.Equal(p.VerificationKey, lockVal.PubKey) {
+			return errors.New("participant verification key mismatch", z.Int("share_idx", p.ShareIdx))
+		}
+
+		pubShare := curve.NewIdentityPoint()
+		for _, comms := range commitments {
+			pubShare = pubShare.Add(evalFeldman(comms, uint32(p.ShareIdx)))
+		}
+
+		if !bytes.Equal(pubShare.ToAffineCompressed(), p.PublicShare) {
+			return errors.New("commitments don't match participant public share", z.Int("share_idx", p.ShareIdx))
+		}
+
+		if !bytes.Equal(p.PublicShare, lockVal.PubShares[p.ShareIdx-1]) {
+			return errors.New("participant public share doesn't match lock", z.Int("share_idx", p.ShareIdx))
+		}
+	}
+
+	return nil
+}
+
+// verifyParticipantProof returns the participant's decoded commitments after verifying its proof of knowledge
+// of the secret constant term, mirroring the FROST DKG round 2 verification.
+func verifyParticipantProof(p TranscriptParticipant, dkgCtx string, threshold int) ([]curves.Point, error) {
+	if len(p.Commitments) != threshold {
+		return nil, errors.New("invalid commitments count", z.Int("count", len(p.Commitments)))
+	}
+
+	var comms []curves.Point
+	for _, b := range p.Commitments {
+		comm, err := curve.Point.FromAffineCompressed(b)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode commitment")
+		}
+		comms = append(comms, comm)
+	}
+
+	wi, err := curve.Scalar.SetBytes(p.ProofW)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode proof w")
+	}
+
+	ci, err := curve.Scalar.SetBytes(p.ProofC)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode proof c")
+	} else if ci.IsZero() {
+		return nil, errors.New("zero proof c")
+	}
+
+	aj0 := comms[0]
+	if aj0.IsIdentity() {
+		return nil, errors.New("identity commitment")
+	}
+
+	// c_j = H(j, CTX, A_{j,0}, g^{w_j}*A_{j,0}^{-c_j})
+	prod := curve.ScalarBaseMult(wi).Add(aj0.Mul(ci.Neg()))
+
+	var msg []byte
+	msg = append(msg, byte(p.ShareIdx))
+	msg = append(msg, frostCtxByte(dkgCtx))
+	msg = append(msg, aj0.ToAffineCompressed()...)
+	msg = append(msg, prod.ToAffineCompressed()...)
+
+	if curve.Scalar.Hash(msg).Cmp(ci) != 0 {
+		return nil, errors.New("invalid proof of knowledge")
+	}
+
+	return comms, nil
+}
+
+// verifyTranscriptSignatures returns an error if the transcript isn't signed by all the lock's operators.
+func verifyTranscriptSignatures(t Transcript, lock cluster.Lock) error {
+	if len(t.Signatures) != len(lock.Operators) {
+		return errors.New("invalid transcript signatures count", z.Int("count", len(t.Signatures)))
+	}
+
+	peers, err := lock.Peers()
+	if err != nil {
+		return err
+	}
+
+	hash, err := t.Hash()
+	if err != nil {
+		return err
+	}
+
+	for i, p := range peers {
+		pubkey, err := p.PublicKey()
+		if err != nil {
+			return err
+		}
+
+		ok, err := k1util.Verify65(pubkey, hash, t.Signatures[i])
+		if err != nil {
+			return errors.Wrap(err, "verify transcript signature", z.Int("peer_index", i))
+		} else if !ok {
+			return errors.New("invalid transcript signature", z.Int("peer_index", i))
+		}
+	}
+
+	return nil
+}
+
+// evalFeldman returns the public point of the polynomial committed to by comms (including the constant term),
+// evaluated at the provided share ID.
+func evalFeldman(comms []curves.Point, id uint32) curves.Point {
+	x := curve.Scalar.New(int(id))
+	xi := curve.Scalar.One()
+	resp := curve.NewIdentityPoint()
+	for _, comm := range comms {
+		resp = resp.Add(comm.Mul(xi))
+		xi = xi.Mul(x)
+	}
+
+	return resp
+}
+
+// frostCtxByte returns the context byte used by FROST DKG participants for the provided context string.
+// This mirrors kryptology's frost.NewDkgParticipant.
+func frostCtxByte(dkgCtx string) byte {
+	ctxV, _ := strconv.Atoi(dkgCtx)
+
+	return byte(ctxV)
+}
+
+// newTranscript returns a transcript (without signatures) from the recorded FROST broadcast messages.
+func newTranscript(defHash []byte, dkgCtx string, threshold, numNodes int, shares []share, rec *transcriptRecorder) (Transcript, error) {
+	resp := Transcript{
+		DefinitionHash: defHash,
+		DKGContext:     dkgCtx,
+		Threshold:      threshold,
+	}
+
+	for vIdx, s := range shares {
+		val := TranscriptValidator{PubKey: s.PubKey[:]}
+
+		for id := uint32(1); id <= uint32(numNodes); id++ {
+			key := msgKey{ValIdx: uint32(vIdx), SourceID: id}
+
+			r1, ok := rec.castR1[key]
+			if !ok {
+				return Transcript{}, errors.New("missing round 1 broadcast", z.Int("validator_index", vIdx), z.Uint("source_id", uint(id)))
+			}
+
+			r2, ok := rec.castR2[key]
+			if !ok {
+				return Transcript{}, errors.New("missing round 2 broadcast", z.Int("validator_index", vIdx), z.Uint("source_id", uint(id)))
+			}
+
+			var comms []hexBytes
+			for _, comm := range r1.Verifiers.Commitments {
+				comms = append(comms, comm.ToAffineCompressed())
+			}
+
+			val.Participants = append(val.Participants, TranscriptParticipant{
+				ShareIdx:        int(id),
+				Commitments:     comms,
+				ProofW:          r1.Wi.Bytes(),
+				ProofC:          r1.Ci.Bytes(),
+				VerificationKey: r2.VerificationKey.ToAffineCompressed(),
+				PublicShare:     r2.VkShare.ToAffineCompressed(),
+			})
+		}
+
+		resp.Validators = append(resp.Validators, val)
+	}
+
+	return resp, nil
+}
+
+// writeTranscript writes the transcript to disk.
+func writeTranscript(datadir string, t Transcript) error {
+	b, err := json.MarshalIndent(t, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal transcript")
+	}
+
+	//nolint:gosec // File needs to be read-only for everybody
+	err = os.WriteFile(path.Join(datadir, TranscriptFile), b, 0o444) // Read-only
+	if err != nil {
+		return errors.Wrap(err, "write transcript")
+	}
+
+	return nil
+}
+
+// transcriptRecorder wraps a FROST transport and records all received broadcast messages.
+type transcriptRecorder struct {
+	fTransport
+
+	castR1 map[msgKey]frost.Round1Bcast
+	castR2 map[msgKey]frost.Round2Bcast
+}
+
+// newTranscriptRecorder returns a new transcript recorder wrapping the provided transport.
+func newTranscriptRecorder(tp fTransport) *transcriptRecorder {
+	return &transcriptRecorder{fTransport: tp}
+}
+
+func (r *transcriptRecorder) Round1(ctx context.Context, bcast map[msgKey]frost.Round1Bcast, shares map[msgKey]sharing.ShamirShare,
+) (map[msgKey]frost.Round1Bcast, map[msgKey]sharing.ShamirShare, error) {
+	castR1, p2pR1, err := r.fTransport.Round1(ctx, bcast, shares)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.castR1 = castR1
+
+	return castR1, p2pR1, nil
+}
+
+func (r *transcriptRecorder) Round2(ctx context.Context, bcast map[msgKey]frost.Round2Bcast) (map[msgKey]frost.Round2Bcast, error) {
+	castR2, err := r.fTransport.Round2(ctx, bcast)
+	if err != nil {
+		return nil, err
+	}
+
+	r.castR2 = castR2
+
+	return castR2, nil
+}
+
+// hexBytes is a byte slice that is json encoded as a 0x-prefixed hex string.
+type hexBytes []byte
+
+func (h hexBytes) MarshalJSON() ([]byte, error) {
+	resp, err := json.Marshal(fmt.Sprintf("%#x", []byte(h)))
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal hex")
+	}
+
+	return resp, nil
+}
+
+func (h *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrap(err, "unmarshal hex string")
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "decode hex")
+	}
+
+	*h = b
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/cluster"
+)
+
+func TestTranscript(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const (
+		nodes     = 3
+		threshold = 2
+		vals      = 2
+		dkgCtx    = "0xdeadbeef"
+	)
+
+	defHash := []byte("definition hash")
+	tp := &frostMemTransport{nodes: nodes}
+
+	var (
+		eg          errgroup.Group
+		mu          sync.Mutex
+		transcripts = make(map[int]Transcript)
+		allShares   = make(map[int][]share)
+	)
+	for i := range nodes {
+		eg.Go(func() error {
+			rec := newTranscriptRecorder(tp)
+			shares, err := runFrostParallel(ctx, rec, vals, nodes, threshold, uint32(i+1), dkgCtx)
+			if err != nil {
+				cancel()
+				return err
+			}
+
+			transcript, err := newTranscript(defHash, dkgCtx, threshold, nodes, shares, rec)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			transcripts[i+1] = transcript
+			allShares[i+1] = shares
+			mu.Unlock()
+
+			return nil
+		})
+	}
+	require.NoError(t, eg.Wait())
+
+	// All nodes record identical transcripts.
+	hash, err := transcripts[1].Hash()
+	require.NoError(t, err)
+	for _, transcript := range transcripts {
+		h, err := transcript.Hash()
+		require.NoError(t, err)
+		require.Equal(t, hash, h)
+	}
+
+	transcript := transcripts[1]
+
+	// Transcript json roundtrip.
+	b, err := json.Marshal(transcript)
+	require.NoError(t, err)
+	var decoded Transcript
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Equal(t, transcript, decoded)
+
+	lockVals := make([]cluster.DistValidator, vals)
+	for vIdx := range vals {
+		lockVals[vIdx].PubKey = allShares[1][vIdx].PubKey[:]
+		for shareIdx := 1; shareIdx <= nodes; shareIdx++ {
+			pubShare := allShares[1][vIdx].PublicShares[shareIdx]
+			lockVals[vIdx].PubShares = append(lockVals[vIdx].PubShares, pubShare[:])
+		}
+	}
+
+	for vIdx, val := range transcript.Validators {
+		require.NoError(t, verifyTranscriptValidator(val, lockVals[vIdx], dkgCtx, nodes, threshold))
+	}
+
+	t.Run("invalid proof", func(t *testing.T) {
+		val := cloneTranscriptValidator(t, transcript.Validators[0])
+		val.Participants[1].ProofC = val.Participants[0].ProofC
+
+		err := verifyTranscriptValidator(val, lockVals[0], dkgCtx, nodes, threshold)
+		require.ErrorContains(t, err, "invalid proof of knowledge")
+	})
+
+	t.Run("wrong context", func(t *testing.T) {
+		err := verifyTranscriptValidator(transcript.Validators[0], lockVals[0], "1", nodes, threshold)
+		require.ErrorContains(t, err, "invalid proof of knowledge")
+	})
+
+	t.Run("wrong validator", func(t *testing.T) {
+		err := verifyTranscriptValidator(transcript.Validators[0], lockVals[1], dkgCtx, nodes, threshold)
+		require.ErrorContains(t, err, "commitments don't match distributed public key")
+	})
+
+	t.Run("swapped public shares", func(t *testing.T) {
+		lockVal := lockVals[0]
+		lockVal.PubShares = [][]byte{lockVal.PubShares[1], lockVal.PubShares[0], lockVal.PubShares[2]}
+
+		err := verifyTranscriptValidator(transcript.Validators[0], lockVal, dkgCtx, nodes, threshold)
+		require.ErrorContains(t, err, "participant public share doesn't match lock")
+	})
+
+	t.Run("missing commitment", func(t *testing.T) {
+		val := cloneTranscriptValidator(t, transcript.Validators[0])
+		val.Participants[2].Commitments = val.Participants[2].Commitments[:1]
+
+		err := verifyTranscriptValidator(val, lockVals[0], dkgCtx, nodes, threshold)
+		require.ErrorContains(t, err, "invalid commitments count")
+	})
+}
+
+func cloneTranscriptValidator(t *testing.T, val TranscriptValidator) TranscriptValidator {
+	t.Helper()
+
+	b, err := json.Marshal(val)
+	require.NoError(t, err)
+
+	var resp TranscriptValidator
+	require.NoError(t, json.Unmarshal(b, &resp))
+
+	return resp
+}
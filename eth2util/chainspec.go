@@ -0,0 +1,109 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2util
+
+import (
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// chainSpec is the subset of a consensus layer chain spec config file (config.yaml) defining a network.
+type chainSpec struct {
+	ConfigName             string `yaml:"CONFIG_NAME"`
+	PresetBase             string `yaml:"PRESET_BASE"`
+	GenesisForkVersion     string `yaml:"GENESIS_FORK_VERSION"`
+	CapellaForkVersion     string `yaml:"CAPELLA_FORK_VERSION"`
+	DepositChainID         string `yaml:"DEPOSIT_CHAIN_ID"`
+	DepositContractAddress string `yaml:"DEPOSIT_CONTRACT_ADDRESS"`
+	MinGenesisTime         string `yaml:"MIN_GENESIS_TIME"`
+	GenesisDelay           string `yaml:"GENESIS_DELAY"`
+}
+
+// LoadNetworkSpec returns the network defined by the consensus layer chain spec config file (config.yaml)
+// as used by custom devnets and testnets like Kurtosis or ephemery.
+// The genesis timestamp is approximated by MIN_GENESIS_TIME plus GENESIS_DELAY.
+func LoadNetworkSpec(filename string) (Network, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return Network{}, errors.Wrap(err, "read chain spec file", z.Str("path", filename))
+	}
+
+	var spec chainSpec
+	if err := yaml.Unmarshal(b, &spec); err != nil {
+		return Network{}, errors.Wrap(err, "unmarshal chain spec file", z.Str("path", filename))
+	}
+
+	if spec.GenesisForkVersion == "" {
+		return Network{}, errors.New("chain spec file missing GENESIS_FORK_VERSION", z.Str("path", filename))
+	}
+
+	name := spec.ConfigName
+	if name == "" {
+		name = spec.PresetBase
+	}
+
+	resp := Network{
+		Name:                   name,
+		GenesisForkVersionHex:  spec.GenesisForkVersion,
+		CapellaHardFork:        spec.CapellaForkVersion,
+		DepositContractAddress: spec.DepositContractAddress,
+	}
+
+	if spec.DepositChainID != "" {
+		resp.ChainID, err = strconv.ParseUint(spec.DepositChainID, 10, 64)
+		if err != nil {
+			return Network{}, errors.Wrap(err, "parse DEPOSIT_CHAIN_ID", z.Str("path", filename))
+		}
+	}
+
+	if spec.MinGenesisTime != "" {
+		minGenesis, err := strconv.ParseInt(spec.MinGenesisTime, 10, 64)
+		if err != nil {
+			return Network{}, errors.Wrap(err, "parse MIN_GENESIS_TIME", z.Str("path", filename))
+		}
+
+		var delay int64
+		if spec.GenesisDelay != "" {
+			delay, err = strconv.ParseInt(spec.GenesisDelay, 10, 64)
+			if err != nil {
+				return Network{}, errors.Wrap(err, "parse GENESIS_DELAY", z.Str("path", filename))
+			}
+		}
+
+		resp.GenesisTimestamp = minGenesis + delay
+	}
+
+	return resp, nil
+}
+
+// MergeNetwork returns the network with all zero fields set to the fields of the fallback network.
+func MergeNetwork(network, fallback Network) Network {
+	if network.ChainID == 0 {
+		network.ChainID = fallback.ChainID
+	}
+	if network.Name == "" {
+		network.Name = fallback.Name
+	}
+	if network.GenesisForkVersionHex == "" {
+		network.GenesisForkVersionHex = fallback.GenesisForkVersionHex
+	}
+	if network.GenesisTimestamp == 0 {
+		network.GenesisTimestamp = fallback.GenesisTimestamp
+	}
+	if network.CapellaHardFork == "" {
+		network.CapellaHardFork = fallback.CapellaHardFork
+	}
+	if network.GenesisValidatorsRootHex == "" {
+		network.GenesisValidatorsRootHex = fallback.GenesisValidatorsRootHex
+	}
+	if network.DepositContractAddress == "" {
+		network.DepositContractAddress = fallback.DepositContractAddress
+	}
+
+	return network
+}
@@ -0,0 +1,59 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2util_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+const devnetSpec = `# Extends the minimal preset
+PRESET_BASE: 'minimal'
+CONFIG_NAME: 'kurtosis'
+
+MIN_GENESIS_TIME: 1695902100
+GENESIS_FORK_VERSION: 0x10000038
+GENESIS_DELAY: 300
+
+CAPELLA_FORK_VERSION: 0x40000038
+CAPELLA_FORK_EPOCH: 0
+
+DEPOSIT_CHAIN_ID: 3151908
+DEPOSIT_NETWORK_ID: 3151908
+DEPOSIT_CONTRACT_ADDRESS: 0x4242424242424242424242424242424242424242
+
+BLOB_SCHEDULE:
+  - EPOCH: 0
+    MAX_BLOBS_PER_BLOCK: 9
+`
+
+func TestLoadNetworkSpec(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte(devnetSpec), 0o644))
+
+	network, err := eth2util.LoadNetworkSpec(filename)
+	require.NoError(t, err)
+	require.Equal(t, eth2util.Network{
+		ChainID:                3151908,
+		Name:                   "kurtosis",
+		GenesisForkVersionHex:  "0x10000038",
+		GenesisTimestamp:       1695902400,
+		CapellaHardFork:        "0x40000038",
+		DepositContractAddress: "0x4242424242424242424242424242424242424242",
+	}, network)
+	require.True(t, network.IsNonZero())
+
+	merged := eth2util.MergeNetwork(eth2util.Network{Name: "devnet", ChainID: 1337}, network)
+	require.Equal(t, "devnet", merged.Name)
+	require.EqualValues(t, 1337, merged.ChainID)
+	require.Equal(t, network.GenesisForkVersionHex, merged.GenesisForkVersionHex)
+
+	require.NoError(t, os.WriteFile(filename, []byte("CONFIG_NAME: 'kurtosis'\n"), 0o644))
+	_, err = eth2util.LoadNetworkSpec(filename)
+	require.ErrorContains(t, err, "chain spec file missing GENESIS_FORK_VERSION")
+}
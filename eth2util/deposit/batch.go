@@ -0,0 +1,200 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package deposit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// BatchJSONFile is the filename of the batched deposits json file.
+	BatchJSONFile = "deposit-batch.json"
+	// BatchCSVFile is the filename of the batched deposits csv file.
+	BatchCSVFile = "deposit-batch.csv"
+)
+
+// batchCSVHeader is the header row of the batched deposits csv file.
+var batchCSVHeader = []string{"pubkey", "withdrawal_credentials", "amount", "signature", "deposit_data_root"}
+
+// depositBatchJSON is the json representation of batched deposits. The concatenated byte fields and the
+// per deposit lists can be passed as-is to batch deposit contracts.
+type depositBatchJSON struct {
+	NetworkName           string   `json:"network_name"`
+	ForkVersion           string   `json:"fork_version"`
+	DepositCount          int      `json:"deposit_count"`
+	TotalAmount           uint64   `json:"total_amount"`
+	Pubkeys               string   `json:"pubkeys"`
+	WithdrawalCredentials string   `json:"withdrawal_credentials"`
+	Signatures            string   `json:"signatures"`
+	DepositDataRoots      []string `json:"deposit_data_roots"`
+	Amounts               []uint64 `json:"amounts"`
+}
+
+// BatchDepositDatas returns the deposit datas to submit for each of the provided deposit amounts in order.
+// The deposit datas of amounts specified multiple times are repeated, since signed deposit datas are
+// only created once per distinct amount.
+func BatchDepositDatas(depositDatas [][]eth2p0.DepositData, amounts []eth2p0.Gwei) ([][]eth2p0.DepositData, error) {
+	byAmount := make(map[eth2p0.Gwei][]eth2p0.DepositData)
+	for _, dd := range depositDatas {
+		if len(dd) == 0 {
+			return nil, errors.New("empty deposit data")
+		}
+		byAmount[dd[0].Amount] = dd
+	}
+
+	var resp [][]eth2p0.DepositData
+	for _, amount := range amounts {
+		dd, ok := byAmount[amount]
+		if !ok {
+			return nil, errors.New("missing deposit data for amount", z.U64("amount", uint64(amount)))
+		}
+
+		resp = append(resp, dd)
+	}
+
+	return resp, nil
+}
+
+// MarshalBatchJSON serializes all deposit datas of all amounts into a single batched deposits json file.
+func MarshalBatchJSON(depositDatas [][]eth2p0.DepositData, network string) ([]byte, error) {
+	ddList, err := batchDepositDataJSONs(depositDatas, network)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := depositBatchJSON{
+		NetworkName:      network,
+		ForkVersion:      ddList[0].ForkVersion,
+		DepositCount:     len(ddList),
+		DepositDataRoots: []string{},
+		Amounts:          []uint64{},
+	}
+
+	var pubkeys, creds, sigs strings.Builder
+	for _, dd := range ddList {
+		pubkeys.WriteString(dd.PubKey)
+		creds.WriteString(dd.WithdrawalCredentials)
+		sigs.WriteString(dd.Signature)
+
+		batch.TotalAmount += dd.Amount
+		batch.Amounts = append(batch.Amounts, dd.Amount)
+		batch.DepositDataRoots = append(batch.DepositDataRoots, "0x"+dd.DepositDataRoot)
+	}
+
+	batch.Pubkeys = "0x" + pubkeys.String()
+	batch.WithdrawalCredentials = "0x" + creds.String()
+	batch.Signatures = "0x" + sigs.String()
+
+	b, err := json.MarshalIndent(batch, "", " ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal deposit batch")
+	}
+
+	return b, nil
+}
+
+// MarshalBatchCSV serializes all deposit datas of all amounts into a single batched deposits csv file
+// with one row per deposit.
+func MarshalBatchCSV(depositDatas [][]eth2p0.DepositData, network string) ([]byte, error) {
+	ddList, err := batchDepositDataJSONs(depositDatas, network)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(batchCSVHeader); err != nil {
+		return nil, errors.Wrap(err, "write csv header")
+	}
+
+	for _, dd := range ddList {
+		row := []string{
+			"0x" + dd.PubKey,
+			"0x" + dd.WithdrawalCredentials,
+			strconv.FormatUint(dd.Amount, 10),
+			"0x" + dd.Signature,
+			"0x" + dd.DepositDataRoot,
+		}
+		if err := w.Write(row); err != nil {
+			return nil, errors.Wrap(err, "write csv row")
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, errors.Wrap(err, "flush csv")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// batchDepositDataJSONs returns the verified json representations of all deposit datas in order, with each
+// group of deposit datas sorted by public key.
+func batchDepositDataJSONs(depositDatas [][]eth2p0.DepositData, network string) ([]depositDataJSON, error) {
+	var resp []depositDataJSON
+	for _, dd := range depositDatas {
+		ddList, err := verifiedDepositDataJSONs(dd, network)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, ddList...)
+	}
+
+	if len(resp) == 0 {
+		return nil, errors.New("empty deposit data")
+	}
+
+	return resp, nil
+}
+
+// WriteDepositBatchFiles writes the deposit-batch.json and deposit-batch.csv files containing
+// the deposit datas of all amounts.
+func WriteDepositBatchFiles(depositDatas [][]eth2p0.DepositData, network string, dataDir string) error {
+	jsonBytes, err := MarshalBatchJSON(depositDatas, network)
+	if err != nil {
+		return err
+	}
+
+	csvBytes, err := MarshalBatchCSV(depositDatas, network)
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec // File needs to be read-only for everybody
+	if err := os.WriteFile(path.Join(dataDir, BatchJSONFile), jsonBytes, 0o444); err != nil {
+		return errors.Wrap(err, "write deposit batch json")
+	}
+
+	//nolint:gosec // File needs to be read-only for everybody
+	if err := os.WriteFile(path.Join(dataDir, BatchCSVFile), csvBytes, 0o444); err != nil {
+		return errors.Wrap(err, "write deposit batch csv")
+	}
+
+	return nil
+}
+
+// WriteClusterDepositBatchFiles writes the deposit batch files to each node directory.
+func WriteClusterDepositBatchFiles(depositDatas [][]eth2p0.DepositData, network string, clusterDir string, numNodes int) error {
+	for n := range numNodes {
+		nodeDir := path.Join(clusterDir, fmt.Sprintf("node%d", n))
+		if err := WriteDepositBatchFiles(depositDatas, network, nodeDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
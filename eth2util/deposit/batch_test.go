@@ -0,0 +1,92 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package deposit_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+//go:generate go test . -run=TestMarshalBatch -update -clean
+
+func TestMarshalBatchJSON(t *testing.T) {
+	datas := [][]eth2p0.DepositData{
+		mustGenerateDepositDatas(t, deposit.MaxDepositAmount/2),
+		mustGenerateDepositDatas(t, deposit.MaxDepositAmount/4),
+	}
+
+	actual, err := deposit.MarshalBatchJSON(datas, eth2util.Goerli.Name)
+	require.NoError(t, err)
+
+	testutil.RequireGoldenBytes(t, actual)
+}
+
+func TestMarshalBatchCSV(t *testing.T) {
+	datas := [][]eth2p0.DepositData{
+		mustGenerateDepositDatas(t, deposit.MaxDepositAmount/2),
+		mustGenerateDepositDatas(t, deposit.MaxDepositAmount/4),
+	}
+
+	actual, err := deposit.MarshalBatchCSV(datas, eth2util.Goerli.Name)
+	require.NoError(t, err)
+
+	testutil.RequireGoldenBytes(t, actual)
+
+	t.Run("empty deposit datas", func(t *testing.T) {
+		_, err := deposit.MarshalBatchCSV(nil, eth2util.Goerli.Name)
+		require.ErrorContains(t, err, "empty deposit data")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		invalid := mustGenerateDepositDatas(t, deposit.MaxDepositAmount)
+		invalid[0].Amount /= 2
+
+		_, err := deposit.MarshalBatchCSV([][]eth2p0.DepositData{invalid}, eth2util.Goerli.Name)
+		require.ErrorContains(t, err, "invalid deposit data signature")
+	})
+}
+
+func TestBatchDepositDatas(t *testing.T) {
+	half := mustGenerateDepositDatas(t, deposit.MaxDepositAmount/2)
+	quarter := mustGenerateDepositDatas(t, deposit.MaxDepositAmount/4)
+	datas := [][]eth2p0.DepositData{quarter, half}
+
+	batch, err := deposit.BatchDepositDatas(datas, []eth2p0.Gwei{
+		deposit.MaxDepositAmount / 2,
+		deposit.MaxDepositAmount / 4,
+		deposit.MaxDepositAmount / 4,
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]eth2p0.DepositData{half, quarter, quarter}, batch)
+
+	_, err = deposit.BatchDepositDatas(datas, []eth2p0.Gwei{deposit.MaxDepositAmount})
+	require.ErrorContains(t, err, "missing deposit data for amount")
+}
+
+func TestWriteDepositBatchFiles(t *testing.T) {
+	dir := t.TempDir()
+	datas := [][]eth2p0.DepositData{mustGenerateDepositDatas(t, deposit.MaxDepositAmount)}
+
+	err := deposit.WriteDepositBatchFiles(datas, eth2util.Goerli.Name, dir)
+	require.NoError(t, err)
+
+	expectedJSON, err := deposit.MarshalBatchJSON(datas, eth2util.Goerli.Name)
+	require.NoError(t, err)
+	actualJSON, err := os.ReadFile(path.Join(dir, deposit.BatchJSONFile))
+	require.NoError(t, err)
+	require.Equal(t, expectedJSON, actualJSON)
+
+	expectedCSV, err := deposit.MarshalBatchCSV(datas, eth2util.Goerli.Name)
+	require.NoError(t, err)
+	actualCSV, err := os.ReadFile(path.Join(dir, deposit.BatchCSVFile))
+	require.NoError(t, err)
+	require.Equal(t, expectedCSV, actualCSV)
+}
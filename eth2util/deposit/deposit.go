@@ -34,9 +34,16 @@ var (
 	// Maximum allowed deposit amount (32ETH).
 	MaxDepositAmount = eth2p0.Gwei(32000000000)
 
+	// Maximum allowed deposit amount for compounding validators (2048ETH).
+	// See spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/electra/beacon-chain.md#gwei-values
+	MaxCompoundingDepositAmount = eth2p0.Gwei(2048000000000)
+
 	// https://github.com/ethereum/consensus-specs/blob/dev/specs/phase0/validator.md#eth1_address_withdrawal_prefix
 	eth1AddressWithdrawalPrefix = []byte{0x01}
 
+	// https://github.com/ethereum/consensus-specs/blob/dev/specs/electra/beacon-chain.md#withdrawal-prefixes
+	compoundingWithdrawalPrefix = []byte{0x02}
+
 	// DOMAIN_DEPOSIT. See spec: https://benjaminion.xyz/eth2-annotated-spec/phase0/beacon-chain/#domain-types
 	depositDomainType = eth2p0.DomainType([4]byte{0x03, 0x00, 0x00, 0x00})
 
@@ -46,7 +53,7 @@ var (
 
 // NewMessage returns a deposit message created using the provided parameters.
 func NewMessage(pubkey eth2p0.BLSPubKey, withdrawalAddr string, amount eth2p0.Gwei) (eth2p0.DepositMessage, error) {
-	creds, err := withdrawalCredsFromAddr(withdrawalAddr)
+	creds, err := withdrawalCredsFromAddr(withdrawalAddr, eth1AddressWithdrawalPrefix)
 	if err != nil {
 		return eth2p0.DepositMessage{}, err
 	}
@@ -66,8 +73,46 @@ func NewMessage(pubkey eth2p0.BLSPubKey, withdrawalAddr string, amount eth2p0.Gw
 	}, nil
 }
 
+// NewCompoundingMessage returns a deposit message with '0x02' compounding withdrawal credentials
+// created using the provided parameters. Compounding validators support deposits of up to 2048ETH.
+func NewCompoundingMessage(pubkey eth2p0.BLSPubKey, withdrawalAddr string, amount eth2p0.Gwei) (eth2p0.DepositMessage, error) {
+	creds, err := withdrawalCredsFromAddr(withdrawalAddr, compoundingWithdrawalPrefix)
+	if err != nil {
+		return eth2p0.DepositMessage{}, err
+	}
+
+	if amount < MinDepositAmount {
+		return eth2p0.DepositMessage{}, errors.New("deposit message minimum amount must be >= 1ETH", z.U64("amount", uint64(amount)))
+	}
+
+	if amount > MaxCompoundingDepositAmount {
+		return eth2p0.DepositMessage{}, errors.New("compounding deposit message maximum amount must <= 2048ETH", z.U64("amount", uint64(amount)))
+	}
+
+	return eth2p0.DepositMessage{
+		PublicKey:             pubkey,
+		WithdrawalCredentials: creds[:],
+		Amount:                amount,
+	}, nil
+}
+
 // MarshalDepositData serializes a list of deposit data into a single file.
 func MarshalDepositData(depositDatas []eth2p0.DepositData, network string) ([]byte, error) {
+	ddList, err := verifiedDepositDataJSONs(depositDatas, network)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := json.MarshalIndent(ddList, "", " ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal deposit data")
+	}
+
+	return bytes, nil
+}
+
+// verifiedDepositDataJSONs verifies the deposit data signatures and returns their json representations sorted by public key.
+func verifiedDepositDataJSONs(depositDatas []eth2p0.DepositData, network string) ([]depositDataJSON, error) {
 	forkVersion, err := eth2util.NetworkToForkVersion(network)
 	if err != nil {
 		return nil, err
@@ -121,12 +166,7 @@ func MarshalDepositData(depositDatas []eth2p0.DepositData, network string) ([]by
 		return ddList[i].PubKey < ddList[j].PubKey
 	})
 
-	bytes, err := json.MarshalIndent(ddList, "", " ")
-	if err != nil {
-		return nil, errors.Wrap(err, "marshal deposit data")
-	}
-
-	return bytes, nil
+	return ddList, nil
 }
 
 // getDepositDomain returns the deposit signature domain.
@@ -175,11 +215,12 @@ func GetMessageSigningRoot(msg eth2p0.DepositMessage, network string) ([32]byte,
 	return resp, nil
 }
 
-// withdrawalCredsFromAddr returns the Withdrawal Credentials corresponding to a '0x01' Ethereum withdrawal address.
-func withdrawalCredsFromAddr(addr string) ([32]byte, error) {
+// withdrawalCredsFromAddr returns the Withdrawal Credentials corresponding to an Ethereum withdrawal address
+// with the provided '0x01' or '0x02' prefix.
+func withdrawalCredsFromAddr(addr string, prefix []byte) ([32]byte, error) {
 	// Check for validity of address.
 	if _, err := eth2util.ChecksumAddress(addr); err != nil {
-		return [32]byte{}, errors.Wrap(err, "invalid withdrawal address", z.Str("addr", addr))
+		return [32]byte{}, errors.Wrap(err, "invalid withdrawal address", z.Redact(z.Str("addr", addr)))
 	}
 
 	addrBytes, err := hex.DecodeString(strings.TrimPrefix(addr, "0x"))
@@ -188,8 +229,8 @@ func withdrawalCredsFromAddr(addr string) ([32]byte, error) {
 	}
 
 	var creds [32]byte
-	copy(creds[0:], eth1AddressWithdrawalPrefix) // Add 1 byte prefix.
-	copy(creds[12:], addrBytes)                  // Add 20 bytes of ethereum address suffix.
+	copy(creds[0:], prefix)     // Add 1 byte prefix.
+	copy(creds[12:], addrBytes) // Add 20 bytes of ethereum address suffix.
 
 	return creds, nil
 }
@@ -230,6 +271,35 @@ func VerifyDepositAmounts(amounts []eth2p0.Gwei) error {
 	return nil
 }
 
+// VerifyCompoundingDepositAmounts verifies partial deposits rules for compounding validators.
+// Each amount must be at least 1ETH and the amounts must sum up to between 32ETH and 2048ETH,
+// allowing deposits above 32ETH to be split into an activation deposit and top-ups.
+func VerifyCompoundingDepositAmounts(amounts []eth2p0.Gwei) error {
+	if len(amounts) == 0 {
+		// If no partial amounts specified, the implementation shall default to 32ETH.
+		return nil
+	}
+
+	var sum eth2p0.Gwei
+	for _, amount := range amounts {
+		if amount < MinDepositAmount {
+			return errors.New("each partial deposit amount must be greater than 1ETH", z.U64("amount", uint64(amount)))
+		}
+
+		sum += amount
+	}
+
+	if sum < MaxDepositAmount {
+		return errors.New("sum of compounding deposit amounts must be at least 32ETH", z.U64("sum", uint64(sum)))
+	}
+
+	if sum > MaxCompoundingDepositAmount {
+		return errors.New("sum of compounding deposit amounts must not exceed 2048ETH", z.U64("sum", uint64(sum)))
+	}
+
+	return nil
+}
+
 // EthsToGweis converts amounts from []int (ETH) to []eth2p0.Gwei.
 // For verification, please see VerifyDepositAmounts().
 func EthsToGweis(ethAmounts []int) []eth2p0.Gwei {
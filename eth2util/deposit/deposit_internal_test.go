@@ -11,10 +11,17 @@ import (
 
 func TestWithdrawalCredentials(t *testing.T) {
 	expectedWithdrawalCreds := "010000000000000000000000c0404ed740a69d11201f5ed297c5732f562c6e4e"
-	creds, err := withdrawalCredsFromAddr("0xc0404ed740a69d11201f5ed297c5732f562c6e4e")
+	creds, err := withdrawalCredsFromAddr("0xc0404ed740a69d11201f5ed297c5732f562c6e4e", eth1AddressWithdrawalPrefix)
 	require.NoError(t, err)
 
 	credsHex := hex.EncodeToString(creds[:])
 
 	require.Equal(t, expectedWithdrawalCreds, credsHex)
+
+	t.Run("compounding", func(t *testing.T) {
+		creds, err := withdrawalCredsFromAddr("0xc0404ed740a69d11201f5ed297c5732f562c6e4e", compoundingWithdrawalPrefix)
+		require.NoError(t, err)
+
+		require.Equal(t, "020000000000000000000000c0404ed740a69d11201f5ed297c5732f562c6e4e", hex.EncodeToString(creds[:]))
+	})
 }
@@ -46,6 +46,32 @@ func TestNewMessage(t *testing.T) {
 	})
 }
 
+func TestNewCompoundingMessage(t *testing.T) {
+	const privKey = "01477d4bfbbcebe1fef8d4d6f624ecbb6e3178558bb1b0d6286c816c66842a6d"
+	const addr = "0x321dcb529f3945bc94fecea9d3bc5caf35253b94"
+	amount := deposit.MaxCompoundingDepositAmount
+	_, pubKey := GetKeys(t, privKey)
+
+	msg, err := deposit.NewCompoundingMessage(pubKey, addr, amount)
+
+	require.NoError(t, err)
+	require.Equal(t, pubKey, msg.PublicKey)
+	require.Equal(t, amount, msg.Amount)
+	require.EqualValues(t, 0x02, msg.WithdrawalCredentials[0])
+
+	t.Run("amount below minimum", func(t *testing.T) {
+		_, err := deposit.NewCompoundingMessage(pubKey, addr, deposit.MinDepositAmount-1)
+
+		require.ErrorContains(t, err, "deposit message minimum amount must be >= 1ETH")
+	})
+
+	t.Run("amount above maximum", func(t *testing.T) {
+		_, err := deposit.NewCompoundingMessage(pubKey, addr, deposit.MaxCompoundingDepositAmount+1)
+
+		require.ErrorContains(t, err, "compounding deposit message maximum amount must <= 2048ETH")
+	})
+}
+
 func TestMarshalDepositData(t *testing.T) {
 	datas := mustGenerateDepositDatas(t, deposit.MaxDepositAmount)
 
@@ -124,6 +150,41 @@ func TestVerifyDepositAmounts(t *testing.T) {
 	})
 }
 
+func TestVerifyCompoundingDepositAmounts(t *testing.T) {
+	t.Run("empty slice", func(t *testing.T) {
+		require.NoError(t, deposit.VerifyCompoundingDepositAmounts(nil))
+	})
+
+	t.Run("valid amounts", func(t *testing.T) {
+		amounts := deposit.EthsToGweis([]int{1, 31, 32, 1984})
+
+		require.NoError(t, deposit.VerifyCompoundingDepositAmounts(amounts))
+	})
+
+	t.Run("each amount is greater than 1ETH", func(t *testing.T) {
+		amounts := []eth2p0.Gwei{
+			eth2p0.Gwei(500000000),   // 0.5ETH
+			eth2p0.Gwei(31500000000), // 31.5ETH
+		}
+
+		err := deposit.VerifyCompoundingDepositAmounts(amounts)
+
+		require.ErrorContains(t, err, "each partial deposit amount must be greater than 1ETH")
+	})
+
+	t.Run("total sum below 32ETH", func(t *testing.T) {
+		err := deposit.VerifyCompoundingDepositAmounts(deposit.EthsToGweis([]int{8, 16}))
+
+		require.ErrorContains(t, err, "sum of compounding deposit amounts must be at least 32ETH")
+	})
+
+	t.Run("total sum above 2048ETH", func(t *testing.T) {
+		err := deposit.VerifyCompoundingDepositAmounts(deposit.EthsToGweis([]int{2048, 1}))
+
+		require.ErrorContains(t, err, "sum of compounding deposit amounts must not exceed 2048ETH")
+	})
+}
+
 func TestEthsToGweis(t *testing.T) {
 	t.Run("nil slice", func(t *testing.T) {
 		slice := deposit.EthsToGweis(nil)
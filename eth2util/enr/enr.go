@@ -32,6 +32,12 @@ const (
 	keyTCP = "tcp"
 	// keyUDP is the key used to store the UDP port in the record.
 	keyUDP = "udp"
+	// keyIP6 is the key used to store the IP v6 address in the record.
+	keyIP6 = "ip6"
+	// keyTCP6 is the key used to store the IP v6 specific TCP port in the record.
+	keyTCP6 = "tcp6"
+	// keyDNS is the non-standard key used to store the DNS hostname in the record.
+	keyDNS = "dns"
 )
 
 // Parse parses the given base64 encoded string into a record.
@@ -64,6 +70,7 @@ func Parse(enrStr string) (Record, error) {
 
 	r := Record{
 		Signature: elements[0],
+		seq:       fromBigEndian(elements[1]),
 		kvs:       make(map[string][]byte),
 	}
 
@@ -99,51 +106,87 @@ func Parse(enrStr string) (Record, error) {
 	return r, nil
 }
 
-// Option is a function that sets a key-value pair in the record.
-type Option func(elements map[string][]byte)
+// Option is a function that sets a key-value pair or the sequence number of the record.
+type Option func(r *Record)
 
 // WithIP returns an option that sets the IP address of the record.
 func WithIP(ip net.IP) Option {
-	return func(kvs map[string][]byte) {
-		kvs[keyIP] = ip.To4()
+	return func(r *Record) {
+		r.kvs[keyIP] = ip.To4()
 	}
 }
 
 // WithTCP returns an option that sets the TCP port of the record.
 func WithTCP(port int) Option {
-	return func(kvs map[string][]byte) {
-		kvs[keyTCP] = toBigEndian(port)
+	return func(r *Record) {
+		r.kvs[keyTCP] = toBigEndian(port)
 	}
 }
 
 // WithUDP returns an option that sets the TCP port of the record.
 func WithUDP(port int) Option {
-	return func(kvs map[string][]byte) {
-		kvs[keyUDP] = toBigEndian(port)
+	return func(r *Record) {
+		r.kvs[keyUDP] = toBigEndian(port)
+	}
+}
+
+// WithIP6 returns an option that sets the IP v6 address of the record.
+func WithIP6(ip net.IP) Option {
+	return func(r *Record) {
+		r.kvs[keyIP6] = ip.To16()
+	}
+}
+
+// WithTCP6 returns an option that sets the IP v6 specific TCP port of the record.
+func WithTCP6(port int) Option {
+	return func(r *Record) {
+		r.kvs[keyTCP6] = toBigEndian(port)
+	}
+}
+
+// WithDNS returns an option that sets the DNS hostname of the record.
+// Note this is a charon specific key that other ENR implementations ignore.
+func WithDNS(host string) Option {
+	return func(r *Record) {
+		r.kvs[keyDNS] = []byte(host)
+	}
+}
+
+// WithSeq returns an option that sets the sequence number of the record.
+// The sequence number must be increased whenever the record is updated, e.g. when advertised addresses rotate.
+func WithSeq(seq int) Option {
+	return func(r *Record) {
+		r.seq = seq
 	}
 }
 
 // New returns a new enr record for the given private key and provided options.
 func New(privkey *k1.PrivateKey, opts ...Option) (Record, error) {
-	kvs := map[string][]byte{
-		keyID:        []byte(valID),
-		keySecp256k1: privkey.PubKey().SerializeCompressed(),
+	return NewWithSigner(k1util.NewSigner(privkey), opts...)
+}
+
+// NewWithSigner returns a new enr record signed by the given signer and provided options.
+func NewWithSigner(signer k1util.Signer, opts ...Option) (Record, error) {
+	r := Record{
+		PubKey: signer.PubKey(),
+		kvs: map[string][]byte{
+			keyID:        []byte(valID),
+			keySecp256k1: signer.PubKey().SerializeCompressed(),
+		},
 	}
 
 	for _, opt := range opts {
-		opt(kvs)
+		opt(&r)
 	}
 
-	sig, err := sign(privkey, kvs)
+	sig, err := sign(signer, r.seq, r.kvs)
 	if err != nil {
 		return Record{}, err
 	}
 
-	return Record{
-		PubKey:    privkey.PubKey(),
-		Signature: sig,
-		kvs:       kvs,
-	}, nil
+	r.Signature = sig
+
+	return r, nil
 }
 
 // Record represents an Ethereum Node Record.
@@ -153,9 +196,15 @@ type Record struct {
 	// Signature of the record.
 	Signature []byte
 
+	seq int
 	kvs map[string][]byte
 }
 
+// Seq returns the sequence number of the record.
+func (r Record) Seq() int {
+	return r.seq
+}
+
 // IP returns the IP address of the record or false if not present.
 func (r Record) IP() (net.IP, bool) {
 	ip, ok := r.kvs[keyIP]
@@ -174,20 +223,39 @@ func (r Record) UDP() (int, bool) {
 	return fromBigEndian(b), ok
 }
 
+// IP6 returns the IP v6 address of the record or false if not present.
+func (r Record) IP6() (net.IP, bool) {
+	ip, ok := r.kvs[keyIP6]
+	return ip, ok
+}
+
+// TCP6 returns the IP v6 specific TCP port of the record or false if not present.
+// Note that the TCP port also applies to IP v6 if this isn't present.
+func (r Record) TCP6() (int, bool) {
+	b, ok := r.kvs[keyTCP6]
+	return fromBigEndian(b), ok
+}
+
+// DNS returns the DNS hostname of the record or false if not present.
+func (r Record) DNS() (string, bool) {
+	b, ok := r.kvs[keyDNS]
+	return string(b), ok
+}
+
 // String returns the base64 encoded string representation of the record.
 func (r Record) String() string {
-	return "enr:" + base64.RawURLEncoding.EncodeToString(encodeElements(r.Signature, r.kvs))
+	return "enr:" + base64.RawURLEncoding.EncodeToString(encodeElements(r.Signature, r.seq, r.kvs))
 }
 
 // encodeElements returns the RLP encoding of a minimal set of record elements including optional signature.
-func encodeElements(signature []byte, kvs map[string][]byte) []byte {
+func encodeElements(signature []byte, seq int, kvs map[string][]byte) []byte {
 	var keys []string
 	for k := range kvs {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
-	elements := [][]byte{toBigEndian(0)} // Sequence number=0
+	elements := [][]byte{toBigEndian(seq)}
 	for _, key := range keys {
 		elements = append(elements, []byte(key), kvs[key])
 	}
@@ -200,12 +268,12 @@ func encodeElements(signature []byte, kvs map[string][]byte) []byte {
 }
 
 // sign returns a enr record signature.
-func sign(privkey *k1.PrivateKey, kvs map[string][]byte) ([]byte, error) {
+func sign(signer k1util.Signer, seq int, kvs map[string][]byte) ([]byte, error) {
 	h := sha3.NewLegacyKeccak256()
-	_, _ = h.Write(encodeElements(nil, kvs))
+	_, _ = h.Write(encodeElements(nil, seq, kvs))
 	digest := h.Sum(nil)
 
-	sig, err := k1util.Sign(privkey, digest)
+	sig, err := signer.Sign(digest)
 	if err != nil {
 		return nil, errors.Wrap(err, "sign enr")
 	}
@@ -25,7 +25,7 @@ func TestBackwardsENR(t *testing.T) {
 		require.NoError(t, err)
 
 		// Encode ENR string with padding which is supported by charon versions v0.9.0 or earlier.
-		enrStr := "enr:" + base64.URLEncoding.EncodeToString(encodeElements(record.Signature, record.kvs))
+		enrStr := "enr:" + base64.URLEncoding.EncodeToString(encodeElements(record.Signature, record.seq, record.kvs))
 
 		_, err = Parse(enrStr)
 		require.NoError(t, err)
@@ -95,6 +95,38 @@ func TestIPTCP(t *testing.T) {
 	require.Equal(t, expectUDP, udp)
 }
 
+func TestIP6DNSSeq(t *testing.T) {
+	privkey, err := k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	expectIP6 := net.ParseIP("2001:db8::1")
+	expectTCP6 := 8001
+	expectDNS := "charon.example.com"
+
+	r1, err := enr.New(privkey, enr.WithIP6(expectIP6), enr.WithTCP6(expectTCP6), enr.WithDNS(expectDNS), enr.WithSeq(3))
+	require.NoError(t, err)
+
+	r2, err := enr.Parse(r1.String())
+	require.NoError(t, err)
+	require.Equal(t, r1.String(), r2.String())
+	require.Equal(t, 3, r2.Seq())
+
+	ip6, ok := r2.IP6()
+	require.True(t, ok)
+	require.Equal(t, expectIP6, ip6)
+
+	tcp6, ok := r2.TCP6()
+	require.True(t, ok)
+	require.Equal(t, expectTCP6, tcp6)
+
+	dns, ok := r2.DNS()
+	require.True(t, ok)
+	require.Equal(t, expectDNS, dns)
+
+	_, ok = r2.IP()
+	require.False(t, ok)
+}
+
 func TestNew(t *testing.T) {
 	privkey := testutil.GenerateInsecureK1Key(t, 0)
 
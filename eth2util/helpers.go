@@ -70,11 +70,11 @@ func EpochFromSlot(ctx context.Context, eth2Cl eth2client.SlotsPerEpochProvider,
 // ChecksumAddress returns an EIP55-compliant 0xhex representation of the 0xhex ethereum address.
 func ChecksumAddress(address string) (string, error) {
 	if !strings.HasPrefix(address, "0x") || len(address) != 2+20*2 {
-		return "", errors.New("invalid ethereum address", z.Str("address", address))
+		return "", errors.New("invalid ethereum address", z.Redact(z.Str("address", address)))
 	}
 	b, err := hex.DecodeString(address[2:])
 	if err != nil {
-		return "", errors.New("invalid ethereum hex address", z.Str("address", address))
+		return "", errors.New("invalid ethereum hex address", z.Redact(z.Str("address", address)))
 	}
 
 	return checksumAddressBytes(b), nil
@@ -42,7 +42,7 @@ func (c Client) ImportKeystores(ctx context.Context, keystores []keystore.Keysto
 
 	keymanagerURL, err := url.ParseRequestURI(c.baseURL)
 	if err != nil {
-		return errors.Wrap(err, "parse address", z.Str("addr", c.baseURL))
+		return errors.Wrap(err, "parse address", z.Redact(z.Str("addr", c.baseURL)))
 	}
 
 	keystoresURL := keymanagerURL.JoinPath("/eth/v1/keystores")
@@ -59,7 +59,7 @@ func (c Client) ImportKeystores(ctx context.Context, keystores []keystore.Keysto
 func (c Client) VerifyConnection(ctx context.Context) error {
 	keymanagerURL, err := url.Parse(c.baseURL)
 	if err != nil {
-		return errors.Wrap(err, "parse address", z.Str("addr", c.baseURL))
+		return errors.Wrap(err, "parse address", z.Redact(z.Str("addr", c.baseURL)))
 	}
 
 	var d net.Dialer
@@ -68,7 +68,7 @@ func (c Client) VerifyConnection(ctx context.Context) error {
 
 	conn, err := d.DialContext(ctx, "tcp", keymanagerURL.Host)
 	if err != nil {
-		return errors.Wrap(err, "cannot ping address", z.Str("addr", c.baseURL))
+		return errors.Wrap(err, "cannot ping address", z.Redact(z.Str("addr", c.baseURL)))
 	}
 	_ = conn.Close()
 
@@ -0,0 +1,96 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package keystore
+
+import (
+	"math/bits"
+	"testing"
+
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// KDFPbkdf2 is the PBKDF2 key derivation function, the default.
+	KDFPbkdf2 = "pbkdf2"
+	// KDFScrypt is the scrypt key derivation function.
+	KDFScrypt = "scrypt"
+
+	// DefaultCostPower is the EIP-2335 default key derivation cost (c or n) of 2^18.
+	DefaultCostPower = 18
+
+	// minCostPower is the minimum allowed key derivation cost power, below which keystores are trivially brute-forced.
+	minCostPower = 10
+	// maxCostPower is the maximum allowed key derivation cost power, above which scrypt requires more than 1GB of memory.
+	maxCostPower = 20
+)
+
+// Options defines the key derivation function and cost used when encrypting keystores.
+// The zero value results in the EIP-2335 defaults of pbkdf2 with cost 2^18.
+type Options struct {
+	// KDF is the key derivation function; pbkdf2 or scrypt.
+	KDF string
+	// CostPower is the key derivation cost (pbkdf2 iterations or scrypt n) as a power of 2.
+	CostPower uint
+}
+
+// Validate returns an error if the options are invalid.
+func (o Options) Validate() error {
+	if o.KDF != "" && o.KDF != KDFPbkdf2 && o.KDF != KDFScrypt {
+		return errors.New("invalid keystore kdf, must be pbkdf2 or scrypt", z.Str("kdf", o.KDF))
+	}
+
+	if o.CostPower != 0 && (o.CostPower < minCostPower || o.CostPower > maxCostPower) {
+		return errors.New("invalid keystore kdf cost power",
+			z.Uint("cost_power", o.CostPower), z.Int("min", minCostPower), z.Int("max", maxCostPower))
+	}
+
+	return nil
+}
+
+// EncryptorOptions returns the keystore encryptor options, see Encrypt.
+func (o Options) EncryptorOptions() []keystorev4.Option {
+	var opts []keystorev4.Option
+	if o.KDF != "" {
+		opts = append(opts, keystorev4.WithCipher(o.KDF))
+	}
+
+	if o.CostPower != 0 {
+		// WithCost requires a testing.T to discourage lowering security, we only allow validated cost powers.
+		opts = append(opts, keystorev4.WithCost(new(testing.T), o.CostPower))
+	}
+
+	return opts
+}
+
+// KDFOptions returns the key derivation function and cost used by the encrypted keystore.
+func KDFOptions(store Keystore) (Options, error) {
+	kdf, ok := store.Crypto["kdf"].(map[string]any)
+	if !ok {
+		return Options{}, errors.New("keystore missing kdf")
+	}
+
+	function, _ := kdf["function"].(string)
+	params, _ := kdf["params"].(map[string]any)
+
+	var cost float64
+	switch function {
+	case KDFPbkdf2:
+		cost, _ = params["c"].(float64)
+	case KDFScrypt:
+		cost, _ = params["n"].(float64)
+	default:
+		return Options{}, errors.New("unsupported keystore kdf", z.Str("kdf", function))
+	}
+
+	if cost < 1 || uint64(cost)&(uint64(cost)-1) != 0 {
+		return Options{}, errors.New("keystore kdf cost not a power of 2", z.Str("kdf", function))
+	}
+
+	return Options{
+		KDF:       function,
+		CostPower: uint(bits.TrailingZeros64(uint64(cost))),
+	}, nil
+}
@@ -13,7 +13,7 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -23,6 +23,7 @@ import (
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/forkjoin"
+	"github.com/obolnetwork/charon/app/memlock"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster/manifest"
 	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
@@ -73,6 +74,16 @@ func StoreKeys(secrets []tbls.PrivateKey, dir string) error {
 	return storeKeysInternal(secrets, dir, "keystore-%d.json")
 }
 
+// StoreKeysWithOptions is identical to StoreKeys but encrypts the keystores using the
+// provided key derivation function and cost options.
+func StoreKeysWithOptions(secrets []tbls.PrivateKey, dir string, opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	return storeKeysInternal(secrets, dir, "keystore-%d.json", opts.EncryptorOptions()...)
+}
+
 func storeKeysInternal(secrets []tbls.PrivateKey, dir string, filenameFmt string, opts ...keystorev4.Option) error {
 	if err := checkDir(dir); err != nil {
 		return err
@@ -86,33 +97,7 @@ func storeKeysInternal(secrets []tbls.PrivateKey, dir string, filenameFmt string
 	fork, join, cancel := forkjoin.New(
 		context.Background(),
 		func(_ context.Context, d data) (any, error) {
-			filename := path.Join(dir, fmt.Sprintf(filenameFmt, d.index))
-
-			password, err := randomHex32()
-			if err != nil {
-				return nil, err
-			}
-
-			store, err := Encrypt(d.secret, password, rand.Reader, opts...)
-			if err != nil {
-				return nil, errors.Wrap(err, "encryption error", z.Str("filename", filename))
-			}
-
-			b, err := json.MarshalIndent(store, "", " ")
-			if err != nil {
-				return nil, errors.Wrap(err, "marshal keystore", z.Str("filename", filename))
-			}
-
-			//nolint:gosec // File needs to be read-only for everybody
-			if err := os.WriteFile(filename, b, 0o444); err != nil {
-				return nil, errors.Wrap(err, "write keystore", z.Str("filename", filename))
-			}
-
-			if err := storePassword(filename, password); err != nil {
-				return nil, errors.Wrap(err, "store password", z.Str("filename", filename))
-			}
-
-			return nil, nil //nolint:nilnil
+			return nil, storeKey(d.secret, filepath.Join(dir, fmt.Sprintf(filenameFmt, d.index)), opts...)
 		},
 		forkjoin.WithWorkers(loadStoreWorkers),
 	)
@@ -134,6 +119,60 @@ func storeKeysInternal(secrets []tbls.PrivateKey, dir string, filenameFmt string
 	return err
 }
 
+// StoreKey stores the secret in a dir/keystore-%d.json EIP 2335 Keystore file with the index
+// and a new random password stored in dir/keystore-%d.txt. It allows storing large key sets one key at a time.
+//
+// Note it doesn't ensure the folder dir exists.
+func StoreKey(secret tbls.PrivateKey, dir string, index int) error {
+	if err := checkDir(dir); err != nil {
+		return err
+	}
+
+	return storeKey(secret, filepath.Join(dir, fmt.Sprintf("keystore-%d.json", index)))
+}
+
+// StoreKeyInsecure is identical to StoreKey but stores the secret in a dir/keystore-insecure-%d.json file.
+//
+// 🚨 The keystore is insecure and should only be used for testing large validator sets
+// as it speeds up encryption and decryption at the cost of security.
+func StoreKeyInsecure(secret tbls.PrivateKey, dir string, index int, _ confirmInsecure) error {
+	if err := checkDir(dir); err != nil {
+		return err
+	}
+
+	return storeKey(secret, filepath.Join(dir, fmt.Sprintf("keystore-insecure-%d.json", index)),
+		keystorev4.WithCost(new(testing.T), insecureCost))
+}
+
+// storeKey stores the secret in the EIP 2335 Keystore file with a new random password stored in the adjacent .txt file.
+func storeKey(secret tbls.PrivateKey, filename string, opts ...keystorev4.Option) error {
+	password, err := randomHex32()
+	if err != nil {
+		return err
+	}
+
+	store, err := Encrypt(secret, password, rand.Reader, opts...)
+	if err != nil {
+		return errors.Wrap(err, "encryption error", z.Str("filename", filename))
+	}
+
+	b, err := json.MarshalIndent(store, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal keystore", z.Str("filename", filename))
+	}
+
+	//nolint:gosec // File needs to be read-only for everybody
+	if err := os.WriteFile(filename, b, 0o444); err != nil {
+		return errors.Wrap(err, "write keystore", z.Str("filename", filename))
+	}
+
+	if err := storePassword(filename, password); err != nil {
+		return errors.Wrap(err, "store password", z.Str("filename", filename))
+	}
+
+	return nil
+}
+
 // Keystore json file representation as a Go struct.
 type Keystore struct {
 	Crypto      map[string]any `json:"crypto"`
@@ -169,6 +208,26 @@ func Encrypt(secret tbls.PrivateKey, password string, random io.Reader,
 	}, nil
 }
 
+// DecryptFile returns the secret of the EIP-2335 keystore file encrypted with the password.
+func DecryptFile(keyFile string, password string) (tbls.PrivateKey, error) {
+	b, err := os.ReadFile(keyFile)
+	if err != nil {
+		return tbls.PrivateKey{}, errors.Wrap(err, "read keystore", z.Str("filename", keyFile))
+	}
+
+	var store Keystore
+	if err := json.Unmarshal(b, &store); err != nil {
+		return tbls.PrivateKey{}, errors.Wrap(err, "unmarshal keystore", z.Str("filename", keyFile))
+	}
+
+	secret, err := decrypt(store, password)
+	if err != nil {
+		return tbls.PrivateKey{}, errors.Wrap(err, "keystore decryption", z.Str("filename", keyFile))
+	}
+
+	return secret, nil
+}
+
 // decrypt returns the secret from the encrypted (empty password) Keystore.
 func decrypt(store Keystore, password string) (tbls.PrivateKey, error) {
 	decryptor := keystorev4.New()
@@ -177,6 +236,8 @@ func decrypt(store Keystore, password string) (tbls.PrivateKey, error) {
 		return tbls.PrivateKey{}, errors.Wrap(err, "decrypt keystore")
 	}
 
+	defer memlock.Zero(secretBytes)
+
 	return tblsconv.PrivkeyFromBytes(secretBytes)
 }
 
@@ -194,8 +255,7 @@ func loadPassword(keyFile string) (string, error) {
 		return "", errors.New("keystore password file not found " + keyFile)
 	}
 
-	passwordFile := strings.Replace(keyFile, ".json", ".txt", 1)
-	b, err := os.ReadFile(passwordFile)
+	b, err := os.ReadFile(passwordFile(keyFile))
 	if err != nil {
 		return "", errors.Wrap(err, "read password file")
 	}
@@ -205,9 +265,7 @@ func loadPassword(keyFile string) (string, error) {
 
 // storePassword stores a password to the Keystore's associated password file.
 func storePassword(keyFile string, password string) error {
-	passwordFile := strings.Replace(keyFile, ".json", ".txt", 1)
-
-	err := os.WriteFile(passwordFile, []byte(password), 0o400)
+	err := os.WriteFile(passwordFile(keyFile), []byte(password), 0o400)
 	if err != nil {
 		return errors.Wrap(err, "write password file")
 	}
@@ -215,6 +273,11 @@ func storePassword(keyFile string, password string) error {
 	return nil
 }
 
+// passwordFile returns the path of the Keystore's associated password file, replacing the .json extension with .txt.
+func passwordFile(keyFile string) string {
+	return strings.TrimSuffix(keyFile, ".json") + ".txt"
+}
+
 // randomHex32 returns a random 32 character hex string. It uses crypto/rand.
 func randomHex32() (string, error) {
 	b := make([]byte, 16)
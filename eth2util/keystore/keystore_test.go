@@ -5,6 +5,7 @@ package keystore_test
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"os"
@@ -191,6 +192,32 @@ func TestStoreLoadSequentialNonCharonNames(t *testing.T) {
 	require.Empty(t, actual)
 }
 
+func TestStoreKeyFilesByIndex(t *testing.T) {
+	dir := t.TempDir()
+
+	var secrets []tbls.PrivateKey
+	for _, idx := range []int{2, 0} {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+
+		require.NoError(t, keystore.StoreKeyInsecure(secret, dir, idx, keystore.ConfirmInsecureKeys))
+		secrets = append(secrets, secret)
+	}
+
+	files, err := keystore.FilesByIndex(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Equal(t, filepath.Join(dir, "keystore-insecure-2.json"), files[2])
+
+	keyFile, err := keystore.LoadFile(files[0])
+	require.NoError(t, err)
+	require.Equal(t, secrets[1], keyFile.PrivateKey)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keystore-foo.json"), nil, 0o644))
+	_, err = keystore.FilesByIndex(dir)
+	require.ErrorContains(t, err, "unknown keystore index")
+}
+
 func TestLoadEmpty(t *testing.T) {
 	_, err := keystore.LoadFilesUnordered(".")
 	require.Error(t, err)
@@ -205,6 +232,41 @@ func TestLoadScrypt(t *testing.T) {
 	require.Equal(t, "10b16fc552aa607fa1399027f7b86ab789077e470b5653b338693dc2dde02468", hex.EncodeToString(keyfiles[0].PrivateKey[:]))
 }
 
+func TestStoreKeysWithOptions(t *testing.T) {
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+
+	for _, opts := range []keystore.Options{
+		{KDF: keystore.KDFPbkdf2, CostPower: 10},
+		{KDF: keystore.KDFScrypt, CostPower: 10},
+	} {
+		t.Run(opts.KDF, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, keystore.StoreKeysWithOptions([]tbls.PrivateKey{secret}, dir, opts))
+
+			b, err := os.ReadFile(filepath.Join(dir, "keystore-0.json"))
+			require.NoError(t, err)
+
+			var store keystore.Keystore
+			require.NoError(t, json.Unmarshal(b, &store))
+
+			actual, err := keystore.KDFOptions(store)
+			require.NoError(t, err)
+			require.Equal(t, opts, actual)
+
+			keyFiles, err := keystore.LoadFilesUnordered(dir)
+			require.NoError(t, err)
+			require.Equal(t, []tbls.PrivateKey{secret}, keyFiles.Keys())
+		})
+	}
+
+	err = keystore.StoreKeysWithOptions([]tbls.PrivateKey{secret}, t.TempDir(), keystore.Options{KDF: "argon2"})
+	require.ErrorContains(t, err, "invalid keystore kdf")
+
+	err = keystore.StoreKeysWithOptions([]tbls.PrivateKey{secret}, t.TempDir(), keystore.Options{CostPower: 4})
+	require.ErrorContains(t, err, "invalid keystore kdf cost power")
+}
+
 func TestSequencedKeys(t *testing.T) {
 	tests := []struct {
 		name     string
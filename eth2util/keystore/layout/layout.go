@@ -0,0 +1,369 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package layout provides functions to store and load EIP-2335 keystores in the directory layouts
+// expected by different validator clients and remote signers, simplifying migrations between them.
+package layout
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/web3signer"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// Format is a keystore directory layout.
+type Format string
+
+const (
+	// FormatEIP2335 is charon's layout: dir/keystore-N.json keystores with dir/keystore-N.txt password files.
+	FormatEIP2335 Format = "eip2335"
+	// FormatWeb3Signer is the Web3Signer layout: dir/keys/keystore-N.yaml key config files referencing
+	// dir/keystores/keystore-N.json keystores and dir/keystores/keystore-N.txt password files.
+	FormatWeb3Signer Format = "web3signer"
+	// FormatLighthouse is the Lighthouse validator client layout: dir/validators/0x<pubkey>/voting-keystore.json
+	// keystores with dir/secrets/0x<pubkey> password files.
+	FormatLighthouse Format = "lighthouse"
+	// FormatTeku is the Teku validator client layout: dir/keys/keystore-N.json keystores with
+	// dir/passwords/keystore-N.txt password files.
+	FormatTeku Format = "teku"
+	// FormatNimbus is the Nimbus validator client layout: dir/validators/0x<pubkey>/keystore.json keystores
+	// with dir/secrets/0x<pubkey> password files.
+	FormatNimbus Format = "nimbus"
+	// FormatPrysm is the layout imported by `prysm validator accounts import`: dir/keys/keystore-N.json
+	// keystores all encrypted with the password in dir/password.txt.
+	FormatPrysm Format = "prysm"
+)
+
+// Formats returns all supported formats.
+func Formats() []Format {
+	return []Format{FormatEIP2335, FormatWeb3Signer, FormatLighthouse, FormatTeku, FormatNimbus, FormatPrysm}
+}
+
+// Validate returns an error if the format isn't supported.
+func (f Format) Validate() error {
+	for _, format := range Formats() {
+		if f == format {
+			return nil
+		}
+	}
+
+	return errors.New("unsupported keystore format", z.Str("format", string(f)))
+}
+
+// Store encrypts the secrets with new random passwords and stores them in dir using the format's layout.
+func Store(secrets []tbls.PrivateKey, dir string, format Format, opts keystore.Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatEIP2335:
+		if err := mkdirNoKeystores(dir); err != nil {
+			return err
+		}
+
+		return keystore.StoreKeysWithOptions(secrets, dir, opts)
+	case FormatWeb3Signer:
+		keystoreDir := filepath.Join(dir, "keystores")
+		if err := mkdirNoKeystores(keystoreDir); err != nil {
+			return err
+		}
+
+		if err := keystore.StoreKeysWithOptions(secrets, keystoreDir, opts); err != nil {
+			return err
+		}
+
+		_, err := web3signer.WriteKeyConfigs(dir, keystoreDir)
+
+		return err
+	case FormatLighthouse:
+		return storeByPubkey(secrets, dir, "voting-keystore.json", opts)
+	case FormatNimbus:
+		return storeByPubkey(secrets, dir, "keystore.json", opts)
+	case FormatTeku:
+		return storeFiles(secrets, opts, func(i int, _ string) (string, string) {
+			return filepath.Join(dir, "keys", fmt.Sprintf("keystore-%d.json", i)),
+				filepath.Join(dir, "passwords", fmt.Sprintf("keystore-%d.txt", i))
+		})
+	case FormatPrysm:
+		if err := mkdirNoKeystores(filepath.Join(dir, "keys")); err != nil {
+			return err
+		}
+
+		password, err := randomPassword()
+		if err != nil {
+			return err
+		}
+
+		passwordFile := filepath.Join(dir, "password.txt")
+		if err := os.WriteFile(passwordFile, []byte(password), 0o400); err != nil {
+			return errors.Wrap(err, "write password file")
+		}
+
+		return storeFilesWithPassword(secrets, opts, password, func(i int, _ string) string {
+			return filepath.Join(dir, "keys", fmt.Sprintf("keystore-%d.json", i))
+		})
+	default:
+		return format.Validate()
+	}
+}
+
+// Load returns the decrypted secrets stored in dir using the format's layout.
+// Secrets are ordered by keystore index if available, otherwise by filename.
+func Load(dir string, format Format) ([]tbls.PrivateKey, error) {
+	switch format {
+	case FormatEIP2335:
+		keyFiles, err := keystore.LoadFilesUnordered(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		return keyFiles.SequencedKeys()
+	case FormatWeb3Signer:
+		return loadWeb3Signer(dir)
+	case FormatLighthouse:
+		return loadByPubkey(dir, "voting-keystore.json")
+	case FormatNimbus:
+		return loadByPubkey(dir, "keystore.json")
+	case FormatTeku:
+		return loadFiles(filepath.Join(dir, "keys", "*.json"), func(keyFile string) (string, error) {
+			return filepath.Join(dir, "passwords", strings.TrimSuffix(filepath.Base(keyFile), ".json")+".txt"), nil
+		})
+	case FormatPrysm:
+		return loadFiles(filepath.Join(dir, "keys", "*.json"), func(string) (string, error) {
+			return filepath.Join(dir, "password.txt"), nil
+		})
+	default:
+		return nil, format.Validate()
+	}
+}
+
+// mkdirNoKeystores creates the directory if it doesn't exist and returns an error if it already contains keystores.
+func mkdirNoKeystores(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(err, "mkdir")
+	}
+
+	existing, err := filepath.Glob(filepath.Join(dir, "keystore-*.json"))
+	if err != nil {
+		return errors.Wrap(err, "glob keystore files")
+	} else if len(existing) > 0 {
+		return errors.New("keystore files already exist", z.Str("dir", dir))
+	}
+
+	return nil
+}
+
+// storeByPubkey stores the keystores in dir/validators/0x<pubkey>/keystoreName with dir/secrets/0x<pubkey> password files.
+func storeByPubkey(secrets []tbls.PrivateKey, dir string, keystoreName string, opts keystore.Options) error {
+	return storeFiles(secrets, opts, func(_ int, pubkey string) (string, string) {
+		return filepath.Join(dir, "validators", pubkey, keystoreName), filepath.Join(dir, "secrets", pubkey)
+	})
+}
+
+// loadByPubkey loads the keystores in dir/validators/0x<pubkey>/keystoreName with dir/secrets/0x<pubkey> password files.
+func loadByPubkey(dir string, keystoreName string) ([]tbls.PrivateKey, error) {
+	return loadFiles(filepath.Join(dir, "validators", "*", keystoreName), func(keyFile string) (string, error) {
+		return filepath.Join(dir, "secrets", filepath.Base(filepath.Dir(keyFile))), nil
+	})
+}
+
+// storeFiles encrypts each secret with a new random password and writes the keystore and password files
+// to the paths returned by filenames given the secret index and 0x-prefixed public key.
+func storeFiles(secrets []tbls.PrivateKey, opts keystore.Options, filenames func(int, string) (string, string)) error {
+	for i, secret := range secrets {
+		pubkey, err := pubkeyHex(secret)
+		if err != nil {
+			return err
+		}
+
+		password, err := randomPassword()
+		if err != nil {
+			return err
+		}
+
+		keyFile, passwordFile := filenames(i, pubkey)
+		if err := writeKeystore(keyFile, secret, password, opts); err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(passwordFile), 0o700); err != nil {
+			return errors.Wrap(err, "mkdir")
+		}
+
+		if err := os.WriteFile(passwordFile, []byte(password), 0o400); err != nil {
+			return errors.Wrap(err, "write password file", z.Str("filename", passwordFile))
+		}
+	}
+
+	return nil
+}
+
+// storeFilesWithPassword encrypts each secret with the password and writes the keystore to the path returned by
+// filename given the secret index and 0x-prefixed public key.
+func storeFilesWithPassword(secrets []tbls.PrivateKey, opts keystore.Options, password string, filename func(int, string) string) error {
+	for i, secret := range secrets {
+		pubkey, err := pubkeyHex(secret)
+		if err != nil {
+			return err
+		}
+
+		if err := writeKeystore(filename(i, pubkey), secret, password, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeKeystore writes the secret as a keystore encrypted with the password to the file.
+func writeKeystore(keyFile string, secret tbls.PrivateKey, password string, opts keystore.Options) error {
+	if _, err := os.Stat(keyFile); err == nil {
+		return errors.New("keystore file already exists", z.Str("filename", keyFile))
+	}
+
+	store, err := keystore.Encrypt(secret, password, rand.Reader, opts.EncryptorOptions()...)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(store, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal keystore")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(keyFile), 0o755); err != nil {
+		return errors.Wrap(err, "mkdir")
+	}
+
+	//nolint:gosec // File needs to be read-only for everybody
+	if err := os.WriteFile(keyFile, b, 0o444); err != nil {
+		return errors.Wrap(err, "write keystore", z.Str("filename", keyFile))
+	}
+
+	return nil
+}
+
+// loadFiles decrypts the keystore files matching the glob pattern sorted by filename
+// using the passwords in the files returned by passwordFile.
+func loadFiles(pattern string, passwordFile func(keyFile string) (string, error)) ([]tbls.PrivateKey, error) {
+	keyFiles, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "glob keystore files")
+	} else if len(keyFiles) == 0 {
+		return nil, errors.New("no keystore files found", z.Str("pattern", pattern))
+	}
+	sort.Strings(keyFiles)
+
+	var secrets []tbls.PrivateKey
+	for _, keyFile := range keyFiles {
+		filename, err := passwordFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		secret, err := decryptFile(keyFile, filename)
+		if err != nil {
+			return nil, err
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
+
+// web3signerKeyConfig is a Web3Signer file-keystore key configuration file.
+type web3signerKeyConfig struct {
+	Type                 string `yaml:"type"`
+	KeystoreFile         string `yaml:"keystoreFile"`
+	KeystorePasswordFile string `yaml:"keystorePasswordFile"`
+}
+
+// loadWeb3Signer decrypts the keystores referenced by the file-keystore key config files in dir/keys.
+// Relative paths are resolved relative to dir.
+func loadWeb3Signer(dir string) ([]tbls.PrivateKey, error) {
+	configFiles, err := filepath.Glob(filepath.Join(dir, web3signer.KeysDir, "*.yaml"))
+	if err != nil {
+		return nil, errors.Wrap(err, "glob key config files")
+	} else if len(configFiles) == 0 {
+		return nil, errors.New("no web3signer key config files found", z.Str("dir", dir))
+	}
+	sort.Strings(configFiles)
+
+	resolve := func(path string) string {
+		if filepath.IsAbs(path) {
+			return path
+		}
+
+		return filepath.Join(dir, path)
+	}
+
+	var secrets []tbls.PrivateKey
+	for _, configFile := range configFiles {
+		b, err := os.ReadFile(configFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "read key config", z.Str("filename", configFile))
+		}
+
+		var config web3signerKeyConfig
+		if err := yaml.Unmarshal(b, &config); err != nil {
+			return nil, errors.Wrap(err, "unmarshal key config", z.Str("filename", configFile))
+		}
+
+		if config.Type != "file-keystore" {
+			return nil, errors.New("unsupported web3signer key config type", z.Str("filename", configFile), z.Str("type", config.Type))
+		}
+
+		secret, err := decryptFile(resolve(config.KeystoreFile), resolve(config.KeystorePasswordFile))
+		if err != nil {
+			return nil, err
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
+
+// decryptFile decrypts the keystore file using the password in the password file, ignoring trailing newlines.
+func decryptFile(keyFile, passwordFile string) (tbls.PrivateKey, error) {
+	password, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return tbls.PrivateKey{}, errors.Wrap(err, "read password file", z.Str("filename", passwordFile))
+	}
+
+	return keystore.DecryptFile(keyFile, strings.TrimRight(string(password), "\r\n"))
+}
+
+// pubkeyHex returns the 0x-prefixed hex encoded public key of the secret.
+func pubkeyHex(secret tbls.PrivateKey) (string, error) {
+	pubkey, err := tbls.SecretToPublicKey(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return "0x" + hex.EncodeToString(pubkey[:]), nil
+}
+
+// randomPassword returns a random 32 character hex password.
+func randomPassword() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "read random")
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,68 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package layout_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/keystore/layout"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestStoreLoad(t *testing.T) {
+	var secrets []tbls.PrivateKey
+	for range 3 {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+		secrets = append(secrets, secret)
+	}
+
+	pubkey, err := tbls.SecretToPublicKey(secrets[0])
+	require.NoError(t, err)
+
+	opts := keystore.Options{KDF: keystore.KDFScrypt, CostPower: 10}
+
+	for _, format := range layout.Formats() {
+		t.Run(string(format), func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, layout.Store(secrets, dir, format, opts))
+
+			loaded, err := layout.Load(dir, format)
+			require.NoError(t, err)
+			require.ElementsMatch(t, secrets, loaded)
+
+			if format == layout.FormatLighthouse {
+				_, err := os.Stat(filepath.Join(dir, "validators", fmt.Sprintf("%#x", pubkey), "voting-keystore.json"))
+				require.NoError(t, err)
+			}
+
+			require.ErrorContains(t, layout.Store(secrets, dir, format, opts), "already exist")
+		})
+	}
+
+	require.ErrorContains(t, layout.Store(secrets, t.TempDir(), "vouch", opts), "unsupported keystore format")
+}
+
+func TestLoadTrailingNewline(t *testing.T) {
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, layout.Store([]tbls.PrivateKey{secret}, dir, layout.FormatTeku, keystore.Options{CostPower: 10}))
+
+	passwordFile := filepath.Join(dir, "passwords", "keystore-0.txt")
+	password, err := os.ReadFile(passwordFile)
+	require.NoError(t, err)
+	require.NoError(t, os.Chmod(passwordFile, 0o600))
+	require.NoError(t, os.WriteFile(passwordFile, append(password, '\n'), 0o600))
+
+	loaded, err := layout.Load(dir, layout.FormatTeku)
+	require.NoError(t, err)
+	require.Equal(t, []tbls.PrivateKey{secret}, loaded)
+}
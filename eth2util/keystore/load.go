@@ -6,7 +6,6 @@ import (
 	"context"
 	"encoding/json"
 	"os"
-	"path"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -72,7 +71,7 @@ func (k KeyFile) HasIndex() bool {
 // using password stored in dir/keystore-*.txt.
 // The resulting keystore files are in random order.
 func LoadFilesUnordered(dir string) (KeyFiles, error) {
-	files, err := filepath.Glob(path.Join(dir, "keystore-*.json"))
+	files, err := filepath.Glob(filepath.Join(dir, "keystore-*.json"))
 	if err != nil {
 		return nil, errors.Wrap(err, "read files")
 	}
@@ -82,36 +81,7 @@ func LoadFilesUnordered(dir string) (KeyFiles, error) {
 	}
 
 	workFunc := func(_ context.Context, filename string) (KeyFile, error) {
-		b, err := os.ReadFile(filename)
-		if err != nil {
-			return KeyFile{}, errors.Wrap(err, "read file", z.Str("filename", filename))
-		}
-
-		var store Keystore
-		if err := json.Unmarshal(b, &store); err != nil {
-			return KeyFile{}, errors.Wrap(err, "unmarshal keystore", z.Str("filename", filename))
-		}
-
-		password, err := loadPassword(filename)
-		if err != nil {
-			return KeyFile{}, errors.Wrap(err, "load password", z.Str("filename", filename))
-		}
-
-		secret, err := decrypt(store, password)
-		if err != nil {
-			return KeyFile{}, errors.Wrap(err, "keystore decryption", z.Str("filename", filename))
-		}
-
-		idx, err := extractFileIndex(filename)
-		if err != nil {
-			return KeyFile{}, errors.Wrap(err, "extract file index", z.Str("filename", filename))
-		}
-
-		return KeyFile{
-			PrivateKey: secret,
-			Filename:   filename,
-			FileIndex:  idx,
-		}, nil
+		return LoadFile(filename)
 	}
 
 	joinResults, cancel := forkjoin.NewWithInputs(
@@ -125,6 +95,69 @@ func LoadFilesUnordered(dir string) (KeyFiles, error) {
 	return joinResults.Flatten()
 }
 
+// FilesByIndex returns the dir/keystore-*.json EIP-2335 Keystore file names by file index without decrypting them.
+// It returns an error if any file index is unknown or duplicated.
+func FilesByIndex(dir string) (map[int]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "keystore-*.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "read files")
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("no keys found")
+	}
+
+	resp := make(map[int]string)
+	for _, filename := range files {
+		idx, err := extractFileIndex(filename)
+		if err != nil {
+			return nil, errors.Wrap(err, "extract file index", z.Str("filename", filename))
+		} else if idx == -1 {
+			return nil, errors.New("unknown keystore index, filename not 'keystore-%d.json'", z.Str("filename", filename))
+		} else if _, ok := resp[idx]; ok {
+			return nil, errors.New("duplicate keystore index", z.Int("index", idx), z.Str("filename", filename))
+		}
+
+		resp[idx] = filename
+	}
+
+	return resp, nil
+}
+
+// LoadFile returns the decrypted EIP-2335 Keystore file using the password stored in the adjacent .txt file.
+func LoadFile(filename string) (KeyFile, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return KeyFile{}, errors.Wrap(err, "read file", z.Str("filename", filename))
+	}
+
+	var store Keystore
+	if err := json.Unmarshal(b, &store); err != nil {
+		return KeyFile{}, errors.Wrap(err, "unmarshal keystore", z.Str("filename", filename))
+	}
+
+	password, err := loadPassword(filename)
+	if err != nil {
+		return KeyFile{}, errors.Wrap(err, "load password", z.Str("filename", filename))
+	}
+
+	secret, err := decrypt(store, password)
+	if err != nil {
+		return KeyFile{}, errors.Wrap(err, "keystore decryption", z.Str("filename", filename))
+	}
+
+	idx, err := extractFileIndex(filename)
+	if err != nil {
+		return KeyFile{}, errors.Wrap(err, "extract file index", z.Str("filename", filename))
+	}
+
+	return KeyFile{
+		PrivateKey: secret,
+		Filename:   filename,
+		FileIndex:  idx,
+	}, nil
+}
+
 var extractor = regexp.MustCompile(`keystore-(?:insecure-)?([0-9]+).json`)
 
 // extractFileIndex extracts the index from a keystore file name or returns -1
@@ -0,0 +1,164 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package mnemonic derives validator private keys from BIP-39 mnemonics
+// using the EIP-2333 key tree and EIP-2334 validator signing key paths.
+package mnemonic
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+const (
+	// purpose and coinType are the EIP-2334 path levels of Ethereum validator keys.
+	purpose  = 12381
+	coinType = 3600
+
+	// lamportChunks is the number of 32 byte chunks of EIP-2333 lamport secret keys.
+	lamportChunks = 255
+)
+
+// curveOrder is the order r of the BLS12-381 curve.
+var curveOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// ValidatorKeys returns the signing private keys of count validators starting at the validator index
+// derived from the BIP-39 mnemonic and optional passphrase.
+func ValidatorKeys(mnemonic string, passphrase string, start, count int) ([]tbls.PrivateKey, error) {
+	if start < 0 || count <= 0 {
+		return nil, errors.New("invalid mnemonic validator range", z.Int("start", start), z.Int("count", count))
+	}
+
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid mnemonic")
+	}
+
+	master, err := deriveMasterSK(bip39.NewSeed(mnemonic, passphrase))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []tbls.PrivateKey
+	for i := start; i < start+count; i++ {
+		sk, err := deriveSK(master, Path(i))
+		if err != nil {
+			return nil, err
+		}
+
+		secret, err := tblsconv.PrivkeyFromBytes(sk.FillBytes(make([]byte, 32)))
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, secret)
+	}
+
+	return resp, nil
+}
+
+// Path returns the EIP-2334 signing key path of the validator index.
+func Path(index int) string {
+	return fmt.Sprintf("m/%d/%d/%d/0/0", purpose, coinType, index)
+}
+
+// deriveSK returns the EIP-2333 child secret key of the path, starting at the master secret key.
+func deriveSK(master *big.Int, path string) (*big.Int, error) {
+	var indexes []uint32
+	for i, level := range strings.Split(path, "/") {
+		if i == 0 {
+			continue // Skip "m"
+		}
+
+		var index uint32
+		if _, err := fmt.Sscanf(level, "%d", &index); err != nil {
+			return nil, errors.Wrap(err, "invalid key path", z.Str("path", path))
+		}
+
+		indexes = append(indexes, index)
+	}
+
+	sk := master
+	for _, index := range indexes {
+		sk = deriveChildSK(sk, index)
+	}
+
+	return sk, nil
+}
+
+// deriveMasterSK returns the EIP-2333 master secret key of the seed.
+func deriveMasterSK(seed []byte) (*big.Int, error) {
+	if len(seed) < 32 {
+		return nil, errors.New("seed too short")
+	}
+
+	return hkdfModR(seed), nil
+}
+
+// deriveChildSK returns the EIP-2333 child secret key of the parent secret key at the index.
+func deriveChildSK(parent *big.Int, index uint32) *big.Int {
+	return hkdfModR(parentSKToLamportPK(parent, index))
+}
+
+// hkdfModR returns the EIP-2333 secret key derived from the input key material.
+func hkdfModR(ikm []byte) *big.Int {
+	const l = 48
+
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	sk := new(big.Int)
+	for sk.Sign() == 0 {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+
+		okm := make([]byte, l)
+		r := hkdf.New(sha256.New, append(append([]byte{}, ikm...), 0), salt, binary.BigEndian.AppendUint16(nil, l))
+		_, _ = io.ReadFull(r, okm) // Can't fail for l < 255*32.
+
+		sk.Mod(new(big.Int).SetBytes(okm), curveOrder)
+	}
+
+	return sk
+}
+
+// parentSKToLamportPK returns the EIP-2333 compressed lamport public key of the parent secret key at the index.
+func parentSKToLamportPK(parent *big.Int, index uint32) []byte {
+	salt := binary.BigEndian.AppendUint32(nil, index)
+	ikm := parent.FillBytes(make([]byte, 32))
+
+	notIKM := make([]byte, len(ikm))
+	for i, b := range ikm {
+		notIKM[i] = ^b
+	}
+
+	lamportPK := sha256.New()
+	for _, chunk := range append(ikmToLamportSK(ikm, salt), ikmToLamportSK(notIKM, salt)...) {
+		h := sha256.Sum256(chunk)
+		_, _ = lamportPK.Write(h[:])
+	}
+
+	return lamportPK.Sum(nil)
+}
+
+// ikmToLamportSK returns the EIP-2333 lamport secret key chunks of the input key material.
+func ikmToLamportSK(ikm, salt []byte) [][]byte {
+	okm := make([]byte, 32*lamportChunks)
+	_, _ = io.ReadFull(hkdf.New(sha256.New, ikm, salt, nil), okm) // Can't fail for exactly 255*32.
+
+	var resp [][]byte
+	for i := range lamportChunks {
+		resp = append(resp, okm[i*32:(i+1)*32])
+	}
+
+	return resp
+}
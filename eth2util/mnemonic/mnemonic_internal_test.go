@@ -0,0 +1,85 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package mnemonic
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// TestEIP2333 tests the EIP-2333 test vectors.
+func TestEIP2333(t *testing.T) {
+	tests := []struct {
+		seed     string
+		master   string
+		index    uint32
+		childSK  string
+		mnemonic bool
+	}{
+		{
+			seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+			master:   "6083874454709270928345386274498605044986640685124978867557563392430687146096",
+			index:    0,
+			childSK:  "20397789859736650942317412262472558107875392172444076792671091975210932703118",
+			mnemonic: true,
+		},
+		{
+			seed:    "3141592653589793238462643383279502884197169399375105820974944592",
+			master:  "29757020647961307431480504535336562678282505419141012933316116377660817309383",
+			index:   3141592653,
+			childSK: "25457201688850691947727629385191704516744796114925897962676248250929345014287",
+		},
+	}
+
+	for _, test := range tests {
+		seed, err := hex.DecodeString(test.seed)
+		require.NoError(t, err)
+
+		if test.mnemonic {
+			require.Equal(t, seed, bip39.NewSeed(testMnemonic, "TREZOR"))
+		}
+
+		master, err := deriveMasterSK(seed)
+		require.NoError(t, err)
+		require.Equal(t, test.master, master.String())
+		require.Equal(t, test.childSK, deriveChildSK(master, test.index).String())
+	}
+}
+
+func TestValidatorKeys(t *testing.T) {
+	keys, err := ValidatorKeys(testMnemonic, "TREZOR", 1, 2)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	seed := bip39.NewSeed(testMnemonic, "TREZOR")
+	master, err := deriveMasterSK(seed)
+	require.NoError(t, err)
+
+	for i, key := range keys {
+		sk, err := deriveSK(master, Path(i+1))
+		require.NoError(t, err)
+		require.Equal(t, sk, new(big.Int).SetBytes(key[:]))
+
+		_, err = tbls.SecretToPublicKey(key)
+		require.NoError(t, err)
+	}
+
+	// Extra whitespace is ignored.
+	other, err := ValidatorKeys("  "+testMnemonic+"\n", "TREZOR", 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, keys[0], other[0])
+
+	_, err = ValidatorKeys("abandon abandon", "", 0, 1)
+	require.ErrorContains(t, err, "invalid mnemonic")
+
+	_, err = ValidatorKeys(testMnemonic, "", 0, 0)
+	require.ErrorContains(t, err, "invalid mnemonic validator range")
+}
@@ -27,6 +27,10 @@ type Network struct {
 	GenesisTimestamp int64
 	// CapellaHardFork represents capella fork version, used for computing domains for signatures
 	CapellaHardFork string
+	// GenesisValidatorsRootHex represents the genesis validators root of the network in hex, empty if unknown.
+	GenesisValidatorsRootHex string
+	// DepositContractAddress represents the 0x-prefixed execution layer deposit contract address, empty if unknown.
+	DepositContractAddress string
 }
 
 // IsNonZero checks if each field in this struct is not equal to its zero value.
@@ -37,54 +41,69 @@ func (n Network) IsNonZero() bool {
 // Pre-defined network configurations.
 var (
 	Mainnet = Network{
-		ChainID:               1,
-		Name:                  "mainnet",
-		GenesisForkVersionHex: "0x00000000",
-		GenesisTimestamp:      1606824023,
-		CapellaHardFork:       "0x03000000",
+		ChainID:                  1,
+		Name:                     "mainnet",
+		GenesisForkVersionHex:    "0x00000000",
+		GenesisTimestamp:         1606824023,
+		CapellaHardFork:          "0x03000000",
+		GenesisValidatorsRootHex: "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
 	}
 	Goerli = Network{
-		ChainID:               5,
-		Name:                  "goerli",
-		GenesisForkVersionHex: "0x00001020",
-		GenesisTimestamp:      1616508000,
-		CapellaHardFork:       "0x03001020",
+		ChainID:                  5,
+		Name:                     "goerli",
+		GenesisForkVersionHex:    "0x00001020",
+		GenesisTimestamp:         1616508000,
+		CapellaHardFork:          "0x03001020",
+		GenesisValidatorsRootHex: "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb",
 	}
 	Gnosis = Network{
-		ChainID:               100,
-		Name:                  "gnosis",
-		GenesisForkVersionHex: "0x00000064",
-		GenesisTimestamp:      1638993340,
-		CapellaHardFork:       "0x03000064",
+		ChainID:                  100,
+		Name:                     "gnosis",
+		GenesisForkVersionHex:    "0x00000064",
+		GenesisTimestamp:         1638993340,
+		CapellaHardFork:          "0x03000064",
+		GenesisValidatorsRootHex: "0xf5dcb5564e829aab27264b9becd5dfaa017085611224cb3036f573368dbb9d47",
 	}
 	Chiado = Network{
-		ChainID:               10200,
-		Name:                  "chiado",
-		GenesisForkVersionHex: "0x0000006f",
-		GenesisTimestamp:      1665396300,
-		CapellaHardFork:       "0x0300006f",
+		ChainID:                  10200,
+		Name:                     "chiado",
+		GenesisForkVersionHex:    "0x0000006f",
+		GenesisTimestamp:         1665396300,
+		CapellaHardFork:          "0x0300006f",
+		GenesisValidatorsRootHex: "0x9d642dac73058fbf39c0ae41ab1e34e4d889043cb199851ded7095bc99eb4c1e",
 	}
 	Sepolia = Network{
-		ChainID:               11155111,
-		Name:                  "sepolia",
-		GenesisForkVersionHex: "0x90000069",
-		GenesisTimestamp:      1655733600,
-		CapellaHardFork:       "0x90000072",
+		ChainID:                  11155111,
+		Name:                     "sepolia",
+		GenesisForkVersionHex:    "0x90000069",
+		GenesisTimestamp:         1655733600,
+		CapellaHardFork:          "0x90000072",
+		GenesisValidatorsRootHex: "0xd8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078",
 	}
 	// Holesky metadata taken from https://github.com/eth-clients/holesky#metadata.
 	Holesky = Network{
-		ChainID:               17000,
-		Name:                  "holesky",
-		GenesisForkVersionHex: "0x01017000",
-		GenesisTimestamp:      1696000704,
-		CapellaHardFork:       "0x04017000",
+		ChainID:                  17000,
+		Name:                     "holesky",
+		GenesisForkVersionHex:    "0x01017000",
+		GenesisTimestamp:         1696000704,
+		CapellaHardFork:          "0x04017000",
+		GenesisValidatorsRootHex: "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1",
+	}
+	// Hoodi metadata taken from https://github.com/eth-clients/hoodi#metadata.
+	Hoodi = Network{
+		ChainID:                  560048,
+		Name:                     "hoodi",
+		GenesisForkVersionHex:    "0x10000910",
+		GenesisTimestamp:         1742213400,
+		CapellaHardFork:          "0x40000910",
+		GenesisValidatorsRootHex: "0x212f13fc4df078b6cb7db228f1c8307566dcecf900867401a92023d7ba99cb5f",
 	}
 )
 
 var (
 	networksMu        sync.Mutex
 	supportedNetworks = []Network{
-		Mainnet, Goerli, Gnosis, Chiado, Sepolia, Holesky,
+		Mainnet, Goerli, Gnosis, Chiado, Sepolia, Holesky, Hoodi,
 	}
 )
 
@@ -169,12 +188,42 @@ func NetworkToForkVersionBytes(name string) ([]byte, error) {
 	return b, nil
 }
 
+// ForkVersionToDepositContract returns the deposit contract address corresponding to the provided fork version,
+// or empty if unknown.
+func ForkVersionToDepositContract(forkVersion []byte) (string, error) {
+	network, err := networkFromForkVersion(fmt.Sprintf("%#x", forkVersion))
+	if err != nil {
+		return "", err
+	}
+
+	return network.DepositContractAddress, nil
+}
+
 // ValidNetwork returns true if the provided network name is a valid one.
 func ValidNetwork(name string) bool {
 	_, err := networkFromName(name)
 	return err == nil
 }
 
+// ForkVersionToGenesisValidatorsRoot returns the genesis validators root corresponding to the provided fork version.
+func ForkVersionToGenesisValidatorsRoot(forkVersion []byte) ([]byte, error) {
+	network, err := networkFromForkVersion(fmt.Sprintf("%#x", forkVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	if network.GenesisValidatorsRootHex == "" {
+		return nil, errors.New("unknown genesis validators root", z.Str("network", network.Name))
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(network.GenesisValidatorsRootHex, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode genesis validators root hex")
+	}
+
+	return b, nil
+}
+
 func NetworkToGenesisTime(name string) (time.Time, error) {
 	network, err := networkFromName(name)
 	if err != nil {
@@ -69,12 +69,25 @@ func TestNetworkToForkVersionBytes(t *testing.T) {
 	require.ErrorContains(t, err, "invalid network name")
 }
 
+func TestForkVersionToGenesisValidatorsRoot(t *testing.T) {
+	mainnetForkVersion, err := hex.DecodeString(strings.TrimPrefix(eth2util.Mainnet.GenesisForkVersionHex, "0x"))
+	require.NoError(t, err)
+
+	root, err := eth2util.ForkVersionToGenesisValidatorsRoot(mainnetForkVersion)
+	require.NoError(t, err)
+	require.Equal(t, eth2util.Mainnet.GenesisValidatorsRootHex, "0x"+hex.EncodeToString(root))
+
+	_, err = eth2util.ForkVersionToGenesisValidatorsRoot(invalidForkVersion)
+	require.ErrorContains(t, err, "invalid fork version")
+}
+
 func TestValidNetwork(t *testing.T) {
 	supportedNetworks := []string{
 		"mainnet",
 		"goerli",
 		"sepolia",
 		"holesky",
+		"hoodi",
 		"gnosis",
 		"chiado",
 	}
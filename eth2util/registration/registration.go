@@ -42,7 +42,7 @@ func NewMessage(pubkey eth2p0.BLSPubKey, feeRecipient string, gasLimit uint64, t
 func executionAddressFromStr(addr string) ([20]byte, error) {
 	// Check for validity of address.
 	if _, err := eth2util.ChecksumAddress(addr); err != nil {
-		return [20]byte{}, errors.Wrap(err, "invalid address", z.Str("addr", addr))
+		return [20]byte{}, errors.Wrap(err, "invalid address", z.Redact(z.Str("addr", addr)))
 	}
 
 	addrBytes, err := hex.DecodeString(strings.TrimPrefix(addr, "0x"))
@@ -0,0 +1,398 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package vault provides a HashiCorp Vault (https://developer.hashicorp.com/vault) backend storing validator
+// key shares in a KV version 2 secrets engine, optionally encrypted by a Transit secrets engine key,
+// so key shares are never written to node disks.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// Supported authentication methods.
+const (
+	AuthToken      = "token"
+	AuthAppRole    = "approle"
+	AuthKubernetes = "kubernetes"
+)
+
+const (
+	// keySharePrefix is the prefix of the KV secret name of each key share, followed by its index.
+	keySharePrefix = "keyshare-"
+	// minRenewPeriod is the minimum period between token renewals.
+	minRenewPeriod = 5 * time.Second
+)
+
+// Config defines the Vault server, authentication method and key share location.
+type Config struct {
+	// Addr is the Vault server address, Vault is disabled if empty.
+	Addr string
+	// AuthMethod is the authentication method, one of token, approle or kubernetes.
+	AuthMethod string
+	// AuthMount is the path the auth method is mounted at, defaults to the auth method name.
+	AuthMount string
+	// Token is the Vault token used by the token authentication method.
+	Token string
+	// Role is the AppRole role ID or the Kubernetes auth role name.
+	Role string
+	// SecretFile is the file containing the AppRole secret ID or the Kubernetes service account token.
+	SecretFile string
+	// KVMount is the path the KV version 2 secrets engine is mounted at.
+	KVMount string
+	// Path is the KV path containing the key shares.
+	Path string
+	// TransitKey is the optional Transit key encrypting key shares before they are stored in KV.
+	TransitKey string
+	// TransitMount is the path the Transit secrets engine is mounted at.
+	TransitMount string
+}
+
+// Enabled returns true if a Vault server is configured.
+func (c Config) Enabled() bool {
+	return c.Addr != ""
+}
+
+// Client is a Vault client storing and loading key shares.
+type Client struct {
+	conf   Config
+	httpCl *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+// New returns a new Client authenticated with the configured authentication method.
+// The token is renewed, or the client re-authenticates, before it expires until the context is cancelled.
+func New(ctx context.Context, conf Config) (*Client, error) {
+	if conf.AuthMount == "" {
+		conf.AuthMount = conf.AuthMethod
+	}
+
+	c := &Client{
+		conf:   conf,
+		httpCl: &http.Client{Timeout: 30 * time.Second},
+	}
+
+	ttl, renewable, err := c.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl > 0 {
+		go c.renewForever(ctx, ttl, renewable)
+	}
+
+	return c, nil
+}
+
+// login authenticates with the configured authentication method and returns the token's ttl and whether
+// it is renewable. A zero ttl indicates a token that doesn't expire.
+func (c *Client) login(ctx context.Context) (time.Duration, bool, error) {
+	var auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+		Renewable     bool   `json:"renewable"`
+	}
+
+	switch c.conf.AuthMethod {
+	case AuthToken:
+		if c.conf.Token == "" {
+			return 0, false, errors.New("vault token not set")
+		}
+		c.setToken(c.conf.Token)
+
+		var resp struct {
+			Data struct {
+				TTL       int64 `json:"ttl"`
+				Renewable bool  `json:"renewable"`
+			} `json:"data"`
+		}
+		if err := c.do(ctx, http.MethodGet, "auth/token/lookup-self", nil, &resp); err != nil {
+			return 0, false, errors.Wrap(err, "lookup vault token")
+		}
+
+		return time.Duration(resp.Data.TTL) * time.Second, resp.Data.Renewable, nil
+	case AuthAppRole, AuthKubernetes:
+		secret, err := os.ReadFile(c.conf.SecretFile)
+		if err != nil {
+			return 0, false, errors.Wrap(err, "read vault auth secret file", z.Str("path", c.conf.SecretFile))
+		}
+
+		req := map[string]string{"role_id": c.conf.Role, "secret_id": strings.TrimSpace(string(secret))}
+		if c.conf.AuthMethod == AuthKubernetes {
+			req = map[string]string{"role": c.conf.Role, "jwt": strings.TrimSpace(string(secret))}
+		}
+
+		var resp struct {
+			Auth *json.RawMessage `json:"auth"`
+		}
+		if err := c.do(ctx, http.MethodPost, "auth/"+c.conf.AuthMount+"/login", req, &resp); err != nil {
+			return 0, false, errors.Wrap(err, "vault login", z.Str("method", c.conf.AuthMethod))
+		} else if resp.Auth == nil {
+			return 0, false, errors.New("vault login response missing auth", z.Str("method", c.conf.AuthMethod))
+		}
+
+		if err := json.Unmarshal(*resp.Auth, &auth); err != nil {
+			return 0, false, errors.Wrap(err, "unmarshal vault auth")
+		}
+		c.setToken(auth.ClientToken)
+
+		return time.Duration(auth.LeaseDuration) * time.Second, auth.Renewable, nil
+	default:
+		return 0, false, errors.New("unsupported vault auth method", z.Str("method", c.conf.AuthMethod))
+	}
+}
+
+// renewForever renews the token at half its ttl, or re-authenticates if the token isn't renewable,
+// until the context is cancelled.
+func (c *Client) renewForever(ctx context.Context, ttl time.Duration, renewable bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(max(ttl/2, minRenewPeriod)):
+		}
+
+		var err error
+		if renewable {
+			ttl, err = c.renew(ctx)
+		}
+		if !renewable || err != nil {
+			if err != nil {
+				log.Warn(ctx, "Vault token renewal failed, re-authenticating", err)
+			}
+			ttl, renewable, err = c.login(ctx)
+		}
+		if err != nil {
+			log.Warn(ctx, "Vault authentication failed", err)
+			ttl = 0
+		} else if ttl == 0 {
+			return // Token doesn't expire.
+		}
+	}
+}
+
+// renew renews the token and returns its new ttl.
+func (c *Client) renew(ctx context.Context) (time.Duration, error) {
+	var resp struct {
+		Auth struct {
+			LeaseDuration int64 `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := c.do(ctx, http.MethodPost, "auth/token/renew-self", struct{}{}, &resp); err != nil {
+		return 0, errors.Wrap(err, "renew vault token")
+	}
+
+	return time.Duration(resp.Auth.LeaseDuration) * time.Second, nil
+}
+
+// StoreKeys stores the key shares in KV at path/keyshare-%d, encrypted with the Transit key if configured.
+// It returns an error if the path already contains key shares.
+func (c *Client) StoreKeys(ctx context.Context, secrets []tbls.PrivateKey) error {
+	existing, err := c.listKeyShares(ctx)
+	if err != nil {
+		return err
+	} else if len(existing) > 0 {
+		return errors.New("vault path already contains key shares", z.Str("path", c.conf.Path))
+	}
+
+	for i, secret := range secrets {
+		pubkey, err := tbls.SecretToPublicKey(secret)
+		if err != nil {
+			return err
+		}
+
+		data := map[string]string{"pubkey": "0x" + hex.EncodeToString(pubkey[:])}
+		if c.conf.TransitKey != "" {
+			var resp struct {
+				Data struct {
+					Ciphertext string `json:"ciphertext"`
+				} `json:"data"`
+			}
+			req := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(secret[:])}
+			if err := c.do(ctx, http.MethodPost, c.conf.TransitMount+"/encrypt/"+c.conf.TransitKey, req, &resp); err != nil {
+				return errors.Wrap(err, "encrypt key share", z.Int("index", i))
+			}
+			data["ciphertext"] = resp.Data.Ciphertext
+		} else {
+			data["secret"] = "0x" + hex.EncodeToString(secret[:])
+		}
+
+		req := map[string]any{"data": data}
+		if err := c.do(ctx, http.MethodPost, c.kvPath("data", keySharePrefix+strconv.Itoa(i)), req, nil); err != nil {
+			return errors.Wrap(err, "store key share", z.Int("index", i))
+		}
+	}
+
+	return nil
+}
+
+// LoadKeys returns the key shares stored in KV at path/keyshare-%d ordered by index.
+func (c *Client) LoadKeys(ctx context.Context) ([]tbls.PrivateKey, error) {
+	indexes, err := c.listKeyShares(ctx)
+	if err != nil {
+		return nil, err
+	} else if len(indexes) == 0 {
+		return nil, errors.New("no key shares found in vault", z.Str("path", c.conf.Path))
+	}
+
+	var resp []tbls.PrivateKey
+	for i, index := range indexes {
+		if index != i {
+			return nil, errors.New("vault key share indexes not sequential", z.Int("missing", i))
+		}
+
+		var kv struct {
+			Data struct {
+				Data map[string]string `json:"data"`
+			} `json:"data"`
+		}
+		if err := c.do(ctx, http.MethodGet, c.kvPath("data", keySharePrefix+strconv.Itoa(index)), nil, &kv); err != nil {
+			return nil, errors.Wrap(err, "load key share", z.Int("index", index))
+		}
+
+		var secret []byte
+		if ciphertext, ok := kv.Data.Data["ciphertext"]; ok {
+			var dec struct {
+				Data struct {
+					Plaintext string `json:"plaintext"`
+				} `json:"data"`
+			}
+			req := map[string]string{"ciphertext": ciphertext}
+			if err := c.do(ctx, http.MethodPost, c.conf.TransitMount+"/decrypt/"+c.conf.TransitKey, req, &dec); err != nil {
+				return nil, errors.Wrap(err, "decrypt key share", z.Int("index", index))
+			}
+
+			secret, err = base64.StdEncoding.DecodeString(dec.Data.Plaintext)
+		} else {
+			secret, err = hex.DecodeString(strings.TrimPrefix(kv.Data.Data["secret"], "0x"))
+		}
+		if err != nil || len(secret) != len(tbls.PrivateKey{}) {
+			return nil, errors.New("invalid key share", z.Int("index", index))
+		}
+
+		resp = append(resp, tbls.PrivateKey(secret))
+	}
+
+	return resp, nil
+}
+
+// listKeyShares returns the sorted indexes of the key shares stored in KV.
+func (c *Client) listKeyShares(ctx context.Context) ([]int, error) {
+	var resp struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+	err := c.do(ctx, "LIST", c.kvPath("metadata", ""), nil, &resp)
+	if errors.Is(err, errNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "list key shares")
+	}
+
+	var indexes []int
+	for _, key := range resp.Data.Keys {
+		index, err := strconv.Atoi(strings.TrimPrefix(key, keySharePrefix))
+		if !strings.HasPrefix(key, keySharePrefix) || err != nil {
+			continue
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	return indexes, nil
+}
+
+// kvPath returns the KV version 2 API path of the secret in the configured path.
+func (c *Client) kvPath(api, name string) string {
+	return strings.TrimSuffix(c.conf.KVMount+"/"+api+"/"+strings.Trim(c.conf.Path, "/")+"/"+name, "/")
+}
+
+func (c *Client) setToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.token = token
+}
+
+func (c *Client) getToken() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.token
+}
+
+// errNotFound is returned by do if the Vault path doesn't exist.
+var errNotFound = errors.New("vault path not found")
+
+// do sends the json request to the Vault API endpoint and unmarshals the json response if not nil.
+func (c *Client) do(ctx context.Context, method, endpoint string, req, resp any) error {
+	addr, err := url.JoinPath(c.conf.Addr, "v1", endpoint)
+	if err != nil {
+		return errors.Wrap(err, "invalid vault address", z.Redact(z.Str("addr", c.conf.Addr)))
+	}
+
+	var body io.Reader
+	if req != nil {
+		b, err := json.Marshal(req)
+		if err != nil {
+			return errors.Wrap(err, "marshal vault request")
+		}
+		body = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, addr, body)
+	if err != nil {
+		return errors.Wrap(err, "new vault request")
+	}
+	if token := c.getToken(); token != "" {
+		httpReq.Header.Set("X-Vault-Token", token)
+	}
+
+	httpResp, err := c.httpCl.Do(httpReq)
+	if err != nil {
+		return errors.Wrap(err, "vault request", z.Str("endpoint", endpoint))
+	}
+	defer httpResp.Body.Close()
+
+	b, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read vault response")
+	}
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	} else if httpResp.StatusCode/100 != 2 {
+		return errors.New("vault request failed", z.Str("endpoint", endpoint),
+			z.Int("status", httpResp.StatusCode), z.Str("body", string(b)))
+	}
+
+	if resp == nil || len(b) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(b, resp); err != nil {
+		return errors.Wrap(err, "unmarshal vault response")
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package vault_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/vault"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestStoreLoadKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		conf func(secretFile string) vault.Config
+	}{
+		{
+			name: "token",
+			conf: func(string) vault.Config {
+				return vault.Config{AuthMethod: vault.AuthToken, Token: "root"}
+			},
+		},
+		{
+			name: "approle with transit",
+			conf: func(secretFile string) vault.Config {
+				return vault.Config{
+					AuthMethod:   vault.AuthAppRole,
+					Role:         "charon",
+					SecretFile:   secretFile,
+					TransitMount: "transit",
+					TransitKey:   "charon",
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			srv := newVaultServer(t)
+			defer srv.Close()
+
+			secretFile := filepath.Join(t.TempDir(), "secret-id")
+			require.NoError(t, os.WriteFile(secretFile, []byte("secret\n"), 0o600))
+
+			conf := test.conf(secretFile)
+			conf.Addr = srv.URL
+			conf.KVMount = "secret"
+			conf.Path = "charon/node0"
+
+			cl, err := vault.New(ctx, conf)
+			require.NoError(t, err)
+
+			var secrets []tbls.PrivateKey
+			for range 3 {
+				secret, err := tbls.GenerateSecretKey()
+				require.NoError(t, err)
+				secrets = append(secrets, secret)
+			}
+
+			require.NoError(t, cl.StoreKeys(ctx, secrets))
+			require.ErrorContains(t, cl.StoreKeys(ctx, secrets), "vault path already contains key shares")
+
+			loaded, err := cl.LoadKeys(ctx)
+			require.NoError(t, err)
+			require.Equal(t, secrets, loaded)
+		})
+	}
+}
+
+func TestUnauthenticated(t *testing.T) {
+	srv := newVaultServer(t)
+	defer srv.Close()
+
+	_, err := vault.New(context.Background(), vault.Config{Addr: srv.URL, AuthMethod: vault.AuthToken, Token: "invalid"})
+	require.ErrorContains(t, err, "lookup vault token")
+
+	_, err = vault.New(context.Background(), vault.Config{Addr: srv.URL, AuthMethod: "userpass"})
+	require.ErrorContains(t, err, "unsupported vault auth method")
+}
+
+// newVaultServer returns a fake Vault server supporting token and approle authentication,
+// a KV version 2 secrets engine mounted at secret and a Transit secrets engine mounted at transit.
+func newVaultServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var (
+		mu sync.Mutex
+		kv = make(map[string]json.RawMessage)
+	)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		writeJSON := func(v any) {
+			b, err := json.Marshal(v)
+			require.NoError(t, err)
+			_, _ = w.Write(b)
+		}
+
+		var req map[string]any
+		if r.Body != nil {
+			_ = json.NewDecoder(r.Body).Decode(&req)
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, "/v1/")
+		if path == "auth/approle/login" {
+			require.Equal(t, "charon", req["role_id"])
+			require.Equal(t, "secret", req["secret_id"])
+			writeJSON(map[string]any{"auth": map[string]any{"client_token": "approle-token", "lease_duration": 3600, "renewable": true}})
+
+			return
+		}
+
+		if token := r.Header.Get("X-Vault-Token"); token != "root" && token != "approle-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch {
+		case path == "auth/token/lookup-self":
+			writeJSON(map[string]any{"data": map[string]any{"ttl": 0, "renewable": false}})
+		case r.Method == "LIST" && strings.HasPrefix(path, "secret/metadata/"):
+			prefix := strings.TrimPrefix(path, "secret/metadata/") + "/"
+			var keys []string
+			for key := range kv {
+				if strings.HasPrefix(key, prefix) {
+					keys = append(keys, strings.TrimPrefix(key, prefix))
+				}
+			}
+			if len(keys) == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeJSON(map[string]any{"data": map[string]any{"keys": keys}})
+		case strings.HasPrefix(path, "secret/data/"):
+			key := strings.TrimPrefix(path, "secret/data/")
+			if r.Method == http.MethodPost {
+				b, err := json.Marshal(req["data"])
+				require.NoError(t, err)
+				kv[key] = b
+
+				return
+			}
+
+			data, ok := kv[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeJSON(map[string]any{"data": map[string]any{"data": data}})
+		case path == "transit/encrypt/charon":
+			writeJSON(map[string]any{"data": map[string]any{"ciphertext": "vault:v1:" + req["plaintext"].(string)}})
+		case path == "transit/decrypt/charon":
+			writeJSON(map[string]any{"data": map[string]any{"plaintext": strings.TrimPrefix(req["ciphertext"].(string), "vault:v1:")}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
@@ -0,0 +1,253 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// NewClient returns a new Client.
+func NewClient(baseURL string) Client {
+	return Client{baseURL: baseURL}
+}
+
+// Client is the REST client for the Web3Signer eth2 signing API.
+// See https://consensys.github.io/web3signer/web3signer-eth2.html.
+type Client struct {
+	baseURL string // Base Web3Signer URL
+}
+
+// PublicKeys returns the BLS public keys available for signing.
+func (c Client) PublicKeys(ctx context.Context) ([]eth2p0.BLSPubKey, error) {
+	var resp []string
+	if err := c.do(ctx, http.MethodGet, "/api/v1/eth2/publicKeys", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	var pubkeys []eth2p0.BLSPubKey
+	for _, s := range resp {
+		b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil || len(b) != len(eth2p0.BLSPubKey{}) {
+			return nil, errors.New("invalid public key", z.Redact(z.Str("pubkey", s)))
+		}
+
+		pubkeys = append(pubkeys, eth2p0.BLSPubKey(b))
+	}
+
+	return pubkeys, nil
+}
+
+// Sign returns the signature of the request by the public key's private key.
+// Web3Signer applies slashing protection to block and attestation requests.
+func (c Client) Sign(ctx context.Context, pubkey eth2p0.BLSPubKey, req SignRequest) (eth2p0.BLSSignature, error) {
+	var resp struct {
+		Signature string `json:"signature"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/api/v1/eth2/sign/"+fmt.Sprintf("%#x", pubkey), req, &resp); err != nil {
+		return eth2p0.BLSSignature{}, err
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(resp.Signature, "0x"))
+	if err != nil || len(b) != len(eth2p0.BLSSignature{}) {
+		return eth2p0.BLSSignature{}, errors.New("invalid signature", z.Str("signature", resp.Signature))
+	}
+
+	return eth2p0.BLSSignature(b), nil
+}
+
+// do sends the json request to the Web3Signer endpoint and unmarshals the json response.
+func (c Client) do(ctx context.Context, method, endpoint string, req, resp any) error {
+	addr, err := url.JoinPath(c.baseURL, endpoint)
+	if err != nil {
+		return errors.Wrap(err, "invalid address", z.Redact(z.Str("addr", c.baseURL)))
+	}
+
+	var body io.Reader
+	if req != nil {
+		b, err := json.Marshal(req)
+		if err != nil {
+			return errors.Wrap(err, "marshal web3signer request")
+		}
+		body = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, addr, body)
+	if err != nil {
+		return errors.Wrap(err, "new web3signer request")
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if req != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	httpResp, err := new(http.Client).Do(httpReq)
+	if err != nil {
+		return errors.Wrap(err, "web3signer request", z.Str("endpoint", endpoint))
+	}
+	defer httpResp.Body.Close()
+
+	b, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read web3signer response")
+	}
+
+	if httpResp.StatusCode/100 != 2 {
+		return errors.New("web3signer request failed", z.Str("endpoint", endpoint),
+			z.Int("status", httpResp.StatusCode), z.Str("body", string(b)))
+	}
+
+	if err := json.Unmarshal(b, resp); err != nil {
+		return errors.Wrap(err, "unmarshal web3signer response")
+	}
+
+	return nil
+}
+
+// ForkInfo is the fork and genesis validators root used by Web3Signer to compute signing domains.
+type ForkInfo struct {
+	Fork                  *eth2p0.Fork `json:"fork"`
+	GenesisValidatorsRoot eth2p0.Root  `json:"genesis_validators_root"`
+}
+
+// SignRequest is a Web3Signer eth2 signing request, containing the type and the message to sign.
+type SignRequest struct {
+	Type        string    `json:"type"`
+	ForkInfo    *ForkInfo `json:"fork_info,omitempty"`
+	SigningRoot string    `json:"signingRoot,omitempty"`
+
+	AggregationSlot             *slotMessage                        `json:"aggregation_slot,omitempty"`
+	AggregateAndProof           *eth2p0.AggregateAndProof           `json:"aggregate_and_proof,omitempty"`
+	Attestation                 *eth2p0.AttestationData             `json:"attestation,omitempty"`
+	BeaconBlock                 *beaconBlock                        `json:"beacon_block,omitempty"`
+	RandaoReveal                *epochMessage                       `json:"randao_reveal,omitempty"`
+	SyncCommitteeMessage        *syncCommitteeMessage               `json:"sync_committee_message,omitempty"`
+	SyncAggregatorSelectionData *altair.SyncAggregatorSelectionData `json:"sync_aggregator_selection_data,omitempty"`
+	ContributionAndProof        *altair.ContributionAndProof        `json:"contribution_and_proof,omitempty"`
+	ValidatorRegistration       *eth2v1.ValidatorRegistration       `json:"validator_registration,omitempty"`
+}
+
+type slotMessage struct {
+	Slot string `json:"slot"`
+}
+
+type epochMessage struct {
+	Epoch string `json:"epoch"`
+}
+
+type beaconBlock struct {
+	Version     string                    `json:"version"`
+	BlockHeader *eth2p0.BeaconBlockHeader `json:"block_header"`
+}
+
+type syncCommitteeMessage struct {
+	BeaconBlockRoot eth2p0.Root `json:"beacon_block_root"`
+	Slot            string      `json:"slot"`
+}
+
+// NewSignRequest returns the signing request of the message with the expected signing root.
+// Supported messages are aggregation slots (eth2p0.Slot), randao reveal epochs (eth2p0.Epoch), attestation data,
+// aggregate and proofs, block proposals, sync committee messages, sync committee selections,
+// contribution and proofs and validator registrations.
+func NewSignRequest(msg any, forkInfo *ForkInfo, signingRoot []byte) (SignRequest, error) {
+	req := SignRequest{
+		ForkInfo:    forkInfo,
+		SigningRoot: fmt.Sprintf("%#x", signingRoot),
+	}
+
+	switch msg := msg.(type) {
+	case eth2p0.Slot:
+		req.Type = "AGGREGATION_SLOT"
+		req.AggregationSlot = &slotMessage{Slot: fmt.Sprint(msg)}
+	case eth2p0.Epoch:
+		req.Type = "RANDAO_REVEAL"
+		req.RandaoReveal = &epochMessage{Epoch: fmt.Sprint(msg)}
+	case *eth2p0.AttestationData:
+		req.Type = "ATTESTATION"
+		req.Attestation = msg
+	case *eth2p0.AggregateAndProof:
+		req.Type = "AGGREGATE_AND_PROOF"
+		req.AggregateAndProof = msg
+	case *eth2api.VersionedProposal:
+		block, err := newBeaconBlock(msg)
+		if err != nil {
+			return SignRequest{}, err
+		}
+		req.Type = "BLOCK_V2"
+		req.BeaconBlock = block
+	case *altair.SyncCommitteeMessage:
+		req.Type = "SYNC_COMMITTEE_MESSAGE"
+		req.SyncCommitteeMessage = &syncCommitteeMessage{BeaconBlockRoot: msg.BeaconBlockRoot, Slot: fmt.Sprint(msg.Slot)}
+	case *altair.SyncAggregatorSelectionData:
+		req.Type = "SYNC_COMMITTEE_SELECTION_PROOF"
+		req.SyncAggregatorSelectionData = msg
+	case *altair.ContributionAndProof:
+		req.Type = "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF"
+		req.ContributionAndProof = msg
+	case *eth2api.VersionedValidatorRegistration:
+		if msg.V1 == nil {
+			return SignRequest{}, errors.New("missing validator registration")
+		}
+		req.Type = "VALIDATOR_REGISTRATION"
+		req.ForkInfo = nil // Validator registrations are signed with the genesis fork version.
+		req.ValidatorRegistration = msg.V1
+	default:
+		return SignRequest{}, errors.New("unsupported web3signer message", z.Str("type", fmt.Sprintf("%T", msg)))
+	}
+
+	return req, nil
+}
+
+// newBeaconBlock returns the versioned block header of the proposal.
+func newBeaconBlock(proposal *eth2api.VersionedProposal) (*beaconBlock, error) {
+	slot, err := proposal.Slot()
+	if err != nil {
+		return nil, errors.Wrap(err, "proposal slot")
+	}
+
+	proposerIndex, err := proposal.ProposerIndex()
+	if err != nil {
+		return nil, errors.Wrap(err, "proposal proposer index")
+	}
+
+	parentRoot, err := proposal.ParentRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "proposal parent root")
+	}
+
+	stateRoot, err := proposal.StateRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "proposal state root")
+	}
+
+	bodyRoot, err := proposal.BodyRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "proposal body root")
+	}
+
+	return &beaconBlock{
+		Version: strings.ToUpper(proposal.Version.String()),
+		BlockHeader: &eth2p0.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: proposerIndex,
+			ParentRoot:    parentRoot,
+			StateRoot:     stateRoot,
+			BodyRoot:      bodyRoot,
+		},
+	}, nil
+}
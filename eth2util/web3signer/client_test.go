@@ -0,0 +1,101 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package web3signer_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/web3signer"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	pubkey := testutil.RandomEth2PubKey(t)
+	sig := testutil.RandomEth2Signature()
+	attData := testutil.RandomAttestationData()
+	signingRoot := testutil.RandomRoot()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/eth2/publicKeys":
+			_, _ = fmt.Fprintf(w, "[%q]", fmt.Sprintf("%#x", pubkey))
+		case fmt.Sprintf("/api/v1/eth2/sign/%#x", pubkey):
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			var req map[string]any
+			require.NoError(t, json.Unmarshal(b, &req))
+			require.Equal(t, "ATTESTATION", req["type"])
+			require.Equal(t, fmt.Sprintf("%#x", signingRoot), req["signingRoot"])
+			require.Contains(t, req, "attestation")
+			require.Contains(t, req, "fork_info")
+
+			_, _ = fmt.Fprintf(w, `{"signature":"%#x"}`, sig)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	cl := web3signer.NewClient(srv.URL)
+
+	pubkeys, err := cl.PublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []eth2p0.BLSPubKey{pubkey}, pubkeys)
+
+	forkInfo := &web3signer.ForkInfo{Fork: &eth2p0.Fork{Epoch: 1}, GenesisValidatorsRoot: testutil.RandomRoot()}
+	req, err := web3signer.NewSignRequest(attData, forkInfo, signingRoot[:])
+	require.NoError(t, err)
+
+	resp, err := cl.Sign(ctx, pubkey, req)
+	require.NoError(t, err)
+	require.Equal(t, sig, resp)
+
+	_, err = cl.Sign(ctx, testutil.RandomEth2PubKey(t), req)
+	require.ErrorContains(t, err, "web3signer request failed")
+
+	_, err = web3signer.NewSignRequest("unsupported", forkInfo, signingRoot[:])
+	require.ErrorContains(t, err, "unsupported web3signer message")
+}
+
+func TestNewSignRequest(t *testing.T) {
+	forkInfo := &web3signer.ForkInfo{Fork: &eth2p0.Fork{Epoch: 1}}
+	root := testutil.RandomRoot()
+
+	req, err := web3signer.NewSignRequest(eth2p0.Epoch(5), forkInfo, root[:])
+	require.NoError(t, err)
+	b, err := json.Marshal(req)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"type":"RANDAO_REVEAL"`)
+	require.Contains(t, string(b), `"randao_reveal":{"epoch":"5"}`)
+
+	proposal := testutil.RandomDenebVersionedProposal()
+	req, err = web3signer.NewSignRequest(proposal, forkInfo, root[:])
+	require.NoError(t, err)
+	require.Equal(t, "BLOCK_V2", req.Type)
+	b, err = json.Marshal(req)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"version":"DENEB"`)
+
+	registration := &eth2api.VersionedValidatorRegistration{
+		Version: eth2spec.BuilderVersionV1,
+		V1:      testutil.RandomVersionedSignedValidatorRegistration(t).V1.Message,
+	}
+	req, err = web3signer.NewSignRequest(registration, forkInfo, root[:])
+	require.NoError(t, err)
+	require.Equal(t, "VALIDATOR_REGISTRATION", req.Type)
+	require.Nil(t, req.ForkInfo)
+}
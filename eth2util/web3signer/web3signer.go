@@ -0,0 +1,169 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package web3signer provides functions to write validator keys in the layout expected by
+// Web3Signer (https://docs.web3signer.consensys.io/) and a client requesting signatures from it.
+package web3signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// KeysDir is the directory containing the key configuration files, use it as Web3Signer's --key-store-path.
+	KeysDir = "keys"
+	// SlashingProtectionFile is the EIP-3076 slashing protection interchange file to import into
+	// Web3Signer's slashing protection database via `web3signer eth2 import --from`.
+	SlashingProtectionFile = "slashing-protection.json"
+
+	// interchangeFormatVersion is the EIP-3076 interchange format version.
+	interchangeFormatVersion = "5"
+)
+
+// Write writes a Web3Signer key configuration file to dir/keys for each EIP 2335 keystore in keystoreDir
+// and initialises the slashing protection database by writing a EIP-3076 interchange file without any signing
+// history for all keystore public keys to dir. Keystore passwords are expected in files with identical names
+// as the keystores, except with txt extension.
+func Write(dir, keystoreDir string, genesisValidatorsRoot []byte) error {
+	pubkeys, err := WriteKeyConfigs(dir, keystoreDir)
+	if err != nil {
+		return err
+	}
+
+	return writeSlashingProtection(filepath.Join(dir, SlashingProtectionFile), genesisValidatorsRoot, pubkeys)
+}
+
+// WriteKeyConfigs writes a Web3Signer key configuration file to dir/keys for each EIP 2335 keystore in keystoreDir
+// and returns the 0x-prefixed keystore public keys. Keystore passwords are expected in files with identical names
+// as the keystores, except with txt extension.
+func WriteKeyConfigs(dir, keystoreDir string) ([]string, error) {
+	keystoreDir, err := filepath.Abs(keystoreDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "absolute keystore dir")
+	}
+
+	keyFiles, err := filepath.Glob(filepath.Join(keystoreDir, "keystore-*.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "glob keystore files")
+	} else if len(keyFiles) == 0 {
+		return nil, errors.New("no keystore files found", z.Str("dir", keystoreDir))
+	}
+	sort.Strings(keyFiles)
+
+	if err := os.MkdirAll(filepath.Join(dir, KeysDir), 0o755); err != nil {
+		return nil, errors.Wrap(err, "mkdir web3signer keys dir")
+	}
+
+	var pubkeys []string
+	for _, keyFile := range keyFiles {
+		pubkey, err := loadPubkey(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		pubkeys = append(pubkeys, pubkey)
+
+		if err := writeKeyConfig(dir, keyFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return pubkeys, nil
+}
+
+// loadPubkey returns the 0x-prefixed public key of the keystore file without decrypting it.
+func loadPubkey(keyFile string) (string, error) {
+	b, err := os.ReadFile(keyFile)
+	if err != nil {
+		return "", errors.Wrap(err, "read keystore", z.Str("file", keyFile))
+	}
+
+	var store struct {
+		Pubkey string `json:"pubkey"`
+	}
+	if err := json.Unmarshal(b, &store); err != nil {
+		return "", errors.Wrap(err, "unmarshal keystore", z.Str("file", keyFile))
+	}
+
+	pubkey, err := hex.DecodeString(strings.TrimPrefix(store.Pubkey, "0x"))
+	if err != nil || len(pubkey) == 0 {
+		return "", errors.New("invalid keystore pubkey", z.Str("file", keyFile))
+	}
+
+	return "0x" + hex.EncodeToString(pubkey), nil
+}
+
+// writeKeyConfig writes a Web3Signer file-keystore key configuration file referencing the keystore file.
+// See https://docs.web3signer.consensys.io/reference/key-config-file-params.
+func writeKeyConfig(dir, keyFile string) error {
+	passwordFile := strings.TrimSuffix(keyFile, ".json") + ".txt"
+	if _, err := os.Stat(passwordFile); err != nil {
+		return errors.Wrap(err, "keystore password file not found", z.Str("file", passwordFile))
+	}
+
+	config := fmt.Sprintf("type: \"file-keystore\"\nkeyType: \"BLS\"\nkeystoreFile: %q\nkeystorePasswordFile: %q\n",
+		keyFile, passwordFile)
+
+	filename := filepath.Join(dir, KeysDir, strings.TrimSuffix(filepath.Base(keyFile), ".json")+".yaml")
+
+	//nolint:gosec // File needs to be read-only for everybody
+	if err := os.WriteFile(filename, []byte(config), 0o444); err != nil {
+		return errors.Wrap(err, "write key config", z.Str("file", filename))
+	}
+
+	return nil
+}
+
+// interchange is the EIP-3076 slashing protection interchange format.
+// See https://eips.ethereum.org/EIPS/eip-3076.
+type interchange struct {
+	Metadata struct {
+		InterchangeFormatVersion string `json:"interchange_format_version"`
+		GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+	} `json:"metadata"`
+	Data []interchangeData `json:"data"`
+}
+
+type interchangeData struct {
+	Pubkey             string `json:"pubkey"`
+	SignedBlocks       []any  `json:"signed_blocks"`
+	SignedAttestations []any  `json:"signed_attestations"`
+}
+
+// writeSlashingProtection writes a EIP-3076 interchange file without any signing history for the public keys.
+func writeSlashingProtection(filename string, genesisValidatorsRoot []byte, pubkeys []string) error {
+	if len(genesisValidatorsRoot) != 32 {
+		return errors.New("invalid genesis validators root length", z.Int("length", len(genesisValidatorsRoot)))
+	}
+
+	var resp interchange
+	resp.Metadata.InterchangeFormatVersion = interchangeFormatVersion
+	resp.Metadata.GenesisValidatorsRoot = "0x" + hex.EncodeToString(genesisValidatorsRoot)
+
+	for _, pubkey := range pubkeys {
+		resp.Data = append(resp.Data, interchangeData{
+			Pubkey:             pubkey,
+			SignedBlocks:       []any{},
+			SignedAttestations: []any{},
+		})
+	}
+
+	b, err := json.MarshalIndent(resp, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal slashing protection interchange")
+	}
+
+	//nolint:gosec // File needs to be read-only for everybody
+	if err := os.WriteFile(filename, b, 0o444); err != nil {
+		return errors.Wrap(err, "write slashing protection interchange", z.Str("file", filename))
+	}
+
+	return nil
+}
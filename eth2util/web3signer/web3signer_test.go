@@ -0,0 +1,90 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package web3signer_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/web3signer"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestWrite(t *testing.T) {
+	const numKeys = 2
+
+	dir := t.TempDir()
+	keysDir := filepath.Join(dir, "validator_keys")
+	require.NoError(t, os.Mkdir(keysDir, 0o755))
+
+	var (
+		secrets []tbls.PrivateKey
+		pubkeys []string
+	)
+	for range numKeys {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+		secrets = append(secrets, secret)
+
+		pubkey, err := tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+		pubkeys = append(pubkeys, "0x"+hex.EncodeToString(pubkey[:]))
+	}
+
+	require.NoError(t, keystore.StoreKeysInsecure(secrets, keysDir, keystore.ConfirmInsecureKeys))
+
+	gvr := make([]byte, 32)
+	gvr[0] = 1
+
+	web3signerDir := filepath.Join(dir, "web3signer")
+	require.NoError(t, web3signer.Write(web3signerDir, keysDir, gvr))
+
+	for i := range numKeys {
+		b, err := os.ReadFile(filepath.Join(web3signerDir, web3signer.KeysDir, "keystore-insecure-"+strconv.Itoa(i)+".yaml"))
+		require.NoError(t, err)
+		require.Contains(t, string(b), `type: "file-keystore"`)
+		require.Contains(t, string(b), `keyType: "BLS"`)
+		require.Contains(t, string(b), filepath.Join(keysDir, "keystore-insecure-"+strconv.Itoa(i)+".json"))
+		require.Contains(t, string(b), filepath.Join(keysDir, "keystore-insecure-"+strconv.Itoa(i)+".txt"))
+	}
+
+	b, err := os.ReadFile(filepath.Join(web3signerDir, web3signer.SlashingProtectionFile))
+	require.NoError(t, err)
+
+	var interchange struct {
+		Metadata struct {
+			InterchangeFormatVersion string `json:"interchange_format_version"`
+			GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+		} `json:"metadata"`
+		Data []struct {
+			Pubkey             string            `json:"pubkey"`
+			SignedBlocks       []json.RawMessage `json:"signed_blocks"`
+			SignedAttestations []json.RawMessage `json:"signed_attestations"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(b, &interchange))
+	require.Equal(t, "5", interchange.Metadata.InterchangeFormatVersion)
+	require.Equal(t, "0x"+hex.EncodeToString(gvr), interchange.Metadata.GenesisValidatorsRoot)
+	require.Len(t, interchange.Data, numKeys)
+
+	for i, data := range interchange.Data {
+		require.Equal(t, pubkeys[i], data.Pubkey)
+		require.NotNil(t, data.SignedBlocks)
+		require.Empty(t, data.SignedBlocks)
+		require.Empty(t, data.SignedAttestations)
+	}
+}
+
+func TestWriteNoKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	err := web3signer.Write(dir, dir, make([]byte, 32))
+	require.ErrorContains(t, err, "no keystore files found")
+}
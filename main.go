@@ -9,6 +9,7 @@ import (
 	"syscall"
 
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/winsvc"
 	"github.com/obolnetwork/charon/cmd"
 )
 
@@ -16,7 +17,12 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	ctx = log.WithTopic(ctx, "cmd")
 
-	err := cmd.New().ExecuteContext(ctx)
+	var err error
+	if winsvc.IsService() {
+		err = winsvc.Run(ctx, winsvc.ServiceName, cmd.New().ExecuteContext)
+	} else {
+		err = cmd.New().ExecuteContext(ctx)
+	}
 
 	cancel()
 
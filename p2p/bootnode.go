@@ -30,7 +30,7 @@ func NewRelays(ctx context.Context, relayAddrs []string, lockHashHex string,
 	for _, relayAddr := range relayAddrs {
 		if strings.HasPrefix(relayAddr, "http") {
 			if !strings.HasPrefix(relayAddr, "https") {
-				log.Warn(ctx, "Relay URL does not use https protocol", nil, z.Str("addr", relayAddr))
+				log.Warn(ctx, "Relay URL does not use https protocol", nil, z.Redact(z.Str("addr", relayAddr)))
 			}
 			mutable := new(MutablePeer)
 			go resolveRelay(ctx, relayAddr, lockHashHex, mutable.Set)
@@ -41,12 +41,12 @@ func NewRelays(ctx context.Context, relayAddrs []string, lockHashHex string,
 
 		addr, err := ma.NewMultiaddr(relayAddr)
 		if err != nil {
-			return nil, errors.Wrap(err, "invalid relay multiaddr", z.Str("addr", relayAddr))
+			return nil, errors.Wrap(err, "invalid relay multiaddr", z.Redact(z.Str("addr", relayAddr)))
 		}
 
 		info, err := peer.AddrInfoFromP2pAddr(addr)
 		if err != nil {
-			return nil, errors.Wrap(err, "peer from multiaddr", z.Str("addr", relayAddr))
+			return nil, errors.Wrap(err, "peer from multiaddr", z.Redact(z.Str("addr", relayAddr)))
 		}
 
 		resp = append(resp, NewMutablePeer(NewRelayPeer(*info)))
@@ -101,7 +101,7 @@ func resolveRelay(ctx context.Context, rawURL, lockHashHex string, callback func
 
 			infos, err := peer.AddrInfosFromP2pAddrs(addrs...)
 			if err != nil {
-				log.Error(ctx, "Failed resolving relay ID from addresses", err, z.Any("addrs", addrs))
+				log.Error(ctx, "Failed resolving relay ID from addresses", err, z.Redact(z.Any("addrs", addrs)))
 			} else if len(infos) != 1 {
 				log.Error(ctx, "Failed resolving a single relay ID from addresses", nil, z.Int("n", len(infos)))
 			} else {
@@ -109,7 +109,7 @@ func resolveRelay(ctx context.Context, rawURL, lockHashHex string, callback func
 				log.Info(ctx, "Resolved new relay",
 					z.Str("peer", p.Name),
 					z.Str("url", rawURL),
-					z.Any("addrs", p.Addrs),
+					z.Redact(z.Any("addrs", p.Addrs)),
 				)
 				callback(p)
 			}
@@ -185,7 +185,7 @@ func queryRelayAddrs(ctx context.Context, relayURL string, backoff func(), lockH
 		for _, addr := range addrs {
 			maddr, err := ma.NewMultiaddr(addr)
 			if err != nil {
-				log.Warn(ctx, "Failure parsing relay multiaddrs (will try again)", err, z.Str("addr", addr))
+				log.Warn(ctx, "Failure parsing relay multiaddrs (will try again)", err, z.Redact(z.Str("addr", addr)))
 				continue
 			}
 			maddrs = append(maddrs, maddr)
@@ -198,20 +198,31 @@ func queryRelayAddrs(ctx context.Context, relayURL string, backoff func(), lockH
 }
 
 // multiAddrFromENRStr returns the multiaddr from the ENR string.
+// It prefers the IPv4 address, then the IPv6 address and then the DNS hostname of the ENR.
 func multiAddrFromENRStr(enrStr string) (ma.Multiaddr, error) {
 	r, err := enr.Parse(enrStr)
 	if err != nil {
 		return nil, errors.Wrap(err, "parse ENR")
 	}
 
-	ip, ok := r.IP()
-	if !ok {
-		return nil, errors.New("enr does not have an IP")
+	tcp, hasTCP := r.TCP()
+	tcp6, hasTCP6 := r.TCP6()
+	if !hasTCP6 {
+		// The TCP port also applies to IPv6 if no IPv6 specific port is present.
+		tcp6, hasTCP6 = tcp, hasTCP
 	}
 
-	port, ok := r.TCP()
-	if !ok {
+	var transport string
+	if ip, ok := r.IP(); ok && hasTCP {
+		transport = fmt.Sprintf("/ip4/%s/tcp/%d", ip.String(), tcp)
+	} else if ip6, ok := r.IP6(); ok && hasTCP6 {
+		transport = fmt.Sprintf("/ip6/%s/tcp/%d", ip6.String(), tcp6)
+	} else if host, ok := r.DNS(); ok && hasTCP6 {
+		transport = fmt.Sprintf("/dns/%s/tcp/%d", host, tcp6)
+	} else if !hasTCP6 {
 		return nil, errors.New("enr does not have a TCP port")
+	} else {
+		return nil, errors.New("enr does not have an IP")
 	}
 
 	id, err := PeerIDFromKey(r.PubKey)
@@ -219,7 +230,7 @@ func multiAddrFromENRStr(enrStr string) (ma.Multiaddr, error) {
 		return nil, errors.Wrap(err, "get peer ID from ENR key")
 	}
 
-	addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d/p2p/%s", ip.String(), port, id))
+	addr, err := ma.NewMultiaddr(fmt.Sprintf("%s/p2p/%s", transport, id))
 	if err != nil {
 		return nil, errors.Wrap(err, "create multiaddr")
 	}
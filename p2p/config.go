@@ -14,10 +14,10 @@ import (
 type Config struct {
 	// Relays defines the libp2p relay multiaddrs or URLs.
 	Relays []string
-	// ExternalIP is the IP advertised by libp2p.
-	ExternalIP string
-	// ExternalHost is the DNS hostname advertised by libp2p.
-	ExternalHost string
+	// ExternalIPs are the IPs (v4 or v6) advertised by libp2p.
+	ExternalIPs []string
+	// ExternalHosts are the DNS hostnames advertised by libp2p.
+	ExternalHosts []string
 	// TCPAddrs defines the lib-p2p tcp listen addresses.
 	TCPAddrs []string
 	// DisableReuseport disables TCP port reuse for libp2p.
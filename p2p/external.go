@@ -0,0 +1,154 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// externalResolvePeriod is the period at which external hostnames are resolved.
+const externalResolvePeriod = time.Minute
+
+// lookupFunc abstracts net.Resolver.LookupIP.
+type lookupFunc func(ctx context.Context, network, host string) ([]net.IP, error)
+
+// externalAddrs is the set of external addresses advertised by libp2p.
+// External hostnames are advertised as DNS multiaddrs as well as the IPs they resolve to.
+// Hostnames are resolved periodically, rotating the advertised set when the external address changes.
+// libp2p detects the change and pushes it to connected peers via identify, so peers update without restart.
+type externalAddrs struct {
+	ips    []net.IP
+	hosts  []string
+	ports  []int
+	lookup lookupFunc
+
+	mu       sync.RWMutex
+	resolved map[string][]net.IP
+}
+
+// newExternalAddrs returns the external addresses of the config.
+func newExternalAddrs(cfg Config, lookup lookupFunc) (*externalAddrs, error) {
+	tcpAddrs, err := cfg.ParseTCPAddrs()
+	if err != nil {
+		return nil, err
+	}
+
+	var ports []int
+	for _, addr := range tcpAddrs {
+		ports = append(ports, addr.Port)
+	}
+
+	var ips []net.IP
+	for _, ipStr := range cfg.ExternalIPs {
+		ip := net.ParseIP(ipStr)
+		if ip == nil {
+			return nil, errors.New("invalid external ip", z.Redact(z.Str("ip", ipStr)))
+		}
+
+		ips = append(ips, ip)
+	}
+
+	return &externalAddrs{
+		ips:      ips,
+		hosts:    cfg.ExternalHosts,
+		ports:    ports,
+		lookup:   lookup,
+		resolved: make(map[string][]net.IP),
+	}, nil
+}
+
+// Multiaddrs returns the current set of external multiaddrs using the listen TCP address ports.
+func (e *externalAddrs) Multiaddrs() ([]ma.Multiaddr, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var resp []ma.Multiaddr
+	for _, port := range e.ports {
+		for _, ip := range e.ips {
+			maddr, err := multiAddrFromIPPort(ip, port)
+			if err != nil {
+				return nil, err
+			}
+
+			resp = append(resp, maddr)
+		}
+
+		for _, host := range e.hosts {
+			maddr, err := ma.NewMultiaddr(fmt.Sprintf("/dns/%s/tcp/%d", host, port))
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid dns multiaddr")
+			}
+
+			resp = append(resp, maddr)
+
+			for _, ip := range e.resolved[host] {
+				maddr, err := multiAddrFromIPPort(ip, port)
+				if err != nil {
+					return nil, err
+				}
+
+				resp = append(resp, maddr)
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// Run resolves the external hostnames periodically until the context is cancelled.
+func (e *externalAddrs) Run(ctx context.Context) {
+	if len(e.hosts) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(externalResolvePeriod)
+	defer ticker.Stop()
+
+	for {
+		e.resolve(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// resolve resolves the external hostnames, logging when the resolved IPs change.
+// The previously resolved IPs are retained if resolution fails.
+func (e *externalAddrs) resolve(ctx context.Context) {
+	for _, host := range e.hosts {
+		ips, err := e.lookup(ctx, "ip", host)
+		if ctx.Err() != nil {
+			return
+		} else if err != nil {
+			log.Warn(ctx, "Failed to resolve external hostname", err, z.Redact(z.Str("host", host)))
+			continue
+		}
+
+		slices.SortFunc(ips, func(a, b net.IP) int { return slices.Compare(a.To16(), b.To16()) })
+
+		e.mu.Lock()
+		prev, ok := e.resolved[host]
+		e.resolved[host] = ips
+		e.mu.Unlock()
+
+		if ok && !slices.EqualFunc(prev, ips, net.IP.Equal) {
+			log.Info(ctx, "External hostname address changed, rotating advertised addresses",
+				z.Redact(z.Str("host", host)), z.Any("prev", prev), z.Any("new", ips))
+			externalRotationsCounter.Inc()
+		}
+	}
+}
@@ -3,11 +3,20 @@
 package p2p
 
 import (
+	"context"
+	"maps"
+	"time"
+
 	"github.com/libp2p/go-libp2p/core/connmgr"
 	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
+
+	"github.com/obolnetwork/charon/app/lifecycle"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
 )
 
 var _ connmgr.ConnectionGater = ConnGater{}
@@ -35,9 +44,17 @@ func NewOpenGater() ConnGater {
 
 // ConnGater filters incoming connections by the cluster peers.
 type ConnGater struct {
-	peerIDs map[peer.ID]bool
-	relays  []*MutablePeer
-	open    bool
+	peerIDs  map[peer.ID]bool
+	expiries map[peer.ID]time.Time
+	relays   []*MutablePeer
+	open     bool
+}
+
+// WithExpiringPeers returns a copy of the gater that rejects the provided peers after their expiry times.
+func (c ConnGater) WithExpiringPeers(expiries map[peer.ID]time.Time) ConnGater {
+	c.expiries = expiries
+
+	return c
 }
 
 // InterceptPeerDial does nothing.
@@ -59,6 +76,10 @@ func (c ConnGater) InterceptSecured(_ network.Direction, id peer.ID, _ network.C
 		return true
 	}
 
+	if expiry, ok := c.expiries[id]; ok && !time.Now().Before(expiry) {
+		return false
+	}
+
 	if c.peerIDs[id] {
 		return true
 	}
@@ -77,3 +98,36 @@ func (c ConnGater) InterceptSecured(_ network.Direction, id peer.ID, _ network.C
 func (ConnGater) InterceptUpgraded(_ network.Conn) (bool, control.DisconnectReason) {
 	return true, 0
 }
+
+// NewPeerExpirer returns a lifecycle hook that disconnects the provided peers at their expiry times.
+// It should be used with a connection gater rejecting the peers after their expiry, see ConnGater.WithExpiringPeers.
+func NewPeerExpirer(tcpNode host.Host, expiries map[peer.ID]time.Time) lifecycle.HookFuncCtx {
+	return func(ctx context.Context) {
+		expiries := maps.Clone(expiries)
+		for len(expiries) > 0 {
+			var (
+				next   peer.ID
+				nextAt time.Time
+			)
+			for id, expiry := range expiries {
+				if nextAt.IsZero() || expiry.Before(nextAt) {
+					next, nextAt = id, expiry
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Until(nextAt)):
+			}
+
+			log.Warn(ctx, "Peer expired, disconnecting", nil, z.Str("peer", PeerName(next)), z.Any("expiry", nextAt))
+
+			if err := tcpNode.Network().ClosePeer(next); err != nil {
+				log.Warn(ctx, "Failed to disconnect expired peer", err, z.Str("peer", PeerName(next)))
+			}
+
+			delete(expiries, next)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/crypto"
@@ -39,6 +40,20 @@ func TestInterceptSecured(t *testing.T) {
 	}
 }
 
+func TestInterceptSecuredExpiry(t *testing.T) {
+	c, err := p2p.NewConnGater([]peer.ID{"peer", "expired", "unexpired"}, nil)
+	require.NoError(t, err)
+
+	c = c.WithExpiringPeers(map[peer.ID]time.Time{
+		"expired":   time.Now().Add(-time.Minute),
+		"unexpired": time.Now().Add(time.Hour),
+	})
+
+	require.True(t, c.InterceptSecured(0, "peer", nil))
+	require.False(t, c.InterceptSecured(0, "expired", nil))
+	require.True(t, c.InterceptSecured(0, "unexpired", nil))
+}
+
 func TestP2PConnGating(t *testing.T) {
 	c, err := p2p.NewConnGater(nil, nil)
 	require.NoError(t, err)
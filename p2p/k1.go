@@ -3,10 +3,15 @@
 package p2p
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
 	"os"
 	"path"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/crypto/pb"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/k1util"
@@ -45,3 +50,62 @@ func NewSavedPrivKey(datadir string) (*k1.PrivateKey, error) {
 
 	return key, nil
 }
+
+// SignerPrivKey returns a libp2p private key delegating signing to the signer.
+// The in-memory private key is returned for local signers.
+func SignerPrivKey(signer k1util.Signer) crypto.PrivKey {
+	if key, ok := k1util.PrivKeyFromSigner(signer); ok {
+		return (*crypto.Secp256k1PrivateKey)(key)
+	}
+
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+
+	return signerPrivKey{signer: signer, secret: secret}
+}
+
+// signerPrivKey implements crypto.PrivKey for secp256k1 keys held by an external signer.
+type signerPrivKey struct {
+	signer k1util.Signer
+	secret []byte
+}
+
+// Sign returns a DER encoded signature of the sha256 hash of the data, as per crypto.Secp256k1PrivateKey.
+func (k signerPrivKey) Sign(data []byte) ([]byte, error) {
+	hash := sha256.Sum256(data)
+
+	sig, err := k.signer.Sign(hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var r, s k1.ModNScalar
+	if r.SetByteSlice(sig[:32]) || s.SetByteSlice(sig[32:64]) {
+		return nil, errors.New("signature scalar overflow")
+	}
+
+	return ecdsa.NewSignature(&r, &s).Serialize(), nil
+}
+
+func (k signerPrivKey) GetPublic() crypto.PubKey {
+	return (*crypto.Secp256k1PublicKey)(k.signer.PubKey())
+}
+
+// Raw returns a random per-process secret since the private key isn't available.
+// libp2p only uses it as key material to derive QUIC stateless reset and token keys.
+func (k signerPrivKey) Raw() ([]byte, error) {
+	return k.secret, nil
+}
+
+func (signerPrivKey) Type() pb.KeyType {
+	return pb.KeyType_Secp256k1
+}
+
+func (k signerPrivKey) Equals(other crypto.Key) bool {
+	otherKey, ok := other.(signerPrivKey)
+	if !ok {
+		return false
+	}
+
+	return k.signer.PubKey().IsEqual(otherKey.signer.PubKey())
+}
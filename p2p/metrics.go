@@ -44,6 +44,12 @@ var (
 		Help:      "Current libp2p reachability status of this node as detected by autonat: unknown(0), public(1) or private(2).",
 	})
 
+	externalRotationsCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "p2p",
+		Name:      "external_address_rotations_total",
+		Help:      "Total number of times the advertised external addresses rotated due to external hostnames resolving to new IPs.",
+	})
+
 	relayConnGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "p2p",
 		Name:      "relay_connections",
@@ -11,7 +11,6 @@ import (
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/libp2p/go-libp2p"
-	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/event"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
@@ -24,6 +23,7 @@ import (
 	manet "github.com/multiformats/go-multiaddr/net"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/app/lifecycle"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/version"
@@ -35,6 +35,13 @@ var activationThreshOnce = sync.Once{}
 // NewTCPNode returns a started tcp-based libp2p host.
 func NewTCPNode(ctx context.Context, cfg Config, key *k1.PrivateKey, connGater ConnGater,
 	filterPrivateAddrs bool, opts ...libp2p.Option,
+) (host.Host, error) {
+	return NewTCPNodeWithSigner(ctx, cfg, k1util.NewSigner(key), connGater, filterPrivateAddrs, opts...)
+}
+
+// NewTCPNodeWithSigner returns a started tcp-based libp2p host with identity key held by the signer.
+func NewTCPNodeWithSigner(ctx context.Context, cfg Config, signer k1util.Signer, connGater ConnGater,
+	filterPrivateAddrs bool, opts ...libp2p.Option,
 ) (host.Host, error) {
 	activationThreshOnce.Do(func() {
 		// Use own observed addresses as soon as a single relay reports it.
@@ -51,10 +58,11 @@ func NewTCPNode(ctx context.Context, cfg Config, key *k1.PrivateKey, connGater C
 		log.Info(ctx, "LibP2P not accepting incoming connections since --p2p-tcp-addresses empty")
 	}
 
-	externalAddrs, err := externalMultiAddrs(cfg)
+	externalAddrs, err := newExternalAddrs(cfg, net.DefaultResolver.LookupIP)
 	if err != nil {
 		return nil, err
 	}
+	go externalAddrs.Run(ctx)
 
 	var tcpOpts []any // libp2p.Transport requires empty interface options.
 	if cfg.DisableReuseport {
@@ -64,7 +72,7 @@ func NewTCPNode(ctx context.Context, cfg Config, key *k1.PrivateKey, connGater C
 	// Init options.
 	defaultOpts := []libp2p.Option{
 		// Set P2P identity key.
-		libp2p.Identity((*crypto.Secp256k1PrivateKey)(key)),
+		libp2p.Identity(SignerPrivKey(signer)),
 		// Set TCP listen addresses.
 		libp2p.ListenAddrs(addrs...),
 		// Set up user-agent.
@@ -74,7 +82,12 @@ func NewTCPNode(ctx context.Context, cfg Config, key *k1.PrivateKey, connGater C
 		// Enable Autonat (required for hole punching)
 		libp2p.EnableNATService(),
 		libp2p.AddrsFactory(func(internalAddrs []ma.Multiaddr) []ma.Multiaddr {
-			return filterAdvertisedAddrs(externalAddrs, internalAddrs, filterPrivateAddrs)
+			extAddrs, err := externalAddrs.Multiaddrs()
+			if err != nil {
+				log.Warn(ctx, "Failed to build external addresses", err)
+			}
+
+			return filterAdvertisedAddrs(extAddrs, internalAddrs, filterPrivateAddrs)
 		}),
 		libp2p.Transport(tcp.NewTCPTransport, tcpOpts...),
 		libp2p.SwarmOpts(swarm.WithDialRanker(swarm.NoDelayDialRanker)),
@@ -117,46 +130,6 @@ func filterAdvertisedAddrs(externalAddrs, internalAddrs []ma.Multiaddr, excludeI
 	return resp
 }
 
-// externalMultiAddrs returns the external IP and Hostname fields as multiaddrs using the listen TCP address ports.
-func externalMultiAddrs(cfg Config) ([]ma.Multiaddr, error) {
-	tcpAddrs, err := cfg.ParseTCPAddrs()
-	if err != nil {
-		return nil, err
-	}
-
-	var ports []int
-	for _, addr := range tcpAddrs {
-		ports = append(ports, addr.Port)
-	}
-
-	var resp []ma.Multiaddr
-
-	if cfg.ExternalIP != "" {
-		ip := net.ParseIP(cfg.ExternalIP)
-		for _, port := range ports {
-			maddr, err := multiAddrFromIPPort(ip, port)
-			if err != nil {
-				return nil, err
-			}
-
-			resp = append(resp, maddr)
-		}
-	}
-
-	if cfg.ExternalHost != "" {
-		for _, port := range ports {
-			maddr, err := ma.NewMultiaddr(fmt.Sprintf("/dns/%s/tcp/%d", cfg.ExternalHost, port))
-			if err != nil {
-				return nil, errors.Wrap(err, "invalid dns multiaddr")
-			}
-
-			resp = append(resp, maddr)
-		}
-	}
-
-	return resp, nil
-}
-
 // multiAddrsViaRelay returns multiaddrs to the peer via the relay.
 // See https://github.com/libp2p/go-libp2p/blob/master/examples/relay/main.go.
 func multiAddrsViaRelay(relayPeer Peer, peerID peer.ID) ([]ma.Multiaddr, error) {
@@ -342,24 +315,24 @@ func RegisterConnectionLogger(ctx context.Context, tcpNode host.Host, peerIDs []
 				}
 			case e := <-events:
 				// Log and instrument events.
-				addr := NamedAddr(e.Addr)
+				addr := z.Redact(z.Lazy("peer_address", func() any { return NamedAddr(e.Addr) }))
 				name := PeerName(e.Peer)
 				typ := addrType(e.Addr)
 
 				if e.Listen {
-					log.Debug(ctx, "Libp2p listening on address", z.Str("address", addr))
+					log.Debug(ctx, "Libp2p listening on address", z.Redact(z.Lazy("address", func() any { return NamedAddr(e.Addr) })))
 					continue
 				} else if e.Connected {
 					log.Debug(ctx, "Libp2p new connection",
 						z.Str("peer", name),
-						z.Any("peer_address", addr),
+						addr,
 						z.Any("direction", e.Direction),
 						z.Str("type", typ),
 					)
 				} else if e.Disconnect {
 					log.Debug(ctx, "Libp2p disconnected",
 						z.Str("peer", name),
-						z.Any("peer_address", addr),
+						addr,
 						z.Any("direction", e.Direction),
 						z.Str("type", typ),
 					)
@@ -3,6 +3,8 @@
 package p2p
 
 import (
+	"context"
+	"net"
 	"testing"
 
 	ma "github.com/multiformats/go-multiaddr"
@@ -80,3 +82,46 @@ func TestFilterAdvertisedAddrs(t *testing.T) {
 		})
 	}
 }
+
+func TestExternalAddrsRotation(t *testing.T) {
+	ctx := context.Background()
+
+	resolved := []net.IP{net.ParseIP("2.2.2.2")}
+	lookup := func(context.Context, string, string) ([]net.IP, error) {
+		return resolved, nil
+	}
+
+	ext, err := newExternalAddrs(Config{
+		TCPAddrs:      []string{"0.0.0.0:3610"},
+		ExternalIPs:   []string{"1.1.1.1", "2001:db8::1"},
+		ExternalHosts: []string{"charon.example.com"},
+	}, lookup)
+	require.NoError(t, err)
+
+	requireAddrs := func(t *testing.T, expect ...string) {
+		t.Helper()
+
+		addrs, err := ext.Multiaddrs()
+		require.NoError(t, err)
+
+		var actual []string
+		for _, addr := range addrs {
+			actual = append(actual, addr.String())
+		}
+		require.Equal(t, expect, actual)
+	}
+
+	// Hostname only advertised as DNS multiaddr before it is resolved.
+	requireAddrs(t, "/ip4/1.1.1.1/tcp/3610", "/ip6/2001:db8::1/tcp/3610", "/dns/charon.example.com/tcp/3610")
+
+	ext.resolve(ctx)
+	requireAddrs(t, "/ip4/1.1.1.1/tcp/3610", "/ip6/2001:db8::1/tcp/3610", "/dns/charon.example.com/tcp/3610", "/ip4/2.2.2.2/tcp/3610")
+
+	// Rotate the external hostname address.
+	resolved = []net.IP{net.ParseIP("3.3.3.3")}
+	ext.resolve(ctx)
+	requireAddrs(t, "/ip4/1.1.1.1/tcp/3610", "/ip6/2001:db8::1/tcp/3610", "/dns/charon.example.com/tcp/3610", "/ip4/3.3.3.3/tcp/3610")
+
+	_, err = newExternalAddrs(Config{ExternalIPs: []string{"invalid"}}, lookup)
+	require.ErrorContains(t, err, "invalid external ip")
+}
@@ -139,7 +139,11 @@ func (p *MutablePeer) Subscribe(sub func(Peer)) {
 
 // VerifyP2PKey returns an error if the p2pkey doesn't match any lock operator ENR.
 func VerifyP2PKey(peers []Peer, key *k1.PrivateKey) error {
-	want := key.PubKey()
+	return VerifyP2PPubKey(peers, key.PubKey())
+}
+
+// VerifyP2PPubKey returns an error if the p2p public key doesn't match any lock operator ENR.
+func VerifyP2PPubKey(peers []Peer, want *k1.PublicKey) error {
 
 	for _, p := range peers {
 		pk, err := p.ID.ExtractPublicKey()
@@ -8,8 +8,10 @@ import (
 	"testing"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 
+	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/eth2util/enr"
 	"github.com/obolnetwork/charon/p2p"
@@ -36,6 +38,42 @@ func TestNewHost(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// externalSigner wraps a local signer to hide the in-memory private key.
+type externalSigner struct {
+	k1util.Signer
+}
+
+func TestSignerPrivKey(t *testing.T) {
+	key, err := k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	privKey := p2p.SignerPrivKey(externalSigner{Signer: k1util.NewSigner(key)})
+	raw, err := privKey.Raw()
+	require.NoError(t, err)
+	require.NotEqual(t, key.Serialize(), raw)
+
+	data := []byte("charon")
+	sig, err := privKey.Sign(data)
+	require.NoError(t, err)
+
+	ok, err := privKey.GetPublic().Verify(data, sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Ensure libp2p handshakes succeed with a host using an external signer.
+	ctx := context.Background()
+	external, err := p2p.NewTCPNodeWithSigner(ctx, p2p.Config{TCPAddrs: []string{testutil.AvailableAddr(t).String()}},
+		externalSigner{Signer: k1util.NewSigner(key)}, p2p.NewOpenGater(), false)
+	require.NoError(t, err)
+	defer external.Close()
+
+	local, err := p2p.NewTCPNode(ctx, p2p.Config{}, testutil.GenerateInsecureK1Key(t, 1), p2p.NewOpenGater(), false)
+	require.NoError(t, err)
+	defer local.Close()
+
+	require.NoError(t, local.Connect(ctx, peer.AddrInfo{ID: external.ID(), Addrs: external.Addrs()}))
+}
+
 func TestVerifyP2PKey(t *testing.T) {
 	seed := 0
 	random := rand.New(rand.NewSource(int64(seed)))
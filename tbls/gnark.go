@@ -0,0 +1,211 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tbls
+
+import (
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// dst is the Ethereum BLS signature domain separation tag (proof of possession scheme).
+var dst = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+// Gnark is an implementation using the pure Go gnark-crypto library for operations on public data only,
+// i.e. verification, aggregation and public key recovery. gnark-crypto's scalar multiplication isn't
+// constant time, so all operations on secret keys and key shares, including signing, are delegated to Herumi.
+type Gnark struct {
+	Herumi
+}
+
+func (Gnark) RecoverPublicKey(publicSharesByIndex map[int]PublicKey) (PublicKey, error) {
+	coefs, err := lagrangeCoefficients(publicSharesByIndex)
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	var agg bls12381.G1Jac
+	for idx, share := range publicSharesByIndex {
+		pk, err := gnarkPublicKey(share)
+		if err != nil {
+			return PublicKey{}, errors.Wrap(err, "cannot unmarshal public share", z.Int("key_number", idx))
+		}
+
+		var jac bls12381.G1Jac
+		jac.ScalarMultiplication(jac.FromAffine(&pk), coefs[idx].BigInt(new(big.Int)))
+		agg.AddAssign(&jac)
+	}
+
+	var resp bls12381.G1Affine
+	resp.FromJacobian(&agg)
+
+	return resp.Bytes(), nil
+}
+
+func (Gnark) Aggregate(signs []Signature) (Signature, error) {
+	var agg bls12381.G2Jac
+	for idx, rawSignature := range signs {
+		sig, err := gnarkSignature(rawSignature)
+		if err != nil {
+			return Signature{}, errors.Wrap(err, "cannot unmarshal signature", z.Int("signature_number", idx))
+		}
+
+		var jac bls12381.G2Jac
+		agg.AddAssign(jac.FromAffine(&sig))
+	}
+
+	var resp bls12381.G2Affine
+	resp.FromJacobian(&agg)
+
+	return resp.Bytes(), nil
+}
+
+func (Gnark) ThresholdAggregate(partialSignaturesByIndex map[int]Signature) (Signature, error) {
+	coefs, err := lagrangeCoefficients(partialSignaturesByIndex)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	var agg bls12381.G2Jac
+	for idx, rawSignature := range partialSignaturesByIndex {
+		sig, err := gnarkSignature(rawSignature)
+		if err != nil {
+			return Signature{}, errors.Wrap(err, "cannot unmarshal signature", z.Int("signature_number", idx))
+		}
+
+		var jac bls12381.G2Jac
+		jac.ScalarMultiplication(jac.FromAffine(&sig), coefs[idx].BigInt(new(big.Int)))
+		agg.AddAssign(&jac)
+	}
+
+	var resp bls12381.G2Affine
+	resp.FromJacobian(&agg)
+
+	return resp.Bytes(), nil
+}
+
+func (Gnark) Verify(compressedPublicKey PublicKey, data []byte, rawSignature Signature) error {
+	pk, err := gnarkPublicKey(compressedPublicKey)
+	if err != nil {
+		return err
+	}
+
+	sig, err := gnarkSignature(rawSignature)
+	if err != nil {
+		return errors.Wrap(err, "cannot unmarshal signature")
+	}
+
+	return gnarkVerify(pk, data, sig)
+}
+
+func (Gnark) VerifyAggregate(publicShares []PublicKey, signature Signature, data []byte) error {
+	if len(publicShares) == 0 {
+		return errors.New("no public keys to verify against")
+	}
+
+	sig, err := gnarkSignature(signature)
+	if err != nil {
+		return errors.Wrap(err, "cannot unmarshal signature")
+	}
+
+	var agg bls12381.G1Jac
+	for _, share := range publicShares {
+		pk, err := gnarkPublicKey(share)
+		if err != nil {
+			return err
+		}
+
+		var jac bls12381.G1Jac
+		agg.AddAssign(jac.FromAffine(&pk))
+	}
+
+	var pk bls12381.G1Affine
+	pk.FromJacobian(&agg)
+
+	return gnarkVerify(pk, data, sig)
+}
+
+// gnarkVerify returns an error if the signature of data isn't valid for the public key.
+func gnarkVerify(pk bls12381.G1Affine, data []byte, sig bls12381.G2Affine) error {
+	h, err := bls12381.HashToG2(data, dst)
+	if err != nil {
+		return errors.Wrap(err, "hash to curve")
+	}
+
+	_, _, g1, _ := bls12381.Generators()
+	var negG1 bls12381.G1Affine
+	negG1.Neg(&g1)
+
+	// Check e(pk, H(m)) * e(-g1, sig) == 1.
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{pk, negG1}, []bls12381.G2Affine{h, sig})
+	if err != nil {
+		return errors.Wrap(err, "pairing check")
+	} else if !ok {
+		return errors.New("signature not verified")
+	}
+
+	return nil
+}
+
+// lagrangeCoefficients returns the Lagrange coefficients at zero of the share indexes.
+func lagrangeCoefficients[T any](sharesByIndex map[int]T) (map[int]*fr.Element, error) {
+	if len(sharesByIndex) == 0 {
+		return nil, errors.New("no shares provided")
+	}
+
+	resp := make(map[int]*fr.Element)
+	for i := range sharesByIndex {
+		if i < 1 {
+			return nil, errors.New("invalid share index", z.Int("index", i))
+		}
+
+		num := new(fr.Element).SetOne()
+		den := new(fr.Element).SetOne()
+
+		var xi fr.Element
+		xi.SetUint64(uint64(i))
+
+		for j := range sharesByIndex {
+			if i == j {
+				continue
+			}
+
+			var xj, diff fr.Element
+			xj.SetUint64(uint64(j))
+			diff.Sub(&xj, &xi)
+
+			num.Mul(num, &xj)
+			den.Mul(den, &diff)
+		}
+
+		resp[i] = num.Div(num, den)
+	}
+
+	return resp, nil
+}
+
+// gnarkPublicKey returns the subgroup checked G1 point of the public key, which may not be infinity.
+func gnarkPublicKey(pubkey PublicKey) (bls12381.G1Affine, error) {
+	var pk bls12381.G1Affine
+	if _, err := pk.SetBytes(pubkey[:]); err != nil {
+		return bls12381.G1Affine{}, errors.Wrap(err, "cannot unmarshal public key")
+	} else if pk.IsInfinity() {
+		return bls12381.G1Affine{}, errors.New("infinity public key")
+	}
+
+	return pk, nil
+}
+
+// gnarkSignature returns the subgroup checked G2 point of the signature.
+func gnarkSignature(signature Signature) (bls12381.G2Affine, error) {
+	var sig bls12381.G2Affine
+	if _, err := sig.SetBytes(signature[:]); err != nil {
+		return bls12381.G2Affine{}, errors.Wrap(err, "invalid signature")
+	}
+
+	return sig, nil
+}
@@ -53,18 +53,15 @@ func (Herumi) GenerateInsecureKey(t *testing.T, random io.Reader) (PrivateKey, e
 
 func (Herumi) GenerateSecretKey() (PrivateKey, error) {
 	var p bls.SecretKey
+	defer clearSecrets(&p)
 	p.SetByCSPRNG()
 
-	// Commenting here once, this syntax will appear often:
-	// here I'm converting the output of p.Serialize() to a pointer to instance of v2.PrivateKey, which is
-	// an array with constant size.
-	// I'm dereferencing it to return a copy as well.
-	// Ref: https://go.dev/ref/spec#Conversions_from_slice_to_array_pointer
-	return *(*PrivateKey)(p.Serialize()), nil
+	return serializeSecret(&p), nil
 }
 
 func (Herumi) SecretToPublicKey(secret PrivateKey) (PublicKey, error) {
 	var p bls.SecretKey
+	defer clearSecrets(&p)
 
 	if err := p.Deserialize(secret[:]); err != nil {
 		return PublicKey{}, errors.Wrap(err, "cannot unmarshal secret into Herumi secret key")
@@ -128,7 +125,8 @@ func (Herumi) ThresholdSplitInsecure(t *testing.T, secret PrivateKey, total uint
 			return nil, errors.Wrap(err, "cannot set ID on polynomial", z.Int("id_number", i))
 		}
 
-		ret[i] = *(*PrivateKey)(sk.Serialize())
+		ret[i] = serializeSecret(&sk)
+		clearSecrets(&sk)
 	}
 
 	return ret, nil
@@ -136,6 +134,7 @@ func (Herumi) ThresholdSplitInsecure(t *testing.T, secret PrivateKey, total uint
 
 func (Herumi) ThresholdSplit(secret PrivateKey, total uint, threshold uint) (map[int]PrivateKey, error) {
 	var p bls.SecretKey
+	defer clearSecrets(&p)
 
 	if threshold <= 1 {
 		return nil, errors.New("threshold has to be greater than 1")
@@ -147,14 +146,17 @@ func (Herumi) ThresholdSplit(secret PrivateKey, total uint, threshold uint) (map
 
 	// master key Polynomial
 	poly := make([]bls.SecretKey, threshold)
+	defer func() {
+		for i := range poly {
+			clearSecrets(&poly[i])
+		}
+	}()
 
 	poly[0] = p
 
 	// initialize threshold amount of points
 	for i := 1; i < int(threshold); i++ {
-		var sk bls.SecretKey
-		sk.SetByCSPRNG()
-		poly[i] = sk
+		poly[i].SetByCSPRNG()
 	}
 
 	ret := make(map[int]PrivateKey)
@@ -178,7 +180,8 @@ func (Herumi) ThresholdSplit(secret PrivateKey, total uint, threshold uint) (map
 			return nil, errors.Wrap(err, "cannot set ID on polynomial", z.Int("id_number", i))
 		}
 
-		ret[i] = *(*PrivateKey)(sk.Serialize())
+		ret[i] = serializeSecret(&sk)
+		clearSecrets(&sk)
 	}
 
 	return ret, nil
@@ -190,6 +193,12 @@ func (Herumi) RecoverSecret(shares map[int]PrivateKey, _, _ uint) (PrivateKey, e
 		rawKeys []bls.SecretKey
 		rawIDs  []bls.ID
 	)
+	defer func() {
+		clearSecrets(&pk)
+		for i := range rawKeys {
+			clearSecrets(&rawKeys[i])
+		}
+	}()
 
 	for idx, key := range shares {
 		var kpk bls.SecretKey
@@ -202,6 +211,7 @@ func (Herumi) RecoverSecret(shares map[int]PrivateKey, _, _ uint) (PrivateKey, e
 		}
 
 		rawKeys = append(rawKeys, kpk)
+		clearSecrets(&kpk)
 
 		var id bls.ID
 		if err := id.SetDecString(strconv.Itoa(idx)); err != nil {
@@ -219,7 +229,37 @@ func (Herumi) RecoverSecret(shares map[int]PrivateKey, _, _ uint) (PrivateKey, e
 		return PrivateKey{}, errors.Wrap(err, "cannot recover full private key from partial keys")
 	}
 
-	return *(*PrivateKey)(pk.Serialize()), nil
+	return serializeSecret(&pk), nil
+}
+
+func (Herumi) RecoverPublicKey(publicSharesByIndex map[int]PublicKey) (PublicKey, error) {
+	var (
+		rawKeys []bls.PublicKey
+		rawIDs  []bls.ID
+	)
+
+	for idx, share := range publicSharesByIndex {
+		var pubKey bls.PublicKey
+		if err := pubKey.Deserialize(share[:]); err != nil {
+			return PublicKey{}, errors.Wrap(err, "cannot set compressed public key in Herumi format", z.Int("key_number", idx))
+		}
+
+		rawKeys = append(rawKeys, pubKey)
+
+		var id bls.ID
+		if err := id.SetDecString(strconv.Itoa(idx)); err != nil {
+			return PublicKey{}, errors.Wrap(err, "public key id isn't a number", z.Int("key_number", idx))
+		}
+
+		rawIDs = append(rawIDs, id)
+	}
+
+	var pk bls.PublicKey
+	if err := pk.Recover(rawKeys, rawIDs); err != nil {
+		return PublicKey{}, errors.Wrap(err, "cannot recover full public key from public shares")
+	}
+
+	return *(*PublicKey)(pk.Serialize()), nil
 }
 
 func (Herumi) Aggregate(signs []Signature) (Signature, error) {
@@ -305,6 +345,7 @@ func (Herumi) Verify(compressedPublicKey PublicKey, data []byte, rawSignature Si
 
 func (Herumi) Sign(privateKey PrivateKey, data []byte) (Signature, error) {
 	var p bls.SecretKey
+	defer clearSecrets(&p)
 
 	if err := p.Deserialize(privateKey[:]); err != nil {
 		return Signature{}, errors.Wrap(err, "cannot unmarshal secret into Herumi secret key")
@@ -361,3 +402,20 @@ func generateInsecureSecret(t *testing.T, random io.Reader) (bls.SecretKey, erro
 
 	return bls.SecretKey{}, errors.New("cannot generate insecure key")
 }
+
+// serializeSecret returns the Herumi secret key as a PrivateKey, zeroizing the intermediate serialized buffer.
+func serializeSecret(p *bls.SecretKey) PrivateKey {
+	b := p.Serialize()
+	defer clear(b)
+
+	// Converting the serialized bytes to a pointer to a PrivateKey array and dereferencing it to return a copy.
+	// Ref: https://go.dev/ref/spec#Conversions_from_slice_to_array_pointer
+	return *(*PrivateKey)(b)
+}
+
+// clearSecrets zeroizes the Herumi secret keys.
+func clearSecrets(keys ...*bls.SecretKey) {
+	for _, key := range keys {
+		*key = bls.SecretKey{}
+	}
+}
@@ -4,13 +4,29 @@ package tbls
 
 import (
 	"io"
+	"sort"
 	"sync"
 	"testing"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// BackendHerumi is the default Herumi (cgo) implementation.
+	BackendHerumi = "herumi"
+	// BackendGnark is the gnark-crypto implementation of verification and aggregation, signing with Herumi.
+	BackendGnark = "gnark"
 )
 
 var (
 	impl     Implementation = Herumi{}
 	implLock sync.Mutex
+
+	backends = map[string]Implementation{
+		BackendHerumi: Herumi{},
+		BackendGnark:  Gnark{},
+	}
 )
 
 type (
@@ -48,6 +64,9 @@ type Implementation interface {
 	// RecoverSecret recovers the original secret off the input shares.
 	RecoverSecret(shares map[int]PrivateKey, total uint, threshold uint) (PrivateKey, error)
 
+	// RecoverPublicKey recovers the original public key off the input public shares.
+	RecoverPublicKey(publicSharesByIndex map[int]PublicKey) (PublicKey, error)
+
 	// ThresholdAggregate aggregates the partial signatures passed in input in the final original signature.
 	ThresholdAggregate(partialSignaturesByIndex map[int]Signature) (Signature, error)
 
@@ -75,6 +94,39 @@ func SetImplementation(newImpl Implementation) {
 	impl = newImpl
 }
 
+// Backends returns the names of the available implementations.
+func Backends() []string {
+	var resp []string
+	for name := range backends {
+		resp = append(resp, name)
+	}
+	sort.Strings(resp)
+
+	return resp
+}
+
+// Backend returns the named implementation.
+func Backend(name string) (Implementation, error) {
+	backend, ok := backends[name]
+	if !ok {
+		return nil, errors.New("unknown bls backend", z.Str("backend", name), z.Any("supported", Backends()))
+	}
+
+	return backend, nil
+}
+
+// SetBackend sets the named implementation as the package backing implementation.
+func SetBackend(name string) error {
+	backend, err := Backend(name)
+	if err != nil {
+		return err
+	}
+
+	SetImplementation(backend)
+
+	return nil
+}
+
 // GenerateSecretKey generates a secret key and returns its compressed serialized representation.
 func GenerateSecretKey() (PrivateKey, error) {
 	return impl.GenerateSecretKey()
@@ -111,6 +163,11 @@ func RecoverSecret(shares map[int]PrivateKey, total uint, threshold uint) (Priva
 	return impl.RecoverSecret(shares, total, threshold)
 }
 
+// RecoverPublicKey recovers the original public key off the input public shares.
+func RecoverPublicKey(publicSharesByIndex map[int]PublicKey) (PublicKey, error) {
+	return impl.RecoverPublicKey(publicSharesByIndex)
+}
+
 // ThresholdAggregate aggregates the partial signatures passed in input in the final original signature.
 func ThresholdAggregate(partialSignaturesByIndex map[int]Signature) (Signature, error) {
 	return impl.ThresholdAggregate(partialSignaturesByIndex)
@@ -6,8 +6,10 @@ import (
 	"crypto/rand"
 	"io"
 	"math/big"
+	mrand "math/rand"
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/obolnetwork/charon/tbls"
@@ -69,6 +71,31 @@ func (ts *TestSuite) Test_RecoverSecret() {
 	ts.Require().ElementsMatch(secret, recovered)
 }
 
+func (ts *TestSuite) Test_RecoverPublicKey() {
+	secret, err := tbls.GenerateSecretKey()
+	ts.Require().NoError(err)
+
+	pubkey, err := tbls.SecretToPublicKey(secret)
+	ts.Require().NoError(err)
+
+	shares, err := tbls.ThresholdSplit(secret, 5, 3)
+	ts.Require().NoError(err)
+
+	pubshares := make(map[int]tbls.PublicKey)
+	for idx, share := range shares {
+		if idx == 2 || idx == 4 {
+			continue
+		}
+
+		pubshares[idx], err = tbls.SecretToPublicKey(share)
+		ts.Require().NoError(err)
+	}
+
+	recovered, err := tbls.RecoverPublicKey(pubshares)
+	ts.Require().NoError(err)
+	ts.Require().Equal(pubkey, recovered)
+}
+
 func (ts *TestSuite) Test_ThresholdAggregate() {
 	data := []byte("hello obol!")
 
@@ -177,6 +204,60 @@ func TestHerumiImplementation(t *testing.T) {
 	runSuite(t, tbls.Herumi{})
 }
 
+func TestGnarkImplementation(t *testing.T) {
+	runSuite(t, tbls.Gnark{})
+}
+
+// TestGnarkHerumiCompatibility ensures that the gnark operations on public data produce results identical to herumi.
+func TestGnarkHerumiCompatibility(t *testing.T) {
+	herumi, gnark := tbls.Herumi{}, tbls.Gnark{}
+	data := []byte("hello obol!")
+
+	secret, err := herumi.GenerateSecretKey()
+	require.NoError(t, err)
+
+	pubkey, err := herumi.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	shares, err := herumi.ThresholdSplitInsecure(t, secret, 4, 3, mrand.New(mrand.NewSource(1)))
+	require.NoError(t, err)
+
+	var (
+		partials  = make(map[int]tbls.Signature)
+		pubshares = make(map[int]tbls.PublicKey)
+		sigs      []tbls.Signature
+	)
+	for idx, share := range shares {
+		partials[idx], err = herumi.Sign(share, data)
+		require.NoError(t, err)
+		pubshares[idx], err = herumi.SecretToPublicKey(share)
+		require.NoError(t, err)
+
+		require.NoError(t, gnark.Verify(pubshares[idx], data, partials[idx]))
+		sigs = append(sigs, partials[idx])
+	}
+
+	herumiSig, err := herumi.ThresholdAggregate(partials)
+	require.NoError(t, err)
+	gnarkSig, err := gnark.ThresholdAggregate(partials)
+	require.NoError(t, err)
+	require.Equal(t, herumiSig, gnarkSig)
+	require.NoError(t, gnark.Verify(pubkey, data, gnarkSig))
+
+	herumiAgg, err := herumi.Aggregate(sigs)
+	require.NoError(t, err)
+	gnarkAgg, err := gnark.Aggregate(sigs)
+	require.NoError(t, err)
+	require.Equal(t, herumiAgg, gnarkAgg)
+
+	herumiRecovered, err := herumi.RecoverPublicKey(pubshares)
+	require.NoError(t, err)
+	gnarkRecovered, err := gnark.RecoverPublicKey(pubshares)
+	require.NoError(t, err)
+	require.Equal(t, pubkey, herumiRecovered)
+	require.Equal(t, pubkey, gnarkRecovered)
+}
+
 func runBenchmark(b *testing.B, impl tbls.Implementation) {
 	b.Helper()
 	s := NewTestSuite(impl)
@@ -195,6 +276,7 @@ func runBenchmark(b *testing.B, impl tbls.Implementation) {
 		s.Test_SecretToPublicKey()
 		s.Test_ThresholdSplit()
 		s.Test_RecoverSecret()
+		s.Test_RecoverPublicKey()
 		s.Test_ThresholdAggregate()
 		s.Test_Verify()
 		s.Test_Sign()
@@ -206,10 +288,15 @@ func BenchmarkHerumiImplementation(b *testing.B) {
 	runBenchmark(b, tbls.Herumi{})
 }
 
+func BenchmarkGnarkImplementation(b *testing.B) {
+	runBenchmark(b, tbls.Gnark{})
+}
+
 func TestRandomized(t *testing.T) {
 	runSuite(t, randomizedImpl{
 		implementations: []tbls.Implementation{
 			tbls.Herumi{},
+			tbls.Gnark{},
 		},
 	})
 }
@@ -293,6 +380,15 @@ func (r randomizedImpl) RecoverSecret(shares map[int]tbls.PrivateKey, total uint
 	return impl.RecoverSecret(shares, total, threshold)
 }
 
+func (r randomizedImpl) RecoverPublicKey(publicSharesByIndex map[int]tbls.PublicKey) (tbls.PublicKey, error) {
+	impl, err := r.selectImpl()
+	if err != nil {
+		return tbls.PublicKey{}, err
+	}
+
+	return impl.RecoverPublicKey(publicSharesByIndex)
+}
+
 func (r randomizedImpl) ThresholdAggregate(partialSignaturesByIndex map[int]tbls.Signature) (tbls.Signature, error) {
 	impl, err := r.selectImpl()
 	if err != nil {
@@ -343,3 +439,13 @@ func FuzzRandomImplementations(f *testing.F) {
 		TestRandomized(t)
 	})
 }
+
+func TestBackends(t *testing.T) {
+	require.Equal(t, []string{tbls.BackendGnark, tbls.BackendHerumi}, tbls.Backends())
+
+	backend, err := tbls.Backend(tbls.BackendGnark)
+	require.NoError(t, err)
+	require.Equal(t, tbls.Gnark{}, backend)
+
+	require.ErrorContains(t, tbls.SetBackend("blst"), "unknown bls backend")
+}
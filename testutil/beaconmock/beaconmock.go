@@ -128,6 +128,8 @@ type Mock struct {
 	AttesterDutiesFunc                     func(context.Context, eth2p0.Epoch, []eth2p0.ValidatorIndex) ([]*eth2v1.AttesterDuty, error)
 	BlockAttestationsFunc                  func(ctx context.Context, stateID string) ([]*eth2p0.Attestation, error)
 	NodePeerCountFunc                      func(ctx context.Context) (int, error)
+	AttestationRewardsFunc                 func(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (*eth2wrap.AttestationRewards, error)
+	BlockRewardsFunc                       func(ctx context.Context, blockID string) (*eth2wrap.BlockRewards, error)
 	ProposalFunc                           func(ctx context.Context, opts *eth2api.ProposalOpts) (*eth2api.VersionedProposal, error)
 	SignedBeaconBlockFunc                  func(ctx context.Context, blockID string) (*eth2spec.VersionedSignedBeaconBlock, error)
 	ProposerDutiesFunc                     func(context.Context, eth2p0.Epoch, []eth2p0.ValidatorIndex) ([]*eth2v1.ProposerDuty, error)
@@ -285,6 +287,14 @@ func (m Mock) NodePeerCount(ctx context.Context) (int, error) {
 	return m.NodePeerCountFunc(ctx)
 }
 
+func (m Mock) AttestationRewards(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (*eth2wrap.AttestationRewards, error) {
+	return m.AttestationRewardsFunc(ctx, epoch, indices)
+}
+
+func (m Mock) BlockRewards(ctx context.Context, blockID string) (*eth2wrap.BlockRewards, error) {
+	return m.BlockRewardsFunc(ctx, blockID)
+}
+
 func (m Mock) SubmitAttestations(ctx context.Context, attestations []*eth2p0.Attestation) error {
 	return m.SubmitAttestationsFunc(ctx, attestations)
 }
@@ -26,6 +26,7 @@ import (
 const (
 	topicHead  = "head"
 	topicBlock = "block"
+	topicReorg = "chain_reorg"
 )
 
 func newHeadProducer() *headProducer {
@@ -39,8 +40,9 @@ func newHeadProducer() *headProducer {
 // headProducer is a stateful struct for providing deterministic block roots based on slot events.
 type headProducer struct {
 	// Immutable state
-	server *sse.Server
-	quit   chan struct{}
+	server   *sse.Server
+	quit     chan struct{}
+	scenario *scenarioState // Nil if no scenario is configured.
 
 	// Mutable state
 	mu             sync.Mutex
@@ -66,7 +68,7 @@ func (p *headProducer) Start(httpMock HTTPMock) error {
 		return errors.New("fetch slot duration")
 	}
 
-	startSlotTicker(p.quit, p.updateHead, genesisTime, slotDuration)
+	startSlotTicker(p.quit, p.onSlot, genesisTime, slotDuration)
 
 	return nil
 }
@@ -110,9 +112,37 @@ func (p *headProducer) setStreamIDs(topic string, streamID string) {
 	p.streamsByTopic[topic] = append(p.streamsByTopic[topic], streamID)
 }
 
+// onSlot is called at the start of each slot and updates the head, delayed if the scenario delays the block.
+func (p *headProducer) onSlot(slot eth2p0.Slot) {
+	p.scenario.setSlot(slot)
+
+	delay := p.scenario.delay(ScenarioDelayedBlock, slot)
+	if delay == 0 {
+		p.updateHead(slot)
+		return
+	}
+
+	go func() {
+		select {
+		case <-p.quit:
+		case <-time.After(delay):
+			p.updateHead(slot)
+		}
+	}()
+}
+
 // updateHead updates current head based on provided slot.
 func (p *headProducer) updateHead(slot eth2p0.Slot) {
+	prevHead := p.getCurrentHead()
+	if prevHead != nil && prevHead.Slot > slot {
+		return // Ignore blocks delayed beyond the next slot.
+	}
+
 	currentHead := pseudoRandomHeadEvent(slot)
+	if depth := p.scenario.reorgDepth(slot); depth > 0 {
+		currentHead = forkedHeadEvent(slot)
+		p.publishReorg(prevHead, currentHead, depth)
+	}
 	p.setCurrentHead(currentHead)
 
 	currentBlock := &eth2v1.BlockEvent{
@@ -157,6 +187,43 @@ func (p *headProducer) updateHead(slot eth2p0.Slot) {
 	}
 }
 
+// publishReorg publishes a chain_reorg event replacing the previous head with the new head.
+func (p *headProducer) publishReorg(prevHead, newHead *eth2v1.HeadEvent, depth uint64) {
+	if prevHead == nil {
+		prevHead = pseudoRandomHeadEvent(newHead.Slot - 1)
+	}
+
+	reorgData, err := json.Marshal(reorgEventJSON{
+		Slot:                fmt.Sprintf("%d", newHead.Slot),
+		Depth:               fmt.Sprintf("%d", depth),
+		OldHeadBlock:        fmt.Sprintf("%#x", prevHead.Block),
+		NewHeadBlock:        fmt.Sprintf("%#x", newHead.Block),
+		OldHeadState:        fmt.Sprintf("%#x", prevHead.State),
+		NewHeadState:        fmt.Sprintf("%#x", newHead.State),
+		ExecutionOptimistic: false,
+	})
+	if err != nil {
+		panic(err) // This should never happen and this is test code sorry ;)
+	}
+
+	for _, streamID := range p.getStreamIDs(topicReorg) {
+		p.server.Publish(streamID, &sse.Event{
+			Event: []byte(topicReorg),
+			Data:  reorgData,
+		})
+	}
+}
+
+type reorgEventJSON struct {
+	Slot                string `json:"slot"`
+	Depth               string `json:"depth"`
+	OldHeadBlock        string `json:"old_head_block"`
+	NewHeadBlock        string `json:"new_head_block"`
+	OldHeadState        string `json:"old_head_state"`
+	NewHeadState        string `json:"new_head_state"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
 type getBlockRootResponseJSON struct {
 	ExecutionOptimistic bool                    `json:"execution_optimistic"`
 	Data                beaconBlockRootDataJSON `json:"data"`
@@ -238,7 +305,7 @@ func (p *headProducer) handleEvents(w http.ResponseWriter, r *http.Request) {
 	r.URL.RawQuery = query.Encode()
 
 	for _, topic := range query["topics"] {
-		if topic != topicHead && topic != topicBlock {
+		if topic != topicHead && topic != topicBlock && topic != topicReorg {
 			log.Warn(context.Background(), "Unsupported topic requested", nil, z.Str("topic", topic))
 			w.WriteHeader(http.StatusInternalServerError)
 			resp, err := json.Marshal(errorMsgJSON{
@@ -290,7 +357,17 @@ func startSlotTicker(quit chan struct{}, callback func(eth2p0.Slot), genesisTime
 }
 
 func pseudoRandomHeadEvent(slot eth2p0.Slot) *eth2v1.HeadEvent {
-	r := rand.New(rand.NewSource(int64(slot))) //nolint:gosec
+	return seededHeadEvent(slot, int64(slot))
+}
+
+// forkedHeadEvent returns a deterministic head event of a different fork than pseudoRandomHeadEvent.
+func forkedHeadEvent(slot eth2p0.Slot) *eth2v1.HeadEvent {
+	return seededHeadEvent(slot, ^int64(slot))
+}
+
+// seededHeadEvent returns a head event of the slot with roots generated from the seed.
+func seededHeadEvent(slot eth2p0.Slot, seed int64) *eth2v1.HeadEvent {
+	r := rand.New(rand.NewSource(seed)) //nolint:gosec
 
 	root := func() eth2p0.Root {
 		var root eth2p0.Root
@@ -542,6 +542,12 @@ func defaultMock(httpMock HTTPMock, httpServer *http.Server, clock clockwork.Clo
 		NodePeerCountFunc: func(context.Context) (int, error) {
 			return 80, nil
 		},
+		AttestationRewardsFunc: func(context.Context, eth2p0.Epoch, []eth2p0.ValidatorIndex) (*eth2wrap.AttestationRewards, error) {
+			return &eth2wrap.AttestationRewards{}, nil
+		},
+		BlockRewardsFunc: func(context.Context, string) (*eth2wrap.BlockRewards, error) {
+			return nil, nil
+		},
 		AttestationDataFunc: func(ctx context.Context, slot eth2p0.Slot, index eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
 			return attStore.NewAttestationData(ctx, slot, index)
 		},
@@ -0,0 +1,279 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package beaconmock
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// ScenarioEventType is the type of scripted scenario event.
+type ScenarioEventType string
+
+const (
+	// ScenarioReorg replaces the head at the event slot with a block of a different fork, publishing a chain_reorg event.
+	ScenarioReorg ScenarioEventType = "reorg"
+	// ScenarioDelayedBlock delays the head and block events and the head block root update of the event slot.
+	ScenarioDelayedBlock ScenarioEventType = "delayed_block"
+	// ScenarioLateAttestationData delays attestation data responses of the event slot.
+	ScenarioLateAttestationData ScenarioEventType = "late_attestation_data"
+	// ScenarioSlashed marks the validators as slashed from the event slot.
+	ScenarioSlashed ScenarioEventType = "slashed"
+	// ScenarioExited marks the validators as exited from the event slot.
+	ScenarioExited ScenarioEventType = "exited"
+)
+
+// Scenario is a script of beacon node events reproducing edge cases deterministically.
+type Scenario struct {
+	// Period is the number of slots after which the events repeat, making scenarios deterministic
+	// for nodes started at different times. Event slots are absolute if zero.
+	Period uint64 `json:"period"`
+	// Events are the scripted events.
+	Events []ScenarioEvent `json:"events"`
+}
+
+// ScenarioEvent is a scripted scenario event.
+type ScenarioEvent struct {
+	Type ScenarioEventType `json:"type"`
+	// Slot is the slot of the event, relative to the start of each period if the scenario is periodic.
+	Slot uint64 `json:"slot"`
+	// Depth is the number of slots reorged, required for reorg events.
+	Depth uint64 `json:"depth,omitempty"`
+	// DelayMillis is the delay in milliseconds, required for delayed block and late attestation data events.
+	DelayMillis uint64 `json:"delay_ms,omitempty"`
+	// Validators are the indices of the validators slashed or exited, all validators if empty.
+	Validators []eth2p0.ValidatorIndex `json:"validators,omitempty"`
+}
+
+// delay returns the event delay.
+func (e ScenarioEvent) delay() time.Duration {
+	return time.Duration(e.DelayMillis) * time.Millisecond
+}
+
+// LoadScenario returns the scenario loaded from the JSON file.
+func LoadScenario(file string) (Scenario, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return Scenario{}, errors.Wrap(err, "read scenario file")
+	}
+
+	var resp Scenario
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return Scenario{}, errors.Wrap(err, "unmarshal scenario file")
+	}
+
+	if err := resp.Validate(); err != nil {
+		return Scenario{}, err
+	}
+
+	return resp, nil
+}
+
+// Validate returns an error if the scenario is invalid.
+func (s Scenario) Validate() error {
+	for i, event := range s.Events {
+		if s.Period > 0 && event.Slot >= s.Period {
+			return errors.New("scenario event slot not within period", z.Int("event", i), z.U64("slot", event.Slot))
+		}
+
+		switch event.Type {
+		case ScenarioReorg:
+			if event.Depth == 0 {
+				return errors.New("scenario reorg depth must be positive", z.Int("event", i))
+			}
+		case ScenarioDelayedBlock, ScenarioLateAttestationData:
+			if event.DelayMillis == 0 {
+				return errors.New("scenario delay must be positive", z.Int("event", i), z.Any("type", event.Type))
+			}
+		case ScenarioSlashed, ScenarioExited:
+		default:
+			return errors.New("unknown scenario event type", z.Int("event", i), z.Any("type", event.Type))
+		}
+	}
+
+	return nil
+}
+
+// WithScenario configures the mock to play the scripted scenario. It wraps the validator and attestation data
+// functions, so it must be provided after options overriding those, e.g. WithValidatorSet.
+func WithScenario(scenario Scenario) Option {
+	return func(mock *Mock) {
+		if mock.headProducer == nil {
+			return // Only configure the mock, not the http mock.
+		}
+
+		s := &scenarioState{Scenario: scenario}
+		mock.headProducer.scenario = s
+
+		attDataFunc := mock.AttestationDataFunc
+		mock.AttestationDataFunc = func(ctx context.Context, slot eth2p0.Slot, index eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
+			if delay := s.delay(ScenarioLateAttestationData, slot); delay > 0 {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(delay):
+				}
+			}
+
+			return attDataFunc(ctx, slot, index)
+		}
+
+		validatorsFunc := mock.ValidatorsFunc
+		mock.ValidatorsFunc = func(ctx context.Context, opts *eth2api.ValidatorsOpts) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error) {
+			vals, err := validatorsFunc(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			return s.applyStatuses(vals), nil
+		}
+
+		byPubKeyFunc := mock.ValidatorsByPubKeyFunc
+		mock.ValidatorsByPubKeyFunc = func(ctx context.Context, stateID string, pubkeys []eth2p0.BLSPubKey) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error) {
+			vals, err := byPubKeyFunc(ctx, stateID, pubkeys)
+			if err != nil {
+				return nil, err
+			}
+
+			return s.applyStatuses(vals), nil
+		}
+
+		cachedFunc := mock.CachedValidatorsFunc
+		mock.CachedValidatorsFunc = func(ctx context.Context) (eth2wrap.ActiveValidators, eth2wrap.CompleteValidators, error) {
+			active, complete, err := cachedFunc(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			complete = s.applyStatuses(complete)
+
+			filtered := make(eth2wrap.ActiveValidators)
+			for idx, pubkey := range active {
+				if val, ok := complete[idx]; ok && !val.Status.IsActive() {
+					continue
+				}
+				filtered[idx] = pubkey
+			}
+
+			return filtered, complete, nil
+		}
+	}
+}
+
+// scenarioState plays a scenario, tracking the current slot. A nil scenario state is a valid noop.
+type scenarioState struct {
+	Scenario
+
+	mu   sync.Mutex
+	slot eth2p0.Slot
+}
+
+// setSlot sets the current slot.
+func (s *scenarioState) setSlot(slot eth2p0.Slot) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.slot = slot
+}
+
+// getSlot returns the current slot.
+func (s *scenarioState) getSlot() eth2p0.Slot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.slot
+}
+
+// relative returns the slot relative to the start of its period.
+func (s *scenarioState) relative(slot eth2p0.Slot) uint64 {
+	if s.Period == 0 {
+		return uint64(slot)
+	}
+
+	return uint64(slot) % s.Period
+}
+
+// event returns the event of the type at the slot.
+func (s *scenarioState) event(typ ScenarioEventType, slot eth2p0.Slot) (ScenarioEvent, bool) {
+	if s == nil {
+		return ScenarioEvent{}, false
+	}
+
+	for _, event := range s.Events {
+		if event.Type == typ && event.Slot == s.relative(slot) {
+			return event, true
+		}
+	}
+
+	return ScenarioEvent{}, false
+}
+
+// delay returns the delay of the event type at the slot, or zero.
+func (s *scenarioState) delay(typ ScenarioEventType, slot eth2p0.Slot) time.Duration {
+	event, ok := s.event(typ, slot)
+	if !ok {
+		return 0
+	}
+
+	return event.delay()
+}
+
+// reorgDepth returns the depth of the reorg at the slot, or zero.
+func (s *scenarioState) reorgDepth(slot eth2p0.Slot) uint64 {
+	event, ok := s.event(ScenarioReorg, slot)
+	if !ok {
+		return 0
+	}
+
+	return event.Depth
+}
+
+// applyStatuses returns the validators with the slashed and exited statuses of the current slot applied.
+func (s *scenarioState) applyStatuses(vals map[eth2p0.ValidatorIndex]*eth2v1.Validator) map[eth2p0.ValidatorIndex]*eth2v1.Validator {
+	slot := s.relative(s.getSlot())
+
+	resp := make(map[eth2p0.ValidatorIndex]*eth2v1.Validator, len(vals))
+	for idx, val := range vals {
+		for _, event := range s.Events {
+			if event.Slot > slot || (event.Type != ScenarioSlashed && event.Type != ScenarioExited) {
+				continue
+			} else if len(event.Validators) > 0 && !slices.Contains(event.Validators, idx) {
+				continue
+			}
+
+			val = cloneValidator(val)
+			exited := event.Type == ScenarioExited || val.Status == eth2v1.ValidatorStateExitedUnslashed
+			slashed := event.Type == ScenarioSlashed || val.Validator.Slashed
+
+			val.Validator.Slashed = slashed
+			switch {
+			case exited && slashed:
+				val.Status = eth2v1.ValidatorStateExitedSlashed
+			case exited:
+				val.Status = eth2v1.ValidatorStateExitedUnslashed
+			default:
+				val.Status = eth2v1.ValidatorStateActiveSlashed
+			}
+		}
+
+		resp[idx] = val
+	}
+
+	return resp
+}
@@ -0,0 +1,137 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package beaconmock
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarioValidate(t *testing.T) {
+	require.NoError(t, Scenario{Period: 10, Events: []ScenarioEvent{
+		{Type: ScenarioReorg, Slot: 1, Depth: 1},
+		{Type: ScenarioDelayedBlock, Slot: 2, DelayMillis: 500},
+		{Type: ScenarioLateAttestationData, Slot: 3, DelayMillis: 500},
+		{Type: ScenarioSlashed, Slot: 4, Validators: []eth2p0.ValidatorIndex{1}},
+		{Type: ScenarioExited, Slot: 5},
+	}}.Validate())
+
+	for _, event := range []ScenarioEvent{
+		{Type: ScenarioReorg, Slot: 1},
+		{Type: ScenarioDelayedBlock, Slot: 1},
+		{Type: ScenarioLateAttestationData, Slot: 1},
+		{Type: ScenarioExited, Slot: 10},
+		{Type: "unknown", Slot: 1},
+	} {
+		require.Error(t, Scenario{Period: 10, Events: []ScenarioEvent{event}}.Validate(), event.Type)
+	}
+}
+
+func TestLoadScenario(t *testing.T) {
+	scenario := Scenario{Period: 32, Events: []ScenarioEvent{
+		{Type: ScenarioReorg, Slot: 8, Depth: 2},
+		{Type: ScenarioSlashed, Slot: 16, Validators: []eth2p0.ValidatorIndex{1, 2}},
+	}}
+
+	b, err := json.Marshal(scenario)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "scenario.json")
+	require.NoError(t, os.WriteFile(file, b, 0o644))
+
+	loaded, err := LoadScenario(file)
+	require.NoError(t, err)
+	require.Equal(t, scenario, loaded)
+}
+
+func TestScenarioStatuses(t *testing.T) {
+	s := &scenarioState{Scenario: Scenario{Period: 10, Events: []ScenarioEvent{
+		{Type: ScenarioSlashed, Slot: 2, Validators: []eth2p0.ValidatorIndex{1}},
+		{Type: ScenarioExited, Slot: 4, Validators: []eth2p0.ValidatorIndex{1, 2}},
+	}}}
+
+	statuses := func(slot eth2p0.Slot) map[eth2p0.ValidatorIndex]eth2v1.ValidatorState {
+		s.setSlot(slot)
+
+		resp := make(map[eth2p0.ValidatorIndex]eth2v1.ValidatorState)
+		for idx, val := range s.applyStatuses(ValidatorSetA) {
+			resp[idx] = val.Status
+		}
+
+		return resp
+	}
+
+	active := eth2v1.ValidatorStateActiveOngoing
+	require.Equal(t, map[eth2p0.ValidatorIndex]eth2v1.ValidatorState{1: active, 2: active, 3: active}, statuses(11))
+	require.Equal(t, map[eth2p0.ValidatorIndex]eth2v1.ValidatorState{
+		1: eth2v1.ValidatorStateActiveSlashed, 2: active, 3: active,
+	}, statuses(12))
+	require.Equal(t, map[eth2p0.ValidatorIndex]eth2v1.ValidatorState{
+		1: eth2v1.ValidatorStateExitedSlashed, 2: eth2v1.ValidatorStateExitedUnslashed, 3: active,
+	}, statuses(19))
+
+	// The validator set isn't modified.
+	require.Equal(t, active, ValidatorSetA[1].Status)
+	require.False(t, ValidatorSetA[1].Validator.Slashed)
+}
+
+func TestScenarioReorg(t *testing.T) {
+	p := newHeadProducer()
+	p.scenario = &scenarioState{Scenario: Scenario{Events: []ScenarioEvent{
+		{Type: ScenarioReorg, Slot: 2, Depth: 1},
+		{Type: ScenarioDelayedBlock, Slot: 3, DelayMillis: 100},
+	}}}
+	defer p.Close()
+
+	p.onSlot(1)
+	require.Equal(t, pseudoRandomHeadEvent(1), p.getCurrentHead())
+
+	p.onSlot(2)
+	require.Equal(t, forkedHeadEvent(2), p.getCurrentHead())
+	require.NotEqual(t, pseudoRandomHeadEvent(2).Block, p.getCurrentHead().Block)
+
+	// Delayed block only updates the head after the delay.
+	p.onSlot(3)
+	require.Equal(t, eth2p0.Slot(2), p.getCurrentHead().Slot)
+	require.Eventually(t, func() bool {
+		return p.getCurrentHead().Slot == 3
+	}, time.Second, time.Millisecond*10)
+}
+
+func TestWithScenario(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := New(
+		WithValidatorSet(ValidatorSetA),
+		WithScenario(Scenario{Events: []ScenarioEvent{
+			{Type: ScenarioLateAttestationData, Slot: 1, DelayMillis: 100},
+			{Type: ScenarioExited, Slot: 0, Validators: []eth2p0.ValidatorIndex{2}},
+		}}),
+	)
+	require.NoError(t, err)
+	defer bmock.Close()
+
+	t0 := time.Now()
+	_, err = bmock.AttestationData(ctx, &eth2api.AttestationDataOpts{Slot: 1})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(t0), time.Millisecond*100)
+
+	vals, err := bmock.Validators(ctx, &eth2api.ValidatorsOpts{})
+	require.NoError(t, err)
+	require.Equal(t, eth2v1.ValidatorStateExitedUnslashed, vals.Data[2].Status)
+	require.Equal(t, eth2v1.ValidatorStateActiveOngoing, vals.Data[1].Status)
+
+	active, err := bmock.ActiveValidators(ctx)
+	require.NoError(t, err)
+	require.Len(t, active, 2)
+	require.NotContains(t, active, eth2p0.ValidatorIndex(2))
+}
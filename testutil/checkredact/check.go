@@ -0,0 +1,126 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// sensitiveKeys are the field keys of validator public keys and peer addresses.
+var sensitiveKeys = map[string]bool{
+	"addr":                 true,
+	"address":              true,
+	"addrs":                true,
+	"core_pubkey":          true,
+	"dv":                   true,
+	"enr":                  true,
+	"ext_host":             true,
+	"ext_ip":               true,
+	"external_ip":          true,
+	"host":                 true,
+	"ip":                   true,
+	"new_enr":              true,
+	"new_operator_enr":     true,
+	"operator_enr":         true,
+	"peer_address":         true,
+	"pub_key":              true,
+	"public_key":           true,
+	"pubkey":               true,
+	"pubkeys":              true,
+	"pubshare":             true,
+	"validator":            true,
+	"validator_pubkey":     true,
+	"validator_public_key": true,
+}
+
+// field is an unredacted sensitive field.
+type field struct {
+	Pos token.Position
+	Key string
+}
+
+// skipped returns true if the file isn't checked: tests and test utilities don't log in production.
+func skipped(path string) bool {
+	path = filepath.ToSlash(path)
+
+	return !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") ||
+		strings.HasPrefix(path, "testutil/") || strings.Contains(path, "/testutil/")
+}
+
+// goFiles returns the checked Go files in the directory tree.
+func goFiles(root string) ([]string, error) {
+	var resp []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if d.IsDir() && path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		} else if !d.IsDir() && !skipped(path) {
+			resp = append(resp, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "walk dir")
+	}
+
+	return resp, nil
+}
+
+// check returns the z fields with sensitive keys in the file that aren't wrapped with z.Redact.
+func check(path string) ([]field, error) {
+	if skipped(path) {
+		return nil, nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse file")
+	}
+
+	var (
+		resp     []field
+		redacted = make(map[ast.Expr]bool)
+	)
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		} else if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "z" {
+			return true
+		}
+
+		if sel.Sel.Name == "Redact" {
+			redacted[call.Args[0]] = true
+			return true
+		}
+
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING || redacted[call] {
+			return true
+		}
+
+		if key, err := strconv.Unquote(lit.Value); err == nil && sensitiveKeys[key] {
+			resp = append(resp, field{Pos: fset.Position(call.Pos()), Key: key})
+		}
+
+		return true
+	})
+
+	return resp, nil
+}
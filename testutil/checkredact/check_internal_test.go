@@ -0,0 +1,37 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	b, err := os.ReadFile("testdata/fields.go.txt")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "fields.go")
+	require.NoError(t, os.WriteFile(path, b, 0o644))
+
+	unredacted, err := check(path)
+	require.NoError(t, err)
+
+	var keys []string
+	for _, field := range unredacted {
+		require.Equal(t, path, field.Pos.Filename)
+		keys = append(keys, field.Key)
+	}
+	require.Equal(t, []string{"pubkey", "addrs", "enr"}, keys)
+}
+
+func TestSkipped(t *testing.T) {
+	require.False(t, skipped("app/app.go"))
+	require.True(t, skipped("app/app_test.go"))
+	require.True(t, skipped("testutil/compose/compose.go"))
+	require.True(t, skipped("core/testutil/helpers.go"))
+	require.True(t, skipped("docs/configuration.md"))
+}
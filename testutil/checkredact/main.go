@@ -0,0 +1,41 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Command checkredact provides a tool to verify that log and error fields containing validator public keys
+// and peer addresses are wrapped with z.Redact, so they are redacted from logs with --log-privacy.
+// It checks the provided Go files, or all non-test Go files in the current directory if none are provided.
+package main
+
+import (
+	"log"
+	"os"
+)
+
+func main() {
+	files := os.Args[1:]
+	if len(files) == 0 {
+		var err error
+		files, err = goFiles(".")
+		if err != nil {
+			log.Printf("❌ Listing go files failed: %+v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var failed bool
+	for _, file := range files {
+		unredacted, err := check(file)
+		if err != nil {
+			log.Printf("❌ Checking %s failed: %+v\n", file, err)
+			os.Exit(1)
+		}
+
+		for _, field := range unredacted {
+			log.Printf("❌ %s: %q field must be wrapped with z.Redact\n", field.Pos, field.Key)
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
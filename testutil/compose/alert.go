@@ -6,7 +6,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"os/exec"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/obolnetwork/charon/app/errors"
@@ -16,9 +18,110 @@ import (
 
 const alertsPolled = "alerts_polled"
 
-// startAlertCollector starts a goroutine that polls prometheus alerts until the context is closed and returns
+// AlertSource is a source of alerts polled during the run step.
+type AlertSource interface {
+	// Name returns the name of the alert source.
+	Name() string
+	// Poll returns the descriptions of the currently active alerts.
+	Poll(ctx context.Context, engine Engine, dir string) ([]string, error)
+}
+
+// AlertConfig configures the alert sources polled during the run step and the assertions on the detected alerts.
+type AlertConfig struct {
+	// Sources are the alert sources to poll. Empty defaults to the prometheus alerting rules.
+	Sources []AlertSource
+	// Require are regular expressions of alerts that must be detected, each must match at least one alert.
+	Require []string
+	// Allow are regular expressions of alerts that may be detected without failing the run.
+	// Alerts matching Require are also allowed, all other detected alerts fail the run.
+	Allow []string
+}
+
+// sources returns the configured alert sources or the default prometheus alerting rules source.
+func (c AlertConfig) sources() []AlertSource {
+	if len(c.Sources) == 0 {
+		return []AlertSource{PrometheusRules{}}
+	}
+
+	return c.Sources
+}
+
+// validate returns an error if the alert assertions are invalid.
+func (c AlertConfig) validate() error {
+	if _, err := compileRegexes(append(append([]string(nil), c.Require...), c.Allow...)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// check returns an error if the detected alerts don't satisfy the assertions.
+func (c AlertConfig) check(alerts []string) error {
+	require, err := compileRegexes(c.Require)
+	if err != nil {
+		return err
+	}
+
+	allow, err := compileRegexes(c.Allow)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, regex := range require {
+		if !matchAny([]*regexp.Regexp{regex}, alerts) {
+			missing = append(missing, regex.String())
+		}
+	}
+
+	permitted := append(append([]*regexp.Regexp(nil), require...), allow...)
+
+	var unexpected []string
+	for _, alert := range alerts {
+		if !matchAny(permitted, []string{alert}) {
+			unexpected = append(unexpected, alert)
+		}
+	}
+
+	if len(unexpected) > 0 {
+		return errors.New("alerts detected", z.Any("alerts", unexpected))
+	} else if len(missing) > 0 {
+		return errors.New("required alerts not detected", z.Any("required", missing))
+	}
+
+	return nil
+}
+
+// compileRegexes returns the compiled regular expressions.
+func compileRegexes(exprs []string) ([]*regexp.Regexp, error) {
+	var resp []*regexp.Regexp
+	for _, expr := range exprs {
+		regex, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, errors.Wrap(err, "compile alert regex", z.Str("regex", expr))
+		}
+		resp = append(resp, regex)
+	}
+
+	return resp, nil
+}
+
+// matchAny returns true if any of the regular expressions matches any of the strings.
+func matchAny(regexes []*regexp.Regexp, strs []string) bool {
+	for _, regex := range regexes {
+		for _, s := range strs {
+			if regex.MatchString(s) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// startAlertCollector starts a goroutine that polls the alert sources until the context is closed and returns
 // a channel on which the received alert descriptions will be sent.
-func startAlertCollector(ctx context.Context, dir string) chan string {
+func startAlertCollector(ctx context.Context, engine Engine, dir string, sources []AlertSource) chan string {
 	resp := make(chan string, 100)
 
 	go func() {
@@ -33,40 +136,36 @@ func startAlertCollector(ctx context.Context, dir string) chan string {
 		// Time required to wait for prometheus container to start.
 		time.Sleep(time.Second * 10)
 		for ; ctx.Err() == nil; time.Sleep(iterSleep) { // Sleep for iterSleep before next iteration.
-			cmd := exec.CommandContext(ctx, "docker", "compose", "exec", "-T", "curl", "curl", "-s", "http://prometheus:9090/api/v1/rules?type=alert")
-			cmd.Dir = dir
-			out, err := cmd.CombinedOutput()
-			if ctx.Err() != nil {
-				return
-			} else if err != nil {
-				log.Error(ctx, "Exec curl alerts", err, z.Str("out", string(out)))
-				continue
-			}
+			var (
+				active []string
+				polled = true
+			)
+			for _, source := range sources {
+				alerts, err := source.Poll(ctx, engine, dir)
+				if ctx.Err() != nil {
+					return
+				} else if err != nil {
+					log.Error(ctx, "Poll alerts", err, z.Str("source", source.Name()))
+					polled = false
 
-			var alerts promAlerts
-			if err := json.Unmarshal(bytes.TrimSpace(out), &alerts); err != nil {
-				resp <- errors.Wrap(err, "unmarshal alerts", z.Str("out", string(out))).Error()
-				continue
-			}
-
-			if alerts.Status != "success" {
-				resp <- "non success status from prometheus alerts: " + alerts.Status
-				continue
+					continue
+				}
+				active = append(active, alerts...)
 			}
 
-			if !success {
+			if polled && !success {
 				resp <- alertsPolled // Push initial "fake alert" so logic can fail is not alerts polled.
 				success = true
 			}
 
-			for _, active := range getActiveAlerts(alerts) {
-				if dedup[active] {
+			for _, alert := range active {
+				if dedup[alert] {
 					continue
 				}
-				dedup[active] = true
-				log.Info(ctx, "Detected new alert", z.Str("alert", active))
+				dedup[alert] = true
+				log.Info(ctx, "Detected new alert", z.Str("alert", alert))
 
-				resp <- active
+				resp <- alert
 			}
 		}
 	}()
@@ -74,6 +173,157 @@ func startAlertCollector(ctx context.Context, dir string) chan string {
 	return resp
 }
 
+// PrometheusRules is an alert source returning the active prometheus alerting rules of the compose prometheus service.
+type PrometheusRules struct{}
+
+func (PrometheusRules) Name() string {
+	return "prometheus"
+}
+
+func (PrometheusRules) Poll(ctx context.Context, engine Engine, dir string) ([]string, error) {
+	out, err := execCurl(ctx, engine, dir, "http://prometheus:9090/api/v1/rules?type=alert")
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts promAlerts
+	if err := json.Unmarshal(out, &alerts); err != nil {
+		return nil, errors.Wrap(err, "unmarshal alerts", z.Str("out", string(out)))
+	} else if alerts.Status != "success" {
+		return nil, errors.New("non success status from prometheus alerts", z.Str("status", alerts.Status))
+	}
+
+	return getActiveAlerts(alerts), nil
+}
+
+// Alertmanager is an alert source returning the active alerts of a Prometheus Alertmanager.
+type Alertmanager struct {
+	// URL is the Alertmanager base URL reachable from the compose network, e.g. "http://alertmanager:9093".
+	URL string
+}
+
+func (Alertmanager) Name() string {
+	return "alertmanager"
+}
+
+func (a Alertmanager) Poll(ctx context.Context, engine Engine, dir string) ([]string, error) {
+	out, err := execCurl(ctx, engine, dir, strings.TrimSuffix(a.URL, "/")+"/api/v2/alerts?active=true&silenced=false&inhibited=false")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAlertmanagerAlerts(out)
+}
+
+// parseAlertmanagerAlerts returns the descriptions, or names if no description, of the Alertmanager v2 API alerts.
+func parseAlertmanagerAlerts(b []byte) ([]string, error) {
+	var alerts []struct {
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+	}
+	if err := json.Unmarshal(b, &alerts); err != nil {
+		return nil, errors.Wrap(err, "unmarshal alertmanager alerts", z.Str("out", string(b)))
+	}
+
+	var resp []string
+	for _, alert := range alerts {
+		desc := alert.Annotations["description"]
+		if desc == "" {
+			desc = alert.Labels["alertname"]
+		}
+		resp = append(resp, desc)
+	}
+
+	return resp, nil
+}
+
+// PromQLAlert is an alert source that fires the named alert while the PromQL query returns a non-empty result,
+// e.g. `up == 0` or `sum(core_tracker_failed_duties_total) > 0`.
+type PromQLAlert struct {
+	AlertName string
+	Query     string
+}
+
+func (PromQLAlert) Name() string {
+	return "promql"
+}
+
+func (a PromQLAlert) Poll(ctx context.Context, engine Engine, dir string) ([]string, error) {
+	out, err := execPromQuery(ctx, engine, dir, a.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	var query promQuery
+	if err := json.Unmarshal(out, &query); err != nil {
+		return nil, errors.Wrap(err, "unmarshal query response", z.Str("out", string(out)))
+	} else if query.Status != "success" {
+		return nil, errors.New("non success status from prometheus query", z.Str("status", query.Status))
+	} else if len(query.Data.Result) == 0 {
+		return nil, nil
+	}
+
+	return []string{fmt.Sprintf("%s: %s", a.AlertName, a.Query)}, nil
+}
+
+// LogAlert is an alert source that fires an alert for each log line of the compose service matching the pattern,
+// e.g. `panic` or `level=error.*consensus`.
+type LogAlert struct {
+	// Service is the compose service to match logs of, e.g. "node0". Empty matches the logs of all services.
+	Service string
+	// Pattern is the regular expression log lines are matched against.
+	Pattern string
+}
+
+func (LogAlert) Name() string {
+	return "logs"
+}
+
+func (a LogAlert) Poll(ctx context.Context, engine Engine, dir string) ([]string, error) {
+	regex, err := regexp.Compile(a.Pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "compile log alert pattern", z.Str("pattern", a.Pattern))
+	}
+
+	args := []string{"logs", "--no-color", "--no-log-prefix"}
+	if a.Service != "" {
+		args = append(args, a.Service)
+	}
+
+	out, err := engine.ComposeCmd(ctx, dir, args...).Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "exec compose logs", z.Str("service", a.Service))
+	}
+
+	return matchLogLines(out, regex), nil
+}
+
+// matchLogLines returns the log lines matching the regular expression, prefixed with the pattern.
+func matchLogLines(logs []byte, regex *regexp.Regexp) []string {
+	var resp []string
+	for _, line := range strings.Split(string(logs), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || !regex.MatchString(line) {
+			continue
+		}
+
+		resp = append(resp, fmt.Sprintf("log pattern %q: %s", regex.String(), line))
+	}
+
+	return resp
+}
+
+// execCurl executes curl via the compose curl service and returns the response.
+func execCurl(ctx context.Context, engine Engine, dir string, rawURL string) ([]byte, error) {
+	cmd := engine.ComposeCmd(ctx, dir, "exec", "-T", "curl", "curl", "-s", rawURL)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, errors.Wrap(err, "exec curl", z.Str("url", rawURL), z.Str("out", string(out)))
+	}
+
+	return bytes.TrimSpace(out), nil
+}
+
 func getActiveAlerts(alerts promAlerts) []string {
 	var resp []string
 	for _, group := range alerts.Data.Groups {
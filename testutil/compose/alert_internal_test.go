@@ -0,0 +1,93 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertConfigCheck(t *testing.T) {
+	alerts := []string{"Cluster is missing duties", "Node node0 is down"}
+
+	tests := []struct {
+		Name string
+		Conf AlertConfig
+		Err  string
+	}{
+		{
+			Name: "no alerts allowed",
+			Err:  "alerts detected",
+		},
+		{
+			Name: "all allowed",
+			Conf: AlertConfig{Allow: []string{"missing duties", `node\d is down`}},
+		},
+		{
+			Name: "required and allowed",
+			Conf: AlertConfig{Require: []string{"node0 is down"}, Allow: []string{"duties"}},
+		},
+		{
+			Name: "unexpected alert",
+			Conf: AlertConfig{Require: []string{"node0 is down"}},
+			Err:  "alerts detected",
+		},
+		{
+			Name: "required alert missing",
+			Conf: AlertConfig{Require: []string{"node3 is down"}, Allow: []string{".*"}},
+			Err:  "required alerts not detected",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			require.NoError(t, test.Conf.validate())
+
+			err := test.Conf.check(alerts)
+			if test.Err != "" {
+				require.ErrorContains(t, err, test.Err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	require.NoError(t, AlertConfig{}.check(nil))
+	require.ErrorContains(t, AlertConfig{Require: []string{"down"}}.check(nil), "required alerts not detected")
+	require.ErrorContains(t, AlertConfig{Allow: []string{"("}}.validate(), "compile alert regex")
+	require.Equal(t, []AlertSource{PrometheusRules{}}, AlertConfig{}.sources())
+}
+
+func TestParseAlertmanagerAlerts(t *testing.T) {
+	alerts, err := parseAlertmanagerAlerts([]byte(`[
+		{"labels":{"alertname":"ClusterMissingDuties"},"annotations":{"description":"Cluster is missing duties"}},
+		{"labels":{"alertname":"NodeDown"},"annotations":{}}
+	]`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"Cluster is missing duties", "NodeDown"}, alerts)
+
+	_, err = parseAlertmanagerAlerts([]byte(`{}`))
+	require.ErrorContains(t, err, "unmarshal alertmanager alerts")
+}
+
+func TestLogAlert(t *testing.T) {
+	// The fake engine echoes the logs command args followed by fixed log lines.
+	const script = `echo "$@"; printf 'INFO started\nERRO consensus timeout\n\nERRO consensus timeout\n'`
+	fakeEngine := cliEngine{name: "fake", compose: []string{"sh", "-c", script, "sh"}}
+
+	alerts, err := LogAlert{Service: "node0", Pattern: `ERRO.*consensus`}.Poll(context.Background(), fakeEngine, t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`log pattern "ERRO.*consensus": ERRO consensus timeout`,
+		`log pattern "ERRO.*consensus": ERRO consensus timeout`,
+	}, alerts)
+
+	alerts, err = LogAlert{Pattern: `--no-log-prefix$`}.Poll(context.Background(), fakeEngine, t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, []string{`log pattern "--no-log-prefix$": logs --no-color --no-log-prefix`}, alerts)
+
+	_, err = LogAlert{Pattern: "("}.Poll(context.Background(), fakeEngine, t.TempDir())
+	require.ErrorContains(t, err, "compile log alert pattern")
+}
@@ -0,0 +1,192 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// artifactsDir is the default compose directory folder artifact bundles are written to.
+const artifactsDir = "artifacts"
+
+// artifactPatterns are the compose directory files included in artifact bundles.
+// Private keys and keystores are never included.
+var artifactPatterns = []string{
+	configFile,
+	resultsFile,
+	"docker-compose.yml",
+	"cluster-definition.json",
+	"node*/cluster-lock.json",
+	"node*/deposit-data*.json",
+	"prometheus/*.yml",
+}
+
+// nodeServiceRegex matches charon node service names.
+var nodeServiceRegex = regexp.MustCompile(`^node\d+$`)
+
+// collectArtifacts collects the container logs, charon node metrics snapshots and generated configs of the compose
+// cluster into a timestamped tar.gz bundle in the output dir and returns its path. Logs and metrics are collected
+// best effort, failures are recorded in errors.txt in the bundle.
+func collectArtifacts(ctx context.Context, engine Engine, dir string, outDir string, now time.Time) (string, error) {
+	files, err := artifactFiles(dir)
+	if err != nil {
+		return "", err
+	}
+
+	var failures []string
+	fail := func(err error) {
+		failures = append(failures, err.Error())
+	}
+
+	out, err := engine.ComposeCmd(ctx, dir, "config", "--services").Output()
+	if err != nil {
+		fail(errors.Wrap(err, "exec compose config services"))
+	}
+
+	for _, service := range strings.Fields(string(out)) {
+		logs, err := engine.ComposeCmd(ctx, dir, "logs", "--no-color", "--timestamps", service).CombinedOutput()
+		if err != nil {
+			fail(errors.Wrap(err, "exec compose logs", z.Str("service", service)))
+		}
+		files[path.Join("logs", service+".log")] = logs
+
+		if !nodeServiceRegex.MatchString(service) {
+			continue
+		}
+
+		metrics, err := engine.ComposeCmd(ctx, dir, "exec", "-T", "curl", "curl", "-s", "http://"+service+":3620/metrics").Output()
+		if err != nil {
+			fail(errors.Wrap(err, "exec curl metrics", z.Str("service", service)))
+			continue
+		}
+		files[path.Join("metrics", service+".prom")] = metrics
+	}
+
+	if len(failures) > 0 {
+		files["errors.txt"] = []byte(strings.Join(failures, "\n") + "\n")
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return "", errors.Wrap(err, "create artifacts dir")
+	}
+
+	bundle := path.Join(outDir, "artifacts-"+now.UTC().Format("20060102T150405Z")+".tar.gz")
+	if err := writeTarGz(bundle, files, now); err != nil {
+		return "", err
+	}
+
+	return bundle, nil
+}
+
+// artifactFiles returns the compose directory files included in artifact bundles by relative path.
+func artifactFiles(dir string) (map[string][]byte, error) {
+	resp := make(map[string][]byte)
+	for _, pattern := range artifactPatterns {
+		matches, err := filepath.Glob(path.Join(dir, pattern))
+		if err != nil {
+			return nil, errors.Wrap(err, "glob artifacts")
+		}
+
+		for _, match := range matches {
+			b, err := os.ReadFile(match)
+			if err != nil {
+				return nil, errors.Wrap(err, "read artifact", z.Str("path", match))
+			}
+
+			rel, err := filepath.Rel(dir, match)
+			if err != nil {
+				return nil, errors.Wrap(err, "relative artifact path")
+			}
+
+			resp[filepath.ToSlash(rel)] = b
+		}
+	}
+
+	return resp, nil
+}
+
+// writeTarGz writes the files to a gzipped tar archive sorted by name.
+func writeTarGz(file string, files map[string][]byte, modTime time.Time) error {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	for _, name := range names {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(files[name])),
+			ModTime: modTime,
+		})
+		if err != nil {
+			return errors.Wrap(err, "write tar header")
+		}
+
+		if _, err := tw.Write(files[name]); err != nil {
+			return errors.Wrap(err, "write tar file")
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "close tar")
+	} else if err := gw.Close(); err != nil {
+		return errors.Wrap(err, "close gzip")
+	}
+
+	//nolint:gosec // Artifacts don't contain secrets.
+	if err := os.WriteFile(file, buf.Bytes(), 0o644); err != nil {
+		return errors.Wrap(err, "write artifacts bundle")
+	}
+
+	return nil
+}
+
+// teardownArtifacts collects artifacts before teardown if the run failed or always is true, logging failures.
+// It returns the bundle path or empty if not collected.
+func teardownArtifacts(ctx context.Context, engine Engine, dir string, conf AutoConfig, runErr error) string {
+	if runErr == nil && !conf.AlwaysCollectArtifacts {
+		return ""
+	}
+
+	outDir := conf.ArtifactsDir
+	if outDir == "" {
+		outDir = path.Join(dir, artifactsDir)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	if err := syncRemote(ctx, engine, dir, false); err != nil {
+		log.Warn(ctx, "Failed pulling remote compose dir, collecting local files", err)
+	}
+
+	bundle, err := collectArtifacts(ctx, engine, dir, outDir, time.Now())
+	if err != nil {
+		log.Warn(ctx, "Failed collecting artifacts", err)
+		return ""
+	}
+
+	log.Info(ctx, "Collected artifacts", z.Str("bundle", bundle))
+
+	return bundle
+}
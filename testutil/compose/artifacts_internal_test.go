@@ -0,0 +1,75 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(nodeFile(dir, 0, "validator_keys"), 0o755))
+
+	for name, content := range map[string]string{
+		configFile:                           "{}",
+		"docker-compose.yml":                 "services: {}",
+		"node0/cluster-lock.json":            "lock",
+		"node0/charon-enr-private-key":       "secret",
+		"node0/validator_keys/keystore.json": "secret",
+	} {
+		require.NoError(t, os.WriteFile(path.Join(dir, name), []byte(content), 0o644))
+	}
+
+	// The fake engine lists services and echoes their logs and metrics requests.
+	const script = `case "$1" in
+		config) printf 'node0\nnode0-netem\nrelay\n' ;;
+		logs) echo "logs of $4" ;;
+		exec) echo "metrics from $6" ;;
+	esac`
+	fakeEngine := cliEngine{name: "fake", compose: []string{"sh", "-c", script, "sh"}}
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	bundle, err := collectArtifacts(context.Background(), fakeEngine, dir, path.Join(dir, artifactsDir), now)
+	require.NoError(t, err)
+	require.Equal(t, path.Join(dir, artifactsDir, "artifacts-20240102T030405Z.tar.gz"), bundle)
+
+	b, err := os.ReadFile(bundle)
+	require.NoError(t, err)
+
+	gr, err := gzip.NewReader(bytes.NewReader(b))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	files := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		b, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(b)
+	}
+
+	require.Equal(t, map[string]string{
+		configFile:                "{}",
+		"docker-compose.yml":      "services: {}",
+		"node0/cluster-lock.json": "lock",
+		"logs/node0.log":          "logs of node0\n",
+		"logs/node0-netem.log":    "logs of node0-netem\n",
+		"logs/relay.log":          "logs of relay\n",
+		"metrics/node0.prom":      "metrics from http://node0:3620/metrics\n",
+	}, files)
+}
@@ -30,18 +30,96 @@ type AutoConfig struct {
 	DefineTmplFunc func(*TmplData)
 	// LogFile enables writing (appending) docker compose output to this file path instead of stdout.
 	LogFile string
+	// Chaos enables the chaos controller injecting faults during the run step. Alerts are then expected
+	// and not treated as errors, instead duty success rates are asserted. Nil disables chaos testing.
+	Chaos *ChaosConfig
+	// Upgrade enables a rolling upgrade during the run step. Alerts are then expected and not treated
+	// as errors, instead duty success rates are asserted after each node upgrade. Nil disables upgrade testing.
+	Upgrade *UpgradeConfig
+	// RelayOutage enables relay outages during the run step. Alerts are then expected and not treated as errors,
+	// instead node connectivity is asserted to degrade and recover. Nil disables relay outage testing.
+	RelayOutage *RelayOutageConfig
+	// ArtifactsDir is the directory run step artifact bundles are written to. Empty defaults to the compose dir's artifacts folder.
+	ArtifactsDir string
+	// AlwaysCollectArtifacts collects artifacts on teardown of successful runs, they are always collected on failure.
+	AlwaysCollectArtifacts bool
+	// Alerts configures the alert sources and assertions of the run step. The zero value polls the prometheus
+	// alerting rules and fails the run if any alert is detected.
+	Alerts AlertConfig
+	// Hooks are Go callbacks around the define, lock and run steps, e.g. for custom assertions.
+	Hooks Hooks
 }
 
 // Auto runs all three steps (define,lock,run) sequentially with support for detecting alerts.
-func Auto(ctx context.Context, conf AutoConfig) error {
+// It returns the results of the run, which are also written to results.json in the compose dir.
+func Auto(ctx context.Context, conf AutoConfig) (Results, error) {
 	ctx = log.WithTopic(ctx, "auto")
 
+	results := Results{StartedAt: time.Now()}
+	err := auto(ctx, conf, &results)
+	results.finish(err)
+
+	if writeErr := writeResults(conf.Dir, results); writeErr != nil {
+		if err != nil {
+			log.Warn(ctx, "Failed writing results", writeErr)
+			return results, err
+		}
+
+		return results, writeErr
+	}
+
+	return results, err
+}
+
+// auto runs all three steps, populating the results.
+func auto(ctx context.Context, conf AutoConfig, results *Results) (err error) {
 	w, closeFunc, err := newLogWriter(conf.LogFile)
 	if err != nil {
 		return err
 	}
 	defer closeFunc() //nolint:errcheck // non-critical
 
+	composeConf, err := loadConfig(conf.Dir)
+	if err != nil {
+		return err
+	}
+
+	engine, err := newConfEngine(ctx, composeConf)
+	if err != nil {
+		return err
+	}
+
+	if err := conf.Alerts.validate(); err != nil {
+		return err
+	}
+
+	var scenarios int
+	for _, enabled := range []bool{conf.Chaos != nil, conf.Upgrade != nil, conf.RelayOutage != nil} {
+		if enabled {
+			scenarios++
+		}
+	}
+
+	if scenarios > 1 {
+		return errors.New("chaos, upgrade and relay outage testing are mutually exclusive")
+	} else if conf.Chaos != nil {
+		if err := conf.Chaos.validate(); err != nil {
+			return err
+		}
+	} else if conf.Upgrade != nil {
+		if err := conf.Upgrade.validate(); err != nil {
+			return err
+		}
+	} else if conf.RelayOutage != nil {
+		if err := conf.RelayOutage.validate(); err != nil {
+			return err
+		} else if composeConf.ExternalRelay != "" {
+			return errors.New("relay outage testing requires the compose relay, not an external relay")
+		}
+	}
+
+	var runTmpl TmplData
+
 	steps := []struct {
 		Name     string
 		RunFunc  RunFunc
@@ -64,6 +142,7 @@ func Auto(ctx context.Context, conf AutoConfig) error {
 	}
 
 	for _, step := range steps {
+		started := time.Now()
 		run := NewRunnerFunc(step.Name, conf.Dir, false, step.RunFunc)
 		tmpl, err := run(ctx)
 		if err != nil {
@@ -76,8 +155,19 @@ func Auto(ctx context.Context, conf AutoConfig) error {
 			}
 		}
 
-		if step.TmplFunc != nil {
-			step.TmplFunc(&tmpl)
+		chaos := step.RunStep && conf.Chaos != nil
+		upgrade := step.RunStep && conf.Upgrade != nil
+		if step.TmplFunc != nil || chaos || upgrade {
+			if step.TmplFunc != nil {
+				step.TmplFunc(&tmpl)
+			}
+			tmpl.Chaos = chaos
+			if upgrade {
+				for i := range tmpl.Nodes {
+					pinImageTag(&tmpl.Nodes[i], conf.Upgrade.FromImageTag)
+				}
+			}
+
 			err := WriteDockerCompose(conf.Dir, tmpl)
 			if err != nil {
 				return err
@@ -91,23 +181,41 @@ func Auto(ctx context.Context, conf AutoConfig) error {
 		}
 
 		if step.RunStep { // Continue below if final run step.
+			runTmpl = tmpl
 			break
 		}
 
+		if err := callStepHook(ctx, conf.Hooks.PreStep, step.Name, engine, conf.Dir, tmpl); err != nil {
+			return err
+		}
+
 		_, _ = w.Write([]byte("===== " + step.Name + " step: docker compose up =====\n"))
 
-		if err := execUp(ctx, conf.Dir, w); err != nil {
+		if err := execUp(ctx, engine, conf.Dir, w, true); err != nil {
 			return err
 		}
+
+		results.addStep(step.Name, started)
+
+		if err := callStepHook(ctx, conf.Hooks.PostStep, step.Name, engine, conf.Dir, tmpl); err != nil {
+			return err
+		}
+
+		// Deposits must be submitted when running against an external chain for the validators to be activated.
+		if step.Name == "lock" && composeConf.externalBeacon() {
+			if _, err := CollectDeposits(ctx, conf.Dir); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Ensure everything is clean before we start with alert test.
-	_ = execDown(ctx, conf.Dir)
+	_ = execDown(ctx, engine, conf.Dir)
 
 	_, _ = w.Write([]byte("===== run step: docker compose up --no-start --build =====\n"))
 
 	// Build and create docker compose services before executing docker compose up.
-	if err = execBuildAndCreate(ctx, conf.Dir); err != nil {
+	if err = execBuildAndCreate(ctx, engine, conf.Dir); err != nil {
 		return err
 	}
 
@@ -117,15 +225,66 @@ func Auto(ctx context.Context, conf AutoConfig) error {
 		defer cancel()
 	}
 
-	alerts := startAlertCollector(ctx, conf.Dir)
+	alerts := startAlertCollector(ctx, engine, conf.Dir, conf.Alerts.sources())
+
+	var chaosResults chan []string
+	if conf.Chaos != nil {
+		chaosResults = startChaos(ctx, engine, conf.Dir, *conf.Chaos, len(runTmpl.Nodes), composeConf.Threshold, runTmpl.Relay)
+	}
+
+	var upgradeResults chan upgradeResult
+	if conf.Upgrade != nil {
+		upgradeResults = startUpgrade(ctx, engine, conf.Dir, runTmpl, *conf.Upgrade)
+	}
+
+	var relayResults chan RelayOutageResult
+	if conf.RelayOutage != nil {
+		relayResults = startRelayOutage(ctx, engine, conf.Dir, len(runTmpl.Nodes), *conf.RelayOutage)
+	}
 
 	defer func() {
-		_ = execDown(context.Background(), conf.Dir)
+		// Collect artifacts before teardown removes the containers and their logs.
+		results.Artifacts = teardownArtifacts(context.WithoutCancel(ctx), engine, conf.Dir, conf, err)
+		_ = execDown(context.Background(), engine, conf.Dir)
 	}()
 
+	if err = callStepHook(ctx, conf.Hooks.PreStep, "run", engine, conf.Dir, runTmpl); err != nil {
+		return err
+	}
+
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+
+	runningErr, err := startRunningHook(runCtx, cancelRun, conf.Hooks.Running, engine, conf.Dir, runTmpl)
+	if err != nil {
+		return err
+	}
+
 	_, _ = w.Write([]byte("===== run step: docker compose up =====\n"))
 
-	if err = execUp(ctx, conf.Dir, w); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+	// Killed, recreated or stopped containers are expected during chaos, upgrade and relay outage testing,
+	// so don't abort when containers exit.
+	abortOnExit := scenarios == 0
+	started := time.Now()
+	err = execUp(runCtx, engine, conf.Dir, w, abortOnExit)
+	cancelRun()
+	if hookErr := <-runningErr; hookErr != nil {
+		err = hookErr
+		return err
+	} else if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	results.addStep("run", started)
+
+	// Scrape duty counts before the deferred shutdown, the run context may already be closed.
+	scrapeCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	results.Nodes, err = scrapeDutyCounts(scrapeCtx, engine, conf.Dir)
+	if err != nil {
+		log.Warn(ctx, "Failed scraping duty counts", err)
+	}
+
+	if err = callStepHook(context.WithoutCancel(ctx), conf.Hooks.PostStep, "run", engine, conf.Dir, runTmpl); err != nil {
 		return err
 	}
 
@@ -140,13 +299,88 @@ func Auto(ctx context.Context, conf AutoConfig) error {
 			alertMsgs = append(alertMsgs, alert)
 		}
 	}
+	results.Alerts = alertMsgs
+
 	if !alertSuccess {
 		return errors.New("alerts couldn't be polled")
-	} else if len(alertMsgs) > 0 {
-		return errors.New("alerts detected", z.Any("alerts", alertMsgs))
+	} else if abortOnExit {
+		if err := conf.Alerts.check(alertMsgs); err != nil {
+			return err
+		}
 	}
 
-	log.Info(ctx, "No alerts detected")
+	if conf.Chaos != nil {
+		log.Info(ctx, "Ignoring alerts expected during chaos testing", z.Any("alerts", alertMsgs))
+
+		var (
+			violations []string
+			checked    bool
+		)
+		for result := range chaosResults {
+			violations = result // Success rates are cumulative, so only the last check is asserted.
+			checked = true
+		}
+		results.Violations = violations
+
+		if conf.Chaos.MinSuccessRate > 0 && !checked {
+			return errors.New("duty success rates couldn't be checked during chaos testing")
+		} else if len(violations) > 0 {
+			return errors.New("duty success rates below minimum during chaos testing", z.Any("violations", violations))
+		}
+
+		log.Info(ctx, "Duty success rates within thresholds during chaos testing")
+
+		return nil
+	}
+
+	if conf.Upgrade != nil {
+		log.Info(ctx, "Ignoring alerts expected during rolling upgrade", z.Any("alerts", alertMsgs))
+
+		var (
+			upgraded   int
+			violations []string
+		)
+		for result := range upgradeResults {
+			upgraded++
+			violations = append(violations, result.Violations...)
+		}
+		results.Violations = violations
+
+		if len(violations) > 0 {
+			return errors.New("duties missed during rolling upgrade", z.Any("violations", violations))
+		} else if upgraded < len(runTmpl.Nodes) {
+			return errors.New("rolling upgrade incomplete before alert timeout",
+				z.Int("upgraded", upgraded), z.Int("nodes", len(runTmpl.Nodes)))
+		}
+
+		log.Info(ctx, "Rolling upgrade completed without missed duties", z.Int("nodes", upgraded))
+
+		return nil
+	}
+
+	if conf.RelayOutage != nil {
+		log.Info(ctx, "Ignoring alerts expected during relay outages", z.Any("alerts", alertMsgs))
+
+		var violations []string
+		for result := range relayResults {
+			results.RelayOutages = append(results.RelayOutages, result)
+			violations = append(violations, result.Violations...)
+		}
+		results.Violations = violations
+
+		if len(violations) > 0 {
+			return errors.New("connectivity not degraded or recovered during relay outages", z.Any("violations", violations))
+		} else if len(results.RelayOutages) < conf.RelayOutage.Outages {
+			return errors.New("relay outages incomplete before alert timeout",
+				z.Int("completed", len(results.RelayOutages)), z.Int("outages", conf.RelayOutage.Outages))
+		}
+
+		log.Info(ctx, "Connectivity degraded and recovered during relay outages", z.Int("outages", len(results.RelayOutages)))
+
+		return nil
+	}
+
+	log.Info(ctx, "Alert assertions passed", z.Any("alerts", alertMsgs))
 
 	return nil
 }
@@ -184,7 +418,7 @@ func fixPerms(ctx context.Context, dir string) error {
 }
 
 // execDown executes `docker compose down`.
-func execDown(ctx context.Context, dir string) error {
+func execDown(ctx context.Context, engine Engine, dir string) error {
 	log.Info(ctx, "Finding and fixing permissions in /tmp")
 	chownCmd := exec.CommandContext(ctx, "sudo", "find", "/tmp/", "-name", "001", "-exec", "chown", "-R", "runner:docker", "{}", ";")
 	output, _ := chownCmd.CombinedOutput()
@@ -194,11 +428,10 @@ func execDown(ctx context.Context, dir string) error {
 
 	log.Info(ctx, "Executing docker compose down")
 
-	cmd := exec.CommandContext(ctx, "docker", "compose", "down",
+	cmd := engine.ComposeCmd(ctx, dir, "down",
 		"--remove-orphans",
 		"--timeout=2",
 	)
-	cmd.Dir = dir
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
@@ -209,22 +442,26 @@ func execDown(ctx context.Context, dir string) error {
 }
 
 // execUp executes `docker compose up` and it writes docker compose logs to the given out io.Writer.
-func execUp(ctx context.Context, dir string, out io.Writer) error {
+// If abortOnExit is true, all containers are stopped when any container exits.
+func execUp(ctx context.Context, engine Engine, dir string, out io.Writer, abortOnExit bool) error {
+	if err := syncRemote(ctx, engine, dir, true); err != nil {
+		return err
+	}
+
 	// Build first so containers start at the same time below.
 	log.Info(ctx, "Executing docker compose build")
-	cmd := exec.CommandContext(ctx, "docker", "compose", "build", "--parallel")
-	cmd.Dir = dir
+	cmd := engine.ComposeCmd(ctx, dir, "build", "--parallel")
 	if out, err := cmd.CombinedOutput(); err != nil {
 		return errors.Wrap(err, "exec docker compose build", z.Str("output", string(out)))
 	}
 
 	log.Info(ctx, "Executing docker compose up")
-	cmd = exec.CommandContext(ctx, "docker", "compose", "up",
-		"--remove-orphans",
-		"--abort-on-container-exit",
-		"--quiet-pull",
-	)
-	cmd.Dir = dir
+	args := []string{"up", "--remove-orphans", "--quiet-pull"}
+	if abortOnExit {
+		args = append(args, "--abort-on-container-exit")
+	}
+
+	cmd = engine.ComposeCmd(ctx, dir, args...)
 	cmd.Stdout = out
 	cmd.Stderr = out
 
@@ -236,14 +473,18 @@ func execUp(ctx context.Context, dir string, out io.Writer) error {
 		return errors.Wrap(err, "exec docker compose up")
 	}
 
-	return nil
+	// Pull files written by containers on remote docker hosts, e.g. the cluster lock.
+	return syncRemote(ctx, engine, dir, false)
 }
 
 // execBuildAndCreate builds and creates containers. It should be called before execUp for run step.
-func execBuildAndCreate(ctx context.Context, dir string) error {
+func execBuildAndCreate(ctx context.Context, engine Engine, dir string) error {
+	if err := syncRemote(ctx, engine, dir, true); err != nil {
+		return err
+	}
+
 	log.Info(ctx, "Executing docker compose up --no-start --build")
-	cmd := exec.CommandContext(ctx, "docker", "compose", "up", "--no-start", "--build")
-	cmd.Dir = dir
+	cmd := engine.ComposeCmd(ctx, dir, "up", "--no-start", "--build")
 	if out, err := cmd.CombinedOutput(); err != nil {
 		return errors.Wrap(err, "exec docker compose up --no-start --build", z.Str("output", string(out)))
 	}
@@ -275,13 +516,30 @@ func NewRunnerFunc(topic string, dir string, up bool, runFunc RunFunc,
 		}
 
 		if up {
-			return data, execUp(ctx, dir, os.Stdout)
+			engine, err := newConfEngine(ctx, conf)
+			if err != nil {
+				return TmplData{}, err
+			}
+
+			return data, execUp(ctx, engine, dir, os.Stdout, true)
 		}
 
 		return data, nil
 	}
 }
 
+// loadConfig returns the compose config.json in the compose dir.
+func loadConfig(dir string) (Config, error) {
+	conf, err := LoadConfig(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Config{}, errors.New("compose config.json not found; maybe try `compose new` first", z.Str("dir", dir))
+	} else if err != nil {
+		return Config{}, err
+	}
+
+	return conf, nil
+}
+
 // newLogWriter returns io writer and a close function or an error.
 func newLogWriter(logFile string) (io.WriteCloser, func() error, error) {
 	if logFile == "" {
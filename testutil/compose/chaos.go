@@ -0,0 +1,324 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// ChaosFault is a type of fault injected by the chaos controller.
+type ChaosFault string
+
+const (
+	// FaultLatency injects network latency and packet loss via netem.
+	FaultLatency ChaosFault = "latency"
+	// FaultPartition partitions nodes from the rest of the cluster and the relay via iptables.
+	FaultPartition ChaosFault = "partition"
+	// FaultKill kills nodes and restarts them.
+	FaultKill ChaosFault = "kill"
+)
+
+// ChaosFaults returns all supported chaos faults.
+func ChaosFaults() []ChaosFault {
+	return []ChaosFault{FaultLatency, FaultPartition, FaultKill}
+}
+
+// ChaosConfig configures the chaos controller that injects faults into a running compose cluster.
+type ChaosConfig struct {
+	// Faults are the fault types to inject, a random one is picked for each event.
+	Faults []ChaosFault
+	// Interval is the period between the start of consecutive fault events.
+	Interval time.Duration
+	// Duration is the time a fault is active before it is reverted, it must be shorter than Interval.
+	Duration time.Duration
+	// Latency is the network latency injected by latency faults.
+	Latency time.Duration
+	// LossPercent is the packet loss percentage injected by latency faults.
+	LossPercent int
+	// MinSuccessRate is the minimum ratio of successful to expected duties per duty type, zero disables the assertion.
+	MinSuccessRate float64
+	// Seed seeds the random fault schedule.
+	Seed int64
+}
+
+// DefaultChaosConfig returns the default chaos config.
+func DefaultChaosConfig() ChaosConfig {
+	return ChaosConfig{
+		Faults:         ChaosFaults(),
+		Interval:       time.Second * 30,
+		Duration:       time.Second * 10,
+		Latency:        time.Millisecond * 200,
+		LossPercent:    5,
+		MinSuccessRate: 0.8,
+		Seed:           time.Now().UnixNano(),
+	}
+}
+
+// validate returns an error if the chaos config is invalid.
+func (c ChaosConfig) validate() error {
+	if len(c.Faults) == 0 {
+		return errors.New("no chaos faults configured")
+	}
+
+	for _, fault := range c.Faults {
+		var ok bool
+		for _, supported := range ChaosFaults() {
+			ok = ok || fault == supported
+		}
+		if !ok {
+			return errors.New("unknown chaos fault", z.Str("fault", string(fault)))
+		}
+	}
+
+	if c.Duration <= 0 || c.Duration >= c.Interval {
+		return errors.New("chaos duration must be positive and shorter than interval")
+	} else if c.LossPercent < 0 || c.LossPercent > 100 {
+		return errors.New("invalid chaos loss percent", z.Int("loss", c.LossPercent))
+	} else if c.MinSuccessRate < 0 || c.MinSuccessRate > 1 {
+		return errors.New("invalid chaos min success rate", z.Any("rate", c.MinSuccessRate))
+	}
+
+	return nil
+}
+
+// chaosEvent is a fault injected into a subset of nodes.
+type chaosEvent struct {
+	Fault ChaosFault
+	Nodes []int
+}
+
+// nextChaosEvent returns a random fault event affecting at most numNodes-threshold nodes,
+// so the remaining nodes can still reach threshold.
+func nextChaosEvent(random *rand.Rand, faults []ChaosFault, numNodes, threshold int) chaosEvent {
+	maxNodes := numNodes - threshold
+	if maxNodes < 1 {
+		maxNodes = 1
+	}
+
+	count := 1 + random.Intn(maxNodes)
+
+	return chaosEvent{
+		Fault: faults[random.Intn(len(faults))],
+		Nodes: random.Perm(numNodes)[:count],
+	}
+}
+
+// injectArgs returns the compose command args that inject the fault event.
+func injectArgs(event chaosEvent, conf ChaosConfig, numNodes int, relay bool) [][]string {
+	var resp [][]string
+	for _, node := range event.Nodes {
+		switch event.Fault {
+		case FaultLatency:
+			resp = append(resp, netemArgs(node, "tc", "qdisc", "add", "dev", "eth0", "root", "netem",
+				"delay", conf.Latency.String(), "loss", strconv.Itoa(conf.LossPercent)+"%"))
+		case FaultPartition:
+			for _, peer := range partitionPeers(event.Nodes, numNodes, relay) {
+				resp = append(resp,
+					netemArgs(node, "iptables", "-A", "INPUT", "-s", peer, "-j", "DROP"),
+					netemArgs(node, "iptables", "-A", "OUTPUT", "-d", peer, "-j", "DROP"),
+				)
+			}
+		case FaultKill:
+			resp = append(resp, []string{"kill", nodeService(node)})
+		}
+	}
+
+	return resp
+}
+
+// revertArgs returns the compose command args that revert the fault event.
+func revertArgs(event chaosEvent) [][]string {
+	var resp [][]string
+	for _, node := range event.Nodes {
+		switch event.Fault {
+		case FaultLatency:
+			resp = append(resp, netemArgs(node, "tc", "qdisc", "del", "dev", "eth0", "root"))
+		case FaultPartition:
+			resp = append(resp,
+				netemArgs(node, "iptables", "-F", "INPUT"),
+				netemArgs(node, "iptables", "-F", "OUTPUT"),
+			)
+		case FaultKill:
+			// The netem sidecar shares the node's network namespace, so restart it with the node.
+			resp = append(resp,
+				[]string{"start", nodeService(node)},
+				[]string{"restart", nodeService(node) + "-netem"},
+			)
+		}
+	}
+
+	return resp
+}
+
+// partitionPeers returns the hostnames of the nodes not in the partition and the relay.
+func partitionPeers(partition []int, numNodes int, relay bool) []string {
+	partitioned := make(map[int]bool)
+	for _, node := range partition {
+		partitioned[node] = true
+	}
+
+	var resp []string
+	for i := range numNodes {
+		if !partitioned[i] {
+			resp = append(resp, nodeService(i))
+		}
+	}
+
+	if relay {
+		resp = append(resp, "relay")
+	}
+
+	return resp
+}
+
+// netemArgs returns the compose args executing the command in the node's netem sidecar.
+func netemArgs(node int, cmd ...string) []string {
+	return append([]string{"exec", "-T", nodeService(node) + "-netem"}, cmd...)
+}
+
+func nodeService(node int) string {
+	return fmt.Sprintf("node%d", node)
+}
+
+// startChaos starts a goroutine that injects and reverts random fault events until the context is closed
+// and returns a channel on which the duty performance violations of each check are sent.
+func startChaos(ctx context.Context, engine Engine, dir string, conf ChaosConfig, numNodes, threshold int, relay bool) chan []string {
+	ctx = log.WithTopic(ctx, "chaos")
+	resp := make(chan []string, 100)
+
+	go func() {
+		defer close(resp)
+
+		random := rand.New(rand.NewSource(conf.Seed)) //nolint:gosec // Reproducible fault schedule.
+		log.Info(ctx, "Starting chaos controller", z.I64("seed", conf.Seed), z.Any("faults", conf.Faults))
+
+		ticker := time.NewTicker(conf.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			event := nextChaosEvent(random, conf.Faults, numNodes, threshold)
+			log.Info(ctx, "Injecting chaos fault", z.Str("fault", string(event.Fault)), z.Any("nodes", event.Nodes))
+			execChaos(ctx, engine, dir, injectArgs(event, conf, numNodes, relay))
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(conf.Duration):
+			}
+
+			// Always revert, even after the context is closed, so the cluster is shut down cleanly.
+			log.Info(ctx, "Reverting chaos fault", z.Str("fault", string(event.Fault)), z.Any("nodes", event.Nodes))
+			execChaos(context.Background(), engine, dir, revertArgs(event))
+
+			if conf.MinSuccessRate == 0 || ctx.Err() != nil {
+				continue
+			}
+
+			violations, err := checkDutySuccess(ctx, engine, dir, conf.MinSuccessRate)
+			if ctx.Err() != nil {
+				return
+			} else if err != nil {
+				log.Warn(ctx, "Failed checking duty success rates", err)
+				continue
+			}
+
+			resp <- violations
+		}
+	}()
+
+	return resp
+}
+
+// execChaos executes the compose commands, logging failures.
+func execChaos(ctx context.Context, engine Engine, dir string, argsList [][]string) {
+	for _, args := range argsList {
+		out, err := engine.ComposeCmd(ctx, dir, args...).CombinedOutput()
+		if err != nil && ctx.Err() == nil {
+			log.Warn(ctx, "Failed executing chaos command", err, z.Any("args", args), z.Str("out", string(out)))
+		}
+	}
+}
+
+// dutySuccessQuery is the prometheus query returning the ratio of successful to expected duties per duty type.
+const dutySuccessQuery = `sum by (duty) (core_tracker_success_duties_total) / sum by (duty) (core_tracker_expect_duties_total)`
+
+// checkDutySuccess returns the duty types with success rates below the minimum.
+func checkDutySuccess(ctx context.Context, engine Engine, dir string, minRate float64) ([]string, error) {
+	out, err := execPromQuery(ctx, engine, dir, dutySuccessQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return dutySuccessViolations(out, minRate)
+}
+
+// execPromQuery executes the prometheus instant query via the curl service and returns the response.
+func execPromQuery(ctx context.Context, engine Engine, dir string, query string) ([]byte, error) {
+	cmd := engine.ComposeCmd(ctx, dir, "exec", "-T", "curl", "curl", "-s",
+		"--data-urlencode", "query="+query, "http://prometheus:9090/api/v1/query")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, errors.Wrap(err, "exec curl prometheus query", z.Str("query", query), z.Str("out", string(out)))
+	}
+
+	return bytes.TrimSpace(out), nil
+}
+
+// dutySuccessViolations returns the duty types of the prometheus query response with success rates below the minimum.
+func dutySuccessViolations(resp []byte, minRate float64) ([]string, error) {
+	var query promQuery
+	if err := json.Unmarshal(resp, &query); err != nil {
+		return nil, errors.Wrap(err, "unmarshal query response", z.Str("out", string(resp)))
+	} else if query.Status != "success" {
+		return nil, errors.New("non success status from prometheus query", z.Str("status", query.Status))
+	}
+
+	var violations []string
+	for _, result := range query.Data.Result {
+		if len(result.Value) != 2 {
+			return nil, errors.New("invalid prometheus query result value")
+		}
+
+		valStr, ok := result.Value[1].(string)
+		if !ok {
+			return nil, errors.New("invalid prometheus query result value")
+		}
+
+		rate, err := strconv.ParseFloat(valStr, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse duty success rate")
+		}
+
+		if rate < minRate {
+			violations = append(violations, fmt.Sprintf("duty %s success rate %.2f below minimum %.2f", result.Metric["duty"], rate, minRate))
+		}
+	}
+
+	return violations, nil
+}
+
+// promQuery is the json response returned by a prometheus instant vector query.
+type promQuery struct {
+	Status string `json:"status"`
+	Data   struct {
+		Result []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []any             `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
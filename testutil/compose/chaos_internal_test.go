@@ -0,0 +1,81 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextChaosEvent(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+
+	for range 100 {
+		event := nextChaosEvent(random, ChaosFaults(), 7, 5)
+		require.NotEmpty(t, event.Nodes)
+		require.LessOrEqual(t, len(event.Nodes), 2)
+		require.Contains(t, ChaosFaults(), event.Fault)
+
+		for _, node := range event.Nodes {
+			require.Less(t, node, 7)
+		}
+	}
+}
+
+func TestChaosArgs(t *testing.T) {
+	conf := DefaultChaosConfig()
+	conf.Latency = 100 * time.Millisecond
+	conf.LossPercent = 10
+
+	latency := chaosEvent{Fault: FaultLatency, Nodes: []int{1}}
+	require.Equal(t, [][]string{
+		{"exec", "-T", "node1-netem", "tc", "qdisc", "add", "dev", "eth0", "root", "netem", "delay", "100ms", "loss", "10%"},
+	}, injectArgs(latency, conf, 4, true))
+	require.Equal(t, [][]string{
+		{"exec", "-T", "node1-netem", "tc", "qdisc", "del", "dev", "eth0", "root"},
+	}, revertArgs(latency))
+
+	partition := chaosEvent{Fault: FaultPartition, Nodes: []int{0, 2}}
+	inject := injectArgs(partition, conf, 3, true)
+	require.Len(t, inject, 8) // 2 nodes * 2 peers (node1 and relay) * 2 chains
+	require.Equal(t, []string{"exec", "-T", "node0-netem", "iptables", "-A", "INPUT", "-s", "node1", "-j", "DROP"}, inject[0])
+	require.Equal(t, []string{"exec", "-T", "node2-netem", "iptables", "-A", "OUTPUT", "-d", "relay", "-j", "DROP"}, inject[7])
+	require.Len(t, revertArgs(partition), 4)
+
+	kill := chaosEvent{Fault: FaultKill, Nodes: []int{3}}
+	require.Equal(t, [][]string{{"kill", "node3"}}, injectArgs(kill, conf, 4, false))
+	require.Equal(t, [][]string{{"start", "node3"}, {"restart", "node3-netem"}}, revertArgs(kill))
+}
+
+func TestChaosConfigValidate(t *testing.T) {
+	require.NoError(t, DefaultChaosConfig().validate())
+
+	for _, fn := range []func(*ChaosConfig){
+		func(c *ChaosConfig) { c.Faults = nil },
+		func(c *ChaosConfig) { c.Faults = []ChaosFault{"meteor"} },
+		func(c *ChaosConfig) { c.Duration = c.Interval },
+		func(c *ChaosConfig) { c.LossPercent = 101 },
+		func(c *ChaosConfig) { c.MinSuccessRate = 1.5 },
+	} {
+		conf := DefaultChaosConfig()
+		fn(&conf)
+		require.Error(t, conf.validate())
+	}
+}
+
+func TestDutySuccessViolations(t *testing.T) {
+	resp := []byte(`{"status":"success","data":{"resultType":"vector","result":[
+		{"metric":{"duty":"attester"},"value":[1700000000.123,"0.95"]},
+		{"metric":{"duty":"proposer"},"value":[1700000000.123,"0.5"]}
+	]}}`)
+
+	violations, err := dutySuccessViolations(resp, 0.8)
+	require.NoError(t, err)
+	require.Equal(t, []string{"duty proposer success rate 0.50 below minimum 0.80"}, violations)
+
+	_, err = dutySuccessViolations([]byte(`{"status":"error"}`), 0.8)
+	require.ErrorContains(t, err, "non success status")
+}
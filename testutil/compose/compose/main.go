@@ -12,14 +12,19 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os/signal"
+	"regexp"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/testutil/compose"
 )
@@ -56,6 +61,17 @@ func newRootCmd() *cobra.Command {
 		"Creates a docker-compose.yml that executes `charon run`",
 		compose.Run,
 	))
+	root.AddCommand(newK8sCmd())
+	root.AddCommand(newKurtosisCmd())
+	root.AddCommand(newDepositsCmd())
+	root.AddCommand(newMatrixCmd())
+	root.AddCommand(newParallelCmd())
+	root.AddCommand(newSnapshotCmd())
+	root.AddCommand(newRestoreCmd())
+	root.AddCommand(newWatchCmd())
+	root.AddCommand(newImportCmd())
+	root.AddCommand(newPinCmd())
+	root.AddCommand(newPrefetchCmd())
 
 	return root
 }
@@ -81,6 +97,94 @@ func newDockerCmd(use string, short string, runFunc compose.RunFunc) *cobra.Comm
 	return cmd
 }
 
+func newK8sCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "k8s",
+		Short: "Creates a k8s.yml with Kubernetes manifests of a compose step instead of executing docker compose",
+		Args:  cobra.NoArgs,
+	}
+
+	steps := map[string]compose.RunFunc{
+		"define": compose.Define,
+		"lock":   compose.Lock,
+		"run":    compose.Run,
+	}
+
+	dir := addDirFlag(cmd.Flags())
+	step := cmd.Flags().String("step", "run", "Compose step to create Kubernetes manifests for: define, lock, run")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		runFunc, ok := steps[*step]
+		if !ok {
+			return errors.New("invalid compose step", z.Str("step", *step))
+		}
+
+		data, err := compose.NewRunnerFunc("k8s", *dir, false, runFunc)(cmd.Context())
+		if err != nil {
+			log.Error(cmd.Context(), "Fatal error", err)
+			return err
+		}
+
+		// Define and lock steps run to completion, so render Jobs instead of StatefulSets.
+		if err := compose.WriteK8sManifests(*dir, data, *step != "run"); err != nil {
+			return err
+		}
+
+		log.Info(cmd.Context(), "Created k8s.yml, apply it with: kubectl apply -f k8s.yml")
+
+		return nil
+	}
+
+	return cmd
+}
+
+func newKurtosisCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kurtosis",
+		Short: "Configures a new compose config to run against a Kurtosis ethereum-package devnet instead of simnet",
+		Args:  cobra.NoArgs,
+	}
+
+	var conf compose.KurtosisConfig
+
+	dir := addDirFlag(cmd.Flags())
+	cmd.Flags().StringVar(&conf.Enclave, "enclave", "", "Name of the Kurtosis enclave running the devnet.")
+	cmd.Flags().StringVar(&conf.BeaconService, "beacon-service", "cl-1-lighthouse-geth", "Name of the Kurtosis consensus layer service the charon nodes connect to.")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		if conf.Enclave == "" {
+			return errors.New("missing --enclave flag")
+		}
+
+		ctx := log.WithTopic(cmd.Context(), "kurtosis")
+		if _, err := compose.Kurtosis(ctx, *dir, conf); err != nil {
+			log.Error(ctx, "Fatal error", err)
+			return err
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+func newDepositsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposits",
+		Short: "Collects the cluster's deposit data files created by the lock step for submission to the deposit contract",
+		Args:  cobra.NoArgs,
+	}
+
+	dir := addDirFlag(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		_, err := compose.CollectDeposits(log.WithTopic(cmd.Context(), "deposits"), *dir)
+		return err
+	}
+
+	return cmd
+}
+
 func newAutoCmd() *cobra.Command {
 	var conf compose.AutoConfig
 
@@ -89,7 +193,7 @@ func newAutoCmd() *cobra.Command {
 		Short: "Convenience function that runs `compose define && compose lock && compose run`",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
-			err := compose.Auto(cmd.Context(), conf)
+			_, err := compose.Auto(cmd.Context(), conf)
 			if err != nil {
 				log.Error(cmd.Context(), "auto command fatal error", err)
 				return err
@@ -103,19 +207,345 @@ func newAutoCmd() *cobra.Command {
 	cmd.Flags().DurationVar(&conf.AlertTimeout, "alert-timeout", 0, "Timeout to collect alerts before shutdown. Zero disables timeout.")
 	cmd.Flags().BoolVar(&conf.SudoPerms, "sudo-perms", false, "Enables changing all compose artefacts file permissions using sudo.")
 	cmd.Flags().BoolVar(&conf.PrintYML, "print-yml", false, "Print generated docker-compose.yml files.")
+	cmd.Flags().StringVar(&conf.ArtifactsDir, "artifacts-dir", "", "Directory to write artifact bundles of container logs, metrics and configs to. Empty defaults to <compose-dir>/artifacts.")
+	cmd.Flags().BoolVar(&conf.AlwaysCollectArtifacts, "always-collect-artifacts", false, "Collect artifacts on teardown of successful runs, they are always collected on failure.")
+
+	chaosConf := compose.DefaultChaosConfig()
+	var faults []string
+	for _, fault := range chaosConf.Faults {
+		faults = append(faults, string(fault))
+	}
+
+	chaos := cmd.Flags().Bool("chaos", false, "Enables chaos testing, injecting faults during the run step and asserting duty success rates instead of alerts.")
+	chaosFaults := cmd.Flags().StringSlice("chaos-faults", faults, "Chaos faults to inject: latency, partition, kill.")
+	cmd.Flags().DurationVar(&chaosConf.Interval, "chaos-interval", chaosConf.Interval, "Period between chaos fault events.")
+	cmd.Flags().DurationVar(&chaosConf.Duration, "chaos-duration", chaosConf.Duration, "Duration of each chaos fault event.")
+	cmd.Flags().Float64Var(&chaosConf.MinSuccessRate, "chaos-min-success-rate", chaosConf.MinSuccessRate, "Minimum ratio of successful to expected duties per duty type during chaos testing.")
+
+	upgradeConf := compose.DefaultUpgradeConfig("")
+	cmd.Flags().StringVar(&upgradeConf.FromImageTag, "upgrade-from", "", "Enables rolling upgrade testing, starting all nodes on this charon image tag and upgrading them one at a time.")
+	cmd.Flags().StringVar(&upgradeConf.ToImageTag, "upgrade-to", "", "Charon image tag to upgrade nodes to. Empty defaults to the compose config image tag.")
+	cmd.Flags().DurationVar(&upgradeConf.Interval, "upgrade-interval", upgradeConf.Interval, "Period before each node upgrade and before checking duties after it.")
+
+	relayConf := compose.DefaultRelayOutageConfig()
+	relayOutage := cmd.Flags().Bool("relay-outage", false, "Enables relay outage testing, stopping and restarting the relay during the run step and asserting node connectivity degrades and recovers instead of alerts.")
+	cmd.Flags().IntVar(&relayConf.Outages, "relay-outages", relayConf.Outages, "Number of relay outages.")
+	cmd.Flags().DurationVar(&relayConf.Interval, "relay-outage-interval", relayConf.Interval, "Period the relay runs before each outage.")
+	cmd.Flags().DurationVar(&relayConf.Duration, "relay-outage-duration", relayConf.Duration, "Duration the relay is stopped during each outage.")
+	cmd.Flags().DurationVar(&relayConf.RecoveryTimeout, "relay-recovery-timeout", relayConf.RecoveryTimeout, "Maximum time after restarting the relay for node connectivity to recover.")
+	cmd.Flags().BoolVar(&relayConf.RequirePeerLoss, "relay-require-peer-loss", false, "Require nodes to lose peer connectivity during relay outages, e.g. if nodes can only connect via the relay.")
+
+	cmd.Flags().StringSliceVar(&conf.Alerts.Require, "require-alerts", nil, "Regular expressions of alerts that must be detected during the run step.")
+	cmd.Flags().StringSliceVar(&conf.Alerts.Allow, "allow-alerts", nil, "Regular expressions of alerts that may be detected during the run step without failing it.")
+	alertmanager := cmd.Flags().String("alertmanager-url", "", "Also poll alerts from this Prometheus Alertmanager URL reachable from the compose network.")
+	promQLAlerts := cmd.Flags().StringArray("promql-alert", nil, "Also fire an alert while a PromQL query returns results, formatted as 'name=query'. Can be repeated.")
+	logAlerts := cmd.Flags().StringArray("log-alert", nil, "Also fire an alert for log lines matching a regular expression, formatted as '[service=]pattern'. Can be repeated.")
+
+	cmd.PreRunE = func(*cobra.Command, []string) error {
+		if *alertmanager != "" || len(*promQLAlerts) > 0 || len(*logAlerts) > 0 {
+			sources, err := alertSources(*alertmanager, *promQLAlerts, *logAlerts)
+			if err != nil {
+				return err
+			}
+			conf.Alerts.Sources = sources
+		}
+
+		if upgradeConf.FromImageTag != "" {
+			conf.Upgrade = &upgradeConf
+		}
+
+		if *relayOutage {
+			conf.RelayOutage = &relayConf
+		}
+
+		if !*chaos {
+			return nil
+		}
+
+		chaosConf.Faults = nil
+		for _, fault := range *chaosFaults {
+			chaosConf.Faults = append(chaosConf.Faults, compose.ChaosFault(fault))
+		}
+		conf.Chaos = &chaosConf
+
+		return nil
+	}
 
 	return cmd
 }
 
+func newMatrixCmd() *cobra.Command {
+	var conf compose.MatrixConfig
+
+	cmd := &cobra.Command{
+		Use:   "matrix",
+		Short: "Runs `compose auto` for each combination of per-node validator client types of a new compose config",
+		Args:  cobra.NoArgs,
+	}
+
+	var types []string
+	for _, typ := range compose.VCTypes() {
+		types = append(types, string(typ))
+	}
+
+	cmd.Flags().StringVar(&conf.Auto.Dir, "compose-dir", ".", "Directory to use for compose artifacts")
+	cmd.Flags().DurationVar(&conf.Auto.AlertTimeout, "alert-timeout", time.Minute*2, "Timeout to collect alerts before shutdown of each run. Zero disables timeout.")
+	cmd.Flags().BoolVar(&conf.Auto.SudoPerms, "sudo-perms", false, "Enables changing all compose artefacts file permissions using sudo.")
+	cmd.Flags().StringVar(&conf.Auto.LogFile, "log-file", "", "Appends docker compose output of all runs to this file instead of stdout.")
+	vcTypes := cmd.Flags().StringSlice("validator-types", types, "Validator client types to combine.")
+	cmd.Flags().IntVar(&conf.MaxRuns, "max-runs", 0, "Maximum number of combinations to run. Zero runs all combinations.")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		for _, typ := range *vcTypes {
+			conf.VCTypes = append(conf.VCTypes, compose.VCType(typ))
+		}
+
+		results, err := compose.Matrix(cmd.Context(), conf)
+		for _, result := range results {
+			status := "ok"
+			if result.Err != nil {
+				status = "failed"
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-8s %v\n", status, result.VCs)
+		}
+
+		return err
+	}
+
+	return cmd
+}
+
+func newParallelCmd() *cobra.Command {
+	var conf compose.ParallelConfig
+
+	cmd := &cobra.Command{
+		Use:   "parallel [compose-dir...]",
+		Short: "Runs `compose auto` concurrently for the new compose configs in the compose dirs, each as an isolated cluster",
+		Args:  cobra.MinimumNArgs(1),
+	}
+
+	cmd.Flags().DurationVar(&conf.Auto.AlertTimeout, "alert-timeout", time.Minute*2, "Timeout to collect alerts before shutdown of each cluster. Zero disables timeout.")
+	cmd.Flags().BoolVar(&conf.Auto.SudoPerms, "sudo-perms", false, "Enables changing all compose artefacts file permissions using sudo.")
+	cmd.Flags().StringVar(&conf.Auto.ArtifactsDir, "artifacts-dir", "", "Directory to write artifact bundles to, in a folder per cluster. Empty defaults to each <compose-dir>/artifacts.")
+	cmd.Flags().IntVar(&conf.MaxParallel, "max-parallel", 0, "Maximum number of clusters running concurrently. Zero runs all clusters concurrently.")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		conf.Dirs = args
+
+		results, err := compose.Parallel(cmd.Context(), conf)
+		for _, result := range results {
+			status := "ok"
+			if result.Err != nil {
+				status = "failed"
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-8s %s\n", status, result.Dir)
+		}
+
+		return err
+	}
+
+	return cmd
+}
+
+func newSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Snapshots the state of the compose cluster, i.e. the compose dir and optional container paths, for later restoring",
+		Args:  cobra.NoArgs,
+	}
+
+	dir := addDirFlag(cmd.Flags())
+	name := cmd.Flags().String("name", "", "Name of the snapshot. Empty defaults to the current time.")
+	containerPaths := cmd.Flags().StringArray("container-path", nil, "Also snapshot a path inside a service's container, formatted as 'service:/path', e.g. a validator client data dir. Can be repeated.")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		var paths []compose.ContainerPath
+		for _, containerPath := range *containerPaths {
+			service, p, ok := strings.Cut(containerPath, ":")
+			if !ok || service == "" || p == "" {
+				return errors.New("invalid container path, expected 'service:/path'", z.Str("path", containerPath))
+			}
+			paths = append(paths, compose.ContainerPath{Service: service, Path: p})
+		}
+
+		if *name == "" {
+			*name = time.Now().UTC().Format("20060102T150405Z")
+		}
+
+		ctx := log.WithTopic(cmd.Context(), "snapshot")
+		if _, err := compose.Snapshot(ctx, *dir, *name, paths); err != nil {
+			log.Error(ctx, "Fatal error", err)
+			return err
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+func newRestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restores the state of the compose cluster from a snapshot, start it again with: docker compose up",
+		Args:  cobra.NoArgs,
+	}
+
+	dir := addDirFlag(cmd.Flags())
+	name := cmd.Flags().String("name", "", "Name of the snapshot to restore.")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		if *name == "" {
+			return errors.New("missing --name flag")
+		}
+
+		ctx := log.WithTopic(cmd.Context(), "restore")
+		if err := compose.Restore(ctx, *dir, *name); err != nil {
+			log.Error(ctx, "Fatal error", err)
+			return err
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+func newWatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Renders a live terminal dashboard of per-node duties, peer connectivity and consensus latencies of the running cluster",
+		Args:  cobra.NoArgs,
+	}
+
+	dir := addDirFlag(cmd.Flags())
+	interval := cmd.Flags().Duration("interval", time.Second*5, "Dashboard refresh interval.")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		return compose.Watch(cmd.Context(), *dir, *interval, cmd.OutOrStdout())
+	}
+
+	return cmd
+}
+
+func newImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [flags] <node-dir>...",
+		Short: "Imports an existing cluster lock and the charon dirs of all its operators into a new compose config, ready for `compose run`",
+		Args:  cobra.MinimumNArgs(1),
+	}
+
+	dir := addDirFlag(cmd.Flags())
+	lockFile := cmd.Flags().String("lock-file", "", "Path of the cluster lock file to import. Empty defaults to the cluster-lock.json of the first node dir.")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := log.WithTopic(cmd.Context(), "import")
+		if err := compose.Import(ctx, *dir, *lockFile, args); err != nil {
+			log.Error(ctx, "Fatal error", err)
+			return err
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+func newPinCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pin",
+		Short: "Pins all upstream validator client, monitoring and tooling images to their current digests in an image lock file",
+		Args:  cobra.NoArgs,
+	}
+
+	dir := addDirFlag(cmd.Flags())
+	file := cmd.Flags().String("file", "images.lock.json", "Path, relative to the compose dir if not absolute, of the image lock file to write.")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		ctx := log.WithTopic(cmd.Context(), "pin")
+		if _, err := compose.Pin(ctx, *dir, *file); err != nil {
+			log.Error(ctx, "Fatal error", err)
+			return err
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+func newPrefetchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prefetch",
+		Short: "Pulls all images pinned by the compose config's image lock file, verifying the pinned digests exist",
+		Args:  cobra.NoArgs,
+	}
+
+	dir := addDirFlag(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		ctx := log.WithTopic(cmd.Context(), "prefetch")
+		if err := compose.Prefetch(ctx, *dir); err != nil {
+			log.Error(ctx, "Fatal error", err)
+			return err
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+// alertSources returns the default prometheus alerting rules source and the additional alert sources of the flags.
+func alertSources(alertmanager string, promQLAlerts []string, logAlerts []string) ([]compose.AlertSource, error) {
+	resp := []compose.AlertSource{compose.PrometheusRules{}}
+	if alertmanager != "" {
+		resp = append(resp, compose.Alertmanager{URL: alertmanager})
+	}
+
+	for _, alert := range promQLAlerts {
+		name, query, ok := strings.Cut(alert, "=")
+		if !ok || name == "" || query == "" {
+			return nil, errors.New("invalid promql alert, expected 'name=query'", z.Str("alert", alert))
+		}
+		resp = append(resp, compose.PromQLAlert{AlertName: name, Query: query})
+	}
+
+	for _, alert := range logAlerts {
+		var service string
+		pattern := alert
+		// Only treat the prefix as a service if it's a valid compose service name, patterns may contain '='.
+		if before, after, ok := strings.Cut(alert, "="); ok && serviceNameRegex.MatchString(before) {
+			service, pattern = before, after
+		}
+		resp = append(resp, compose.LogAlert{Service: service, Pattern: pattern})
+	}
+
+	return resp, nil
+}
+
+// serviceNameRegex matches valid compose service names.
+var serviceNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
 func newBuildLocalCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "build-local",
 		Short: "Builds the obolnetwork/charon:local docker container from the local source code. Note this requires the CHARON_REPO env var.",
 		Args:  cobra.NoArgs,
-		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
-			return compose.BuildLocal(cmd.Context())
-		},
 	}
+
+	engineName := addEngineFlag(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		engine, err := compose.NewEngine(cmd.Context(), *engineName)
+		if err != nil {
+			return err
+		}
+
+		return compose.BuildLocal(cmd.Context(), engine)
+	}
+
+	return cmd
 }
 
 func newNewCmd() *cobra.Command {
@@ -130,31 +560,62 @@ func newNewCmd() *cobra.Command {
 	keygen := cmd.Flags().String("keygen", string(conf.KeyGen), "Key generation process: create, split, dkg")
 	buildLocal := cmd.Flags().Bool("build-local", conf.BuildLocal, "Enables building a local charon container from source. Note this requires the CHARON_REPO env var.")
 	beaconNode := cmd.Flags().String("beacon-nodes", conf.BeaconNodes, "Beacon node URL endpoints or 'mock' for simnet.")
+	network := cmd.Flags().String("network", conf.Network, "Ethereum network to create the cluster for: mainnet, goerli, sepolia, holesky, hoodi, gnosis, chiado.")
+	chainSpec := cmd.Flags().String("testnet-chain-spec", "", "Path, relative to the compose dir, of a custom test network chain spec file (config.yaml). Overrides network.")
+	hostGateway := cmd.Flags().Bool("host-gateway", false, "Makes the docker host reachable from charon nodes as host.docker.internal, e.g. for beacon nodes published on the host.")
 	extRelay := cmd.Flags().String("external-relay", "", "Optional external relay HTTP url.")
 	splitKeys := cmd.Flags().String("split-keys-dir", conf.SplitKeysDir, "Directory containing keys to split for keygen==create, or empty not to split.")
 	featureSet := cmd.Flags().String("feature-set", conf.FeatureSet, "Minimum feature set to enable: alpha, beta, stable")
 	numVals := cmd.Flags().Int("num-validators", conf.NumValidators, "Number of distributed validators.")
-	vcTypes := cmd.Flags().StringSlice("validator-types", conf.VCStrings(), "Validator types to include.")
+	vcTypes := cmd.Flags().StringSlice("validator-types", conf.VCStrings(), "Validator types to include, node i uses type i modulo the number of types: mock, teku, lighthouse, vouch, lodestar, nimbus.")
 	nodes := cmd.Flags().Int("nodes", conf.NumNodes, "Number of charon nodes in the cluster.")
+	threshold := cmd.Flags().Int("threshold", 0, "Threshold required for signature reconstruction. Zero defaults to the safe threshold for the number of nodes.")
 	insecureKeys := cmd.Flags().Bool("insecure-keys", conf.InsecureKeys, "To generate keys quickly.")
 	slotDuration := cmd.Flags().Duration("simnet-slot-duration", time.Second, "Configures slot duration in simnet beacon mock.")
 	beaconFuzz := cmd.Flags().Bool("beacon-fuzz", false, "Configures simnet beaconmock to return fuzzed responses.")
+	beaconScenario := cmd.Flags().String("beacon-mock-scenario", "", "Path, relative to the compose dir, of a simnet beacon mock scenario file scripting reorgs, delayed blocks, late attestation data and slashed or exited validators.")
 	p2pFuzz := cmd.Flags().Bool("p2p-fuzz", false, "Configures charon p2p network to return fuzzed responses of one of the nodes in the cluster.")
+	hardwareProfile := cmd.Flags().String("hardware-profile", "", "Constrains the resources of all nodes to a predefined hardware profile: "+strings.Join(compose.HardwareProfiles(), ", ")+". Empty disables constraints.")
+	imageLockFile := cmd.Flags().String("image-lock-file", "", "Path, relative to the compose dir if not absolute, of an image lock file pinning upstream images to digests, see `compose pin`. Empty disables pinning.")
+	monitoring := cmd.Flags().Bool("monitoring", conf.Monitoring, "Enables the grafana, loki and jaeger observability stack with charon nodes exporting logs and traces to it.")
+	dockerHost := cmd.Flags().String("docker-host", "", "Remote docker daemon to run the cluster against, e.g. ssh://user@lab-server. Empty uses the local docker daemon.")
+	dockerContext := cmd.Flags().String("docker-context", "", "Docker context of a remote docker daemon to run the cluster against, mutually exclusive with --docker-host.")
+	remoteDir := cmd.Flags().String("remote-dir", "", "Absolute path of the compose dir on the remote docker host, synced via rsync for SSH daemons. Required with --docker-host or --docker-context.")
+	engine := addEngineFlag(cmd.Flags())
 
 	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		if *dockerHost != "" || *dockerContext != "" || *remoteDir != "" {
+			conf.Remote = &compose.RemoteConfig{Host: *dockerHost, Context: *dockerContext, Dir: *remoteDir}
+		}
+		conf.Monitoring = *monitoring
+		conf.ImageLockFile = *imageLockFile
+		if *hardwareProfile != "" {
+			conf.NodeResources = make(map[int]compose.Resources)
+			for i := range *nodes {
+				conf.NodeResources[i] = compose.Resources{Profile: *hardwareProfile}
+			}
+		}
 		conf.KeyGen = compose.KeyGen(*keygen)
 		conf.BuildLocal = *buildLocal
 		conf.BeaconNodes = *beaconNode
+		conf.Network = *network
+		conf.TestnetChainSpec = *chainSpec
+		conf.HostGateway = *hostGateway
 		conf.SplitKeysDir = *splitKeys
 		conf.FeatureSet = *featureSet
 		conf.ExternalRelay = *extRelay
 		conf.NumValidators = *numVals
 		conf.NumNodes = *nodes
-		conf.Threshold = cluster.Threshold(conf.NumNodes)
+		conf.Threshold = *threshold
+		if conf.Threshold == 0 {
+			conf.Threshold = cluster.Threshold(conf.NumNodes)
+		}
 		conf.InsecureKeys = *insecureKeys
 		conf.SlotDuration = *slotDuration
 		conf.BeaconFuzz = *beaconFuzz
+		conf.BeaconMockScenario = *beaconScenario
 		conf.P2PFuzz = *p2pFuzz
+		conf.Engine = *engine
 
 		if conf.BuildLocal {
 			conf.ImageTag = "local"
@@ -197,6 +658,10 @@ func addDirFlag(flags *pflag.FlagSet) *string {
 	return flags.String("compose-dir", ".", "Directory to use for compose artifacts")
 }
 
+func addEngineFlag(flags *pflag.FlagSet) *string {
+	return flags.String("engine", "", fmt.Sprintf("Compose engine: %s. Empty detects the first available.", strings.Join(compose.EngineNames(), ", ")))
+}
+
 func addUpFlag(flags *pflag.FlagSet) *bool {
 	return flags.Bool("up", true, "Execute `docker compose up` when compose command completes")
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"os"
 	"path"
+	"strings"
 	"testing"
 	"text/template"
 
@@ -54,6 +55,17 @@ func TestDockerCompose(t *testing.T) {
 			},
 			RunFunc: Lock,
 		},
+		{
+			Name: "lock create kurtosis",
+			ConfFunc: func(conf *Config) {
+				conf.Step = stepDefined
+				conf.KeyGen = KeyGenCreate
+				conf.BeaconNodes = "http://host.docker.internal:33001"
+				conf.TestnetChainSpec = "kurtosis/config.yaml"
+				conf.HostGateway = true
+			},
+			RunFunc: Lock,
+		},
 		{
 			Name: "run",
 			ConfFunc: func(conf *Config) {
@@ -62,6 +74,73 @@ func TestDockerCompose(t *testing.T) {
 			},
 			RunFunc: Run,
 		},
+		{
+			Name: "run beacon mock scenario",
+			ConfFunc: func(conf *Config) {
+				conf.Step = stepLocked
+				conf.BeaconMockScenario = "scenario.json"
+			},
+			RunFunc: Run,
+		},
+		{
+			Name: "run faults",
+			ConfFunc: func(conf *Config) {
+				conf.Step = stepLocked
+				conf.VCs = []VCType{VCLighthouse, VCTeku, VCMock}
+				conf.NodeFaults = map[int]FaultProfile{
+					0: {ClockSkew: "+2s", SlowDisk: &SlowDisk{Device: "/dev/sda", Rate: "1mb"}},
+					1: {OfflineAfterSlots: 10, OfflineSlots: 5},
+					2: {Byzantine: true, OfflineAfterSlots: 3, OfflineSlots: 2},
+				}
+			},
+			RunFunc: Run,
+		},
+		{
+			Name: "run no monitoring",
+			ConfFunc: func(conf *Config) {
+				conf.Step = stepLocked
+				conf.Monitoring = false
+			},
+			RunFunc: Run,
+		},
+		{
+			Name: "run resources",
+			ConfFunc: func(conf *Config) {
+				conf.Step = stepLocked
+				conf.NodeResources = map[int]Resources{
+					0: {Profile: "rpi4"},
+					1: {CPUs: 1.5, Memory: "512m"},
+					2: {IOWeight: 100},
+				}
+				conf.NodeFaults = map[int]FaultProfile{2: {SlowDisk: &SlowDisk{Device: "/dev/sda", Rate: "1mb"}}}
+			},
+			RunFunc: Run,
+		},
+		{
+			Name: "run parallel",
+			ConfFunc: func(conf *Config) {
+				conf.Step = stepLocked
+				conf.ProjectName = "cluster-1"
+				conf.PortOffset = parallelPortStride
+			},
+			RunFunc: Run,
+		},
+		{
+			Name: "run remote",
+			ConfFunc: func(conf *Config) {
+				conf.Step = stepLocked
+				conf.Remote = &RemoteConfig{Host: "ssh://ops@lab-server", Dir: "/srv/compose"}
+			},
+			RunFunc: Run,
+		},
+		{
+			Name: "run mixed vcs",
+			ConfFunc: func(conf *Config) {
+				conf.Step = stepLocked
+				conf.VCs = []VCType{VCNimbus, VCLodestar, VCVouch, VCMock}
+			},
+			RunFunc: Run,
+		},
 	}
 
 	const seed = 0
@@ -88,6 +167,14 @@ func TestDockerCompose(t *testing.T) {
 				testutil.RequireGoldenBytes(t, b)
 			})
 
+			t.Run("k8s", func(t *testing.T) {
+				require.NoError(t, WriteK8sManifests(dir, data, !strings.HasPrefix(test.Name, "run")))
+				b, err := os.ReadFile(path.Join(dir, "k8s.yml"))
+				require.NoError(t, err)
+				b = bytes.ReplaceAll(b, []byte(dir), []byte("testdir"))
+				testutil.RequireGoldenBytes(t, b)
+			})
+
 			t.Run("template", func(t *testing.T) {
 				data.ComposeDir = "testdir"
 				testutil.RequireGoldenJSON(t, data)
@@ -96,10 +183,34 @@ func TestDockerCompose(t *testing.T) {
 	}
 }
 
+func TestCommandArgs(t *testing.T) {
+	tests := []struct {
+		Command string
+		Expect  []string
+	}{
+		{Command: "", Expect: nil},
+		{Command: "run", Expect: []string{"run"}},
+		{Command: cmdCreateCluster, Expect: []string{"create", "cluster"}},
+		{Command: "[-c,'/usr/local/bin/charon dkg && sleep 2']", Expect: []string{"-c", "/usr/local/bin/charon dkg && sleep 2"}},
+		{Command: "No charon commands needed", Expect: []string{"No", "charon", "commands", "needed"}},
+		{
+			Command: "|\n      validator-client\n      --beacon-node-api-endpoint=\"http://node0:3600\"\n      --network=auto",
+			Expect:  []string{"validator-client", "--beacon-node-api-endpoint=http://node0:3600", "--network=auto"},
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.Expect, commandArgs(test.Command), test.Command)
+	}
+}
+
 func TestParseTemplate(t *testing.T) {
 	_, err := template.New("").Parse(string(tmpl))
 	require.NoError(t, err)
 
+	_, err = template.New("").Funcs(template.FuncMap{"json": toJSON}).Parse(string(k8sTmpl))
+	require.NoError(t, err)
+
 	_, err = getVC(VCTeku, 0, 1, false, true)
 	require.NoError(t, err)
 }
@@ -2,13 +2,19 @@
 
 package compose
 
-import "time"
+import (
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
 
 const (
 	version           = "obol/charon/compose/1.0.0"
 	configFile        = "config.json"
 	defaultImageTag   = "latest"
 	defaultBeaconNode = "mock"
+	defaultNetwork    = "goerli"
 	defaultKeyGen     = KeyGenCreate
 	defaultNumVals    = 1
 	defaultNumNodes   = 4
@@ -40,8 +46,14 @@ const (
 	VCLighthouse VCType = "lighthouse"
 	VCVouch      VCType = "vouch"
 	VCLodestar   VCType = "lodestar"
+	VCNimbus     VCType = "nimbus"
 )
 
+// VCTypes returns all supported validator client types.
+func VCTypes() []VCType {
+	return []VCType{VCMock, VCTeku, VCLighthouse, VCVouch, VCLodestar, VCNimbus}
+}
+
 // KeyGen defines a key generation process.
 type KeyGen string
 
@@ -91,10 +103,22 @@ type Config struct {
 	// BeaconNodes url endpoint or "mock" for simnet.
 	BeaconNodes string `json:"beacon_nodes"`
 
+	// Network is the name of the supported Ethereum network to create the cluster for, ignored if TestnetChainSpec is set.
+	Network string `json:"network"`
+
+	// TestnetChainSpec is the path, relative to the compose dir, of a custom test network's chain spec file (config.yaml),
+	// e.g. of a Kurtosis devnet. Empty uses Network.
+	TestnetChainSpec string `json:"testnet_chain_spec"`
+
+	// HostGateway makes the docker host reachable from the charon nodes as host.docker.internal,
+	// required for external beacon nodes only published on the host, e.g. by Kurtosis.
+	HostGateway bool `json:"host_gateway"`
+
 	// ExternalRelay HTTP url endpoint or empty to disable.
 	ExternalRelay string `json:"external_relay"`
 
-	// VCs define the types of validator clients to use.
+	// VCs define the types of validator clients to use. Node i uses VCs[i%len(VCs)],
+	// so a cluster mixing validator clients per node is defined by specifying one type per node.
 	VCs []VCType `json:"validator_clients"`
 
 	// FeatureSet defines the minimum feature set to enable.
@@ -113,17 +137,46 @@ type Config struct {
 	// BeaconFuzz configures simnet beaconmock to return fuzzed responses.
 	BeaconFuzz bool `json:"beacon-fuzz"`
 
+	// BeaconMockScenario is the path, relative to the compose dir, of a simnet beacon mock scenario file scripting
+	// reorgs, delayed blocks, late attestation data and slashed or exited validators. Empty disables scenarios.
+	BeaconMockScenario string `json:"beacon_mock_scenario"`
+
 	// P2PFuzz configures charon p2p network to send and receive fuzzed messages.
 	P2PFuzz bool `json:"p2p-fuzz"`
 
 	// SyntheticBlockProposals configures use of synthetic block proposals in simnet cluster.
 	SyntheticBlockProposals bool `json:"synthetic_block_proposals"`
 
-	// Monitoring enables monitoring stack for the compose cluster. It includes grafana, loki and jaeger services.
+	// Monitoring enables the observability stack for the compose cluster. It includes grafana, with pre-provisioned
+	// charon dashboards, loki and jaeger services, with the charon nodes exporting logs and traces to them.
+	// Prometheus is always included since alerts are polled from it.
 	Monitoring bool `json:"monitoring"`
 
 	// BuilderAPI enables the builder API for the compose cluster.
 	BuilderAPI bool `json:"builder_api"`
+
+	// NodeFaults are the fault profiles injected into nodes during the run step by node index.
+	NodeFaults map[int]FaultProfile `json:"node_faults"`
+
+	// NodeResources are the CPU, memory and IO constraints of nodes by node index, e.g. to simulate constrained hardware.
+	NodeResources map[int]Resources `json:"node_resources"`
+
+	// ImageLockFile is the path, relative to the compose dir if not absolute, of the image lock file pinning
+	// upstream images to digests. Empty disables pinning.
+	ImageLockFile string `json:"image_lock_file"`
+
+	// ProjectName is the compose project name, empty defaults to the compose dir name.
+	// Concurrent clusters require distinct project names to isolate their containers and networks.
+	ProjectName string `json:"project_name"`
+
+	// PortOffset is added to all host ports, concurrent clusters require distinct offsets.
+	PortOffset int `json:"port_offset"`
+
+	// Remote configures running the cluster against a remote docker daemon, nil uses the local docker daemon.
+	Remote *RemoteConfig `json:"remote"`
+
+	// Engine is the compose engine to use: docker, docker-compose or podman-compose. Empty detects the first available.
+	Engine string `json:"engine"`
 }
 
 // VCStrings returns the VCs field as a slice of strings.
@@ -136,6 +189,42 @@ func (c Config) VCStrings() []string {
 	return resp
 }
 
+// NodeVC returns the validator client type of the node.
+func (c Config) NodeVC(node int) VCType {
+	return c.VCs[node%len(c.VCs)]
+}
+
+// validateVCs returns an error if no or unsupported validator client types are configured.
+func (c Config) validateVCs() error {
+	if len(c.VCs) == 0 {
+		return errors.New("no validator client types configured")
+	}
+
+	for _, vc := range c.VCs {
+		var ok bool
+		for _, supported := range VCTypes() {
+			ok = ok || vc == supported
+		}
+		if !ok {
+			return errors.New("unsupported validator client type", z.Str("type", string(vc)))
+		}
+	}
+
+	return nil
+}
+
+// validatePorts returns an error if the port offset results in invalid host ports.
+func (c Config) validatePorts() error {
+	const maxPort = 65535
+
+	maxOffset := maxPort - charonPorts[len(charonPorts)-1].External - 10000*(c.NumNodes-1)
+	if c.PortOffset < 0 || c.PortOffset > maxOffset {
+		return errors.New("invalid port offset", z.Int("offset", c.PortOffset), z.Int("max", maxOffset))
+	}
+
+	return nil
+}
+
 // NewDefaultConfig returns a new default config.
 func NewDefaultConfig() Config {
 	return Config{
@@ -147,6 +236,7 @@ func NewDefaultConfig() Config {
 		VCs:                     []VCType{VCTeku, VCLighthouse, VCMock},
 		KeyGen:                  defaultKeyGen,
 		BeaconNodes:             defaultBeaconNode,
+		Network:                 defaultNetwork,
 		Step:                    stepNew,
 		FeatureSet:              defaultFeatureSet,
 		SlotDuration:            time.Second,
@@ -8,7 +8,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
 	"strconv"
@@ -20,7 +19,6 @@ import (
 	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
-	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/enr"
 )
 
@@ -81,18 +79,29 @@ func Define(ctx context.Context, dir string, conf Config) (TmplData, error) {
 		return TmplData{}, errors.New("compose config not new, so can't be defined", z.Any("step", conf.Step))
 	}
 
-	if conf.BuildLocal {
-		if err := BuildLocal(ctx); err != nil {
+	if conf.BuildLocal || (!noPull && conf.ImageTag == "latest") {
+		engine, err := newConfEngine(ctx, conf)
+		if err != nil {
 			return TmplData{}, err
 		}
-	}
 
-	if !noPull && !conf.BuildLocal && conf.ImageTag == "latest" {
-		if err := pullLatest(ctx); err != nil {
+		if conf.BuildLocal {
+			if err := BuildLocal(ctx, engine); err != nil {
+				return TmplData{}, err
+			}
+		} else if err := pullLatest(ctx, engine); err != nil {
 			return TmplData{}, err
 		}
 	}
 
+	if err := validateNetwork(dir, conf); err != nil {
+		return TmplData{}, err
+	}
+
+	if err := validateBeaconMockScenario(dir, conf); err != nil {
+		return TmplData{}, err
+	}
+
 	if conf.SplitKeysDir != "" {
 		if err := validateSplitKeysDir(dir, conf.SplitKeysDir); err != nil {
 			return TmplData{}, err
@@ -126,7 +135,7 @@ func Define(ctx context.Context, dir string, conf Config) (TmplData, error) {
 			enrs = append(enrs, record.String())
 		}
 
-		n := TmplNode{EnvVars: []kv{
+		n := TmplNode{EnvVars: append([]kv{
 			{"name", "compose"},
 			{"num_validators", strconv.Itoa(conf.NumValidators)},
 			{"operator_enrs", strings.Join(enrs, ",")},
@@ -135,14 +144,16 @@ func Define(ctx context.Context, dir string, conf Config) (TmplData, error) {
 			{"fee-recipient_addresses", zeroAddress},
 			{"dkg_algorithm", "frost"},
 			{"output_dir", "/compose"},
-			{"network", eth2util.Goerli.Name},
-		}}
+		}, networkEnvs(conf)...)}
 
 		data = TmplData{
 			ComposeDir:     dir,
 			CharonImageTag: conf.ImageTag,
 			CharonCommand:  cmdCreateDKG,
 			Nodes:          []TmplNode{n},
+			HostGateway:    conf.HostGateway,
+			ProjectName:    conf.ProjectName,
+			RemoteDir:      conf.remoteDir(),
 		}
 	} else {
 		// Other keygens only need a noop docker compose, since charon-compose.yml
@@ -154,6 +165,8 @@ func Define(ctx context.Context, dir string, conf Config) (TmplData, error) {
 			CharonEntrypoint: "echo",
 			CharonCommand:    fmt.Sprintf("No charon commands needed for keygen=%s define step", conf.KeyGen),
 			Nodes:            []TmplNode{{}},
+			ProjectName:      conf.ProjectName,
+			RemoteDir:        conf.remoteDir(),
 		}
 	}
 
@@ -214,10 +227,10 @@ func getRelSplitKeysDir(dir, splitKeysDir string) (string, error) {
 }
 
 // pullLatest pulls the latest charon docker image.
-func pullLatest(ctx context.Context) error {
+func pullLatest(ctx context.Context, engine Engine) error {
 	log.Info(ctx, "Pulling latest charon docker image")
 
-	cmd := exec.CommandContext(ctx, "docker", "pull", charonImage+":latest")
+	cmd := engine.ContainerCmd(ctx, "pull", charonImage+":latest")
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -229,7 +242,7 @@ func pullLatest(ctx context.Context) error {
 }
 
 // BuildLocal builds an `obolnetwork/charon:local` docker container from source. Note this requires CHARON_REPO env var.
-func BuildLocal(ctx context.Context) error {
+func BuildLocal(ctx context.Context, engine Engine) error {
 	repo, ok := os.LookupEnv("CHARON_REPO")
 	if !ok || repo == "" {
 		return errors.New("cannot build local charon binary; CHARON_REPO env var, the path to the charon repo, is not set")
@@ -238,7 +251,7 @@ func BuildLocal(ctx context.Context) error {
 	log.Info(ctx, "Building `obolnetwork/charon:local` docker container", z.Str("repo", repo))
 
 	var out bytes.Buffer // Only log output if there is an error.
-	cmd := exec.CommandContext(ctx, "docker", "build", "-t", "obolnetwork/charon:local", ".")
+	cmd := engine.ContainerCmd(ctx, "build", "-t", "obolnetwork/charon:local", ".")
 	cmd.Stdout = &out
 	cmd.Stderr = &out
 	cmd.Dir = repo
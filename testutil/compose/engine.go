@@ -0,0 +1,125 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// EngineDocker is the docker compose v2 plugin, i.e. `docker compose`.
+	EngineDocker = "docker"
+	// EngineDockerCompose is the legacy standalone `docker-compose` binary.
+	EngineDockerCompose = "docker-compose"
+	// EnginePodman is the `podman-compose` binary using podman as container engine.
+	EnginePodman = "podman-compose"
+)
+
+// Engine executes compose and container commands of a container engine.
+type Engine interface {
+	// Name returns the name of the engine.
+	Name() string
+	// ComposeCmd returns a command executing the compose subcommand and args in the compose directory.
+	ComposeCmd(ctx context.Context, dir string, args ...string) *exec.Cmd
+	// ContainerCmd returns a command executing the container engine subcommand and args, e.g. "pull" or "build".
+	ContainerCmd(ctx context.Context, args ...string) *exec.Cmd
+}
+
+// cliEngine is an Engine executing CLI binaries.
+type cliEngine struct {
+	name      string
+	compose   []string // Compose binary and leading args.
+	container string   // Container engine binary.
+}
+
+func (e cliEngine) Name() string {
+	return e.name
+}
+
+func (e cliEngine) ComposeCmd(ctx context.Context, dir string, args ...string) *exec.Cmd {
+	args = append(append([]string(nil), e.compose[1:]...), args...)
+
+	cmd := exec.CommandContext(ctx, e.compose[0], args...)
+	cmd.Dir = dir
+
+	return cmd
+}
+
+func (e cliEngine) ContainerCmd(ctx context.Context, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, e.container, args...)
+}
+
+// engines are the supported engines in order of detection preference.
+var engines = []cliEngine{
+	{name: EngineDocker, compose: []string{"docker", "compose"}, container: "docker"},
+	{name: EngineDockerCompose, compose: []string{"docker-compose"}, container: "docker"},
+	{name: EnginePodman, compose: []string{"podman-compose"}, container: "podman"},
+}
+
+// EngineNames returns the names of the supported engines.
+func EngineNames() []string {
+	var resp []string
+	for _, e := range engines {
+		resp = append(resp, e.name)
+	}
+
+	return resp
+}
+
+// NewEngine returns the named engine or detects the first available engine if name is empty.
+func NewEngine(ctx context.Context, name string) (Engine, error) {
+	if name != "" {
+		for _, e := range engines {
+			if e.name == name {
+				return e, nil
+			}
+		}
+
+		return nil, errors.New("unknown compose engine", z.Str("engine", name), z.Str("supported", strings.Join(EngineNames(), ", ")))
+	}
+
+	e, err := detectEngine(ctx, probeEngine)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug(ctx, "Detected compose engine", z.Str("engine", e.Name()))
+
+	return e, nil
+}
+
+// detectEngine returns the first engine for which the probe succeeds.
+func detectEngine(ctx context.Context, probe func(context.Context, cliEngine) error) (Engine, error) {
+	var errs []string
+	for _, e := range engines {
+		err := probe(ctx, e)
+		if err == nil {
+			return e, nil
+		}
+
+		errs = append(errs, e.name+": "+err.Error())
+	}
+
+	return nil, errors.New("no compose engine found, install the docker compose plugin, docker-compose or podman-compose",
+		z.Str("errors", strings.Join(errs, "; ")))
+}
+
+// probeEngine returns an error if the engine's compose command isn't installed or doesn't execute.
+func probeEngine(ctx context.Context, e cliEngine) error {
+	if _, err := exec.LookPath(e.compose[0]); err != nil {
+		return errors.Wrap(err, "lookup binary")
+	}
+
+	out, err := e.ComposeCmd(ctx, "", "version").CombinedOutput()
+	if err != nil {
+		return errors.Wrap(err, "exec compose version", z.Str("output", strings.TrimSpace(string(out))))
+	}
+
+	return nil
+}
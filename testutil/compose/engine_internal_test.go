@@ -0,0 +1,79 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+func TestDetectEngine(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		installed map[string]bool
+		expect    string
+	}{
+		{
+			name:      "prefer docker compose plugin",
+			installed: map[string]bool{EngineDocker: true, EngineDockerCompose: true, EnginePodman: true},
+			expect:    EngineDocker,
+		},
+		{
+			name:      "legacy docker-compose",
+			installed: map[string]bool{EngineDockerCompose: true, EnginePodman: true},
+			expect:    EngineDockerCompose,
+		},
+		{
+			name:      "podman-compose",
+			installed: map[string]bool{EnginePodman: true},
+			expect:    EnginePodman,
+		},
+		{
+			name: "none",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			engine, err := detectEngine(ctx, func(_ context.Context, e cliEngine) error {
+				if !test.installed[e.name] {
+					return errors.New("not installed")
+				}
+
+				return nil
+			})
+			if test.expect == "" {
+				require.ErrorContains(t, err, "no compose engine found")
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expect, engine.Name())
+		})
+	}
+}
+
+func TestNewEngine(t *testing.T) {
+	ctx := context.Background()
+
+	engine, err := NewEngine(ctx, EngineDocker)
+	require.NoError(t, err)
+
+	cmd := engine.ComposeCmd(ctx, "/tmp/compose", "up", "--quiet-pull")
+	require.Equal(t, []string{"docker", "compose", "up", "--quiet-pull"}, cmd.Args)
+	require.Equal(t, "/tmp/compose", cmd.Dir)
+
+	engine, err = NewEngine(ctx, EnginePodman)
+	require.NoError(t, err)
+	require.Equal(t, []string{"podman-compose", "down"}, engine.ComposeCmd(ctx, "", "down").Args)
+	require.Equal(t, []string{"podman", "pull", "image"}, engine.ContainerCmd(ctx, "pull", "image").Args)
+
+	_, err = NewEngine(ctx, "nerdctl")
+	require.ErrorContains(t, err, "unknown compose engine")
+}
@@ -0,0 +1,121 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// FaultProfile defines the faults injected into a node during the run step.
+type FaultProfile struct {
+	// OfflineAfterSlots is the number of slots the node runs before going offline, if OfflineSlots is positive.
+	OfflineAfterSlots int `json:"offline_after_slots,omitempty"`
+
+	// OfflineSlots is the number of slots the node is offline before it is restarted. Zero disables going offline.
+	OfflineSlots int `json:"offline_slots,omitempty"`
+
+	// Byzantine makes the node send fuzzed p2p messages, including consensus messages, to its peers.
+	Byzantine bool `json:"byzantine,omitempty"`
+
+	// SlowDisk limits the read and write throughput of the node's disk.
+	SlowDisk *SlowDisk `json:"slow_disk,omitempty"`
+
+	// ClockSkew offsets the clock of the node's validator client via libfaketime, e.g. "+2s" or "-1m".
+	// Only validator clients using libc for time are supported; lighthouse, lodestar and nimbus.
+	// Charon itself reads the clock via the Go runtime's vDSO calls which libfaketime can't intercept.
+	ClockSkew string `json:"clock_skew,omitempty"`
+}
+
+// SlowDisk limits the throughput of a block device via docker blkio config.
+type SlowDisk struct {
+	// Device is the host block device backing the compose dir, e.g. "/dev/sda".
+	Device string `json:"device"`
+	// Rate is the maximum read and write rate, e.g. "1mb".
+	Rate string `json:"rate"`
+}
+
+// clockSkewRegex matches libfaketime relative offsets.
+var clockSkewRegex = regexp.MustCompile(`^[+-]\d+(\.\d+)?[smhd]?$`)
+
+// clockSkewVCs are the validator client types supporting clock skew.
+var clockSkewVCs = map[VCType]bool{VCLighthouse: true, VCLodestar: true, VCNimbus: true}
+
+// validateFaults returns an error if the node fault profiles are invalid.
+func validateFaults(conf Config) error {
+	for node, profile := range conf.NodeFaults {
+		if node < 0 || node >= conf.NumNodes {
+			return errors.New("fault profile node out of range", z.Int("node", node))
+		} else if profile.OfflineSlots < 0 || profile.OfflineAfterSlots < 0 {
+			return errors.New("negative offline slots", z.Int("node", node))
+		} else if profile.OfflineSlots > 0 && conf.SlotDuration <= 0 {
+			return errors.New("offline fault requires slot duration", z.Int("node", node))
+		} else if profile.SlowDisk != nil && (profile.SlowDisk.Device == "" || profile.SlowDisk.Rate == "") {
+			return errors.New("slow disk fault requires device and rate", z.Int("node", node))
+		}
+
+		if profile.ClockSkew == "" {
+			continue
+		}
+
+		if !clockSkewRegex.MatchString(profile.ClockSkew) {
+			return errors.New("invalid clock skew", z.Int("node", node), z.Str("skew", profile.ClockSkew))
+		} else if vc := conf.NodeVC(node); !clockSkewVCs[vc] {
+			return errors.New("clock skew not supported by validator client", z.Int("node", node), z.Str("vc", string(vc)))
+		}
+	}
+
+	return nil
+}
+
+// applyFaults wires the node fault profiles into the run step node and validator client template data.
+func applyFaults(conf Config, charonCmd string, nodes []TmplNode, vcs []TmplVC) {
+	var indexes []int
+	for node := range conf.NodeFaults {
+		indexes = append(indexes, node)
+	}
+	sort.Ints(indexes)
+
+	for _, i := range indexes {
+		profile := conf.NodeFaults[i]
+
+		cmd := charonCmd
+		if profile.Byzantine {
+			cmd = cmdUnsafeRun
+			nodes[i].EnvVars = append(nodes[i].EnvVars, kv{"p2p-fuzz", `"true"`})
+		}
+
+		if profile.OfflineSlots > 0 {
+			after := seconds(conf.SlotDuration * time.Duration(profile.OfflineAfterSlots))
+			offline := seconds(conf.SlotDuration * time.Duration(profile.OfflineSlots))
+			args := strings.Join(commandArgs(cmd), " ")
+
+			// Stop the node after the online period, keep it offline, then restart it.
+			nodes[i].Entrypoint = "sh"
+			nodes[i].Command = fmt.Sprintf("[-c,'timeout %s %s %s; sleep %s; exec %s %s']",
+				after, containerBinary, args, offline, containerBinary, args)
+		} else if cmd != charonCmd {
+			nodes[i].Command = cmd
+		}
+
+		if profile.SlowDisk != nil {
+			nodes[i].SlowDisk = profile.SlowDisk
+		}
+
+		if profile.ClockSkew != "" {
+			vcs[i].EnvVars = append(vcs[i].EnvVars, kv{"FAKETIME", fmt.Sprintf("%q", profile.ClockSkew)})
+		}
+	}
+}
+
+// seconds returns the duration in seconds as accepted by the sleep and timeout commands.
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
@@ -0,0 +1,66 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFaults(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Profile FaultProfile
+		Node    int
+		Err     string
+	}{
+		{
+			Name:    "valid",
+			Profile: FaultProfile{OfflineAfterSlots: 2, OfflineSlots: 3, Byzantine: true, ClockSkew: "-1.5s"},
+			Node:    1, // Lighthouse
+		},
+		{
+			Name:    "node out of range",
+			Profile: FaultProfile{Byzantine: true},
+			Node:    4,
+			Err:     "fault profile node out of range",
+		},
+		{
+			Name:    "negative slots",
+			Profile: FaultProfile{OfflineSlots: -1},
+			Err:     "negative offline slots",
+		},
+		{
+			Name:    "incomplete slow disk",
+			Profile: FaultProfile{SlowDisk: &SlowDisk{Device: "/dev/sda"}},
+			Err:     "slow disk fault requires device and rate",
+		},
+		{
+			Name:    "invalid clock skew",
+			Profile: FaultProfile{ClockSkew: "2 seconds"},
+			Node:    1,
+			Err:     "invalid clock skew",
+		},
+		{
+			Name:    "clock skew unsupported vc",
+			Profile: FaultProfile{ClockSkew: "+2s"},
+			Node:    0, // Teku
+			Err:     "clock skew not supported by validator client",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			conf := NewDefaultConfig()
+			conf.NodeFaults = map[int]FaultProfile{test.Node: test.Profile}
+
+			err := validateFaults(conf)
+			if test.Err != "" {
+				require.ErrorContains(t, err, test.Err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
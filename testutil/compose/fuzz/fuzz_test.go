@@ -78,7 +78,7 @@ func TestFuzzers(t *testing.T) {
 				autoConfig.LogFile = path.Join(*logDir, test.name+".log")
 			}
 
-			err := compose.Auto(context.Background(), autoConfig)
+			_, err := compose.Auto(context.Background(), autoConfig)
 			testutil.RequireNoError(t, err)
 		})
 	}
@@ -0,0 +1,160 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// StepHook is called around a compose step with the step name (define, lock or run) and the cluster handle.
+// Returning an error fails the auto run.
+type StepHook func(ctx context.Context, step string, cluster Cluster) error
+
+// Hooks are Go callbacks around the compose steps of an auto run, e.g. for custom assertions.
+type Hooks struct {
+	// PreStep is called before each step's docker compose up.
+	PreStep StepHook
+	// PostStep is called after each step's docker compose up completed. For the run step it is called
+	// after the cluster stopped but before it is torn down, so logs can still be inspected.
+	PostStep StepHook
+	// Running is called concurrently once the run step's cluster is started, with a context closed when the run
+	// step stops, e.g. for mid-run assertions. Returning an error stops the cluster and fails the auto run.
+	Running func(ctx context.Context, cluster Cluster) error
+}
+
+// Cluster is a handle of a compose cluster provided to hooks.
+type Cluster struct {
+	// Dir is the compose directory.
+	Dir string
+	// Engine is the compose engine.
+	Engine Engine
+	// Config is the compose config of the step.
+	Config Config
+	// Tmpl is the docker-compose.yml template data of the step.
+	Tmpl TmplData
+}
+
+// newCluster returns the cluster handle of the step template data with the current compose config.
+func newCluster(dir string, engine Engine, tmpl TmplData) (Cluster, error) {
+	conf, err := LoadConfig(dir)
+	if err != nil {
+		return Cluster{}, err
+	}
+
+	return Cluster{Dir: dir, Engine: engine, Config: conf, Tmpl: tmpl}, nil
+}
+
+// NodeAPI returns the validator API URL of the charon node reachable from the compose network, e.g. via Curl.
+func (Cluster) NodeAPI(node int) string {
+	return fmt.Sprintf("http://node%d:3600", node)
+}
+
+// NodeMonitoring returns the monitoring API URL of the charon node reachable from the compose network, e.g. via Curl.
+func (Cluster) NodeMonitoring(node int) string {
+	return fmt.Sprintf("http://node%d:3620", node)
+}
+
+// NodeHostURL returns the URL of the charon node's internal port published on the docker host, e.g. 3600 for the
+// validator API, using the remote docker host's hostname if configured. It returns an error if the port isn't published.
+func (c Cluster) NodeHostURL(node int, internal int) (string, error) {
+	if node < 0 || node >= len(c.Tmpl.Nodes) {
+		return "", errors.New("node out of range", z.Int("node", node))
+	}
+
+	for _, p := range c.Tmpl.Nodes[node].Ports {
+		if p.Internal == internal {
+			return fmt.Sprintf("http://%s:%d", hostname(c.Engine), p.External), nil
+		}
+	}
+
+	return "", errors.New("node port not published", z.Int("node", node), z.Int("port", internal))
+}
+
+// Exec executes the command in the running container of the compose service and returns its output.
+func (c Cluster) Exec(ctx context.Context, service string, args ...string) ([]byte, error) {
+	out, err := c.Engine.ComposeCmd(ctx, c.Dir, append([]string{"exec", "-T", service}, args...)...).CombinedOutput()
+	if err != nil {
+		return nil, errors.Wrap(err, "exec compose exec", z.Str("service", service), z.Str("out", string(out)))
+	}
+
+	return out, nil
+}
+
+// Curl returns the response of the URL requested from the compose network, only available in the run step.
+func (c Cluster) Curl(ctx context.Context, url string) ([]byte, error) {
+	return execCurl(ctx, c.Engine, c.Dir, url)
+}
+
+// Metrics returns the prometheus metrics of the charon node in text format, only available in the run step.
+func (c Cluster) Metrics(ctx context.Context, node int) (string, error) {
+	out, err := c.Curl(ctx, c.NodeMonitoring(node)+"/metrics")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Query returns the samples of the prometheus instant vector query of the compose prometheus service,
+// scraping all nodes, only available in the run step. Samples of node metrics have a "job" label of the node, e.g. "node0".
+func (c Cluster) Query(ctx context.Context, query string) ([]PromSample, error) {
+	out, err := execPromQuery(ctx, c.Engine, c.Dir, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePromSamples(out)
+}
+
+// startRunningHook starts the running hook, if not nil, in a goroutine and returns a channel on which its error
+// is sent once it returns, or nil if not set. The run is cancelled if the hook returns an error.
+func startRunningHook(ctx context.Context, cancelRun context.CancelFunc, hook func(context.Context, Cluster) error,
+	engine Engine, dir string, tmpl TmplData,
+) (<-chan error, error) {
+	resp := make(chan error, 1)
+	if hook == nil {
+		resp <- nil
+		return resp, nil
+	}
+
+	cluster, err := newCluster(dir, engine, tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		err := hook(ctx, cluster)
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			err = nil // Errors due to the run step stopping are expected.
+		} else if err != nil {
+			err = errors.Wrap(err, "running hook")
+			cancelRun() // Stop the cluster.
+		}
+		resp <- err
+	}()
+
+	return resp, nil
+}
+
+// callStepHook calls the step hook, if not nil, with a cluster handle of the step.
+func callStepHook(ctx context.Context, hook StepHook, step string, engine Engine, dir string, tmpl TmplData) error {
+	if hook == nil {
+		return nil
+	}
+
+	cluster, err := newCluster(dir, engine, tmpl)
+	if err != nil {
+		return err
+	}
+
+	if err := hook(ctx, step, cluster); err != nil {
+		return errors.Wrap(err, "step hook", z.Str("step", step))
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+func TestCluster(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	conf := NewDefaultConfig()
+	conf.PortOffset = 100
+	require.NoError(t, WriteConfig(dir, conf))
+
+	// The fake engine returns a prometheus response or echoes the exec'ed command.
+	const script = `case "$*" in
+		*api/v1/query*) echo '{"status":"success","data":{"result":[{"metric":{"job":"node0"},"value":[0,"3"]}]}}' ;;
+		*) shift 3; echo "$@" ;;
+	esac`
+	fakeEngine := cliEngine{name: "fake", compose: []string{"sh", "-c", script, "sh"}}
+
+	tmpl := TmplData{Nodes: []TmplNode{{Ports: []port{{External: 3700, Internal: 3600}}}}}
+	cluster, err := newCluster(dir, fakeEngine, tmpl)
+	require.NoError(t, err)
+	require.Equal(t, conf, cluster.Config)
+
+	url, err := cluster.NodeHostURL(0, 3600)
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:3700", url)
+
+	_, err = cluster.NodeHostURL(0, 3620)
+	require.ErrorContains(t, err, "node port not published")
+	_, err = cluster.NodeHostURL(1, 3600)
+	require.ErrorContains(t, err, "node out of range")
+
+	out, err := cluster.Exec(ctx, "node0", "ls", "/compose")
+	require.NoError(t, err)
+	require.Equal(t, "ls /compose\n", string(out))
+
+	metrics, err := cluster.Metrics(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, "curl -s http://node1:3620/metrics", metrics)
+
+	samples, err := cluster.Query(ctx, "sum by (job) (p2p_ping_success)")
+	require.NoError(t, err)
+	require.Equal(t, []PromSample{{Metric: map[string]string{"job": "node0"}, Value: 3}}, samples)
+}
+
+func TestRunningHook(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, WriteConfig(dir, NewDefaultConfig()))
+
+	t.Run("nil", func(t *testing.T) {
+		errCh, err := startRunningHook(context.Background(), func() {}, nil, nil, dir, TmplData{})
+		require.NoError(t, err)
+		require.NoError(t, <-errCh)
+	})
+
+	t.Run("error cancels run", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		hook := func(context.Context, Cluster) error {
+			return errors.New("assertion failed")
+		}
+
+		errCh, err := startRunningHook(ctx, cancel, hook, nil, dir, TmplData{})
+		require.NoError(t, err)
+		require.ErrorContains(t, <-errCh, "running hook: assertion failed")
+		require.Error(t, ctx.Err())
+	})
+
+	t.Run("run stopped", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		hook := func(ctx context.Context, _ Cluster) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		errCh, err := startRunningHook(ctx, cancel, hook, nil, dir, TmplData{})
+		require.NoError(t, err)
+		cancel()
+		require.NoError(t, <-errCh)
+	})
+}
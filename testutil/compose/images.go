@@ -0,0 +1,310 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// tekuImage is the teku validator client image.
+const tekuImage = "consensys/teku:latest"
+
+var (
+	// templateImageRegex matches the upstream images of the docker-compose.yml template.
+	templateImageRegex = regexp.MustCompile(`\$\.Image "([^"]+)"`)
+	// buildArgImageRegex matches the base image build args of the validator client Dockerfiles.
+	buildArgImageRegex = regexp.MustCompile(`(?m)^ARG (\w+_IMAGE)=(\S+)$`)
+	// envDefaultRegex matches env var interpolations with defaults, e.g. "${PROMETHEUS_VERSION:-v2.50.1}".
+	envDefaultRegex = regexp.MustCompile(`\$\{\w+:-([^}]*)\}`)
+	// digestRegex matches image digests.
+	digestRegex = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+)
+
+// ImageLock pins upstream images to digests, eliminating flakiness from upstream tag changes.
+type ImageLock struct {
+	// Images are the pinned image digests by upstream image reference, e.g. "prom/prometheus:v2.50.1".
+	Images map[string]string `json:"images"`
+}
+
+// pin returns the image reference pinned to its digest, or the reference if not pinned.
+// Env var interpolations are resolved to their defaults when looking up the digest.
+func (l ImageLock) pin(ref string) string {
+	digest, ok := l.Images[expandEnvDefaults(ref)]
+	if !ok {
+		return ref
+	}
+
+	return imageName(expandEnvDefaults(ref)) + "@" + digest
+}
+
+// UpstreamImages returns the references of all upstream images used by compose clusters; the validator client,
+// monitoring and tooling images as well as the validator client Dockerfiles' base images.
+func UpstreamImages() ([]string, error) {
+	var resp []string
+	for _, match := range templateImageRegex.FindAllStringSubmatch(string(tmpl), -1) {
+		resp = append(resp, expandEnvDefaults(match[1]))
+	}
+	resp = append(resp, tekuImage)
+
+	args, err := dockerfileImageArgs()
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		resp = append(resp, arg.Value)
+	}
+
+	sort.Strings(resp)
+
+	return resp, nil
+}
+
+// dockerfileImageArgs returns the base image build args of the validator client Dockerfiles by build context.
+func dockerfileImageArgs() (map[string]kv, error) {
+	dirs, err := static.ReadDir("static")
+	if err != nil {
+		return nil, errors.Wrap(err, "read static dirs")
+	}
+
+	resp := make(map[string]kv)
+	for _, dir := range dirs {
+		b, err := static.ReadFile(path.Join("static", dir.Name(), "Dockerfile"))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "read dockerfile")
+		}
+
+		for _, match := range buildArgImageRegex.FindAllStringSubmatch(string(b), -1) {
+			resp[dir.Name()+"/"+match[1]] = kv{Key: match[1], Value: match[2]}
+		}
+	}
+
+	return resp, nil
+}
+
+// pinnedBuildArgs returns the build args pinning the base images of the validator client build context.
+func pinnedBuildArgs(lock ImageLock, build string) ([]kv, error) {
+	args, err := dockerfileImageArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []kv
+	for key, arg := range args {
+		if !strings.HasPrefix(key, build+"/") {
+			continue
+		}
+
+		if pinned := lock.pin(arg.Value); pinned != arg.Value {
+			resp = append(resp, kv{Key: arg.Key, Value: pinned})
+		}
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Key < resp[j].Key
+	})
+
+	return resp, nil
+}
+
+// expandEnvDefaults returns the reference with env var interpolations replaced by their defaults.
+func expandEnvDefaults(ref string) string {
+	return envDefaultRegex.ReplaceAllString(ref, "$1")
+}
+
+// imageName returns the image reference without tag or digest, e.g. "prom/prometheus" of "prom/prometheus:v2.50.1".
+func imageName(ref string) string {
+	if i := strings.Index(ref, "@"); i >= 0 {
+		return ref[:i]
+	}
+
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[:i]
+	}
+
+	return ref
+}
+
+// imageLockPath returns the path of the image lock file, relative paths are relative to the compose dir.
+func imageLockPath(dir string, file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+
+	return path.Join(dir, file)
+}
+
+// loadImageLock returns the image lock of the compose config or an empty lock if not configured.
+func loadImageLock(dir string, conf Config) (ImageLock, error) {
+	if conf.ImageLockFile == "" {
+		return ImageLock{}, nil
+	}
+
+	b, err := os.ReadFile(imageLockPath(dir, conf.ImageLockFile))
+	if err != nil {
+		return ImageLock{}, errors.Wrap(err, "read image lock file")
+	}
+
+	var lock ImageLock
+	if err := json.Unmarshal(b, &lock); err != nil {
+		return ImageLock{}, errors.Wrap(err, "unmarshal image lock file")
+	}
+
+	if err := lock.validate(); err != nil {
+		return ImageLock{}, err
+	}
+
+	return lock, nil
+}
+
+// validate returns an error if any upstream image isn't pinned or a digest is invalid.
+func (l ImageLock) validate() error {
+	for ref, digest := range l.Images {
+		if !digestRegex.MatchString(digest) {
+			return errors.New("invalid pinned image digest", z.Str("image", ref), z.Str("digest", digest))
+		}
+	}
+
+	images, err := UpstreamImages()
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, image := range images {
+		if _, ok := l.Images[image]; !ok {
+			missing = append(missing, image)
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.New("upstream images not pinned in image lock file, update it with `compose pin`",
+			z.Any("images", missing))
+	}
+
+	return nil
+}
+
+// Pin resolves the digests of all upstream images by pulling them and writes them to the image lock file,
+// relative paths are relative to the compose dir.
+func Pin(ctx context.Context, dir string, file string) (ImageLock, error) {
+	conf, err := loadConfig(dir)
+	if err != nil {
+		return ImageLock{}, err
+	}
+
+	engine, err := newConfEngine(ctx, conf)
+	if err != nil {
+		return ImageLock{}, err
+	}
+
+	images, err := UpstreamImages()
+	if err != nil {
+		return ImageLock{}, err
+	}
+
+	lock := ImageLock{Images: make(map[string]string)}
+	for _, image := range images {
+		digest, err := resolveDigest(ctx, engine, image)
+		if err != nil {
+			return ImageLock{}, err
+		}
+
+		log.Info(ctx, "Pinned image", z.Str("image", image), z.Str("digest", digest))
+		lock.Images[image] = digest
+	}
+
+	b, err := json.MarshalIndent(lock, "", " ")
+	if err != nil {
+		return ImageLock{}, errors.Wrap(err, "marshal image lock")
+	}
+
+	//nolint:gosec // Image lock file doesn't contain secrets.
+	if err := os.WriteFile(imageLockPath(dir, file), append(b, '\n'), 0o644); err != nil {
+		return ImageLock{}, errors.Wrap(err, "write image lock file")
+	}
+
+	return lock, nil
+}
+
+// resolveDigest pulls the image and returns its repository digest.
+func resolveDigest(ctx context.Context, engine Engine, image string) (string, error) {
+	if out, err := engine.ContainerCmd(ctx, "pull", "--quiet", image).CombinedOutput(); err != nil {
+		return "", errors.Wrap(err, "pull image", z.Str("image", image), z.Str("output", string(out)))
+	}
+
+	out, err := engine.ContainerCmd(ctx, "image", "inspect", "--format", "{{json .RepoDigests}}", image).Output()
+	if err != nil {
+		return "", errors.Wrap(err, "inspect image", z.Str("image", image))
+	}
+
+	var repoDigests []string
+	if err := json.Unmarshal(out, &repoDigests); err != nil {
+		return "", errors.Wrap(err, "unmarshal repo digests", z.Str("out", string(out)))
+	}
+
+	for _, repoDigest := range repoDigests {
+		name, digest, ok := strings.Cut(repoDigest, "@")
+		if ok && strings.HasSuffix(name, imageName(image)) && digestRegex.MatchString(digest) {
+			return digest, nil
+		}
+	}
+
+	return "", errors.New("image repo digest not found", z.Str("image", image))
+}
+
+// Prefetch pulls all images pinned by the compose config's image lock file, verifying the pinned digests exist,
+// so runs don't depend on registry availability.
+func Prefetch(ctx context.Context, dir string) error {
+	conf, err := loadConfig(dir)
+	if err != nil {
+		return err
+	} else if conf.ImageLockFile == "" {
+		return errors.New("no image lock file configured, configure it with `compose new --image-lock-file`")
+	}
+
+	engine, err := newConfEngine(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	lock, err := loadImageLock(dir, conf)
+	if err != nil {
+		return err
+	}
+
+	return prefetch(ctx, engine, lock)
+}
+
+// prefetch pulls the pinned images.
+func prefetch(ctx context.Context, engine Engine, lock ImageLock) error {
+	var refs []string
+	for ref := range lock.Images {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	for _, ref := range refs {
+		pinned := lock.pin(ref)
+		log.Info(ctx, "Pulling pinned image", z.Str("image", pinned))
+
+		if out, err := engine.ContainerCmd(ctx, "pull", "--quiet", pinned).CombinedOutput(); err != nil {
+			return errors.Wrap(err, "pull pinned image, does the digest exist?", z.Str("image", pinned),
+				z.Str("output", string(out)))
+		}
+	}
+
+	return nil
+}
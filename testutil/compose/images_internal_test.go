@@ -0,0 +1,100 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestUpstreamImages(t *testing.T) {
+	images, err := UpstreamImages()
+	require.NoError(t, err)
+	testutil.RequireGoldenJSON(t, images)
+}
+
+func TestImageName(t *testing.T) {
+	require.Equal(t, "prom/prometheus", imageName("prom/prometheus:v2.50.1"))
+	require.Equal(t, "localhost:5000/vouch", imageName("localhost:5000/vouch:1.9.0"))
+	require.Equal(t, "localhost:5000/vouch", imageName("localhost:5000/vouch"))
+	require.Equal(t, "teku", imageName("teku@sha256:abc"))
+	require.Equal(t, "prom/prometheus:v2.50.1", expandEnvDefaults("prom/prometheus:${PROMETHEUS_VERSION:-v2.50.1}"))
+}
+
+// pinAll returns an image lock pinning all upstream images to fake digests.
+func pinAll(t *testing.T) ImageLock {
+	t.Helper()
+
+	images, err := UpstreamImages()
+	require.NoError(t, err)
+
+	lock := ImageLock{Images: make(map[string]string)}
+	for i, image := range images {
+		lock.Images[image] = fmt.Sprintf("sha256:%064x", i)
+	}
+
+	return lock
+}
+
+func TestImageLockValidate(t *testing.T) {
+	lock := pinAll(t)
+	require.NoError(t, lock.validate())
+
+	lock.Images[tekuImage] = "latest"
+	require.ErrorContains(t, lock.validate(), "invalid pinned image digest")
+
+	delete(lock.Images, tekuImage)
+	require.ErrorContains(t, lock.validate(), "upstream images not pinned")
+}
+
+func TestRunPinnedImages(t *testing.T) {
+	dir := t.TempDir()
+
+	b, err := json.Marshal(pinAll(t))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path.Join(dir, "images.lock.json"), b, 0o644))
+
+	conf := NewDefaultConfig()
+	conf.Step = stepLocked
+	conf.VCs = []VCType{VCTeku, VCNimbus, VCVouch}
+	conf.ImageLockFile = "images.lock.json"
+
+	_, err = Run(context.Background(), dir, conf)
+	require.NoError(t, err)
+
+	b, err = os.ReadFile(path.Join(dir, "docker-compose.yml"))
+	require.NoError(t, err)
+	b = bytes.ReplaceAll(b, []byte(dir), []byte("testdir"))
+	testutil.RequireGoldenBytes(t, b)
+}
+
+func TestResolveDigest(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+
+	// The fake container engine pulls successfully and returns the repo digests of the image.
+	script := path.Join(t.TempDir(), "docker")
+	content := `#!/bin/sh
+case "$1" in
+	pull) ;;
+	image) echo '["docker.io/prom/prometheus@` + digest + `"]' ;;
+esac`
+	require.NoError(t, os.WriteFile(script, []byte(content), 0o755))
+	fakeEngine := cliEngine{name: "fake", container: script}
+
+	resolved, err := resolveDigest(context.Background(), fakeEngine, "prom/prometheus:v2.50.1")
+	require.NoError(t, err)
+	require.Equal(t, digest, resolved)
+
+	_, err = resolveDigest(context.Background(), fakeEngine, "grafana/loki:2.8.2")
+	require.ErrorContains(t, err, "image repo digest not found")
+}
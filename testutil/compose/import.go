@@ -0,0 +1,181 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/enr"
+)
+
+// Import imports an existing cluster into a new compose config, so bugs reported from real clusters can be reproduced
+// locally with the exact same cluster parameters. The node dirs are the charon dirs of all the cluster's operators,
+// in any order, each containing the operator's charon-enr-private-key and validator_keys folder. The cluster lock file
+// defaults to the cluster-lock.json of the first node dir.
+//
+// The number of nodes, threshold, number of validators and network are set from the cluster lock, while other config,
+// e.g. the validator client types, is retained. The compose config is locked on success, ready for the run step.
+func Import(ctx context.Context, dir string, lockFile string, nodeDirs []string) error {
+	conf, err := loadConfig(dir)
+	if err != nil {
+		return err
+	} else if conf.Step != stepNew {
+		return errors.New("compose config not new, so can't import", z.Any("step", conf.Step))
+	} else if len(nodeDirs) == 0 {
+		return errors.New("no node dirs")
+	}
+
+	if lockFile == "" {
+		lockFile = path.Join(nodeDirs[0], "cluster-lock.json")
+	}
+
+	b, err := os.ReadFile(lockFile)
+	if err != nil {
+		return errors.Wrap(err, "read cluster lock")
+	}
+
+	var lock cluster.Lock
+	if err := json.Unmarshal(b, &lock); err != nil {
+		return errors.Wrap(err, "unmarshal cluster lock")
+	} else if err := lock.VerifyHashes(); err != nil {
+		return errors.Wrap(err, "verify cluster lock hashes")
+	} else if err := lock.VerifySignatures(); err != nil {
+		return errors.Wrap(err, "verify cluster lock signatures")
+	}
+
+	if conf.TestnetChainSpec == "" {
+		network, err := eth2util.ForkVersionToNetwork(lock.ForkVersion)
+		if err != nil {
+			return errors.Wrap(err, "unknown cluster lock network, configure it with `compose new --testnet-chain-spec`")
+		}
+		conf.Network = network
+	}
+
+	if err := validateNetwork(dir, conf); err != nil {
+		return err
+	}
+
+	conf.NumNodes = len(lock.Operators)
+	conf.Threshold = lock.Threshold
+	conf.NumValidators = len(lock.Validators)
+
+	nodes, err := operatorNodeDirs(lock, nodeDirs)
+	if err != nil {
+		return err
+	}
+
+	for i, nodeDir := range nodes {
+		if err := importNode(dir, i, nodeDir, b, conf.NumValidators); err != nil {
+			return errors.Wrap(err, "import node", z.Int("node", i), z.Str("node_dir", nodeDir))
+		}
+	}
+
+	if err := copyStaticFolders(dir); err != nil {
+		return err
+	}
+
+	conf.Step = stepLocked
+	if err := WriteConfig(dir, conf); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Imported cluster lock, run it with: compose run",
+		z.Str("name", lock.Name), z.Int("nodes", conf.NumNodes), z.Int("threshold", conf.Threshold),
+		z.Int("validators", conf.NumValidators), z.Str("network", conf.Network))
+
+	return nil
+}
+
+// operatorNodeDirs returns the node dirs ordered by the cluster lock operator index of their private keys.
+func operatorNodeDirs(lock cluster.Lock, nodeDirs []string) ([]string, error) {
+	resp := make([]string, len(lock.Operators))
+	for _, nodeDir := range nodeDirs {
+		key, err := k1util.Load(path.Join(nodeDir, "charon-enr-private-key"))
+		if err != nil {
+			return nil, errors.Wrap(err, "load charon-enr-private-key", z.Str("node_dir", nodeDir))
+		}
+
+		index := -1
+		for i, op := range lock.Operators {
+			record, err := enr.Parse(op.ENR)
+			if err != nil {
+				return nil, errors.Wrap(err, "parse operator enr", z.Int("operator", i))
+			}
+
+			if record.PubKey.IsEqual(key.PubKey()) {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 {
+			return nil, errors.New("charon-enr-private-key not a cluster lock operator", z.Str("node_dir", nodeDir))
+		} else if resp[index] != "" {
+			return nil, errors.New("duplicate operator node dirs", z.Str("node_dir", nodeDir), z.Str("duplicate", resp[index]))
+		}
+
+		resp[index] = nodeDir
+	}
+
+	for i, nodeDir := range resp {
+		if nodeDir == "" {
+			return nil, errors.New("node dir of cluster lock operator missing", z.Int("operator", i))
+		}
+	}
+
+	return resp, nil
+}
+
+// importNode copies the private key and validator keys of the node dir and the cluster lock to the compose node dir.
+func importNode(dir string, node int, nodeDir string, lock []byte, numValidators int) error {
+	keystores, err := filepath.Glob(path.Join(nodeDir, "validator_keys", "keystore-*.json"))
+	if err != nil {
+		return errors.Wrap(err, "glob keystores")
+	} else if len(keystores) != numValidators {
+		return errors.New("validator key shares don't match cluster lock validators",
+			z.Int("keystores", len(keystores)), z.Int("validators", numValidators))
+	}
+
+	files := []string{"charon-enr-private-key"}
+	for _, keystore := range keystores {
+		rel, err := filepath.Rel(nodeDir, keystore)
+		if err != nil {
+			return errors.Wrap(err, "relative keystore path")
+		}
+
+		password := rel[:len(rel)-len(filepath.Ext(rel))] + ".txt"
+		files = append(files, rel, password)
+	}
+
+	if err := os.MkdirAll(nodeFile(dir, node, "validator_keys"), 0o755); err != nil {
+		return errors.Wrap(err, "create node dir")
+	}
+
+	for _, file := range files {
+		b, err := os.ReadFile(path.Join(nodeDir, file))
+		if err != nil {
+			return errors.Wrap(err, "read node file")
+		}
+
+		if err := os.WriteFile(nodeFile(dir, node, file), b, 0o600); err != nil {
+			return errors.Wrap(err, "write node file")
+		}
+	}
+
+	//nolint:gosec // Cluster lock isn't secret.
+	if err := os.WriteFile(nodeFile(dir, node, "cluster-lock.json"), lock, 0o644); err != nil {
+		return errors.Wrap(err, "write cluster lock")
+	}
+
+	return nil
+}
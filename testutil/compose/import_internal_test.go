@@ -0,0 +1,125 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		numVals  = 2
+		numNodes = 4
+		thresh   = 3
+	)
+
+	lock, p2pKeys, shares := cluster.NewForT(t, numVals, thresh, numNodes, 1, rand.New(rand.NewSource(1)))
+	lockJSON, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	// Create the charon dirs of the operators.
+	srcDir := t.TempDir()
+	var nodeDirs []string
+	for i := range numNodes {
+		nodeDir := path.Join(srcDir, "operator", string(rune('a'+i)))
+		require.NoError(t, os.MkdirAll(path.Join(nodeDir, "validator_keys"), 0o755))
+		require.NoError(t, k1util.Save(p2pKeys[i], path.Join(nodeDir, "charon-enr-private-key")))
+		require.NoError(t, os.WriteFile(path.Join(nodeDir, "cluster-lock.json"), lockJSON, 0o644))
+
+		var secrets []tbls.PrivateKey
+		for _, valShares := range shares {
+			secrets = append(secrets, valShares[i])
+		}
+		require.NoError(t, keystore.StoreKeysInsecure(secrets, path.Join(nodeDir, "validator_keys"), keystore.ConfirmInsecureKeys))
+
+		nodeDirs = append(nodeDirs, nodeDir)
+	}
+
+	newDir := func(t *testing.T) string {
+		t.Helper()
+		dir := t.TempDir()
+		require.NoError(t, WriteConfig(dir, NewDefaultConfig()))
+
+		return dir
+	}
+
+	t.Run("import", func(t *testing.T) {
+		dir := newDir(t)
+
+		// Node dirs in any order.
+		reversed := []string{nodeDirs[3], nodeDirs[2], nodeDirs[1], nodeDirs[0]}
+		require.NoError(t, Import(ctx, dir, "", reversed))
+
+		conf, err := LoadConfig(dir)
+		require.NoError(t, err)
+		require.Equal(t, stepLocked, conf.Step)
+		require.Equal(t, numNodes, conf.NumNodes)
+		require.Equal(t, thresh, conf.Threshold)
+		require.Equal(t, numVals, conf.NumValidators)
+		require.Equal(t, "goerli", conf.Network)
+
+		for i := range numNodes {
+			key, err := k1util.Load(nodeFile(dir, i, "charon-enr-private-key"))
+			require.NoError(t, err)
+			require.True(t, key.PubKey().IsEqual(p2pKeys[i].PubKey()))
+
+			b, err := os.ReadFile(nodeFile(dir, i, "cluster-lock.json"))
+			require.NoError(t, err)
+			require.Equal(t, lockJSON, b)
+
+			keyFiles, err := keystore.LoadFilesUnordered(nodeFile(dir, i, "validator_keys"))
+			require.NoError(t, err)
+			require.Len(t, keyFiles, numVals)
+		}
+
+		// The imported cluster can be run.
+		_, err = Run(ctx, dir, conf)
+		require.NoError(t, err)
+	})
+
+	t.Run("missing operator", func(t *testing.T) {
+		err := Import(ctx, newDir(t), "", nodeDirs[:3])
+		require.ErrorContains(t, err, "node dir of cluster lock operator missing")
+	})
+
+	t.Run("duplicate operator", func(t *testing.T) {
+		err := Import(ctx, newDir(t), "", append(nodeDirs, nodeDirs[0]))
+		require.ErrorContains(t, err, "duplicate operator node dirs")
+	})
+
+	t.Run("unknown operator", func(t *testing.T) {
+		other, _, _ := cluster.NewForT(t, numVals, thresh, numNodes, 2, rand.New(rand.NewSource(2)))
+		otherJSON, err := json.Marshal(other)
+		require.NoError(t, err)
+
+		lockFile := path.Join(t.TempDir(), "cluster-lock.json")
+		require.NoError(t, os.WriteFile(lockFile, otherJSON, 0o644))
+
+		err = Import(ctx, newDir(t), lockFile, nodeDirs)
+		require.ErrorContains(t, err, "charon-enr-private-key not a cluster lock operator")
+	})
+
+	t.Run("not new", func(t *testing.T) {
+		dir := t.TempDir()
+		conf := NewDefaultConfig()
+		conf.Step = stepLocked
+		require.NoError(t, WriteConfig(dir, conf))
+
+		err := Import(ctx, dir, "", nodeDirs)
+		require.ErrorContains(t, err, "compose config not new")
+	})
+}
@@ -0,0 +1,258 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	_ "embed"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+//go:embed k8s.template
+var k8sTmpl []byte
+
+// k8sData is the k8s.yml template data.
+type k8sData struct {
+	ComposeDir string
+	// Jobs renders workloads as run-to-completion Jobs instead of StatefulSets, used for the define and lock steps.
+	Jobs      bool
+	Workloads []k8sWorkload
+}
+
+// k8sWorkload is a charon node, relay or validator client workload with a headless service and env config map.
+type k8sWorkload struct {
+	Name    string
+	Image   string
+	Command []string
+	Args    []string
+	Env     []kv
+	Ports   []k8sPort
+	// Limits constrains the workload's CPU and memory if not nil, IO weight isn't supported by Kubernetes.
+	Limits *Resources
+}
+
+// k8sPort is a named container and service port.
+type k8sPort struct {
+	Name string
+	Port int
+}
+
+// k8sNodePorts are the ports exposed by charon node services.
+var k8sNodePorts = []k8sPort{
+	{Name: "validator-api", Port: 3600},
+	{Name: "p2p-tcp", Port: 3610},
+	{Name: "monitoring", Port: 3620},
+}
+
+// WriteK8sManifests renders the template data as Kubernetes manifests and writes them to k8s.yml.
+// Charon nodes and validator clients are rendered as StatefulSets, or as Jobs if jobs is true,
+// each with a headless Service and a ConfigMap containing its environment variables.
+// The compose directory is mounted as a hostPath volume, so it must be available on the Kubernetes nodes.
+func WriteK8sManifests(dir string, data TmplData, jobs bool) error {
+	tpl, err := template.New("").Funcs(template.FuncMap{"json": toJSON}).Parse(string(k8sTmpl))
+	if err != nil {
+		return errors.Wrap(err, "new template")
+	}
+
+	// hostPath volumes require absolute paths.
+	data.ComposeDir, err = filepath.Abs(data.ComposeDir)
+	if err != nil {
+		return errors.Wrap(err, "abs compose dir")
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, newK8sData(data, jobs)); err != nil {
+		return errors.Wrap(err, "exec template")
+	}
+
+	err = os.WriteFile(path.Join(dir, "k8s.yml"), buf.Bytes(), 0o644) //nolint:gosec
+	if err != nil {
+		return errors.Wrap(err, "write k8s.yml")
+	}
+
+	return nil
+}
+
+// newK8sData returns the k8s template data of the docker compose template data.
+func newK8sData(data TmplData, jobs bool) k8sData {
+	resp := k8sData{
+		ComposeDir: data.ComposeDir,
+		Jobs:       jobs,
+	}
+
+	for i, node := range data.Nodes {
+		tag := data.CharonImageTag
+		if node.ImageTag != "" {
+			tag = node.ImageTag
+		}
+
+		entrypoint := data.CharonEntrypoint
+		if node.Entrypoint != "" {
+			entrypoint = node.Entrypoint
+		}
+
+		command := data.CharonCommand
+		if node.Command != "" {
+			command = node.Command
+		}
+
+		resp.Workloads = append(resp.Workloads, k8sWorkload{
+			Name:    fmt.Sprintf("node%d", i),
+			Image:   charonImage + ":" + tag,
+			Command: commandArgs(entrypoint),
+			Args:    commandArgs(command),
+			Env:     charonEnv(node.EnvVars),
+			Ports:   k8sNodePorts,
+			Limits:  k8sLimits(node.Resources),
+		})
+	}
+
+	if data.Relay {
+		resp.Workloads = append(resp.Workloads, k8sWorkload{
+			Name:  "relay",
+			Image: charonImage + ":" + data.CharonImageTag,
+			Args:  []string{"relay"},
+			Env: charonEnv([]kv{
+				{"http-address", "0.0.0.0:3640"},
+				{"monitoring-address", "0.0.0.0:3620"},
+				{"data-dir", "/compose/relay"},
+				{"p2p-relays", ""},
+				{"p2p-external-hostname", "relay"},
+				{"p2p-tcp-address", "0.0.0.0:3610"},
+				{"p2p-udp-address", "0.0.0.0:3630"},
+				{"p2p-advertise-private-addresses", "true"},
+			}),
+			Ports: []k8sPort{{Name: "p2p-tcp", Port: 3610}, {Name: "monitoring", Port: 3620}, {Name: "http", Port: 3640}},
+		})
+	}
+
+	for i, vc := range data.VCs {
+		if vc.Label == "" {
+			continue
+		}
+
+		image := vc.Image
+		if vc.Build != "" {
+			// Kubernetes can't build images, they must be built and loaded into the cluster beforehand.
+			image = "charon-compose-" + vc.Label + ":local"
+		}
+
+		resp.Workloads = append(resp.Workloads, k8sWorkload{
+			Name:  fmt.Sprintf("vc%d-%s", i, vc.Label),
+			Image: image,
+			Args:  commandArgs(vc.Command),
+			Env:   append([]kv{{"NODE", fmt.Sprintf("node%d", i)}}, unquoteValues(vc.EnvVars)...),
+		})
+	}
+
+	return resp
+}
+
+// k8sLimits returns the resources if they constrain CPU or memory, or nil otherwise.
+func k8sLimits(r *Resources) *Resources {
+	if r == nil || (r.CPUs == 0 && r.Memory == "") {
+		return nil
+	}
+
+	return r
+}
+
+// charonEnv returns the charon env vars of the key value pairs with unquoted values.
+func charonEnv(kvs []kv) []kv {
+	var resp []kv
+	for _, pair := range unquoteValues(kvs) {
+		resp = append(resp, kv{Key: "CHARON_" + pair.EnvKey(), Value: pair.Value})
+	}
+
+	return resp
+}
+
+// unquoteValues returns the key value pairs with unquoted values.
+func unquoteValues(kvs []kv) []kv {
+	var resp []kv
+	for _, pair := range kvs {
+		value := pair.Value
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+
+		resp = append(resp, kv{Key: pair.Key, Value: value})
+	}
+
+	return resp
+}
+
+// commandArgs returns the arguments of a docker compose command, which is either a flow sequence,
+// e.g. "[-c,'echo hello']", a block scalar, e.g. "|\n  run\n  --flag", or a shell-like string, e.g. "run --flag".
+func commandArgs(command string) []string {
+	command = strings.TrimSpace(command)
+	if strings.HasPrefix(command, "[") && strings.HasSuffix(command, "]") {
+		return splitWords(command[1:len(command)-1], ',')
+	}
+
+	return splitWords(strings.TrimPrefix(command, "|"), 0)
+}
+
+// splitWords splits s by the separator, or by whitespace if zero, ignoring separators within single or
+// double quotes which are removed.
+func splitWords(s string, sep rune) []string {
+	var (
+		resp   []string
+		word   strings.Builder
+		quote  rune
+		inWord bool
+	)
+
+	flush := func() {
+		if inWord {
+			resp = append(resp, word.String())
+		}
+		word.Reset()
+		inWord = false
+	}
+
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case (sep == 0 && unicode.IsSpace(r)) || (sep != 0 && r == sep):
+			flush()
+		case unicode.IsSpace(r) && !inWord:
+			// Skip leading whitespace of separated words.
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	flush()
+
+	return resp
+}
+
+// toJSON returns the JSON encoding of v, which is valid inline YAML.
+func toJSON(v any) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", errors.Wrap(err, "marshal json")
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,123 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+const (
+	// kurtosisDir is the compose directory folder the Kurtosis devnet's genesis data is downloaded to.
+	kurtosisDir = "kurtosis"
+	// kurtosisGenesisArtifact is the Kurtosis ethereum-package files artifact containing the consensus layer genesis data.
+	kurtosisGenesisArtifact = "el_cl_genesis_data"
+	// hostGatewayHost is the hostname resolving to the docker host from within containers with a host gateway.
+	hostGatewayHost = "host.docker.internal"
+)
+
+// KurtosisConfig defines the Kurtosis devnet to point a compose cluster at.
+type KurtosisConfig struct {
+	// Enclave is the name of the Kurtosis enclave running the ethereum-package devnet.
+	Enclave string
+	// BeaconService is the name of the Kurtosis consensus layer service the charon nodes connect to, e.g. "cl-1-lighthouse-geth".
+	BeaconService string
+}
+
+// Kurtosis configures a new compose config to run against a Kurtosis provisioned devnet instead of simnet.
+// It downloads the devnet's chain spec to the compose dir and sets the beacon node endpoint to the
+// consensus layer service's http port published on the docker host.
+func Kurtosis(ctx context.Context, dir string, kurtosisConf KurtosisConfig) (Config, error) {
+	conf, err := LoadConfig(dir)
+	if err != nil {
+		return Config{}, err
+	} else if conf.Step != stepNew {
+		return Config{}, errors.New("compose config not new, so can't target kurtosis", z.Any("step", conf.Step))
+	}
+
+	log.Info(ctx, "Downloading kurtosis genesis data", z.Str("enclave", kurtosisConf.Enclave))
+
+	if err := os.RemoveAll(path.Join(dir, kurtosisDir)); err != nil {
+		return Config{}, errors.Wrap(err, "remove kurtosis dir")
+	}
+
+	_, err = execKurtosis(ctx, "files", "download", kurtosisConf.Enclave, kurtosisGenesisArtifact, path.Join(dir, kurtosisDir))
+	if err != nil {
+		return Config{}, err
+	}
+
+	out, err := execKurtosis(ctx, "port", "print", kurtosisConf.Enclave, kurtosisConf.BeaconService, "http")
+	if err != nil {
+		return Config{}, err
+	}
+
+	beaconURL, err := containerURL(out)
+	if err != nil {
+		return Config{}, err
+	}
+
+	conf.TestnetChainSpec = path.Join(kurtosisDir, "config.yaml")
+	network, err := eth2util.LoadNetworkSpec(path.Join(dir, conf.TestnetChainSpec))
+	if err != nil {
+		return Config{}, err
+	}
+
+	conf.Network = network.Name
+	conf.BeaconNodes = beaconURL
+	conf.HostGateway = true
+
+	log.Info(ctx, "Configured compose cluster for kurtosis devnet",
+		z.Str("network", network.Name),
+		z.Str("beacon_node", beaconURL),
+		z.Str("deposit_contract", network.DepositContractAddress),
+	)
+
+	return conf, WriteConfig(dir, conf)
+}
+
+// execKurtosis executes the kurtosis CLI with the args and returns its trimmed stdout.
+func execKurtosis(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "kurtosis", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrap(err, "exec kurtosis", z.Any("args", args), z.Str("stderr", stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// containerURL returns the endpoint published on the docker host as reachable from containers via the host gateway.
+// Endpoints without a scheme default to http.
+func containerURL(endpoint string) (string, error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", errors.Wrap(err, "parse endpoint", z.Str("endpoint", endpoint))
+	} else if u.Port() == "" {
+		return "", errors.New("endpoint port missing", z.Str("endpoint", endpoint))
+	}
+
+	switch u.Hostname() {
+	case "127.0.0.1", "localhost", "0.0.0.0", "::1":
+		u.Host = net.JoinHostPort(hostGatewayHost, u.Port())
+	}
+
+	return u.String(), nil
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
-	"github.com/obolnetwork/charon/eth2util"
 )
 
 // Lock creates a docker-compose.yml from a charon-compose.yml for generating keys and a cluster lock file.
@@ -33,7 +32,7 @@ func Lock(ctx context.Context, dir string, conf Config) (TmplData, error) {
 		}
 
 		// Only single node to call charon create cluster generate keys
-		n := TmplNode{EnvVars: []kv{
+		envs := []kv{
 			{"name", fmt.Sprintf("compose-%d-%d", conf.NumNodes, conf.NumValidators)},
 			{"threshold", strconv.Itoa(conf.Threshold)},
 			{"nodes", strconv.Itoa(conf.NumNodes)},
@@ -44,14 +43,18 @@ func Lock(ctx context.Context, dir string, conf Config) (TmplData, error) {
 			{"insecure-keys", fmt.Sprintf(`"%v"`, conf.InsecureKeys)},
 			{"withdrawal-addresses", zeroAddress},
 			{"fee-recipient-addresses", zeroAddress},
-			{"network", eth2util.Goerli.Name},
-		}}
+		}
+		envs = append(envs, networkEnvs(conf)...)
+		n := TmplNode{EnvVars: append(envs, depositEnvs(conf)...)}
 
 		data = TmplData{
 			ComposeDir:     dir,
 			CharonImageTag: conf.ImageTag,
 			CharonCommand:  cmdCreateCluster,
 			Nodes:          []TmplNode{n},
+			HostGateway:    conf.HostGateway,
+			ProjectName:    conf.ProjectName,
+			RemoteDir:      conf.remoteDir(),
 		}
 	case KeyGenDKG:
 
@@ -71,6 +74,9 @@ func Lock(ctx context.Context, dir string, conf Config) (TmplData, error) {
 			CharonCommand:  "not used",
 			Relay:          true,
 			Nodes:          nodes,
+			HostGateway:    conf.HostGateway,
+			ProjectName:    conf.ProjectName,
+			RemoteDir:      conf.remoteDir(),
 		}
 	default:
 		return TmplData{}, errors.New("unsupported keygen", z.Any("keygen", conf.KeyGen))
@@ -119,19 +125,42 @@ func newNodeEnvs(index int, conf Config, vcType VCType) []kv {
 		{"feature-set", conf.FeatureSet},
 	}
 
+	if conf.TestnetChainSpec != "" {
+		kvs = append(kvs, kv{"testnet-chain-spec", path.Join("/compose", conf.TestnetChainSpec)})
+	}
+
 	if conf.Step == stepDefined {
 		// Define lock config
-		return append(kvs,
+		kvs = append(kvs,
 			kv{"data-dir", fmt.Sprintf("/compose/node%d", index)},
 			kv{"definition-file", "/compose/cluster-definition.json"},
 			kv{"insecure-keys", fmt.Sprintf(`"%v"`, conf.InsecureKeys)},
 		)
+
+		return append(kvs, depositEnvs(conf)...)
+	}
+
+	if conf.Monitoring {
+		// Export traces via OTLP and logs to the jaeger and loki services of the observability stack.
+		kvs = append(kvs,
+			kv{"otlp-service-name", fmt.Sprintf("node%d", index)},
+			kv{"otlp-address", "jaeger:4317"},
+			kv{"loki-addresses", "http://loki:3100/loki/api/v1/push"},
+			kv{"loki-service", fmt.Sprintf("node%d", index)},
+		)
+	}
+
+	if beaconMock && conf.BeaconMockScenario != "" {
+		kvs = append(kvs, kv{"simnet-beacon-mock-scenario-file", path.Join("/compose", conf.BeaconMockScenario)})
+	}
+
+	if vcType == VCMock {
+		// Write the validator mock duty report to the node dir, so runs can assert duty success rates.
+		kvs = append(kvs, kv{"simnet-validator-mock-report-file", fmt.Sprintf("/compose/node%d/vmock-report.json", index)})
 	}
 
 	// Define run config
 	return append(kvs,
-		kv{"jaeger-service", fmt.Sprintf("node%d", index)},
-		kv{"jaeger-address", "jaeger:6831"},
 		kv{"lock-file", lockFile},
 		kv{"validator-api-address", "0.0.0.0:3600"},
 		kv{"beacon-node-endpoints", beaconNode},
@@ -140,8 +169,6 @@ func newNodeEnvs(index int, conf Config, vcType VCType) []kv {
 		kv{"simnet-slot-duration", conf.SlotDuration.String()},
 		kv{"simnet-validator-keys-dir", fmt.Sprintf("/compose/node%d/validator_keys", index)},
 		kv{"simnet-beacon-mock-fuzz", fmt.Sprintf(`"%v"`, conf.BeaconFuzz)},
-		kv{"loki-addresses", "http://loki:3100/loki/api/v1/push"},
-		kv{"loki-service", fmt.Sprintf("node%d", index)},
 		kv{"synthetic-block-proposals", fmt.Sprintf(`"%v"`, conf.SyntheticBlockProposals)},
 		kv{"builder-api", fmt.Sprintf(`"%v"`, conf.BuilderAPI)},
 	)
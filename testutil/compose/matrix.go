@@ -0,0 +1,117 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// MatrixConfig configures a validator client matrix run, executing `compose auto` for combinations
+// of per-node validator client types.
+type MatrixConfig struct {
+	// Auto is the auto config used for each run, all runs share its compose directory.
+	Auto AutoConfig
+	// VCTypes are the validator client types to combine.
+	VCTypes []VCType
+	// MaxRuns limits the number of combinations run, zero runs all combinations.
+	MaxRuns int
+}
+
+// MatrixResult is the result of a validator client matrix run.
+type MatrixResult struct {
+	// VCs are the validator client types of the nodes.
+	VCs []VCType
+	// Results are the auto run results.
+	Results Results
+	// Err is the auto run error, nil if it succeeded.
+	Err error
+}
+
+// Matrix runs `compose auto` for each combination of validator client types over the nodes of the compose config
+// in the auto config's compose directory, which must not be defined yet. It returns the results of all runs and
+// an error if any run failed.
+func Matrix(ctx context.Context, conf MatrixConfig) ([]MatrixResult, error) {
+	ctx = log.WithTopic(ctx, "matrix")
+
+	composeConf, err := LoadConfig(conf.Auto.Dir)
+	if err != nil {
+		return nil, err
+	} else if composeConf.Step != stepNew {
+		return nil, errors.New("compose config not new, so can't run matrix", z.Any("step", composeConf.Step))
+	}
+
+	composeConf.VCs = conf.VCTypes
+	if err := composeConf.validateVCs(); err != nil {
+		return nil, err
+	}
+
+	combinations := VCMatrix(conf.VCTypes, composeConf.NumNodes)
+	if conf.MaxRuns > 0 && len(combinations) > conf.MaxRuns {
+		combinations = combinations[:conf.MaxRuns]
+	}
+
+	log.Info(ctx, "Running validator client matrix", z.Int("runs", len(combinations)))
+
+	var (
+		resp     []MatrixResult
+		failures []string
+	)
+	for i, vcs := range combinations {
+		log.Info(ctx, "Starting matrix run", z.Int("run", i), z.Any("validator_clients", vcs))
+
+		composeConf.VCs = vcs
+		var results Results
+		err := New(ctx, conf.Auto.Dir, composeConf)
+		if err == nil {
+			results, err = Auto(ctx, conf.Auto)
+		}
+
+		if ctx.Err() != nil {
+			return resp, ctx.Err()
+		} else if err != nil {
+			log.Warn(ctx, "Matrix run failed", err, z.Int("run", i), z.Any("validator_clients", vcs))
+			failures = append(failures, fmt.Sprint(vcs))
+		} else {
+			log.Info(ctx, "Matrix run succeeded", z.Int("run", i), z.Any("validator_clients", vcs))
+		}
+
+		resp = append(resp, MatrixResult{VCs: vcs, Results: results, Err: err})
+	}
+
+	if len(failures) > 0 {
+		return resp, errors.New("validator client matrix runs failed", z.Any("failures", failures))
+	}
+
+	return resp, nil
+}
+
+// VCMatrix returns all combinations, with repetition, of validator client types over the nodes.
+// Since nodes are interchangeable, combinations only differing in the order of types are only returned once.
+func VCMatrix(types []VCType, numNodes int) [][]VCType {
+	var (
+		resp    [][]VCType
+		combine func(start int, prefix []VCType)
+	)
+
+	combine = func(start int, prefix []VCType) {
+		if len(prefix) == numNodes {
+			resp = append(resp, append([]VCType(nil), prefix...))
+			return
+		}
+
+		for i := start; i < len(types); i++ {
+			combine(i, append(prefix, types[i]))
+		}
+	}
+
+	if numNodes > 0 {
+		combine(0, nil)
+	}
+
+	return resp
+}
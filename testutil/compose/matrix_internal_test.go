@@ -0,0 +1,49 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVCMatrix(t *testing.T) {
+	require.Empty(t, VCMatrix([]VCType{VCMock}, 0))
+	require.Equal(t, [][]VCType{{VCMock, VCMock}}, VCMatrix([]VCType{VCMock}, 2))
+
+	require.Equal(t, [][]VCType{
+		{VCMock, VCMock, VCMock},
+		{VCMock, VCMock, VCTeku},
+		{VCMock, VCTeku, VCTeku},
+		{VCTeku, VCTeku, VCTeku},
+	}, VCMatrix([]VCType{VCMock, VCTeku}, 3))
+
+	// Combinations with repetition of 6 types over 4 nodes: (6+4-1)! / (4! * (6-1)!).
+	matrix := VCMatrix(VCTypes(), 4)
+	require.Len(t, matrix, 126)
+
+	unique := make(map[string]bool)
+	for _, vcs := range matrix {
+		require.Len(t, vcs, 4)
+		unique[strings.Join(Config{VCs: vcs}.VCStrings(), ",")] = true
+	}
+	require.Len(t, unique, len(matrix))
+}
+
+func TestValidateVCs(t *testing.T) {
+	conf := NewDefaultConfig()
+	require.NoError(t, conf.validateVCs())
+
+	conf.VCs = VCTypes()
+	require.NoError(t, conf.validateVCs())
+	require.Equal(t, VCNimbus, conf.NodeVC(5))
+	require.Equal(t, VCMock, conf.NodeVC(6))
+
+	conf.VCs = nil
+	require.ErrorContains(t, conf.validateVCs(), "no validator client types configured")
+
+	conf.VCs = []VCType{VCTeku, "prysm"}
+	require.ErrorContains(t, conf.validateVCs(), "unsupported validator client type")
+}
@@ -0,0 +1,135 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+// depositsDir is the compose directory folder the cluster's deposit files are collected in.
+const depositsDir = "deposits"
+
+// externalBeacon returns true if the nodes connect to an external beacon node instead of the simnet beacon mock.
+func (c Config) externalBeacon() bool {
+	return c.BeaconNodes != defaultBeaconNode
+}
+
+// validateNetwork returns an error if the config's network or custom test network chain spec is invalid.
+func validateNetwork(dir string, conf Config) error {
+	if conf.TestnetChainSpec == "" {
+		if !eth2util.ValidNetwork(conf.Network) {
+			return errors.New("unsupported compose network", z.Str("network", conf.Network))
+		}
+
+		return nil
+	}
+
+	if filepath.IsAbs(conf.TestnetChainSpec) || strings.HasPrefix(filepath.Clean(conf.TestnetChainSpec), "..") {
+		return errors.New("testnet chain spec must be relative to the compose dir", z.Str("path", conf.TestnetChainSpec))
+	}
+
+	_, err := eth2util.LoadNetworkSpec(path.Join(dir, conf.TestnetChainSpec))
+
+	return err
+}
+
+// validateBeaconMockScenario returns an error if the config's simnet beacon mock scenario is invalid.
+func validateBeaconMockScenario(dir string, conf Config) error {
+	if conf.BeaconMockScenario == "" {
+		return nil
+	} else if conf.externalBeacon() {
+		return errors.New("beacon mock scenario requires the simnet beacon mock")
+	} else if filepath.IsAbs(conf.BeaconMockScenario) || strings.HasPrefix(filepath.Clean(conf.BeaconMockScenario), "..") {
+		return errors.New("beacon mock scenario must be relative to the compose dir", z.Str("path", conf.BeaconMockScenario))
+	}
+
+	_, err := beaconmock.LoadScenario(path.Join(dir, conf.BeaconMockScenario))
+
+	return err
+}
+
+// networkEnvs returns the env vars selecting the network of the charon create commands,
+// either a custom test network chain spec or a supported network name.
+func networkEnvs(conf Config) []kv {
+	if conf.TestnetChainSpec != "" {
+		return []kv{{"testnet-chain-spec", path.Join("/compose", conf.TestnetChainSpec)}}
+	}
+
+	return []kv{{"network", conf.Network}}
+}
+
+// depositEnvs returns the env vars of the charon keygen commands writing deposit files.
+// Batch deposit files are only written when targeting an external chain where deposits are actually submitted.
+func depositEnvs(conf Config) []kv {
+	if !conf.externalBeacon() {
+		return nil
+	}
+
+	return []kv{{"deposit-batch", `"true"`}}
+}
+
+// CollectDeposits copies the cluster's deposit data and batch deposit files created by the lock step
+// from node0 to the compose deposits folder, ready for submission to the network's deposit contract.
+// It returns the paths of the collected files.
+func CollectDeposits(ctx context.Context, dir string) ([]string, error) {
+	conf, err := LoadConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, pattern := range []string{"deposit-data*.json", "deposit-batch.*"} {
+		matches, err := filepath.Glob(nodeFile(dir, 0, pattern))
+		if err != nil {
+			return nil, errors.Wrap(err, "glob deposit files")
+		}
+		files = append(files, matches...)
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("no deposit files found, run the lock step first", z.Str("dir", nodeFile(dir, 0, "")))
+	}
+
+	if err := os.MkdirAll(path.Join(dir, depositsDir), 0o755); err != nil {
+		return nil, errors.Wrap(err, "create deposits dir")
+	}
+
+	var resp []string
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "read deposit file")
+		}
+
+		target := path.Join(dir, depositsDir, filepath.Base(file))
+		if err := os.WriteFile(target, b, 0o644); err != nil { //nolint:gosec // Deposit data is public.
+			return nil, errors.Wrap(err, "write deposit file")
+		}
+
+		resp = append(resp, target)
+	}
+
+	network, depositContract := conf.Network, ""
+	if conf.TestnetChainSpec != "" {
+		spec, err := eth2util.LoadNetworkSpec(path.Join(dir, conf.TestnetChainSpec))
+		if err != nil {
+			return nil, err
+		}
+		network, depositContract = spec.Name, spec.DepositContractAddress
+	}
+
+	log.Info(ctx, "Collected deposit files, submit them to the network's deposit contract to activate the validators",
+		z.Str("network", network), z.Str("deposit_contract", depositContract), z.Any("files", resp))
+
+	return resp, nil
+}
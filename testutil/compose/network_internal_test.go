@@ -0,0 +1,138 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testChainSpec = `CONFIG_NAME: kurtosis
+PRESET_BASE: mainnet
+GENESIS_FORK_VERSION: 0x10000038
+CAPELLA_FORK_VERSION: 0x40000038
+DEPOSIT_CHAIN_ID: 3151908
+DEPOSIT_CONTRACT_ADDRESS: 0x4242424242424242424242424242424242424242
+MIN_GENESIS_TIME: 1700000000
+GENESIS_DELAY: 60
+`
+
+func TestValidateNetwork(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(dir, kurtosisDir), 0o755))
+	require.NoError(t, os.WriteFile(path.Join(dir, kurtosisDir, "config.yaml"), []byte(testChainSpec), 0o644))
+
+	conf := NewDefaultConfig()
+	require.NoError(t, validateNetwork(dir, conf))
+
+	conf.Network = "hoodi"
+	require.NoError(t, validateNetwork(dir, conf))
+
+	conf.Network = "ropsten"
+	require.ErrorContains(t, validateNetwork(dir, conf), "unsupported compose network")
+
+	conf.TestnetChainSpec = "kurtosis/config.yaml"
+	require.NoError(t, validateNetwork(dir, conf))
+
+	conf.TestnetChainSpec = "kurtosis/missing.yaml"
+	require.ErrorContains(t, validateNetwork(dir, conf), "read chain spec file")
+
+	for _, spec := range []string{"../config.yaml", "/etc/config.yaml"} {
+		conf.TestnetChainSpec = spec
+		require.ErrorContains(t, validateNetwork(dir, conf), "testnet chain spec must be relative to the compose dir")
+	}
+}
+
+func TestValidateBeaconMockScenario(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(path.Join(dir, "scenario.json"),
+		[]byte(`{"period":32,"events":[{"type":"reorg","slot":8,"depth":2}]}`), 0o644))
+	require.NoError(t, os.WriteFile(path.Join(dir, "invalid.json"),
+		[]byte(`{"events":[{"type":"reorg","slot":8}]}`), 0o644))
+
+	conf := NewDefaultConfig()
+	require.NoError(t, validateBeaconMockScenario(dir, conf))
+
+	conf.BeaconMockScenario = "scenario.json"
+	require.NoError(t, validateBeaconMockScenario(dir, conf))
+
+	conf.BeaconMockScenario = "invalid.json"
+	require.ErrorContains(t, validateBeaconMockScenario(dir, conf), "scenario reorg depth must be positive")
+
+	conf.BeaconMockScenario = "../scenario.json"
+	require.ErrorContains(t, validateBeaconMockScenario(dir, conf), "must be relative to the compose dir")
+
+	conf.BeaconMockScenario = "scenario.json"
+	conf.BeaconNodes = "http://host.docker.internal:33001"
+	require.ErrorContains(t, validateBeaconMockScenario(dir, conf), "requires the simnet beacon mock")
+}
+
+func TestNetworkEnvs(t *testing.T) {
+	conf := NewDefaultConfig()
+	require.Equal(t, []kv{{"network", defaultNetwork}}, networkEnvs(conf))
+	require.Empty(t, depositEnvs(conf))
+
+	conf.TestnetChainSpec = "kurtosis/config.yaml"
+	conf.BeaconNodes = "http://host.docker.internal:33001"
+	require.Equal(t, []kv{{"testnet-chain-spec", "/compose/kurtosis/config.yaml"}}, networkEnvs(conf))
+	require.Equal(t, []kv{{"deposit-batch", `"true"`}}, depositEnvs(conf))
+}
+
+func TestCollectDeposits(t *testing.T) {
+	dir := t.TempDir()
+	conf := NewDefaultConfig()
+	conf.TestnetChainSpec = "kurtosis/config.yaml"
+	require.NoError(t, WriteConfig(dir, conf))
+	require.NoError(t, os.MkdirAll(path.Join(dir, kurtosisDir), 0o755))
+	require.NoError(t, os.WriteFile(path.Join(dir, kurtosisDir, "config.yaml"), []byte(testChainSpec), 0o644))
+
+	_, err := CollectDeposits(context.Background(), dir)
+	require.ErrorContains(t, err, "no deposit files found")
+
+	require.NoError(t, os.MkdirAll(nodeFile(dir, 0, ""), 0o755))
+	for _, name := range []string{"deposit-data.json", "deposit-data-1eth.json", "deposit-batch.json", "deposit-batch.csv", "cluster-lock.json"} {
+		require.NoError(t, os.WriteFile(nodeFile(dir, 0, name), []byte(name), 0o644))
+	}
+
+	files, err := CollectDeposits(context.Background(), dir)
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Base(file), string(b))
+		require.Equal(t, path.Join(dir, depositsDir), filepath.Dir(file))
+	}
+}
+
+func TestContainerURL(t *testing.T) {
+	tests := []struct {
+		Endpoint string
+		Expect   string
+		Err      string
+	}{
+		{Endpoint: "http://127.0.0.1:33001", Expect: "http://host.docker.internal:33001"},
+		{Endpoint: "127.0.0.1:33001", Expect: "http://host.docker.internal:33001"},
+		{Endpoint: "https://localhost:5052/", Expect: "https://host.docker.internal:5052/"},
+		{Endpoint: "http://beacon.example.com:5052", Expect: "http://beacon.example.com:5052"},
+		{Endpoint: "http://127.0.0.1", Err: "endpoint port missing"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Endpoint, func(t *testing.T) {
+			actual, err := containerURL(test.Endpoint)
+			if test.Err != "" {
+				require.ErrorContains(t, err, test.Err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.Expect, actual)
+		})
+	}
+}
@@ -18,6 +18,12 @@ func New(ctx context.Context, dir string, conf Config) error {
 
 	conf.Step = stepNew
 
+	if conf.Remote != nil {
+		if err := conf.Remote.validate(); err != nil {
+			return err
+		}
+	}
+
 	log.Info(ctx, "Writing config to compose dir",
 		z.Str("dir", dir),
 		z.Str("config", fmt.Sprintf("%#v", conf)),
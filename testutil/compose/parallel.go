@@ -0,0 +1,161 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// parallelPortStride is the host port offset between concurrent clusters.
+	parallelPortStride = 100
+	// parallelLogFile is the compose dir file docker compose output of concurrent clusters is written to.
+	parallelLogFile = "compose.log"
+)
+
+// ParallelConfig configures running multiple isolated compose clusters concurrently.
+type ParallelConfig struct {
+	// Auto is the auto config used for each cluster, its Dir and LogFile are overridden per cluster.
+	Auto AutoConfig
+	// Dirs are the compose directories of the clusters, each containing a new compose config.
+	Dirs []string
+	// MaxParallel limits the number of clusters running concurrently, zero runs all clusters concurrently.
+	MaxParallel int
+}
+
+// ParallelResult is the result of a cluster of a parallel run.
+type ParallelResult struct {
+	// Dir is the compose directory of the cluster.
+	Dir string
+	// ProjectName is the compose project name of the cluster.
+	ProjectName string
+	// Results are the auto run results.
+	Results Results
+	// Err is the auto run error, nil if it succeeded.
+	Err error
+}
+
+// Parallel runs `compose auto` concurrently for the new compose configs in the provided directories, e.g.
+// with different number of nodes, thresholds or validator clients. Each cluster is isolated by a distinct compose
+// project name, which also names its network, and distinct host ports. Docker compose output of each cluster is
+// written to compose.log in its compose directory. It returns the results of all clusters, in the order of
+// the directories, and an error if any cluster failed.
+func Parallel(ctx context.Context, conf ParallelConfig) ([]ParallelResult, error) {
+	ctx = log.WithTopic(ctx, "parallel")
+
+	if len(conf.Dirs) == 0 {
+		return nil, errors.New("no compose dirs")
+	}
+
+	resp := make([]ParallelResult, len(conf.Dirs))
+	dedup := make(map[string]bool)
+	for i, dir := range conf.Dirs {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, errors.Wrap(err, "absolute compose dir path")
+		} else if dedup[abs] {
+			return nil, errors.New("duplicate compose dir", z.Str("dir", dir))
+		}
+		dedup[abs] = true
+
+		composeConf, err := LoadConfig(dir)
+		if err != nil {
+			return nil, err
+		} else if composeConf.Step != stepNew {
+			return nil, errors.New("compose config not new, so can't run in parallel", z.Str("dir", dir), z.Any("step", composeConf.Step))
+		}
+
+		composeConf.ProjectName = projectName(dir, i)
+		composeConf.PortOffset = parallelPortStride * i
+		if err := composeConf.validatePorts(); err != nil {
+			return nil, errors.Wrap(err, "too many clusters for host ports", z.Str("dir", dir))
+		}
+
+		if err := WriteConfig(dir, composeConf); err != nil {
+			return nil, err
+		}
+
+		resp[i] = ParallelResult{Dir: dir, ProjectName: composeConf.ProjectName}
+	}
+
+	log.Info(ctx, "Running compose clusters in parallel", z.Int("clusters", len(conf.Dirs)))
+
+	var eg errgroup.Group
+	if conf.MaxParallel > 0 {
+		eg.SetLimit(conf.MaxParallel)
+	}
+
+	for i := range resp {
+		autoConf := conf.Auto
+		autoConf.Dir = resp[i].Dir
+		autoConf.LogFile = path.Join(resp[i].Dir, parallelLogFile)
+		if conf.Auto.ArtifactsDir != "" {
+			autoConf.ArtifactsDir = path.Join(conf.Auto.ArtifactsDir, resp[i].ProjectName)
+		}
+
+		eg.Go(func() error {
+			ctx := log.WithCtx(ctx, z.Str("project", resp[i].ProjectName))
+			log.Info(ctx, "Starting compose cluster", z.Str("dir", resp[i].Dir))
+
+			results, err := Auto(ctx, autoConf)
+			if err != nil {
+				log.Warn(ctx, "Compose cluster failed", err)
+			} else {
+				log.Info(ctx, "Compose cluster succeeded")
+			}
+
+			resp[i].Results = results
+			resp[i].Err = err
+
+			return nil // Don't abort other clusters.
+		})
+	}
+
+	_ = eg.Wait()
+
+	if ctx.Err() != nil {
+		return resp, ctx.Err()
+	}
+
+	var failures []string
+	for _, result := range resp {
+		if result.Err != nil {
+			failures = append(failures, result.Dir)
+		}
+	}
+
+	if len(failures) > 0 {
+		return resp, errors.New("parallel compose clusters failed", z.Any("failures", failures))
+	}
+
+	return resp, nil
+}
+
+// invalidProjectChars matches characters not allowed in compose project names.
+var invalidProjectChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// projectName returns a unique compose project name for the compose dir of the cluster index.
+func projectName(dir string, index int) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+
+	name := strings.Trim(invalidProjectChars.ReplaceAllString(strings.ToLower(filepath.Base(abs)), "-"), "-_")
+	if name == "" {
+		name = "compose"
+	}
+
+	return fmt.Sprintf("%s-%d", name, index)
+}
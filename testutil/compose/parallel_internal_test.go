@@ -0,0 +1,63 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectName(t *testing.T) {
+	require.Equal(t, "compose-0", projectName("/tmp/compose", 0))
+	require.Equal(t, "my-cluster_7-1", projectName("/tmp/My Cluster_7", 1))
+	require.Equal(t, "compose-2", projectName("/tmp/...", 2))
+}
+
+func TestValidatePorts(t *testing.T) {
+	conf := NewDefaultConfig()
+	require.NoError(t, conf.validatePorts())
+
+	conf.PortOffset = parallelPortStride * 10
+	require.NoError(t, conf.validatePorts())
+
+	conf.PortOffset = -1
+	require.ErrorContains(t, conf.validatePorts(), "invalid port offset")
+
+	conf.PortOffset = parallelPortStride * 20
+	conf.NumNodes = 7
+	require.ErrorContains(t, conf.validatePorts(), "invalid port offset")
+}
+
+func TestParallelValidation(t *testing.T) {
+	ctx := context.Background()
+
+	newDir := func(t *testing.T, step step) string {
+		t.Helper()
+
+		dir := t.TempDir()
+		conf := NewDefaultConfig()
+		conf.Step = step
+		require.NoError(t, WriteConfig(dir, conf))
+
+		return dir
+	}
+
+	t.Run("no dirs", func(t *testing.T) {
+		_, err := Parallel(ctx, ParallelConfig{})
+		require.ErrorContains(t, err, "no compose dirs")
+	})
+
+	t.Run("duplicate dirs", func(t *testing.T) {
+		dir := newDir(t, stepNew)
+		_, err := Parallel(ctx, ParallelConfig{Dirs: []string{dir, path.Join(dir, ".")}})
+		require.ErrorContains(t, err, "duplicate compose dir")
+	})
+
+	t.Run("not new", func(t *testing.T) {
+		_, err := Parallel(ctx, ParallelConfig{Dirs: []string{newDir(t, stepNew), newDir(t, stepLocked)}})
+		require.ErrorContains(t, err, "compose config not new")
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"text/template"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// prometheusTmpl is the prometheus.yml template scraping the charon nodes and relay.
+var prometheusTmpl = template.Must(template.New("").Parse(`global:
+  scrape_interval:     5s # Set the scrape interval to every 5 seconds. Default is every 1 minute.
+  evaluation_interval: 5s # Evaluate rules every 5 seconds. The default is every 1 minute.
+
+scrape_configs:
+{{- range .}}
+  - job_name: '{{.}}'
+    static_configs:
+      - targets: ['{{.}}:3620']
+{{- end}}
+
+rule_files:
+  - /etc/prometheus/rules.yml
+`))
+
+// writePrometheusConfig writes the prometheus.yml scraping the monitoring endpoints of all charon nodes and the relay.
+func writePrometheusConfig(dir string, numNodes int) error {
+	var jobs []string
+	for i := range numNodes {
+		jobs = append(jobs, fmt.Sprintf("node%d", i))
+	}
+	jobs = append(jobs, "relay")
+
+	var buf bytes.Buffer
+	if err := prometheusTmpl.Execute(&buf, jobs); err != nil {
+		return errors.Wrap(err, "exec prometheus template")
+	}
+
+	if err := os.MkdirAll(path.Join(dir, "prometheus"), 0o755); err != nil {
+		return errors.Wrap(err, "mkdir prometheus")
+	}
+
+	//nolint:gosec // Prometheus config doesn't contain secrets.
+	if err := os.WriteFile(path.Join(dir, "prometheus", "prometheus.yml"), buf.Bytes(), 0o644); err != nil {
+		return errors.Wrap(err, "write prometheus.yml")
+	}
+
+	return nil
+}
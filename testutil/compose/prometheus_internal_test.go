@@ -0,0 +1,22 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestWritePrometheusConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, writePrometheusConfig(dir, 3))
+
+	b, err := os.ReadFile(path.Join(dir, "prometheus", "prometheus.yml"))
+	require.NoError(t, err)
+	testutil.RequireGoldenBytes(t, b)
+}
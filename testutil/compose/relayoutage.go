@@ -0,0 +1,266 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// relayConnectionsQuery is the prometheus query returning the number of relay connections per node.
+	relayConnectionsQuery = `sum by (job) (p2p_relay_connections)`
+	// relayPollInterval is the period between connectivity queries while waiting for recovery, matching the scrape interval.
+	relayPollInterval = time.Second * 5
+)
+
+// RelayOutageConfig configures relay outage testing, stopping the relay on a schedule and asserting that
+// node connectivity degrades during each outage and recovers after the relay is restarted.
+type RelayOutageConfig struct {
+	// Outages is the number of relay outages.
+	Outages int
+	// Interval is the period the relay runs before each outage.
+	Interval time.Duration
+	// Duration is the time the relay is stopped during each outage, it must exceed the prometheus scrape interval.
+	Duration time.Duration
+	// RecoveryTimeout is the maximum time after restarting the relay for node connectivity to recover.
+	RecoveryTimeout time.Duration
+	// RequirePeerLoss requires nodes to lose peer connectivity during outages, i.e. for clusters whose nodes
+	// can only connect via the relay. Otherwise only the nodes' relay connections are required to drop.
+	RequirePeerLoss bool
+}
+
+// DefaultRelayOutageConfig returns the default relay outage config.
+func DefaultRelayOutageConfig() RelayOutageConfig {
+	return RelayOutageConfig{
+		Outages:         2,
+		Interval:        time.Minute,
+		Duration:        time.Second * 30,
+		RecoveryTimeout: time.Minute,
+	}
+}
+
+// validate returns an error if the relay outage config is invalid.
+func (c RelayOutageConfig) validate() error {
+	if c.Outages <= 0 {
+		return errors.New("relay outages must be positive")
+	} else if c.Interval <= 0 {
+		return errors.New("relay outage interval must be positive")
+	} else if c.Duration <= relayPollInterval {
+		return errors.New("relay outage duration must exceed the prometheus scrape interval", z.Any("scrape_interval", relayPollInterval))
+	} else if c.RecoveryTimeout <= relayPollInterval {
+		return errors.New("relay recovery timeout must exceed the prometheus scrape interval", z.Any("scrape_interval", relayPollInterval))
+	}
+
+	return nil
+}
+
+// NodeConnectivity is the connectivity of a node queried from prometheus.
+type NodeConnectivity struct {
+	Node string `json:"node"`
+	// Peers is the number of peers successfully pinged.
+	Peers float64 `json:"peers"`
+	// RelayConnections is the number of connections to relays.
+	RelayConnections float64 `json:"relay_connections"`
+}
+
+// RelayOutageResult is the result of a relay outage with the node connectivity before, during and after it.
+type RelayOutageResult struct {
+	// Outage is the index of the outage.
+	Outage int                `json:"outage"`
+	Before []NodeConnectivity `json:"before"`
+	During []NodeConnectivity `json:"during"`
+	After  []NodeConnectivity `json:"after"`
+	// RecoverySeconds is the time after restarting the relay until connectivity recovered, zero if it didn't.
+	RecoverySeconds float64 `json:"recovery_seconds"`
+	// Violations are the connectivity expectations not met.
+	Violations []string `json:"violations"`
+}
+
+// startRelayOutage starts a goroutine that stops and restarts the relay on the schedule and returns a channel
+// on which the result of each outage is sent. The channel is closed when all outages completed or the context is closed.
+func startRelayOutage(ctx context.Context, engine Engine, dir string, numNodes int, conf RelayOutageConfig) chan RelayOutageResult {
+	ctx = log.WithTopic(ctx, "relay")
+	resp := make(chan RelayOutageResult, conf.Outages)
+
+	go func() {
+		defer close(resp)
+
+		for outage := range conf.Outages {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(conf.Interval):
+			}
+
+			result, err := relayOutage(ctx, engine, dir, numNodes, conf, outage)
+			if ctx.Err() != nil {
+				return
+			} else if err != nil {
+				result.Violations = append(result.Violations, fmt.Sprintf("relay outage %d: %v", outage, err))
+			}
+
+			log.Info(ctx, "Relay outage completed", z.Int("outage", outage),
+				z.Any("recovery_seconds", result.RecoverySeconds), z.Any("violations", result.Violations))
+			resp <- result
+		}
+	}()
+
+	return resp
+}
+
+// relayOutage stops the relay for the configured duration, restarts it and returns the outage result.
+func relayOutage(ctx context.Context, engine Engine, dir string, numNodes int, conf RelayOutageConfig, outage int) (RelayOutageResult, error) {
+	result := RelayOutageResult{Outage: outage}
+
+	var err error
+	result.Before, err = queryConnectivity(ctx, engine, dir, numNodes)
+	if err != nil {
+		return result, err
+	}
+
+	log.Info(ctx, "Stopping relay", z.Int("outage", outage))
+	if out, err := engine.ComposeCmd(ctx, dir, "stop", "relay").CombinedOutput(); err != nil {
+		return result, errors.Wrap(err, "exec compose stop relay", z.Str("out", string(out)))
+	}
+
+	// Always restart the relay, even after the context is closed, so the cluster is shut down cleanly.
+	restarted := false
+	defer func() {
+		if restarted {
+			return
+		}
+		if out, err := engine.ComposeCmd(context.WithoutCancel(ctx), dir, "start", "relay").CombinedOutput(); err != nil {
+			log.Warn(ctx, "Failed restarting relay", err, z.Str("out", string(out)))
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return result, ctx.Err()
+	case <-time.After(conf.Duration):
+	}
+
+	result.During, err = queryConnectivity(ctx, engine, dir, numNodes)
+	if err != nil {
+		return result, err
+	}
+	result.Violations = degradedViolations(result.Before, result.During, conf.RequirePeerLoss)
+
+	log.Info(ctx, "Restarting relay", z.Int("outage", outage))
+	if out, err := engine.ComposeCmd(ctx, dir, "start", "relay").CombinedOutput(); err != nil {
+		return result, errors.Wrap(err, "exec compose start relay", z.Str("out", string(out)))
+	}
+	restarted = true
+
+	started := time.Now()
+	deadline := started.Add(conf.RecoveryTimeout)
+	for {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(relayPollInterval):
+		}
+
+		var violations []string
+		result.After, err = queryConnectivity(ctx, engine, dir, numNodes)
+		if err != nil {
+			violations = []string{"query connectivity: " + err.Error()}
+		} else {
+			violations = recoveryViolations(result.Before, result.After)
+		}
+
+		if len(violations) == 0 {
+			result.RecoverySeconds = time.Since(started).Seconds()
+			return result, nil
+		} else if time.Now().After(deadline) {
+			result.Violations = append(result.Violations, violations...)
+			return result, nil
+		}
+	}
+}
+
+// queryConnectivity returns the connectivity of the nodes queried from prometheus.
+func queryConnectivity(ctx context.Context, engine Engine, dir string, numNodes int) ([]NodeConnectivity, error) {
+	resp := make([]NodeConnectivity, numNodes)
+	index := make(map[string]int)
+	for i := range numNodes {
+		resp[i].Node = nodeService(i)
+		index[nodeService(i)] = i
+	}
+
+	for _, query := range []string{watchPeersQuery, relayConnectionsQuery} {
+		out, err := execPromQuery(ctx, engine, dir, query)
+		if err != nil {
+			return nil, err
+		}
+
+		samples, err := parsePromSamples(out)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sample := range samples {
+			i, ok := index[sample.Metric["job"]]
+			if !ok {
+				continue // Ignore relay and other jobs.
+			}
+
+			if query == watchPeersQuery {
+				resp[i].Peers = sample.Value
+			} else {
+				resp[i].RelayConnections = sample.Value
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// degradedViolations returns the nodes not connected to the relay before the outage or whose connectivity didn't
+// degrade during it.
+func degradedViolations(before, during []NodeConnectivity, requirePeerLoss bool) []string {
+	var resp []string
+	for _, node := range before {
+		if node.RelayConnections == 0 {
+			resp = append(resp, node.Node+" no relay connections before outage")
+		}
+	}
+
+	for _, node := range during {
+		if node.RelayConnections > 0 {
+			resp = append(resp, fmt.Sprintf("%s relay connections %.0f during outage", node.Node, node.RelayConnections))
+		}
+		if requirePeerLoss && node.Peers > 0 {
+			resp = append(resp, fmt.Sprintf("%s peers %.0f during outage", node.Node, node.Peers))
+		}
+	}
+
+	return resp
+}
+
+// recoveryViolations returns the nodes whose connectivity didn't recover to the levels before the relay outage.
+func recoveryViolations(before, after []NodeConnectivity) []string {
+	var resp []string
+	for i, node := range after {
+		if i >= len(before) {
+			break
+		}
+
+		if node.RelayConnections < before[i].RelayConnections {
+			resp = append(resp, fmt.Sprintf("%s relay connections %.0f not recovered to %.0f",
+				node.Node, node.RelayConnections, before[i].RelayConnections))
+		}
+		if node.Peers < before[i].Peers {
+			resp = append(resp, fmt.Sprintf("%s peers %.0f not recovered to %.0f",
+				node.Node, node.Peers, before[i].Peers))
+		}
+	}
+
+	return resp
+}
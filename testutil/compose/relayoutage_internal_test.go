@@ -0,0 +1,74 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayOutageConfigValidate(t *testing.T) {
+	require.NoError(t, DefaultRelayOutageConfig().validate())
+
+	for _, fn := range []func(*RelayOutageConfig){
+		func(c *RelayOutageConfig) { c.Outages = 0 },
+		func(c *RelayOutageConfig) { c.Interval = 0 },
+		func(c *RelayOutageConfig) { c.Duration = time.Second },
+		func(c *RelayOutageConfig) { c.RecoveryTimeout = relayPollInterval },
+	} {
+		conf := DefaultRelayOutageConfig()
+		fn(&conf)
+		require.Error(t, conf.validate())
+	}
+}
+
+func TestQueryConnectivity(t *testing.T) {
+	// The fake engine returns prometheus responses by query, including the relay job which is ignored.
+	const script = `case "$*" in
+		*p2p_ping_success*) echo '{"status":"success","data":{"result":[{"metric":{"job":"node0"},"value":[0,"2"]},{"metric":{"job":"node1"},"value":[0,"1"]}]}}' ;;
+		*p2p_relay_connections*) echo '{"status":"success","data":{"result":[{"metric":{"job":"node0"},"value":[0,"1"]},{"metric":{"job":"relay"},"value":[0,"5"]}]}}' ;;
+	esac`
+	fakeEngine := cliEngine{name: "fake", compose: []string{"sh", "-c", script, "sh"}}
+
+	conn, err := queryConnectivity(context.Background(), fakeEngine, "", 3)
+	require.NoError(t, err)
+	require.Equal(t, []NodeConnectivity{
+		{Node: "node0", Peers: 2, RelayConnections: 1},
+		{Node: "node1", Peers: 1},
+		{Node: "node2"},
+	}, conn)
+}
+
+func TestRelayOutageViolations(t *testing.T) {
+	before := []NodeConnectivity{
+		{Node: "node0", Peers: 2, RelayConnections: 1},
+		{Node: "node1", Peers: 2, RelayConnections: 1},
+		{Node: "node2", Peers: 2},
+	}
+	during := []NodeConnectivity{
+		{Node: "node0", Peers: 2},
+		{Node: "node1", RelayConnections: 1},
+		{Node: "node2"},
+	}
+
+	require.Equal(t, []string{
+		"node2 no relay connections before outage",
+		"node1 relay connections 1 during outage",
+	}, degradedViolations(before, during, false))
+
+	require.Equal(t, []string{
+		"node2 no relay connections before outage",
+		"node0 peers 2 during outage",
+		"node1 relay connections 1 during outage",
+	}, degradedViolations(before, during, true))
+
+	require.Empty(t, recoveryViolations(before, before))
+	require.Equal(t, []string{
+		"node0 relay connections 0 not recovered to 1",
+		"node1 peers 0 not recovered to 2",
+		"node2 peers 0 not recovered to 2",
+	}, recoveryViolations(before, during))
+}
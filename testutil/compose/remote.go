@@ -0,0 +1,174 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// RemoteConfig configures running a compose cluster against a remote docker daemon, e.g. a lab server
+// driven from a laptop.
+type RemoteConfig struct {
+	// Host is the remote docker daemon set as DOCKER_HOST, e.g. "ssh://user@lab-server" or "tcp://lab-server:2375".
+	Host string `json:"host,omitempty"`
+	// Context is the docker context of the remote docker daemon set as DOCKER_CONTEXT, mutually exclusive with Host.
+	Context string `json:"context,omitempty"`
+	// Dir is the absolute path on the remote host of the compose dir bind mounted into containers.
+	// It is synced with the local compose dir via rsync for SSH daemons, other daemons require it to be
+	// shared with the local compose dir, e.g. via NFS.
+	Dir string `json:"dir,omitempty"`
+}
+
+// validate returns an error if the remote config is invalid.
+func (r RemoteConfig) validate() error {
+	if (r.Host == "") == (r.Context == "") {
+		return errors.New("exactly one of remote docker host or context required")
+	} else if !path.IsAbs(r.Dir) {
+		return errors.New("remote compose dir not absolute", z.Str("dir", r.Dir))
+	}
+
+	return nil
+}
+
+// remoteDir returns the remote compose dir or empty if the cluster runs against the local docker daemon.
+func (c Config) remoteDir() string {
+	if c.Remote == nil {
+		return ""
+	}
+
+	return c.Remote.Dir
+}
+
+// newConfEngine returns the compose config's engine, executing commands against the remote docker daemon if configured.
+func newConfEngine(ctx context.Context, conf Config) (Engine, error) {
+	engine, err := NewEngine(ctx, conf.Engine)
+	if err != nil {
+		return nil, err
+	} else if conf.Remote == nil {
+		return engine, nil
+	}
+
+	remote, err := newRemoteEngine(ctx, engine, *conf.Remote)
+	if err != nil {
+		return nil, err
+	}
+
+	return remote, nil
+}
+
+// remoteEngine is an Engine executing commands against a remote docker daemon.
+type remoteEngine struct {
+	Engine
+	env      []string // Docker env vars selecting the remote daemon.
+	hostname string   // Hostname of the remote daemon, empty if unknown, e.g. unix sockets.
+	ssh      *url.URL // SSH URL of the remote daemon, nil if not an SSH daemon.
+	dir      string   // Remote compose dir.
+}
+
+// newRemoteEngine returns the engine executing commands against the remote docker daemon.
+func newRemoteEngine(ctx context.Context, engine Engine, conf RemoteConfig) (remoteEngine, error) {
+	if err := conf.validate(); err != nil {
+		return remoteEngine{}, err
+	} else if engine.Name() == EnginePodman {
+		return remoteEngine{}, errors.New("remote docker daemons not supported by podman-compose")
+	}
+
+	host := conf.Host
+	env := []string{"DOCKER_HOST=" + host}
+	if conf.Context != "" {
+		out, err := engine.ContainerCmd(ctx, "context", "inspect", "--format", "{{.Endpoints.docker.Host}}", conf.Context).Output()
+		if err != nil {
+			return remoteEngine{}, errors.Wrap(err, "inspect docker context", z.Str("context", conf.Context))
+		}
+
+		host = strings.TrimSpace(string(out))
+		env = []string{"DOCKER_HOST=", "DOCKER_CONTEXT=" + conf.Context} // DOCKER_HOST overrides DOCKER_CONTEXT.
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return remoteEngine{}, errors.Wrap(err, "parse docker host", z.Str("host", host))
+	}
+
+	resp := remoteEngine{
+		Engine:   engine,
+		env:      env,
+		hostname: u.Hostname(),
+		dir:      conf.Dir,
+	}
+	if u.Scheme == "ssh" {
+		resp.ssh = u
+	}
+
+	return resp, nil
+}
+
+func (e remoteEngine) ComposeCmd(ctx context.Context, dir string, args ...string) *exec.Cmd {
+	return e.withEnv(e.Engine.ComposeCmd(ctx, dir, args...))
+}
+
+func (e remoteEngine) ContainerCmd(ctx context.Context, args ...string) *exec.Cmd {
+	return e.withEnv(e.Engine.ContainerCmd(ctx, args...))
+}
+
+// withEnv returns the command with the docker env vars selecting the remote daemon.
+func (e remoteEngine) withEnv(cmd *exec.Cmd) *exec.Cmd {
+	cmd.Env = append(os.Environ(), e.env...)
+	return cmd
+}
+
+// rsyncArgs returns the rsync args pushing the local compose dir to the remote compose dir or pulling it back.
+func (e remoteEngine) rsyncArgs(dir string, push bool) []string {
+	target := e.ssh.Hostname() + ":" + e.dir + "/"
+	if e.ssh.User != nil {
+		target = e.ssh.User.Username() + "@" + target
+	}
+
+	args := []string{"--archive", "--compress"}
+	if e.ssh.Port() != "" {
+		args = append(args, "--rsh=ssh -p "+e.ssh.Port())
+	}
+
+	if push {
+		// Mirror the local compose dir, creating the remote compose dir if it doesn't exist yet.
+		return append(args, "--delete", "--rsync-path=mkdir -p "+e.dir+" && rsync", dir+"/", target)
+	}
+
+	return append(args, target, dir+"/")
+}
+
+// syncRemote pushes the local compose dir to the remote compose dir, or pulls it back, if the engine executes
+// commands against a remote SSH docker daemon. Other remote daemons require the compose dir to be shared.
+func syncRemote(ctx context.Context, engine Engine, dir string, push bool) error {
+	remote, ok := engine.(remoteEngine)
+	if !ok || remote.ssh == nil {
+		return nil
+	}
+
+	log.Debug(ctx, "Syncing remote compose dir", z.Str("remote_dir", remote.dir), z.Bool("push", push))
+
+	out, err := exec.CommandContext(ctx, "rsync", remote.rsyncArgs(dir, push)...).CombinedOutput()
+	if err != nil {
+		return errors.Wrap(err, "rsync remote compose dir", z.Str("remote_dir", remote.dir), z.Str("output", string(out)))
+	}
+
+	return nil
+}
+
+// hostname returns the hostname ports published by the engine's docker daemon are reachable on.
+func hostname(engine Engine) string {
+	if remote, ok := engine.(remoteEngine); ok && remote.hostname != "" {
+		return remote.hostname
+	}
+
+	return "localhost"
+}
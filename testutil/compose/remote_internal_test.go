@@ -0,0 +1,120 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		conf   RemoteConfig
+		errMsg string
+	}{
+		{
+			name: "host",
+			conf: RemoteConfig{Host: "ssh://user@lab-server", Dir: "/srv/compose"},
+		},
+		{
+			name: "context",
+			conf: RemoteConfig{Context: "lab", Dir: "/srv/compose"},
+		},
+		{
+			name:   "host and context",
+			conf:   RemoteConfig{Host: "ssh://user@lab-server", Context: "lab", Dir: "/srv/compose"},
+			errMsg: "exactly one of remote docker host or context required",
+		},
+		{
+			name:   "neither host nor context",
+			conf:   RemoteConfig{Dir: "/srv/compose"},
+			errMsg: "exactly one of remote docker host or context required",
+		},
+		{
+			name:   "relative dir",
+			conf:   RemoteConfig{Host: "ssh://user@lab-server", Dir: "compose"},
+			errMsg: "remote compose dir not absolute",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.conf.validate()
+			if test.errMsg != "" {
+				require.ErrorContains(t, err, test.errMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRemoteEngine(t *testing.T) {
+	ctx := context.Background()
+	local := cliEngine{name: EngineDocker, compose: []string{"docker", "compose"}, container: "docker"}
+
+	engine, err := newRemoteEngine(ctx, local, RemoteConfig{Host: "ssh://ops@lab-server:2222", Dir: "/srv/compose"})
+	require.NoError(t, err)
+	require.Equal(t, "lab-server", hostname(engine))
+
+	cmd := engine.ComposeCmd(ctx, "/tmp/compose", "up")
+	require.Equal(t, []string{"docker", "compose", "up"}, cmd.Args)
+	require.Equal(t, "DOCKER_HOST=ssh://ops@lab-server:2222", cmd.Env[len(cmd.Env)-1])
+	require.Equal(t, cmd.Env, engine.ContainerCmd(ctx, "pull", "image").Env)
+
+	require.Equal(t, []string{
+		"--archive", "--compress", "--rsh=ssh -p 2222", "--delete", "--rsync-path=mkdir -p /srv/compose && rsync",
+		"/tmp/compose/", "ops@lab-server:/srv/compose/",
+	}, engine.rsyncArgs("/tmp/compose", true))
+	require.Equal(t, []string{
+		"--archive", "--compress", "--rsh=ssh -p 2222", "ops@lab-server:/srv/compose/", "/tmp/compose/",
+	}, engine.rsyncArgs("/tmp/compose", false))
+
+	// TCP daemons require a shared compose dir, so syncing is a noop.
+	engine, err = newRemoteEngine(ctx, local, RemoteConfig{Host: "tcp://10.0.0.5:2375", Dir: "/srv/compose"})
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.5", hostname(engine))
+	require.Nil(t, engine.ssh)
+	require.NoError(t, syncRemote(ctx, engine, "/tmp/compose", true))
+
+	// The local engine doesn't sync.
+	require.Equal(t, "localhost", hostname(local))
+	require.NoError(t, syncRemote(ctx, local, "/tmp/compose", true))
+
+	_, err = newRemoteEngine(ctx, cliEngine{name: EnginePodman}, RemoteConfig{Host: "tcp://10.0.0.5:2375", Dir: "/srv/compose"})
+	require.ErrorContains(t, err, "remote docker daemons not supported by podman-compose")
+}
+
+func TestRemoteEngineContext(t *testing.T) {
+	// The fake container engine returns the docker host of the inspected context.
+	script := path.Join(t.TempDir(), "docker")
+	content := `#!/bin/sh
+[ "$1 $2 $5" = "context inspect lab" ] && echo "ssh://ops@lab-server"`
+	require.NoError(t, os.WriteFile(script, []byte(content), 0o755))
+	fakeEngine := cliEngine{name: "fake", compose: []string{"docker", "compose"}, container: script}
+
+	engine, err := newRemoteEngine(context.Background(), fakeEngine, RemoteConfig{Context: "lab", Dir: "/srv/compose"})
+	require.NoError(t, err)
+	require.Equal(t, "lab-server", hostname(engine))
+	require.Equal(t, []string{"DOCKER_HOST=", "DOCKER_CONTEXT=lab"}, engine.env)
+	require.Equal(t, []string{"--archive", "--compress", "ops@lab-server:/srv/compose/", "/tmp/compose/"},
+		engine.rsyncArgs("/tmp/compose", false))
+
+	_, err = newRemoteEngine(context.Background(), fakeEngine, RemoteConfig{Context: "unknown", Dir: "/srv/compose"})
+	require.ErrorContains(t, err, "inspect docker context")
+}
+
+func TestHostPath(t *testing.T) {
+	var data TmplData
+	require.Equal(t, ".", data.HostPath("."))
+	require.Equal(t, "./prometheus/rules.yml", data.HostPath("prometheus/rules.yml"))
+
+	data.RemoteDir = "/srv/compose"
+	require.Equal(t, "/srv/compose", data.HostPath("."))
+	require.Equal(t, "/srv/compose/prometheus/rules.yml", data.HostPath("prometheus/rules.yml"))
+}
@@ -0,0 +1,114 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// Resources defines the resource constraints of a node's charon container.
+type Resources struct {
+	// Profile is the name of a predefined hardware profile providing defaults for unset constraints, e.g. "rpi4".
+	Profile string `json:"profile,omitempty"`
+	// CPUs is the maximum number of CPUs, e.g. 1.5.
+	CPUs float64 `json:"cpus,omitempty"`
+	// Memory is the maximum memory in docker format, e.g. "512m" or "2g".
+	Memory string `json:"memory,omitempty"`
+	// IOWeight is the relative block IO weight between 10 and 1000, lower values get less disk throughput under contention.
+	IOWeight int `json:"io_weight,omitempty"`
+}
+
+// hardwareProfiles are the predefined hardware profiles. Docker can't emulate slower CPU cores,
+// so the CPU limits approximate the profile's compute relative to a modern x86 core.
+var hardwareProfiles = map[string]Resources{
+	"rpi3": {CPUs: 0.5, Memory: "1g", IOWeight: 10},
+	"rpi4": {CPUs: 1, Memory: "4g", IOWeight: 50},
+	"rpi5": {CPUs: 2, Memory: "8g", IOWeight: 100},
+}
+
+// HardwareProfiles returns the names of the predefined hardware profiles.
+func HardwareProfiles() []string {
+	var resp []string
+	for name := range hardwareProfiles {
+		resp = append(resp, name)
+	}
+	sort.Strings(resp)
+
+	return resp
+}
+
+// memoryRegex matches docker memory limits.
+var memoryRegex = regexp.MustCompile(`^\d+[bkmg]?$`)
+
+// resolve returns the resources with unset constraints defaulted from the hardware profile.
+func (r Resources) resolve() (Resources, error) {
+	if r.Profile != "" {
+		profile, ok := hardwareProfiles[r.Profile]
+		if !ok {
+			return Resources{}, errors.New("unknown hardware profile", z.Str("profile", r.Profile),
+				z.Str("supported", strings.Join(HardwareProfiles(), ", ")))
+		}
+
+		if r.CPUs == 0 {
+			r.CPUs = profile.CPUs
+		}
+		if r.Memory == "" {
+			r.Memory = profile.Memory
+		}
+		if r.IOWeight == 0 {
+			r.IOWeight = profile.IOWeight
+		}
+	}
+
+	if r.CPUs < 0 {
+		return Resources{}, errors.New("negative cpus limit")
+	} else if r.Memory != "" && !memoryRegex.MatchString(r.Memory) {
+		return Resources{}, errors.New("invalid memory limit", z.Str("memory", r.Memory))
+	} else if r.IOWeight != 0 && (r.IOWeight < 10 || r.IOWeight > 1000) {
+		return Resources{}, errors.New("io weight not between 10 and 1000", z.Int("io_weight", r.IOWeight))
+	}
+
+	return r, nil
+}
+
+// CPUsString returns the CPUs limit formatted for docker compose and Kubernetes, e.g. "1.5".
+func (r Resources) CPUsString() string {
+	return strconv.FormatFloat(r.CPUs, 'f', -1, 64)
+}
+
+// K8sMemory returns the memory limit formatted as Kubernetes quantity, e.g. "2g" as "2Gi".
+func (r Resources) K8sMemory() string {
+	suffixes := map[byte]string{'b': "", 'k': "Ki", 'm': "Mi", 'g': "Gi"}
+
+	last := r.Memory[len(r.Memory)-1]
+	if suffix, ok := suffixes[last]; ok {
+		return r.Memory[:len(r.Memory)-1] + suffix
+	}
+
+	return r.Memory
+}
+
+// nodeResources returns the resolved resource constraints of each node by index.
+func nodeResources(conf Config) (map[int]Resources, error) {
+	resp := make(map[int]Resources)
+	for node, resources := range conf.NodeResources {
+		if node < 0 || node >= conf.NumNodes {
+			return nil, errors.New("node resources out of range", z.Int("node", node))
+		}
+
+		resolved, err := resources.resolve()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid node resources", z.Int("node", node))
+		}
+
+		resp[node] = resolved
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,82 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveResources(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Resources Resources
+		Expected  Resources
+		Err       string
+	}{
+		{
+			Name:      "explicit",
+			Resources: Resources{CPUs: 0.5, Memory: "256m", IOWeight: 10},
+			Expected:  Resources{CPUs: 0.5, Memory: "256m", IOWeight: 10},
+		},
+		{
+			Name:      "profile",
+			Resources: Resources{Profile: "rpi4"},
+			Expected:  Resources{Profile: "rpi4", CPUs: 1, Memory: "4g", IOWeight: 50},
+		},
+		{
+			Name:      "profile override",
+			Resources: Resources{Profile: "rpi3", Memory: "2g"},
+			Expected:  Resources{Profile: "rpi3", CPUs: 0.5, Memory: "2g", IOWeight: 10},
+		},
+		{
+			Name:      "unknown profile",
+			Resources: Resources{Profile: "mainframe"},
+			Err:       "unknown hardware profile",
+		},
+		{
+			Name:      "negative cpus",
+			Resources: Resources{CPUs: -1},
+			Err:       "negative cpus limit",
+		},
+		{
+			Name:      "invalid memory",
+			Resources: Resources{Memory: "2GB"},
+			Err:       "invalid memory limit",
+		},
+		{
+			Name:      "invalid io weight",
+			Resources: Resources{IOWeight: 5},
+			Err:       "io weight not between 10 and 1000",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			resolved, err := test.Resources.resolve()
+			if test.Err != "" {
+				require.ErrorContains(t, err, test.Err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, resolved)
+		})
+	}
+}
+
+func TestK8sMemory(t *testing.T) {
+	require.Equal(t, "512Mi", Resources{Memory: "512m"}.K8sMemory())
+	require.Equal(t, "2Gi", Resources{Memory: "2g"}.K8sMemory())
+	require.Equal(t, "1024", Resources{Memory: "1024b"}.K8sMemory())
+	require.Equal(t, "1024", Resources{Memory: "1024"}.K8sMemory())
+}
+
+func TestNodeResourcesOutOfRange(t *testing.T) {
+	conf := NewDefaultConfig()
+	conf.NodeResources = map[int]Resources{conf.NumNodes: {CPUs: 1}}
+
+	_, err := nodeResources(conf)
+	require.ErrorContains(t, err, "node resources out of range")
+}
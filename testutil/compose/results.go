@@ -0,0 +1,166 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// resultsFile is the compose directory file auto run results are written to.
+const resultsFile = "results.json"
+
+// Results are the machine-readable results of an auto run.
+type Results struct {
+	// Success is true if the auto run succeeded.
+	Success bool `json:"success"`
+	// Error is the auto run error, empty if it succeeded.
+	Error string `json:"error,omitempty"`
+	// StartedAt is the time the auto run started.
+	StartedAt time.Time `json:"started_at"`
+	// DurationSeconds is the total duration of the auto run.
+	DurationSeconds float64 `json:"duration_seconds"`
+	// Steps are the results of the executed compose steps.
+	Steps []StepResult `json:"steps"`
+	// Alerts are the alerts detected during the run step.
+	Alerts []string `json:"alerts"`
+	// Violations are the duty success rate violations detected during chaos or upgrade testing,
+	// or the connectivity violations detected during relay outage testing.
+	Violations []string `json:"violations"`
+	// RelayOutages are the results of the relay outages during relay outage testing.
+	RelayOutages []RelayOutageResult `json:"relay_outages,omitempty"`
+	// Nodes are the duty counts of each node scraped from prometheus at the end of the run step.
+	Nodes []NodeResult `json:"nodes"`
+	// Artifacts is the path of the artifacts bundle collected on teardown, empty if not collected.
+	Artifacts string `json:"artifacts,omitempty"`
+}
+
+// StepResult is the result of a compose step.
+type StepResult struct {
+	Name            string  `json:"name"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// NodeResult contains the duty counts of a node by duty type.
+type NodeResult struct {
+	Node   string                `json:"node"`
+	Duties map[string]DutyCounts `json:"duties"`
+}
+
+// DutyCounts are the number of expected and successful duties of a duty type.
+type DutyCounts struct {
+	Expected float64 `json:"expected"`
+	Success  float64 `json:"success"`
+}
+
+// addStep adds the result of the step that started at the provided time.
+func (r *Results) addStep(name string, started time.Time) {
+	r.Steps = append(r.Steps, StepResult{Name: name, DurationSeconds: time.Since(started).Seconds()})
+}
+
+// finish sets the final result of the auto run.
+func (r *Results) finish(err error) {
+	r.DurationSeconds = time.Since(r.StartedAt).Seconds()
+	r.Success = err == nil
+	if err != nil {
+		r.Error = err.Error()
+	}
+}
+
+// writeResults writes the results as JSON to the compose dir results file.
+func writeResults(dir string, results Results) error {
+	b, err := json.MarshalIndent(results, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal results")
+	}
+
+	err = os.WriteFile(path.Join(dir, resultsFile), b, 0o644) //nolint:gosec
+	if err != nil {
+		return errors.Wrap(err, "write results")
+	}
+
+	return nil
+}
+
+const (
+	// expectDutiesQuery is the prometheus query returning the number of expected duties per node and duty type.
+	expectDutiesQuery = `sum by (job, duty) (core_tracker_expect_duties_total)`
+	// successDutiesQuery is the prometheus query returning the number of successful duties per node and duty type.
+	successDutiesQuery = `sum by (job, duty) (core_tracker_success_duties_total)`
+)
+
+// scrapeDutyCounts returns the duty counts of each node queried from prometheus.
+func scrapeDutyCounts(ctx context.Context, engine Engine, dir string) ([]NodeResult, error) {
+	expected, err := execPromQuery(ctx, engine, dir, expectDutiesQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	success, err := execPromQuery(ctx, engine, dir, successDutiesQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDutyCounts(expected, success)
+}
+
+// parseDutyCounts returns the node duty counts of the expected and successful duties prometheus query responses.
+func parseDutyCounts(expected, success []byte) ([]NodeResult, error) {
+	counts := make(map[string]map[string]DutyCounts)
+	for i, resp := range [][]byte{expected, success} {
+		var query promQuery
+		if err := json.Unmarshal(resp, &query); err != nil {
+			return nil, errors.Wrap(err, "unmarshal query response", z.Str("out", string(resp)))
+		} else if query.Status != "success" {
+			return nil, errors.New("non success status from prometheus query", z.Str("status", query.Status))
+		}
+
+		for _, result := range query.Data.Result {
+			if len(result.Value) != 2 {
+				return nil, errors.New("invalid prometheus query result value")
+			}
+
+			valStr, ok := result.Value[1].(string)
+			if !ok {
+				return nil, errors.New("invalid prometheus query result value")
+			}
+
+			val, err := strconv.ParseFloat(valStr, 64)
+			if err != nil {
+				return nil, errors.Wrap(err, "parse duty count")
+			}
+
+			node, duty := result.Metric["job"], result.Metric["duty"]
+			if counts[node] == nil {
+				counts[node] = make(map[string]DutyCounts)
+			}
+
+			count := counts[node][duty]
+			if i == 0 {
+				count.Expected = val
+			} else {
+				count.Success = val
+			}
+			counts[node][duty] = count
+		}
+	}
+
+	var resp []NodeResult
+	for node, duties := range counts {
+		resp = append(resp, NodeResult{Node: node, Duties: duties})
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Node < resp[j].Node
+	})
+
+	return resp, nil
+}
@@ -0,0 +1,62 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDutyCounts(t *testing.T) {
+	expected := []byte(`{"status":"success","data":{"resultType":"vector","result":[
+		{"metric":{"job":"node1","duty":"attester"},"value":[1700000000,"10"]},
+		{"metric":{"job":"node0","duty":"attester"},"value":[1700000000,"10"]},
+		{"metric":{"job":"node0","duty":"proposer"},"value":[1700000000,"2"]}
+	]}}`)
+	success := []byte(`{"status":"success","data":{"resultType":"vector","result":[
+		{"metric":{"job":"node0","duty":"attester"},"value":[1700000000,"9"]},
+		{"metric":{"job":"node1","duty":"attester"},"value":[1700000000,"10"]}
+	]}}`)
+
+	nodes, err := parseDutyCounts(expected, success)
+	require.NoError(t, err)
+	require.Equal(t, []NodeResult{
+		{Node: "node0", Duties: map[string]DutyCounts{
+			"attester": {Expected: 10, Success: 9},
+			"proposer": {Expected: 2},
+		}},
+		{Node: "node1", Duties: map[string]DutyCounts{
+			"attester": {Expected: 10, Success: 10},
+		}},
+	}, nodes)
+
+	_, err = parseDutyCounts([]byte(`{"status":"error"}`), success)
+	require.ErrorContains(t, err, "non success status from prometheus query")
+
+	_, err = parseDutyCounts(expected, []byte(`not json`))
+	require.ErrorContains(t, err, "unmarshal query response")
+}
+
+func TestAutoResults(t *testing.T) {
+	dir := t.TempDir()
+
+	// Auto fails without a compose config, but still writes the results.
+	results, err := Auto(context.Background(), AutoConfig{Dir: dir})
+	require.ErrorContains(t, err, "compose config.json not found")
+	require.False(t, results.Success)
+	require.Equal(t, err.Error(), results.Error)
+
+	b, err := os.ReadFile(path.Join(dir, resultsFile))
+	require.NoError(t, err)
+
+	var actual Results
+	require.NoError(t, json.Unmarshal(b, &actual))
+	require.Equal(t, results.Error, actual.Error)
+	require.False(t, actual.Success)
+	require.Empty(t, actual.Steps)
+}
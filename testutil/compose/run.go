@@ -19,22 +19,57 @@ func Run(ctx context.Context, dir string, conf Config) (TmplData, error) {
 		return TmplData{}, errors.New("compose config not locked, so can't be run", z.Any("step", conf.Step))
 	}
 
+	if err := conf.validateVCs(); err != nil {
+		return TmplData{}, err
+	}
+
+	if err := validateFaults(conf); err != nil {
+		return TmplData{}, err
+	}
+
+	if err := conf.validatePorts(); err != nil {
+		return TmplData{}, err
+	}
+
+	resources, err := nodeResources(conf)
+	if err != nil {
+		return TmplData{}, err
+	}
+
+	lock, err := loadImageLock(dir, conf)
+	if err != nil {
+		return TmplData{}, err
+	}
+
 	var (
 		nodes []TmplNode
 		vcs   []TmplVC
 	)
 	for i := range conf.NumNodes {
-		typ := conf.VCs[i%len(conf.VCs)]
+		typ := conf.NodeVC(i)
 		vc, err := getVC(typ, i, conf.NumValidators, conf.InsecureKeys, conf.BuilderAPI)
 		if err != nil {
 			return TmplData{}, err
 		}
+
+		if vc.Image != "" {
+			vc.Image = lock.pin(vc.Image)
+		}
+		if vc.Build != "" {
+			vc.BuildArgs, err = pinnedBuildArgs(lock, vc.Build)
+			if err != nil {
+				return TmplData{}, err
+			}
+		}
 		vcs = append(vcs, vc)
 
 		n := TmplNode{EnvVars: newNodeEnvs(i, conf, typ)}
+		if r, ok := resources[i]; ok {
+			n.Resources = &r
+		}
 		if !conf.DisableMonitoringPorts {
 			for _, p := range charonPorts {
-				p.External += 10000 * i
+				p.External += 10000*i + conf.PortOffset
 				n.Ports = append(n.Ports, p)
 			}
 		}
@@ -47,6 +82,8 @@ func Run(ctx context.Context, dir string, conf Config) (TmplData, error) {
 		charonCmd = cmdUnsafeRun
 	}
 
+	applyFaults(conf, charonCmd, nodes, vcs)
+
 	data := TmplData{
 		ComposeDir:      dir,
 		CharonImageTag:  conf.ImageTag,
@@ -57,6 +94,15 @@ func Run(ctx context.Context, dir string, conf Config) (TmplData, error) {
 		Alerting:        true,
 		MonitoringPorts: !conf.DisableMonitoringPorts,
 		VCs:             vcs,
+		HostGateway:     conf.HostGateway,
+		ProjectName:     conf.ProjectName,
+		RemoteDir:       conf.remoteDir(),
+		PortOffset:      conf.PortOffset,
+		ImageLock:       lock,
+	}
+
+	if err := writePrometheusConfig(dir, conf.NumNodes); err != nil {
+		return TmplData{}, err
 	}
 
 	log.Info(ctx, "Created docker-compose.yml")
@@ -84,9 +130,13 @@ func getVC(typ VCType, nodeIdx int, numVals int, insecure, builderAPI bool) (Tmp
 			Label: string(VCLodestar),
 			Build: "lodestar",
 		},
+		VCNimbus: {
+			Label: string(VCNimbus),
+			Build: "nimbus",
+		},
 		VCTeku: {
 			Label: string(VCTeku),
-			Image: "consensys/teku:latest",
+			Image: tekuImage,
 			Command: `|
       validator-client
       --network=auto
@@ -99,6 +149,7 @@ func getVC(typ VCType, nodeIdx int, numVals int, insecure, builderAPI bool) (Tmp
 	}
 
 	resp := vcByType[typ]
+	resp.Type = typ
 	if typ == VCTeku {
 		var keys []string
 		for i := range numVals {
@@ -40,6 +40,8 @@ func TestSmoke(t *testing.T) {
 		DefineTmplFunc func(*compose.TmplData)
 		PrintYML       bool
 		Timeout        time.Duration
+		Chaos          *compose.ChaosConfig
+		Upgrade        *compose.UpgradeConfig
 	}{
 		{
 			Name:     "default_alpha",
@@ -155,12 +157,44 @@ func TestSmoke(t *testing.T) {
 				conf.VCs = []compose.VCType{compose.VCLodestar}
 			},
 		},
+		{
+			Name: "cluster_with_nimbus",
+			ConfigFunc: func(conf *compose.Config) {
+				conf.VCs = []compose.VCType{compose.VCNimbus}
+			},
+		},
+		{
+			Name: "cluster_with_mixed_vcs",
+			ConfigFunc: func(conf *compose.Config) {
+				conf.VCs = []compose.VCType{compose.VCNimbus, compose.VCLodestar, compose.VCLighthouse, compose.VCTeku}
+			},
+		},
 		{
 			Name: "blinded_blocks_vmock",
 			ConfigFunc: func(conf *compose.Config) {
 				conf.BuilderAPI = true
 			},
 		},
+		{
+			Name:    "chaos",
+			Timeout: time.Minute * 3,
+			Chaos: func() *compose.ChaosConfig {
+				conf := compose.DefaultChaosConfig()
+				return &conf
+			}(),
+		},
+		{
+			Name:    "rolling_upgrade",
+			Timeout: time.Minute * 5,
+			ConfigFunc: func(conf *compose.Config) {
+				conf.VCs = []compose.VCType{compose.VCMock}
+			},
+			Upgrade: func() *compose.UpgradeConfig {
+				// Upgrade from the previous release to the locally built latest version.
+				conf := compose.DefaultUpgradeConfig(nth(version.Supported(), 1) + ".0-rc1")
+				return &conf
+			}(),
+		},
 		{
 			Name: "blinded_blocks_teku",
 			ConfigFunc: func(conf *compose.Config) {
@@ -198,13 +232,15 @@ func TestSmoke(t *testing.T) {
 				PrintYML:       test.PrintYML,
 				RunTmplFunc:    test.RunTmplFunc,
 				DefineTmplFunc: test.DefineTmplFunc,
+				Chaos:          test.Chaos,
+				Upgrade:        test.Upgrade,
 			}
 
 			if *logDir != "" {
 				autoConfig.LogFile = path.Join(*logDir, test.Name+".log")
 			}
 
-			err := compose.Auto(context.Background(), autoConfig)
+			_, err := compose.Auto(context.Background(), autoConfig)
 			testutil.RequireNoError(t, err)
 		})
 	}
@@ -0,0 +1,412 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// snapshotsDir is the compose directory folder snapshots are written to.
+	snapshotsDir = "snapshots"
+	// snapshotManifest is the snapshot file listing the snapshotted container paths.
+	snapshotManifest = "snapshot.json"
+	// snapshotContainers is the snapshot folder containing the snapshotted container paths.
+	snapshotContainers = "containers"
+	// snapshotCompose is the snapshot folder containing the snapshotted compose dir.
+	snapshotCompose = "compose"
+)
+
+// snapshotNameRegex matches valid snapshot names.
+var snapshotNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// ContainerPath is a path inside the container of a compose service, e.g. a validator client or beacon node data dir.
+type ContainerPath struct {
+	Service string `json:"service"`
+	Path    string `json:"path"`
+}
+
+// snapshotMeta is the snapshot manifest.
+type snapshotMeta struct {
+	ContainerPaths []ContainerPath `json:"container_paths"`
+}
+
+// Snapshot writes the state of the compose cluster to a named snapshot in the compose dir's snapshots folder
+// and returns its path. The state includes the compose dir, i.e. the config, keys, cluster lock and the data dirs
+// of the nodes and relay, and the provided container paths. Running containers are paused while snapshotting.
+func Snapshot(ctx context.Context, dir string, name string, containerPaths []ContainerPath) (string, error) {
+	if !snapshotNameRegex.MatchString(name) {
+		return "", errors.New("invalid snapshot name", z.Str("name", name))
+	}
+
+	conf, err := loadConfig(dir)
+	if err != nil {
+		return "", err
+	}
+
+	engine, err := newConfEngine(ctx, conf)
+	if err != nil {
+		return "", err
+	}
+
+	return snapshot(ctx, engine, dir, name, containerPaths)
+}
+
+// snapshot writes the state of the compose cluster to a named snapshot using the engine.
+func snapshot(ctx context.Context, engine Engine, dir string, name string, containerPaths []ContainerPath) (string, error) {
+	out, err := engine.ComposeCmd(ctx, dir, "ps", "--quiet", "--status=running").Output()
+	if err != nil {
+		return "", errors.Wrap(err, "exec compose ps")
+	}
+
+	// Pause running containers for a consistent snapshot.
+	if len(strings.TrimSpace(string(out))) > 0 {
+		if out, err := engine.ComposeCmd(ctx, dir, "pause").CombinedOutput(); err != nil {
+			return "", errors.Wrap(err, "exec compose pause", z.Str("output", string(out)))
+		}
+		defer func() {
+			if out, err := engine.ComposeCmd(context.WithoutCancel(ctx), dir, "unpause").CombinedOutput(); err != nil {
+				log.Warn(ctx, "Failed unpausing containers", err, z.Str("output", string(out)))
+			}
+		}()
+	}
+
+	// Pull files written by containers on remote docker hosts.
+	if err := syncRemote(ctx, engine, dir, false); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.MkdirTemp("", "compose-snapshot")
+	if err != nil {
+		return "", errors.Wrap(err, "create temp dir")
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, cp := range containerPaths {
+		if cp.Service == "" || !path.IsAbs(cp.Path) {
+			return "", errors.New("invalid container path, expected service and absolute path",
+				z.Str("service", cp.Service), z.Str("path", cp.Path))
+		}
+
+		target := path.Join(tmp, cp.Service, path.Dir(cp.Path))
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return "", errors.Wrap(err, "create container path dir")
+		}
+
+		out, err := engine.ComposeCmd(ctx, dir, "cp", cp.Service+":"+cp.Path, target).CombinedOutput()
+		if err != nil {
+			return "", errors.Wrap(err, "exec compose cp", z.Str("service", cp.Service),
+				z.Str("path", cp.Path), z.Str("output", string(out)))
+		}
+	}
+
+	meta, err := json.MarshalIndent(snapshotMeta{ContainerPaths: containerPaths}, "", " ")
+	if err != nil {
+		return "", errors.Wrap(err, "marshal snapshot manifest")
+	}
+
+	if err := os.MkdirAll(path.Join(dir, snapshotsDir), 0o755); err != nil {
+		return "", errors.Wrap(err, "create snapshots dir")
+	}
+
+	file := path.Join(dir, snapshotsDir, name+".tar.gz")
+	if err := writeSnapshot(file, dir, tmp, meta); err != nil {
+		return "", err
+	}
+
+	log.Info(ctx, "Snapshotted compose cluster", z.Str("snapshot", file), z.Int("container_paths", len(containerPaths)))
+
+	return file, nil
+}
+
+// Restore restores the state of the compose cluster from the named snapshot in the compose dir's snapshots folder.
+// The compose dir is replaced by the snapshotted compose dir and the snapshotted container paths are copied into
+// newly created containers, so the cluster continues from the snapshotted state when started, e.g. via
+// `docker compose up`. The snapshots and artifacts folders are retained.
+func Restore(ctx context.Context, dir string, name string) error {
+	if !snapshotNameRegex.MatchString(name) {
+		return errors.New("invalid snapshot name", z.Str("name", name))
+	}
+
+	file := path.Join(dir, snapshotsDir, name+".tar.gz")
+	if _, err := os.Stat(file); err != nil {
+		return errors.Wrap(err, "snapshot not found", z.Str("snapshot", file))
+	}
+
+	tmp, err := os.MkdirTemp("", "compose-restore")
+	if err != nil {
+		return errors.Wrap(err, "create temp dir")
+	}
+	defer os.RemoveAll(tmp)
+
+	// Extract the manifest and container paths, the compose dir is only replaced once the cluster is stopped.
+	err = extractTarGz(file, func(name string) string {
+		if name == snapshotManifest || strings.HasPrefix(name, snapshotContainers+"/") {
+			return path.Join(tmp, name)
+		}
+
+		return ""
+	})
+	if err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(path.Join(tmp, snapshotManifest))
+	if err != nil {
+		return errors.Wrap(err, "read snapshot manifest")
+	}
+
+	var meta snapshotMeta
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return errors.Wrap(err, "unmarshal snapshot manifest")
+	}
+
+	conf, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
+
+	engine, err := newConfEngine(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	return restore(ctx, engine, dir, file, tmp, meta)
+}
+
+// restore restores the snapshot file to the compose dir using the engine, the snapshot's container paths are
+// already extracted to tmp.
+func restore(ctx context.Context, engine Engine, dir string, file string, tmp string, meta snapshotMeta) error {
+	// Stop the running cluster before replacing its state.
+	if out, err := engine.ComposeCmd(ctx, dir, "down", "--remove-orphans", "--timeout=2").CombinedOutput(); err != nil {
+		log.Warn(ctx, "Failed stopping compose cluster", err, z.Str("output", string(out)))
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return errors.Wrap(err, "read compose dir")
+	}
+
+	for _, entry := range entries {
+		if entry.Name() == snapshotsDir || entry.Name() == artifactsDir {
+			continue
+		} else if strings.HasSuffix(entry.Name(), ".go") || strings.HasPrefix(entry.Name(), "go.") {
+			return errors.New("go files found, compose dir incorrect", z.Str("dir", dir))
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.Name() == snapshotsDir || entry.Name() == artifactsDir {
+			continue
+		}
+
+		if err := os.RemoveAll(path.Join(dir, entry.Name())); err != nil {
+			return errors.Wrap(err, "remove compose dir file")
+		}
+	}
+
+	err = extractTarGz(file, func(name string) string {
+		if rel, ok := strings.CutPrefix(name, snapshotCompose+"/"); ok {
+			return path.Join(dir, rel)
+		}
+
+		return ""
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := syncRemote(ctx, engine, dir, true); err != nil {
+		return err
+	}
+
+	if len(meta.ContainerPaths) == 0 {
+		log.Info(ctx, "Restored compose cluster", z.Str("dir", dir))
+		return nil
+	}
+
+	if out, err := engine.ComposeCmd(ctx, dir, "up", "--no-start").CombinedOutput(); err != nil {
+		return errors.Wrap(err, "exec compose up --no-start", z.Str("output", string(out)))
+	}
+
+	for _, cp := range meta.ContainerPaths {
+		source := path.Join(tmp, snapshotContainers, cp.Service, cp.Path)
+		out, err := engine.ComposeCmd(ctx, dir, "cp", source, cp.Service+":"+path.Dir(cp.Path)).CombinedOutput()
+		if err != nil {
+			return errors.Wrap(err, "exec compose cp", z.Str("service", cp.Service),
+				z.Str("path", cp.Path), z.Str("output", string(out)))
+		}
+	}
+
+	log.Info(ctx, "Restored compose cluster", z.Str("dir", dir), z.Int("container_paths", len(meta.ContainerPaths)))
+
+	return nil
+}
+
+// writeSnapshot writes a gzipped tar archive of the compose dir, excluding the snapshots and artifacts folders,
+// the copied container paths and the manifest, retaining file modes.
+func writeSnapshot(file string, dir string, containersDir string, meta []byte) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return errors.Wrap(err, "create snapshot")
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	err = tw.WriteHeader(&tar.Header{Name: snapshotManifest, Mode: 0o644, Size: int64(len(meta))})
+	if err != nil {
+		return errors.Wrap(err, "write tar header")
+	} else if _, err := tw.Write(meta); err != nil {
+		return errors.Wrap(err, "write tar file")
+	}
+
+	skip := func(rel string) bool {
+		return rel == snapshotsDir || rel == artifactsDir
+	}
+	if err := addTarDir(tw, dir, snapshotCompose, skip); err != nil {
+		return err
+	}
+
+	if err := addTarDir(tw, containersDir, snapshotContainers, func(string) bool { return false }); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "close tar")
+	} else if err := gw.Close(); err != nil {
+		return errors.Wrap(err, "close gzip")
+	} else if err := f.Close(); err != nil {
+		return errors.Wrap(err, "close snapshot")
+	}
+
+	return nil
+}
+
+// addTarDir adds the files in the dir, excluding skipped relative paths, to the tar archive with the prefix.
+func addTarDir(tw *tar.Writer, dir string, prefix string, skip func(rel string) bool) error {
+	return filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return errors.Wrap(err, "walk dir")
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return errors.Wrap(err, "relative path")
+		} else if rel == "." {
+			return nil
+		} else if d.IsDir() && skip(rel) {
+			return filepath.SkipDir
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return errors.Wrap(err, "file info")
+		} else if !info.Mode().IsDir() && !info.Mode().IsRegular() {
+			return nil // Skip symlinks and special files.
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return errors.Wrap(err, "tar header")
+		}
+		header.Name = path.Join(prefix, filepath.ToSlash(rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return errors.Wrap(err, "write tar header")
+		} else if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return errors.Wrap(err, "open file")
+		}
+		defer f.Close()
+
+		if _, err := io.Copy(tw, f); err != nil {
+			return errors.Wrap(err, "write tar file")
+		}
+
+		return nil
+	})
+}
+
+// extractTarGz extracts the files of the gzipped tar archive to the paths returned by the target function,
+// retaining file modes. Files for which the target function returns empty are skipped.
+func extractTarGz(file string, target func(name string) string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return errors.Wrap(err, "open snapshot")
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return errors.Wrap(err, "gzip reader")
+	}
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "read tar")
+		}
+
+		name := path.Clean(header.Name)
+		if !fs.ValidPath(name) {
+			return errors.New("invalid snapshot file path", z.Str("path", header.Name))
+		}
+
+		to := target(name)
+		if to == "" {
+			continue
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(to, header.FileInfo().Mode().Perm()|0o700); err != nil {
+				return errors.Wrap(err, "create dir")
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(path.Dir(to), 0o755); err != nil {
+				return errors.Wrap(err, "create dir")
+			}
+
+			out, err := os.OpenFile(to, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, header.FileInfo().Mode().Perm())
+			if err != nil {
+				return errors.Wrap(err, "create file")
+			}
+
+			_, err = io.Copy(out, tr) //nolint:gosec // Snapshots are trusted local test artifacts.
+			if closeErr := out.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return errors.Wrap(err, "write file")
+			}
+		default:
+			return errors.New("unsupported snapshot file type", z.Str("path", header.Name))
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(nodeFile(dir, 0, "validator_keys"), 0o755))
+	require.NoError(t, os.MkdirAll(path.Join(dir, artifactsDir), 0o755))
+
+	for name, content := range map[string]string{
+		configFile:                      "{}",
+		"node0/cluster-lock.json":       "lock",
+		"node0/charon-enr-private-key":  "secret",
+		"lighthouse/run.sh":             "#!/bin/sh",
+		path.Join(artifactsDir, "keep"): "artifact",
+	} {
+		require.NoError(t, os.MkdirAll(path.Dir(path.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(path.Join(dir, name), []byte(content), 0o600))
+	}
+	require.NoError(t, os.Chmod(path.Join(dir, "lighthouse/run.sh"), 0o755))
+
+	// The fake engine logs its commands, copies container paths from a fake container dir and reports no running containers.
+	container := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(container, "data"), 0o755))
+	require.NoError(t, os.WriteFile(path.Join(container, "data", "slashing.db"), []byte("db"), 0o644))
+
+	cmdLog := path.Join(t.TempDir(), "cmds")
+	script := `echo "$1" >> ` + cmdLog + `
+		case "$1" in
+		cp) case "$2" in
+			*:*) cp -r "` + container + `${2#*:}" "$3" ;;
+			*) cp -r "$2" "` + container + `/restored" ;;
+			esac ;;
+		esac`
+	fakeEngine := cliEngine{name: "fake", compose: []string{"sh", "-c", script, "sh"}}
+
+	containerPaths := []ContainerPath{{Service: "vc0-lighthouse", Path: "/data"}}
+	file, err := snapshot(ctx, fakeEngine, dir, "slot-100", containerPaths)
+	require.NoError(t, err)
+	require.Equal(t, path.Join(dir, snapshotsDir, "slot-100.tar.gz"), file)
+
+	// Mutate the cluster state after the snapshot.
+	require.NoError(t, os.WriteFile(path.Join(dir, "node0/cluster-lock.json"), []byte("changed"), 0o644))
+	require.NoError(t, os.WriteFile(path.Join(dir, "new-file"), []byte("new"), 0o644))
+
+	tmp := t.TempDir()
+	err = extractTarGz(file, func(name string) string {
+		if name == snapshotManifest || strings.HasPrefix(name, snapshotContainers+"/") {
+			return path.Join(tmp, name)
+		}
+
+		return ""
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, restore(ctx, fakeEngine, dir, file, tmp, snapshotMeta{ContainerPaths: containerPaths}))
+
+	b, err := os.ReadFile(path.Join(dir, "node0/cluster-lock.json"))
+	require.NoError(t, err)
+	require.Equal(t, "lock", string(b))
+	require.NoFileExists(t, path.Join(dir, "new-file"))
+	require.FileExists(t, path.Join(dir, artifactsDir, "keep"))
+	require.FileExists(t, file)
+
+	info, err := os.Stat(path.Join(dir, "node0/charon-enr-private-key"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	info, err = os.Stat(path.Join(dir, "lighthouse/run.sh"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+
+	b, err = os.ReadFile(path.Join(container, "restored", "slashing.db"))
+	require.NoError(t, err)
+	require.Equal(t, "db", string(b))
+
+	b, err = os.ReadFile(cmdLog)
+	require.NoError(t, err)
+	require.Equal(t, "ps\ncp\ndown\nup\ncp\n", string(b))
+}
+
+func TestSnapshotName(t *testing.T) {
+	_, err := Snapshot(context.Background(), t.TempDir(), "../escape", nil)
+	require.ErrorContains(t, err, "invalid snapshot name")
+
+	err = Restore(context.Background(), t.TempDir(), "missing")
+	require.ErrorContains(t, err, "snapshot not found")
+}
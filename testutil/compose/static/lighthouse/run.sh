@@ -21,7 +21,8 @@ done
 
 
 echo "Starting lighthouse validator client for ${NODE}"
-exec lighthouse validator \
+# FAKETIME skews the validator client clock if configured by a compose fault profile.
+exec ${FAKETIME:+faketime -f "${FAKETIME}"} lighthouse validator \
   --testnet-dir "/tmp/testnet" \
   --beacon-nodes "http://${NODE}:3600" \
   --suggested-fee-recipient "0x0000000000000000000000000000000000000000"
@@ -22,7 +22,8 @@ done
 
 echo "Imported all keys"
 
-node /usr/app/packages/cli/bin/lodestar validator \
+# FAKETIME skews the validator client clock if configured by a compose fault profile.
+exec ${FAKETIME:+faketime -f "${FAKETIME}"} node /usr/app/packages/cli/bin/lodestar validator \
     --network="dev" \
     --metrics=true \
     --metrics.address="0.0.0.0" \
@@ -0,0 +1,31 @@
+#!/usr/bin/env bash
+
+while ! curl "http://${NODE}:3600/up" 2>/dev/null; do
+  echo "Waiting for http://${NODE}:3600/up to become available..."
+  sleep 5
+done
+
+# Nimbus imports all keystores in a directory with a single password, so import them one at a time.
+tmpkeys="/tmp/validator_keys"
+mkdir -p "${tmpkeys}"
+
+for f in /compose/"${NODE}"/validator_keys/keystore-*.json; do
+  echo "Importing key ${f}"
+  cp "${f}" "${tmpkeys}"
+  cat "${f%.json}.txt" | /home/user/nimbus_beacon_node deposits import \
+    --data-dir=/home/user/data \
+    "${tmpkeys}"
+  rm "${tmpkeys}"/*.json
+done
+
+echo "Starting nimbus validator client for ${NODE}"
+# FAKETIME skews the validator client clock if configured by a compose fault profile.
+exec ${FAKETIME:+faketime -f "${FAKETIME}"} /home/user/nimbus_validator_client \
+  --data-dir=/home/user/data \
+  --beacon-node="http://${NODE}:3600" \
+  --doppelganger-detection=false \
+  --metrics \
+  --metrics-address=0.0.0.0 \
+  --metrics-port=5064 \
+  --suggested-fee-recipient="0x0000000000000000000000000000000000000000" \
+  --distributed
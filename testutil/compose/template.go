@@ -34,15 +34,52 @@ type TmplData struct {
 	Monitoring      bool
 	Alerting        bool
 	MonitoringPorts bool
+	// HostGateway adds a host.docker.internal host entry resolving to the docker host to the charon nodes.
+	HostGateway bool
+	// Chaos adds a netem sidecar sharing each node's network namespace for network fault injection.
+	Chaos bool
+	// ProjectName is the compose project name, isolating the containers and networks of concurrent clusters.
+	// Empty defaults to the compose dir name.
+	ProjectName string
+	// RemoteDir is the compose dir on the remote docker host bind mounted into containers, empty for the local docker host.
+	RemoteDir string
+	// PortOffset is added to the monitoring host ports.
+	PortOffset int
+	// ImageLock pins the upstream images.
+	ImageLock ImageLock
+}
+
+// Image returns the upstream image reference pinned to its digest if pinned by the image lock.
+func (d TmplData) Image(ref string) string {
+	return d.ImageLock.pin(ref)
+}
+
+// HostPath returns the path, relative to the compose dir, bind mounted into containers from the docker host.
+func (d TmplData) HostPath(rel string) string {
+	if d.RemoteDir != "" {
+		return path.Join(d.RemoteDir, rel)
+	} else if rel == "." {
+		return rel
+	}
+
+	return "./" + rel
+}
+
+// HostPort returns the host port of the monitoring port.
+func (d TmplData) HostPort(port int) int {
+	return port + d.PortOffset
 }
 
 // TmplVC represents a validator client service in a docker-compose.yml.
 type TmplVC struct {
-	Label   string
-	Image   string
-	Build   string
-	Command string
-	Ports   []port
+	Type      VCType // Type is the validator client type, mock validator clients have no Label and aren't rendered.
+	Label     string
+	Image     string
+	Build     string
+	Command   string
+	Ports     []port
+	EnvVars   []kv // EnvVars are additional raw environment variables.
+	BuildArgs []kv // BuildArgs are the Dockerfile build args, e.g. pinning base images.
 }
 
 // TmplNode represents a charon TmplNode service in a docker-compose.yml.
@@ -52,6 +89,17 @@ type TmplNode struct {
 	Command    string // Command is empty by default, resulting in CharonCommand being used.
 	EnvVars    []kv
 	Ports      []port
+	SlowDisk   *SlowDisk  // SlowDisk limits the node's disk throughput if not nil.
+	Resources  *Resources // Resources constrains the node's CPU, memory and IO if not nil.
+}
+
+// IOWeight returns the node's block IO weight or zero if not constrained.
+func (n TmplNode) IOWeight() int {
+	if n.Resources == nil {
+		return 0
+	}
+
+	return n.Resources.IOWeight
 }
 
 // kv is a key value pair.
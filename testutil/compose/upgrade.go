@@ -0,0 +1,138 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// UpgradeConfig configures a rolling upgrade test, starting the cluster on one charon version
+// and upgrading the nodes one at a time to another.
+type UpgradeConfig struct {
+	// FromImageTag is the charon docker image tag all nodes are started on.
+	FromImageTag string
+	// ToImageTag is the charon docker image tag nodes are upgraded to, empty defaults to the compose config's image tag.
+	ToImageTag string
+	// Interval is the period before each node upgrade and before checking duties after it.
+	Interval time.Duration
+	// MinSuccessRate is the minimum ratio of successful to expected duties per duty type, checked after each upgrade.
+	MinSuccessRate float64
+}
+
+// DefaultUpgradeConfig returns the default rolling upgrade config upgrading from the image tag.
+func DefaultUpgradeConfig(fromImageTag string) UpgradeConfig {
+	return UpgradeConfig{
+		FromImageTag:   fromImageTag,
+		Interval:       time.Second * 30,
+		MinSuccessRate: 1,
+	}
+}
+
+// validate returns an error if the upgrade config is invalid.
+func (c UpgradeConfig) validate() error {
+	if c.FromImageTag == "" {
+		return errors.New("upgrade from image tag required")
+	} else if c.FromImageTag == c.ToImageTag {
+		return errors.New("upgrade from and to image tags are identical", z.Str("tag", c.FromImageTag))
+	} else if c.Interval <= 0 {
+		return errors.New("upgrade interval must be positive")
+	} else if c.MinSuccessRate < 0 || c.MinSuccessRate > 1 {
+		return errors.New("invalid upgrade min success rate", z.Any("rate", c.MinSuccessRate))
+	}
+
+	return nil
+}
+
+// upgradeResult is the result of upgrading a node.
+type upgradeResult struct {
+	Node       int
+	Violations []string
+}
+
+// pinImageTag pins the node to the charon docker image tag, using the image's contained binary.
+func pinImageTag(node *TmplNode, imageTag string) {
+	node.ImageTag = imageTag
+	node.Entrypoint = containerBinary
+}
+
+// upgradeNode returns the template data with the node upgraded to the image tag, or to the
+// default charon image tag if empty.
+func upgradeNode(data TmplData, node int, imageTag string) TmplData {
+	nodes := append([]TmplNode(nil), data.Nodes...)
+	if imageTag == "" || imageTag == data.CharonImageTag {
+		nodes[node].ImageTag = ""
+		nodes[node].Entrypoint = ""
+	} else {
+		pinImageTag(&nodes[node], imageTag)
+	}
+
+	data.Nodes = nodes
+
+	return data
+}
+
+// startUpgrade starts a goroutine that upgrades the nodes one at a time, recreating their containers,
+// and returns a channel on which the duty performance violations after each node upgrade are sent.
+// The channel is closed when all nodes are upgraded or the context is closed.
+func startUpgrade(ctx context.Context, engine Engine, dir string, data TmplData, conf UpgradeConfig) chan upgradeResult {
+	ctx = log.WithTopic(ctx, "upgrade")
+	resp := make(chan upgradeResult, len(data.Nodes))
+
+	go func() {
+		defer close(resp)
+
+		for node := range data.Nodes {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(conf.Interval):
+			}
+
+			log.Info(ctx, "Upgrading node", z.Int("node", node),
+				z.Str("from", conf.FromImageTag), z.Str("to", conf.ToImageTag))
+
+			data = upgradeNode(data, node, conf.ToImageTag)
+			if err := WriteDockerCompose(dir, data); err != nil {
+				log.Error(ctx, "Failed writing upgraded docker-compose.yml", err)
+				return
+			}
+
+			// Recreates the node's container with the upgraded image.
+			out, err := engine.ComposeCmd(ctx, dir, "up", "--detach", "--no-deps", "--quiet-pull", nodeService(node)).CombinedOutput()
+			if ctx.Err() != nil {
+				return
+			} else if err != nil {
+				resp <- upgradeResult{Node: node, Violations: []string{fmt.Sprintf("upgrade node%d: %v: %s", node, err, out)}}
+				return
+			}
+
+			// Let the upgraded node catch up before checking duties.
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(conf.Interval):
+			}
+
+			var violations []string
+			if conf.MinSuccessRate > 0 {
+				violations, err = checkDutySuccess(ctx, engine, dir, conf.MinSuccessRate)
+				if ctx.Err() != nil {
+					return
+				} else if err != nil {
+					violations = []string{"check duty success rates: " + err.Error()}
+				}
+			}
+
+			log.Info(ctx, "Upgraded node", z.Int("node", node), z.Any("violations", violations))
+			resp <- upgradeResult{Node: node, Violations: violations}
+		}
+	}()
+
+	return resp
+}
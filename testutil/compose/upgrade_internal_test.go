@@ -0,0 +1,42 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeNode(t *testing.T) {
+	data := TmplData{CharonImageTag: "local", Nodes: make([]TmplNode, 3)}
+	for i := range data.Nodes {
+		pinImageTag(&data.Nodes[i], "v1.0.0")
+	}
+
+	upgraded := upgradeNode(data, 1, "")
+	require.Equal(t, TmplNode{}, upgraded.Nodes[1])
+	require.Equal(t, "v1.0.0", upgraded.Nodes[0].ImageTag)
+	require.Equal(t, containerBinary, upgraded.Nodes[2].Entrypoint)
+
+	// The original template data isn't modified.
+	require.Equal(t, "v1.0.0", data.Nodes[1].ImageTag)
+
+	upgraded = upgradeNode(upgraded, 2, "v1.1.0")
+	require.Equal(t, TmplNode{ImageTag: "v1.1.0", Entrypoint: containerBinary}, upgraded.Nodes[2])
+}
+
+func TestUpgradeConfigValidate(t *testing.T) {
+	require.NoError(t, DefaultUpgradeConfig("v1.0.0").validate())
+
+	for _, fn := range []func(*UpgradeConfig){
+		func(c *UpgradeConfig) { c.FromImageTag = "" },
+		func(c *UpgradeConfig) { c.ToImageTag = c.FromImageTag },
+		func(c *UpgradeConfig) { c.Interval = 0 },
+		func(c *UpgradeConfig) { c.MinSuccessRate = 2 },
+	} {
+		conf := DefaultUpgradeConfig("v1.0.0")
+		fn(&conf)
+		require.Error(t, conf.validate())
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// watchFailedQuery is the prometheus query returning the number of failed duties per node and duty type.
+	watchFailedQuery = `sum by (job, duty) (core_tracker_failed_duties_total)`
+	// watchPeersQuery is the prometheus query returning the number of peers successfully pinged per node.
+	watchPeersQuery = `sum by (job) (p2p_ping_success)`
+	// watchConsensusQuery is the prometheus query returning the 90th percentile consensus duration per node.
+	watchConsensusQuery = `histogram_quantile(0.9, sum by (job, le) (rate(core_consensus_duration_seconds_bucket[1m])))`
+
+	// clearScreen moves the cursor home and clears the terminal.
+	clearScreen = "\033[H\033[2J"
+)
+
+// watchNode is the live status of a charon node.
+type watchNode struct {
+	Node         string
+	Peers        float64
+	ConsensusP90 float64 // ConsensusP90 is NaN if no consensus instances completed recently.
+	Duties       map[string]watchDuty
+}
+
+// watchDuty are the successful and failed duties of a duty type.
+type watchDuty struct {
+	Success float64
+	Failed  float64
+}
+
+// Watch renders a terminal dashboard of the per-node duty successes and failures, peer connectivity and consensus
+// latencies of the running compose cluster, queried from its prometheus service, to the writer every interval
+// until the context is closed.
+func Watch(ctx context.Context, dir string, interval time.Duration, w io.Writer) error {
+	conf, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
+
+	engine, err := newConfEngine(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		nodes, err := queryWatchNodes(ctx, engine, dir, conf.NumNodes)
+		if ctx.Err() != nil {
+			return nil //nolint:nilerr // Watching until the context is closed isn't an error.
+		}
+
+		_, _ = io.WriteString(w, clearScreen)
+		if err := renderWatch(w, nodes, conf.NumNodes, time.Now(), err); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// queryWatchNodes returns the live status of the nodes queried from prometheus.
+func queryWatchNodes(ctx context.Context, engine Engine, dir string, numNodes int) ([]watchNode, error) {
+	nodes := make(map[string]*watchNode)
+	for i := range numNodes {
+		name := fmt.Sprintf("node%d", i)
+		nodes[name] = &watchNode{Node: name, ConsensusP90: math.NaN(), Duties: make(map[string]watchDuty)}
+	}
+
+	setters := map[string]func(node *watchNode, duty string, val float64){
+		successDutiesQuery: func(node *watchNode, duty string, val float64) {
+			d := node.Duties[duty]
+			d.Success = val
+			node.Duties[duty] = d
+		},
+		watchFailedQuery: func(node *watchNode, duty string, val float64) {
+			d := node.Duties[duty]
+			d.Failed = val
+			node.Duties[duty] = d
+		},
+		watchPeersQuery: func(node *watchNode, _ string, val float64) {
+			node.Peers = val
+		},
+		watchConsensusQuery: func(node *watchNode, _ string, val float64) {
+			node.ConsensusP90 = val
+		},
+	}
+
+	for _, query := range []string{successDutiesQuery, watchFailedQuery, watchPeersQuery, watchConsensusQuery} {
+		out, err := execPromQuery(ctx, engine, dir, query)
+		if err != nil {
+			return nil, err
+		}
+
+		samples, err := parsePromSamples(out)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sample := range samples {
+			node, ok := nodes[sample.Metric["job"]]
+			if !ok {
+				continue // Ignore relay and other jobs.
+			}
+			setters[query](node, sample.Metric["duty"], sample.Value)
+		}
+	}
+
+	var resp []watchNode
+	for i := range numNodes {
+		resp = append(resp, *nodes[fmt.Sprintf("node%d", i)])
+	}
+
+	return resp, nil
+}
+
+// PromSample is a sample of a prometheus instant vector query response.
+type PromSample struct {
+	// Metric are the labels of the sample.
+	Metric map[string]string
+	Value  float64
+}
+
+// parsePromSamples returns the samples of the prometheus instant vector query response.
+func parsePromSamples(b []byte) ([]PromSample, error) {
+	var query promQuery
+	if err := json.Unmarshal(b, &query); err != nil {
+		return nil, errors.Wrap(err, "unmarshal query response", z.Str("out", string(b)))
+	} else if query.Status != "success" {
+		return nil, errors.New("non success status from prometheus query", z.Str("status", query.Status))
+	}
+
+	var resp []PromSample
+	for _, result := range query.Data.Result {
+		if len(result.Value) != 2 {
+			return nil, errors.New("invalid prometheus query result value")
+		}
+
+		valStr, ok := result.Value[1].(string)
+		if !ok {
+			return nil, errors.New("invalid prometheus query result value")
+		}
+
+		val, err := strconv.ParseFloat(valStr, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse prometheus query result value")
+		}
+
+		resp = append(resp, PromSample{Metric: result.Metric, Value: val})
+	}
+
+	return resp, nil
+}
+
+// renderWatch renders the node statuses as a table, with the query error if not nil.
+func renderWatch(w io.Writer, nodes []watchNode, numNodes int, now time.Time, queryErr error) error {
+	_, _ = fmt.Fprintf(w, "compose watch: %s\n\n", now.Format(time.TimeOnly))
+
+	if queryErr != nil {
+		_, _ = fmt.Fprintf(w, "Querying prometheus failed, is the cluster running? %v\n", queryErr)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NODE\tPEERS\tCONSENSUS P90\tDUTY\tSUCCESS\tFAILED")
+
+	for _, node := range nodes {
+		latency := "-"
+		if !math.IsNaN(node.ConsensusP90) {
+			latency = time.Duration(node.ConsensusP90 * float64(time.Second)).Round(time.Millisecond).String()
+		}
+
+		peers := fmt.Sprintf("%.0f/%d", node.Peers, numNodes-1)
+
+		var duties []string
+		for duty := range node.Duties {
+			duties = append(duties, duty)
+		}
+		sort.Strings(duties)
+
+		if len(duties) == 0 {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t-\t-\t-\n", node.Node, peers, latency)
+			continue
+		}
+
+		for i, duty := range duties {
+			if i > 0 {
+				_, _ = fmt.Fprintf(tw, "\t\t\t%s\t%.0f\t%.0f\n", duty, node.Duties[duty].Success, node.Duties[duty].Failed)
+				continue
+			}
+
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%.0f\t%.0f\n", node.Node, peers, latency,
+				duty, node.Duties[duty].Success, node.Duties[duty].Failed)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, "flush table")
+	}
+
+	return nil
+}
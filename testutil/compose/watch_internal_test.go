@@ -0,0 +1,49 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestWatch(t *testing.T) {
+	// The fake engine returns prometheus responses by query.
+	const script = `case "$*" in
+		*success_duties*) echo '{"status":"success","data":{"result":[
+			{"metric":{"job":"node0","duty":"attester"},"value":[0,"10"]},
+			{"metric":{"job":"node0","duty":"proposer"},"value":[0,"1"]},
+			{"metric":{"job":"node1","duty":"attester"},"value":[0,"8"]}]}}' ;;
+		*failed_duties*) echo '{"status":"success","data":{"result":[
+			{"metric":{"job":"node1","duty":"attester"},"value":[0,"2"]}]}}' ;;
+		*ping_success*) echo '{"status":"success","data":{"result":[
+			{"metric":{"job":"node0"},"value":[0,"2"]},
+			{"metric":{"job":"node1"},"value":[0,"1"]},
+			{"metric":{"job":"relay"},"value":[0,"3"]}]}}' ;;
+		*consensus_duration*) echo '{"status":"success","data":{"result":[
+			{"metric":{"job":"node0"},"value":[0,"0.1234"]},
+			{"metric":{"job":"node1"},"value":[0,"NaN"]}]}}' ;;
+	esac`
+	fakeEngine := cliEngine{name: "fake", compose: []string{"sh", "-c", script, "sh"}}
+
+	nodes, err := queryWatchNodes(context.Background(), fakeEngine, t.TempDir(), 3)
+	require.NoError(t, err)
+	require.Len(t, nodes, 3)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var buf bytes.Buffer
+	require.NoError(t, renderWatch(&buf, nodes, 3, now, nil))
+	testutil.RequireGoldenBytes(t, buf.Bytes())
+
+	buf.Reset()
+	require.NoError(t, renderWatch(&buf, nil, 3, now, errors.New("exec curl")))
+	require.Contains(t, buf.String(), "Querying prometheus failed, is the cluster running? exec curl")
+}
@@ -47,7 +47,7 @@ func TestPingCluster(t *testing.T) {
 	t.Run("relay_discovery_externalhost", func(t *testing.T) {
 		pingCluster(t, pingTest{
 			BindLocalhost: true,
-			ExternalHost:  "localhost",
+			ExternalHosts: []string{"localhost"},
 			AddrFilter:    "dns",
 		})
 	})
@@ -58,7 +58,7 @@ func TestPingCluster(t *testing.T) {
 	t.Run("relay_incorrect_externalhost", func(t *testing.T) {
 		pingCluster(t, pingTest{
 			BindLocalhost: true,
-			ExternalIP:    "222.222.222.22",
+			ExternalIPs:   []string{"222.222.222.22"},
 		})
 	})
 }
@@ -66,8 +66,8 @@ func TestPingCluster(t *testing.T) {
 type pingTest struct {
 	BindLocalhost bool
 	BindZeroIP    bool
-	ExternalIP    string
-	ExternalHost  string
+	ExternalIPs   []string
+	ExternalHosts []string
 	AddrFilter    string // Regexp filter for advertised libp2p addresses.
 }
 
@@ -115,9 +115,9 @@ func pingCluster(t *testing.T, test pingTest) {
 				LibP2POpts: []libp2p.Option{newAddrFactoryFilter(test.AddrFilter)},
 			},
 			P2P: p2p.Config{
-				Relays:       []string{relayAddr},
-				ExternalHost: test.ExternalHost,
-				ExternalIP:   test.ExternalIP,
+				Relays:        []string{relayAddr},
+				ExternalHosts: test.ExternalHosts,
+				ExternalIPs:   test.ExternalIPs,
 			},
 		}
 
@@ -0,0 +1,58 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package obolapimock
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+const (
+	configHashPath = "{config_hash}"
+
+	definitionTmpl = "/dv/" + configHashPath
+	lockTmpl       = "/lock/" + lockHashPath
+)
+
+// HandleGetDefinition returns the definition of a stored lock file by its config hash.
+func (ts *testServer) HandleGetDefinition(writer http.ResponseWriter, request *http.Request) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	configHash := mux.Vars(request)[cleanTmpl(configHashPath)]
+
+	for _, lock := range ts.lockFiles {
+		if "0x"+hex.EncodeToString(lock.ConfigHash) != configHash {
+			continue
+		}
+
+		if err := json.NewEncoder(writer).Encode(lock.Definition); err != nil {
+			writeErr(writer, http.StatusInternalServerError, errors.Wrap(err, "cannot marshal definition").Error())
+		}
+
+		return
+	}
+
+	writeErr(writer, http.StatusNotFound, "definition not found")
+}
+
+// HandleGetLock returns a stored lock file by its lock hash.
+func (ts *testServer) HandleGetLock(writer http.ResponseWriter, request *http.Request) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	lock, ok := ts.lockFiles[mux.Vars(request)[cleanTmpl(lockHashPath)]]
+	if !ok {
+		writeErr(writer, http.StatusNotFound, "lock not found")
+		return
+	}
+
+	if err := json.NewEncoder(writer).Encode(lock); err != nil {
+		writeErr(writer, http.StatusInternalServerError, errors.Wrap(err, "cannot marshal lock").Error())
+	}
+}
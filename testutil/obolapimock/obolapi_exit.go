@@ -36,6 +36,7 @@ const (
 	fullExitEndTmp   = "/" + lockHashPath + "/" + shareIndexPath + "/" + valPubkeyPath
 
 	partialExitTmpl = "/exp/partial_exits/" + lockHashPath
+	exitStatusTmpl  = partialExitTmpl + "/" + valPubkeyPath
 )
 
 type contextKey string
@@ -278,6 +279,39 @@ func (ts *testServer) HandleFullExit(writer http.ResponseWriter, request *http.R
 	}
 }
 
+func (ts *testServer) HandleExitStatus(writer http.ResponseWriter, request *http.Request) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	vars := mux.Vars(request)
+
+	valPubkey := vars[cleanTmpl(valPubkeyPath)]
+	lockHash := vars[cleanTmpl(lockHashPath)]
+
+	if _, ok := ts.lockFiles[lockHash]; !ok {
+		writeErr(writer, http.StatusNotFound, "lock not found")
+		return
+	}
+
+	partialExits, ok := ts.partialExits[valPubkey]
+	if !ok {
+		writeErr(writer, http.StatusNotFound, "validator not found")
+		return
+	}
+
+	var ret obolapi.ExitStatusResponse
+	for _, pExit := range partialExits {
+		ret.ShareIndices = append(ret.ShareIndices, pExit.shareIdx)
+		ret.Epoch = strconv.FormatUint(uint64(pExit.SignedExitMessage.Message.Epoch), 10)
+		ret.ValidatorIndex = pExit.SignedExitMessage.Message.ValidatorIndex
+	}
+
+	if err := json.NewEncoder(writer).Encode(ret); err != nil {
+		writeErr(writer, http.StatusInternalServerError, errors.Wrap(err, "cannot marshal exit status").Error())
+		return
+	}
+}
+
 func (ts *testServer) partialExitsMatch(newOne obolapi.ExitBlob) bool {
 	// get the last one
 	exitsLen := len(ts.partialExits[newOne.PublicKey])
@@ -332,6 +366,9 @@ func MockServer(dropOnePsig bool, beacon eth2wrap.Client) (http.Handler, func(lo
 	full.HandleFunc(fullExitEndTmp, ts.HandleFullExit).Methods(http.MethodGet)
 
 	router.HandleFunc(partialExitTmpl, ts.HandlePartialExit).Methods(http.MethodPost)
+	router.HandleFunc(exitStatusTmpl, ts.HandleExitStatus).Methods(http.MethodGet)
+	router.HandleFunc(definitionTmpl, ts.HandleGetDefinition).Methods(http.MethodGet)
+	router.HandleFunc(lockTmpl, ts.HandleGetLock).Methods(http.MethodGet)
 
 	return router, ts.addLockFiles
 }
@@ -250,7 +250,7 @@ func prepareAggregators(ctx context.Context, eth2Cl eth2wrap.Client, signFunc Si
 			return nil, errors.New("missing validator index")
 		}
 
-		slotSig, err := signFunc(pubkey, sigData[:])
+		slotSig, err := signFunc(pubkey, sigData[:], slot)
 		if err != nil {
 			return nil, err
 		}
@@ -326,7 +326,7 @@ func attest(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, slot
 		}
 
 		for _, duty := range duties {
-			sig, err := signFunc(duty.PubKey, sigData[:])
+			sig, err := signFunc(duty.PubKey, sigData[:], data)
 			if err != nil {
 				return nil, err
 			}
@@ -409,7 +409,7 @@ func aggregate(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, s
 			return false, errors.New("missing validator index", z.U64("vidx", uint64(selection.ValidatorIndex)))
 		}
 
-		proofSig, err := signFunc(pubkey, sigData[:])
+		proofSig, err := signFunc(pubkey, sigData[:], &proof)
 		if err != nil {
 			return false, err
 		}
@@ -40,6 +40,7 @@ func New(ctx context.Context,
 	slotDuration time.Duration,
 	slotsPerEpoch uint64,
 	builderAPI bool,
+	opts ...Option,
 ) *Component {
 	c := &Component{
 		eth2ClProvider: eth2ClProvider,
@@ -54,8 +55,15 @@ func New(ctx context.Context,
 		attestersBySlot:  make(map[uint64]*SlotAttester),
 		syncCommsByEpoch: make(map[uint64]*SyncCommMember),
 		scheduled:        make(chan scheduleTuple),
+		recorder:         newDutyRecorder(),
 	}
 
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.signFunc = c.faults.SignFunc(log.WithTopic(ctx, "vmock"), c.signFunc)
+
 	go c.Run(ctx)
 
 	return c
@@ -73,6 +81,9 @@ type Component struct {
 	meta           specMeta
 	scheduled      chan scheduleTuple
 	builderAPI     bool
+	faults         *faultInjector // Nil if no faults are injected.
+	recorder       *dutyRecorder
+	reportFile     string
 
 	// Mutable state.
 	mu               sync.Mutex
@@ -126,6 +137,7 @@ func (m *Component) Run(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
+			m.writeReport(ctx)
 			return
 		case next := <-m.scheduled:
 			// Schedule duty async
@@ -134,7 +146,17 @@ func (m *Component) Run(ctx context.Context) {
 				case <-ctx.Done():
 					return
 				case <-sleepUntil(scheduled.startTime):
+					if m.faults.Skip(ctx, scheduled.duty) {
+						m.recorder.Skipped(scheduled.duty)
+						return
+					}
+
 					err := m.runDuty(ctx, scheduled.duty)
+					if ctx.Err() != nil {
+						return // Don't record duties interrupted by shutdown.
+					}
+
+					m.recorder.Completed(scheduled.duty, time.Since(scheduled.startTime), err)
 					if err != nil {
 						log.Warn(ctx, "Duty failed", err, z.Any("duty", scheduled.duty))
 					}
@@ -144,6 +166,22 @@ func (m *Component) Run(ctx context.Context) {
 	}
 }
 
+// Report returns the duty report of the duties performed so far.
+func (m *Component) Report() DutyReport {
+	return m.recorder.Report()
+}
+
+// writeReport writes the duty report to the report file if configured.
+func (m *Component) writeReport(ctx context.Context) {
+	if m.reportFile == "" {
+		return
+	}
+
+	if err := writeReport(m.reportFile, m.Report()); err != nil {
+		log.Warn(ctx, "Failed writing validator mock duty report", err)
+	}
+}
+
 // SlotTicked is called when a slot ticks/starts. This is called by the scheduler component.
 // This is only called once per slot.
 func (m *Component) SlotTicked(ctx context.Context, slot core.Slot) error {
@@ -181,6 +219,8 @@ func (m *Component) scheduleSlot(ctx context.Context, slot metaSlot) error {
 		if err != nil {
 			return err
 		}
+
+		m.writeReport(ctx)
 	}
 
 	// Get duties to perform this slot
@@ -272,7 +312,12 @@ func (m *Component) runDuty(ctx context.Context, duty core.Duty) error {
 			return err
 		}
 	case core.DutyProposer:
-		if err = ProposeBlock(ctx, eth2Cl, m.signFunc, eth2Slot); err != nil {
+		propose := ProposeBlock
+		if m.builderAPI {
+			propose = ProposeBlindedBlock
+		}
+
+		if err = propose(ctx, eth2Cl, m.signFunc, eth2Slot); err != nil {
 			return err
 		}
 	case core.DutyBuilderProposer:
@@ -287,11 +332,8 @@ func (m *Component) runDuty(ctx context.Context, duty core.Duty) error {
 			return err
 		}
 
-		for pubshare, reg := range regs {
-			err = Register(ctx, eth2Cl, m.signFunc, reg, pubshare)
-			if err != nil {
-				return err
-			}
+		if err = RegisterAll(ctx, eth2Cl, m.signFunc, regs); err != nil {
+			return err
 		}
 	case core.DutyPrepareSyncContribution:
 		if syncComm == nil {
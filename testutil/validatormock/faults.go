@@ -0,0 +1,184 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatormock
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// Option configures the validator mock component.
+type Option func(*Component)
+
+// WithFaults configures the validator mock to inject the provided faults, exercising
+// charon's timeout, retry and tracker failure paths.
+func WithFaults(faults Faults) Option {
+	return func(c *Component) {
+		c.faults = newFaultInjector(faults)
+	}
+}
+
+// Faults configures failure and latency injection of the validator mock.
+// Rates are probabilities between 0 and 1, zero disables the fault.
+// Delays are uniformly distributed between MinDelay and MaxDelay.
+type Faults struct {
+	// SkipRate is the rate of duties that are skipped entirely.
+	SkipRate float64
+	// LateRate is the rate of signatures that are delayed.
+	LateRate float64
+	// MinDelay is the minimum delay of late signatures.
+	MinDelay time.Duration
+	// MaxDelay is the maximum delay of late signatures.
+	MaxDelay time.Duration
+	// MalformedRate is the rate of signatures that are replaced by random invalid signatures.
+	MalformedRate float64
+	// DutyTypes limits the faults to the provided duty types, all duty types are affected if empty.
+	DutyTypes []core.DutyType
+	// Seed is the random seed, making injected faults reproducible.
+	Seed int64
+}
+
+// newFaultInjector returns a new fault injector for the provided faults.
+func newFaultInjector(faults Faults) *faultInjector {
+	return &faultInjector{
+		faults: faults,
+		rand:   rand.New(rand.NewSource(faults.Seed)), //nolint:gosec // Reproducible weak random is fine for tests.
+	}
+}
+
+// faultInjector injects faults into validator mock duties. A nil fault injector is a valid noop.
+type faultInjector struct {
+	faults Faults
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// enabled returns true if faults are injected for the provided duty type.
+func (f *faultInjector) enabled(dutyType core.DutyType) bool {
+	if f == nil {
+		return false
+	} else if len(f.faults.DutyTypes) == 0 {
+		return true
+	}
+
+	for _, typ := range f.faults.DutyTypes {
+		if typ == dutyType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// roll returns true with the provided probability.
+func (f *faultInjector) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.rand.Float64() < rate
+}
+
+// delay returns a random delay between the configured min and max delays.
+func (f *faultInjector) delay() time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	spread := f.faults.MaxDelay - f.faults.MinDelay
+	if spread <= 0 {
+		return f.faults.MinDelay
+	}
+
+	return f.faults.MinDelay + time.Duration(f.rand.Int63n(int64(spread)))
+}
+
+// Skip returns true if the duty should be skipped.
+func (f *faultInjector) Skip(ctx context.Context, duty core.Duty) bool {
+	if !f.enabled(duty.Type) || !f.roll(f.faults.SkipRate) {
+		return false
+	}
+
+	log.Info(ctx, "Injected fault: skipping duty", z.Any("duty", duty))
+
+	return true
+}
+
+// SignFunc wraps the sign function with late and malformed signature faults.
+func (f *faultInjector) SignFunc(ctx context.Context, signFunc SignFunc) SignFunc {
+	if f == nil || (f.faults.LateRate <= 0 && f.faults.MalformedRate <= 0) {
+		return signFunc
+	}
+
+	return func(pubshare eth2p0.BLSPubKey, data []byte, msg any) (eth2p0.BLSSignature, error) {
+		dutyType := dutyTypeFromMsg(msg)
+		if !f.enabled(dutyType) {
+			return signFunc(pubshare, data, msg)
+		}
+
+		if f.roll(f.faults.LateRate) {
+			delay := f.delay()
+			log.Info(ctx, "Injected fault: delaying signature", z.Any("duty_type", dutyType), z.Any("delay", delay))
+
+			select {
+			case <-ctx.Done():
+				return eth2p0.BLSSignature{}, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		sig, err := signFunc(pubshare, data, msg)
+		if err != nil {
+			return eth2p0.BLSSignature{}, err
+		}
+
+		if f.roll(f.faults.MalformedRate) {
+			log.Info(ctx, "Injected fault: malformed signature", z.Any("duty_type", dutyType))
+
+			f.mu.Lock()
+			_, _ = f.rand.Read(sig[:])
+			f.mu.Unlock()
+		}
+
+		return sig, nil
+	}
+}
+
+// dutyTypeFromMsg returns the duty type of the message signed by the validator mock.
+func dutyTypeFromMsg(msg any) core.DutyType {
+	switch msg.(type) {
+	case eth2p0.Epoch:
+		return core.DutyRandao
+	case *eth2api.VersionedProposal:
+		return core.DutyProposer
+	case *eth2api.VersionedValidatorRegistration:
+		return core.DutyBuilderRegistration
+	case eth2p0.Slot:
+		return core.DutyPrepareAggregator
+	case *eth2p0.AttestationData:
+		return core.DutyAttester
+	case *eth2p0.AggregateAndProof:
+		return core.DutyAggregator
+	case *altair.SyncAggregatorSelectionData:
+		return core.DutyPrepareSyncContribution
+	case *altair.SyncCommitteeMessage:
+		return core.DutySyncMessage
+	case *altair.ContributionAndProof:
+		return core.DutySyncContribution
+	default:
+		return core.DutyUnknown
+	}
+}
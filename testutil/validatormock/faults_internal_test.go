@@ -0,0 +1,107 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatormock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestFaultsNoop(t *testing.T) {
+	ctx := context.Background()
+
+	var noop *faultInjector
+	require.False(t, noop.Skip(ctx, core.NewAttesterDuty(1)))
+
+	signFunc := func(eth2p0.BLSPubKey, []byte, any) (eth2p0.BLSSignature, error) {
+		return eth2p0.BLSSignature{1}, nil
+	}
+	sig, err := noop.SignFunc(ctx, signFunc)(eth2p0.BLSPubKey{}, nil, &eth2p0.AttestationData{})
+	require.NoError(t, err)
+	require.Equal(t, eth2p0.BLSSignature{1}, sig)
+}
+
+func TestFaultsSkip(t *testing.T) {
+	ctx := context.Background()
+
+	faults := newFaultInjector(Faults{SkipRate: 1, DutyTypes: []core.DutyType{core.DutyAttester}})
+	require.True(t, faults.Skip(ctx, core.NewAttesterDuty(1)))
+	require.False(t, faults.Skip(ctx, core.NewProposerDuty(1)))
+
+	// Skipped duties are reproducible given the same seed.
+	skips := func() []bool {
+		faults := newFaultInjector(Faults{SkipRate: 0.5, Seed: 1})
+
+		var resp []bool
+		for slot := range uint64(100) {
+			resp = append(resp, faults.Skip(ctx, core.NewAttesterDuty(slot)))
+		}
+
+		return resp
+	}
+	first := skips()
+	require.Equal(t, first, skips())
+	require.Contains(t, first, true)
+	require.Contains(t, first, false)
+}
+
+func TestFaultsSignFunc(t *testing.T) {
+	ctx := context.Background()
+
+	expect := testutil.RandomEth2Signature()
+	signFunc := func(eth2p0.BLSPubKey, []byte, any) (eth2p0.BLSSignature, error) {
+		return expect, nil
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		faults := newFaultInjector(Faults{MalformedRate: 1, DutyTypes: []core.DutyType{core.DutySyncMessage}})
+		sign := faults.SignFunc(ctx, signFunc)
+
+		sig, err := sign(eth2p0.BLSPubKey{}, nil, &altair.SyncCommitteeMessage{})
+		require.NoError(t, err)
+		require.NotEqual(t, expect, sig)
+
+		// Other duty types are not affected.
+		sig, err = sign(eth2p0.BLSPubKey{}, nil, &eth2p0.AttestationData{})
+		require.NoError(t, err)
+		require.Equal(t, expect, sig)
+	})
+
+	t.Run("late", func(t *testing.T) {
+		const delay = time.Millisecond * 50
+		faults := newFaultInjector(Faults{LateRate: 1, MinDelay: delay, MaxDelay: delay * 2})
+		sign := faults.SignFunc(ctx, signFunc)
+
+		t0 := time.Now()
+		sig, err := sign(eth2p0.BLSPubKey{}, nil, eth2p0.Slot(1))
+		require.NoError(t, err)
+		require.Equal(t, expect, sig)
+		require.GreaterOrEqual(t, time.Since(t0), delay)
+	})
+
+	t.Run("late cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		faults := newFaultInjector(Faults{LateRate: 1, MinDelay: time.Hour, MaxDelay: time.Hour})
+		_, err := faults.SignFunc(ctx, signFunc)(eth2p0.BLSPubKey{}, nil, eth2p0.Epoch(1))
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestDutyTypeFromMsg(t *testing.T) {
+	require.Equal(t, core.DutyRandao, dutyTypeFromMsg(eth2p0.Epoch(1)))
+	require.Equal(t, core.DutyPrepareAggregator, dutyTypeFromMsg(eth2p0.Slot(1)))
+	require.Equal(t, core.DutyAggregator, dutyTypeFromMsg(&eth2p0.AggregateAndProof{}))
+	require.Equal(t, core.DutyPrepareSyncContribution, dutyTypeFromMsg(&altair.SyncAggregatorSelectionData{}))
+	require.Equal(t, core.DutySyncContribution, dutyTypeFromMsg(&altair.ContributionAndProof{}))
+	require.Equal(t, core.DutyUnknown, dutyTypeFromMsg("unknown"))
+}
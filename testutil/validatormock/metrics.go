@@ -0,0 +1,40 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatormock
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	dutyCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vmock",
+		Subsystem: "duty",
+		Name:      "total",
+		Help:      "Total number of duties attempted by the validator mock by type",
+	}, []string{"duty"})
+
+	dutyErrorCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vmock",
+		Subsystem: "duty",
+		Name:      "errors_total",
+		Help:      "Total number of duties failed by the validator mock by type",
+	}, []string{"duty"})
+
+	dutySkippedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vmock",
+		Subsystem: "duty",
+		Name:      "skipped_total",
+		Help:      "Total number of duties skipped by the validator mock due to injected faults by type",
+	}, []string{"duty"})
+
+	dutyLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "vmock",
+		Subsystem: "duty",
+		Name:      "latency_seconds",
+		Help:      "Latency in seconds of successful validator mock duties from their scheduled start time by type",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 20, 30, 60},
+	}, []string{"duty"})
+)
@@ -6,6 +6,7 @@ package validatormock
 import (
 	"context"
 	"encoding/hex"
+	"math"
 	"strings"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
@@ -22,19 +23,34 @@ import (
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/signing"
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
 )
 
-// SignFunc abstract signing done by the validator client.
-type SignFunc func(pubshare eth2p0.BLSPubKey, data []byte) (eth2p0.BLSSignature, error)
+// SignFunc abstract signing done by the validator client. The data is the signing root of the message,
+// which is also provided for signers that need the message itself, e.g. remote signers applying slashing protection.
+type SignFunc func(pubshare eth2p0.BLSPubKey, data []byte, msg any) (eth2p0.BLSSignature, error)
 
 // ProposeBlock proposes block for the given slot.
 func ProposeBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc,
 	slot eth2p0.Slot,
 ) error {
+	return propose(ctx, eth2Cl, signFunc, slot, false)
+}
+
+// ProposeBlindedBlock proposes a blinded block for the given slot, requesting maximum builder boost
+// like validator clients with builder API (MEV) enabled. It returns an error if the proposal isn't blinded.
+func ProposeBlindedBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc,
+	slot eth2p0.Slot,
+) error {
+	return propose(ctx, eth2Cl, signFunc, slot, true)
+}
+
+// propose proposes a block for the given slot, requiring a blinded block if builder is true.
+func propose(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, slot eth2p0.Slot, builder bool) error {
 	valMap, err := eth2Cl.ActiveValidators(ctx)
 	if err != nil {
 		return err
@@ -90,7 +106,7 @@ func ProposeBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc
 		return err
 	}
 
-	randao, err := signFunc(slotProposer.PubKey, randaoSigData[:])
+	randao, err := signFunc(slotProposer.PubKey, randaoSigData[:], epoch)
 	if err != nil {
 		return err
 	}
@@ -100,6 +116,10 @@ func ProposeBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc
 		Slot:         slot,
 		RandaoReveal: randao,
 	}
+	if builder {
+		bbf := uint64(math.MaxUint64) // Always prefer builder blocks.
+		proposalOpts.BuilderBoostFactor = &bbf
+	}
 	eth2ProposalResp, err := eth2Cl.Proposal(ctx, proposalOpts)
 	if err != nil {
 		return errors.Wrap(err, "vmock beacon block proposal")
@@ -108,6 +128,8 @@ func ProposeBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc
 
 	if block == nil {
 		return errors.New("block not found")
+	} else if builder && !block.Blinded {
+		return errors.New("builder api enabled but proposal not blinded", z.U64("slot", uint64(slot)))
 	}
 
 	// Sign beacon block
@@ -121,7 +143,7 @@ func ProposeBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc
 		return err
 	}
 
-	sig, err := signFunc(pubkey, blockSigData[:])
+	sig, err := signFunc(pubkey, blockSigData[:], block)
 	if err != nil {
 		return err
 	}
@@ -250,24 +272,58 @@ func RegistrationsFromProposerConfig(ctx context.Context, eth2Cl eth2wrap.Client
 func Register(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc,
 	registration *eth2api.VersionedValidatorRegistration, pubshare eth2p0.BLSPubKey,
 ) error {
-	sigRoot, err := registration.Root()
+	signed, err := signRegistration(ctx, eth2Cl, signFunc, registration, pubshare)
 	if err != nil {
 		return err
 	}
 
+	return eth2Cl.SubmitValidatorRegistrations(ctx, []*eth2api.VersionedSignedValidatorRegistration{signed})
+}
+
+// RegisterAll signs and submits all the validator builder registrations to the validator API in a single request,
+// like validator clients do.
+func RegisterAll(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc,
+	registrations map[eth2p0.BLSPubKey]*eth2api.VersionedValidatorRegistration,
+) error {
+	if len(registrations) == 0 {
+		return nil
+	}
+
+	var signed []*eth2api.VersionedSignedValidatorRegistration
+	for pubshare, registration := range registrations {
+		reg, err := signRegistration(ctx, eth2Cl, signFunc, registration, pubshare)
+		if err != nil {
+			return err
+		}
+
+		signed = append(signed, reg)
+	}
+
+	return eth2Cl.SubmitValidatorRegistrations(ctx, signed)
+}
+
+// signRegistration returns the signed validator builder registration.
+func signRegistration(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc,
+	registration *eth2api.VersionedValidatorRegistration, pubshare eth2p0.BLSPubKey,
+) (*eth2api.VersionedSignedValidatorRegistration, error) {
+	sigRoot, err := registration.Root()
+	if err != nil {
+		return nil, err
+	}
+
 	// Always use epoch 0 for DomainApplicationBuilder
 	sigData, err := signing.GetDataRoot(ctx, eth2Cl, signing.DomainApplicationBuilder, 0, sigRoot)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	sig, err := signFunc(pubshare, sigData[:])
+	sig, err := signFunc(pubshare, sigData[:], registration)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// create signed builder registration
-	signedRegistration := new(eth2api.VersionedSignedValidatorRegistration)
+	signedRegistration := &eth2api.VersionedSignedValidatorRegistration{Version: registration.Version}
 	switch signedRegistration.Version {
 	case eth2spec.BuilderVersionV1:
 		signedRegistration.V1 = &eth2v1.SignedValidatorRegistration{
@@ -275,10 +331,10 @@ func Register(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc,
 			Signature: sig,
 		}
 	default:
-		return errors.New("invalid registration")
+		return nil, errors.New("invalid registration")
 	}
 
-	return eth2Cl.SubmitValidatorRegistrations(ctx, []*eth2api.VersionedSignedValidatorRegistration{signedRegistration})
+	return signedRegistration, nil
 }
 
 // NewSigner returns a signing function supporting the provided private keys.
@@ -298,13 +354,13 @@ func NewSigner(secrets ...tbls.PrivateKey) (SignFunc, error) {
 		secretByPubkey[eth2Pubkey] = secret
 	}
 
-	return func(pubkey eth2p0.BLSPubKey, msg []byte) (eth2p0.BLSSignature, error) {
+	return func(pubkey eth2p0.BLSPubKey, data []byte, _ any) (eth2p0.BLSSignature, error) {
 		secret, ok := secretByPubkey[pubkey]
 		if !ok {
 			return eth2p0.BLSSignature{}, errors.New("secret not found")
 		}
 
-		sig, err := tbls.Sign(secret, msg)
+		sig, err := tbls.Sign(secret, data)
 		if err != nil {
 			return eth2p0.BLSSignature{}, err
 		}
@@ -11,6 +11,8 @@ import (
 	"testing"
 	"time"
 
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/require"
@@ -67,7 +69,7 @@ func TestAttest(t *testing.T) {
 			}
 
 			// Signature stub function
-			signFunc := func(key eth2p0.BLSPubKey, _ []byte) (eth2p0.BLSSignature, error) {
+			signFunc := func(key eth2p0.BLSPubKey, _ []byte, _ any) (eth2p0.BLSSignature, error) {
 				var sig eth2p0.BLSSignature
 				copy(sig[:], key[:])
 
@@ -121,7 +123,7 @@ func TestProposeBlock(t *testing.T) {
 	require.NoError(t, err)
 
 	// Signature stub function
-	signFunc := func(key eth2p0.BLSPubKey, _ []byte) (eth2p0.BLSSignature, error) {
+	signFunc := func(key eth2p0.BLSPubKey, _ []byte, _ any) (eth2p0.BLSSignature, error) {
 		var sig eth2p0.BLSSignature
 		copy(sig[:], key[:])
 
@@ -169,7 +171,7 @@ func TestProposeBlindedBlock(t *testing.T) {
 	require.NoError(t, err)
 
 	// Signature stub function
-	signFunc := func(key eth2p0.BLSPubKey, _ []byte) (eth2p0.BLSSignature, error) {
+	signFunc := func(key eth2p0.BLSPubKey, _ []byte, _ any) (eth2p0.BLSSignature, error) {
 		var sig eth2p0.BLSSignature
 		copy(sig[:], key[:])
 
@@ -207,6 +209,88 @@ func TestProposeBlindedBlock(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestProposeBuilderBlock(t *testing.T) {
+	ctx := context.Background()
+
+	valSet := beaconmock.ValidatorSetA
+	beaconMock, err := beaconmock.New(
+		beaconmock.WithValidatorSet(valSet),
+		beaconmock.WithDeterministicProposerDuties(0),
+	)
+	require.NoError(t, err)
+
+	var blinded *eth2api.VersionedSignedBlindedProposal
+	beaconMock.SubmitBlindedProposalFunc = func(_ context.Context, opts *eth2api.SubmitBlindedProposalOpts) error {
+		blinded = opts.Proposal
+		return nil
+	}
+
+	signFunc := func(key eth2p0.BLSPubKey, _ []byte, _ any) (eth2p0.BLSSignature, error) {
+		var sig eth2p0.BLSSignature
+		copy(sig[:], key[:])
+
+		return sig, nil
+	}
+
+	slotsPerEpoch, err := beaconMock.SlotsPerEpoch(ctx)
+	require.NoError(t, err)
+	slot := eth2p0.Slot(slotsPerEpoch)
+
+	// Beaconmock returns blinded blocks if builder boost factor is provided.
+	err = validatormock.ProposeBlindedBlock(ctx, beaconMock, signFunc, slot)
+	require.NoError(t, err)
+	require.NotNil(t, blinded)
+	require.Equal(t, slot, blinded.Capella.Message.Slot)
+
+	// Full blocks are rejected.
+	beaconMock.ProposalFunc = func(_ context.Context, opts *eth2api.ProposalOpts) (*eth2api.VersionedProposal, error) {
+		block := testutil.RandomCapellaVersionedProposal()
+		block.Capella.Slot = opts.Slot
+
+		return block, nil
+	}
+	err = validatormock.ProposeBlindedBlock(ctx, beaconMock, signFunc, slot)
+	require.ErrorContains(t, err, "proposal not blinded")
+}
+
+func TestRegisterAll(t *testing.T) {
+	ctx := context.Background()
+
+	beaconMock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	var submitted []*eth2api.VersionedSignedValidatorRegistration
+	beaconMock.SubmitValidatorRegistrationsFunc = func(_ context.Context, registrations []*eth2api.VersionedSignedValidatorRegistration) error {
+		submitted = append(submitted, registrations...)
+		return nil
+	}
+
+	signFunc := func(key eth2p0.BLSPubKey, _ []byte, _ any) (eth2p0.BLSSignature, error) {
+		var sig eth2p0.BLSSignature
+		copy(sig[:], key[:])
+
+		return sig, nil
+	}
+
+	regs := make(map[eth2p0.BLSPubKey]*eth2api.VersionedValidatorRegistration)
+	for range 3 {
+		reg := &eth2api.VersionedValidatorRegistration{
+			Version: eth2spec.BuilderVersionV1,
+			V1:      testutil.RandomValidatorRegistration(t),
+		}
+		regs[reg.V1.Pubkey] = reg
+	}
+
+	require.NoError(t, validatormock.RegisterAll(ctx, beaconMock, signFunc, regs))
+	require.Len(t, submitted, len(regs))
+
+	for _, reg := range submitted {
+		require.Equal(t, eth2spec.BuilderVersionV1, reg.Version)
+		require.Equal(t, regs[reg.V1.Message.Pubkey].V1, reg.V1.Message)
+		require.Equal(t, reg.V1.Message.Pubkey[:], reg.V1.Signature[:len(reg.V1.Message.Pubkey)])
+	}
+}
+
 type addrWrap struct {
 	eth2wrap.Client
 	addr string
@@ -0,0 +1,164 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatormock
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+)
+
+// WithReportFile configures the validator mock to write its duty report as JSON to the provided file
+// at the start of every epoch and on shutdown.
+func WithReportFile(file string) Option {
+	return func(c *Component) {
+		c.reportFile = file
+	}
+}
+
+// DutyReport is the validator mock duty report, allowing tests to assert quantitative success thresholds.
+type DutyReport struct {
+	// Duties are the duty stats by duty type.
+	Duties map[string]DutyStats `json:"duties"`
+}
+
+// DutyStats are the stats of a duty type.
+type DutyStats struct {
+	// Attempted is the number of duties attempted, excluding skipped duties.
+	Attempted int `json:"attempted"`
+	// Succeeded is the number of duties that succeeded.
+	Succeeded int `json:"succeeded"`
+	// Failed is the number of duties that failed.
+	Failed int `json:"failed"`
+	// Skipped is the number of duties skipped due to injected faults.
+	Skipped int `json:"skipped"`
+	// SuccessRate is the ratio of succeeded to attempted duties.
+	SuccessRate float64 `json:"success_rate"`
+	// LatencyP50 is the median latency in seconds of successful duties from their scheduled start time.
+	LatencyP50 float64 `json:"latency_p50_seconds"`
+	// LatencyP90 is the 90th percentile latency in seconds of successful duties.
+	LatencyP90 float64 `json:"latency_p90_seconds"`
+	// LatencyMax is the maximum latency in seconds of successful duties.
+	LatencyMax float64 `json:"latency_max_seconds"`
+}
+
+// newDutyRecorder returns a new duty recorder.
+func newDutyRecorder() *dutyRecorder {
+	return &dutyRecorder{
+		stats:     make(map[core.DutyType]*DutyStats),
+		latencies: make(map[core.DutyType][]time.Duration),
+	}
+}
+
+// dutyRecorder records duty results as metrics and for the duty report.
+type dutyRecorder struct {
+	mu        sync.Mutex
+	stats     map[core.DutyType]*DutyStats
+	latencies map[core.DutyType][]time.Duration
+}
+
+// getStats returns the mutable stats of the duty type. It must be called with the lock held.
+func (r *dutyRecorder) getStats(dutyType core.DutyType) *DutyStats {
+	stats, ok := r.stats[dutyType]
+	if !ok {
+		stats = new(DutyStats)
+		r.stats[dutyType] = stats
+	}
+
+	return stats
+}
+
+// Skipped records a duty skipped due to injected faults.
+func (r *dutyRecorder) Skipped(duty core.Duty) {
+	dutySkippedCounter.WithLabelValues(duty.Type.String()).Inc()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.getStats(duty.Type).Skipped++
+}
+
+// Completed records an attempted duty, it succeeded if err is nil.
+func (r *dutyRecorder) Completed(duty core.Duty, latency time.Duration, err error) {
+	dutyCounter.WithLabelValues(duty.Type.String()).Inc()
+	if err != nil {
+		dutyErrorCounter.WithLabelValues(duty.Type.String()).Inc()
+	} else {
+		dutyLatency.WithLabelValues(duty.Type.String()).Observe(latency.Seconds())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := r.getStats(duty.Type)
+	stats.Attempted++
+	if err != nil {
+		stats.Failed++
+		return
+	}
+
+	stats.Succeeded++
+	r.latencies[duty.Type] = append(r.latencies[duty.Type], latency)
+}
+
+// Report returns the duty report.
+func (r *dutyRecorder) Report() DutyReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	resp := DutyReport{Duties: make(map[string]DutyStats)}
+	for dutyType, stats := range r.stats {
+		report := *stats
+		if report.Attempted > 0 {
+			report.SuccessRate = float64(report.Succeeded) / float64(report.Attempted)
+		}
+
+		latencies := append([]time.Duration(nil), r.latencies[dutyType]...)
+		sort.Slice(latencies, func(i, j int) bool {
+			return latencies[i] < latencies[j]
+		})
+		if len(latencies) > 0 {
+			report.LatencyP50 = percentile(latencies, 0.5).Seconds()
+			report.LatencyP90 = percentile(latencies, 0.9).Seconds()
+			report.LatencyMax = latencies[len(latencies)-1].Seconds()
+		}
+
+		resp.Duties[dutyType.String()] = report
+	}
+
+	return resp
+}
+
+// percentile returns the nearest-rank percentile of the sorted non-empty latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	idx := int(p*float64(len(sorted))+0.5) - 1
+	idx = max(0, min(idx, len(sorted)-1))
+
+	return sorted[idx]
+}
+
+// writeReport writes the duty report as JSON to the file, replacing it atomically.
+func writeReport(file string, report DutyReport) error {
+	b, err := json.MarshalIndent(report, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal duty report")
+	}
+
+	tmpFile := file + ".tmp"
+	//nolint:gosec // Duty report isn't secret.
+	if err := os.WriteFile(tmpFile, b, 0o644); err != nil {
+		return errors.Wrap(err, "write duty report")
+	}
+
+	if err := os.Rename(tmpFile, filepath.Clean(file)); err != nil {
+		return errors.Wrap(err, "rename duty report")
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatormock
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestDutyReport(t *testing.T) {
+	recorder := newDutyRecorder()
+
+	for i := range 10 {
+		recorder.Completed(core.NewAttesterDuty(uint64(i)), time.Duration(i+1)*time.Second, nil)
+	}
+	recorder.Completed(core.NewAttesterDuty(10), time.Second, errors.New("failed"))
+	recorder.Skipped(core.NewAttesterDuty(11))
+	recorder.Completed(core.NewProposerDuty(1), time.Second, errors.New("failed"))
+
+	report := recorder.Report()
+	require.Equal(t, DutyReport{Duties: map[string]DutyStats{
+		"attester": {
+			Attempted:   11,
+			Succeeded:   10,
+			Failed:      1,
+			Skipped:     1,
+			SuccessRate: 10.0 / 11.0,
+			LatencyP50:  5,
+			LatencyP90:  9,
+			LatencyMax:  10,
+		},
+		"proposer": {
+			Attempted: 1,
+			Failed:    1,
+		},
+	}}, report)
+
+	file := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, writeReport(file, report))
+
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	var actual DutyReport
+	require.NoError(t, json.Unmarshal(b, &actual))
+	require.Equal(t, report, actual)
+}
+
+func TestPercentile(t *testing.T) {
+	require.Equal(t, time.Duration(1), percentile([]time.Duration{1}, 0.5))
+	require.Equal(t, time.Duration(1), percentile([]time.Duration{1, 2}, 0.5))
+	require.Equal(t, time.Duration(2), percentile([]time.Duration{1, 2}, 0.9))
+	require.Equal(t, time.Duration(3), percentile([]time.Duration{1, 2, 3}, 1))
+}
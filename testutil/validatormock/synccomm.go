@@ -211,11 +211,16 @@ func (s *SyncCommMember) PrepareEpoch(ctx context.Context) error {
 }
 
 // PrepareSlot prepares selection proofs at the start of a slot.
+// On failure, no selections are set so Aggregate doesn't block.
 func (s *SyncCommMember) PrepareSlot(ctx context.Context, slot eth2p0.Slot) error {
 	wait(ctx, s.dutiesOK)
 
 	selections, err := prepareSyncSelections(ctx, s.eth2Cl, s.signFunc, s.getDuties(), slot)
 	if err != nil {
+		if setErr := s.setSelections(slot, nil); setErr != nil {
+			log.Warn(ctx, "Failed setting empty sync committee selections", setErr)
+		}
+
 		return err
 	}
 
@@ -223,6 +228,7 @@ func (s *SyncCommMember) PrepareSlot(ctx context.Context, slot eth2p0.Slot) erro
 }
 
 // Message submits sync committee messages at 1/3rd into the slot. It also sets the beacon block root for the slot.
+// On failure, the zero block root is set so Aggregate doesn't block.
 func (s *SyncCommMember) Message(ctx context.Context, slot eth2p0.Slot) error {
 	wait(ctx, s.dutiesOK)
 
@@ -231,19 +237,33 @@ func (s *SyncCommMember) Message(ctx context.Context, slot eth2p0.Slot) error {
 		return s.setBlockRoot(slot, eth2p0.Root{})
 	}
 
+	blockRoot, err := s.message(ctx, slot, duties)
+	if err != nil {
+		if setErr := s.setBlockRoot(slot, eth2p0.Root{}); setErr != nil {
+			log.Warn(ctx, "Failed setting zero sync committee block root", setErr)
+		}
+
+		return err
+	}
+
+	return s.setBlockRoot(slot, blockRoot)
+}
+
+// message submits sync committee messages for the head block root and returns it.
+func (s *SyncCommMember) message(ctx context.Context, slot eth2p0.Slot, duties syncDuties) (eth2p0.Root, error) {
 	opts := &eth2api.BeaconBlockRootOpts{Block: "head"}
 	eth2Resp, err := s.eth2Cl.BeaconBlockRoot(ctx, opts)
 	if err != nil {
-		return err
+		return eth2p0.Root{}, err
 	}
-	blockRoot := eth2Resp.Data
+	blockRoot := *eth2Resp.Data
 
-	err = submitSyncMessages(ctx, s.eth2Cl, slot, *blockRoot, s.signFunc, duties)
+	err = submitSyncMessages(ctx, s.eth2Cl, slot, blockRoot, s.signFunc, duties)
 	if err != nil {
-		return err
+		return eth2p0.Root{}, err
 	}
 
-	return s.setBlockRoot(slot, *blockRoot)
+	return blockRoot, nil
 }
 
 // Aggregate submits SignedContributionAndProof at 2/3rd into the slot. It does sync committee aggregations.
@@ -251,7 +271,13 @@ func (s *SyncCommMember) Message(ctx context.Context, slot eth2p0.Slot) error {
 func (s *SyncCommMember) Aggregate(ctx context.Context, slot eth2p0.Slot) (bool, error) {
 	wait(ctx, s.dutiesOK, s.getSelectionsOK(slot), s.getBlockRootOK(slot))
 
-	return aggContributions(ctx, s.eth2Cl, s.signFunc, slot, s.getVals(), s.getSelections(slot), s.getBlockRoot(slot))
+	selections := s.getSelections(slot)
+	blockRoot := s.getBlockRoot(slot)
+	if len(selections) > 0 && blockRoot == (eth2p0.Root{}) {
+		return false, errors.New("sync committee messages failed, so can't aggregate", z.U64("slot", uint64(slot)))
+	}
+
+	return aggContributions(ctx, s.eth2Cl, s.signFunc, slot, s.getVals(), selections, blockRoot)
 }
 
 // prepareSyncCommDuties returns sync committee duties for the epoch.
@@ -333,7 +359,7 @@ func prepareSyncSelections(ctx context.Context, eth2Cl eth2wrap.Client, signFunc
 				return nil, err
 			}
 
-			sig, err := signFunc(duty.PubKey, sigData[:])
+			sig, err := signFunc(duty.PubKey, sigData[:], &data)
 			if err != nil {
 				return nil, err
 			}
@@ -370,7 +396,7 @@ func prepareSyncSelections(ctx context.Context, eth2Cl eth2wrap.Client, signFunc
 	return selections, nil
 }
 
-// getSubcommittees returns the subcommittee indexes for the provided sync committee duty.
+// getSubcommittees returns the unique subcommittee indexes for the provided sync committee duty.
 func getSubcommittees(ctx context.Context, eth2Cl eth2client.SpecProvider, duty *eth2v1.SyncCommitteeDuty) ([]eth2p0.CommitteeIndex, error) {
 	eth2Resp, err := eth2Cl.Spec(ctx, &eth2api.SpecOpts{})
 	if err != nil {
@@ -389,9 +415,16 @@ func getSubcommittees(ctx context.Context, eth2Cl eth2client.SpecProvider, duty
 	}
 
 	var subcommittees []eth2p0.CommitteeIndex
+	dedup := make(map[eth2p0.CommitteeIndex]bool)
 	for _, idx := range duty.ValidatorSyncCommitteeIndices {
-		subcommIdx := uint64(idx) / (commSize / subnetCount)
-		subcommittees = append(subcommittees, eth2p0.CommitteeIndex(subcommIdx))
+		// A validator may have multiple indices in the same subcommittee, but only aggregates it once.
+		subcommIdx := eth2p0.CommitteeIndex(uint64(idx) / (commSize / subnetCount))
+		if dedup[subcommIdx] {
+			continue
+		}
+		dedup[subcommIdx] = true
+
+		subcommittees = append(subcommittees, subcommIdx)
 	}
 
 	return subcommittees, nil
@@ -415,7 +448,7 @@ func submitSyncMessages(ctx context.Context, eth2Cl eth2wrap.Client, slot eth2p0
 
 	var msgs []*altair.SyncCommitteeMessage
 	for _, duty := range duties {
-		sig, err := signFunc(duty.PubKey, sigData[:])
+		sig, err := signFunc(duty.PubKey, sigData[:], &altair.SyncCommitteeMessage{Slot: slot, BeaconBlockRoot: blockRoot})
 		if err != nil {
 			return err
 		}
@@ -464,6 +497,12 @@ func aggContributions(ctx context.Context, eth2Cl eth2wrap.Client, signFunc Sign
 			return false, err
 		}
 		contrib := eth2Resp.Data
+		if contrib.AggregationBits.Count() == 0 {
+			log.Debug(ctx, "Skipping empty sync committee contribution", z.U64("slot", uint64(slot)),
+				z.U64("subcommittee", uint64(selection.SubcommitteeIndex)))
+
+			continue
+		}
 
 		vIdx := selection.ValidatorIndex
 		contribAndProof := &altair.ContributionAndProof{
@@ -487,7 +526,7 @@ func aggContributions(ctx context.Context, eth2Cl eth2wrap.Client, signFunc Sign
 			return false, err
 		}
 
-		sig, err := signFunc(pubkey, sigData[:])
+		sig, err := signFunc(pubkey, sigData[:], contribAndProof)
 		if err != nil {
 			return false, err
 		}
@@ -500,10 +539,17 @@ func aggContributions(ctx context.Context, eth2Cl eth2wrap.Client, signFunc Sign
 		signedContribAndProofs = append(signedContribAndProofs, signedContribAndProof)
 	}
 
+	if len(signedContribAndProofs) == 0 {
+		return false, nil
+	}
+
 	if err := eth2Cl.SubmitSyncCommitteeContributions(ctx, signedContribAndProofs); err != nil {
 		return false, err
 	}
 
+	log.Info(ctx, "Mock sync committee contributions submitted", z.Int("slot", int(slot)),
+		z.Int("contributions", len(signedContribAndProofs)))
+
 	return true, nil
 }
 
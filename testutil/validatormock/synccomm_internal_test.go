@@ -7,9 +7,12 @@ import (
 	"testing"
 
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/eth2util/eth2exp"
 	"github.com/obolnetwork/charon/testutil"
 	"github.com/obolnetwork/charon/testutil/beaconmock"
 )
@@ -33,4 +36,140 @@ func TestGetSubcommittees(t *testing.T) {
 	subcommittees, err := getSubcommittees(ctx, bmock, duty)
 	require.NoError(t, err)
 	require.Equal(t, expected, subcommittees)
+
+	// Multiple indices in the same subcommittee result in a single subcommittee.
+	duty.ValidatorSyncCommitteeIndices = []eth2p0.CommitteeIndex{75, 76, 491, 133, 489}
+
+	subcommittees, err = getSubcommittees(ctx, bmock, duty)
+	require.NoError(t, err)
+	require.Equal(t, []eth2p0.CommitteeIndex{0, 3, 1}, subcommittees)
+}
+
+func TestSyncCommMember(t *testing.T) {
+	ctx := context.Background()
+
+	valSet := beaconmock.ValidatorSetA
+	bmock, err := beaconmock.New(
+		beaconmock.WithValidatorSet(valSet),
+		beaconmock.WithDeterministicSyncCommDuties(2, 2),
+		// A subcommittee size smaller than TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE makes all members aggregators.
+		beaconmock.WithSyncCommitteeSize(4),
+		beaconmock.WithSyncCommitteeSubnetCount(4),
+	)
+	require.NoError(t, err)
+
+	var (
+		subs     []*eth2v1.SyncCommitteeSubscription
+		msgs     []*altair.SyncCommitteeMessage
+		contribs []*altair.SignedContributionAndProof
+	)
+	bmock.SubmitSyncCommitteeSubscriptionsFunc = func(_ context.Context, subscriptions []*eth2v1.SyncCommitteeSubscription) error {
+		subs = append(subs, subscriptions...)
+		return nil
+	}
+	bmock.SubmitSyncCommitteeMessagesFunc = func(_ context.Context, messages []*altair.SyncCommitteeMessage) error {
+		msgs = append(msgs, messages...)
+		return nil
+	}
+	bmock.SubmitSyncCommitteeContributionsFunc = func(_ context.Context, contributionAndProofs []*altair.SignedContributionAndProof) error {
+		contribs = append(contribs, contributionAndProofs...)
+		return nil
+	}
+
+	signFunc := func(key eth2p0.BLSPubKey, _ []byte, _ any) (eth2p0.BLSSignature, error) {
+		var sig eth2p0.BLSSignature
+		copy(sig[:], key[:])
+
+		return sig, nil
+	}
+
+	const slot = eth2p0.Slot(1)
+
+	member := NewSyncCommMember(bmock, 0, signFunc, valSet.PublicKeys())
+	require.NoError(t, member.PrepareEpoch(ctx))
+	require.NoError(t, member.PrepareSlot(ctx, slot))
+	require.NoError(t, member.Message(ctx, slot))
+	ok, err := member.Aggregate(ctx, slot)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.Len(t, subs, len(valSet))
+	require.Len(t, msgs, len(valSet))
+	require.Len(t, contribs, len(valSet))
+
+	for _, msg := range msgs {
+		require.Equal(t, slot, msg.Slot)
+		require.Equal(t, msgs[0].BeaconBlockRoot, msg.BeaconBlockRoot)
+	}
+
+	subcommittees := make(map[uint64]bool)
+	for _, contrib := range contribs {
+		require.Equal(t, slot, contrib.Message.Contribution.Slot)
+		require.Equal(t, msgs[0].BeaconBlockRoot, contrib.Message.Contribution.BeaconBlockRoot)
+		subcommittees[contrib.Message.Contribution.SubcommitteeIndex] = true
+	}
+	require.Len(t, subcommittees, len(valSet))
+}
+
+func TestSyncCommMemberFailures(t *testing.T) {
+	ctx := context.Background()
+
+	valSet := beaconmock.ValidatorSetA
+	newMember := func(t *testing.T, override func(*beaconmock.Mock)) *SyncCommMember {
+		t.Helper()
+
+		bmock, err := beaconmock.New(
+			beaconmock.WithValidatorSet(valSet),
+			beaconmock.WithDeterministicSyncCommDuties(2, 2),
+			beaconmock.WithSyncCommitteeSize(4),
+			beaconmock.WithSyncCommitteeSubnetCount(4),
+		)
+		require.NoError(t, err)
+		override(&bmock)
+
+		signFunc := func(key eth2p0.BLSPubKey, _ []byte, _ any) (eth2p0.BLSSignature, error) {
+			var sig eth2p0.BLSSignature
+			copy(sig[:], key[:])
+
+			return sig, nil
+		}
+
+		member := NewSyncCommMember(bmock, 0, signFunc, valSet.PublicKeys())
+		require.NoError(t, member.PrepareEpoch(ctx))
+
+		return member
+	}
+
+	const slot = eth2p0.Slot(1)
+
+	t.Run("selections failed", func(t *testing.T) {
+		member := newMember(t, func(bmock *beaconmock.Mock) {
+			bmock.AggregateSyncCommitteeSelectionsFunc = func(context.Context, []*eth2exp.SyncCommitteeSelection) ([]*eth2exp.SyncCommitteeSelection, error) {
+				return nil, errors.New("test error")
+			}
+		})
+
+		require.ErrorContains(t, member.PrepareSlot(ctx, slot), "test error")
+		require.NoError(t, member.Message(ctx, slot))
+
+		// Aggregate doesn't block and doesn't aggregate.
+		ok, err := member.Aggregate(ctx, slot)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("messages failed", func(t *testing.T) {
+		member := newMember(t, func(bmock *beaconmock.Mock) {
+			bmock.SubmitSyncCommitteeMessagesFunc = func(context.Context, []*altair.SyncCommitteeMessage) error {
+				return errors.New("test error")
+			}
+		})
+
+		require.NoError(t, member.PrepareSlot(ctx, slot))
+		require.ErrorContains(t, member.Message(ctx, slot), "test error")
+
+		// Aggregate doesn't block and fails.
+		_, err := member.Aggregate(ctx, slot)
+		require.ErrorContains(t, err, "sync committee messages failed")
+	})
 }